@@ -20,6 +20,7 @@ func newAdoptCommand() *cobra.Command {
 	var scanDirs []string
 	var excludeDirs []string
 	var maxSize string
+	var opts dot.AdoptOptions
 
 	cmd := &cobra.Command{
 		Use:   "adopt [PACKAGE] FILE [FILE...]",
@@ -44,6 +45,11 @@ Path Resolution:
   file or .config/x  → Resolved from target directory ($HOME)
   /abs or ~/file     → Used as absolute path
 
+Nested Directories:
+  A directory's contents go to the package root by default. With
+  --preserve-path the directory keeps its path from the target directory:
+  dot adopt --preserve-path nvim .config/nvim   # Stored as dot-config/nvim
+
 Interactive Mode Options:
   --scan-dirs       Additional directories to scan
   --exclude-dirs    Directories to exclude from discovery
@@ -53,7 +59,7 @@ For shell glob expansion, specify package name:
   dot adopt git .git*         # Package "git" with all .git* files`,
 		Args: cobra.ArbitraryArgs, // Accept 0 or more arguments
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runAdoptCommand(cmd, args, scanDirs, excludeDirs, maxSize)
+			return runAdoptCommand(cmd, args, opts, scanDirs, excludeDirs, maxSize)
 		},
 		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 			// For auto-naming mode, complete with files
@@ -67,6 +73,9 @@ For shell glob expansion, specify package name:
 		},
 	}
 
+	cmd.Flags().BoolVar(&opts.PreservePath, "preserve-path", false,
+		"store adopted directories at their path relative to the target directory")
+
 	// Interactive mode options (only used when no arguments provided)
 	cmd.Flags().StringSliceVar(&scanDirs, "scan-dirs", nil,
 		"additional directories to scan (interactive mode)")
@@ -79,18 +88,18 @@ For shell glob expansion, specify package name:
 }
 
 // runAdoptCommand routes to interactive or traditional mode based on arguments.
-func runAdoptCommand(cmd *cobra.Command, args []string, scanDirs, excludeDirs []string, maxSizeStr string) error {
+func runAdoptCommand(cmd *cobra.Command, args []string, opts dot.AdoptOptions, scanDirs, excludeDirs []string, maxSizeStr string) error {
 	// No arguments → Interactive mode
 	if len(args) == 0 {
-		return runAdoptInteractive(cmd, scanDirs, excludeDirs, maxSizeStr)
+		return runAdoptInteractive(cmd, opts, scanDirs, excludeDirs, maxSizeStr)
 	}
 
 	// Has arguments → Traditional mode
-	return runAdoptTraditional(cmd, args)
+	return runAdoptTraditional(cmd, args, opts)
 }
 
 // runAdoptInteractive handles interactive discovery and adoption.
-func runAdoptInteractive(cmd *cobra.Command, scanDirs, excludeDirs []string, maxSizeStr string) error {
+func runAdoptInteractive(cmd *cobra.Command, adoptOpts dot.AdoptOptions, scanDirs, excludeDirs []string, maxSizeStr string) error {
 	// Build config
 	cfg, err := buildConfigWithCmd(cmd)
 	if err != nil {
@@ -169,7 +178,7 @@ func runAdoptInteractive(cmd *cobra.Command, scanDirs, excludeDirs []string, max
 		colorize,
 		cfg.FS,
 		configDir,
-	)
+	).WithTheme(outputTheme())
 
 	groups, err := adopter.Run(ctx, candidates)
 	if err != nil {
@@ -181,15 +190,15 @@ func runAdoptInteractive(cmd *cobra.Command, scanDirs, excludeDirs []string, max
 	}
 
 	// Execute adoptions
-	colorizer := render.NewColorizer(colorize)
-	formatter := output.NewFormatter(cmd.OutOrStdout(), colorize)
+	colorizer := render.NewColorizer(colorize, outputTheme())
+	formatter := output.NewFormatter(cmd.OutOrStdout(), colorize, outputTheme())
 
 	totalFiles := 0
 	for _, group := range groups {
 		// Check for potential secrets before adopting
 		displaySecretsWarning(cmd.ErrOrStderr(), group.Files)
 
-		if err := client.Adopt(ctx, group.Files, group.PackageName); err != nil {
+		if err := client.AdoptWithOptions(ctx, adoptOpts, group.Files, group.PackageName); err != nil {
 			return formatError(fmt.Errorf("adopt %s: %w", group.PackageName, err))
 		}
 
@@ -209,7 +218,7 @@ func runAdoptInteractive(cmd *cobra.Command, scanDirs, excludeDirs []string, max
 }
 
 // runAdoptTraditional handles the traditional file-based adoption.
-func runAdoptTraditional(cmd *cobra.Command, args []string) error {
+func runAdoptTraditional(cmd *cobra.Command, args []string, opts dot.AdoptOptions) error {
 	cfg, err := buildConfigWithCmd(cmd)
 	if err != nil {
 		return formatError(err)
@@ -247,7 +256,7 @@ func runAdoptTraditional(cmd *cobra.Command, args []string) error {
 	// Check for potential secrets before adopting
 	displaySecretsWarning(cmd.ErrOrStderr(), files)
 
-	if err := client.Adopt(ctx, files, pkg); err != nil {
+	if err := client.AdoptWithOptions(ctx, opts, files, pkg); err != nil {
 		return formatError(err)
 	}
 
@@ -256,8 +265,8 @@ func runAdoptTraditional(cmd *cobra.Command, args []string) error {
 		colorize := shouldUseColor()
 
 		// Create formatter for consistent output
-		formatter := output.NewFormatter(cmd.OutOrStdout(), colorize)
-		colorizer := render.NewColorizer(colorize)
+		formatter := output.NewFormatter(cmd.OutOrStdout(), colorize, outputTheme())
+		colorizer := render.NewColorizer(colorize, outputTheme())
 
 		// Print success message
 		if len(files) == 1 {
@@ -4,10 +4,12 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"runtime"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/yaklabco/dot/pkg/dot"
 )
 
 // setupTestFlags sets up cliFlags and cliContext for a test and returns cleanup function.
@@ -247,3 +249,94 @@ func TestBuildConfig_TranslateFromConfig(t *testing.T) {
 		assert.True(t, *cfg.Translate, "should default to true")
 	})
 }
+
+func TestBuildConfig_Concurrency(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		flag   int
+		want   int
+	}{
+		{name: "default", want: runtime.NumCPU()},
+		{name: "from config", config: "operations:\n  max_parallel: 3\n", want: 3},
+		{name: "flag overrides config", config: "operations:\n  max_parallel: 3\n", flag: 2, want: 2},
+		{name: "flag without config", flag: 1, want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpConfig := filepath.Join(t.TempDir(), "config.yaml")
+			if tt.config != "" {
+				require.NoError(t, os.WriteFile(tmpConfig, []byte(tt.config), 0644))
+			}
+			t.Setenv("DOT_CONFIG", tmpConfig)
+			setupTestFlags(t, CLIFlags{
+				packageDir:  ".",
+				targetDir:   t.TempDir(),
+				concurrency: tt.flag,
+			})
+
+			cfg, err := buildConfig()
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, cfg.Concurrency)
+		})
+	}
+}
+
+func TestBuildConfig_ProfilePreset(t *testing.T) {
+	const config = `symlinks:
+  mode: relative
+cli_profiles:
+  careful:
+    dry_run: true
+    folding: true
+    link_mode: absolute
+`
+	tests := []struct {
+		name      string
+		preset    string
+		dryRunSet bool
+		wantDry   bool
+		wantMode  dot.LinkMode
+		wantFold  bool
+	}{
+		{name: "no preset", wantMode: dot.LinkRelative, wantFold: false},
+		{name: "preset", preset: "careful", wantDry: true, wantMode: dot.LinkRelative, wantFold: true},
+		{name: "flag overrides preset", preset: "careful", dryRunSet: true, wantMode: dot.LinkRelative, wantFold: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpConfig := filepath.Join(t.TempDir(), "config.yaml")
+			require.NoError(t, os.WriteFile(tmpConfig, []byte(config), 0644))
+			t.Setenv("DOT_CONFIG", tmpConfig)
+			setupTestFlags(t, CLIFlags{
+				packageDir:    ".",
+				targetDir:     t.TempDir(),
+				profilePreset: tt.preset,
+				dryRunSet:     tt.dryRunSet,
+			})
+
+			cfg, err := buildConfig()
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantDry, cfg.DryRun)
+			assert.Equal(t, tt.wantMode, cfg.LinkMode, "symlinks.mode in the file wins over the preset")
+			assert.Equal(t, tt.wantFold, cfg.Folding)
+		})
+	}
+}
+
+func TestBuildConfig_UnknownProfilePreset(t *testing.T) {
+	tmpConfig := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(tmpConfig, []byte("cli_profiles:\n  careful:\n    dry_run: true\n"), 0644))
+	t.Setenv("DOT_CONFIG", tmpConfig)
+	setupTestFlags(t, CLIFlags{
+		packageDir:    ".",
+		targetDir:     t.TempDir(),
+		profilePreset: "carefull",
+	})
+
+	_, err := buildConfig()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown profile preset "carefull" (available: careful)`)
+}
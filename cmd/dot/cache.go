@@ -0,0 +1,57 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/yaklabco/dot/internal/cli/output"
+	"github.com/yaklabco/dot/pkg/dot"
+)
+
+// newCacheCommand creates the cache command.
+func newCacheCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Manage the package scan and status cache",
+		Long: `Manage the cache of scanned package trees and package status.
+
+Commands that scan packages cache each package's file tree under the XDG
+cache directory and reuse it while none of the package's directories has
+changed. Status and list cache each package's link health the same way,
+reusing it while the package directory and its links are unchanged. Use
+--no-cache to bypass the cache for a single command.`,
+	}
+
+	cmd.AddCommand(newCacheClearCommand())
+
+	return cmd
+}
+
+// newCacheClearCommand creates the cache clear command.
+func newCacheClearCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "clear",
+		Short: "Remove all cached package trees and status",
+		Long: `Remove every cached package tree and package status. The next
+command that scans a package walks its directory again, and the next status
+checks every link again, caching the results.`,
+		Example: `  dot cache clear`,
+		Args:    argsWithUsage(cobra.NoArgs),
+		RunE:    runCacheClear,
+	}
+}
+
+// runCacheClear handles the cache clear command.
+func runCacheClear(cmd *cobra.Command, args []string) error {
+	dir := dot.DefaultCacheDir()
+	formatter := output.NewFormatter(cmd.OutOrStdout(), shouldUseColor(), outputTheme())
+	if GetCLIFlags().dryRun {
+		formatter.Info("Would clear cache at " + dir)
+		return nil
+	}
+
+	if err := dot.ClearCache(cmd.Context(), dot.NewOSFilesystem(), dir); err != nil {
+		return formatError(err)
+	}
+	formatter.SuccessSimple("Cleared cache at " + dir)
+	return nil
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheCommand(t *testing.T) {
+	setupGlobalCfg(t)
+	cacheHome := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", cacheHome)
+	scanDir := filepath.Join(cacheHome, "dot", "scan")
+
+	packageDir, targetDir := t.TempDir(), t.TempDir()
+	pkgDir := filepath.Join(packageDir, "vim")
+	require.NoError(t, os.MkdirAll(pkgDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(pkgDir, "dot-vimrc"), []byte("set nu"), 0o644))
+	// Age the package so its tree is old enough to be cached
+	old := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(pkgDir, old, old))
+
+	run := func(args ...string) (string, error) {
+		rootCmd := NewRootCommand("dev", "none", "unknown")
+		rootCmd.SetArgs(append(args, "--dir", packageDir, "--target", targetDir))
+		out := &bytes.Buffer{}
+		rootCmd.SetOut(out)
+		rootCmd.SetErr(out)
+		err := rootCmd.Execute()
+		return out.String(), err
+	}
+	cached := func() []os.DirEntry {
+		entries, _ := os.ReadDir(scanDir)
+		return entries
+	}
+
+	_, err := run("--no-cache", "manage", "vim")
+	require.NoError(t, err)
+	assert.Empty(t, cached(), "--no-cache should not populate the cache")
+
+	_, err = run("manage", "vim")
+	require.NoError(t, err)
+	assert.Len(t, cached(), 1)
+
+	out, err := run("--dry-run", "cache", "clear")
+	require.NoError(t, err)
+	assert.Contains(t, out, "Would clear cache at "+filepath.Join(cacheHome, "dot"))
+	assert.Len(t, cached(), 1)
+
+	out, err = run("cache", "clear")
+	require.NoError(t, err)
+	assert.Contains(t, out, "Cleared cache")
+	assert.NoDirExists(t, filepath.Join(cacheHome, "dot"))
+
+	_, err = run("cache", "clear")
+	require.NoError(t, err, "clearing an empty cache succeeds")
+}
@@ -20,6 +20,11 @@ func newCloneCommand() *cobra.Command {
 		cloneInteractive bool
 		cloneForce       bool
 		cloneBranch      string
+		cloneDepth       int
+		cloneSingle      bool
+		cloneSSHKey      string
+		cloneKnownHosts  string
+		cloneManifest    bool
 	)
 
 	cmd := &cobra.Command{
@@ -44,11 +49,31 @@ REPOSITORY CONFIGURATION:
 
 AUTHENTICATION:
   Automatic resolution order:
-  1. GITHUB_TOKEN environment variable
-  2. GIT_TOKEN environment variable
-  3. SSH keys (~/.ssh/)
-  4. GitHub CLI (gh) authenticated session
-  5. No authentication (public repos)
+  1. GITLAB_TOKEN or BITBUCKET_TOKEN, for repositories on that host
+  2. DOT_GIT_TOKEN environment variable
+  3. GITHUB_TOKEN environment variable
+  4. GIT_TOKEN environment variable
+  5. SSH agent, for SSH URLs when SSH_AUTH_SOCK is set
+  6. SSH keys (~/.ssh/)
+  7. GitHub CLI (gh) authenticated session
+  8. No authentication (public repos)
+
+  --ssh-key selects a private key for SSH URLs, overriding the order above.
+  You are prompted for its passphrase if the key is encrypted. Host keys are
+  verified against ~/.ssh/known_hosts, or the file given with --known-hosts.
+
+CLONE DEPTH:
+  By default only the latest commit is fetched (--depth 1). Use --depth 0
+  for full history, or a larger depth for more commits. --single-branch
+  fetches only the branch named by --branch, or the repository's default
+  branch when --branch is omitted; without it, every branch is fetched
+  to the given depth.
+
+EXISTING CLONES:
+  --manifest-only tracks a repository you cloned yourself. The package
+  directory must already be a git repository; nothing is cloned or
+  installed. The URL, the branch (--branch, or the one checked out) and
+  the current commit are recorded in the manifest.
 
 BOOTSTRAP CONFIGURATION:
   Optional .dotbootstrap.yaml defines installation profiles,
@@ -64,6 +89,12 @@ Examples:
   # Clone specific branch
   dot clone https://github.com/user/dotfiles --branch develop
 
+  # Clone full history instead of a shallow clone
+  dot clone https://github.com/user/dotfiles --depth 0
+
+  # Fetch only the develop branch, with its last 20 commits
+  dot clone https://github.com/user/dotfiles --branch develop --single-branch --depth 20
+
   # Use named profile from bootstrap config
   dot clone https://github.com/user/dotfiles --profile minimal
 
@@ -77,10 +108,30 @@ Examples:
   dot clone --force https://github.com/user/dotfiles
 
   # Clone via SSH
-  dot clone git@github.com:user/dotfiles.git`,
+  dot clone git@github.com:user/dotfiles.git
+
+  # Clone a private repository with a specific SSH key
+  dot clone git@github.com:user/dotfiles.git --ssh-key ~/.ssh/id_work
+
+  # Track a repository already cloned into ~/dotfiles
+  dot clone --manifest-only --dir ~/dotfiles https://github.com/user/dotfiles`,
 		Args: argsWithUsage(cobra.ExactArgs(1)),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runClone(cmd, args, cloneProfile, cloneInteractive, cloneForce, cloneBranch)
+			if cloneManifest && (cloneProfile != "" || cloneInteractive || cloneForce) {
+				return fmt.Errorf("--manifest-only cannot be combined with --profile, --interactive or --force")
+			}
+			opts := dot.CloneOptions{
+				Profile:        cloneProfile,
+				Interactive:    cloneInteractive,
+				Force:          cloneForce,
+				Branch:         cloneBranch,
+				Depth:          cloneDepth,
+				SingleBranch:   cloneSingle,
+				SSHKeyPath:     cloneSSHKey,
+				KnownHostsPath: cloneKnownHosts,
+				ManifestOnly:   cloneManifest,
+			}
+			return runClone(cmd, args, opts)
 		},
 		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 			return nil, cobra.ShellCompDirectiveNoFileComp
@@ -91,6 +142,11 @@ Examples:
 	cmd.Flags().BoolVar(&cloneInteractive, "interactive", false, "interactively select packages")
 	cmd.Flags().BoolVar(&cloneForce, "force", false, "overwrite package directory if exists")
 	cmd.Flags().StringVar(&cloneBranch, "branch", "", "branch to clone (defaults to repository default)")
+	cmd.Flags().IntVar(&cloneDepth, "depth", dot.DefaultCloneDepth, "number of commits to fetch (0 for full history)")
+	cmd.Flags().BoolVar(&cloneSingle, "single-branch", false, "fetch only the cloned branch")
+	cmd.Flags().StringVar(&cloneSSHKey, "ssh-key", "", "SSH private key for SSH repository URLs")
+	cmd.Flags().StringVar(&cloneKnownHosts, "known-hosts", "", "known_hosts file for verifying the SSH host key")
+	cmd.Flags().BoolVar(&cloneManifest, "manifest-only", false, "record an existing clone in the manifest without cloning or installing")
 
 	// Add bootstrap subcommand
 	cmd.AddCommand(newCloneBootstrapCommand())
@@ -99,9 +155,13 @@ Examples:
 }
 
 // runClone handles the clone command execution.
-func runClone(cmd *cobra.Command, args []string, profile string, interactive bool, force bool, branch string) error {
+func runClone(cmd *cobra.Command, args []string, opts dot.CloneOptions) error {
 	repoURL := args[0]
 
+	if opts.Depth < 0 {
+		return fmt.Errorf("invalid --depth %d: must be 0 (full history) or greater", opts.Depth)
+	}
+
 	// Check if --dir flag was explicitly provided
 	dirFlag := cmd.Flags().Lookup("dir")
 	dirExplicitlySet := dirFlag != nil && dirFlag.Changed
@@ -136,14 +196,6 @@ func runClone(cmd *cobra.Command, args []string, profile string, interactive boo
 		ctx = context.Background()
 	}
 
-	// Build clone options
-	opts := dot.CloneOptions{
-		Profile:     profile,
-		Interactive: interactive,
-		Force:       force,
-		Branch:      branch,
-	}
-
 	// Execute clone
 	if err := client.Clone(ctx, repoURL, opts); err != nil {
 		return formatCloneError(err)
@@ -151,8 +203,12 @@ func runClone(cmd *cobra.Command, args []string, profile string, interactive boo
 
 	// Print success message
 	colorize := shouldUseColor()
-	formatter := output.NewFormatter(cmd.OutOrStdout(), colorize)
-	formatter.SuccessSimple(fmt.Sprintf("Cloned repository to %s", cfg.PackageDir))
+	formatter := output.NewFormatter(cmd.OutOrStdout(), colorize, outputTheme())
+	if opts.ManifestOnly {
+		formatter.SuccessSimple(fmt.Sprintf("Recorded repository for %s", cfg.PackageDir))
+	} else {
+		formatter.SuccessSimple(fmt.Sprintf("Cloned repository to %s", cfg.PackageDir))
+	}
 
 	return nil
 }
@@ -171,12 +227,17 @@ func formatCloneError(err error) error {
 
 	var invalidBootstrap dot.ErrInvalidBootstrap
 	if errors.As(err, &invalidBootstrap) {
-		return fmt.Errorf("%w\n\nCheck the .dotbootstrap.yaml syntax and validation rules", invalidBootstrap)
+		return fmt.Errorf("%w\n\nCheck the .dotbootstrap.yaml (or .toml or .json) syntax and validation rules", invalidBootstrap)
 	}
 
 	var authFailed dot.ErrAuthFailed
 	if errors.As(err, &authFailed) {
-		return fmt.Errorf("%w\n\nTry:\n  - Setting GITHUB_TOKEN environment variable\n  - Setting GIT_TOKEN environment variable\n  - Configuring SSH keys in ~/.ssh/", authFailed)
+		return fmt.Errorf("%w\n\nTry:\n  - Setting GITHUB_TOKEN, GITLAB_TOKEN or BITBUCKET_TOKEN for the repository host\n  - Setting DOT_GIT_TOKEN environment variable\n  - Configuring SSH keys in ~/.ssh/", authFailed)
+	}
+
+	var notGitRepo dot.ErrNotGitRepository
+	if errors.As(err, &notGitRepo) {
+		return fmt.Errorf("%w\n\n--manifest-only needs an existing clone; use --dir to point at it", notGitRepo)
 	}
 
 	var cloneFailed dot.ErrCloneFailed
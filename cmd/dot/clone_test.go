@@ -34,6 +34,51 @@ func TestCloneCommand_Flags(t *testing.T) {
 		assert.NotNil(t, flag)
 		assert.Equal(t, "string", flag.Value.Type())
 	})
+
+	t.Run("has depth flag defaulting to shallow", func(t *testing.T) {
+		flag := cmd.Flags().Lookup("depth")
+		assert.NotNil(t, flag)
+		assert.Equal(t, "int", flag.Value.Type())
+		assert.Equal(t, "1", flag.DefValue)
+	})
+
+	t.Run("has single-branch flag", func(t *testing.T) {
+		flag := cmd.Flags().Lookup("single-branch")
+		assert.NotNil(t, flag)
+		assert.Equal(t, "bool", flag.Value.Type())
+	})
+
+	t.Run("has manifest-only flag", func(t *testing.T) {
+		flag := cmd.Flags().Lookup("manifest-only")
+		assert.NotNil(t, flag)
+		assert.Equal(t, "bool", flag.Value.Type())
+	})
+}
+
+func TestCloneCommand_ManifestOnlyRejectsInstallFlags(t *testing.T) {
+	for _, flag := range []string{"--profile=minimal", "--interactive", "--force"} {
+		t.Run(flag, func(t *testing.T) {
+			cmd := newCloneCommand()
+			cmd.SetArgs([]string{"--manifest-only", flag, "https://github.com/user/dotfiles"})
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+
+			err := cmd.Execute()
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "--manifest-only cannot be combined")
+		})
+	}
+}
+
+func TestCloneCommand_RejectsNegativeDepth(t *testing.T) {
+	cmd := newCloneCommand()
+	cmd.SetArgs([]string{"--depth", "-1", "https://github.com/user/dotfiles"})
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+
+	err := cmd.Execute()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid --depth -1")
 }
 
 func TestCloneCommand_Args(t *testing.T) {
@@ -130,6 +175,15 @@ func TestFormatCloneError_ProfileNotFound(t *testing.T) {
 	assert.Contains(t, errMsg, ".dotbootstrap.yaml")
 }
 
+func TestFormatCloneError_NotGitRepository(t *testing.T) {
+	err := dot.ErrNotGitRepository{Path: "/path/to/packages"}
+	formatted := formatCloneError(err)
+
+	errMsg := formatted.Error()
+	assert.Contains(t, errMsg, "not a git repository")
+	assert.Contains(t, errMsg, "--dir")
+}
+
 func TestFormatCloneError_GenericError(t *testing.T) {
 	err := assert.AnError
 	formatted := formatCloneError(err)
@@ -7,10 +7,13 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/yaklabco/dot/internal/cli/output"
+	"github.com/yaklabco/dot/internal/scanner"
 	"github.com/yaklabco/dot/pkg/dot"
 )
 
@@ -58,13 +61,13 @@ func executePackageCommand(cmd *cobra.Command, args []string, fn packageCommandF
 
 // formatSuccessMessage prints a standardized success message using the output formatter.
 func formatSuccessMessage(w io.Writer, verb string, count int, colorEnabled bool) {
-	formatter := output.NewFormatter(w, colorEnabled)
+	formatter := output.NewFormatter(w, colorEnabled, outputTheme())
 	formatter.Success(verb, count, "package", "packages")
 }
 
 // formatNoChangesMessage prints a message indicating no changes were detected.
 func formatNoChangesMessage(w io.Writer, count int, colorEnabled bool) {
-	formatter := output.NewFormatter(w, colorEnabled)
+	formatter := output.NewFormatter(w, colorEnabled, outputTheme())
 	formatter.Info(fmt.Sprintf("No changes detected for %s", formatCount(count, "package", "packages")))
 }
 
@@ -73,27 +76,30 @@ func getAvailablePackages() []string {
 	return getAvailablePackagesWithFlags(GetCLIFlags())
 }
 
-// getAvailablePackagesWithFlags returns packages from explicit CLI flags.
+// getAvailablePackagesWithFlags returns the packages in the package
+// directory resolved from explicit CLI flags, environment, and config.
 func getAvailablePackagesWithFlags(flags *CLIFlags) []string {
-	packageDir := flags.packageDir
-	if packageDir == "" {
-		packageDir = "."
-	}
-
-	absDir, err := filepath.Abs(packageDir)
+	packageDir, err := resolvePackageDirectory(flags.packageDir)
 	if err != nil {
 		return nil
 	}
+	return listPackageDirs(context.Background(), dot.NewOSFilesystem(), packageDir)
+}
 
-	entries, err := os.ReadDir(absDir)
+// listPackageDirs returns the names of the package directories in
+// packageDir, skipping hidden, ignored, and reserved names. It returns nil
+// if packageDir cannot be read.
+func listPackageDirs(ctx context.Context, fs dot.FS, packageDir string) []string {
+	entries, err := fs.ReadDir(ctx, packageDir)
 	if err != nil {
 		return nil
 	}
 
 	packages := make([]string, 0, len(entries))
 	for _, entry := range entries {
-		if entry.IsDir() && !isHiddenOrIgnored(entry.Name()) {
-			packages = append(packages, entry.Name())
+		name := entry.Name()
+		if entry.IsDir() && !isHiddenOrIgnored(name) && !scanner.IsReservedPackageName(name) {
+			packages = append(packages, name)
 		}
 	}
 
@@ -156,8 +162,20 @@ func packageCompletion(installed bool) func(*cobra.Command, []string, string) ([
 		} else {
 			packages = getAvailablePackages()
 		}
-		return packages, cobra.ShellCompDirectiveNoFileComp
+		return filterCompletions(packages, args, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// filterCompletions returns the candidates that start with toComplete and
+// are not already among args.
+func filterCompletions(candidates, args []string, toComplete string) []string {
+	matches := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, toComplete) && !slices.Contains(args, candidate) {
+			matches = append(matches, candidate)
+		}
 	}
+	return matches
 }
 
 // derivePackageName derives a package name from a file or directory path.
@@ -182,14 +200,6 @@ func derivePackageName(path string) string {
 	return base
 }
 
-// pluralize returns the singular or plural form based on count.
-func pluralize(count int, singular, plural string) string {
-	if count == 1 {
-		return singular
-	}
-	return plural
-}
-
 // formatCount formats a count with the appropriate singular or plural form.
 // Examples:
 //
@@ -197,7 +207,7 @@ func pluralize(count int, singular, plural string) string {
 //	formatCount(3, "package", "packages") -> "3 packages"
 //	formatCount(0, "file", "files") -> "0 files"
 func formatCount(count int, singular, plural string) string {
-	return fmt.Sprintf("%d %s", count, pluralize(count, singular, plural))
+	return fmt.Sprintf("%d %s", count, output.Pluralize(count, singular, plural))
 }
 
 // secretWarning represents a warning about a potential secret file.
@@ -347,7 +347,7 @@ func TestUnmanageCommand_AllFlag(t *testing.T) {
 	setupGlobalCfg(t)
 
 	cmd := newUnmanageCommand()
-	cmd.SetArgs([]string{"--all", "--yes"}) // Skip confirmation
+	cmd.SetArgs([]string{"--all", "--force"}) // Skip confirmation
 
 	out := &bytes.Buffer{}
 	cmd.SetOut(out)
@@ -2,16 +2,24 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
 
+	"github.com/yaklabco/dot/internal/cli/output"
+	"github.com/yaklabco/dot/internal/cli/prompt"
 	"github.com/yaklabco/dot/internal/cli/render"
+	"github.com/yaklabco/dot/internal/cli/terminal"
 	"github.com/yaklabco/dot/pkg/dot"
 )
 
@@ -55,6 +63,10 @@ files, and managing configuration across sources.`,
 		newConfigListCommand(),
 		newConfigPathCommand(),
 		newConfigUpgradeCommand(),
+		newConfigSchemaCommand(),
+		newConfigValidateCommand(),
+		newConfigEditCommand(),
+		newConfigDumpCommand(),
 	)
 
 	return cmd
@@ -90,7 +102,8 @@ func newConfigInitCommand() *cobra.Command {
 The configuration file is created in the XDG config directory:
   ~/.config/dot/config.yaml (default)
 
-Use --force to overwrite existing configuration.`,
+Use --force or --yes to overwrite existing configuration. When run
+interactively without either, dot asks before overwriting.`,
 		Example: `  # Create config with defaults
   dot config init
 
@@ -100,19 +113,18 @@ Use --force to overwrite existing configuration.`,
   # Create in JSON format
   dot config init --format json`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runConfigInit(force, format)
+			return runConfigInit(cmd.Context(), force, format)
 		},
 	}
 
 	cmd.Flags().BoolVarP(&force, "force", "f", false, "Overwrite existing config")
-	cmd.Flags().BoolVarP(&force, "yes", "y", false, "Overwrite existing config (alias for --force)")
 	cmd.Flags().StringVar(&format, "format", "yaml", "Config format (yaml, json, toml)")
 
 	return cmd
 }
 
 // runConfigInit handles the init subcommand.
-func runConfigInit(force bool, format string) error {
+func runConfigInit(ctx context.Context, force bool, format string) error {
 	configPath := getConfigFilePath()
 
 	// If format was not explicitly specified (still default "yaml"),
@@ -144,7 +156,18 @@ func runConfigInit(force bool, format string) error {
 
 	// Check if exists
 	if _, err := os.Stat(configPath); err == nil && !force {
-		return fmt.Errorf("config file already exists: %s (use --force to overwrite)", configPath)
+		if !prompt.AssumeYes(ctx) && !terminal.IsInteractive() {
+			return fmt.Errorf("config file already exists: %s (use --force to overwrite)", configPath)
+		}
+		confirmed, err := prompt.Confirm(ctx, os.Stdin, os.Stdout,
+			fmt.Sprintf("Config file %s already exists. Overwrite?", configPath), false)
+		if err != nil {
+			return fmt.Errorf("failed to read response: %w", err)
+		}
+		if !confirmed {
+			fmt.Println("Config init cancelled.")
+			return nil
+		}
 	}
 
 	// Create writer and write default config
@@ -174,12 +197,18 @@ func newConfigGetCommand() *cobra.Command {
 		Long: `Retrieve configuration value by key path.
 
 Keys use dot notation: section.field
-For example: directories.package, logging.level`,
+For example: directories.package, logging.level
+
+Every configuration key is supported. Lists are printed comma-separated,
+booleans as true/false.`,
 		Example: `  # Get package directory
   dot config get directories.package
 
   # Get logging level
-  dot config get logging.level`,
+  dot config get logging.level
+
+  # Get ignore patterns
+  dot config get ignore.patterns`,
 		Args: argsWithUsage(cobra.ExactArgs(1)),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runConfigGet(args[0])
@@ -216,52 +245,21 @@ func runConfigGet(key string) error {
 }
 
 // getValidConfigKeys returns all valid configuration keys for completion.
+// Keys are derived from the configuration struct so completion always
+// matches what get accepts.
 func getValidConfigKeys() []string {
-	return []string{
-		"directories.package",
-		"directories.target",
-		"directories.manifest",
-		"logging.level",
-		"logging.format",
-		"logging.destination",
-		"symlinks.mode",
-		"symlinks.backup_suffix",
-		"symlinks.backup_dir",
-		"dotfile.prefix",
-		"dotfile.translate",
-		"dotfile.package_name_mapping",
-		"output.format",
-		"output.color",
-		"packages.sort_by",
-	}
+	return dot.ConfigKeys()
+}
+
+// getSettableConfigKeys returns the configuration keys set accepts, for
+// completion.
+func getSettableConfigKeys() []string {
+	return dot.SettableConfigKeys()
 }
 
 // getConfigValue retrieves a value from config by key path.
 func getConfigValue(cfg *dot.ExtendedConfig, key string) (string, error) {
-	getters := map[string]func() string{
-		"directories.package":    func() string { return cfg.Directories.Package },
-		"directories.target":     func() string { return cfg.Directories.Target },
-		"directories.manifest":   func() string { return cfg.Directories.Manifest },
-		"logging.level":          func() string { return cfg.Logging.Level },
-		"logging.format":         func() string { return cfg.Logging.Format },
-		"logging.destination":    func() string { return cfg.Logging.Destination },
-		"symlinks.mode":          func() string { return cfg.Symlinks.Mode },
-		"symlinks.backup_suffix": func() string { return cfg.Symlinks.BackupSuffix },
-		"symlinks.backup_dir":    func() string { return cfg.Symlinks.BackupDir },
-		"dotfile.prefix":         func() string { return cfg.Dotfile.Prefix },
-		"dotfile.translate":      func() string { return fmt.Sprintf("%t", cfg.Dotfile.Translate) },
-		"dotfile.package_name_mapping": func() string {
-			return fmt.Sprintf("%t", cfg.Dotfile.PackageNameMapping)
-		},
-		"output.format":    func() string { return cfg.Output.Format },
-		"output.color":     func() string { return cfg.Output.Color },
-		"packages.sort_by": func() string { return cfg.Packages.SortBy },
-	}
-
-	if getter, ok := getters[key]; ok {
-		return getter(), nil
-	}
-	return "", fmt.Errorf("unknown config key: %s", key)
+	return dot.GetConfigValue(cfg, key)
 }
 
 // newConfigSetCommand creates the set subcommand.
@@ -272,7 +270,9 @@ func newConfigSetCommand() *cobra.Command {
 		Long: `Set configuration value by key path.
 
 Keys use dot notation: section.field
-Values are automatically type-converted based on the field.`,
+Values are automatically type-converted based on the field. Lists are
+comma-separated and maps are comma-separated key=value pairs. Hooks,
+doctor categories, and CLI profiles are edited in the config file.`,
 		Example: `  # Set package directory
   dot config set directories.package ~/dotfiles
 
@@ -288,7 +288,7 @@ Values are automatically type-converted based on the field.`,
 		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 			// Only complete the first argument (key)
 			if len(args) == 0 {
-				return getValidConfigKeys(), cobra.ShellCompDirectiveNoFileComp
+				return getSettableConfigKeys(), cobra.ShellCompDirectiveNoFileComp
 			}
 			// Don't complete the second argument (value)
 			return nil, cobra.ShellCompDirectiveNoFileComp
@@ -373,7 +373,7 @@ func runConfigListCmd(cmd *cobra.Command, args []string) error {
 
 	// Default text/table format
 	colorize := shouldUseColor()
-	c := render.NewColorizer(colorize)
+	c := render.NewColorizer(colorize, outputTheme())
 
 	var buf bytes.Buffer
 	fmt.Fprintf(&buf, "%s\n\n", c.Dim("Configuration from: "+configPath))
@@ -392,6 +392,7 @@ func runConfigListCmd(cmd *cobra.Command, args []string) error {
 		{"Operations", renderOperationsSection},
 		{"Packages", renderPackagesSection},
 		{"Doctor", renderDoctorSection},
+		{"Hooks", renderHooksSection},
 		{"Experimental", renderExperimentalSection},
 	}
 
@@ -424,6 +425,10 @@ func renderLoggingSection(buf *bytes.Buffer, cfg *dot.ExtendedConfig, c *render.
 	if cfg.Logging.File != "" {
 		fmt.Fprintf(buf, "  %-20s %s\n", c.Dim("file:"), cfg.Logging.File)
 	}
+	fmt.Fprintf(buf, "  %-20s %d\n", c.Dim("max_size_mb:"), cfg.Logging.MaxSizeMB)
+	fmt.Fprintf(buf, "  %-20s %d\n", c.Dim("max_backups:"), cfg.Logging.MaxBackups)
+	fmt.Fprintf(buf, "  %-20s %d\n", c.Dim("max_age_days:"), cfg.Logging.MaxAgeDays)
+	fmt.Fprintf(buf, "  %-20s %s\n", c.Dim("redact_paths:"), formatSlice(cfg.Logging.RedactPaths, c, terminal.Width(cfg.Output.Width)-configValueColumn))
 }
 
 // renderSymlinksSection renders the symlinks configuration.
@@ -434,17 +439,20 @@ func renderSymlinksSection(buf *bytes.Buffer, cfg *dot.ExtendedConfig, c *render
 	fmt.Fprintf(buf, "  %-20s %s\n", c.Dim("overwrite:"), formatBool(cfg.Symlinks.Overwrite, c))
 	fmt.Fprintf(buf, "  %-20s %s\n", c.Dim("backup:"), formatBool(cfg.Symlinks.Backup, c))
 	fmt.Fprintf(buf, "  %-20s %s\n", c.Dim("backup_suffix:"), cfg.Symlinks.BackupSuffix)
+	fmt.Fprintf(buf, "  %-20s %s\n", c.Dim("backup_strategy:"), cfg.Symlinks.BackupStrategy)
 	if cfg.Symlinks.BackupDir != "" {
 		fmt.Fprintf(buf, "  %-20s %s\n", c.Dim("backup_dir:"), cfg.Symlinks.BackupDir)
 	}
 }
 
-// renderIgnoreSection renders the ignore configuration section.
+// renderIgnoreSection renders the ignore configuration section, listing as
+// many patterns as fit the output width.
 func renderIgnoreSection(buf *bytes.Buffer, cfg *dot.ExtendedConfig, c *render.Colorizer) {
+	room := terminal.Width(cfg.Output.Width) - configValueColumn
 	fmt.Fprintf(buf, "%s\n", c.Bold("Ignore"))
 	fmt.Fprintf(buf, "  %-20s %s\n", c.Dim("use_defaults:"), formatBool(cfg.Ignore.UseDefaults, c))
-	fmt.Fprintf(buf, "  %-20s %s\n", c.Dim("patterns:"), formatSlice(cfg.Ignore.Patterns, c))
-	fmt.Fprintf(buf, "  %-20s %s\n", c.Dim("overrides:"), formatSlice(cfg.Ignore.Overrides, c))
+	fmt.Fprintf(buf, "  %-20s %s\n", c.Dim("patterns:"), formatSlice(cfg.Ignore.Patterns, c, room))
+	fmt.Fprintf(buf, "  %-20s %s\n", c.Dim("overrides:"), formatSlice(cfg.Ignore.Overrides, c, room))
 }
 
 // renderDotfileSection renders the dotfile configuration section.
@@ -460,6 +468,7 @@ func renderOutputSection(buf *bytes.Buffer, cfg *dot.ExtendedConfig, c *render.C
 	fmt.Fprintf(buf, "%s\n", c.Bold("Output"))
 	fmt.Fprintf(buf, "  %-20s %s\n", c.Dim("format:"), cfg.Output.Format)
 	fmt.Fprintf(buf, "  %-20s %s\n", c.Dim("color:"), cfg.Output.Color)
+	fmt.Fprintf(buf, "  %-20s %s\n", c.Dim("theme:"), cfg.Output.Theme)
 	fmt.Fprintf(buf, "  %-20s %s\n", c.Dim("progress:"), formatBool(cfg.Output.Progress, c))
 	fmt.Fprintf(buf, "  %-20s %d\n", c.Dim("verbosity:"), cfg.Output.Verbosity)
 	fmt.Fprintf(buf, "  %-20s %d\n", c.Dim("width:"), cfg.Output.Width)
@@ -471,6 +480,9 @@ func renderOperationsSection(buf *bytes.Buffer, cfg *dot.ExtendedConfig, c *rend
 	fmt.Fprintf(buf, "  %-20s %s\n", c.Dim("dry_run:"), formatBool(cfg.Operations.DryRun, c))
 	fmt.Fprintf(buf, "  %-20s %s\n", c.Dim("atomic:"), formatBool(cfg.Operations.Atomic, c))
 	fmt.Fprintf(buf, "  %-20s %d\n", c.Dim("max_parallel:"), cfg.Operations.MaxParallel)
+	fmt.Fprintf(buf, "  %-20s %d\n", c.Dim("transaction_size:"), cfg.Operations.TransactionSize)
+	fmt.Fprintf(buf, "  %-20s %d\n", c.Dim("retry.max_attempts:"), cfg.Operations.Retry.MaxAttempts)
+	fmt.Fprintf(buf, "  %-20s %dms\n", c.Dim("retry.base_backoff:"), cfg.Operations.Retry.BaseBackoffMS)
 }
 
 // renderPackagesSection renders the packages configuration section.
@@ -479,6 +491,18 @@ func renderPackagesSection(buf *bytes.Buffer, cfg *dot.ExtendedConfig, c *render
 	fmt.Fprintf(buf, "  %-20s %s\n", c.Dim("sort_by:"), cfg.Packages.SortBy)
 	fmt.Fprintf(buf, "  %-20s %s\n", c.Dim("auto_discover:"), formatBool(cfg.Packages.AutoDiscover, c))
 	fmt.Fprintf(buf, "  %-20s %s\n", c.Dim("validate_names:"), formatBool(cfg.Packages.ValidateNames, c))
+	if len(cfg.Packages.Mappings) == 0 {
+		return
+	}
+	fmt.Fprintf(buf, "  %s\n", c.Dim("mappings:"))
+	names := make([]string, 0, len(cfg.Packages.Mappings))
+	for name := range cfg.Packages.Mappings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(buf, "    %-18s %s\n", c.Dim(name+":"), cfg.Packages.Mappings[name])
+	}
 }
 
 // renderDoctorSection renders the doctor configuration section.
@@ -489,6 +513,36 @@ func renderDoctorSection(buf *bytes.Buffer, cfg *dot.ExtendedConfig, c *render.C
 	fmt.Fprintf(buf, "  %-20s %s\n", c.Dim("check_broken_links:"), formatBool(cfg.Doctor.CheckBrokenLinks, c))
 	fmt.Fprintf(buf, "  %-20s %s\n", c.Dim("check_orphaned:"), formatBool(cfg.Doctor.CheckOrphaned, c))
 	fmt.Fprintf(buf, "  %-20s %s\n", c.Dim("check_permissions:"), formatBool(cfg.Doctor.CheckPermissions, c))
+	for _, cat := range cfg.Doctor.Categories {
+		fmt.Fprintf(buf, "  %-20s %s\n", c.Dim("category:"), fmt.Sprintf("%s (%s)", cat.Name, strings.Join(cat.Patterns, ", ")))
+	}
+}
+
+// renderHooksSection renders the hooks configuration section.
+func renderHooksSection(buf *bytes.Buffer, cfg *dot.ExtendedConfig, c *render.Colorizer) {
+	fmt.Fprintf(buf, "%s\n", c.Bold("Hooks"))
+	stages := []struct {
+		name  string
+		hooks []dot.HookConfig
+	}{
+		{"pre_manage:", cfg.Hooks.PreManage},
+		{"post_manage:", cfg.Hooks.PostManage},
+		{"pre_unmanage:", cfg.Hooks.PreUnmanage},
+		{"post_unmanage:", cfg.Hooks.PostUnmanage},
+	}
+	for _, stage := range stages {
+		if len(stage.hooks) == 0 {
+			fmt.Fprintf(buf, "  %-20s %s\n", c.Dim(stage.name), c.Dim("none"))
+			continue
+		}
+		for _, hook := range stage.hooks {
+			value := strings.Join(hook.Command, " ")
+			if len(hook.Packages) > 0 {
+				value += c.Dim(" (" + strings.Join(hook.Packages, ", ") + ")")
+			}
+			fmt.Fprintf(buf, "  %-20s %s\n", c.Dim(stage.name), value)
+		}
+	}
 }
 
 // renderExperimentalSection renders the experimental configuration section.
@@ -506,15 +560,34 @@ func formatBool(b bool, c *render.Colorizer) string {
 	return c.Dim("false")
 }
 
-// formatSlice formats a string slice for display.
-func formatSlice(s []string, c *render.Colorizer) string {
+// configValueColumn is the column config list prints values at, after the
+// two-space indent and the 20-character key.
+const configValueColumn = 23
+
+// formatSlice formats a string slice for display, showing at most three
+// items and only as many as fit in room columns. At least one item is
+// always shown.
+func formatSlice(s []string, c *render.Colorizer, room int) string {
 	if len(s) == 0 {
 		return c.Dim("(none)")
 	}
-	if len(s) <= 3 {
+	shown := 1
+	length := len(s[0])
+	for shown < len(s) && shown < 3 {
+		more := len(fmt.Sprintf(" (+%d more)", len(s)-shown-1))
+		if shown+1 == len(s) {
+			more = 0
+		}
+		if length+len(", ")+len(s[shown])+more > room {
+			break
+		}
+		length += len(", ") + len(s[shown])
+		shown++
+	}
+	if shown == len(s) {
 		return strings.Join(s, ", ")
 	}
-	return strings.Join(s[:3], ", ") + c.Dim(fmt.Sprintf(" (+%d more)", len(s)-3))
+	return strings.Join(s[:shown], ", ") + c.Dim(fmt.Sprintf(" (+%d more)", len(s)-shown))
 }
 
 // newConfigPathCommand creates the path subcommand.
@@ -559,10 +632,318 @@ func runConfigPath() error {
 	return nil
 }
 
+// newConfigSchemaCommand creates the schema subcommand.
+func newConfigSchemaCommand() *cobra.Command {
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "schema",
+		Short: "Print JSON Schema for the configuration file",
+		Long: `Print a JSON Schema describing the configuration file.
+
+The schema is generated from the configuration structure and its
+validation rules, so it always matches what dot accepts. Use it for
+editor autocompletion or to validate config files in CI.`,
+		Example: `  # Print schema to stdout
+  dot config schema
+
+  # Write schema to a file
+  dot config schema --output dot.schema.json`,
+		Args: argsWithUsage(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConfigSchema(cmd, output)
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Write schema to file instead of stdout")
+
+	return cmd
+}
+
+// runConfigSchema handles the schema subcommand.
+func runConfigSchema(cmd *cobra.Command, output string) error {
+	schema, err := dot.GenerateConfigSchema()
+	if err != nil {
+		return fmt.Errorf("generate schema: %w", err)
+	}
+
+	if output != "" {
+		if err := os.WriteFile(output, append(schema, '\n'), 0644); err != nil {
+			return fmt.Errorf("write schema: %w", err)
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Wrote configuration schema to %s\n", output)
+		return nil
+	}
+
+	fmt.Fprintln(cmd.OutOrStdout(), string(schema))
+	return nil
+}
+
+// newConfigValidateCommand creates the validate subcommand.
+func newConfigValidateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate [path]",
+		Short: "Check a configuration file for errors",
+		Long: `Check that a configuration file is well-formed and that every value
+is valid. All problems are reported at once, each with its field path.
+
+Without a path, the active configuration file is checked. Exits with a
+non-zero status if any problem is found, making it suitable for CI.`,
+		Example: `  # Validate the active configuration file
+  dot config validate
+
+  # Validate a specific file
+  dot config validate ./config.yaml`,
+		Args: argsWithUsage(cobra.MaximumNArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := getConfigFilePath()
+			if len(args) == 1 {
+				path = args[0]
+			}
+			return runConfigValidate(cmd, path)
+		},
+	}
+
+	return cmd
+}
+
+// runConfigValidate handles the validate subcommand.
+func runConfigValidate(cmd *cobra.Command, path string) error {
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("config file %s: %w", path, err)
+	}
+
+	out := cmd.OutOrStdout()
+	cfg, err := dot.LoadExtendedWithIncludes(path)
+	if err == nil {
+		fmt.Fprintf(out, "Configuration file %s is valid\n", path)
+		for _, warning := range cfg.Warnings() {
+			fmt.Fprintf(out, "  warning: %s\n", warning)
+		}
+		return nil
+	}
+
+	return reportConfigProblems(out, path, err)
+}
+
+// reportConfigProblems prints each validation problem in err and returns
+// the error the command should fail with.
+func reportConfigProblems(out io.Writer, path string, err error) error {
+	var multi dot.ErrMultiple
+	if !errors.As(err, &multi) {
+		// Not a validation failure: the file could not be read or parsed
+		return fmt.Errorf("invalid configuration file %s: %w", path, err)
+	}
+
+	fmt.Fprintf(out, "Configuration file %s has %d %s:\n", path, len(multi.Errors), output.Pluralize(len(multi.Errors), "problem", "problems"))
+	for _, problem := range multi.Errors {
+		fmt.Fprintf(out, "  - %v\n", problem)
+	}
+	return fmt.Errorf("configuration validation failed")
+}
+
+// newConfigEditCommand creates the edit subcommand.
+func newConfigEditCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "edit",
+		Short: "Edit the configuration file and check it on save",
+		Long: `Open the active configuration file in $EDITOR, or $VISUAL when
+$EDITOR is not set. The editor command may include arguments, such as
+"code --wait".
+
+A backup is saved to ~/.config/dot/backups/ before the editor starts.
+When the editor exits, the file is validated. If it has problems they
+are listed and you are asked whether to reopen the editor; declining
+restores the configuration from the backup.`,
+		Example: `  # Edit with the configured editor
+  dot config edit
+
+  # Edit with a specific editor
+  EDITOR=nano dot config edit`,
+		Args: argsWithUsage(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConfigEdit(cmd, getConfigFilePath())
+		},
+	}
+
+	return cmd
+}
+
+// runConfigEdit handles the edit subcommand.
+func runConfigEdit(cmd *cobra.Command, path string) error {
+	editor := configEditor()
+	if len(editor) == 0 {
+		return fmt.Errorf("no editor set: set EDITOR or VISUAL, for example EDITOR=vim")
+	}
+
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return fmt.Errorf("config file does not exist: %s\nRun 'dot config init' to create one", path)
+	}
+
+	backupPath, err := dot.BackupConfig(path)
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	out := cmd.OutOrStdout()
+
+	for {
+		editorCmd := exec.CommandContext(ctx, editor[0], append(editor[1:], path)...)
+		// Only a real terminal is handed to the editor: exec copies any other
+		// reader into a pipe and would swallow the answer to the prompt below
+		if in, ok := cmd.InOrStdin().(*os.File); ok {
+			editorCmd.Stdin = in
+		}
+		editorCmd.Stdout = out
+		editorCmd.Stderr = cmd.ErrOrStderr()
+		if err := editorCmd.Run(); err != nil {
+			return fmt.Errorf("run editor %s: %w (backup saved to %s)", editor[0], err, backupPath)
+		}
+
+		_, err := dot.LoadExtendedFromFile(path)
+		if err == nil {
+			fmt.Fprintf(out, "Configuration file %s is valid\n", path)
+			return nil
+		}
+
+		validateErr := reportConfigProblems(out, path, err)
+		var multi dot.ErrMultiple
+		if !errors.As(err, &multi) {
+			// A file that does not parse is not listed as problems
+			fmt.Fprintf(out, "%v\n", validateErr)
+		}
+
+		// Not prompt.Confirm: --yes or closed input must not reopen the
+		// editor forever, so both restore the backup instead
+		reopen, promptErr := prompt.New(cmd.InOrStdin(), out).ConfirmWithDefault("Reopen the editor?", false)
+		if promptErr != nil {
+			return fmt.Errorf("failed to read response: %w", promptErr)
+		}
+		if reopen {
+			continue
+		}
+
+		if err := dot.RestoreConfig(backupPath, path); err != nil {
+			return fmt.Errorf("%w (backup saved to %s)", err, backupPath)
+		}
+		fmt.Fprintf(out, "Restored %s from %s\n", path, backupPath)
+		return validateErr
+	}
+}
+
+// configEditor returns the editor command from $EDITOR or $VISUAL, split
+// into the program and its arguments.
+func configEditor() []string {
+	for _, name := range []string{"EDITOR", "VISUAL"} {
+		if fields := strings.Fields(os.Getenv(name)); len(fields) > 0 {
+			return fields
+		}
+	}
+	return nil
+}
+
+// newConfigDumpCommand creates the dump subcommand.
+func newConfigDumpCommand() *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "dump",
+		Short: "Print the fully resolved configuration",
+		Long: `Print the complete configuration dot will use after merging built-in
+defaults, the configuration file, DOT_* environment variables and global
+flags such as --dir, --target and --verbose.
+
+Unlike 'config list', the output is a complete configuration file that
+can be saved and loaded again. Secret values are masked.`,
+		Example: `  # Print resolved configuration as YAML
+  dot config dump
+
+  # Save resolved configuration as JSON
+  dot config dump --format json > config.json`,
+		Args: argsWithUsage(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConfigDump(cmd, format)
+		},
+	}
+
+	cmd.Flags().StringVarP(&format, "format", "f", "yaml", "Output format (yaml, json)")
+
+	return cmd
+}
+
+// runConfigDump handles the dump subcommand.
+func runConfigDump(cmd *cobra.Command, format string) error {
+	extCfg, err := loadConfigWithRepoPriority(GetCLIFlags().packageDir, getConfigFilePath())
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	resolved := dot.ApplyConfigFlags(extCfg, changedGlobalFlags(cmd))
+	resolved, err = dot.RedactedConfig(resolved)
+	if err != nil {
+		return fmt.Errorf("redact config: %w", err)
+	}
+
+	var data []byte
+	switch format {
+	case "yaml":
+		data, err = yaml.Marshal(resolved)
+	case "json":
+		data, err = json.MarshalIndent(resolved, "", "  ")
+		data = append(data, '\n')
+	default:
+		return fmt.Errorf("invalid format %q (must be yaml or json)", format)
+	}
+	if err != nil {
+		return fmt.Errorf("marshal config: %w", err)
+	}
+
+	_, err = cmd.OutOrStdout().Write(data)
+	return err
+}
+
+// changedGlobalFlags returns the explicitly set global flags that override
+// configuration values, keyed by flag name.
+func changedGlobalFlags(cmd *cobra.Command) map[string]interface{} {
+	flags := GetCLIFlags()
+	overrides := make(map[string]interface{})
+
+	changed := func(name string) bool {
+		f := cmd.Flag(name)
+		return f != nil && f.Changed
+	}
+
+	if changed("dir") {
+		overrides["dir"] = flags.packageDir
+	}
+	if changed("target") {
+		overrides["target"] = flags.targetDir
+	}
+	if changed("dry-run") {
+		overrides["dry-run"] = flags.dryRun
+	}
+	if changed("verbose") {
+		overrides["verbose"] = flags.verbose
+	}
+	if changed("quiet") {
+		overrides["quiet"] = flags.quiet
+	}
+	if changed("log-json") {
+		overrides["log-json"] = flags.logJSON
+	}
+	if changed("no-color") && flags.noColor {
+		overrides["color"] = "never"
+	}
+	return overrides
+}
+
 // newConfigUpgradeCommand creates the upgrade subcommand.
 func newConfigUpgradeCommand() *cobra.Command {
 	var force bool
-	var yes bool
 
 	cmd := &cobra.Command{
 		Use:   "upgrade",
@@ -586,14 +967,12 @@ backups are automatically retained.`,
   dot config upgrade --force
   dot config upgrade --yes`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runConfigUpgrade(cmd, force || yes)
+			return runConfigUpgrade(cmd, force || prompt.AssumeYes(cmd.Context()))
 		},
 	}
 
 	cmd.Flags().BoolVarP(&force, "force", "f", false,
-		"Skip confirmation prompt")
-	cmd.Flags().BoolVarP(&yes, "yes", "y", false,
-		"Skip confirmation prompt (alias for --force)")
+		"Skip confirmation prompt (same as --yes)")
 
 	return cmd
 }
@@ -611,16 +990,13 @@ func runConfigUpgrade(cmd *cobra.Command, force bool) error {
 	if !force {
 		fmt.Println("This will upgrade your configuration file to the latest format.")
 		fmt.Println("A backup will be created before making any changes.")
-		fmt.Printf("\nConfig file: %s\n", configPath)
-		fmt.Print("\nContinue? [y/N] ")
+		fmt.Printf("\nConfig file: %s\n\n", configPath)
 
-		var response string
-		if _, err := fmt.Scanln(&response); err != nil {
+		confirmed, err := prompt.Confirm(cmd.Context(), cmd.InOrStdin(), cmd.OutOrStdout(), "Continue?", false)
+		if err != nil {
 			return fmt.Errorf("failed to read response: %w", err)
 		}
-
-		response = strings.ToLower(strings.TrimSpace(response))
-		if response != "y" && response != "yes" {
+		if !confirmed {
 			fmt.Println("Upgrade cancelled.")
 			return nil
 		}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const validEditConfig = "logging:\n  level: DEBUG\n"
+
+// setupConfigEdit writes a config file and an editor script that replaces
+// the file with each of edits in turn, one per invocation.
+func setupConfigEdit(t *testing.T, edits ...string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("editor script requires a POSIX shell")
+	}
+
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(dir, "config"))
+	configPath := filepath.Join(dir, "config.yaml")
+	t.Setenv("DOT_CONFIG", configPath)
+	require.NoError(t, os.WriteFile(configPath, []byte("logging:\n  level: INFO\n"), 0600))
+
+	for i, edit := range edits {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "edit"+strconv.Itoa(i+1)+".yaml"), []byte(edit), 0600))
+	}
+	script := filepath.Join(dir, "editor.sh")
+	content := `#!/bin/sh
+n=$(cat "` + dir + `/count" 2>/dev/null || echo 0)
+n=$((n + 1))
+echo "$n" > "` + dir + `/count"
+cp "` + dir + `/edit$n.yaml" "$1"
+`
+	require.NoError(t, os.WriteFile(script, []byte(content), 0700))
+	t.Setenv("EDITOR", script)
+	t.Setenv("VISUAL", "")
+
+	return configPath
+}
+
+func runConfigEditCommand(t *testing.T, input string) (string, error) {
+	t.Helper()
+	cmd := newConfigEditCommand()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetIn(strings.NewReader(input))
+	cmd.SetArgs([]string{})
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestConfigEditCommand_ValidEdit(t *testing.T) {
+	configPath := setupConfigEdit(t, validEditConfig)
+
+	out, err := runConfigEditCommand(t, "")
+	require.NoError(t, err)
+	assert.Contains(t, out, "is valid")
+
+	data, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, validEditConfig, string(data))
+
+	backups, err := filepath.Glob(filepath.Join(os.Getenv("XDG_CONFIG_HOME"), "dot", "backups", "*-config.bak"))
+	require.NoError(t, err)
+	assert.Len(t, backups, 1, "a backup is made before editing")
+}
+
+func TestConfigEditCommand_ReopensAfterInvalidEdit(t *testing.T) {
+	configPath := setupConfigEdit(t, "symlinks:\n  mode: sideways\n", validEditConfig)
+
+	out, err := runConfigEditCommand(t, "y\n")
+	require.NoError(t, err)
+	assert.Contains(t, out, "symlinks.mode")
+	assert.Contains(t, out, "Reopen the editor?")
+	assert.Contains(t, out, "is valid")
+
+	data, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, validEditConfig, string(data))
+}
+
+func TestConfigEditCommand_DeclineRestoresBackup(t *testing.T) {
+	tests := []struct {
+		name string
+		edit string
+		want string
+	}{
+		{name: "validation problem", edit: "symlinks:\n  mode: sideways\n", want: "symlinks.mode"},
+		{name: "parse error", edit: "logging: [broken\n", want: "invalid configuration file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := setupConfigEdit(t, tt.edit)
+
+			out, err := runConfigEditCommand(t, "n\n")
+			require.Error(t, err)
+			assert.Contains(t, out, tt.want)
+			assert.Contains(t, out, "Restored")
+
+			data, err := os.ReadFile(configPath)
+			require.NoError(t, err)
+			assert.Equal(t, "logging:\n  level: INFO\n", string(data))
+		})
+	}
+}
+
+func TestConfigEditCommand_NoEditor(t *testing.T) {
+	t.Setenv("EDITOR", "")
+	t.Setenv("VISUAL", "")
+	t.Setenv("DOT_CONFIG", filepath.Join(t.TempDir(), "config.yaml"))
+
+	_, err := runConfigEditCommand(t, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no editor set")
+}
+
+func TestConfigEditor(t *testing.T) {
+	tests := []struct {
+		name   string
+		editor string
+		visual string
+		want   []string
+	}{
+		{name: "editor with arguments", editor: "code --wait", want: []string{"code", "--wait"}},
+		{name: "editor before visual", editor: "vim", visual: "emacs", want: []string{"vim"}},
+		{name: "visual fallback", visual: "emacs", want: []string{"emacs"}},
+		{name: "neither set"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("EDITOR", tt.editor)
+			t.Setenv("VISUAL", tt.visual)
+			assert.Equal(t, tt.want, configEditor())
+		})
+	}
+}
@@ -1,10 +1,13 @@
 package main
 
 import (
+	"bytes"
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"testing/iotest"
 	"time"
 
 	"github.com/stretchr/testify/assert"
@@ -301,65 +304,65 @@ ignore:
 	assert.Contains(t, header, "# See https://github.com/yaklabco/dot")
 }
 
-func TestNewConfigUpgradeCommand_YesFlag(t *testing.T) {
+func TestConfigUpgrade_YesFlag(t *testing.T) {
 	cmd := newConfigUpgradeCommand()
+	assert.Nil(t, cmd.Flags().Lookup("yes"), "--yes is a global flag")
+
+	for _, args := range [][]string{
+		{"config", "upgrade", "--yes"},
+		{"config", "upgrade", "-y"},
+		{"--assume-yes", "config", "upgrade"},
+	} {
+		t.Run(strings.Join(args, " "), func(t *testing.T) {
+			tempDir := t.TempDir()
+			configPath := filepath.Join(tempDir, "config.yaml")
+
+			cfg := config.DefaultExtended()
+			cfg.Directories.Package = "/test/yes-flag"
+			writer := config.NewWriter(configPath)
+			require.NoError(t, writer.Write(cfg, config.WriteOptions{Format: "yaml"}))
+			t.Setenv("DOT_CONFIG", configPath)
+			t.Setenv("XDG_CONFIG_HOME", tempDir)
+
+			rootCmd := NewRootCommand("dev", "none", "unknown")
+			rootCmd.SetArgs(args)
+			rootCmd.SetIn(iotest.ErrReader(errors.New("stdin must not be read with --yes")))
+			var out bytes.Buffer
+			rootCmd.SetOut(&out)
+
+			require.NoError(t, rootCmd.Execute())
+			assert.NotContains(t, out.String(), "Continue?")
+
+			loader := config.NewLoader("dot", configPath)
+			upgraded, err := loader.LoadWithEnv()
+			require.NoError(t, err)
+			assert.Equal(t, "/test/yes-flag", upgraded.Directories.Package)
+			entries, err := os.ReadDir(filepath.Join(tempDir, "dot", "backups"))
+			require.NoError(t, err)
+			assert.Len(t, entries, 1, "upgrade should have run and made a backup")
+		})
+	}
+}
+
+func TestConfigUpgrade_PromptsWithoutYes(t *testing.T) {
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "config.yaml")
 
-	t.Run("has --yes flag", func(t *testing.T) {
-		flag := cmd.Flags().Lookup("yes")
-		require.NotNil(t, flag, "--yes flag should exist")
-		assert.Equal(t, "y", flag.Shorthand)
-		assert.Equal(t, "false", flag.DefValue)
-	})
-
-	t.Run("--yes skips confirmation like --force", func(t *testing.T) {
-		tempDir := t.TempDir()
-		configPath := filepath.Join(tempDir, "config.yaml")
-
-		cfg := config.DefaultExtended()
-		cfg.Directories.Package = "/test/yes-flag"
-		writer := config.NewWriter(configPath)
-		require.NoError(t, writer.Write(cfg, config.WriteOptions{Format: "yaml"}))
-
-		originalEnv := os.Getenv("DOT_CONFIG")
-		os.Setenv("DOT_CONFIG", configPath)
-		defer os.Setenv("DOT_CONFIG", originalEnv)
-
-		yesCmd := newConfigUpgradeCommand()
-		yesCmd.SetArgs([]string{"--yes"})
-
-		err := yesCmd.Execute()
-		require.NoError(t, err)
-
-		loader := config.NewLoader("dot", configPath)
-		upgraded, err := loader.LoadWithEnv()
-		require.NoError(t, err)
-		assert.Equal(t, "/test/yes-flag", upgraded.Directories.Package)
-	})
-
-	t.Run("-y shorthand works", func(t *testing.T) {
-		tempDir := t.TempDir()
-		configPath := filepath.Join(tempDir, "config.yaml")
-
-		cfg := config.DefaultExtended()
-		cfg.Directories.Package = "/test/y-shorthand"
-		writer := config.NewWriter(configPath)
-		require.NoError(t, writer.Write(cfg, config.WriteOptions{Format: "yaml"}))
-
-		originalEnv := os.Getenv("DOT_CONFIG")
-		os.Setenv("DOT_CONFIG", configPath)
-		defer os.Setenv("DOT_CONFIG", originalEnv)
-
-		shortCmd := newConfigUpgradeCommand()
-		shortCmd.SetArgs([]string{"-y"})
-
-		err := shortCmd.Execute()
-		require.NoError(t, err)
-
-		loader := config.NewLoader("dot", configPath)
-		upgraded, err := loader.LoadWithEnv()
-		require.NoError(t, err)
-		assert.Equal(t, "/test/y-shorthand", upgraded.Directories.Package)
-	})
+	writer := config.NewWriter(configPath)
+	require.NoError(t, writer.Write(config.DefaultExtended(), config.WriteOptions{Format: "yaml"}))
+	t.Setenv("DOT_CONFIG", configPath)
+	t.Setenv("XDG_CONFIG_HOME", tempDir)
+
+	rootCmd := NewRootCommand("dev", "none", "unknown")
+	rootCmd.SetArgs([]string{"config", "upgrade"})
+	rootCmd.SetIn(strings.NewReader("n\n"))
+	var out bytes.Buffer
+	rootCmd.SetOut(&out)
+
+	require.NoError(t, rootCmd.Execute())
+	assert.Contains(t, out.String(), "Continue? [y/N]")
+	_, err := os.Stat(filepath.Join(tempDir, "dot", "backups"))
+	assert.True(t, os.IsNotExist(err), "declined upgrade must not touch the config")
 }
 
 func TestRunConfigUpgrade_Integration(t *testing.T) {
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/yaklabco/dot/internal/cli/render"
+	"github.com/yaklabco/dot/pkg/dot"
+)
+
+// newDiffCommand creates the diff command.
+func newDiffCommand() *cobra.Command {
+	var asJSON bool
+
+	cmd := &cobra.Command{
+		Use:   "diff PACKAGE",
+		Short: "Show drift between a package and its live links",
+		Long: `Compare the links a package declares against the target directory.
+
+Lines starting with + show what the package declares but the target lacks;
+lines starting with - show what the target holds instead. Reported drift:
+
+  missing       a declared link does not exist
+  wrong target  a symlink points somewhere other than the package file
+  not symlink   a regular file or directory sits where a link belongs
+  orphaned      a link recorded for the package that it no longer declares
+
+Run 'dot remanage PACKAGE' to bring the links back in line. For checks
+across every package, use 'dot doctor'.`,
+		Example: `  # Show drift for the vim package
+  dot diff vim
+
+  # Show drift as JSON
+  dot diff vim --json`,
+		Args:              argsWithUsage(cobra.ExactArgs(1)),
+		ValidArgsFunction: packageCompletion(false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDiff(cmd, args[0], asJSON)
+		},
+	}
+
+	cmd.Flags().BoolVar(&asJSON, "json", false, "Output drift as JSON")
+
+	return cmd
+}
+
+// runDiff handles the diff command.
+func runDiff(cmd *cobra.Command, pkg string, asJSON bool) error {
+	cfg, err := buildConfigWithCmd(cmd)
+	if err != nil {
+		return err
+	}
+	client, err := dot.NewClient(cfg)
+	if err != nil {
+		return formatError(err)
+	}
+
+	diff, err := client.Diff(cmd.Context(), pkg)
+	if err != nil {
+		return formatError(err)
+	}
+
+	if asJSON {
+		data, err := json.MarshalIndent(diff, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal diff: %w", err)
+		}
+		_, err = fmt.Fprintln(cmd.OutOrStdout(), string(data))
+		return err
+	}
+
+	renderPackageDiff(cmd.OutOrStdout(), diff, render.NewColorizer(shouldUseColor(), outputTheme()))
+	return nil
+}
+
+// renderPackageDiff writes drift as a diff: - lines show the live state
+// and + lines the state the package declares.
+func renderPackageDiff(w io.Writer, diff dot.PackageDiff, c *render.Colorizer) {
+	if !diff.HasDrift() {
+		fmt.Fprintf(w, "%s %s is in sync (%s)\n",
+			c.Success("✓"), diff.Package, formatCount(diff.InSync, "link", "links"))
+		return
+	}
+
+	fmt.Fprintf(w, "%s: %s differ, %d in sync\n",
+		c.Bold(diff.Package), formatCount(len(diff.Drift), "link", "links"), diff.InSync)
+	for _, d := range diff.Drift {
+		switch d.Kind {
+		case dot.DriftMissing:
+			fmt.Fprintf(w, "%s %s -> %s %s\n", c.Success("+"), d.Path, d.Expected, c.Dim("(missing)"))
+		case dot.DriftWrongTarget:
+			fmt.Fprintf(w, "%s %s -> %s\n", c.Error("-"), d.Path, d.Actual)
+			fmt.Fprintf(w, "%s %s -> %s %s\n", c.Success("+"), d.Path, d.Expected, c.Dim("(wrong target)"))
+		case dot.DriftNotSymlink:
+			fmt.Fprintf(w, "%s %s\n", c.Error("-"), d.Path)
+			fmt.Fprintf(w, "%s %s -> %s %s\n", c.Success("+"), d.Path, d.Expected, c.Dim("(not a symlink)"))
+		case dot.DriftOrphaned:
+			fmt.Fprintf(w, "%s %s -> %s %s\n", c.Error("-"), d.Path, d.Actual, c.Dim("(orphaned)"))
+		}
+	}
+}
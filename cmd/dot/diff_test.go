@@ -0,0 +1,75 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yaklabco/dot/internal/cli/render"
+	"github.com/yaklabco/dot/pkg/dot"
+)
+
+func TestNewDiffCommand(t *testing.T) {
+	cmd := newDiffCommand()
+
+	assert.Contains(t, cmd.Use, "diff")
+	assert.NotEmpty(t, cmd.Short)
+	assert.NotEmpty(t, cmd.Example)
+
+	jsonFlag := cmd.Flags().Lookup("json")
+	require.NotNil(t, jsonFlag)
+	assert.Equal(t, "false", jsonFlag.DefValue)
+}
+
+func TestDiffCommand_RequiresPackage(t *testing.T) {
+	cmd := newDiffCommand()
+	cmd.SetArgs([]string{})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+
+	require.Error(t, cmd.Execute())
+}
+
+func TestRenderPackageDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		diff dot.PackageDiff
+		want string
+	}{
+		{
+			name: "in sync",
+			diff: dot.PackageDiff{Package: "vim", InSync: 2},
+			want: "✓ vim is in sync (2 links)\n",
+		},
+		{
+			name: "drift",
+			diff: dot.PackageDiff{
+				Package: "vim",
+				InSync:  1,
+				Drift: []dot.Drift{
+					{Kind: dot.DriftNotSymlink, Path: ".exrc", Expected: "/pkg/vim/dot-exrc"},
+					{Kind: dot.DriftWrongTarget, Path: ".gvimrc", Expected: "/pkg/vim/dot-gvimrc", Actual: "/elsewhere"},
+					{Kind: dot.DriftOrphaned, Path: ".viminfo", Actual: "/pkg/vim/dot-viminfo"},
+					{Kind: dot.DriftMissing, Path: ".vimrc", Expected: "/pkg/vim/dot-vimrc"},
+				},
+			},
+			want: "vim: 4 links differ, 1 in sync\n" +
+				"- .exrc\n" +
+				"+ .exrc -> /pkg/vim/dot-exrc (not a symlink)\n" +
+				"- .gvimrc -> /elsewhere\n" +
+				"+ .gvimrc -> /pkg/vim/dot-gvimrc (wrong target)\n" +
+				"- .viminfo -> /pkg/vim/dot-viminfo (orphaned)\n" +
+				"+ .vimrc -> /pkg/vim/dot-vimrc (missing)\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			renderPackageDiff(&buf, tt.diff, render.NewColorizer(false, render.DefaultTheme()))
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
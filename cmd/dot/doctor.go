@@ -5,12 +5,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"path/filepath"
 	"strings"
 
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
 
 	"github.com/yaklabco/dot/internal/cli/pretty"
+	"github.com/yaklabco/dot/internal/cli/prompt"
 	"github.com/yaklabco/dot/internal/cli/render"
 	"github.com/yaklabco/dot/internal/cli/renderer"
 	"github.com/yaklabco/dot/pkg/dot"
@@ -18,16 +20,7 @@ import (
 
 // DoctorExitCode returns the exit code for a given health status.
 func DoctorExitCode(status dot.HealthStatus) int {
-	switch status {
-	case dot.HealthOK:
-		return 0
-	case dot.HealthWarnings:
-		return 1
-	case dot.HealthErrors:
-		return 2
-	default:
-		return 0
-	}
+	return status.ExitCode()
 }
 
 // doctorFlags holds parsed flags.
@@ -35,6 +28,10 @@ type doctorFlags struct {
 	format, color, scanMode, mode string
 	maxDepth                      int
 	triage, autoIgnore, detailed  bool
+	fix, yes                      bool
+	rules                         string
+	watch                         bool
+	explain                       string
 }
 
 // parseDoctorFlags extracts flags from command.
@@ -47,7 +44,12 @@ func parseDoctorFlags(cmd *cobra.Command) doctorFlags {
 	autoIgnore, _ := cmd.Flags().GetBool("auto-ignore")
 	mode, _ := cmd.Flags().GetString("mode")
 	detailed, _ := cmd.Flags().GetBool("detailed")
-	return doctorFlags{format, color, scanMode, mode, maxDepth, triage, autoIgnore, detailed}
+	fix, _ := cmd.Flags().GetBool("fix")
+	yes := prompt.AssumeYes(cmd.Context())
+	rules, _ := cmd.Flags().GetString("rules")
+	watch, _ := cmd.Flags().GetBool("watch")
+	explain, _ := cmd.Flags().GetString("explain")
+	return doctorFlags{format, color, scanMode, mode, maxDepth, triage, autoIgnore, detailed, fix, yes, rules, watch, explain}
 }
 
 // buildScanConfig creates scan configuration from flags.
@@ -82,7 +84,7 @@ func parseDoctorMode(mode string) (dot.DiagnosticMode, error) {
 }
 
 // renderDoctorOutput renders the report.
-func renderDoctorOutput(cmd *cobra.Command, report dot.DiagnosticReport, flags doctorFlags, extCfg *dot.ExtendedConfig) error {
+func renderDoctorOutput(cmd *cobra.Command, client *dot.Client, report dot.DiagnosticReport, flags doctorFlags, extCfg *dot.ExtendedConfig) error {
 	colorize := shouldColorize(flags.color)
 	tableStyle := ""
 	if extCfg != nil {
@@ -91,9 +93,7 @@ func renderDoctorOutput(cmd *cobra.Command, report dot.DiagnosticReport, flags d
 
 	switch flags.format {
 	case "json":
-		enc := json.NewEncoder(cmd.OutOrStdout())
-		enc.SetIndent("", "  ")
-		return enc.Encode(report)
+		return renderDoctorJSON(cmd.OutOrStdout(), client.DoctorStructuredReport(cmd.Context(), report))
 	case "yaml":
 		return yaml.NewEncoder(cmd.OutOrStdout()).Encode(report)
 	case "text", "table":
@@ -106,7 +106,12 @@ func renderDoctorOutput(cmd *cobra.Command, report dot.DiagnosticReport, flags d
 		} else {
 			renderSuccinctDiagnostics(&buf, report, colorize, tableStyle)
 		}
-		pager := pretty.NewPager(pretty.PagerConfig{PageSize: 0, Output: cmd.OutOrStdout()})
+		// Watch mode reprints the report on every change, so it cannot page
+		if flags.watch {
+			_, err := buf.WriteTo(cmd.OutOrStdout())
+			return err
+		}
+		pager := pretty.NewPager(pretty.PagerConfig{PageSize: 0, Output: cmd.OutOrStdout(), Width: outputWidth()})
 		return pager.PageLines(strings.Split(buf.String(), "\n"))
 	default:
 		r, err := renderer.NewRenderer(flags.format, colorize, tableStyle)
@@ -117,6 +122,13 @@ func renderDoctorOutput(cmd *cobra.Command, report dot.DiagnosticReport, flags d
 	}
 }
 
+// renderDoctorJSON writes the structured report as indented JSON.
+func renderDoctorJSON(w io.Writer, report dot.StructuredReport) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
+
 // storeDoctorStatus stores the health status in the context for exit code determination.
 // The result is stored in the DoctorResultHolder from the context.
 func storeDoctorStatus(cmd *cobra.Command, report dot.DiagnosticReport) {
@@ -132,12 +144,17 @@ func newDoctorCommand() *cobra.Command {
 	cmd.AddCommand(newDoctorIgnoreCommand(), newDoctorUnignoreCommand(), newDoctorIgnoresCommand())
 
 	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		flags := parseDoctorFlags(cmd)
+		// Explanations need no configuration or package directory
+		if flags.explain != "" {
+			return runDoctorExplain(cmd, flags)
+		}
+
 		cfg, err := buildConfigWithCmd(cmd)
 		if err != nil {
 			return err
 		}
 
-		flags := parseDoctorFlags(cmd)
 		client, err := dot.NewClient(cfg)
 		if err != nil {
 			return formatError(err)
@@ -148,8 +165,27 @@ func newDoctorCommand() *cobra.Command {
 			return err
 		}
 
-		if flags.triage {
-			return runTriage(cmd, client, scanCfg, flags.autoIgnore)
+		if flags.watch && (flags.fix || flags.triage || flags.rules != "") {
+			return fmt.Errorf("--watch cannot be combined with --fix, --triage or --rules")
+		}
+
+		if flags.triage || flags.rules != "" {
+			return runTriage(cmd, client, scanCfg, flags)
+		}
+
+		configPath := getConfigFilePath()
+		extCfg, _ := loadConfigWithRepoPriority(GetCLIFlags().packageDir, configPath)
+
+		if flags.watch {
+			return runDoctorWatch(cmd, client, scanCfg, flags, extCfg)
+		}
+
+		if flags.fix {
+			return runDoctorFix(cmd, client, scanCfg, flags.yes)
+		}
+		// doctor.auto_fix applies fixes without prompting
+		if extCfg != nil && extCfg.Doctor.AutoFix {
+			return runDoctorFix(cmd, client, scanCfg, true)
 		}
 
 		doctorMode, err := parseDoctorMode(flags.mode)
@@ -162,10 +198,7 @@ func newDoctorCommand() *cobra.Command {
 			return formatError(err)
 		}
 
-		configPath := getConfigFilePath()
-		extCfg, _ := loadConfigWithRepoPriority(GetCLIFlags().packageDir, configPath)
-
-		if err := renderDoctorOutput(cmd, report, flags, extCfg); err != nil {
+		if err := renderDoctorOutput(cmd, client, report, flags, extCfg); err != nil {
 			return err
 		}
 
@@ -177,9 +210,30 @@ func newDoctorCommand() *cobra.Command {
 	return cmd
 }
 
+// runDoctorExplain prints the remediation guide for one issue type.
+func runDoctorExplain(cmd *cobra.Command, flags doctorFlags) error {
+	issueType, err := dot.ParseIssueType(flags.explain)
+	if err != nil {
+		return err
+	}
+
+	c := render.NewColorizer(shouldColorize(flags.color), outputTheme())
+	fmt.Fprintln(cmd.OutOrStdout(), render.NewLayout(outputWidth()).Markdown(issueType.Explanation(), c))
+	return nil
+}
+
+// issueTypeCompletion completes issue type names for --explain.
+func issueTypeCompletion(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	var names []string
+	for _, t := range dot.IssueTypes() {
+		names = append(names, t.String())
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
 // renderVerboseDiagnostics outputs detailed diagnostics with all issue information.
 func renderVerboseDiagnostics(w io.Writer, report dot.DiagnosticReport, colorize bool) {
-	c := render.NewColorizer(colorize)
+	c := render.NewColorizer(colorize, outputTheme())
 
 	// Health status header
 	healthIcon, healthText, healthColor := getHealthDisplay(report.OverallHealth, c)
@@ -191,8 +245,14 @@ func renderVerboseDiagnostics(w io.Writer, report dot.DiagnosticReport, colorize
 		fmt.Fprintf(w, "Statistics:\n")
 		fmt.Fprintf(w, "  Total links: %d\n", report.Statistics.TotalLinks)
 		fmt.Fprintf(w, "  Managed links: %d\n", report.Statistics.ManagedLinks)
+		if report.Statistics.Junctions > 0 {
+			fmt.Fprintf(w, "  Junctions: %d\n", report.Statistics.Junctions)
+		}
 		fmt.Fprintf(w, "  Broken links: %d\n", report.Statistics.BrokenLinks)
 		fmt.Fprintf(w, "  Orphaned links: %d\n", report.Statistics.OrphanedLinks)
+		if report.Statistics.OwnershipConflicts > 0 {
+			fmt.Fprintf(w, "  Ownership conflicts: %d\n", report.Statistics.OwnershipConflicts)
+		}
 		fmt.Fprintf(w, "\n")
 	}
 
@@ -258,7 +318,7 @@ func getSeverityIcon(severity dot.IssueSeverity, c *render.Colorizer) string {
 
 // renderSuccinctDiagnostics outputs diagnostics in a succinct, colorized format.
 func renderSuccinctDiagnostics(w io.Writer, report dot.DiagnosticReport, colorize bool, tableStyle string) {
-	c := render.NewColorizer(colorize)
+	c := render.NewColorizer(colorize, outputTheme())
 
 	// Health status header
 	healthIcon, healthText, healthColor := getHealthDisplay(report.OverallHealth, c)
@@ -278,6 +338,12 @@ func renderSuccinctDiagnostics(w io.Writer, report dot.DiagnosticReport, coloriz
 				report.Statistics.OrphanedLinks)),
 		)
 	}
+	if report.Statistics.Junctions > 0 {
+		fmt.Fprintf(w, "  %s %s\n",
+			c.Dim("•"),
+			c.Dim(fmt.Sprintf("%d managed links are directory junctions", report.Statistics.Junctions)),
+		)
+	}
 
 	// Issues grouped by severity
 	errors := filterIssuesBySeverity(report.Issues, dot.SeverityError)
@@ -353,10 +419,19 @@ func renderIssueList(w io.Writer, issues []dot.Issue, c *render.Colorizer) {
 	}
 }
 
-// runTriage executes interactive triage mode.
-func runTriage(cmd *cobra.Command, client *dot.Client, scanCfg dot.ScanConfig, autoIgnore bool) error {
+// runTriage executes triage mode, interactively or from a rules file.
+func runTriage(cmd *cobra.Command, client *dot.Client, scanCfg dot.ScanConfig, flags doctorFlags) error {
 	triageOpts := dot.TriageOptions{
-		AutoIgnoreHighConfidence: autoIgnore,
+		AutoIgnoreHighConfidence: flags.autoIgnore,
+		AutoConfirm:              flags.yes,
+		DryRun:                   GetCLIFlags().dryRun,
+	}
+	if flags.rules != "" {
+		rulesFile, err := filepath.Abs(flags.rules)
+		if err != nil {
+			return fmt.Errorf("invalid rules file: %w", err)
+		}
+		triageOpts.RulesFile = rulesFile
 	}
 
 	result, err := client.Triage(cmd.Context(), scanCfg, triageOpts)
@@ -370,10 +445,60 @@ func runTriage(cmd *cobra.Command, client *dot.Client, scanCfg dot.ScanConfig, a
 	return nil
 }
 
+// runDoctorFix repairs issues found by doctor and reports the outcome.
+func runDoctorFix(cmd *cobra.Command, client *dot.Client, scanCfg dot.ScanConfig, yes bool) error {
+	result, err := client.DoctorFix(cmd.Context(), scanCfg, dot.FixOptions{
+		AutoConfirm: yes,
+		Interactive: !yes,
+	})
+	if err != nil {
+		return formatError(err)
+	}
+
+	renderFixResults(cmd.OutOrStdout(), result)
+	return nil
+}
+
+// renderFixResults displays the fix operation results.
+func renderFixResults(w io.Writer, result dot.FixResult) {
+	c := render.NewColorizer(shouldUseColor(), outputTheme())
+
+	fmt.Fprintln(w)
+	if len(result.Fixed) == 0 && len(result.Skipped) == 0 && len(result.Reported) == 0 && len(result.Errors) == 0 {
+		fmt.Fprintln(w, c.Dim("No fixable issues found"))
+		return
+	}
+
+	if len(result.Fixed) > 0 {
+		fmt.Fprintf(w, "%s %s fixed:\n", c.Success("✓"), formatCount(len(result.Fixed), "issue", "issues"))
+		for _, path := range result.Fixed {
+			fmt.Fprintf(w, "  %s\n", c.Dim(path))
+		}
+	}
+
+	if len(result.Skipped) > 0 {
+		fmt.Fprintf(w, "%s %s skipped\n", c.Dim("•"), formatCount(len(result.Skipped), "issue", "issues"))
+	}
+
+	if len(result.Reported) > 0 {
+		fmt.Fprintf(w, "%s %s not managed by dot (left untouched):\n", c.Warning("!"), formatCount(len(result.Reported), "broken link", "broken links"))
+		for _, path := range result.Reported {
+			fmt.Fprintf(w, "  %s\n", c.Dim(path))
+		}
+	}
+
+	if len(result.Errors) > 0 {
+		fmt.Fprintf(w, "%s %s:\n", c.Error("✗"), formatCount(len(result.Errors), "error", "errors"))
+		for path, err := range result.Errors {
+			fmt.Fprintf(w, "  %s %s %s\n", c.Bold(path), c.Dim("—"), c.Error(err.Error()))
+		}
+	}
+}
+
 // renderTriageResults displays the triage operation results.
 func renderTriageResults(w io.Writer, result dot.TriageResult) {
 	colorize := shouldUseColor()
-	c := render.NewColorizer(colorize)
+	c := render.NewColorizer(colorize, outputTheme())
 
 	fmt.Fprintln(w)
 	fmt.Fprintln(w, c.Success("Triage Complete"))
@@ -438,6 +563,7 @@ Checks for:
   - Broken unmanaged symlinks (orphaned links with non-existent targets)
   - Permission issues
   - Manifest inconsistencies
+  - Link ownership conflicts (one target link claimed by several packages)
 
 Orphan Detection:
   By default, doctor uses scoped scanning to find unmanaged symlinks in
@@ -453,6 +579,34 @@ Triage Mode:
   individually. This is useful for cleaning up after uninstalling packages or
   managing symlinks created by other tools.
 
+  Use --rules FILE to triage without prompts, for example in CI. Each rule
+  matches orphans by category and/or link path pattern and applies ignore,
+  adopt or skip; the first matching rule wins and unmatched orphans are
+  skipped. Add --yes to save the changes without confirmation.
+
+Fix Mode:
+  Use --fix to repair issues. Managed broken links are recreated from their
+  package source, or removed from disk and the manifest when the source no
+  longer exists. Managed links pointing at a different file than their
+  recorded source are replaced in a single plan that rolls back if any
+  replacement fails. Broken links not managed by dot are reported but left
+  untouched. For each link ownership conflict you choose which package keeps
+  the link; the others drop it from the manifest. With --yes, fixes are
+  applied without prompting and conflicts keep the package the link
+  currently points to. Setting doctor.auto_fix in the configuration behaves
+  like --fix --yes.
+
+Explaining Issues:
+  Use --explain TYPE to print what an issue type means, its common causes
+  and how to fix it. TYPE is the issue type shown in reports, such as
+  broken_link or manifest_corrupt. No checks are run.
+
+Watch Mode:
+  Use --watch to keep doctor running while you reorganize your dotfiles. The
+  report is reprinted whenever the package directory or a directory holding
+  managed links changes; bursts of changes, such as an editor save, trigger a
+  single rerun. Press Ctrl-C to exit.
+
 Exit codes:
   0 - Healthy (no issues found)
   1 - Warnings detected (e.g., orphaned links)
@@ -469,6 +623,18 @@ Exit codes:
   # Interactive triage mode for orphaned symlinks
   dot doctor --triage
 
+  # Non-interactive triage from a rules file
+  dot doctor --rules triage-rules.yaml --yes
+
+  # Repair broken links and resolve ownership conflicts
+  dot doctor --fix
+
+  # Re-run checks whenever dotfiles change
+  dot doctor --watch
+
+  # Explain an issue type and how to fix it
+  dot doctor --explain broken_link
+
   # Run health check with JSON output
   dot doctor --format=json
 
@@ -488,6 +654,11 @@ Exit codes:
 	cmd.Flags().Bool("auto-ignore", false, "Automatically ignore high-confidence categories in triage mode")
 	cmd.Flags().String("mode", "fast", "Diagnostic mode (fast, deep)")
 	cmd.Flags().Bool("detailed", false, "Show detailed diagnostic output")
+	cmd.Flags().Bool("fix", false, "Repair broken links and resolve link ownership conflicts")
+	cmd.Flags().String("rules", "", "Triage orphaned symlinks non-interactively using a YAML rules file")
+	cmd.Flags().Bool("watch", false, "Re-run checks when the package or target directories change")
+	cmd.Flags().String("explain", "", "Explain an issue type and how to fix it, then exit")
+	_ = cmd.RegisterFlagCompletionFunc("explain", issueTypeCompletion)
 
 	return cmd
 }
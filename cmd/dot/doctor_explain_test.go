@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDoctorExplain_PrintsGuide(t *testing.T) {
+	setupGlobalCfg(t)
+
+	tests := []struct {
+		name string
+		arg  string
+		want string
+	}{
+		{"broken link", "broken_link", "dot doctor --fix"},
+		{"dashed name", "link-ownership-conflict", "Two or more packages claim"},
+		{"manifest corrupt", "manifest_corrupt", "checksum"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rootCmd := NewRootCommand("dev", "none", "unknown")
+			// No package or target directory is needed to explain an issue
+			rootCmd.SetArgs([]string{"doctor", "--explain", tt.arg, "--color", "never", "--dir", "/nonexistent"})
+			out := &bytes.Buffer{}
+			rootCmd.SetOut(out)
+
+			require.NoError(t, rootCmd.Execute())
+			assert.Contains(t, out.String(), tt.want)
+			assert.NotContains(t, out.String(), "```")
+			assert.NotContains(t, out.String(), "\x1b[")
+		})
+	}
+}
+
+func TestDoctorExplain_UnknownType(t *testing.T) {
+	setupGlobalCfg(t)
+
+	rootCmd := NewRootCommand("dev", "none", "unknown")
+	rootCmd.SetArgs([]string{"doctor", "--explain", "bogus"})
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+
+	err := rootCmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown issue type")
+	assert.Contains(t, err.Error(), "orphaned_link")
+}
+
+func TestDoctorExplain_Completion(t *testing.T) {
+	setupGlobalCfg(t)
+	cmd := newDoctorCommand()
+
+	complete, ok := cmd.GetFlagCompletionFunc("explain")
+	require.True(t, ok)
+	names, directive := complete(cmd, nil, "")
+	assert.Contains(t, names, "broken_link")
+	assert.Contains(t, names, "link_ownership_conflict")
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+}
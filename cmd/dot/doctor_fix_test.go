@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yaklabco/dot/pkg/dot"
+)
+
+func TestDoctorCommand_HasFixFlags(t *testing.T) {
+	cmd := NewDoctorCommand(&dot.Config{})
+
+	assert.NotNil(t, cmd.Flags().Lookup("fix"), "--fix flag should exist")
+	assert.Nil(t, cmd.Flags().Lookup("yes"), "--yes is a global flag")
+}
+
+func TestDoctorCommand_HasRulesFlag(t *testing.T) {
+	cmd := NewDoctorCommand(&dot.Config{})
+
+	rules := cmd.Flags().Lookup("rules")
+	if assert.NotNil(t, rules, "--rules flag should exist") {
+		assert.Empty(t, rules.DefValue)
+	}
+}
+
+func TestRenderFixResults(t *testing.T) {
+	tests := []struct {
+		name     string
+		result   dot.FixResult
+		contains []string
+		excludes []string
+	}{
+		{
+			name:     "nothing to fix",
+			result:   dot.FixResult{},
+			contains: []string{"No fixable issues found"},
+		},
+		{
+			name: "fixed and reported",
+			result: dot.FixResult{
+				Fixed:    []string{".vimrc"},
+				Reported: []string{".stray"},
+			},
+			contains: []string{"1 issue fixed", ".vimrc", "1 broken link not managed by dot", ".stray"},
+			excludes: []string{"No fixable issues found", "skipped"},
+		},
+		{
+			name: "skipped and errors",
+			result: dot.FixResult{
+				Skipped: []string{".bashrc", ".zshrc"},
+				Errors:  map[string]error{".gitconfig": errors.New("permission denied")},
+			},
+			contains: []string{"2 issues skipped", "1 error", ".gitconfig", "permission denied"},
+			excludes: []string{"fixed:"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			renderFixResults(&buf, tt.result)
+			out := buf.String()
+			for _, s := range tt.contains {
+				assert.Contains(t, out, s)
+			}
+			for _, s := range tt.excludes {
+				assert.NotContains(t, out, s)
+			}
+		})
+	}
+}
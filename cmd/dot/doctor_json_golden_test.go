@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaklabco/dot/internal/adapters"
+	"github.com/yaklabco/dot/internal/cli/golden"
+	"github.com/yaklabco/dot/pkg/dot"
+)
+
+// TestDoctorJSON_Golden checks the JSON report shape for a mix of broken and
+// orphaned links.
+func TestDoctorJSON_Golden(t *testing.T) {
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	require.NoError(t, fs.MkdirAll(ctx, "/dotfiles/vim", 0o755))
+	require.NoError(t, fs.MkdirAll(ctx, "/home/.npm/bin", 0o755))
+	require.NoError(t, fs.WriteFile(ctx, "/dotfiles/vim/dot-vimrc", []byte("set nu"), 0o644))
+	require.NoError(t, fs.WriteFile(ctx, "/dotfiles/vim/dot-gvimrc", []byte("set go="), 0o644))
+	require.NoError(t, fs.WriteFile(ctx, "/home/.npm/bin/tool", []byte("#!/bin/sh"), 0o755))
+
+	client, err := dot.NewClient(dot.Config{
+		PackageDir: "/dotfiles",
+		TargetDir:  "/home",
+		FS:         fs,
+		Logger:     adapters.NewNoopLogger(),
+	})
+	require.NoError(t, err)
+	require.NoError(t, client.Manage(ctx, "vim"))
+
+	// Managed link whose source was deleted
+	require.NoError(t, fs.Remove(ctx, "/dotfiles/vim/dot-gvimrc"))
+	// Unmanaged links: one into a known tool directory, one dangling
+	require.NoError(t, fs.Symlink(ctx, "/home/.npm/bin/tool", "/home/tool"))
+	require.NoError(t, fs.Symlink(ctx, "/nowhere/config", "/home/.stray"))
+
+	report, err := client.DoctorWithMode(ctx, dot.DiagnosticFast, dot.DefaultScanConfig())
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, renderDoctorJSON(&buf, client.DoctorStructuredReport(ctx, report)))
+
+	golden.New(t, "doctor").Assert("doctor_json_mixed", buf.Bytes())
+
+	var decoded struct {
+		Status   string `json:"status"`
+		ExitCode int    `json:"exit_code"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, report.OverallHealth.String(), decoded.Status)
+	assert.Equal(t, DoctorExitCode(report.OverallHealth), decoded.ExitCode)
+	assert.Equal(t, 2, decoded.ExitCode)
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/yaklabco/dot/internal/cli/terminal"
+	"github.com/yaklabco/dot/internal/cli/watch"
+	"github.com/yaklabco/dot/pkg/dot"
+)
+
+// newDoctorWatcher creates the filesystem watcher for doctor --watch.
+// Tests replace it with a fake.
+var newDoctorWatcher = watch.NewFSWatcher
+
+// clearScreen moves the cursor home and clears the terminal.
+const clearScreen = "\033[H\033[2J"
+
+// runDoctorWatch runs doctor, then re-runs it whenever the package directory
+// or a directory holding managed links changes, until the command context
+// is cancelled.
+func runDoctorWatch(cmd *cobra.Command, client *dot.Client, scanCfg dot.ScanConfig, flags doctorFlags, extCfg *dot.ExtendedConfig) error {
+	doctorMode, err := parseDoctorMode(flags.mode)
+	if err != nil {
+		return err
+	}
+
+	w, err := newDoctorWatcher()
+	if err != nil {
+		return err
+	}
+	defer w.Close()
+
+	if err := addDoctorWatches(cmd.Context(), w, client); err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	clearOnRerun := terminal.IsInteractive()
+
+	return watch.Run(cmd.Context(), w, watch.DefaultDelay, func(ctx context.Context) {
+		report, err := client.DoctorWithMode(ctx, doctorMode, scanCfg)
+		if ctx.Err() != nil {
+			return
+		}
+		if clearOnRerun {
+			fmt.Fprint(out, clearScreen)
+		}
+		if err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", formatError(err))
+		} else {
+			if err := renderDoctorOutput(cmd, client, report, flags, extCfg); err != nil {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+			}
+			storeDoctorStatus(cmd, report)
+		}
+		fmt.Fprintln(out, "\nWatching for changes (Ctrl-C to exit)...")
+	})
+}
+
+// addDoctorWatches watches the package directory tree, the target directory
+// and every directory that holds a managed link. The target directory is not
+// watched recursively because it is usually the home directory.
+func addDoctorWatches(ctx context.Context, w watch.Watcher, client *dot.Client) error {
+	cfg := client.Config()
+
+	if err := watch.AddTree(w, cfg.PackageDir, func(name string) bool { return name == ".git" }); err != nil {
+		return fmt.Errorf("watch package directory: %w", err)
+	}
+
+	dirs := map[string]bool{cfg.TargetDir: true}
+	status, err := client.Status(ctx)
+	if err != nil {
+		return formatError(err)
+	}
+	for _, pkg := range status.Packages {
+		for _, link := range pkg.Links {
+			dirs[filepath.Dir(filepath.Join(cfg.TargetDir, link))] = true
+		}
+	}
+
+	for dir := range dirs {
+		// Directories that no longer exist show up as broken links instead
+		if err := w.Add(dir); err != nil && dir == cfg.TargetDir {
+			return fmt.Errorf("watch target directory: %w", err)
+		}
+	}
+	return nil
+}
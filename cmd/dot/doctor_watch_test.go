@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yaklabco/dot/internal/cli/watch"
+)
+
+// fakeWatcher is a watch.Watcher whose events are sent by the test.
+type fakeWatcher struct {
+	mu     sync.Mutex
+	paths  []string
+	events chan string
+	errs   chan error
+}
+
+func (f *fakeWatcher) Add(path string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.paths = append(f.paths, path)
+	return nil
+}
+
+func (f *fakeWatcher) Events() <-chan string { return f.events }
+func (f *fakeWatcher) Errors() <-chan error  { return f.errs }
+func (f *fakeWatcher) Close() error          { return nil }
+
+// syncBuffer is a bytes.Buffer safe for concurrent use.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestDoctorCommand_HasWatchFlag(t *testing.T) {
+	setupGlobalCfg(t)
+	cmd := newDoctorCommand()
+	assert.NotNil(t, cmd.Flags().Lookup("watch"), "--watch flag should exist")
+}
+
+func TestDoctorWatch_RerunsOnChange(t *testing.T) {
+	setupGlobalCfg(t)
+
+	packageDir := t.TempDir()
+	targetDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(packageDir, "vim"), 0755))
+
+	fake := &fakeWatcher{events: make(chan string, 1), errs: make(chan error)}
+	previous := newDoctorWatcher
+	newDoctorWatcher = func() (watch.Watcher, error) { return fake, nil }
+	t.Cleanup(func() { newDoctorWatcher = previous })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rootCmd := NewRootCommand("dev", "none", "unknown")
+	rootCmd.SetArgs([]string{"doctor", "--watch", "--dir", packageDir, "--target", targetDir, "--scan-mode", "off"})
+	out := &syncBuffer{}
+	rootCmd.SetOut(out)
+	rootCmd.SetErr(out)
+
+	done := make(chan error, 1)
+	go func() { done <- rootCmd.ExecuteContext(ctx) }()
+
+	waitForReports := func(n int) {
+		t.Helper()
+		require.Eventually(t, func() bool {
+			return strings.Count(out.String(), "Watching for changes") >= n
+		}, 5*time.Second, 10*time.Millisecond)
+	}
+
+	waitForReports(1)
+	fake.events <- filepath.Join(packageDir, "vim")
+	waitForReports(2)
+
+	cancel()
+	select {
+	case err := <-done:
+		require.NoError(t, err, "Ctrl-C should exit cleanly")
+	case <-time.After(5 * time.Second):
+		t.Fatal("doctor --watch did not exit after cancel")
+	}
+
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	assert.Contains(t, fake.paths, packageDir)
+	assert.Contains(t, fake.paths, filepath.Join(packageDir, "vim"))
+	assert.Contains(t, fake.paths, targetDir)
+}
+
+func TestDoctorWatch_RejectsFix(t *testing.T) {
+	setupGlobalCfg(t)
+
+	rootCmd := NewRootCommand("dev", "none", "unknown")
+	rootCmd.SetArgs([]string{"doctor", "--watch", "--fix"})
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+
+	err := rootCmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--watch cannot be combined")
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"os"
@@ -10,6 +11,10 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/yaklabco/dot/internal/adapters"
+	"github.com/yaklabco/dot/internal/cli/render"
+	"github.com/yaklabco/dot/pkg/dot"
 )
 
 // setupHelpersTestFlags sets up cliFlags and cliContext for a test.
@@ -87,21 +92,12 @@ func TestShouldColorizeWithNoColorFlag(t *testing.T) {
 		assert.False(t, result, "--no-color should disable colors")
 	})
 
-	t.Run("NO_COLOR env takes precedence over --color=always when --no-color is false", func(t *testing.T) {
+	t.Run("--color=always takes precedence over NO_COLOR env", func(t *testing.T) {
 		setupHelpersTestFlags(t, CLIFlags{noColor: false})
-
-		original := os.Getenv("NO_COLOR")
-		os.Setenv("NO_COLOR", "1")
-		t.Cleanup(func() {
-			if original == "" {
-				os.Unsetenv("NO_COLOR")
-			} else {
-				os.Setenv("NO_COLOR", original)
-			}
-		})
+		t.Setenv("NO_COLOR", "1")
 
 		result := shouldColorize("always")
-		assert.False(t, result, "NO_COLOR should disable colors when --no-color is not set")
+		assert.True(t, result, "an explicit --color=always should win over NO_COLOR")
 	})
 
 	t.Run("colors enabled when neither flag nor env set", func(t *testing.T) {
@@ -120,6 +116,73 @@ func TestShouldColorizeWithNoColorFlag(t *testing.T) {
 	})
 }
 
+func TestShouldColorizeWithFlags_Env(t *testing.T) {
+	tests := []struct {
+		name    string
+		noColor bool
+		color   string
+		env     map[string]string
+		want    bool
+	}{
+		// Tests do not run on a terminal, so auto detection is off
+		{"no env", false, "auto", nil, false},
+		{"NO_COLOR", false, "auto", map[string]string{"NO_COLOR": "1"}, false},
+		{"FORCE_COLOR", false, "auto", map[string]string{"FORCE_COLOR": "1"}, true},
+		{"CLICOLOR_FORCE", false, "auto", map[string]string{"CLICOLOR_FORCE": "1"}, true},
+		{"FORCE_COLOR=0 does not force", false, "auto", map[string]string{"FORCE_COLOR": "0"}, false},
+		{"FORCE_COLOR over NO_COLOR", false, "auto", map[string]string{"FORCE_COLOR": "1", "NO_COLOR": "1"}, true},
+		{"CLICOLOR_FORCE over NO_COLOR", false, "auto", map[string]string{"CLICOLOR_FORCE": "1", "NO_COLOR": "1"}, true},
+		{"--color=always over NO_COLOR", false, "always", map[string]string{"NO_COLOR": "1"}, true},
+		{"--color=never over FORCE_COLOR", false, "never", map[string]string{"FORCE_COLOR": "1"}, false},
+		{"--no-color over FORCE_COLOR", true, "auto", map[string]string{"FORCE_COLOR": "1"}, false},
+		{"--no-color over --color=always", true, "always", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range []string{"FORCE_COLOR", "CLICOLOR_FORCE", "NO_COLOR"} {
+				t.Setenv(name, tt.env[name])
+			}
+
+			got := shouldColorizeWithFlags(&CLIFlags{noColor: tt.noColor}, tt.color)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestShouldColorize_OutputColorConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		flag   string
+		env    map[string]string
+		want   bool
+	}{
+		{"always over NO_COLOR", "always", "auto", map[string]string{"NO_COLOR": "1"}, true},
+		{"never over FORCE_COLOR", "never", "auto", map[string]string{"FORCE_COLOR": "1"}, false},
+		{"auto defers to FORCE_COLOR", "auto", "auto", map[string]string{"FORCE_COLOR": "1"}, true},
+		{"--color=never over config always", "always", "never", nil, false},
+		{"--color=always over config never", "never", "always", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupHelpersTestFlags(t, CLIFlags{})
+			for _, name := range []string{"FORCE_COLOR", "CLICOLOR_FORCE", "NO_COLOR"} {
+				t.Setenv(name, tt.env[name])
+			}
+			previous := activeOutput
+			activeOutput = &outputSettings{theme: render.DefaultTheme(), color: tt.config}
+			t.Cleanup(func() { activeOutput = previous })
+
+			assert.Equal(t, tt.want, shouldColorize(tt.flag))
+			if tt.flag == "auto" {
+				assert.Equal(t, tt.want, shouldUseColor(), "commands without --color follow the same order")
+			}
+		})
+	}
+}
+
 func TestShouldColorize_Auto(t *testing.T) {
 	// Auto detection depends on if stdout is a TTY
 	// In tests, it's typically not a TTY, so should be false
@@ -166,6 +229,43 @@ func TestCreateLogger_AllModes(t *testing.T) {
 	}
 }
 
+func TestConfigureLogDestination_FormatOnStderr(t *testing.T) {
+	stderr, err := os.CreateTemp(t.TempDir(), "stderr")
+	require.NoError(t, err)
+	defer stderr.Close()
+	original := os.Stderr
+	os.Stderr = stderr
+	t.Cleanup(func() { os.Stderr = original })
+
+	extCfg := dot.DefaultExtendedConfig()
+	extCfg.Logging.Destination = "stderr"
+	extCfg.Logging.Format = "json"
+
+	flags := &CLIFlags{verbose: 1}
+	logger := configureLogDestination(flags, extCfg, createLoggerWithFlags(flags))
+	logger.Info(context.Background(), "configured")
+
+	data, err := os.ReadFile(stderr.Name())
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"msg":"configured"`)
+}
+
+func TestConfigureLogDestination_RedactPaths(t *testing.T) {
+	extCfg := dot.DefaultExtendedConfig()
+	extCfg.Logging.Destination = "file"
+	extCfg.Logging.File = filepath.Join(t.TempDir(), "dot.log")
+	extCfg.Logging.RedactPaths = []string{"*.pem"}
+
+	flags := &CLIFlags{verbose: 1}
+	logger := configureLogDestination(flags, extCfg, createLoggerWithFlags(flags))
+	logger.Info(context.Background(), "loaded", "path", "/home/user/certs/client.pem")
+
+	data, err := os.ReadFile(extCfg.Logging.File)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "client.pem")
+	assert.Contains(t, string(data), "path=***")
+}
+
 func TestIsHiddenOrIgnored(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -232,6 +332,63 @@ func TestPackageCompletion_Available(t *testing.T) {
 	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
 }
 
+func TestPackageCompletion_FiltersByPrefix(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	setupHelpersTestFlags(t, CLIFlags{
+		packageDir: tmpDir,
+	})
+
+	for _, name := range []string{"vim", "vscode", "tmux", "dot"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, name), 0755))
+	}
+
+	completionFunc := packageCompletion(false)
+
+	completions, directive := completionFunc(&cobra.Command{}, []string{}, "v")
+	assert.ElementsMatch(t, []string{"vim", "vscode"}, completions)
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+
+	completions, _ = completionFunc(&cobra.Command{}, []string{"vim"}, "v")
+	assert.Equal(t, []string{"vscode"}, completions, "packages already given are not offered again")
+
+	completions, _ = completionFunc(&cobra.Command{}, []string{}, "d")
+	assert.Empty(t, completions, "reserved names are not offered")
+}
+
+func TestGetAvailablePackages_FromEnv(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "zsh"), 0755))
+
+	setupHelpersTestFlags(t, CLIFlags{})
+	t.Setenv("DOT_PACKAGE_DIR", tmpDir)
+
+	assert.Equal(t, []string{"zsh"}, getAvailablePackages())
+}
+
+func TestListPackageDirs(t *testing.T) {
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	for _, dir := range []string{"vim", "tmux", ".git", ".hidden", "node_modules", "dot", "dot-config"} {
+		require.NoError(t, fs.MkdirAll(ctx, filepath.Join("/packages", dir), 0755))
+	}
+	require.NoError(t, fs.WriteFile(ctx, "/packages/README.md", []byte("readme"), 0644))
+
+	packages := listPackageDirs(ctx, fs, "/packages")
+	assert.ElementsMatch(t, []string{"vim", "tmux"}, packages)
+
+	assert.Nil(t, listPackageDirs(ctx, fs, "/missing"))
+}
+
+func TestFilterCompletions(t *testing.T) {
+	candidates := []string{"vim", "vscode", "tmux"}
+
+	assert.Equal(t, candidates, filterCompletions(candidates, nil, ""))
+	assert.Equal(t, []string{"vim", "vscode"}, filterCompletions(candidates, nil, "v"))
+	assert.Equal(t, []string{"vscode"}, filterCompletions(candidates, []string{"vim"}, "v"))
+	assert.Empty(t, filterCompletions(candidates, nil, "z"))
+}
+
 func TestGetInstalledPackages(t *testing.T) {
 	// Test that getInstalledPackages doesn't crash
 	packages := getInstalledPackages()
@@ -321,3 +478,66 @@ func TestGetAvailablePackages_InvalidDir(t *testing.T) {
 	packages := getAvailablePackages()
 	assert.Nil(t, packages)
 }
+
+func TestResolveTheme(t *testing.T) {
+	var buf bytes.Buffer
+
+	theme := resolveTheme("solarized", &buf)
+	assert.Equal(t, render.ThemeSolarized, theme.Name)
+	assert.Empty(t, buf.String())
+
+	theme = resolveTheme("", &buf)
+	assert.Equal(t, render.ThemeDefault, theme.Name)
+	assert.Empty(t, buf.String())
+
+	theme = resolveTheme("monokai", &buf)
+	assert.Equal(t, render.ThemeDefault, theme.Name)
+	assert.Contains(t, buf.String(), `unknown output theme "monokai", using default`)
+	assert.Contains(t, buf.String(), "high-contrast")
+}
+
+func TestResolveOutputSettings(t *testing.T) {
+	cfg := dot.DefaultExtendedConfig()
+	cfg.Output.Theme = render.ThemeLight
+	cfg.Output.Colors.Dim = "238"
+	cfg.Output.Colors.Heading = "19"
+
+	var buf bytes.Buffer
+	settings := resolveOutputSettings(cfg, &buf)
+	assert.Equal(t, "auto", settings.color)
+	assert.Equal(t, "238", settings.theme.Dim, "a configured color replaces the theme's")
+	assert.Equal(t, "28", settings.theme.Success, "unset roles keep the theme's color")
+
+	c := render.NewColorizer(true, settings.theme)
+	assert.Equal(t, "\033[38;5;238mhint\033[0m", c.Dim("hint"))
+	assert.Equal(t, "\033[1m\033[38;5;19mTitle\033[0m", c.Bold("Title"))
+
+	cfg.Output.Color = "never"
+	settings = resolveOutputSettings(cfg, &buf)
+	assert.Equal(t, "never", settings.color)
+	c = render.NewColorizer(true, settings.theme)
+	assert.Equal(t, "hint", c.Dim("hint"), "never ignores the theme and its colors")
+	assert.Equal(t, "failed", c.Error("failed"))
+
+	assert.Equal(t, render.DefaultTheme(), resolveOutputSettings(nil, &buf).theme)
+	assert.Empty(t, buf.String())
+}
+
+func TestResolveOutputSettings_EveryColorRole(t *testing.T) {
+	cfg := dot.DefaultExtendedConfig()
+	theme := render.DefaultTheme()
+	for _, role := range cfg.Output.Colors.Roles() {
+		assert.True(t, theme.SetRole(role.Name, "0"), "theme has no %s role", role.Name)
+	}
+}
+
+func TestOutputWidth(t *testing.T) {
+	t.Cleanup(func() { activeOutput = nil })
+	t.Setenv("COLUMNS", "120")
+
+	activeOutput = &outputSettings{width: 72}
+	assert.Equal(t, 72, outputWidth(), "output.width wins over COLUMNS")
+
+	activeOutput = &outputSettings{}
+	assert.Equal(t, 120, outputWidth(), "COLUMNS applies when output.width is 0")
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -31,9 +32,14 @@ func newListCommand() *cobra.Command {
 		// Get flags
 		format, _ := cmd.Flags().GetString("format")
 		color, _ := cmd.Flags().GetString("color")
-		sortBy, _ := cmd.Flags().GetString("sort")
+		reverse, _ := cmd.Flags().GetBool("reverse")
 		showTarget, _ := cmd.Flags().GetBool("show-target")
 
+		sortBy, err := resolveListSort(cmd, extCfg)
+		if err != nil {
+			return err
+		}
+
 		// Create client
 		client, err := dot.NewClient(cfg)
 		if err != nil {
@@ -47,7 +53,7 @@ func newListCommand() *cobra.Command {
 		}
 
 		// Sort packages
-		sortPackages(packages, sortBy)
+		sortPackages(packages, sortBy, reverse)
 
 		// Create status from packages
 		status := dot.Status{
@@ -105,6 +111,7 @@ func NewListCommand(cfg *dot.Config) *cobra.Command {
 	var format string
 	var color string
 	var sortBy string
+	var reverse bool
 	var showTarget bool
 
 	cmd := &cobra.Command{
@@ -120,13 +127,17 @@ Health indicators:
   ✓ (green) - All symlinks are valid
   ✗ (red)   - Package has issues (broken links, wrong target, or missing links)
 
-The list can be sorted by various fields and displayed in multiple output formats.`,
+The list can be sorted by various fields and displayed in multiple output formats.
+Without --sort, packages are ordered by packages.sort_by from the configuration.`,
 		Example: `  # List all packages with health status
   dot list
 
   # List packages sorted by link count
   dot list --sort=links
 
+  # List oldest installations first
+  dot list --sort=date --reverse
+
   # Show target directory
   dot list --show-target
 
@@ -143,7 +154,8 @@ The list can be sorted by various fields and displayed in multiple output format
 
 	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml, table)")
 	cmd.Flags().StringVar(&color, "color", "auto", "Colorize output (auto, always, never)")
-	cmd.Flags().StringVar(&sortBy, "sort", "name", "Sort by field (name, links, date)")
+	cmd.Flags().StringVar(&sortBy, "sort", "name", "Sort by field (name, links, date); defaults to packages.sort_by from config")
+	cmd.Flags().BoolVar(&reverse, "reverse", false, "Reverse the sort order")
 	cmd.Flags().BoolVar(&showTarget, "show-target", false, "Show target directory in output")
 
 	return cmd
@@ -254,7 +266,7 @@ func renderCleanList(w io.Writer, packages []dot.PackageInfo, packageDir string,
 		return
 	}
 
-	colorizer := render.NewColorizer(shouldUseColor())
+	colorizer := render.NewColorizer(shouldUseColor(), outputTheme())
 
 	// Header
 	pluralS := ""
@@ -326,25 +338,56 @@ func formatTimeAgo(t time.Time) string {
 	}
 }
 
+// validListSortFields are the accepted values for list --sort.
+var validListSortFields = []string{"name", "links", "date"}
+
+// resolveListSort picks the sort field: an explicit --sort flag wins,
+// otherwise packages.sort_by from config, otherwise the flag default.
+func resolveListSort(cmd *cobra.Command, extCfg *dot.ExtendedConfig) (string, error) {
+	sortBy, _ := cmd.Flags().GetString("sort")
+	if !cmd.Flags().Changed("sort") && extCfg != nil && extCfg.Packages.SortBy != "" {
+		sortBy = extCfg.Packages.SortBy
+	}
+
+	for _, valid := range validListSortFields {
+		if sortBy == valid {
+			return sortBy, nil
+		}
+	}
+	return "", fmt.Errorf("invalid sort field %q (must be one of: name, links, date)", sortBy)
+}
+
 // sortPackages sorts packages by the specified field.
-func sortPackages(packages []dot.PackageInfo, sortBy string) {
+// Links and date sort descending (most links, most recent first); reverse
+// inverts that order. Ties are always broken by ascending name so output
+// is deterministic.
+func sortPackages(packages []dot.PackageInfo, sortBy string, reverse bool) {
+	// compare returns <0 when a sorts before b on the primary key
+	var compare func(a, b dot.PackageInfo) int
 	switch sortBy {
-	case "name":
-		sort.Slice(packages, func(i, j int) bool {
-			return packages[i].Name < packages[j].Name
-		})
 	case "links":
-		sort.Slice(packages, func(i, j int) bool {
-			return packages[i].LinkCount > packages[j].LinkCount // Descending
-		})
+		compare = func(a, b dot.PackageInfo) int {
+			return b.LinkCount - a.LinkCount // Descending
+		}
 	case "date":
-		sort.Slice(packages, func(i, j int) bool {
-			return packages[i].InstalledAt.After(packages[j].InstalledAt) // Most recent first
-		})
+		compare = func(a, b dot.PackageInfo) int {
+			return b.InstalledAt.Compare(a.InstalledAt) // Most recent first
+		}
 	default:
 		// Default to name sorting
-		sort.Slice(packages, func(i, j int) bool {
-			return packages[i].Name < packages[j].Name
-		})
+		compare = func(a, b dot.PackageInfo) int {
+			return strings.Compare(a.Name, b.Name)
+		}
 	}
+
+	sort.SliceStable(packages, func(i, j int) bool {
+		c := compare(packages[i], packages[j])
+		if reverse {
+			c = -c
+		}
+		if c != 0 {
+			return c < 0
+		}
+		return packages[i].Name < packages[j].Name
+	})
 }
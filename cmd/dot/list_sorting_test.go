@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/yaklabco/dot/pkg/dot"
 )
@@ -16,7 +17,7 @@ func TestSortPackages_ByName(t *testing.T) {
 		{Name: "vim"},
 	}
 
-	sortPackages(packages, "name")
+	sortPackages(packages, "name", false)
 
 	assert.Equal(t, "bash", packages[0].Name)
 	assert.Equal(t, "vim", packages[1].Name)
@@ -30,7 +31,7 @@ func TestSortPackages_ByLinks(t *testing.T) {
 		{Name: "c", LinkCount: 3},
 	}
 
-	sortPackages(packages, "links")
+	sortPackages(packages, "links", false)
 
 	assert.Equal(t, 10, packages[0].LinkCount)
 	assert.Equal(t, 5, packages[1].LinkCount)
@@ -45,7 +46,7 @@ func TestSortPackages_ByDate(t *testing.T) {
 		{Name: "c", InstalledAt: now.Add(-1 * time.Hour)},
 	}
 
-	sortPackages(packages, "date")
+	sortPackages(packages, "date", false)
 
 	assert.Equal(t, "b", packages[0].Name) // Most recent first
 	assert.Equal(t, "c", packages[1].Name)
@@ -59,8 +60,102 @@ func TestSortPackages_InvalidSortBy(t *testing.T) {
 	}
 
 	// Should default to name sorting
-	sortPackages(packages, "invalid")
+	sortPackages(packages, "invalid", false)
 
 	assert.Equal(t, "bash", packages[0].Name)
 	assert.Equal(t, "zsh", packages[1].Name)
 }
+
+func packageNames(packages []dot.PackageInfo) []string {
+	names := make([]string, len(packages))
+	for i, p := range packages {
+		names[i] = p.Name
+	}
+	return names
+}
+
+func TestSortPackages_TiesBrokenByName(t *testing.T) {
+	installed := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	fixture := func() []dot.PackageInfo {
+		return []dot.PackageInfo{
+			{Name: "zsh", LinkCount: 2, InstalledAt: installed},
+			{Name: "git", LinkCount: 5, InstalledAt: installed.Add(time.Hour)},
+			{Name: "bash", LinkCount: 2, InstalledAt: installed},
+			{Name: "vim", LinkCount: 5, InstalledAt: installed},
+			{Name: "tmux", LinkCount: 2, InstalledAt: installed.Add(time.Hour)},
+		}
+	}
+
+	tests := []struct {
+		sortBy  string
+		reverse bool
+		want    []string
+	}{
+		{"name", false, []string{"bash", "git", "tmux", "vim", "zsh"}},
+		{"name", true, []string{"zsh", "vim", "tmux", "git", "bash"}},
+		{"links", false, []string{"git", "vim", "bash", "tmux", "zsh"}},
+		{"links", true, []string{"bash", "tmux", "zsh", "git", "vim"}},
+		{"date", false, []string{"git", "tmux", "bash", "vim", "zsh"}},
+		{"date", true, []string{"bash", "vim", "zsh", "git", "tmux"}},
+	}
+
+	for _, tt := range tests {
+		name := tt.sortBy
+		if tt.reverse {
+			name += "/reverse"
+		}
+		t.Run(name, func(t *testing.T) {
+			// Same result regardless of input order
+			for _, rotate := range []int{0, 1, 3} {
+				packages := fixture()
+				packages = append(packages[rotate:], packages[:rotate]...)
+				sortPackages(packages, tt.sortBy, tt.reverse)
+				assert.Equal(t, tt.want, packageNames(packages))
+			}
+		})
+	}
+}
+
+func TestResolveListSort(t *testing.T) {
+	withSortBy := func(sortBy string) *dot.ExtendedConfig {
+		cfg := dot.DefaultExtendedConfig()
+		cfg.Packages.SortBy = sortBy
+		return cfg
+	}
+
+	tests := []struct {
+		name    string
+		args    []string
+		extCfg  *dot.ExtendedConfig
+		want    string
+		wantErr bool
+	}{
+		{"default without config", nil, nil, "name", false},
+		{"config applies", nil, withSortBy("links"), "links", false},
+		{"flag overrides config", []string{"--sort", "date"}, withSortBy("links"), "date", false},
+		{"explicit name overrides config", []string{"--sort", "name"}, withSortBy("date"), "name", false},
+		{"invalid flag", []string{"--sort", "size"}, nil, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := NewListCommand(&dot.Config{})
+			require.NoError(t, cmd.ParseFlags(tt.args))
+
+			got, err := resolveListSort(cmd, tt.extCfg)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestListCommand_ReverseFlag(t *testing.T) {
+	cmd := NewListCommand(&dot.Config{})
+	flag := cmd.Flags().Lookup("reverse")
+	require.NotNil(t, flag)
+	assert.Equal(t, "false", flag.DefValue)
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/yaklabco/dot/internal/adapters"
+	"github.com/yaklabco/dot/internal/cli/logs"
+	"github.com/yaklabco/dot/internal/cli/pretty"
+)
+
+// logsOptions holds flag values for the logs command.
+type logsOptions struct {
+	file   string
+	format string
+	level  string
+	since  string
+	lines  int
+	follow bool
+}
+
+// newLogsCommand creates the logs command.
+func newLogsCommand() *cobra.Command {
+	opts := &logsOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "logs",
+		Short: "View the dot log file",
+		Long: `Display entries from the log file written when logging.destination is "file".
+
+Entries are parsed using the configured logging.format (text or json) and
+can be filtered by minimum level and age. Use --follow to keep watching
+the file for new entries.`,
+		Example: `  # Show the log file
+  dot logs
+
+  # Show only warnings and errors from the last hour
+  dot logs --level WARN --since 1h
+
+  # Show the last 50 entries and keep watching
+  dot logs -n 50 --follow`,
+		Args: argsWithUsage(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runLogs(cmd, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.file, "file", "", "Log file to read (default: logging.file from config)")
+	cmd.Flags().StringVar(&opts.format, "log-format", "", "Log line format: text, json, auto (default: logging.format from config)")
+	cmd.Flags().StringVar(&opts.level, "level", "DEBUG", "Minimum level to show: DEBUG, INFO, WARN, ERROR")
+	cmd.Flags().StringVar(&opts.since, "since", "", "Only show entries newer than a duration (e.g. 1h) or timestamp")
+	cmd.Flags().IntVarP(&opts.lines, "lines", "n", 0, "Show only the last N matching entries (0 = all)")
+	cmd.Flags().BoolVarP(&opts.follow, "follow", "f", false, "Keep watching the log file for new entries")
+
+	return cmd
+}
+
+// runLogs handles the logs command.
+func runLogs(cmd *cobra.Command, opts *logsOptions) error {
+	path, format := resolveLogSource(opts)
+	if path == "" {
+		return fmt.Errorf("no log file configured: set logging.file or use --file")
+	}
+
+	if !isValidLogLevel(opts.level) {
+		return fmt.Errorf("invalid level %q (must be one of: DEBUG, INFO, WARN, ERROR)", opts.level)
+	}
+	since, err := logs.ParseSince(opts.since, time.Now())
+	if err != nil {
+		return err
+	}
+	filter := logs.Filter{MinLevel: adapters.ParseLogLevel(opts.level), Since: since}
+
+	offset, err := printLogFile(cmd.OutOrStdout(), path, format, filter, opts.lines, !opts.follow)
+	if err != nil {
+		return err
+	}
+
+	if !opts.follow {
+		return nil
+	}
+	return logs.Follow(cmd.Context(), path, offset, format, filter, cmd.OutOrStdout(), logs.DefaultPollInterval)
+}
+
+// resolveLogSource determines the log file and format from flags and config.
+func resolveLogSource(opts *logsOptions) (string, string) {
+	path := opts.file
+	format := opts.format
+
+	if path == "" || format == "" {
+		extCfg, err := loadConfigWithRepoPriority(GetCLIFlags().packageDir, getConfigFilePath())
+		if err == nil && extCfg != nil {
+			if path == "" {
+				path = extCfg.Logging.File
+			}
+			if format == "" {
+				format = extCfg.Logging.Format
+			}
+		}
+	}
+	if format == "" {
+		format = logs.FormatAuto
+	}
+	return path, format
+}
+
+// printLogFile writes matching entries from path and returns the file size
+// so a follow loop can continue where reading stopped. A missing file is
+// not an error: nothing has been logged yet.
+func printLogFile(w io.Writer, path, format string, filter logs.Filter, lastN int, usePager bool) (int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			fmt.Fprintf(os.Stderr, "Log file %s does not exist yet\n", path)
+			return 0, nil
+		}
+		return 0, fmt.Errorf("open log file: %w", err)
+	}
+	defer f.Close()
+
+	entries, err := logs.Read(f, format, filter)
+	if err != nil {
+		return 0, err
+	}
+	offset, err := f.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return 0, fmt.Errorf("seek log file: %w", err)
+	}
+
+	if lastN > 0 && len(entries) > lastN {
+		entries = entries[len(entries)-lastN:]
+	}
+	if len(entries) == 0 {
+		return offset, nil
+	}
+
+	lines := make([]string, len(entries))
+	for i, entry := range entries {
+		lines[i] = entry.Raw
+	}
+	content := strings.Join(lines, "\n") + "\n"
+
+	if usePager && w == os.Stdout {
+		return offset, pretty.NewPager(pretty.PagerConfig{Output: w, Width: outputWidth()}).Page(content)
+	}
+	_, err = io.WriteString(w, content)
+	return offset, err
+}
+
+// isValidLogLevel reports whether level names one of the standard slog levels.
+func isValidLogLevel(level string) bool {
+	var l slog.Level
+	return l.UnmarshalText([]byte(level)) == nil
+}
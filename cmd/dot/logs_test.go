@@ -0,0 +1,71 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeLogFixture(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "dot.log")
+	content := `time=2026-01-10T09:00:00.000Z level=DEBUG msg=scanning
+time=2026-01-10T09:00:01.000Z level=INFO msg=started
+time=2026-01-10T09:00:02.000Z level=WARN msg=conflict
+time=2026-01-10T09:00:03.000Z level=ERROR msg=failed
+`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func TestLogsCommand_LevelFilter(t *testing.T) {
+	path := writeLogFixture(t)
+
+	cmd := newLogsCommand()
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetArgs([]string{"--file", path, "--log-format", "text", "--level", "WARN"})
+
+	require.NoError(t, cmd.Execute())
+	assert.NotContains(t, buf.String(), "msg=started")
+	assert.Contains(t, buf.String(), "msg=conflict")
+	assert.Contains(t, buf.String(), "msg=failed")
+}
+
+func TestLogsCommand_LastLines(t *testing.T) {
+	path := writeLogFixture(t)
+
+	cmd := newLogsCommand()
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetArgs([]string{"--file", path, "--log-format", "text", "-n", "1"})
+
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, "time=2026-01-10T09:00:03.000Z level=ERROR msg=failed\n", buf.String())
+}
+
+func TestLogsCommand_InvalidLevel(t *testing.T) {
+	path := writeLogFixture(t)
+
+	cmd := newLogsCommand()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetArgs([]string{"--file", path, "--level", "LOUD"})
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid level")
+}
+
+func TestLogsCommand_MissingFile(t *testing.T) {
+	cmd := newLogsCommand()
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetArgs([]string{"--file", filepath.Join(t.TempDir(), "absent.log")})
+
+	require.NoError(t, cmd.Execute())
+	assert.Empty(t, buf.String())
+}
@@ -16,6 +16,7 @@ func TestMain(m *testing.M) {
 	oldXDGData := os.Getenv("XDG_DATA_HOME")
 	oldXDGConfig := os.Getenv("XDG_CONFIG_HOME")
 	oldXDGState := os.Getenv("XDG_STATE_HOME")
+	oldXDGCache := os.Getenv("XDG_CACHE_HOME")
 
 	// Create temp directory for XDG paths
 	tmpDir, err := os.MkdirTemp("", "dot-test-*")
@@ -27,6 +28,7 @@ func TestMain(m *testing.M) {
 	os.Setenv("XDG_DATA_HOME", filepath.Join(tmpDir, "data"))
 	os.Setenv("XDG_CONFIG_HOME", filepath.Join(tmpDir, "config"))
 	os.Setenv("XDG_STATE_HOME", filepath.Join(tmpDir, "state"))
+	os.Setenv("XDG_CACHE_HOME", filepath.Join(tmpDir, "cache"))
 
 	// Run tests
 	exitCode := m.Run()
@@ -50,6 +52,11 @@ func TestMain(m *testing.M) {
 	} else {
 		os.Unsetenv("XDG_STATE_HOME")
 	}
+	if oldXDGCache != "" {
+		os.Setenv("XDG_CACHE_HOME", oldXDGCache)
+	} else {
+		os.Unsetenv("XDG_CACHE_HOME")
+	}
 
 	os.Exit(exitCode)
 }
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -34,12 +35,72 @@ Examples:
   packages/dot-ssh/config       -> ~/.ssh/config
   packages/dot-vim/dot-vimrc    -> ~/.vim/.vimrc
   packages/vim/dot-vimrc        -> ~/vim/.vimrc
-  packages/scripts/hello.sh     -> ~/scripts/hello.sh`,
+  packages/scripts/hello.sh     -> ~/scripts/hello.sh
+
+Package patterns:
+  Package arguments may be globs matched against the package directories:
+  * and ? wildcards, [...] character classes and {a,b} alternatives.
+  Quote them so the shell does not expand them first. Each package is
+  managed once, in argument order, and a glob that matches nothing is an
+  error. Names without glob characters are used as given.
+
+  dot manage 'shell-*'
+  dot manage '{vim,tmux}'
+
+Folding:
+  With folding, a directory whose contents all come from one package and
+  that does not exist in the target yet is linked as a whole instead of
+  file by file. symlinks.folding sets the default; --folding and
+  --no-folding override it for one run.
+
+Conflicts:
+  When a file already exists where a link belongs and neither --backup
+  nor --overwrite is set, manage asks how to resolve each conflict:
+  back up, overwrite, or skip. It asks when input is a terminal (unless
+  --batch is set) or when --interactive is set; otherwise it reports the
+  conflicts and stops.
+
+  --adopt resolves conflicts with existing files by moving each file into
+  the package and linking it, in one transaction. A file identical to the
+  package's version is set aside in the backup directory. A file that
+  differs remains a conflict unless --adopt-replace is set, in which case
+  the package's version is moved to the backup directory and the file
+  takes its place.
+
+Partial installation:
+  --only links just the files whose path within the package matches a
+  glob, written as stored (dot-config/nvim) or as linked (.config/nvim).
+  A glob naming a directory takes everything beneath it, and parent
+  directories are still created. Repeat the flag or separate globs with
+  commas. The manifest records the globs, so
+  remanage and diff keep to them and doctor does not report the rest of
+  the package. Managing again without --only links the whole package.
+
+  dot manage editors --only .config/nvim
+
+Unchanged packages:
+  The manifest records a hash of each package's files and the links they
+  produce. A package whose hash still matches and whose links are all in
+  place is skipped without planning. --force plans it anyway.
+
+Hooks:
+  Commands in hooks.pre_manage run before the packages are linked and
+  abort the run if one fails; hooks.post_manage commands run afterwards
+  and only warn on failure. --no-hooks skips both.`,
 		Args:              argsWithUsage(cobra.MinimumNArgs(1)),
 		RunE:              runManage,
 		ValidArgsFunction: packageCompletion(false), // Complete with available packages
 	}
 
+	cmd.Flags().Bool("folding", false, "link whole directories where possible, overriding config")
+	cmd.Flags().Bool("no-folding", false, "link files individually, overriding config")
+	cmd.Flags().Bool("interactive", false, "prompt for how to resolve each conflict")
+	cmd.Flags().StringSlice("only", nil, "link only package files matching these globs (repeatable)")
+	cmd.Flags().Bool("force", false, "plan packages even if unchanged since they were last managed")
+	cmd.Flags().Bool("adopt", false, "move conflicting files into the package instead of failing")
+	cmd.Flags().Bool("adopt-replace", false, "with --adopt, replace package files that differ from the adopted file")
+	cmd.Flags().Bool("no-hooks", false, "do not run pre_manage and post_manage hooks")
+
 	return cmd
 }
 
@@ -50,6 +111,10 @@ func runManage(cmd *cobra.Command, args []string) error {
 		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
 		return err
 	}
+	if err := applyFoldingFlags(cmd, &cfg); err != nil {
+		return err
+	}
+	applyNoHooksFlag(cmd, &cfg)
 
 	// Load extended config for table_style
 	configPath := getConfigFilePath()
@@ -66,7 +131,21 @@ func runManage(cmd *cobra.Command, args []string) error {
 		ctx = context.Background()
 	}
 
-	packages := args
+	packages, err := client.ExpandPackages(ctx, args)
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return err
+	}
+	only, _ := cmd.Flags().GetStringSlice("only")
+	force, _ := cmd.Flags().GetBool("force")
+	adopt, _ := cmd.Flags().GetBool("adopt")
+	adoptReplace, _ := cmd.Flags().GetBool("adopt-replace")
+	if adoptReplace && !adopt {
+		err := fmt.Errorf("--adopt-replace requires --adopt")
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return err
+	}
+	opts := dot.ManageOptions{Include: only, Force: force, Adopt: adopt, AdoptReplace: adoptReplace}
 
 	// Check for potential secrets in packages before managing
 	if warnings := checkPackagesForSecrets(ctx, client, packages); len(warnings) > 0 {
@@ -79,7 +158,7 @@ func runManage(cmd *cobra.Command, args []string) error {
 
 	// If dry-run mode, render the plan instead of executing
 	if cfg.DryRun {
-		plan, err := client.PlanManage(ctx, packages...)
+		plan, err := client.PlanManageWithOptions(ctx, opts, packages...)
 		if err != nil {
 			fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
 			return err
@@ -96,7 +175,13 @@ func runManage(cmd *cobra.Command, args []string) error {
 			return err
 		}
 
-		if err := rend.RenderPlan(os.Stdout, plan); err != nil {
+		effects, err := client.DryRunPlan(ctx, plan)
+		if err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+			return err
+		}
+
+		if err := rend.RenderDryRun(os.Stdout, plan, effects); err != nil {
 			fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
 			return err
 		}
@@ -104,8 +189,23 @@ func runManage(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	// Ask before applying a plan that deletes many files; planning errors
+	// are reported by the manage run itself
+	if plan, err := client.PlanManageWithOptions(ctx, opts, packages...); err == nil {
+		if err := confirmRiskyPlan(ctx, cmd, plan); err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+			return err
+		}
+	}
+
 	// Normal execution
-	if err := client.Manage(ctx, packages...); err != nil {
+	start := time.Now()
+	err = client.ManageWithOptions(ctx, opts, packages...)
+	var conflictErr dot.ErrConflict
+	if errors.As(err, &conflictErr) && shouldResolveInteractively(cmd) {
+		err = manageWithConflictPrompts(ctx, cmd, client, opts, packages, err)
+	}
+	if err != nil {
 		var noChanges dot.ErrNoChanges
 		if errors.As(err, &noChanges) {
 			formatNoChangesMessage(cmd.OutOrStdout(), len(packages), shouldUseColor())
@@ -119,9 +219,37 @@ func runManage(cmd *cobra.Command, args []string) error {
 	colorize := shouldUseColor()
 
 	// Create formatter and print success message
-	formatter := output.NewFormatter(cmd.OutOrStdout(), colorize)
+	formatter := output.NewFormatter(cmd.OutOrStdout(), colorize, outputTheme())
 	formatter.Success("managed", len(packages), "package", "packages")
+	printResolutionSummary(ctx, cmd.OutOrStdout(), client, start)
 	formatter.BlankLine()
 
 	return nil
 }
+
+// applyFoldingFlags overrides the configured folding setting with
+// --folding or --no-folding and logs the value in effect.
+func applyFoldingFlags(cmd *cobra.Command, cfg *dot.Config) error {
+	flags := cmd.Flags()
+	if flags.Changed("folding") && flags.Changed("no-folding") {
+		return fmt.Errorf("--folding and --no-folding cannot be used together")
+	}
+
+	source := "config"
+	switch {
+	case flags.Changed("folding"):
+		cfg.Folding, _ = flags.GetBool("folding")
+		source = "flag"
+	case flags.Changed("no-folding"):
+		noFolding, _ := flags.GetBool("no-folding")
+		cfg.Folding = !noFolding
+		source = "flag"
+	}
+
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	cfg.Logger.Debug(ctx, "folding", "enabled", cfg.Folding, "source", source)
+	return nil
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/yaklabco/dot/pkg/dot"
+)
+
+// conflictActions maps conflict prompt choices to resolution policies.
+var conflictActions = map[string]dot.ResolutionPolicy{
+	"b": dot.PolicyBackup,
+	"o": dot.PolicyOverwrite,
+	"s": dot.PolicySkip,
+}
+
+// shouldResolveInteractively reports whether manage prompts for conflicts:
+// when --interactive is set, or when input is a terminal outside batch mode.
+func shouldResolveInteractively(cmd *cobra.Command) bool {
+	if interactive, _ := cmd.Flags().GetBool("interactive"); interactive {
+		return true
+	}
+	if batch, _ := cmd.Flags().GetBool("batch"); batch {
+		return false
+	}
+	return isTerminal(cmd)
+}
+
+// manageWithConflictPrompts plans packages with opts, asks how to resolve
+// each conflict, then manages them with the chosen resolutions. It returns
+// conflictErr unchanged if the user quits.
+func manageWithConflictPrompts(ctx context.Context, cmd *cobra.Command, client *dot.Client, opts dot.ManageOptions, packages []string, conflictErr error) error {
+	plan, err := client.PlanManageWithOptions(ctx, opts, packages...)
+	if err != nil {
+		return err
+	}
+	if len(plan.Metadata.Conflicts) == 0 {
+		return conflictErr
+	}
+
+	resolutions, ok := promptConflictResolutions(cmd.OutOrStdout(), bufio.NewReader(cmd.InOrStdin()), plan.Metadata.Conflicts)
+	if !ok {
+		return conflictErr
+	}
+	opts.Resolutions = resolutions
+	return client.ManageWithOptions(ctx, opts, packages...)
+}
+
+// promptConflictResolutions asks for an action on each conflict and
+// returns the chosen policy per path. It reports false if the user quits.
+func promptConflictResolutions(w io.Writer, r *bufio.Reader, conflicts []dot.ConflictInfo) (map[string]dot.ResolutionPolicy, bool) {
+	resolutions := make(map[string]dot.ResolutionPolicy, len(conflicts))
+	applyToAll := false
+	applyToAllAction := ""
+
+	for i := 0; i < len(conflicts); i++ {
+		conflict := conflicts[i]
+		if applyToAll {
+			resolutions[conflict.Path] = conflictActions[applyToAllAction]
+			continue
+		}
+
+		action, all := promptConflictAction(w, r, conflict, i+1, len(conflicts))
+		if action == "q" {
+			return nil, false
+		}
+		policy, ok := conflictActions[action]
+		if !ok {
+			fmt.Fprintf(w, "Unknown choice %q\n", action)
+			i-- // ask again
+			continue
+		}
+		if all {
+			applyToAll = true
+			applyToAllAction = action
+		}
+		resolutions[conflict.Path] = policy
+	}
+	return resolutions, true
+}
+
+// promptConflictAction prompts for action on an individual conflict.
+// Returns (action, applyToAll).
+func promptConflictAction(w io.Writer, r *bufio.Reader, conflict dot.ConflictInfo, current, total int) (string, bool) {
+	fmt.Fprintf(w, "\nConflict [%d/%d]: %s\n", current, total, conflict.Path)
+	fmt.Fprintf(w, "  Reason: %s\n", conflict.Details)
+
+	fmt.Fprintf(w, "\nActions:\n")
+	fmt.Fprintf(w, "  b - Back up the existing file, then link\n")
+	fmt.Fprintf(w, "  o - Overwrite the existing file with the link\n")
+	fmt.Fprintf(w, "  s - Skip this file\n")
+	fmt.Fprintf(w, "  B/O/S - Apply to all remaining\n")
+	fmt.Fprintf(w, "  q - Quit without managing\n")
+	fmt.Fprintf(w, "\nChoice [s]: ")
+
+	line, err := r.ReadString('\n')
+	if err != nil && line == "" {
+		// Handle EOF or input error
+		return "q", false
+	}
+	choice := strings.TrimSpace(line)
+
+	// Check for "apply to all" BEFORE lowercasing
+	switch choice {
+	case "B", "O", "S":
+		return strings.ToLower(choice), true
+	}
+
+	choice = strings.ToLower(choice)
+	if choice == "" {
+		choice = "s"
+	}
+	return choice, false
+}
@@ -454,10 +454,11 @@ func TestManageCommand_Verification(t *testing.T) {
 		require.NoError(t, err)
 		require.True(t, info.Mode()&os.ModeSymlink != 0, "should be a symlink")
 
-		// Verify symlink points to correct location
+		// Verify symlink points to correct location, relative by default
 		linkTarget, err := os.Readlink(symlinkPath)
 		require.NoError(t, err)
-		require.Equal(t, vimrcPath, linkTarget, "symlink should point to package file")
+		require.False(t, filepath.IsAbs(linkTarget), "default link mode is relative")
+		require.Equal(t, vimrcPath, filepath.Join(filepath.Dir(symlinkPath), linkTarget), "symlink should point to package file")
 
 		// Verify content is accessible through symlink
 		content, err := os.ReadFile(symlinkPath)
@@ -1,9 +1,14 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"io"
 	"os"
 	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -168,3 +173,285 @@ func TestManageCommand_Integration_PackageNotFound(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "not found")
 }
+
+func TestManageCommand_FoldingFlags(t *testing.T) {
+	tests := []struct {
+		name       string
+		flags      []string
+		wantFolded bool
+		wantErr    string
+	}{
+		{name: "folding", flags: []string{"--folding"}, wantFolded: true},
+		{name: "no folding", flags: []string{"--no-folding"}, wantFolded: false},
+		{name: "both", flags: []string{"--folding", "--no-folding"}, wantErr: "cannot be used together"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			packageDir := filepath.Join(tmpDir, "packages")
+			targetDir := filepath.Join(tmpDir, "target")
+			t.Setenv("XDG_CONFIG_HOME", filepath.Join(tmpDir, "config"))
+			t.Setenv("XDG_DATA_HOME", filepath.Join(tmpDir, "data"))
+
+			app := filepath.Join(packageDir, "dot-local", "share", "app")
+			require.NoError(t, os.MkdirAll(filepath.Join(app, "themes"), 0755))
+			require.NoError(t, os.WriteFile(filepath.Join(app, "settings"), []byte("settings"), 0644))
+			require.NoError(t, os.WriteFile(filepath.Join(app, "themes", "dark"), []byte("dark"), 0644))
+			require.NoError(t, os.MkdirAll(filepath.Join(targetDir, ".local", "share"), 0755))
+
+			setupIntegrationTestFlags(t, CLIFlags{packageDir: packageDir, targetDir: targetDir})
+
+			cmd := newManageCommand()
+			cmd.SetContext(context.Background())
+			cmd.SetArgs(append(tt.flags, "dot-local"))
+			err := cmd.Execute()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			info, err := os.Lstat(filepath.Join(targetDir, ".local", "share", "app"))
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantFolded, info.Mode()&os.ModeSymlink != 0, "app directory linked as a whole")
+
+			data, err := os.ReadFile(filepath.Join(targetDir, ".local", "share", "app", "themes", "dark"))
+			require.NoError(t, err)
+			assert.Equal(t, "dark", string(data))
+		})
+	}
+}
+
+func TestManageCommand_InteractiveConflicts(t *testing.T) {
+	tests := []struct {
+		name       string
+		flags      []string
+		input      string
+		wantErr    bool
+		wantLinked []string
+		wantPrompt bool
+	}{
+		{
+			name:       "backup first then skip remaining",
+			flags:      []string{"--interactive"},
+			input:      "b\nS\n",
+			wantLinked: []string{".bashrc"},
+			wantPrompt: true,
+		},
+		{
+			name:       "overwrite all",
+			flags:      []string{"--interactive"},
+			input:      "O\n",
+			wantLinked: []string{".bashrc", ".profile", ".zshrc"},
+			wantPrompt: true,
+		},
+		{
+			name:       "unknown choice asks again",
+			flags:      []string{"--interactive"},
+			input:      "x\nS\n",
+			wantPrompt: true,
+		},
+		{
+			name:       "quit",
+			flags:      []string{"--interactive"},
+			input:      "q\n",
+			wantErr:    true,
+			wantPrompt: true,
+		},
+		{
+			name:    "non-interactive fails unchanged",
+			input:   "O\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			packageDir := filepath.Join(tmpDir, "packages")
+			targetDir := filepath.Join(tmpDir, "target")
+			t.Setenv("XDG_CONFIG_HOME", filepath.Join(tmpDir, "config"))
+			t.Setenv("XDG_DATA_HOME", filepath.Join(tmpDir, "data"))
+
+			// Package "shell" links into <target>/shell
+			names := []string{".bashrc", ".profile", ".zshrc"}
+			shellDir := filepath.Join(targetDir, "shell")
+			require.NoError(t, os.MkdirAll(filepath.Join(packageDir, "shell"), 0755))
+			require.NoError(t, os.MkdirAll(shellDir, 0755))
+			for _, name := range names {
+				require.NoError(t, os.WriteFile(filepath.Join(packageDir, "shell", "dot-"+name[1:]), []byte("managed"), 0644))
+				require.NoError(t, os.WriteFile(filepath.Join(shellDir, name), []byte("original"), 0644))
+			}
+
+			setupIntegrationTestFlags(t, CLIFlags{packageDir: packageDir, targetDir: targetDir})
+
+			var out bytes.Buffer
+			cmd := newManageCommand()
+			cmd.SetContext(context.Background())
+			cmd.SetIn(strings.NewReader(tt.input))
+			cmd.SetOut(&out)
+			cmd.SetErr(io.Discard)
+			cmd.SetArgs(append(tt.flags, "shell"))
+			err := cmd.Execute()
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "conflict")
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantPrompt, strings.Contains(out.String(), "Conflict [1/3]"))
+
+			for _, name := range names {
+				info, err := os.Lstat(filepath.Join(shellDir, name))
+				require.NoError(t, err)
+				linked := info.Mode()&os.ModeSymlink != 0
+				assert.Equal(t, slices.Contains(tt.wantLinked, name), linked, name)
+			}
+		})
+	}
+}
+
+func TestManageCommand_Only(t *testing.T) {
+	tmpDir := t.TempDir()
+	packageDir := filepath.Join(tmpDir, "packages")
+	targetDir := filepath.Join(tmpDir, "target")
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(tmpDir, "config"))
+	t.Setenv("XDG_DATA_HOME", filepath.Join(tmpDir, "data"))
+
+	editors := filepath.Join(packageDir, "editors")
+	require.NoError(t, os.MkdirAll(filepath.Join(editors, "dot-config", "nvim", "lua"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(editors, "dot-config", "helix"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(editors, "dot-config", "nvim", "init.lua"), []byte("init"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(editors, "dot-config", "nvim", "lua", "plugins.lua"), []byte("plugins"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(editors, "dot-config", "helix", "config.toml"), []byte("helix"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(editors, "dot-vimrc"), []byte("vim"), 0644))
+	require.NoError(t, os.MkdirAll(targetDir, 0755))
+
+	setupIntegrationTestFlags(t, CLIFlags{packageDir: packageDir, targetDir: targetDir})
+
+	cmd := newManageCommand()
+	cmd.SetContext(context.Background())
+	cmd.SetArgs([]string{"editors", "--only", ".config/nvim"})
+	require.NoError(t, cmd.Execute())
+
+	// Package name mapping links editors under target/editors/
+	pkgTarget := filepath.Join(targetDir, "editors")
+	assert.FileExists(t, filepath.Join(pkgTarget, ".config", "nvim", "init.lua"))
+	assert.FileExists(t, filepath.Join(pkgTarget, ".config", "nvim", "lua", "plugins.lua"))
+	assert.NoFileExists(t, filepath.Join(pkgTarget, ".config", "helix", "config.toml"))
+	assert.NoFileExists(t, filepath.Join(pkgTarget, ".vimrc"))
+}
+
+func TestManageCommand_Adopt(t *testing.T) {
+	tests := []struct {
+		name        string
+		flags       []string
+		wantErr     string
+		wantPackage string
+	}{
+		{name: "differs without replace", flags: []string{"--adopt"}, wantErr: "differs from the package version"},
+		{name: "replace", flags: []string{"--adopt", "--adopt-replace"}, wantPackage: "existing"},
+		{name: "replace requires adopt", flags: []string{"--adopt-replace"}, wantErr: "--adopt-replace requires --adopt"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			packageDir := filepath.Join(tmpDir, "packages")
+			targetDir := filepath.Join(tmpDir, "target")
+			t.Setenv("XDG_CONFIG_HOME", filepath.Join(tmpDir, "config"))
+			t.Setenv("XDG_DATA_HOME", filepath.Join(tmpDir, "data"))
+
+			require.NoError(t, os.MkdirAll(filepath.Join(packageDir, "vim"), 0755))
+			// Package vim links into ~/vim
+			existing := filepath.Join(targetDir, "vim", ".vimrc")
+			require.NoError(t, os.MkdirAll(filepath.Dir(existing), 0755))
+			require.NoError(t, os.WriteFile(filepath.Join(packageDir, "vim", "dot-vimrc"), []byte("package"), 0644))
+			require.NoError(t, os.WriteFile(existing, []byte("existing"), 0644))
+
+			setupIntegrationTestFlags(t, CLIFlags{packageDir: packageDir, targetDir: targetDir})
+
+			cmd := newManageCommand()
+			cmd.SetContext(context.Background())
+			cmd.SetIn(strings.NewReader(""))
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+			cmd.SetArgs(append(tt.flags, "vim"))
+			err := cmd.Execute()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				data, readErr := os.ReadFile(existing)
+				require.NoError(t, readErr)
+				assert.Equal(t, "existing", string(data), "the existing file is left in place")
+				return
+			}
+			require.NoError(t, err)
+
+			info, err := os.Lstat(existing)
+			require.NoError(t, err)
+			assert.NotZero(t, info.Mode()&os.ModeSymlink, "the adopted file is linked")
+			data, err := os.ReadFile(filepath.Join(packageDir, "vim", "dot-vimrc"))
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantPackage, string(data))
+		})
+	}
+}
+
+func TestManageCommand_Hooks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hooks in this test use a POSIX shell")
+	}
+
+	tests := []struct {
+		name       string
+		flags      []string
+		wantErr    string
+		wantLinked bool
+	}{
+		{name: "pre hook failure aborts", wantErr: "pre_manage hook"},
+		{name: "no hooks", flags: []string{"--no-hooks"}, wantLinked: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			packageDir := filepath.Join(tmpDir, "packages")
+			targetDir := filepath.Join(tmpDir, "target")
+			configPath := filepath.Join(tmpDir, "config.yaml")
+			t.Setenv("XDG_CONFIG_HOME", filepath.Join(tmpDir, "config"))
+			t.Setenv("XDG_DATA_HOME", filepath.Join(tmpDir, "data"))
+			t.Setenv("DOT_CONFIG", configPath)
+
+			require.NoError(t, os.MkdirAll(filepath.Join(packageDir, "vim"), 0755))
+			require.NoError(t, os.MkdirAll(targetDir, 0755))
+			require.NoError(t, os.WriteFile(filepath.Join(packageDir, "vim", "dot-vimrc"), []byte("set number"), 0644))
+			require.NoError(t, os.WriteFile(configPath, []byte(`
+hooks:
+  pre_manage:
+    - command: ["sh", "-c", "exit 1"]
+      packages: [vim]
+`), 0600))
+
+			setupIntegrationTestFlags(t, CLIFlags{packageDir: packageDir, targetDir: targetDir})
+
+			cmd := newManageCommand()
+			cmd.SetContext(context.Background())
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+			cmd.SetArgs(append(tt.flags, "vim"))
+			err := cmd.Execute()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			_, err = os.Lstat(filepath.Join(targetDir, "vim", ".vimrc"))
+			assert.Equal(t, tt.wantLinked, err == nil)
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/yaklabco/dot/internal/cli/output"
+	"github.com/yaklabco/dot/pkg/dot"
+)
+
+// newManifestCommand creates the manifest command.
+func newManifestCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "manifest",
+		Short: "Export or import the installation manifest",
+		Long: `Move an installation to another machine without selecting packages again.
+
+The manifest records the managed packages, their links, ignored doctor
+patterns, and the cloned repository. Export writes it to a versioned JSON
+bundle; import restores the bundle on another machine and manages the
+listed packages.`,
+		Example: `  # Save the current installation
+  dot manifest export ~/dot-bundle.json
+
+  # Recreate it on a new machine after cloning the package directory
+  dot manifest import ~/dot-bundle.json`,
+	}
+
+	cmd.AddCommand(
+		newManifestExportCommand(),
+		newManifestImportCommand(),
+	)
+
+	return cmd
+}
+
+// newManifestExportCommand creates the manifest export command.
+func newManifestExportCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "export FILE",
+		Short: "Write the manifest to a portable bundle",
+		Long: `Write the current manifest to FILE as a versioned JSON bundle.
+Use - to write to standard output.`,
+		Example: `  dot manifest export dot-bundle.json
+  dot manifest export - > dot-bundle.json`,
+		Args: argsWithUsage(cobra.ExactArgs(1)),
+		RunE: runManifestExport,
+	}
+}
+
+// newManifestImportCommand creates the manifest import command.
+func newManifestImportCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "import FILE",
+		Short: "Restore a bundle and manage its packages",
+		Long: `Replace the manifest with the bundle in FILE, then manage every
+listed package found in the package directory, creating its links.
+Use - to read from standard input.
+
+The bundle's format version is checked first. Packages missing from the
+package directory are reported as warnings and skipped; they stay in the
+manifest so doctor can flag them until they are restored.`,
+		Example: `  dot manifest import dot-bundle.json
+
+  # Preview the links that would be created
+  dot --dry-run manifest import dot-bundle.json`,
+		Args: argsWithUsage(cobra.ExactArgs(1)),
+		RunE: runManifestImport,
+	}
+}
+
+// runManifestExport handles the manifest export command.
+func runManifestExport(cmd *cobra.Command, args []string) error {
+	cfg, err := buildConfigWithCmd(cmd)
+	if err != nil {
+		return err
+	}
+	client, err := dot.NewClient(cfg)
+	if err != nil {
+		return formatError(err)
+	}
+
+	if args[0] == "-" {
+		return formatError(client.ExportManifest(cmd.Context(), cmd.OutOrStdout()))
+	}
+
+	// The bundle lists every managed path, so keep it private like the manifest
+	f, err := os.OpenFile(args[0], os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("create bundle: %w", err)
+	}
+	if err := client.ExportManifest(cmd.Context(), f); err != nil {
+		_ = f.Close()
+		return formatError(err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("write bundle: %w", err)
+	}
+
+	formatter := output.NewFormatter(cmd.OutOrStdout(), shouldUseColor(), outputTheme())
+	formatter.SuccessSimple("Exported manifest to " + args[0])
+	return nil
+}
+
+// runManifestImport handles the manifest import command.
+func runManifestImport(cmd *cobra.Command, args []string) error {
+	cfg, err := buildConfigWithCmd(cmd)
+	if err != nil {
+		return err
+	}
+	client, err := dot.NewClient(cfg)
+	if err != nil {
+		return formatError(err)
+	}
+
+	var r io.Reader = cmd.InOrStdin()
+	if args[0] != "-" {
+		f, err := os.Open(args[0])
+		if err != nil {
+			return fmt.Errorf("open bundle: %w", err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	restore, err := client.RestoreManifest(cmd.Context(), r)
+	formatter := output.NewFormatter(cmd.OutOrStdout(), shouldUseColor(), outputTheme())
+	for _, pkg := range restore.Missing {
+		formatter.Warning(fmt.Sprintf("Package %s not found in %s; skipped", pkg, cfg.PackageDir))
+	}
+	if err != nil {
+		return formatError(err)
+	}
+
+	if cfg.DryRun {
+		return nil
+	}
+	formatter.SuccessSimple("Imported manifest and managed " + formatCount(len(restore.Managed), "package", "packages"))
+	return nil
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runManifestSubcommand executes `dot manifest <args...>` against the given
+// dirs and returns captured output.
+func runManifestSubcommand(t *testing.T, packageDir, targetDir string, args ...string) (string, error) {
+	t.Helper()
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(targetDir, ".config"))
+	t.Setenv("XDG_DATA_HOME", filepath.Join(targetDir, ".local", "share"))
+	setupIntegrationTestFlags(t, CLIFlags{
+		packageDir: packageDir,
+		targetDir:  targetDir,
+	})
+
+	cmd := newManifestCommand()
+	cmd.SetContext(context.Background())
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestManifestCommand_ExportImport(t *testing.T) {
+	tmpDir := t.TempDir()
+	packageDir := filepath.Join(tmpDir, "packages")
+	for pkg, file := range map[string]string{"vim": "dot-vimrc", "zsh": "dot-zshrc"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(packageDir, pkg), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(packageDir, pkg, file), []byte(pkg), 0o644))
+	}
+	oldTarget := filepath.Join(tmpDir, "old")
+	require.NoError(t, os.MkdirAll(oldTarget, 0o755))
+
+	t.Setenv("XDG_DATA_HOME", filepath.Join(oldTarget, ".local", "share"))
+	setupIntegrationTestFlags(t, CLIFlags{packageDir: packageDir, targetDir: oldTarget})
+	manageCmd := newManageCommand()
+	manageCmd.SetContext(context.Background())
+	manageCmd.SetOut(&bytes.Buffer{})
+	manageCmd.SetArgs([]string{"vim", "zsh"})
+	require.NoError(t, manageCmd.Execute())
+
+	bundle := filepath.Join(tmpDir, "bundle.json")
+	out, err := runManifestSubcommand(t, packageDir, oldTarget, "export", bundle)
+	require.NoError(t, err)
+	assert.Contains(t, out, "Exported manifest")
+	info, err := os.Stat(bundle)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	// The new machine has not restored zsh yet
+	require.NoError(t, os.RemoveAll(filepath.Join(packageDir, "zsh")))
+	newTarget := filepath.Join(tmpDir, "new")
+	require.NoError(t, os.MkdirAll(newTarget, 0o755))
+
+	out, err = runManifestSubcommand(t, packageDir, newTarget, "import", bundle)
+	require.NoError(t, err)
+	assert.Contains(t, out, "Package zsh not found")
+	assert.Contains(t, out, "managed 1 package")
+
+	link := filepath.Join(newTarget, "vim", ".vimrc")
+	target, err := os.Readlink(link)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(packageDir, "vim", "dot-vimrc"), filepath.Join(filepath.Dir(link), target))
+}
+
+func TestManifestCommand_ImportRejectsUnknownVersion(t *testing.T) {
+	tmpDir := t.TempDir()
+	bundle := filepath.Join(tmpDir, "bundle.json")
+	require.NoError(t, os.WriteFile(bundle, []byte(`{"format":"dot-manifest-export","format_version":99}`), 0o600))
+
+	_, err := runManifestSubcommand(t, tmpDir, tmpDir, "import", bundle)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported manifest export version 99")
+}
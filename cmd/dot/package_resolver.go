@@ -3,6 +3,8 @@ package main
 import (
 	"os"
 	"path/filepath"
+
+	"github.com/yaklabco/dot/internal/bootstrap"
 )
 
 // resolvePackageDirectory resolves the package directory using hierarchical discovery.
@@ -10,8 +12,9 @@ import (
 // Resolution order (highest to lowest priority):
 //  1. Explicit --dir flag (if not ".")
 //  2. Environment variable: DOT_PACKAGE_DIR
-//  3. Current directory if it contains .dotbootstrap.yaml
-//  4. Parent directories up to home (searching for .dotbootstrap.yaml)
+//  3. Current directory if it contains a bootstrap file (.dotbootstrap.yaml,
+//     .yml, .toml or .json)
+//  4. Parent directories up to home (searching for a bootstrap file)
 //  5. Config file: directories.package
 //  6. Default: ~/.dotfiles
 func resolvePackageDirectory(explicitDir string) (string, error) {
@@ -25,7 +28,7 @@ func resolvePackageDirectory(explicitDir string) (string, error) {
 		return filepath.Abs(envDir)
 	}
 
-	// 3. Current directory if it contains .dotbootstrap.yaml
+	// 3. Current directory if it contains a bootstrap file
 	cwd, err := os.Getwd()
 	if err == nil && isDotfilesRepo(cwd) {
 		return cwd, nil
@@ -57,11 +60,14 @@ func resolvePackageDirectory(explicitDir string) (string, error) {
 }
 
 // isDotfilesRepo checks if the given directory is a dotfiles repository
-// by looking for .dotbootstrap.yaml file.
+// by looking for a bootstrap file in any supported format.
 func isDotfilesRepo(dir string) bool {
-	bootstrapPath := filepath.Join(dir, ".dotbootstrap.yaml")
-	_, err := os.Stat(bootstrapPath)
-	return err == nil
+	for _, name := range bootstrap.ConfigFileNames {
+		if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+			return true
+		}
+	}
+	return false
 }
 
 // findDotfilesRepo searches parent directories for a dotfiles repository.
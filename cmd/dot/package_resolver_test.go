@@ -109,6 +109,11 @@ func TestIsDotfilesRepo(t *testing.T) {
 
 	// Now it's a repo
 	assert.True(t, isDotfilesRepo(tmpDir))
+
+	// Other formats mark a repo too
+	tomlDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tomlDir, ".dotbootstrap.toml"), []byte("version = \"1.0\"\n"), 0644))
+	assert.True(t, isDotfilesRepo(tomlDir))
 }
 
 func TestFindDotfilesRepo_NotFound(t *testing.T) {
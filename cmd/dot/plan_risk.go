@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/yaklabco/dot/internal/cli/output"
+	"github.com/yaklabco/dot/internal/cli/prompt"
+	"github.com/yaklabco/dot/internal/cli/renderer"
+	"github.com/yaklabco/dot/pkg/dot"
+)
+
+// errRiskNotConfirmed is returned when the user declines a high-risk plan.
+var errRiskNotConfirmed = errors.New("high-risk plan not confirmed; rerun with --yes to apply it")
+
+// confirmRiskyPlan prints a warning banner for a high-risk plan and asks
+// whether to apply it, unless --yes is set. Plans below high risk pass
+// silently.
+func confirmRiskyPlan(ctx context.Context, cmd *cobra.Command, plan dot.Plan) error {
+	if plan.Metadata.RiskLevel != dot.RiskHigh {
+		return nil
+	}
+
+	w := cmd.ErrOrStderr()
+	formatter := output.NewFormatter(w, shouldUseColor(), outputTheme())
+	formatter.BlankLine()
+	formatter.Warning(fmt.Sprintf("High-risk plan: %d %s delete files or directories that are not backed up",
+		plan.Metadata.DestructiveOps, output.Pluralize(plan.Metadata.DestructiveOps, "operation", "operations")))
+	if plan.Metadata.BytesBackedUp > 0 {
+		formatter.Bullet(fmt.Sprintf("%s of existing files will be backed up", renderer.FormatBytes(plan.Metadata.BytesBackedUp)))
+	}
+	formatter.Bullet("Preview the changes with --dry-run")
+	formatter.BlankLine()
+
+	ok, err := prompt.Confirm(ctx, cmd.InOrStdin(), w, "Apply this plan?", false)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errRiskNotConfirmed
+	}
+	return nil
+}
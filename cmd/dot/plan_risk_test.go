@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupRiskyManage creates a shell package whose files each collide with
+// an existing file in the target, enough to make overwriting them high
+// risk, configures overwriting, and returns the package and target
+// directories.
+func setupRiskyManage(t *testing.T) (string, string) {
+	t.Helper()
+	tmpDir := t.TempDir()
+	packageDir := filepath.Join(tmpDir, "packages")
+	targetDir := filepath.Join(tmpDir, "target")
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(tmpDir, "config"))
+	t.Setenv("XDG_DATA_HOME", filepath.Join(tmpDir, "data"))
+
+	// Overwrite conflicting files instead of stopping at them
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte("symlinks:\n  overwrite: true\n"), 0644))
+	t.Setenv("DOT_CONFIG", configPath)
+
+	shell := filepath.Join(packageDir, "dot-shell")
+	require.NoError(t, os.MkdirAll(shell, 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(targetDir, ".shell"), 0755))
+	for i := range 6 {
+		name := fmt.Sprintf("rc%d", i)
+		require.NoError(t, os.WriteFile(filepath.Join(shell, name), []byte("new"), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(targetDir, ".shell", name), []byte("existing"), 0644))
+	}
+	return packageDir, targetDir
+}
+
+func TestManageCommand_HighRiskPlan(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		stdin     string
+		wantErr   bool
+		wantLinks bool
+	}{
+		{name: "declined", args: nil, stdin: "n\n", wantErr: true},
+		{name: "no answer", args: nil, stdin: "", wantErr: true},
+		{name: "confirmed", args: nil, stdin: "y\n", wantLinks: true},
+		{name: "yes flag", args: []string{"--yes"}, wantLinks: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			packageDir, targetDir := setupRiskyManage(t)
+
+			rootCmd := NewRootCommand("dev", "none", "unknown")
+			args := append([]string{"--dir", packageDir, "--target", targetDir, "--batch"}, tt.args...)
+			rootCmd.SetArgs(append(args, "manage", "dot-shell"))
+			if tt.stdin == "" && len(tt.args) > 0 {
+				rootCmd.SetIn(iotest.ErrReader(errors.New("stdin must not be read with --yes")))
+			} else {
+				rootCmd.SetIn(strings.NewReader(tt.stdin))
+			}
+			var stderr bytes.Buffer
+			rootCmd.SetErr(&stderr)
+			rootCmd.SetOut(&bytes.Buffer{})
+
+			err := rootCmd.Execute()
+			assert.Contains(t, stderr.String(), "High-risk plan: 6 operations")
+
+			info, lerr := os.Lstat(filepath.Join(targetDir, ".shell", "rc0"))
+			require.NoError(t, lerr)
+			if tt.wantErr {
+				require.ErrorIs(t, err, errRiskNotConfirmed)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantLinks, info.Mode()&os.ModeSymlink != 0, "existing files overwritten")
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/yaklabco/dot/internal/cli/output"
+	"github.com/yaklabco/dot/pkg/dot"
+)
+
+// newResolutionsCommand creates the resolutions command.
+func newResolutionsCommand() *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "resolutions [PACKAGE...]",
+		Short: "Show conflicts resolved automatically by policy",
+		Long: `Display the audit log of conflicts that dot resolved without asking,
+using the backup, overwrite, or skip policies.
+
+Each entry records when the conflict was resolved, the package involved,
+the conflicting path, the policy applied, and the backup location when the
+original file was backed up. The log is stored in the manifest and keeps
+the most recent entries.`,
+		Example: `  # Show all automatic resolutions
+  dot resolutions
+
+  # Show resolutions for one package as JSON
+  dot resolutions vim --format json`,
+		ValidArgsFunction: packageCompletion(true),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runResolutions(cmd, args, format)
+		},
+	}
+
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json)")
+
+	return cmd
+}
+
+// runResolutions handles the resolutions command.
+func runResolutions(cmd *cobra.Command, packages []string, format string) error {
+	if format != "text" && format != "json" {
+		return fmt.Errorf("invalid format %q (must be text or json)", format)
+	}
+
+	cfg, err := buildConfigWithCmd(cmd)
+	if err != nil {
+		return err
+	}
+	client, err := dot.NewClient(cfg)
+	if err != nil {
+		return formatError(err)
+	}
+
+	records, err := client.Resolutions(cmd.Context(), packages...)
+	if err != nil {
+		return formatError(err)
+	}
+
+	if format == "json" {
+		data, err := json.MarshalIndent(records, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal resolutions: %w", err)
+		}
+		_, err = fmt.Fprintln(cmd.OutOrStdout(), string(data))
+		return err
+	}
+
+	formatter := output.NewFormatter(cmd.OutOrStdout(), shouldUseColor(), outputTheme())
+	if len(records) == 0 {
+		formatter.Info("No conflicts have been resolved automatically")
+		return nil
+	}
+	for _, record := range records {
+		fmt.Fprintf(cmd.OutOrStdout(), "%s  %s\n",
+			record.ResolvedAt.Local().Format(time.DateTime), describeResolution(record))
+	}
+	return nil
+}
+
+// printResolutionSummary lists conflicts resolved automatically since start,
+// so a run's summary shows what policies changed and where its backups went.
+func printResolutionSummary(ctx context.Context, w io.Writer, client *dot.Client, start time.Time) {
+	records, err := client.Resolutions(ctx)
+	if err != nil {
+		return
+	}
+
+	var recent []dot.ResolutionRecord
+	for _, record := range records {
+		if !record.ResolvedAt.Before(start) {
+			recent = append(recent, record)
+		}
+	}
+	if len(recent) == 0 {
+		return
+	}
+
+	formatter := output.NewFormatter(w, shouldUseColor(), outputTheme())
+	formatter.Info(fmt.Sprintf("Resolved %s automatically:", formatCount(len(recent), "conflict", "conflicts")))
+	for _, record := range recent {
+		formatter.Bullet(describeResolution(record))
+	}
+	if dir := backupRunDir(recent); dir != "" {
+		formatter.Info("Backups saved to " + dir)
+	}
+	formatter.Info("Run 'dot resolutions' to review past resolutions")
+}
+
+// backupRunDir returns the directory the run's backups were written to, or
+// "" when nothing was backed up. Timestamped backups of one run share a
+// subdirectory of the backup directory.
+func backupRunDir(records []dot.ResolutionRecord) string {
+	for _, record := range records {
+		if record.BackupPath != "" {
+			return filepath.Dir(record.BackupPath)
+		}
+	}
+	return ""
+}
+
+// describeResolution renders a single resolution as one line of text.
+func describeResolution(record dot.ResolutionRecord) string {
+	line := record.Policy + " " + record.Path
+	if record.BackupPath != "" {
+		line += " -> " + record.BackupPath
+	}
+	if record.Package != "" {
+		line += " (" + record.Package + ")"
+	}
+	return line
+}
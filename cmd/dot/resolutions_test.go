@@ -0,0 +1,70 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yaklabco/dot/pkg/dot"
+)
+
+func TestNewResolutionsCommand(t *testing.T) {
+	cmd := newResolutionsCommand()
+
+	assert.Contains(t, cmd.Use, "resolutions")
+	assert.NotEmpty(t, cmd.Short)
+	assert.NotEmpty(t, cmd.Example)
+
+	formatFlag := cmd.Flags().Lookup("format")
+	require.NotNil(t, formatFlag)
+	assert.Equal(t, "text", formatFlag.DefValue)
+}
+
+func TestResolutionsCommand_InvalidFormat(t *testing.T) {
+	cmd := newResolutionsCommand()
+	cmd.SetArgs([]string{"--format", "yaml"})
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid format")
+}
+
+func TestDescribeResolution(t *testing.T) {
+	tests := []struct {
+		name   string
+		record dot.ResolutionRecord
+		want   string
+	}{
+		{
+			name: "backup with package",
+			record: dot.ResolutionRecord{
+				Package:    "vim",
+				Policy:     "backup",
+				Path:       "/home/user/.vimrc",
+				BackupPath: "/home/user/.dot-backup/20251007-103000/.vimrc.20251007-103000",
+			},
+			want: "backup /home/user/.vimrc -> /home/user/.dot-backup/20251007-103000/.vimrc.20251007-103000 (vim)",
+		},
+		{
+			name:   "skip without package",
+			record: dot.ResolutionRecord{Policy: "skip", Path: "/home/user/.zshrc"},
+			want:   "skip /home/user/.zshrc",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, describeResolution(tt.record))
+		})
+	}
+}
+
+func TestBackupRunDir(t *testing.T) {
+	records := []dot.ResolutionRecord{
+		{Policy: "skip", Path: "/home/user/.zshrc"},
+		{Policy: "backup", Path: "/home/user/.vimrc", BackupPath: "/home/user/.dot-backup/20251007-103000/.vimrc.20251007-103000"},
+	}
+	assert.Equal(t, "/home/user/.dot-backup/20251007-103000", backupRunDir(records))
+	assert.Empty(t, backupRunDir(records[:1]), "nothing was backed up")
+}
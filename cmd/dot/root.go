@@ -3,15 +3,20 @@ package main
 import (
 	"context"
 	"fmt"
+	"io"
 	"log/slog"
 	"math"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"time"
 
 	"golang.org/x/term"
 
 	"github.com/spf13/cobra"
+	"github.com/yaklabco/dot/internal/cli/prompt"
+	"github.com/yaklabco/dot/internal/cli/render"
 	"github.com/yaklabco/dot/internal/cli/terminal"
 	"github.com/yaklabco/dot/pkg/dot"
 )
@@ -20,10 +25,14 @@ import (
 // This struct is populated during flag parsing and passed explicitly to functions
 // that need flag values, eliminating global mutable state.
 type CLIFlags struct {
-	packageDir     string
-	targetDir      string
-	backupDir      string
-	dryRun         bool
+	packageDir    string
+	targetDir     string
+	backupDir     string
+	profilePreset string
+	dryRun        bool
+	// dryRunSet records whether --dry-run was given, so that
+	// --dry-run=false overrides operations.dry_run
+	dryRunSet      bool
 	verbose        int
 	quiet          bool
 	logJSON        bool
@@ -36,6 +45,9 @@ type CLIFlags struct {
 	noDefaults     bool
 	noDotignore    bool
 	batch          bool
+	concurrency    int
+	assumeYes      bool
+	noCache        bool
 }
 
 // cliFlags is the package-level flags instance used during command execution.
@@ -43,6 +55,20 @@ type CLIFlags struct {
 // For test isolation, use WithCLIFlags to set flags in context instead of mutating this directly.
 var cliFlags CLIFlags
 
+// activeOutput caches the color settings resolved from configuration for
+// the current command. It is reset by NewRootCommand.
+var activeOutput *outputSettings
+
+// outputSettings are the color and width settings from the output config
+// section.
+type outputSettings struct {
+	theme render.Theme
+	// color is the output.color setting: auto, always or never.
+	color string
+	// width is the output.width setting; 0 detects the width.
+	width int
+}
+
 // cliContext holds the root context with CLI flags for use by GetCLIFlags.
 // This is set during command execution and provides backward compatibility.
 var cliContext context.Context
@@ -73,6 +99,7 @@ func getPackageLevelCLIFlags() *CLIFlags {
 func NewRootCommand(version, commit, date string) *cobra.Command {
 	// Reset flags for clean initialization (important for tests)
 	cliFlags = CLIFlags{}
+	activeOutput = nil
 	rootCmd := &cobra.Command{
 		Use:   "dot",
 		Short: "Modern symlink manager for dotfiles",
@@ -90,7 +117,9 @@ comprehensive conflict detection, and incremental updates.`,
 			if ctx == nil {
 				ctx = context.Background()
 			}
+			cliFlags.dryRunSet = cmd.Flags().Changed("dry-run")
 			ctx = WithCLIFlags(ctx, &cliFlags)
+			ctx = prompt.WithAssumeYes(ctx, cliFlags.assumeYes)
 			cmd.SetContext(ctx)
 
 			// Update package-level context for GetCLIFlags() backward compatibility
@@ -127,6 +156,9 @@ comprehensive conflict detection, and incremental updates.`,
 		"Target directory for symlinks")
 	rootCmd.PersistentFlags().StringVar(&cliFlags.backupDir, "backup-dir", "",
 		"Directory for backup files (default: <target>/.dot-backup)")
+	rootCmd.PersistentFlags().StringVar(&cliFlags.profilePreset, "profile-preset", "",
+		"Apply the named cli_profiles preset of command defaults")
+	_ = rootCmd.RegisterFlagCompletionFunc("profile-preset", profilePresetCompletion)
 	rootCmd.PersistentFlags().BoolVarP(&cliFlags.dryRun, "dry-run", "n", false,
 		"Show what would be done without applying changes")
 	rootCmd.PersistentFlags().CountVarP(&cliFlags.verbose, "verbose", "v",
@@ -153,6 +185,15 @@ comprehensive conflict detection, and incremental updates.`,
 		"Disable default ignore patterns (.git, .DS_Store, etc.)")
 	rootCmd.PersistentFlags().BoolVar(&cliFlags.noDotignore, "no-dotignore", false,
 		"Disable reading per-package .dotignore files")
+	rootCmd.PersistentFlags().BoolVar(&cliFlags.noCache, "no-cache", false,
+		"Scan packages and check links without reading or updating the cache")
+	rootCmd.PersistentFlags().IntVar(&cliFlags.concurrency, "concurrency", 0,
+		"Maximum operations to run in parallel (default: operations.max_parallel or CPU count)")
+	rootCmd.PersistentFlags().BoolVarP(&cliFlags.assumeYes, "yes", "y", false,
+		"Answer yes to all confirmation prompts")
+	rootCmd.PersistentFlags().BoolVar(&cliFlags.assumeYes, "assume-yes", false,
+		"Alias for --yes")
+	_ = rootCmd.PersistentFlags().MarkHidden("assume-yes")
 
 	// Add subcommands
 	rootCmd.AddCommand(
@@ -161,9 +202,15 @@ comprehensive conflict detection, and incremental updates.`,
 		newRemanageCommand(),
 		newAdoptCommand(),
 		newStatusCommand(),
+		newDiffCommand(),
 		newListCommand(),
+		newResolutionsCommand(),
 		newDoctorCommand(),
+		newVerifyCommand(),
+		newManifestCommand(),
 		newConfigCommand(),
+		newLogsCommand(),
+		newCacheCommand(),
 		newCloneCommand(),
 		newUpgradeCommand(version),
 	)
@@ -172,7 +219,7 @@ comprehensive conflict detection, and incremental updates.`,
 }
 
 // buildConfig creates a dot.Config from CLI flags and adapters.
-// Precedence: flags (if set) > config file > defaults
+// Precedence: flags (if set) > env > config file > profile preset > defaults
 func buildConfig() (dot.Config, error) {
 	return buildConfigWithFlags(GetCLIFlags(), nil)
 }
@@ -191,6 +238,13 @@ func buildIgnoreConfig(flags *CLIFlags, extCfg *dot.ExtendedConfig) (bool, bool,
 		perPackageIgnore = extCfg.Ignore.PerPackageIgnore
 		interactiveLargeFiles = extCfg.Ignore.InteractiveLargeFiles
 		ignorePatterns = append(ignorePatterns, extCfg.Ignore.Patterns...)
+		// Deprecated overrides act as negations after the patterns, as
+		// config upgrade rewrites them
+		for _, pattern := range extCfg.Ignore.Overrides {
+			if !slices.Contains(ignorePatterns, "!"+pattern) {
+				ignorePatterns = append(ignorePatterns, "!"+pattern)
+			}
+		}
 		maxFileSize = extCfg.Ignore.MaxFileSize
 	}
 
@@ -274,6 +328,9 @@ func buildConfigWithFlags(flags *CLIFlags, cmd *cobra.Command) (dot.Config, erro
 	if err != nil {
 		return dot.Config{}, fmt.Errorf("load configuration: %w", err)
 	}
+	if extCfg != nil {
+		logger = configureLogDestination(flags, extCfg, logger)
+	}
 
 	// Start with config file values
 	var packageDir, targetDir, backupDir, manifestDir string
@@ -289,7 +346,7 @@ func buildConfigWithFlags(flags *CLIFlags, cmd *cobra.Command) (dot.Config, erro
 	}
 
 	// Resolve package directory using hierarchical discovery
-	// Priority: flag > env > cwd/.dotbootstrap.yaml > parent search > config > default
+	// Priority: flag > env > cwd bootstrap file > parent search > config > default
 	packageDir, err = resolvePackageDirectory(flags.packageDir)
 	if err != nil {
 		return dot.Config{}, fmt.Errorf("resolve package directory: %w", err)
@@ -328,18 +385,31 @@ func buildConfigWithFlags(flags *CLIFlags, cmd *cobra.Command) (dot.Config, erro
 		PackageDir:               packageDir,
 		TargetDir:                targetDir,
 		BackupDir:                backupDir,
+		LinkMode:                 linkMode(extCfg),
+		Folding:                  folding(extCfg),
 		Backup:                   backup,
 		Overwrite:                overwrite,
+		BackupStrategy:           backupStrategy(extCfg),
+		BackupSuffix:             backupSuffix(extCfg),
 		ManifestDir:              manifestDir,
-		DryRun:                   flags.dryRun,
+		DryRun:                   dryRun(flags, extCfg),
 		Verbosity:                flags.verbose,
 		Translate:                translateConfig(extCfg),
 		PackageNameMapping:       packageNameMapping(extCfg),
+		PackageMappings:          packageMappings(extCfg),
 		UseDefaultIgnorePatterns: useDefaults,
 		IgnorePatterns:           ignorePatterns,
 		PerPackageIgnore:         perPackageIgnore,
 		MaxFileSize:              maxFileSize,
 		InteractiveLargeFiles:    interactiveLargeFiles,
+		CacheDir:                 cacheDir(flags),
+		TransactionSize:          transactionSize(extCfg),
+		RetryAttempts:            retryAttempts(extCfg),
+		RetryBackoff:             retryBackoff(extCfg),
+		Concurrency:              concurrency(flags, extCfg),
+		DoctorCategories:         doctorCategories(extCfg),
+		Hooks:                    hooks(extCfg),
+		Theme:                    themeName(extCfg),
 		FS:                       fs,
 		Logger:                   logger,
 	}
@@ -382,7 +452,7 @@ func loadConfigWithRepoPriority(packageDirFlag, xdgConfigPath string) (*dot.Exte
 		if _, err := os.Stat(repoConfigPath); err == nil {
 			// Repository config exists - use it
 			loader := dot.NewConfigLoader("dot", repoConfigPath)
-			cfg, err := loader.LoadWithEnv()
+			cfg, err := loader.LoadWithPreset(GetCLIFlags().profilePreset)
 			if err == nil {
 				return cfg, nil
 			}
@@ -393,7 +463,24 @@ func loadConfigWithRepoPriority(packageDirFlag, xdgConfigPath string) (*dot.Exte
 
 	// Fall back to XDG location
 	loader := dot.NewConfigLoader("dot", xdgConfigPath)
-	return loader.LoadWithEnv()
+	return loader.LoadWithPreset(GetCLIFlags().profilePreset)
+}
+
+// profilePresetCompletion completes --profile-preset with the presets
+// defined in the cli_profiles config section.
+func profilePresetCompletion(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	extCfg, err := loadConfigWithRepoPriority(GetCLIFlags().packageDir, getConfigFilePath())
+	if err != nil || extCfg == nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var names []string
+	for _, name := range extCfg.CLIProfileNames() {
+		if strings.HasPrefix(name, toComplete) {
+			names = append(names, name)
+		}
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
 }
 
 // createLogger creates appropriate logger based on CLI flags (legacy wrapper).
@@ -416,6 +503,53 @@ func createLoggerWithFlags(flags *CLIFlags) dot.Logger {
 	return dot.NewTextLogger(os.Stderr, level)
 }
 
+// configureLogDestination replaces the default logger with one that
+// writes logging.format to logging.destination. File logs are written at
+// logging.level, or lower if -v requests more detail. On error the
+// fallback logger is kept so logging problems never block a command.
+// The log file stays open for the lifetime of the process.
+func configureLogDestination(flags *CLIFlags, extCfg *dot.ExtendedConfig, fallback dot.Logger) dot.Logger {
+	if flags.quiet {
+		return fallback
+	}
+
+	logging := extCfg.Logging
+	level := verbosityToLevel(flags.verbose)
+	switch logging.Destination {
+	case "file":
+		var configured slog.Level
+		if configured.UnmarshalText([]byte(logging.Level)) == nil && configured < level {
+			level = configured
+		}
+	case "", "stderr", "stdout":
+	default:
+		return fallback
+	}
+
+	format := logging.Format
+	if flags.logJSON {
+		format = "json"
+	}
+
+	logger, _, err := dot.NewConfiguredLogger(dot.LogConfig{
+		Level:       level,
+		Format:      format,
+		Destination: logging.Destination,
+		File:        logging.File,
+		Rotation: dot.LogRotationConfig{
+			MaxSize:    int64(logging.MaxSizeMB) * 1024 * 1024,
+			MaxAge:     time.Duration(logging.MaxAgeDays) * 24 * time.Hour,
+			MaxBackups: logging.MaxBackups,
+		},
+		RedactPaths: logging.RedactPaths,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v; logging to stderr\n", err)
+		return fallback
+	}
+	return logger
+}
+
 // verbosityToLevel converts verbosity count to log level.
 // Level mapping:
 //   - 0 (no flag): ERROR only - suppress all logs, show only user messages
@@ -456,30 +590,86 @@ func argsWithUsage(validator cobra.PositionalArgs) cobra.PositionalArgs {
 	}
 }
 
-// shouldUseColor determines if color should be enabled based on CLI flags and terminal detection.
+// shouldUseColor determines if color should be enabled for commands without
+// a --color flag, based on CLI flags, the output.color setting, the
+// environment, and terminal detection.
 func shouldUseColor() bool {
-	return shouldUseColorWithFlags(GetCLIFlags())
+	return shouldColorize("auto")
 }
 
-// shouldUseColorWithFlags determines color usage from explicit CLI flags.
+// shouldUseColorWithFlags determines color usage from explicit CLI flags and
+// the environment, without consulting configuration.
 func shouldUseColorWithFlags(flags *CLIFlags) bool {
-	// Check --no-color flag first (highest precedence)
-	if flags.noColor {
-		return false
+	return shouldColorizeWithFlags(flags, "auto")
+}
+
+// outputTheme returns the color theme selected by the output.theme config
+// setting with the output.colors overrides applied. The configuration is
+// read once per command.
+func outputTheme() render.Theme {
+	return loadOutputSettings().theme
+}
+
+// outputWidth returns the width to wrap output to: output.width, then
+// COLUMNS, then the terminal width, then a default.
+func outputWidth() int {
+	return terminal.Width(loadOutputSettings().width)
+}
+
+// loadOutputSettings resolves the output settings from configuration, once
+// per command.
+func loadOutputSettings() outputSettings {
+	if activeOutput != nil {
+		return *activeOutput
+	}
+	extCfg, err := loadConfigWithRepoPriority(GetCLIFlags().packageDir, getConfigFilePath())
+	if err != nil {
+		extCfg = nil
 	}
+	settings := resolveOutputSettings(extCfg, os.Stderr)
+	activeOutput = &settings
+	return settings
+}
 
-	// Respect NO_COLOR environment variable (https://no-color.org/)
-	if os.Getenv("NO_COLOR") != "" {
-		return false
+// resolveOutputSettings derives the output settings from extCfg, which may
+// be nil. When output.color is never the theme and its overrides are
+// ignored.
+func resolveOutputSettings(extCfg *dot.ExtendedConfig, w io.Writer) outputSettings {
+	if extCfg == nil {
+		return outputSettings{theme: render.DefaultTheme()}
+	}
+	if extCfg.Output.Color == "never" {
+		theme, _ := render.ThemeByName(render.ThemeNoColor)
+		return outputSettings{theme: theme, color: "never", width: extCfg.Output.Width}
 	}
 
-	// Check if stdout is a terminal
-	return term.IsTerminal(terminal.FdInt(os.Stdout.Fd()))
+	theme := resolveTheme(extCfg.Output.Theme, w)
+	for _, role := range extCfg.Output.Colors.Roles() {
+		if role.Code != "" {
+			theme.SetRole(role.Name, role.Code)
+		}
+	}
+	return outputSettings{theme: theme, color: extCfg.Output.Color, width: extCfg.Output.Width}
+}
+
+// resolveTheme looks up a theme by name, warning on w and falling back to
+// the default theme if the name is unknown.
+func resolveTheme(name string, w io.Writer) render.Theme {
+	theme, ok := render.ThemeByName(name)
+	if !ok {
+		fmt.Fprintf(w, "Warning: unknown output theme %q, using %s (available: %s)\n",
+			name, render.ThemeDefault, strings.Join(render.ThemeNames(), ", "))
+	}
+	return theme
 }
 
 // shouldColorize determines if output should be colorized based on the color flag.
-// Precedence: --no-color flag > NO_COLOR env > --color flag > auto
+// Precedence: --no-color flag > --color flag > output.color config >
+// FORCE_COLOR/CLICOLOR_FORCE env > NO_COLOR env > terminal detection
 func shouldColorize(color string) bool {
+	if color != "always" && color != "never" {
+		color = loadOutputSettings().color
+	}
 	return shouldColorizeWithFlags(GetCLIFlags(), color)
 }
 
@@ -490,24 +680,21 @@ func shouldColorizeWithFlags(flags *CLIFlags, color string) bool {
 		return false
 	}
 
-	// Respect NO_COLOR environment variable (https://no-color.org/)
-	if os.Getenv("NO_COLOR") != "" {
-		return false
-	}
-
-	// Check --color flag value
+	// An explicit always or never wins over the environment
 	switch color {
 	case "always":
 		return true
 	case "never":
 		return false
-	case "auto":
-		// Check if stdout is a terminal using portable detection
-		return term.IsTerminal(terminal.FdInt(os.Stdout.Fd()))
-	default:
-		// Default to auto behavior
-		return term.IsTerminal(terminal.FdInt(os.Stdout.Fd()))
 	}
+
+	// FORCE_COLOR, CLICOLOR_FORCE and NO_COLOR (https://no-color.org/)
+	if enabled, ok := terminal.ColorFromEnv(); ok {
+		return enabled
+	}
+
+	// Check if stdout is a terminal using portable detection
+	return term.IsTerminal(terminal.FdInt(os.Stdout.Fd()))
 }
 
 // translateConfig returns the translate setting from config.
@@ -529,6 +716,169 @@ func packageNameMapping(extCfg *dot.ExtendedConfig) bool {
 	return extCfg.Dotfile.PackageNameMapping
 }
 
+// packageMappings returns the packages.mappings setting from config, or nil
+// when there is no config file.
+func packageMappings(extCfg *dot.ExtendedConfig) map[string]string {
+	if extCfg == nil {
+		return nil
+	}
+	return extCfg.Packages.Mappings
+}
+
+// themeName returns the output.theme setting from config, or "" when there
+// is no config file.
+func themeName(extCfg *dot.ExtendedConfig) string {
+	if extCfg == nil {
+		return ""
+	}
+	return extCfg.Output.Theme
+}
+
+// concurrency returns the --concurrency flag when set, otherwise the
+// operations.max_parallel setting from config. Zero lets the client default
+// to the CPU count.
+func concurrency(flags *CLIFlags, extCfg *dot.ExtendedConfig) int {
+	if flags.concurrency != 0 || extCfg == nil {
+		return flags.concurrency
+	}
+	return extCfg.Operations.MaxParallel
+}
+
+// cacheDir returns the cache directory, or "" when --no-cache disables
+// caching.
+func cacheDir(flags *CLIFlags) string {
+	if flags.noCache {
+		return ""
+	}
+	return dot.DefaultCacheDir()
+}
+
+// transactionSize returns the operations.transaction_size setting from
+// config, or 0 (single transaction) when there is no config file.
+func transactionSize(extCfg *dot.ExtendedConfig) int {
+	if extCfg == nil {
+		return 0
+	}
+	return extCfg.Operations.TransactionSize
+}
+
+// retryAttempts returns the operations.retry.max_attempts setting from
+// config, or the default when there is no config file.
+func retryAttempts(extCfg *dot.ExtendedConfig) int {
+	if extCfg == nil {
+		extCfg = dot.DefaultExtendedConfig()
+	}
+	return extCfg.Operations.Retry.MaxAttempts
+}
+
+// retryBackoff returns the operations.retry.base_backoff_ms setting from
+// config, or the default when there is no config file.
+func retryBackoff(extCfg *dot.ExtendedConfig) time.Duration {
+	if extCfg == nil {
+		extCfg = dot.DefaultExtendedConfig()
+	}
+	return time.Duration(extCfg.Operations.Retry.BaseBackoffMS) * time.Millisecond
+}
+
+// dryRun returns --dry-run when it was given, and otherwise the
+// operations.dry_run setting from config.
+func dryRun(flags *CLIFlags, extCfg *dot.ExtendedConfig) bool {
+	if flags.dryRun || flags.dryRunSet || extCfg == nil {
+		return flags.dryRun
+	}
+	return extCfg.Operations.DryRun
+}
+
+// folding returns the symlinks.folding setting from config, or false when
+// there is no config file.
+func folding(extCfg *dot.ExtendedConfig) bool {
+	if extCfg == nil {
+		return false
+	}
+	return extCfg.Symlinks.Folding
+}
+
+// linkMode maps the symlinks.mode config value to a link mode. Validation
+// has already rejected unknown values, so anything else is relative.
+func linkMode(extCfg *dot.ExtendedConfig) dot.LinkMode {
+	if extCfg == nil {
+		return dot.LinkRelative
+	}
+	switch extCfg.Symlinks.Mode {
+	case "absolute":
+		return dot.LinkAbsolute
+	case "auto":
+		return dot.LinkAuto
+	default:
+		return dot.LinkRelative
+	}
+}
+
+// backupStrategy maps the symlinks.backup_strategy config value to a
+// backup strategy. Validation has already rejected unknown values.
+func backupStrategy(extCfg *dot.ExtendedConfig) dot.BackupStrategy {
+	if extCfg == nil || extCfg.Symlinks.BackupStrategy == "" {
+		return dot.BackupTimestamped
+	}
+	return dot.BackupStrategy(extCfg.Symlinks.BackupStrategy)
+}
+
+// backupSuffix returns the symlinks.backup_suffix config value.
+func backupSuffix(extCfg *dot.ExtendedConfig) string {
+	if extCfg == nil {
+		return ""
+	}
+	return extCfg.Symlinks.BackupSuffix
+}
+
+// doctorCategories converts the doctor.categories config section into
+// triage pattern categories.
+func doctorCategories(extCfg *dot.ExtendedConfig) []dot.PatternCategory {
+	if extCfg == nil || len(extCfg.Doctor.Categories) == 0 {
+		return nil
+	}
+	categories := make([]dot.PatternCategory, 0, len(extCfg.Doctor.Categories))
+	for _, c := range extCfg.Doctor.Categories {
+		categories = append(categories, dot.PatternCategory{
+			Name:        c.Name,
+			Description: c.Description,
+			Patterns:    c.Patterns,
+			Confidence:  c.Confidence,
+		})
+	}
+	return categories
+}
+
+// hooks converts the hooks config section into client hooks.
+func hooks(extCfg *dot.ExtendedConfig) dot.Hooks {
+	if extCfg == nil {
+		return dot.Hooks{}
+	}
+	convert := func(configured []dot.HookConfig) []dot.Hook {
+		if len(configured) == 0 {
+			return nil
+		}
+		converted := make([]dot.Hook, 0, len(configured))
+		for _, h := range configured {
+			converted = append(converted, dot.Hook{Command: h.Command, Shell: h.Shell, Packages: h.Packages})
+		}
+		return converted
+	}
+	return dot.Hooks{
+		PreManage:    convert(extCfg.Hooks.PreManage),
+		PostManage:   convert(extCfg.Hooks.PostManage),
+		PreUnmanage:  convert(extCfg.Hooks.PreUnmanage),
+		PostUnmanage: convert(extCfg.Hooks.PostUnmanage),
+	}
+}
+
+// applyNoHooksFlag clears the configured hooks when --no-hooks is set.
+func applyNoHooksFlag(cmd *cobra.Command, cfg *dot.Config) {
+	if noHooks, _ := cmd.Flags().GetBool("no-hooks"); noHooks {
+		cfg.Hooks = dot.Hooks{}
+	}
+}
+
 // performStartupVersionCheck performs a non-blocking version check at startup.
 func performStartupVersionCheck(currentVersion string) {
 	// Don't check if this is a dev build
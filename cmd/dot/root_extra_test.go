@@ -4,6 +4,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yaklabco/dot/pkg/dot"
 )
 
 func TestNewRootCommand_Structure(t *testing.T) {
@@ -34,3 +37,13 @@ func TestNewRootCommand_HasGlobalFlags(t *testing.T) {
 	assert.NotNil(t, cmd.PersistentFlags().Lookup("quiet"))
 	assert.NotNil(t, cmd.PersistentFlags().Lookup("log-json"))
 }
+
+func TestBuildIgnoreConfig_OverridesFollowPatterns(t *testing.T) {
+	extCfg := dot.DefaultExtendedConfig()
+	extCfg.Ignore.Patterns = []string{"*.log", "!keep.conf"}
+	extCfg.Ignore.Overrides = []string{"keep.conf", "important.log"}
+
+	_, _, _, patterns, _, err := buildIgnoreConfig(&CLIFlags{}, extCfg)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"*.log", "!keep.conf", "!important.log"}, patterns)
+}
@@ -45,6 +45,8 @@ func TestRootCommand_GlobalFlags(t *testing.T) {
 	require.NotNil(t, rootCmd.PersistentFlags().Lookup("verbose"))
 	require.NotNil(t, rootCmd.PersistentFlags().Lookup("quiet"))
 	require.NotNil(t, rootCmd.PersistentFlags().Lookup("log-json"))
+	require.NotNil(t, rootCmd.PersistentFlags().Lookup("yes"))
+	require.NotNil(t, rootCmd.PersistentFlags().Lookup("assume-yes"))
 }
 
 func TestRootCommand_ShortFlags(t *testing.T) {
@@ -56,6 +58,7 @@ func TestRootCommand_ShortFlags(t *testing.T) {
 	require.NotNil(t, rootCmd.PersistentFlags().ShorthandLookup("n"))
 	require.NotNil(t, rootCmd.PersistentFlags().ShorthandLookup("v"))
 	require.NotNil(t, rootCmd.PersistentFlags().ShorthandLookup("q"))
+	require.NotNil(t, rootCmd.PersistentFlags().ShorthandLookup("y"))
 }
 
 func TestParseFileSize(t *testing.T) {
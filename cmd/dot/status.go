@@ -1,12 +1,17 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 
 	"github.com/yaklabco/dot/internal/cli/renderer"
+	"github.com/yaklabco/dot/internal/cli/terminal"
 	"github.com/yaklabco/dot/pkg/dot"
 )
 
@@ -35,8 +40,20 @@ func newStatusCommand() *cobra.Command {
 			return formatError(err)
 		}
 
+		if detailed, _ := cmd.Flags().GetBool("json"); detailed {
+			return runStatusDetailed(cmd, client, args)
+		}
+		if orphansOnly, _ := cmd.Flags().GetBool("orphans-only"); orphansOnly {
+			return runStatusOrphans(cmd, client, args, extCfg)
+		}
+
+		packages, err := client.ExpandInstalledPackages(cmd.Context(), args)
+		if err != nil {
+			return formatError(err)
+		}
+
 		// Get status
-		status, err := client.Status(cmd.Context(), args...)
+		status, err := client.Status(cmd.Context(), packages...)
 		if err != nil {
 			return formatError(err)
 		}
@@ -44,14 +61,9 @@ func newStatusCommand() *cobra.Command {
 		// Determine colorization
 		colorize := shouldColorize(color)
 
-		// Create renderer with table_style from config
-		tableStyle := ""
-		if extCfg != nil {
-			tableStyle = extCfg.Output.TableStyle
-		}
-		r, err := renderer.NewRenderer(format, colorize, tableStyle)
+		r, err := newStatusRenderer(cmd, format, colorize, extCfg)
 		if err != nil {
-			return fmt.Errorf("invalid format: %w", err)
+			return err
 		}
 
 		// Render status
@@ -79,6 +91,8 @@ func newStatusCommand() *cobra.Command {
 func NewStatusCommand(cfg *dot.Config) *cobra.Command {
 	var format string
 	var color string
+	var orphansOnly bool
+	var detailed bool
 
 	cmd := &cobra.Command{
 		Use:   "status [PACKAGE...]",
@@ -86,18 +100,41 @@ func NewStatusCommand(cfg *dot.Config) *cobra.Command {
 		Long: `Display the current installation state for specified packages.
 
 If no packages are specified, shows status for all installed packages.
-The status includes installation timestamp, number of links, and link paths.`,
+Package arguments may be globs matched against installed package names,
+such as 'shell-*' or '{vim,zsh}'.
+The status includes installation timestamp, number of links, and link paths.
+
+With --json, prints every managed link instead of the summary: its path
+in the target directory, the source it resolves to, whether it is relative
+or absolute, and whether it is ok or broken. Only the manifest and the
+links themselves are read, so nothing is planned.
+
+With --orphans-only, scans the target directory instead and lists symlinks
+dot does not manage, each with its target and detected category. This is a
+lighter-weight alternative to 'dot doctor' when auditing stray links.`,
 		Example: `  # Show status for all packages
   dot status
 
   # Show status for specific packages
   dot status vim tmux
 
+  # Show status as a table with broken and orphaned link counts
+  dot status --format=table
+
   # Show status in JSON format
   dot status --format=json
 
+  # List every link with its source and state as JSON
+  dot status --json
+
   # Show status with colors disabled
-  dot status --color=never`,
+  dot status --color=never
+
+  # List orphaned symlinks with their targets and categories
+  dot status --orphans-only
+
+  # List orphaned symlinks as JSON
+  dot status --orphans-only --format=json`,
 		ValidArgsFunction: packageCompletion(true), // Complete with installed packages
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Load extended config for table_style
@@ -110,8 +147,20 @@ The status includes installation timestamp, number of links, and link paths.`,
 				return formatError(err)
 			}
 
+			if detailed {
+				return runStatusDetailed(cmd, client, args)
+			}
+			if orphansOnly {
+				return runStatusOrphans(cmd, client, args, extCfg)
+			}
+
+			packages, err := client.ExpandInstalledPackages(cmd.Context(), args)
+			if err != nil {
+				return formatError(err)
+			}
+
 			// Get status
-			status, err := client.Status(cmd.Context(), args...)
+			status, err := client.Status(cmd.Context(), packages...)
 			if err != nil {
 				return formatError(err)
 			}
@@ -119,14 +168,9 @@ The status includes installation timestamp, number of links, and link paths.`,
 			// Determine colorization
 			colorize := shouldColorize(color)
 
-			// Create renderer with table_style from config
-			tableStyle := ""
-			if extCfg != nil {
-				tableStyle = extCfg.Output.TableStyle
-			}
-			r, err := renderer.NewRenderer(format, colorize, tableStyle)
+			r, err := newStatusRenderer(cmd, format, colorize, extCfg)
 			if err != nil {
-				return fmt.Errorf("invalid format: %w", err)
+				return err
 			}
 
 			// Render status
@@ -145,6 +189,112 @@ The status includes installation timestamp, number of links, and link paths.`,
 
 	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml, table)")
 	cmd.Flags().StringVar(&color, "color", "auto", "Colorize output (auto, always, never)")
+	cmd.Flags().BoolVar(&orphansOnly, "orphans-only", false, "List orphaned symlinks instead of package status")
+	cmd.Flags().String("scan-mode", "scoped", "Orphan scan mode with --orphans-only (scoped, deep)")
+	cmd.Flags().BoolVar(&detailed, "json", false, "Print every link with its source, link mode and state as JSON")
 
 	return cmd
 }
+
+// newStatusRenderer creates the renderer for package status, using the
+// output.table_style and output.width settings. Tables written anywhere but
+// a terminal fall back to the simple style, which has no box drawing.
+func newStatusRenderer(cmd *cobra.Command, format string, colorize bool, extCfg *dot.ExtendedConfig) (renderer.Renderer, error) {
+	tableStyle := ""
+	width := 0
+	if extCfg != nil {
+		tableStyle = extCfg.Output.TableStyle
+		width = extCfg.Output.Width
+	}
+	if format == "table" && !isOutputTerminal(cmd) {
+		tableStyle = "simple"
+	}
+	r, err := renderer.NewRendererWithWidth(format, colorize, tableStyle, width)
+	if err != nil {
+		return nil, fmt.Errorf("invalid format: %w", err)
+	}
+	return r, nil
+}
+
+// isOutputTerminal checks if the command's output stream is a terminal.
+func isOutputTerminal(cmd *cobra.Command) bool {
+	if f, ok := cmd.OutOrStdout().(*os.File); ok {
+		return term.IsTerminal(terminal.FdInt(f.Fd()))
+	}
+	return false
+}
+
+// runStatusDetailed prints the per-link status of the packages as JSON.
+func runStatusDetailed(cmd *cobra.Command, client *dot.Client, args []string) error {
+	if orphansOnly, _ := cmd.Flags().GetBool("orphans-only"); orphansOnly {
+		return fmt.Errorf("--json cannot be combined with --orphans-only")
+	}
+	if cmd.Flags().Changed("format") {
+		return fmt.Errorf("--json cannot be combined with --format")
+	}
+
+	packages, err := client.ExpandInstalledPackages(cmd.Context(), args)
+	if err != nil {
+		return formatError(err)
+	}
+	status, err := client.StatusDetailed(cmd.Context(), packages...)
+	if err != nil {
+		return formatError(err)
+	}
+
+	if err := renderStatusDetailedJSON(cmd.OutOrStdout(), status); err != nil {
+		return err
+	}
+	if len(status.NotFound) > 0 {
+		return fmt.Errorf("package not found: %s", strings.Join(status.NotFound, ", "))
+	}
+	return nil
+}
+
+// renderStatusDetailedJSON writes status as indented JSON.
+func renderStatusDetailedJSON(w io.Writer, status dot.DetailedStatus) error {
+	data, err := json.MarshalIndent(status, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal status: %w", err)
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+// runStatusOrphans lists orphaned symlinks found by a scan of the target
+// directory.
+func runStatusOrphans(cmd *cobra.Command, client *dot.Client, args []string, extCfg *dot.ExtendedConfig) error {
+	if len(args) > 0 {
+		return fmt.Errorf("--orphans-only does not accept package arguments")
+	}
+
+	format, _ := cmd.Flags().GetString("format")
+	color, _ := cmd.Flags().GetString("color")
+	scanMode, _ := cmd.Flags().GetString("scan-mode")
+	if scanMode == "off" {
+		return fmt.Errorf("--orphans-only requires scan-mode scoped or deep")
+	}
+	scanCfg, err := buildScanConfig(scanMode, 10)
+	if err != nil {
+		return err
+	}
+
+	orphans, err := client.DoctorListOrphans(cmd.Context(), scanCfg)
+	if err != nil {
+		return formatError(err)
+	}
+
+	tableStyle := ""
+	if extCfg != nil {
+		tableStyle = extCfg.Output.TableStyle
+	}
+	r, err := renderer.NewRenderer(format, shouldColorize(color), tableStyle)
+	if err != nil {
+		return fmt.Errorf("invalid format: %w", err)
+	}
+
+	if err := r.RenderOrphans(cmd.OutOrStdout(), orphans); err != nil {
+		return fmt.Errorf("render failed: %w", err)
+	}
+	return nil
+}
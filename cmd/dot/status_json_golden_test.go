@@ -0,0 +1,54 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/yaklabco/dot/internal/adapters"
+	"github.com/yaklabco/dot/internal/cli/golden"
+	"github.com/yaklabco/dot/pkg/dot"
+)
+
+// TestStatusDetailedJSON_Golden checks the per-link JSON shape for a
+// package with a healthy, a relative, a dangling and a missing link.
+func TestStatusDetailedJSON_Golden(t *testing.T) {
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	require.NoError(t, fs.MkdirAll(ctx, "/dotfiles/vim", 0o755))
+	require.NoError(t, fs.WriteFile(ctx, "/dotfiles/vim/dot-vimrc", []byte("set nu"), 0o644))
+	require.NoError(t, fs.WriteFile(ctx, "/dotfiles/vim/dot-gvimrc", []byte("set go="), 0o644))
+	require.NoError(t, fs.WriteFile(ctx, "/dotfiles/vim/dot-exrc", []byte("set ai"), 0o644))
+	require.NoError(t, fs.WriteFile(ctx, "/dotfiles/vim/dot-viminfo", []byte(""), 0o644))
+	require.NoError(t, fs.MkdirAll(ctx, "/home", 0o755))
+
+	client, err := dot.NewClient(dot.Config{
+		PackageDir: "/dotfiles",
+		TargetDir:  "/home",
+		LinkMode:   dot.LinkAbsolute,
+		FS:         fs,
+		Logger:     adapters.NewNoopLogger(),
+	})
+	require.NoError(t, err)
+	require.NoError(t, client.Manage(ctx, "vim"))
+
+	// Relative link to the same source
+	require.NoError(t, fs.Remove(ctx, "/home/.exrc"))
+	require.NoError(t, fs.Symlink(ctx, "../dotfiles/vim/dot-exrc", "/home/.exrc"))
+	// Managed link whose source was deleted
+	require.NoError(t, fs.Remove(ctx, "/dotfiles/vim/dot-gvimrc"))
+	// Managed link that was deleted
+	require.NoError(t, fs.Remove(ctx, "/home/.viminfo"))
+
+	status, err := client.StatusDetailed(ctx)
+	require.NoError(t, err)
+	require.Len(t, status.Packages, 1)
+	status.Packages[0].InstalledAt = time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	var buf bytes.Buffer
+	require.NoError(t, renderStatusDetailedJSON(&buf, status))
+
+	golden.New(t, "status").Assert("status_json_detailed", buf.Bytes())
+}
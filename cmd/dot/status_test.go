@@ -2,6 +2,9 @@ package main
 
 import (
 	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -34,6 +37,18 @@ func TestStatusCommand_Flags(t *testing.T) {
 	colorFlag := cmd.Flags().Lookup("color")
 	require.NotNil(t, colorFlag)
 	assert.Equal(t, "auto", colorFlag.DefValue)
+
+	orphansFlag := cmd.Flags().Lookup("orphans-only")
+	require.NotNil(t, orphansFlag)
+	assert.Equal(t, "false", orphansFlag.DefValue)
+
+	scanFlag := cmd.Flags().Lookup("scan-mode")
+	require.NotNil(t, scanFlag)
+	assert.Equal(t, "scoped", scanFlag.DefValue)
+
+	jsonFlag := cmd.Flags().Lookup("json")
+	require.NotNil(t, jsonFlag)
+	assert.Equal(t, "false", jsonFlag.DefValue)
 }
 
 func TestStatusCommand_OutputFormat(t *testing.T) {
@@ -112,3 +127,135 @@ func TestStatusCommand_NonexistentPackageReturnsError(t *testing.T) {
 	require.Error(t, err, "status should return error for nonexistent packages")
 	assert.Contains(t, err.Error(), "not found")
 }
+
+func TestStatusCommand_OrphansOnlyJSON(t *testing.T) {
+	setupGlobalCfg(t)
+
+	packageDir := t.TempDir()
+	targetDir := t.TempDir()
+	npmDir := filepath.Join(t.TempDir(), ".npm")
+	require.NoError(t, os.MkdirAll(npmDir, 0755))
+	npmFile := filepath.Join(npmDir, "tsc")
+	require.NoError(t, os.WriteFile(npmFile, []byte("tsc"), 0644))
+	require.NoError(t, os.Symlink(npmFile, filepath.Join(targetDir, ".tsc")))
+
+	rootCmd := NewRootCommand("dev", "none", "unknown")
+	rootCmd.SetArgs([]string{"status", "--dir", packageDir, "--target", targetDir,
+		"--orphans-only", "--scan-mode", "deep", "--format", "json"})
+
+	out := &bytes.Buffer{}
+	rootCmd.SetOut(out)
+	rootCmd.SetErr(&bytes.Buffer{})
+	require.NoError(t, rootCmd.Execute())
+
+	var orphans []dot.OrphanInfo
+	require.NoError(t, json.Unmarshal(out.Bytes(), &orphans))
+	require.Len(t, orphans, 1)
+	assert.Equal(t, ".tsc", orphans[0].Path)
+	assert.Equal(t, npmFile, orphans[0].Target)
+	assert.Equal(t, "npm", orphans[0].Category)
+	assert.Equal(t, "high", orphans[0].Confidence)
+}
+
+func TestStatusCommand_OrphansOnlyRejectsPackages(t *testing.T) {
+	setupGlobalCfg(t)
+
+	rootCmd := NewRootCommand("dev", "none", "unknown")
+	rootCmd.SetArgs([]string{"status", "--orphans-only", "vim"})
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+
+	err := rootCmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not accept package arguments")
+}
+
+func TestStatusCommand_PackageGlobs(t *testing.T) {
+	setupGlobalCfg(t)
+
+	packageDir := t.TempDir()
+	targetDir := t.TempDir()
+	for _, pkg := range []string{"shell-bash", "shell-zsh", "vim"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(packageDir, pkg), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(packageDir, pkg, "dot-"+pkg+"rc"), []byte("x"), 0644))
+	}
+
+	run := func(args ...string) (string, error) {
+		rootCmd := NewRootCommand("dev", "none", "unknown")
+		rootCmd.SetArgs(append(args, "--dir", packageDir, "--target", targetDir))
+		out := &bytes.Buffer{}
+		rootCmd.SetOut(out)
+		rootCmd.SetErr(&bytes.Buffer{})
+		err := rootCmd.Execute()
+		return out.String(), err
+	}
+
+	_, err := run("manage", "shell-*")
+	require.NoError(t, err)
+
+	out, err := run("status", "--format", "json", "shell-?ash", "{vim,*-zsh}")
+	require.NoError(t, err, "a brace glob needs only one alternative to match")
+	var status dot.Status
+	require.NoError(t, json.Unmarshal([]byte(out), &status))
+	require.Len(t, status.Packages, 2)
+	assert.Equal(t, "shell-bash", status.Packages[0].Name)
+	assert.Equal(t, "shell-zsh", status.Packages[1].Name)
+
+	_, err = run("status", "vim*")
+	require.Error(t, err, "vim was never managed")
+
+	_, err = run("manage", "emacs-*")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `no packages match "emacs-*"`)
+}
+
+func TestStatusCommand_DetailedJSON(t *testing.T) {
+	setupGlobalCfg(t)
+
+	packageDir := t.TempDir()
+	targetDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(packageDir, "vim"), 0755))
+	source := filepath.Join(packageDir, "vim", "dot-vimrc")
+	require.NoError(t, os.WriteFile(source, []byte("set nu"), 0644))
+
+	run := func(args ...string) (string, error) {
+		rootCmd := NewRootCommand("dev", "none", "unknown")
+		rootCmd.SetArgs(append(args, "--dir", packageDir, "--target", targetDir))
+		out := &bytes.Buffer{}
+		rootCmd.SetOut(out)
+		rootCmd.SetErr(&bytes.Buffer{})
+		err := rootCmd.Execute()
+		return out.String(), err
+	}
+
+	_, err := run("manage", "vim")
+	require.NoError(t, err)
+
+	out, err := run("status", "--json")
+	require.NoError(t, err)
+	var status dot.DetailedStatus
+	require.NoError(t, json.Unmarshal([]byte(out), &status))
+	require.Len(t, status.Packages, 1)
+	require.Len(t, status.Packages[0].Links, 1)
+	link := status.Packages[0].Links[0]
+	assert.Equal(t, filepath.Join(targetDir, "vim", ".vimrc"), link.Target)
+	assert.Equal(t, dot.LinkStateOK, link.State)
+	assert.Equal(t, "relative", link.Mode)
+	wantSource, err := filepath.EvalSymlinks(source)
+	require.NoError(t, err)
+	gotSource, err := filepath.EvalSymlinks(link.Source)
+	require.NoError(t, err)
+	assert.Equal(t, wantSource, gotSource)
+
+	_, err = run("status", "--json", "emacs")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "package not found: emacs")
+
+	_, err = run("status", "--json", "--orphans-only")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--json cannot be combined with --orphans-only")
+
+	_, err = run("status", "--json", "--format", "yaml")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--json cannot be combined with --format")
+}
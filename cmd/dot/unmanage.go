@@ -1,9 +1,10 @@
 package main
 
 import (
-	"bufio"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -12,6 +13,7 @@ import (
 	"golang.org/x/term"
 
 	"github.com/yaklabco/dot/internal/cli/output"
+	"github.com/yaklabco/dot/internal/cli/prompt"
 	"github.com/yaklabco/dot/internal/cli/render"
 	"github.com/yaklabco/dot/internal/cli/renderer"
 	"github.com/yaklabco/dot/internal/cli/terminal"
@@ -24,7 +26,7 @@ func newUnmanageCommand() *cobra.Command {
 	var noRestore bool
 	var cleanup bool
 	var all bool
-	var yes bool
+	var force bool
 
 	cmd := &cobra.Command{
 		Use:   "unmanage PACKAGE [PACKAGE...]",
@@ -38,11 +40,26 @@ their original locations. Managed packages only have their symlinks removed.
 Cleanup mode removes orphaned packages from the manifest without modifying 
 the filesystem - useful when packages no longer exist.
 
+Package arguments may be globs matched against managed package names, such
+as 'shell-*', 'vim?' or '{bash,zsh}'; quote them so the shell does not
+expand them first. A glob that matches no package is an error.
+
+--purge deletes the package directories. It first lists every file and
+directory that will be permanently removed and asks for confirmation
+unless --yes or --force is specified; with --dry-run it only lists them.
+
 Use --all to remove all managed packages at once. This requires confirmation
-unless --yes or --force is specified.`,
+unless --yes or --force is specified.
+
+Commands in hooks.pre_unmanage run before the links are removed and abort
+the run if one fails; hooks.post_unmanage commands run afterwards and only
+warn on failure. --no-hooks skips both.`,
 		Example: `  # Remove package and restore adopted files
   dot unmanage ssh
 
+  # Remove every managed shell-* package
+  dot unmanage 'shell-*'
+
   # Remove package and delete package directory
   dot unmanage ssh --purge
 
@@ -71,7 +88,7 @@ unless --yes or --force is specified.`,
 			return nil
 		}),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runUnmanage(cmd, args, purge, noRestore, cleanup, all, yes)
+			return runUnmanage(cmd, args, purge, noRestore, cleanup, all, force)
 		},
 		ValidArgsFunction: packageCompletion(true), // Complete with installed packages
 	}
@@ -80,18 +97,19 @@ unless --yes or --force is specified.`,
 	cmd.Flags().BoolVar(&noRestore, "no-restore", false, "Don't restore adopted files (leave in package directory)")
 	cmd.Flags().BoolVar(&cleanup, "cleanup", false, "Remove orphaned manifest entries (packages with missing links/directories)")
 	cmd.Flags().BoolVar(&all, "all", false, "Remove all managed packages")
-	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip confirmation prompt")
-	cmd.Flags().BoolVar(&yes, "force", false, "Skip confirmation prompt (alias for --yes)")
+	cmd.Flags().BoolVar(&force, "force", false, "Skip confirmation prompt (same as --yes)")
+	cmd.Flags().Bool("no-hooks", false, "Do not run pre_unmanage and post_unmanage hooks")
 
 	return cmd
 }
 
 // runUnmanage handles the unmanage command execution.
-func runUnmanage(cmd *cobra.Command, args []string, purge, noRestore, cleanup, all, yes bool) error {
+func runUnmanage(cmd *cobra.Command, args []string, purge, noRestore, cleanup, all, force bool) error {
 	cfg, err := buildConfigWithCmd(cmd)
 	if err != nil {
 		return err
 	}
+	applyNoHooksFlag(cmd, &cfg)
 
 	client, err := dot.NewClient(cfg)
 	if err != nil {
@@ -112,14 +130,17 @@ func runUnmanage(cmd *cobra.Command, args []string, purge, noRestore, cleanup, a
 
 	// Handle --all flag
 	if all {
-		return runUnmanageAll(cmd, cfg, client, ctx, opts, yes)
+		return runUnmanageAll(cmd, cfg, client, ctx, opts, force || prompt.AssumeYes(ctx))
 	}
 
-	packages := args
+	packages, err := client.ExpandInstalledPackages(ctx, args)
+	if err != nil {
+		return err
+	}
 
 	// If dry-run mode, render the plan instead of executing
 	if cfg.DryRun {
-		plan, err := client.PlanUnmanage(ctx, packages...)
+		plan, err := client.PlanUnmanageWithOptions(ctx, opts, packages...)
 		if err != nil {
 			return err
 		}
@@ -134,7 +155,40 @@ func runUnmanage(cmd *cobra.Command, args []string, purge, noRestore, cleanup, a
 			return err
 		}
 
-		return rend.RenderPlan(os.Stdout, plan)
+		effects, err := client.DryRunPlan(ctx, plan)
+		if err != nil {
+			return err
+		}
+
+		if err := rend.RenderDryRun(os.Stdout, plan, effects); err != nil {
+			return err
+		}
+		if purge {
+			paths, err := client.PermanentDeletions(ctx, plan)
+			if err != nil {
+				return err
+			}
+			listPermanentDeletions(cmd.OutOrStdout(), paths, "Would permanently delete")
+		}
+		return nil
+	}
+
+	if purge {
+		// Purging deletes the package directories themselves, so always
+		// show exactly what goes and ask first
+		plan, err := client.PlanUnmanageWithOptions(ctx, opts, packages...)
+		if err != nil {
+			return err
+		}
+		if err := confirmPurge(ctx, cmd, client, plan, force); err != nil {
+			return err
+		}
+	} else if plan, err := client.PlanUnmanageWithOptions(ctx, opts, packages...); err == nil {
+		// Ask before applying a plan that deletes many files; planning
+		// errors are reported by the unmanage run itself
+		if err := confirmRiskyPlan(ctx, cmd, plan); err != nil {
+			return err
+		}
 	}
 
 	// Execute unmanage with options
@@ -145,15 +199,15 @@ func runUnmanage(cmd *cobra.Command, args []string, purge, noRestore, cleanup, a
 	{
 		// Determine colorization from global flag
 		colorize := shouldUseColor()
-		formatter := output.NewFormatter(cmd.OutOrStdout(), colorize)
-		colorizer := render.NewColorizer(colorize)
+		formatter := output.NewFormatter(cmd.OutOrStdout(), colorize, outputTheme())
+		colorizer := render.NewColorizer(colorize, outputTheme())
 
 		if cleanup {
 			if len(packages) > 0 {
 				fmt.Fprintf(cmd.OutOrStdout(), "%s Cleaned up %d %s from manifest\n",
 					colorizer.Success("✓"),
 					len(packages),
-					pluralize(len(packages), "orphaned package", "orphaned packages"))
+					output.Pluralize(len(packages), "orphaned package", "orphaned packages"))
 			} else {
 				fmt.Fprintln(cmd.OutOrStdout(), "No orphaned packages found in manifest")
 			}
@@ -161,17 +215,17 @@ func runUnmanage(cmd *cobra.Command, args []string, purge, noRestore, cleanup, a
 			fmt.Fprintf(cmd.OutOrStdout(), "%s Unmanaged and purged %d %s\n",
 				colorizer.Success("✓"),
 				len(packages),
-				pluralize(len(packages), "package", "packages"))
+				output.Pluralize(len(packages), "package", "packages"))
 		} else if opts.Restore {
 			fmt.Fprintf(cmd.OutOrStdout(), "%s Unmanaged and restored %d %s\n",
 				colorizer.Success("✓"),
 				len(packages),
-				pluralize(len(packages), "package", "packages"))
+				output.Pluralize(len(packages), "package", "packages"))
 		} else {
 			fmt.Fprintf(cmd.OutOrStdout(), "%s Unmanaged %d %s\n",
 				colorizer.Success("✓"),
 				len(packages),
-				pluralize(len(packages), "package", "packages"))
+				output.Pluralize(len(packages), "package", "packages"))
 		}
 		formatter.BlankLine()
 	}
@@ -203,6 +257,11 @@ func runUnmanageAll(cmd *cobra.Command, cfg dot.Config, client *dot.Client, ctx
 	// Show detailed summary in dry-run mode or when confirming
 	if cfg.DryRun || !skipConfirm {
 		displayUnmanageAllSummary(status.Packages, opts, cfg.PackageDir, flags)
+		if opts.Purge {
+			if err := listPurgeAll(ctx, cmd, client, status.Packages, opts, cfg.DryRun); err != nil {
+				return err
+			}
+		}
 	}
 
 	// Request confirmation unless --yes/--force/--dry-run
@@ -210,7 +269,7 @@ func runUnmanageAll(cmd *cobra.Command, cfg dot.Config, client *dot.Client, ctx
 		if !isTerminal(cmd) {
 			return fmt.Errorf("stdin is not a terminal; use --yes to confirm")
 		}
-		if !confirmAction(cmd, "Proceed with unmanaging all packages?") {
+		if !confirmAction(ctx, cmd, "Proceed with unmanaging all packages?") {
 			fmt.Println("Operation cancelled")
 			return nil
 		}
@@ -227,10 +286,34 @@ func runUnmanageAll(cmd *cobra.Command, cfg dot.Config, client *dot.Client, ctx
 	return nil
 }
 
+// listPurgeAll lists the files and directories purging every package
+// would permanently delete.
+func listPurgeAll(ctx context.Context, cmd *cobra.Command, client *dot.Client, packages []dot.PackageInfo, opts dot.UnmanageOptions, dryRun bool) error {
+	names := make([]string, 0, len(packages))
+	for _, pkg := range packages {
+		names = append(names, pkg.Name)
+	}
+	plan, err := client.PlanUnmanageWithOptions(ctx, opts, names...)
+	if err != nil {
+		return err
+	}
+	paths, err := client.PermanentDeletions(ctx, plan)
+	if err != nil {
+		return err
+	}
+
+	heading := "Purge will permanently delete"
+	if dryRun {
+		heading = "Would permanently delete"
+	}
+	listPermanentDeletions(cmd.OutOrStdout(), paths, heading)
+	return nil
+}
+
 // displayUnmanageAllSummary shows what will be unmanaged.
 func displayUnmanageAllSummary(packages []dot.PackageInfo, opts dot.UnmanageOptions, packageDir string, flags *CLIFlags) {
 	colorize := shouldUseColorWithFlags(flags)
-	c := render.NewColorizer(colorize)
+	c := render.NewColorizer(colorize, outputTheme())
 
 	fmt.Printf("This will unmanage %s:\n", c.Accent(fmt.Sprintf("%d package(s)", len(packages))))
 	for _, pkg := range packages {
@@ -259,7 +342,7 @@ func getOperationColor(operation string) func(string) string {
 // getOperationColorWithFlags returns the appropriate color function for an operation using explicit flags.
 func getOperationColorWithFlags(operation string, flags *CLIFlags) func(string) string {
 	colorize := shouldUseColorWithFlags(flags)
-	c := render.NewColorizer(colorize)
+	c := render.NewColorizer(colorize, outputTheme())
 
 	switch operation {
 	case "purge":
@@ -296,9 +379,9 @@ func getUnmanageOperation(pkg dot.PackageInfo, opts dot.UnmanageOptions) string
 // reportUnmanageAllResults displays the final results.
 func reportUnmanageAllResults(count int, opts dot.UnmanageOptions, dryRun bool, flags *CLIFlags) {
 	colorize := shouldUseColorWithFlags(flags)
-	colorizer := render.NewColorizer(colorize)
+	colorizer := render.NewColorizer(colorize, outputTheme())
 
-	packageText := fmt.Sprintf("%d %s", count, pluralize(count, "package", "packages"))
+	packageText := fmt.Sprintf("%d %s", count, output.Pluralize(count, "package", "packages"))
 
 	if dryRun {
 		operation := "unmanage"
@@ -327,6 +410,50 @@ func reportUnmanageAllResults(count int, opts dot.UnmanageOptions, dryRun bool,
 	}
 }
 
+// errPurgeNotConfirmed is returned when the user declines a purge.
+var errPurgeNotConfirmed = errors.New("purge not confirmed; rerun with --yes to delete these files")
+
+// confirmPurge lists the files and directories plan would permanently
+// delete and asks whether to go ahead, unless force or --yes is set.
+func confirmPurge(ctx context.Context, cmd *cobra.Command, client *dot.Client, plan dot.Plan, force bool) error {
+	if force || prompt.AssumeYes(ctx) {
+		return nil
+	}
+	paths, err := client.PermanentDeletions(ctx, plan)
+	if err != nil {
+		return err
+	}
+	if len(paths) == 0 {
+		return nil
+	}
+
+	w := cmd.ErrOrStderr()
+	listPermanentDeletions(w, paths, "Purge will permanently delete")
+	ok, err := prompt.Confirm(ctx, cmd.InOrStdin(), w, "Delete these files?", false)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errPurgeNotConfirmed
+	}
+	return nil
+}
+
+// listPermanentDeletions writes a heading followed by one path per line.
+func listPermanentDeletions(w io.Writer, paths []string, heading string) {
+	if len(paths) == 0 {
+		return
+	}
+	formatter := output.NewFormatter(w, shouldUseColor(), outputTheme())
+	formatter.BlankLine()
+	formatter.Warning(fmt.Sprintf("%s %s:", heading,
+		formatCount(len(paths), "file or directory", "files and directories")))
+	for _, path := range paths {
+		formatter.Bullet(path)
+	}
+	formatter.BlankLine()
+}
+
 // isTerminal checks if the command's input stream is a terminal.
 func isTerminal(cmd *cobra.Command) bool {
 	in := cmd.InOrStdin()
@@ -336,14 +463,9 @@ func isTerminal(cmd *cobra.Command) bool {
 	return false
 }
 
-// confirmAction prompts the user for confirmation using the command's input stream.
-func confirmAction(cmd *cobra.Command, prompt string) bool {
-	fmt.Printf("%s [y/N]: ", prompt)
-	reader := bufio.NewReader(cmd.InOrStdin())
-	response, err := reader.ReadString('\n')
-	if err != nil {
-		return false
-	}
-	response = strings.ToLower(strings.TrimSpace(response))
-	return response == "y" || response == "yes"
+// confirmAction prompts the user for confirmation using the command's input
+// and output streams, defaulting to no.
+func confirmAction(ctx context.Context, cmd *cobra.Command, message string) bool {
+	confirmed, err := prompt.Confirm(ctx, cmd.InOrStdin(), cmd.OutOrStdout(), message, false)
+	return err == nil && confirmed
 }
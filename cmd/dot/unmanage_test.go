@@ -2,10 +2,14 @@ package main
 
 import (
 	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/yaklabco/dot/internal/cli/render"
 	"github.com/yaklabco/dot/pkg/dot"
 )
@@ -100,7 +104,7 @@ func TestIsTerminal(t *testing.T) {
 
 func TestRenderColorizer(t *testing.T) {
 	// Test that colorizer functions work correctly
-	c := render.NewColorizer(false)
+	c := render.NewColorizer(false, render.DefaultTheme())
 
 	tests := []struct {
 		name string
@@ -122,3 +126,84 @@ func TestRenderColorizer(t *testing.T) {
 		})
 	}
 }
+
+func TestUnmanageCommand_PurgeConfirmation(t *testing.T) {
+	setup := func(t *testing.T) (packageDir, targetDir string) {
+		t.Helper()
+		setupGlobalCfg(t)
+		packageDir = t.TempDir()
+		targetDir = t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(packageDir, "vim", "dot-vim"), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(packageDir, "vim", "dot-vimrc"), []byte("set nu"), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(packageDir, "vim", "dot-vim", "colors.vim"), []byte("hi"), 0644))
+		return packageDir, targetDir
+	}
+	run := func(packageDir, targetDir, stdin string, args ...string) (string, string, error) {
+		rootCmd := NewRootCommand("dev", "none", "unknown")
+		rootCmd.SetArgs(append(args, "--dir", packageDir, "--target", targetDir))
+		rootCmd.SetIn(strings.NewReader(stdin))
+		out, errOut := &bytes.Buffer{}, &bytes.Buffer{}
+		rootCmd.SetOut(out)
+		rootCmd.SetErr(errOut)
+		err := rootCmd.Execute()
+		return out.String(), errOut.String(), err
+	}
+
+	t.Run("declined purge deletes nothing", func(t *testing.T) {
+		packageDir, targetDir := setup(t)
+		_, _, err := run(packageDir, targetDir, "", "manage", "vim")
+		require.NoError(t, err)
+
+		_, errOut, err := run(packageDir, targetDir, "n\n", "unmanage", "--purge", "vim")
+		require.ErrorIs(t, err, errPurgeNotConfirmed)
+		assert.Contains(t, errOut, "Purge will permanently delete 4 files and directories")
+		assert.Contains(t, errOut, filepath.Join(packageDir, "vim", "dot-vim", "colors.vim"))
+
+		assert.FileExists(t, filepath.Join(packageDir, "vim", "dot-vimrc"))
+		_, err = os.Lstat(filepath.Join(targetDir, "vim", ".vimrc"))
+		assert.NoError(t, err, "links must stay when the purge is declined")
+	})
+
+	t.Run("no input declines", func(t *testing.T) {
+		packageDir, targetDir := setup(t)
+		_, _, err := run(packageDir, targetDir, "", "manage", "vim")
+		require.NoError(t, err)
+
+		_, _, err = run(packageDir, targetDir, "", "unmanage", "--purge", "vim")
+		require.ErrorIs(t, err, errPurgeNotConfirmed)
+		assert.DirExists(t, filepath.Join(packageDir, "vim"))
+	})
+
+	t.Run("dry run lists without deleting", func(t *testing.T) {
+		packageDir, targetDir := setup(t)
+		_, _, err := run(packageDir, targetDir, "", "manage", "vim")
+		require.NoError(t, err)
+
+		out, _, err := run(packageDir, targetDir, "", "unmanage", "--purge", "--dry-run", "vim")
+		require.NoError(t, err)
+		assert.Contains(t, out, "Would permanently delete 4 files and directories")
+		assert.Contains(t, out, filepath.Join(packageDir, "vim", "dot-vimrc"))
+		assert.DirExists(t, filepath.Join(packageDir, "vim"))
+	})
+
+	t.Run("confirmed purge deletes the package", func(t *testing.T) {
+		packageDir, targetDir := setup(t)
+		_, _, err := run(packageDir, targetDir, "", "manage", "vim")
+		require.NoError(t, err)
+
+		_, _, err = run(packageDir, targetDir, "y\n", "unmanage", "--purge", "vim")
+		require.NoError(t, err)
+		assert.NoDirExists(t, filepath.Join(packageDir, "vim"))
+	})
+
+	t.Run("yes skips the prompt", func(t *testing.T) {
+		packageDir, targetDir := setup(t)
+		_, _, err := run(packageDir, targetDir, "", "manage", "vim")
+		require.NoError(t, err)
+
+		_, errOut, err := run(packageDir, targetDir, "", "unmanage", "--purge", "--yes", "vim")
+		require.NoError(t, err)
+		assert.NotContains(t, errOut, "permanently delete")
+		assert.NoDirExists(t, filepath.Join(packageDir, "vim"))
+	})
+}
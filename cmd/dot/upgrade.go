@@ -1,21 +1,25 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/yaklabco/dot/internal/cli/output"
+	"github.com/yaklabco/dot/internal/cli/prompt"
 	"github.com/yaklabco/dot/internal/cli/render"
 	"github.com/yaklabco/dot/pkg/dot"
 )
 
 // newUpgradeCommand creates the upgrade command.
 func newUpgradeCommand(version string) *cobra.Command {
-	var yes bool
 	var checkOnly bool
+	var refreshDetection bool
 
 	cmd := &cobra.Command{
 		Use:   "upgrade",
@@ -30,7 +34,8 @@ Configuration (in ~/.config/dot/config.yaml):
   update:
     package_manager: auto    # auto, brew, apt, yum, pacman, dnf, zypper, manual
     repository: yaklabco/dot
-    include_prerelease: false`,
+    include_prerelease: false
+    detection_cache_ttl: 24  # hours to reuse the detected package manager`,
 		Example: `  # Check for and install updates
   dot upgrade
 
@@ -38,20 +43,23 @@ Configuration (in ~/.config/dot/config.yaml):
   dot upgrade --check-only
 
   # Skip confirmation prompt
-  dot upgrade --yes`,
+  dot upgrade --yes
+
+  # Detect the package manager again instead of using the cached result
+  dot upgrade --refresh-detection`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runUpgrade(version, yes, checkOnly)
+			return runUpgrade(cmd.Context(), version, checkOnly, refreshDetection)
 		},
 	}
 
-	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip confirmation prompt")
 	cmd.Flags().BoolVar(&checkOnly, "check-only", false, "Check for updates without installing")
+	cmd.Flags().BoolVar(&refreshDetection, "refresh-detection", false, "Detect the package manager again instead of using the cached result")
 
 	return cmd
 }
 
 // runUpgrade handles the upgrade command execution.
-func runUpgrade(currentVersion string, yes, checkOnly bool) error {
+func runUpgrade(ctx context.Context, currentVersion string, checkOnly, refreshDetection bool) error {
 	// Load configuration
 	cfg, err := loadConfig()
 	if err != nil {
@@ -68,7 +76,7 @@ func runUpgrade(currentVersion string, yes, checkOnly bool) error {
 	}
 
 	colorize := shouldUseColor()
-	c := render.NewColorizer(colorize)
+	c := render.NewColorizer(colorize, outputTheme())
 
 	if !hasUpdate {
 		fmt.Printf("%s You are already running the latest version (%s)\n",
@@ -85,7 +93,8 @@ func runUpgrade(currentVersion string, yes, checkOnly bool) error {
 	}
 
 	// Resolve package manager
-	pkgMgr, err := dot.ResolvePackageManager(cfg.Update.PackageManager)
+	ttl := time.Duration(cfg.Update.DetectionCacheTTL) * time.Hour
+	pkgMgr, err := dot.ResolvePackageManagerCached(cfg.Update.PackageManager, dot.DefaultStateDir(), ttl, refreshDetection)
 	if err != nil {
 		return fmt.Errorf("resolve package manager: %w", err)
 	}
@@ -107,29 +116,69 @@ func runUpgrade(currentVersion string, yes, checkOnly bool) error {
 	fmt.Printf("Upgrade command: %s\n\n", c.Dim(strings.Join(cmd, " ")))
 
 	// Confirm upgrade
-	if !yes && !confirmUpgrade() {
+	if !confirmUpgrade(ctx) {
 		fmt.Println("Upgrade cancelled.")
 		return nil
 	}
 
 	// Execute upgrade
 	fmt.Printf("\n%s Upgrading...\n\n", c.Info("→"))
+	configDir := filepath.Dir(getConfigFilePath())
+	record := dot.UpgradeRecord{Attempted: latestRelease.TagName}
 	if err := executeUpgradeCommand(cmd); err != nil {
+		_ = dot.RecordUpgrade(configDir, record)
 		return err
 	}
 
+	// Verify the upgraded binary actually runs the new version
+	resulting, verifyErr := verifyUpgradedBinary(latestRelease.TagName)
+	record.Resulting = resulting
+	record.Verified = verifyErr == nil
+	if err := dot.RecordUpgrade(configDir, record); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to record upgrade: %v\n", err)
+	}
+
 	// Create formatter for output
-	formatter := output.NewFormatter(os.Stdout, colorize)
-	colorizer := render.NewColorizer(colorize)
+	formatter := output.NewFormatter(os.Stdout, colorize, outputTheme())
 
 	fmt.Fprintln(os.Stdout)
-	formatter.SuccessSimple("Upgrade completed")
-	fmt.Fprintf(os.Stdout, "Run %s to verify the new version.\n", colorizer.Accent("dot --version"))
+	if verifyErr != nil {
+		formatter.Error("Upgrade verification failed")
+		displayReinstallInstructions(pkgMgr, latestRelease.HTMLURL)
+		return verifyErr
+	}
+
+	formatter.SuccessSimple(fmt.Sprintf("Upgraded to %s", resulting))
 	formatter.BlankLine()
 
 	return nil
 }
 
+// verifyUpgradedBinary runs the installed binary's --version and checks it
+// reports at least the attempted version. It returns the reported version.
+func verifyUpgradedBinary(attempted string) (string, error) {
+	binary, err := dot.FindInstalledBinary()
+	if err != nil {
+		return "", err
+	}
+	return dot.VerifyUpgrade(binary, attempted)
+}
+
+// displayReinstallInstructions tells the user how to reinstall dot after
+// an upgrade that could not be verified.
+func displayReinstallInstructions(pkgMgr dot.PackageManager, releaseURL string) {
+	colorize := shouldUseColor()
+	c := render.NewColorizer(colorize, outputTheme())
+
+	fmt.Println(c.Bold("Reinstall Instructions:"))
+	if cmd := pkgMgr.ReinstallCommand(); len(cmd) > 0 {
+		fmt.Printf("\n  Reinstall dot with %s:\n", pkgMgr.Name())
+		fmt.Printf("  %s\n", c.Accent(strings.Join(cmd, " ")))
+	}
+	fmt.Printf("\n  Or download the release directly:\n")
+	fmt.Printf("  %s\n\n", c.Accent(releaseURL))
+}
+
 // loadConfig loads the configuration from the config file.
 func loadConfig() (*dot.ExtendedConfig, error) {
 	configPath := getConfigFilePath()
@@ -140,7 +189,7 @@ func loadConfig() (*dot.ExtendedConfig, error) {
 // displayUpdateInfo shows update information and release notes.
 func displayUpdateInfo(currentVersion string, release *dot.GitHubRelease) {
 	colorize := shouldUseColor()
-	c := render.NewColorizer(colorize)
+	c := render.NewColorizer(colorize, outputTheme())
 
 	fmt.Printf("\n%s A new version is available!\n\n", c.Info("ℹ"))
 	fmt.Printf("  Current version:  %s\n", c.Accent(currentVersion))
@@ -170,20 +219,18 @@ func displayUpdateInfo(currentVersion string, release *dot.GitHubRelease) {
 // displayManualInstructions shows manual upgrade instructions.
 func displayManualInstructions(releaseURL string) {
 	colorize := shouldUseColor()
-	c := render.NewColorizer(colorize)
+	c := render.NewColorizer(colorize, outputTheme())
 
 	fmt.Println(c.Bold("Manual Upgrade Instructions:"))
 	fmt.Printf("\n  Visit the release page to download the latest version:\n")
 	fmt.Printf("  %s\n\n", c.Accent(releaseURL))
 }
 
-// confirmUpgrade prompts the user for upgrade confirmation.
-func confirmUpgrade() bool {
-	fmt.Printf("Do you want to upgrade now? [y/N]: ")
-	var response string
-	fmt.Scanln(&response)
-	response = strings.ToLower(strings.TrimSpace(response))
-	return response == "y" || response == "yes"
+// confirmUpgrade prompts the user for upgrade confirmation unless --yes
+// was given.
+func confirmUpgrade(ctx context.Context) bool {
+	confirmed, err := prompt.Confirm(ctx, os.Stdin, os.Stdout, "Do you want to upgrade now?", false)
+	return err == nil && confirmed
 }
 
 // executeUpgradeCommand executes the upgrade command directly without shell invocation.
@@ -19,13 +19,9 @@ func TestNewUpgradeCommand(t *testing.T) {
 	assert.NotEmpty(t, cmd.Example)
 
 	// Check flags
-	assert.NotNil(t, cmd.Flags().Lookup("yes"))
 	assert.NotNil(t, cmd.Flags().Lookup("check-only"))
 
 	// Verify flag defaults
-	yesFlag := cmd.Flags().Lookup("yes")
-	assert.Equal(t, "false", yesFlag.DefValue)
-
 	checkOnlyFlag := cmd.Flags().Lookup("check-only")
 	assert.Equal(t, "false", checkOnlyFlag.DefValue)
 }
@@ -53,10 +49,6 @@ func TestUpgradeCommand_Help(t *testing.T) {
 func TestUpgradeCommand_FlagShortcuts(t *testing.T) {
 	cmd := newUpgradeCommand("1.0.0")
 
-	// Verify yes flag has -y shortcut
-	yesFlag := cmd.Flags().Lookup("yes")
-	assert.Equal(t, "y", yesFlag.Shorthand)
-
 	// Verify check-only has no shortcut
 	checkOnlyFlag := cmd.Flags().Lookup("check-only")
 	assert.Empty(t, checkOnlyFlag.Shorthand)
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/yaklabco/dot/internal/cli/output"
+	"github.com/yaklabco/dot/pkg/dot"
+)
+
+// newVerifyCommand creates the verify command.
+func newVerifyCommand() *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "verify [PACKAGE...]",
+		Short: "Check that managed links match the manifest exactly",
+		Long: `Check every link the manifest records, for the given packages or all
+managed packages, and report links that are missing, point somewhere other
+than the source they were linked to, or were replaced by a regular file.
+
+Unlike doctor, verify looks only at the manifest's links and applies no
+heuristics, so it is suited to CI. It exits non-zero when any link does
+not match.`,
+		Example: `  # Verify all managed packages
+  dot verify
+
+  # Verify some packages and print the report as JSON
+  dot verify vim 'shell-*' --format json`,
+		ValidArgsFunction: packageCompletion(true),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runVerify(cmd, args, format)
+		},
+	}
+
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json)")
+
+	return cmd
+}
+
+// runVerify handles the verify command.
+func runVerify(cmd *cobra.Command, args []string, format string) error {
+	if format != "text" && format != "json" {
+		return fmt.Errorf("invalid format %q (must be text or json)", format)
+	}
+
+	cfg, err := buildConfigWithCmd(cmd)
+	if err != nil {
+		return err
+	}
+	client, err := dot.NewClient(cfg)
+	if err != nil {
+		return formatError(err)
+	}
+
+	packages, err := client.ExpandInstalledPackages(cmd.Context(), args)
+	if err != nil {
+		return formatError(err)
+	}
+	report, err := client.Verify(cmd.Context(), packages...)
+	if err != nil {
+		return formatError(err)
+	}
+
+	if format == "json" {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal verify report: %w", err)
+		}
+		if _, err := fmt.Fprintln(cmd.OutOrStdout(), string(data)); err != nil {
+			return err
+		}
+	} else {
+		renderVerifyReport(cmd, report)
+	}
+
+	if !report.OK() {
+		return fmt.Errorf("verification failed: %s not match the manifest",
+			formatCount(report.DiscrepancyCount(), "link does", "links do"))
+	}
+	return nil
+}
+
+// renderVerifyReport writes the text form of report.
+func renderVerifyReport(cmd *cobra.Command, report dot.VerifyReport) {
+	formatter := output.NewFormatter(cmd.OutOrStdout(), shouldUseColor(), outputTheme())
+	if report.OK() {
+		links := 0
+		for _, pkg := range report.Packages {
+			links += pkg.Links
+		}
+		formatter.SuccessSimple(fmt.Sprintf("Verified %s in %s",
+			formatCount(links, "link", "links"),
+			formatCount(len(report.Packages), "package", "packages")))
+		return
+	}
+
+	for _, pkg := range report.Packages {
+		if len(pkg.Discrepancies) == 0 {
+			continue
+		}
+		formatter.Error(pkg.Package)
+		for _, d := range pkg.Discrepancies {
+			formatter.Bullet(describeDiscrepancy(d))
+		}
+	}
+}
+
+// describeDiscrepancy renders a single discrepancy as one line of text.
+func describeDiscrepancy(d dot.LinkDiscrepancy) string {
+	switch d.Kind {
+	case dot.DiscrepancyMissing:
+		return d.Link + ": missing"
+	case dot.DiscrepancyNotSymlink:
+		return d.Link + ": replaced by a regular file or directory"
+	case dot.DiscrepancyWrongTarget:
+		return fmt.Sprintf("%s: points to %s, expected %s", d.Link, d.Actual, d.Expected)
+	default:
+		return d.Link + ": " + string(d.Kind)
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yaklabco/dot/pkg/dot"
+)
+
+func TestVerifyCommand(t *testing.T) {
+	setupGlobalCfg(t)
+
+	packageDir := t.TempDir()
+	targetDir := t.TempDir()
+	for _, pkg := range []string{"vim", "zsh"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(packageDir, pkg), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(packageDir, pkg, "dot-"+pkg+"rc"), []byte("x"), 0644))
+	}
+
+	run := func(args ...string) (string, error) {
+		rootCmd := NewRootCommand("dev", "none", "unknown")
+		rootCmd.SetArgs(append(args, "--dir", packageDir, "--target", targetDir))
+		out := &bytes.Buffer{}
+		rootCmd.SetOut(out)
+		rootCmd.SetErr(&bytes.Buffer{})
+		err := rootCmd.Execute()
+		return out.String(), err
+	}
+
+	_, err := run("manage", "vim", "zsh")
+	require.NoError(t, err)
+
+	out, err := run("verify")
+	require.NoError(t, err)
+	assert.Contains(t, out, "Verified 2 links in 2 packages")
+
+	require.NoError(t, os.Remove(filepath.Join(targetDir, "zsh", ".zshrc")))
+	require.NoError(t, os.WriteFile(filepath.Join(targetDir, "zsh", ".zshrc"), []byte("edited"), 0644))
+
+	out, err = run("verify")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 link does not match the manifest")
+	assert.Contains(t, out, "zsh/.zshrc: replaced by a regular file or directory")
+
+	out, err = run("verify", "--format", "json", "v*")
+	require.NoError(t, err, "only vim is verified")
+	var report dot.VerifyReport
+	require.NoError(t, json.Unmarshal([]byte(out), &report))
+	require.Len(t, report.Packages, 1)
+	assert.Equal(t, "vim", report.Packages[0].Package)
+
+	_, err = run("verify", "--format", "yaml")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid format")
+}
+
+func TestDescribeDiscrepancy(t *testing.T) {
+	assert.Equal(t, ".vimrc: missing", describeDiscrepancy(dot.LinkDiscrepancy{Link: ".vimrc", Kind: dot.DiscrepancyMissing}))
+	assert.Equal(t, ".vimrc: points to /a, expected /b", describeDiscrepancy(dot.LinkDiscrepancy{
+		Link: ".vimrc", Kind: dot.DiscrepancyWrongTarget, Actual: "/a", Expected: "/b",
+	}))
+}
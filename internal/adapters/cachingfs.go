@@ -0,0 +1,220 @@
+package adapters
+
+import (
+	"context"
+	"io/fs"
+	"sync"
+
+	"github.com/yaklabco/dot/internal/domain"
+)
+
+// CachingFS wraps a domain.FS and memoizes Stat and ReadDir results, which
+// planning requests repeatedly for the same paths. Exists and IsDir are
+// answered from the cached Stat result.
+//
+// Every mutating call clears the cache. Stat follows symlinks, so a write
+// can change the result for paths other than the one written; clearing
+// everything keeps results correct without tracking link targets. Use Reset
+// to bound the cache to a single operation.
+//
+// CachingFS is safe for concurrent use.
+type CachingFS struct {
+	fs domain.FS
+
+	mu sync.RWMutex
+	// gen counts resets. A result fetched from the underlying FS is only
+	// stored if no reset happened while it was being fetched.
+	gen   uint64
+	stats map[string]statEntry
+	dirs  map[string]readDirEntry
+}
+
+type statEntry struct {
+	info fs.FileInfo
+	err  error
+}
+
+type readDirEntry struct {
+	entries []fs.DirEntry
+	err     error
+}
+
+// NewCachingFS creates a caching decorator around inner.
+func NewCachingFS(inner domain.FS) *CachingFS {
+	return &CachingFS{
+		fs:    inner,
+		stats: make(map[string]statEntry),
+		dirs:  make(map[string]readDirEntry),
+	}
+}
+
+// Reset discards all cached results.
+func (c *CachingFS) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.gen++
+	c.stats = make(map[string]statEntry)
+	c.dirs = make(map[string]readDirEntry)
+}
+
+// cacheable reports whether a result fetched under ctx may be cached.
+// Cancellation says nothing about the filesystem, so it is never cached.
+func cacheable(ctx context.Context) bool {
+	return ctx.Err() == nil
+}
+
+// Stat returns file information, from the cache if available.
+func (c *CachingFS) Stat(ctx context.Context, name string) (fs.FileInfo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	c.mu.RLock()
+	entry, ok := c.stats[name]
+	gen := c.gen
+	c.mu.RUnlock()
+	if ok {
+		return entry.info, entry.err
+	}
+
+	info, err := c.fs.Stat(ctx, name)
+	if cacheable(ctx) {
+		c.mu.Lock()
+		if c.gen == gen {
+			c.stats[name] = statEntry{info: info, err: err}
+		}
+		c.mu.Unlock()
+	}
+	return info, err
+}
+
+// ReadDir lists directory contents, from the cache if available. The
+// returned slice is a copy and may be modified by the caller.
+func (c *CachingFS) ReadDir(ctx context.Context, name string) ([]fs.DirEntry, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	c.mu.RLock()
+	entry, ok := c.dirs[name]
+	gen := c.gen
+	c.mu.RUnlock()
+	if ok {
+		return cloneEntries(entry.entries), entry.err
+	}
+
+	entries, err := c.fs.ReadDir(ctx, name)
+	if cacheable(ctx) {
+		c.mu.Lock()
+		if c.gen == gen {
+			c.dirs[name] = readDirEntry{entries: cloneEntries(entries), err: err}
+		}
+		c.mu.Unlock()
+	}
+	return entries, err
+}
+
+func cloneEntries(entries []fs.DirEntry) []fs.DirEntry {
+	if entries == nil {
+		return nil
+	}
+	return append([]fs.DirEntry(nil), entries...)
+}
+
+// Exists checks if a path exists using the cached Stat result.
+func (c *CachingFS) Exists(ctx context.Context, name string) bool {
+	_, err := c.Stat(ctx, name)
+	return err == nil
+}
+
+// IsDir checks if a path is a directory using the cached Stat result.
+func (c *CachingFS) IsDir(ctx context.Context, name string) (bool, error) {
+	info, err := c.Stat(ctx, name)
+	if err != nil {
+		return false, err
+	}
+	return info.IsDir(), nil
+}
+
+// Lstat returns file information without following symlinks. Not cached.
+func (c *CachingFS) Lstat(ctx context.Context, name string) (fs.FileInfo, error) {
+	return c.fs.Lstat(ctx, name)
+}
+
+// ReadLink reads the target of a symbolic link. Not cached.
+func (c *CachingFS) ReadLink(ctx context.Context, name string) (string, error) {
+	return c.fs.ReadLink(ctx, name)
+}
+
+// ReadFile reads the entire file. Not cached.
+func (c *CachingFS) ReadFile(ctx context.Context, name string) ([]byte, error) {
+	return c.fs.ReadFile(ctx, name)
+}
+
+// ReadFileRange reads part of a file. Not cached.
+func (c *CachingFS) ReadFileRange(ctx context.Context, name string, offset, length int64) ([]byte, error) {
+	return c.fs.ReadFileRange(ctx, name, offset, length)
+}
+
+// Walk walks a tree. Not cached.
+func (c *CachingFS) Walk(ctx context.Context, root string, fn domain.WalkFunc) error {
+	return c.fs.Walk(ctx, root, fn)
+}
+
+// IsSymlink checks if a path is a symbolic link. Not cached.
+func (c *CachingFS) IsSymlink(ctx context.Context, name string) (bool, error) {
+	return c.fs.IsSymlink(ctx, name)
+}
+
+// LinkKind reports how the link at name was created. Not cached.
+func (c *CachingFS) LinkKind(ctx context.Context, name string) (domain.LinkKind, error) {
+	return domain.LinkKindOf(ctx, c.fs, name)
+}
+
+// WriteFile writes data to a file and clears the cache.
+func (c *CachingFS) WriteFile(ctx context.Context, name string, data []byte, perm fs.FileMode) error {
+	defer c.Reset()
+	return c.fs.WriteFile(ctx, name, data, perm)
+}
+
+// Mkdir creates a directory and clears the cache.
+func (c *CachingFS) Mkdir(ctx context.Context, name string, perm fs.FileMode) error {
+	defer c.Reset()
+	return c.fs.Mkdir(ctx, name, perm)
+}
+
+// MkdirAll creates a directory tree and clears the cache.
+func (c *CachingFS) MkdirAll(ctx context.Context, name string, perm fs.FileMode) error {
+	defer c.Reset()
+	return c.fs.MkdirAll(ctx, name, perm)
+}
+
+// Remove removes a file or empty directory and clears the cache.
+func (c *CachingFS) Remove(ctx context.Context, name string) error {
+	defer c.Reset()
+	return c.fs.Remove(ctx, name)
+}
+
+// RemoveAll removes a directory tree and clears the cache.
+func (c *CachingFS) RemoveAll(ctx context.Context, name string) error {
+	defer c.Reset()
+	return c.fs.RemoveAll(ctx, name)
+}
+
+// Symlink creates a symbolic link and clears the cache.
+func (c *CachingFS) Symlink(ctx context.Context, oldname, newname string) error {
+	defer c.Reset()
+	return c.fs.Symlink(ctx, oldname, newname)
+}
+
+// Link creates a hard link and clears the cache.
+func (c *CachingFS) Link(ctx context.Context, oldname, newname string) error {
+	defer c.Reset()
+	return c.fs.Link(ctx, oldname, newname)
+}
+
+// Rename moves or renames a file and clears the cache.
+func (c *CachingFS) Rename(ctx context.Context, oldpath, newpath string) error {
+	defer c.Reset()
+	return c.fs.Rename(ctx, oldpath, newpath)
+}
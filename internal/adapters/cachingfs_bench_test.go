@@ -0,0 +1,77 @@
+package adapters_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/yaklabco/dot/internal/adapters"
+	"github.com/yaklabco/dot/internal/domain"
+	"github.com/yaklabco/dot/internal/ignore"
+	"github.com/yaklabco/dot/internal/pipeline"
+	"github.com/yaklabco/dot/internal/planner"
+)
+
+// setupPlanBenchmark creates packages that share target directories, so
+// planning visits the same directories repeatedly.
+func setupPlanBenchmark(b *testing.B) (pipeline.ManageInput, []string) {
+	b.Helper()
+	root := b.TempDir()
+	packageDir := filepath.Join(root, "packages")
+	targetDir := filepath.Join(root, "target")
+	require.NoError(b, os.MkdirAll(filepath.Join(targetDir, ".config"), 0755))
+
+	var packages []string
+	for p := 0; p < 20; p++ {
+		name := fmt.Sprintf("pkg%02d", p)
+		packages = append(packages, name)
+		for f := 0; f < 10; f++ {
+			path := filepath.Join(packageDir, name, "dot-config", name, fmt.Sprintf("file%d", f))
+			require.NoError(b, os.MkdirAll(filepath.Dir(path), 0755))
+			require.NoError(b, os.WriteFile(path, []byte("x"), 0644))
+		}
+	}
+
+	return pipeline.ManageInput{
+		PackageDir: domain.NewPackagePath(packageDir).Unwrap(),
+		TargetDir:  domain.NewTargetPath(targetDir).Unwrap(),
+		Packages:   packages,
+	}, packages
+}
+
+// BenchmarkPlan_CachingFS compares filesystem calls made while planning
+// with and without the caching decorator. The fscalls/op metric counts
+// Stat and ReadDir calls that reach the OS.
+func BenchmarkPlan_CachingFS(b *testing.B) {
+	input, _ := setupPlanBenchmark(b)
+	ctx := context.Background()
+
+	run := func(b *testing.B, wrap func(domain.FS) domain.FS) {
+		counter := &countingFS{FS: adapters.NewOSFilesystem()}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			// A fresh decorator per plan scopes the cache to one operation
+			pipe := pipeline.NewManagePipeline(pipeline.ManagePipelineOpts{
+				FS:        wrap(counter),
+				IgnoreSet: ignore.NewIgnoreSet(),
+				Policies:  planner.DefaultPolicies(),
+			})
+			result := pipe.Execute(ctx, input)
+			if result.IsErr() {
+				b.Fatal(result.UnwrapErr())
+			}
+		}
+		b.ReportMetric(float64(counter.calls())/float64(b.N), "fscalls/op")
+	}
+
+	b.Run("OSFilesystem", func(b *testing.B) {
+		run(b, func(fs domain.FS) domain.FS { return fs })
+	})
+	b.Run("CachingFS", func(b *testing.B) {
+		run(b, func(fs domain.FS) domain.FS { return adapters.NewCachingFS(fs) })
+	})
+}
@@ -0,0 +1,267 @@
+package adapters_test
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yaklabco/dot/internal/adapters"
+	"github.com/yaklabco/dot/internal/domain"
+)
+
+// countingFS counts the read calls that reach the wrapped FS.
+type countingFS struct {
+	domain.FS
+	stats, readDirs atomic.Int64
+}
+
+func (c *countingFS) Stat(ctx context.Context, name string) (fs.FileInfo, error) {
+	c.stats.Add(1)
+	return c.FS.Stat(ctx, name)
+}
+
+func (c *countingFS) ReadDir(ctx context.Context, name string) ([]fs.DirEntry, error) {
+	c.readDirs.Add(1)
+	return c.FS.ReadDir(ctx, name)
+}
+
+func (c *countingFS) Exists(ctx context.Context, name string) bool {
+	_, err := c.Stat(ctx, name)
+	return err == nil
+}
+
+func (c *countingFS) IsDir(ctx context.Context, name string) (bool, error) {
+	info, err := c.Stat(ctx, name)
+	if err != nil {
+		return false, err
+	}
+	return info.IsDir(), nil
+}
+
+func (c *countingFS) calls() int64 {
+	return c.stats.Load() + c.readDirs.Load()
+}
+
+func newCachingMemFS(t *testing.T) (*adapters.CachingFS, *countingFS) {
+	t.Helper()
+	ctx := context.Background()
+	mem := adapters.NewMemFS()
+	require.NoError(t, mem.MkdirAll(ctx, "/home/.config", 0755))
+	require.NoError(t, mem.WriteFile(ctx, "/home/.vimrc", []byte("set nu"), 0644))
+
+	counter := &countingFS{FS: mem}
+	return adapters.NewCachingFS(counter), counter
+}
+
+func TestCachingFS_MemoizesReads(t *testing.T) {
+	ctx := context.Background()
+	cfs, counter := newCachingMemFS(t)
+
+	for i := 0; i < 3; i++ {
+		info, err := cfs.Stat(ctx, "/home/.vimrc")
+		require.NoError(t, err)
+		assert.Equal(t, ".vimrc", info.Name())
+
+		assert.True(t, cfs.Exists(ctx, "/home/.vimrc"))
+		isDir, err := cfs.IsDir(ctx, "/home/.config")
+		require.NoError(t, err)
+		assert.True(t, isDir)
+
+		entries, err := cfs.ReadDir(ctx, "/home")
+		require.NoError(t, err)
+		assert.Len(t, entries, 2)
+	}
+
+	assert.Equal(t, int64(2), counter.stats.Load(), "one Stat per path")
+	assert.Equal(t, int64(1), counter.readDirs.Load())
+}
+
+func TestCachingFS_CachesMissingPaths(t *testing.T) {
+	ctx := context.Background()
+	cfs, counter := newCachingMemFS(t)
+
+	assert.False(t, cfs.Exists(ctx, "/home/.zshrc"))
+	_, err := cfs.Stat(ctx, "/home/.zshrc")
+	assert.True(t, os.IsNotExist(err))
+	_, err = cfs.IsDir(ctx, "/home/.zshrc")
+	assert.Error(t, err)
+
+	assert.Equal(t, int64(1), counter.stats.Load())
+}
+
+func TestCachingFS_InvalidatesOnWrite(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(ctx context.Context, fs *adapters.CachingFS) error
+		path   string
+		exists bool
+	}{
+		{
+			name: "WriteFile",
+			mutate: func(ctx context.Context, fs *adapters.CachingFS) error {
+				return fs.WriteFile(ctx, "/home/.zshrc", []byte("x"), 0644)
+			},
+			path:   "/home/.zshrc",
+			exists: true,
+		},
+		{
+			name: "Symlink",
+			mutate: func(ctx context.Context, fs *adapters.CachingFS) error {
+				return fs.Symlink(ctx, "/home/.vimrc", "/home/.zshrc")
+			},
+			path:   "/home/.zshrc",
+			exists: true,
+		},
+		{
+			name: "Mkdir",
+			mutate: func(ctx context.Context, fs *adapters.CachingFS) error {
+				return fs.Mkdir(ctx, "/home/.zshrc", 0755)
+			},
+			path:   "/home/.zshrc",
+			exists: true,
+		},
+		{
+			name: "MkdirAll",
+			mutate: func(ctx context.Context, fs *adapters.CachingFS) error {
+				return fs.MkdirAll(ctx, "/home/.zshrc/d", 0755)
+			},
+			path:   "/home/.zshrc",
+			exists: true,
+		},
+		{
+			name: "Remove",
+			mutate: func(ctx context.Context, fs *adapters.CachingFS) error {
+				return fs.Remove(ctx, "/home/.vimrc")
+			},
+			path:   "/home/.vimrc",
+			exists: false,
+		},
+		{
+			name: "RemoveAll",
+			mutate: func(ctx context.Context, fs *adapters.CachingFS) error {
+				return fs.RemoveAll(ctx, "/home/.config")
+			},
+			path:   "/home/.config",
+			exists: false,
+		},
+		{
+			name: "Rename",
+			mutate: func(ctx context.Context, fs *adapters.CachingFS) error {
+				return fs.Rename(ctx, "/home/.vimrc", "/home/.zshrc")
+			},
+			path:   "/home/.vimrc",
+			exists: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			cfs, _ := newCachingMemFS(t)
+
+			// Prime the cache with the state before the write
+			before := cfs.Exists(ctx, tt.path)
+			entriesBefore, err := cfs.ReadDir(ctx, "/home")
+			require.NoError(t, err)
+			require.NotEqual(t, tt.exists, before)
+
+			require.NoError(t, tt.mutate(ctx, cfs))
+
+			assert.Equal(t, tt.exists, cfs.Exists(ctx, tt.path))
+			entriesAfter, err := cfs.ReadDir(ctx, "/home")
+			require.NoError(t, err)
+			assert.NotEqual(t, names(entriesBefore), names(entriesAfter), "directory listing should be refreshed")
+		})
+	}
+}
+
+func TestCachingFS_InvalidatesStatThroughSymlink(t *testing.T) {
+	ctx := context.Background()
+	cfs, _ := newCachingMemFS(t)
+	require.NoError(t, cfs.Symlink(ctx, "/home/.vimrc", "/home/.link"))
+
+	assert.True(t, cfs.Exists(ctx, "/home/.link"))
+
+	// Removing the link target changes Stat for the link itself
+	require.NoError(t, cfs.Remove(ctx, "/home/.vimrc"))
+	assert.False(t, cfs.Exists(ctx, "/home/.link"))
+}
+
+func TestCachingFS_Reset(t *testing.T) {
+	ctx := context.Background()
+	cfs, counter := newCachingMemFS(t)
+
+	_, err := cfs.Stat(ctx, "/home/.vimrc")
+	require.NoError(t, err)
+	cfs.Reset()
+	_, err = cfs.Stat(ctx, "/home/.vimrc")
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(2), counter.stats.Load())
+}
+
+func TestCachingFS_DoesNotCacheCancellation(t *testing.T) {
+	cfs, _ := newCachingMemFS(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.False(t, cfs.Exists(ctx, "/home/.vimrc"))
+	_, err := cfs.ReadDir(ctx, "/home")
+	require.Error(t, err)
+
+	assert.True(t, cfs.Exists(context.Background(), "/home/.vimrc"))
+	entries, err := cfs.ReadDir(context.Background(), "/home")
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+}
+
+func TestCachingFS_ReadDirReturnsCopy(t *testing.T) {
+	ctx := context.Background()
+	cfs, _ := newCachingMemFS(t)
+
+	entries, err := cfs.ReadDir(ctx, "/home")
+	require.NoError(t, err)
+	entries[0] = nil
+
+	again, err := cfs.ReadDir(ctx, "/home")
+	require.NoError(t, err)
+	assert.NotNil(t, again[0])
+}
+
+func TestCachingFS_Concurrent(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	cfs := adapters.NewCachingFS(adapters.NewOSFilesystem())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			path := filepath.Join(root, "file", string(rune('a'+i)))
+			for j := 0; j < 50; j++ {
+				_ = cfs.MkdirAll(ctx, filepath.Dir(path), 0755)
+				_ = cfs.WriteFile(ctx, path, []byte("x"), 0644)
+				assert.True(t, cfs.Exists(ctx, path))
+				_, _ = cfs.ReadDir(ctx, filepath.Dir(path))
+				_ = cfs.Remove(ctx, path)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func names(entries []fs.DirEntry) []string {
+	result := make([]string, len(entries))
+	for i, e := range entries {
+		result[i] = e.Name()
+	}
+	return result
+}
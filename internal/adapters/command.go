@@ -0,0 +1,61 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+)
+
+// Command describes an external program to run.
+type Command struct {
+	// Args holds the program and its arguments. Args[0] is looked up in
+	// PATH unless it contains a path separator.
+	Args []string
+
+	// Env lists additional KEY=value entries appended to the inherited
+	// environment.
+	Env []string
+
+	// Dir is the working directory. Empty means the current directory.
+	Dir string
+
+	// Stdout and Stderr receive the program's output. Nil discards it.
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+// CommandRunner runs external commands.
+type CommandRunner interface {
+	// Run starts cmd and waits for it to exit. Returns an error if the
+	// program cannot be started or exits with a non-zero status.
+	Run(ctx context.Context, cmd Command) error
+}
+
+// ExecRunner runs commands with os/exec. Arguments are passed to the
+// program as given; no shell is involved.
+type ExecRunner struct{}
+
+// NewExecRunner returns a CommandRunner backed by os/exec.
+func NewExecRunner() *ExecRunner {
+	return &ExecRunner{}
+}
+
+// Run implements CommandRunner.
+func (r *ExecRunner) Run(ctx context.Context, cmd Command) error {
+	if len(cmd.Args) == 0 || cmd.Args[0] == "" {
+		return fmt.Errorf("empty command")
+	}
+
+	// #nosec G204 -- the caller supplies an explicit argument vector, not a shell string
+	c := exec.CommandContext(ctx, cmd.Args[0], cmd.Args[1:]...)
+	c.Dir = cmd.Dir
+	c.Env = append(os.Environ(), cmd.Env...)
+	c.Stdout = cmd.Stdout
+	c.Stderr = cmd.Stderr
+	if err := c.Run(); err != nil {
+		return fmt.Errorf("run %s: %w", cmd.Args[0], err)
+	}
+	return nil
+}
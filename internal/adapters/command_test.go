@@ -0,0 +1,53 @@
+package adapters
+
+import (
+	"bytes"
+	"context"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecRunner_Run(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a POSIX shell")
+	}
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+	ctx := context.Background()
+	runner := NewExecRunner()
+
+	t.Run("passes arguments literally", func(t *testing.T) {
+		var out bytes.Buffer
+		err := runner.Run(ctx, Command{
+			Args:   []string{"sh", "-c", `printf '%s|%s' "$1" "$DOT_TEST"`, "sh", "$HOME; echo injected"},
+			Env:    []string{"DOT_TEST=value"},
+			Stdout: &out,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "$HOME; echo injected|value", out.String())
+	})
+
+	t.Run("runs in dir", func(t *testing.T) {
+		dir := t.TempDir()
+		var out bytes.Buffer
+		require.NoError(t, runner.Run(ctx, Command{Args: []string{"pwd"}, Dir: dir, Stdout: &out}))
+		assert.Contains(t, out.String(), filepath.Base(dir))
+	})
+
+	t.Run("non-zero exit", func(t *testing.T) {
+		err := runner.Run(ctx, Command{Args: []string{"sh", "-c", "exit 3"}})
+		var exitErr *exec.ExitError
+		require.ErrorAs(t, err, &exitErr)
+		assert.Equal(t, 3, exitErr.ExitCode())
+	})
+
+	t.Run("empty command", func(t *testing.T) {
+		assert.Error(t, runner.Run(ctx, Command{}))
+	})
+}
@@ -3,6 +3,7 @@ package adapters
 
 import (
 	"context"
+	"fmt"
 	"io"
 )
 
@@ -17,6 +18,15 @@ type GitCloner interface {
 	//   - Network errors occur
 	//   - Repository is not accessible
 	Clone(ctx context.Context, url string, path string, opts CloneOptions) error
+
+	// Unshallow fetches the full history of the shallow clone at path from
+	// its origin remote. It does nothing if the history is already complete.
+	//
+	// Returns an error if:
+	//   - path is not a git repository
+	//   - Authentication fails
+	//   - Network errors occur
+	Unshallow(ctx context.Context, path string, opts UnshallowOptions) error
 }
 
 // CloneOptions configures repository cloning behavior.
@@ -34,9 +44,39 @@ type CloneOptions struct {
 	// If 1, only the latest commit is fetched (shallow clone).
 	Depth int
 
+	// SingleBranch fetches only Branch, or the remote's default branch
+	// when Branch is empty, instead of all branches.
+	SingleBranch bool
+
 	// Progress is an optional writer for clone progress output.
 	// If nil, no progress is reported.
 	Progress io.Writer
+
+	// PassphrasePrompter asks for the passphrase of an encrypted SSH key
+	// when SSHAuth has none. If nil, encrypted keys without a passphrase
+	// fail to load.
+	PassphrasePrompter PassphrasePrompter
+}
+
+// UnshallowOptions configures fetching the full history of a shallow clone.
+type UnshallowOptions struct {
+	// Auth specifies the authentication method.
+	// If nil, no authentication is used (public repos only).
+	Auth AuthMethod
+
+	// Progress is an optional writer for fetch progress output.
+	// If nil, no progress is reported.
+	Progress io.Writer
+
+	// PassphrasePrompter asks for the passphrase of an encrypted SSH key
+	// when SSHAuth has none.
+	PassphrasePrompter PassphrasePrompter
+}
+
+// PassphrasePrompter asks the user for the passphrase of an encrypted SSH key.
+type PassphrasePrompter interface {
+	// PromptPassphrase returns the passphrase for the key at keyPath.
+	PromptPassphrase(keyPath string) (string, error)
 }
 
 // AuthMethod represents a git authentication method.
@@ -57,16 +97,22 @@ func (NoAuth) isAuthMethod() {}
 
 // TokenAuth represents token-based authentication (HTTPS).
 //
-// The token is transmitted using HTTP Basic Authentication with "git" as the
-// username and the token as the password. This format is compatible with:
+// The token is transmitted using HTTP Basic Authentication with the token as
+// the password. Username defaults to "git", which is compatible with:
 //   - GitHub personal access tokens
 //   - GitHub fine-grained tokens
 //   - GitLab personal access tokens
 //   - Gitea tokens
 //   - Azure DevOps personal access tokens
+//
+// Hosts with their own convention set Username, such as "oauth2" for GitLab
+// or "x-token-auth" for Bitbucket access tokens.
 type TokenAuth struct {
 	// Token is the authentication token.
 	Token string
+
+	// Username sent with the token. Empty means "git".
+	Username string
 }
 
 func (TokenAuth) isAuthMethod() {}
@@ -74,10 +120,62 @@ func (TokenAuth) isAuthMethod() {}
 // SSHAuth represents SSH key-based authentication.
 type SSHAuth struct {
 	// PrivateKeyPath is the filesystem path to the SSH private key.
+	// If empty, keys are requested from the running SSH agent.
 	PrivateKeyPath string
 
 	// Passphrase is an optional passphrase for encrypted keys.
 	Passphrase string
+
+	// KnownHostsPath is the known_hosts file used to verify the server's
+	// host key. If empty, SSH_KNOWN_HOSTS, ~/.ssh/known_hosts and
+	// /etc/ssh/ssh_known_hosts are used.
+	KnownHostsPath string
 }
 
 func (SSHAuth) isAuthMethod() {}
+
+// CredentialHelperAuth represents credentials obtained from a git
+// credential helper configured in the user's gitconfig.
+//
+// The credentials are transmitted using HTTP Basic Authentication.
+type CredentialHelperAuth struct {
+	// Username returned by the helper. May be empty.
+	Username string
+
+	// Password or token returned by the helper.
+	Password string
+}
+
+func (CredentialHelperAuth) isAuthMethod() {}
+
+// ErrHostKeyVerification indicates the SSH server's host key could not be
+// verified against the known_hosts files.
+type ErrHostKeyVerification struct {
+	// Host is the SSH server that failed verification.
+	Host string
+
+	// Mismatch reports that known_hosts records a different key for Host,
+	// rather than no key at all.
+	Mismatch bool
+
+	Cause error
+}
+
+func (e ErrHostKeyVerification) Error() string {
+	if e.Mismatch {
+		return fmt.Sprintf("host key verification failed for %s: the server's key does not match the one in known_hosts; "+
+			"if the key was changed legitimately, remove the old entry with 'ssh-keygen -R %s'", e.Host, e.Host)
+	}
+	return fmt.Sprintf("host key verification failed for %s: the host is not in known_hosts; "+
+		"connect once with ssh to review and trust its key", e.Host)
+}
+
+func (e ErrHostKeyVerification) Unwrap() error {
+	return e.Cause
+}
+
+// Is implements errors.Is for ErrHostKeyVerification.
+func (e ErrHostKeyVerification) Is(target error) bool {
+	_, ok := target.(ErrHostKeyVerification)
+	return ok
+}
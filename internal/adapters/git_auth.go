@@ -2,6 +2,7 @@ package adapters
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -9,51 +10,149 @@ import (
 	"github.com/cli/go-gh/pkg/auth"
 )
 
+// AuthOptions adjusts how authentication is resolved.
+type AuthOptions struct {
+	// SSHKeyPath selects the private key for SSH URLs instead of the
+	// SSH agent or the keys found in ~/.ssh.
+	SSHKeyPath string
+
+	// KnownHostsPath verifies SSH host keys against this file instead of
+	// the default known_hosts files.
+	KnownHostsPath string
+}
+
 // ResolveAuth determines the appropriate authentication method for a repository URL.
 //
 // Resolution priority:
-//  1. GITHUB_TOKEN environment variable → TokenAuth
-//  2. GIT_TOKEN environment variable → TokenAuth
-//  3. SSH keys in ~/.ssh/ → SSHAuth (for SSH URLs)
-//  4. GitHub CLI (gh) authenticated token → TokenAuth (for HTTPS GitHub URLs)
-//  5. NoAuth (public repositories)
+//  1. git credential helper from gitconfig → CredentialHelperAuth (for HTTP(S) URLs)
+//  2. GITLAB_TOKEN or BITBUCKET_TOKEN for repositories on that host → TokenAuth
+//  3. DOT_GIT_TOKEN environment variable → TokenAuth
+//  4. GITHUB_TOKEN environment variable → TokenAuth
+//  5. GIT_TOKEN environment variable → TokenAuth
+//  6. SSH agent, when SSH_AUTH_SOCK is set → SSHAuth (for SSH URLs)
+//  7. SSH keys in ~/.ssh/ → SSHAuth (for SSH URLs)
+//  8. GitHub CLI (gh) authenticated token → TokenAuth (for HTTPS GitHub URLs)
+//  9. NoAuth (public repositories)
+//
+// Environment tokens carry the username the repository host expects, such
+// as "oauth2" for GitLab.
 //
 // The function inspects the URL to determine authentication needs.
 // For SSH URLs (git@... or ssh://...), SSH key auth is preferred.
 // For GitHub HTTPS URLs, checks gh CLI if environment tokens not set.
 // This ensures SSH URLs use SSH keys as users expect.
 func ResolveAuth(ctx context.Context, repoURL string) (AuthMethod, error) {
-	// Priority 1: Check for token in environment variables
-	if token := os.Getenv("GITHUB_TOKEN"); token != "" {
-		return TokenAuth{Token: token}, nil
+	return ResolveAuthWithOptions(ctx, repoURL, AuthOptions{})
+}
+
+// ResolveAuthWithOptions determines the authentication method for a
+// repository URL like ResolveAuth. An explicit SSH key path takes
+// precedence over every other method and requires an SSH URL.
+func ResolveAuthWithOptions(ctx context.Context, repoURL string, opts AuthOptions) (AuthMethod, error) {
+	if opts.SSHKeyPath != "" {
+		if !isSSHURL(repoURL) {
+			return nil, fmt.Errorf("SSH key %s given for non-SSH URL", opts.SSHKeyPath)
+		}
+		if _, err := os.Stat(opts.SSHKeyPath); err != nil {
+			return nil, fmt.Errorf("SSH key: %w", err)
+		}
+		return SSHAuth{PrivateKeyPath: opts.SSHKeyPath, KnownHostsPath: opts.KnownHostsPath}, nil
+	}
+
+	// Priority 1: Ask a configured git credential helper
+	if helperAuth := resolveCredentialHelperAuth(ctx, repoURL); helperAuth != nil {
+		return *helperAuth, nil
 	}
 
-	if token := os.Getenv("GIT_TOKEN"); token != "" {
-		return TokenAuth{Token: token}, nil
+	// Priority 2: Check for token in environment variables
+	provider := detectGitProvider(repoURL)
+	for _, name := range tokenEnvVars(provider) {
+		if token := os.Getenv(name); token != "" {
+			return TokenAuth{Token: token, Username: provider.tokenUsername()}, nil
+		}
 	}
 
-	// Priority 2: For SSH URLs, try to find SSH keys
+	// Priority 3: For SSH URLs, use the SSH agent or find SSH keys
 	// SSH URLs explicitly request SSH auth, so honor that before trying tokens
 	if isSSHURL(repoURL) {
+		// An empty key path selects the agent
+		if os.Getenv("SSH_AUTH_SOCK") != "" {
+			return SSHAuth{KnownHostsPath: opts.KnownHostsPath}, nil
+		}
 		homeDir, err := os.UserHomeDir()
 		if err == nil {
 			if keyPath := findSSHKey(homeDir); keyPath != "" {
-				return SSHAuth{PrivateKeyPath: keyPath}, nil
+				return SSHAuth{PrivateKeyPath: keyPath, KnownHostsPath: opts.KnownHostsPath}, nil
 			}
 		}
 	}
 
-	// Priority 3: GitHub CLI for HTTPS GitHub URLs
+	// Priority 4: GitHub CLI for HTTPS GitHub URLs
 	if isGitHubURL(repoURL) && !isSSHURL(repoURL) {
 		if token := getGitHubCLIToken(); token != "" {
 			return TokenAuth{Token: token}, nil
 		}
 	}
 
-	// Priority 4: Fall back to no authentication (public repos)
+	// Priority 5: Fall back to no authentication (public repos)
 	return NoAuth{}, nil
 }
 
+// gitProvider identifies a repository host with its own token conventions.
+type gitProvider int
+
+const (
+	providerGeneric gitProvider = iota
+	providerGitHub
+	providerGitLab
+	providerBitbucket
+)
+
+// detectGitProvider determines the provider from the repository host.
+// Self-hosted instances are recognized when the provider name appears in
+// the host name, such as gitlab.example.com.
+func detectGitProvider(repoURL string) gitProvider {
+	host := strings.ToLower(repoHost(repoURL))
+	switch {
+	case strings.Contains(host, "gitlab"):
+		return providerGitLab
+	case strings.Contains(host, "bitbucket"):
+		return providerBitbucket
+	case strings.Contains(host, "github"):
+		return providerGitHub
+	default:
+		return providerGeneric
+	}
+}
+
+// tokenEnvVars returns the environment variables holding a token for the
+// provider, in the order they are checked.
+func tokenEnvVars(provider gitProvider) []string {
+	generic := []string{"DOT_GIT_TOKEN", "GITHUB_TOKEN", "GIT_TOKEN"}
+	switch provider {
+	case providerGitLab:
+		return append([]string{"GITLAB_TOKEN"}, generic...)
+	case providerBitbucket:
+		return append([]string{"BITBUCKET_TOKEN"}, generic...)
+	default:
+		return generic
+	}
+}
+
+// tokenUsername returns the username the provider expects alongside a
+// token. GitHub and most other hosts accept any username, so they use
+// TokenAuth's default.
+func (p gitProvider) tokenUsername() string {
+	switch p {
+	case providerGitLab:
+		return "oauth2"
+	case providerBitbucket:
+		return "x-token-auth"
+	default:
+		return ""
+	}
+}
+
 // isSSHURL checks if a URL uses SSH protocol.
 func isSSHURL(url string) bool {
 	return strings.HasPrefix(url, "git@") ||
@@ -63,6 +63,110 @@ func TestResolveAuth_WithGitToken(t *testing.T) {
 	assert.Equal(t, "token123", tokenAuth.Token)
 }
 
+// clearTokenEnv unsets every environment variable ResolveAuth reads a
+// token from for the duration of the test.
+func clearTokenEnv(t *testing.T) {
+	t.Helper()
+	for _, name := range []string{"GITLAB_TOKEN", "BITBUCKET_TOKEN", "DOT_GIT_TOKEN", "GITHUB_TOKEN", "GIT_TOKEN"} {
+		t.Setenv(name, "")
+	}
+}
+
+func TestResolveAuth_HostTokens(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+		env  map[string]string
+		want AuthMethod
+	}{
+		{
+			name: "GitLab token for gitlab.com",
+			url:  "https://gitlab.com/user/dotfiles.git",
+			env:  map[string]string{"GITLAB_TOKEN": "glpat", "DOT_GIT_TOKEN": "dot", "GITHUB_TOKEN": "ghp"},
+			want: TokenAuth{Token: "glpat", Username: "oauth2"},
+		},
+		{
+			name: "GitLab token for self-hosted GitLab",
+			url:  "https://gitlab.example.com/team/dotfiles.git",
+			env:  map[string]string{"GITLAB_TOKEN": "glpat"},
+			want: TokenAuth{Token: "glpat", Username: "oauth2"},
+		},
+		{
+			name: "generic token for GitLab uses GitLab username",
+			url:  "https://gitlab.com/user/dotfiles.git",
+			env:  map[string]string{"DOT_GIT_TOKEN": "dot", "GITHUB_TOKEN": "ghp"},
+			want: TokenAuth{Token: "dot", Username: "oauth2"},
+		},
+		{
+			name: "Bitbucket token for bitbucket.org",
+			url:  "https://bitbucket.org/team/dotfiles.git",
+			env:  map[string]string{"BITBUCKET_TOKEN": "bbtoken", "GITLAB_TOKEN": "glpat"},
+			want: TokenAuth{Token: "bbtoken", Username: "x-token-auth"},
+		},
+		{
+			name: "GitLab token ignored for Bitbucket",
+			url:  "https://bitbucket.org/team/dotfiles.git",
+			env:  map[string]string{"GITLAB_TOKEN": "glpat", "GIT_TOKEN": "git"},
+			want: TokenAuth{Token: "git", Username: "x-token-auth"},
+		},
+		{
+			name: "GitLab token ignored for GitHub",
+			url:  "https://github.com/user/dotfiles.git",
+			env:  map[string]string{"GITLAB_TOKEN": "glpat", "GITHUB_TOKEN": "ghp"},
+			want: TokenAuth{Token: "ghp"},
+		},
+		{
+			name: "DOT_GIT_TOKEN before GITHUB_TOKEN",
+			url:  "https://github.com/user/dotfiles.git",
+			env:  map[string]string{"DOT_GIT_TOKEN": "dot", "GITHUB_TOKEN": "ghp"},
+			want: TokenAuth{Token: "dot"},
+		},
+		{
+			name: "GITHUB_TOKEN fallback for other hosts",
+			url:  "https://git.example.com/dotfiles.git",
+			env:  map[string]string{"GITHUB_TOKEN": "ghp", "GIT_TOKEN": "git"},
+			want: TokenAuth{Token: "ghp"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withGitConfig(t, "[user]\n\tname = Test\n")
+			clearTokenEnv(t)
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+
+			auth, err := ResolveAuth(context.Background(), tt.url)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, auth)
+		})
+	}
+}
+
+func TestDetectGitProvider(t *testing.T) {
+	tests := []struct {
+		url  string
+		want gitProvider
+	}{
+		{url: "https://github.com/user/repo.git", want: providerGitHub},
+		{url: "git@github.com:user/repo.git", want: providerGitHub},
+		{url: "https://gitlab.com/user/repo.git", want: providerGitLab},
+		{url: "git@gitlab.com:user/repo.git", want: providerGitLab},
+		{url: "https://GitLab.Example.com/team/repo.git", want: providerGitLab},
+		{url: "https://bitbucket.org/team/repo.git", want: providerBitbucket},
+		{url: "ssh://git@bitbucket.org/team/repo.git", want: providerBitbucket},
+		{url: "https://git.example.com/github/repo.git", want: providerGeneric},
+		{url: "", want: providerGeneric},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			assert.Equal(t, tt.want, detectGitProvider(tt.url))
+		})
+	}
+}
+
 func TestResolveAuth_WithSSHKey(t *testing.T) {
 	ctx := context.Background()
 
@@ -104,6 +208,7 @@ func TestResolveAuth_WithSSHKey(t *testing.T) {
 		}
 	}()
 	os.Setenv("HOME", tempDir)
+	t.Setenv("SSH_AUTH_SOCK", "")
 
 	auth, err := ResolveAuth(ctx, "git@github.com:user/repo.git")
 	require.NoError(t, err)
@@ -134,6 +239,7 @@ func TestResolveAuth_NoAuth(t *testing.T) {
 
 	os.Unsetenv("GITHUB_TOKEN")
 	os.Unsetenv("GIT_TOKEN")
+	clearTokenEnv(t)
 	os.Setenv("HOME", "/nonexistent")
 
 	// Use non-GitHub URL to test NoAuth fallback without gh CLI interference
@@ -166,6 +272,7 @@ func TestResolveAuth_SSHURLWithoutKeys(t *testing.T) {
 	os.Unsetenv("GITHUB_TOKEN")
 	os.Unsetenv("GIT_TOKEN")
 	os.Setenv("HOME", "/nonexistent")
+	t.Setenv("SSH_AUTH_SOCK", "")
 
 	auth, err := ResolveAuth(ctx, "git@github.com:user/repo.git")
 	require.NoError(t, err)
@@ -175,6 +282,91 @@ func TestResolveAuth_SSHURLWithoutKeys(t *testing.T) {
 	assert.True(t, ok)
 }
 
+func TestResolveAuthWithOptions_SSH(t *testing.T) {
+	// A default key in ~/.ssh that an explicit key or the agent must win over
+	home := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(home, ".ssh"), 0700))
+	defaultKey := filepath.Join(home, ".ssh", "id_ed25519")
+	require.NoError(t, os.WriteFile(defaultKey, []byte("fake-ssh-key"), 0600))
+	explicitKey := filepath.Join(t.TempDir(), "id_work")
+	require.NoError(t, os.WriteFile(explicitKey, []byte("fake-ssh-key"), 0600))
+
+	tests := []struct {
+		name        string
+		agentSocket string
+		opts        AuthOptions
+		want        AuthMethod
+	}{
+		{
+			name:        "explicit key wins over agent",
+			agentSocket: "/tmp/agent.sock",
+			opts:        AuthOptions{SSHKeyPath: explicitKey, KnownHostsPath: "/etc/known_hosts"},
+			want:        SSHAuth{PrivateKeyPath: explicitKey, KnownHostsPath: "/etc/known_hosts"},
+		},
+		{
+			name: "explicit key wins over default keys",
+			opts: AuthOptions{SSHKeyPath: explicitKey},
+			want: SSHAuth{PrivateKeyPath: explicitKey},
+		},
+		{
+			name:        "agent when running",
+			agentSocket: "/tmp/agent.sock",
+			opts:        AuthOptions{KnownHostsPath: "/etc/known_hosts"},
+			want:        SSHAuth{KnownHostsPath: "/etc/known_hosts"},
+		},
+		{
+			name: "default key without agent",
+			opts: AuthOptions{KnownHostsPath: "/etc/known_hosts"},
+			want: SSHAuth{PrivateKeyPath: defaultKey, KnownHostsPath: "/etc/known_hosts"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearTokenEnv(t)
+			t.Setenv("HOME", home)
+			t.Setenv("SSH_AUTH_SOCK", tt.agentSocket)
+
+			auth, err := ResolveAuthWithOptions(context.Background(), "git@github.com:user/repo.git", tt.opts)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, auth)
+		})
+	}
+}
+
+func TestResolveAuthWithOptions_InvalidSSHKey(t *testing.T) {
+	keyPath := filepath.Join(t.TempDir(), "id_work")
+	require.NoError(t, os.WriteFile(keyPath, []byte("fake-ssh-key"), 0600))
+
+	tests := []struct {
+		name    string
+		url     string
+		keyPath string
+		wantErr string
+	}{
+		{
+			name:    "non-SSH URL",
+			url:     "https://github.com/user/repo",
+			keyPath: keyPath,
+			wantErr: "non-SSH URL",
+		},
+		{
+			name:    "missing key",
+			url:     "git@github.com:user/repo.git",
+			keyPath: filepath.Join(t.TempDir(), "missing"),
+			wantErr: "missing",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ResolveAuthWithOptions(context.Background(), tt.url, AuthOptions{SSHKeyPath: tt.keyPath})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
 func TestFindSSHKey(t *testing.T) {
 	t.Run("finds id_rsa", func(t *testing.T) {
 		tempDir := t.TempDir()
@@ -334,6 +526,7 @@ func TestResolveAuth_GitHubCLI_NotGitHub(t *testing.T) {
 
 	os.Unsetenv("GITHUB_TOKEN")
 	os.Unsetenv("GIT_TOKEN")
+	clearTokenEnv(t)
 	os.Setenv("HOME", "/nonexistent")
 
 	// Test with non-GitHub URL (GitLab)
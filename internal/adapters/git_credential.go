@@ -0,0 +1,234 @@
+package adapters
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// credentialHelperTimeout bounds how long a credential helper may run.
+const credentialHelperTimeout = 10 * time.Second
+
+// validHelperName restricts short helper names (e.g. "store", "osxkeychain")
+// to characters that cannot alter the constructed command.
+var validHelperName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// runCredentialHelper executes a helper command and returns its stdout.
+// It is a variable so tests can substitute a fake helper.
+var runCredentialHelper = func(ctx context.Context, argv []string, input string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, credentialHelperTimeout)
+	defer cancel()
+
+	// Execute command directly (no shell invocation)
+	// #nosec G204 -- argv is built by helperCommand from validated gitconfig values
+	cmd := exec.CommandContext(ctx, argv[0], argv[1:]...)
+	cmd.Stdin = strings.NewReader(input)
+	// Never let a helper block on an interactive prompt
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0", "GCM_INTERACTIVE=never")
+
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("run credential helper %s: %w", argv[0], err)
+	}
+	return stdout.String(), nil
+}
+
+// resolveCredentialHelperAuth asks the git credential helpers configured in
+// the user's global gitconfig for credentials for an HTTP(S) repository URL.
+// Returns nil when the URL is not HTTP(S), no helper is configured, or no
+// helper returns a password.
+func resolveCredentialHelperAuth(ctx context.Context, repoURL string) *CredentialHelperAuth {
+	parsed, err := url.Parse(repoURL)
+	if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") {
+		return nil
+	}
+
+	cfg := configuredCredentials(repoURL)
+	if len(cfg.helpers) == 0 {
+		return nil
+	}
+
+	// Like git, send the path only when credential.useHttpPath asks for
+	// it; helpers such as store match entries saved without one
+	input := fmt.Sprintf("protocol=%s\nhost=%s\n", parsed.Scheme, parsed.Host)
+	if cfg.useHTTPPath {
+		input += fmt.Sprintf("path=%s\n", strings.TrimPrefix(parsed.Path, "/"))
+	}
+	input += "\n"
+
+	for _, helper := range cfg.helpers {
+		argv, ok := helperCommand(helper)
+		if !ok {
+			continue
+		}
+		output, err := runCredentialHelper(ctx, argv, input)
+		if err != nil {
+			continue
+		}
+		username, password := parseCredentialOutput(output)
+		if password != "" {
+			return &CredentialHelperAuth{Username: username, Password: password}
+		}
+	}
+	return nil
+}
+
+// helperCommand converts a credential.helper value into an argv following
+// git's rules, without invoking a shell:
+//   - "name [args]" runs "git credential-name [args] get"
+//   - "/abs/path [args]" runs the path directly with "get"
+//
+// Shell snippets ("!cmd") are rejected since they require sh -c.
+func helperCommand(helper string) ([]string, bool) {
+	fields := strings.Fields(helper)
+	if len(fields) == 0 || strings.HasPrefix(fields[0], "!") {
+		return nil, false
+	}
+
+	name, args := fields[0], fields[1:]
+	if filepath.IsAbs(name) {
+		argv := append([]string{name}, args...)
+		return append(argv, "get"), true
+	}
+
+	if !validHelperName.MatchString(name) {
+		return nil, false
+	}
+	argv := append([]string{"git", "credential-" + name}, args...)
+	return append(argv, "get"), true
+}
+
+// parseCredentialOutput extracts username and password from the
+// key=value output of the git credential protocol.
+func parseCredentialOutput(output string) (string, string) {
+	var username, password string
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "username":
+			username = value
+		case "password":
+			password = value
+		}
+	}
+	return username, password
+}
+
+// credentialConfig holds the credential settings that apply to a URL.
+type credentialConfig struct {
+	helpers     []string
+	useHTTPPath bool
+}
+
+// configuredCredentials returns the credential settings that apply to
+// repoURL, with helpers in the order git would try them. URL-scoped
+// sections ([credential "https://host"]) are included when their URL
+// prefixes repoURL.
+func configuredCredentials(repoURL string) credentialConfig {
+	var cfg credentialConfig
+	for _, path := range globalGitConfigPaths() {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		parseCredentialConfig(string(data), repoURL, &cfg)
+	}
+	return cfg
+}
+
+// globalGitConfigPaths returns the global git config files, honoring
+// GIT_CONFIG_GLOBAL like git does.
+func globalGitConfigPaths() []string {
+	if path := os.Getenv("GIT_CONFIG_GLOBAL"); path != "" {
+		return []string{path}
+	}
+
+	var paths []string
+	xdgConfig := os.Getenv("XDG_CONFIG_HOME")
+	homeDir, _ := os.UserHomeDir()
+	if xdgConfig == "" && homeDir != "" {
+		xdgConfig = filepath.Join(homeDir, ".config")
+	}
+	if xdgConfig != "" {
+		paths = append(paths, filepath.Join(xdgConfig, "git", "config"))
+	}
+	if homeDir != "" {
+		paths = append(paths, filepath.Join(homeDir, ".gitconfig"))
+	}
+	return paths
+}
+
+// parseCredentialConfig scans gitconfig content for helper and
+// useHttpPath entries, adding them to cfg.
+func parseCredentialConfig(content, repoURL string, cfg *credentialConfig) {
+	inCredential := false
+
+	for _, raw := range strings.Split(content, "\n") {
+		line := strings.TrimSpace(raw)
+		if line == "" || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			inCredential = credentialSectionApplies(line, repoURL)
+			continue
+		}
+		if !inCredential {
+			continue
+		}
+
+		key, value, hasValue := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		value = strings.Trim(strings.TrimSpace(value), `"`)
+		switch {
+		case strings.EqualFold(key, "helper") && hasValue:
+			if value == "" {
+				// An empty helper resets the list, as in git
+				cfg.helpers = nil
+				continue
+			}
+			cfg.helpers = append(cfg.helpers, value)
+		case strings.EqualFold(key, "useHttpPath"):
+			// A key without a value is true, as in git
+			cfg.useHTTPPath = !hasValue || gitConfigBool(value)
+		}
+	}
+}
+
+// gitConfigBool reports whether value is one of git's true spellings.
+func gitConfigBool(value string) bool {
+	switch strings.ToLower(value) {
+	case "true", "yes", "on", "1":
+		return true
+	default:
+		return false
+	}
+}
+
+// credentialSectionApplies reports whether a section header is [credential]
+// or a [credential "<url>"] whose URL prefixes repoURL.
+func credentialSectionApplies(header, repoURL string) bool {
+	inner := strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(header, "["), "]"))
+	name, subsection, hasSub := strings.Cut(inner, " ")
+	if !strings.EqualFold(name, "credential") {
+		return false
+	}
+	if !hasSub {
+		return true
+	}
+	scope := strings.Trim(strings.TrimSpace(subsection), `"`)
+	return strings.HasPrefix(repoURL, scope)
+}
@@ -0,0 +1,247 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMain isolates tests from the developer's real gitconfig so that a
+// locally configured credential helper is never invoked.
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "dot-adapters-gitconfig")
+	if err != nil {
+		panic(err)
+	}
+	os.Setenv("GIT_CONFIG_GLOBAL", filepath.Join(dir, "gitconfig"))
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+// withGitConfig points GIT_CONFIG_GLOBAL at a temporary file with content.
+func withGitConfig(t *testing.T, content string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "gitconfig")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	t.Setenv("GIT_CONFIG_GLOBAL", path)
+}
+
+// withFakeHelper replaces the helper runner for the duration of the test.
+func withFakeHelper(t *testing.T, fn func(argv []string, input string) (string, error)) {
+	t.Helper()
+	original := runCredentialHelper
+	runCredentialHelper = func(_ context.Context, argv []string, input string) (string, error) {
+		return fn(argv, input)
+	}
+	t.Cleanup(func() { runCredentialHelper = original })
+}
+
+func TestHelperCommand(t *testing.T) {
+	tests := []struct {
+		helper string
+		want   []string
+		ok     bool
+	}{
+		{"store", []string{"git", "credential-store", "get"}, true},
+		{"store --file /tmp/creds", []string{"git", "credential-store", "--file", "/tmp/creds", "get"}, true},
+		{"/usr/local/bin/my-helper", []string{"/usr/local/bin/my-helper", "get"}, true},
+		{"!f() { echo password=x; }; f", nil, false},
+		{"bad;name", nil, false},
+		{"", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.helper, func(t *testing.T) {
+			got, ok := helperCommand(tt.helper)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseCredentialHelpers(t *testing.T) {
+	content := `[user]
+	name = Test
+[credential]
+	helper = cache
+[credential "https://github.com"]
+	helper = osxkeychain
+[credential "https://gitlab.com"]
+	helper = store
+`
+	assert.Equal(t, []string{"cache", "osxkeychain"}, parseHelpers(content, "https://github.com/org/repo"))
+	assert.Equal(t, []string{"cache", "store"}, parseHelpers(content, "https://gitlab.com/org/repo"))
+
+	reset := "[credential]\n\thelper = cache\n\thelper =\n\thelper = store\n"
+	assert.Equal(t, []string{"store"}, parseHelpers(reset, "https://example.com/r"))
+}
+
+// parseHelpers returns the helpers content configures for repoURL.
+func parseHelpers(content, repoURL string) []string {
+	var cfg credentialConfig
+	parseCredentialConfig(content, repoURL, &cfg)
+	return cfg.helpers
+}
+
+func TestParseCredentialConfig_UseHTTPPath(t *testing.T) {
+	tests := []struct {
+		content string
+		want    bool
+	}{
+		{"[credential]\n\thelper = store\n", false},
+		{"[credential]\n\tuseHttpPath = true\n", true},
+		{"[credential]\n\tusehttppath\n", true},
+		{"[credential]\n\tuseHttpPath = true\n\tuseHttpPath = false\n", false},
+		{"[credential \"https://github.com\"]\n\tuseHttpPath = yes\n", true},
+		{"[credential \"https://gitlab.com\"]\n\tuseHttpPath = true\n", false},
+	}
+
+	for _, tt := range tests {
+		var cfg credentialConfig
+		parseCredentialConfig(tt.content, "https://github.com/org/repo", &cfg)
+		assert.Equal(t, tt.want, cfg.useHTTPPath, tt.content)
+	}
+}
+
+func TestParseCredentialOutput(t *testing.T) {
+	username, password := parseCredentialOutput("protocol=https\nhost=github.com\nusername=octo\npassword=s3cret\n")
+	assert.Equal(t, "octo", username)
+	assert.Equal(t, "s3cret", password)
+}
+
+func TestResolveAuth_CredentialHelper(t *testing.T) {
+	withGitConfig(t, "[credential]\n\thelper = store\n")
+	t.Setenv("GITHUB_TOKEN", "env_token")
+
+	var gotArgv []string
+	var gotInput string
+	withFakeHelper(t, func(argv []string, input string) (string, error) {
+		gotArgv, gotInput = argv, input
+		return "username=octo\npassword=helper_token\n", nil
+	})
+
+	auth, err := ResolveAuth(context.Background(), "https://github.com/org/dotfiles.git")
+	require.NoError(t, err)
+
+	assert.Equal(t, CredentialHelperAuth{Username: "octo", Password: "helper_token"}, auth)
+	assert.Equal(t, []string{"git", "credential-store", "get"}, gotArgv)
+	assert.Equal(t, "protocol=https\nhost=github.com\n\n", gotInput)
+}
+
+func TestResolveAuth_CredentialHelperSendsPathWhenConfigured(t *testing.T) {
+	withGitConfig(t, "[credential]\n\thelper = store\n\tuseHttpPath = true\n")
+
+	var gotInput string
+	withFakeHelper(t, func(_ []string, input string) (string, error) {
+		gotInput = input
+		return "password=helper_token\n", nil
+	})
+
+	_, err := ResolveAuth(context.Background(), "https://github.com/org/dotfiles.git")
+	require.NoError(t, err)
+	assert.Equal(t, "protocol=https\nhost=github.com\npath=org/dotfiles.git\n\n", gotInput)
+}
+
+func TestResolveAuth_CredentialStoreEntryWithoutPath(t *testing.T) {
+	withGitConfig(t, "[credential]\n\thelper = store\n")
+	t.Setenv("DOT_GIT_TOKEN", "")
+	t.Setenv("GITHUB_TOKEN", "")
+
+	// Like credential-store, answer only a query that matches a saved
+	// entry exactly; a stored entry has no path
+	stored := map[string]string{"protocol": "https", "host": "github.com"}
+	withFakeHelper(t, func(_ []string, input string) (string, error) {
+		for _, line := range strings.Split(strings.TrimSpace(input), "\n") {
+			key, value, _ := strings.Cut(line, "=")
+			if stored[key] != value {
+				return "", nil
+			}
+		}
+		return "username=octo\npassword=stored_token\n", nil
+	})
+
+	auth, err := ResolveAuth(context.Background(), "https://github.com/org/dotfiles.git")
+	require.NoError(t, err)
+	assert.Equal(t, CredentialHelperAuth{Username: "octo", Password: "stored_token"}, auth)
+}
+
+func TestResolveAuth_CredentialHelperFallsBackToEnv(t *testing.T) {
+	t.Setenv("DOT_GIT_TOKEN", "")
+	t.Setenv("GITHUB_TOKEN", "env_token")
+
+	t.Run("no helper configured", func(t *testing.T) {
+		withGitConfig(t, "[user]\n\tname = Test\n")
+		withFakeHelper(t, func([]string, string) (string, error) {
+			t.Fatal("helper must not run")
+			return "", nil
+		})
+
+		auth, err := ResolveAuth(context.Background(), "https://github.com/org/repo")
+		require.NoError(t, err)
+		assert.Equal(t, TokenAuth{Token: "env_token"}, auth)
+	})
+
+	t.Run("helper fails", func(t *testing.T) {
+		withGitConfig(t, "[credential]\n\thelper = store\n")
+		withFakeHelper(t, func([]string, string) (string, error) {
+			return "", errors.New("exit status 1")
+		})
+
+		auth, err := ResolveAuth(context.Background(), "https://github.com/org/repo")
+		require.NoError(t, err)
+		assert.Equal(t, TokenAuth{Token: "env_token"}, auth)
+	})
+
+	t.Run("ssh url skips helper", func(t *testing.T) {
+		withGitConfig(t, "[credential]\n\thelper = store\n")
+		withFakeHelper(t, func([]string, string) (string, error) {
+			t.Fatal("helper must not run for ssh urls")
+			return "", nil
+		})
+
+		auth, err := ResolveAuth(context.Background(), "git@github.com:org/repo.git")
+		require.NoError(t, err)
+		assert.Equal(t, TokenAuth{Token: "env_token"}, auth)
+	})
+}
+
+func TestConvertAuthMethod_CredentialHelper(t *testing.T) {
+	auth, err := convertAuthMethod(CredentialHelperAuth{Password: "secret"}, nil)
+	require.NoError(t, err)
+
+	basic, ok := auth.(*http.BasicAuth)
+	require.True(t, ok)
+	assert.Equal(t, "git", basic.Username)
+	assert.Equal(t, "secret", basic.Password)
+}
+
+func TestConvertAuthMethod_TokenUsername(t *testing.T) {
+	tests := []struct {
+		name     string
+		auth     TokenAuth
+		wantUser string
+	}{
+		{name: "default username", auth: TokenAuth{Token: "ghp"}, wantUser: "git"},
+		{name: "host username", auth: TokenAuth{Token: "glpat", Username: "oauth2"}, wantUser: "oauth2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auth, err := convertAuthMethod(tt.auth, nil)
+			require.NoError(t, err)
+
+			basic, ok := auth.(*http.BasicAuth)
+			require.True(t, ok)
+			assert.Equal(t, tt.wantUser, basic.Username)
+			assert.Equal(t, tt.auth.Token, basic.Password)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package adapters
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+
+	"github.com/yaklabco/dot/internal/cli/terminal"
+)
+
+// TerminalPassphrasePrompter asks for SSH key passphrases on the given
+// input and output. Input from a terminal is read without echo.
+type TerminalPassphrasePrompter struct {
+	in  io.Reader
+	out io.Writer
+}
+
+// NewTerminalPassphrasePrompter creates a prompter that reads from in and
+// writes prompts to out.
+func NewTerminalPassphrasePrompter(in io.Reader, out io.Writer) *TerminalPassphrasePrompter {
+	return &TerminalPassphrasePrompter{
+		in:  in,
+		out: out,
+	}
+}
+
+// PromptPassphrase asks for the passphrase of the key at keyPath.
+func (p *TerminalPassphrasePrompter) PromptPassphrase(keyPath string) (string, error) {
+	fmt.Fprintf(p.out, "Enter passphrase for key '%s': ", keyPath)
+
+	if f, ok := p.in.(*os.File); ok && term.IsTerminal(terminal.FdInt(f.Fd())) {
+		passphrase, err := term.ReadPassword(terminal.FdInt(f.Fd()))
+		fmt.Fprintln(p.out)
+		if err != nil {
+			return "", fmt.Errorf("read passphrase: %w", err)
+		}
+		return string(passphrase), nil
+	}
+
+	line, err := bufio.NewReader(p.in).ReadString('\n')
+	if err != nil && line == "" {
+		return "", fmt.Errorf("read passphrase: %w", err)
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
@@ -0,0 +1,28 @@
+package adapters
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTerminalPassphrasePrompter(t *testing.T) {
+	var out bytes.Buffer
+	prompter := NewTerminalPassphrasePrompter(strings.NewReader("secret\r\n"), &out)
+
+	passphrase, err := prompter.PromptPassphrase("/home/user/.ssh/id_work")
+	require.NoError(t, err)
+	assert.Equal(t, "secret", passphrase)
+	assert.Contains(t, out.String(), "/home/user/.ssh/id_work")
+}
+
+func TestTerminalPassphrasePrompter_NoInput(t *testing.T) {
+	var out bytes.Buffer
+	prompter := NewTerminalPassphrasePrompter(strings.NewReader(""), &out)
+
+	_, err := prompter.PromptPassphrase("/home/user/.ssh/id_work")
+	assert.Error(t, err)
+}
@@ -2,14 +2,20 @@ package adapters
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math"
+	neturl "net/url"
 	"os"
+	"strings"
 
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/transport"
 	"github.com/go-git/go-git/v5/plumbing/transport/http"
 	"github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	gossh "golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
 )
 
 // GoGitCloner implements GitCloner using go-git library.
@@ -28,16 +34,17 @@ func (g *GoGitCloner) Clone(ctx context.Context, url string, path string, opts C
 	}
 
 	// Convert auth method to go-git transport auth
-	auth, err := convertAuthMethod(opts.Auth)
+	auth, err := convertAuthMethod(opts.Auth, opts.PassphrasePrompter)
 	if err != nil {
 		return fmt.Errorf("configure authentication: %w", err)
 	}
 
 	// Build clone options
 	cloneOpts := &git.CloneOptions{
-		URL:      url,
-		Progress: opts.Progress,
-		Auth:     auth,
+		URL:          url,
+		Progress:     opts.Progress,
+		Auth:         auth,
+		SingleBranch: opts.SingleBranch,
 	}
 
 	// Set branch reference if specified
@@ -53,12 +60,99 @@ func (g *GoGitCloner) Clone(ctx context.Context, url string, path string, opts C
 	// Perform clone with context
 	_, err = git.PlainCloneContext(ctx, path, false, cloneOpts)
 	if err != nil {
+		if hostErr, ok := hostKeyError(url, err); ok {
+			return hostErr
+		}
 		return fmt.Errorf("clone repository: %w", err)
 	}
 
 	return nil
 }
 
+// Unshallow fetches the full history of the shallow clone at path.
+func (g *GoGitCloner) Unshallow(ctx context.Context, path string, opts UnshallowOptions) error {
+	repo, err := git.PlainOpen(path)
+	if err != nil {
+		return fmt.Errorf("open repository: %w", err)
+	}
+
+	shallows, err := repo.Storer.Shallow()
+	if err != nil {
+		return fmt.Errorf("read shallow commits: %w", err)
+	}
+	if len(shallows) == 0 {
+		return nil
+	}
+
+	auth, err := convertAuthMethod(opts.Auth, opts.PassphrasePrompter)
+	if err != nil {
+		return fmt.Errorf("configure authentication: %w", err)
+	}
+
+	// git fetch --unshallow requests the same "infinite" depth
+	err = repo.FetchContext(ctx, &git.FetchOptions{
+		RemoteName: git.DefaultRemoteName,
+		Depth:      math.MaxInt32,
+		Auth:       auth,
+		Progress:   opts.Progress,
+	})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		url, _ := OriginURL(path)
+		if hostErr, ok := hostKeyError(url, err); ok {
+			return hostErr
+		}
+		return fmt.Errorf("fetch full history: %w", err)
+	}
+
+	// go-git records new shallow boundaries but never drops the ones the
+	// server deepened, so keep only commits still missing a parent
+	shallows, err = repo.Storer.Shallow()
+	if err != nil {
+		return fmt.Errorf("read shallow commits: %w", err)
+	}
+	remaining := make([]plumbing.Hash, 0, len(shallows))
+	for _, hash := range shallows {
+		if !hasParents(repo, hash) {
+			remaining = append(remaining, hash)
+		}
+	}
+	if err := repo.Storer.SetShallow(remaining); err != nil {
+		return fmt.Errorf("update shallow commits: %w", err)
+	}
+	return nil
+}
+
+// hasParents reports whether every parent of the commit is present.
+func hasParents(repo *git.Repository, hash plumbing.Hash) bool {
+	commit, err := repo.CommitObject(hash)
+	if err != nil {
+		return false
+	}
+	for _, parent := range commit.ParentHashes {
+		if _, err := repo.Storer.EncodedObject(plumbing.CommitObject, parent); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// OriginURL returns the URL of the origin remote of the repository at path.
+func OriginURL(path string) (string, error) {
+	repo, err := git.PlainOpen(path)
+	if err != nil {
+		return "", fmt.Errorf("open repository: %w", err)
+	}
+	remote, err := repo.Remote(git.DefaultRemoteName)
+	if err != nil {
+		return "", fmt.Errorf("read %s remote: %w", git.DefaultRemoteName, err)
+	}
+	urls := remote.Config().URLs
+	if len(urls) == 0 {
+		return "", fmt.Errorf("%s remote has no URL", git.DefaultRemoteName)
+	}
+	return urls[0], nil
+}
+
 // validateTargetPath checks if the target path is suitable for cloning.
 func validateTargetPath(path string) error {
 	info, err := os.Stat(path)
@@ -89,7 +183,8 @@ func validateTargetPath(path string) error {
 }
 
 // convertAuthMethod converts our AuthMethod to go-git transport auth.
-func convertAuthMethod(auth AuthMethod) (transport.AuthMethod, error) {
+// prompter supplies the passphrase of an encrypted SSH key; it may be nil.
+func convertAuthMethod(auth AuthMethod, prompter PassphrasePrompter) (transport.AuthMethod, error) {
 	if auth == nil {
 		return nil, nil
 	}
@@ -101,20 +196,125 @@ func convertAuthMethod(auth AuthMethod) (transport.AuthMethod, error) {
 	case TokenAuth:
 		// Most git providers (GitHub, GitLab, Gitea, Azure DevOps) expect the token
 		// in the password field with a placeholder username
+		username := a.Username
+		if username == "" {
+			username = "git"
+		}
 		return &http.BasicAuth{
-			Username: "git",
+			Username: username,
 			Password: a.Token, // Token goes in password field
 		}, nil
 
-	case SSHAuth:
-		// Load SSH private key
-		publicKeys, err := ssh.NewPublicKeysFromFile("git", a.PrivateKeyPath, a.Passphrase)
-		if err != nil {
-			return nil, fmt.Errorf("load SSH key: %w", err)
+	case CredentialHelperAuth:
+		username := a.Username
+		if username == "" {
+			username = "git"
 		}
-		return publicKeys, nil
+		return &http.BasicAuth{
+			Username: username,
+			Password: a.Password,
+		}, nil
+
+	case SSHAuth:
+		return convertSSHAuth(a, prompter)
 
 	default:
 		return nil, fmt.Errorf("unsupported authentication method: %T", auth)
 	}
 }
+
+// convertSSHAuth builds go-git SSH auth from a private key file, or from
+// the SSH agent when no key path is set.
+func convertSSHAuth(a SSHAuth, prompter PassphrasePrompter) (transport.AuthMethod, error) {
+	var hostKeyCallback gossh.HostKeyCallback
+	if a.KnownHostsPath != "" {
+		callback, err := ssh.NewKnownHostsCallback(a.KnownHostsPath)
+		if err != nil {
+			return nil, fmt.Errorf("load known hosts %s: %w", a.KnownHostsPath, err)
+		}
+		hostKeyCallback = callback
+	}
+
+	if a.PrivateKeyPath == "" {
+		agentAuth, err := ssh.NewSSHAgentAuth(ssh.DefaultUsername)
+		if err != nil {
+			return nil, fmt.Errorf("connect to SSH agent: %w", err)
+		}
+		agentAuth.HostKeyCallback = hostKeyCallback
+		return agentAuth, nil
+	}
+
+	publicKeys, err := loadSSHKey(a.PrivateKeyPath, a.Passphrase, prompter)
+	if err != nil {
+		return nil, err
+	}
+	publicKeys.HostKeyCallback = hostKeyCallback
+	return publicKeys, nil
+}
+
+// loadSSHKey reads a private key, asking prompter for the passphrase when
+// the key is encrypted and none was given.
+func loadSSHKey(path, passphrase string, prompter PassphrasePrompter) (*ssh.PublicKeys, error) {
+	pemBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("load SSH key: %w", err)
+	}
+
+	if passphrase == "" {
+		var missing *gossh.PassphraseMissingError
+		if _, err := gossh.ParsePrivateKey(pemBytes); errors.As(err, &missing) {
+			if prompter == nil {
+				return nil, fmt.Errorf("load SSH key: %s is encrypted and no passphrase was given", path)
+			}
+			passphrase, err = prompter.PromptPassphrase(path)
+			if err != nil {
+				return nil, fmt.Errorf("read passphrase for %s: %w", path, err)
+			}
+		}
+	}
+
+	publicKeys, err := ssh.NewPublicKeys(ssh.DefaultUsername, pemBytes, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("load SSH key: %w", err)
+	}
+	return publicKeys, nil
+}
+
+// hostKeyError reports whether err is an SSH host key verification
+// failure and, if so, returns it as ErrHostKeyVerification.
+func hostKeyError(repoURL string, err error) (ErrHostKeyVerification, bool) {
+	hostErr := ErrHostKeyVerification{Host: repoHost(repoURL), Cause: err}
+
+	var keyErr *knownhosts.KeyError
+	if errors.As(err, &keyErr) {
+		hostErr.Mismatch = len(keyErr.Want) > 0
+		return hostErr, true
+	}
+
+	// go-git does not always wrap the underlying error
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "knownhosts: key mismatch"):
+		hostErr.Mismatch = true
+		return hostErr, true
+	case strings.Contains(msg, "knownhosts: key is unknown"),
+		strings.Contains(msg, "unable to find any valid known_hosts file"):
+		return hostErr, true
+	}
+	return ErrHostKeyVerification{}, false
+}
+
+// repoHost extracts the host name from a repository URL, in either
+// scp-like (git@host:path) or scheme://host form.
+func repoHost(repoURL string) string {
+	if strings.Contains(repoURL, "://") {
+		if u, err := neturl.Parse(repoURL); err == nil {
+			return u.Hostname()
+		}
+	}
+	host, _, _ := strings.Cut(repoURL, ":")
+	if _, after, found := strings.Cut(host, "@"); found {
+		host = after
+	}
+	return host
+}
@@ -2,16 +2,25 @@ package adapters
 
 import (
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport/ssh"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	gossh "golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
 )
 
 // getTestRepoURL returns a file:// URL to the local test repository fixture.
@@ -209,3 +218,318 @@ func TestGoGitCloner_Clone_ContextCancellation(t *testing.T) {
 	err := cloner.Clone(ctx, url, tempDir, opts)
 	assert.Error(t, err)
 }
+
+func TestGoGitCloner_Clone_SingleBranch(t *testing.T) {
+	ctx := context.Background()
+
+	// Source repository with two branches
+	srcPath := filepath.Join(t.TempDir(), "src")
+	require.NoError(t, os.MkdirAll(srcPath, 0755))
+	repo, err := git.PlainInit(srcPath, false)
+	require.NoError(t, err)
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(srcPath, "README.md"), []byte("dotfiles\n"), 0644))
+	_, err = worktree.Add("README.md")
+	require.NoError(t, err)
+	hash, err := worktree.Commit("Initial commit", &git.CommitOptions{
+		Author: &object.Signature{Name: "Test User", Email: "test@example.com", When: time.Now()},
+	})
+	require.NoError(t, err)
+	require.NoError(t, repo.Storer.SetReference(plumbing.NewHashReference("refs/heads/work", hash)))
+
+	remoteBranches := func(t *testing.T, path string) []string {
+		t.Helper()
+		cloned, err := git.PlainOpen(path)
+		require.NoError(t, err)
+		refs, err := cloned.References()
+		require.NoError(t, err)
+		var names []string
+		require.NoError(t, refs.ForEach(func(ref *plumbing.Reference) error {
+			if ref.Name().IsRemote() {
+				names = append(names, ref.Name().Short())
+			}
+			return nil
+		}))
+		return names
+	}
+
+	tests := []struct {
+		name         string
+		singleBranch bool
+		want         []string
+	}{
+		{name: "all branches", singleBranch: false, want: []string{"origin/master", "origin/work"}},
+		{name: "single branch", singleBranch: true, want: []string{"origin/work"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			targetPath := filepath.Join(t.TempDir(), "repo")
+			err := NewGoGitCloner().Clone(ctx, "file://"+srcPath, targetPath, CloneOptions{
+				Auth:         NoAuth{},
+				Branch:       "work",
+				SingleBranch: tt.singleBranch,
+			})
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.want, remoteBranches(t, targetPath))
+		})
+	}
+}
+
+// initHistoryRepo creates a repository with the given number of commits,
+// each changing README.md, and returns its path.
+func initHistoryRepo(t *testing.T, commits int) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "src")
+	repo, err := git.PlainInit(path, false)
+	require.NoError(t, err)
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+	for i := 0; i < commits; i++ {
+		require.NoError(t, os.WriteFile(filepath.Join(path, "README.md"), []byte(fmt.Sprintf("revision %d\n", i)), 0644))
+		_, err = worktree.Add("README.md")
+		require.NoError(t, err)
+		_, err = worktree.Commit(fmt.Sprintf("Commit %d", i), &git.CommitOptions{
+			Author: &object.Signature{Name: "Test User", Email: "test@example.com", When: time.Now()},
+		})
+		require.NoError(t, err)
+	}
+	return path
+}
+
+// countObjects returns the number of objects stored in the repository at path.
+func countObjects(t *testing.T, path string) int {
+	t.Helper()
+	repo, err := git.PlainOpen(path)
+	require.NoError(t, err)
+	iter, err := repo.Storer.IterEncodedObjects(plumbing.AnyObject)
+	require.NoError(t, err)
+	count := 0
+	require.NoError(t, iter.ForEach(func(plumbing.EncodedObject) error {
+		count++
+		return nil
+	}))
+	return count
+}
+
+func TestGoGitCloner_Unshallow(t *testing.T) {
+	ctx := context.Background()
+	srcPath := initHistoryRepo(t, 3)
+	targetPath := filepath.Join(t.TempDir(), "repo")
+	cloner := NewGoGitCloner()
+	require.NoError(t, cloner.Clone(ctx, "file://"+srcPath, targetPath, CloneOptions{Auth: NoAuth{}, Depth: 1}))
+
+	shallowCount := countObjects(t, targetPath)
+
+	var progress strings.Builder
+	require.NoError(t, cloner.Unshallow(ctx, targetPath, UnshallowOptions{Auth: NoAuth{}, Progress: &progress}))
+
+	assert.Greater(t, countObjects(t, targetPath), shallowCount)
+	repo, err := git.PlainOpen(targetPath)
+	require.NoError(t, err)
+	shallows, err := repo.Storer.Shallow()
+	require.NoError(t, err)
+	assert.Empty(t, shallows)
+
+	log, err := repo.Log(&git.LogOptions{})
+	require.NoError(t, err)
+	history := 0
+	require.NoError(t, log.ForEach(func(*object.Commit) error {
+		history++
+		return nil
+	}))
+	assert.Equal(t, 3, history)
+}
+
+func TestGoGitCloner_Unshallow_CompleteRepository(t *testing.T) {
+	ctx := context.Background()
+	srcPath := initHistoryRepo(t, 2)
+	targetPath := filepath.Join(t.TempDir(), "repo")
+	cloner := NewGoGitCloner()
+	require.NoError(t, cloner.Clone(ctx, "file://"+srcPath, targetPath, CloneOptions{Auth: NoAuth{}}))
+	before := countObjects(t, targetPath)
+
+	// Removing the source proves no fetch is attempted
+	require.NoError(t, os.RemoveAll(srcPath))
+	require.NoError(t, cloner.Unshallow(ctx, targetPath, UnshallowOptions{Auth: NoAuth{}}))
+	assert.Equal(t, before, countObjects(t, targetPath))
+}
+
+func TestGoGitCloner_Unshallow_NotRepository(t *testing.T) {
+	err := NewGoGitCloner().Unshallow(context.Background(), t.TempDir(), UnshallowOptions{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "open repository")
+}
+
+func TestOriginURL(t *testing.T) {
+	srcPath := initHistoryRepo(t, 1)
+	targetPath := filepath.Join(t.TempDir(), "repo")
+	require.NoError(t, NewGoGitCloner().Clone(context.Background(), "file://"+srcPath, targetPath, CloneOptions{Auth: NoAuth{}}))
+
+	url, err := OriginURL(targetPath)
+	require.NoError(t, err)
+	assert.Equal(t, "file://"+srcPath, url)
+
+	_, err = OriginURL(srcPath)
+	require.Error(t, err)
+}
+
+// writeSSHKey generates an ed25519 private key and writes it to a
+// temporary file, encrypted with passphrase if it is non-empty.
+func writeSSHKey(t *testing.T, passphrase string) string {
+	t.Helper()
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	var block *pem.Block
+	if passphrase == "" {
+		block, err = gossh.MarshalPrivateKey(priv, "")
+	} else {
+		block, err = gossh.MarshalPrivateKeyWithPassphrase(priv, "", []byte(passphrase))
+	}
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "id_ed25519")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(block), 0600))
+	return path
+}
+
+// stubPrompter returns a fixed passphrase and records the keys it was asked about.
+type stubPrompter struct {
+	passphrase string
+	keys       []string
+}
+
+func (p *stubPrompter) PromptPassphrase(keyPath string) (string, error) {
+	p.keys = append(p.keys, keyPath)
+	return p.passphrase, nil
+}
+
+func TestLoadSSHKey(t *testing.T) {
+	t.Run("unencrypted key does not prompt", func(t *testing.T) {
+		path := writeSSHKey(t, "")
+		prompter := &stubPrompter{}
+
+		_, err := loadSSHKey(path, "", prompter)
+		require.NoError(t, err)
+		assert.Empty(t, prompter.keys)
+	})
+
+	t.Run("encrypted key prompts for passphrase", func(t *testing.T) {
+		path := writeSSHKey(t, "secret")
+		prompter := &stubPrompter{passphrase: "secret"}
+
+		_, err := loadSSHKey(path, "", prompter)
+		require.NoError(t, err)
+		assert.Equal(t, []string{path}, prompter.keys)
+	})
+
+	t.Run("configured passphrase skips prompt", func(t *testing.T) {
+		path := writeSSHKey(t, "secret")
+		prompter := &stubPrompter{}
+
+		_, err := loadSSHKey(path, "secret", prompter)
+		require.NoError(t, err)
+		assert.Empty(t, prompter.keys)
+	})
+
+	t.Run("wrong passphrase", func(t *testing.T) {
+		path := writeSSHKey(t, "secret")
+
+		_, err := loadSSHKey(path, "", &stubPrompter{passphrase: "wrong"})
+		assert.Error(t, err)
+	})
+
+	t.Run("encrypted key without prompter", func(t *testing.T) {
+		path := writeSSHKey(t, "secret")
+
+		_, err := loadSSHKey(path, "", nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "is encrypted")
+	})
+}
+
+func TestConvertSSHAuth_KnownHosts(t *testing.T) {
+	keyPath := writeSSHKey(t, "")
+	knownHosts := filepath.Join(t.TempDir(), "known_hosts")
+	require.NoError(t, os.WriteFile(knownHosts, nil, 0600))
+
+	auth, err := convertSSHAuth(SSHAuth{PrivateKeyPath: keyPath, KnownHostsPath: knownHosts}, nil)
+	require.NoError(t, err)
+	publicKeys, ok := auth.(*ssh.PublicKeys)
+	require.True(t, ok)
+	assert.NotNil(t, publicKeys.HostKeyCallback)
+
+	_, err = convertSSHAuth(SSHAuth{PrivateKeyPath: keyPath, KnownHostsPath: filepath.Join(t.TempDir(), "missing")}, nil)
+	assert.Error(t, err)
+}
+
+func TestHostKeyError(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		wantOK       bool
+		wantMismatch bool
+	}{
+		{
+			name:   "unknown host",
+			err:    fmt.Errorf("handshake: %w", &knownhosts.KeyError{}),
+			wantOK: true,
+		},
+		{
+			name:         "changed host key",
+			err:          fmt.Errorf("handshake: %w", &knownhosts.KeyError{Want: []knownhosts.KnownKey{{Filename: "known_hosts", Line: 3}}}),
+			wantOK:       true,
+			wantMismatch: true,
+		},
+		{
+			name:   "unwrapped unknown host",
+			err:    errors.New("ssh: handshake failed: knownhosts: key is unknown"),
+			wantOK: true,
+		},
+		{
+			name:         "unwrapped mismatch",
+			err:          errors.New("ssh: handshake failed: knownhosts: key mismatch"),
+			wantOK:       true,
+			wantMismatch: true,
+		},
+		{
+			name: "other error",
+			err:  errors.New("repository not found"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hostErr, ok := hostKeyError("git@github.com:user/repo.git", tt.err)
+			assert.Equal(t, tt.wantOK, ok)
+			if !tt.wantOK {
+				return
+			}
+			assert.Equal(t, "github.com", hostErr.Host)
+			assert.Equal(t, tt.wantMismatch, hostErr.Mismatch)
+			assert.ErrorIs(t, hostErr, tt.err)
+		})
+	}
+}
+
+func TestRepoHost(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{url: "git@github.com:user/repo.git", want: "github.com"},
+		{url: "github.com:user/repo.git", want: "github.com"},
+		{url: "ssh://git@gitlab.example.com:2222/user/repo.git", want: "gitlab.example.com"},
+		{url: "ssh://example.com/repo.git", want: "example.com"},
+		{url: "https://gitlab.com/user/repo.git", want: "gitlab.com"},
+		{url: "https://user@bitbucket.org:443/team/repo.git", want: "bitbucket.org"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			assert.Equal(t, tt.want, repoHost(tt.url))
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"io/fs"
 	"path/filepath"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/yaklabco/dot/internal/domain"
@@ -13,6 +14,7 @@ import (
 
 // MemFS implements an in-memory filesystem for testing.
 // It is thread-safe and can be used in concurrent tests.
+// Stat, ReadFile, and IsDir follow symlinks; Lstat and ReadLink do not.
 type MemFS struct {
 	files map[string]*memFile
 	mu    sync.RWMutex
@@ -26,6 +28,10 @@ type memFile struct {
 	symlink string // If not empty, this is a symlink
 }
 
+// maxSymlinkHops bounds symlink resolution, matching the Linux MAXSYMLINKS
+// limit at which the kernel reports ELOOP.
+const maxSymlinkHops = 40
+
 // NewMemFS creates a new in-memory filesystem.
 func NewMemFS() *MemFS {
 	return &MemFS{
@@ -37,9 +43,9 @@ func (f *MemFS) Stat(ctx context.Context, name string) (domain.FileInfo, error)
 	f.mu.RLock()
 	defer f.mu.RUnlock()
 
-	file, exists := f.files[name]
-	if !exists {
-		return nil, fs.ErrNotExist
+	file, err := f.resolve("stat", name)
+	if err != nil {
+		return nil, err
 	}
 
 	return &memFileInfo{
@@ -116,6 +122,11 @@ func (f *MemFS) ReadDir(ctx context.Context, name string) ([]domain.DirEntry, er
 	return entries, nil
 }
 
+// Walk walks the tree at root. Symlinks are not followed.
+func (f *MemFS) Walk(ctx context.Context, root string, fn domain.WalkFunc) error {
+	return domain.WalkDir(ctx, f, root, fn)
+}
+
 func (f *MemFS) ReadLink(ctx context.Context, name string) (string, error) {
 	f.mu.RLock()
 	defer f.mu.RUnlock()
@@ -135,9 +146,9 @@ func (f *MemFS) ReadFile(ctx context.Context, name string) ([]byte, error) {
 	f.mu.RLock()
 	defer f.mu.RUnlock()
 
-	file, exists := f.files[name]
-	if !exists {
-		return nil, fs.ErrNotExist
+	file, err := f.resolve("open", name)
+	if err != nil {
+		return nil, err
 	}
 	if file.isDir {
 		return nil, errors.New("is a directory")
@@ -146,6 +157,21 @@ func (f *MemFS) ReadFile(ctx context.Context, name string) ([]byte, error) {
 	return file.data, nil
 }
 
+func (f *MemFS) ReadFileRange(ctx context.Context, name string, offset, length int64) ([]byte, error) {
+	if offset < 0 || length < 0 {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: fs.ErrInvalid}
+	}
+	data, err := f.ReadFile(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if offset >= int64(len(data)) {
+		return []byte{}, nil
+	}
+	end := min(offset+length, int64(len(data)))
+	return append([]byte(nil), data[offset:end]...), nil
+}
+
 func (f *MemFS) WriteFile(ctx context.Context, name string, data []byte, perm fs.FileMode) error {
 	f.mu.Lock()
 	defer f.mu.Unlock()
@@ -283,6 +309,33 @@ func (f *MemFS) Symlink(ctx context.Context, oldname, newname string) error {
 	return nil
 }
 
+// Link creates a hard link. Both names share the same underlying file entry.
+func (f *MemFS) Link(ctx context.Context, oldname, newname string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	file, exists := f.files[oldname]
+	if !exists {
+		return fs.ErrNotExist
+	}
+	if file.isDir {
+		return fs.ErrPermission
+	}
+	if _, exists := f.files[newname]; exists {
+		return fs.ErrExist
+	}
+
+	parent := filepath.Dir(newname)
+	if parent != "." && parent != "/" {
+		if _, exists := f.files[parent]; !exists {
+			return fs.ErrNotExist
+		}
+	}
+
+	f.files[newname] = file
+	return nil
+}
+
 func (f *MemFS) Rename(ctx context.Context, oldname, newname string) error {
 	f.mu.Lock()
 	defer f.mu.Unlock()
@@ -333,9 +386,9 @@ func (f *MemFS) IsDir(ctx context.Context, name string) (bool, error) {
 	f.mu.RLock()
 	defer f.mu.RUnlock()
 
-	file, exists := f.files[name]
-	if !exists {
-		return false, fs.ErrNotExist
+	file, err := f.resolve("stat", name)
+	if err != nil {
+		return false, err
 	}
 
 	return file.isDir, nil
@@ -353,6 +406,28 @@ func (f *MemFS) IsSymlink(ctx context.Context, name string) (bool, error) {
 	return file.symlink != "", nil
 }
 
+// resolve returns the entry for name, following symlinks. Relative link
+// targets are resolved against the link's directory. Like the OS, a chain
+// longer than maxSymlinkHops (including a cycle) fails with ELOOP. Callers
+// must hold f.mu.
+func (f *MemFS) resolve(op, name string) (*memFile, error) {
+	current := name
+	for hops := 0; ; hops++ {
+		file, exists := f.files[current]
+		if !exists {
+			return nil, fs.ErrNotExist
+		}
+		if file.symlink == "" {
+			return file, nil
+		}
+		if hops == maxSymlinkHops {
+			return nil, &fs.PathError{Op: op, Path: name, Err: syscall.ELOOP}
+		}
+
+		current = domain.ResolveLinkTarget(current, file.symlink)
+	}
+}
+
 // memFileInfo implements fs.FileInfo (domain.FileInfo is a type alias for fs.FileInfo).
 type memFileInfo struct {
 	name    string
@@ -0,0 +1,130 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemFS_FollowsSymlinks(t *testing.T) {
+	ctx := context.Background()
+	mfs := NewMemFS()
+	require.NoError(t, mfs.MkdirAll(ctx, "/home/config", 0755))
+	require.NoError(t, mfs.WriteFile(ctx, "/home/config/vimrc", []byte("set nu"), 0644))
+	require.NoError(t, mfs.Symlink(ctx, "config/vimrc", "/home/.vimrc"))
+	require.NoError(t, mfs.Symlink(ctx, "/home/.vimrc", "/home/.vimrc2"))
+	require.NoError(t, mfs.Symlink(ctx, "/home/config", "/home/.config"))
+
+	data, err := mfs.ReadFile(ctx, "/home/.vimrc2")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("set nu"), data)
+
+	info, err := mfs.Stat(ctx, "/home/.vimrc")
+	require.NoError(t, err)
+	assert.Equal(t, int64(len("set nu")), info.Size())
+	assert.Zero(t, info.Mode()&fs.ModeSymlink)
+
+	isDir, err := mfs.IsDir(ctx, "/home/.config")
+	require.NoError(t, err)
+	assert.True(t, isDir)
+
+	// Lstat still reports the link itself
+	info, err = mfs.Lstat(ctx, "/home/.vimrc")
+	require.NoError(t, err)
+	assert.NotZero(t, info.Mode()&fs.ModeSymlink)
+}
+
+func TestMemFS_DanglingSymlink(t *testing.T) {
+	ctx := context.Background()
+	mfs := NewMemFS()
+	require.NoError(t, mfs.MkdirAll(ctx, "/home", 0755))
+	require.NoError(t, mfs.Symlink(ctx, "/home/missing", "/home/dangling"))
+
+	_, err := mfs.Stat(ctx, "/home/dangling")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+
+	_, err = mfs.Lstat(ctx, "/home/dangling")
+	assert.NoError(t, err)
+}
+
+func TestMemFS_SymlinkLoop(t *testing.T) {
+	tests := []struct {
+		name  string
+		links map[string]string // link -> target
+		path  string
+	}{
+		{
+			name:  "self reference",
+			links: map[string]string{"/home/a": "a"},
+			path:  "/home/a",
+		},
+		{
+			name:  "two link cycle",
+			links: map[string]string{"/home/a": "/home/b", "/home/b": "/home/a"},
+			path:  "/home/a",
+		},
+		{
+			name:  "chain into cycle",
+			links: map[string]string{"/home/start": "x", "/home/x": "y", "/home/y": "z", "/home/z": "x"},
+			path:  "/home/start",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			mfs := NewMemFS()
+			require.NoError(t, mfs.MkdirAll(ctx, "/home", 0755))
+			for link, target := range tt.links {
+				require.NoError(t, mfs.Symlink(ctx, target, link))
+			}
+
+			_, err := mfs.Stat(ctx, tt.path)
+			assertLoopError(t, err, "stat", tt.path)
+
+			_, err = mfs.ReadFile(ctx, tt.path)
+			assertLoopError(t, err, "open", tt.path)
+
+			_, err = mfs.IsDir(ctx, tt.path)
+			assertLoopError(t, err, "stat", tt.path)
+		})
+	}
+}
+
+func TestMemFS_SymlinkChainAtHopLimit(t *testing.T) {
+	ctx := context.Background()
+	mfs := NewMemFS()
+	require.NoError(t, mfs.MkdirAll(ctx, "/home", 0755))
+	require.NoError(t, mfs.WriteFile(ctx, "/home/file", []byte("data"), 0644))
+
+	// A chain of exactly maxSymlinkHops links resolves
+	target := "/home/file"
+	for i := 0; i < maxSymlinkHops; i++ {
+		link := target + "-link"
+		require.NoError(t, mfs.Symlink(ctx, target, link))
+		target = link
+	}
+	_, err := mfs.Stat(ctx, target)
+	require.NoError(t, err)
+
+	// One more hop exceeds the limit
+	require.NoError(t, mfs.Symlink(ctx, target, "/home/too-far"))
+	_, err = mfs.Stat(ctx, "/home/too-far")
+	assertLoopError(t, err, "stat", "/home/too-far")
+}
+
+func assertLoopError(t *testing.T, err error, op, path string) {
+	t.Helper()
+	require.Error(t, err)
+	assert.ErrorIs(t, err, syscall.ELOOP)
+
+	var pathErr *fs.PathError
+	require.True(t, errors.As(err, &pathErr))
+	assert.Equal(t, op, pathErr.Op)
+	assert.Equal(t, path, pathErr.Path)
+}
@@ -62,6 +62,37 @@ func TestMemFS_ReadFile_IsDirectory(t *testing.T) {
 	require.Contains(t, err.Error(), "directory")
 }
 
+func TestMemFS_ReadFileRange(t *testing.T) {
+	ctx := context.Background()
+	mfs := NewMemFS()
+
+	require.NoError(t, mfs.MkdirAll(ctx, "/home", 0755))
+	require.NoError(t, mfs.WriteFile(ctx, "/home/test.txt", []byte("0123456789"), 0644))
+
+	data, err := mfs.ReadFileRange(ctx, "/home/test.txt", 2, 4)
+	require.NoError(t, err)
+	require.Equal(t, []byte("2345"), data)
+
+	data[0] = 'x'
+	full, err := mfs.ReadFile(ctx, "/home/test.txt")
+	require.NoError(t, err)
+	require.Equal(t, []byte("0123456789"), full, "the returned range is a copy")
+
+	data, err = mfs.ReadFileRange(ctx, "/home/test.txt", 8, 100)
+	require.NoError(t, err)
+	require.Equal(t, []byte("89"), data)
+
+	data, err = mfs.ReadFileRange(ctx, "/home/test.txt", 20, 4)
+	require.NoError(t, err)
+	require.Empty(t, data)
+
+	_, err = mfs.ReadFileRange(ctx, "/home", 0, 4)
+	require.Error(t, err)
+
+	_, err = mfs.ReadFileRange(ctx, "/nonexistent", 0, 4)
+	require.ErrorIs(t, err, fs.ErrNotExist)
+}
+
 func TestMemFS_Mkdir(t *testing.T) {
 	ctx := context.Background()
 	mfs := NewMemFS()
@@ -183,6 +214,44 @@ func TestMemFS_Symlink_ParentNotExist(t *testing.T) {
 	require.ErrorIs(t, err, fs.ErrNotExist)
 }
 
+func TestMemFS_Link(t *testing.T) {
+	ctx := context.Background()
+	mfs := NewMemFS()
+
+	require.NoError(t, mfs.MkdirAll(ctx, "/packages", 0755))
+	require.NoError(t, mfs.MkdirAll(ctx, "/home", 0755))
+	require.NoError(t, mfs.WriteFile(ctx, "/packages/file", []byte("content"), 0644))
+
+	require.NoError(t, mfs.Link(ctx, "/packages/file", "/home/file"))
+
+	data, err := mfs.ReadFile(ctx, "/home/file")
+	require.NoError(t, err)
+	require.Equal(t, []byte("content"), data)
+
+	// A hard link is a regular file, not a symlink
+	isSymlink, err := mfs.IsSymlink(ctx, "/home/file")
+	require.NoError(t, err)
+	require.False(t, isSymlink)
+
+	// Removing the original leaves the link intact
+	require.NoError(t, mfs.Remove(ctx, "/packages/file"))
+	require.True(t, mfs.Exists(ctx, "/home/file"))
+}
+
+func TestMemFS_Link_Errors(t *testing.T) {
+	ctx := context.Background()
+	mfs := NewMemFS()
+
+	require.NoError(t, mfs.MkdirAll(ctx, "/dir", 0755))
+	require.NoError(t, mfs.WriteFile(ctx, "/dir/file", []byte("x"), 0644))
+	require.NoError(t, mfs.WriteFile(ctx, "/dir/existing", []byte("y"), 0644))
+
+	require.ErrorIs(t, mfs.Link(ctx, "/dir/missing", "/dir/new"), fs.ErrNotExist)
+	require.ErrorIs(t, mfs.Link(ctx, "/dir/file", "/nonexistent/new"), fs.ErrNotExist)
+	require.ErrorIs(t, mfs.Link(ctx, "/dir/file", "/dir/existing"), fs.ErrExist)
+	require.ErrorIs(t, mfs.Link(ctx, "/dir", "/dirlink"), fs.ErrPermission)
+}
+
 func TestMemFS_ReadLink_NotSymlink(t *testing.T) {
 	ctx := context.Background()
 	mfs := NewMemFS()
@@ -459,3 +528,40 @@ func TestMemFS_ConcurrentAccess(t *testing.T) {
 
 	// No test assertion - just verify no panics
 }
+
+func TestMemFS_Walk(t *testing.T) {
+	ctx := context.Background()
+	mfs := NewMemFS()
+
+	require.NoError(t, mfs.MkdirAll(ctx, "/pkg/b/deep", 0755))
+	require.NoError(t, mfs.MkdirAll(ctx, "/pkg/skip", 0755))
+	require.NoError(t, mfs.WriteFile(ctx, "/pkg/a", []byte("a"), 0644))
+	require.NoError(t, mfs.WriteFile(ctx, "/pkg/b/deep/c", []byte("c"), 0644))
+	require.NoError(t, mfs.WriteFile(ctx, "/pkg/skip/d", []byte("d"), 0644))
+	require.NoError(t, mfs.Symlink(ctx, "/pkg/b", "/pkg/link"))
+
+	var visited []string
+	err := mfs.Walk(ctx, "/pkg", func(path string, d fs.DirEntry, err error) error {
+		require.NoError(t, err)
+		visited = append(visited, path)
+		if path == "/pkg/link" {
+			require.NotZero(t, d.Type()&fs.ModeSymlink, "symlinks are reported, not followed")
+		}
+		if path == "/pkg/skip" {
+			return fs.SkipDir
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"/pkg", "/pkg/a", "/pkg/b", "/pkg/b/deep", "/pkg/b/deep/c", "/pkg/link", "/pkg/skip"}, visited)
+}
+
+func TestMemFS_Walk_MissingRoot(t *testing.T) {
+	ctx := context.Background()
+	mfs := NewMemFS()
+
+	err := mfs.Walk(ctx, "/missing", func(path string, d fs.DirEntry, err error) error {
+		return err
+	})
+	require.ErrorIs(t, err, fs.ErrNotExist)
+}
@@ -0,0 +1,189 @@
+package adapters
+
+import (
+	"context"
+	"io/fs"
+	"time"
+
+	"github.com/yaklabco/dot/internal/domain"
+)
+
+// MeteredFS wraps a domain.FS and reports every call through a
+// domain.Metrics. For each method it records:
+//
+//   - <method>_total: calls made, such as symlink_total
+//   - <method>_seconds: call latency, such as readfile_seconds
+//   - <method>_errors_total: calls that returned an error
+//
+// Method names are lowercased. Exists cannot fail, so it records no errors.
+//
+// MeteredFS composes with the other decorators. Placed beneath CachingFS it
+// measures real filesystem work; placed above it, cache hits are counted
+// too.
+type MeteredFS struct {
+	fs      domain.FS
+	metrics domain.Metrics
+}
+
+// NewMeteredFS creates a metering decorator around inner.
+func NewMeteredFS(inner domain.FS, metrics domain.Metrics) *MeteredFS {
+	return &MeteredFS{fs: inner, metrics: metrics}
+}
+
+// observe records one call to method that started at start.
+func (m *MeteredFS) observe(method string, start time.Time, err error) {
+	m.metrics.Counter(method + "_total").Inc()
+	m.metrics.Histogram(method + "_seconds").Observe(time.Since(start).Seconds())
+	if err != nil {
+		m.metrics.Counter(method + "_errors_total").Inc()
+	}
+}
+
+// Stat returns file information.
+func (m *MeteredFS) Stat(ctx context.Context, name string) (fs.FileInfo, error) {
+	start := time.Now()
+	info, err := m.fs.Stat(ctx, name)
+	m.observe("stat", start, err)
+	return info, err
+}
+
+// Lstat returns file information without following symlinks.
+func (m *MeteredFS) Lstat(ctx context.Context, name string) (fs.FileInfo, error) {
+	start := time.Now()
+	info, err := m.fs.Lstat(ctx, name)
+	m.observe("lstat", start, err)
+	return info, err
+}
+
+// ReadDir lists directory contents.
+func (m *MeteredFS) ReadDir(ctx context.Context, name string) ([]fs.DirEntry, error) {
+	start := time.Now()
+	entries, err := m.fs.ReadDir(ctx, name)
+	m.observe("readdir", start, err)
+	return entries, err
+}
+
+// ReadLink reads the target of a symbolic link.
+func (m *MeteredFS) ReadLink(ctx context.Context, name string) (string, error) {
+	start := time.Now()
+	target, err := m.fs.ReadLink(ctx, name)
+	m.observe("readlink", start, err)
+	return target, err
+}
+
+// ReadFile reads the entire file.
+func (m *MeteredFS) ReadFile(ctx context.Context, name string) ([]byte, error) {
+	start := time.Now()
+	data, err := m.fs.ReadFile(ctx, name)
+	m.observe("readfile", start, err)
+	return data, err
+}
+
+// ReadFileRange reads part of a file.
+func (m *MeteredFS) ReadFileRange(ctx context.Context, name string, offset, length int64) ([]byte, error) {
+	start := time.Now()
+	data, err := m.fs.ReadFileRange(ctx, name, offset, length)
+	m.observe("readfilerange", start, err)
+	return data, err
+}
+
+// Walk walks a tree.
+func (m *MeteredFS) Walk(ctx context.Context, root string, fn domain.WalkFunc) error {
+	start := time.Now()
+	err := m.fs.Walk(ctx, root, fn)
+	m.observe("walk", start, err)
+	return err
+}
+
+// Exists checks if a path exists.
+func (m *MeteredFS) Exists(ctx context.Context, name string) bool {
+	start := time.Now()
+	exists := m.fs.Exists(ctx, name)
+	m.observe("exists", start, nil)
+	return exists
+}
+
+// IsDir checks if a path is a directory.
+func (m *MeteredFS) IsDir(ctx context.Context, name string) (bool, error) {
+	start := time.Now()
+	isDir, err := m.fs.IsDir(ctx, name)
+	m.observe("isdir", start, err)
+	return isDir, err
+}
+
+// IsSymlink checks if a path is a symbolic link.
+func (m *MeteredFS) IsSymlink(ctx context.Context, name string) (bool, error) {
+	start := time.Now()
+	isLink, err := m.fs.IsSymlink(ctx, name)
+	m.observe("issymlink", start, err)
+	return isLink, err
+}
+
+// LinkKind reports how the link at name was created. It is not metered.
+func (m *MeteredFS) LinkKind(ctx context.Context, name string) (domain.LinkKind, error) {
+	return domain.LinkKindOf(ctx, m.fs, name)
+}
+
+// WriteFile writes data to a file.
+func (m *MeteredFS) WriteFile(ctx context.Context, name string, data []byte, perm fs.FileMode) error {
+	start := time.Now()
+	err := m.fs.WriteFile(ctx, name, data, perm)
+	m.observe("writefile", start, err)
+	return err
+}
+
+// Mkdir creates a directory.
+func (m *MeteredFS) Mkdir(ctx context.Context, name string, perm fs.FileMode) error {
+	start := time.Now()
+	err := m.fs.Mkdir(ctx, name, perm)
+	m.observe("mkdir", start, err)
+	return err
+}
+
+// MkdirAll creates a directory and all parents.
+func (m *MeteredFS) MkdirAll(ctx context.Context, name string, perm fs.FileMode) error {
+	start := time.Now()
+	err := m.fs.MkdirAll(ctx, name, perm)
+	m.observe("mkdirall", start, err)
+	return err
+}
+
+// Remove removes a file or empty directory.
+func (m *MeteredFS) Remove(ctx context.Context, name string) error {
+	start := time.Now()
+	err := m.fs.Remove(ctx, name)
+	m.observe("remove", start, err)
+	return err
+}
+
+// RemoveAll removes a path and all children.
+func (m *MeteredFS) RemoveAll(ctx context.Context, name string) error {
+	start := time.Now()
+	err := m.fs.RemoveAll(ctx, name)
+	m.observe("removeall", start, err)
+	return err
+}
+
+// Symlink creates a symbolic link.
+func (m *MeteredFS) Symlink(ctx context.Context, oldname, newname string) error {
+	start := time.Now()
+	err := m.fs.Symlink(ctx, oldname, newname)
+	m.observe("symlink", start, err)
+	return err
+}
+
+// Link creates a hard link.
+func (m *MeteredFS) Link(ctx context.Context, oldname, newname string) error {
+	start := time.Now()
+	err := m.fs.Link(ctx, oldname, newname)
+	m.observe("link", start, err)
+	return err
+}
+
+// Rename renames (moves) a file or directory.
+func (m *MeteredFS) Rename(ctx context.Context, oldpath, newpath string) error {
+	start := time.Now()
+	err := m.fs.Rename(ctx, oldpath, newpath)
+	m.observe("rename", start, err)
+	return err
+}
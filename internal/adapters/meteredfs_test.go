@@ -0,0 +1,157 @@
+package adapters_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yaklabco/dot/internal/adapters"
+	"github.com/yaklabco/dot/internal/domain"
+)
+
+// fakeMetrics records counter totals and histogram observations by name.
+type fakeMetrics struct {
+	mu           sync.Mutex
+	counters     map[string]float64
+	observations map[string][]float64
+}
+
+func newFakeMetrics() *fakeMetrics {
+	return &fakeMetrics{
+		counters:     make(map[string]float64),
+		observations: make(map[string][]float64),
+	}
+}
+
+func (m *fakeMetrics) Counter(name string, labels ...string) domain.Counter {
+	return fakeCounter{m: m, name: name}
+}
+
+func (m *fakeMetrics) Histogram(name string, labels ...string) domain.Histogram {
+	return fakeHistogram{m: m, name: name}
+}
+
+func (m *fakeMetrics) Gauge(name string, labels ...string) domain.Gauge {
+	return domain.NewNoopMetrics().Gauge(name, labels...)
+}
+
+func (m *fakeMetrics) counter(name string) float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.counters[name]
+}
+
+func (m *fakeMetrics) observed(name string) []float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.observations[name]
+}
+
+type fakeCounter struct {
+	m    *fakeMetrics
+	name string
+}
+
+func (c fakeCounter) Inc(labels ...string) { c.Add(1, labels...) }
+
+func (c fakeCounter) Add(value float64, labels ...string) {
+	c.m.mu.Lock()
+	defer c.m.mu.Unlock()
+	c.m.counters[c.name] += value
+}
+
+type fakeHistogram struct {
+	m    *fakeMetrics
+	name string
+}
+
+func (h fakeHistogram) Observe(value float64, labels ...string) {
+	h.m.mu.Lock()
+	defer h.m.mu.Unlock()
+	h.m.observations[h.name] = append(h.m.observations[h.name], value)
+}
+
+func TestMeteredFS_RecordsEveryMethod(t *testing.T) {
+	ctx := context.Background()
+	metrics := newFakeMetrics()
+	mfs := adapters.NewMeteredFS(adapters.NewMemFS(), metrics)
+
+	require.NoError(t, mfs.MkdirAll(ctx, "/home/dir", 0755))
+	require.NoError(t, mfs.Mkdir(ctx, "/home/other", 0755))
+	require.NoError(t, mfs.WriteFile(ctx, "/home/file", []byte("data"), 0644))
+	require.NoError(t, mfs.Symlink(ctx, "/home/file", "/home/link"))
+	require.NoError(t, mfs.Link(ctx, "/home/file", "/home/hard"))
+	_, err := mfs.Stat(ctx, "/home/file")
+	require.NoError(t, err)
+	_, err = mfs.Lstat(ctx, "/home/link")
+	require.NoError(t, err)
+	_, err = mfs.ReadDir(ctx, "/home")
+	require.NoError(t, err)
+	_, err = mfs.ReadLink(ctx, "/home/link")
+	require.NoError(t, err)
+	_, err = mfs.ReadFile(ctx, "/home/file")
+	require.NoError(t, err)
+	assert.True(t, mfs.Exists(ctx, "/home/file"))
+	_, err = mfs.IsDir(ctx, "/home/dir")
+	require.NoError(t, err)
+	_, err = mfs.IsSymlink(ctx, "/home/link")
+	require.NoError(t, err)
+	require.NoError(t, mfs.Rename(ctx, "/home/hard", "/home/moved"))
+	require.NoError(t, mfs.Remove(ctx, "/home/moved"))
+	require.NoError(t, mfs.RemoveAll(ctx, "/home/dir"))
+
+	methods := []string{
+		"mkdirall", "mkdir", "writefile", "symlink", "link", "stat", "lstat",
+		"readdir", "readlink", "readfile", "exists", "isdir", "issymlink",
+		"rename", "remove", "removeall",
+	}
+	for _, method := range methods {
+		assert.Equal(t, 1.0, metrics.counter(method+"_total"), method)
+		assert.Len(t, metrics.observed(method+"_seconds"), 1, method)
+		assert.Zero(t, metrics.counter(method+"_errors_total"), method)
+	}
+}
+
+func TestMeteredFS_CountsErrors(t *testing.T) {
+	ctx := context.Background()
+	metrics := newFakeMetrics()
+	mfs := adapters.NewMeteredFS(adapters.NewMemFS(), metrics)
+
+	_, err := mfs.ReadFile(ctx, "/missing")
+	require.Error(t, err)
+	_, err = mfs.ReadFile(ctx, "/missing")
+	require.Error(t, err)
+
+	assert.Equal(t, 2.0, metrics.counter("readfile_total"))
+	assert.Equal(t, 2.0, metrics.counter("readfile_errors_total"))
+	assert.Len(t, metrics.observed("readfile_seconds"), 2)
+}
+
+func TestMeteredFS_ComposesWithDecorators(t *testing.T) {
+	ctx := context.Background()
+	mem := adapters.NewMemFS()
+	require.NoError(t, mem.WriteFile(ctx, "/file", []byte("data"), 0644))
+
+	metrics := newFakeMetrics()
+	// Beneath the cache, only calls that reach the filesystem are metered
+	fs := adapters.NewCachingFS(adapters.NewReadOnlyFS(adapters.NewMeteredFS(mem, metrics)))
+
+	for i := 0; i < 3; i++ {
+		_, err := fs.Stat(ctx, "/file")
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 1.0, metrics.counter("stat_total"))
+
+	// Rejected writes never reach the metered FS
+	require.Error(t, fs.Symlink(ctx, "/file", "/link"))
+	assert.Zero(t, metrics.counter("symlink_total"))
+
+	// Metering above the read-only view counts the rejected call as an error
+	outer := adapters.NewMeteredFS(adapters.NewReadOnlyFS(mem), metrics)
+	require.Error(t, outer.Symlink(ctx, "/file", "/link"))
+	assert.Equal(t, 1.0, metrics.counter("symlink_total"))
+	assert.Equal(t, 1.0, metrics.counter("symlink_errors_total"))
+}
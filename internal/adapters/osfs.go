@@ -3,8 +3,11 @@ package adapters
 
 import (
 	"context"
+	"errors"
+	"io"
 	"io/fs"
 	"os"
+	"path/filepath"
 
 	"github.com/yaklabco/dot/internal/domain"
 )
@@ -32,7 +35,7 @@ func (f *OSFilesystem) Lstat(ctx context.Context, name string) (fs.FileInfo, err
 		return nil, err
 	}
 
-	return os.Lstat(name)
+	return lstat(name)
 }
 
 // ReadDir lists directory contents.
@@ -62,6 +65,49 @@ func (f *OSFilesystem) ReadFile(ctx context.Context, name string) ([]byte, error
 	return os.ReadFile(name)
 }
 
+// Walk walks the tree at root with filepath.WalkDir. Junctions are
+// presented as symbolic links, as Lstat does, and not descended into.
+func (f *OSFilesystem) Walk(ctx context.Context, root string, fn domain.WalkFunc) error {
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if d != nil && d.Type()&fs.ModeIrregular != 0 {
+			if info, lerr := lstat(path); lerr == nil && info.Mode()&fs.ModeSymlink != 0 {
+				if err := fn(path, fs.FileInfoToDirEntry(info), err); err != nil || !d.IsDir() {
+					return err
+				}
+				return fs.SkipDir
+			}
+		}
+		return fn(path, d, err)
+	})
+}
+
+// ReadFileRange reads up to length bytes of a file starting at offset,
+// without reading the rest of the file.
+func (f *OSFilesystem) ReadFileRange(ctx context.Context, name string, offset, length int64) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if offset < 0 || length < 0 {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: fs.ErrInvalid}
+	}
+
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	buf := make([]byte, length)
+	n, err := file.ReadAt(buf, offset)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	return buf[:n], nil
+}
+
 // WriteFile writes data to a file.
 func (f *OSFilesystem) WriteFile(ctx context.Context, name string, data []byte, perm fs.FileMode) error {
 	if err := ctx.Err(); err != nil {
@@ -107,13 +153,34 @@ func (f *OSFilesystem) RemoveAll(ctx context.Context, name string) error {
 	return os.RemoveAll(name)
 }
 
-// Symlink creates a symbolic link.
+// Symlink creates a symbolic link. On Windows without the symlink privilege,
+// a directory is linked with a junction instead and a file link fails with
+// domain.ErrSymlinkNotPermitted.
 func (f *OSFilesystem) Symlink(ctx context.Context, oldname, newname string) error {
 	if err := ctx.Err(); err != nil {
 		return err
 	}
 
-	return os.Symlink(oldname, newname)
+	return symlink(oldname, newname)
+}
+
+// LinkKind reports whether the link at name is a symbolic link or, on
+// Windows, a junction.
+func (f *OSFilesystem) LinkKind(ctx context.Context, name string) (domain.LinkKind, error) {
+	if err := ctx.Err(); err != nil {
+		return domain.LinkSymlink, err
+	}
+
+	return linkKind(name)
+}
+
+// Link creates a hard link.
+func (f *OSFilesystem) Link(ctx context.Context, oldname, newname string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return os.Link(oldname, newname)
 }
 
 // Rename moves or renames a file.
@@ -155,7 +222,7 @@ func (f *OSFilesystem) IsSymlink(ctx context.Context, name string) (bool, error)
 		return false, err
 	}
 
-	info, err := os.Lstat(name)
+	info, err := lstat(name)
 	if err != nil {
 		return false, err
 	}
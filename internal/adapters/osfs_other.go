@@ -0,0 +1,23 @@
+//go:build !windows
+
+package adapters
+
+import (
+	"io/fs"
+	"os"
+
+	"github.com/yaklabco/dot/internal/domain"
+)
+
+func symlink(oldname, newname string) error {
+	return os.Symlink(oldname, newname)
+}
+
+func lstat(name string) (fs.FileInfo, error) {
+	return os.Lstat(name)
+}
+
+// linkKind always reports a symbolic link; junctions exist only on Windows.
+func linkKind(string) (domain.LinkKind, error) {
+	return domain.LinkSymlink, nil
+}
@@ -0,0 +1,35 @@
+//go:build !windows
+
+package adapters_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yaklabco/dot/internal/adapters"
+	"github.com/yaklabco/dot/internal/domain"
+)
+
+func TestOSFilesystem_LinkKind(t *testing.T) {
+	ctx := context.Background()
+	fs := adapters.NewOSFilesystem()
+	dir := t.TempDir()
+
+	source := filepath.Join(dir, "source")
+	link := filepath.Join(dir, "link")
+	require.NoError(t, os.Mkdir(source, 0755))
+	require.NoError(t, fs.Symlink(ctx, source, link))
+
+	kind, err := fs.LinkKind(ctx, link)
+	require.NoError(t, err)
+	assert.Equal(t, domain.LinkSymlink, kind)
+
+	isLink, err := fs.IsSymlink(ctx, link)
+	require.NoError(t, err)
+	assert.True(t, isLink)
+}
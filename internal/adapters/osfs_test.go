@@ -2,6 +2,7 @@ package adapters_test
 
 import (
 	"context"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"testing"
@@ -44,6 +45,42 @@ func TestOSFilesystem_ReadDir(t *testing.T) {
 	assert.Len(t, entries, 3)
 }
 
+func TestOSFilesystem_Walk(t *testing.T) {
+	ctx := context.Background()
+	fsys := adapters.NewOSFilesystem()
+
+	tmpDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "b", "deep"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "skip"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "a"), []byte("a"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "skip", "d"), []byte("d"), 0644))
+
+	var visited []string
+	err := fsys.Walk(ctx, tmpDir, func(path string, d fs.DirEntry, err error) error {
+		require.NoError(t, err)
+		rel, err := filepath.Rel(tmpDir, path)
+		require.NoError(t, err)
+		visited = append(visited, filepath.ToSlash(rel))
+		if rel == "skip" {
+			return fs.SkipDir
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{".", "a", "b", "b/deep", "skip"}, visited)
+}
+
+func TestOSFilesystem_Walk_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	fsys := adapters.NewOSFilesystem()
+
+	err := fsys.Walk(ctx, t.TempDir(), func(path string, d fs.DirEntry, err error) error {
+		return err
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
 func TestOSFilesystem_ReadLink(t *testing.T) {
 	ctx := context.Background()
 	fsys := adapters.NewOSFilesystem()
@@ -74,6 +111,32 @@ func TestOSFilesystem_ReadFile(t *testing.T) {
 	assert.Equal(t, content, data)
 }
 
+func TestOSFilesystem_ReadFileRange(t *testing.T) {
+	ctx := context.Background()
+	fsys := adapters.NewOSFilesystem()
+
+	tmpFile := filepath.Join(t.TempDir(), "test.txt")
+	require.NoError(t, os.WriteFile(tmpFile, []byte("0123456789"), 0644))
+
+	data, err := fsys.ReadFileRange(ctx, tmpFile, 2, 4)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("2345"), data)
+
+	data, err = fsys.ReadFileRange(ctx, tmpFile, 8, 100)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("89"), data, "a range past the end is cut short")
+
+	data, err = fsys.ReadFileRange(ctx, tmpFile, 20, 4)
+	require.NoError(t, err)
+	assert.Empty(t, data)
+
+	_, err = fsys.ReadFileRange(ctx, tmpFile, -1, 4)
+	require.Error(t, err)
+
+	_, err = fsys.ReadFileRange(ctx, filepath.Join(filepath.Dir(tmpFile), "missing"), 0, 4)
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
 func TestOSFilesystem_WriteFile(t *testing.T) {
 	ctx := context.Background()
 	fsys := adapters.NewOSFilesystem()
@@ -175,6 +238,27 @@ func TestOSFilesystem_Symlink(t *testing.T) {
 	assert.Equal(t, target, linkTarget)
 }
 
+func TestOSFilesystem_Link(t *testing.T) {
+	ctx := context.Background()
+	fsys := adapters.NewOSFilesystem()
+
+	tmpDir := t.TempDir()
+	original := filepath.Join(tmpDir, "original.txt")
+	link := filepath.Join(tmpDir, "link.txt")
+
+	require.NoError(t, os.WriteFile(original, []byte("test"), 0644))
+
+	err := fsys.Link(ctx, original, link)
+	require.NoError(t, err)
+
+	originalInfo, err := os.Stat(original)
+	require.NoError(t, err)
+	linkInfo, err := os.Lstat(link)
+	require.NoError(t, err)
+	assert.True(t, os.SameFile(originalInfo, linkInfo))
+	assert.True(t, linkInfo.Mode().IsRegular())
+}
+
 func TestOSFilesystem_Rename(t *testing.T) {
 	ctx := context.Background()
 	fsys := adapters.NewOSFilesystem()
@@ -0,0 +1,163 @@
+//go:build windows
+
+package adapters
+
+import (
+	"encoding/binary"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"unicode/utf16"
+
+	"golang.org/x/sys/windows"
+
+	"github.com/yaklabco/dot/internal/domain"
+)
+
+// symlink creates a symbolic link. Without the symlink privilege (Developer
+// Mode off and not elevated), a directory source is linked with a junction
+// instead. Junctions cannot point at files, so file links fail with
+// domain.ErrSymlinkNotPermitted.
+func symlink(oldname, newname string) error {
+	err := os.Symlink(oldname, newname)
+	if err == nil || !errors.Is(err, windows.ERROR_PRIVILEGE_NOT_HELD) {
+		return err
+	}
+
+	// Relative link targets are relative to the link's directory, but a
+	// junction needs an absolute path
+	source, absErr := filepath.Abs(domain.ResolveLinkTarget(newname, oldname))
+	if absErr != nil {
+		return err
+	}
+
+	info, statErr := os.Stat(source)
+	if statErr != nil || !info.IsDir() {
+		return domain.ErrSymlinkNotPermitted{Path: newname}
+	}
+	return createJunction(source, newname)
+}
+
+// createJunction creates a directory junction at link pointing to the
+// absolute directory target.
+func createJunction(target, link string) error {
+	if err := os.Mkdir(link, 0o755); err != nil {
+		return err
+	}
+	if err := setMountPoint(target, link); err != nil {
+		_ = os.Remove(link)
+		return &os.LinkError{Op: "junction", Old: target, New: link, Err: err}
+	}
+	return nil
+}
+
+// setMountPoint turns the empty directory link into a junction by writing a
+// mount point reparse point.
+func setMountPoint(target, link string) error {
+	path, err := windows.UTF16PtrFromString(link)
+	if err != nil {
+		return err
+	}
+	handle, err := windows.CreateFile(path, windows.GENERIC_WRITE, 0, nil, windows.OPEN_EXISTING,
+		windows.FILE_FLAG_OPEN_REPARSE_POINT|windows.FILE_FLAG_BACKUP_SEMANTICS, 0)
+	if err != nil {
+		return err
+	}
+	defer windows.CloseHandle(handle)
+
+	buf := mountPointReparseData(target)
+	var returned uint32
+	return windows.DeviceIoControl(handle, windows.FSCTL_SET_REPARSE_POINT,
+		&buf[0], uint32(len(buf)), nil, 0, &returned, nil)
+}
+
+// mountPointReparseData encodes a REPARSE_DATA_BUFFER for a mount point.
+// The substitute name is the NT path of target; the print name is target as
+// given. Both are stored NUL-terminated, one after the other.
+func mountPointReparseData(target string) []byte {
+	substitute := utf16.Encode([]rune(`\??\` + target))
+	printName := utf16.Encode([]rune(target))
+
+	substituteLen := len(substitute) * 2
+	printLen := len(printName) * 2
+	// Four uint16 offset and length fields, then both names and their NULs
+	dataLen := 8 + substituteLen + 2 + printLen + 2
+
+	buf := make([]byte, 8+dataLen)
+	binary.LittleEndian.PutUint32(buf[0:], windows.IO_REPARSE_TAG_MOUNT_POINT)
+	binary.LittleEndian.PutUint16(buf[4:], uint16(dataLen))
+	binary.LittleEndian.PutUint16(buf[8:], 0)
+	binary.LittleEndian.PutUint16(buf[10:], uint16(substituteLen))
+	binary.LittleEndian.PutUint16(buf[12:], uint16(substituteLen+2))
+	binary.LittleEndian.PutUint16(buf[14:], uint16(printLen))
+
+	offset := 16
+	for _, c := range substitute {
+		binary.LittleEndian.PutUint16(buf[offset:], c)
+		offset += 2
+	}
+	offset += 2
+	for _, c := range printName {
+		binary.LittleEndian.PutUint16(buf[offset:], c)
+		offset += 2
+	}
+	return buf
+}
+
+// isJunction reports whether name is a directory junction.
+func isJunction(name string) (bool, error) {
+	path, err := windows.UTF16PtrFromString(name)
+	if err != nil {
+		return false, err
+	}
+	var data windows.Win32finddata
+	handle, err := windows.FindFirstFile(path, &data)
+	if err != nil {
+		return false, &os.PathError{Op: "FindFirstFile", Path: name, Err: err}
+	}
+	_ = windows.FindClose(handle)
+
+	// Reserved0 holds the reparse tag when the reparse point attribute is set
+	return data.FileAttributes&windows.FILE_ATTRIBUTE_REPARSE_POINT != 0 &&
+		data.Reserved0 == windows.IO_REPARSE_TAG_MOUNT_POINT, nil
+}
+
+// lstat returns file information without following links. Go reports
+// junctions as irregular directories; they are presented as symbolic links
+// so callers treat both kinds of link alike.
+func lstat(name string) (fs.FileInfo, error) {
+	info, err := os.Lstat(name)
+	if err != nil || info.Mode()&fs.ModeIrregular == 0 {
+		return info, err
+	}
+	if junction, jerr := isJunction(name); jerr != nil || !junction {
+		return info, nil
+	}
+	return junctionInfo{info}, nil
+}
+
+// junctionInfo reports a junction as a symbolic link.
+type junctionInfo struct {
+	fs.FileInfo
+}
+
+func (j junctionInfo) Mode() fs.FileMode {
+	return fs.ModeSymlink | j.FileInfo.Mode().Perm()
+}
+
+func (j junctionInfo) IsDir() bool {
+	return false
+}
+
+// linkKind reports whether the link at name is a junction or a symbolic link.
+func linkKind(name string) (domain.LinkKind, error) {
+	junction, err := isJunction(name)
+	if err != nil {
+		return domain.LinkSymlink, err
+	}
+	if junction {
+		return domain.LinkJunction, nil
+	}
+	return domain.LinkSymlink, nil
+}
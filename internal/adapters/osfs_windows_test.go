@@ -0,0 +1,69 @@
+//go:build windows
+
+package adapters
+
+import (
+	"context"
+	"encoding/binary"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/windows"
+
+	"github.com/yaklabco/dot/internal/domain"
+)
+
+func TestMountPointReparseData(t *testing.T) {
+	buf := mountPointReparseData(`C:\dot`)
+
+	// `\??\C:\dot` is 10 UTF-16 units, `C:\dot` is 6
+	assert.Equal(t, uint32(windows.IO_REPARSE_TAG_MOUNT_POINT), binary.LittleEndian.Uint32(buf[0:]))
+	assert.Equal(t, uint16(8+20+2+12+2), binary.LittleEndian.Uint16(buf[4:]))
+	assert.Equal(t, uint16(0), binary.LittleEndian.Uint16(buf[8:]))
+	assert.Equal(t, uint16(20), binary.LittleEndian.Uint16(buf[10:]))
+	assert.Equal(t, uint16(22), binary.LittleEndian.Uint16(buf[12:]))
+	assert.Equal(t, uint16(12), binary.LittleEndian.Uint16(buf[14:]))
+	assert.Len(t, buf, 8+8+20+2+12+2)
+}
+
+func TestCreateJunction(t *testing.T) {
+	ctx := context.Background()
+	osfs := NewOSFilesystem()
+	dir := t.TempDir()
+
+	source := filepath.Join(dir, "source")
+	link := filepath.Join(dir, "link")
+	require.NoError(t, os.Mkdir(source, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(source, "file"), []byte("data"), 0644))
+
+	require.NoError(t, createJunction(source, link))
+
+	// The junction resolves to the source directory
+	data, err := os.ReadFile(filepath.Join(link, "file"))
+	require.NoError(t, err)
+	assert.Equal(t, "data", string(data))
+
+	target, err := osfs.ReadLink(ctx, link)
+	require.NoError(t, err)
+	assert.Equal(t, source, target)
+
+	// It is presented as a link and reported as a junction
+	info, err := osfs.Lstat(ctx, link)
+	require.NoError(t, err)
+	assert.NotZero(t, info.Mode()&fs.ModeSymlink)
+	isLink, err := osfs.IsSymlink(ctx, link)
+	require.NoError(t, err)
+	assert.True(t, isLink)
+
+	kind, err := osfs.LinkKind(ctx, link)
+	require.NoError(t, err)
+	assert.Equal(t, domain.LinkJunction, kind)
+
+	// Removing the junction leaves the source intact
+	require.NoError(t, osfs.Remove(ctx, link))
+	assert.FileExists(t, filepath.Join(source, "file"))
+}
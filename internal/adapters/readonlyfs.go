@@ -0,0 +1,118 @@
+package adapters
+
+import (
+	"context"
+	"io/fs"
+
+	"github.com/yaklabco/dot/internal/domain"
+)
+
+// ReadOnlyFS wraps a domain.FS and rejects every mutating call with
+// domain.ErrReadOnlyFS. Read methods pass through unchanged.
+//
+// It guards code paths that must never write, such as dry runs and status
+// queries, so that a bug surfaces as an error instead of a modified
+// filesystem.
+type ReadOnlyFS struct {
+	fs domain.FS
+}
+
+// NewReadOnlyFS creates a read-only view of inner.
+func NewReadOnlyFS(inner domain.FS) *ReadOnlyFS {
+	return &ReadOnlyFS{fs: inner}
+}
+
+// Stat returns file information.
+func (r *ReadOnlyFS) Stat(ctx context.Context, name string) (fs.FileInfo, error) {
+	return r.fs.Stat(ctx, name)
+}
+
+// Lstat returns file information without following symlinks.
+func (r *ReadOnlyFS) Lstat(ctx context.Context, name string) (fs.FileInfo, error) {
+	return r.fs.Lstat(ctx, name)
+}
+
+// ReadDir lists directory contents.
+func (r *ReadOnlyFS) ReadDir(ctx context.Context, name string) ([]fs.DirEntry, error) {
+	return r.fs.ReadDir(ctx, name)
+}
+
+// ReadLink reads the target of a symbolic link.
+func (r *ReadOnlyFS) ReadLink(ctx context.Context, name string) (string, error) {
+	return r.fs.ReadLink(ctx, name)
+}
+
+// ReadFile reads the entire file.
+func (r *ReadOnlyFS) ReadFile(ctx context.Context, name string) ([]byte, error) {
+	return r.fs.ReadFile(ctx, name)
+}
+
+// ReadFileRange reads part of a file.
+func (r *ReadOnlyFS) ReadFileRange(ctx context.Context, name string, offset, length int64) ([]byte, error) {
+	return r.fs.ReadFileRange(ctx, name, offset, length)
+}
+
+// Walk walks a tree.
+func (r *ReadOnlyFS) Walk(ctx context.Context, root string, fn domain.WalkFunc) error {
+	return r.fs.Walk(ctx, root, fn)
+}
+
+// Exists checks if a path exists.
+func (r *ReadOnlyFS) Exists(ctx context.Context, name string) bool {
+	return r.fs.Exists(ctx, name)
+}
+
+// IsDir checks if a path is a directory.
+func (r *ReadOnlyFS) IsDir(ctx context.Context, name string) (bool, error) {
+	return r.fs.IsDir(ctx, name)
+}
+
+// IsSymlink checks if a path is a symbolic link.
+func (r *ReadOnlyFS) IsSymlink(ctx context.Context, name string) (bool, error) {
+	return r.fs.IsSymlink(ctx, name)
+}
+
+// LinkKind reports how the link at name was created.
+func (r *ReadOnlyFS) LinkKind(ctx context.Context, name string) (domain.LinkKind, error) {
+	return domain.LinkKindOf(ctx, r.fs, name)
+}
+
+// WriteFile is rejected with domain.ErrReadOnlyFS.
+func (r *ReadOnlyFS) WriteFile(ctx context.Context, name string, data []byte, perm fs.FileMode) error {
+	return domain.ErrReadOnlyFS{Operation: "write", Path: name}
+}
+
+// Mkdir is rejected with domain.ErrReadOnlyFS.
+func (r *ReadOnlyFS) Mkdir(ctx context.Context, name string, perm fs.FileMode) error {
+	return domain.ErrReadOnlyFS{Operation: "create directory", Path: name}
+}
+
+// MkdirAll is rejected with domain.ErrReadOnlyFS.
+func (r *ReadOnlyFS) MkdirAll(ctx context.Context, name string, perm fs.FileMode) error {
+	return domain.ErrReadOnlyFS{Operation: "create directory", Path: name}
+}
+
+// Remove is rejected with domain.ErrReadOnlyFS.
+func (r *ReadOnlyFS) Remove(ctx context.Context, name string) error {
+	return domain.ErrReadOnlyFS{Operation: "remove", Path: name}
+}
+
+// RemoveAll is rejected with domain.ErrReadOnlyFS.
+func (r *ReadOnlyFS) RemoveAll(ctx context.Context, name string) error {
+	return domain.ErrReadOnlyFS{Operation: "remove", Path: name}
+}
+
+// Symlink is rejected with domain.ErrReadOnlyFS.
+func (r *ReadOnlyFS) Symlink(ctx context.Context, oldname, newname string) error {
+	return domain.ErrReadOnlyFS{Operation: "create symlink", Path: newname}
+}
+
+// Link is rejected with domain.ErrReadOnlyFS.
+func (r *ReadOnlyFS) Link(ctx context.Context, oldname, newname string) error {
+	return domain.ErrReadOnlyFS{Operation: "create hard link", Path: newname}
+}
+
+// Rename is rejected with domain.ErrReadOnlyFS.
+func (r *ReadOnlyFS) Rename(ctx context.Context, oldpath, newpath string) error {
+	return domain.ErrReadOnlyFS{Operation: "rename", Path: oldpath}
+}
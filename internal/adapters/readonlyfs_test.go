@@ -0,0 +1,97 @@
+package adapters_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yaklabco/dot/internal/adapters"
+	"github.com/yaklabco/dot/internal/domain"
+)
+
+func newReadOnlyMemFS(t *testing.T) (*adapters.ReadOnlyFS, *adapters.MemFS) {
+	t.Helper()
+	ctx := context.Background()
+	mem := adapters.NewMemFS()
+	require.NoError(t, mem.MkdirAll(ctx, "/home/.config", 0755))
+	require.NoError(t, mem.WriteFile(ctx, "/home/.vimrc", []byte("set nu"), 0644))
+	require.NoError(t, mem.Symlink(ctx, "/home/.vimrc", "/home/.link"))
+	return adapters.NewReadOnlyFS(mem), mem
+}
+
+func TestReadOnlyFS_PassesReads(t *testing.T) {
+	ctx := context.Background()
+	rofs, _ := newReadOnlyMemFS(t)
+
+	info, err := rofs.Stat(ctx, "/home/.vimrc")
+	require.NoError(t, err)
+	assert.Equal(t, ".vimrc", info.Name())
+
+	data, err := rofs.ReadFile(ctx, "/home/.vimrc")
+	require.NoError(t, err)
+	assert.Equal(t, "set nu", string(data))
+
+	entries, err := rofs.ReadDir(ctx, "/home")
+	require.NoError(t, err)
+	assert.Len(t, entries, 3)
+
+	target, err := rofs.ReadLink(ctx, "/home/.link")
+	require.NoError(t, err)
+	assert.Equal(t, "/home/.vimrc", target)
+
+	isLink, err := rofs.IsSymlink(ctx, "/home/.link")
+	require.NoError(t, err)
+	assert.True(t, isLink)
+
+	isDir, err := rofs.IsDir(ctx, "/home/.config")
+	require.NoError(t, err)
+	assert.True(t, isDir)
+
+	assert.True(t, rofs.Exists(ctx, "/home/.vimrc"))
+	_, err = rofs.Lstat(ctx, "/home/.link")
+	assert.NoError(t, err)
+}
+
+func TestReadOnlyFS_RejectsWrites(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		path   string
+		mutate func(fs domain.FS) error
+	}{
+		{"WriteFile", "/home/.vimrc", func(fs domain.FS) error {
+			return fs.WriteFile(ctx, "/home/.vimrc", []byte("changed"), 0644)
+		}},
+		{"Mkdir", "/home/new", func(fs domain.FS) error { return fs.Mkdir(ctx, "/home/new", 0755) }},
+		{"MkdirAll", "/home/new/deep", func(fs domain.FS) error { return fs.MkdirAll(ctx, "/home/new/deep", 0755) }},
+		{"Remove", "/home/.vimrc", func(fs domain.FS) error { return fs.Remove(ctx, "/home/.vimrc") }},
+		{"RemoveAll", "/home/.config", func(fs domain.FS) error { return fs.RemoveAll(ctx, "/home/.config") }},
+		{"Symlink", "/home/.new", func(fs domain.FS) error { return fs.Symlink(ctx, "/home/.vimrc", "/home/.new") }},
+		{"Link", "/home/.hard", func(fs domain.FS) error { return fs.Link(ctx, "/home/.vimrc", "/home/.hard") }},
+		{"Rename", "/home/.vimrc", func(fs domain.FS) error { return fs.Rename(ctx, "/home/.vimrc", "/home/.moved") }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rofs, mem := newReadOnlyMemFS(t)
+
+			err := tt.mutate(rofs)
+			var roErr domain.ErrReadOnlyFS
+			require.True(t, errors.As(err, &roErr), "expected ErrReadOnlyFS, got %v", err)
+			assert.Equal(t, tt.path, roErr.Path)
+
+			// The underlying filesystem is untouched
+			data, err := mem.ReadFile(ctx, "/home/.vimrc")
+			require.NoError(t, err)
+			assert.Equal(t, "set nu", string(data))
+			assert.True(t, mem.Exists(ctx, "/home/.config"))
+			for _, path := range []string{"/home/new", "/home/.new", "/home/.hard", "/home/.moved"} {
+				assert.False(t, mem.Exists(ctx, path), path)
+			}
+		})
+	}
+}
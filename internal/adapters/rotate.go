@@ -0,0 +1,189 @@
+package adapters
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/yaklabco/dot/internal/domain"
+)
+
+// RotatingFile is an append-only log file that rotates itself when it grows
+// past a size limit or becomes older than an age limit.
+//
+// On rotation dot.log becomes dot.log.1, dot.log.1 becomes dot.log.2, and
+// so on; rotations beyond MaxBackups are deleted. Writes are serialized, so
+// a single record never straddles two files.
+type RotatingFile struct {
+	path       string
+	maxSize    int64
+	maxAge     time.Duration
+	maxBackups int
+
+	mu      sync.Mutex
+	file    *os.File
+	size    int64
+	created time.Time
+	now     func() time.Time
+}
+
+// RotationConfig controls when a RotatingFile rotates.
+// A zero MaxSize or MaxAge disables that trigger.
+type RotationConfig struct {
+	MaxSize    int64         // bytes
+	MaxAge     time.Duration // age of the current file
+	MaxBackups int           // rotated files to keep; 0 keeps none
+}
+
+// OpenRotatingFile opens path for appending, creating its parent directory
+// if needed.
+func OpenRotatingFile(path string, cfg RotationConfig) (*RotatingFile, error) {
+	return openRotatingFile(path, cfg, time.Now)
+}
+
+func openRotatingFile(path string, cfg RotationConfig, now func() time.Time) (*RotatingFile, error) {
+	r := &RotatingFile{
+		path:       path,
+		maxSize:    cfg.MaxSize,
+		maxAge:     cfg.MaxAge,
+		maxBackups: cfg.MaxBackups,
+		now:        now,
+	}
+	if err := os.MkdirAll(filepath.Dir(path), domain.PermUserRWX); err != nil {
+		return nil, fmt.Errorf("create log directory: %w", err)
+	}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Write appends p, rotating first if p would exceed the size limit or the
+// current file is older than the age limit.
+func (r *RotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return 0, os.ErrClosed
+	}
+	if r.shouldRotate(int64(len(p))) {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+// Close closes the current file.
+func (r *RotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
+
+func (r *RotatingFile) shouldRotate(incoming int64) bool {
+	// An empty file never rotates, so oversized records still get written
+	if r.size == 0 {
+		return false
+	}
+	if r.maxSize > 0 && r.size+incoming > r.maxSize {
+		return true
+	}
+	return r.maxAge > 0 && r.now().Sub(r.created) > r.maxAge
+}
+
+func (r *RotatingFile) open() error {
+	f, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, domain.PermUserRW)
+	if err != nil {
+		return fmt.Errorf("open log file: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("stat log file: %w", err)
+	}
+
+	r.file = f
+	r.size = info.Size()
+	if created, ok := r.readCreated(); ok && r.size > 0 {
+		r.created = created
+		return nil
+	}
+
+	r.created = r.now()
+	if r.size > 0 {
+		// A file from before creation times were recorded is aged from
+		// its last write
+		r.created = info.ModTime()
+	}
+	// The log is reopened on every run, so the creation time is kept
+	// beside it rather than derived from the file. Failing to record it
+	// only makes the next run age the file from its last write.
+	_ = os.WriteFile(r.createdPath(), []byte(r.created.Format(time.RFC3339Nano)), domain.PermUserRW)
+	return nil
+}
+
+// readCreated returns the recorded creation time of the current file.
+func (r *RotatingFile) readCreated() (time.Time, bool) {
+	data, err := os.ReadFile(r.createdPath())
+	if err != nil {
+		return time.Time{}, false
+	}
+	created, err := time.Parse(time.RFC3339Nano, string(data))
+	if err != nil {
+		return time.Time{}, false
+	}
+	return created, true
+}
+
+// createdPath is the hidden file beside the log that records when the
+// current file was created, e.g. .dot.log.created for dot.log.
+func (r *RotatingFile) createdPath() string {
+	return filepath.Join(filepath.Dir(r.path), "."+filepath.Base(r.path)+".created")
+}
+
+// rotate shifts existing backups up by one, moves the current file to .1
+// and opens a fresh file. Must be called with mu held.
+func (r *RotatingFile) rotate() error {
+	if err := r.file.Close(); err != nil {
+		return fmt.Errorf("close log file: %w", err)
+	}
+	r.file = nil
+
+	if r.maxBackups <= 0 {
+		if err := os.Remove(r.path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("remove log file: %w", err)
+		}
+		return r.open()
+	}
+
+	// Drop the oldest backup, then shift the rest up
+	if err := os.Remove(r.backupPath(r.maxBackups)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("remove old log file: %w", err)
+	}
+	for i := r.maxBackups - 1; i >= 1; i-- {
+		if err := os.Rename(r.backupPath(i), r.backupPath(i+1)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("rotate log file: %w", err)
+		}
+	}
+	if err := os.Rename(r.path, r.backupPath(1)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("rotate log file: %w", err)
+	}
+	return r.open()
+}
+
+func (r *RotatingFile) backupPath(n int) string {
+	return fmt.Sprintf("%s.%d", r.path, n)
+}
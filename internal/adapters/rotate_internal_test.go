@@ -0,0 +1,47 @@
+package adapters
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotatingFile_AgesAcrossReopens(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dot.log")
+	cfg := RotationConfig{MaxAge: 24 * time.Hour, MaxBackups: 1}
+	start := time.Now()
+
+	// Each run opens the log, writes an entry at the given time and exits
+	run := func(at time.Time, entry string) {
+		t.Helper()
+		f, err := openRotatingFile(path, cfg, func() time.Time { return at })
+		require.NoError(t, err)
+		_, err = f.Write([]byte(entry))
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+		require.NoError(t, os.Chtimes(path, at, at))
+	}
+
+	run(start, "first\n")
+	run(start.Add(20*time.Hour), "second\n")
+	// Last written 10 hours ago, but created 30 hours ago
+	run(start.Add(30*time.Hour), "third\n")
+
+	current, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "third\n", string(current))
+
+	rotated, err := os.ReadFile(path + ".1")
+	require.NoError(t, err)
+	assert.Equal(t, "first\nsecond\n", string(rotated))
+
+	// The new file is aged from its rotation
+	run(start.Add(40*time.Hour), "fourth\n")
+	current, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "third\nfourth\n", string(current))
+}
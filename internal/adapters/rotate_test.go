@@ -0,0 +1,181 @@
+package adapters_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaklabco/dot/internal/adapters"
+)
+
+func TestRotatingFile_RotatesOnSize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "dot.log")
+	f, err := adapters.OpenRotatingFile(path, adapters.RotationConfig{MaxSize: 20, MaxBackups: 2})
+	require.NoError(t, err)
+	defer f.Close()
+
+	// Each write is 10 bytes, so every third write rotates
+	for i := 0; i < 7; i++ {
+		_, err := fmt.Fprintf(f, "line %04d\n", i)
+		require.NoError(t, err)
+	}
+
+	current, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "line 0006\n", string(current))
+
+	first, err := os.ReadFile(path + ".1")
+	require.NoError(t, err)
+	assert.Equal(t, "line 0004\nline 0005\n", string(first))
+
+	second, err := os.ReadFile(path + ".2")
+	require.NoError(t, err)
+	assert.Equal(t, "line 0002\nline 0003\n", string(second))
+
+	// Retention: only MaxBackups rotated files are kept
+	_, err = os.Stat(path + ".3")
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestRotatingFile_NoBackups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dot.log")
+	f, err := adapters.OpenRotatingFile(path, adapters.RotationConfig{MaxSize: 10, MaxBackups: 0})
+	require.NoError(t, err)
+	defer f.Close()
+
+	for i := 0; i < 3; i++ {
+		_, err := fmt.Fprintf(f, "line %04d\n", i)
+		require.NoError(t, err)
+	}
+
+	current, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "line 0002\n", string(current))
+
+	matches, err := filepath.Glob(path + ".*")
+	require.NoError(t, err)
+	assert.Empty(t, matches)
+}
+
+func TestRotatingFile_OversizedWriteIsKept(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dot.log")
+	f, err := adapters.OpenRotatingFile(path, adapters.RotationConfig{MaxSize: 5, MaxBackups: 1})
+	require.NoError(t, err)
+	defer f.Close()
+
+	_, err = f.Write([]byte("much longer than five bytes\n"))
+	require.NoError(t, err)
+
+	current, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "much longer than five bytes\n", string(current))
+}
+
+func TestRotatingFile_RotatesOnAge(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dot.log")
+	require.NoError(t, os.WriteFile(path, []byte("old entry\n"), 0600))
+	old := time.Now().Add(-48 * time.Hour)
+	require.NoError(t, os.Chtimes(path, old, old))
+
+	f, err := adapters.OpenRotatingFile(path, adapters.RotationConfig{MaxAge: 24 * time.Hour, MaxBackups: 1})
+	require.NoError(t, err)
+	_, err = f.Write([]byte("new entry\n"))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	current, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "new entry\n", string(current))
+
+	rotated, err := os.ReadFile(path + ".1")
+	require.NoError(t, err)
+	assert.Equal(t, "old entry\n", string(rotated))
+}
+
+func TestRotatingFile_AppendsToExisting(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dot.log")
+	require.NoError(t, os.WriteFile(path, []byte("0123456789"), 0600))
+
+	f, err := adapters.OpenRotatingFile(path, adapters.RotationConfig{MaxSize: 15, MaxBackups: 1})
+	require.NoError(t, err)
+	defer f.Close()
+
+	// Existing size counts toward the limit
+	_, err = f.Write([]byte("abcdef"))
+	require.NoError(t, err)
+
+	rotated, err := os.ReadFile(path + ".1")
+	require.NoError(t, err)
+	assert.Equal(t, "0123456789", string(rotated))
+}
+
+func TestRotatingFile_WriteAfterClose(t *testing.T) {
+	f, err := adapters.OpenRotatingFile(filepath.Join(t.TempDir(), "dot.log"), adapters.RotationConfig{})
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	require.NoError(t, f.Close())
+
+	_, err = f.Write([]byte("x"))
+	assert.ErrorIs(t, err, os.ErrClosed)
+}
+
+func TestRotatingFile_ConcurrentLogging(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "dot.log")
+
+	const workers, perWorker, backups = 8, 100, 100
+	logger, closer, err := adapters.NewLoggerFromConfig(adapters.LogConfig{
+		Level:       slog.LevelInfo,
+		Format:      adapters.LogFormatJSON,
+		Destination: adapters.LogDestinationFile,
+		File:        path,
+		Rotation:    adapters.RotationConfig{MaxSize: 2048, MaxBackups: backups},
+	})
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				logger.Info(context.Background(), "entry", "worker", w, "index", i)
+			}
+		}(w)
+	}
+	wg.Wait()
+	require.NoError(t, closer.Close())
+
+	files, err := filepath.Glob(path + "*")
+	require.NoError(t, err)
+	assert.Greater(t, len(files), 1, "log should have rotated")
+
+	total := 0
+	for _, file := range files {
+		info, err := os.Stat(file)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, info.Size(), int64(2048), file)
+
+		f, err := os.Open(file)
+		require.NoError(t, err)
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			var record map[string]any
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &record), "%s: %s", file, scanner.Text())
+			total++
+		}
+		f.Close()
+		assert.False(t, strings.HasSuffix(file, fmt.Sprintf(".%d", backups+1)))
+	}
+	assert.Equal(t, workers*perWorker, total)
+}
@@ -2,8 +2,10 @@ package adapters
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"log/slog"
+	"os"
 	"strings"
 
 	console "github.com/phsym/console-slog"
@@ -17,9 +19,16 @@ type SlogLogger struct {
 }
 
 // NewSlogLogger creates a new slog logger adapter.
+// URL credentials are redacted from all messages and attributes.
 func NewSlogLogger(logger *slog.Logger) *SlogLogger {
+	return NewSlogLoggerWithRedactor(logger, NewRedactor())
+}
+
+// NewSlogLoggerWithRedactor creates a slog logger adapter that masks values
+// using the given redactor, e.g. one configured with sensitive path patterns.
+func NewSlogLoggerWithRedactor(logger *slog.Logger, redactor *Redactor) *SlogLogger {
 	return &SlogLogger{
-		logger: logger,
+		logger: slog.New(NewRedactingHandler(logger.Handler(), redactor)),
 	}
 }
 
@@ -31,11 +40,99 @@ func NewConsoleLogger(w io.Writer, level string) *SlogLogger {
 		Level: logLevel,
 	})
 
-	return &SlogLogger{
-		logger: slog.New(handler),
+	return NewSlogLogger(slog.New(handler))
+}
+
+// Log formats and destinations accepted by NewLogHandler.
+const (
+	LogFormatText = "text"
+	LogFormatJSON = "json"
+
+	LogDestinationStderr = "stderr"
+	LogDestinationStdout = "stdout"
+	LogDestinationFile   = "file"
+)
+
+// LogConfig describes where and how structured logs are written.
+type LogConfig struct {
+	Level       slog.Level
+	Format      string // text, json (default text)
+	Destination string // stderr, stdout, file (default stderr)
+	File        string // log file path, required when Destination is file
+
+	// Rotation limits the size and age of the log file.
+	Rotation RotationConfig
+
+	// RedactPaths lists glob patterns for values that are masked entirely,
+	// as accepted by NewRedactor. URL credentials are always masked.
+	RedactPaths []string
+
+	// Stdout and Stderr override the process streams, primarily for tests.
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+// NewLogHandler builds the slog.Handler for the configured format and
+// destination. For the file destination the parent directory is created
+// and the file is opened for appending; the returned closer releases it.
+// For stream destinations the closer is a no-op.
+func NewLogHandler(cfg LogConfig) (slog.Handler, io.Closer, error) {
+	var w io.Writer
+	closer := io.Closer(nopCloser{})
+
+	switch cfg.Destination {
+	case "", LogDestinationStderr:
+		w = cfg.Stderr
+		if w == nil {
+			w = os.Stderr
+		}
+	case LogDestinationStdout:
+		w = cfg.Stdout
+		if w == nil {
+			w = os.Stdout
+		}
+	case LogDestinationFile:
+		if cfg.File == "" {
+			return nil, nil, fmt.Errorf("log file must be specified when destination is %q", LogDestinationFile)
+		}
+		f, err := OpenRotatingFile(cfg.File, cfg.Rotation)
+		if err != nil {
+			return nil, nil, err
+		}
+		w, closer = f, f
+	default:
+		return nil, nil, fmt.Errorf("invalid log destination %q", cfg.Destination)
+	}
+
+	// The standard slog handlers serialize each record into a single Write
+	// under a shared mutex, so concurrent records never interleave.
+	handlerOpts := &slog.HandlerOptions{Level: cfg.Level}
+	switch cfg.Format {
+	case "", LogFormatText:
+		return slog.NewTextHandler(w, handlerOpts), closer, nil
+	case LogFormatJSON:
+		return slog.NewJSONHandler(w, handlerOpts), closer, nil
+	default:
+		_ = closer.Close()
+		return nil, nil, fmt.Errorf("invalid log format %q", cfg.Format)
 	}
 }
 
+// NewLoggerFromConfig creates a logger from cfg that redacts URL
+// credentials and values matching cfg.RedactPaths.
+// Callers should Close the returned closer when logging is finished.
+func NewLoggerFromConfig(cfg LogConfig) (*SlogLogger, io.Closer, error) {
+	handler, closer, err := NewLogHandler(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	return NewSlogLoggerWithRedactor(slog.New(handler), NewRedactor(cfg.RedactPaths...)), closer, nil
+}
+
+type nopCloser struct{}
+
+func (nopCloser) Close() error { return nil }
+
 // Debug logs a debug-level message.
 func (l *SlogLogger) Debug(ctx context.Context, msg string, args ...any) {
 	l.logger.DebugContext(ctx, msg, args...)
@@ -1,9 +1,16 @@
 package adapters_test
 
 import (
+	"bufio"
 	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
 	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -109,3 +116,177 @@ func TestParseLogLevel(t *testing.T) {
 		})
 	}
 }
+
+func TestNewLogHandler_Formats(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		check  func(t *testing.T, line string)
+	}{
+		{
+			name:   "text",
+			format: adapters.LogFormatText,
+			check: func(t *testing.T, line string) {
+				assert.Contains(t, line, "level=INFO")
+				assert.Contains(t, line, `msg="hello world"`)
+				assert.Contains(t, line, "package=vim")
+			},
+		},
+		{
+			name:   "default is text",
+			format: "",
+			check: func(t *testing.T, line string) {
+				assert.Contains(t, line, "level=INFO")
+			},
+		},
+		{
+			name:   "json",
+			format: adapters.LogFormatJSON,
+			check: func(t *testing.T, line string) {
+				var record map[string]any
+				require.NoError(t, json.Unmarshal([]byte(line), &record))
+				assert.Equal(t, "INFO", record[slog.LevelKey])
+				assert.Equal(t, "hello world", record[slog.MessageKey])
+				assert.Equal(t, "vim", record["package"])
+				assert.Contains(t, record, slog.TimeKey)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger, closer, err := adapters.NewLoggerFromConfig(adapters.LogConfig{
+				Level:  slog.LevelInfo,
+				Format: tt.format,
+				Stderr: &buf,
+			})
+			require.NoError(t, err)
+			defer closer.Close()
+
+			logger.Debug(context.Background(), "filtered out")
+			logger.Info(context.Background(), "hello world", "package", "vim")
+
+			lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+			require.Len(t, lines, 1)
+			tt.check(t, lines[0])
+		})
+	}
+}
+
+func TestNewLogHandler_Destinations(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	cfg := adapters.LogConfig{Level: slog.LevelInfo, Stdout: &stdout, Stderr: &stderr}
+
+	cfg.Destination = adapters.LogDestinationStdout
+	handler, _, err := adapters.NewLogHandler(cfg)
+	require.NoError(t, err)
+	slog.New(handler).Info("to stdout")
+
+	cfg.Destination = adapters.LogDestinationStderr
+	handler, _, err = adapters.NewLogHandler(cfg)
+	require.NoError(t, err)
+	slog.New(handler).Info("to stderr")
+
+	assert.Contains(t, stdout.String(), "to stdout")
+	assert.NotContains(t, stdout.String(), "to stderr")
+	assert.Contains(t, stderr.String(), "to stderr")
+}
+
+func TestNewLogHandler_FileCreatesDirectory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "dot", "dot.log")
+
+	logger, closer, err := adapters.NewLoggerFromConfig(adapters.LogConfig{
+		Level:       slog.LevelInfo,
+		Format:      adapters.LogFormatJSON,
+		Destination: adapters.LogDestinationFile,
+		File:        path,
+	})
+	require.NoError(t, err)
+	logger.Info(context.Background(), "first")
+	require.NoError(t, closer.Close())
+
+	// Reopening appends rather than truncating
+	logger, closer, err = adapters.NewLoggerFromConfig(adapters.LogConfig{
+		Level:       slog.LevelInfo,
+		Format:      adapters.LogFormatJSON,
+		Destination: adapters.LogDestinationFile,
+		File:        path,
+	})
+	require.NoError(t, err)
+	logger.Info(context.Background(), "second")
+	require.NoError(t, closer.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, 2, strings.Count(string(data), "\n"))
+	assert.Contains(t, string(data), `"msg":"first"`)
+	assert.Contains(t, string(data), `"msg":"second"`)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
+
+func TestNewLogHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  adapters.LogConfig
+		want string
+	}{
+		{"unknown format", adapters.LogConfig{Format: "xml"}, "invalid log format"},
+		{"unknown destination", adapters.LogConfig{Destination: "syslog"}, "invalid log destination"},
+		{"file without path", adapters.LogConfig{Destination: adapters.LogDestinationFile}, "log file must be specified"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := adapters.NewLogHandler(tt.cfg)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestNewLogHandler_JSONConcurrentWrites(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dot.log")
+	logger, closer, err := adapters.NewLoggerFromConfig(adapters.LogConfig{
+		Level:       slog.LevelDebug,
+		Format:      adapters.LogFormatJSON,
+		Destination: adapters.LogDestinationFile,
+		File:        path,
+	})
+	require.NoError(t, err)
+
+	const workers, perWorker = 8, 50
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			child := logger.With("worker", w)
+			for i := 0; i < perWorker; i++ {
+				child.Info(context.Background(), "operation", "index", i, "path", fmt.Sprintf("/pkg/%d/%d", w, i))
+			}
+		}(w)
+	}
+	wg.Wait()
+	require.NoError(t, closer.Close())
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	count := 0
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var record map[string]any
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record), "line %d: %s", count+1, scanner.Text())
+		assert.Equal(t, "operation", record[slog.MessageKey])
+		assert.Contains(t, record, "worker")
+		assert.Contains(t, record, "index")
+		count++
+	}
+	require.NoError(t, scanner.Err())
+	assert.Equal(t, workers*perWorker, count)
+}
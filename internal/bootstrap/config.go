@@ -12,52 +12,57 @@ import (
 // Config represents the bootstrap configuration for a dotfiles repository.
 type Config struct {
 	// Version specifies the bootstrap config schema version.
-	Version string `yaml:"version"`
+	Version string `yaml:"version" json:"version" toml:"version"`
+
+	// Include lists other bootstrap files whose packages and profiles are
+	// merged beneath this one: paths relative to this file within the
+	// repository, or HTTPS URLs.
+	Include Includes `yaml:"include,omitempty" json:"include,omitempty" toml:"include,omitempty"`
 
 	// Packages lists all available packages in the repository.
-	Packages []PackageSpec `yaml:"packages"`
+	Packages []PackageSpec `yaml:"packages" json:"packages" toml:"packages"`
 
 	// Profiles defines named sets of packages for different use cases.
-	Profiles map[string]Profile `yaml:"profiles,omitempty"`
+	Profiles map[string]Profile `yaml:"profiles,omitempty" json:"profiles,omitempty" toml:"profiles,omitempty"`
 
 	// Defaults specifies default settings for installation.
-	Defaults Defaults `yaml:"defaults,omitempty"`
+	Defaults Defaults `yaml:"defaults,omitempty" json:"defaults,omitempty" toml:"defaults,omitempty"`
 }
 
 // PackageSpec defines a package and its installation requirements.
 type PackageSpec struct {
 	// Name is the package directory name.
-	Name string `yaml:"name"`
+	Name string `yaml:"name" json:"name" toml:"name"`
 
 	// Required indicates if this package must be installed.
-	Required bool `yaml:"required"`
+	Required bool `yaml:"required" json:"required" toml:"required"`
 
 	// Platform restricts installation to specific operating systems.
 	// Valid values: linux, darwin, windows, freebsd
-	Platform []string `yaml:"platform,omitempty"`
+	Platform []string `yaml:"platform,omitempty" json:"platform,omitempty" toml:"platform,omitempty"`
 
 	// ConflictPolicy specifies how to handle conflicts for this package.
 	// Valid values: fail, backup, overwrite, skip
-	ConflictPolicy string `yaml:"on_conflict,omitempty"`
+	ConflictPolicy string `yaml:"on_conflict,omitempty" json:"on_conflict,omitempty" toml:"on_conflict,omitempty"`
 }
 
 // Profile represents a named set of packages.
 type Profile struct {
 	// Description provides human-readable explanation of the profile.
-	Description string `yaml:"description"`
+	Description string `yaml:"description" json:"description" toml:"description"`
 
 	// Packages lists the package names included in this profile.
-	Packages []string `yaml:"packages"`
+	Packages []string `yaml:"packages" json:"packages" toml:"packages"`
 }
 
 // Defaults specifies default configuration values.
 type Defaults struct {
 	// ConflictPolicy is the default conflict resolution strategy.
 	// Valid values: fail, backup, overwrite, skip
-	ConflictPolicy string `yaml:"on_conflict"`
+	ConflictPolicy string `yaml:"on_conflict" json:"on_conflict" toml:"on_conflict"`
 
 	// Profile is the default profile to use if none specified.
-	Profile string `yaml:"profile"`
+	Profile string `yaml:"profile" json:"profile" toml:"profile"`
 }
 
 // Validate checks the configuration for errors.
@@ -0,0 +1,123 @@
+package bootstrap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFileNames lists the names a bootstrap file may have in a
+// repository. The format is chosen by the extension.
+var ConfigFileNames = []string{
+	".dotbootstrap.yaml",
+	".dotbootstrap.yml",
+	".dotbootstrap.toml",
+	".dotbootstrap.json",
+}
+
+// ExistsFS reports whether files exist, for finding a bootstrap file.
+type ExistsFS interface {
+	Exists(ctx context.Context, path string) bool
+}
+
+// ErrAmbiguousConfig reports a repository with more than one bootstrap
+// file, where it is unclear which one to use.
+type ErrAmbiguousConfig struct {
+	Dir   string
+	Files []string
+}
+
+func (e ErrAmbiguousConfig) Error() string {
+	return fmt.Sprintf("multiple bootstrap files in %s: %s; keep only one", e.Dir, strings.Join(e.Files, ", "))
+}
+
+// FindConfig returns the path of the bootstrap file in dir, or false if
+// there is none. More than one bootstrap file is an ErrAmbiguousConfig.
+func FindConfig(ctx context.Context, fs ExistsFS, dir string) (string, bool, error) {
+	var found []string
+	for _, name := range ConfigFileNames {
+		if fs.Exists(ctx, filepath.Join(dir, name)) {
+			found = append(found, name)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return "", false, nil
+	case 1:
+		return filepath.Join(dir, found[0]), true, nil
+	default:
+		return "", false, ErrAmbiguousConfig{Dir: dir, Files: found}
+	}
+}
+
+// UnmarshalJSON accepts a single include as well as a list.
+func (i *Includes) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*i = Includes{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*i = list
+	return nil
+}
+
+// decodeConfig parses a bootstrap file in the format given by the
+// extension of source, a path or URL. Unknown extensions are parsed as
+// YAML.
+func decodeConfig(source string, data []byte) (Config, error) {
+	var cfg Config
+	switch configFormat(source) {
+	case "json":
+		if err := json.Unmarshal(data, &cfg); err != nil {
+			return Config{}, fmt.Errorf("parse JSON: %w", err)
+		}
+	case "toml":
+		// Decode through JSON so fields accepting several shapes, such as
+		// include, need only the YAML and JSON unmarshalers
+		var doc map[string]any
+		if err := toml.Unmarshal(data, &doc); err != nil {
+			return Config{}, fmt.Errorf("parse TOML: %w", err)
+		}
+		encoded, err := json.Marshal(doc)
+		if err != nil {
+			return Config{}, fmt.Errorf("parse TOML: %w", err)
+		}
+		if err := json.Unmarshal(encoded, &cfg); err != nil {
+			return Config{}, fmt.Errorf("parse TOML: %w", err)
+		}
+	default:
+		if err := yaml.Unmarshal(data, &cfg); err != nil {
+			return Config{}, fmt.Errorf("parse YAML: %w", err)
+		}
+	}
+	return cfg, nil
+}
+
+// configFormat returns the format named by the extension of source.
+func configFormat(source string) string {
+	ext := filepath.Ext(source)
+	if isURL(source) {
+		if u, err := url.Parse(source); err == nil {
+			ext = path.Ext(u.Path)
+		}
+	}
+	switch strings.ToLower(ext) {
+	case ".json":
+		return "json"
+	case ".toml":
+		return "toml"
+	default:
+		return "yaml"
+	}
+}
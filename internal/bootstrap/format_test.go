@@ -0,0 +1,168 @@
+package bootstrap
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// formatFixtures holds the same bootstrap configuration in every format.
+var formatFixtures = map[string]string{
+	".dotbootstrap.yaml": `version: "1.0"
+packages:
+  - name: git
+    required: true
+  - name: vim
+    platform: [linux, darwin]
+    on_conflict: backup
+profiles:
+  base:
+    description: Base setup
+    packages: [git, vim]
+defaults:
+  profile: base
+  on_conflict: fail
+`,
+	".dotbootstrap.toml": `version = "1.0"
+
+[[packages]]
+name = "git"
+required = true
+
+[[packages]]
+name = "vim"
+platform = ["linux", "darwin"]
+on_conflict = "backup"
+
+[profiles.base]
+description = "Base setup"
+packages = ["git", "vim"]
+
+[defaults]
+profile = "base"
+on_conflict = "fail"
+`,
+	".dotbootstrap.json": `{
+  "version": "1.0",
+  "packages": [
+    {"name": "git", "required": true},
+    {"name": "vim", "platform": ["linux", "darwin"], "on_conflict": "backup"}
+  ],
+  "profiles": {
+    "base": {"description": "Base setup", "packages": ["git", "vim"]}
+  },
+  "defaults": {"profile": "base", "on_conflict": "fail"}
+}
+`,
+}
+
+func TestLoad_Formats(t *testing.T) {
+	want := Config{
+		Version: "1.0",
+		Packages: []PackageSpec{
+			{Name: "git", Required: true},
+			{Name: "vim", Platform: []string{"linux", "darwin"}, ConflictPolicy: "backup"},
+		},
+		Profiles: map[string]Profile{
+			"base": {Description: "Base setup", Packages: []string{"git", "vim"}},
+		},
+		Defaults: Defaults{Profile: "base", ConflictPolicy: "fail"},
+	}
+
+	for name, content := range formatFixtures {
+		t.Run(name, func(t *testing.T) {
+			fs := writeRepo(t, map[string]string{name: content})
+			cfg, err := Load(context.Background(), fs, "/repo/"+name)
+			require.NoError(t, err)
+			assert.Equal(t, want, cfg)
+		})
+	}
+
+	t.Run(".dotbootstrap.yml", func(t *testing.T) {
+		fs := writeRepo(t, map[string]string{".dotbootstrap.yml": formatFixtures[".dotbootstrap.yaml"]})
+		cfg, err := Load(context.Background(), fs, "/repo/.dotbootstrap.yml")
+		require.NoError(t, err)
+		assert.Equal(t, want, cfg)
+	})
+}
+
+func TestLoad_FormatErrors(t *testing.T) {
+	tests := map[string]string{
+		".dotbootstrap.toml": "parse TOML",
+		".dotbootstrap.json": "parse JSON",
+		".dotbootstrap.yaml": "parse YAML",
+	}
+	for name, wantErr := range tests {
+		t.Run(name, func(t *testing.T) {
+			fs := writeRepo(t, map[string]string{name: "version = [unclosed"})
+			_, err := Load(context.Background(), fs, "/repo/"+name)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), wantErr)
+		})
+	}
+}
+
+func TestLoad_IncludeAcrossFormats(t *testing.T) {
+	fs := writeRepo(t, map[string]string{
+		"includes/base.json": `{"version": "1.0", "packages": [{"name": "git"}]}`,
+		"includes/vim.yaml":  "version: \"1.0\"\npackages:\n  - name: vim\n",
+		".dotbootstrap.toml": `version = "1.0"
+include = ["includes/base.json", "includes/vim.yaml"]
+
+[[packages]]
+name = "zsh"
+`,
+	})
+
+	cfg, err := Load(context.Background(), fs, "/repo/.dotbootstrap.toml")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"git", "vim", "zsh"}, GetPackageNames(cfg))
+}
+
+func TestIncludes_SingleValue(t *testing.T) {
+	for name, content := range map[string]string{
+		".dotbootstrap.toml": "version = \"1.0\"\ninclude = \"includes/base.yaml\"\n",
+		".dotbootstrap.json": `{"version": "1.0", "include": "includes/base.yaml"}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			fs := writeRepo(t, map[string]string{name: content, "includes/base.yaml": baseInclude})
+			cfg, err := Load(context.Background(), fs, "/repo/"+name)
+			require.NoError(t, err)
+			assert.Equal(t, []string{"git", "vim"}, GetPackageNames(cfg))
+		})
+	}
+}
+
+func TestFindConfig(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("none", func(t *testing.T) {
+		fs := writeRepo(t, nil)
+		path, found, err := FindConfig(ctx, fs, "/repo")
+		require.NoError(t, err)
+		assert.False(t, found)
+		assert.Empty(t, path)
+	})
+
+	for _, name := range ConfigFileNames {
+		t.Run(name, func(t *testing.T) {
+			fs := writeRepo(t, map[string]string{name: ""})
+			path, found, err := FindConfig(ctx, fs, "/repo")
+			require.NoError(t, err)
+			assert.True(t, found)
+			assert.Equal(t, "/repo/"+name, path)
+		})
+	}
+
+	t.Run("ambiguous", func(t *testing.T) {
+		fs := writeRepo(t, map[string]string{".dotbootstrap.yaml": "", ".dotbootstrap.toml": ""})
+		_, found, err := FindConfig(ctx, fs, "/repo")
+		assert.False(t, found)
+		var ambiguous ErrAmbiguousConfig
+		require.ErrorAs(t, err, &ambiguous)
+		assert.Equal(t, []string{".dotbootstrap.yaml", ".dotbootstrap.toml"}, ambiguous.Files)
+		assert.Contains(t, err.Error(), "multiple bootstrap files in /repo")
+	})
+}
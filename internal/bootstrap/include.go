@@ -0,0 +1,249 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultMaxIncludeDepth limits how deeply includes may nest.
+const DefaultMaxIncludeDepth = 5
+
+// maxRemoteIncludeSize caps the size of a bootstrap file fetched by URL.
+const maxRemoteIncludeSize = 1 << 20
+
+// Includes lists bootstrap files to include. It is either a single string
+// or a list of strings.
+type Includes []string
+
+// UnmarshalYAML accepts a single include as well as a list.
+func (i *Includes) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		*i = Includes{value.Value}
+		return nil
+	}
+	var list []string
+	if err := value.Decode(&list); err != nil {
+		return err
+	}
+	*i = list
+	return nil
+}
+
+// Fetcher retrieves the contents of an include given by URL.
+type Fetcher interface {
+	Fetch(ctx context.Context, url string) ([]byte, error)
+}
+
+// HTTPFetcher fetches includes over HTTPS.
+type HTTPFetcher struct {
+	client *http.Client
+}
+
+// NewHTTPFetcher creates a fetcher that uses client, refusing redirects to
+// anything but HTTPS.
+func NewHTTPFetcher(client *http.Client) *HTTPFetcher {
+	c := *client
+	c.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if req.URL.Scheme != "https" {
+			return fmt.Errorf("redirect to %s: only HTTPS is allowed", req.URL.Redacted())
+		}
+		if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
+		return nil
+	}
+	return &HTTPFetcher{client: &c}
+}
+
+// Fetch downloads the file at rawURL.
+func (f *HTTPFetcher) Fetch(ctx context.Context, rawURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxRemoteIncludeSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("read response: %w", err)
+	}
+	if len(data) > maxRemoteIncludeSize {
+		return nil, fmt.Errorf("file exceeds %d bytes", maxRemoteIncludeSize)
+	}
+	return data, nil
+}
+
+// includeLoader loads a bootstrap file and the files it includes.
+type includeLoader struct {
+	fs       FS
+	fetcher  Fetcher
+	maxDepth int
+	// root is the repository directory local includes must stay within.
+	root string
+}
+
+// load reads the bootstrap file at source and merges the files it
+// includes beneath it, later includes overriding earlier ones and the file
+// itself overriding all of them. chain holds the files including source,
+// outermost first.
+func (l *includeLoader) load(ctx context.Context, source string, chain []string) (Config, error) {
+	if slices.Contains(chain, source) {
+		return Config{}, fmt.Errorf("include cycle: %s", strings.Join(append(chain, source), " -> "))
+	}
+	if len(chain) > l.maxDepth {
+		return Config{}, fmt.Errorf("includes nested deeper than %d", l.maxDepth)
+	}
+
+	data, err := l.read(ctx, source)
+	if err != nil {
+		return Config{}, err
+	}
+	cfg, err := decodeConfig(source, data)
+	if err != nil {
+		return Config{}, err
+	}
+
+	var merged Config
+	for _, ref := range cfg.Include {
+		resolved, err := l.resolve(source, ref)
+		if err != nil {
+			return Config{}, fmt.Errorf("include %s: %w", ref, err)
+		}
+		included, err := l.load(ctx, resolved, append(slices.Clone(chain), source))
+		if err != nil {
+			return Config{}, fmt.Errorf("include %s: %w", ref, err)
+		}
+		merged = mergeConfig(merged, included)
+	}
+	return mergeConfig(merged, cfg), nil
+}
+
+// read returns the contents of a local or remote bootstrap file.
+func (l *includeLoader) read(ctx context.Context, source string) ([]byte, error) {
+	if !isURL(source) {
+		data, err := l.fs.ReadFile(ctx, source)
+		if err != nil {
+			return nil, fmt.Errorf("read config file: %w", err)
+		}
+		return data, nil
+	}
+
+	if l.fetcher == nil {
+		return nil, errors.New("URL includes are not supported")
+	}
+	data, err := l.fetcher.Fetch(ctx, source)
+	if err != nil {
+		return nil, fmt.Errorf("fetch %s: %w", redactURL(source), err)
+	}
+	return data, nil
+}
+
+// resolve returns the file ref refers to from the file parent. URLs must
+// use HTTPS; relative references in a remote file resolve against its URL.
+// Local paths must be relative and stay within the repository.
+func (l *includeLoader) resolve(parent, ref string) (string, error) {
+	if strings.TrimSpace(ref) == "" {
+		return "", errors.New("include must not be empty")
+	}
+
+	if isURL(ref) || isURL(parent) {
+		resolved, err := url.Parse(ref)
+		if err != nil {
+			return "", fmt.Errorf("invalid URL: %w", err)
+		}
+		if isURL(parent) {
+			// parent was resolved from a valid URL before it was loaded
+			base, _ := url.Parse(parent)
+			resolved = base.ResolveReference(resolved)
+		}
+		if resolved.Scheme != "https" {
+			return "", errors.New("only HTTPS URLs are allowed")
+		}
+		return resolved.String(), nil
+	}
+
+	if filepath.IsAbs(ref) || strings.HasPrefix(ref, "/") {
+		return "", errors.New("local includes must be relative to the including file")
+	}
+	path := filepath.Join(filepath.Dir(parent), filepath.FromSlash(ref))
+	rel, err := filepath.Rel(l.root, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", errors.New("path is outside the repository")
+	}
+	return path, nil
+}
+
+// mergeConfig returns base with over applied on top: packages and profiles
+// in over replace those of the same name, and set fields in over win.
+func mergeConfig(base, over Config) Config {
+	merged := Config{
+		Version:  base.Version,
+		Include:  over.Include,
+		Packages: slices.Clone(base.Packages),
+		Defaults: base.Defaults,
+	}
+	if over.Version != "" {
+		merged.Version = over.Version
+	}
+
+	index := make(map[string]int, len(merged.Packages))
+	for i, pkg := range merged.Packages {
+		index[pkg.Name] = i
+	}
+	for _, pkg := range over.Packages {
+		if i, ok := index[pkg.Name]; ok {
+			merged.Packages[i] = pkg
+			continue
+		}
+		index[pkg.Name] = len(merged.Packages)
+		merged.Packages = append(merged.Packages, pkg)
+	}
+
+	if len(base.Profiles) > 0 || len(over.Profiles) > 0 {
+		merged.Profiles = make(map[string]Profile, len(base.Profiles)+len(over.Profiles))
+		for name, profile := range base.Profiles {
+			merged.Profiles[name] = profile
+		}
+		for name, profile := range over.Profiles {
+			merged.Profiles[name] = profile
+		}
+	}
+
+	if over.Defaults.ConflictPolicy != "" {
+		merged.Defaults.ConflictPolicy = over.Defaults.ConflictPolicy
+	}
+	if over.Defaults.Profile != "" {
+		merged.Defaults.Profile = over.Defaults.Profile
+	}
+	return merged
+}
+
+// isURL reports whether an include refers to a URL rather than a path.
+func isURL(ref string) bool {
+	return strings.Contains(ref, "://")
+}
+
+// redactURL strips credentials from a URL for error messages.
+func redactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	return u.Redacted()
+}
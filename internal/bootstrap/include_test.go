@@ -0,0 +1,279 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yaklabco/dot/internal/adapters"
+)
+
+// mapFetcher serves includes from a map of URL to contents.
+type mapFetcher map[string]string
+
+func (f mapFetcher) Fetch(_ context.Context, url string) ([]byte, error) {
+	data, ok := f[url]
+	if !ok {
+		return nil, fmt.Errorf("unexpected status 404 Not Found")
+	}
+	return []byte(data), nil
+}
+
+// writeRepo writes files into a memory filesystem rooted at /repo.
+func writeRepo(t *testing.T, files map[string]string) *adapters.MemFS {
+	t.Helper()
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	require.NoError(t, fs.MkdirAll(ctx, "/repo/includes", 0755))
+	for name, content := range files {
+		require.NoError(t, fs.WriteFile(ctx, "/repo/"+name, []byte(content), 0644))
+	}
+	return fs
+}
+
+const baseInclude = `version: "1.0"
+packages:
+  - name: git
+    required: true
+  - name: vim
+    on_conflict: backup
+profiles:
+  base:
+    description: Team base
+    packages: [git, vim]
+defaults:
+  profile: base
+  on_conflict: fail
+`
+
+func TestLoad_LocalInclude(t *testing.T) {
+	fs := writeRepo(t, map[string]string{
+		"includes/base.yaml": baseInclude,
+		".dotbootstrap.yaml": `version: "1.0"
+include: includes/base.yaml
+packages:
+  - name: vim
+    on_conflict: skip
+  - name: zsh
+profiles:
+  mine:
+    description: Mine
+    packages: [git, zsh]
+defaults:
+  on_conflict: backup
+`,
+	})
+
+	cfg, err := Load(context.Background(), fs, "/repo/.dotbootstrap.yaml")
+	require.NoError(t, err)
+
+	assert.Equal(t, []PackageSpec{
+		{Name: "git", Required: true},
+		{Name: "vim", ConflictPolicy: "skip"},
+		{Name: "zsh"},
+	}, cfg.Packages)
+	assert.Equal(t, []string{"base", "mine"}, sortedKeys(cfg.Profiles))
+	assert.Equal(t, Defaults{Profile: "base", ConflictPolicy: "backup"}, cfg.Defaults)
+}
+
+func TestLoad_IncludeList(t *testing.T) {
+	fs := writeRepo(t, map[string]string{
+		"includes/base.yaml":  baseInclude,
+		"includes/extra.yaml": "packages:\n  - name: vim\n    required: true\n  - name: tmux\n",
+		".dotbootstrap.yaml":  "version: \"1.0\"\ninclude:\n  - includes/base.yaml\n  - includes/extra.yaml\n",
+	})
+
+	cfg, err := Load(context.Background(), fs, "/repo/.dotbootstrap.yaml")
+	require.NoError(t, err)
+
+	// Later includes override earlier ones
+	assert.Equal(t, []string{"git", "vim", "tmux"}, GetPackageNames(cfg))
+	assert.True(t, cfg.Packages[1].Required)
+}
+
+func TestLoad_NestedIncludeIsRelativeToIncludingFile(t *testing.T) {
+	fs := writeRepo(t, map[string]string{
+		"includes/base.yaml": baseInclude,
+		"includes/team.yaml": "include: base.yaml\npackages:\n  - name: tmux\n",
+		".dotbootstrap.yaml": "version: \"1.0\"\ninclude: includes/team.yaml\n",
+	})
+
+	cfg, err := Load(context.Background(), fs, "/repo/.dotbootstrap.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"git", "vim", "tmux"}, GetPackageNames(cfg))
+}
+
+func TestLoad_IncludeErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		opts    LoadOptions
+		wantErr string
+	}{
+		{
+			name: "cycle",
+			files: map[string]string{
+				"a.yaml":             "include: b.yaml\n",
+				"b.yaml":             "include: a.yaml\n",
+				".dotbootstrap.yaml": "version: \"1.0\"\ninclude: a.yaml\n",
+			},
+			wantErr: filepath.FromSlash("include cycle: /repo/.dotbootstrap.yaml -> /repo/a.yaml -> /repo/b.yaml -> /repo/a.yaml"),
+		},
+		{
+			name: "self include",
+			files: map[string]string{
+				".dotbootstrap.yaml": "version: \"1.0\"\ninclude: .dotbootstrap.yaml\n",
+			},
+			wantErr: "include cycle",
+		},
+		{
+			name: "too deep",
+			files: map[string]string{
+				"a.yaml":             "include: b.yaml\n",
+				"b.yaml":             "include: c.yaml\n",
+				"c.yaml":             "packages:\n  - name: vim\n",
+				".dotbootstrap.yaml": "version: \"1.0\"\ninclude: a.yaml\n",
+			},
+			opts:    LoadOptions{MaxIncludeDepth: 2},
+			wantErr: "nested deeper than 2",
+		},
+		{
+			name: "outside repository",
+			files: map[string]string{
+				".dotbootstrap.yaml": "version: \"1.0\"\ninclude: ../shared.yaml\n",
+			},
+			wantErr: "outside the repository",
+		},
+		{
+			name: "absolute path",
+			files: map[string]string{
+				".dotbootstrap.yaml": "version: \"1.0\"\ninclude: /etc/base.yaml\n",
+			},
+			wantErr: "must be relative",
+		},
+		{
+			name: "missing file",
+			files: map[string]string{
+				".dotbootstrap.yaml": "version: \"1.0\"\ninclude: missing.yaml\n",
+			},
+			wantErr: "include missing.yaml: read config file",
+		},
+		{
+			name: "plain HTTP",
+			files: map[string]string{
+				".dotbootstrap.yaml": "version: \"1.0\"\ninclude: http://example.com/base.yaml\n",
+			},
+			opts:    LoadOptions{Fetcher: mapFetcher{}},
+			wantErr: "only HTTPS URLs are allowed",
+		},
+		{
+			name: "URL without fetcher",
+			files: map[string]string{
+				".dotbootstrap.yaml": "version: \"1.0\"\ninclude: https://example.com/base.yaml\n",
+			},
+			wantErr: "URL includes are not supported",
+		},
+		{
+			name: "empty include",
+			files: map[string]string{
+				".dotbootstrap.yaml": "version: \"1.0\"\ninclude: [\"\"]\n",
+			},
+			wantErr: "must not be empty",
+		},
+		{
+			name: "merged config is validated",
+			files: map[string]string{
+				"base.yaml":          "packages:\n  - name: vim\n",
+				".dotbootstrap.yaml": "version: \"1.0\"\ninclude: base.yaml\nprofiles:\n  p:\n    description: p\n    packages: [emacs]\n",
+			},
+			wantErr: "emacs",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := writeRepo(t, tt.files)
+			_, err := LoadWithOptions(context.Background(), fs, "/repo/.dotbootstrap.yaml", tt.opts)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestLoadWithOptions_URLInclude(t *testing.T) {
+	fs := writeRepo(t, map[string]string{
+		".dotbootstrap.yaml": "version: \"1.0\"\ninclude: https://example.com/team/bootstrap.yaml\npackages:\n  - name: zsh\n",
+	})
+	fetcher := mapFetcher{
+		"https://example.com/team/bootstrap.yaml": "include: common.yaml\npackages:\n  - name: tmux\n",
+		"https://example.com/team/common.yaml":    baseInclude,
+	}
+
+	cfg, err := LoadWithOptions(context.Background(), fs, "/repo/.dotbootstrap.yaml", LoadOptions{Fetcher: fetcher})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"git", "vim", "tmux", "zsh"}, GetPackageNames(cfg))
+	assert.Equal(t, "base", cfg.Defaults.Profile)
+}
+
+func TestHTTPFetcher(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/base.yaml", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, baseInclude)
+	})
+	server := httptest.NewTLSServer(mux)
+	defer server.Close()
+
+	plain := httptest.NewServer(mux)
+	defer plain.Close()
+	mux.HandleFunc("/downgrade.yaml", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, plain.URL+"/base.yaml", http.StatusFound)
+	})
+
+	fetcher := NewHTTPFetcher(server.Client())
+	ctx := context.Background()
+
+	t.Run("fetches file", func(t *testing.T) {
+		data, err := fetcher.Fetch(ctx, server.URL+"/base.yaml")
+		require.NoError(t, err)
+		assert.Equal(t, baseInclude, string(data))
+	})
+
+	t.Run("reports HTTP errors", func(t *testing.T) {
+		_, err := fetcher.Fetch(ctx, server.URL+"/missing.yaml")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "404")
+	})
+
+	t.Run("refuses redirect to HTTP", func(t *testing.T) {
+		_, err := fetcher.Fetch(ctx, server.URL+"/downgrade.yaml")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "only HTTPS is allowed")
+	})
+}
+
+func TestIncludes_UnmarshalYAML(t *testing.T) {
+	fs := writeRepo(t, map[string]string{
+		"base.yaml":          "packages:\n  - name: vim\n",
+		".dotbootstrap.yaml": "version: \"1.0\"\ninclude: base.yaml\n",
+	})
+
+	cfg, err := Load(context.Background(), fs, "/repo/.dotbootstrap.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, Includes{"base.yaml"}, cfg.Include)
+}
+
+func sortedKeys(profiles map[string]Profile) []string {
+	keys := make([]string, 0, len(profiles))
+	for name := range profiles {
+		keys = append(keys, name)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -3,8 +3,7 @@ package bootstrap
 import (
 	"context"
 	"fmt"
-
-	"gopkg.in/yaml.v3"
+	"path/filepath"
 )
 
 // FS defines filesystem operations required for loading bootstrap config.
@@ -12,25 +11,56 @@ type FS interface {
 	ReadFile(ctx context.Context, path string) ([]byte, error)
 }
 
-// Load reads and parses a bootstrap configuration file.
+// LoadOptions configures how Load resolves includes.
+type LoadOptions struct {
+	// Fetcher retrieves includes given by URL. If nil, URL includes are
+	// rejected.
+	Fetcher Fetcher
+
+	// MaxIncludeDepth limits how deeply includes may nest. Zero uses
+	// DefaultMaxIncludeDepth.
+	MaxIncludeDepth int
+}
+
+// Load reads and parses a bootstrap configuration file, in YAML, TOML or
+// JSON according to its extension.
 //
 // Returns an error if:
 //   - File cannot be read
-//   - YAML syntax is invalid
+//   - The file's syntax is invalid
 //   - Configuration validation fails
 //
-// The configuration is automatically validated after loading.
+// The configuration is automatically validated after loading. Local
+// includes are resolved; URL includes are rejected. Use LoadWithOptions
+// to fetch them.
 func Load(ctx context.Context, fs FS, path string) (Config, error) {
-	// Read file
-	data, err := fs.ReadFile(ctx, path)
-	if err != nil {
-		return Config{}, fmt.Errorf("read config file: %w", err)
+	return LoadWithOptions(ctx, fs, path, LoadOptions{})
+}
+
+// LoadWithOptions reads and parses a bootstrap configuration file, merging
+// the files it includes before validation.
+//
+// Included files are read through fs when local and through opts.Fetcher
+// when given by URL. Their packages and profiles are merged beneath the
+// including file, which wins when names clash. Includes that form a cycle,
+// nest deeper than the depth limit, leave the repository directory, or
+// use a URL scheme other than HTTPS are errors.
+func LoadWithOptions(ctx context.Context, fs FS, path string, opts LoadOptions) (Config, error) {
+	maxDepth := opts.MaxIncludeDepth
+	if maxDepth <= 0 {
+		maxDepth = DefaultMaxIncludeDepth
+	}
+	path = filepath.Clean(path)
+	loader := &includeLoader{
+		fs:       fs,
+		fetcher:  opts.Fetcher,
+		maxDepth: maxDepth,
+		root:     filepath.Dir(path),
 	}
 
-	// Parse YAML
-	var cfg Config
-	if err := yaml.Unmarshal(data, &cfg); err != nil {
-		return Config{}, fmt.Errorf("parse YAML: %w", err)
+	cfg, err := loader.load(ctx, path, nil)
+	if err != nil {
+		return Config{}, err
 	}
 
 	// Validate configuration
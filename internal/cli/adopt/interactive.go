@@ -15,7 +15,8 @@ import (
 	"strconv"
 	"strings"
 
-	"github.com/charmbracelet/lipgloss"
+	"github.com/yaklabco/dot/internal/cli/prompt"
+	"github.com/yaklabco/dot/internal/cli/render"
 	"github.com/yaklabco/dot/internal/domain"
 )
 
@@ -25,6 +26,7 @@ type InteractiveAdopter struct {
 	output     io.Writer
 	candidates []DotfileCandidate
 	colorize   bool
+	theme      render.Theme
 	fs         domain.FS
 	configDir  string
 }
@@ -35,11 +37,18 @@ func NewInteractiveAdopter(input io.Reader, output io.Writer, colorize bool, fs
 		input:     input,
 		output:    output,
 		colorize:  colorize,
+		theme:     render.DefaultTheme(),
 		fs:        fs,
 		configDir: configDir,
 	}
 }
 
+// WithTheme sets the color theme used for prompts and the file selector.
+func (ia *InteractiveAdopter) WithTheme(theme render.Theme) *InteractiveAdopter {
+	ia.theme = theme
+	return ia
+}
+
 // Run executes the interactive adoption workflow.
 // Returns selected groups ready for adoption.
 func (ia *InteractiveAdopter) Run(ctx context.Context, candidates []DotfileCandidate) ([]AdoptGroup, error) {
@@ -73,7 +82,7 @@ func (ia *InteractiveAdopter) Run(ctx context.Context, candidates []DotfileCandi
 	}
 
 	// Step 3: Preview changes (dry-run style)
-	if !ia.confirmAdoption(groups) {
+	if !ia.confirmAdoption(ctx, groups) {
 		fmt.Fprintln(ia.output, "Adoption cancelled.")
 		return nil, nil
 	}
@@ -84,7 +93,7 @@ func (ia *InteractiveAdopter) Run(ctx context.Context, candidates []DotfileCandi
 // selectFiles displays candidates and prompts for selection using arrow keys.
 func (ia *InteractiveAdopter) selectFiles(ctx context.Context) ([]int, error) {
 	// Use arrow-key selector
-	sel := NewArrowSelector(ia.input, ia.output, ia.fs, ia.configDir)
+	sel := NewArrowSelector(ia.input, ia.output, ia.fs, ia.configDir).WithTheme(ia.theme)
 
 	// Format candidates as display strings
 	displayItems := make([]string, len(ia.candidates))
@@ -119,8 +128,8 @@ func (ia *InteractiveAdopter) organizeIntoPackages(selections []int) ([]AdoptGro
 	groups := GroupByCategory(ia.candidates, selections)
 
 	// Display groups and allow editing
-	headerStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("110")).Bold(true)
-	promptStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("109"))
+	headerStyle := ia.theme.Foreground(ia.theme.Info).Bold(true)
+	promptStyle := ia.theme.Foreground(ia.theme.Cursor)
 
 	fmt.Fprintln(ia.output, "")
 	fmt.Fprintln(ia.output, headerStyle.Render("Package Organization"))
@@ -191,10 +200,10 @@ func (ia *InteractiveAdopter) organizeIntoPackages(selections []int) ([]AdoptGro
 }
 
 // confirmAdoption displays preview and confirms.
-func (ia *InteractiveAdopter) confirmAdoption(groups []AdoptGroup) bool {
-	headerStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("110")).Bold(true)
-	accentStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("109"))
-	warningStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("179"))
+func (ia *InteractiveAdopter) confirmAdoption(ctx context.Context, groups []AdoptGroup) bool {
+	headerStyle := ia.theme.Foreground(ia.theme.Info).Bold(true)
+	accentStyle := ia.theme.Foreground(ia.theme.Cursor)
+	warningStyle := ia.theme.Foreground(ia.theme.Warning)
 
 	fmt.Fprintln(ia.output, "")
 	fmt.Fprintln(ia.output, headerStyle.Render("Adoption Preview"))
@@ -222,15 +231,8 @@ func (ia *InteractiveAdopter) confirmAdoption(groups []AdoptGroup) bool {
 	fmt.Fprintln(ia.output, warningStyle.Render("⚠")+" Files will be moved and symlinks created.")
 	fmt.Fprintln(ia.output, "")
 
-	fmt.Fprint(ia.output, "Proceed with adoption? [y/N]: ")
-
-	scanner := bufio.NewScanner(ia.input)
-	if !scanner.Scan() {
-		return false
-	}
-
-	response := strings.TrimSpace(strings.ToLower(scanner.Text()))
-	return response == "y" || response == "yes"
+	confirmed, err := prompt.Confirm(ctx, ia.input, ia.output, "Proceed with adoption?", false)
+	return err == nil && confirmed
 }
 
 // formatSize formats bytes into human-readable size.
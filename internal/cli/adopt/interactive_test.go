@@ -3,13 +3,16 @@ package adopt
 import (
 	"bytes"
 	"context"
+	"errors"
 	"strings"
 	"testing"
+	"testing/iotest"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/yaklabco/dot/internal/adapters"
+	"github.com/yaklabco/dot/internal/cli/prompt"
 )
 
 func TestNewInteractiveAdopter(t *testing.T) {
@@ -271,7 +274,7 @@ func TestConfirmAdoption_Accept(t *testing.T) {
 		},
 	}
 
-	result := adopter.confirmAdoption(groups)
+	result := adopter.confirmAdoption(context.Background(), groups)
 	assert.True(t, result)
 }
 
@@ -289,7 +292,7 @@ func TestConfirmAdoption_Reject(t *testing.T) {
 		},
 	}
 
-	result := adopter.confirmAdoption(groups)
+	result := adopter.confirmAdoption(context.Background(), groups)
 	assert.False(t, result)
 }
 
@@ -307,10 +310,28 @@ func TestConfirmAdoption_DefaultNo(t *testing.T) {
 		},
 	}
 
-	result := adopter.confirmAdoption(groups)
+	result := adopter.confirmAdoption(context.Background(), groups)
 	assert.False(t, result)
 }
 
+func TestConfirmAdoption_AssumeYes(t *testing.T) {
+	output := &bytes.Buffer{}
+	fs := adapters.NewMemFS()
+	adopter := NewInteractiveAdopter(iotest.ErrReader(errors.New("stdin must not be read")), output, false, fs, "/tmp/test-config")
+
+	groups := []AdoptGroup{
+		{
+			PackageName: "bash",
+			Files:       []string{"/home/user/.bashrc"},
+			Category:    "shell",
+		},
+	}
+
+	result := adopter.confirmAdoption(prompt.WithAssumeYes(context.Background(), true), groups)
+	assert.True(t, result)
+	assert.NotContains(t, output.String(), "Proceed with adoption?")
+}
+
 func TestConfirmAdoption_DisplaysPreview(t *testing.T) {
 	input := strings.NewReader("n\n")
 	output := &bytes.Buffer{}
@@ -330,7 +351,7 @@ func TestConfirmAdoption_DisplaysPreview(t *testing.T) {
 		},
 	}
 
-	adopter.confirmAdoption(groups)
+	adopter.confirmAdoption(context.Background(), groups)
 
 	outputStr := output.String()
 	assert.Contains(t, outputStr, "Adoption Preview")
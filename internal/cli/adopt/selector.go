@@ -5,6 +5,7 @@
 package adopt
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
@@ -18,6 +19,7 @@ import (
 	"github.com/alecthomas/chroma/v2/quick"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/yaklabco/dot/internal/cli/render"
 	"github.com/yaklabco/dot/internal/domain"
 )
 
@@ -37,6 +39,7 @@ type ArrowSelector struct {
 	output    io.Writer
 	fs        domain.FS
 	configDir string
+	theme     render.Theme
 }
 
 // NewArrowSelector creates a new arrow-key selector.
@@ -46,9 +49,16 @@ func NewArrowSelector(input io.Reader, output io.Writer, fs domain.FS, configDir
 		output:    output,
 		fs:        fs,
 		configDir: configDir,
+		theme:     render.DefaultTheme(),
 	}
 }
 
+// WithTheme sets the color theme used to render the selector.
+func (s *ArrowSelector) WithTheme(theme render.Theme) *ArrowSelector {
+	s.theme = theme
+	return s
+}
+
 // bubbleModel represents the Bubble Tea model for the selector.
 type bubbleModel struct {
 	items       []string
@@ -66,6 +76,7 @@ type bubbleModel struct {
 	candidates  []DotfileCandidate // Original candidates
 	fs          domain.FS          // Filesystem for operations
 	configDir   string             // Config directory
+	theme       render.Theme       // Color theme
 }
 
 // Message types for ignore animation and view modal
@@ -529,8 +540,9 @@ func (m bubbleModel) buildViewContent(ctx context.Context, candidate DotfileCand
 			}
 		}
 	} else {
-		// Show file preview (first 50 lines)
-		content, err := m.fs.ReadFile(ctx, candidate.Path)
+		// Show file preview (first 50 lines), reading only enough of the
+		// file for the preview and the binary check
+		content, err := m.fs.ReadFileRange(ctx, candidate.Path, 0, previewReadLimit)
 		if err != nil {
 			b.WriteString(fmt.Sprintf("Error reading file: %v\n", err))
 		} else {
@@ -545,6 +557,15 @@ func (m bubbleModel) buildViewContent(ctx context.Context, candidate DotfileCand
 				b.WriteString("  - Archives (.zip, .tar, .gz)\n")
 				b.WriteString("  - Compiled code (.pyc, .o, .a)\n")
 			} else {
+				// A file longer than the read limit is previewed up to the
+				// last whole line read; its line count is unknown
+				partial := len(content) == previewReadLimit && candidate.Size > previewReadLimit
+				if partial {
+					if end := bytes.LastIndexByte(content, '\n'); end >= 0 {
+						content = content[:end]
+					}
+				}
+
 				// Apply syntax highlighting based on file extension
 				highlighted := m.highlightContent(candidate.Path, content)
 				lines := strings.Split(highlighted, "\n")
@@ -556,9 +577,12 @@ func (m bubbleModel) buildViewContent(ctx context.Context, candidate DotfileCand
 					maxLines = 50
 				}
 
-				if totalLines <= 50 {
+				switch {
+				case partial:
+					b.WriteString(fmt.Sprintf("Preview (first %d lines of %s):\n", maxLines, formatSize(candidate.Size)))
+				case totalLines <= 50:
 					b.WriteString(fmt.Sprintf("Preview (%d lines):\n", totalLines))
-				} else {
+				default:
 					b.WriteString(fmt.Sprintf("Preview (first %d of %d lines):\n", maxLines, totalLines))
 				}
 				b.WriteString("\n")
@@ -566,15 +590,18 @@ func (m bubbleModel) buildViewContent(ctx context.Context, candidate DotfileCand
 				for i := 0; i < maxLines; i++ {
 					// Truncate long lines (accounting for ANSI codes)
 					line := lines[i]
-					visualLen := len(stripANSI(line))
+					visualLen := render.VisibleWidth(line)
 					if visualLen > 80 {
 						// Find position to truncate (need to handle ANSI codes)
-						line = truncateWithANSI(line, 77) + "..."
+						line = render.TruncateWithANSI(line, 77) + "..."
 					}
 					b.WriteString(fmt.Sprintf("%4d | %s\n", i+1, line))
 				}
 
-				if totalLines > 50 {
+				switch {
+				case partial:
+					b.WriteString("\n... and more\n")
+				case totalLines > 50:
 					b.WriteString(fmt.Sprintf("\n... and %d more lines\n", totalLines-50))
 				}
 			}
@@ -584,6 +611,10 @@ func (m bubbleModel) buildViewContent(ctx context.Context, candidate DotfileCand
 	return b.String()
 }
 
+// previewReadLimit caps how much of a file the view modal reads. It covers
+// the binary check sample and 50 lines of all but very long lines.
+const previewReadLimit = 64 * 1024
+
 // isBinaryContent checks if the content appears to be binary.
 // Returns true if the content contains null bytes or has high ratio of non-printable characters.
 func isBinaryContent(content []byte) bool {
@@ -637,67 +668,6 @@ func (m bubbleModel) highlightContent(filePath string, content []byte) string {
 	return highlighted.String()
 }
 
-// stripANSI removes ANSI escape codes from a string to get visual length.
-func stripANSI(s string) string {
-	// Simple ANSI stripper - matches ESC [ ... m
-	inEscape := false
-	var result strings.Builder
-
-	for i := 0; i < len(s); i++ {
-		if s[i] == '\x1b' && i+1 < len(s) && s[i+1] == '[' {
-			inEscape = true
-			i++ // Skip the '['
-			continue
-		}
-
-		if inEscape {
-			if s[i] == 'm' {
-				inEscape = false
-			}
-			continue
-		}
-
-		result.WriteByte(s[i])
-	}
-
-	return result.String()
-}
-
-// truncateWithANSI truncates a string containing ANSI codes to a visual width.
-func truncateWithANSI(s string, maxVisualLen int) string {
-	visualLen := 0
-	var result strings.Builder
-	inEscape := false
-
-	for i := 0; i < len(s); i++ {
-		// Detect start of ANSI sequence
-		if s[i] == '\x1b' && i+1 < len(s) && s[i+1] == '[' {
-			inEscape = true
-			result.WriteByte(s[i])
-			continue
-		}
-
-		// Copy ANSI codes without counting them
-		if inEscape {
-			result.WriteByte(s[i])
-			if s[i] == 'm' {
-				inEscape = false
-			}
-			continue
-		}
-
-		// Count and copy visible characters
-		if visualLen >= maxVisualLen {
-			break
-		}
-
-		result.WriteByte(s[i])
-		visualLen++
-	}
-
-	return result.String()
-}
-
 // getGridLayout calculates the grid layout parameters.
 // Returns (numCols, totalRows) for row-major layout.
 func (m *bubbleModel) getGridLayout() (numCols, totalRows int) {
@@ -1045,22 +1015,27 @@ type viewStyles struct {
 	highlight   lipgloss.Style
 }
 
-// getStyles returns the lipgloss styles for rendering.
+// getStyles returns the lipgloss styles for rendering, using the model's theme.
 func (m bubbleModel) getStyles() viewStyles {
+	return newViewStyles(m.theme)
+}
+
+// newViewStyles maps theme roles to the selector's lipgloss styles.
+func newViewStyles(theme render.Theme) viewStyles {
 	return viewStyles{
-		header:      lipgloss.NewStyle().Foreground(lipgloss.Color("110")).Bold(true),
-		cursor:      lipgloss.NewStyle().Foreground(lipgloss.Color("109")).Bold(true),
-		selected:    lipgloss.NewStyle().Foreground(lipgloss.Color("42")),
-		dim:         lipgloss.NewStyle().Foreground(lipgloss.Color("240")),
-		instruction: lipgloss.NewStyle().Foreground(lipgloss.Color("245")),
-		ignoring:    lipgloss.NewStyle().Foreground(lipgloss.Color("244")),
+		header:      theme.Foreground(theme.Info).Bold(true),
+		cursor:      theme.Foreground(theme.Cursor).Bold(true),
+		selected:    theme.Foreground(theme.Selected),
+		dim:         theme.Foreground(theme.Dim),
+		instruction: theme.Foreground(theme.Dim),
+		ignoring:    theme.Foreground(theme.Dim),
 		modal: lipgloss.NewStyle().
 			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("109")).
+			BorderForeground(theme.Foreground(theme.Cursor).GetForeground()).
 			Padding(1, 2).
 			Width(80),
-		modalBorder: lipgloss.NewStyle().Foreground(lipgloss.Color("109")),
-		highlight:   lipgloss.NewStyle().Background(lipgloss.Color("235")),
+		modalBorder: theme.Foreground(theme.Cursor),
+		highlight:   theme.Background(theme.Highlight),
 	}
 }
 
@@ -1176,7 +1151,7 @@ func (m bubbleModel) renderViewModal(baseView string, styles viewStyles) string
 	// Create the modal style
 	modalStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("109")).
+		BorderForeground(styles.modalBorder.GetForeground()).
 		Padding(1, 2).
 		Width(modalWidth).
 		MaxHeight(maxHeight + 2) // Account for padding
@@ -1230,10 +1205,11 @@ func (m bubbleModel) renderRow(b *strings.Builder, styles viewStyles, row, numCo
 
 		if isCursor {
 			// Apply highlight background to all components
-			cursorStyle := styles.cursor.Copy().Background(lipgloss.Color("235"))
-			selectedStyle := styles.selected.Copy().Background(lipgloss.Color("235"))
-			ignoringStyle := styles.ignoring.Copy().Background(lipgloss.Color("235"))
-			normalStyle := lipgloss.NewStyle().Background(lipgloss.Color("235"))
+			highlight := styles.highlight.GetBackground()
+			cursorStyle := styles.cursor.Copy().Background(highlight)
+			selectedStyle := styles.selected.Copy().Background(highlight)
+			ignoringStyle := styles.ignoring.Copy().Background(highlight)
+			normalStyle := styles.highlight
 
 			// Prefix with highlight (always cursor for highlighted row)
 			prefix = cursorStyle.Render("❯ ")
@@ -1322,6 +1298,7 @@ func (s *ArrowSelector) SelectMultiple(items []string, candidates []DotfileCandi
 		width:      80, // Default, will be updated by WindowSizeMsg
 		fs:         s.fs,
 		configDir:  s.configDir,
+		theme:      s.theme,
 	}
 
 	// Use tea.WithAltScreen() for proper alternate screen buffer handling
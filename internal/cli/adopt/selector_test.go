@@ -2,13 +2,18 @@ package adopt
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"strings"
 	"testing"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/yaklabco/dot/internal/adapters"
+	"github.com/yaklabco/dot/internal/cli/render"
 )
 
 func TestNewArrowSelector(t *testing.T) {
@@ -193,3 +198,58 @@ func TestBubbleModel_UpdateViewport(t *testing.T) {
 	m.updateViewport()
 	assert.Equal(t, 0, m.viewportTop) // Should be at top
 }
+
+func TestNewViewStyles_Theme(t *testing.T) {
+	styles := newViewStyles(render.DefaultTheme())
+	assert.Equal(t, lipgloss.Color("109"), styles.cursor.GetForeground())
+	assert.Equal(t, lipgloss.Color("235"), styles.highlight.GetBackground())
+
+	noColor, _ := render.ThemeByName(render.ThemeNoColor)
+	styles = newViewStyles(noColor)
+	assert.Equal(t, lipgloss.NoColor{}, styles.cursor.GetForeground())
+	assert.Equal(t, lipgloss.NoColor{}, styles.selected.GetForeground())
+	assert.Equal(t, lipgloss.NoColor{}, styles.highlight.GetBackground())
+	assert.Equal(t, "[✓]", styles.selected.Render("[✓]"))
+}
+
+// rangeOnlyFS fails whole-file reads, proving a caller reads ranges only.
+type rangeOnlyFS struct {
+	*adapters.MemFS
+}
+
+func (f rangeOnlyFS) ReadFile(ctx context.Context, name string) ([]byte, error) {
+	return nil, errors.New("whole-file read of " + name)
+}
+
+func TestBubbleModel_BuildViewContent_LargeFile(t *testing.T) {
+	ctx := context.Background()
+	mem := adapters.NewMemFS()
+	require.NoError(t, mem.MkdirAll(ctx, "/home", 0755))
+
+	// Far larger than the preview reads
+	line := strings.Repeat("x", 63) + "\n"
+	content := strings.Repeat(line, 4*previewReadLimit/len(line))
+	require.NoError(t, mem.WriteFile(ctx, "/home/huge.log", []byte(content), 0644))
+
+	m := bubbleModel{fs: rangeOnlyFS{mem}}
+	view := m.buildViewContent(ctx, DotfileCandidate{Path: "/home/huge.log", Size: int64(len(content))})
+
+	assert.NotContains(t, view, "Error reading file")
+	assert.Contains(t, view, "Preview (first 50 lines of 256.0KB):")
+	assert.Contains(t, view, "  50 | ")
+	assert.NotContains(t, view, "  51 | ")
+	assert.Contains(t, view, "... and more")
+}
+
+func TestBubbleModel_BuildViewContent_SmallFile(t *testing.T) {
+	ctx := context.Background()
+	mem := adapters.NewMemFS()
+	require.NoError(t, mem.MkdirAll(ctx, "/home", 0755))
+	require.NoError(t, mem.WriteFile(ctx, "/home/.bashrc", []byte("one\ntwo\nthree"), 0644))
+
+	m := bubbleModel{fs: rangeOnlyFS{mem}}
+	view := m.buildViewContent(ctx, DotfileCandidate{Path: "/home/.bashrc", Size: 13})
+
+	assert.Contains(t, view, "Preview (3 lines):")
+	assert.NotContains(t, view, "... and more")
+}
@@ -153,6 +153,34 @@ func (f *Formatter) getTemplate(err error, ctx ErrorContext) *Template {
 		}
 	}
 
+	var readOnly domain.ErrReadOnlyFS
+	if errors.As(err, &readOnly) {
+		return &Template{
+			Title:       "Read-Only Filesystem",
+			Description: fmt.Sprintf("Cannot %s %q", readOnly.Operation, readOnly.Path),
+			Suggestions: []string{
+				"Filesystem changes are blocked in dry-run mode",
+				"Run the command without --dry-run to apply changes",
+			},
+		}
+	}
+
+	var notPermitted domain.ErrSymlinkNotPermitted
+	if errors.As(err, &notPermitted) {
+		return &Template{
+			Title:       "Symbolic Links Not Permitted",
+			Description: fmt.Sprintf("Cannot create symbolic link %q", notPermitted.Path),
+			Details: []string{
+				"Windows only allows symbolic links with Developer Mode or administrator rights",
+				"Directories are linked with junctions instead, but files have no equivalent",
+			},
+			Suggestions: []string{
+				"Enable Developer Mode in Settings > System > For developers",
+				"Or run dot from an elevated (administrator) terminal",
+			},
+		}
+	}
+
 	// Executor Errors
 	var emptyPlan domain.ErrEmptyPlan
 	if errors.As(err, &emptyPlan) {
@@ -172,6 +200,13 @@ func (f *Formatter) getTemplate(err error, ctx ErrorContext) *Template {
 		if execFailed.RolledBack > 0 {
 			details = append(details, fmt.Sprintf("%d operations rolled back", execFailed.RolledBack))
 		}
+		if execFailed.RollbackFailed > 0 {
+			details = append(details, fmt.Sprintf("%d operations could not be rolled back", execFailed.RollbackFailed))
+		}
+		if execFailed.Transactions > 0 {
+			details = append(details, fmt.Sprintf("%d of %d transactions committed",
+				execFailed.CommittedTransactions, execFailed.Transactions))
+		}
 		return &Template{
 			Title:       "Execution Failed",
 			Description: "Some operations could not be completed",
@@ -126,6 +126,37 @@ func TestFormatter_Format_ExecutionFailed(t *testing.T) {
 	assert.Contains(t, result, "5 operations succeeded")
 	assert.Contains(t, result, "2 operations failed")
 	assert.Contains(t, result, "2 operations rolled back")
+	assert.NotContains(t, result, "transactions committed")
+}
+
+func TestFormatter_Format_ExecutionFailed_RollbackFailures(t *testing.T) {
+	f := NewFormatter(false, 0)
+	err := domain.ErrExecutionFailed{
+		Executed:       3,
+		Failed:         1,
+		RolledBack:     2,
+		RollbackFailed: 1,
+		Errors:         []error{errors.New("error 1"), errors.New("error 2")},
+	}
+
+	result := f.Format(err)
+	assert.Contains(t, result, "1 operations could not be rolled back")
+	assert.Contains(t, result, "clean them up manually")
+}
+
+func TestFormatter_Format_ExecutionFailed_Transactions(t *testing.T) {
+	f := NewFormatter(false, 0)
+	err := domain.ErrExecutionFailed{
+		Executed:              10,
+		Failed:                1,
+		RolledBack:            2,
+		Errors:                []error{errors.New("error 1")},
+		Transactions:          3,
+		CommittedTransactions: 2,
+	}
+
+	result := f.Format(err)
+	assert.Contains(t, result, "2 of 3 transactions committed")
 }
 
 func TestFormatter_Format_SourceNotFound(t *testing.T) {
@@ -351,3 +382,27 @@ func TestFormatter_Format_NarrowTerminal(t *testing.T) {
 		}
 	}
 }
+
+func TestFormatter_Format_SymlinkNotPermitted(t *testing.T) {
+	f := NewFormatter(false, 0)
+	err := domain.ErrExecutionFailed{
+		Executed: 1,
+		Failed:   1,
+		Errors:   []error{domain.ErrSymlinkNotPermitted{Path: "/home/.vimrc"}},
+	}
+
+	result := f.Format(err)
+	assert.Contains(t, result, "Symbolic Links Not Permitted")
+	assert.Contains(t, result, `Cannot create symbolic link "/home/.vimrc"`)
+	assert.Contains(t, result, "Developer Mode")
+}
+
+func TestFormatter_Format_ReadOnlyFS(t *testing.T) {
+	f := NewFormatter(false, 0)
+	err := domain.ErrReadOnlyFS{Operation: "create symlink", Path: "/home/.vimrc"}
+
+	result := f.Format(err)
+	assert.Contains(t, result, "Read-Only Filesystem")
+	assert.Contains(t, result, `Cannot create symlink "/home/.vimrc"`)
+	assert.Contains(t, result, "dry-run")
+}
@@ -160,7 +160,15 @@ func (e *SuggestionEngine) suggestForExecutionFailed(err domain.ErrExecutionFail
 		"Review the individual error messages above for specific issues",
 	}
 
-	if err.RolledBack > 0 {
+	if err.CommittedTransactions > 0 {
+		suggestions = append(suggestions,
+			"Operations in committed transactions were kept",
+			"Fix the cause and run the command again to apply the remaining operations")
+	} else if err.RollbackFailed > 0 {
+		suggestions = append(suggestions,
+			"Some operations could not be rolled back",
+			"Check the paths listed above and clean them up manually")
+	} else if err.RolledBack > 0 {
 		suggestions = append(suggestions,
 			"Some operations were rolled back automatically",
 			"The system should be in a consistent state")
@@ -216,6 +216,39 @@ func TestSuggestionEngine_Generate_ExecutionFailed_WithRollback(t *testing.T) {
 	assert.True(t, found, "should mention rollback")
 }
 
+func TestSuggestionEngine_Generate_ExecutionFailed_WithRollbackFailures(t *testing.T) {
+	engine := SuggestionEngine{}
+	err := domain.ErrExecutionFailed{
+		Executed:       5,
+		Failed:         1,
+		RolledBack:     3,
+		RollbackFailed: 1,
+		Errors:         []error{errors.New("error 1"), errors.New("error 2")},
+	}
+
+	suggestions := engine.Generate(err)
+
+	assert.Contains(t, suggestions, "Some operations could not be rolled back")
+	assert.NotContains(t, suggestions, "The system should be in a consistent state")
+}
+
+func TestSuggestionEngine_Generate_ExecutionFailed_WithCommittedTransactions(t *testing.T) {
+	engine := SuggestionEngine{}
+	err := domain.ErrExecutionFailed{
+		Executed:              10,
+		Failed:                1,
+		RolledBack:            2,
+		Errors:                []error{errors.New("error 1")},
+		Transactions:          3,
+		CommittedTransactions: 2,
+	}
+
+	suggestions := engine.Generate(err)
+
+	assert.Contains(t, suggestions, "Operations in committed transactions were kept")
+	assert.NotContains(t, suggestions, "The system should be in a consistent state")
+}
+
 func TestSuggestionEngine_Generate_ExecutionFailed_WithDryRun(t *testing.T) {
 	engine := SuggestionEngine{
 		context: ErrorContext{
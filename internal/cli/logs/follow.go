@@ -0,0 +1,100 @@
+package logs
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// DefaultPollInterval is how often Follow checks the log file for new data.
+const DefaultPollInterval = 500 * time.Millisecond
+
+// Follow streams new lines appended to path after offset to w, writing only
+// lines that parse and match filter. It returns when ctx is canceled.
+//
+// If the file shrinks (truncated or rotated), reading restarts from the
+// beginning of the new file and any unfinished line from the old file is
+// dropped.
+func Follow(ctx context.Context, path string, offset int64, format string, filter Filter, w io.Writer, poll time.Duration) error {
+	if poll <= 0 {
+		poll = DefaultPollInterval
+	}
+
+	var pending []byte
+	ticker := time.NewTicker(poll)
+	defer ticker.Stop()
+
+	for {
+		data, newOffset, err := readFrom(path, offset)
+		if err != nil {
+			return err
+		}
+		// Reading restarted before offset, so the file was truncated or
+		// rotated and a partial line from the old file will never complete
+		if newOffset-int64(len(data)) < offset {
+			pending = nil
+		}
+		offset = newOffset
+
+		pending = append(pending, data...)
+		for {
+			idx := bytes.IndexByte(pending, '\n')
+			if idx < 0 {
+				break
+			}
+			line := string(pending[:idx])
+			pending = pending[idx+1:]
+
+			entry, err := ParseLine(line, format)
+			if err != nil || !filter.Match(entry) {
+				continue
+			}
+			if _, err := fmt.Fprintln(w, entry.Raw); err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// readFrom returns the bytes in path after offset and the new offset.
+// A missing file yields no data; a file smaller than offset is reread from
+// the start.
+func readFrom(path string, offset int64) ([]byte, int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, 0, nil
+		}
+		return nil, offset, fmt.Errorf("open log file: %w", err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, offset, fmt.Errorf("stat log file: %w", err)
+	}
+	if info.Size() < offset {
+		offset = 0
+	}
+	if info.Size() == offset {
+		return nil, offset, nil
+	}
+
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return nil, offset, fmt.Errorf("seek log file: %w", err)
+	}
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return nil, offset, fmt.Errorf("read log file: %w", err)
+	}
+	return data, offset + int64(len(data)), nil
+}
@@ -0,0 +1,212 @@
+// Package logs parses and filters dot's structured log files.
+//
+// Both formats produced by the slog-based logging adapter are supported:
+// text (key=value pairs, as written by slog.TextHandler) and json (one
+// object per line, as written by slog.JSONHandler).
+package logs
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Log formats understood by ParseLine.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+	FormatAuto = "auto"
+)
+
+// ErrUnparseable indicates a line is not a structured log record.
+var ErrUnparseable = errors.New("unparseable log line")
+
+// Entry is a single parsed log record.
+type Entry struct {
+	Time    time.Time
+	Level   slog.Level
+	Message string
+	Attrs   map[string]string
+	Raw     string
+}
+
+// ParseLine parses a single log line in the given format.
+// FormatAuto (or an empty format) detects json by a leading '{'.
+func ParseLine(line, format string) (Entry, error) {
+	trimmed := strings.TrimSpace(line)
+	if trimmed == "" {
+		return Entry{}, ErrUnparseable
+	}
+
+	switch format {
+	case FormatJSON:
+		return parseJSON(trimmed, line)
+	case FormatText:
+		return parseText(trimmed, line)
+	case FormatAuto, "":
+		if strings.HasPrefix(trimmed, "{") {
+			return parseJSON(trimmed, line)
+		}
+		return parseText(trimmed, line)
+	default:
+		return Entry{}, fmt.Errorf("unknown log format %q", format)
+	}
+}
+
+func parseJSON(trimmed, raw string) (Entry, error) {
+	var fields map[string]any
+	if err := json.Unmarshal([]byte(trimmed), &fields); err != nil {
+		return Entry{}, fmt.Errorf("%w: %v", ErrUnparseable, err)
+	}
+
+	attrs := make(map[string]string, len(fields))
+	for k, v := range fields {
+		switch typed := v.(type) {
+		case string:
+			attrs[k] = typed
+		default:
+			encoded, _ := json.Marshal(typed)
+			attrs[k] = string(encoded)
+		}
+	}
+	return newEntry(attrs, raw)
+}
+
+func parseText(trimmed, raw string) (Entry, error) {
+	attrs := make(map[string]string)
+	rest := trimmed
+	for rest != "" {
+		eq := strings.IndexByte(rest, '=')
+		if eq <= 0 || strings.ContainsAny(rest[:eq], " \t") {
+			return Entry{}, ErrUnparseable
+		}
+		key := rest[:eq]
+		rest = rest[eq+1:]
+
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			quoted, err := strconv.QuotedPrefix(rest)
+			if err != nil {
+				return Entry{}, fmt.Errorf("%w: %v", ErrUnparseable, err)
+			}
+			value, _ = strconv.Unquote(quoted)
+			rest = rest[len(quoted):]
+		} else {
+			end := strings.IndexAny(rest, " \t")
+			if end < 0 {
+				end = len(rest)
+			}
+			value = rest[:end]
+			rest = rest[end:]
+		}
+		attrs[key] = value
+		rest = strings.TrimLeft(rest, " \t")
+	}
+	return newEntry(attrs, raw)
+}
+
+// newEntry builds an Entry from the standard slog keys, leaving the
+// remaining keys as attributes.
+func newEntry(attrs map[string]string, raw string) (Entry, error) {
+	levelStr, ok := attrs[slog.LevelKey]
+	if !ok {
+		return Entry{}, ErrUnparseable
+	}
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(levelStr)); err != nil {
+		return Entry{}, fmt.Errorf("%w: %v", ErrUnparseable, err)
+	}
+
+	entry := Entry{
+		Level:   level,
+		Message: attrs[slog.MessageKey],
+		Raw:     strings.TrimRight(raw, "\r\n"),
+	}
+	if ts, ok := attrs[slog.TimeKey]; ok {
+		if parsed, err := time.Parse(time.RFC3339Nano, ts); err == nil {
+			entry.Time = parsed
+		}
+	}
+
+	delete(attrs, slog.LevelKey)
+	delete(attrs, slog.MessageKey)
+	delete(attrs, slog.TimeKey)
+	entry.Attrs = attrs
+	return entry, nil
+}
+
+// AttrKeys returns the entry's attribute keys in sorted order.
+func (e Entry) AttrKeys() []string {
+	keys := make([]string, 0, len(e.Attrs))
+	for k := range e.Attrs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Filter selects log entries.
+type Filter struct {
+	// MinLevel drops entries below this level.
+	MinLevel slog.Level
+
+	// Since drops entries older than this time. Zero means no limit.
+	Since time.Time
+}
+
+// Match reports whether the entry passes the filter.
+// Entries without a timestamp are kept when Since is set.
+func (f Filter) Match(e Entry) bool {
+	if e.Level < f.MinLevel {
+		return false
+	}
+	if !f.Since.IsZero() && !e.Time.IsZero() && e.Time.Before(f.Since) {
+		return false
+	}
+	return true
+}
+
+// Read parses every line from r and returns the entries that match filter.
+// Lines that are not structured log records are skipped.
+func Read(r io.Reader, format string, filter Filter) ([]Entry, error) {
+	var entries []Entry
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		entry, err := ParseLine(scanner.Text(), format)
+		if err != nil {
+			continue
+		}
+		if filter.Match(entry) {
+			entries = append(entries, entry)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return entries, fmt.Errorf("read log: %w", err)
+	}
+	return entries, nil
+}
+
+// ParseSince interprets a --since value as either a duration relative to
+// now (e.g. "30m", "2h") or an absolute RFC3339 timestamp or date.
+func ParseSince(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(value); err == nil {
+		return now.Add(-d), nil
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, value, now.Location()); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid since value %q: expected duration (e.g. 1h) or timestamp (e.g. 2006-01-02T15:04:05Z)", value)
+}
@@ -0,0 +1,207 @@
+package logs_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yaklabco/dot/internal/cli/logs"
+)
+
+func TestParseLine_Text(t *testing.T) {
+	line := `time=2026-01-10T09:00:02.000Z level=WARN msg="file exists" path="/home/user/my file" count=3`
+
+	entry, err := logs.ParseLine(line, logs.FormatText)
+	require.NoError(t, err)
+
+	assert.Equal(t, slog.LevelWarn, entry.Level)
+	assert.Equal(t, "file exists", entry.Message)
+	assert.Equal(t, time.Date(2026, 1, 10, 9, 0, 2, 0, time.UTC), entry.Time)
+	assert.Equal(t, "/home/user/my file", entry.Attrs["path"])
+	assert.Equal(t, "3", entry.Attrs["count"])
+	assert.Equal(t, []string{"count", "path"}, entry.AttrKeys())
+	assert.Equal(t, line, entry.Raw)
+}
+
+func TestParseLine_JSON(t *testing.T) {
+	line := `{"time":"2026-01-10T10:30:00Z","level":"ERROR","msg":"operation_failed","error":"permission denied","attempt":2}`
+
+	entry, err := logs.ParseLine(line, logs.FormatJSON)
+	require.NoError(t, err)
+
+	assert.Equal(t, slog.LevelError, entry.Level)
+	assert.Equal(t, "operation_failed", entry.Message)
+	assert.Equal(t, time.Date(2026, 1, 10, 10, 30, 0, 0, time.UTC), entry.Time)
+	assert.Equal(t, "permission denied", entry.Attrs["error"])
+	assert.Equal(t, "2", entry.Attrs["attempt"])
+}
+
+func TestParseLine_Auto(t *testing.T) {
+	jsonEntry, err := logs.ParseLine(`{"level":"INFO","msg":"a"}`, logs.FormatAuto)
+	require.NoError(t, err)
+	assert.Equal(t, "a", jsonEntry.Message)
+
+	textEntry, err := logs.ParseLine(`level=INFO msg=b`, "")
+	require.NoError(t, err)
+	assert.Equal(t, "b", textEntry.Message)
+}
+
+func TestParseLine_Unparseable(t *testing.T) {
+	for _, line := range []string{"", "plain text", `msg=no_level`, `{"msg":"no level"}`, `{broken`} {
+		_, err := logs.ParseLine(line, logs.FormatAuto)
+		assert.ErrorIs(t, err, logs.ErrUnparseable, "line %q", line)
+	}
+
+	_, err := logs.ParseLine("level=INFO", "xml")
+	assert.Error(t, err)
+}
+
+func TestRead_LevelFilter(t *testing.T) {
+	for _, tc := range []struct{ file, format string }{
+		{"dot.log", logs.FormatText},
+		{"dot.json.log", logs.FormatJSON},
+	} {
+		t.Run(tc.format, func(t *testing.T) {
+			f, err := os.Open(filepath.Join("testdata", tc.file))
+			require.NoError(t, err)
+			defer f.Close()
+
+			entries, err := logs.Read(f, tc.format, logs.Filter{MinLevel: slog.LevelWarn})
+			require.NoError(t, err)
+
+			require.Len(t, entries, 2)
+			assert.Equal(t, "file exists", entries[0].Message)
+			assert.Equal(t, "operation_failed", entries[1].Message)
+		})
+	}
+}
+
+func TestRead_AllLevelsSkipsGarbage(t *testing.T) {
+	f, err := os.Open(filepath.Join("testdata", "dot.log"))
+	require.NoError(t, err)
+	defer f.Close()
+
+	entries, err := logs.Read(f, logs.FormatText, logs.Filter{MinLevel: slog.LevelDebug})
+	require.NoError(t, err)
+	assert.Len(t, entries, 5)
+}
+
+func TestRead_SinceFilter(t *testing.T) {
+	f, err := os.Open(filepath.Join("testdata", "dot.json.log"))
+	require.NoError(t, err)
+	defer f.Close()
+
+	since := time.Date(2026, 1, 10, 10, 0, 0, 0, time.UTC)
+	entries, err := logs.Read(f, logs.FormatJSON, logs.Filter{MinLevel: slog.LevelDebug, Since: since})
+	require.NoError(t, err)
+
+	require.Len(t, entries, 2)
+	assert.Equal(t, "operation_failed", entries[0].Message)
+	assert.Equal(t, "manage_complete", entries[1].Message)
+}
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+
+	got, err := logs.ParseSince("2h", now)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(-2*time.Hour), got)
+
+	got, err = logs.ParseSince("2026-01-09T08:00:00Z", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 1, 9, 8, 0, 0, 0, time.UTC), got)
+
+	got, err = logs.ParseSince("2026-01-09", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 1, 9, 0, 0, 0, 0, time.UTC), got)
+
+	got, err = logs.ParseSince("", now)
+	require.NoError(t, err)
+	assert.True(t, got.IsZero())
+
+	_, err = logs.ParseSince("yesterday", now)
+	assert.Error(t, err)
+}
+
+// syncBuffer is a bytes.Buffer safe for concurrent reads and writes.
+type syncBuffer struct {
+	mu  chan struct{}
+	buf bytes.Buffer
+}
+
+func newSyncBuffer() *syncBuffer { return &syncBuffer{mu: make(chan struct{}, 1)} }
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu <- struct{}{}
+	defer func() { <-b.mu }()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu <- struct{}{}
+	defer func() { <-b.mu }()
+	return b.buf.String()
+}
+
+func TestFollow_StreamsAppendedEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dot.log")
+	require.NoError(t, os.WriteFile(path, []byte("level=INFO msg=old\n"), 0600))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	out := newSyncBuffer()
+	done := make(chan error, 1)
+	go func() {
+		done <- logs.Follow(ctx, path, info.Size(), logs.FormatText, logs.Filter{MinLevel: slog.LevelWarn}, out, 10*time.Millisecond)
+	}()
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	require.NoError(t, err)
+	_, err = f.WriteString("level=DEBUG msg=hidden\nlevel=ERROR msg=shown\nlevel=WARN msg=par")
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool { return strings.Contains(out.String(), "msg=shown") }, time.Second, 10*time.Millisecond)
+
+	// Partial line is emitted once completed
+	_, err = f.WriteString("tial\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	require.Eventually(t, func() bool { return strings.Contains(out.String(), "msg=partial") }, time.Second, 10*time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-done)
+
+	assert.NotContains(t, out.String(), "msg=old")
+	assert.NotContains(t, out.String(), "msg=hidden")
+}
+
+func TestFollow_DropsPartialLineOnTruncation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dot.log")
+	require.NoError(t, os.WriteFile(path, []byte("level=WARN msg=first\nlevel=WARN msg=par"), 0600))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	out := newSyncBuffer()
+	done := make(chan error, 1)
+	go func() {
+		done <- logs.Follow(ctx, path, 0, logs.FormatText, logs.Filter{}, out, 10*time.Millisecond)
+	}()
+	require.Eventually(t, func() bool { return strings.Contains(out.String(), "msg=first") }, time.Second, 10*time.Millisecond)
+
+	// Rotation leaves a shorter file in place of the old one
+	require.NoError(t, os.WriteFile(path, []byte("level=ERROR msg=new\n"), 0600))
+	require.Eventually(t, func() bool { return strings.Contains(out.String(), "msg=new") }, time.Second, 10*time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-done)
+
+	assert.Equal(t, "level=WARN msg=first\nlevel=ERROR msg=new\n", out.String())
+}
@@ -17,10 +17,11 @@ type Formatter struct {
 	writer    io.Writer
 }
 
-// NewFormatter creates a formatter with the given colorization setting.
-func NewFormatter(w io.Writer, colorEnabled bool) *Formatter {
+// NewFormatter creates a formatter with the given colorization setting and
+// color theme.
+func NewFormatter(w io.Writer, colorEnabled bool, theme render.Theme) *Formatter {
 	return &Formatter{
-		colorizer: render.NewColorizer(colorEnabled),
+		colorizer: render.NewColorizer(colorEnabled, theme),
 		writer:    w,
 	}
 }
@@ -30,7 +31,7 @@ func NewFormatter(w io.Writer, colorEnabled bool) *Formatter {
 // Example: "✓ Managed 2 packages"
 func (f *Formatter) Success(verb string, count int, singular, plural string) {
 	verb = cases.Title(language.English).String(verb)
-	itemText := pluralize(count, singular, plural)
+	itemText := Pluralize(count, singular, plural)
 	fmt.Fprintf(f.writer, "%s %s %d %s\n",
 		f.colorizer.Success("✓"),
 		verb,
@@ -112,8 +113,8 @@ func (f *Formatter) Indent(level int, text string) {
 	fmt.Fprintf(f.writer, "%s%s\n", indent, text)
 }
 
-// pluralize returns the singular or plural form based on count.
-func pluralize(count int, singular, plural string) string {
+// Pluralize returns the singular or plural form based on count.
+func Pluralize(count int, singular, plural string) string {
 	if count == 1 {
 		return singular
 	}
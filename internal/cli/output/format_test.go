@@ -6,6 +6,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/yaklabco/dot/internal/cli/render"
 )
 
 func TestFormatter_Success(t *testing.T) {
@@ -46,7 +48,7 @@ func TestFormatter_Success(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			var buf bytes.Buffer
-			f := NewFormatter(&buf, false) // Disable colors for testing
+			f := NewFormatter(&buf, false, render.DefaultTheme()) // Disable colors for testing
 
 			f.Success(tt.verb, tt.count, tt.singular, tt.plural)
 
@@ -59,7 +61,7 @@ func TestFormatter_Success(t *testing.T) {
 
 func TestFormatter_SuccessSimple(t *testing.T) {
 	var buf bytes.Buffer
-	f := NewFormatter(&buf, false)
+	f := NewFormatter(&buf, false, render.DefaultTheme())
 
 	f.SuccessSimple("Upgrade completed")
 
@@ -70,7 +72,7 @@ func TestFormatter_SuccessSimple(t *testing.T) {
 
 func TestFormatter_Error(t *testing.T) {
 	var buf bytes.Buffer
-	f := NewFormatter(&buf, false)
+	f := NewFormatter(&buf, false, render.DefaultTheme())
 
 	f.Error("Operation failed")
 
@@ -81,7 +83,7 @@ func TestFormatter_Error(t *testing.T) {
 
 func TestFormatter_Warning(t *testing.T) {
 	var buf bytes.Buffer
-	f := NewFormatter(&buf, false)
+	f := NewFormatter(&buf, false, render.DefaultTheme())
 
 	f.Warning("Potential issue detected")
 
@@ -92,7 +94,7 @@ func TestFormatter_Warning(t *testing.T) {
 
 func TestFormatter_Info(t *testing.T) {
 	var buf bytes.Buffer
-	f := NewFormatter(&buf, false)
+	f := NewFormatter(&buf, false, render.DefaultTheme())
 
 	f.Info("Additional information")
 
@@ -103,7 +105,7 @@ func TestFormatter_Info(t *testing.T) {
 
 func TestFormatter_Bullet(t *testing.T) {
 	var buf bytes.Buffer
-	f := NewFormatter(&buf, false)
+	f := NewFormatter(&buf, false, render.DefaultTheme())
 
 	f.Bullet("Item one")
 
@@ -115,7 +117,7 @@ func TestFormatter_Bullet(t *testing.T) {
 
 func TestFormatter_BulletWithDetail(t *testing.T) {
 	var buf bytes.Buffer
-	f := NewFormatter(&buf, false)
+	f := NewFormatter(&buf, false, render.DefaultTheme())
 
 	f.BulletWithDetail("main text", "detail text")
 
@@ -128,7 +130,7 @@ func TestFormatter_BulletWithDetail(t *testing.T) {
 
 func TestFormatter_Header(t *testing.T) {
 	var buf bytes.Buffer
-	f := NewFormatter(&buf, false)
+	f := NewFormatter(&buf, false, render.DefaultTheme())
 
 	f.Header("Section Title")
 
@@ -138,7 +140,7 @@ func TestFormatter_Header(t *testing.T) {
 
 func TestFormatter_WithColors(t *testing.T) {
 	var buf bytes.Buffer
-	f := NewFormatter(&buf, true) // Enable colors
+	f := NewFormatter(&buf, true, render.DefaultTheme()) // Enable colors
 
 	f.Success("managed", 2, "package", "packages")
 
@@ -179,7 +181,7 @@ func TestFormatter_Indent(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			var buf bytes.Buffer
-			f := NewFormatter(&buf, false)
+			f := NewFormatter(&buf, false, render.DefaultTheme())
 
 			f.Indent(tt.level, tt.text)
 
@@ -222,7 +224,7 @@ func TestPluralize(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := pluralize(tt.count, tt.singular, tt.plural)
+			got := Pluralize(tt.count, tt.singular, tt.plural)
 			assert.Equal(t, tt.want, got)
 		})
 	}
@@ -230,7 +232,7 @@ func TestPluralize(t *testing.T) {
 
 func TestFormatter_BlankLine(t *testing.T) {
 	var buf bytes.Buffer
-	f := NewFormatter(&buf, false)
+	f := NewFormatter(&buf, false, render.DefaultTheme())
 
 	f.BlankLine()
 
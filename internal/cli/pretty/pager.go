@@ -4,10 +4,13 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"slices"
 	"strings"
+	"unicode/utf8"
 
 	"golang.org/x/term"
 
+	"github.com/yaklabco/dot/internal/cli/render"
 	"github.com/yaklabco/dot/internal/cli/terminal"
 )
 
@@ -15,6 +18,8 @@ import (
 type Pager struct {
 	output   io.Writer
 	pageSize int
+	width    int
+	noWrap   bool
 }
 
 // PagerConfig holds configuration for the pager.
@@ -23,6 +28,12 @@ type PagerConfig struct {
 	PageSize int
 	// Output is where paginated content is written
 	Output io.Writer
+	// Width is the width lines wrap at and the status line separators
+	// fill (0 = COLUMNS or terminal width)
+	Width int
+	// NoWrap starts paging with long lines unwrapped; w toggles wrapping
+	// while paging either way
+	NoWrap bool
 }
 
 // DefaultPagerConfig returns sensible defaults for pagination.
@@ -48,12 +59,15 @@ func NewPager(config PagerConfig) *Pager {
 	return &Pager{
 		output:   config.Output,
 		pageSize: pageSize,
+		width:    terminal.Width(config.Width),
+		noWrap:   config.NoWrap,
 	}
 }
 
 // Page displays content with pagination if in an interactive terminal.
 // If not interactive (piped or redirected), content is displayed without pagination.
-// Supports spacebar/Enter for next page, up/down arrows for line scrolling, and 'q' to quit.
+// Supports spacebar/Enter for next page, up/down arrows for line scrolling, 'w' to
+// toggle wrapping of long lines, and 'q' to quit.
 func (p *Pager) Page(content string) error {
 	lines := strings.Split(content, "\n")
 
@@ -63,8 +77,10 @@ func (p *Pager) Page(content string) error {
 		return err
 	}
 
-	// If content fits on screen, just print it
-	if len(lines) <= p.pageSize {
+	// If content fits on screen, just print it; the terminal wraps any
+	// long lines
+	wrap := !p.noWrap && p.hasWideLines(lines)
+	if screen, _ := p.layout(lines, wrap); len(screen) <= p.pageSize {
 		_, err := fmt.Fprint(p.output, content)
 		return err
 	}
@@ -73,8 +89,39 @@ func (p *Pager) Page(content string) error {
 	return p.pageInteractive(lines)
 }
 
+// hasWideLines reports whether any line is wider than the pager.
+func (p *Pager) hasWideLines(lines []string) bool {
+	for _, line := range lines {
+		if render.VisibleWidth(line) > p.width {
+			return true
+		}
+	}
+	return false
+}
+
+// layout returns the screen lines for lines, soft-wrapped to the pager
+// width when wrap is set, and the index in lines each one comes from.
+func (p *Pager) layout(lines []string, wrap bool) (screen []string, origin []int) {
+	for i, line := range lines {
+		parts := []string{line}
+		if wrap {
+			parts = render.WrapANSI(line, p.width)
+		}
+		for _, part := range parts {
+			screen = append(screen, part)
+			origin = append(origin, i)
+		}
+	}
+	return screen, origin
+}
+
 // pageInteractive handles interactive pagination with keyboard controls.
-func (p *Pager) pageInteractive(lines []string) error {
+// Lines wider than the pager are soft-wrapped, so that every screen line
+// counts toward the page, unless wrapping is off; w toggles it.
+func (p *Pager) pageInteractive(source []string) error {
+	wideLines := p.hasWideLines(source)
+	wrap := !p.noWrap && wideLines
+	lines, origin := p.layout(source, wrap)
 	position := 0
 	maxPos := len(lines)
 
@@ -92,7 +139,7 @@ func (p *Pager) pageInteractive(lines []string) error {
 		// Show status line
 		remaining := maxPos - end
 		if remaining > 0 {
-			p.showStatusLine(position, end, maxPos)
+			p.showStatusLine(position, end, maxPos, wideLines)
 
 			// Get next action from user
 			action := p.getKeyPress()
@@ -117,6 +164,15 @@ func (p *Pager) pageInteractive(lines []string) error {
 					position--
 				}
 				// If can't scroll up, just stay at current position
+			case actionToggleWrap:
+				if wideLines {
+					// Keep the line at the top of the screen in view
+					top := origin[position]
+					wrap = !wrap
+					lines, origin = p.layout(source, wrap)
+					maxPos = len(lines)
+					position = slices.Index(origin, top)
+				}
 			}
 		} else {
 			// Last page, just display and exit
@@ -136,6 +192,7 @@ const (
 	actionPageDown
 	actionLineUp
 	actionLineDown
+	actionToggleWrap
 )
 
 // clearStatusLine clears the status line without leaving blank lines.
@@ -150,20 +207,36 @@ func (p *Pager) clearStatusLine() {
 	fmt.Fprint(p.output, "\n")
 }
 
-// showStatusLine displays the pagination status and controls hint.
-func (p *Pager) showStatusLine(start, end, total int) {
+// showStatusLine displays the pagination status and controls hint. The
+// wrap toggle is offered only when canWrap is set.
+func (p *Pager) showStatusLine(start, end, total int, canWrap bool) {
 	percent := (end * 100) / total
-	status := fmt.Sprintf("\n\n%s [%d-%d/%d %d%%] Space/Enter: page down | ↑↓: scroll | q: quit %s",
-		Dim("───"),
+	wrapHint := ""
+	if canWrap {
+		wrapHint = " | w: wrap"
+	}
+	text := fmt.Sprintf(" [%d-%d/%d %d%%] Space/Enter: page down | ↑↓: scroll%s | q: quit ",
 		start+1,
 		end,
 		total,
 		percent,
-		Dim("───"),
+		wrapHint,
 	)
+	left, right := separatorWidths(p.width, utf8.RuneCountInString(text))
+	status := fmt.Sprintf("\n\n%s%s%s", Dim(strings.Repeat("─", left)), text, Dim(strings.Repeat("─", right)))
 	fmt.Fprint(p.output, status)
 }
 
+// separatorWidths splits the room left on a line of width around text of
+// length n between the separators either side of it, keeping at least
+// three characters for each.
+func separatorWidths(width, n int) (left, right int) {
+	room := width - n
+	left = max(room/2, 3)
+	right = max(room-room/2, 3)
+	return left, right
+}
+
 // getKeyPress reads a single keypress from stdin in raw mode.
 func (p *Pager) getKeyPress() pagerAction {
 	// Get file descriptor for stdin
@@ -199,6 +272,9 @@ func (p *Pager) getKeyPress() pagerAction {
 	if input[0] == ' ' || input[0] == '\r' || input[0] == '\n' {
 		return actionPageDown
 	}
+	if input[0] == 'w' || input[0] == 'W' {
+		return actionToggleWrap
+	}
 
 	// Handle arrow key escape sequences: ESC [ [A-D]
 	if len(input) >= 3 && input[0] == 27 && input[1] == 91 {
@@ -2,8 +2,10 @@ package pretty
 
 import (
 	"bytes"
+	"regexp"
 	"strings"
 	"testing"
+	"unicode/utf8"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -312,7 +314,7 @@ func TestPager_showStatusLine(t *testing.T) {
 	pager := NewPager(config)
 
 	// Test showStatusLine output
-	pager.showStatusLine(0, 50, 100)
+	pager.showStatusLine(0, 50, 100, false)
 
 	output := buf.String()
 	// Should contain start, end, total
@@ -324,6 +326,72 @@ func TestPager_showStatusLine(t *testing.T) {
 	assert.Contains(t, output, "q: quit")
 }
 
+// ansiPattern matches the color codes Dim wraps separators in.
+var ansiPattern = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+func TestPager_showStatusLine_Width(t *testing.T) {
+	for _, width := range []int{100, 40} {
+		var buf bytes.Buffer
+		pager := NewPager(PagerConfig{PageSize: 10, Output: &buf, Width: width})
+
+		pager.showStatusLine(0, 50, 100, false)
+
+		line := strings.TrimLeft(ansiPattern.ReplaceAllString(buf.String(), ""), "\n")
+		assert.True(t, strings.HasPrefix(line, "───"), "line %q", line)
+		assert.True(t, strings.HasSuffix(line, "───"), "line %q", line)
+		if width == 100 {
+			assert.Equal(t, width, utf8.RuneCountInString(line), "separators fill the width")
+		}
+	}
+}
+
+func TestPager_showStatusLine_WrapHint(t *testing.T) {
+	var buf bytes.Buffer
+	pager := NewPager(PagerConfig{PageSize: 10, Output: &buf})
+
+	pager.showStatusLine(0, 50, 100, false)
+	assert.NotContains(t, buf.String(), "w: wrap")
+
+	buf.Reset()
+	pager.showStatusLine(0, 50, 100, true)
+	assert.Contains(t, buf.String(), "w: wrap")
+}
+
+func TestPager_layout(t *testing.T) {
+	pager := NewPager(PagerConfig{PageSize: 10, Output: &bytes.Buffer{}, Width: 10})
+	colored := "\033[31m" + strings.Repeat("x", 15) + "\033[0m"
+	lines := []string{"short", strings.Repeat("a", 25), colored}
+
+	assert.True(t, pager.hasWideLines(lines))
+	assert.False(t, pager.hasWideLines([]string{"short", "\033[31m0123456789\033[0m"}),
+		"color codes do not count toward the width")
+
+	screen, origin := pager.layout(lines, true)
+	require.Len(t, screen, 6)
+	assert.Equal(t, []int{0, 1, 1, 1, 2, 2}, origin)
+	for _, line := range screen {
+		assert.LessOrEqual(t, utf8.RuneCountInString(ansiPattern.ReplaceAllString(line, "")), 10)
+	}
+
+	screen, origin = pager.layout(lines, false)
+	assert.Equal(t, lines, screen)
+	assert.Equal(t, []int{0, 1, 2}, origin)
+}
+
+func TestPager_pageInteractive_WrapsLongLines(t *testing.T) {
+	var buf bytes.Buffer
+	pager := NewPager(PagerConfig{PageSize: 5, Output: &buf, Width: 10})
+
+	// Two lines that wrap to four screen lines fit on one page
+	require.NoError(t, pager.pageInteractive([]string{strings.Repeat("a", 20), strings.Repeat("b", 20)}))
+	assert.Equal(t, "aaaaaaaaaa\naaaaaaaaaa\nbbbbbbbbbb\nbbbbbbbbbb\n", buf.String())
+
+	buf.Reset()
+	pager = NewPager(PagerConfig{PageSize: 5, Output: &buf, Width: 10, NoWrap: true})
+	require.NoError(t, pager.pageInteractive([]string{strings.Repeat("a", 20)}))
+	assert.Equal(t, strings.Repeat("a", 20)+"\n", buf.String())
+}
+
 func TestPager_clearStatusLine(t *testing.T) {
 	var buf bytes.Buffer
 	config := PagerConfig{
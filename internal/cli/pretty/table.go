@@ -185,9 +185,9 @@ func (w *TableWriter) RenderString() string {
 
 // ShouldUseColor determines if color output should be enabled.
 func ShouldUseColor() bool {
-	// Check NO_COLOR environment variable
-	if os.Getenv("NO_COLOR") != "" {
-		return false
+	// FORCE_COLOR, CLICOLOR_FORCE and NO_COLOR override detection
+	if enabled, ok := terminal.ColorFromEnv(); ok {
+		return enabled
 	}
 
 	// Check if stdout is a terminal
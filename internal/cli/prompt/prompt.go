@@ -3,11 +3,38 @@ package prompt
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"io"
 	"strings"
 )
 
+// assumeYesKey is the context key for the assume-yes setting.
+type assumeYesKey struct{}
+
+// WithAssumeYes returns a context in which Confirm accepts every
+// confirmation without prompting, as set by the global --yes flag.
+func WithAssumeYes(ctx context.Context, yes bool) context.Context {
+	return context.WithValue(ctx, assumeYesKey{}, yes)
+}
+
+// AssumeYes reports whether confirmations in ctx are accepted without
+// prompting.
+func AssumeYes(ctx context.Context) bool {
+	yes, _ := ctx.Value(assumeYesKey{}).(bool)
+	return yes
+}
+
+// Confirm asks a yes/no question on out and reads the answer from in,
+// returning defaultYes when the user just presses enter or input ends.
+// When ctx assumes yes, it returns true without prompting or reading in.
+func Confirm(ctx context.Context, in io.Reader, out io.Writer, message string, defaultYes bool) (bool, error) {
+	if AssumeYes(ctx) {
+		return true, nil
+	}
+	return New(in, out).ConfirmWithDefault(message, defaultYes)
+}
+
 // Prompter handles interactive user prompts.
 type Prompter struct {
 	in  io.Reader
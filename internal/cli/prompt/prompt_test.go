@@ -2,6 +2,8 @@ package prompt
 
 import (
 	"bytes"
+	"context"
+	"io"
 	"strings"
 	"testing"
 
@@ -269,3 +271,54 @@ func TestSelect_EOF(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, -1, result)
 }
+
+// failingReader fails the test if anything reads from it.
+type failingReader struct{ t *testing.T }
+
+func (r failingReader) Read([]byte) (int, error) {
+	r.t.Error("unexpected read from stdin")
+	return 0, io.EOF
+}
+
+func TestAssumeYes(t *testing.T) {
+	ctx := context.Background()
+	assert.False(t, AssumeYes(ctx))
+	assert.True(t, AssumeYes(WithAssumeYes(ctx, true)))
+	assert.False(t, AssumeYes(WithAssumeYes(ctx, false)))
+}
+
+func TestConfirmFunc_AssumeYes(t *testing.T) {
+	for _, defaultYes := range []bool{true, false} {
+		var out bytes.Buffer
+		ctx := WithAssumeYes(context.Background(), true)
+
+		confirmed, err := Confirm(ctx, failingReader{t}, &out, "Proceed?", defaultYes)
+		require.NoError(t, err)
+		assert.True(t, confirmed)
+		assert.Empty(t, out.String(), "no prompt should be shown")
+	}
+}
+
+func TestConfirmFunc_Prompts(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		defaultYes bool
+		expected   bool
+		prompt     string
+	}{
+		{name: "yes", input: "y\n", expected: true, prompt: "Proceed? [y/N]: "},
+		{name: "no", input: "n\n", defaultYes: true, expected: false, prompt: "Proceed? [Y/n]: "},
+		{name: "empty uses default", input: "\n", defaultYes: true, expected: true, prompt: "Proceed? [Y/n]: "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			confirmed, err := Confirm(context.Background(), strings.NewReader(tt.input), &out, "Proceed?", tt.defaultYes)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, confirmed)
+			assert.Equal(t, tt.prompt, out.String())
+		})
+	}
+}
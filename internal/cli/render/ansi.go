@@ -0,0 +1,110 @@
+package render
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// ansiReset ends every active SGR attribute.
+const ansiReset = "\033[0m"
+
+// StripANSI removes ANSI escape codes from s, leaving the text a terminal
+// displays.
+func StripANSI(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for i := 0; i < len(s); {
+		if n := escapeLen(s[i:]); n > 0 {
+			i += n
+			continue
+		}
+		b.WriteByte(s[i])
+		i++
+	}
+	return b.String()
+}
+
+// VisibleWidth returns the number of characters s displays, not counting
+// ANSI escape codes.
+func VisibleWidth(s string) int {
+	return utf8.RuneCountInString(StripANSI(s))
+}
+
+// TruncateWithANSI truncates s to width visible characters. Escape codes
+// are copied without counting toward the width.
+func TruncateWithANSI(s string, width int) string {
+	var b strings.Builder
+	visible := 0
+	for i := 0; i < len(s); {
+		if n := escapeLen(s[i:]); n > 0 {
+			b.WriteString(s[i : i+n])
+			i += n
+			continue
+		}
+		if visible >= width {
+			break
+		}
+		_, size := utf8.DecodeRuneInString(s[i:])
+		b.WriteString(s[i : i+size])
+		i += size
+		visible++
+	}
+	return b.String()
+}
+
+// WrapANSI splits s into lines of at most width visible characters,
+// breaking at character boundaries. Escape codes do not count toward the
+// width, and color active at a break is reset at the end of the line and
+// restored at the start of the next, so each line renders on its own.
+func WrapANSI(s string, width int) []string {
+	if width <= 0 || VisibleWidth(s) <= width {
+		return []string{s}
+	}
+
+	var lines []string
+	var line strings.Builder
+	var active string // escape codes in effect since the last reset
+	visible := 0
+	for i := 0; i < len(s); {
+		if n := escapeLen(s[i:]); n > 0 {
+			code := s[i : i+n]
+			if code == ansiReset || code == "\033[m" {
+				active = ""
+			} else {
+				active += code
+			}
+			line.WriteString(code)
+			i += n
+			continue
+		}
+		if visible == width {
+			if active != "" {
+				line.WriteString(ansiReset)
+			}
+			lines = append(lines, line.String())
+			line.Reset()
+			line.WriteString(active)
+			visible = 0
+		}
+		_, size := utf8.DecodeRuneInString(s[i:])
+		line.WriteString(s[i : i+size])
+		i += size
+		visible++
+	}
+	return append(lines, line.String())
+}
+
+// escapeLen returns the length of the ANSI escape code at the start of s,
+// or 0 if s does not start with one. Only CSI sequences (ESC [ ... final
+// byte) are recognized, which covers colors and cursor movement.
+func escapeLen(s string) int {
+	if len(s) < 2 || s[0] != '\033' || s[1] != '[' {
+		return 0
+	}
+	for i := 2; i < len(s); i++ {
+		if s[i] >= 0x40 && s[i] <= 0x7e {
+			return i + 1
+		}
+	}
+	return len(s)
+}
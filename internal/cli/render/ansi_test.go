@@ -0,0 +1,77 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVisibleWidth(t *testing.T) {
+	assert.Equal(t, 5, VisibleWidth("hello"))
+	assert.Equal(t, 5, VisibleWidth("\033[38;5;71mhello\033[0m"))
+	assert.Equal(t, 3, VisibleWidth("→é✓"))
+	assert.Equal(t, 0, VisibleWidth(""))
+}
+
+func TestTruncateWithANSI(t *testing.T) {
+	assert.Equal(t, "hel", TruncateWithANSI("hello", 3))
+	assert.Equal(t, "\033[1mhel", TruncateWithANSI("\033[1mhello\033[0m", 3))
+	assert.Equal(t, "→é", TruncateWithANSI("→é✓", 2))
+	assert.Equal(t, "hi", TruncateWithANSI("hi", 10))
+}
+
+func TestWrapANSI(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		width int
+		want  []string
+	}{
+		{
+			name:  "fits",
+			input: "short",
+			width: 10,
+			want:  []string{"short"},
+		},
+		{
+			name:  "plain",
+			input: "abcdefghij",
+			width: 4,
+			want:  []string{"abcd", "efgh", "ij"},
+		},
+		{
+			name:  "color codes do not count",
+			input: "\033[31mabcd\033[0mef",
+			width: 4,
+			want:  []string{"\033[31mabcd\033[0m", "ef"},
+		},
+		{
+			name:  "color carries across a break",
+			input: "\033[31mabcdef\033[0m",
+			width: 4,
+			want:  []string{"\033[31mabcd\033[0m", "\033[31mef\033[0m"},
+		},
+		{
+			name:  "multibyte characters",
+			input: "→→→→→",
+			width: 2,
+			want:  []string{"→→", "→→", "→"},
+		},
+		{
+			name:  "zero width leaves the line",
+			input: "abcdef",
+			width: 0,
+			want:  []string{"abcdef"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := WrapANSI(tt.input, tt.width)
+			assert.Equal(t, tt.want, got)
+			for _, line := range got {
+				assert.LessOrEqual(t, VisibleWidth(line), max(tt.width, VisibleWidth(tt.input)))
+			}
+		})
+	}
+}
@@ -22,6 +22,8 @@ type ColorScheme struct {
 	Info    Color
 	Dim     Color
 	Accent  Color
+	// Heading is combined with bold; see Colorizer.Bold.
+	Heading Color
 }
 
 // Predefined colors using 256-color ANSI codes for consistent muted professional palette.
@@ -54,6 +56,7 @@ var (
 		Info:    Color{ANSI: ""},
 		Dim:     Color{ANSI: ""},
 		Accent:  Color{ANSI: ""},
+		Heading: Color{ANSI: ""},
 	}
 )
 
@@ -67,9 +70,9 @@ func (c Color) Apply(text string) string {
 
 // ShouldUseColor determines if color output should be enabled.
 func ShouldUseColor() bool {
-	// Check NO_COLOR environment variable
-	if os.Getenv("NO_COLOR") != "" {
-		return false
+	// FORCE_COLOR, CLICOLOR_FORCE and NO_COLOR override detection
+	if enabled, ok := terminal.ColorFromEnv(); ok {
+		return enabled
 	}
 
 	// Check if stdout is a terminal
@@ -105,11 +108,12 @@ type Colorizer struct {
 	scheme  ColorScheme
 }
 
-// NewColorizer creates a colorizer with the appropriate scheme.
-func NewColorizer(enabled bool) *Colorizer {
+// NewColorizer creates a colorizer using the palette of theme. When
+// enabled is false all output is plain regardless of theme.
+func NewColorizer(enabled bool, theme Theme) *Colorizer {
 	scheme := NoColorScheme
 	if enabled {
-		scheme = DefaultScheme
+		scheme = theme.Scheme()
 	}
 	return &Colorizer{
 		enabled: enabled,
@@ -117,40 +121,41 @@ func NewColorizer(enabled bool) *Colorizer {
 	}
 }
 
-// Success formats text with success color (muted green).
+// Success formats text with success color.
 func (c *Colorizer) Success(text string) string {
 	return c.scheme.Success.Apply(text)
 }
 
-// Warning formats text with warning color (muted gold).
+// Warning formats text with warning color.
 func (c *Colorizer) Warning(text string) string {
 	return c.scheme.Warning.Apply(text)
 }
 
-// Error formats text with error color (muted red).
+// Error formats text with error color.
 func (c *Colorizer) Error(text string) string {
 	return c.scheme.Error.Apply(text)
 }
 
-// Info formats text with info color (muted blue).
+// Info formats text with info color.
 func (c *Colorizer) Info(text string) string {
 	return c.scheme.Info.Apply(text)
 }
 
-// Dim formats text with dim color (muted gray).
+// Dim formats text with dim color.
 func (c *Colorizer) Dim(text string) string {
 	return c.scheme.Dim.Apply(text)
 }
 
-// Accent formats text with accent color (dark blue/purple).
+// Accent formats text with accent color.
 func (c *Colorizer) Accent(text string) string {
 	return c.scheme.Accent.Apply(text)
 }
 
-// Bold formats text with bold styling.
+// Bold formats text with bold styling, in the heading color if the theme
+// sets one.
 func (c *Colorizer) Bold(text string) string {
 	if !c.enabled {
 		return text
 	}
-	return colorBold + text + colorReset
+	return colorBold + c.scheme.Heading.ANSI + text + colorReset
 }
@@ -82,7 +82,7 @@ func TestColorizer(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			c := NewColorizer(tt.enabled)
+			c := NewColorizer(tt.enabled, DefaultTheme())
 			result := tt.wantFunc(c, tt.text)
 
 			if tt.enabled {
@@ -4,7 +4,7 @@ import (
 	"fmt"
 	"strings"
 
-	"golang.org/x/term"
+	"github.com/yaklabco/dot/internal/cli/terminal"
 )
 
 // Layout provides text layout utilities.
@@ -17,13 +17,10 @@ func NewLayout(width int) *Layout {
 	return &Layout{width: width}
 }
 
-// NewLayoutAuto creates a layout with automatic width detection.
+// NewLayoutAuto creates a layout with automatic width detection from
+// COLUMNS or the terminal.
 func NewLayoutAuto() *Layout {
-	width := 80 // Default
-	if w, _, err := term.GetSize(0); err == nil && w > 0 {
-		width = w
-	}
-	return &Layout{width: width}
+	return &Layout{width: terminal.Width(0)}
 }
 
 // Width returns the layout width.
@@ -54,7 +51,7 @@ func (l *Layout) Box(text string, title string) string {
 	maxLen := len(title) + 4
 
 	for _, line := range lines {
-		lineLen := stripANSI(line)
+		lineLen := StripANSI(line)
 		if len(lineLen) > maxLen {
 			maxLen = len(lineLen)
 		}
@@ -84,7 +81,7 @@ func (l *Layout) Box(text string, title string) string {
 		b.WriteString("│ ")
 		b.WriteString(line)
 		// Pad to max length (accounting for ANSI codes)
-		plainLen := len(stripANSI(line))
+		plainLen := len(StripANSI(line))
 		if plainLen < maxLen {
 			b.WriteString(strings.Repeat(" ", maxLen-plainLen))
 		}
@@ -125,7 +122,7 @@ func (l *Layout) calculateColumnWidths(headers []string, rows [][]string) []int
 	for _, row := range rows {
 		for i, cell := range row {
 			if i < len(colWidths) {
-				cellLen := len(stripANSI(cell))
+				cellLen := len(StripANSI(cell))
 				if cellLen > colWidths[i] {
 					colWidths[i] = cellLen
 				}
@@ -197,7 +194,7 @@ func (l *Layout) writeTableRows(b *strings.Builder, rows [][]string, colWidths [
 }
 
 func (l *Layout) writeTableCell(b *strings.Builder, cell string, width int) {
-	plainCell := stripANSI(cell)
+	plainCell := StripANSI(cell)
 	padding := width - len(plainCell)
 	b.WriteString(cell)
 	if padding > 0 {
@@ -248,35 +245,13 @@ func wrapText(text string, width, indent int) string {
 
 // padRight pads string to width (right-aligned).
 func padRight(s string, width int) string {
-	sLen := len(stripANSI(s))
+	sLen := len(StripANSI(s))
 	if sLen >= width {
 		return s
 	}
 	return s + strings.Repeat(" ", width-sLen)
 }
 
-// stripANSI removes ANSI escape codes from string for length calculation.
-func stripANSI(s string) string {
-	// Simple ANSI stripping - matches \033[...m patterns
-	result := ""
-	inEscape := false
-	for i := 0; i < len(s); i++ {
-		if s[i] == '\033' && i+1 < len(s) && s[i+1] == '[' {
-			inEscape = true
-			i++ // Skip the '['
-			continue
-		}
-		if inEscape {
-			if s[i] == 'm' {
-				inEscape = false
-			}
-			continue
-		}
-		result += string(s[i])
-	}
-	return result
-}
-
 // Divider returns a horizontal divider line.
 func (l *Layout) Divider(char string) string {
 	if char == "" {
@@ -287,7 +262,7 @@ func (l *Layout) Divider(char string) string {
 
 // Center centers text within the layout width.
 func (l *Layout) Center(text string) string {
-	textLen := len(stripANSI(text))
+	textLen := len(StripANSI(text))
 	if textLen >= l.width {
 		return text
 	}
@@ -203,7 +203,7 @@ func TestStripANSI(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := stripANSI(tt.input)
+			result := StripANSI(tt.input)
 			assert.Equal(t, tt.expected, result)
 		})
 	}
@@ -0,0 +1,190 @@
+package render
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// spanSpace stands in for spaces inside inline spans while wrapping, so a
+// code span such as `dot doctor --fix` is never split across lines. It must
+// not be a Unicode space, which strings.Fields would split on.
+const spanSpace = "\x1f"
+
+var (
+	bulletItem   = regexp.MustCompile(`^[-*]\s+(.*)$`)
+	numberedItem = regexp.MustCompile(`^(\d+)\.\s+(.*)$`)
+	inlineSpan   = regexp.MustCompile("`[^`]+`|\\*\\*[^*]+\\*\\*")
+)
+
+// listLevel is a list open at one nesting depth.
+type listLevel struct {
+	// indent is the source indentation of the level's markers.
+	indent  int
+	ordered bool
+	// next is the number of the next item of an ordered list.
+	next int
+	// content is the rendered column the level's item text starts at.
+	content int
+}
+
+// Markdown renders a small subset of Markdown for the terminal: "#" and
+// "##" headings, paragraphs, "-" and numbered lists, fenced code blocks,
+// and inline `code` and **bold** spans. Text is wrapped to the layout
+// width; code blocks are indented and never wrapped.
+//
+// A list item indented further than the item before it starts a nested
+// list, which may mix numbered and bulleted items. A numbered list keeps
+// its first number and counts on from it. A blank line, heading or code
+// block ends every open list.
+func (l *Layout) Markdown(src string, c *Colorizer) string {
+	var out []string
+
+	// The paragraph or list item being collected; continuation lines
+	// join it until a blank line or a new block starts.
+	var block []string
+	var prefix, styledPrefix string
+
+	// The open lists, outermost first
+	var lists []listLevel
+
+	emit := func(line string) {
+		// Collapse runs of blank lines
+		if line == "" && (len(out) == 0 || out[len(out)-1] == "") {
+			return
+		}
+		out = append(out, line)
+	}
+	flush := func() {
+		if len(block) > 0 {
+			emit(l.inlineBlock(strings.Join(block, " "), prefix, styledPrefix, c))
+			block, prefix, styledPrefix = nil, "", ""
+		}
+	}
+	endLists := func() {
+		flush()
+		lists = nil
+	}
+	startItem := func(indent int, ordered bool, number int, text string) {
+		flush()
+		lists = openListLevel(lists, indent, ordered, number)
+		level := &lists[len(lists)-1]
+
+		marker := "•"
+		if ordered {
+			marker = strconv.Itoa(level.next) + "."
+			level.next++
+		}
+		pad := "  "
+		if len(lists) > 1 {
+			pad = strings.Repeat(" ", lists[len(lists)-2].content)
+		}
+		prefix = pad + marker + " "
+		styledPrefix = pad + c.Accent(marker) + " "
+		level.content = len([]rune(prefix))
+		block = []string{text}
+	}
+
+	inCode := false
+	for _, line := range strings.Split(src, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "```") {
+			endLists()
+			inCode = !inCode
+			continue
+		}
+		if inCode {
+			emit("    " + c.Info(strings.TrimRight(line, " \t")))
+			continue
+		}
+
+		indent := leadingIndent(line)
+		switch {
+		case trimmed == "":
+			endLists()
+			emit("")
+		case strings.HasPrefix(trimmed, "# "):
+			endLists()
+			emit(c.Bold(c.Accent(strings.TrimPrefix(trimmed, "# "))))
+		case strings.HasPrefix(trimmed, "## "):
+			endLists()
+			emit(c.Bold(strings.TrimPrefix(trimmed, "## ")))
+		case bulletItem.MatchString(trimmed):
+			startItem(indent, false, 0, bulletItem.FindStringSubmatch(trimmed)[1])
+		case numberedItem.MatchString(trimmed):
+			m := numberedItem.FindStringSubmatch(trimmed)
+			number, err := strconv.Atoi(m[1])
+			if err != nil {
+				// Too many digits to count from; start at one
+				number = 1
+			}
+			startItem(indent, true, number, m[2])
+		default:
+			block = append(block, trimmed)
+		}
+	}
+	flush()
+
+	for len(out) > 0 && out[len(out)-1] == "" {
+		out = out[:len(out)-1]
+	}
+	return strings.Join(out, "\n")
+}
+
+// openListLevel returns lists with the level an item indented by indent
+// belongs to last. Deeper levels are closed; an item indented further
+// than the innermost level opens a nested one, as does an item of the
+// other kind at the same indentation, which starts a new list there.
+// New ordered lists count from number.
+func openListLevel(lists []listLevel, indent int, ordered bool, number int) []listLevel {
+	for len(lists) > 0 && lists[len(lists)-1].indent > indent {
+		lists = lists[:len(lists)-1]
+	}
+	if n := len(lists); n > 0 && lists[n-1].indent == indent {
+		if lists[n-1].ordered == ordered {
+			return lists
+		}
+		lists = lists[:n-1]
+	}
+	return append(lists, listLevel{indent: indent, ordered: ordered, next: number})
+}
+
+// leadingIndent returns the indentation of line in columns, counting a tab
+// as four.
+func leadingIndent(line string) int {
+	indent := 0
+	for _, r := range line {
+		switch r {
+		case ' ':
+			indent++
+		case '\t':
+			indent += 4
+		default:
+			return indent
+		}
+	}
+	return indent
+}
+
+// inlineBlock wraps text after prefix, with continuation lines indented to
+// align under the first, and applies inline styling. styledPrefix replaces
+// prefix in the output, so list markers can be colored without counting
+// escape codes toward the width.
+func (l *Layout) inlineBlock(text, prefix, styledPrefix string, c *Colorizer) string {
+	// Protect spaces inside spans so wrapping keeps each span whole
+	protected := inlineSpan.ReplaceAllStringFunc(text, func(span string) string {
+		return strings.ReplaceAll(span, " ", spanSpace)
+	})
+
+	indent := len([]rune(prefix))
+	wrapped := styledPrefix + wrapText(protected, l.width, indent)
+
+	styled := inlineSpan.ReplaceAllStringFunc(wrapped, func(span string) string {
+		if strings.HasPrefix(span, "`") {
+			return c.Accent(span[1 : len(span)-1])
+		}
+		return c.Bold(span[2 : len(span)-2])
+	})
+	return strings.ReplaceAll(styled, spanSpace, " ")
+}
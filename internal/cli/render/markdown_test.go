@@ -0,0 +1,154 @@
+package render
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLayout_Markdown(t *testing.T) {
+	c := NewColorizer(false, DefaultTheme())
+
+	tests := []struct {
+		name  string
+		width int
+		src   string
+		want  string
+	}{
+		{
+			name:  "headings and paragraph",
+			width: 80,
+			src:   "# Title\n\nFirst line\ncontinues here.\n\n## Section\nBody.",
+			want:  "Title\n\nFirst line continues here.\n\nSection\nBody.",
+		},
+		{
+			name:  "bullets and numbered list",
+			width: 80,
+			src:   "- one\n* two\n1. first\n2. second",
+			want:  "  • one\n  • two\n  1. first\n  2. second",
+		},
+		{
+			name:  "inline spans lose markers",
+			width: 80,
+			src:   "Run `dot doctor` with **care**.",
+			want:  "Run dot doctor with care.",
+		},
+		{
+			name:  "code block kept verbatim",
+			width: 20,
+			src:   "```sh\ndot doctor --fix --yes --scan-mode deep\n```",
+			want:  "    dot doctor --fix --yes --scan-mode deep",
+		},
+		{
+			name:  "blank lines collapsed and trimmed",
+			width: 80,
+			src:   "\n\none\n\n\n\ntwo\n\n",
+			want:  "one\n\ntwo",
+		},
+		{
+			name:  "bullet wraps under its text",
+			width: 24,
+			src:   "- alpha beta gamma delta epsilon zeta",
+			want:  "  • alpha beta gamma\n    delta epsilon zeta",
+		},
+		{
+			name:  "list item continues on next source line",
+			width: 80,
+			src:   "1. first part\n   second part\n2. next",
+			want:  "  1. first part second part\n  2. next",
+		},
+		{
+			name:  "numbering continues from the first item",
+			width: 80,
+			src:   "3. three\n1. four\n1. five",
+			want:  "  3. three\n  4. four\n  5. five",
+		},
+		{
+			name:  "blank line ends a list",
+			width: 80,
+			src:   "1. one\n2. two\n\n1. again\n   - not nested",
+			want:  "  1. one\n  2. two\n\n  1. again\n     • not nested",
+		},
+		{
+			name:  "nested bullets",
+			width: 80,
+			src:   "- outer\n  - inner\n    - innermost\n  - inner again\n- outer again",
+			want:  "  • outer\n    • inner\n      • innermost\n    • inner again\n  • outer again",
+		},
+		{
+			name:  "mixed ordered and unordered nesting",
+			width: 80,
+			src: "1. Install\n" +
+				"   - clone the repo\n" +
+				"   - run setup\n" +
+				"     1. pick a profile\n" +
+				"     2. confirm\n" +
+				"2. Verify\n" +
+				"   1. status\n" +
+				"   2. doctor\n" +
+				"- Done",
+			want: "  1. Install\n" +
+				"     • clone the repo\n" +
+				"     • run setup\n" +
+				"       1. pick a profile\n" +
+				"       2. confirm\n" +
+				"  2. Verify\n" +
+				"     1. status\n" +
+				"     2. doctor\n" +
+				"  • Done",
+		},
+		{
+			name:  "nested numbering restarts per parent",
+			width: 80,
+			src:   "- a\n  1. one\n  2. two\n- b\n  1. one",
+			want:  "  • a\n    1. one\n    2. two\n  • b\n    1. one",
+		},
+		{
+			name:  "nested item wraps under its text",
+			width: 30,
+			src:   "- outer\n  1. alpha beta gamma delta epsilon",
+			want:  "  • outer\n    1. alpha beta gamma delta\n       epsilon",
+		},
+		{
+			name:  "tab indentation nests",
+			width: 80,
+			src:   "- outer\n\t- inner",
+			want:  "  • outer\n    • inner",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NewLayout(tt.width).Markdown(tt.src, c))
+		})
+	}
+}
+
+func TestLayout_Markdown_KeepsCodeSpansWhole(t *testing.T) {
+	c := NewColorizer(false, DefaultTheme())
+	out := NewLayout(24).Markdown("Remove it with `dot unmanage some-package` and retry.", c)
+
+	assert.Contains(t, out, "dot unmanage some-package")
+	assert.NotContains(t, out, spanSpace)
+}
+
+func TestLayout_Markdown_ColorizesListMarkers(t *testing.T) {
+	c := NewColorizer(true, DefaultTheme())
+	out := NewLayout(30).Markdown("1. alpha beta gamma delta epsilon\n   - nested", c)
+
+	assert.Contains(t, out, c.Accent("1."))
+	assert.Contains(t, out, c.Accent("•"))
+	// Escape codes in the marker do not count toward the wrap width
+	assert.Equal(t, "  1. alpha beta gamma delta\n     epsilon\n     • nested", StripANSI(out))
+}
+
+func TestLayout_Markdown_Colorized(t *testing.T) {
+	c := NewColorizer(true, DefaultTheme())
+	out := NewLayout(80).Markdown("# Title\n\nUse `dot status`.", c)
+
+	assert.Contains(t, out, "\x1b[")
+	plain := StripANSI(out)
+	assert.True(t, strings.HasPrefix(plain, "Title\n"))
+	assert.Contains(t, plain, "Use dot status.")
+}
@@ -0,0 +1,196 @@
+package render
+
+import (
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Theme names.
+const (
+	ThemeDefault      = "default"
+	ThemeSolarized    = "solarized"
+	ThemeNoColor      = "nocolor"
+	ThemeHighContrast = "high-contrast"
+	ThemeLight        = "light"
+	ThemeDark         = "dark"
+)
+
+// Theme maps semantic roles to 256-color palette codes. An empty code
+// leaves text uncolored.
+type Theme struct {
+	Name string
+
+	Success string
+	Warning string
+	Error   string
+	Info    string
+	Dim     string
+	Accent  string
+	// Heading colors bold headings; empty leaves them bold only.
+	Heading string
+
+	// Cursor marks the focused item in interactive selectors.
+	Cursor string
+	// Selected marks checked items in interactive selectors.
+	Selected string
+	// Highlight is the background of the focused row.
+	Highlight string
+}
+
+var themes = []Theme{
+	{
+		// Muted professional palette
+		Name:      ThemeDefault,
+		Success:   "71",  // #5F875F - muted green
+		Warning:   "179", // #D7AF87 - muted gold
+		Error:     "167", // #D75F5F - muted red
+		Info:      "110", // #87AFD7 - muted blue
+		Dim:       "245", // #8A8A8A - muted gray
+		Accent:    "104", // #8787D7 - dark blue/purple
+		Cursor:    "109", // #87AFAF - muted cyan
+		Selected:  "42",  // #00D787 - green
+		Highlight: "235", // #262626 - near black
+	},
+	{
+		// Closest 256-color approximations of the Solarized accents
+		Name:      ThemeSolarized,
+		Success:   "64",  // green
+		Warning:   "136", // yellow
+		Error:     "160", // red
+		Info:      "33",  // blue
+		Dim:       "244", // base0
+		Accent:    "61",  // violet
+		Cursor:    "37",  // cyan
+		Selected:  "64",  // green
+		Highlight: "235", // base02
+	},
+	{
+		Name: ThemeNoColor,
+	},
+	{
+		Name:      ThemeHighContrast,
+		Success:   "46",  // bright green
+		Warning:   "226", // bright yellow
+		Error:     "196", // bright red
+		Info:      "51",  // bright cyan
+		Dim:       "250", // light gray
+		Accent:    "201", // bright magenta
+		Cursor:    "51",  // bright cyan
+		Selected:  "46",  // bright green
+		Highlight: "238", // dark gray
+	},
+	{
+		// Darker tones that stay readable on light backgrounds
+		Name:      ThemeLight,
+		Success:   "28",  // #008700 - green
+		Warning:   "130", // #AF5F00 - brown
+		Error:     "124", // #AF0000 - dark red
+		Info:      "25",  // #005FAF - blue
+		Dim:       "240", // #585858 - dark gray
+		Accent:    "91",  // #8700AF - purple
+		Heading:   "24",  // #005F87 - deep blue
+		Cursor:    "30",  // #008787 - teal
+		Selected:  "28",  // #008700 - green
+		Highlight: "254", // #E4E4E4 - light gray
+	},
+	{
+		// Brighter tones for dark backgrounds
+		Name:      ThemeDark,
+		Success:   "78",  // #5FD787 - green
+		Warning:   "221", // #FFD75F - gold
+		Error:     "203", // #FF5F5F - red
+		Info:      "117", // #87D7FF - sky blue
+		Dim:       "248", // #A8A8A8 - light gray
+		Accent:    "141", // #AF87FF - lavender
+		Heading:   "153", // #AFD7FF - pale blue
+		Cursor:    "116", // #87D7D7 - cyan
+		Selected:  "78",  // #5FD787 - green
+		Highlight: "237", // #3A3A3A - dark gray
+	},
+}
+
+// DefaultTheme returns the default muted palette.
+func DefaultTheme() Theme {
+	return themes[0]
+}
+
+// ThemeByName returns the named theme. An empty name selects the default
+// theme. For unknown names it returns the default theme and false.
+func ThemeByName(name string) (Theme, bool) {
+	if name == "" {
+		return DefaultTheme(), true
+	}
+	for _, t := range themes {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return DefaultTheme(), false
+}
+
+// ThemeNames returns the names of all available themes.
+func ThemeNames() []string {
+	names := make([]string, len(themes))
+	for i, t := range themes {
+		names[i] = t.Name
+	}
+	return names
+}
+
+// SetRole sets the palette code of the named semantic role, as named in
+// the output.colors configuration. It reports false for unknown roles.
+func (t *Theme) SetRole(role, code string) bool {
+	field, ok := map[string]*string{
+		"success": &t.Success,
+		"warning": &t.Warning,
+		"error":   &t.Error,
+		"info":    &t.Info,
+		"dim":     &t.Dim,
+		"accent":  &t.Accent,
+		"heading": &t.Heading,
+	}[role]
+	if ok {
+		*field = code
+	}
+	return ok
+}
+
+// Scheme returns the ANSI color scheme for the theme.
+func (t Theme) Scheme() ColorScheme {
+	return ColorScheme{
+		Success: ansi256(t.Success),
+		Warning: ansi256(t.Warning),
+		Error:   ansi256(t.Error),
+		Info:    ansi256(t.Info),
+		Dim:     ansi256(t.Dim),
+		Accent:  ansi256(t.Accent),
+		Heading: ansi256(t.Heading),
+	}
+}
+
+// Foreground returns a lipgloss style with the given palette code as
+// foreground color, or an unstyled style if code is empty.
+func (t Theme) Foreground(code string) lipgloss.Style {
+	style := lipgloss.NewStyle()
+	if code == "" {
+		return style
+	}
+	return style.Foreground(lipgloss.Color(code))
+}
+
+// Background returns a lipgloss style with the given palette code as
+// background color, or an unstyled style if code is empty.
+func (t Theme) Background(code string) lipgloss.Style {
+	style := lipgloss.NewStyle()
+	if code == "" {
+		return style
+	}
+	return style.Background(lipgloss.Color(code))
+}
+
+// ansi256 converts a 256-color palette code to a Color.
+func ansi256(code string) Color {
+	if code == "" {
+		return Color{ANSI: ""}
+	}
+	return Color{ANSI: "\033[38;5;" + code + "m"}
+}
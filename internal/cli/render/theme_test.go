@@ -0,0 +1,90 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThemeByName(t *testing.T) {
+	tests := []struct {
+		name     string
+		wantName string
+		wantOK   bool
+	}{
+		{"", ThemeDefault, true},
+		{ThemeDefault, ThemeDefault, true},
+		{ThemeSolarized, ThemeSolarized, true},
+		{ThemeNoColor, ThemeNoColor, true},
+		{ThemeHighContrast, ThemeHighContrast, true},
+		{"monokai", ThemeDefault, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			theme, ok := ThemeByName(tt.name)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantName, theme.Name)
+		})
+	}
+}
+
+func TestThemeNames(t *testing.T) {
+	assert.Equal(t, []string{"default", "solarized", "nocolor", "high-contrast", "light", "dark"}, ThemeNames())
+}
+
+func TestTheme_Scheme(t *testing.T) {
+	assert.Equal(t, DefaultScheme, DefaultTheme().Scheme(), "default theme matches the default scheme")
+
+	noColor, _ := ThemeByName(ThemeNoColor)
+	assert.Equal(t, NoColorScheme, noColor.Scheme())
+
+	solarized, _ := ThemeByName(ThemeSolarized)
+	assert.Equal(t, "\033[38;5;64m", solarized.Scheme().Success.ANSI)
+}
+
+func TestTheme_SetRole(t *testing.T) {
+	theme := DefaultTheme()
+	assert.True(t, theme.SetRole("heading", "19"))
+	assert.Equal(t, "19", theme.Heading)
+
+	assert.False(t, theme.SetRole("cursor", "19"), "selector colors are not configurable roles")
+	assert.Equal(t, DefaultTheme().Cursor, theme.Cursor)
+}
+
+func TestNewColorizer_Theme(t *testing.T) {
+	solarized, _ := ThemeByName(ThemeSolarized)
+	c := NewColorizer(true, solarized)
+	assert.Equal(t, "\033[38;5;160mfailed\033[0m", c.Error("failed"))
+
+	noColor, _ := ThemeByName(ThemeNoColor)
+	c = NewColorizer(true, noColor)
+	assert.Equal(t, "failed", c.Error("failed"))
+
+	// Disabled color ignores the theme
+	c = NewColorizer(false, solarized)
+	assert.Equal(t, "failed", c.Error("failed"))
+}
+
+func TestColorizer_BoldHeading(t *testing.T) {
+	c := NewColorizer(true, DefaultTheme())
+	assert.Equal(t, "\033[1mSection\033[0m", c.Bold("Section"), "no heading color keeps plain bold")
+
+	light, _ := ThemeByName(ThemeLight)
+	c = NewColorizer(true, light)
+	assert.Equal(t, "\033[1m\033[38;5;24mSection\033[0m", c.Bold("Section"))
+
+	c = NewColorizer(false, light)
+	assert.Equal(t, "Section", c.Bold("Section"))
+}
+
+func TestTheme_Styles(t *testing.T) {
+	theme := DefaultTheme()
+	assert.Equal(t, lipgloss.Color("109"), theme.Foreground(theme.Cursor).GetForeground())
+	assert.Equal(t, lipgloss.Color("235"), theme.Background(theme.Highlight).GetBackground())
+
+	noColor, _ := ThemeByName(ThemeNoColor)
+	assert.Equal(t, lipgloss.NoColor{}, noColor.Foreground(noColor.Cursor).GetForeground())
+	assert.Equal(t, lipgloss.NoColor{}, noColor.Background(noColor.Highlight).GetBackground())
+}
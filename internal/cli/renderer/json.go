@@ -32,7 +32,20 @@ func (r *JSONRenderer) RenderDiagnostics(w io.Writer, report dot.DiagnosticRepor
 	return r.newEncoder(w).Encode(report)
 }
 
+// RenderOrphans renders orphaned links as a JSON array.
+func (r *JSONRenderer) RenderOrphans(w io.Writer, orphans []dot.OrphanInfo) error {
+	if orphans == nil {
+		orphans = []dot.OrphanInfo{}
+	}
+	return r.newEncoder(w).Encode(orphans)
+}
+
 // RenderPlan renders an execution plan as JSON.
 func (r *JSONRenderer) RenderPlan(w io.Writer, plan domain.Plan) error {
 	return r.newEncoder(w).Encode(plan)
 }
+
+// RenderDryRun renders a plan and the dry-run effect of each operation as JSON.
+func (r *JSONRenderer) RenderDryRun(w io.Writer, plan domain.Plan, effects []domain.DryRunEffect) error {
+	return r.newEncoder(w).Encode(newDryRunOutput(plan, effects))
+}
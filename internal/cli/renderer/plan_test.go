@@ -101,3 +101,76 @@ func TestTableRenderer_RenderPlan(t *testing.T) {
 	output := buf.String()
 	assert.NotEmpty(t, output)
 }
+
+// dryRunFixture returns a plan with one link to create and one already in
+// place, and their dry-run effects.
+func dryRunFixture() (dot.Plan, []dot.DryRunEffect) {
+	plan := dot.Plan{
+		Operations: []dot.Operation{
+			dot.NewLinkCreate("op1", dot.MustParsePath("/src/vimrc"), dot.MustParseTargetPath("/target/.vimrc")),
+			dot.NewLinkCreate("op2", dot.MustParsePath("/src/bashrc"), dot.MustParseTargetPath("/target/.bashrc")),
+			dot.NewLinkDelete("op3", dot.MustParseTargetPath("/target/.zshrc")),
+		},
+	}
+	effects := []dot.DryRunEffect{
+		{OpID: "op1", Kind: dot.OpKindLinkCreate, Status: dot.EffectApply, Description: "would create symlink /target/.vimrc -> /src/vimrc"},
+		{OpID: "op2", Kind: dot.OpKindLinkCreate, Status: dot.EffectBlocked, Description: "would create symlink /target/.bashrc -> /src/bashrc", Reason: "target already exists"},
+		{OpID: "op3", Kind: dot.OpKindLinkDelete, Status: dot.EffectNoop, Description: "would delete symlink /target/.zshrc", Reason: "link does not exist"},
+	}
+	return plan, effects
+}
+
+func TestTextRenderer_RenderDryRun(t *testing.T) {
+	r := &TextRenderer{colorize: false, scheme: ColorScheme{}, width: 80}
+	plan, effects := dryRunFixture()
+
+	var buf bytes.Buffer
+	require.NoError(t, r.RenderDryRun(&buf, plan, effects))
+
+	output := buf.String()
+	assert.Contains(t, output, "+ would create symlink /target/.vimrc -> /src/vimrc\n")
+	assert.Contains(t, output, "! would create symlink /target/.bashrc -> /src/bashrc (target already exists)\n")
+	assert.Contains(t, output, "= would delete symlink /target/.zshrc (link does not exist)\n")
+	assert.Contains(t, output, "Already in place: 1")
+	assert.Contains(t, output, "Blocked: 1")
+}
+
+func TestTableRenderer_RenderDryRun(t *testing.T) {
+	plan, effects := dryRunFixture()
+
+	for _, style := range []string{"default", "simple"} {
+		t.Run(style, func(t *testing.T) {
+			r := &TableRenderer{tableStyle: style}
+
+			var buf bytes.Buffer
+			require.NoError(t, r.RenderDryRun(&buf, plan, effects))
+
+			output := buf.String()
+			assert.Contains(t, output, "blocked")
+			assert.Contains(t, output, "target already exists")
+			assert.Contains(t, output, "Summary:")
+		})
+	}
+}
+
+func TestJSONRenderer_RenderDryRun(t *testing.T) {
+	r := &JSONRenderer{}
+	plan, effects := dryRunFixture()
+
+	var buf bytes.Buffer
+	require.NoError(t, r.RenderDryRun(&buf, plan, effects))
+
+	output := buf.String()
+	assert.Contains(t, output, `"effects":[`)
+	assert.Contains(t, output, `"status":"blocked"`)
+	assert.Contains(t, output, `"reason":"target already exists"`)
+}
+
+func TestYAMLRenderer_RenderDryRun(t *testing.T) {
+	r := &YAMLRenderer{indent: 2}
+
+	var buf bytes.Buffer
+	require.NoError(t, r.RenderDryRun(&buf, dot.Plan{}, nil))
+
+	assert.Contains(t, buf.String(), "effects: []")
+}
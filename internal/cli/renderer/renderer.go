@@ -4,12 +4,9 @@ import (
 	"fmt"
 	"io"
 	"math"
-	"os"
 	"strings"
 	"time"
 
-	"golang.org/x/term"
-
 	"github.com/yaklabco/dot/internal/cli/terminal"
 	"github.com/yaklabco/dot/internal/domain"
 	"github.com/yaklabco/dot/pkg/dot"
@@ -19,7 +16,22 @@ import (
 type Renderer interface {
 	RenderStatus(w io.Writer, status dot.Status) error
 	RenderDiagnostics(w io.Writer, report dot.DiagnosticReport) error
+	RenderOrphans(w io.Writer, orphans []dot.OrphanInfo) error
 	RenderPlan(w io.Writer, plan dot.Plan) error
+	RenderDryRun(w io.Writer, plan dot.Plan, effects []dot.DryRunEffect) error
+}
+
+// dryRunOutput is the structured form of a dry run.
+type dryRunOutput struct {
+	Plan    dot.Plan           `json:"plan" yaml:"plan"`
+	Effects []dot.DryRunEffect `json:"effects" yaml:"effects"`
+}
+
+func newDryRunOutput(plan dot.Plan, effects []dot.DryRunEffect) dryRunOutput {
+	if effects == nil {
+		effects = []dot.DryRunEffect{}
+	}
+	return dryRunOutput{Plan: plan, Effects: effects}
 }
 
 // ColorScheme defines semantic colors for terminal output.
@@ -33,9 +45,10 @@ type ColorScheme struct {
 }
 
 // DefaultColorScheme returns the default muted professional color scheme.
-// Colors are disabled if NO_COLOR environment variable is set.
+// Colors are disabled if NO_COLOR is set, unless FORCE_COLOR or
+// CLICOLOR_FORCE forces them on.
 func DefaultColorScheme() ColorScheme {
-	if os.Getenv("NO_COLOR") != "" {
+	if enabled, ok := terminal.ColorFromEnv(); ok && !enabled {
 		return ColorScheme{}
 	}
 
@@ -53,7 +66,16 @@ func DefaultColorScheme() ColorScheme {
 // tableStyle should be "default" (modern with borders) or "simple" (legacy plain text).
 // If empty, defaults to "default".
 func NewRenderer(format string, colorize bool, tableStyle string) (Renderer, error) {
-	width := getTerminalWidth()
+	return NewRendererWithWidth(format, colorize, tableStyle, 0)
+}
+
+// NewRendererWithWidth creates a renderer like NewRenderer that sizes its
+// output to width, the output.width setting. Zero or less falls back to
+// COLUMNS, then the terminal width.
+func NewRendererWithWidth(format string, colorize bool, tableStyle string, width int) (Renderer, error) {
+	if width <= 0 {
+		width = getTerminalWidth()
+	}
 	scheme := DefaultColorScheme()
 
 	if !colorize {
@@ -93,17 +115,14 @@ func NewRenderer(format string, colorize bool, tableStyle string) (Renderer, err
 	}
 }
 
-// getTerminalWidth returns the width of the terminal, or a default if not available.
+// getTerminalWidth returns the output width from COLUMNS or the terminal,
+// or a default if neither is available.
 func getTerminalWidth() int {
-	width, _, err := term.GetSize(terminal.FdInt(os.Stdout.Fd()))
-	if err != nil || width == 0 {
-		return 80 // Default fallback
-	}
-	return width
+	return terminal.Width(0)
 }
 
-// formatBytes converts bytes to human-readable format.
-func formatBytes(bytes int64) string {
+// FormatBytes converts bytes to human-readable format.
+func FormatBytes(bytes int64) string {
 	if bytes == 0 {
 		return "0 B"
 	}
@@ -121,8 +121,57 @@ func TestRendererInterface(t *testing.T) {
 	}
 }
 
+func TestRenderOrphans(t *testing.T) {
+	orphans := []dot.OrphanInfo{
+		{Path: ".npmrc", Target: "/home/user/.npm/npmrc", Category: "npm", Confidence: "high"},
+		{Path: ".stray", Target: "/tmp/stray"},
+	}
+
+	tests := []struct {
+		name     string
+		format   string
+		style    string
+		contains []string
+	}{
+		{"text", "text", "", []string{"Orphaned links: 2", ".npmrc -> /home/user/.npm/npmrc [npm, high confidence]", ".stray -> /tmp/stray"}},
+		{"table", "table", "", []string{"CATEGORY", ".npmrc", "npm", "2 orphaned links"}},
+		{"simple table", "table", "simple", []string{"Confidence", "/tmp/stray", "2 orphaned links"}},
+		{"json", "json", "", []string{`"path": ".npmrc"`, `"category": "npm"`, `"confidence": "high"`, `"target": "/tmp/stray"`}},
+		{"yaml", "yaml", "", []string{"path: .npmrc", "category: npm", "target: /tmp/stray"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := NewRenderer(tt.format, false, tt.style)
+			require.NoError(t, err)
+
+			var buf bytes.Buffer
+			require.NoError(t, r.RenderOrphans(&buf, orphans))
+			for _, s := range tt.contains {
+				assert.Contains(t, buf.String(), s)
+			}
+		})
+	}
+}
+
+func TestRenderOrphans_Empty(t *testing.T) {
+	for _, format := range []string{"text", "table"} {
+		r, err := NewRenderer(format, false, "")
+		require.NoError(t, err)
+		var buf bytes.Buffer
+		require.NoError(t, r.RenderOrphans(&buf, nil))
+		assert.Contains(t, buf.String(), "No orphaned links found")
+	}
+
+	r, err := NewRenderer("json", false, "")
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, r.RenderOrphans(&buf, nil))
+	assert.Equal(t, "[]\n", buf.String())
+}
+
 func TestFormatHelpers(t *testing.T) {
-	t.Run("formatBytes", func(t *testing.T) {
+	t.Run("FormatBytes", func(t *testing.T) {
 		tests := []struct {
 			bytes int64
 			want  string
@@ -136,7 +185,7 @@ func TestFormatHelpers(t *testing.T) {
 		}
 
 		for _, tt := range tests {
-			got := formatBytes(tt.bytes)
+			got := FormatBytes(tt.bytes)
 			assert.Equal(t, tt.want, got)
 		}
 	})
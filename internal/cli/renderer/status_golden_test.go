@@ -0,0 +1,51 @@
+package renderer
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/yaklabco/dot/internal/cli/golden"
+	"github.com/yaklabco/dot/pkg/dot"
+)
+
+// TestTableRenderer_StatusGolden checks the status table in both styles,
+// at a width that forces the long package name to be truncated.
+func TestTableRenderer_StatusGolden(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	status := dot.Status{
+		Packages: []dot.PackageInfo{
+			{Name: "zsh", LinkCount: 4, IsHealthy: true, InstalledAt: now.Add(-3 * time.Hour)},
+			{Name: "vim", LinkCount: 12, BrokenLinks: 2, Orphaned: 1, IssueType: "broken links", InstalledAt: now.Add(-2 * 24 * time.Hour)},
+			{Name: "neovim-configuration-with-plugins", LinkCount: 30, IsHealthy: true, InstalledAt: now.Add(-5 * time.Minute)},
+		},
+	}
+
+	for _, tt := range []struct {
+		name  string
+		style string
+		width int
+	}{
+		{name: "status_table_default", style: "default", width: 100},
+		{name: "status_table_default_narrow", style: "default", width: 60},
+		{name: "status_table_simple", style: "simple", width: 100},
+		{name: "status_table_simple_narrow", style: "simple", width: 60},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &TableRenderer{
+				width:      tt.width,
+				tableStyle: tt.style,
+				now:        func() time.Time { return now },
+			}
+
+			var buf bytes.Buffer
+			require.NoError(t, r.RenderStatus(&buf, status))
+
+			for _, line := range bytes.Split(bytes.TrimRight(buf.Bytes(), "\n"), []byte("\n")) {
+				require.LessOrEqual(t, len([]rune(string(line))), tt.width, "line exceeds width: %q", line)
+			}
+			golden.New(t, "status").Assert(tt.name, buf.Bytes())
+		})
+	}
+}
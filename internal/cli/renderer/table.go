@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"io"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/yaklabco/dot/internal/cli/pretty"
 	"github.com/yaklabco/dot/internal/domain"
@@ -16,9 +18,17 @@ type TableRenderer struct {
 	colorize   bool
 	scheme     ColorScheme
 	width      int
-	tableStyle string // "default" = modern borders, "simple" = legacy plain text
+	tableStyle string           // "default" = modern borders, "simple" = legacy plain text
+	now        func() time.Time // clock for relative times; nil means time.Now
 }
 
+// statusHeaders are the columns of the status table.
+var statusHeaders = []string{"Package", "Links", "Broken", "Orphaned", "Last Managed"}
+
+// minStatusNameWidth is the narrowest the package column is truncated to
+// when fitting the status table to the output width.
+const minStatusNameWidth = 8
+
 // RenderStatus renders installation status as a table.
 func (r *TableRenderer) RenderStatus(w io.Writer, status dot.Status) error {
 	// Show not-found messages for specifically requested packages
@@ -38,17 +48,6 @@ func (r *TableRenderer) RenderStatus(w io.Writer, status dot.Status) error {
 		return status.Packages[i].Name < status.Packages[j].Name
 	})
 
-	// Calculate statistics
-	healthyCount := 0
-	unhealthyCount := 0
-	for _, pkg := range status.Packages {
-		if pkg.IsHealthy {
-			healthyCount++
-		} else {
-			unhealthyCount++
-		}
-	}
-
 	// Use legacy simple rendering if configured
 	if r.tableStyle == "simple" {
 		return r.renderStatusSimple(w, status)
@@ -58,24 +57,15 @@ func (r *TableRenderer) RenderStatus(w io.Writer, status dot.Status) error {
 	table := pretty.NewTableWriter(pretty.StyleLight, pretty.TableConfig{
 		ColorEnabled: r.colorize,
 		AutoWrap:     true,
-		MaxWidth:     0, // Auto-detect terminal width
+		MaxWidth:     r.width,
 	})
 
-	// Set header
-	table.SetHeader("Health", "Package", "Links", "Installed")
-
-	// Add rows
-	for _, pkg := range status.Packages {
-		healthSymbol := "✓"
-		if !pkg.IsHealthy {
-			healthSymbol = "✗ " + pkg.IssueType
-		}
-		table.AppendRow(
-			healthSymbol,
-			pkg.Name,
-			fmt.Sprintf("%d", pkg.LinkCount),
-			formatDuration(pkg.InstalledAt),
-		)
+	// Bordered cells carry one space of padding each side and share a
+	// border with their neighbour
+	rows := r.statusRows(status.Packages, 3*len(statusHeaders)+1)
+	table.SetHeader(toAny(statusHeaders)...)
+	for _, row := range rows {
+		table.AppendRow(toAny(row)...)
 	}
 
 	// Render
@@ -83,54 +73,98 @@ func (r *TableRenderer) RenderStatus(w io.Writer, status dot.Status) error {
 
 	// Print statistics summary
 	fmt.Fprintln(w)
-	if unhealthyCount == 0 {
-		fmt.Fprintf(w, "%d healthy\n", healthyCount)
-	} else {
-		fmt.Fprintf(w, "%d healthy, %d unhealthy\n", healthyCount, unhealthyCount)
-	}
+	fmt.Fprintln(w, statusSummary(status.Packages))
 
 	return nil
 }
 
 // renderStatusSimple renders status using legacy plain text format.
 func (r *TableRenderer) renderStatusSimple(w io.Writer, status dot.Status) error {
-	headers := []string{"Health", "Package", "Links", "Installed"}
-	rows := make([][]string, 0, len(status.Packages))
+	// Plain rows are indented two spaces, with two between columns and
+	// two trailing
+	rows := r.statusRows(status.Packages, 2*len(statusHeaders)+2)
 
-	healthyCount := 0
-	unhealthyCount := 0
+	if err := r.renderTableSimple(w, statusHeaders, rows); err != nil {
+		return err
+	}
 
-	for _, pkg := range status.Packages {
-		healthSymbol := "✓"
-		if !pkg.IsHealthy {
-			healthSymbol = "✗ " + pkg.IssueType
-			unhealthyCount++
-		} else {
-			healthyCount++
-		}
+	// Print statistics summary
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "  %s\n", statusSummary(status.Packages))
+
+	return nil
+}
 
-		row := []string{
-			healthSymbol,
+// statusRows builds the status table rows. Package names are truncated so
+// the table fits the output width, given the width the style spends on
+// borders and padding.
+func (r *TableRenderer) statusRows(packages []dot.PackageInfo, overhead int) [][]string {
+	now := time.Now()
+	if r.now != nil {
+		now = r.now()
+	}
+
+	rows := make([][]string, 0, len(packages))
+	for _, pkg := range packages {
+		rows = append(rows, []string{
 			pkg.Name,
-			fmt.Sprintf("%d", pkg.LinkCount),
-			formatDuration(pkg.InstalledAt),
-		}
-		rows = append(rows, row)
+			strconv.Itoa(pkg.LinkCount),
+			strconv.Itoa(pkg.BrokenLinks),
+			strconv.Itoa(pkg.Orphaned),
+			formatDurationFrom(pkg.InstalledAt, now),
+		})
 	}
 
-	if err := r.renderTableSimple(w, headers, rows); err != nil {
-		return err
+	if r.width <= 0 {
+		return rows
 	}
 
-	// Print statistics summary
-	fmt.Fprintln(w)
-	if unhealthyCount == 0 {
-		fmt.Fprintf(w, "  %d healthy\n", healthyCount)
-	} else {
-		fmt.Fprintf(w, "  %d healthy, %d unhealthy\n", healthyCount, unhealthyCount)
+	widths := make([]int, len(statusHeaders))
+	for i, h := range statusHeaders {
+		widths[i] = len(h)
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], len(cell))
+		}
+	}
+	room := r.width - overhead
+	for _, width := range widths[1:] {
+		room -= width
 	}
+	room = max(room, minStatusNameWidth, len(statusHeaders[0]))
+	if widths[0] <= room {
+		return rows
+	}
+	for _, row := range rows {
+		if len(row[0]) > room {
+			row[0] = row[0][:room-3] + "..."
+		}
+	}
+	return rows
+}
 
-	return nil
+// statusSummary counts healthy and unhealthy packages.
+func statusSummary(packages []dot.PackageInfo) string {
+	healthyCount := 0
+	for _, pkg := range packages {
+		if pkg.IsHealthy {
+			healthyCount++
+		}
+	}
+	if unhealthyCount := len(packages) - healthyCount; unhealthyCount > 0 {
+		return fmt.Sprintf("%d healthy, %d unhealthy", healthyCount, unhealthyCount)
+	}
+	return fmt.Sprintf("%d healthy", healthyCount)
+}
+
+// toAny converts table cells for TableWriter.
+func toAny(cells []string) []interface{} {
+	values := make([]interface{}, len(cells))
+	for i, cell := range cells {
+		values[i] = cell
+	}
+	return values
 }
 
 func (r *TableRenderer) resetColor() string {
@@ -206,8 +240,15 @@ func (r *TableRenderer) RenderDiagnostics(w io.Writer, report dot.DiagnosticRepo
 	fmt.Fprintln(w, "Statistics:")
 	fmt.Fprintf(w, "  Total Links: %d\n", report.Statistics.TotalLinks)
 	fmt.Fprintf(w, "  Managed Links: %d\n", report.Statistics.ManagedLinks)
+	if report.Statistics.Junctions > 0 {
+		fmt.Fprintf(w, "  Junctions: %d\n", report.Statistics.Junctions)
+	}
 	fmt.Fprintf(w, "  Broken Links: %d\n", report.Statistics.BrokenLinks)
-	fmt.Fprintf(w, "  Orphaned Links: %d\n\n", report.Statistics.OrphanedLinks)
+	fmt.Fprintf(w, "  Orphaned Links: %d\n", report.Statistics.OrphanedLinks)
+	if report.Statistics.OwnershipConflicts > 0 {
+		fmt.Fprintf(w, "  Ownership Conflicts: %d\n", report.Statistics.OwnershipConflicts)
+	}
+	fmt.Fprintln(w)
 
 	// Show issues in a table
 	if len(report.Issues) == 0 {
@@ -269,6 +310,45 @@ func (r *TableRenderer) renderDiagnosticsSimple(w io.Writer, issues []dot.Issue)
 	return r.renderTableSimple(w, headers, rows)
 }
 
+// RenderOrphans renders orphaned links as a table.
+func (r *TableRenderer) RenderOrphans(w io.Writer, orphans []dot.OrphanInfo) error {
+	if len(orphans) == 0 {
+		fmt.Fprintf(w, "%sNo orphaned links found%s\n", r.colorText(r.scheme.Success), r.resetColor())
+		return nil
+	}
+
+	headers := []string{"Path", "Target", "Category", "Confidence"}
+	rows := make([][]string, 0, len(orphans))
+	for _, orphan := range orphans {
+		category := orphan.Category
+		if category == "" {
+			category = "-"
+		}
+		rows = append(rows, []string{orphan.Path, orphan.Target, category, orphan.Confidence})
+	}
+
+	if r.tableStyle == "simple" {
+		if err := r.renderTableSimple(w, headers, rows); err != nil {
+			return err
+		}
+	} else {
+		table := pretty.NewTableWriter(pretty.StyleLight, pretty.TableConfig{
+			ColorEnabled: r.colorize,
+			AutoWrap:     true,
+			MaxWidth:     0, // Auto-detect terminal width
+		})
+		table.SetHeader(headers[0], headers[1], headers[2], headers[3])
+		for _, row := range rows {
+			table.AppendRow(row[0], row[1], row[2], row[3])
+		}
+		table.Render(w)
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "%d orphaned %s\n", len(orphans), pluralize(len(orphans), "link"))
+	return nil
+}
+
 func (r *TableRenderer) colorText(color string) string {
 	if r.colorize && color != "" {
 		return color
@@ -370,8 +450,55 @@ func (r *TableRenderer) RenderPlan(w io.Writer, plan domain.Plan) error {
 		table.Render(w)
 	}
 
-	// Summary
 	fmt.Fprintln(w)
+	r.renderPlanSummary(w, plan)
+	return nil
+}
+
+// RenderDryRun renders what each operation in plan would do against the
+// current filesystem as a table.
+func (r *TableRenderer) RenderDryRun(w io.Writer, plan domain.Plan, effects []domain.DryRunEffect) error {
+	fmt.Fprintf(w, "%sDry run mode - no changes will be applied%s\n\n", r.colorText(r.scheme.Warning), r.resetColor())
+
+	if len(effects) == 0 {
+		fmt.Fprintln(w, "No operations required")
+		return nil
+	}
+
+	headers := []string{"#", "Status", "Effect"}
+	rows := make([][]string, 0, len(effects))
+	for i, effect := range effects {
+		rows = append(rows, []string{
+			fmt.Sprintf("%d", i+1),
+			effect.Status.String(),
+			effect.String(),
+		})
+	}
+
+	if r.tableStyle == "simple" {
+		if err := r.renderTableSimple(w, headers, rows); err != nil {
+			return err
+		}
+	} else {
+		table := pretty.NewTableWriter(pretty.StyleLight, pretty.TableConfig{
+			ColorEnabled: r.colorize,
+			AutoWrap:     true,
+			MaxWidth:     0, // Auto-detect terminal width
+		})
+		table.SetHeader("#", "Status", "Effect")
+		for _, row := range rows {
+			table.AppendRow(row[0], row[1], row[2])
+		}
+		table.Render(w)
+	}
+
+	fmt.Fprintln(w)
+	r.renderPlanSummary(w, plan)
+	return nil
+}
+
+// renderPlanSummary renders operation counts and conflicts for plan.
+func (r *TableRenderer) renderPlanSummary(w io.Writer, plan domain.Plan) {
 	fmt.Fprintln(w, "Summary:")
 
 	// Count all operation kinds in a single pass
@@ -402,8 +529,6 @@ func (r *TableRenderer) RenderPlan(w io.Writer, plan domain.Plan) error {
 
 	// Always show conflicts count
 	fmt.Fprintf(w, "  Conflicts: %d\n", len(plan.Metadata.Conflicts))
-
-	return nil
 }
 
 // renderPlanSimple renders execution plan using legacy plain text format.
@@ -97,12 +97,18 @@ func (r *TextRenderer) RenderDiagnostics(w io.Writer, report dot.DiagnosticRepor
 	fmt.Fprintf(w, "Statistics:\n")
 	fmt.Fprintf(w, "  Total Links: %d\n", report.Statistics.TotalLinks)
 	fmt.Fprintf(w, "  Managed Links: %d\n", report.Statistics.ManagedLinks)
+	if report.Statistics.Junctions > 0 {
+		fmt.Fprintf(w, "  Junctions: %d\n", report.Statistics.Junctions)
+	}
 	if report.Statistics.BrokenLinks > 0 {
 		fmt.Fprintf(w, "  %sBroken Links: %d%s\n", r.colorText(r.scheme.Error), report.Statistics.BrokenLinks, r.resetColor())
 	}
 	if report.Statistics.OrphanedLinks > 0 {
 		fmt.Fprintf(w, "  %sOrphaned Links: %d%s\n", r.colorText(r.scheme.Warning), report.Statistics.OrphanedLinks, r.resetColor())
 	}
+	if report.Statistics.OwnershipConflicts > 0 {
+		fmt.Fprintf(w, "  %sOwnership Conflicts: %d%s\n", r.colorText(r.scheme.Warning), report.Statistics.OwnershipConflicts, r.resetColor())
+	}
 	fmt.Fprintln(w)
 
 	// Show issues
@@ -139,6 +145,30 @@ func (r *TextRenderer) RenderDiagnostics(w io.Writer, report dot.DiagnosticRepor
 	return nil
 }
 
+// RenderOrphans renders orphaned links as plain text, one per line with
+// target and category.
+func (r *TextRenderer) RenderOrphans(w io.Writer, orphans []dot.OrphanInfo) error {
+	if len(orphans) == 0 {
+		fmt.Fprintf(w, "%sNo orphaned links found%s\n", r.colorText(r.scheme.Success), r.resetColor())
+		return nil
+	}
+
+	fmt.Fprintf(w, "%sOrphaned links: %d%s\n\n", r.colorText(r.scheme.Warning), len(orphans), r.resetColor())
+	for _, orphan := range orphans {
+		target := orphan.Target
+		if target == "" {
+			target = "(unreadable)"
+		}
+		fmt.Fprintf(w, "  %s -> %s", orphan.Path, target)
+		if orphan.Category != "" {
+			fmt.Fprintf(w, " %s[%s, %s confidence]%s", r.colorText(r.scheme.Muted), orphan.Category, orphan.Confidence, r.resetColor())
+		}
+		fmt.Fprintln(w)
+	}
+
+	return nil
+}
+
 // RenderPlan renders an execution plan as plain text.
 func (r *TextRenderer) RenderPlan(w io.Writer, plan domain.Plan) error {
 	// Header
@@ -155,7 +185,60 @@ func (r *TextRenderer) RenderPlan(w io.Writer, plan domain.Plan) error {
 	}
 	fmt.Fprintln(w)
 
-	// Summary counts
+	r.renderPlanSummary(w, plan)
+	return nil
+}
+
+// RenderDryRun renders what each operation in plan would do against the
+// current filesystem, as reported by its dry-run effect.
+func (r *TextRenderer) RenderDryRun(w io.Writer, plan domain.Plan, effects []domain.DryRunEffect) error {
+	fmt.Fprintf(w, "%sDry run mode - no changes will be applied%s\n\n", r.colorText(r.scheme.Warning), r.resetColor())
+
+	fmt.Fprintln(w, "Plan:")
+	if len(effects) == 0 {
+		fmt.Fprintln(w, "  No operations required")
+	}
+	var noop, blocked int
+	for _, effect := range effects {
+		switch effect.Status {
+		case domain.EffectNoop:
+			noop++
+		case domain.EffectBlocked:
+			blocked++
+		}
+		fmt.Fprintf(w, "  %s %s\n", r.effectSymbol(effect), effect.String())
+	}
+	fmt.Fprintln(w)
+
+	r.renderPlanSummary(w, plan)
+	if noop > 0 {
+		fmt.Fprintf(w, "  Already in place: %d\n", noop)
+	}
+	if blocked > 0 {
+		fmt.Fprintf(w, "  %sBlocked: %d%s\n", r.colorText(r.scheme.Error), blocked, r.resetColor())
+	}
+	return nil
+}
+
+// effectSymbol returns the marker for an effect: + or - for a change,
+// = when nothing would change, and ! when the operation would fail.
+func (r *TextRenderer) effectSymbol(effect domain.DryRunEffect) string {
+	switch effect.Status {
+	case domain.EffectNoop:
+		return r.colorText(r.scheme.Muted) + "=" + r.resetColor()
+	case domain.EffectBlocked:
+		return r.colorText(r.scheme.Error) + "!" + r.resetColor()
+	}
+	switch effect.Kind {
+	case domain.OpKindLinkDelete, domain.OpKindDirDelete, domain.OpKindDirRemoveAll, domain.OpKindFileDelete:
+		return r.colorText(r.scheme.Error) + "-" + r.resetColor()
+	default:
+		return r.colorText(r.scheme.Success) + "+" + r.resetColor()
+	}
+}
+
+// renderPlanSummary renders operation counts and conflicts for plan.
+func (r *TextRenderer) renderPlanSummary(w io.Writer, plan domain.Plan) {
 	fmt.Fprintln(w, "Summary:")
 	counts := r.countOperations(plan)
 	if counts.DirCreate > 0 {
@@ -182,8 +265,6 @@ func (r *TextRenderer) RenderPlan(w io.Writer, plan domain.Plan) error {
 	} else {
 		fmt.Fprintf(w, "  Conflicts: 0\n")
 	}
-
-	return nil
 }
 
 // renderOperation renders a single operation.
@@ -35,9 +35,26 @@ func (r *YAMLRenderer) RenderDiagnostics(w io.Writer, report dot.DiagnosticRepor
 	return encoder.Encode(report)
 }
 
+// RenderOrphans renders orphaned links as YAML.
+func (r *YAMLRenderer) RenderOrphans(w io.Writer, orphans []dot.OrphanInfo) error {
+	if orphans == nil {
+		orphans = []dot.OrphanInfo{}
+	}
+	encoder := r.newEncoder(w)
+	defer encoder.Close()
+	return encoder.Encode(orphans)
+}
+
 // RenderPlan renders an execution plan as YAML.
 func (r *YAMLRenderer) RenderPlan(w io.Writer, plan domain.Plan) error {
 	encoder := r.newEncoder(w)
 	defer encoder.Close()
 	return encoder.Encode(plan)
 }
+
+// RenderDryRun renders a plan and the dry-run effect of each operation as YAML.
+func (r *YAMLRenderer) RenderDryRun(w io.Writer, plan domain.Plan, effects []domain.DryRunEffect) error {
+	encoder := r.newEncoder(w)
+	defer encoder.Close()
+	return encoder.Encode(newDryRunOutput(plan, effects))
+}
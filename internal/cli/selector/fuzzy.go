@@ -0,0 +1,334 @@
+package selector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"golang.org/x/term"
+
+	"github.com/yaklabco/dot/internal/cli/render"
+	"github.com/yaklabco/dot/internal/cli/terminal"
+)
+
+// ErrSelectionCancelled is returned when the user leaves the fuzzy selector
+// without confirming.
+var ErrSelectionCancelled = errors.New("package selection cancelled")
+
+// FuzzySelector implements PackageSelector with a type-to-filter list.
+// Typing narrows the packages to fuzzy matches, space toggles the
+// highlighted package and enter confirms.
+type FuzzySelector struct {
+	input  io.Reader
+	output io.Writer
+	theme  render.Theme
+}
+
+// NewFuzzySelector creates a new fuzzy selector.
+func NewFuzzySelector(input io.Reader, output io.Writer) *FuzzySelector {
+	return &FuzzySelector{
+		input:  input,
+		output: output,
+		theme:  render.DefaultTheme(),
+	}
+}
+
+// WithTheme sets the color theme used to render the selector.
+func (s *FuzzySelector) WithTheme(theme render.Theme) *FuzzySelector {
+	s.theme = theme
+	return s
+}
+
+// Select shows the packages and returns those the user selected, in their
+// original order.
+func (s *FuzzySelector) Select(ctx context.Context, packages []string) ([]string, error) {
+	if len(packages) == 0 {
+		return []string{}, nil
+	}
+
+	p := tea.NewProgram(newFuzzyModel(packages, s.theme),
+		tea.WithContext(ctx),
+		tea.WithInput(s.input),
+		tea.WithOutput(s.output),
+		tea.WithAltScreen(),
+	)
+	final, err := p.Run()
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("run selector: %w", err)
+	}
+
+	m := final.(fuzzyModel)
+	if !m.confirmed {
+		return nil, ErrSelectionCancelled
+	}
+	return m.result(), nil
+}
+
+// New returns the selector suited to input and output: the fuzzy selector
+// when both are terminals, otherwise the numeric prompt, which also works
+// with piped input.
+func New(input io.Reader, output io.Writer, theme render.Theme) PackageSelector {
+	if isTerminal(input) && isTerminal(output) {
+		return NewFuzzySelector(input, output).WithTheme(theme)
+	}
+	return NewInteractiveSelector(input, output).WithTheme(theme)
+}
+
+// isTerminal reports whether v is a file attached to a terminal.
+func isTerminal(v any) bool {
+	f, ok := v.(*os.File)
+	return ok && term.IsTerminal(terminal.FdInt(f.Fd()))
+}
+
+// fuzzyModel is the Bubble Tea model for FuzzySelector.
+type fuzzyModel struct {
+	packages  []string
+	query     []rune
+	matches   []int // indices into packages, best match first
+	cursor    int   // position in matches
+	offset    int   // first visible position in matches
+	selected  map[int]bool
+	height    int
+	theme     render.Theme
+	confirmed bool
+	quitting  bool
+}
+
+func newFuzzyModel(packages []string, theme render.Theme) fuzzyModel {
+	m := fuzzyModel{
+		packages: packages,
+		selected: make(map[int]bool),
+		height:   24, // Updated by WindowSizeMsg
+		theme:    theme,
+	}
+	m.filter()
+	return m
+}
+
+func (m fuzzyModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m fuzzyModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.height = msg.Height
+		m.scroll()
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	}
+	return m, nil
+}
+
+func (m fuzzyModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		m.quitting = true
+		return m, tea.Quit
+	case tea.KeyEsc:
+		// The first escape clears the filter, the second cancels
+		if len(m.query) > 0 {
+			m.query = nil
+			m.filter()
+			return m, nil
+		}
+		m.quitting = true
+		return m, tea.Quit
+	case tea.KeyEnter:
+		// With nothing toggled, enter picks the highlighted package
+		if len(m.selected) == 0 && len(m.matches) > 0 {
+			m.selected[m.matches[m.cursor]] = true
+		}
+		m.confirmed = true
+		m.quitting = true
+		return m, tea.Quit
+	case tea.KeySpace:
+		if len(m.matches) > 0 {
+			idx := m.matches[m.cursor]
+			if m.selected[idx] {
+				delete(m.selected, idx)
+			} else {
+				m.selected[idx] = true
+			}
+		}
+	case tea.KeyCtrlA:
+		m.toggleAllMatches()
+	case tea.KeyUp, tea.KeyCtrlP:
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case tea.KeyDown, tea.KeyCtrlN:
+		if m.cursor < len(m.matches)-1 {
+			m.cursor++
+		}
+	case tea.KeyBackspace:
+		if len(m.query) > 0 {
+			m.query = m.query[:len(m.query)-1]
+			m.filter()
+		}
+	case tea.KeyRunes:
+		m.query = append(m.query, msg.Runes...)
+		m.filter()
+	}
+	m.scroll()
+	return m, nil
+}
+
+// toggleAllMatches selects every shown package, or clears them when all
+// are already selected.
+func (m *fuzzyModel) toggleAllMatches() {
+	all := true
+	for _, idx := range m.matches {
+		if !m.selected[idx] {
+			all = false
+			break
+		}
+	}
+	for _, idx := range m.matches {
+		if all {
+			delete(m.selected, idx)
+		} else {
+			m.selected[idx] = true
+		}
+	}
+}
+
+// filter recomputes the matches for the query and resets the cursor.
+func (m *fuzzyModel) filter() {
+	type scored struct {
+		idx   int
+		score int
+	}
+	var found []scored
+	for i, name := range m.packages {
+		if score, ok := fuzzyMatch(string(m.query), name); ok {
+			found = append(found, scored{idx: i, score: score})
+		}
+	}
+	sort.SliceStable(found, func(i, j int) bool {
+		return found[i].score > found[j].score
+	})
+
+	m.matches = make([]int, len(found))
+	for i, f := range found {
+		m.matches[i] = f.idx
+	}
+	m.cursor = 0
+	m.offset = 0
+}
+
+// visibleRows returns how many packages fit between header and footer.
+func (m fuzzyModel) visibleRows() int {
+	rows := m.height - 7
+	if rows < 3 {
+		rows = 3
+	}
+	return rows
+}
+
+// scroll keeps the cursor inside the visible window.
+func (m *fuzzyModel) scroll() {
+	rows := m.visibleRows()
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+rows {
+		m.offset = m.cursor - rows + 1
+	}
+}
+
+// result returns the selected packages in their original order.
+func (m fuzzyModel) result() []string {
+	selected := make([]string, 0, len(m.selected))
+	for i, name := range m.packages {
+		if m.selected[i] {
+			selected = append(selected, name)
+		}
+	}
+	return selected
+}
+
+func (m fuzzyModel) View() string {
+	if m.quitting {
+		return ""
+	}
+
+	theme := m.theme
+	headerStyle := theme.Foreground(theme.Info).Bold(true)
+	dimStyle := theme.Foreground(theme.Dim)
+	cursorStyle := theme.Foreground(theme.Cursor).Bold(true)
+	selectedStyle := theme.Foreground(theme.Selected)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n", headerStyle.Render(fmt.Sprintf("Package Selection (%d/%d selected)", len(m.selected), len(m.packages))))
+	fmt.Fprintf(&b, "%s %s\n", cursorStyle.Render("❯"), string(m.query))
+	fmt.Fprintf(&b, "%s\n", dimStyle.Render(fmt.Sprintf("%d of %d packages match", len(m.matches), len(m.packages))))
+
+	end := m.offset + m.visibleRows()
+	if end > len(m.matches) {
+		end = len(m.matches)
+	}
+	for pos := m.offset; pos < end; pos++ {
+		idx := m.matches[pos]
+		box := "[ ]"
+		if m.selected[idx] {
+			box = selectedStyle.Render("[x]")
+		}
+		line := box + " " + m.packages[idx]
+		if pos == m.cursor {
+			line = cursorStyle.Render("›") + " " + line
+		} else {
+			line = "  " + line
+		}
+		b.WriteString(line + "\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(dimStyle.Render("Type to filter | ↑↓: move | Space: toggle | Ctrl+A: all shown | Enter: confirm | Esc: clear/cancel"))
+	return b.String()
+}
+
+// fuzzyMatch reports whether the characters of query appear in name in
+// order, ignoring case, and scores the match. Matches at the start of the
+// name, after a separator, or directly after the previous matched character
+// score higher. An empty query matches everything with score zero.
+func fuzzyMatch(query, name string) (int, bool) {
+	q := []rune(strings.ToLower(query))
+	if len(q) == 0 {
+		return 0, true
+	}
+	n := []rune(strings.ToLower(name))
+
+	score, qi, last := 0, 0, -2
+	for ni := 0; ni < len(n) && qi < len(q); ni++ {
+		if n[ni] != q[qi] {
+			continue
+		}
+		score++
+		switch {
+		case ni == 0:
+			score += 3
+		case !unicode.IsLetter(n[ni-1]) && !unicode.IsDigit(n[ni-1]):
+			score += 2
+		}
+		if ni == last+1 {
+			score += 2
+		}
+		last = ni
+		qi++
+	}
+	if qi < len(q) {
+		return 0, false
+	}
+	// Prefer shorter names among equal matches
+	return score*100 - len(n), true
+}
@@ -0,0 +1,151 @@
+package selector
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yaklabco/dot/internal/cli/render"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		query string
+		name  string
+		match bool
+	}{
+		{"", "anything", true},
+		{"vim", "dot-vim", true},
+		{"dv", "dot-vim", true},
+		{"NV", "nvim", true},
+		{"miv", "vim", false},
+		{"zshx", "zsh", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query+"/"+tt.name, func(t *testing.T) {
+			_, ok := fuzzyMatch(tt.query, tt.name)
+			assert.Equal(t, tt.match, ok)
+		})
+	}
+}
+
+func TestFuzzyMatch_RanksContiguousAndPrefixHigher(t *testing.T) {
+	prefix, _ := fuzzyMatch("git", "gitconfig")
+	scattered, _ := fuzzyMatch("git", "go-install-tools")
+	assert.Greater(t, prefix, scattered)
+
+	shorter, _ := fuzzyMatch("vim", "vim")
+	longer, _ := fuzzyMatch("vim", "vimrc")
+	assert.Greater(t, shorter, longer)
+}
+
+// press feeds keys to the model one at a time.
+func press(m fuzzyModel, keys ...tea.KeyMsg) fuzzyModel {
+	for _, key := range keys {
+		next, _ := m.Update(key)
+		m = next.(fuzzyModel)
+	}
+	return m
+}
+
+func typed(s string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func TestFuzzyModel_FilterAndToggle(t *testing.T) {
+	m := newFuzzyModel([]string{"bash", "git", "nvim", "vim", "zsh"}, render.DefaultTheme())
+	assert.Len(t, m.matches, 5)
+
+	m = press(m, typed("v"), typed("im"))
+	require.Len(t, m.matches, 2)
+	assert.Equal(t, "vim", m.packages[m.matches[0]], "exact prefix ranks first")
+
+	// Toggle both matches, then untoggle the first
+	m = press(m,
+		tea.KeyMsg{Type: tea.KeySpace},
+		tea.KeyMsg{Type: tea.KeyDown},
+		tea.KeyMsg{Type: tea.KeySpace},
+		tea.KeyMsg{Type: tea.KeyUp},
+		tea.KeyMsg{Type: tea.KeySpace},
+	)
+	assert.Equal(t, []string{"nvim"}, m.result())
+
+	// Backspacing widens the list again and keeps the selection
+	m = press(m, tea.KeyMsg{Type: tea.KeyBackspace}, tea.KeyMsg{Type: tea.KeyBackspace})
+	assert.Len(t, m.matches, 2)
+	m = press(m, tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Len(t, m.matches, 5)
+	assert.False(t, m.quitting, "escape with a query only clears it")
+
+	m = press(m, tea.KeyMsg{Type: tea.KeyEnter})
+	assert.True(t, m.confirmed)
+	assert.Equal(t, []string{"nvim"}, m.result())
+}
+
+func TestFuzzyModel_EnterPicksHighlighted(t *testing.T) {
+	m := newFuzzyModel([]string{"bash", "git", "zsh"}, render.DefaultTheme())
+
+	m = press(m, typed("zs"), tea.KeyMsg{Type: tea.KeyEnter})
+	assert.True(t, m.confirmed)
+	assert.Equal(t, []string{"zsh"}, m.result())
+}
+
+func TestFuzzyModel_ToggleAllShown(t *testing.T) {
+	m := newFuzzyModel([]string{"bash", "git", "zsh"}, render.DefaultTheme())
+
+	m = press(m, typed("s"), tea.KeyMsg{Type: tea.KeyCtrlA})
+	assert.Equal(t, []string{"bash", "zsh"}, m.result())
+
+	m = press(m, tea.KeyMsg{Type: tea.KeyCtrlA})
+	assert.Empty(t, m.result())
+}
+
+func TestFuzzyModel_ScrollsWithCursor(t *testing.T) {
+	packages := make([]string, 20)
+	for i := range packages {
+		packages[i] = strings.Repeat("p", i+1)
+	}
+	m := newFuzzyModel(packages, render.DefaultTheme())
+	next, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 10})
+	m = next.(fuzzyModel)
+
+	for range 10 {
+		m = press(m, tea.KeyMsg{Type: tea.KeyDown})
+	}
+	assert.Equal(t, 10, m.cursor)
+	assert.LessOrEqual(t, m.offset, m.cursor)
+	assert.Less(t, m.cursor, m.offset+m.visibleRows())
+	assert.Contains(t, m.View(), packages[10])
+	assert.NotContains(t, m.View(), "\n  [ ] p\n")
+}
+
+func TestFuzzySelector_Select(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sel := NewFuzzySelector(strings.NewReader("zs \r"), &bytes.Buffer{})
+	selected, err := sel.Select(ctx, []string{"bash", "git", "zsh"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"zsh"}, selected)
+}
+
+func TestFuzzySelector_Cancel(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sel := NewFuzzySelector(strings.NewReader("\x03"), &bytes.Buffer{})
+	_, err := sel.Select(ctx, []string{"bash"})
+	assert.ErrorIs(t, err, ErrSelectionCancelled)
+}
+
+func TestNew_FallsBackWithoutTerminal(t *testing.T) {
+	sel := New(strings.NewReader(""), &bytes.Buffer{}, render.DefaultTheme())
+	assert.IsType(t, &InteractiveSelector{}, sel)
+}
@@ -6,14 +6,11 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"os"
 	"sort"
 	"strconv"
 	"strings"
 
-	"github.com/charmbracelet/lipgloss"
-	"golang.org/x/term"
-
+	"github.com/yaklabco/dot/internal/cli/render"
 	"github.com/yaklabco/dot/internal/cli/terminal"
 )
 
@@ -28,6 +25,7 @@ type PackageSelector interface {
 type InteractiveSelector struct {
 	input  io.Reader
 	output io.Writer
+	theme  render.Theme
 }
 
 // NewInteractiveSelector creates a new interactive selector.
@@ -35,9 +33,16 @@ func NewInteractiveSelector(input io.Reader, output io.Writer) *InteractiveSelec
 	return &InteractiveSelector{
 		input:  input,
 		output: output,
+		theme:  render.DefaultTheme(),
 	}
 }
 
+// WithTheme sets the color theme used for the selection prompt.
+func (s *InteractiveSelector) WithTheme(theme render.Theme) *InteractiveSelector {
+	s.theme = theme
+	return s
+}
+
 // Select prompts the user to select packages interactively.
 func (s *InteractiveSelector) Select(ctx context.Context, packages []string) ([]string, error) {
 	// Handle empty package list
@@ -52,11 +57,12 @@ func (s *InteractiveSelector) Select(ctx context.Context, packages []string) ([]
 	contentWidth := calculateContentWidth(packages, termWidth)
 
 	// Define color styles
-	headerStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("110")).Bold(true) // Muted blue, bold
-	separatorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))         // Dark gray
-	countStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))             // Gray
-	instructionStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))       // Gray
-	promptStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("109")).Bold(true) // Muted cyan, bold
+	theme := s.theme
+	headerStyle := theme.Foreground(theme.Info).Bold(true)
+	separatorStyle := theme.Foreground(theme.Dim)
+	countStyle := theme.Foreground(theme.Dim)
+	instructionStyle := theme.Foreground(theme.Dim)
+	promptStyle := theme.Foreground(theme.Cursor).Bold(true)
 
 	// Display header
 	fmt.Fprintln(s.output, headerStyle.Render("Package Selection"))
@@ -64,7 +70,7 @@ func (s *InteractiveSelector) Select(ctx context.Context, packages []string) ([]
 	fmt.Fprintf(s.output, "%s\n\n", countStyle.Render(fmt.Sprintf("%d packages available", len(packages))))
 
 	// Display packages in columns
-	formatted := formatPackagesMultiColumn(packages, termWidth)
+	formatted := formatPackagesMultiColumn(packages, termWidth, theme)
 	fmt.Fprint(s.output, formatted)
 
 	// Display footer
@@ -94,7 +100,6 @@ func (s *InteractiveSelector) Select(ctx context.Context, packages []string) ([]
 
 		input := strings.TrimSpace(scanner.Text())
 		if input == "" {
-			promptStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("109")).Bold(true)
 			fmt.Fprint(s.output, promptStyle.Render("❯")+" ")
 			continue
 		}
@@ -102,8 +107,7 @@ func (s *InteractiveSelector) Select(ctx context.Context, packages []string) ([]
 		// Parse selection
 		indices, err := parseSelection(input, len(packages))
 		if err != nil {
-			warningStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("179")) // Muted gold
-			promptStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("109")).Bold(true)
+			warningStyle := theme.Foreground(theme.Warning)
 			fmt.Fprintf(s.output, "\n%s Invalid selection: %v\n", warningStyle.Render("⚠"), err)
 			fmt.Fprint(s.output, promptStyle.Render("❯")+" ")
 			continue
@@ -225,15 +229,10 @@ func parseRange(rangeStr string, maxIndex int) ([]int, error) {
 	return indices, nil
 }
 
-// getTerminalWidth returns the width of the terminal.
+// getTerminalWidth returns the output width from COLUMNS or the terminal.
 // Returns 80 as a default fallback if detection fails.
 func getTerminalWidth() int {
-	fd := terminal.FdInt(os.Stdout.Fd())
-	width, _, err := term.GetSize(fd)
-	if err != nil || width == 0 {
-		return 80 // Default fallback
-	}
-	return width
+	return terminal.Width(0)
 }
 
 // min returns the smaller of two integers.
@@ -246,7 +245,7 @@ func min(a, b int) int {
 
 // formatPackagesMultiColumn formats packages in a multi-column grid layout.
 // Returns a formatted string with packages arranged in columns for better readability.
-func formatPackagesMultiColumn(packages []string, termWidth int) string {
+func formatPackagesMultiColumn(packages []string, termWidth int, theme render.Theme) string {
 	if len(packages) == 0 {
 		return ""
 	}
@@ -286,8 +285,7 @@ func formatPackagesMultiColumn(packages []string, termWidth int) string {
 	numRows := (len(packages) + numCols - 1) / numCols
 
 	// Define color styles
-	numberStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("109"))  // Muted cyan
-	packageStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("252")) // Light gray/white
+	numberStyle := theme.Foreground(theme.Cursor)
 
 	var result strings.Builder
 	for row := 0; row < numRows; row++ {
@@ -304,7 +302,7 @@ func formatPackagesMultiColumn(packages []string, termWidth int) string {
 
 			result.WriteString(numberStyle.Render(numberStr))
 			result.WriteString("  ")
-			result.WriteString(packageStyle.Render(packageStr))
+			result.WriteString(packageStr)
 
 			// Add spacing between columns (but not after last column)
 			if col < numCols-1 && idx < len(packages)-1 {
@@ -0,0 +1,32 @@
+package terminal
+
+import "os"
+
+// ColorFromEnv reports the color preference set by the environment.
+//
+// FORCE_COLOR or CLICOLOR_FORCE force color on, even when output is not a
+// terminal; a value of "0" or "false" does not force. NO_COLOR set to any
+// value turns color off (https://no-color.org/). Forcing takes precedence
+// over NO_COLOR. ok is false when none of these are set, leaving the
+// decision to terminal detection.
+func ColorFromEnv() (enabled, ok bool) {
+	for _, name := range []string{"FORCE_COLOR", "CLICOLOR_FORCE"} {
+		if forces(os.Getenv(name)) {
+			return true, true
+		}
+	}
+	if os.Getenv("NO_COLOR") != "" {
+		return false, true
+	}
+	return false, false
+}
+
+// forces reports whether a FORCE_COLOR style value turns color on.
+func forces(value string) bool {
+	switch value {
+	case "", "0", "false":
+		return false
+	default:
+		return true
+	}
+}
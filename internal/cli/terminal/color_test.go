@@ -0,0 +1,41 @@
+package terminal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestColorFromEnv(t *testing.T) {
+	tests := []struct {
+		name        string
+		env         map[string]string
+		wantEnabled bool
+		wantOK      bool
+	}{
+		{"nothing set", nil, false, false},
+		{"NO_COLOR", map[string]string{"NO_COLOR": "1"}, false, true},
+		{"NO_COLOR any value", map[string]string{"NO_COLOR": "false"}, false, true},
+		{"FORCE_COLOR", map[string]string{"FORCE_COLOR": "1"}, true, true},
+		{"FORCE_COLOR level", map[string]string{"FORCE_COLOR": "3"}, true, true},
+		{"CLICOLOR_FORCE", map[string]string{"CLICOLOR_FORCE": "1"}, true, true},
+		{"FORCE_COLOR zero", map[string]string{"FORCE_COLOR": "0"}, false, false},
+		{"FORCE_COLOR false", map[string]string{"FORCE_COLOR": "false"}, false, false},
+		{"CLICOLOR_FORCE zero", map[string]string{"CLICOLOR_FORCE": "0"}, false, false},
+		{"FORCE_COLOR over NO_COLOR", map[string]string{"FORCE_COLOR": "1", "NO_COLOR": "1"}, true, true},
+		{"CLICOLOR_FORCE over NO_COLOR", map[string]string{"CLICOLOR_FORCE": "1", "NO_COLOR": "1"}, true, true},
+		{"FORCE_COLOR zero with NO_COLOR", map[string]string{"FORCE_COLOR": "0", "NO_COLOR": "1"}, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range []string{"FORCE_COLOR", "CLICOLOR_FORCE", "NO_COLOR"} {
+				t.Setenv(name, tt.env[name])
+			}
+
+			enabled, ok := ColorFromEnv()
+			assert.Equal(t, tt.wantEnabled, enabled)
+			assert.Equal(t, tt.wantOK, ok)
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package terminal
+
+import (
+	"os"
+	"strconv"
+
+	"golang.org/x/term"
+)
+
+// DefaultWidth is the output width used when no other source provides one.
+const DefaultWidth = 80
+
+// Width returns the width to wrap and size output to.
+//
+// configured, the output.width setting, wins when positive. Otherwise a
+// positive COLUMNS environment variable is used, so piped output and tests
+// can fix the width, then the width of the terminal on stdout, then
+// DefaultWidth.
+func Width(configured int) int {
+	if configured > 0 {
+		return configured
+	}
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+	if width, _, err := term.GetSize(FdInt(os.Stdout.Fd())); err == nil && width > 0 {
+		return width
+	}
+	return DefaultWidth
+}
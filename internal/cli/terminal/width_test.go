@@ -0,0 +1,67 @@
+package terminal
+
+import (
+	"os"
+	"testing"
+
+	"github.com/creack/pty"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// withStdout replaces os.Stdout with f for the duration of the test.
+func withStdout(t *testing.T, f *os.File) {
+	t.Helper()
+	original := os.Stdout
+	os.Stdout = f
+	t.Cleanup(func() { os.Stdout = original })
+}
+
+// withPipeStdout replaces os.Stdout with a pipe, which has no terminal size.
+func withPipeStdout(t *testing.T) {
+	t.Helper()
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	t.Cleanup(func() { r.Close(); w.Close() })
+	withStdout(t, w)
+}
+
+func TestWidth_Configured(t *testing.T) {
+	t.Setenv("COLUMNS", "100")
+	withPipeStdout(t)
+
+	assert.Equal(t, 72, Width(72))
+}
+
+func TestWidth_Columns(t *testing.T) {
+	t.Setenv("COLUMNS", "100")
+	withPipeStdout(t)
+
+	assert.Equal(t, 100, Width(0))
+}
+
+func TestWidth_Terminal(t *testing.T) {
+	ptmx, tty, err := pty.Open()
+	if err != nil {
+		t.Skip("pty not available")
+	}
+	defer ptmx.Close()
+	defer tty.Close()
+	require.NoError(t, pty.Setsize(ptmx, &pty.Winsize{Rows: 24, Cols: 132}))
+
+	t.Setenv("COLUMNS", "")
+	withStdout(t, tty)
+
+	assert.Equal(t, 132, Width(0))
+}
+
+func TestWidth_Default(t *testing.T) {
+	withPipeStdout(t)
+
+	for _, columns := range []string{"", "0", "-5", "wide"} {
+		t.Run("COLUMNS="+columns, func(t *testing.T) {
+			t.Setenv("COLUMNS", columns)
+			assert.Equal(t, DefaultWidth, Width(0))
+		})
+	}
+}
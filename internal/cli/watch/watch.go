@@ -0,0 +1,121 @@
+// Package watch re-runs work when watched directories change.
+package watch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// DefaultDelay is how long the filesystem must be quiet before a burst of
+// events triggers a rerun. An editor save typically produces several events
+// within a few milliseconds.
+const DefaultDelay = 300 * time.Millisecond
+
+// Watcher reports changes in watched directories.
+type Watcher interface {
+	// Add starts watching a directory. Watches are not recursive.
+	Add(path string) error
+	// Events delivers the path of each change. It is closed by Close.
+	Events() <-chan string
+	// Errors delivers watch errors. It is closed by Close.
+	Errors() <-chan error
+	// Close stops watching and releases resources.
+	Close() error
+}
+
+// fsWatcher is a Watcher backed by fsnotify.
+type fsWatcher struct {
+	w      *fsnotify.Watcher
+	events chan string
+}
+
+// NewFSWatcher creates a Watcher for the local filesystem.
+func NewFSWatcher() (Watcher, error) {
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("create watcher: %w", err)
+	}
+	fw := &fsWatcher{w: w, events: make(chan string)}
+	go fw.forward()
+	return fw, nil
+}
+
+// forward translates fsnotify events until the underlying watcher closes.
+func (fw *fsWatcher) forward() {
+	defer close(fw.events)
+	for event := range fw.w.Events {
+		// Permission changes alone do not affect link health
+		if event.Op == fsnotify.Chmod {
+			continue
+		}
+		fw.events <- event.Name
+	}
+}
+
+func (fw *fsWatcher) Add(path string) error { return fw.w.Add(path) }
+func (fw *fsWatcher) Events() <-chan string { return fw.events }
+func (fw *fsWatcher) Errors() <-chan error  { return fw.w.Errors }
+func (fw *fsWatcher) Close() error          { return fw.w.Close() }
+
+// AddTree watches root and every directory below it, skipping directories
+// for which skip returns true. Unreadable directories are ignored.
+func AddTree(w Watcher, root string, skip func(name string) bool) error {
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == root {
+				return err
+			}
+			return nil
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if path != root && skip != nil && skip(d.Name()) {
+			return filepath.SkipDir
+		}
+		if err := w.Add(path); err != nil {
+			return fmt.Errorf("watch %s: %w", path, err)
+		}
+		return nil
+	})
+}
+
+// Run calls fn once, then again after each burst of changes, once no event
+// has arrived for delay. It returns nil when ctx is cancelled or the watcher
+// is closed, and an error if the watcher fails. An event queue overflow is
+// treated as a change.
+func Run(ctx context.Context, w Watcher, delay time.Duration, fn func(context.Context)) error {
+	fn(ctx)
+
+	timer := time.NewTimer(delay)
+	timer.Stop()
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case _, ok := <-w.Events():
+			if !ok {
+				return nil
+			}
+			timer.Reset(delay)
+		case err, ok := <-w.Errors():
+			if !ok {
+				return nil
+			}
+			if !errors.Is(err, fsnotify.ErrEventOverflow) {
+				return fmt.Errorf("watch: %w", err)
+			}
+			timer.Reset(delay)
+		case <-timer.C:
+			fn(ctx)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package watch
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeWatcher is a Watcher whose events are sent by the test.
+type fakeWatcher struct {
+	mu     sync.Mutex
+	paths  []string
+	events chan string
+	errs   chan error
+}
+
+func newFakeWatcher() *fakeWatcher {
+	return &fakeWatcher{events: make(chan string), errs: make(chan error)}
+}
+
+func (f *fakeWatcher) Add(path string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.paths = append(f.paths, path)
+	return nil
+}
+
+func (f *fakeWatcher) Events() <-chan string { return f.events }
+func (f *fakeWatcher) Errors() <-chan error  { return f.errs }
+
+func (f *fakeWatcher) Close() error {
+	close(f.events)
+	close(f.errs)
+	return nil
+}
+
+// runAsync starts Run in the background and returns a channel receiving one
+// value per fn call, and a channel receiving Run's result.
+func runAsync(ctx context.Context, w Watcher, delay time.Duration) (<-chan struct{}, <-chan error) {
+	calls := make(chan struct{}, 10)
+	done := make(chan error, 1)
+	go func() {
+		done <- Run(ctx, w, delay, func(context.Context) { calls <- struct{}{} })
+	}()
+	return calls, done
+}
+
+func waitCall(t *testing.T, calls <-chan struct{}) {
+	t.Helper()
+	select {
+	case <-calls:
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for rerun")
+	}
+}
+
+func TestRun_DebouncesBurst(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	w := newFakeWatcher()
+
+	calls, done := runAsync(ctx, w, 20*time.Millisecond)
+	waitCall(t, calls) // initial run
+
+	for i := 0; i < 5; i++ {
+		w.events <- "/home/user/.vimrc"
+	}
+	waitCall(t, calls)
+
+	select {
+	case <-calls:
+		t.Fatal("burst should trigger a single rerun")
+	case <-time.After(60 * time.Millisecond):
+	}
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func TestRun_StopsWhenWatcherCloses(t *testing.T) {
+	w := newFakeWatcher()
+
+	calls, done := runAsync(context.Background(), w, time.Millisecond)
+	waitCall(t, calls)
+
+	require.NoError(t, w.Close())
+	require.NoError(t, <-done)
+}
+
+func TestRun_Errors(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	w := newFakeWatcher()
+
+	calls, done := runAsync(ctx, w, time.Millisecond)
+	waitCall(t, calls)
+
+	// Overflow means events were lost, so it reruns
+	w.errs <- fsnotify.ErrEventOverflow
+	waitCall(t, calls)
+
+	w.errs <- errors.New("watch limit reached")
+	err := <-done
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "watch limit reached")
+}
+
+func TestAddTree(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "vim", "dot-vim", "colors"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, ".git", "objects"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "vim", "dot-vimrc"), []byte("set nu"), 0644))
+
+	w := newFakeWatcher()
+	err := AddTree(w, root, func(name string) bool { return name == ".git" })
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{
+		root,
+		filepath.Join(root, "vim"),
+		filepath.Join(root, "vim", "dot-vim"),
+		filepath.Join(root, "vim", "dot-vim", "colors"),
+	}, w.paths)
+}
+
+func TestAddTree_MissingRoot(t *testing.T) {
+	err := AddTree(newFakeWatcher(), filepath.Join(t.TempDir(), "missing"), nil)
+	require.Error(t, err)
+}
+
+func TestFSWatcher_ReportsChanges(t *testing.T) {
+	dir := t.TempDir()
+	w, err := NewFSWatcher()
+	require.NoError(t, err)
+	defer w.Close()
+	require.NoError(t, w.Add(dir))
+
+	path := filepath.Join(dir, ".vimrc")
+	require.NoError(t, os.Symlink("/nowhere", path))
+
+	select {
+	case got := <-w.Events():
+		assert.Equal(t, path, got)
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for event")
+	}
+}
@@ -10,11 +10,12 @@ const (
 	DefaultLogDestination = "stderr" // Default log destination (stderr, stdout, file)
 
 	// Symlink defaults
-	DefaultSymlinkMode         = "relative" // Default symlink mode (relative, absolute)
-	DefaultSymlinkFolding      = true       // Enable directory folding optimization
-	DefaultSymlinkOverwrite    = false      // Do not overwrite existing files (safe default)
-	DefaultSymlinkBackup       = false      // Do not create backups (explicit opt-in)
-	DefaultSymlinkBackupSuffix = ".bak"     // Default backup file suffix
+	DefaultSymlinkMode           = "relative"    // Default symlink mode (relative, absolute, auto)
+	DefaultSymlinkFolding        = false         // Link file by file so existing layouts are kept (explicit opt-in)
+	DefaultSymlinkOverwrite      = false         // Do not overwrite existing files (safe default)
+	DefaultSymlinkBackup         = false         // Do not create backups (explicit opt-in)
+	DefaultSymlinkBackupSuffix   = ".bak"        // Default backup file suffix
+	DefaultSymlinkBackupStrategy = "timestamped" // Keep every backup (timestamped, overwrite)
 
 	// Dotfile translation defaults
 	DefaultDotfileTranslate          = true   // Enable dot- to . translation
@@ -22,16 +23,20 @@ const (
 	DefaultDotfilePackageNameMapping = true   // Enable package name to target directory mapping (pre-1.0 breaking change)
 
 	// Output defaults
-	DefaultOutputFormat    = "text" // Default output format (text, json, yaml, table)
-	DefaultOutputColor     = "auto" // Default color mode (auto, always, never)
-	DefaultOutputProgress  = true   // Show progress indicators
-	DefaultOutputVerbosity = 1      // Default verbosity (0=quiet, 1=normal, 2=verbose, 3=debug)
-	DefaultOutputWidth     = 0      // Terminal width (0 = auto-detect)
+	DefaultOutputFormat    = "text"    // Default output format (text, json, yaml, table)
+	DefaultOutputColor     = "auto"    // Default color mode (auto, always, never)
+	DefaultOutputProgress  = true      // Show progress indicators
+	DefaultOutputVerbosity = 1         // Default verbosity (0=quiet, 1=normal, 2=verbose, 3=debug)
+	DefaultOutputWidth     = 0         // Terminal width (0 = auto-detect)
+	DefaultOutputTheme     = "default" // Color theme (default, solarized, nocolor, high-contrast, light, dark)
 
 	// Operations defaults
-	DefaultOperationsDryRun      = false // Execute operations (not dry-run)
-	DefaultOperationsAtomic      = true  // Enable atomic operations with rollback
-	DefaultOperationsMaxParallel = 0     // Max parallel operations (0 = auto-detect CPU count)
+	DefaultOperationsDryRun             = false // Execute operations (not dry-run)
+	DefaultOperationsAtomic             = true  // Enable atomic operations with rollback
+	DefaultOperationsMaxParallel        = 0     // Max parallel operations (0 = auto-detect CPU count)
+	DefaultOperationsTransactionSize    = 0     // Max operations per transaction (0 = single transaction)
+	DefaultOperationsRetryMaxAttempts   = 3     // Tries per operation on transient filesystem errors
+	DefaultOperationsRetryBaseBackoffMS = 100   // Milliseconds before the first retry, doubling after
 
 	// Packages defaults
 	DefaultPackagesSortBy        = "name" // Default sort order (name, links, date)
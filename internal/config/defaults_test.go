@@ -20,7 +20,7 @@ func TestDefaultValueConstants(t *testing.T) {
 
 		// Symlink defaults
 		{name: "DefaultSymlinkMode", constant: DefaultSymlinkMode, expected: "relative", desc: "default symlink mode"},
-		{name: "DefaultSymlinkFolding", constant: DefaultSymlinkFolding, expected: true, desc: "default symlink folding"},
+		{name: "DefaultSymlinkFolding", constant: DefaultSymlinkFolding, expected: false, desc: "default symlink folding"},
 		{name: "DefaultSymlinkOverwrite", constant: DefaultSymlinkOverwrite, expected: false, desc: "default symlink overwrite"},
 		{name: "DefaultSymlinkBackup", constant: DefaultSymlinkBackup, expected: false, desc: "default symlink backup"},
 		{name: "DefaultSymlinkBackupSuffix", constant: DefaultSymlinkBackupSuffix, expected: ".bak", desc: "default backup suffix"},
@@ -40,6 +40,9 @@ func TestDefaultValueConstants(t *testing.T) {
 		{name: "DefaultOperationsDryRun", constant: DefaultOperationsDryRun, expected: false, desc: "default dry run mode"},
 		{name: "DefaultOperationsAtomic", constant: DefaultOperationsAtomic, expected: true, desc: "default atomic operations"},
 		{name: "DefaultOperationsMaxParallel", constant: DefaultOperationsMaxParallel, expected: 0, desc: "default max parallel (auto)"},
+		{name: "DefaultOperationsTransactionSize", constant: DefaultOperationsTransactionSize, expected: 0, desc: "default transaction size (single transaction)"},
+		{name: "DefaultOperationsRetryMaxAttempts", constant: DefaultOperationsRetryMaxAttempts, expected: 3, desc: "default retry attempts"},
+		{name: "DefaultOperationsRetryBaseBackoffMS", constant: DefaultOperationsRetryBaseBackoffMS, expected: 100, desc: "default retry backoff"},
 
 		// Packages defaults
 		{name: "DefaultPackagesSortBy", constant: DefaultPackagesSortBy, expected: "name", desc: "default package sort"},
@@ -141,11 +144,11 @@ func TestDefaultValueSemantics(t *testing.T) {
 		assert.False(t, DefaultDoctorAutoFix, "auto-fix should default to false (explicit action)")
 		assert.False(t, DefaultSymlinkOverwrite, "overwrite should default to false (safe)")
 		assert.False(t, DefaultSymlinkBackup, "backup should default to false (explicit opt-in)")
+		assert.False(t, DefaultSymlinkFolding, "folding should default to false (keeps existing link layouts)")
 	})
 
 	t.Run("user-friendly defaults", func(t *testing.T) {
 		// Defaults should be user-friendly
-		assert.True(t, DefaultSymlinkFolding, "folding should be enabled (convenience)")
 		assert.True(t, DefaultDotfileTranslate, "dotfile translation should be enabled (convenience)")
 		assert.True(t, DefaultOutputProgress, "progress should be enabled (feedback)")
 		assert.Equal(t, "auto", DefaultOutputColor, "color should auto-detect (smart default)")
@@ -170,7 +173,7 @@ func TestDefaultValueSemantics(t *testing.T) {
 		validFormats := []string{"text", "json", "yaml", "table"}
 		assert.Contains(t, validFormats, DefaultOutputFormat, "output format should be valid")
 
-		validModes := []string{"relative", "absolute"}
+		validModes := []string{"relative", "absolute", "auto"}
 		assert.Contains(t, validModes, DefaultSymlinkMode, "symlink mode should be valid")
 
 		validColors := []string{"auto", "always", "never"}
@@ -0,0 +1,96 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/yaklabco/dot/internal/domain"
+)
+
+// pathField is a path-typed configuration field whose value may reference
+// environment variables.
+type pathField struct {
+	key   string
+	value *string
+	// required fields report undefined variables as validation errors.
+	required bool
+}
+
+func (c *ExtendedConfig) pathFields() []pathField {
+	return []pathField{
+		{KeyDirPackage, &c.Directories.Package, true},
+		{KeyDirTarget, &c.Directories.Target, true},
+		{KeyDirManifest, &c.Directories.Manifest, false},
+		{KeySymlinkBackupDir, &c.Symlinks.BackupDir, false},
+		{KeyLogFile, &c.Logging.File, c.Logging.Destination == "file"},
+	}
+}
+
+// expandPaths expands a leading ~ and ${VAR} and $VAR references in the
+// path fields using the process environment. Undefined variables expand
+// to empty; each one in a required path is returned as a FieldError.
+func (c *ExtendedConfig) expandPaths() []error {
+	var errs []error
+	for _, field := range c.pathFields() {
+		var undefined []string
+		*field.value = expandHome(os.Expand(*field.value, func(name string) string {
+			value, ok := os.LookupEnv(name)
+			if !ok {
+				undefined = append(undefined, name)
+			}
+			return value
+		}))
+		if field.required {
+			for _, name := range undefined {
+				errs = append(errs, fieldError(field.key, "undefined environment variable $%s", name))
+			}
+		}
+	}
+	return errs
+}
+
+// validateExpanded validates cfg after expandPaths, reporting expandErrs
+// first. Other problems with a field that has an undefined variable are
+// dropped, since they follow from the variable.
+func validateExpanded(cfg *ExtendedConfig, expandErrs []error) error {
+	err := cfg.Validate()
+	if len(expandErrs) == 0 {
+		return err
+	}
+
+	reported := make(map[string]bool, len(expandErrs))
+	for _, expandErr := range expandErrs {
+		var fieldErr FieldError
+		if errors.As(expandErr, &fieldErr) {
+			reported[fieldErr.Field] = true
+		}
+	}
+
+	errs := expandErrs
+	var multi domain.ErrMultiple
+	if errors.As(err, &multi) {
+		for _, validationErr := range multi.Errors {
+			var fieldErr FieldError
+			if errors.As(validationErr, &fieldErr) && reported[fieldErr.Field] {
+				continue
+			}
+			errs = append(errs, validationErr)
+		}
+	}
+	return domain.ErrMultiple{Errors: errs}
+}
+
+// expandHome replaces a leading ~ in path with the home directory. Paths
+// naming another user's home (~user) are returned unchanged.
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[1:])
+}
@@ -0,0 +1,206 @@
+package config_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yaklabco/dot/internal/config"
+	"github.com/yaklabco/dot/internal/domain"
+)
+
+// unsetEnv removes name from the environment for the duration of the test.
+func unsetEnv(t *testing.T, name string) {
+	t.Helper()
+	t.Setenv(name, "")
+	require.NoError(t, os.Unsetenv(name))
+}
+
+// fieldErrors returns the FieldErrors held by err, keyed by field.
+func fieldErrors(t *testing.T, err error) map[string]string {
+	t.Helper()
+	var multi domain.ErrMultiple
+	require.True(t, errors.As(err, &multi), "error %v should be an ErrMultiple", err)
+	fields := make(map[string]string)
+	for _, e := range multi.Errors {
+		var fieldErr config.FieldError
+		require.True(t, errors.As(e, &fieldErr), "error %v should be a FieldError", e)
+		fields[fieldErr.Field] = fieldErr.Message
+	}
+	return fields
+}
+
+func TestLoadFromFile_ExpandsPathVariables(t *testing.T) {
+	t.Setenv("DOT_TEST_HOME", "/home/tester")
+	t.Setenv("DOT_TEST_STATE", "/var/state/dot")
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfigFile(t, configPath, `
+directories:
+  package: ${DOT_TEST_HOME}/dotfiles
+  target: $DOT_TEST_HOME
+  manifest: ${DOT_TEST_STATE}/manifest
+symlinks:
+  backup_dir: $DOT_TEST_STATE/backups
+logging:
+  destination: file
+  file: ${DOT_TEST_STATE}/logs/dot.log
+`)
+
+	cfg, err := config.LoadExtendedFromFile(configPath)
+	require.NoError(t, err)
+
+	assert.Equal(t, "/home/tester/dotfiles", cfg.Directories.Package)
+	assert.Equal(t, "/home/tester", cfg.Directories.Target)
+	assert.Equal(t, "/var/state/dot/manifest", cfg.Directories.Manifest)
+	assert.Equal(t, "/var/state/dot/backups", cfg.Symlinks.BackupDir)
+	assert.Equal(t, "/var/state/dot/logs/dot.log", cfg.Logging.File)
+}
+
+func TestLoadFromFile_ExpandsHome(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfigFile(t, configPath, `
+directories:
+  package: ~/dotfiles
+  target: ~
+symlinks:
+  backup_dir: ~user/backups
+logging:
+  destination: file
+  file: ~/.local/state/dot/dot.log
+`)
+
+	cfg, err := config.LoadExtendedFromFile(configPath)
+	require.NoError(t, err)
+
+	assert.Equal(t, filepath.Join(home, "dotfiles"), cfg.Directories.Package)
+	assert.Equal(t, home, cfg.Directories.Target)
+	assert.Equal(t, "~user/backups", cfg.Symlinks.BackupDir, "other users' homes are not expanded")
+	assert.Equal(t, filepath.Join(home, ".local", "state", "dot", "dot.log"), cfg.Logging.File)
+}
+
+func TestLoadFromFile_ExpandsNestedSeparators(t *testing.T) {
+	t.Setenv("DOT_TEST_ROOT", "/srv/users/tester/")
+	t.Setenv("DOT_TEST_SUB", "dotfiles/main")
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfigFile(t, configPath, `
+directories:
+  package: ${DOT_TEST_ROOT}repos/${DOT_TEST_SUB}/packages
+  target: ${DOT_TEST_ROOT}
+`)
+
+	cfg, err := config.LoadExtendedFromFile(configPath)
+	require.NoError(t, err)
+
+	// Separators inside values are kept; paths are not cleaned
+	assert.Equal(t, "/srv/users/tester/repos/dotfiles/main/packages", cfg.Directories.Package)
+	assert.Equal(t, "/srv/users/tester/", cfg.Directories.Target)
+}
+
+func TestLoadFromFile_UndefinedPathVariables(t *testing.T) {
+	unsetEnv(t, "DOT_TEST_UNDEFINED")
+	unsetEnv(t, "DOT_TEST_MISSING")
+	t.Setenv("DOT_TEST_EMPTY", "")
+
+	t.Run("required paths fail validation", func(t *testing.T) {
+		configPath := filepath.Join(t.TempDir(), "config.yaml")
+		writeConfigFile(t, configPath, `
+directories:
+  package: ${DOT_TEST_UNDEFINED}/dotfiles
+  target: $DOT_TEST_MISSING
+`)
+
+		_, err := config.LoadExtendedFromFile(configPath)
+		require.Error(t, err)
+		assert.Equal(t, map[string]string{
+			"directories.package": "undefined environment variable $DOT_TEST_UNDEFINED",
+			"directories.target":  "undefined environment variable $DOT_TEST_MISSING",
+		}, fieldErrors(t, err), "the undefined variable replaces the empty target error")
+	})
+
+	t.Run("optional paths expand to empty", func(t *testing.T) {
+		configPath := filepath.Join(t.TempDir(), "config.yaml")
+		writeConfigFile(t, configPath, `
+directories:
+  manifest: ${DOT_TEST_UNDEFINED}
+symlinks:
+  backup_dir: ${DOT_TEST_UNDEFINED}
+`)
+
+		cfg, err := config.LoadExtendedFromFile(configPath)
+		require.NoError(t, err)
+		assert.Empty(t, cfg.Directories.Manifest)
+		assert.Empty(t, cfg.Symlinks.BackupDir)
+	})
+
+	t.Run("defined but empty is not undefined", func(t *testing.T) {
+		configPath := filepath.Join(t.TempDir(), "config.yaml")
+		writeConfigFile(t, configPath, `
+directories:
+  package: ${DOT_TEST_EMPTY}/dotfiles
+`)
+
+		cfg, err := config.LoadExtendedFromFile(configPath)
+		require.NoError(t, err)
+		assert.Equal(t, "/dotfiles", cfg.Directories.Package)
+	})
+
+	t.Run("log file required only for file destination", func(t *testing.T) {
+		configPath := filepath.Join(t.TempDir(), "config.yaml")
+		writeConfigFile(t, configPath, `
+logging:
+  destination: file
+  file: ${DOT_TEST_UNDEFINED}/dot.log
+`)
+
+		_, err := config.LoadExtendedFromFile(configPath)
+		require.Error(t, err)
+		assert.Equal(t, map[string]string{
+			"logging.file": "undefined environment variable $DOT_TEST_UNDEFINED",
+		}, fieldErrors(t, err))
+	})
+}
+
+func TestLoader_Include_ExpandsPathVariables(t *testing.T) {
+	t.Setenv("DOT_TEST_HOME", "/home/tester")
+	tmpDir := t.TempDir()
+	mainPath := filepath.Join(tmpDir, "config.yaml")
+	writeConfigFile(t, filepath.Join(tmpDir, "shared.yaml"), `
+directories:
+  package: ${DOT_TEST_HOME}/dotfiles
+`)
+	writeConfigFile(t, mainPath, `
+include:
+  - shared.yaml
+directories:
+  target: ${DOT_TEST_HOME}
+`)
+
+	cfg, err := config.NewLoader("dot", mainPath).Load()
+	require.NoError(t, err)
+	assert.Equal(t, "/home/tester/dotfiles", cfg.Directories.Package)
+	assert.Equal(t, "/home/tester", cfg.Directories.Target)
+}
+
+func TestWriter_UpdateKeepsPathVariables(t *testing.T) {
+	t.Setenv("DOT_TEST_HOME", "/home/tester")
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	writeConfigFile(t, configPath, `{"directories": {"package": "${DOT_TEST_HOME}/dotfiles", "target": "$DOT_TEST_HOME"}}`)
+
+	require.NoError(t, config.NewWriter(configPath).Update("logging.level", "DEBUG"))
+
+	data, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"${DOT_TEST_HOME}/dotfiles"`)
+	assert.Contains(t, string(data), `"$DOT_TEST_HOME"`)
+
+	cfg, err := config.LoadExtendedFromFile(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, "/home/tester/dotfiles", cfg.Directories.Package)
+	assert.Equal(t, "DEBUG", cfg.Logging.Level)
+}
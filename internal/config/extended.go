@@ -4,24 +4,38 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/viper"
+
+	"github.com/yaklabco/dot/internal/domain"
 )
 
 // ExtendedConfig contains all application configuration with comprehensive settings.
 type ExtendedConfig struct {
-	Directories  DirectoriesConfig  `mapstructure:"directories" json:"directories" yaml:"directories" toml:"directories"`
-	Logging      LoggingConfig      `mapstructure:"logging" json:"logging" yaml:"logging" toml:"logging"`
-	Symlinks     SymlinksConfig     `mapstructure:"symlinks" json:"symlinks" yaml:"symlinks" toml:"symlinks"`
-	Ignore       IgnoreConfig       `mapstructure:"ignore" json:"ignore" yaml:"ignore" toml:"ignore"`
-	Dotfile      DotfileConfig      `mapstructure:"dotfile" json:"dotfile" yaml:"dotfile" toml:"dotfile"`
-	Output       OutputConfig       `mapstructure:"output" json:"output" yaml:"output" toml:"output"`
-	Operations   OperationsConfig   `mapstructure:"operations" json:"operations" yaml:"operations" toml:"operations"`
-	Packages     PackagesConfig     `mapstructure:"packages" json:"packages" yaml:"packages" toml:"packages"`
-	Doctor       DoctorConfig       `mapstructure:"doctor" json:"doctor" yaml:"doctor" toml:"doctor"`
-	Update       UpdateConfig       `mapstructure:"update" json:"update" yaml:"update" toml:"update"`
-	Network      NetworkConfig      `mapstructure:"network" json:"network" yaml:"network" toml:"network"`
+	// Include lists other config files merged beneath this one.
+	// Relative paths resolve against the including file's directory.
+	Include []string `mapstructure:"include" json:"include,omitempty" yaml:"include,omitempty" toml:"include,omitempty"`
+
+	Directories DirectoriesConfig `mapstructure:"directories" json:"directories" yaml:"directories" toml:"directories"`
+	Logging     LoggingConfig     `mapstructure:"logging" json:"logging" yaml:"logging" toml:"logging"`
+	Symlinks    SymlinksConfig    `mapstructure:"symlinks" json:"symlinks" yaml:"symlinks" toml:"symlinks"`
+	Ignore      IgnoreConfig      `mapstructure:"ignore" json:"ignore" yaml:"ignore" toml:"ignore"`
+	Dotfile     DotfileConfig     `mapstructure:"dotfile" json:"dotfile" yaml:"dotfile" toml:"dotfile"`
+	Output      OutputConfig      `mapstructure:"output" json:"output" yaml:"output" toml:"output"`
+	Operations  OperationsConfig  `mapstructure:"operations" json:"operations" yaml:"operations" toml:"operations"`
+	Packages    PackagesConfig    `mapstructure:"packages" json:"packages" yaml:"packages" toml:"packages"`
+	Doctor      DoctorConfig      `mapstructure:"doctor" json:"doctor" yaml:"doctor" toml:"doctor"`
+	Update      UpdateConfig      `mapstructure:"update" json:"update" yaml:"update" toml:"update"`
+	Network     NetworkConfig     `mapstructure:"network" json:"network" yaml:"network" toml:"network"`
+	Hooks       HooksConfig       `mapstructure:"hooks" json:"hooks" yaml:"hooks" toml:"hooks"`
+
+	// CLIProfiles maps preset names to command defaults applied with
+	// --profile-preset.
+	CLIProfiles map[string]CLIProfileConfig `mapstructure:"cli_profiles" json:"cli_profiles,omitempty" yaml:"cli_profiles,omitempty" toml:"cli_profiles,omitempty"`
+
 	Experimental ExperimentalConfig `mapstructure:"experimental" json:"experimental" yaml:"experimental" toml:"experimental"`
 }
 
@@ -50,11 +64,23 @@ type LoggingConfig struct {
 
 	// Log file path (only used if destination is "file")
 	File string `mapstructure:"file" json:"file" yaml:"file" toml:"file"`
+
+	// Rotate the log file once it exceeds this size in megabytes (0 = never)
+	MaxSizeMB int `mapstructure:"max_size_mb" json:"max_size_mb" yaml:"max_size_mb" toml:"max_size_mb"`
+
+	// Number of rotated log files to keep
+	MaxBackups int `mapstructure:"max_backups" json:"max_backups" yaml:"max_backups" toml:"max_backups"`
+
+	// Rotate the log file once it is older than this many days (0 = never)
+	MaxAgeDays int `mapstructure:"max_age_days" json:"max_age_days" yaml:"max_age_days" toml:"max_age_days"`
+
+	// Glob patterns for paths whose values are masked in log output
+	RedactPaths []string `mapstructure:"redact_paths" json:"redact_paths" yaml:"redact_paths" toml:"redact_paths"`
 }
 
 // SymlinksConfig contains symlink behavior configuration.
 type SymlinksConfig struct {
-	// Link mode: relative, absolute
+	// Link mode: relative, absolute, auto
 	Mode string `mapstructure:"mode" json:"mode" yaml:"mode" toml:"mode"`
 
 	// Enable directory folding optimization
@@ -69,6 +95,9 @@ type SymlinksConfig struct {
 	// Backup suffix when backups enabled
 	BackupSuffix string `mapstructure:"backup_suffix" json:"backup_suffix" yaml:"backup_suffix" toml:"backup_suffix"`
 
+	// Backup naming: timestamped keeps every backup in a per-run subdirectory, overwrite reuses <name><backup_suffix>
+	BackupStrategy string `mapstructure:"backup_strategy" json:"backup_strategy" yaml:"backup_strategy" toml:"backup_strategy"`
+
 	// Directory for backup files (default: <target>/.dot-backup)
 	BackupDir string `mapstructure:"backup_dir" json:"backup_dir" yaml:"backup_dir" toml:"backup_dir"`
 }
@@ -119,6 +148,12 @@ type OutputConfig struct {
 	// Table style: default (modern with borders), simple (legacy plain text)
 	TableStyle string `mapstructure:"table_style" json:"table_style" yaml:"table_style" toml:"table_style"`
 
+	// Color theme: default, solarized, nocolor, high-contrast, light, dark
+	Theme string `mapstructure:"theme" json:"theme" yaml:"theme" toml:"theme"`
+
+	// Per-role color overrides applied on top of the theme
+	Colors ColorsConfig `mapstructure:"colors" json:"colors,omitempty" yaml:"colors,omitempty" toml:"colors,omitempty"`
+
 	// Show progress indicators
 	Progress bool `mapstructure:"progress" json:"progress" yaml:"progress" toml:"progress"`
 
@@ -129,6 +164,62 @@ type OutputConfig struct {
 	Width int `mapstructure:"width" json:"width" yaml:"width" toml:"width"`
 }
 
+// ColorsConfig overrides individual colors of the output theme. Each value
+// is a 256-color palette code from 0 to 255; an empty value keeps the
+// theme's color.
+type ColorsConfig struct {
+	Success string `mapstructure:"success" json:"success,omitempty" yaml:"success,omitempty" toml:"success,omitempty"`
+	Warning string `mapstructure:"warning" json:"warning,omitempty" yaml:"warning,omitempty" toml:"warning,omitempty"`
+	Error   string `mapstructure:"error" json:"error,omitempty" yaml:"error,omitempty" toml:"error,omitempty"`
+	Info    string `mapstructure:"info" json:"info,omitempty" yaml:"info,omitempty" toml:"info,omitempty"`
+	Dim     string `mapstructure:"dim" json:"dim,omitempty" yaml:"dim,omitempty" toml:"dim,omitempty"`
+	Accent  string `mapstructure:"accent" json:"accent,omitempty" yaml:"accent,omitempty" toml:"accent,omitempty"`
+	// Heading colors bold section headings
+	Heading string `mapstructure:"heading" json:"heading,omitempty" yaml:"heading,omitempty" toml:"heading,omitempty"`
+}
+
+// ColorRole is a color role and its configured palette code.
+type ColorRole struct {
+	Name string
+	Code string
+}
+
+// colorRoles lists each color role with its field, in declaration order.
+// Validation, environment binding, merging and theme overrides all iterate
+// it, so a new role is only added here.
+var colorRoles = []struct {
+	name  string
+	field func(*ColorsConfig) *string
+}{
+	{"success", func(c *ColorsConfig) *string { return &c.Success }},
+	{"warning", func(c *ColorsConfig) *string { return &c.Warning }},
+	{"error", func(c *ColorsConfig) *string { return &c.Error }},
+	{"info", func(c *ColorsConfig) *string { return &c.Info }},
+	{"dim", func(c *ColorsConfig) *string { return &c.Dim }},
+	{"accent", func(c *ColorsConfig) *string { return &c.Accent }},
+	{"heading", func(c *ColorsConfig) *string { return &c.Heading }},
+}
+
+// Roles lists each role with its configured code, in declaration order.
+func (c ColorsConfig) Roles() []ColorRole {
+	roles := make([]ColorRole, len(colorRoles))
+	for i, role := range colorRoles {
+		roles[i] = ColorRole{Name: role.name, Code: *role.field(&c)}
+	}
+	return roles
+}
+
+// IsZero reports whether no color is overridden.
+func (c ColorsConfig) IsZero() bool {
+	return c == ColorsConfig{}
+}
+
+// validColorCode reports whether code is a 256-color palette code.
+func validColorCode(code string) bool {
+	n, err := strconv.Atoi(code)
+	return err == nil && n >= 0 && n <= 255 && strconv.Itoa(n) == code
+}
+
 // OperationsConfig contains operation behavior configuration.
 type OperationsConfig struct {
 	// Enable dry-run mode by default
@@ -139,6 +230,23 @@ type OperationsConfig struct {
 
 	// Maximum number of parallel operations (0 = auto-detect CPU count)
 	MaxParallel int `mapstructure:"max_parallel" json:"max_parallel" yaml:"max_parallel" toml:"max_parallel"`
+
+	// Maximum operations per transaction (0 = whole plan in one transaction)
+	TransactionSize int `mapstructure:"transaction_size" json:"transaction_size" yaml:"transaction_size" toml:"transaction_size"`
+
+	// Retry of operations that fail with a transient filesystem error
+	Retry RetryConfig `mapstructure:"retry" json:"retry" yaml:"retry" toml:"retry"`
+}
+
+// RetryConfig retries an operation that fails with a transient filesystem
+// error (EAGAIN, EBUSY, EINTR, ETIMEDOUT), as network filesystems report
+// while a file is briefly locked. Other errors are never retried.
+type RetryConfig struct {
+	// Times an operation is tried, including the first (0 or 1 = no retry)
+	MaxAttempts int `mapstructure:"max_attempts" json:"max_attempts" yaml:"max_attempts" toml:"max_attempts"`
+
+	// Milliseconds to wait before the first retry, doubling for each retry after
+	BaseBackoffMS int `mapstructure:"base_backoff_ms" json:"base_backoff_ms" yaml:"base_backoff_ms" toml:"base_backoff_ms"`
 }
 
 // PackagesConfig contains package management configuration.
@@ -151,6 +259,10 @@ type PackagesConfig struct {
 
 	// Package naming convention validation
 	ValidateNames bool `mapstructure:"validate_names" json:"validate_names" yaml:"validate_names" toml:"validate_names"`
+
+	// Explicit target subpaths per package name, taking precedence over
+	// dotfile.package_name_mapping (e.g. dot-work-ssh: .ssh)
+	Mappings map[string]string `mapstructure:"mappings" json:"mappings,omitempty" yaml:"mappings,omitempty" toml:"mappings,omitempty"`
 }
 
 // DoctorConfig contains doctor command configuration.
@@ -169,6 +281,78 @@ type DoctorConfig struct {
 
 	// Check file permissions
 	CheckPermissions bool `mapstructure:"check_permissions" json:"check_permissions" yaml:"check_permissions" toml:"check_permissions"`
+
+	// Additional symlink categories for orphan triage, merged with the
+	// built-in categories. A category with a built-in name replaces it.
+	Categories []CategoryConfig `mapstructure:"categories" json:"categories,omitempty" yaml:"categories,omitempty" toml:"categories,omitempty"`
+}
+
+// CategoryConfig describes an orphan triage category: symlinks whose target
+// matches one of Patterns belong to it.
+type CategoryConfig struct {
+	// Unique category name
+	Name string `mapstructure:"name" json:"name" yaml:"name" toml:"name"`
+
+	// Human-readable description shown during triage
+	Description string `mapstructure:"description" json:"description,omitempty" yaml:"description,omitempty" toml:"description,omitempty"`
+
+	// Confidence that links in this category are safe to ignore: high, medium, low
+	Confidence string `mapstructure:"confidence" json:"confidence,omitempty" yaml:"confidence,omitempty" toml:"confidence,omitempty"`
+
+	// Glob patterns matched against symlink targets
+	Patterns []string `mapstructure:"patterns" json:"patterns" yaml:"patterns" toml:"patterns"`
+}
+
+// HooksConfig lists commands run before and after managing and unmanaging
+// packages.
+type HooksConfig struct {
+	// Commands run before packages are managed; a failure aborts manage
+	PreManage []HookConfig `mapstructure:"pre_manage" json:"pre_manage,omitempty" yaml:"pre_manage,omitempty" toml:"pre_manage,omitempty"`
+
+	// Commands run after packages are managed; a failure is a warning
+	PostManage []HookConfig `mapstructure:"post_manage" json:"post_manage,omitempty" yaml:"post_manage,omitempty" toml:"post_manage,omitempty"`
+
+	// Commands run before packages are unmanaged; a failure aborts unmanage
+	PreUnmanage []HookConfig `mapstructure:"pre_unmanage" json:"pre_unmanage,omitempty" yaml:"pre_unmanage,omitempty" toml:"pre_unmanage,omitempty"`
+
+	// Commands run after packages are unmanaged; a failure is a warning
+	PostUnmanage []HookConfig `mapstructure:"post_unmanage" json:"post_unmanage,omitempty" yaml:"post_unmanage,omitempty" toml:"post_unmanage,omitempty"`
+}
+
+// HookConfig describes one hook command.
+type HookConfig struct {
+	// Program and arguments, passed without shell expansion
+	Command []string `mapstructure:"command" json:"command" yaml:"command" toml:"command"`
+
+	// Run the single command element as a script with sh -c
+	Shell bool `mapstructure:"shell" json:"shell,omitempty" yaml:"shell,omitempty" toml:"shell,omitempty"`
+
+	// Packages the hook runs for; empty means every package
+	Packages []string `mapstructure:"packages" json:"packages,omitempty" yaml:"packages,omitempty" toml:"packages,omitempty"`
+}
+
+// CLIProfileConfig is a named preset of command defaults, selected with
+// --profile-preset. Each field replaces the default of the setting named
+// in its comment; unset fields leave the default alone. Unlike bootstrap
+// profiles, presets do not select packages.
+type CLIProfileConfig struct {
+	// Preview changes without applying them (operations.dry_run)
+	DryRun *bool `mapstructure:"dry_run" json:"dry_run,omitempty" yaml:"dry_run,omitempty" toml:"dry_run,omitempty"`
+
+	// Link whole directories where possible (symlinks.folding)
+	Folding *bool `mapstructure:"folding" json:"folding,omitempty" yaml:"folding,omitempty" toml:"folding,omitempty"`
+
+	// Link mode: relative, absolute, auto (symlinks.mode)
+	LinkMode string `mapstructure:"link_mode" json:"link_mode,omitempty" yaml:"link_mode,omitempty" toml:"link_mode,omitempty"`
+
+	// Back up conflicting files before replacing them (symlinks.backup)
+	Backup *bool `mapstructure:"backup" json:"backup,omitempty" yaml:"backup,omitempty" toml:"backup,omitempty"`
+
+	// Overwrite conflicting files (symlinks.overwrite)
+	Overwrite *bool `mapstructure:"overwrite" json:"overwrite,omitempty" yaml:"overwrite,omitempty" toml:"overwrite,omitempty"`
+
+	// Backup naming: timestamped, overwrite (symlinks.backup_strategy)
+	BackupStrategy string `mapstructure:"backup_strategy" json:"backup_strategy,omitempty" yaml:"backup_strategy,omitempty" toml:"backup_strategy,omitempty"`
 }
 
 // UpdateConfig contains update and upgrade configuration.
@@ -187,6 +371,9 @@ type UpdateConfig struct {
 
 	// Enable pre-release versions
 	IncludePrerelease bool `mapstructure:"include_prerelease" json:"include_prerelease" yaml:"include_prerelease" toml:"include_prerelease"`
+
+	// Hours to reuse the detected package manager (0 = detect every time)
+	DetectionCacheTTL int `mapstructure:"detection_cache_ttl" json:"detection_cache_ttl" yaml:"detection_cache_ttl" toml:"detection_cache_ttl"`
 }
 
 // NetworkConfig contains network and HTTP configuration.
@@ -208,6 +395,11 @@ type NetworkConfig struct {
 
 	// TLS handshake timeout in seconds (0 = use default 5s)
 	TLSTimeout int `mapstructure:"tls_timeout" json:"tls_timeout" yaml:"tls_timeout" toml:"tls_timeout"`
+
+	// Retries of a git clone that failed with a transient network error
+	// (0 = no retries). The wait before the first retry is the connection
+	// timeout and doubles with each further attempt.
+	CloneRetries int `mapstructure:"clone_retries" json:"clone_retries" yaml:"clone_retries" toml:"clone_retries"`
 }
 
 // ExperimentalConfig contains experimental feature flags.
@@ -219,6 +411,30 @@ type ExperimentalConfig struct {
 	Profiling bool `mapstructure:"profiling" json:"profiling" yaml:"profiling" toml:"profiling"`
 }
 
+// Allowed values for enumerated fields. These are shared by Validate and
+// GenerateSchema so the two cannot drift apart.
+var (
+	validLogLevels        = []string{"DEBUG", "INFO", "WARN", "ERROR"}
+	validLogFormats       = []string{"text", "json"}
+	validLogDestinations  = []string{"stderr", "stdout", "file"}
+	validSymlinkModes     = []string{"relative", "absolute", "auto"}
+	validBackupStrategies = []string{"timestamped", "overwrite"}
+	validOutputFormats    = []string{"text", "json", "yaml", "table"}
+	validColorModes       = []string{"auto", "always", "never"}
+	validThemes           = []string{"default", "solarized", "nocolor", "high-contrast", "light", "dark"}
+	validSortFields       = []string{"name", "links", "date"}
+	validPackageManagers  = []string{"auto", "brew", "apt", "yum", "pacman", "dnf", "zypper", "manual"}
+
+	validCategoryConfidences = []string{"high", "medium", "low"}
+)
+
+// Numeric bounds shared by Validate and GenerateSchema.
+const (
+	minVerbosity      = 0
+	maxVerbosity      = 3
+	minCheckFrequency = -1
+)
+
 // DefaultExtended returns extended configuration with sensible defaults.
 func DefaultExtended() *ExtendedConfig {
 	homeDir, _ := os.UserHomeDir()
@@ -236,14 +452,19 @@ func DefaultExtended() *ExtendedConfig {
 			Level:       "INFO",
 			Format:      "text",
 			Destination: "stderr",
-			File:        getXDGStatePath("dot/dot.log"),
+			File:        XDGStatePath("dot/dot.log"),
+			MaxSizeMB:   10,
+			MaxBackups:  5,
+			MaxAgeDays:  0,
+			RedactPaths: []string{},
 		},
 		Symlinks: SymlinksConfig{
-			Mode:         "relative",
-			Folding:      true,
-			Overwrite:    false,
-			Backup:       false,
-			BackupSuffix: ".bak",
+			Mode:           "relative",
+			Folding:        false,
+			Overwrite:      false,
+			Backup:         false,
+			BackupSuffix:   ".bak",
+			BackupStrategy: "timestamped",
 		},
 		Ignore: IgnoreConfig{
 			UseDefaults:           true,
@@ -262,14 +483,20 @@ func DefaultExtended() *ExtendedConfig {
 			Format:     "text",
 			Color:      "auto",
 			TableStyle: "default",
+			Theme:      "default",
 			Progress:   true,
 			Verbosity:  1,
 			Width:      0,
 		},
 		Operations: OperationsConfig{
-			DryRun:      false,
-			Atomic:      true,
-			MaxParallel: 0,
+			DryRun:          false,
+			Atomic:          true,
+			MaxParallel:     0,
+			TransactionSize: 0,
+			Retry: RetryConfig{
+				MaxAttempts:   DefaultOperationsRetryMaxAttempts,
+				BaseBackoffMS: DefaultOperationsRetryBaseBackoffMS,
+			},
 		},
 		Packages: PackagesConfig{
 			SortBy:        "name",
@@ -289,6 +516,7 @@ func DefaultExtended() *ExtendedConfig {
 			PackageManager:    "auto",
 			Repository:        "yaklabco/dot",
 			IncludePrerelease: false,
+			DetectionCacheTTL: 24,
 		},
 		Network: NetworkConfig{
 			HTTPProxy:      "", // Empty = use environment or no proxy
@@ -297,6 +525,7 @@ func DefaultExtended() *ExtendedConfig {
 			Timeout:        10, // 10 seconds total timeout
 			ConnectTimeout: 5,  // 5 seconds connection timeout
 			TLSTimeout:     5,  // 5 seconds TLS handshake timeout
+			CloneRetries:   3,  // 3 retries of a transiently failed clone
 		},
 		Experimental: ExperimentalConfig{
 			Parallel:  false,
@@ -306,7 +535,23 @@ func DefaultExtended() *ExtendedConfig {
 }
 
 // LoadExtendedFromFile loads extended configuration from specified file.
+// Environment variables in path fields are expanded.
 func LoadExtendedFromFile(path string) (*ExtendedConfig, error) {
+	cfg, err := readExtendedFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateExpanded(cfg, cfg.expandPaths()); err != nil {
+		return nil, fmt.Errorf("validate config: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// readExtendedFile reads the file at path over the defaults, leaving
+// environment variable references unexpanded and the result unvalidated.
+func readExtendedFile(path string) (*ExtendedConfig, error) {
 	v := viper.New()
 	v.SetConfigFile(path)
 
@@ -319,102 +564,133 @@ func LoadExtendedFromFile(path string) (*ExtendedConfig, error) {
 		return nil, fmt.Errorf("unmarshal config: %w", err)
 	}
 
-	if err := cfg.Validate(); err != nil {
-		return nil, fmt.Errorf("validate config: %w", err)
-	}
-
 	return cfg, nil
 }
 
+// FieldError is a validation problem with a single configuration field.
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+func (e FieldError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+func fieldError(field, format string, args ...any) error {
+	return FieldError{Field: field, Message: fmt.Sprintf(format, args...)}
+}
+
 // Validate checks configuration for errors.
+// Every problem is reported: the returned error is a domain.ErrMultiple
+// holding one FieldError per invalid field.
 func (c *ExtendedConfig) Validate() error {
-	if err := c.validateDirectories(); err != nil {
-		return err
-	}
-	if err := c.validateLogging(); err != nil {
-		return err
-	}
-	if err := c.validateSymlinks(); err != nil {
-		return err
-	}
-	if err := c.validateIgnore(); err != nil {
-		return err
-	}
-	if err := c.validateDotfile(); err != nil {
-		return err
-	}
-	if err := c.validateOutput(); err != nil {
-		return err
-	}
-	if err := c.validateOperations(); err != nil {
-		return err
-	}
-	if err := c.validatePackages(); err != nil {
-		return err
-	}
-	if err := c.validateUpdate(); err != nil {
-		return err
-	}
-	if err := c.validateNetwork(); err != nil {
-		return err
+	var errs []error
+	errs = append(errs, c.validateDirectories()...)
+	errs = append(errs, c.validateLogging()...)
+	errs = append(errs, c.validateSymlinks()...)
+	errs = append(errs, c.validateIgnore()...)
+	errs = append(errs, c.validateDotfile()...)
+	errs = append(errs, c.validateOutput()...)
+	errs = append(errs, c.validateOperations()...)
+	errs = append(errs, c.validatePackages()...)
+	errs = append(errs, c.validateDoctor()...)
+	errs = append(errs, c.validateUpdate()...)
+	errs = append(errs, c.validateNetwork()...)
+	errs = append(errs, c.validateHooks()...)
+	errs = append(errs, c.validateCLIProfiles()...)
+
+	if len(errs) > 0 {
+		return domain.ErrMultiple{Errors: errs}
 	}
-
 	return nil
 }
 
-func (c *ExtendedConfig) validateDirectories() error {
+// Warnings returns problems that do not prevent the configuration from
+// being used, such as an unknown output theme that falls back to default.
+func (c *ExtendedConfig) Warnings() []string {
+	var warnings []string
+	if c.Output.Theme != "" && !contains(validThemes, c.Output.Theme) {
+		warnings = append(warnings, fmt.Sprintf("output.theme: unknown theme %q, using default (must be one of: %s)",
+			c.Output.Theme, strings.Join(validThemes, ", ")))
+	}
+	return warnings
+}
+
+func (c *ExtendedConfig) validateDirectories() []error {
+	var errs []error
 	if c.Directories.Package == "" {
-		return fmt.Errorf("directories.package: package directory cannot be empty")
+		errs = append(errs, fieldError("directories.package", "package directory cannot be empty"))
 	}
 
 	if c.Directories.Target == "" {
-		return fmt.Errorf("directories.target: target directory cannot be empty")
+		errs = append(errs, fieldError("directories.target", "target directory cannot be empty"))
 	}
 
-	return nil
+	return errs
 }
 
-func (c *ExtendedConfig) validateLogging() error {
-	validLevels := []string{"DEBUG", "INFO", "WARN", "ERROR"}
-	if !contains(validLevels, c.Logging.Level) {
-		return fmt.Errorf("logging.level: invalid log level %q (must be one of: %s)",
-			c.Logging.Level, strings.Join(validLevels, ", "))
+func (c *ExtendedConfig) validateLogging() []error {
+	var errs []error
+	if !contains(validLogLevels, c.Logging.Level) {
+		errs = append(errs, fieldError("logging.level", "invalid log level %q (must be one of: %s)",
+			c.Logging.Level, strings.Join(validLogLevels, ", ")))
 	}
 
-	validFormats := []string{"text", "json"}
-	if !contains(validFormats, c.Logging.Format) {
-		return fmt.Errorf("logging.format: invalid log format %q (must be one of: %s)",
-			c.Logging.Format, strings.Join(validFormats, ", "))
+	if !contains(validLogFormats, c.Logging.Format) {
+		errs = append(errs, fieldError("logging.format", "invalid log format %q (must be one of: %s)",
+			c.Logging.Format, strings.Join(validLogFormats, ", ")))
 	}
 
-	validDestinations := []string{"stderr", "stdout", "file"}
-	if !contains(validDestinations, c.Logging.Destination) {
-		return fmt.Errorf("logging.destination: invalid log destination %q (must be one of: %s)",
-			c.Logging.Destination, strings.Join(validDestinations, ", "))
+	if !contains(validLogDestinations, c.Logging.Destination) {
+		errs = append(errs, fieldError("logging.destination", "invalid log destination %q (must be one of: %s)",
+			c.Logging.Destination, strings.Join(validLogDestinations, ", ")))
 	}
 
 	if c.Logging.Destination == "file" && c.Logging.File == "" {
-		return fmt.Errorf("logging.file: log file must be specified when destination is 'file'")
+		errs = append(errs, fieldError("logging.file", "log file must be specified when destination is 'file'"))
 	}
 
-	return nil
+	if c.Logging.MaxSizeMB < 0 {
+		errs = append(errs, fieldError("logging.max_size_mb", "must be non-negative, got %d", c.Logging.MaxSizeMB))
+	}
+	if c.Logging.MaxBackups < 0 {
+		errs = append(errs, fieldError("logging.max_backups", "must be non-negative, got %d", c.Logging.MaxBackups))
+	}
+	if c.Logging.MaxAgeDays < 0 {
+		errs = append(errs, fieldError("logging.max_age_days", "must be non-negative, got %d", c.Logging.MaxAgeDays))
+	}
+	for i, pattern := range c.Logging.RedactPaths {
+		if _, err := filepath.Match(pattern, "test"); err != nil {
+			errs = append(errs, fieldError(fmt.Sprintf("logging.redact_paths[%d]", i), "invalid glob pattern %q: %v", pattern, err))
+		}
+	}
+
+	return errs
 }
 
-func (c *ExtendedConfig) validateSymlinks() error {
-	validModes := []string{"relative", "absolute"}
-	if !contains(validModes, c.Symlinks.Mode) {
-		return fmt.Errorf("symlinks.mode: invalid symlink mode %q (must be one of: %s)",
-			c.Symlinks.Mode, strings.Join(validModes, ", "))
+func (c *ExtendedConfig) validateSymlinks() []error {
+	var errs []error
+	if !contains(validSymlinkModes, c.Symlinks.Mode) {
+		errs = append(errs, fieldError("symlinks.mode", "invalid symlink mode %q (must be one of: %s)",
+			c.Symlinks.Mode, strings.Join(validSymlinkModes, ", ")))
 	}
 
 	if c.Symlinks.Backup && c.Symlinks.BackupSuffix == "" {
-		return fmt.Errorf("symlinks.backup_suffix: backup suffix cannot be empty when backup is enabled")
+		errs = append(errs, fieldError("symlinks.backup_suffix", "backup suffix cannot be empty when backup is enabled"))
 	}
 
-	return nil
+	if c.Symlinks.BackupStrategy != "" && !contains(validBackupStrategies, c.Symlinks.BackupStrategy) {
+		errs = append(errs, fieldError("symlinks.backup_strategy", "invalid backup strategy %q (must be one of: %s)",
+			c.Symlinks.BackupStrategy, strings.Join(validBackupStrategies, ", ")))
+	}
+
+	return errs
 }
 
-func (c *ExtendedConfig) validateIgnore() error {
+func (c *ExtendedConfig) validateIgnore() []error {
+	var errs []error
+
 	// Validate ignore patterns are valid globs
 	for i, pattern := range c.Ignore.Patterns {
 		// Skip negation prefix for validation
@@ -423,113 +699,236 @@ func (c *ExtendedConfig) validateIgnore() error {
 			testPattern = pattern[1:]
 		}
 		if _, err := filepath.Match(testPattern, "test"); err != nil {
-			return fmt.Errorf("ignore.patterns[%d]: invalid glob pattern %q: %w", i, pattern, err)
+			errs = append(errs, fieldError(fmt.Sprintf("ignore.patterns[%d]", i), "invalid glob pattern %q: %v", pattern, err))
 		}
 	}
 
 	// Validate override patterns
 	for i, pattern := range c.Ignore.Overrides {
 		if _, err := filepath.Match(pattern, "test"); err != nil {
-			return fmt.Errorf("ignore.overrides[%d]: invalid glob pattern %q: %w", i, pattern, err)
+			errs = append(errs, fieldError(fmt.Sprintf("ignore.overrides[%d]", i), "invalid glob pattern %q: %v", pattern, err))
 		}
 	}
 
 	// Validate max file size is non-negative
 	if c.Ignore.MaxFileSize < 0 {
-		return fmt.Errorf("ignore.max_file_size: must be non-negative (got %d)", c.Ignore.MaxFileSize)
+		errs = append(errs, fieldError("ignore.max_file_size", "must be non-negative (got %d)", c.Ignore.MaxFileSize))
 	}
 
-	return nil
+	return errs
 }
 
-func (c *ExtendedConfig) validateDotfile() error {
+func (c *ExtendedConfig) validateDotfile() []error {
 	if c.Dotfile.Translate && c.Dotfile.Prefix == "" {
-		return fmt.Errorf("dotfile.prefix: dotfile prefix cannot be empty when translate is enabled")
+		return []error{fieldError("dotfile.prefix", "dotfile prefix cannot be empty when translate is enabled")}
 	}
 
 	return nil
 }
 
-func (c *ExtendedConfig) validateOutput() error {
-	validFormats := []string{"text", "json", "yaml", "table"}
-	if !contains(validFormats, c.Output.Format) {
-		return fmt.Errorf("output.format: invalid output format %q (must be one of: %s)",
-			c.Output.Format, strings.Join(validFormats, ", "))
+func (c *ExtendedConfig) validateOutput() []error {
+	var errs []error
+	if !contains(validOutputFormats, c.Output.Format) {
+		errs = append(errs, fieldError("output.format", "invalid output format %q (must be one of: %s)",
+			c.Output.Format, strings.Join(validOutputFormats, ", ")))
 	}
 
-	validColors := []string{"auto", "always", "never"}
-	if !contains(validColors, c.Output.Color) {
-		return fmt.Errorf("output.color: invalid color mode %q (must be one of: %s)",
-			c.Output.Color, strings.Join(validColors, ", "))
+	if !contains(validColorModes, c.Output.Color) {
+		errs = append(errs, fieldError("output.color", "invalid color mode %q (must be one of: %s)",
+			c.Output.Color, strings.Join(validColorModes, ", ")))
 	}
 
-	if c.Output.Verbosity < 0 || c.Output.Verbosity > 3 {
-		return fmt.Errorf("output.verbosity: verbosity must be between 0 and 3, got %d", c.Output.Verbosity)
+	if c.Output.Verbosity < minVerbosity || c.Output.Verbosity > maxVerbosity {
+		errs = append(errs, fieldError("output.verbosity", "verbosity must be between %d and %d, got %d",
+			minVerbosity, maxVerbosity, c.Output.Verbosity))
 	}
 
 	if c.Output.Width < 0 {
-		return fmt.Errorf("output.width: width cannot be negative (use 0 for auto-detect), got %d", c.Output.Width)
+		errs = append(errs, fieldError("output.width", "width cannot be negative (use 0 for auto-detect), got %d", c.Output.Width))
 	}
 
-	return nil
+	for _, role := range c.Output.Colors.Roles() {
+		if role.Code != "" && !validColorCode(role.Code) {
+			errs = append(errs, fieldError("output.colors."+role.Name, "invalid color %q (must be a 256-color code from 0 to 255)", role.Code))
+		}
+	}
+
+	return errs
 }
 
-func (c *ExtendedConfig) validateOperations() error {
+func (c *ExtendedConfig) validateOperations() []error {
+	var errs []error
+
 	if c.Operations.MaxParallel < 0 {
-		return fmt.Errorf("operations.max_parallel: max_parallel cannot be negative (use 0 for auto-detect), got %d",
-			c.Operations.MaxParallel)
+		errs = append(errs, fieldError("operations.max_parallel", "max_parallel cannot be negative (use 0 for auto-detect), got %d",
+			c.Operations.MaxParallel))
 	}
 
-	return nil
+	if c.Operations.TransactionSize < 0 {
+		errs = append(errs, fieldError("operations.transaction_size", "transaction_size cannot be negative (use 0 for a single transaction), got %d",
+			c.Operations.TransactionSize))
+	}
+
+	if c.Operations.Retry.MaxAttempts < 0 {
+		errs = append(errs, fieldError("operations.retry.max_attempts", "max_attempts cannot be negative (use 0 to disable retries), got %d",
+			c.Operations.Retry.MaxAttempts))
+	}
+
+	if c.Operations.Retry.BaseBackoffMS < 0 {
+		errs = append(errs, fieldError("operations.retry.base_backoff_ms", "base_backoff_ms cannot be negative, got %d",
+			c.Operations.Retry.BaseBackoffMS))
+	}
+
+	return errs
 }
 
-func (c *ExtendedConfig) validatePackages() error {
-	validSortBy := []string{"name", "links", "date"}
-	if !contains(validSortBy, c.Packages.SortBy) {
-		return fmt.Errorf("packages.sort_by: invalid sort field %q (must be one of: %s)",
-			c.Packages.SortBy, strings.Join(validSortBy, ", "))
+func (c *ExtendedConfig) validatePackages() []error {
+	var errs []error
+	if !contains(validSortFields, c.Packages.SortBy) {
+		errs = append(errs, fieldError("packages.sort_by", "invalid sort field %q (must be one of: %s)",
+			c.Packages.SortBy, strings.Join(validSortFields, ", ")))
 	}
 
-	return nil
+	names := make([]string, 0, len(c.Packages.Mappings))
+	for name := range c.Packages.Mappings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		subpath := c.Packages.Mappings[name]
+		if err := domain.ValidateTargetSubpath(subpath); err != nil {
+			errs = append(errs, fieldError("packages.mappings."+name,
+				"target %q must be a relative path within the target directory", subpath))
+		}
+	}
+
+	return errs
 }
 
-func (c *ExtendedConfig) validateUpdate() error {
-	if c.Update.CheckFrequency < -1 {
-		return fmt.Errorf("update.check_frequency: check frequency cannot be less than -1, got %d",
-			c.Update.CheckFrequency)
+func (c *ExtendedConfig) validateDoctor() []error {
+	var errs []error
+	seen := make(map[string]bool)
+
+	for i, cat := range c.Doctor.Categories {
+		field := fmt.Sprintf("doctor.categories[%d]", i)
+		if cat.Name == "" {
+			errs = append(errs, fieldError(field+".name", "category name cannot be empty"))
+		} else if seen[cat.Name] {
+			errs = append(errs, fieldError(field+".name", "duplicate category name %q", cat.Name))
+		}
+		seen[cat.Name] = true
+
+		if cat.Confidence != "" && !contains(validCategoryConfidences, cat.Confidence) {
+			errs = append(errs, fieldError(field+".confidence", "invalid confidence %q (must be one of: %s)",
+				cat.Confidence, strings.Join(validCategoryConfidences, ", ")))
+		}
+
+		if len(cat.Patterns) == 0 {
+			errs = append(errs, fieldError(field+".patterns", "category must have at least one pattern"))
+		}
+		for j, pattern := range cat.Patterns {
+			if _, err := filepath.Match(pattern, "test"); err != nil {
+				errs = append(errs, fieldError(fmt.Sprintf("%s.patterns[%d]", field, j), "invalid glob pattern %q: %v", pattern, err))
+			}
+		}
 	}
 
-	validPackageManagers := []string{"auto", "brew", "apt", "yum", "pacman", "dnf", "zypper", "manual"}
-	if !contains(validPackageManagers, c.Update.PackageManager) {
-		return fmt.Errorf("update.package_manager: invalid package manager %q (must be one of: %s)",
-			c.Update.PackageManager, strings.Join(validPackageManagers, ", "))
+	return errs
+}
+
+func (c *ExtendedConfig) validateHooks() []error {
+	var errs []error
+	stages := []struct {
+		key   string
+		hooks []HookConfig
+	}{
+		{"hooks.pre_manage", c.Hooks.PreManage},
+		{"hooks.post_manage", c.Hooks.PostManage},
+		{"hooks.pre_unmanage", c.Hooks.PreUnmanage},
+		{"hooks.post_unmanage", c.Hooks.PostUnmanage},
+	}
+
+	for _, stage := range stages {
+		for i, hook := range stage.hooks {
+			field := fmt.Sprintf("%s[%d].command", stage.key, i)
+			switch {
+			case len(hook.Command) == 0 || hook.Command[0] == "":
+				errs = append(errs, fieldError(field, "hook command cannot be empty"))
+			case hook.Shell && len(hook.Command) != 1:
+				errs = append(errs, fieldError(field, "a shell hook takes a single script, got %d elements", len(hook.Command)))
+			}
+			for _, arg := range hook.Command {
+				if strings.ContainsRune(arg, 0) {
+					errs = append(errs, fieldError(field, "hook command contains a null byte"))
+					break
+				}
+			}
+		}
 	}
 
-	if c.Update.Repository == "" {
-		return fmt.Errorf("update.repository: repository cannot be empty")
+	return errs
+}
+
+func (c *ExtendedConfig) validateCLIProfiles() []error {
+	var errs []error
+	for _, name := range c.CLIProfileNames() {
+		profile := c.CLIProfiles[name]
+		field := "cli_profiles." + name
+		if profile.LinkMode != "" && !contains(validSymlinkModes, profile.LinkMode) {
+			errs = append(errs, fieldError(field+".link_mode", "invalid link mode %q (must be one of: %s)",
+				profile.LinkMode, strings.Join(validSymlinkModes, ", ")))
+		}
+		if profile.BackupStrategy != "" && !contains(validBackupStrategies, profile.BackupStrategy) {
+			errs = append(errs, fieldError(field+".backup_strategy", "invalid backup strategy %q (must be one of: %s)",
+				profile.BackupStrategy, strings.Join(validBackupStrategies, ", ")))
+		}
+	}
+	return errs
+}
+
+func (c *ExtendedConfig) validateUpdate() []error {
+	var errs []error
+	if c.Update.CheckFrequency < minCheckFrequency {
+		errs = append(errs, fieldError("update.check_frequency", "check frequency cannot be less than -1, got %d",
+			c.Update.CheckFrequency))
+	}
+
+	if c.Update.DetectionCacheTTL < 0 {
+		errs = append(errs, fieldError("update.detection_cache_ttl", "detection cache TTL cannot be negative, got %d",
+			c.Update.DetectionCacheTTL))
+	}
+
+	if !contains(validPackageManagers, c.Update.PackageManager) {
+		errs = append(errs, fieldError("update.package_manager", "invalid package manager %q (must be one of: %s)",
+			c.Update.PackageManager, strings.Join(validPackageManagers, ", ")))
 	}
 
 	// Basic validation for repository format (owner/repo)
-	parts := strings.Split(c.Update.Repository, "/")
-	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
-		return fmt.Errorf("update.repository: repository must be in 'owner/repo' format, got %q",
-			c.Update.Repository)
+	if c.Update.Repository == "" {
+		errs = append(errs, fieldError("update.repository", "repository cannot be empty"))
+	} else if parts := strings.Split(c.Update.Repository, "/"); len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		errs = append(errs, fieldError("update.repository", "repository must be in 'owner/repo' format, got %q",
+			c.Update.Repository))
 	}
 
-	return nil
+	return errs
 }
 
-func (c *ExtendedConfig) validateNetwork() error {
+func (c *ExtendedConfig) validateNetwork() []error {
+	var errs []error
 	if c.Network.Timeout < 0 {
-		return fmt.Errorf("network.timeout must be non-negative, got %d", c.Network.Timeout)
+		errs = append(errs, fieldError("network.timeout", "must be non-negative, got %d", c.Network.Timeout))
 	}
 	if c.Network.ConnectTimeout < 0 {
-		return fmt.Errorf("network.connect_timeout must be non-negative, got %d", c.Network.ConnectTimeout)
+		errs = append(errs, fieldError("network.connect_timeout", "must be non-negative, got %d", c.Network.ConnectTimeout))
 	}
 	if c.Network.TLSTimeout < 0 {
-		return fmt.Errorf("network.tls_timeout must be non-negative, got %d", c.Network.TLSTimeout)
+		errs = append(errs, fieldError("network.tls_timeout", "must be non-negative, got %d", c.Network.TLSTimeout))
 	}
-	return nil
+	if c.Network.CloneRetries < 0 {
+		errs = append(errs, fieldError("network.clone_retries", "must be non-negative, got %d", c.Network.CloneRetries))
+	}
+	return errs
 }
 
 // getXDGDataPath returns XDG data directory path.
@@ -544,8 +943,9 @@ func getXDGDataPath(suffix string) string {
 	return filepath.Join(homeDir, ".local", "share", suffix)
 }
 
-// getXDGStatePath returns XDG state directory path.
-func getXDGStatePath(suffix string) string {
+// XDGStatePath returns suffix under the XDG state directory, falling back
+// to ~/.local/state when XDG_STATE_HOME is unset.
+func XDGStatePath(suffix string) string {
 	if stateHome := os.Getenv("XDG_STATE_HOME"); stateHome != "" {
 		return filepath.Join(stateHome, suffix)
 	}
@@ -1,13 +1,16 @@
 package config_test
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/yaklabco/dot/internal/config"
+	"github.com/yaklabco/dot/internal/domain"
 )
 
 func TestExtendedConfig_Default(t *testing.T) {
@@ -27,7 +30,7 @@ func TestExtendedConfig_Default(t *testing.T) {
 
 	// Symlinks
 	assert.Equal(t, "relative", cfg.Symlinks.Mode)
-	assert.True(t, cfg.Symlinks.Folding)
+	assert.False(t, cfg.Symlinks.Folding)
 	assert.False(t, cfg.Symlinks.Overwrite)
 	assert.False(t, cfg.Symlinks.Backup)
 	assert.Equal(t, ".bak", cfg.Symlinks.BackupSuffix)
@@ -72,6 +75,7 @@ func TestExtendedConfig_Default(t *testing.T) {
 	assert.Equal(t, "auto", cfg.Update.PackageManager)
 	assert.Equal(t, "yaklabco/dot", cfg.Update.Repository)
 	assert.False(t, cfg.Update.IncludePrerelease)
+	assert.Equal(t, 24, cfg.Update.DetectionCacheTTL)
 
 	// Experimental
 	assert.False(t, cfg.Experimental.Parallel)
@@ -257,6 +261,34 @@ func TestExtendedConfig_ValidateLogging(t *testing.T) {
 	}
 }
 
+func TestExtendedConfig_ValidateLogRotation(t *testing.T) {
+	cfg := config.DefaultExtended()
+	assert.Equal(t, 10, cfg.Logging.MaxSizeMB)
+	assert.Equal(t, 5, cfg.Logging.MaxBackups)
+	assert.Equal(t, 0, cfg.Logging.MaxAgeDays)
+
+	cfg.Logging.MaxSizeMB = -1
+	cfg.Logging.MaxBackups = -1
+	cfg.Logging.MaxAgeDays = -1
+
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "logging.max_size_mb")
+	assert.Contains(t, err.Error(), "logging.max_backups")
+	assert.Contains(t, err.Error(), "logging.max_age_days")
+}
+
+func TestExtendedConfig_ValidateRedactPaths(t *testing.T) {
+	cfg := config.DefaultExtended()
+	assert.Empty(t, cfg.Logging.RedactPaths)
+
+	cfg.Logging.RedactPaths = []string{"*.pem", "[invalid"}
+
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "logging.redact_paths[1]")
+}
+
 func TestExtendedConfig_ValidateSymlinks(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -321,6 +353,62 @@ func TestExtendedConfig_ValidateOutput(t *testing.T) {
 	}
 }
 
+func TestExtendedConfig_ValidateOutputColors(t *testing.T) {
+	cfg := config.DefaultExtended()
+	cfg.Output.Colors = config.ColorsConfig{Success: "0", Error: "196", Heading: "255"}
+	assert.NoError(t, cfg.Validate())
+
+	for _, code := range []string{"256", "-1", "red", "007", "#ff0000"} {
+		cfg.Output.Colors = config.ColorsConfig{Accent: code}
+		err := cfg.Validate()
+		require.Error(t, err, code)
+		assert.Contains(t, err.Error(), "output.colors.accent")
+	}
+}
+
+func TestColorsConfig_RolesCoverEveryField(t *testing.T) {
+	var keys []string
+	for _, key := range config.Keys() {
+		if role, ok := strings.CutPrefix(key, "output.colors."); ok {
+			keys = append(keys, role)
+		}
+	}
+
+	var names []string
+	for _, role := range (config.ColorsConfig{}).Roles() {
+		names = append(names, role.Name)
+	}
+	assert.Equal(t, keys, names)
+}
+
+func TestXDGStatePath(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", "/var/state")
+	assert.Equal(t, filepath.Join("/var/state", "dot"), config.XDGStatePath("dot"))
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_STATE_HOME", "")
+	assert.Equal(t, filepath.Join(home, ".local", "state", "dot"), config.XDGStatePath("dot"))
+}
+
+func TestExtendedConfig_Warnings(t *testing.T) {
+	cfg := config.DefaultExtended()
+	assert.Equal(t, "default", cfg.Output.Theme)
+	assert.Empty(t, cfg.Warnings())
+
+	for _, theme := range []string{"solarized", "nocolor", "high-contrast", "light", "dark", ""} {
+		cfg.Output.Theme = theme
+		assert.Empty(t, cfg.Warnings(), theme)
+	}
+
+	// Unknown themes fall back to default instead of failing validation
+	cfg.Output.Theme = "monokai"
+	assert.NoError(t, cfg.Validate())
+	warnings := cfg.Warnings()
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], `output.theme: unknown theme "monokai"`)
+}
+
 func TestExtendedConfig_ValidatePackages(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -348,6 +436,37 @@ func TestExtendedConfig_ValidatePackages(t *testing.T) {
 	}
 }
 
+func TestExtendedConfig_ValidatePackageMappings(t *testing.T) {
+	tests := []struct {
+		name     string
+		mappings map[string]string
+		wantErr  bool
+	}{
+		{"no mappings", nil, false},
+		{"directory in target", map[string]string{"dot-work-ssh": ".ssh"}, false},
+		{"nested directory", map[string]string{"nvim-work": ".config/nvim"}, false},
+		{"target root", map[string]string{"shell": "."}, false},
+		{"empty target", map[string]string{"shell": ""}, true},
+		{"escapes target", map[string]string{"dot-work-ssh": "../.ssh"}, true},
+		{"absolute target", map[string]string{"dot-work-ssh": "/etc/ssh"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultExtended()
+			cfg.Packages.Mappings = tt.mappings
+
+			err := cfg.Validate()
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "packages.mappings.")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestExtendedConfig_ValidateIgnorePatterns(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -387,6 +506,31 @@ func TestExtendedConfig_ValidateOperations(t *testing.T) {
 	// Test invalid max_parallel
 	cfg.Operations.MaxParallel = -1
 	assert.Error(t, cfg.Validate())
+
+	// Test transaction_size
+	cfg.Operations.MaxParallel = 0
+	cfg.Operations.TransactionSize = 50
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Operations.TransactionSize = -1
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "operations.transaction_size")
+
+	// Test retry
+	cfg.Operations.TransactionSize = 0
+	cfg.Operations.Retry = config.RetryConfig{MaxAttempts: 0, BaseBackoffMS: 0}
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Operations.Retry.MaxAttempts = -1
+	err = cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "operations.retry.max_attempts")
+
+	cfg.Operations.Retry = config.RetryConfig{MaxAttempts: 3, BaseBackoffMS: -5}
+	err = cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "operations.retry.base_backoff_ms")
 }
 
 func TestExtendedConfig_ValidateUpdate(t *testing.T) {
@@ -433,6 +577,17 @@ func TestExtendedConfig_ValidateUpdate(t *testing.T) {
 	}
 }
 
+func TestExtendedConfig_ValidateDetectionCacheTTL(t *testing.T) {
+	cfg := config.DefaultExtended()
+	cfg.Update.DetectionCacheTTL = 0
+	assert.NoError(t, cfg.Validate(), "0 disables the cache")
+
+	cfg.Update.DetectionCacheTTL = -1
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "update.detection_cache_ttl")
+}
+
 func TestExtendedConfig_ValidateNetwork(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -445,10 +600,10 @@ func TestExtendedConfig_ValidateNetwork(t *testing.T) {
 		{"valid defaults (all zero)", 0, 0, 0, false, ""},
 		{"valid positive timeout", 30, 10, 10, false, ""},
 		{"valid large values", 300, 60, 60, false, ""},
-		{"negative timeout", -1, 0, 0, true, "network.timeout must be non-negative"},
-		{"negative connect_timeout", 0, -5, 0, true, "network.connect_timeout must be non-negative"},
-		{"negative tls_timeout", 0, 0, -10, true, "network.tls_timeout must be non-negative"},
-		{"multiple negative (timeout checked first)", -1, -5, -10, true, "network.timeout must be non-negative"},
+		{"negative timeout", -1, 0, 0, true, "network.timeout: must be non-negative"},
+		{"negative connect_timeout", 0, -5, 0, true, "network.connect_timeout: must be non-negative"},
+		{"negative tls_timeout", 0, 0, -10, true, "network.tls_timeout: must be non-negative"},
+		{"multiple negative (all reported)", -1, -5, -10, true, "network.tls_timeout: must be non-negative"},
 	}
 
 	for _, tt := range tests {
@@ -471,6 +626,19 @@ func TestExtendedConfig_ValidateNetwork(t *testing.T) {
 	}
 }
 
+func TestExtendedConfig_ValidateCloneRetries(t *testing.T) {
+	cfg := config.DefaultExtended()
+	assert.Equal(t, 3, cfg.Network.CloneRetries)
+
+	cfg.Network.CloneRetries = 0
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Network.CloneRetries = -1
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "network.clone_retries: must be non-negative")
+}
+
 func TestExtendedConfig_MarshalYAML(t *testing.T) {
 	cfg := config.DefaultExtended()
 	cfg.Directories.Package = "/test/dotfiles"
@@ -489,3 +657,113 @@ func TestExtendedConfig_MarshalJSON(t *testing.T) {
 	// Verify config is valid
 	assert.NoError(t, cfg.Validate())
 }
+
+func TestExtendedConfig_ValidateAccumulatesErrors(t *testing.T) {
+	cfg := config.DefaultExtended()
+	cfg.Directories.Package = ""
+	cfg.Logging.Level = "LOUD"
+	cfg.Output.Verbosity = 9
+	cfg.Ignore.Patterns = []string{"ok", "[bad"}
+	cfg.Network.TLSTimeout = -1
+
+	err := cfg.Validate()
+	require.Error(t, err)
+
+	var multi domain.ErrMultiple
+	require.True(t, errors.As(err, &multi))
+
+	var fields []string
+	for _, e := range multi.Errors {
+		var fieldErr config.FieldError
+		require.True(t, errors.As(e, &fieldErr), "error %v should be a FieldError", e)
+		fields = append(fields, fieldErr.Field)
+	}
+	assert.Equal(t, []string{
+		"directories.package",
+		"logging.level",
+		"ignore.patterns[1]",
+		"output.verbosity",
+		"network.tls_timeout",
+	}, fields)
+	assert.Contains(t, err.Error(), "5 errors occurred")
+}
+
+func TestExtendedConfig_ValidateSingleErrorMessage(t *testing.T) {
+	cfg := config.DefaultExtended()
+	cfg.Symlinks.Mode = "sideways"
+
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Equal(t, `symlinks.mode: invalid symlink mode "sideways" (must be one of: relative, absolute, auto)`, err.Error())
+}
+
+func TestExtendedConfig_ValidateDoctorCategories(t *testing.T) {
+	valid := config.CategoryConfig{
+		Name:       "work",
+		Confidence: "high",
+		Patterns:   []string{"/opt/work/*"},
+	}
+
+	tests := []struct {
+		name       string
+		categories []config.CategoryConfig
+		wantField  string
+	}{
+		{"no categories", nil, ""},
+		{"valid category", []config.CategoryConfig{valid}, ""},
+		{"confidence omitted", []config.CategoryConfig{{Name: "work", Patterns: []string{"*/work/*"}}}, ""},
+		{"missing name", []config.CategoryConfig{{Patterns: []string{"*"}}}, "doctor.categories[0].name"},
+		{"duplicate name", []config.CategoryConfig{valid, valid}, "doctor.categories[1].name"},
+		{"invalid confidence", []config.CategoryConfig{{Name: "work", Confidence: "certain", Patterns: []string{"*"}}}, "doctor.categories[0].confidence"},
+		{"no patterns", []config.CategoryConfig{{Name: "work"}}, "doctor.categories[0].patterns"},
+		{"invalid glob", []config.CategoryConfig{{Name: "work", Patterns: []string{"*/ok/*", "[invalid"}}}, "doctor.categories[0].patterns[1]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultExtended()
+			cfg.Doctor.Categories = tt.categories
+
+			err := cfg.Validate()
+			if tt.wantField == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantField)
+		})
+	}
+}
+
+func TestExtendedConfig_ValidateHooks(t *testing.T) {
+	tests := []struct {
+		name      string
+		hooks     config.HooksConfig
+		wantField string
+	}{
+		{"no hooks", config.HooksConfig{}, ""},
+		{"valid hooks", config.HooksConfig{
+			PreManage:  []config.HookConfig{{Command: []string{"gpg", "--import", "keys.asc"}, Packages: []string{"dot-gnupg"}}},
+			PostManage: []config.HookConfig{{Command: []string{"echo $DOT_PACKAGE"}, Shell: true}},
+		}, ""},
+		{"empty command", config.HooksConfig{PostManage: []config.HookConfig{{}}}, "hooks.post_manage[0].command"},
+		{"empty program", config.HooksConfig{PreUnmanage: []config.HookConfig{{Command: []string{""}}}}, "hooks.pre_unmanage[0].command"},
+		{"shell with arguments", config.HooksConfig{PostUnmanage: []config.HookConfig{{Command: []string{"echo", "hi"}, Shell: true}}}, "hooks.post_unmanage[0].command"},
+		{"null byte", config.HooksConfig{PreManage: []config.HookConfig{{Command: []string{"echo", "a\x00b"}}}}, "hooks.pre_manage[0].command"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultExtended()
+			cfg.Hooks = tt.hooks
+
+			err := cfg.Validate()
+			if tt.wantField == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantField)
+		})
+	}
+}
@@ -0,0 +1,227 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// lookupField returns the struct field in cfg addressed by a dotted key
+// such as "symlinks.folding". Key segments are matched against yaml tags.
+func lookupField(cfg *ExtendedConfig, key string) (reflect.Value, error) {
+	if cfg == nil {
+		return reflect.Value{}, fmt.Errorf("config is nil")
+	}
+
+	current := reflect.ValueOf(cfg).Elem()
+	parts := strings.Split(key, ".")
+	for i, part := range parts {
+		if current.Kind() != reflect.Struct {
+			return reflect.Value{}, fmt.Errorf("unknown config key: %s", key)
+		}
+		field, ok := fieldByYAMLName(current, part)
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("unknown config key: %s", key)
+		}
+		current = field
+		if i < len(parts)-1 && current.Kind() != reflect.Struct {
+			return reflect.Value{}, fmt.Errorf("unknown config key: %s", key)
+		}
+	}
+
+	if current.Kind() == reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("config key %s is a section, not a value", key)
+	}
+	return current, nil
+}
+
+// fieldByYAMLName finds the field of struct value v whose yaml tag is name.
+func fieldByYAMLName(v reflect.Value, name string) (reflect.Value, bool) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if yamlFieldName(t.Field(i)) == name {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// Keys returns every configuration key in dotted form, in declaration
+// order, derived from the yaml tags of ExtendedConfig.
+func Keys() []string {
+	return collectKeys(reflect.TypeOf(ExtendedConfig{}), "")
+}
+
+func collectKeys(t reflect.Type, prefix string) []string {
+	var keys []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := yamlFieldName(field)
+		if name == "" {
+			continue
+		}
+		key := name
+		if prefix != "" {
+			key = prefix + "." + name
+		}
+		if field.Type.Kind() == reflect.Struct {
+			keys = append(keys, collectKeys(field.Type, key)...)
+			continue
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// SettableKeys returns the configuration keys that set accepts: every key
+// whose value can be written as a single string. Lists and maps of
+// structured entries, such as hooks, are edited in the config file.
+func SettableKeys() []string {
+	cfg := DefaultExtended()
+	keys := Keys()
+	settable := keys[:0]
+	for _, key := range keys {
+		if field, err := lookupField(cfg, key); err == nil && isSettable(field.Type()) {
+			settable = append(settable, key)
+		}
+	}
+	return settable
+}
+
+// isSettable reports whether setField can convert a value to type t.
+func isSettable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.String, reflect.Bool, reflect.Int, reflect.Int64:
+		return true
+	case reflect.Slice:
+		return t.Elem().Kind() == reflect.String
+	case reflect.Map:
+		return t.Key().Kind() == reflect.String && t.Elem().Kind() == reflect.String
+	default:
+		return false
+	}
+}
+
+// setField sets the dotted configuration key in cfg from value, converting
+// it to the field's type. Strings are accepted for every type in the form
+// GetValue renders: true or false, decimal integers, comma-separated lists,
+// and comma-separated key=value pairs for maps.
+func setField(cfg *ExtendedConfig, key string, value interface{}) error {
+	field, err := lookupField(cfg, key)
+	if err != nil {
+		return err
+	}
+	if !isSettable(field.Type()) {
+		return fmt.Errorf("config key %s cannot be set from a single value; edit the config file instead", key)
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		str, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("%s: value must be string", key)
+		}
+		field.SetString(str)
+	case reflect.Bool:
+		b, err := toBool(value, key)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int64:
+		i, err := toInt(value, key)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(i))
+	case reflect.Slice:
+		items, err := toStringList(value, key)
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(items))
+	case reflect.Map:
+		pairs, err := toStringMap(value, key)
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(pairs))
+	}
+	return nil
+}
+
+// toStringList converts a []string or comma-separated string to a list.
+func toStringList(value interface{}, fieldName string) ([]string, error) {
+	switch v := value.(type) {
+	case []string:
+		return v, nil
+	case string:
+		if strings.TrimSpace(v) == "" {
+			return []string{}, nil
+		}
+		items := strings.Split(v, ",")
+		for i := range items {
+			items[i] = strings.TrimSpace(items[i])
+		}
+		return items, nil
+	default:
+		return nil, fmt.Errorf("%s: value must be []string or string", fieldName)
+	}
+}
+
+// toStringMap converts a map[string]string or comma-separated key=value
+// pairs to a map.
+func toStringMap(value interface{}, fieldName string) (map[string]string, error) {
+	switch v := value.(type) {
+	case map[string]string:
+		return v, nil
+	case string:
+		pairs := make(map[string]string)
+		if strings.TrimSpace(v) == "" {
+			return pairs, nil
+		}
+		for _, item := range strings.Split(v, ",") {
+			k, val, ok := strings.Cut(strings.TrimSpace(item), "=")
+			if !ok || k == "" {
+				return nil, fmt.Errorf("%s: value must be key=value pairs, got %q", fieldName, item)
+			}
+			pairs[k] = val
+		}
+		return pairs, nil
+	default:
+		return nil, fmt.Errorf("%s: value must be map[string]string or string", fieldName)
+	}
+}
+
+// GetValue returns the value of a dotted configuration key rendered as a
+// string. Slices are comma-joined; booleans and numbers use their Go
+// formatting.
+func GetValue(cfg *ExtendedConfig, key string) (string, error) {
+	field, err := lookupField(cfg, key)
+	if err != nil {
+		return "", err
+	}
+	return formatFieldValue(field), nil
+}
+
+func formatFieldValue(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		items := make([]string, v.Len())
+		for i := range items {
+			items[i] = formatFieldValue(v.Index(i))
+		}
+		return strings.Join(items, ",")
+	case reflect.Map:
+		items := make([]string, 0, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			items = append(items, fmt.Sprintf("%v=%s", iter.Key().Interface(), formatFieldValue(iter.Value())))
+		}
+		sort.Strings(items)
+		return strings.Join(items, ",")
+	default:
+		return fmt.Sprint(v.Interface())
+	}
+}
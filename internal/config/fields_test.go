@@ -0,0 +1,74 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaklabco/dot/internal/config"
+)
+
+func TestKeys(t *testing.T) {
+	keys := config.Keys()
+
+	assert.Contains(t, keys, config.KeyDirPackage)
+	assert.Contains(t, keys, config.KeyIgnorePatterns)
+	assert.Contains(t, keys, config.KeySymlinkFolding)
+	assert.Contains(t, keys, "operations.max_parallel")
+	assert.Contains(t, keys, "network.timeout")
+	assert.NotContains(t, keys, "directories", "sections are not keys")
+
+	// Every key resolves against a real config
+	cfg := config.DefaultExtended()
+	for _, key := range keys {
+		_, err := config.GetValue(cfg, key)
+		assert.NoError(t, err, key)
+	}
+}
+
+func TestGetValue(t *testing.T) {
+	cfg := config.DefaultExtended()
+	cfg.Ignore.Patterns = []string{"*.log", ".DS_Store"}
+	cfg.Operations.MaxParallel = 4
+	cfg.Symlinks.Folding = false
+	cfg.Logging.Level = "WARN"
+
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{"logging.level", "WARN"},
+		{"ignore.patterns", "*.log,.DS_Store"},
+		{"operations.max_parallel", "4"},
+		{"symlinks.folding", "false"},
+		{"dotfile.translate", "true"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			got, err := config.GetValue(cfg, tt.key)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGetValue_EmptySlice(t *testing.T) {
+	cfg := config.DefaultExtended()
+	cfg.Ignore.Overrides = nil
+
+	got, err := config.GetValue(cfg, "ignore.overrides")
+	require.NoError(t, err)
+	assert.Equal(t, "", got)
+}
+
+func TestGetValue_Errors(t *testing.T) {
+	cfg := config.DefaultExtended()
+
+	for _, key := range []string{"unknown.key", "logging.nope", "logging", "logging.level.extra"} {
+		t.Run(key, func(t *testing.T) {
+			_, err := config.GetValue(cfg, key)
+			assert.Error(t, err)
+		})
+	}
+}
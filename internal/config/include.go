@@ -0,0 +1,173 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// ErrIncludeNotFound indicates an include directive references a file
+// that does not exist.
+type ErrIncludeNotFound struct {
+	Path         string
+	IncludedFrom string
+}
+
+func (e ErrIncludeNotFound) Error() string {
+	return fmt.Sprintf("included config file %s not found (included from %s)", e.Path, e.IncludedFrom)
+}
+
+// ErrIncludeCycle indicates config files include each other.
+// Chain lists the files in include order, ending with the repeated file.
+type ErrIncludeCycle struct {
+	Chain []string
+}
+
+func (e ErrIncludeCycle) Error() string {
+	return fmt.Sprintf("config include cycle: %s", strings.Join(e.Chain, " -> "))
+}
+
+// configLayer is a config file read without defaults, together with the
+// dotted keys it sets explicitly.
+type configLayer struct {
+	cfg  *ExtendedConfig
+	keys map[string]bool
+}
+
+// hasIncludes reports whether the config file at path declares includes.
+func hasIncludes(path string) (bool, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return false, fmt.Errorf("read config file: %w", err)
+	}
+	return len(v.GetStringSlice("include")) > 0, nil
+}
+
+// LoadExtendedWithIncludes loads the config file at path over the
+// defaults the way Loader.Load reads it: includes are resolved when the
+// file declares any. Environment variables and presets are not applied.
+func LoadExtendedWithIncludes(path string) (*ExtendedConfig, error) {
+	includes, err := hasIncludes(path)
+	if err != nil {
+		return nil, err
+	}
+	if includes {
+		return loadWithIncludes(path)
+	}
+	return LoadExtendedFromFile(path)
+}
+
+// loadWithIncludes loads path and every file it includes, recursively.
+//
+// Included files are merged in order, later includes overriding earlier
+// ones, and the including file overrides all of its includes. Merging uses
+// mergeStructs, so unset values in a file never clobber values set by a
+// file beneath it. Booleans are taken from a file only when it sets them.
+func loadWithIncludes(path string) (*ExtendedConfig, error) {
+	cfg := DefaultExtended()
+	if err := applyIncludeLayers(cfg, path, nil); err != nil {
+		return nil, err
+	}
+
+	if err := validateExpanded(cfg, cfg.expandPaths()); err != nil {
+		return nil, fmt.Errorf("validate config: %w", err)
+	}
+	return cfg, nil
+}
+
+// applyIncludeLayers merges the file at path, preceded by its includes,
+// onto cfg. stack holds the chain of including files for cycle detection.
+func applyIncludeLayers(cfg *ExtendedConfig, path string, stack []string) error {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("resolve config path %s: %w", path, err)
+	}
+	for _, seen := range stack {
+		if seen == absPath {
+			return ErrIncludeCycle{Chain: append(append([]string{}, stack...), absPath)}
+		}
+	}
+	stack = append(stack, absPath)
+
+	layer, err := readConfigLayer(absPath)
+	if err != nil {
+		return err
+	}
+
+	for _, include := range layer.cfg.Include {
+		includePath := resolveIncludePath(include, filepath.Dir(absPath))
+		if !fileExists(includePath) {
+			return ErrIncludeNotFound{Path: includePath, IncludedFrom: absPath}
+		}
+		if err := applyIncludeLayers(cfg, includePath, stack); err != nil {
+			return err
+		}
+	}
+
+	inheritUnsetBools(reflect.ValueOf(layer.cfg).Elem(), reflect.ValueOf(cfg).Elem(), layer.keys, "")
+	merged := &ExtendedConfig{}
+	mergeStructs(reflect.ValueOf(layer.cfg).Elem(), reflect.ValueOf(cfg).Elem(), reflect.ValueOf(merged).Elem())
+	*cfg = *merged
+	return nil
+}
+
+// readConfigLayer reads a config file without applying defaults.
+func readConfigLayer(path string) (*configLayer, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("read config file %s: %w", path, err)
+	}
+
+	cfg := &ExtendedConfig{}
+	if err := v.Unmarshal(cfg); err != nil {
+		return nil, fmt.Errorf("unmarshal config %s: %w", path, err)
+	}
+
+	keys := make(map[string]bool)
+	for _, key := range v.AllKeys() {
+		keys[key] = true
+	}
+	return &configLayer{cfg: cfg, keys: keys}, nil
+}
+
+// resolveIncludePath expands a leading ~ and resolves relative paths
+// against dir.
+func resolveIncludePath(include, dir string) string {
+	include = expandHome(include)
+	if !filepath.IsAbs(include) {
+		include = filepath.Join(dir, include)
+	}
+	return filepath.Clean(include)
+}
+
+// inheritUnsetBools copies boolean fields from base into layer when the
+// layer does not set them. mergeStructs always keeps the overriding bool,
+// so without this an absent key would reset a value to false.
+func inheritUnsetBools(layer, base reflect.Value, keys map[string]bool, prefix string) {
+	t := layer.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name := yamlFieldName(t.Field(i))
+		if name == "" {
+			continue
+		}
+		key := name
+		if prefix != "" {
+			key = prefix + "." + name
+		}
+
+		field := layer.Field(i)
+		switch field.Kind() {
+		case reflect.Struct:
+			inheritUnsetBools(field, base.Field(i), keys, key)
+		case reflect.Bool:
+			if !keys[key] {
+				field.SetBool(base.Field(i).Bool())
+			}
+		}
+	}
+}
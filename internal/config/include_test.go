@@ -0,0 +1,179 @@
+package config_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaklabco/dot/internal/config"
+)
+
+func writeConfigFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func TestLoader_Include_MergeOrder(t *testing.T) {
+	tmpDir := t.TempDir()
+	mainPath := filepath.Join(tmpDir, "config.yaml")
+
+	writeConfigFile(t, filepath.Join(tmpDir, "shared.yaml"), `
+directories:
+  package: /shared/dotfiles
+  target: /shared/home
+logging:
+  level: DEBUG
+  format: json
+`)
+	writeConfigFile(t, filepath.Join(tmpDir, "machine", "laptop.yaml"), `
+directories:
+  target: /laptop/home
+logging:
+  level: WARN
+`)
+	writeConfigFile(t, mainPath, `
+include:
+  - shared.yaml
+  - machine/laptop.yaml
+logging:
+  level: ERROR
+`)
+
+	cfg, err := config.NewLoader("dot", mainPath).Load()
+	require.NoError(t, err)
+
+	// Only set in the first include
+	assert.Equal(t, "/shared/dotfiles", cfg.Directories.Package)
+	assert.Equal(t, "json", cfg.Logging.Format)
+	// Later include overrides earlier include
+	assert.Equal(t, "/laptop/home", cfg.Directories.Target)
+	// Including file overrides all includes
+	assert.Equal(t, "ERROR", cfg.Logging.Level)
+	// Unset everywhere falls back to defaults
+	assert.Equal(t, config.DefaultExtended().Symlinks.Mode, cfg.Symlinks.Mode)
+	assert.Equal(t, []string{"shared.yaml", "machine/laptop.yaml"}, cfg.Include)
+}
+
+func TestLoader_Include_Booleans(t *testing.T) {
+	tmpDir := t.TempDir()
+	mainPath := filepath.Join(tmpDir, "config.yaml")
+
+	writeConfigFile(t, filepath.Join(tmpDir, "base.yaml"), `
+symlinks:
+  backup: true
+  folding: false
+`)
+	writeConfigFile(t, mainPath, `
+include: [base.yaml]
+symlinks:
+  mode: absolute
+  overwrite: true
+`)
+
+	cfg, err := config.NewLoader("dot", mainPath).Load()
+	require.NoError(t, err)
+
+	assert.Equal(t, "absolute", cfg.Symlinks.Mode)
+	assert.True(t, cfg.Symlinks.Overwrite)
+	// Not set by the including file, so the include's values survive
+	assert.True(t, cfg.Symlinks.Backup)
+	assert.False(t, cfg.Symlinks.Folding)
+	// Not set anywhere, so the default survives
+	assert.Equal(t, config.DefaultExtended().Ignore.UseDefaults, cfg.Ignore.UseDefaults)
+}
+
+func TestLoader_Include_Nested(t *testing.T) {
+	tmpDir := t.TempDir()
+	mainPath := filepath.Join(tmpDir, "config.yaml")
+
+	writeConfigFile(t, filepath.Join(tmpDir, "a", "b.yaml"), `
+include: [c.yaml]
+directories:
+  target: /from/b
+`)
+	writeConfigFile(t, filepath.Join(tmpDir, "a", "c.yaml"), `
+directories:
+  package: /from/c
+  target: /from/c
+`)
+	writeConfigFile(t, mainPath, "include: [a/b.yaml]\n")
+
+	cfg, err := config.NewLoader("dot", mainPath).Load()
+	require.NoError(t, err)
+
+	assert.Equal(t, "/from/c", cfg.Directories.Package)
+	assert.Equal(t, "/from/b", cfg.Directories.Target)
+}
+
+func TestLoader_Include_AbsolutePath(t *testing.T) {
+	tmpDir := t.TempDir()
+	sharedPath := filepath.Join(tmpDir, "elsewhere", "shared.yaml")
+	mainPath := filepath.Join(tmpDir, "config", "config.yaml")
+
+	writeConfigFile(t, sharedPath, "directories:\n  package: /abs/dotfiles\n")
+	writeConfigFile(t, mainPath, "include:\n  - "+sharedPath+"\n")
+
+	cfg, err := config.NewLoader("dot", mainPath).Load()
+	require.NoError(t, err)
+	assert.Equal(t, "/abs/dotfiles", cfg.Directories.Package)
+}
+
+func TestLoader_Include_MissingFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	mainPath := filepath.Join(tmpDir, "config.yaml")
+	writeConfigFile(t, mainPath, "include: [missing.yaml]\n")
+
+	_, err := config.NewLoader("dot", mainPath).Load()
+	require.Error(t, err)
+
+	var notFound config.ErrIncludeNotFound
+	require.True(t, errors.As(err, &notFound))
+	assert.Equal(t, filepath.Join(tmpDir, "missing.yaml"), notFound.Path)
+	assert.Equal(t, mainPath, notFound.IncludedFrom)
+}
+
+func TestLoader_Include_Cycle(t *testing.T) {
+	tmpDir := t.TempDir()
+	mainPath := filepath.Join(tmpDir, "config.yaml")
+	writeConfigFile(t, mainPath, "include: [a.yaml]\n")
+	writeConfigFile(t, filepath.Join(tmpDir, "a.yaml"), "include: [b.yaml]\n")
+	writeConfigFile(t, filepath.Join(tmpDir, "b.yaml"), "include: [a.yaml]\n")
+
+	_, err := config.NewLoader("dot", mainPath).Load()
+	require.Error(t, err)
+
+	var cycle config.ErrIncludeCycle
+	require.True(t, errors.As(err, &cycle))
+	assert.Equal(t, []string{
+		mainPath,
+		filepath.Join(tmpDir, "a.yaml"),
+		filepath.Join(tmpDir, "b.yaml"),
+		filepath.Join(tmpDir, "a.yaml"),
+	}, cycle.Chain)
+}
+
+func TestLoader_Include_SelfInclude(t *testing.T) {
+	tmpDir := t.TempDir()
+	mainPath := filepath.Join(tmpDir, "config.yaml")
+	writeConfigFile(t, mainPath, "include: [config.yaml]\n")
+
+	_, err := config.NewLoader("dot", mainPath).Load()
+
+	var cycle config.ErrIncludeCycle
+	require.True(t, errors.As(err, &cycle))
+}
+
+func TestLoader_Include_ValidatesMergedResult(t *testing.T) {
+	tmpDir := t.TempDir()
+	mainPath := filepath.Join(tmpDir, "config.yaml")
+	writeConfigFile(t, filepath.Join(tmpDir, "bad.yaml"), "logging:\n  level: LOUD\n")
+	writeConfigFile(t, mainPath, "include: [bad.yaml]\n")
+
+	_, err := config.NewLoader("dot", mainPath).Load()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "logging.level")
+}
@@ -15,14 +15,19 @@ const (
 	KeyLogFormat      = "logging.format"
 	KeyLogDestination = "logging.destination"
 	KeyLogFile        = "logging.file"
+	KeyLogMaxSizeMB   = "logging.max_size_mb"
+	KeyLogMaxBackups  = "logging.max_backups"
+	KeyLogMaxAgeDays  = "logging.max_age_days"
+	KeyLogRedactPaths = "logging.redact_paths"
 
 	// Symlink configuration keys
-	KeySymlinkMode         = "symlinks.mode"
-	KeySymlinkFolding      = "symlinks.folding"
-	KeySymlinkOverwrite    = "symlinks.overwrite"
-	KeySymlinkBackup       = "symlinks.backup"
-	KeySymlinkBackupSuffix = "symlinks.backup_suffix"
-	KeySymlinkBackupDir    = "symlinks.backup_dir"
+	KeySymlinkMode           = "symlinks.mode"
+	KeySymlinkFolding        = "symlinks.folding"
+	KeySymlinkOverwrite      = "symlinks.overwrite"
+	KeySymlinkBackup         = "symlinks.backup"
+	KeySymlinkBackupSuffix   = "symlinks.backup_suffix"
+	KeySymlinkBackupStrategy = "symlinks.backup_strategy"
+	KeySymlinkBackupDir      = "symlinks.backup_dir"
 
 	// Ignore pattern configuration keys
 	KeyIgnoreUseDefaults = "ignore.use_defaults"
@@ -39,11 +44,15 @@ const (
 	KeyOutputProgress  = "output.progress"
 	KeyOutputVerbosity = "output.verbosity"
 	KeyOutputWidth     = "output.width"
+	KeyOutputTheme     = "output.theme"
 
 	// Operations configuration keys
-	KeyOperationsDryRun      = "operations.dry_run"
-	KeyOperationsAtomic      = "operations.atomic"
-	KeyOperationsMaxParallel = "operations.max_parallel"
+	KeyOperationsDryRun             = "operations.dry_run"
+	KeyOperationsAtomic             = "operations.atomic"
+	KeyOperationsMaxParallel        = "operations.max_parallel"
+	KeyOperationsTransactionSize    = "operations.transaction_size"
+	KeyOperationsRetryMaxAttempts   = "operations.retry.max_attempts"
+	KeyOperationsRetryBaseBackoffMS = "operations.retry.base_backoff_ms"
 
 	// Packages configuration keys
 	KeyPackagesSortBy        = "packages.sort_by"
@@ -59,4 +68,5 @@ const (
 	KeyDoctorOrphanScanMode     = "doctor.orphan_scan_mode"
 	KeyDoctorOrphanScanDepth    = "doctor.orphan_scan_depth"
 	KeyDoctorOrphanSkipPatterns = "doctor.orphan_skip_patterns"
+	KeyDoctorCategories         = "doctor.categories"
 )
@@ -48,11 +48,15 @@ func TestConfigurationKeyConstants(t *testing.T) {
 		{name: "KeyOutputProgress", key: KeyOutputProgress, expected: "output.progress", category: "output"},
 		{name: "KeyOutputVerbosity", key: KeyOutputVerbosity, expected: "output.verbosity", category: "output"},
 		{name: "KeyOutputWidth", key: KeyOutputWidth, expected: "output.width", category: "output"},
+		{name: "KeyOutputTheme", key: KeyOutputTheme, expected: "output.theme", category: "output"},
 
 		// Operations keys
 		{name: "KeyOperationsDryRun", key: KeyOperationsDryRun, expected: "operations.dry_run", category: "operations"},
 		{name: "KeyOperationsAtomic", key: KeyOperationsAtomic, expected: "operations.atomic", category: "operations"},
 		{name: "KeyOperationsMaxParallel", key: KeyOperationsMaxParallel, expected: "operations.max_parallel", category: "operations"},
+		{name: "KeyOperationsTransactionSize", key: KeyOperationsTransactionSize, expected: "operations.transaction_size", category: "operations"},
+		{name: "KeyOperationsRetryMaxAttempts", key: KeyOperationsRetryMaxAttempts, expected: "operations.retry.max_attempts", category: "operations"},
+		{name: "KeyOperationsRetryBaseBackoffMS", key: KeyOperationsRetryBaseBackoffMS, expected: "operations.retry.base_backoff_ms", category: "operations"},
 
 		// Packages keys
 		{name: "KeyPackagesSortBy", key: KeyPackagesSortBy, expected: "packages.sort_by", category: "packages"},
@@ -85,7 +89,7 @@ func TestKeyFormatConsistency(t *testing.T) {
 		KeySymlinkBackupSuffix, KeySymlinkBackupDir,
 		KeyIgnoreUseDefaults, KeyIgnorePatterns, KeyIgnoreOverrides,
 		KeyDotfileTranslate, KeyDotfilePrefix,
-		KeyOutputFormat, KeyOutputColor, KeyOutputProgress, KeyOutputVerbosity, KeyOutputWidth,
+		KeyOutputFormat, KeyOutputColor, KeyOutputProgress, KeyOutputVerbosity, KeyOutputWidth, KeyOutputTheme,
 		KeyOperationsDryRun, KeyOperationsAtomic, KeyOperationsMaxParallel,
 		KeyPackagesSortBy, KeyPackagesAutoDiscover, KeyPackagesValidateNames,
 		KeyDoctorAutoFix, KeyDoctorCheckManifest, KeyDoctorCheckBrokenLinks,
@@ -121,7 +125,7 @@ func TestKeyCategoryGrouping(t *testing.T) {
 		"symlinks":    {KeySymlinkMode, KeySymlinkFolding, KeySymlinkOverwrite, KeySymlinkBackup, KeySymlinkBackupSuffix, KeySymlinkBackupDir},
 		"ignore":      {KeyIgnoreUseDefaults, KeyIgnorePatterns, KeyIgnoreOverrides},
 		"dotfile":     {KeyDotfileTranslate, KeyDotfilePrefix},
-		"output":      {KeyOutputFormat, KeyOutputColor, KeyOutputProgress, KeyOutputVerbosity, KeyOutputWidth},
+		"output":      {KeyOutputFormat, KeyOutputColor, KeyOutputProgress, KeyOutputVerbosity, KeyOutputWidth, KeyOutputTheme},
 		"operations":  {KeyOperationsDryRun, KeyOperationsAtomic, KeyOperationsMaxParallel},
 		"packages":    {KeyPackagesSortBy, KeyPackagesAutoDiscover, KeyPackagesValidateNames},
 		"doctor":      {KeyDoctorAutoFix, KeyDoctorCheckManifest, KeyDoctorCheckBrokenLinks, KeyDoctorCheckOrphaned, KeyDoctorOrphanScanMode, KeyDoctorOrphanScanDepth, KeyDoctorOrphanSkipPatterns},
@@ -145,7 +149,7 @@ func TestKeyUniqueness(t *testing.T) {
 		KeySymlinkBackupSuffix, KeySymlinkBackupDir,
 		KeyIgnoreUseDefaults, KeyIgnorePatterns, KeyIgnoreOverrides,
 		KeyDotfileTranslate, KeyDotfilePrefix,
-		KeyOutputFormat, KeyOutputColor, KeyOutputProgress, KeyOutputVerbosity, KeyOutputWidth,
+		KeyOutputFormat, KeyOutputColor, KeyOutputProgress, KeyOutputVerbosity, KeyOutputWidth, KeyOutputTheme,
 		KeyOperationsDryRun, KeyOperationsAtomic, KeyOperationsMaxParallel,
 		KeyPackagesSortBy, KeyPackagesAutoDiscover, KeyPackagesValidateNames,
 		KeyDoctorAutoFix, KeyDoctorCheckManifest, KeyDoctorCheckBrokenLinks,
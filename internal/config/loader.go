@@ -22,11 +22,11 @@ func NewLoader(appName string, configPath string) *Loader {
 }
 
 // Load loads configuration from file with proper precedence.
-// Precedence: file > defaults
+// Precedence: file > included files > defaults
 func (l *Loader) Load() (*ExtendedConfig, error) {
 	// Load from config file if it exists
 	if fileExists(l.configPath) {
-		fileCfg, err := LoadExtendedFromFile(l.configPath)
+		fileCfg, err := LoadExtendedWithIncludes(l.configPath)
 		if err != nil {
 			return nil, fmt.Errorf("load config file: %w", err)
 		}
@@ -77,8 +77,7 @@ func (l *Loader) LoadWithFlags(flags map[string]interface{}) (*ExtendedConfig, e
 	}
 
 	// Apply flag overrides
-	flagCfg, verbositySet := l.configFromFlags(flags)
-	cfg = mergeConfigsWithVerbosity(cfg, flagCfg, verbositySet)
+	cfg = ApplyFlags(cfg, flags)
 
 	// Validate again after flag overrides
 	if err := cfg.Validate(); err != nil {
@@ -144,6 +143,18 @@ func loadLoggingFromEnv(v *viper.Viper, cfg *LoggingConfig) {
 	if v.IsSet("logging.file") {
 		cfg.File = v.GetString("logging.file")
 	}
+	if v.IsSet("logging.max_size_mb") {
+		cfg.MaxSizeMB = v.GetInt("logging.max_size_mb")
+	}
+	if v.IsSet("logging.max_backups") {
+		cfg.MaxBackups = v.GetInt("logging.max_backups")
+	}
+	if v.IsSet("logging.max_age_days") {
+		cfg.MaxAgeDays = v.GetInt("logging.max_age_days")
+	}
+	if v.IsSet("logging.redact_paths") {
+		cfg.RedactPaths = v.GetStringSlice("logging.redact_paths")
+	}
 }
 
 func loadSymlinksFromEnv(v *viper.Viper, cfg *SymlinksConfig) {
@@ -162,6 +173,9 @@ func loadSymlinksFromEnv(v *viper.Viper, cfg *SymlinksConfig) {
 	if v.IsSet("symlinks.backup_suffix") {
 		cfg.BackupSuffix = v.GetString("symlinks.backup_suffix")
 	}
+	if v.IsSet("symlinks.backup_strategy") {
+		cfg.BackupStrategy = v.GetString("symlinks.backup_strategy")
+	}
 }
 
 func loadIgnoreFromEnv(v *viper.Viper, cfg *IgnoreConfig) {
@@ -210,6 +224,14 @@ func loadOutputFromEnv(v *viper.Viper, cfg *OutputConfig) {
 	if v.IsSet("output.width") {
 		cfg.Width = v.GetInt("output.width")
 	}
+	if v.IsSet("output.theme") {
+		cfg.Theme = v.GetString("output.theme")
+	}
+	for _, role := range colorRoles {
+		if v.IsSet("output.colors." + role.name) {
+			*role.field(&cfg.Colors) = v.GetString("output.colors." + role.name)
+		}
+	}
 }
 
 func loadOperationsFromEnv(v *viper.Viper, cfg *OperationsConfig) {
@@ -222,6 +244,15 @@ func loadOperationsFromEnv(v *viper.Viper, cfg *OperationsConfig) {
 	if v.IsSet("operations.max_parallel") {
 		cfg.MaxParallel = v.GetInt("operations.max_parallel")
 	}
+	if v.IsSet("operations.transaction_size") {
+		cfg.TransactionSize = v.GetInt("operations.transaction_size")
+	}
+	if v.IsSet("operations.retry.max_attempts") {
+		cfg.Retry.MaxAttempts = v.GetInt("operations.retry.max_attempts")
+	}
+	if v.IsSet("operations.retry.base_backoff_ms") {
+		cfg.Retry.BaseBackoffMS = v.GetInt("operations.retry.base_backoff_ms")
+	}
 }
 
 func loadPackagesFromEnv(v *viper.Viper, cfg *PackagesConfig) {
@@ -273,12 +304,17 @@ func (l *Loader) bindEnvKeys(v *viper.Viper) {
 	v.BindEnv("logging.format")
 	v.BindEnv("logging.destination")
 	v.BindEnv("logging.file")
+	v.BindEnv("logging.max_size_mb")
+	v.BindEnv("logging.max_backups")
+	v.BindEnv("logging.max_age_days")
+	v.BindEnv("logging.redact_paths")
 
 	v.BindEnv("symlinks.mode")
 	v.BindEnv("symlinks.folding")
 	v.BindEnv("symlinks.overwrite")
 	v.BindEnv("symlinks.backup")
 	v.BindEnv("symlinks.backup_suffix")
+	v.BindEnv("symlinks.backup_strategy")
 
 	v.BindEnv("ignore.use_defaults")
 	v.BindEnv("ignore.patterns")
@@ -295,10 +331,17 @@ func (l *Loader) bindEnvKeys(v *viper.Viper) {
 	v.BindEnv("output.progress")
 	v.BindEnv("output.verbosity")
 	v.BindEnv("output.width")
+	v.BindEnv("output.theme")
+	for _, role := range colorRoles {
+		v.BindEnv("output.colors." + role.name)
+	}
 
 	v.BindEnv("operations.dry_run")
 	v.BindEnv("operations.atomic")
 	v.BindEnv("operations.max_parallel")
+	v.BindEnv("operations.transaction_size")
+	v.BindEnv("operations.retry.max_attempts")
+	v.BindEnv("operations.retry.base_backoff_ms")
 
 	v.BindEnv("packages.sort_by")
 	v.BindEnv("packages.auto_discover")
@@ -314,13 +357,12 @@ func (l *Loader) bindEnvKeys(v *viper.Viper) {
 	v.BindEnv("experimental.profiling")
 }
 
-// configFromFlags creates partial config from flag map.
-func (l *Loader) configFromFlags(flags map[string]interface{}) (*ExtendedConfig, bool) {
-	cfg := createSparseConfig()
-
-	verbositySet := applyFlagsToConfig(cfg, flags)
-
-	return cfg, verbositySet
+// ApplyFlags returns cfg with command-line flag overrides applied.
+// Flags are keyed by their long name, e.g. "dir", "verbose", "log-json".
+func ApplyFlags(cfg *ExtendedConfig, flags map[string]interface{}) *ExtendedConfig {
+	flagCfg := createSparseConfig()
+	verbositySet := applyFlagsToConfig(flagCfg, flags)
+	return mergeConfigsWithVerbosity(cfg, flagCfg, verbositySet)
 }
 
 // createSparseConfig creates an empty config for flag/env merging.
@@ -448,6 +490,18 @@ func mergeLogging(merged *ExtendedConfig, override *ExtendedConfig) {
 	if override.Logging.File != "" {
 		merged.Logging.File = override.Logging.File
 	}
+	if override.Logging.MaxSizeMB > 0 {
+		merged.Logging.MaxSizeMB = override.Logging.MaxSizeMB
+	}
+	if override.Logging.MaxBackups > 0 {
+		merged.Logging.MaxBackups = override.Logging.MaxBackups
+	}
+	if override.Logging.MaxAgeDays > 0 {
+		merged.Logging.MaxAgeDays = override.Logging.MaxAgeDays
+	}
+	if len(override.Logging.RedactPaths) > 0 {
+		merged.Logging.RedactPaths = override.Logging.RedactPaths
+	}
 }
 
 // mergeSymlinks merges symlink configuration.
@@ -458,6 +512,9 @@ func mergeSymlinks(merged *ExtendedConfig, override *ExtendedConfig) {
 	if override.Symlinks.BackupSuffix != "" {
 		merged.Symlinks.BackupSuffix = override.Symlinks.BackupSuffix
 	}
+	if override.Symlinks.BackupStrategy != "" {
+		merged.Symlinks.BackupStrategy = override.Symlinks.BackupStrategy
+	}
 	if override.Symlinks.Overwrite {
 		merged.Symlinks.Overwrite = true
 	}
@@ -497,6 +554,19 @@ func mergeOutput(merged *ExtendedConfig, override *ExtendedConfig, verbosityExpl
 	if override.Output.Width > 0 {
 		merged.Output.Width = override.Output.Width
 	}
+	if override.Output.Theme != "" {
+		merged.Output.Theme = override.Output.Theme
+	}
+	mergeColors(&merged.Output.Colors, override.Output.Colors)
+}
+
+// mergeColors overrides each color role override sets.
+func mergeColors(merged *ColorsConfig, override ColorsConfig) {
+	for _, role := range colorRoles {
+		if code := *role.field(&override); code != "" {
+			*role.field(merged) = code
+		}
+	}
 }
 
 // mergeOperations merges operation configuration.
@@ -507,6 +577,15 @@ func mergeOperations(merged *ExtendedConfig, override *ExtendedConfig) {
 	if override.Operations.MaxParallel > 0 {
 		merged.Operations.MaxParallel = override.Operations.MaxParallel
 	}
+	if override.Operations.TransactionSize > 0 {
+		merged.Operations.TransactionSize = override.Operations.TransactionSize
+	}
+	if override.Operations.Retry.MaxAttempts > 0 {
+		merged.Operations.Retry.MaxAttempts = override.Operations.Retry.MaxAttempts
+	}
+	if override.Operations.Retry.BaseBackoffMS > 0 {
+		merged.Operations.Retry.BaseBackoffMS = override.Operations.Retry.BaseBackoffMS
+	}
 }
 
 // mergePackages merges package management configuration.
@@ -521,6 +600,9 @@ func mergeDoctor(merged *ExtendedConfig, override *ExtendedConfig) {
 	if override.Doctor.AutoFix {
 		merged.Doctor.AutoFix = true
 	}
+	if len(override.Doctor.Categories) > 0 {
+		merged.Doctor.Categories = override.Doctor.Categories
+	}
 }
 
 // mergeExperimental merges experimental feature configuration.
@@ -357,3 +357,145 @@ package = "/test/dotfiles"
 		})
 	}
 }
+
+func TestLoadFromFile_DoctorCategories(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+
+	configContent := `
+doctor:
+  categories:
+    - name: work
+      description: Work tooling
+      confidence: high
+      patterns:
+        - "/opt/work/*"
+        - "*/work-bin/*"
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(configContent), 0600))
+
+	cfg, err := config.LoadExtendedFromFile(configPath)
+	require.NoError(t, err)
+
+	require.Len(t, cfg.Doctor.Categories, 1)
+	cat := cfg.Doctor.Categories[0]
+	assert.Equal(t, "work", cat.Name)
+	assert.Equal(t, "Work tooling", cat.Description)
+	assert.Equal(t, "high", cat.Confidence)
+	assert.Equal(t, []string{"/opt/work/*", "*/work-bin/*"}, cat.Patterns)
+}
+
+func TestLoadFromFile_Hooks(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+
+	configContent := `
+hooks:
+  post_manage:
+    - command: ["gpg", "--import", "keys.asc"]
+      packages: [dot-gnupg]
+  pre_unmanage:
+    - command: ["gpgconf --kill gpg-agent"]
+      shell: true
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(configContent), 0600))
+
+	cfg, err := config.LoadExtendedFromFile(configPath)
+	require.NoError(t, err)
+
+	want := config.HooksConfig{
+		PostManage:  []config.HookConfig{{Command: []string{"gpg", "--import", "keys.asc"}, Packages: []string{"dot-gnupg"}}},
+		PreUnmanage: []config.HookConfig{{Command: []string{"gpgconf --kill gpg-agent"}, Shell: true}},
+	}
+	assert.Equal(t, want, cfg.Hooks)
+
+	// The commented YAML output reads back the same hooks
+	data, err := config.NewYAMLStrategy().Marshal(cfg, config.MarshalOptions{IncludeComments: true})
+	require.NoError(t, err)
+	reread, err := config.NewYAMLStrategy().Unmarshal(data)
+	require.NoError(t, err)
+	assert.Equal(t, want, reread.Hooks)
+}
+
+func TestLoadFromFile_PackageMappings(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+
+	configContent := `
+packages:
+  mappings:
+    dot-work-ssh: .ssh
+    nvim-work: .config/nvim
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(configContent), 0600))
+
+	cfg, err := config.LoadExtendedFromFile(configPath)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{
+		"dot-work-ssh": ".ssh",
+		"nvim-work":    ".config/nvim",
+	}, cfg.Packages.Mappings)
+}
+
+func TestLoadFromFile_OutputColors(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+
+	// Codes may be written unquoted
+	configContent := `
+output:
+  theme: dark
+  colors:
+    success: 34
+    heading: "117"
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(configContent), 0600))
+
+	cfg, err := config.LoadExtendedFromFile(configPath)
+	require.NoError(t, err)
+
+	assert.Equal(t, "dark", cfg.Output.Theme)
+	assert.Equal(t, "34", cfg.Output.Colors.Success)
+	assert.Equal(t, "117", cfg.Output.Colors.Heading)
+	assert.Empty(t, cfg.Output.Colors.Error)
+}
+
+func TestLoader_OutputColorsFromEnv(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	configContent := `
+output:
+  colors:
+    success: "34"
+    heading: "117"
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(configContent), 0600))
+	t.Setenv("DOT_OUTPUT_COLORS_HEADING", "19")
+	t.Setenv("DOT_OUTPUT_COLORS_DIM", "238")
+
+	cfg, err := config.NewLoader("dot", configPath).LoadWithEnv()
+	require.NoError(t, err)
+
+	assert.Equal(t, "34", cfg.Output.Colors.Success, "roles not set in the environment keep the file's color")
+	assert.Equal(t, "19", cfg.Output.Colors.Heading)
+	assert.Equal(t, "238", cfg.Output.Colors.Dim)
+}
+
+func TestLoadFromFile_DoctorCategoriesInvalidPattern(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+
+	configContent := `
+doctor:
+  categories:
+    - name: work
+      patterns:
+        - "[unclosed"
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(configContent), 0600))
+
+	loader := config.NewLoader("dot", configPath)
+	_, err := loader.Load()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "doctor.categories[0].patterns[0]")
+}
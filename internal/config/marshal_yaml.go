@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
@@ -60,6 +62,15 @@ func (s *YAMLStrategy) marshalWithComments(cfg *ExtendedConfig) ([]byte, error)
 	buf.WriteString("# Dot Configuration File\n")
 	buf.WriteString("# Documentation: https://github.com/yaklabco/dot/docs/configuration.md\n\n")
 
+	if len(cfg.Include) > 0 {
+		buf.WriteString("# Additional config files merged beneath this one\n")
+		buf.WriteString("include:\n")
+		for _, path := range cfg.Include {
+			buf.WriteString(fmt.Sprintf("  - %s\n", path))
+		}
+		buf.WriteString("\n")
+	}
+
 	buf.WriteString("# Core Directories\n")
 	buf.WriteString("directories:\n")
 	buf.WriteString("  # Package directory containing packages\n")
@@ -78,11 +89,20 @@ func (s *YAMLStrategy) marshalWithComments(cfg *ExtendedConfig) ([]byte, error)
 	buf.WriteString("  # Log destination: stderr, stdout, file\n")
 	buf.WriteString(fmt.Sprintf("  destination: %s\n", cfg.Logging.Destination))
 	buf.WriteString("  # Log file path (only used if destination is file)\n")
-	buf.WriteString(fmt.Sprintf("  file: %s\n\n", cfg.Logging.File))
+	buf.WriteString(fmt.Sprintf("  file: %s\n", cfg.Logging.File))
+	buf.WriteString("  # Rotate the log file past this size in MB (0 = never)\n")
+	buf.WriteString(fmt.Sprintf("  max_size_mb: %d\n", cfg.Logging.MaxSizeMB))
+	buf.WriteString("  # Number of rotated log files to keep\n")
+	buf.WriteString(fmt.Sprintf("  max_backups: %d\n", cfg.Logging.MaxBackups))
+	buf.WriteString("  # Rotate the log file once older than this many days (0 = never)\n")
+	buf.WriteString(fmt.Sprintf("  max_age_days: %d\n", cfg.Logging.MaxAgeDays))
+	buf.WriteString("  # Paths to mask in log output (glob format)\n")
+	s.writeYAMLList(&buf, "redact_paths", cfg.Logging.RedactPaths, 2)
+	buf.WriteString("\n")
 
 	buf.WriteString("# Symlink Behavior\n")
 	buf.WriteString("symlinks:\n")
-	buf.WriteString("  # Link mode: relative, absolute\n")
+	buf.WriteString("  # Link mode: relative, absolute, auto\n")
 	buf.WriteString(fmt.Sprintf("  mode: %s\n", cfg.Symlinks.Mode))
 	buf.WriteString("  # Enable directory folding optimization\n")
 	buf.WriteString(fmt.Sprintf("  folding: %t\n", cfg.Symlinks.Folding))
@@ -92,6 +112,8 @@ func (s *YAMLStrategy) marshalWithComments(cfg *ExtendedConfig) ([]byte, error)
 	buf.WriteString(fmt.Sprintf("  backup: %t\n", cfg.Symlinks.Backup))
 	buf.WriteString("  # Backup suffix when backups enabled\n")
 	buf.WriteString(fmt.Sprintf("  backup_suffix: %s\n", cfg.Symlinks.BackupSuffix))
+	buf.WriteString("  # Backup naming: timestamped (keep every backup), overwrite (reuse <name><backup_suffix>)\n")
+	buf.WriteString(fmt.Sprintf("  backup_strategy: %s\n", cfg.Symlinks.BackupStrategy))
 	buf.WriteString("  # Directory for backup files\n")
 	if cfg.Symlinks.BackupDir == "" {
 		buf.WriteString("  backup_dir:\n\n")
@@ -122,6 +144,9 @@ func (s *YAMLStrategy) marshalWithComments(cfg *ExtendedConfig) ([]byte, error)
 	buf.WriteString(fmt.Sprintf("  format: %s\n", cfg.Output.Format))
 	buf.WriteString("  # Enable colored output: auto, always, never\n")
 	buf.WriteString(fmt.Sprintf("  color: %s\n", cfg.Output.Color))
+	buf.WriteString("  # Color theme: default, solarized, nocolor, high-contrast, light, dark\n")
+	buf.WriteString(fmt.Sprintf("  theme: %s\n", cfg.Output.Theme))
+	writeOutputColors(&buf, cfg.Output.Colors)
 	buf.WriteString("  # Show progress indicators\n")
 	buf.WriteString(fmt.Sprintf("  progress: %t\n", cfg.Output.Progress))
 	buf.WriteString("  # Verbosity level: 0 (quiet), 1 (normal), 2 (verbose), 3 (debug)\n")
@@ -136,7 +161,15 @@ func (s *YAMLStrategy) marshalWithComments(cfg *ExtendedConfig) ([]byte, error)
 	buf.WriteString("  # Enable atomic operations with rollback\n")
 	buf.WriteString(fmt.Sprintf("  atomic: %t\n", cfg.Operations.Atomic))
 	buf.WriteString("  # Maximum number of parallel operations (0 = auto)\n")
-	buf.WriteString(fmt.Sprintf("  max_parallel: %d\n\n", cfg.Operations.MaxParallel))
+	buf.WriteString(fmt.Sprintf("  max_parallel: %d\n", cfg.Operations.MaxParallel))
+	buf.WriteString("  # Maximum operations per transaction (0 = single transaction)\n")
+	buf.WriteString(fmt.Sprintf("  transaction_size: %d\n", cfg.Operations.TransactionSize))
+	buf.WriteString("  # Retry operations failing with transient filesystem errors (EAGAIN, EBUSY)\n")
+	buf.WriteString("  retry:\n")
+	buf.WriteString("    # Tries per operation, including the first (0 or 1 = no retry)\n")
+	buf.WriteString(fmt.Sprintf("    max_attempts: %d\n", cfg.Operations.Retry.MaxAttempts))
+	buf.WriteString("    # Milliseconds before the first retry, doubling for each retry after\n")
+	buf.WriteString(fmt.Sprintf("    base_backoff_ms: %d\n\n", cfg.Operations.Retry.BaseBackoffMS))
 
 	buf.WriteString("# Package Management\n")
 	buf.WriteString("packages:\n")
@@ -145,7 +178,9 @@ func (s *YAMLStrategy) marshalWithComments(cfg *ExtendedConfig) ([]byte, error)
 	buf.WriteString("  # Automatically scan for new packages\n")
 	buf.WriteString(fmt.Sprintf("  auto_discover: %t\n", cfg.Packages.AutoDiscover))
 	buf.WriteString("  # Package naming convention validation\n")
-	buf.WriteString(fmt.Sprintf("  validate_names: %t\n\n", cfg.Packages.ValidateNames))
+	buf.WriteString(fmt.Sprintf("  validate_names: %t\n", cfg.Packages.ValidateNames))
+	writePackageMappings(&buf, cfg.Packages.Mappings)
+	buf.WriteString("\n")
 
 	buf.WriteString("# Doctor Configuration\n")
 	buf.WriteString("doctor:\n")
@@ -158,7 +193,15 @@ func (s *YAMLStrategy) marshalWithComments(cfg *ExtendedConfig) ([]byte, error)
 	buf.WriteString("  # Check for orphaned links\n")
 	buf.WriteString(fmt.Sprintf("  check_orphaned: %t\n", cfg.Doctor.CheckOrphaned))
 	buf.WriteString("  # Check file permissions\n")
-	buf.WriteString(fmt.Sprintf("  check_permissions: %t\n\n", cfg.Doctor.CheckPermissions))
+	buf.WriteString(fmt.Sprintf("  check_permissions: %t\n", cfg.Doctor.CheckPermissions))
+	writeDoctorCategories(&buf, cfg.Doctor.Categories)
+	buf.WriteString("\n")
+
+	writeHooks(&buf, cfg.Hooks)
+	buf.WriteString("\n")
+
+	writeCLIProfiles(&buf, cfg)
+	buf.WriteString("\n")
 
 	buf.WriteString("# Experimental Features\n")
 	buf.WriteString("experimental:\n")
@@ -188,3 +231,183 @@ func (s *YAMLStrategy) writeYAMLList(buf *bytes.Buffer, key string, items []stri
 		buf.WriteString(fmt.Sprintf("%s  - %s\n", prefix, item))
 	}
 }
+
+// writePackageMappings writes explicit package target mappings, or a
+// commented example when none are configured.
+func writePackageMappings(buf *bytes.Buffer, mappings map[string]string) {
+	buf.WriteString("  # Explicit target subpaths per package (override package name mapping)\n")
+	if len(mappings) == 0 {
+		buf.WriteString("  # mappings:\n")
+		buf.WriteString("  #   dot-work-ssh: .ssh\n")
+		return
+	}
+
+	names := make([]string, 0, len(mappings))
+	for name := range mappings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	buf.WriteString("  mappings:\n")
+	for _, name := range names {
+		buf.WriteString(fmt.Sprintf("    %q: %q\n", name, mappings[name]))
+	}
+}
+
+// writeOutputColors writes the per-role color overrides, or a commented
+// example when none are configured.
+func writeOutputColors(buf *bytes.Buffer, colors ColorsConfig) {
+	buf.WriteString("  # Per-role 256-color codes (0-255) overriding the theme\n")
+	if colors.IsZero() {
+		buf.WriteString("  # colors:\n")
+		buf.WriteString("  #   dim: \"240\"\n")
+		buf.WriteString("  #   heading: \"25\"\n")
+		return
+	}
+
+	buf.WriteString("  colors:\n")
+	for _, role := range colors.Roles() {
+		if role.Code != "" {
+			buf.WriteString(fmt.Sprintf("    %s: %q\n", role.Name, role.Code))
+		}
+	}
+}
+
+// writeDoctorCategories writes custom orphan triage categories, or a
+// commented example when none are configured.
+func writeDoctorCategories(buf *bytes.Buffer, categories []CategoryConfig) {
+	buf.WriteString("  # Custom symlink categories for orphan triage (override built-ins by name)\n")
+	if len(categories) == 0 {
+		buf.WriteString("  # categories:\n")
+		buf.WriteString("  #   - name: work-tools\n")
+		buf.WriteString("  #     description: Tools installed by the work bootstrap\n")
+		buf.WriteString("  #     confidence: high\n")
+		buf.WriteString("  #     patterns: [\"/opt/work/*\"]\n")
+		return
+	}
+
+	buf.WriteString("  categories:\n")
+	for _, cat := range categories {
+		buf.WriteString(fmt.Sprintf("    - name: %q\n", cat.Name))
+		if cat.Description != "" {
+			buf.WriteString(fmt.Sprintf("      description: %q\n", cat.Description))
+		}
+		if cat.Confidence != "" {
+			buf.WriteString(fmt.Sprintf("      confidence: %s\n", cat.Confidence))
+		}
+		buf.WriteString("      patterns:\n")
+		for _, pattern := range cat.Patterns {
+			buf.WriteString(fmt.Sprintf("        - %q\n", pattern))
+		}
+	}
+}
+
+// writeHooks writes the configured hooks, or a commented example when
+// none are configured.
+func writeHooks(buf *bytes.Buffer, hooks HooksConfig) {
+	buf.WriteString("# Hooks\n")
+	buf.WriteString("# Commands run before and after manage and unmanage, once per package\n")
+	stages := []struct {
+		key   string
+		hooks []HookConfig
+	}{
+		{"pre_manage", hooks.PreManage},
+		{"post_manage", hooks.PostManage},
+		{"pre_unmanage", hooks.PreUnmanage},
+		{"post_unmanage", hooks.PostUnmanage},
+	}
+
+	configured := false
+	for _, stage := range stages {
+		configured = configured || len(stage.hooks) > 0
+	}
+	if !configured {
+		buf.WriteString("# hooks:\n")
+		buf.WriteString("#   post_manage:\n")
+		buf.WriteString("#     - command: [\"gpg\", \"--import\", \"keys.asc\"]\n")
+		buf.WriteString("#       packages: [\"dot-gnupg\"]\n")
+		return
+	}
+
+	buf.WriteString("hooks:\n")
+	for _, stage := range stages {
+		if len(stage.hooks) == 0 {
+			continue
+		}
+		buf.WriteString(fmt.Sprintf("  %s:\n", stage.key))
+		for _, hook := range stage.hooks {
+			buf.WriteString(fmt.Sprintf("    - command: %s\n", quotedList(hook.Command)))
+			if hook.Shell {
+				buf.WriteString("      shell: true\n")
+			}
+			if len(hook.Packages) > 0 {
+				buf.WriteString(fmt.Sprintf("      packages: %s\n", quotedList(hook.Packages)))
+			}
+		}
+	}
+}
+
+// writeCLIProfiles writes the named command presets, or a commented
+// example when none are configured.
+func writeCLIProfiles(buf *bytes.Buffer, cfg *ExtendedConfig) {
+	buf.WriteString("# CLI Profiles\n")
+	buf.WriteString("# Named command defaults selected with --profile-preset; settings in this\n")
+	buf.WriteString("# file, environment variables and flags still override them\n")
+	if len(cfg.CLIProfiles) == 0 {
+		buf.WriteString("# cli_profiles:\n")
+		buf.WriteString("#   careful:\n")
+		buf.WriteString("#     dry_run: true\n")
+		buf.WriteString("#     folding: false\n")
+		buf.WriteString("#     link_mode: absolute\n")
+		buf.WriteString("#     backup: true\n")
+		return
+	}
+
+	buf.WriteString("cli_profiles:\n")
+	for _, name := range cfg.CLIProfileNames() {
+		profile := cfg.CLIProfiles[name]
+		buf.WriteString(fmt.Sprintf("  %q:", name))
+		settings := []struct {
+			key   string
+			value string
+		}{
+			{"dry_run", formatOptionalBool(profile.DryRun)},
+			{"folding", formatOptionalBool(profile.Folding)},
+			{"link_mode", profile.LinkMode},
+			{"backup", formatOptionalBool(profile.Backup)},
+			{"overwrite", formatOptionalBool(profile.Overwrite)},
+			{"backup_strategy", profile.BackupStrategy},
+		}
+		empty := true
+		for _, setting := range settings {
+			if setting.value == "" {
+				continue
+			}
+			if empty {
+				buf.WriteString("\n")
+				empty = false
+			}
+			buf.WriteString(fmt.Sprintf("    %s: %s\n", setting.key, setting.value))
+		}
+		if empty {
+			buf.WriteString(" {}\n")
+		}
+	}
+}
+
+// formatOptionalBool formats a set boolean, or returns "" when unset.
+func formatOptionalBool(b *bool) string {
+	if b == nil {
+		return ""
+	}
+	return fmt.Sprintf("%t", *b)
+}
+
+// quotedList formats items as a YAML flow sequence of quoted strings.
+func quotedList(items []string) string {
+	quoted := make([]string, len(items))
+	for i, item := range items {
+		quoted[i] = fmt.Sprintf("%q", item)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
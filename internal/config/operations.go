@@ -296,6 +296,42 @@ func getBackupDir() (string, error) {
 	return backupDir, nil
 }
 
+// BackupConfig saves a timestamped copy of the config file to the backup
+// directory and returns its path. Only the last 5 backups are kept.
+func BackupConfig(configPath string) (string, error) {
+	backupDir, err := getBackupDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get backup directory: %w", err)
+	}
+
+	backupPath, err := createBackup(configPath, backupDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to create backup: %w", err)
+	}
+
+	if err := cleanupOldBackups(backupDir, 5); err != nil {
+		// Non-fatal: the backup itself was written
+		fmt.Fprintf(os.Stderr, "Warning: failed to cleanup old backups: %v\n", err)
+	}
+
+	return backupPath, nil
+}
+
+// RestoreConfig copies a backup made by BackupConfig over the config file,
+// leaving the backup in place.
+func RestoreConfig(backupPath, configPath string) error {
+	data, err := os.ReadFile(backupPath)
+	if err != nil {
+		return fmt.Errorf("cannot read backup file: %w", err)
+	}
+
+	if err := os.WriteFile(configPath, data, 0600); err != nil {
+		return fmt.Errorf("cannot restore config file: %w", err)
+	}
+
+	return nil
+}
+
 // createBackup creates a timestamped backup of the config file.
 func createBackup(configPath, backupDir string) (string, error) {
 	// Read original config
@@ -729,3 +729,23 @@ func TestCreateBackup_PreservesContent(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, testContent, backupContent)
 }
+
+func TestBackupConfig_RestoreConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", tmpDir)
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	original := []byte("logging:\n  level: INFO\n")
+	require.NoError(t, os.WriteFile(configPath, original, 0600))
+
+	backupPath, err := BackupConfig(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(tmpDir, "dot", "backups"), filepath.Dir(backupPath))
+
+	require.NoError(t, os.WriteFile(configPath, []byte("logging: [broken\n"), 0600))
+	require.NoError(t, RestoreConfig(backupPath, configPath))
+
+	restored, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, original, restored)
+	assert.FileExists(t, backupPath, "restoring keeps the backup")
+}
@@ -0,0 +1,88 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrUnknownCLIProfile indicates --profile-preset names a preset that the
+// cli_profiles section does not define.
+type ErrUnknownCLIProfile struct {
+	Name      string
+	Available []string
+}
+
+func (e ErrUnknownCLIProfile) Error() string {
+	if len(e.Available) == 0 {
+		return fmt.Sprintf("unknown profile preset %q (no cli_profiles are configured)", e.Name)
+	}
+	return fmt.Sprintf("unknown profile preset %q (available: %s)", e.Name, strings.Join(e.Available, ", "))
+}
+
+// CLIProfileNames returns the names of the configured CLI profiles, sorted.
+func (c *ExtendedConfig) CLIProfileNames() []string {
+	names := make([]string, 0, len(c.CLIProfiles))
+	for name := range c.CLIProfiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// applyTo sets the settings the profile defines on cfg.
+func (p CLIProfileConfig) applyTo(cfg *ExtendedConfig) {
+	if p.DryRun != nil {
+		cfg.Operations.DryRun = *p.DryRun
+	}
+	if p.Folding != nil {
+		cfg.Symlinks.Folding = *p.Folding
+	}
+	if p.LinkMode != "" {
+		cfg.Symlinks.Mode = p.LinkMode
+	}
+	if p.Backup != nil {
+		cfg.Symlinks.Backup = *p.Backup
+	}
+	if p.Overwrite != nil {
+		cfg.Symlinks.Overwrite = *p.Overwrite
+	}
+	if p.BackupStrategy != "" {
+		cfg.Symlinks.BackupStrategy = p.BackupStrategy
+	}
+}
+
+// LoadWithPreset loads configuration like LoadWithEnv with the named CLI
+// profile applied beneath the config file: the preset replaces defaults,
+// but settings the file or environment set explicitly win over it. An
+// empty name loads without a preset.
+// Precedence: env > file > preset > defaults
+func (l *Loader) LoadWithPreset(name string) (*ExtendedConfig, error) {
+	cfg, err := l.LoadWithEnv()
+	if err != nil || name == "" {
+		return cfg, err
+	}
+
+	profile, ok := cfg.CLIProfiles[name]
+	if !ok {
+		return nil, ErrUnknownCLIProfile{Name: name, Available: cfg.CLIProfileNames()}
+	}
+
+	// Layer the file over the preset as it would be layered over an
+	// included file, so only keys it sets replace preset values
+	cfg = DefaultExtended()
+	profile.applyTo(cfg)
+	if err := applyIncludeLayers(cfg, l.configPath, nil); err != nil {
+		return nil, fmt.Errorf("load config file: %w", err)
+	}
+	if err := validateExpanded(cfg, cfg.expandPaths()); err != nil {
+		return nil, fmt.Errorf("validate config: %w", err)
+	}
+
+	cfg = mergeConfigs(cfg, l.loadFromEnv())
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,107 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaklabco/dot/internal/config"
+)
+
+const profilesConfig = `
+symlinks:
+  folding: true
+cli_profiles:
+  careful:
+    dry_run: true
+    folding: false
+    link_mode: absolute
+    backup: true
+  quick:
+    overwrite: true
+`
+
+func writeProfilesConfig(t *testing.T, content string) string {
+	t.Helper()
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(content), 0600))
+	return configPath
+}
+
+func TestLoader_LoadWithPreset(t *testing.T) {
+	loader := config.NewLoader("dot", writeProfilesConfig(t, profilesConfig))
+
+	cfg, err := loader.LoadWithPreset("careful")
+	require.NoError(t, err)
+
+	assert.True(t, cfg.Operations.DryRun, "preset replaces defaults")
+	assert.Equal(t, "absolute", cfg.Symlinks.Mode)
+	assert.True(t, cfg.Symlinks.Backup)
+	assert.True(t, cfg.Symlinks.Folding, "settings in the file win over the preset")
+	assert.False(t, cfg.Symlinks.Overwrite, "settings of other presets are not applied")
+}
+
+func TestLoader_LoadWithPreset_NoPreset(t *testing.T) {
+	loader := config.NewLoader("dot", writeProfilesConfig(t, profilesConfig))
+
+	withEnv, err := loader.LoadWithEnv()
+	require.NoError(t, err)
+	cfg, err := loader.LoadWithPreset("")
+	require.NoError(t, err)
+
+	assert.Equal(t, withEnv, cfg)
+	assert.Equal(t, []string{"careful", "quick"}, cfg.CLIProfileNames())
+}
+
+func TestLoader_LoadWithPreset_EnvOverridesPreset(t *testing.T) {
+	t.Setenv("DOT_SYMLINKS_MODE", "auto")
+	loader := config.NewLoader("dot", writeProfilesConfig(t, profilesConfig))
+
+	cfg, err := loader.LoadWithPreset("careful")
+	require.NoError(t, err)
+
+	assert.Equal(t, "auto", cfg.Symlinks.Mode)
+	assert.True(t, cfg.Operations.DryRun)
+}
+
+func TestLoader_LoadWithPreset_Unknown(t *testing.T) {
+	loader := config.NewLoader("dot", writeProfilesConfig(t, profilesConfig))
+
+	_, err := loader.LoadWithPreset("missing")
+	var unknown config.ErrUnknownCLIProfile
+	require.ErrorAs(t, err, &unknown)
+	assert.Equal(t, []string{"careful", "quick"}, unknown.Available)
+	assert.Contains(t, err.Error(), `unknown profile preset "missing" (available: careful, quick)`)
+
+	loader = config.NewLoader("dot", filepath.Join(t.TempDir(), "absent.yaml"))
+	_, err = loader.LoadWithPreset("careful")
+	require.ErrorAs(t, err, &unknown)
+	assert.Contains(t, err.Error(), "no cli_profiles are configured")
+}
+
+func TestValidate_CLIProfiles(t *testing.T) {
+	cfg := config.DefaultExtended()
+	cfg.CLIProfiles = map[string]config.CLIProfileConfig{
+		"bad": {LinkMode: "hard", BackupStrategy: "numbered"},
+	}
+
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `cli_profiles.bad.link_mode: invalid link mode "hard"`)
+	assert.Contains(t, err.Error(), `cli_profiles.bad.backup_strategy: invalid backup strategy "numbered"`)
+}
+
+func TestYAMLStrategy_RoundTripsCLIProfiles(t *testing.T) {
+	loader := config.NewLoader("dot", writeProfilesConfig(t, profilesConfig))
+	cfg, err := loader.LoadWithEnv()
+	require.NoError(t, err)
+
+	data, err := config.NewYAMLStrategy().Marshal(cfg, config.MarshalOptions{})
+	require.NoError(t, err)
+	reloaded, err := config.NewLoader("dot", writeProfilesConfig(t, string(data))).LoadWithEnv()
+	require.NoError(t, err)
+
+	assert.Equal(t, cfg.CLIProfiles, reloaded.CLIProfiles)
+}
@@ -0,0 +1,69 @@
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/yaklabco/dot/internal/adapters"
+)
+
+// sensitiveKeyParts mark fields whose values are never printed.
+var sensitiveKeyParts = []string{"token", "password", "passphrase", "secret", "credential", "api_key"}
+
+// Redacted returns a deep copy of cfg that is safe to print or share.
+// Fields whose names suggest secrets are masked, and credentials embedded
+// in URL values are removed.
+func Redacted(cfg *ExtendedConfig) (*ExtendedConfig, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+
+	// Round-trip through JSON for a deep copy so slices are not shared
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	clone := &ExtendedConfig{}
+	if err := json.Unmarshal(data, clone); err != nil {
+		return nil, err
+	}
+
+	redactValue(reflect.ValueOf(clone).Elem(), false)
+	return clone, nil
+}
+
+func redactValue(v reflect.Value, sensitive bool) {
+	switch v.Kind() {
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			name := yamlFieldName(t.Field(i))
+			if name == "" {
+				continue
+			}
+			redactValue(v.Field(i), sensitive || isSensitiveKey(name))
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			redactValue(v.Index(i), sensitive)
+		}
+	case reflect.String:
+		switch {
+		case sensitive && v.String() != "":
+			v.SetString(adapters.RedactedValue)
+		case strings.Contains(v.String(), "://"):
+			v.SetString(adapters.RedactURL(v.String()))
+		}
+	}
+}
+
+func isSensitiveKey(name string) bool {
+	lower := strings.ToLower(name)
+	for _, part := range sensitiveKeyParts {
+		if strings.Contains(lower, part) {
+			return true
+		}
+	}
+	return false
+}
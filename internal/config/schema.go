@@ -0,0 +1,192 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// SchemaID is the canonical identifier of the configuration JSON Schema.
+const SchemaID = "https://github.com/yaklabco/dot/config.schema.json"
+
+// schemaConstraint describes validation rules for a single field that
+// cannot be inferred from its Go type alone.
+type schemaConstraint struct {
+	enum      []string
+	minimum   *int
+	maximum   *int
+	minLength int
+	pattern   string
+}
+
+func intPtr(i int) *int { return &i }
+
+// colorCodePattern matches a 256-color palette code, as validColorCode does.
+const colorCodePattern = "^(25[0-5]|2[0-4][0-9]|1[0-9]{2}|[1-9]?[0-9])$"
+
+// schemaConstraints mirrors the rules enforced by ExtendedConfig.Validate.
+// Keys are dotted paths using yaml tag names. Enum and bound values reference
+// the same variables Validate uses.
+var schemaConstraints = map[string]schemaConstraint{
+	"directories.package":              {minLength: 1},
+	"directories.target":               {minLength: 1},
+	"logging.level":                    {enum: validLogLevels},
+	"logging.format":                   {enum: validLogFormats},
+	"logging.destination":              {enum: validLogDestinations},
+	"logging.max_size_mb":              {minimum: intPtr(0)},
+	"logging.max_backups":              {minimum: intPtr(0)},
+	"logging.max_age_days":             {minimum: intPtr(0)},
+	"symlinks.mode":                    {enum: validSymlinkModes},
+	"symlinks.backup_strategy":         {enum: validBackupStrategies},
+	"cli_profiles.link_mode":           {enum: validSymlinkModes},
+	"cli_profiles.backup_strategy":     {enum: validBackupStrategies},
+	"ignore.max_file_size":             {minimum: intPtr(0)},
+	"output.format":                    {enum: validOutputFormats},
+	"output.color":                     {enum: validColorModes},
+	"output.verbosity":                 {minimum: intPtr(minVerbosity), maximum: intPtr(maxVerbosity)},
+	"output.width":                     {minimum: intPtr(0)},
+	"output.theme":                     {enum: validThemes},
+	"output.colors.success":            {pattern: colorCodePattern},
+	"output.colors.warning":            {pattern: colorCodePattern},
+	"output.colors.error":              {pattern: colorCodePattern},
+	"output.colors.info":               {pattern: colorCodePattern},
+	"output.colors.dim":                {pattern: colorCodePattern},
+	"output.colors.accent":             {pattern: colorCodePattern},
+	"output.colors.heading":            {pattern: colorCodePattern},
+	"operations.max_parallel":          {minimum: intPtr(0)},
+	"operations.transaction_size":      {minimum: intPtr(0)},
+	"operations.retry.max_attempts":    {minimum: intPtr(0)},
+	"operations.retry.base_backoff_ms": {minimum: intPtr(0)},
+	"packages.sort_by":                 {enum: validSortFields},
+	"update.check_frequency":           {minimum: intPtr(minCheckFrequency)},
+	"update.package_manager":           {enum: validPackageManagers},
+	"update.repository":                {pattern: "^[^/]+/[^/]+$"},
+	"update.detection_cache_ttl":       {minimum: intPtr(0)},
+	"network.timeout":                  {minimum: intPtr(0)},
+	"network.connect_timeout":          {minimum: intPtr(0)},
+	"network.tls_timeout":              {minimum: intPtr(0)},
+	"network.clone_retries":            {minimum: intPtr(0)},
+}
+
+// GenerateSchema returns a JSON Schema (draft 2020-12) describing ExtendedConfig.
+//
+// Property names and types are derived from the struct's yaml tags and Go
+// field types. Enumerations and numeric bounds come from the same values used
+// by Validate. Returns an error if a constraint refers to a field that no
+// longer exists.
+func GenerateSchema() ([]byte, error) {
+	used := make(map[string]bool, len(schemaConstraints))
+	root := schemaForType(reflect.TypeOf(ExtendedConfig{}), "", used)
+	root["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	root["$id"] = SchemaID
+	root["title"] = "dot configuration"
+
+	var missing []string
+	for key := range schemaConstraints {
+		if !used[key] {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, fmt.Errorf("schema constraints reference unknown fields: %s", strings.Join(missing, ", "))
+	}
+
+	return json.MarshalIndent(root, "", "  ")
+}
+
+// schemaForType builds the schema fragment for t located at the dotted path.
+func schemaForType(t reflect.Type, path string, used map[string]bool) map[string]any {
+	switch t.Kind() {
+	case reflect.Struct:
+		properties := make(map[string]any, t.NumField())
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name := yamlFieldName(field)
+			if name == "" {
+				continue
+			}
+			childPath := name
+			if path != "" {
+				childPath = path + "." + name
+			}
+			properties[name] = schemaForType(field.Type, childPath, used)
+		}
+		return map[string]any{
+			"type":                 "object",
+			"properties":           properties,
+			"additionalProperties": false,
+		}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{
+			"type":  "array",
+			"items": schemaForType(t.Elem(), path, used),
+		}
+	case reflect.Map:
+		return map[string]any{
+			"type":                 "object",
+			"additionalProperties": schemaForType(t.Elem(), path, used),
+		}
+	case reflect.Pointer:
+		// Optional values are described by the type they point to
+		return schemaForType(t.Elem(), path, used)
+	}
+
+	schema := map[string]any{"type": jsonSchemaType(t.Kind())}
+	if c, ok := schemaConstraints[path]; ok {
+		used[path] = true
+		applyConstraint(schema, c)
+	}
+	return schema
+}
+
+func applyConstraint(schema map[string]any, c schemaConstraint) {
+	if len(c.enum) > 0 {
+		schema["enum"] = c.enum
+	}
+	if c.minimum != nil {
+		schema["minimum"] = *c.minimum
+	}
+	if c.maximum != nil {
+		schema["maximum"] = *c.maximum
+	}
+	if c.minLength > 0 {
+		schema["minLength"] = c.minLength
+	}
+	if c.pattern != "" {
+		schema["pattern"] = c.pattern
+	}
+}
+
+// yamlFieldName returns the yaml key for a struct field, or "" if the
+// field is skipped.
+func yamlFieldName(field reflect.StructField) string {
+	if !field.IsExported() {
+		return ""
+	}
+	tag := field.Tag.Get("yaml")
+	if tag == "-" {
+		return ""
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	if name == "" {
+		return strings.ToLower(field.Name)
+	}
+	return name
+}
+
+func jsonSchemaType(kind reflect.Kind) string {
+	switch kind {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	default:
+		return "string"
+	}
+}
@@ -0,0 +1,160 @@
+package config_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaklabco/dot/internal/config"
+)
+
+// validateAgainstSchema is a minimal JSON Schema validator covering the
+// keywords emitted by GenerateSchema.
+func validateAgainstSchema(schema map[string]any, value any, path string) error {
+	switch schema["type"] {
+	case "object":
+		obj, ok := value.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%s: expected object", path)
+		}
+		props, _ := schema["properties"].(map[string]any)
+		for key, v := range obj {
+			propSchema, ok := props[key].(map[string]any)
+			if !ok {
+				if schema["additionalProperties"] == false {
+					return fmt.Errorf("%s.%s: unknown property", path, key)
+				}
+				continue
+			}
+			if err := validateAgainstSchema(propSchema, v, path+"."+key); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "array":
+		if value == nil {
+			return nil
+		}
+		arr, ok := value.([]any)
+		if !ok {
+			return fmt.Errorf("%s: expected array", path)
+		}
+		items, _ := schema["items"].(map[string]any)
+		for i, v := range arr {
+			if err := validateAgainstSchema(items, v, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%s: expected boolean", path)
+		}
+		return nil
+	case "integer":
+		n, ok := value.(float64)
+		if !ok || n != float64(int64(n)) {
+			return fmt.Errorf("%s: expected integer", path)
+		}
+		if minimum, ok := schema["minimum"].(float64); ok && n < minimum {
+			return fmt.Errorf("%s: below minimum", path)
+		}
+		if maximum, ok := schema["maximum"].(float64); ok && n > maximum {
+			return fmt.Errorf("%s: above maximum", path)
+		}
+		return nil
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("%s: expected string", path)
+		}
+		if enum, ok := schema["enum"].([]any); ok {
+			found := false
+			for _, e := range enum {
+				if e == s {
+					found = true
+				}
+			}
+			if !found {
+				return fmt.Errorf("%s: %q not in enum", path, s)
+			}
+		}
+		if minLength, ok := schema["minLength"].(float64); ok && float64(len(s)) < minLength {
+			return fmt.Errorf("%s: too short", path)
+		}
+		if pattern, ok := schema["pattern"].(string); ok && !regexp.MustCompile(pattern).MatchString(s) {
+			return fmt.Errorf("%s: does not match pattern", path)
+		}
+		return nil
+	}
+	return fmt.Errorf("%s: unsupported schema type %v", path, schema["type"])
+}
+
+func loadSchema(t *testing.T) map[string]any {
+	t.Helper()
+	data, err := config.GenerateSchema()
+	require.NoError(t, err)
+
+	var schema map[string]any
+	require.NoError(t, json.Unmarshal(data, &schema))
+	return schema
+}
+
+func configAsJSONValue(t *testing.T, cfg *config.ExtendedConfig) map[string]any {
+	t.Helper()
+	data, err := json.Marshal(cfg)
+	require.NoError(t, err)
+
+	var value map[string]any
+	require.NoError(t, json.Unmarshal(data, &value))
+	return value
+}
+
+func TestGenerateSchema_Metadata(t *testing.T) {
+	schema := loadSchema(t)
+
+	assert.Equal(t, config.SchemaID, schema["$id"])
+	assert.Equal(t, "object", schema["type"])
+
+	props := schema["properties"].(map[string]any)
+	for _, section := range []string{"directories", "logging", "symlinks", "output", "update"} {
+		assert.Contains(t, props, section)
+	}
+
+	logging := props["logging"].(map[string]any)["properties"].(map[string]any)
+	assert.ElementsMatch(t, []any{"DEBUG", "INFO", "WARN", "ERROR"}, logging["level"].(map[string]any)["enum"])
+
+	ignore := props["ignore"].(map[string]any)["properties"].(map[string]any)
+	assert.Equal(t, "array", ignore["patterns"].(map[string]any)["type"])
+}
+
+func TestGenerateSchema_ValidatesDefaultConfig(t *testing.T) {
+	schema := loadSchema(t)
+	value := configAsJSONValue(t, config.DefaultExtended())
+
+	assert.NoError(t, validateAgainstSchema(schema, value, "$"))
+}
+
+func TestGenerateSchema_RejectsInvalidLogLevel(t *testing.T) {
+	schema := loadSchema(t)
+	cfg := config.DefaultExtended()
+	cfg.Logging.Level = "VERBOSE"
+
+	// Schema and Validate must agree
+	require.Error(t, cfg.Validate())
+	err := validateAgainstSchema(schema, configAsJSONValue(t, cfg), "$")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "logging.level")
+}
+
+func TestGenerateSchema_RejectsOutOfRangeVerbosity(t *testing.T) {
+	schema := loadSchema(t)
+	cfg := config.DefaultExtended()
+	cfg.Output.Verbosity = 4
+
+	require.Error(t, cfg.Validate())
+	assert.Error(t, validateAgainstSchema(schema, configAsJSONValue(t, cfg), "$"))
+}
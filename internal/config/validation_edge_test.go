@@ -53,6 +53,7 @@ func TestValidateSymlinks_AllModes(t *testing.T) {
 	}{
 		{"relative", false},
 		{"absolute", false},
+		{"auto", false},
 		{"invalid-mode", true},
 		{"", true},
 	}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/yaklabco/dot/internal/domain"
@@ -57,7 +58,8 @@ func (w *Writer) Update(key string, value interface{}) error {
 	var err error
 
 	if fileExists(w.path) {
-		cfg, err = LoadExtendedFromFile(w.path)
+		// Read without expanding so ${VAR} references are written back as is
+		cfg, err = readExtendedFile(w.path)
 		if err != nil {
 			return fmt.Errorf("load existing config: %w", err)
 		}
@@ -67,7 +69,7 @@ func (w *Writer) Update(key string, value interface{}) error {
 	}
 
 	// Update value
-	if err := w.setValue(cfg, key, value); err != nil {
+	if err := setField(cfg, key, value); err != nil {
 		return fmt.Errorf("set value: %w", err)
 	}
 
@@ -76,6 +78,11 @@ func (w *Writer) Update(key string, value interface{}) error {
 		return err
 	}
 
+	// Edit existing YAML files in place so user comments and layout survive
+	if w.DetectFormat() == "yaml" && fileExists(w.path) {
+		return w.updateYAMLInPlace(cfg, key)
+	}
+
 	// Write back
 	opts := WriteOptions{
 		Format:          w.DetectFormat(),
@@ -84,6 +91,30 @@ func (w *Writer) Update(key string, value interface{}) error {
 	return w.Write(cfg, opts)
 }
 
+// updateYAMLInPlace writes the value of key from cfg into the existing YAML
+// file, leaving every other node untouched.
+func (w *Writer) updateYAMLInPlace(cfg *ExtendedConfig, key string) error {
+	field, err := lookupField(cfg, key)
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(w.path)
+	if err != nil {
+		return fmt.Errorf("read config file: %w", err)
+	}
+
+	updated, err := setYAMLValue(data, key, field.Interface())
+	if err != nil {
+		return fmt.Errorf("update %s: %w", key, err)
+	}
+
+	if err := os.WriteFile(w.path, updated, domain.PermUserRW); err != nil {
+		return fmt.Errorf("write config file: %w", err)
+	}
+	return nil
+}
+
 // WriteOptions controls configuration file output.
 type WriteOptions struct {
 	Format          string // yaml, json, toml
@@ -126,343 +157,6 @@ func (w *Writer) DetectFormat() string {
 	}
 }
 
-// setValue sets a configuration value by dotted key path.
-func (w *Writer) setValue(cfg *ExtendedConfig, key string, value interface{}) error {
-	parts := strings.Split(key, ".")
-	if len(parts) < 2 {
-		return fmt.Errorf("invalid key: %s (must be section.field)", key)
-	}
-
-	section := parts[0]
-	field := parts[1]
-
-	switch section {
-	case "directories":
-		return setDirectoriesValue(&cfg.Directories, field, value)
-	case "logging":
-		return setLoggingValue(&cfg.Logging, field, value)
-	case "symlinks":
-		return setSymlinksValue(&cfg.Symlinks, field, value)
-	case "ignore":
-		return setIgnoreValue(&cfg.Ignore, field, value)
-	case "dotfile":
-		return setDotfileValue(&cfg.Dotfile, field, value)
-	case "output":
-		return setOutputValue(&cfg.Output, field, value)
-	case "operations":
-		return setOperationsValue(&cfg.Operations, field, value)
-	case "packages":
-		return setPackagesValue(&cfg.Packages, field, value)
-	case "doctor":
-		return setDoctorValue(&cfg.Doctor, field, value)
-	case "experimental":
-		return setExperimentalValue(&cfg.Experimental, field, value)
-	default:
-		return fmt.Errorf("unknown section: %s", section)
-	}
-}
-
-func setDirectoriesValue(cfg *DirectoriesConfig, field string, value interface{}) error {
-	str, ok := value.(string)
-	if !ok {
-		return fmt.Errorf("directories.%s: value must be string", field)
-	}
-
-	switch field {
-	case "package":
-		cfg.Package = str
-	case "target":
-		cfg.Target = str
-	case "manifest":
-		cfg.Manifest = str
-	default:
-		return fmt.Errorf("unknown field: directories.%s", field)
-	}
-
-	return nil
-}
-
-func setLoggingValue(cfg *LoggingConfig, field string, value interface{}) error {
-	switch field {
-	case "level", "format", "destination", "file":
-		str, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("logging.%s: value must be string", field)
-		}
-
-		switch field {
-		case "level":
-			cfg.Level = str
-		case "format":
-			cfg.Format = str
-		case "destination":
-			cfg.Destination = str
-		case "file":
-			cfg.File = str
-		}
-	default:
-		return fmt.Errorf("unknown field: logging.%s", field)
-	}
-
-	return nil
-}
-
-func setSymlinksValue(cfg *SymlinksConfig, field string, value interface{}) error {
-	switch field {
-	case "mode", "backup_suffix":
-		str, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("symlinks.%s: value must be string", field)
-		}
-
-		switch field {
-		case "mode":
-			cfg.Mode = str
-		case "backup_suffix":
-			cfg.BackupSuffix = str
-		}
-
-	case "folding", "overwrite", "backup":
-		b, ok := value.(bool)
-		if !ok {
-			return fmt.Errorf("symlinks.%s: value must be bool", field)
-		}
-
-		switch field {
-		case "folding":
-			cfg.Folding = b
-		case "overwrite":
-			cfg.Overwrite = b
-		case "backup":
-			cfg.Backup = b
-		}
-
-	default:
-		return fmt.Errorf("unknown field: symlinks.%s", field)
-	}
-
-	return nil
-}
-
-func setIgnoreValue(cfg *IgnoreConfig, field string, value interface{}) error {
-	switch field {
-	case "use_defaults":
-		b, ok := value.(bool)
-		if !ok {
-			return fmt.Errorf("ignore.%s: value must be bool", field)
-		}
-		cfg.UseDefaults = b
-
-	case "patterns", "overrides":
-		// Accept both []string and string
-		var arr []string
-		switch v := value.(type) {
-		case []string:
-			arr = v
-		case string:
-			// Split comma-separated string
-			arr = strings.Split(v, ",")
-			for i := range arr {
-				arr[i] = strings.TrimSpace(arr[i])
-			}
-		default:
-			return fmt.Errorf("ignore.%s: value must be []string or string", field)
-		}
-
-		switch field {
-		case "patterns":
-			cfg.Patterns = arr
-		case "overrides":
-			cfg.Overrides = arr
-		}
-
-	default:
-		return fmt.Errorf("unknown field: ignore.%s", field)
-	}
-
-	return nil
-}
-
-func setDotfileValue(cfg *DotfileConfig, field string, value interface{}) error {
-	switch field {
-	case "translate", "package_name_mapping":
-		b, err := toBool(value, "dotfile."+field)
-		if err != nil {
-			return err
-		}
-		switch field {
-		case "translate":
-			cfg.Translate = b
-		case "package_name_mapping":
-			cfg.PackageNameMapping = b
-		}
-
-	case "prefix":
-		str, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("dotfile.%s: value must be string", field)
-		}
-		cfg.Prefix = str
-
-	default:
-		return fmt.Errorf("unknown field: dotfile.%s", field)
-	}
-
-	return nil
-}
-
-func setOutputValue(cfg *OutputConfig, field string, value interface{}) error {
-	switch field {
-	case "format", "color":
-		str, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("output.%s: value must be string", field)
-		}
-
-		switch field {
-		case "format":
-			cfg.Format = str
-		case "color":
-			cfg.Color = str
-		}
-
-	case "progress":
-		b, ok := value.(bool)
-		if !ok {
-			return fmt.Errorf("output.%s: value must be bool", field)
-		}
-		cfg.Progress = b
-
-	case "verbosity", "width":
-		var i int
-		switch v := value.(type) {
-		case int:
-			i = v
-		case float64:
-			i = int(v)
-		default:
-			return fmt.Errorf("output.%s: value must be int", field)
-		}
-
-		switch field {
-		case "verbosity":
-			cfg.Verbosity = i
-		case "width":
-			cfg.Width = i
-		}
-
-	default:
-		return fmt.Errorf("unknown field: output.%s", field)
-	}
-
-	return nil
-}
-
-func setOperationsValue(cfg *OperationsConfig, field string, value interface{}) error {
-	switch field {
-	case "dry_run", "atomic":
-		b, ok := value.(bool)
-		if !ok {
-			return fmt.Errorf("operations.%s: value must be bool", field)
-		}
-
-		switch field {
-		case "dry_run":
-			cfg.DryRun = b
-		case "atomic":
-			cfg.Atomic = b
-		}
-
-	case "max_parallel":
-		var i int
-		switch v := value.(type) {
-		case int:
-			i = v
-		case float64:
-			i = int(v)
-		default:
-			return fmt.Errorf("operations.%s: value must be int", field)
-		}
-		cfg.MaxParallel = i
-
-	default:
-		return fmt.Errorf("unknown field: operations.%s", field)
-	}
-
-	return nil
-}
-
-func setPackagesValue(cfg *PackagesConfig, field string, value interface{}) error {
-	switch field {
-	case "sort_by":
-		str, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("packages.%s: value must be string", field)
-		}
-		cfg.SortBy = str
-
-	case "auto_discover", "validate_names":
-		b, ok := value.(bool)
-		if !ok {
-			return fmt.Errorf("packages.%s: value must be bool", field)
-		}
-
-		switch field {
-		case "auto_discover":
-			cfg.AutoDiscover = b
-		case "validate_names":
-			cfg.ValidateNames = b
-		}
-
-	default:
-		return fmt.Errorf("unknown field: packages.%s", field)
-	}
-
-	return nil
-}
-
-func setDoctorValue(cfg *DoctorConfig, field string, value interface{}) error {
-	b, ok := value.(bool)
-	if !ok {
-		return fmt.Errorf("doctor.%s: value must be bool", field)
-	}
-
-	switch field {
-	case "auto_fix":
-		cfg.AutoFix = b
-	case "check_manifest":
-		cfg.CheckManifest = b
-	case "check_broken_links":
-		cfg.CheckBrokenLinks = b
-	case "check_orphaned":
-		cfg.CheckOrphaned = b
-	case "check_permissions":
-		cfg.CheckPermissions = b
-	default:
-		return fmt.Errorf("unknown field: doctor.%s", field)
-	}
-
-	return nil
-}
-
-func setExperimentalValue(cfg *ExperimentalConfig, field string, value interface{}) error {
-	b, ok := value.(bool)
-	if !ok {
-		return fmt.Errorf("experimental.%s: value must be bool", field)
-	}
-
-	switch field {
-	case "parallel":
-		cfg.Parallel = b
-	case "profiling":
-		cfg.Profiling = b
-	default:
-		return fmt.Errorf("unknown field: experimental.%s", field)
-	}
-
-	return nil
-}
-
 // WriteConfigWithHeader writes configuration with a custom header comment.
 func WriteConfigWithHeader(path string, cfg *ExtendedConfig, header string) error {
 	writer := NewWriter(path)
@@ -516,6 +210,24 @@ func toBool(value interface{}, fieldName string) (bool, error) {
 	}
 }
 
+// toInt converts a value to int, accepting int, float64 and numeric strings.
+func toInt(value interface{}, fieldName string) (int, error) {
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case float64:
+		return int(v), nil
+	case string:
+		i, err := strconv.Atoi(strings.TrimSpace(v))
+		if err != nil {
+			return 0, fmt.Errorf("%s: value must be an integer, got %q", fieldName, v)
+		}
+		return i, nil
+	default:
+		return 0, fmt.Errorf("%s: value must be int", fieldName)
+	}
+}
+
 // fileExists checks if a file exists.
 func fileExists(path string) bool {
 	_, err := os.Stat(path)
@@ -3,6 +3,7 @@ package config_test
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -123,6 +124,128 @@ func TestWriter_Update(t *testing.T) {
 	assert.Equal(t, "/new/dotfiles", loaded.Directories.Package)
 }
 
+func TestWriter_UpdatePreservesComments(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+
+	original := `# My dotfiles config
+directories:
+  # Keep packages in the repo checkout
+  package: ~/dotfiles
+  target: ~
+
+logging:
+  # Raised while debugging adopt
+  level: INFO # was DEBUG
+  format: text
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(original), 0600))
+
+	writer := config.NewWriter(configPath)
+	require.NoError(t, writer.Update("logging.level", "WARN"))
+
+	data, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	content := string(data)
+
+	assert.Contains(t, content, "# My dotfiles config")
+	assert.Contains(t, content, "# Keep packages in the repo checkout")
+	assert.Contains(t, content, "# Raised while debugging adopt")
+	assert.Contains(t, content, "level: WARN # was DEBUG")
+	assert.Contains(t, content, "package: ~/dotfiles")
+	assert.NotContains(t, content, "symlinks:", "unrelated defaults must not be written")
+
+	// Key order is unchanged
+	assert.Less(t, strings.Index(content, "directories:"), strings.Index(content, "logging:"))
+	assert.Less(t, strings.Index(content, "level:"), strings.Index(content, "format:"))
+}
+
+func TestWriter_UpdateInsertsMissingKey(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+
+	original := `# Shared settings
+logging:
+    level: INFO
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(original), 0600))
+
+	writer := config.NewWriter(configPath)
+	require.NoError(t, writer.Update("logging.format", "json"))
+	require.NoError(t, writer.Update("symlinks.mode", "absolute"))
+
+	data, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	content := string(data)
+
+	assert.Contains(t, content, "# Shared settings")
+	assert.Contains(t, content, "    format: json", "detected 4-space indentation is kept")
+	assert.Contains(t, content, "symlinks:\n    mode: absolute")
+
+	loaded, err := config.LoadExtendedFromFile(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, "json", loaded.Logging.Format)
+	assert.Equal(t, "absolute", loaded.Symlinks.Mode)
+	assert.Equal(t, "INFO", loaded.Logging.Level)
+}
+
+func TestWriter_UpdateListValue(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+
+	original := "ignore:\n  # project specific\n  patterns: []\n"
+	require.NoError(t, os.WriteFile(configPath, []byte(original), 0600))
+
+	writer := config.NewWriter(configPath)
+	require.NoError(t, writer.Update("ignore.patterns", "*.log, *.tmp"))
+
+	loaded, err := config.LoadExtendedFromFile(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"*.log", "*.tmp"}, loaded.Ignore.Patterns)
+
+	data, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "# project specific")
+}
+
+func TestWriter_UpdateLogRotation(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	writer := config.NewWriter(configPath)
+
+	require.NoError(t, writer.Update("logging.max_size_mb", "25"))
+	require.NoError(t, writer.Update("logging.max_backups", 3))
+
+	loaded, err := config.LoadExtendedFromFile(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, 25, loaded.Logging.MaxSizeMB)
+	assert.Equal(t, 3, loaded.Logging.MaxBackups)
+
+	err = writer.Update("logging.max_age_days", "weekly")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must be an integer")
+
+	err = writer.Update("logging.max_backups", "-1")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "logging.max_backups")
+}
+
+func TestWriter_UpdateRetry(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	writer := config.NewWriter(configPath)
+
+	require.NoError(t, writer.Update("operations.retry.max_attempts", "5"))
+	require.NoError(t, writer.Update("operations.retry.base_backoff_ms", 250))
+
+	loaded, err := config.LoadExtendedFromFile(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, 5, loaded.Operations.Retry.MaxAttempts)
+	assert.Equal(t, 250, loaded.Operations.Retry.BaseBackoffMS)
+
+	err = writer.Update("operations.retry.max_attempts", "-1")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "operations.retry.max_attempts")
+}
+
 func TestWriter_UpdateNonExistentFile(t *testing.T) {
 	tmpDir := t.TempDir()
 	configPath := filepath.Join(tmpDir, "config.yaml")
@@ -149,7 +272,68 @@ func TestWriter_UpdateInvalidKey(t *testing.T) {
 	// Try to update with invalid key
 	err = writer.Update("invalid", "value")
 	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "invalid key")
+	assert.Contains(t, err.Error(), "unknown config key: invalid")
+}
+
+func TestWriter_UpdateEverySettableKey(t *testing.T) {
+	// Every key set completion offers must be accepted by Update. Each key
+	// is set to its current default so validation still passes.
+	defaults := config.DefaultExtended()
+	for _, key := range config.SettableKeys() {
+		t.Run(key, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "config.yaml")
+			writer := config.NewWriter(configPath)
+
+			value, err := config.GetValue(defaults, key)
+			require.NoError(t, err)
+			require.NoError(t, writer.Update(key, value))
+			// The second update edits the existing file in place
+			require.NoError(t, writer.Update(key, value))
+
+			loaded, err := config.LoadExtendedFromFile(configPath)
+			require.NoError(t, err)
+			got, err := config.GetValue(loaded, key)
+			require.NoError(t, err)
+			assert.Equal(t, value, got)
+		})
+	}
+}
+
+func TestWriter_UpdateConvertsStrings(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	writer := config.NewWriter(configPath)
+
+	require.NoError(t, writer.Update("network.timeout", "5"))
+	require.NoError(t, writer.Update("symlinks.folding", "false"))
+	require.NoError(t, writer.Update("update.include_prerelease", "true"))
+	require.NoError(t, writer.Update("packages.mappings", "dot-vim=.vim, git=.config/git"))
+
+	loaded, err := config.LoadExtendedFromFile(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, 5, loaded.Network.Timeout)
+	assert.False(t, loaded.Symlinks.Folding)
+	assert.True(t, loaded.Update.IncludePrerelease)
+	assert.Equal(t, map[string]string{"dot-vim": ".vim", "git": ".config/git"}, loaded.Packages.Mappings)
+
+	err = writer.Update("symlinks.folding", "sometimes")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must be true or false")
+
+	err = writer.Update("packages.mappings", "vim")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "key=value")
+}
+
+func TestWriter_UpdateStructuredKey(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	writer := config.NewWriter(configPath)
+
+	err := writer.Update("hooks.pre_manage", "echo hi")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "edit the config file")
+	assert.NotContains(t, config.SettableKeys(), "hooks.pre_manage")
+	assert.NotContains(t, config.SettableKeys(), "cli_profiles")
+	assert.Contains(t, config.SettableKeys(), "network.timeout")
 }
 
 func TestWriter_UpdateInvalidValue(t *testing.T) {
@@ -0,0 +1,118 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// defaultYAMLIndent is used when the indentation of a document cannot be detected.
+const defaultYAMLIndent = 2
+
+// setYAMLValue sets the dotted key to value within a YAML document.
+//
+// The document is edited as a node tree so comments, key order, and the
+// formatting of untouched values are preserved. Missing sections and keys
+// are appended to the end of their parent mapping.
+func setYAMLValue(data []byte, key string, value interface{}) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parse yaml: %w", err)
+	}
+
+	if doc.Kind == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{newMappingNode()}}
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("config root must be a mapping")
+	}
+
+	var replacement yaml.Node
+	if err := replacement.Encode(value); err != nil {
+		return nil, fmt.Errorf("encode value for %s: %w", key, err)
+	}
+
+	mapping := doc.Content[0]
+	parts := strings.Split(key, ".")
+	for i, part := range parts {
+		last := i == len(parts)-1
+		valueNode := mappingValue(mapping, part)
+
+		if valueNode == nil {
+			keyNode := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: part}
+			if last {
+				valueNode = &replacement
+			} else {
+				valueNode = newMappingNode()
+			}
+			mapping.Content = append(mapping.Content, keyNode, valueNode)
+			mapping = valueNode
+			continue
+		}
+
+		if last {
+			replaceNode(valueNode, &replacement)
+			break
+		}
+
+		if valueNode.Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("%s is not a section", strings.Join(parts[:i+1], "."))
+		}
+		mapping = valueNode
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(detectYAMLIndent(data))
+	if err := enc.Encode(&doc); err != nil {
+		return nil, fmt.Errorf("encode yaml: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("encode yaml: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// mappingValue returns the value node for key in mapping, or nil.
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// replaceNode overwrites dst with src while keeping dst's comments and, for
+// string scalars, its quoting style.
+func replaceNode(dst, src *yaml.Node) {
+	head, line, foot := dst.HeadComment, dst.LineComment, dst.FootComment
+	style := dst.Style
+	wasString := dst.Kind == yaml.ScalarNode && dst.Tag == "!!str"
+
+	*dst = *src
+	dst.HeadComment, dst.LineComment, dst.FootComment = head, line, foot
+	if wasString && src.Kind == yaml.ScalarNode && src.Tag == "!!str" && src.Style == 0 {
+		dst.Style = style
+	}
+}
+
+func newMappingNode() *yaml.Node {
+	return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+}
+
+// detectYAMLIndent returns the indentation width of the first indented
+// mapping line in data.
+func detectYAMLIndent(data []byte) int {
+	for _, line := range strings.Split(string(data), "\n") {
+		trimmed := strings.TrimLeft(line, " ")
+		indent := len(line) - len(trimmed)
+		if indent == 0 || trimmed == "" || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, "- ") {
+			continue
+		}
+		return indent
+	}
+	return defaultYAMLIndent
+}
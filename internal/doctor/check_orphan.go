@@ -334,6 +334,9 @@ func (c *OrphanCheck) checkForOrphanedLink(ctx context.Context, fullPath string,
 		if ignoreSet != nil && ignoreSet.ShouldIgnore(relPath) {
 			return
 		}
+		if c.linksIntoPartialPackage(ctx, fullPath, m) {
+			return
+		}
 
 		stats.TotalLinks++
 		stats.OrphanedLinks++
@@ -341,12 +344,7 @@ func (c *OrphanCheck) checkForOrphanedLink(ctx context.Context, fullPath string,
 		target, err := c.fs.ReadLink(ctx, fullPath)
 		if err == nil {
 			// Resolve target
-			var absTarget string
-			if filepath.IsAbs(target) {
-				absTarget = target
-			} else {
-				absTarget = filepath.Join(filepath.Dir(fullPath), target)
-			}
+			absTarget := domain.ResolveLinkTarget(fullPath, target)
 
 			// fmt.Printf("DEBUG: Checking broken link for %s -> %s (abs: %s)\n", fullPath, target, absTarget)
 			_, err = c.fs.Stat(ctx, absTarget)
@@ -376,6 +374,34 @@ func (c *OrphanCheck) checkForOrphanedLink(ctx context.Context, fullPath string,
 	}
 }
 
+// linksIntoPartialPackage reports whether the symlink at fullPath points
+// into the directory of a partially managed package. Such links reach files
+// the package's include globs left out, which the user links themselves.
+func (c *OrphanCheck) linksIntoPartialPackage(ctx context.Context, fullPath string, m *manifest.Manifest) bool {
+	var pkgDirs []string
+	for _, pkg := range m.Packages {
+		if pkg.Partial() && pkg.PackageDir != "" {
+			pkgDirs = append(pkgDirs, filepath.Clean(pkg.PackageDir))
+		}
+	}
+	if len(pkgDirs) == 0 {
+		return false
+	}
+
+	target, err := c.fs.ReadLink(ctx, fullPath)
+	if err != nil {
+		return false
+	}
+	target = domain.ResolveLinkTarget(fullPath, target)
+
+	for _, dir := range pkgDirs {
+		if target == dir || strings.HasPrefix(target, dir+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *OrphanCheck) determineScanDirectories(m *manifest.Manifest) []string {
 	if len(c.config.ScopeToDirs) > 0 {
 		return c.config.ScopeToDirs
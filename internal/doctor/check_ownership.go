@@ -0,0 +1,143 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/yaklabco/dot/internal/domain"
+)
+
+// LinkOwnershipCheck detects target paths claimed by more than one package
+// in the manifest. Only one package's link can exist on disk, so the others
+// silently lose it.
+type LinkOwnershipCheck struct {
+	fs                 FSReader
+	manifestSvc        ManifestLoader
+	targetDir          string
+	newTargetPath      TargetPathCreator
+	isManifestNotFound ManifestNotFoundChecker
+}
+
+func NewLinkOwnershipCheck(
+	fs FSReader,
+	manifestSvc ManifestLoader,
+	targetDir string,
+	newTargetPath TargetPathCreator,
+	isManifestNotFound ManifestNotFoundChecker,
+) *LinkOwnershipCheck {
+	return &LinkOwnershipCheck{
+		fs:                 fs,
+		manifestSvc:        manifestSvc,
+		targetDir:          targetDir,
+		newTargetPath:      newTargetPath,
+		isManifestNotFound: isManifestNotFound,
+	}
+}
+
+func (c *LinkOwnershipCheck) Name() string {
+	return "link_ownership"
+}
+
+func (c *LinkOwnershipCheck) Description() string {
+	return "Detects target links claimed by more than one package"
+}
+
+func (c *LinkOwnershipCheck) Run(ctx context.Context) (domain.CheckResult, error) {
+	result := domain.CheckResult{
+		CheckName: c.Name(),
+		Status:    domain.CheckStatusPass,
+		Issues:    make([]domain.Issue, 0),
+		Stats:     make(map[string]any),
+	}
+
+	targetPathResult := c.newTargetPath.NewTargetPath(c.targetDir)
+	if !targetPathResult.IsOk() {
+		return result, targetPathResult.UnwrapErr()
+	}
+
+	manifestResult := c.manifestSvc.Load(ctx, targetPathResult.Unwrap())
+	if !manifestResult.IsOk() {
+		err := manifestResult.UnwrapErr()
+		if c.isManifestNotFound(err) {
+			return result, nil
+		}
+		return result, fmt.Errorf("cannot access manifest: %w", err)
+	}
+	m := manifestResult.Unwrap()
+
+	owners := make(map[string][]string)
+	for pkgName, pkg := range m.Packages {
+		for _, link := range pkg.Links {
+			owners[link] = append(owners[link], pkgName)
+		}
+	}
+
+	links := make([]string, 0, len(owners))
+	for link, pkgs := range owners {
+		if len(pkgs) > 1 {
+			links = append(links, link)
+		}
+	}
+	sort.Strings(links)
+
+	for _, link := range links {
+		pkgs := owners[link]
+		sort.Strings(pkgs)
+
+		packageDirs := make(map[string]string, len(pkgs))
+		for _, name := range pkgs {
+			packageDirs[name] = m.Packages[name].PackageDir
+		}
+		current := LinkOwner(ctx, c.fs, c.targetDir, link, packageDirs)
+
+		message := fmt.Sprintf("Link claimed by multiple packages: %s", strings.Join(pkgs, ", "))
+		if current != "" {
+			message += fmt.Sprintf(" (currently linked to %s)", current)
+		}
+
+		result.Issues = append(result.Issues, domain.Issue{
+			Code:     "LINK_OWNERSHIP_CONFLICT",
+			Message:  message,
+			Severity: domain.IssueSeverityWarning,
+			Path:     link,
+			Context: map[string]any{
+				"packages":      pkgs,
+				"current_owner": current,
+				"suggestion":    "Run 'dot doctor --fix' to choose which package owns this link",
+			},
+		})
+	}
+
+	result.Stats["ownership_conflicts"] = len(links)
+	if len(links) > 0 {
+		result.Status = domain.CheckStatusWarning
+	}
+
+	return result, nil
+}
+
+// LinkOwner returns the package whose directory the link on disk points
+// into, or "" if it cannot be determined. link is relative to targetDir and
+// packageDirs maps package names to their package directories.
+func LinkOwner(ctx context.Context, fs FSReader, targetDir, link string, packageDirs map[string]string) string {
+	fullPath := filepath.Join(targetDir, link)
+	target, err := fs.ReadLink(ctx, fullPath)
+	if err != nil {
+		return ""
+	}
+	target = domain.ResolveLinkTarget(fullPath, target)
+
+	for name, dir := range packageDirs {
+		if dir == "" {
+			continue
+		}
+		dir = filepath.Clean(dir)
+		if target == dir || strings.HasPrefix(target, dir+string(filepath.Separator)) {
+			return name
+		}
+	}
+	return ""
+}
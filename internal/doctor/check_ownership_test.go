@@ -0,0 +1,158 @@
+package doctor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yaklabco/dot/internal/domain"
+	"github.com/yaklabco/dot/internal/manifest"
+)
+
+func newOwnershipTestManifest() manifest.Manifest {
+	m := manifest.New()
+	m.AddPackage(manifest.PackageInfo{
+		Name:       "git-work",
+		LinkCount:  2,
+		Links:      []string{".gitconfig", ".gitignore"},
+		PackageDir: "/home/user/dotfiles/git-work",
+	})
+	m.AddPackage(manifest.PackageInfo{
+		Name:       "git",
+		LinkCount:  1,
+		Links:      []string{".gitconfig"},
+		PackageDir: "/home/user/dotfiles/git",
+	})
+	m.AddPackage(manifest.PackageInfo{
+		Name:       "vim",
+		LinkCount:  1,
+		Links:      []string{".vimrc"},
+		PackageDir: "/home/user/dotfiles/vim",
+	})
+	return m
+}
+
+func TestLinkOwnershipCheck_Name(t *testing.T) {
+	check := NewLinkOwnershipCheck(nil, nil, "", nil, nil)
+	assert.Equal(t, "link_ownership", check.Name())
+	assert.Contains(t, check.Description(), "more than one package")
+}
+
+func TestLinkOwnershipCheck_Run_NoConflicts(t *testing.T) {
+	m := manifest.New()
+	m.AddPackage(manifest.PackageInfo{Name: "vim", LinkCount: 1, Links: []string{".vimrc"}})
+
+	check := NewLinkOwnershipCheck(
+		&mockFS{},
+		&mockManifestLoader{manifest: m},
+		"/home/user",
+		&mockTargetPathCreator{path: createValidTargetPath(t)},
+		isManifestNotFoundFunc,
+	)
+
+	result, err := check.Run(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.CheckStatusPass, result.Status)
+	assert.Empty(t, result.Issues)
+	assert.Equal(t, 0, result.Stats["ownership_conflicts"])
+}
+
+func TestLinkOwnershipCheck_Run_DetectsConflict(t *testing.T) {
+	tests := []struct {
+		name        string
+		linkTarget  string
+		linkErr     error
+		wantCurrent string
+	}{
+		{
+			name:        "absolute link into package",
+			linkTarget:  "/home/user/dotfiles/git/dot-gitconfig",
+			wantCurrent: "git",
+		},
+		{
+			name:        "relative link into package",
+			linkTarget:  "dotfiles/git-work/dot-gitconfig",
+			wantCurrent: "git-work",
+		},
+		{
+			name:        "link points elsewhere",
+			linkTarget:  "/etc/gitconfig",
+			wantCurrent: "",
+		},
+		{
+			name:        "link missing",
+			linkErr:     errors.New("not found"),
+			wantCurrent: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := &mockFS{
+				readLinkFunc: func(ctx context.Context, name string) (string, error) {
+					require.Equal(t, "/home/user/.gitconfig", name)
+					return tt.linkTarget, tt.linkErr
+				},
+			}
+			check := NewLinkOwnershipCheck(
+				fs,
+				&mockManifestLoader{manifest: newOwnershipTestManifest()},
+				"/home/user",
+				&mockTargetPathCreator{path: createValidTargetPath(t)},
+				isManifestNotFoundFunc,
+			)
+
+			result, err := check.Run(context.Background())
+
+			require.NoError(t, err)
+			assert.Equal(t, domain.CheckStatusWarning, result.Status)
+			assert.Equal(t, 1, result.Stats["ownership_conflicts"])
+			require.Len(t, result.Issues, 1)
+
+			issue := result.Issues[0]
+			assert.Equal(t, "LINK_OWNERSHIP_CONFLICT", issue.Code)
+			assert.Equal(t, ".gitconfig", issue.Path)
+			assert.Equal(t, domain.IssueSeverityWarning, issue.Severity)
+			assert.Equal(t, []string{"git", "git-work"}, issue.Context["packages"])
+			assert.Equal(t, tt.wantCurrent, issue.Context["current_owner"])
+			assert.Contains(t, issue.Message, "git, git-work")
+			if tt.wantCurrent != "" {
+				assert.Contains(t, issue.Message, "currently linked to "+tt.wantCurrent)
+			}
+		})
+	}
+}
+
+func TestLinkOwnershipCheck_Run_ManifestNotFound(t *testing.T) {
+	check := NewLinkOwnershipCheck(
+		&mockFS{},
+		&mockManifestLoader{err: errManifestNotFound},
+		"/home/user",
+		&mockTargetPathCreator{path: createValidTargetPath(t)},
+		isManifestNotFoundFunc,
+	)
+
+	result, err := check.Run(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.CheckStatusPass, result.Status)
+}
+
+func TestLinkOwnershipCheck_Run_ManifestLoadError(t *testing.T) {
+	check := NewLinkOwnershipCheck(
+		&mockFS{},
+		&mockManifestLoader{err: errors.New("IO error")},
+		"/home/user",
+		&mockTargetPathCreator{path: createValidTargetPath(t)},
+		isManifestNotFoundFunc,
+	)
+
+	_, err := check.Run(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot access manifest")
+}
@@ -79,9 +79,11 @@ func (c *ManagedPackageCheck) Run(ctx context.Context) (domain.CheckResult, erro
 	totalLinks := 0
 	brokenLinks := 0
 	managedLinks := 0
+	junctions := 0
 
 	for pkgName, pkgInfo := range m.Packages {
 		managedLinks += pkgInfo.LinkCount
+		junctions += len(pkgInfo.Junctions)
 		for _, linkPath := range pkgInfo.Links {
 			totalLinks++
 			healthResult := c.healthChecker.CheckLink(ctx, pkgName, linkPath, pkgInfo.PackageDir)
@@ -111,6 +113,7 @@ func (c *ManagedPackageCheck) Run(ctx context.Context) (domain.CheckResult, erro
 	result.Stats["total_links"] = totalLinks
 	result.Stats["broken_links"] = brokenLinks
 	result.Stats["managed_links"] = managedLinks
+	result.Stats["junctions"] = junctions
 
 	if brokenLinks > 0 {
 		result.Status = domain.CheckStatusFail
@@ -184,10 +187,31 @@ func (c *ManifestIntegrityCheck) Run(ctx context.Context) (domain.CheckResult, e
 
 	m := manifestResult.Unwrap()
 
+	// Checksum verification, when the loader supports it
+	if verifier, ok := c.manifestSvc.(ManifestVerifier); ok {
+		valid, err := verifier.Verify(ctx, targetPath)
+		if err != nil {
+			return result, fmt.Errorf("cannot verify manifest: %w", err)
+		}
+		if !valid {
+			result.Status = domain.CheckStatusFail
+			result.Issues = append(result.Issues, domain.Issue{
+				Code:     "MANIFEST_CORRUPT",
+				Message:  "Manifest checksum does not match its contents; it was modified outside dot or is corrupted",
+				Severity: domain.IssueSeverityError,
+				Context: map[string]any{
+					"suggestion": "Run 'dot remanage' to rebuild the manifest from installed packages",
+				},
+			})
+		}
+	}
+
 	// Consistency checks
 	for pkgName, pkg := range m.Packages {
 		if pkg.LinkCount != len(pkg.Links) {
-			result.Status = domain.CheckStatusWarning
+			if result.Status == domain.CheckStatusPass {
+				result.Status = domain.CheckStatusWarning
+			}
 			result.Issues = append(result.Issues, domain.Issue{
 				Code:     "MANIFEST_INCONSISTENT",
 				Message:  fmt.Sprintf("Package '%s' has link count mismatch (recorded: %d, actual: %d)", pkgName, pkg.LinkCount, len(pkg.Links)),
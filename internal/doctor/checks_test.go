@@ -475,6 +475,95 @@ func TestManifestIntegrityCheck_Run_LinkCountMismatch(t *testing.T) {
 	assert.Contains(t, result.Issues[0].Message, "link count mismatch")
 }
 
+// mockVerifyingManifestLoader is a ManifestLoader that also implements ManifestVerifier.
+type mockVerifyingManifestLoader struct {
+	mockManifestLoader
+	valid     bool
+	verifyErr error
+}
+
+func (m *mockVerifyingManifestLoader) Verify(ctx context.Context, targetPath domain.TargetPath) (bool, error) {
+	return m.valid, m.verifyErr
+}
+
+func TestManifestIntegrityCheck_Run_Checksum(t *testing.T) {
+	tests := []struct {
+		name       string
+		valid      bool
+		verifyErr  error
+		wantStatus domain.CheckStatus
+		wantCodes  []string
+		wantErr    string
+	}{
+		{
+			name:       "checksum matches",
+			valid:      true,
+			wantStatus: domain.CheckStatusPass,
+		},
+		{
+			name:       "checksum mismatch",
+			valid:      false,
+			wantStatus: domain.CheckStatusFail,
+			wantCodes:  []string{"MANIFEST_CORRUPT"},
+		},
+		{
+			name:      "verification error",
+			verifyErr: errors.New("read failed"),
+			wantErr:   "cannot verify manifest",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := NewManifestIntegrityCheck(
+				&mockFS{},
+				&mockVerifyingManifestLoader{
+					mockManifestLoader: mockManifestLoader{manifest: manifest.New()},
+					valid:              tt.valid,
+					verifyErr:          tt.verifyErr,
+				},
+				"/home/user",
+				&mockTargetPathCreator{path: createValidTargetPath(t)},
+				isManifestNotFoundFunc,
+			)
+
+			result, err := check.Run(context.Background())
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, result.Status)
+			var codes []string
+			for _, issue := range result.Issues {
+				codes = append(codes, issue.Code)
+			}
+			assert.Equal(t, tt.wantCodes, codes)
+		})
+	}
+}
+
+func TestManifestIntegrityCheck_Run_ChecksumMismatchNotDowngraded(t *testing.T) {
+	m := manifest.New()
+	m.AddPackage(manifest.PackageInfo{Name: "test-pkg", LinkCount: 5, Links: []string{".bashrc"}})
+
+	check := NewManifestIntegrityCheck(
+		&mockFS{},
+		&mockVerifyingManifestLoader{mockManifestLoader: mockManifestLoader{manifest: m}},
+		"/home/user",
+		&mockTargetPathCreator{path: createValidTargetPath(t)},
+		isManifestNotFoundFunc,
+	)
+
+	result, err := check.Run(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.CheckStatusFail, result.Status)
+	assert.Len(t, result.Issues, 2)
+}
+
 // =============================================================================
 // ConflictCheck Tests
 // =============================================================================
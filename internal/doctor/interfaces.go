@@ -39,6 +39,12 @@ type ManifestLoader interface {
 	Load(ctx context.Context, targetPath domain.TargetPath) domain.Result[manifest.Manifest]
 }
 
+// ManifestVerifier is implemented by manifest loaders that can check the
+// stored manifest against its checksum.
+type ManifestVerifier interface {
+	Verify(ctx context.Context, targetPath domain.TargetPath) (bool, error)
+}
+
 // LinkHealthChecker defines the interface for checking link health.
 type LinkHealthChecker interface {
 	CheckLink(ctx context.Context, pkgName, linkPath, packageDir string) LinkHealthResult
@@ -93,6 +99,8 @@ const (
 	IssueOrphanedLink IssueType = "orphaned_link"
 	// IssueWrongTarget indicates a symlink pointing to an unexpected target.
 	IssueWrongTarget IssueType = "wrong_target"
+	// IssueForeignTarget indicates a managed symlink pointing outside the package directory.
+	IssueForeignTarget IssueType = "foreign_target"
 )
 
 // DiagnosticStats contains summary statistics.
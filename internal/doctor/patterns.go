@@ -58,6 +58,45 @@ func DefaultPatternCategories() []PatternCategory {
 	}
 }
 
+// MergePatternCategories combines built-in and custom categories. A custom
+// category with the same name as a built-in one replaces it in place. Other
+// custom categories come first so they take precedence over broader built-in
+// patterns. Custom categories default to "medium" confidence.
+func MergePatternCategories(builtin, custom []PatternCategory) []PatternCategory {
+	if len(custom) == 0 {
+		return builtin
+	}
+
+	overrides := make(map[string]PatternCategory, len(custom))
+	added := make([]PatternCategory, 0, len(custom))
+	builtinNames := make(map[string]bool, len(builtin))
+	for _, cat := range builtin {
+		builtinNames[cat.Name] = true
+	}
+
+	for _, cat := range custom {
+		if cat.Confidence == "" {
+			cat.Confidence = "medium"
+		}
+		if builtinNames[cat.Name] {
+			overrides[cat.Name] = cat
+			continue
+		}
+		added = append(added, cat)
+	}
+
+	merged := make([]PatternCategory, 0, len(added)+len(builtin))
+	merged = append(merged, added...)
+	for _, cat := range builtin {
+		if override, ok := overrides[cat.Name]; ok {
+			cat = override
+		}
+		merged = append(merged, cat)
+	}
+
+	return merged
+}
+
 // CategorizeSymlink returns category for a symlink target, or nil if unknown.
 func CategorizeSymlink(target string, categories []PatternCategory) *PatternCategory {
 	for i, cat := range categories {
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestDefaultPatternCategories(t *testing.T) {
@@ -165,3 +166,45 @@ func TestCategorizeSymlink_NixTargets(t *testing.T) {
 		}
 	}
 }
+
+func TestMergePatternCategories(t *testing.T) {
+	builtin := []PatternCategory{
+		{Name: "cargo", Description: "Cargo", Patterns: []string{"*/.cargo/bin/*"}, Confidence: "high"},
+		{Name: "npm", Description: "NPM", Patterns: []string{"*/node_modules/*"}, Confidence: "high"},
+	}
+	custom := []PatternCategory{
+		{Name: "work", Description: "Work tools", Patterns: []string{"/opt/work/*"}},
+		{Name: "npm", Description: "Company npm mirror", Patterns: []string{"*/corp-npm/*"}, Confidence: "low"},
+	}
+
+	merged := MergePatternCategories(builtin, custom)
+
+	require.Len(t, merged, 3)
+	assert.Equal(t, "work", merged[0].Name, "new categories take precedence")
+	assert.Equal(t, "medium", merged[0].Confidence, "missing confidence defaults to medium")
+	assert.Equal(t, "cargo", merged[1].Name)
+	assert.Equal(t, "npm", merged[2].Name, "override keeps built-in position")
+	assert.Equal(t, "Company npm mirror", merged[2].Description)
+	assert.Equal(t, []string{"*/corp-npm/*"}, merged[2].Patterns)
+	assert.Equal(t, "low", merged[2].Confidence)
+
+	// Built-ins are not modified
+	assert.Equal(t, "NPM", builtin[1].Description)
+
+	// Overridden patterns no longer match
+	assert.Nil(t, CategorizeSymlink("/home/user/project/node_modules/x", merged))
+	cat := CategorizeSymlink("/opt/work/deploy", merged)
+	require.NotNil(t, cat)
+	assert.Equal(t, "work", cat.Name)
+
+	// Custom categories win over overlapping built-in patterns
+	overlapping := []PatternCategory{{Name: "work", Patterns: []string{"/usr/local/bin/work-*"}}}
+	cat = CategorizeSymlink("/usr/local/bin/work-deploy", MergePatternCategories(DefaultPatternCategories(), overlapping))
+	require.NotNil(t, cat)
+	assert.Equal(t, "work", cat.Name)
+}
+
+func TestMergePatternCategories_NoCustom(t *testing.T) {
+	builtin := DefaultPatternCategories()
+	assert.Equal(t, builtin, MergePatternCategories(builtin, nil))
+}
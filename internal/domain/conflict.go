@@ -16,3 +16,13 @@ type WarningInfo struct {
 	Severity string            `json:"severity"`
 	Context  map[string]string `json:"context,omitempty"`
 }
+
+// ResolutionInfo records a conflict that a resolution policy (backup,
+// overwrite, skip) resolved automatically during planning.
+type ResolutionInfo struct {
+	Package    string `json:"package,omitempty"`
+	Type       string `json:"type"`
+	Path       string `json:"path"`
+	Policy     string `json:"policy"`
+	BackupPath string `json:"backup_path,omitempty"`
+}
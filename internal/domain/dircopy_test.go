@@ -2,6 +2,10 @@ package domain_test
 
 import (
 	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"syscall"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -157,3 +161,122 @@ func TestDirCopy_Dependencies(t *testing.T) {
 	deps := op.Dependencies()
 	assert.Empty(t, deps, "DirCopy should have no dependencies")
 }
+
+// linkFS wraps a filesystem to observe and optionally fail Link calls.
+type linkFS struct {
+	domain.FS
+	linkErr error
+	links   []string
+}
+
+func (f *linkFS) Link(ctx context.Context, oldname, newname string) error {
+	f.links = append(f.links, newname)
+	if f.linkErr != nil {
+		return &os.LinkError{Op: "link", Old: oldname, New: newname, Err: f.linkErr}
+	}
+	return f.FS.Link(ctx, oldname, newname)
+}
+
+func setupHardlinkSource(t *testing.T, fs domain.FS) {
+	t.Helper()
+	ctx := context.Background()
+	require.NoError(t, fs.MkdirAll(ctx, "/source/mydir/sub", 0755))
+	require.NoError(t, fs.WriteFile(ctx, "/source/mydir/file1.txt", []byte("content1"), 0644))
+	require.NoError(t, fs.WriteFile(ctx, "/source/mydir/sub/file2.txt", []byte("content2"), 0600))
+	require.NoError(t, fs.MkdirAll(ctx, "/dest", 0755))
+}
+
+func TestDirCopy_HardlinkWhenPossible(t *testing.T) {
+	ctx := context.Background()
+	fs := &linkFS{FS: adapters.NewMemFS()}
+	setupHardlinkSource(t, fs)
+
+	op := domain.NewDirCopy("copy1", domain.MustParsePath("/source/mydir"), domain.MustParsePath("/dest/mydir"))
+	op.HardlinkWhenPossible = true
+	require.NoError(t, op.Execute(ctx, fs))
+
+	assert.ElementsMatch(t, []string{"/dest/mydir/file1.txt", "/dest/mydir/sub/file2.txt"}, fs.links)
+
+	data, err := fs.ReadFile(ctx, "/dest/mydir/sub/file2.txt")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("content2"), data)
+	assert.True(t, fs.Exists(ctx, "/source/mydir/file1.txt"))
+}
+
+func TestDirCopy_HardlinkDisabledByDefault(t *testing.T) {
+	ctx := context.Background()
+	fs := &linkFS{FS: adapters.NewMemFS()}
+	setupHardlinkSource(t, fs)
+
+	op := domain.NewDirCopy("copy1", domain.MustParsePath("/source/mydir"), domain.MustParsePath("/dest/mydir"))
+	require.NoError(t, op.Execute(ctx, fs))
+
+	assert.Empty(t, fs.links)
+	assert.True(t, fs.Exists(ctx, "/dest/mydir/sub/file2.txt"))
+}
+
+func TestDirCopy_HardlinkFallsBackToCopy(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"cross device", syscall.EXDEV},
+		{"not permitted", syscall.EPERM},
+		{"unsupported", errors.ErrUnsupported},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			fs := &linkFS{FS: adapters.NewMemFS(), linkErr: tt.err}
+			setupHardlinkSource(t, fs)
+
+			op := domain.NewDirCopy("copy1", domain.MustParsePath("/source/mydir"), domain.MustParsePath("/dest/mydir"))
+			op.HardlinkWhenPossible = true
+			require.NoError(t, op.Execute(ctx, fs))
+
+			assert.Len(t, fs.links, 2)
+			data, err := fs.ReadFile(ctx, "/dest/mydir/file1.txt")
+			require.NoError(t, err)
+			assert.Equal(t, []byte("content1"), data)
+
+			info, err := fs.Stat(ctx, "/dest/mydir/sub/file2.txt")
+			require.NoError(t, err)
+			assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+		})
+	}
+}
+
+func TestDirCopy_HardlinkOtherErrorFails(t *testing.T) {
+	ctx := context.Background()
+	fs := &linkFS{FS: adapters.NewMemFS(), linkErr: syscall.EIO}
+	setupHardlinkSource(t, fs)
+
+	op := domain.NewDirCopy("copy1", domain.MustParsePath("/source/mydir"), domain.MustParsePath("/dest/mydir"))
+	op.HardlinkWhenPossible = true
+
+	err := op.Execute(ctx, fs)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, syscall.EIO)
+}
+
+func TestDirCopy_HardlinkOSFilesystem(t *testing.T) {
+	ctx := context.Background()
+	fs := adapters.NewOSFilesystem()
+
+	tmpDir := t.TempDir()
+	srcDir := filepath.Join(tmpDir, "source")
+	dstDir := filepath.Join(tmpDir, "dest")
+	require.NoError(t, os.MkdirAll(filepath.Join(srcDir, "sub"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(srcDir, "sub", "file.txt"), []byte("data"), 0644))
+
+	op := domain.NewDirCopy("copy1", domain.MustParsePath(srcDir), domain.MustParsePath(dstDir))
+	op.HardlinkWhenPossible = true
+	require.NoError(t, op.Execute(ctx, fs))
+
+	srcInfo, err := os.Stat(filepath.Join(srcDir, "sub", "file.txt"))
+	require.NoError(t, err)
+	dstInfo, err := os.Stat(filepath.Join(dstDir, "sub", "file.txt"))
+	require.NoError(t, err)
+	assert.True(t, os.SameFile(srcInfo, dstInfo), "files in the same temp dir should be hardlinked")
+}
@@ -169,10 +169,18 @@ func (p Plan) OperationCountForPackage(pkg string) int {
 
 // PlanMetadata contains statistics and diagnostic information about a plan.
 type PlanMetadata struct {
-	PackageCount   int            `json:"package_count"`
-	OperationCount int            `json:"operation_count"`
-	LinkCount      int            `json:"link_count"`
-	DirCount       int            `json:"dir_count"`
-	Conflicts      []ConflictInfo `json:"conflicts,omitempty"`
-	Warnings       []WarningInfo  `json:"warnings,omitempty"`
+	PackageCount   int              `json:"package_count"`
+	OperationCount int              `json:"operation_count"`
+	LinkCount      int              `json:"link_count"`
+	DirCount       int              `json:"dir_count"`
+	Conflicts      []ConflictInfo   `json:"conflicts,omitempty"`
+	Warnings       []WarningInfo    `json:"warnings,omitempty"`
+	Resolutions    []ResolutionInfo `json:"resolutions,omitempty"`
+	// DestructiveOps counts operations that delete data; see
+	// CountDestructiveOps.
+	DestructiveOps int `json:"destructive_ops"`
+	// BytesBackedUp is the total size of the files the plan backs up.
+	BytesBackedUp int64 `json:"bytes_backed_up"`
+	// RiskLevel summarizes DestructiveOps and BytesBackedUp; see AssessRisk.
+	RiskLevel RiskLevel `json:"risk_level,omitempty"`
 }
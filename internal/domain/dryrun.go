@@ -0,0 +1,610 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// EffectStatus classifies what an operation would do to the filesystem.
+type EffectStatus int
+
+const (
+	// EffectApply means the operation would change the filesystem.
+	EffectApply EffectStatus = iota
+
+	// EffectNoop means the filesystem already matches the operation's result.
+	EffectNoop
+
+	// EffectBlocked means the operation would fail against the filesystem.
+	EffectBlocked
+)
+
+// String returns the string representation of an EffectStatus.
+func (s EffectStatus) String() string {
+	switch s {
+	case EffectApply:
+		return "apply"
+	case EffectNoop:
+		return "noop"
+	case EffectBlocked:
+		return "blocked"
+	default:
+		return "unknown"
+	}
+}
+
+// MarshalText encodes the status by name.
+func (s EffectStatus) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// DryRunEffect describes what an operation would do to the filesystem,
+// determined without modifying it.
+type DryRunEffect struct {
+	OpID        OperationID   `json:"id" yaml:"id"`
+	Kind        OperationKind `json:"-" yaml:"-"`
+	Status      EffectStatus  `json:"status" yaml:"status"`
+	Description string        `json:"description" yaml:"description"`
+	// Reason explains a noop or blocked status, or qualifies an applied
+	// change, such as "target already exists".
+	Reason string `json:"reason,omitempty" yaml:"reason,omitempty"`
+}
+
+// String returns the description followed by the reason, if any.
+func (e DryRunEffect) String() string {
+	if e.Reason == "" {
+		return e.Description
+	}
+	return fmt.Sprintf("%s (%s)", e.Description, e.Reason)
+}
+
+func newEffect(op Operation, status EffectStatus, description, reason string) DryRunEffect {
+	return DryRunEffect{
+		OpID:        op.ID(),
+		Kind:        op.Kind(),
+		Status:      status,
+		Description: description,
+		Reason:      reason,
+	}
+}
+
+// DryRunPlan evaluates operations in order against fs without modifying
+// it. Each operation sees the changes the operations before it would have
+// made, so a link created after backing up and deleting a file is reported
+// as applied rather than blocked by the file.
+func DryRunPlan(ctx context.Context, fsys FSReader, ops []Operation) ([]DryRunEffect, error) {
+	overlay := newDryRunFS(fsys)
+	effects := make([]DryRunEffect, 0, len(ops))
+	for _, op := range ops {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		effect, err := op.DryRunExecute(ctx, overlay)
+		if err != nil {
+			return nil, fmt.Errorf("dry run %s: %w", op.ID(), err)
+		}
+		if effect.Status == EffectApply {
+			overlay.apply(ctx, op)
+		}
+		effects = append(effects, effect)
+	}
+	return effects, nil
+}
+
+// lstatIfExists returns the file info for path, or nil if it does not exist.
+func lstatIfExists(ctx context.Context, fsys FSReader, path string) (FileInfo, error) {
+	info, err := fsys.Lstat(ctx, path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+func isSymlinkInfo(info FileInfo) bool {
+	return info.Mode()&fs.ModeSymlink != 0
+}
+
+func (op LinkCreate) DryRunExecute(ctx context.Context, fsys FSReader) (DryRunEffect, error) {
+	target := op.Target.String()
+	description := fmt.Sprintf("would create symlink %s -> %s", target, op.Source.String())
+
+	info, err := lstatIfExists(ctx, fsys, target)
+	if err != nil {
+		return DryRunEffect{}, err
+	}
+	if info != nil {
+		if !isSymlinkInfo(info) {
+			return newEffect(op, EffectBlocked, description, "target already exists"), nil
+		}
+		dest, err := fsys.ReadLink(ctx, target)
+		if err != nil {
+			return DryRunEffect{}, err
+		}
+		if dest == op.LinkValue() {
+			return newEffect(op, EffectNoop, description, "link already exists"), nil
+		}
+		return newEffect(op, EffectBlocked, description, "target is a symlink to "+dest), nil
+	}
+
+	parent, err := lstatIfExists(ctx, fsys, filepath.Dir(target))
+	if err != nil {
+		return DryRunEffect{}, err
+	}
+	if parent == nil {
+		return newEffect(op, EffectBlocked, description, "parent directory does not exist"), nil
+	}
+
+	source, err := lstatIfExists(ctx, fsys, op.Source.String())
+	if err != nil {
+		return DryRunEffect{}, err
+	}
+	if source == nil {
+		return newEffect(op, EffectApply, description, "source does not exist"), nil
+	}
+	return newEffect(op, EffectApply, description, ""), nil
+}
+
+func (op LinkDelete) DryRunExecute(ctx context.Context, fsys FSReader) (DryRunEffect, error) {
+	target := op.Target.String()
+	description := fmt.Sprintf("would delete symlink %s", target)
+
+	info, err := lstatIfExists(ctx, fsys, target)
+	if err != nil {
+		return DryRunEffect{}, err
+	}
+	if info == nil {
+		return newEffect(op, EffectNoop, description, "link does not exist"), nil
+	}
+	if !isSymlinkInfo(info) {
+		return newEffect(op, EffectApply, description, "target is not a symlink"), nil
+	}
+	return newEffect(op, EffectApply, description, ""), nil
+}
+
+func (op DirCreate) DryRunExecute(ctx context.Context, fsys FSReader) (DryRunEffect, error) {
+	path := op.Path.String()
+	description := fmt.Sprintf("would create directory %s", path)
+
+	info, err := lstatIfExists(ctx, fsys, path)
+	if err != nil {
+		return DryRunEffect{}, err
+	}
+	if info == nil {
+		return newEffect(op, EffectApply, description, ""), nil
+	}
+	if info.IsDir() {
+		return newEffect(op, EffectNoop, description, "directory already exists"), nil
+	}
+	return newEffect(op, EffectBlocked, description, "path exists and is not a directory"), nil
+}
+
+func (op DirDelete) DryRunExecute(ctx context.Context, fsys FSReader) (DryRunEffect, error) {
+	path := op.Path.String()
+	description := fmt.Sprintf("would delete directory %s", path)
+
+	info, err := lstatIfExists(ctx, fsys, path)
+	if err != nil {
+		return DryRunEffect{}, err
+	}
+	if info == nil {
+		return newEffect(op, EffectBlocked, description, "directory does not exist"), nil
+	}
+	if !info.IsDir() {
+		return newEffect(op, EffectBlocked, description, "path is not a directory"), nil
+	}
+	entries, err := fsys.ReadDir(ctx, path)
+	if err != nil {
+		return DryRunEffect{}, err
+	}
+	if len(entries) > 0 {
+		return newEffect(op, EffectBlocked, description, "directory is not empty"), nil
+	}
+	return newEffect(op, EffectApply, description, ""), nil
+}
+
+func (op DirRemoveAll) DryRunExecute(ctx context.Context, fsys FSReader) (DryRunEffect, error) {
+	path := op.Path.String()
+	description := fmt.Sprintf("would recursively delete directory %s", path)
+
+	info, err := lstatIfExists(ctx, fsys, path)
+	if err != nil {
+		return DryRunEffect{}, err
+	}
+	if info == nil {
+		return newEffect(op, EffectNoop, description, "directory does not exist"), nil
+	}
+	return newEffect(op, EffectApply, description, ""), nil
+}
+
+func (op FileMove) DryRunExecute(ctx context.Context, fsys FSReader) (DryRunEffect, error) {
+	source := op.Source.String()
+	dest := op.Dest.String()
+
+	info, err := lstatIfExists(ctx, fsys, source)
+	if err != nil {
+		return DryRunEffect{}, err
+	}
+	if info == nil {
+		description := fmt.Sprintf("would move %s -> %s", source, dest)
+		return newEffect(op, EffectBlocked, description, "source does not exist"), nil
+	}
+	what := "file"
+	if info.IsDir() {
+		what = "directory"
+	}
+	description := fmt.Sprintf("would move %s %s -> %s", what, source, dest)
+
+	existing, err := lstatIfExists(ctx, fsys, dest)
+	if err != nil {
+		return DryRunEffect{}, err
+	}
+	switch {
+	case existing == nil:
+		return newEffect(op, EffectApply, description, ""), nil
+	case existing.IsDir():
+		return newEffect(op, EffectBlocked, description, "destination already exists"), nil
+	default:
+		return newEffect(op, EffectApply, description, "destination will be replaced"), nil
+	}
+}
+
+func (op FileBackup) DryRunExecute(ctx context.Context, fsys FSReader) (DryRunEffect, error) {
+	description := fmt.Sprintf("would back up file %s -> %s", op.Source.String(), op.Backup.String())
+
+	info, err := lstatIfExists(ctx, fsys, op.Source.String())
+	if err != nil {
+		return DryRunEffect{}, err
+	}
+	if info == nil {
+		return newEffect(op, EffectBlocked, description, "source does not exist"), nil
+	}
+	if info.IsDir() {
+		return newEffect(op, EffectBlocked, description, "source is a directory"), nil
+	}
+
+	existing, err := lstatIfExists(ctx, fsys, op.Backup.String())
+	if err != nil {
+		return DryRunEffect{}, err
+	}
+	if existing != nil {
+		return newEffect(op, EffectApply, description, "existing backup will be overwritten"), nil
+	}
+	return newEffect(op, EffectApply, description, ""), nil
+}
+
+func (op FileDelete) DryRunExecute(ctx context.Context, fsys FSReader) (DryRunEffect, error) {
+	path := op.Path.String()
+	description := fmt.Sprintf("would delete file %s", path)
+
+	info, err := lstatIfExists(ctx, fsys, path)
+	if err != nil {
+		return DryRunEffect{}, err
+	}
+	if info == nil {
+		return newEffect(op, EffectBlocked, description, "file does not exist"), nil
+	}
+	if info.IsDir() {
+		entries, err := fsys.ReadDir(ctx, path)
+		if err != nil {
+			return DryRunEffect{}, err
+		}
+		if len(entries) > 0 {
+			return newEffect(op, EffectBlocked, description, "path is a non-empty directory"), nil
+		}
+	}
+	return newEffect(op, EffectApply, description, ""), nil
+}
+
+func (op DirCopy) DryRunExecute(ctx context.Context, fsys FSReader) (DryRunEffect, error) {
+	description := fmt.Sprintf("would copy directory %s -> %s", op.Source.String(), op.Dest.String())
+
+	info, err := lstatIfExists(ctx, fsys, op.Source.String())
+	if err != nil {
+		return DryRunEffect{}, err
+	}
+	if info == nil {
+		return newEffect(op, EffectBlocked, description, "source does not exist"), nil
+	}
+	if !info.IsDir() {
+		return newEffect(op, EffectBlocked, description, "source is not a directory"), nil
+	}
+
+	existing, err := lstatIfExists(ctx, fsys, op.Dest.String())
+	if err != nil {
+		return DryRunEffect{}, err
+	}
+	if existing != nil {
+		return newEffect(op, EffectBlocked, description, "destination already exists"), nil
+	}
+	return newEffect(op, EffectApply, description, ""), nil
+}
+
+// overlayKind identifies how dryRunFS records a path.
+type overlayKind int
+
+const (
+	overlayRemoved overlayKind = iota
+	overlayDir
+	overlaySymlink
+	// overlayCopy mirrors another path of the underlying filesystem, as a
+	// moved, copied or backed up file or directory does.
+	overlayCopy
+)
+
+type overlayEntry struct {
+	kind overlayKind
+	link string // symlink destination for overlaySymlink
+	from string // underlying path for overlayCopy
+}
+
+// dryRunFS is a read-only view of a filesystem with the changes of
+// evaluated operations layered on top, used to dry-run a plan in order.
+type dryRunFS struct {
+	base    FSReader
+	entries map[string]overlayEntry
+}
+
+func newDryRunFS(base FSReader) *dryRunFS {
+	return &dryRunFS{base: base, entries: make(map[string]overlayEntry)}
+}
+
+// resolve finds how path appears in the overlay. It returns the entry
+// recorded for path itself, or otherwise the path to read from the
+// underlying filesystem; removed reports whether path or an ancestor was
+// removed.
+func (d *dryRunFS) resolve(path string) (entry overlayEntry, found bool, basePath string, removed bool) {
+	path = filepath.Clean(path)
+	if e, ok := d.entries[path]; ok {
+		if e.kind == overlayRemoved {
+			return overlayEntry{}, false, "", true
+		}
+		if e.kind == overlayCopy {
+			return overlayEntry{}, false, e.from, false
+		}
+		return e, true, "", false
+	}
+	for dir := filepath.Dir(path); ; dir = filepath.Dir(dir) {
+		if e, ok := d.entries[dir]; ok {
+			switch e.kind {
+			case overlayRemoved, overlaySymlink:
+				return overlayEntry{}, false, "", true
+			case overlayCopy:
+				rel, err := filepath.Rel(dir, path)
+				if err != nil {
+					return overlayEntry{}, false, "", true
+				}
+				return overlayEntry{}, false, filepath.Join(e.from, rel), false
+			case overlayDir:
+				// A new directory starts empty
+				return overlayEntry{}, false, "", true
+			}
+		}
+		if parent := filepath.Dir(dir); parent == dir {
+			break
+		}
+	}
+	return overlayEntry{}, false, path, false
+}
+
+func notExist(op, path string) error {
+	return &fs.PathError{Op: op, Path: path, Err: fs.ErrNotExist}
+}
+
+func (d *dryRunFS) Lstat(ctx context.Context, path string) (FileInfo, error) {
+	entry, found, basePath, removed := d.resolve(path)
+	switch {
+	case removed:
+		return nil, notExist("lstat", path)
+	case found && entry.kind == overlaySymlink:
+		return overlayInfo{name: filepath.Base(path), mode: fs.ModeSymlink | 0777}, nil
+	case found:
+		return overlayInfo{name: filepath.Base(path), mode: fs.ModeDir | DefaultDirPerms}, nil
+	}
+	info, err := d.base.Lstat(ctx, basePath)
+	if err != nil {
+		return nil, err
+	}
+	return renamedInfo{FileInfo: info, name: filepath.Base(path)}, nil
+}
+
+func (d *dryRunFS) Stat(ctx context.Context, path string) (FileInfo, error) {
+	entry, found, basePath, removed := d.resolve(path)
+	switch {
+	case removed:
+		return nil, notExist("stat", path)
+	case found && entry.kind == overlaySymlink:
+		return d.Stat(ctx, ResolveLinkTarget(path, entry.link))
+	case found:
+		return overlayInfo{name: filepath.Base(path), mode: fs.ModeDir | DefaultDirPerms}, nil
+	}
+	return d.base.Stat(ctx, basePath)
+}
+
+func (d *dryRunFS) ReadLink(ctx context.Context, path string) (string, error) {
+	entry, found, basePath, removed := d.resolve(path)
+	switch {
+	case removed:
+		return "", notExist("readlink", path)
+	case found && entry.kind == overlaySymlink:
+		return entry.link, nil
+	case found:
+		return "", &fs.PathError{Op: "readlink", Path: path, Err: fs.ErrInvalid}
+	}
+	return d.base.ReadLink(ctx, basePath)
+}
+
+func (d *dryRunFS) ReadFile(ctx context.Context, path string) ([]byte, error) {
+	_, found, basePath, removed := d.resolve(path)
+	if removed || found {
+		return nil, notExist("read", path)
+	}
+	return d.base.ReadFile(ctx, basePath)
+}
+
+func (d *dryRunFS) ReadFileRange(ctx context.Context, path string, offset, length int64) ([]byte, error) {
+	_, found, basePath, removed := d.resolve(path)
+	if removed || found {
+		return nil, notExist("read", path)
+	}
+	return d.base.ReadFileRange(ctx, basePath, offset, length)
+}
+
+// Walk walks the underlying tree with overlay changes applied.
+func (d *dryRunFS) Walk(ctx context.Context, root string, fn WalkFunc) error {
+	return WalkDir(ctx, d, root, fn)
+}
+
+// ReadDir lists the underlying directory with overlay changes applied to
+// its direct children.
+func (d *dryRunFS) ReadDir(ctx context.Context, path string) ([]DirEntry, error) {
+	path = filepath.Clean(path)
+	entry, found, basePath, removed := d.resolve(path)
+	if removed {
+		return nil, notExist("readdir", path)
+	}
+	if found && entry.kind != overlayDir {
+		return nil, &fs.PathError{Op: "readdir", Path: path, Err: fs.ErrInvalid}
+	}
+
+	children := make(map[string]DirEntry)
+	if !found {
+		base, err := d.base.ReadDir(ctx, basePath)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range base {
+			children[e.Name()] = e
+		}
+	}
+	for p := range d.entries {
+		if filepath.Dir(p) != path || p == path {
+			continue
+		}
+		name := filepath.Base(p)
+		info, err := d.Lstat(ctx, p)
+		if err != nil {
+			delete(children, name)
+			continue
+		}
+		children[name] = fs.FileInfoToDirEntry(info)
+	}
+
+	names := make([]string, 0, len(children))
+	for name := range children {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	result := make([]DirEntry, 0, len(names))
+	for _, name := range names {
+		result = append(result, children[name])
+	}
+	return result, nil
+}
+
+func (d *dryRunFS) Exists(ctx context.Context, path string) bool {
+	_, err := d.Lstat(ctx, path)
+	return err == nil
+}
+
+func (d *dryRunFS) IsDir(ctx context.Context, path string) (bool, error) {
+	info, err := d.Stat(ctx, path)
+	if err != nil {
+		return false, err
+	}
+	return info.IsDir(), nil
+}
+
+func (d *dryRunFS) IsSymlink(ctx context.Context, path string) (bool, error) {
+	info, err := d.Lstat(ctx, path)
+	if err != nil {
+		return false, err
+	}
+	return isSymlinkInfo(info), nil
+}
+
+// underlying returns the path of the underlying filesystem that path
+// currently mirrors.
+func (d *dryRunFS) underlying(path string) string {
+	_, _, basePath, _ := d.resolve(path)
+	if basePath == "" {
+		return filepath.Clean(path)
+	}
+	return basePath
+}
+
+// remove records path as removed, dropping changes recorded beneath it.
+func (d *dryRunFS) remove(path string) {
+	path = filepath.Clean(path)
+	prefix := path + string(filepath.Separator)
+	for p := range d.entries {
+		if strings.HasPrefix(p, prefix) {
+			delete(d.entries, p)
+		}
+	}
+	d.entries[path] = overlayEntry{kind: overlayRemoved}
+}
+
+// apply records the changes op makes once executed.
+func (d *dryRunFS) apply(ctx context.Context, op Operation) {
+	switch o := op.(type) {
+	case LinkCreate:
+		d.entries[filepath.Clean(o.Target.String())] = overlayEntry{kind: overlaySymlink, link: o.LinkValue()}
+	case LinkDelete:
+		d.remove(o.Target.String())
+	case DirCreate:
+		for dir := filepath.Clean(o.Path.String()); !d.Exists(ctx, dir); dir = filepath.Dir(dir) {
+			d.entries[dir] = overlayEntry{kind: overlayDir}
+			if filepath.Dir(dir) == dir {
+				break
+			}
+		}
+	case DirDelete:
+		d.remove(o.Path.String())
+	case DirRemoveAll:
+		d.remove(o.Path.String())
+	case FileDelete:
+		d.remove(o.Path.String())
+	case FileMove:
+		from := d.underlying(o.Source.String())
+		d.remove(o.Source.String())
+		d.entries[filepath.Clean(o.Dest.String())] = overlayEntry{kind: overlayCopy, from: from}
+	case FileBackup:
+		d.entries[filepath.Clean(o.Backup.String())] = overlayEntry{kind: overlayCopy, from: d.underlying(o.Source.String())}
+	case DirCopy:
+		d.entries[filepath.Clean(o.Dest.String())] = overlayEntry{kind: overlayCopy, from: d.underlying(o.Source.String())}
+	}
+}
+
+// overlayInfo describes a directory or symlink that exists only in a
+// dryRunFS overlay.
+type overlayInfo struct {
+	name string
+	mode fs.FileMode
+}
+
+func (i overlayInfo) Name() string       { return i.name }
+func (i overlayInfo) Size() int64        { return 0 }
+func (i overlayInfo) Mode() fs.FileMode  { return i.mode }
+func (i overlayInfo) ModTime() time.Time { return time.Time{} }
+func (i overlayInfo) IsDir() bool        { return i.mode.IsDir() }
+func (i overlayInfo) Sys() any           { return nil }
+
+// renamedInfo reports an underlying file under the overlay path it was
+// moved or copied to.
+type renamedInfo struct {
+	FileInfo
+	name string
+}
+
+func (i renamedInfo) Name() string { return i.name }
+
+var _ FSReader = (*dryRunFS)(nil)
@@ -0,0 +1,371 @@
+package domain_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yaklabco/dot/internal/adapters"
+	"github.com/yaklabco/dot/internal/domain"
+)
+
+// newDryRunFS returns a filesystem with a package file, a regular file and
+// a link in the target directory, and an empty and a populated directory.
+func newDryRunFS(t *testing.T) *adapters.MemFS {
+	t.Helper()
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	require.NoError(t, fs.MkdirAll(ctx, "/pkg/sub", 0755))
+	require.NoError(t, fs.MkdirAll(ctx, "/home/empty", 0755))
+	require.NoError(t, fs.MkdirAll(ctx, "/home/full", 0755))
+	require.NoError(t, fs.WriteFile(ctx, "/pkg/dot-vimrc", []byte("vimrc"), 0644))
+	require.NoError(t, fs.WriteFile(ctx, "/pkg/sub/file", []byte("file"), 0644))
+	require.NoError(t, fs.WriteFile(ctx, "/home/.bashrc", []byte("bashrc"), 0644))
+	require.NoError(t, fs.WriteFile(ctx, "/home/full/file", []byte("file"), 0644))
+	require.NoError(t, fs.Symlink(ctx, "/pkg/dot-vimrc", "/home/.vimrc"))
+	return fs
+}
+
+func TestOperation_DryRunExecute(t *testing.T) {
+	tests := []struct {
+		name       string
+		op         domain.Operation
+		wantStatus domain.EffectStatus
+		wantReason string
+	}{
+		{
+			name:       "link create",
+			op:         domain.NewLinkCreate("op", domain.MustParsePath("/pkg/dot-vimrc"), domain.MustParseTargetPath("/home/.exrc")),
+			wantStatus: domain.EffectApply,
+		},
+		{
+			name:       "link create with missing source",
+			op:         domain.NewLinkCreate("op", domain.MustParsePath("/pkg/missing"), domain.MustParseTargetPath("/home/.exrc")),
+			wantStatus: domain.EffectApply,
+			wantReason: "source does not exist",
+		},
+		{
+			name:       "link create over existing file",
+			op:         domain.NewLinkCreate("op", domain.MustParsePath("/pkg/dot-vimrc"), domain.MustParseTargetPath("/home/.bashrc")),
+			wantStatus: domain.EffectBlocked,
+			wantReason: "target already exists",
+		},
+		{
+			name:       "link create already in place",
+			op:         domain.NewLinkCreate("op", domain.MustParsePath("/pkg/dot-vimrc"), domain.MustParseTargetPath("/home/.vimrc")),
+			wantStatus: domain.EffectNoop,
+			wantReason: "link already exists",
+		},
+		{
+			name:       "link create over other link",
+			op:         domain.NewLinkCreate("op", domain.MustParsePath("/pkg/sub/file"), domain.MustParseTargetPath("/home/.vimrc")),
+			wantStatus: domain.EffectBlocked,
+			wantReason: "target is a symlink to /pkg/dot-vimrc",
+		},
+		{
+			name:       "link create without parent",
+			op:         domain.NewLinkCreate("op", domain.MustParsePath("/pkg/dot-vimrc"), domain.MustParseTargetPath("/home/missing/.vimrc")),
+			wantStatus: domain.EffectBlocked,
+			wantReason: "parent directory does not exist",
+		},
+		{
+			name:       "link delete",
+			op:         domain.NewLinkDelete("op", domain.MustParseTargetPath("/home/.vimrc")),
+			wantStatus: domain.EffectApply,
+		},
+		{
+			name:       "link delete of missing link",
+			op:         domain.NewLinkDelete("op", domain.MustParseTargetPath("/home/.exrc")),
+			wantStatus: domain.EffectNoop,
+			wantReason: "link does not exist",
+		},
+		{
+			name:       "link delete of regular file",
+			op:         domain.NewLinkDelete("op", domain.MustParseTargetPath("/home/.bashrc")),
+			wantStatus: domain.EffectApply,
+			wantReason: "target is not a symlink",
+		},
+		{
+			name:       "dir create",
+			op:         domain.NewDirCreate("op", domain.MustParsePath("/home/.config/nvim")),
+			wantStatus: domain.EffectApply,
+		},
+		{
+			name:       "dir create of existing directory",
+			op:         domain.NewDirCreate("op", domain.MustParsePath("/home/full")),
+			wantStatus: domain.EffectNoop,
+			wantReason: "directory already exists",
+		},
+		{
+			name:       "dir create over file",
+			op:         domain.NewDirCreate("op", domain.MustParsePath("/home/.bashrc")),
+			wantStatus: domain.EffectBlocked,
+			wantReason: "path exists and is not a directory",
+		},
+		{
+			name:       "dir delete",
+			op:         domain.NewDirDelete("op", domain.MustParsePath("/home/empty")),
+			wantStatus: domain.EffectApply,
+		},
+		{
+			name:       "dir delete of non-empty directory",
+			op:         domain.NewDirDelete("op", domain.MustParsePath("/home/full")),
+			wantStatus: domain.EffectBlocked,
+			wantReason: "directory is not empty",
+		},
+		{
+			name:       "dir delete of missing directory",
+			op:         domain.NewDirDelete("op", domain.MustParsePath("/home/missing")),
+			wantStatus: domain.EffectBlocked,
+			wantReason: "directory does not exist",
+		},
+		{
+			name:       "dir remove all",
+			op:         domain.NewDirRemoveAll("op", domain.MustParsePath("/home/full")),
+			wantStatus: domain.EffectApply,
+		},
+		{
+			name:       "dir remove all of missing directory",
+			op:         domain.NewDirRemoveAll("op", domain.MustParsePath("/home/missing")),
+			wantStatus: domain.EffectNoop,
+			wantReason: "directory does not exist",
+		},
+		{
+			name:       "file move",
+			op:         domain.NewFileMove("op", domain.MustParseTargetPath("/home/.bashrc"), domain.MustParsePath("/pkg/dot-bashrc")),
+			wantStatus: domain.EffectApply,
+		},
+		{
+			name:       "file move of missing source",
+			op:         domain.NewFileMove("op", domain.MustParseTargetPath("/home/.profile"), domain.MustParsePath("/pkg/dot-profile")),
+			wantStatus: domain.EffectBlocked,
+			wantReason: "source does not exist",
+		},
+		{
+			name:       "file move over existing file",
+			op:         domain.NewFileMove("op", domain.MustParseTargetPath("/home/.bashrc"), domain.MustParsePath("/pkg/dot-vimrc")),
+			wantStatus: domain.EffectApply,
+			wantReason: "destination will be replaced",
+		},
+		{
+			name:       "file move onto directory",
+			op:         domain.NewFileMove("op", domain.MustParseTargetPath("/home/full"), domain.MustParsePath("/pkg/sub")),
+			wantStatus: domain.EffectBlocked,
+			wantReason: "destination already exists",
+		},
+		{
+			name:       "file backup",
+			op:         domain.NewFileBackup("op", domain.MustParsePath("/home/.bashrc"), domain.MustParsePath("/backup/.bashrc")),
+			wantStatus: domain.EffectApply,
+		},
+		{
+			name:       "file backup of directory",
+			op:         domain.NewFileBackup("op", domain.MustParsePath("/home/full"), domain.MustParsePath("/backup/full")),
+			wantStatus: domain.EffectBlocked,
+			wantReason: "source is a directory",
+		},
+		{
+			name:       "file delete",
+			op:         domain.NewFileDelete("op", domain.MustParsePath("/home/.bashrc")),
+			wantStatus: domain.EffectApply,
+		},
+		{
+			name:       "file delete of missing file",
+			op:         domain.NewFileDelete("op", domain.MustParsePath("/home/.profile")),
+			wantStatus: domain.EffectBlocked,
+			wantReason: "file does not exist",
+		},
+		{
+			name:       "dir copy",
+			op:         domain.NewDirCopy("op", domain.MustParsePath("/pkg/sub"), domain.MustParsePath("/home/sub")),
+			wantStatus: domain.EffectApply,
+		},
+		{
+			name:       "dir copy over existing directory",
+			op:         domain.NewDirCopy("op", domain.MustParsePath("/pkg/sub"), domain.MustParsePath("/home/full")),
+			wantStatus: domain.EffectBlocked,
+			wantReason: "destination already exists",
+		},
+		{
+			name:       "dir copy of file",
+			op:         domain.NewDirCopy("op", domain.MustParsePath("/home/.bashrc"), domain.MustParsePath("/home/sub")),
+			wantStatus: domain.EffectBlocked,
+			wantReason: "source is not a directory",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			fs := newDryRunFS(t)
+			before := snapshotMemFS(t, fs)
+
+			effect, err := tt.op.DryRunExecute(ctx, fs)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, effect.Status, effect.String())
+			assert.Equal(t, tt.wantReason, effect.Reason)
+			assert.Equal(t, tt.op.ID(), effect.OpID)
+			assert.Equal(t, tt.op.Kind(), effect.Kind)
+			assert.Contains(t, effect.Description, "would ")
+
+			assert.Equal(t, before, snapshotMemFS(t, fs), "dry run must not modify the filesystem")
+		})
+	}
+}
+
+// snapshotMemFS lists every path under / with its type and content.
+func snapshotMemFS(t *testing.T, fs *adapters.MemFS) map[string]string {
+	t.Helper()
+	ctx := context.Background()
+	snapshot := make(map[string]string)
+	var walk func(dir string)
+	walk = func(dir string) {
+		entries, err := fs.ReadDir(ctx, dir)
+		require.NoError(t, err)
+		for _, entry := range entries {
+			path := dir + "/" + entry.Name()
+			if dir == "/" {
+				path = "/" + entry.Name()
+			}
+			if isLink, _ := fs.IsSymlink(ctx, path); isLink {
+				dest, err := fs.ReadLink(ctx, path)
+				require.NoError(t, err)
+				snapshot[path] = "link:" + dest
+				continue
+			}
+			if entry.IsDir() {
+				snapshot[path] = "dir"
+				walk(path)
+				continue
+			}
+			data, err := fs.ReadFile(ctx, path)
+			require.NoError(t, err)
+			snapshot[path] = "file:" + string(data)
+		}
+	}
+	for _, root := range []string{"/pkg", "/home"} {
+		walk(root)
+	}
+	return snapshot
+}
+
+func TestDryRunPlan_SeesEarlierOperations(t *testing.T) {
+	tests := []struct {
+		name string
+		ops  []domain.Operation
+		want []domain.EffectStatus
+	}{
+		{
+			name: "backup and delete before linking",
+			ops: []domain.Operation{
+				domain.NewFileBackup("backup", domain.MustParsePath("/home/.bashrc"), domain.MustParsePath("/backup/.bashrc")),
+				domain.NewFileDelete("delete", domain.MustParsePath("/home/.bashrc")),
+				domain.NewLinkCreate("link", domain.MustParsePath("/pkg/dot-bashrc"), domain.MustParseTargetPath("/home/.bashrc")),
+			},
+			want: []domain.EffectStatus{domain.EffectApply, domain.EffectApply, domain.EffectApply},
+		},
+		{
+			name: "adopt moves then links",
+			ops: []domain.Operation{
+				domain.NewFileMove("move", domain.MustParseTargetPath("/home/.bashrc"), domain.MustParsePath("/pkg/dot-bashrc")),
+				domain.NewLinkCreate("link", domain.MustParsePath("/pkg/dot-bashrc"), domain.MustParseTargetPath("/home/.bashrc")),
+				domain.NewFileBackup("backup", domain.MustParsePath("/pkg/dot-bashrc"), domain.MustParsePath("/backup/.bashrc")),
+			},
+			want: []domain.EffectStatus{domain.EffectApply, domain.EffectApply, domain.EffectApply},
+		},
+		{
+			name: "directories created before links inside them",
+			ops: []domain.Operation{
+				domain.NewDirCreate("dir", domain.MustParsePath("/home/.config/nvim")),
+				domain.NewLinkCreate("link", domain.MustParsePath("/pkg/sub/file"), domain.MustParseTargetPath("/home/.config/nvim/init.lua")),
+				domain.NewDirDelete("rmdir", domain.MustParsePath("/home/.config/nvim")),
+			},
+			want: []domain.EffectStatus{domain.EffectApply, domain.EffectApply, domain.EffectBlocked},
+		},
+		{
+			name: "emptied directory can be deleted",
+			ops: []domain.Operation{
+				domain.NewFileDelete("delete", domain.MustParsePath("/home/full/file")),
+				domain.NewDirDelete("rmdir", domain.MustParsePath("/home/full")),
+				domain.NewDirCreate("mkdir", domain.MustParsePath("/home/full")),
+			},
+			want: []domain.EffectStatus{domain.EffectApply, domain.EffectApply, domain.EffectApply},
+		},
+		{
+			name: "second deletion of same link",
+			ops: []domain.Operation{
+				domain.NewLinkDelete("unlink", domain.MustParseTargetPath("/home/.vimrc")),
+				domain.NewLinkDelete("unlink-again", domain.MustParseTargetPath("/home/.vimrc")),
+			},
+			want: []domain.EffectStatus{domain.EffectApply, domain.EffectNoop},
+		},
+		{
+			name: "copied directory keeps its contents",
+			ops: []domain.Operation{
+				domain.NewDirCopy("copy", domain.MustParsePath("/pkg/sub"), domain.MustParsePath("/home/sub")),
+				domain.NewDirDelete("rmdir", domain.MustParsePath("/home/sub")),
+				domain.NewFileDelete("delete", domain.MustParsePath("/home/sub/file")),
+			},
+			want: []domain.EffectStatus{domain.EffectApply, domain.EffectBlocked, domain.EffectApply},
+		},
+		{
+			name: "blocked operations do not change later results",
+			ops: []domain.Operation{
+				domain.NewDirDelete("rmdir", domain.MustParsePath("/home/full")),
+				domain.NewFileDelete("delete", domain.MustParsePath("/home/full/file")),
+			},
+			want: []domain.EffectStatus{domain.EffectBlocked, domain.EffectApply},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := newDryRunFS(t)
+			before := snapshotMemFS(t, fs)
+
+			effects, err := domain.DryRunPlan(context.Background(), fs, tt.ops)
+			require.NoError(t, err)
+			require.Len(t, effects, len(tt.want))
+			for i, effect := range effects {
+				assert.Equal(t, tt.ops[i].ID(), effect.OpID)
+				assert.Equal(t, tt.want[i], effect.Status, effect.String())
+			}
+			assert.Equal(t, before, snapshotMemFS(t, fs))
+		})
+	}
+}
+
+func TestDryRunPlan_ContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	ops := []domain.Operation{domain.NewDirCreate("dir", domain.MustParsePath("/home/new"))}
+	_, err := domain.DryRunPlan(ctx, newDryRunFS(t), ops)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestDryRunEffect_String(t *testing.T) {
+	effect := domain.DryRunEffect{Status: domain.EffectBlocked, Description: "would create symlink /a -> /b", Reason: "target already exists"}
+	assert.Equal(t, "would create symlink /a -> /b (target already exists)", effect.String())
+
+	effect.Reason = ""
+	assert.Equal(t, "would create symlink /a -> /b", effect.String())
+}
+
+func TestDryRunEffect_MarshalJSON(t *testing.T) {
+	effect := domain.DryRunEffect{OpID: "link", Kind: domain.OpKindLinkCreate, Status: domain.EffectNoop, Description: "would create symlink /a -> /b", Reason: "link already exists"}
+
+	data, err := json.Marshal(effect)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id":"link","status":"noop","description":"would create symlink /a -> /b","reason":"link already exists"}`, string(data))
+}
+
+func TestEffectStatus_String(t *testing.T) {
+	assert.Equal(t, "apply", domain.EffectApply.String())
+	assert.Equal(t, "noop", domain.EffectNoop.String())
+	assert.Equal(t, "blocked", domain.EffectBlocked.String())
+	assert.Equal(t, "unknown", domain.EffectStatus(99).String())
+}
@@ -72,6 +72,29 @@ func (e ErrPermissionDenied) Error() string {
 	return fmt.Sprintf("permission denied: cannot %s %q", e.Operation, e.Path)
 }
 
+// ErrReadOnlyFS indicates a write was attempted through a read-only
+// filesystem, such as the one used in dry-run mode.
+type ErrReadOnlyFS struct {
+	Operation string
+	Path      string
+}
+
+func (e ErrReadOnlyFS) Error() string {
+	return fmt.Sprintf("read-only filesystem: cannot %s %q", e.Operation, e.Path)
+}
+
+// ErrSymlinkNotPermitted indicates the operating system refused to create a
+// symbolic link for lack of privilege and no fallback applies. On Windows
+// this happens for file links when Developer Mode is off and the process is
+// not elevated; directory links fall back to junctions instead.
+type ErrSymlinkNotPermitted struct {
+	Path string
+}
+
+func (e ErrSymlinkNotPermitted) Error() string {
+	return fmt.Sprintf("not permitted to create symbolic link %q: enable Developer Mode or run as administrator", e.Path)
+}
+
 // Executor Errors
 
 // ErrEmptyPlan indicates an attempt to execute a plan with no operations.
@@ -92,11 +115,24 @@ func (e ErrExecutionCancelled) Error() string {
 }
 
 // ErrExecutionFailed indicates one or more operations failed during execution.
+//
+// Errors lists an ErrOperationFailed for each operation that failed,
+// followed by an ErrRollbackFailed for each executed operation that could
+// not be rolled back afterwards.
 type ErrExecutionFailed struct {
-	Executed   int
-	Failed     int
-	RolledBack int
-	Errors     []error
+	Executed       int
+	Failed         int
+	RolledBack     int
+	RollbackFailed int
+	Errors         []error
+
+	// Transactions is the number of transactions the plan was split into,
+	// or zero when it ran as a single transaction. The first
+	// CommittedTransactions of them committed and were not rolled back.
+	// Committed lists the operations of those committed transactions.
+	Transactions          int
+	CommittedTransactions int
+	Committed             []OperationID
 }
 
 func (e ErrExecutionFailed) Error() string {
@@ -105,6 +141,12 @@ func (e ErrExecutionFailed) Error() string {
 	if e.RolledBack > 0 {
 		fmt.Fprintf(&b, ", %d rolled back", e.RolledBack)
 	}
+	if e.RollbackFailed > 0 {
+		fmt.Fprintf(&b, ", %d rollbacks failed", e.RollbackFailed)
+	}
+	if e.Transactions > 0 {
+		fmt.Fprintf(&b, "; %d of %d transactions committed", e.CommittedTransactions, e.Transactions)
+	}
 	if len(e.Errors) > 0 {
 		fmt.Fprintf(&b, "\nerrors:\n")
 		for i, err := range e.Errors {
@@ -119,6 +161,38 @@ func (e ErrExecutionFailed) Unwrap() []error {
 	return e.Errors
 }
 
+// ErrOperationFailed records the failure of a single operation during
+// execution.
+type ErrOperationFailed struct {
+	ID   OperationID
+	Kind OperationKind
+	Err  error
+}
+
+func (e ErrOperationFailed) Error() string {
+	return fmt.Sprintf("operation %s (%s) failed: %v", e.ID, e.Kind, e.Err)
+}
+
+func (e ErrOperationFailed) Unwrap() error {
+	return e.Err
+}
+
+// ErrRollbackFailed records an executed operation that could not be rolled
+// back after a later operation failed. Its effects remain on disk.
+type ErrRollbackFailed struct {
+	ID   OperationID
+	Kind OperationKind
+	Err  error
+}
+
+func (e ErrRollbackFailed) Error() string {
+	return fmt.Sprintf("rollback of operation %s (%s) failed: %v", e.ID, e.Kind, e.Err)
+}
+
+func (e ErrRollbackFailed) Unwrap() error {
+	return e.Err
+}
+
 // ErrSourceNotFound indicates an operation source file does not exist.
 type ErrSourceNotFound struct {
 	Path string
@@ -219,7 +293,17 @@ func UserFacingError(err error) string {
 		return fmt.Sprintf("Execution was cancelled: %d operations completed, %d skipped.", e.Executed, e.Skipped)
 
 	case ErrExecutionFailed:
-		return fmt.Sprintf("Execution failed: %d operations succeeded, %d failed.\nRolled back %d operations.", e.Executed, e.Failed, e.RolledBack)
+		msg := fmt.Sprintf("Execution failed: %d operations succeeded, %d failed.\nRolled back %d operations.", e.Executed, e.Failed, e.RolledBack)
+		if e.RollbackFailed > 0 {
+			msg += fmt.Sprintf("\n%d operations could not be rolled back and may need manual cleanup.", e.RollbackFailed)
+		}
+		return msg
+
+	case ErrOperationFailed:
+		return fmt.Sprintf("Operation %s (%s) failed: %s", e.ID, e.Kind, UserFacingError(e.Err))
+
+	case ErrRollbackFailed:
+		return fmt.Sprintf("Could not roll back operation %s (%s): %s", e.ID, e.Kind, UserFacingError(e.Err))
 
 	case ErrSourceNotFound:
 		return fmt.Sprintf("Source file not found: %q\nEnsure the file exists before creating a link.", e.Path)
@@ -232,7 +316,7 @@ func UserFacingError(err error) string {
 			return UserFacingError(e.Errors[0])
 		}
 		var b strings.Builder
-		fmt.Fprintf(&b, "Multiple errors occurred:\n")
+		fmt.Fprintf(&b, "Multiple errors occurred%s:\n", summarizeOutcomes(e.Errors))
 		for i, subErr := range e.Errors {
 			fmt.Fprintf(&b, "%d. %s\n", i+1, UserFacingError(subErr))
 		}
@@ -242,3 +326,21 @@ func UserFacingError(err error) string {
 		return err.Error()
 	}
 }
+
+// summarizeOutcomes counts the operation and rollback failures among errs,
+// returning a parenthesized summary, or an empty string when there are none.
+func summarizeOutcomes(errs []error) string {
+	var failed, rollbackFailed int
+	for _, err := range errs {
+		switch err.(type) {
+		case ErrOperationFailed:
+			failed++
+		case ErrRollbackFailed:
+			rollbackFailed++
+		}
+	}
+	if failed == 0 && rollbackFailed == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (%d operations failed, %d rollbacks failed)", failed, rollbackFailed)
+}
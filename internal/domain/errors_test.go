@@ -73,6 +73,22 @@ func TestErrPermissionDenied(t *testing.T) {
 	assert.Contains(t, err.Error(), "permission denied")
 }
 
+func TestErrReadOnlyFS(t *testing.T) {
+	err := domain.ErrReadOnlyFS{
+		Path:      "/home/.vimrc",
+		Operation: "create symlink",
+	}
+
+	assert.Equal(t, `read-only filesystem: cannot create symlink "/home/.vimrc"`, err.Error())
+}
+
+func TestErrSymlinkNotPermitted(t *testing.T) {
+	err := domain.ErrSymlinkNotPermitted{Path: `C:\Users\me\.vimrc`}
+
+	assert.Contains(t, err.Error(), `C:\\Users\\me\\.vimrc`)
+	assert.Contains(t, err.Error(), "Developer Mode")
+}
+
 func TestErrMultiple(t *testing.T) {
 	err1 := errors.New("error 1")
 	err2 := errors.New("error 2")
@@ -170,6 +186,27 @@ func TestErrExecutionFailed(t *testing.T) {
 		assert.Contains(t, msg, "2 rolled back")
 	})
 
+	t.Run("with rollback failures", func(t *testing.T) {
+		err := domain.ErrExecutionFailed{
+			Executed:       3,
+			Failed:         1,
+			RolledBack:     2,
+			RollbackFailed: 1,
+		}
+		assert.Contains(t, err.Error(), "2 rolled back, 1 rollbacks failed")
+	})
+
+	t.Run("with transactions", func(t *testing.T) {
+		err := domain.ErrExecutionFailed{
+			Executed:              6,
+			Failed:                1,
+			Transactions:          4,
+			CommittedTransactions: 2,
+		}
+		msg := err.Error()
+		assert.Contains(t, msg, "2 of 4 transactions committed")
+	})
+
 	t.Run("with errors", func(t *testing.T) {
 		err := domain.ErrExecutionFailed{
 			Executed: 1,
@@ -188,6 +225,35 @@ func TestErrExecutionFailed(t *testing.T) {
 	})
 }
 
+func TestErrOperationFailed(t *testing.T) {
+	cause := errors.New("disk full")
+	err := domain.ErrOperationFailed{ID: "link-vimrc", Kind: domain.OpKindLinkCreate, Err: cause}
+
+	assert.Equal(t, "operation link-vimrc (LinkCreate) failed: disk full", err.Error())
+	assert.ErrorIs(t, err, cause)
+}
+
+func TestErrRollbackFailed(t *testing.T) {
+	cause := errors.New("permission denied")
+	err := domain.ErrRollbackFailed{ID: "dir-config", Kind: domain.OpKindDirCreate, Err: cause}
+
+	assert.Equal(t, "rollback of operation dir-config (DirCreate) failed: permission denied", err.Error())
+	assert.ErrorIs(t, err, cause)
+}
+
+func TestUserFacingError_SummarizesOperationOutcomes(t *testing.T) {
+	err := domain.ErrMultiple{Errors: []error{
+		domain.ErrOperationFailed{ID: "link-a", Kind: domain.OpKindLinkCreate, Err: errors.New("exists")},
+		domain.ErrRollbackFailed{ID: "link-b", Kind: domain.OpKindLinkCreate, Err: errors.New("busy")},
+		domain.ErrRollbackFailed{ID: "dir-c", Kind: domain.OpKindDirCreate, Err: errors.New("not empty")},
+	}}
+
+	msg := domain.UserFacingError(err)
+	assert.Contains(t, msg, "Multiple errors occurred (1 operations failed, 2 rollbacks failed)")
+	assert.Contains(t, msg, "Operation link-a (LinkCreate) failed: exists")
+	assert.Contains(t, msg, "Could not roll back operation dir-c (DirCreate): not empty")
+}
+
 func TestErrSourceNotFound(t *testing.T) {
 	err := domain.ErrSourceNotFound{Path: "/missing/file"}
 	msg := err.Error()
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"syscall"
 )
@@ -91,6 +92,10 @@ type Operation interface {
 	// Rollback undoes the operation.
 	Rollback(ctx context.Context, fs FS) error
 
+	// DryRunExecute reports what Execute would do against the current
+	// filesystem state without modifying it.
+	DryRunExecute(ctx context.Context, fs FSReader) (DryRunEffect, error)
+
 	// String returns a human-readable description.
 	String() string
 
@@ -103,6 +108,9 @@ type LinkCreate struct {
 	OpID   OperationID
 	Source FilePath
 	Target TargetPath
+	// Relative stores the source in the link relative to the link's
+	// directory instead of as an absolute path.
+	Relative bool
 }
 
 // NewLinkCreate creates a new link creation operation.
@@ -134,7 +142,20 @@ func (op LinkCreate) Dependencies() []Operation {
 }
 
 func (op LinkCreate) Execute(ctx context.Context, fs FS) error {
-	return fs.Symlink(ctx, op.Source.String(), op.Target.String())
+	return fs.Symlink(ctx, op.LinkValue(), op.Target.String())
+}
+
+// LinkValue returns the path stored in the link: the source relative to the
+// target's directory when Relative is set, otherwise the absolute source.
+func (op LinkCreate) LinkValue() string {
+	if !op.Relative {
+		return op.Source.String()
+	}
+	rel, err := filepath.Rel(filepath.Dir(op.Target.String()), op.Source.String())
+	if err != nil {
+		return op.Source.String()
+	}
+	return rel
 }
 
 func (op LinkCreate) Rollback(ctx context.Context, fs FS) error {
@@ -160,6 +181,9 @@ func (op LinkCreate) Equals(other Operation) bool {
 type LinkDelete struct {
 	OpID   OperationID
 	Target TargetPath
+	// Original is the link's destination before deletion. When set,
+	// rollback recreates the link.
+	Original string
 }
 
 // NewLinkDelete creates a new link deletion operation.
@@ -200,9 +224,11 @@ func (op LinkDelete) Execute(ctx context.Context, fs FS) error {
 }
 
 func (op LinkDelete) Rollback(ctx context.Context, fs FS) error {
-	// Cannot restore deleted link without knowing original target
-	// This would require storing the original target in the operation
-	return nil
+	// Without the original target the link cannot be restored
+	if op.Original == "" {
+		return nil
+	}
+	return fs.Symlink(ctx, op.Original, op.Target.String())
 }
 
 func (op LinkDelete) String() string {
@@ -477,6 +503,18 @@ func (op FileMove) copyAndDelete(ctx context.Context, fs FS) error {
 	return nil
 }
 
+// isLinkUnsupportedError reports whether a hardlink failed because the
+// source and destination are on different devices or the filesystem does
+// not support hardlinks, in which case copying is the right fallback.
+func isLinkUnsupportedError(err error) bool {
+	return isCrossDeviceError(err) ||
+		errors.Is(err, errors.ErrUnsupported) ||
+		errors.Is(err, syscall.EPERM) ||
+		errors.Is(err, syscall.EMLINK) ||
+		errors.Is(err, syscall.ENOTSUP) ||
+		errors.Is(err, syscall.EOPNOTSUPP)
+}
+
 // isCrossDeviceError checks if an error is a cross-device link error.
 func isCrossDeviceError(err error) bool {
 	// Check for Unix EXDEV (cross-device link)
@@ -582,6 +620,11 @@ func (op FileBackup) Execute(ctx context.Context, fs FS) error {
 		return err
 	}
 
+	// The backup directory is not created until something is backed up
+	if err := fs.MkdirAll(ctx, filepath.Dir(op.Backup.String()), 0755); err != nil {
+		return err
+	}
+
 	// Write backup with same permissions as source
 	return fs.WriteFile(ctx, op.Backup.String(), data, info.Mode())
 }
@@ -667,6 +710,11 @@ type DirCopy struct {
 	OpID   OperationID
 	Source FilePath
 	Dest   FilePath
+
+	// HardlinkWhenPossible hardlinks regular files instead of copying their
+	// contents. Files fall back to a byte copy when source and destination
+	// are on different devices or the filesystem does not support hardlinks.
+	HardlinkWhenPossible bool
 }
 
 // NewDirCopy creates a new directory copy operation.
@@ -698,6 +746,9 @@ func (op DirCopy) Dependencies() []Operation {
 }
 
 func (op DirCopy) Execute(ctx context.Context, fs FS) error {
+	if op.HardlinkWhenPossible {
+		return copyDirRecursive(ctx, fs, op.Source.String(), op.Dest.String(), true)
+	}
 	return copyDirRecursiveHelper(ctx, fs, op.Source.String(), op.Dest.String())
 }
 
@@ -724,6 +775,12 @@ func (op DirCopy) Equals(other Operation) bool {
 // copyDirRecursiveHelper recursively copies a directory and all its contents.
 // This is a package-level helper used by both FileMove and DirCopy operations.
 func copyDirRecursiveHelper(ctx context.Context, fs FS, src, dst string) error {
+	return copyDirRecursive(ctx, fs, src, dst, false)
+}
+
+// copyDirRecursive copies src to dst, hardlinking regular files when
+// hardlink is set and the filesystem allows it.
+func copyDirRecursive(ctx context.Context, fs FS, src, dst string, hardlink bool) error {
 	// Create destination directory
 	srcInfo, err := fs.Stat(ctx, src)
 	if err != nil {
@@ -746,10 +803,21 @@ func copyDirRecursiveHelper(ctx context.Context, fs FS, src, dst string) error {
 
 		if entry.IsDir() {
 			// Recursively copy subdirectory
-			if err := copyDirRecursiveHelper(ctx, fs, srcPath, dstPath); err != nil {
+			if err := copyDirRecursive(ctx, fs, srcPath, dstPath, hardlink); err != nil {
 				return err
 			}
 		} else {
+			if hardlink && entry.Type().IsRegular() {
+				err := fs.Link(ctx, srcPath, dstPath)
+				if err == nil {
+					continue
+				}
+				if !isLinkUnsupportedError(err) {
+					return err
+				}
+				// Hardlink not possible here; copy this file instead
+			}
+
 			// Copy file
 			data, err := fs.ReadFile(ctx, srcPath)
 			if err != nil {
@@ -36,6 +36,26 @@ func TestLinkCreate_Execute(t *testing.T) {
 	assert.True(t, isLink)
 }
 
+func TestLinkCreate_ExecuteRelative(t *testing.T) {
+	fs := adapters.NewMemFS()
+	ctx := context.Background()
+
+	require.NoError(t, fs.MkdirAll(ctx, "/home/dotfiles", 0755))
+	require.NoError(t, fs.MkdirAll(ctx, "/home/user", 0755))
+	require.NoError(t, fs.WriteFile(ctx, "/home/dotfiles/file", []byte("data"), 0644))
+
+	source := domain.MustParsePath("/home/dotfiles/file")
+	target := domain.NewTargetPath("/home/user/link").Unwrap()
+
+	op := domain.NewLinkCreate("link1", source, target)
+	op.Relative = true
+	require.NoError(t, op.Execute(ctx, fs))
+
+	linkTarget, err := fs.ReadLink(ctx, "/home/user/link")
+	require.NoError(t, err)
+	assert.Equal(t, "../dotfiles/file", linkTarget)
+}
+
 func TestLinkCreate_Rollback(t *testing.T) {
 	fs := adapters.NewMemFS()
 	ctx := context.Background()
@@ -102,6 +122,23 @@ func TestLinkDelete_Rollback(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestLinkDelete_Rollback_RestoresOriginal(t *testing.T) {
+	fs := adapters.NewMemFS()
+	ctx := context.Background()
+
+	require.NoError(t, fs.MkdirAll(ctx, "/target", 0755))
+	require.NoError(t, fs.Symlink(ctx, "/source/file", "/target/link"))
+
+	op := domain.NewLinkDelete("del1", domain.NewTargetPath("/target/link").Unwrap())
+	op.Original = "/source/file"
+	require.NoError(t, op.Execute(ctx, fs))
+	require.NoError(t, op.Rollback(ctx, fs))
+
+	dest, err := fs.ReadLink(ctx, "/target/link")
+	require.NoError(t, err)
+	assert.Equal(t, "/source/file", dest)
+}
+
 func TestDirCreate_Execute(t *testing.T) {
 	fs := adapters.NewMemFS()
 	ctx := context.Background()
@@ -227,6 +264,24 @@ func TestFileBackup_Execute(t *testing.T) {
 	assert.Equal(t, []byte("original"), data)
 }
 
+func TestFileBackup_Execute_CreatesBackupDir(t *testing.T) {
+	fs := adapters.NewMemFS()
+	ctx := context.Background()
+
+	require.NoError(t, fs.MkdirAll(ctx, "/test", 0755))
+	require.NoError(t, fs.WriteFile(ctx, "/test/file.txt", []byte("original"), 0644))
+
+	source := domain.MustParsePath("/test/file.txt")
+	backup := domain.MustParsePath("/test/.dot-backup/file.txt.bak")
+
+	op := domain.NewFileBackup("bak1", source, backup)
+	require.NoError(t, op.Execute(ctx, fs))
+
+	data, err := fs.ReadFile(ctx, "/test/.dot-backup/file.txt.bak")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("original"), data)
+}
+
 func TestFileBackup_Rollback(t *testing.T) {
 	fs := adapters.NewMemFS()
 	ctx := context.Background()
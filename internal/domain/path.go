@@ -153,6 +153,16 @@ func (p Path[K]) Equals(other Path[K]) bool {
 	return p.path == other.path
 }
 
+// ResolveLinkTarget returns the cleaned path that a symlink at linkPath
+// storing target points to. Relative targets are relative to the link's
+// directory.
+func ResolveLinkTarget(linkPath, target string) string {
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(filepath.Dir(linkPath), target)
+	}
+	return filepath.Clean(target)
+}
+
 // clean normalizes a path by removing redundant separators and resolving dots.
 func clean(path string) string {
 	cleaned := filepath.Clean(path)
@@ -180,3 +180,22 @@ func TestPath_JoinSafe_RejectsTraversal(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveLinkTarget(t *testing.T) {
+	tests := []struct {
+		name     string
+		linkPath string
+		target   string
+		want     string
+	}{
+		{"absolute target", "/home/user/.vimrc", "/opt/dotfiles/vim/dot-vimrc", "/opt/dotfiles/vim/dot-vimrc"},
+		{"relative sibling", "/home/user/.vimrc", "dotfiles/vim/dot-vimrc", "/home/user/dotfiles/vim/dot-vimrc"},
+		{"relative parent", "/home/user/.config/nvim", "../../dotfiles/nvim", "/home/dotfiles/nvim"},
+		{"unclean absolute", "/home/user/.vimrc", "/opt/dotfiles/./vim//dot-vimrc", "/opt/dotfiles/vim/dot-vimrc"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, domain.ResolveLinkTarget(tt.linkPath, tt.target))
+		})
+	}
+}
@@ -79,3 +79,19 @@ func ValidateWithValidators(path string, validators []PathValidator) error {
 	}
 	return nil
 }
+
+// ValidateTargetSubpath checks that path names a location inside the target
+// directory: non-empty, relative, and free of traversal once cleaned.
+// Rooted paths such as "/etc" are rejected on every platform.
+func ValidateTargetSubpath(path string) error {
+	if err := (&NonEmptyPathValidator{}).Validate(path); err != nil {
+		return err
+	}
+	if strings.HasPrefix(filepath.ToSlash(path), "/") {
+		return ErrInvalidPath{Path: path, Reason: "path must be relative"}
+	}
+	return ValidateWithValidators(filepath.Clean(path), []PathValidator{
+		&RelativePathValidator{},
+		&TraversalFreeValidator{},
+	})
+}
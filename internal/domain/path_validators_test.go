@@ -281,3 +281,38 @@ func As(err error, target interface{}) bool {
 	}
 	return false
 }
+
+func TestValidateTargetSubpath(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		wantErr bool
+	}{
+		{name: "directory", path: ".ssh"},
+		{name: "nested directory", path: ".config/nvim"},
+		{name: "trailing separator", path: ".ssh/"},
+		{name: "target root", path: "."},
+		{name: "inner traversal that stays inside", path: ".config/../.ssh"},
+		{name: "empty", path: "", wantErr: true},
+		{name: "parent", path: "..", wantErr: true},
+		{name: "escapes target", path: "../etc", wantErr: true},
+		{name: "escapes after nesting", path: ".config/../../etc", wantErr: true},
+		{name: "rooted", path: "/etc", wantErr: true},
+	}
+
+	t.Run("absolute", func(t *testing.T) {
+		assert.Error(t, ValidateTargetSubpath(t.TempDir()))
+	})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateTargetSubpath(tt.path)
+			if tt.wantErr {
+				var pathErr ErrInvalidPath
+				assert.ErrorAs(t, err, &pathErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
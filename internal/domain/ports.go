@@ -13,6 +13,14 @@ type FSReader interface {
 	ReadDir(ctx context.Context, path string) ([]DirEntry, error)
 	ReadLink(ctx context.Context, path string) (string, error)
 	ReadFile(ctx context.Context, path string) ([]byte, error)
+	// ReadFileRange reads up to length bytes starting at offset. Fewer
+	// bytes are returned, without error, when the file ends first.
+	ReadFileRange(ctx context.Context, path string, offset, length int64) ([]byte, error)
+	// Walk visits root and everything beneath it depth first, in lexical
+	// order, without following symlinks, with the semantics of
+	// filepath.WalkDir: fn may return fs.SkipDir to skip a directory or
+	// fs.SkipAll to stop.
+	Walk(ctx context.Context, root string, fn WalkFunc) error
 
 	// Queries (read-only checks)
 	Exists(ctx context.Context, path string) bool
@@ -28,6 +36,7 @@ type FSWriter interface {
 	Remove(ctx context.Context, path string) error
 	RemoveAll(ctx context.Context, path string) error
 	Symlink(ctx context.Context, oldname, newname string) error
+	Link(ctx context.Context, oldname, newname string) error
 	Rename(ctx context.Context, oldpath, newpath string) error
 }
 
@@ -38,6 +47,45 @@ type FS interface {
 	FSWriter
 }
 
+// LinkKind identifies the mechanism behind a link created by FSWriter.Symlink.
+type LinkKind int
+
+const (
+	// LinkSymlink is a symbolic link.
+	LinkSymlink LinkKind = iota
+	// LinkJunction is a Windows directory junction, created in place of a
+	// symbolic link when the process lacks the symlink privilege.
+	LinkJunction
+)
+
+// String returns the name of the link kind.
+func (k LinkKind) String() string {
+	switch k {
+	case LinkSymlink:
+		return "symlink"
+	case LinkJunction:
+		return "junction"
+	default:
+		return "unknown"
+	}
+}
+
+// LinkKindReader is implemented by filesystems that can create links other
+// than symbolic links.
+type LinkKindReader interface {
+	// LinkKind reports how the link at path was created.
+	LinkKind(ctx context.Context, path string) (LinkKind, error)
+}
+
+// LinkKindOf reports how the link at path was created. Filesystems that do
+// not implement LinkKindReader only create symbolic links.
+func LinkKindOf(ctx context.Context, fs FSReader, path string) (LinkKind, error) {
+	if r, ok := fs.(LinkKindReader); ok {
+		return r.LinkKind(ctx, path)
+	}
+	return LinkSymlink, nil
+}
+
 // FileInfo is a type alias for the standard library fs.FileInfo interface.
 // Using the stdlib type directly simplifies interoperability and eliminates
 // the need for wrapper types when interfacing with standard library functions.
@@ -48,6 +96,10 @@ type FileInfo = fs.FileInfo
 // the need for wrapper types when interfacing with standard library functions.
 type DirEntry = fs.DirEntry
 
+// WalkFunc is a type alias for the standard library fs.WalkDirFunc, called
+// by FSReader.Walk for each visited path.
+type WalkFunc = fs.WalkDirFunc
+
 // Logger defines the logging abstraction interface.
 type Logger interface {
 	Debug(ctx context.Context, msg string, fields ...any)
@@ -53,6 +53,14 @@ func (m *MockFS) ReadFile(ctx context.Context, name string) ([]byte, error) {
 	return args.Get(0).([]byte), args.Error(1)
 }
 
+func (m *MockFS) ReadFileRange(ctx context.Context, name string, offset, length int64) ([]byte, error) {
+	args := m.Called(ctx, name, offset, length)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
 func (m *MockFS) WriteFile(ctx context.Context, name string, data []byte, perm fs.FileMode) error {
 	args := m.Called(ctx, name, data, perm)
 	return args.Error(0)
@@ -83,6 +91,11 @@ func (m *MockFS) Symlink(ctx context.Context, oldname, newname string) error {
 	return args.Error(0)
 }
 
+func (m *MockFS) Link(ctx context.Context, oldname, newname string) error {
+	args := m.Called(ctx, oldname, newname)
+	return args.Error(0)
+}
+
 func (m *MockFS) Rename(ctx context.Context, oldname, newname string) error {
 	args := m.Called(ctx, oldname, newname)
 	return args.Error(0)
@@ -103,6 +116,11 @@ func (m *MockFS) IsSymlink(ctx context.Context, name string) (bool, error) {
 	return args.Bool(0), args.Error(1)
 }
 
+func (m *MockFS) Walk(ctx context.Context, root string, fn domain.WalkFunc) error {
+	args := m.Called(ctx, root, fn)
+	return args.Error(0)
+}
+
 func TestMockFS(t *testing.T) {
 	ctx := context.Background()
 	mockFS := new(MockFS)
@@ -364,3 +382,30 @@ func TestFileInfoIsStdlibAlias(t *testing.T) {
 	modTime := domainInfo.ModTime()
 	assert.Equal(t, time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), modTime)
 }
+
+// junctionFS reports every link as a junction.
+type junctionFS struct {
+	MockFS
+}
+
+func (j *junctionFS) LinkKind(ctx context.Context, name string) (domain.LinkKind, error) {
+	return domain.LinkJunction, nil
+}
+
+func TestLinkKindOf(t *testing.T) {
+	ctx := context.Background()
+
+	kind, err := domain.LinkKindOf(ctx, &MockFS{}, "/home/.vim")
+	assert.NoError(t, err)
+	assert.Equal(t, domain.LinkSymlink, kind, "filesystems without LinkKind only create symlinks")
+
+	kind, err = domain.LinkKindOf(ctx, &junctionFS{}, "/home/.vim")
+	assert.NoError(t, err)
+	assert.Equal(t, domain.LinkJunction, kind)
+}
+
+func TestLinkKind_String(t *testing.T) {
+	assert.Equal(t, "symlink", domain.LinkSymlink.String())
+	assert.Equal(t, "junction", domain.LinkJunction.String())
+	assert.Equal(t, "unknown", domain.LinkKind(99).String())
+}
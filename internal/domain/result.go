@@ -125,6 +125,25 @@ func FlatMap[T, U any](r Result[T], fn func(T) Result[U]) Result[U] {
 	return fn(r.value)
 }
 
+// Match returns onOk applied to the contained value if Ok, or onErr applied
+// to the error otherwise. Exactly one of the two functions is called, so
+// neither branch can panic the way Unwrap and UnwrapErr do.
+func Match[T, R any](r Result[T], onOk func(T) R, onErr func(error) R) R {
+	if !r.isOk {
+		return onErr(r.err)
+	}
+	return onOk(r.value)
+}
+
+// Tap calls fn with the contained value if Ok and returns r unchanged,
+// allowing side effects such as logging in the middle of a chain.
+func Tap[T any](r Result[T], fn func(T)) Result[T] {
+	if r.isOk {
+		fn(r.value)
+	}
+	return r
+}
+
 // Collect aggregates a slice of Results into a Result containing a slice.
 // Returns Err if any Result is Err, otherwise returns Ok with all values.
 func Collect[T any](results []Result[T]) Result[[]T] {
@@ -0,0 +1,158 @@
+package domain
+
+import (
+	"context"
+	"path/filepath"
+	"sort"
+)
+
+// RiskLevel summarizes how much applying a plan could lose.
+type RiskLevel string
+
+const (
+	// RiskLow means the plan deletes nothing and backs nothing up.
+	RiskLow RiskLevel = "low"
+
+	// RiskMedium means the plan deletes a few files or directories, or
+	// moves existing files aside into backups.
+	RiskMedium RiskLevel = "medium"
+
+	// RiskHigh means the plan deletes HighRiskDestructiveOps or more files
+	// or directories.
+	RiskHigh RiskLevel = "high"
+)
+
+// HighRiskDestructiveOps is the number of destructive operations at which
+// a plan is high risk.
+const HighRiskDestructiveOps = 5
+
+// CountDestructiveOps counts the operations in ops that delete data the
+// plan does not keep: recursive directory removals, and file deletes, the
+// way an overwrite clears its target, unless the plan backs the file up.
+func CountDestructiveOps(ops []Operation) int {
+	backedUp := make(map[string]bool)
+	for _, op := range ops {
+		if backup, ok := op.(FileBackup); ok {
+			backedUp[backup.Source.String()] = true
+		}
+	}
+
+	count := 0
+	for _, op := range ops {
+		switch o := op.(type) {
+		case DirRemoveAll:
+			count++
+		case FileDelete:
+			if !backedUp[o.Path.String()] {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// PermanentDeletions lists every path applying ops would delete without a
+// backup, sorted: the files CountDestructiveOps counts, and each directory
+// removed recursively along with everything beneath it, as found on fs.
+// Paths that do not exist are left out, and symlinks inside a removed
+// directory are listed but not followed.
+func PermanentDeletions(ctx context.Context, fs FSReader, ops []Operation) ([]string, error) {
+	backedUp := make(map[string]bool)
+	for _, op := range ops {
+		if backup, ok := op.(FileBackup); ok {
+			backedUp[backup.Source.String()] = true
+		}
+	}
+
+	seen := make(map[string]bool)
+	var paths []string
+	add := func(path string) {
+		if !seen[path] {
+			seen[path] = true
+			paths = append(paths, path)
+		}
+	}
+
+	for _, op := range ops {
+		switch o := op.(type) {
+		case DirRemoveAll:
+			if err := walkRemoved(ctx, fs, o.Path.String(), add); err != nil {
+				return nil, err
+			}
+		case FileDelete:
+			path := o.Path.String()
+			if backedUp[path] {
+				continue
+			}
+			info, err := lstatIfExists(ctx, fs, path)
+			if err != nil {
+				return nil, err
+			}
+			if info != nil {
+				add(path)
+			}
+		}
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// walkRemoved calls add for path and, when it is a directory, for every
+// entry beneath it.
+func walkRemoved(ctx context.Context, fs FSReader, path string, add func(string)) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	info, err := lstatIfExists(ctx, fs, path)
+	if err != nil || info == nil {
+		return err
+	}
+	add(path)
+	if !info.IsDir() {
+		return nil
+	}
+
+	entries, err := fs.ReadDir(ctx, path)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if err := walkRemoved(ctx, fs, filepath.Join(path, entry.Name()), add); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AssessRisk derives a risk level from a plan's destructive operation
+// count and the bytes it backs up.
+func AssessRisk(destructiveOps int, bytesBackedUp int64) RiskLevel {
+	switch {
+	case destructiveOps >= HighRiskDestructiveOps:
+		return RiskHigh
+	case destructiveOps > 0 || bytesBackedUp > 0:
+		return RiskMedium
+	default:
+		return RiskLow
+	}
+}
+
+// AnnotateRisk sets the destructive operation count, backed up bytes, and
+// risk level in plan's metadata. Backup sizes are read from fs; a file
+// that cannot be read counts as empty.
+func AnnotateRisk(ctx context.Context, fs FS, plan Plan) Plan {
+	destructive := CountDestructiveOps(plan.Operations)
+	var backedUp int64
+	for _, op := range plan.Operations {
+		if backup, ok := op.(FileBackup); ok && fs != nil {
+			if info, err := fs.Stat(ctx, backup.Source.String()); err == nil {
+				backedUp += info.Size()
+			}
+		}
+	}
+
+	plan.Metadata.DestructiveOps = destructive
+	plan.Metadata.BytesBackedUp = backedUp
+	plan.Metadata.RiskLevel = AssessRisk(destructive, backedUp)
+	return plan
+}
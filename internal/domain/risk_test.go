@@ -0,0 +1,115 @@
+package domain_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yaklabco/dot/internal/adapters"
+	"github.com/yaklabco/dot/internal/domain"
+)
+
+func TestAssessRisk(t *testing.T) {
+	tests := []struct {
+		name          string
+		destructive   int
+		bytesBackedUp int64
+		want          domain.RiskLevel
+	}{
+		{name: "nothing lost", want: domain.RiskLow},
+		{name: "backups only", bytesBackedUp: 10, want: domain.RiskMedium},
+		{name: "few deletes", destructive: 1, want: domain.RiskMedium},
+		{name: "threshold", destructive: domain.HighRiskDestructiveOps, want: domain.RiskHigh},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, domain.AssessRisk(tt.destructive, tt.bytesBackedUp))
+		})
+	}
+}
+
+func TestAnnotateRisk_MixedPlan(t *testing.T) {
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	require.NoError(t, fs.MkdirAll(ctx, "/home/cache", 0755))
+	require.NoError(t, fs.WriteFile(ctx, "/home/.bashrc", []byte("0123456789"), 0644))
+	require.NoError(t, fs.WriteFile(ctx, "/home/.zshrc", []byte("zsh"), 0644))
+
+	plan := domain.Plan{Operations: []domain.Operation{
+		domain.NewDirCreate("dir", domain.MustParsePath("/home/.config")),
+		domain.NewLinkCreate("link", domain.MustParsePath("/pkg/dot-vimrc"), domain.MustParseTargetPath("/home/.vimrc")),
+		// Backed up, then deleted: the file is kept
+		domain.NewFileBackup("backup", domain.MustParsePath("/home/.bashrc"), domain.MustParsePath("/backup/.bashrc")),
+		domain.NewFileDelete("delete-backed-up", domain.MustParsePath("/home/.bashrc")),
+		// Overwritten: the file is lost
+		domain.NewFileDelete("overwrite", domain.MustParsePath("/home/.zshrc")),
+		domain.NewDirRemoveAll("purge", domain.MustParsePath("/home/cache")),
+		domain.NewLinkDelete("unlink", domain.MustParseTargetPath("/home/.old")),
+	}}
+
+	meta := domain.AnnotateRisk(ctx, fs, plan).Metadata
+	assert.Equal(t, 2, meta.DestructiveOps)
+	assert.Equal(t, int64(10), meta.BytesBackedUp)
+	assert.Equal(t, domain.RiskMedium, meta.RiskLevel)
+}
+
+func TestAnnotateRisk_HighRisk(t *testing.T) {
+	ops := make([]domain.Operation, 0, domain.HighRiskDestructiveOps)
+	for i := range domain.HighRiskDestructiveOps {
+		path := domain.MustParsePath(fmt.Sprintf("/home/file%d", i))
+		ops = append(ops, domain.NewFileDelete(domain.OperationID(fmt.Sprintf("delete-%d", i)), path))
+	}
+
+	meta := domain.AnnotateRisk(context.Background(), adapters.NewMemFS(), domain.Plan{Operations: ops}).Metadata
+	assert.Equal(t, domain.HighRiskDestructiveOps, meta.DestructiveOps)
+	assert.Zero(t, meta.BytesBackedUp)
+	assert.Equal(t, domain.RiskHigh, meta.RiskLevel)
+}
+
+func TestAnnotateRisk_LinksOnly(t *testing.T) {
+	plan := domain.Plan{Operations: []domain.Operation{
+		domain.NewLinkCreate("link", domain.MustParsePath("/pkg/dot-vimrc"), domain.MustParseTargetPath("/home/.vimrc")),
+	}}
+
+	meta := domain.AnnotateRisk(context.Background(), adapters.NewMemFS(), plan).Metadata
+	assert.Zero(t, meta.DestructiveOps)
+	assert.Equal(t, domain.RiskLow, meta.RiskLevel)
+}
+
+func TestPermanentDeletions(t *testing.T) {
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	require.NoError(t, fs.MkdirAll(ctx, "/dotfiles/vim/dot-vim/colors", 0755))
+	require.NoError(t, fs.WriteFile(ctx, "/dotfiles/vim/dot-vimrc", []byte("set nu"), 0644))
+	require.NoError(t, fs.WriteFile(ctx, "/dotfiles/vim/dot-vim/colors/dark.vim", []byte("hi"), 0644))
+	require.NoError(t, fs.Symlink(ctx, "/etc/vimrc", "/dotfiles/vim/system"))
+	require.NoError(t, fs.MkdirAll(ctx, "/home", 0755))
+	require.NoError(t, fs.WriteFile(ctx, "/home/.bashrc", []byte("bash"), 0644))
+	require.NoError(t, fs.WriteFile(ctx, "/home/.zshrc", []byte("zsh"), 0644))
+
+	ops := []domain.Operation{
+		domain.NewLinkDelete("unlink", domain.MustParseTargetPath("/home/.vimrc")),
+		domain.NewFileBackup("backup", domain.MustParsePath("/home/.bashrc"), domain.MustParsePath("/backup/.bashrc")),
+		domain.NewFileDelete("delete-backed-up", domain.MustParsePath("/home/.bashrc")),
+		domain.NewFileDelete("overwrite", domain.MustParsePath("/home/.zshrc")),
+		domain.NewFileDelete("gone", domain.MustParsePath("/home/.missing")),
+		domain.NewDirRemoveAll("purge", domain.MustParsePath("/dotfiles/vim")),
+		domain.NewDirRemoveAll("purge-gone", domain.MustParsePath("/dotfiles/emacs")),
+	}
+
+	paths, err := domain.PermanentDeletions(ctx, fs, ops)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"/dotfiles/vim",
+		"/dotfiles/vim/dot-vim",
+		"/dotfiles/vim/dot-vim/colors",
+		"/dotfiles/vim/dot-vim/colors/dark.vim",
+		"/dotfiles/vim/dot-vimrc",
+		"/dotfiles/vim/system",
+		"/home/.zshrc",
+	}, paths)
+}
@@ -0,0 +1,88 @@
+// Package tracetest provides a domain.Tracer that records spans in memory
+// for tests.
+package tracetest
+
+import (
+	"context"
+	"sync"
+
+	"github.com/yaklabco/dot/internal/domain"
+)
+
+// Span captures a span's name, parent, and attributes.
+type Span struct {
+	Name   string
+	Parent *Span
+	Attrs  map[string]any
+	Errs   []error
+	Ended  bool
+
+	tracer *Tracer
+}
+
+// End marks the span as ended.
+func (s *Span) End() {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	s.Ended = true
+}
+
+// RecordError appends err to the span's errors.
+func (s *Span) RecordError(err error) {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	s.Errs = append(s.Errs, err)
+}
+
+// SetAttributes stores the attributes on the span, replacing earlier
+// values for the same keys.
+func (s *Span) SetAttributes(attrs ...domain.Attribute) {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	for _, attr := range attrs {
+		s.Attrs[attr.Key] = attr.Value
+	}
+}
+
+type spanKey struct{}
+
+// Tracer records every span started, tracking parents through the
+// context. The zero value is ready to use.
+type Tracer struct {
+	mu    sync.Mutex
+	spans []*Span
+}
+
+// Start records a new span as a child of the span in ctx, if any.
+func (t *Tracer) Start(ctx context.Context, name string, _ ...domain.SpanOption) (context.Context, domain.Span) {
+	parent, _ := ctx.Value(spanKey{}).(*Span)
+	span := &Span{tracer: t, Name: name, Parent: parent, Attrs: make(map[string]any)}
+
+	t.mu.Lock()
+	t.spans = append(t.spans, span)
+	t.mu.Unlock()
+
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+// Find returns the first span with the given name, or nil if none was
+// started.
+func (t *Tracer) Find(name string) *Span {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, span := range t.spans {
+		if span.Name == name {
+			return span
+		}
+	}
+	return nil
+}
+
+// Spans returns every span started, in order.
+func (t *Tracer) Spans() []*Span {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	spans := make([]*Span, len(t.spans))
+	copy(spans, t.spans)
+	return spans
+}
@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"sort"
+)
+
+// WalkDir implements FSReader.Walk on top of Lstat and ReadDir, for
+// filesystems without a native walk. It matches filepath.WalkDir: root is
+// visited first, directories are read in lexical order, symlinks are not
+// followed, and a ReadDir error is passed to fn a second time for the
+// directory. The walk stops with ctx's error once ctx is done.
+func WalkDir(ctx context.Context, fsys FSReader, root string, fn WalkFunc) error {
+	info, err := fsys.Lstat(ctx, root)
+	if err != nil {
+		err = fn(root, nil, err)
+	} else {
+		err = walkDir(ctx, fsys, root, fs.FileInfoToDirEntry(info), fn)
+	}
+	if errors.Is(err, fs.SkipDir) || errors.Is(err, fs.SkipAll) {
+		return nil
+	}
+	return err
+}
+
+func walkDir(ctx context.Context, fsys FSReader, path string, d DirEntry, fn WalkFunc) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := fn(path, d, nil); err != nil || !d.IsDir() {
+		if errors.Is(err, fs.SkipDir) && d.IsDir() {
+			err = nil
+		}
+		return err
+	}
+
+	entries, err := fsys.ReadDir(ctx, path)
+	if err != nil {
+		// Second call, to report the ReadDir error
+		if err := fn(path, d, err); err != nil {
+			if errors.Is(err, fs.SkipDir) {
+				err = nil
+			}
+			return err
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name() < entries[j].Name()
+	})
+
+	for _, entry := range entries {
+		if err := walkDir(ctx, fsys, filepath.Join(path, entry.Name()), entry, fn); err != nil {
+			if errors.Is(err, fs.SkipDir) {
+				break
+			}
+			return err
+		}
+	}
+	return nil
+}
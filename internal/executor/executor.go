@@ -6,6 +6,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"path/filepath"
 	"runtime"
 
 	"github.com/yaklabco/dot/internal/domain"
@@ -18,6 +19,8 @@ type Executor struct {
 	tracer      domain.Tracer
 	checkpoint  CheckpointStore
 	concurrency int
+	txSize      int
+	retry       RetryPolicy
 }
 
 // Opts configures executor creation.
@@ -31,6 +34,14 @@ type Opts struct {
 	// If zero, defaults to runtime.NumCPU().
 	// If negative, no limit is applied (all operations in batch run concurrently).
 	Concurrency int
+	// TransactionSize splits a plan into sequential transactions of at most
+	// this many operations. Each transaction commits before the next starts,
+	// so a failure only rolls back the current one. Zero or negative runs
+	// the whole plan as a single all-or-nothing transaction.
+	TransactionSize int
+	// Retry retries operations that fail with a transient filesystem
+	// error. The zero value runs each operation once.
+	Retry RetryPolicy
 }
 
 // New creates a new Executor with the given options.
@@ -47,13 +58,16 @@ func New(opts Opts) *Executor {
 		tracer:      opts.Tracer,
 		checkpoint:  opts.Checkpoint,
 		concurrency: opts.Concurrency,
+		txSize:      opts.TransactionSize,
+		retry:       opts.Retry,
 	}
 }
 
 // Execute executes a plan with two-phase commit and automatic rollback on failure.
 func (e *Executor) Execute(ctx context.Context, plan domain.Plan) domain.Result[ExecutionResult] {
-	ctx, span := e.tracer.Start(ctx, "executor.Execute")
+	ctx, span := e.tracer.Start(ctx, "execute")
 	defer span.End()
+	span.SetAttributes(domain.Attribute{Key: "operation_count", Value: len(plan.Operations)})
 
 	// Validate plan is not empty
 	if len(plan.Operations) == 0 {
@@ -73,6 +87,23 @@ func (e *Executor) Execute(ctx context.Context, plan domain.Plan) domain.Result[
 		return domain.Err[ExecutionResult](err)
 	}
 
+	var result ExecutionResult
+	var err error
+	if e.txSize <= 0 || len(plan.Operations) <= e.txSize {
+		result, err = e.commit(ctx, plan)
+	} else {
+		result, err = e.executeTransactions(ctx, plan)
+	}
+	if err != nil {
+		span.RecordError(err)
+		return domain.Err[ExecutionResult](err)
+	}
+	return domain.Ok(result)
+}
+
+// commit executes a prepared plan as one transaction, rolling back every
+// executed operation if any fails.
+func (e *Executor) commit(ctx context.Context, plan domain.Plan) (ExecutionResult, error) {
 	// Create checkpoint before execution
 	checkpoint := e.checkpoint.Create(ctx)
 	e.log.Info(ctx, "checkpoint_created", "checkpoint_id", checkpoint.ID)
@@ -103,8 +134,7 @@ func (e *Executor) Execute(ctx context.Context, plan domain.Plan) domain.Result[
 				"executed", len(result.Executed),
 				"failed_count", len(result.Failed),
 				"cancelled", isCancelled)
-			rolledBack := e.rollback(ctx, result.Executed, checkpoint)
-			result.RolledBack = rolledBack
+			result.RolledBack, result.RollbackErrors = e.rollback(ctx, result.Executed, checkpoint)
 		}
 
 		// Return appropriate error
@@ -113,30 +143,151 @@ func (e *Executor) Execute(ctx context.Context, plan domain.Plan) domain.Result[
 			for _, err := range result.Errors {
 				var cancelErr domain.ErrExecutionCancelled
 				if errors.As(err, &cancelErr) {
-					return domain.Err[ExecutionResult](cancelErr)
+					return result, cancelErr
 				}
 			}
 		}
 
-		// Return execution failure
-		err := domain.ErrExecutionFailed{
-			Executed:   len(result.Executed),
-			Failed:     len(result.Failed),
-			RolledBack: len(result.RolledBack),
-			Errors:     result.Errors,
+		// Return execution failure with the failed operations first,
+		// then any operations that could not be rolled back
+		errs := make([]error, 0, len(result.Errors)+len(result.RollbackErrors))
+		errs = append(errs, result.Errors...)
+		errs = append(errs, result.RollbackErrors...)
+		return result, domain.ErrExecutionFailed{
+			Executed:       len(result.Executed),
+			Failed:         len(result.Failed),
+			RolledBack:     len(result.RolledBack),
+			RollbackFailed: len(result.RollbackErrors),
+			Errors:         errs,
 		}
-		return domain.Err[ExecutionResult](err)
 	}
 
 	// Success - delete checkpoint
 	if err := e.checkpoint.Delete(ctx, checkpoint.ID); err != nil {
 		e.log.Error(ctx, "checkpoint_delete_failed", "checkpoint_id", checkpoint.ID, "error", err)
-		return domain.Err[ExecutionResult](fmt.Errorf("checkpoint cleanup failed: %w", err))
+		return result, fmt.Errorf("checkpoint cleanup failed: %w", err)
 	}
 
 	e.log.Info(ctx, "execution_complete", "operations", len(result.Executed))
 
-	return domain.Ok(result)
+	return result, nil
+}
+
+// executeTransactions commits a prepared plan in sequential transactions of
+// at most txSize operations. Transactions committed before a failure stay
+// in place; only the failing transaction is rolled back.
+func (e *Executor) executeTransactions(ctx context.Context, plan domain.Plan) (ExecutionResult, error) {
+	txs := splitPlan(plan, e.txSize)
+	e.log.Info(ctx, "executing_transactions",
+		"transaction_count", len(txs),
+		"transaction_size", e.txSize)
+
+	total := ExecutionResult{
+		Executed:   []domain.OperationID{},
+		Failed:     []domain.OperationID{},
+		RolledBack: []domain.OperationID{},
+		Errors:     []error{},
+	}
+
+	for i, tx := range txs {
+		ctx, span := e.tracer.Start(ctx, "transaction")
+		span.SetAttributes(
+			domain.Attribute{Key: "transaction", Value: i + 1},
+			domain.Attribute{Key: "operation_count", Value: len(tx.Operations)},
+		)
+
+		result, err := e.commit(ctx, tx)
+		total.Executed = append(total.Executed, result.Executed...)
+		total.Failed = append(total.Failed, result.Failed...)
+		total.RolledBack = append(total.RolledBack, result.RolledBack...)
+		total.RollbackErrors = append(total.RollbackErrors, result.RollbackErrors...)
+		total.Errors = append(total.Errors, result.Errors...)
+		total.Transactions = append(total.Transactions, TransactionResult{
+			Operations: operationIDs(tx.Operations),
+			Committed:  err == nil,
+		})
+
+		if err != nil {
+			span.RecordError(err)
+			span.End()
+			e.log.Error(ctx, "transaction_failed",
+				"transaction", i+1,
+				"committed_transactions", i,
+				"transaction_count", len(txs),
+				"error", err)
+
+			var execFailed domain.ErrExecutionFailed
+			if errors.As(err, &execFailed) {
+				execFailed.Executed = len(total.Executed)
+				execFailed.Transactions = len(txs)
+				execFailed.CommittedTransactions = i
+				for _, committed := range total.Transactions[:i] {
+					execFailed.Committed = append(execFailed.Committed, committed.Operations...)
+				}
+				err = execFailed
+			}
+			return total, err
+		}
+
+		span.End()
+		e.log.Info(ctx, "transaction_committed", "transaction", i+1, "transaction_count", len(txs))
+	}
+
+	return total, nil
+}
+
+// splitPlan splits a plan into consecutive plans of at most size operations.
+// Parallel batches are carried over, restricted to each part's operations,
+// so dependency order within a part is preserved.
+func splitPlan(plan domain.Plan, size int) []domain.Plan {
+	parts := make([]domain.Plan, 0, (len(plan.Operations)+size-1)/size)
+	for start := 0; start < len(plan.Operations); start += size {
+		end := start + size
+		if end > len(plan.Operations) {
+			end = len(plan.Operations)
+		}
+		ops := plan.Operations[start:end]
+		parts = append(parts, domain.Plan{
+			Operations: ops,
+			Metadata:   plan.Metadata,
+			Batches:    restrictBatches(plan.Batches, ops),
+		})
+	}
+	return parts
+}
+
+// restrictBatches returns batches limited to the given operations, dropping
+// batches left empty.
+func restrictBatches(batches [][]domain.Operation, ops []domain.Operation) [][]domain.Operation {
+	if len(batches) == 0 {
+		return nil
+	}
+	include := make(map[domain.OperationID]struct{}, len(ops))
+	for _, op := range ops {
+		include[op.ID()] = struct{}{}
+	}
+
+	var result [][]domain.Operation
+	for _, batch := range batches {
+		var kept []domain.Operation
+		for _, op := range batch {
+			if _, ok := include[op.ID()]; ok {
+				kept = append(kept, op)
+			}
+		}
+		if len(kept) > 0 {
+			result = append(result, kept)
+		}
+	}
+	return result
+}
+
+func operationIDs(ops []domain.Operation) []domain.OperationID {
+	ids := make([]domain.OperationID, len(ops))
+	for i, op := range ops {
+		ids[i] = op.ID()
+	}
+	return ids
 }
 
 // prepare validates all operations and checks preconditions.
@@ -217,9 +368,7 @@ func (e *Executor) checkLinkCreatePreconditionsWithPending(ctx context.Context,
 			}
 		}
 		if !sourceExists && pendingFiles != nil {
-			if _, ok := pendingFiles[sourceStr]; ok {
-				sourceExists = true
-			}
+			sourceExists = movedInto(sourceStr, pendingFiles)
 		}
 	}
 
@@ -263,6 +412,21 @@ func (e *Executor) checkLinkCreatePreconditionsWithPending(ctx context.Context,
 	return nil
 }
 
+// movedInto reports whether path is the destination of a pending move or
+// lies inside one, as when a whole directory is moved.
+func movedInto(path string, pendingFiles map[string]struct{}) bool {
+	for {
+		if _, ok := pendingFiles[path]; ok {
+			return true
+		}
+		parent := filepath.Dir(path)
+		if parent == path {
+			return false
+		}
+		path = parent
+	}
+}
+
 func (e *Executor) checkDirCreatePreconditions(ctx context.Context, op domain.DirCreate) error {
 	return e.checkDirCreatePreconditionsWithPending(ctx, op, nil)
 }
@@ -364,36 +528,102 @@ func (e *Executor) executeSequential(ctx context.Context, plan domain.Plan, chec
 
 		opID := op.ID()
 
-		ctx, span := e.tracer.Start(ctx, "operation.Execute")
 		e.log.Debug(ctx, "executing_operation",
 			"op_id", opID,
 			"op_kind", op.Kind())
 
-		if err := op.Execute(ctx, e.fs); err != nil {
+		if err := e.executeOperation(ctx, op); err != nil {
 			e.log.Error(ctx, "operation_failed", "op_id", opID, "error", err)
 			result.Failed = append(result.Failed, opID)
 			result.Errors = append(result.Errors, err)
-			span.RecordError(err)
-			span.End()
 			break
 		}
 
 		result.Executed = append(result.Executed, opID)
 		checkpoint.Record(opID, op)
-		span.End()
 	}
 
 	return result
 }
 
-// rollback reverses executed operations in reverse order.
-func (e *Executor) rollback(ctx context.Context, executed []domain.OperationID, checkpoint *Checkpoint) []domain.OperationID {
-	ctx, span := e.tracer.Start(ctx, "executor.Rollback")
+// executeOperation executes op within a span named after its kind,
+// retrying transient failures as the retry policy allows. A failure is
+// returned as a domain.ErrOperationFailed naming op.
+func (e *Executor) executeOperation(ctx context.Context, op domain.Operation) error {
+	ctx, span := e.startOperationSpan(ctx, op, op.Kind().String(), false)
+	defer span.End()
+
+	if err := e.executeWithRetry(ctx, op); err != nil {
+		span.RecordError(err)
+		return domain.ErrOperationFailed{ID: op.ID(), Kind: op.Kind(), Err: err}
+	}
+	return nil
+}
+
+// rollbackOperation rolls back op within a span marked as a rollback. A
+// failure is returned as a domain.ErrRollbackFailed naming op.
+func (e *Executor) rollbackOperation(ctx context.Context, op domain.Operation) error {
+	ctx, span := e.startOperationSpan(ctx, op, op.Kind().String()+".rollback", true)
+	defer span.End()
+
+	if err := op.Rollback(ctx, e.fs); err != nil {
+		span.RecordError(err)
+		return domain.ErrRollbackFailed{ID: op.ID(), Kind: op.Kind(), Err: err}
+	}
+	return nil
+}
+
+// startOperationSpan starts a span for op carrying its ID and the path it
+// changes.
+func (e *Executor) startOperationSpan(ctx context.Context, op domain.Operation, name string, rollback bool) (context.Context, domain.Span) {
+	ctx, span := e.tracer.Start(ctx, name)
+	span.SetAttributes(
+		domain.Attribute{Key: "op_id", Value: string(op.ID())},
+		domain.Attribute{Key: "target_path", Value: operationTarget(op)},
+		domain.Attribute{Key: "rollback", Value: rollback},
+	)
+	return ctx, span
+}
+
+// operationTarget returns the path op creates, changes, or removes, or ""
+// for operation kinds the executor does not know.
+func operationTarget(op domain.Operation) string {
+	switch o := op.(type) {
+	case domain.LinkCreate:
+		return o.Target.String()
+	case domain.LinkDelete:
+		return o.Target.String()
+	case domain.DirCreate:
+		return o.Path.String()
+	case domain.DirDelete:
+		return o.Path.String()
+	case domain.DirRemoveAll:
+		return o.Path.String()
+	case domain.FileMove:
+		return o.Dest.String()
+	case domain.FileBackup:
+		return o.Backup.String()
+	case domain.FileDelete:
+		return o.Path.String()
+	case domain.DirCopy:
+		return o.Dest.String()
+	default:
+		return ""
+	}
+}
+
+// rollback reverses executed operations in reverse order. It returns the
+// operations rolled back and a domain.ErrRollbackFailed for each that could
+// not be.
+func (e *Executor) rollback(ctx context.Context, executed []domain.OperationID, checkpoint *Checkpoint) ([]domain.OperationID, []error) {
+	ctx, span := e.tracer.Start(ctx, "rollback")
 	defer span.End()
+	span.SetAttributes(domain.Attribute{Key: "operation_count", Value: len(executed)})
 
 	e.log.Warn(ctx, "starting_rollback", "operations", len(executed))
 
 	var rolledBack []domain.OperationID
+	var rollbackErrs []error
 
 	// Rollback in reverse order
 	for i := len(executed) - 1; i >= 0; i-- {
@@ -419,8 +649,9 @@ func (e *Executor) rollback(ctx context.Context, executed []domain.OperationID,
 
 		e.log.Debug(ctx, "rolling_back_operation", "op_id", opID, "op_kind", op.Kind())
 
-		if err := op.Rollback(ctx, e.fs); err != nil {
+		if err := e.rollbackOperation(ctx, op); err != nil {
 			e.log.Error(ctx, "rollback_failed", "op_id", opID, "error", err)
+			rollbackErrs = append(rollbackErrs, err)
 			// Continue rolling back other operations
 		} else {
 			rolledBack = append(rolledBack, opID)
@@ -430,8 +661,9 @@ func (e *Executor) rollback(ctx context.Context, executed []domain.OperationID,
 	e.log.Info(ctx, "rollback_complete",
 		"attempted", len(executed),
 		"succeeded", len(rolledBack))
+	span.SetAttributes(domain.Attribute{Key: "rolled_back_count", Value: len(rolledBack)})
 
-	return rolledBack
+	return rolledBack, rollbackErrs
 }
 
 // executeParallel executes operations in parallel batches based on dependencies.
@@ -509,7 +741,7 @@ func (e *Executor) executeBatch(ctx context.Context, batch []domain.Operation, c
 
 		e.log.Debug(ctx, "executing_operation", "op_id", opID, "op_kind", op.Kind())
 
-		if err := op.Execute(ctx, e.fs); err != nil {
+		if err := e.executeOperation(ctx, op); err != nil {
 			e.log.Error(ctx, "operation_failed", "op_id", opID, "error", err)
 			result.Failed = append(result.Failed, opID)
 			result.Errors = append(result.Errors, err)
@@ -551,7 +783,7 @@ func (e *Executor) executeBatch(ctx context.Context, batch []domain.Operation, c
 				"op_id", opID,
 				"op_kind", operation.Kind())
 
-			err := operation.Execute(ctx, e.fs)
+			err := e.executeOperation(ctx, operation)
 			resultCh <- opResult{id: opID, err: err}
 		}(op)
 	}
@@ -201,6 +201,9 @@ func (o *concurrencyTrackingOp) Validate() error                           { ret
 func (o *concurrencyTrackingOp) Dependencies() []domain.Operation          { return nil }
 func (o *concurrencyTrackingOp) Rollback(context.Context, domain.FS) error { return nil }
 func (o *concurrencyTrackingOp) String() string                            { return string(o.id) }
+func (o *concurrencyTrackingOp) DryRunExecute(context.Context, domain.FSReader) (domain.DryRunEffect, error) {
+	return domain.DryRunEffect{OpID: o.id}, nil
+}
 func (o *concurrencyTrackingOp) Equals(other domain.Operation) bool {
 	if other == nil {
 		return false
@@ -103,3 +103,28 @@ func TestPrepare_WithFileMoveThenLinkCreate(t *testing.T) {
 	err := exec.prepare(ctx, plan)
 	require.NoError(t, err)
 }
+
+func TestCheckLinkCreatePreconditions_WithPendingDirectoryMove(t *testing.T) {
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	exec := New(Opts{
+		FS:     fs,
+		Logger: adapters.NewNoopLogger(),
+		Tracer: adapters.NewNoopTracer(),
+	})
+	require.NoError(t, fs.MkdirAll(ctx, "/target", 0755))
+
+	// A directory will be moved to /packages/new, bringing its files along
+	pendingFiles := map[string]struct{}{
+		"/packages/new": {},
+	}
+
+	targetPathResult := domain.NewTargetPath("/target/link.txt")
+	require.True(t, targetPathResult.IsOk())
+	op := domain.NewLinkCreate("link1", domain.MustParsePath("/packages/new/sub/file.txt"), targetPathResult.Unwrap())
+	require.NoError(t, exec.checkLinkCreatePreconditionsWithPending(ctx, op, nil, pendingFiles))
+
+	op = domain.NewLinkCreate("link2", domain.MustParsePath("/packages/newer/file.txt"), targetPathResult.Unwrap())
+	require.Error(t, exec.checkLinkCreatePreconditionsWithPending(ctx, op, nil, pendingFiles),
+		"a sibling sharing the prefix is not inside the moved directory")
+}
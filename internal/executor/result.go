@@ -8,6 +8,31 @@ type ExecutionResult struct {
 	Failed     []domain.OperationID
 	RolledBack []domain.OperationID
 	Errors     []error
+
+	// RollbackErrors holds a domain.ErrRollbackFailed for each executed
+	// operation that could not be rolled back after a failure.
+	RollbackErrors []error
+
+	// Transactions lists each transaction in order when the plan was split
+	// by TransactionSize. It is empty for single-transaction execution.
+	Transactions []TransactionResult
+}
+
+// TransactionResult describes one transaction of a split plan.
+type TransactionResult struct {
+	Operations []domain.OperationID
+	Committed  bool
+}
+
+// CommittedTransactions returns the number of committed transactions.
+func (r ExecutionResult) CommittedTransactions() int {
+	n := 0
+	for _, tx := range r.Transactions {
+		if tx.Committed {
+			n++
+		}
+	}
+	return n
 }
 
 // Success returns true if all operations executed successfully.
@@ -0,0 +1,76 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"syscall"
+	"time"
+
+	"github.com/yaklabco/dot/internal/domain"
+)
+
+// RetryPolicy retries operations that fail with a transient filesystem
+// error, as network filesystems report while a file is briefly locked.
+// The zero value runs each operation once.
+type RetryPolicy struct {
+	// MaxAttempts is the number of times an operation is tried, including
+	// the first. Values below 2 disable retries.
+	MaxAttempts int
+	// BaseBackoff is the wait before the first retry. It doubles for each
+	// retry after that.
+	BaseBackoff time.Duration
+}
+
+// transientErrnos are the errors worth retrying: the call may succeed once
+// whatever held the file lets go. Anything else, such as EACCES or EEXIST,
+// fails the same way every time.
+var transientErrnos = []syscall.Errno{
+	syscall.EAGAIN,
+	syscall.EBUSY,
+	syscall.EINTR,
+	syscall.ETIMEDOUT,
+}
+
+// IsTransient reports whether err is a transient filesystem error that
+// RetryPolicy retries.
+func IsTransient(err error) bool {
+	var errno syscall.Errno
+	if !errors.As(err, &errno) {
+		return false
+	}
+	for _, transient := range transientErrnos {
+		if errno == transient {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff returns the wait before retry number n, counting from 1.
+func (p RetryPolicy) backoff(n int) time.Duration {
+	return p.BaseBackoff << (n - 1)
+}
+
+// executeWithRetry runs op, trying it again after a transient error until
+// the policy's attempts run out. ctx is checked before every retry, so a
+// cancelled run stops waiting and reports the last error.
+func (e *Executor) executeWithRetry(ctx context.Context, op domain.Operation) error {
+	err := op.Execute(ctx, e.fs)
+	for attempt := 1; err != nil && attempt < e.retry.MaxAttempts && IsTransient(err); attempt++ {
+		wait := e.retry.backoff(attempt)
+		e.log.Warn(ctx, "retrying_operation",
+			"op_id", op.ID(), "attempt", attempt+1, "max_attempts", e.retry.MaxAttempts,
+			"backoff", wait, "error", err)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return errors.Join(err, ctx.Err())
+		case <-timer.C:
+		}
+
+		err = op.Execute(ctx, e.fs)
+	}
+	return err
+}
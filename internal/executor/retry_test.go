@@ -0,0 +1,164 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaklabco/dot/internal/adapters"
+	"github.com/yaklabco/dot/internal/domain"
+)
+
+// flakyFS fails Symlink and Rename with err for the first failures calls,
+// then behaves normally.
+type flakyFS struct {
+	domain.FS
+	err      error
+	failures int32
+	calls    atomic.Int32
+}
+
+func (f *flakyFS) fail(op, path string) error {
+	if f.calls.Add(1) <= f.failures {
+		return &os.LinkError{Op: op, Old: path, New: path, Err: f.err}
+	}
+	return nil
+}
+
+func (f *flakyFS) Symlink(ctx context.Context, oldname, newname string) error {
+	if err := f.fail("symlink", newname); err != nil {
+		return err
+	}
+	return f.FS.Symlink(ctx, oldname, newname)
+}
+
+func (f *flakyFS) Rename(ctx context.Context, oldname, newname string) error {
+	if err := f.fail("rename", newname); err != nil {
+		return err
+	}
+	return f.FS.Rename(ctx, oldname, newname)
+}
+
+func newRetryExecutor(fs domain.FS, attempts int) *Executor {
+	return New(Opts{
+		FS:     fs,
+		Logger: adapters.NewNoopLogger(),
+		Tracer: adapters.NewNoopTracer(),
+		Retry:  RetryPolicy{MaxAttempts: attempts, BaseBackoff: time.Millisecond},
+	})
+}
+
+// setupRetryFS returns a MemFS holding /packages/pkg/file and /home,
+// wrapped so the first failures calls fail with err.
+func setupRetryFS(t *testing.T, err error, failures int32) *flakyFS {
+	t.Helper()
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	require.NoError(t, fs.MkdirAll(ctx, "/packages/pkg", 0755))
+	require.NoError(t, fs.MkdirAll(ctx, "/home", 0755))
+	require.NoError(t, fs.WriteFile(ctx, "/packages/pkg/file", []byte("content"), 0644))
+	return &flakyFS{FS: fs, err: err, failures: failures}
+}
+
+func linkPlan() domain.Plan {
+	return domain.Plan{Operations: []domain.Operation{
+		domain.NewLinkCreate("link",
+			domain.MustParsePath("/packages/pkg/file"),
+			domain.MustParseTargetPath("/home/file")),
+	}}
+}
+
+func TestExecute_RetriesTransientErrors(t *testing.T) {
+	ctx := context.Background()
+
+	for _, errno := range []syscall.Errno{syscall.EAGAIN, syscall.EBUSY} {
+		t.Run(errno.Error(), func(t *testing.T) {
+			fs := setupRetryFS(t, errno, 2)
+
+			result := newRetryExecutor(fs, 3).Execute(ctx, linkPlan())
+			require.True(t, result.IsOk(), "%v", result)
+			assert.Equal(t, int32(3), fs.calls.Load(), "two failures, then success")
+			target, err := fs.ReadLink(ctx, "/home/file")
+			require.NoError(t, err)
+			assert.Equal(t, "/packages/pkg/file", target)
+		})
+	}
+
+	t.Run("rename", func(t *testing.T) {
+		fs := setupRetryFS(t, syscall.EBUSY, 1)
+		plan := domain.Plan{Operations: []domain.Operation{
+			domain.NewFileMove("move",
+				domain.MustParseTargetPath("/packages/pkg/file"),
+				domain.MustParsePath("/home/moved")),
+		}}
+
+		result := newRetryExecutor(fs, 2).Execute(ctx, plan)
+		require.True(t, result.IsOk(), "%v", result)
+		assert.True(t, fs.Exists(ctx, "/home/moved"))
+	})
+}
+
+func TestExecute_RetryGivesUpAfterMaxAttempts(t *testing.T) {
+	fs := setupRetryFS(t, syscall.EAGAIN, 5)
+
+	result := newRetryExecutor(fs, 3).Execute(context.Background(), linkPlan())
+	require.True(t, result.IsErr())
+	assert.Equal(t, int32(3), fs.calls.Load())
+	assert.ErrorIs(t, result.UnwrapErr(), syscall.EAGAIN)
+}
+
+func TestExecute_PermanentErrorIsNotRetried(t *testing.T) {
+	fs := setupRetryFS(t, syscall.EACCES, 1)
+
+	result := newRetryExecutor(fs, 5).Execute(context.Background(), linkPlan())
+	require.True(t, result.IsErr())
+	assert.Equal(t, int32(1), fs.calls.Load())
+	assert.ErrorIs(t, result.UnwrapErr(), syscall.EACCES)
+}
+
+func TestExecute_NoRetryByDefault(t *testing.T) {
+	fs := setupRetryFS(t, syscall.EAGAIN, 1)
+
+	result := newRetryExecutor(fs, 0).Execute(context.Background(), linkPlan())
+	require.True(t, result.IsErr())
+	assert.Equal(t, int32(1), fs.calls.Load())
+}
+
+func TestExecute_RetryStopsWhenCancelled(t *testing.T) {
+	fs := setupRetryFS(t, syscall.EBUSY, 10)
+	exec := New(Opts{
+		FS:     fs,
+		Logger: adapters.NewNoopLogger(),
+		Tracer: adapters.NewNoopTracer(),
+		Retry:  RetryPolicy{MaxAttempts: 10, BaseBackoff: time.Hour},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	result := exec.Execute(ctx, linkPlan())
+	require.True(t, result.IsErr())
+	assert.Equal(t, int32(1), fs.calls.Load())
+	assert.True(t, errors.Is(result.UnwrapErr(), context.Canceled))
+}
+
+func TestIsTransient(t *testing.T) {
+	assert.True(t, IsTransient(&os.PathError{Op: "symlink", Path: "/x", Err: syscall.EAGAIN}))
+	assert.True(t, IsTransient(syscall.EINTR))
+	assert.False(t, IsTransient(&os.PathError{Op: "symlink", Path: "/x", Err: syscall.EACCES}))
+	assert.False(t, IsTransient(os.ErrExist))
+	assert.False(t, IsTransient(errors.New("busy")))
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	p := RetryPolicy{BaseBackoff: 10 * time.Millisecond}
+	assert.Equal(t, 10*time.Millisecond, p.backoff(1))
+	assert.Equal(t, 20*time.Millisecond, p.backoff(2))
+	assert.Equal(t, 40*time.Millisecond, p.backoff(3))
+}
@@ -2,8 +2,10 @@ package executor
 
 import (
 	"context"
+	"errors"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/yaklabco/dot/internal/adapters"
 	"github.com/yaklabco/dot/internal/domain"
@@ -32,8 +34,9 @@ func TestRollback_SingleOperation(t *testing.T) {
 	checkpoint.Record("link1", op)
 
 	// Rollback
-	rolledBack := exec.rollback(ctx, []domain.OperationID{"link1"}, checkpoint)
+	rolledBack, rollbackErrs := exec.rollback(ctx, []domain.OperationID{"link1"}, checkpoint)
 
+	require.Empty(t, rollbackErrs)
 	require.Len(t, rolledBack, 1)
 	require.Contains(t, rolledBack, domain.OperationID("link1"))
 
@@ -72,8 +75,9 @@ func TestRollback_ReverseOrder(t *testing.T) {
 
 	// Rollback should happen in reverse order: link first, then dir
 	executed := []domain.OperationID{"dir1", "link1"}
-	rolledBack := exec.rollback(ctx, executed, checkpoint)
+	rolledBack, rollbackErrs := exec.rollback(ctx, executed, checkpoint)
 
+	require.Empty(t, rollbackErrs)
 	require.Len(t, rolledBack, 2)
 
 	// Verify both were removed
@@ -112,11 +116,16 @@ func TestRollback_PartialRollbackOnError(t *testing.T) {
 
 	// Rollback both - first should succeed, second should fail (doesn't exist)
 	executed := []domain.OperationID{"link1", "link2"}
-	rolledBack := exec.rollback(ctx, executed, checkpoint)
+	rolledBack, rollbackErrs := exec.rollback(ctx, executed, checkpoint)
 
 	// Should have rolled back link1 even though link2 failed
 	require.Len(t, rolledBack, 1)
 	require.Contains(t, rolledBack, domain.OperationID("link1"))
+	require.Len(t, rollbackErrs, 1)
+	var rbErr domain.ErrRollbackFailed
+	require.ErrorAs(t, rollbackErrs[0], &rbErr)
+	require.Equal(t, domain.OperationID("link2"), rbErr.ID)
+	require.Equal(t, domain.OpKindLinkCreate, rbErr.Kind)
 	require.False(t, fs.Exists(ctx, target1.String()), "link1 should be removed")
 }
 
@@ -154,10 +163,66 @@ func TestExecute_AutomaticRollback(t *testing.T) {
 	require.Len(t, execResult.Failed, 1, "second operation should fail")
 
 	// Now rollback
-	rolledBack := exec.rollback(ctx, execResult.Executed, checkpoint)
+	rolledBack, rollbackErrs := exec.rollback(ctx, execResult.Executed, checkpoint)
+	require.Empty(t, rollbackErrs)
 	require.Len(t, rolledBack, 1, "first operation should be rolled back")
 
 	// Verify first operation was rolled back
 	exists := fs.Exists(ctx, target1.String())
 	require.False(t, exists, "rolled back operation should be undone")
 }
+
+// removeFailFS fails Remove calls for one path, so rolling back the
+// operation that created it fails.
+type removeFailFS struct {
+	domain.FS
+	failPath string
+}
+
+func (f removeFailFS) Remove(ctx context.Context, name string) error {
+	if name == f.failPath {
+		return errors.New("remove failed")
+	}
+	return f.FS.Remove(ctx, name)
+}
+
+func TestExecute_FailureAggregatesOperationAndRollbackErrors(t *testing.T) {
+	ctx := context.Background()
+	// link2 fails to execute; rolling back link1 then fails too
+	fs, plan := setupLinkPlan(t, 4, 2)
+	exec := New(Opts{
+		FS:     removeFailFS{FS: fs, failPath: "/home/file1"},
+		Logger: adapters.NewNoopLogger(),
+		Tracer: adapters.NewNoopTracer(),
+	})
+
+	result := exec.Execute(ctx, plan)
+	require.False(t, result.IsOk())
+
+	var execErr domain.ErrExecutionFailed
+	require.ErrorAs(t, result.UnwrapErr(), &execErr)
+	assert.Equal(t, 2, execErr.Executed)
+	assert.Equal(t, 1, execErr.Failed)
+	assert.Equal(t, 1, execErr.RolledBack)
+	assert.Equal(t, 1, execErr.RollbackFailed)
+	require.Len(t, execErr.Errors, 2)
+
+	// The triggering failure comes first, naming the operation
+	var opErr domain.ErrOperationFailed
+	require.ErrorAs(t, execErr.Errors[0], &opErr)
+	assert.Equal(t, domain.OperationID("link2"), opErr.ID)
+	assert.Equal(t, domain.OpKindLinkCreate, opErr.Kind)
+	assert.EqualError(t, opErr.Err, "symlink failed")
+
+	// Followed by the operation that could not be rolled back
+	var rbErr domain.ErrRollbackFailed
+	require.ErrorAs(t, execErr.Errors[1], &rbErr)
+	assert.Equal(t, domain.OperationID("link1"), rbErr.ID)
+	assert.Equal(t, domain.OpKindLinkCreate, rbErr.Kind)
+	assert.EqualError(t, rbErr.Err, "remove failed")
+
+	msg := domain.UserFacingError(execErr)
+	assert.Contains(t, msg, "2 operations succeeded, 1 failed")
+	assert.Contains(t, msg, "Rolled back 1 operations")
+	assert.Contains(t, msg, "1 operations could not be rolled back")
+}
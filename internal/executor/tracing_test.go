@@ -0,0 +1,102 @@
+package executor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yaklabco/dot/internal/adapters"
+	"github.com/yaklabco/dot/internal/domain"
+	"github.com/yaklabco/dot/internal/domain/tracetest"
+)
+
+func TestExecute_TracesOperations(t *testing.T) {
+	for _, parallel := range []bool{false, true} {
+		t.Run(map[bool]string{false: "sequential", true: "parallel"}[parallel], func(t *testing.T) {
+			ctx := context.Background()
+			fs := adapters.NewMemFS()
+			require.NoError(t, fs.MkdirAll(ctx, "/packages/pkg", 0755))
+			require.NoError(t, fs.MkdirAll(ctx, "/home", 0755))
+			require.NoError(t, fs.WriteFile(ctx, "/packages/pkg/vimrc", []byte("vimrc"), 0644))
+
+			tracer := &tracetest.Tracer{}
+			exec := New(Opts{FS: fs, Logger: adapters.NewNoopLogger(), Tracer: tracer})
+
+			dir := domain.NewDirCreate("dir1", domain.MustParsePath("/home/.config"))
+			link := domain.NewLinkCreate("link1", domain.MustParsePath("/packages/pkg/vimrc"), domain.MustParseTargetPath("/home/.vimrc"))
+			plan := domain.Plan{Operations: []domain.Operation{dir, link}}
+			if parallel {
+				plan.Batches = [][]domain.Operation{{dir, link}}
+			}
+
+			result := exec.Execute(ctx, plan)
+			require.True(t, result.IsOk())
+
+			root := tracer.Find("execute")
+			require.NotNil(t, root)
+
+			dirSpan := tracer.Find("DirCreate")
+			require.NotNil(t, dirSpan)
+			assert.Same(t, root, dirSpan.Parent)
+			assert.Equal(t, "dir1", dirSpan.Attrs["op_id"])
+			assert.Equal(t, "/home/.config", dirSpan.Attrs["target_path"])
+			assert.Equal(t, false, dirSpan.Attrs["rollback"])
+			assert.True(t, dirSpan.Ended)
+
+			linkSpan := tracer.Find("LinkCreate")
+			require.NotNil(t, linkSpan)
+			assert.Same(t, root, linkSpan.Parent)
+			assert.Equal(t, "link1", linkSpan.Attrs["op_id"])
+			assert.Equal(t, "/home/.vimrc", linkSpan.Attrs["target_path"])
+			assert.True(t, linkSpan.Ended)
+			assert.Empty(t, linkSpan.Errs)
+		})
+	}
+}
+
+func TestExecute_TracesRollback(t *testing.T) {
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	require.NoError(t, fs.MkdirAll(ctx, "/packages/pkg", 0755))
+	require.NoError(t, fs.MkdirAll(ctx, "/home", 0755))
+	require.NoError(t, fs.WriteFile(ctx, "/packages/pkg/vimrc", []byte("vimrc"), 0644))
+	require.NoError(t, fs.WriteFile(ctx, "/packages/pkg/zshrc", []byte("zshrc"), 0644))
+
+	tracer := &tracetest.Tracer{}
+	exec := New(Opts{FS: fs, Logger: adapters.NewNoopLogger(), Tracer: tracer})
+
+	// The second link fails because its parent directory does not exist;
+	// commit skips the prepare checks that would catch it.
+	plan := domain.Plan{Operations: []domain.Operation{
+		domain.NewLinkCreate("link1", domain.MustParsePath("/packages/pkg/vimrc"), domain.MustParseTargetPath("/home/.vimrc")),
+		domain.NewLinkCreate("link2", domain.MustParsePath("/packages/pkg/zshrc"), domain.MustParseTargetPath("/nonexistent/.zshrc")),
+	}}
+	ctx, root := tracer.Start(ctx, "execute")
+	_, err := exec.commit(ctx, plan)
+	root.End()
+	require.Error(t, err)
+
+	rollback := tracer.Find("rollback")
+	require.NotNil(t, rollback)
+	assert.Same(t, root, rollback.Parent)
+
+	opRollback := tracer.Find("LinkCreate.rollback")
+	require.NotNil(t, opRollback)
+	assert.Same(t, rollback, opRollback.Parent, "operation rollback spans are children of the rollback span")
+	assert.Equal(t, "link1", opRollback.Attrs["op_id"])
+	assert.Equal(t, "/home/.vimrc", opRollback.Attrs["target_path"])
+	assert.Equal(t, true, opRollback.Attrs["rollback"])
+	assert.True(t, opRollback.Ended)
+
+	var failed *tracetest.Span
+	for _, span := range tracer.Spans() {
+		if span.Name == "LinkCreate" && span.Attrs["op_id"] == "link2" {
+			failed = span
+		}
+	}
+	require.NotNil(t, failed)
+	assert.Same(t, root, failed.Parent)
+	assert.Len(t, failed.Errs, 1, "the failing operation's span records its error")
+}
@@ -0,0 +1,152 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaklabco/dot/internal/adapters"
+	"github.com/yaklabco/dot/internal/domain"
+)
+
+// symlinkFailFS fails Symlink calls for one target path, so an operation
+// passes prepare but fails during execution.
+type symlinkFailFS struct {
+	domain.FS
+	failTarget string
+}
+
+func (f symlinkFailFS) Symlink(ctx context.Context, oldname, newname string) error {
+	if newname == f.failTarget {
+		return errors.New("symlink failed")
+	}
+	return f.FS.Symlink(ctx, oldname, newname)
+}
+
+// setupLinkPlan creates n source files and a plan linking each into /home.
+// It returns an FS on which the operation at index failAt fails.
+func setupLinkPlan(t *testing.T, n, failAt int) (domain.FS, domain.Plan) {
+	t.Helper()
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	require.NoError(t, fs.MkdirAll(ctx, "/packages/pkg", 0755))
+	require.NoError(t, fs.MkdirAll(ctx, "/home", 0755))
+
+	ops := make([]domain.Operation, 0, n)
+	for i := 0; i < n; i++ {
+		source := domain.MustParsePath(fmt.Sprintf("/packages/pkg/file%d", i))
+		target := domain.MustParseTargetPath(fmt.Sprintf("/home/file%d", i))
+		require.NoError(t, fs.WriteFile(ctx, source.String(), []byte("content"), 0644))
+		ops = append(ops, domain.NewLinkCreate(domain.OperationID(fmt.Sprintf("link%d", i)), source, target))
+	}
+	failTarget := fmt.Sprintf("/home/file%d", failAt)
+	return symlinkFailFS{FS: fs, failTarget: failTarget}, domain.Plan{Operations: ops}
+}
+
+func newTransactionExecutor(fs domain.FS, size int) *Executor {
+	return New(Opts{
+		FS:              fs,
+		Logger:          adapters.NewNoopLogger(),
+		Tracer:          adapters.NewNoopTracer(),
+		TransactionSize: size,
+	})
+}
+
+func TestExecute_Transactions_Success(t *testing.T) {
+	ctx := context.Background()
+	fs, plan := setupLinkPlan(t, 5, -1)
+
+	result := newTransactionExecutor(fs, 2).Execute(ctx, plan)
+	require.True(t, result.IsOk(), "execution should succeed")
+
+	res := result.Unwrap()
+	assert.Len(t, res.Executed, 5)
+	require.Len(t, res.Transactions, 3)
+	assert.Equal(t, []domain.OperationID{"link0", "link1"}, res.Transactions[0].Operations)
+	assert.Equal(t, []domain.OperationID{"link4"}, res.Transactions[2].Operations)
+	assert.Equal(t, 3, res.CommittedTransactions())
+}
+
+func TestExecute_Transactions_FailureKeepsCommitted(t *testing.T) {
+	ctx := context.Background()
+	// link3 fails in the second transaction (link2, link3)
+	fs, plan := setupLinkPlan(t, 5, 3)
+
+	result := newTransactionExecutor(fs, 2).Execute(ctx, plan)
+	require.True(t, result.IsErr(), "execution should fail")
+
+	var execFailed domain.ErrExecutionFailed
+	require.True(t, errors.As(result.UnwrapErr(), &execFailed))
+	assert.Equal(t, 3, execFailed.Transactions)
+	assert.Equal(t, 1, execFailed.CommittedTransactions)
+	assert.Equal(t, 3, execFailed.Executed)
+	assert.Equal(t, 1, execFailed.Failed)
+	assert.Equal(t, 1, execFailed.RolledBack)
+	assert.Equal(t, []domain.OperationID{plan.Operations[0].ID(), plan.Operations[1].ID()}, execFailed.Committed)
+
+	// First transaction is kept
+	for _, path := range []string{"/home/file0", "/home/file1"} {
+		isLink, err := fs.IsSymlink(ctx, path)
+		require.NoError(t, err)
+		assert.True(t, isLink, "%s should remain linked", path)
+	}
+	// Failing transaction is rolled back and later ones never run
+	assert.False(t, fs.Exists(ctx, "/home/file2"), "link2 should be rolled back")
+	assert.False(t, fs.Exists(ctx, "/home/file4"), "link4 should not run")
+}
+
+func TestExecute_Transactions_DefaultIsAllOrNothing(t *testing.T) {
+	ctx := context.Background()
+	fs, plan := setupLinkPlan(t, 5, 3)
+
+	result := newTransactionExecutor(fs, 0).Execute(ctx, plan)
+	require.True(t, result.IsErr(), "execution should fail")
+
+	var execFailed domain.ErrExecutionFailed
+	require.True(t, errors.As(result.UnwrapErr(), &execFailed))
+	assert.Zero(t, execFailed.Transactions)
+	assert.NotContains(t, execFailed.Error(), "transactions committed")
+	assert.False(t, fs.Exists(ctx, "/home/file0"), "link0 should be rolled back")
+}
+
+func TestSplitPlan(t *testing.T) {
+	op := func(id string) domain.Operation {
+		return domain.NewDirCreate(domain.OperationID(id), domain.MustParsePath("/home/"+id))
+	}
+	a, b, c, d, e := op("a"), op("b"), op("c"), op("d"), op("e")
+	plan := domain.Plan{
+		Operations: []domain.Operation{a, b, c, d, e},
+		Batches:    [][]domain.Operation{{a, b, c}, {d}, {e}},
+	}
+
+	parts := splitPlan(plan, 2)
+	require.Len(t, parts, 3)
+
+	tests := []struct {
+		ops     []domain.OperationID
+		batches [][]domain.OperationID
+	}{
+		{[]domain.OperationID{"a", "b"}, [][]domain.OperationID{{"a", "b"}}},
+		{[]domain.OperationID{"c", "d"}, [][]domain.OperationID{{"c"}, {"d"}}},
+		{[]domain.OperationID{"e"}, [][]domain.OperationID{{"e"}}},
+	}
+	for i, tt := range tests {
+		assert.Equal(t, tt.ops, operationIDs(parts[i].Operations), "part %d operations", i)
+		var batches [][]domain.OperationID
+		for _, batch := range parts[i].Batches {
+			batches = append(batches, operationIDs(batch))
+		}
+		assert.Equal(t, tt.batches, batches, "part %d batches", i)
+	}
+}
+
+func TestSplitPlan_NoBatches(t *testing.T) {
+	op := domain.NewDirCreate("a", domain.MustParsePath("/home/a"))
+	parts := splitPlan(domain.Plan{Operations: []domain.Operation{op, op, op}}, 2)
+	require.Len(t, parts, 2)
+	assert.Nil(t, parts[0].Batches)
+	assert.Len(t, parts[1].Operations, 1)
+}
@@ -1,5 +1,11 @@
 package ignore
 
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
 // IgnoreSet is a collection of patterns for ignoring files.
 type IgnoreSet struct {
 	patterns []*Pattern
@@ -23,6 +29,29 @@ func NewDefaultIgnoreSet() *IgnoreSet {
 	return set
 }
 
+// NewIgnoreSetFromConfig creates an ignore set from configured patterns.
+// Default patterns, when enabled, come first so user patterns, including
+// negations, can override them.
+func NewIgnoreSetFromConfig(useDefaults bool, patterns []string) (*IgnoreSet, error) {
+	set := NewIgnoreSet()
+
+	if useDefaults {
+		for _, pattern := range DefaultIgnorePatterns() {
+			if err := set.Add(pattern); err != nil {
+				return nil, fmt.Errorf("add default pattern %q: %w", pattern, err)
+			}
+		}
+	}
+
+	for _, pattern := range patterns {
+		if err := set.Add(pattern); err != nil {
+			return nil, fmt.Errorf("add ignore pattern %q: %w", pattern, err)
+		}
+	}
+
+	return set, nil
+}
+
 // Add adds a glob pattern to the ignore set.
 func (s *IgnoreSet) Add(glob string) error {
 	result := NewPattern(glob)
@@ -34,38 +63,41 @@ func (s *IgnoreSet) Add(glob string) error {
 	return nil
 }
 
+// AddScoped adds a glob pattern that only applies beneath root.
+// Used for per-package .dotignore patterns.
+func (s *IgnoreSet) AddScoped(glob, root string) error {
+	result := NewScopedPattern(glob, root)
+	if result.IsErr() {
+		return result.UnwrapErr()
+	}
+
+	s.patterns = append(s.patterns, result.Unwrap())
+	return nil
+}
+
 // AddPattern adds a compiled pattern to the ignore set.
 func (s *IgnoreSet) AddPattern(pattern *Pattern) {
 	s.patterns = append(s.patterns, pattern)
 }
 
 // ShouldIgnore checks if a path should be ignored.
-// Returns true if the path matches any pattern and is not un-ignored by a negation pattern.
+//
+// Patterns are evaluated with gitignore semantics: the last pattern that
+// matches decides. A normal pattern ignores the path and a negation pattern
+// (starting with !) re-includes it, so "*.log", "!important.log" keeps
+// important.log, and a further "*.log" would ignore it again. A path no
+// pattern matches is not ignored.
 //
 // The function checks both full path match and basename match
 // to support patterns like ".DS_Store" matching anywhere in the tree.
-// Patterns are processed in order, with later patterns overriding earlier ones.
-// Negation patterns (starting with !) un-ignore previously ignored files.
 func (s *IgnoreSet) ShouldIgnore(path string) bool {
-	ignored := false
-
-	// Process patterns in order
-	for _, pattern := range s.patterns {
-		// Check if pattern matches (full path or basename)
-		matches := pattern.Match(path) || pattern.MatchBasename(path)
-
-		if matches {
-			if pattern.IsNegation() {
-				// Negation pattern un-ignores
-				ignored = false
-			} else {
-				// Normal pattern ignores
-				ignored = true
-			}
+	for i := len(s.patterns) - 1; i >= 0; i-- {
+		pattern := s.patterns[i]
+		if pattern.Match(path) || pattern.MatchBasename(path) {
+			return !pattern.IsNegation()
 		}
 	}
-
-	return ignored
+	return false
 }
 
 // Size returns the number of patterns in the set.
@@ -79,6 +111,17 @@ func (s *IgnoreSet) Patterns() []*Pattern {
 	return s.patterns
 }
 
+// Fingerprint returns a digest of the patterns in the set, in order. Sets
+// that ignore the same paths the same way share a fingerprint, so results
+// computed with a set can be invalidated when its patterns change.
+func (s *IgnoreSet) Fingerprint() string {
+	h := sha256.New()
+	for _, pattern := range s.patterns {
+		fmt.Fprintf(h, "%d\x00%s\x00%s\n", pattern.typ, pattern.root, pattern.regex.String())
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
 // DefaultIgnorePatterns returns the default set of patterns to ignore.
 // These are common files that should not be managed.
 func DefaultIgnorePatterns() []string {
@@ -99,6 +142,9 @@ func DefaultIgnorePatterns() []string {
 		// Dot metadata
 		".dotignore",
 		".dotbootstrap.yaml",
+		".dotbootstrap.yml",
+		".dotbootstrap.toml",
+		".dotbootstrap.json",
 
 		// Security-sensitive directories and files
 		".gnupg",          // GPG keyring
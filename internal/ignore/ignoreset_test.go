@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/yaklabco/dot/internal/ignore"
 )
 
@@ -413,3 +414,118 @@ func TestDefaultIgnoreSet_SecuritySensitiveFiles(t *testing.T) {
 		})
 	}
 }
+
+func TestIgnoreSet_AddScoped(t *testing.T) {
+	set := ignore.NewIgnoreSet()
+	assert.NoError(t, set.Add("*.log"))
+	assert.NoError(t, set.AddScoped("!keep.log", "/dotfiles/vim"))
+	assert.NoError(t, set.AddScoped("scratch.txt", "/dotfiles/vim"))
+
+	assert.True(t, set.ShouldIgnore("/dotfiles/vim/debug.log"))
+	assert.False(t, set.ShouldIgnore("/dotfiles/vim/keep.log"), "scoped negation overrides global pattern")
+	assert.True(t, set.ShouldIgnore("/dotfiles/zsh/keep.log"), "scoped negation does not leak to other roots")
+	assert.True(t, set.ShouldIgnore("/dotfiles/vim/scratch.txt"))
+	assert.False(t, set.ShouldIgnore("/dotfiles/zsh/scratch.txt"))
+}
+
+func TestIgnoreSet_LastMatchWins(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		expected map[string]bool
+	}{
+		{
+			name:     "re-include after exclude",
+			patterns: []string{"*.log", "!important.log"},
+			expected: map[string]bool{"important.log": false, "debug.log": true},
+		},
+		{
+			name:     "re-exclude after re-include",
+			patterns: []string{"*.log", "!important.log", "*.log"},
+			expected: map[string]bool{"important.log": true, "debug.log": true},
+		},
+		{
+			name:     "re-include again after re-exclude",
+			patterns: []string{"*.log", "!important.log", "*.log", "!important.log"},
+			expected: map[string]bool{"important.log": false, "debug.log": true},
+		},
+		{
+			name:     "narrow re-exclude inside broad re-include",
+			patterns: []string{"*", "!*.conf", "secret.conf"},
+			expected: map[string]bool{"app.conf": false, "secret.conf": true, "notes.txt": true},
+		},
+		{
+			name:     "later non-matching pattern does not change result",
+			patterns: []string{"*.log", "!important.log", "*.tmp"},
+			expected: map[string]bool{"important.log": false, "scratch.tmp": true},
+		},
+		{
+			name:     "absolute paths match by basename",
+			patterns: []string{"*.log", "!important.log", "important.*"},
+			expected: map[string]bool{"/pkg/app/important.log": true, "/pkg/app/debug.log": true, "/pkg/app/readme": false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set := ignore.NewIgnoreSet()
+			for _, pattern := range tt.patterns {
+				require.NoError(t, set.Add(pattern))
+			}
+			for path, want := range tt.expected {
+				assert.Equal(t, want, set.ShouldIgnore(path), "ShouldIgnore(%q)", path)
+			}
+		})
+	}
+}
+
+func TestNewIgnoreSetFromConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		useDefaults bool
+		patterns    []string
+		expected    map[string]bool
+	}{
+		{
+			name:        "defaults apply",
+			useDefaults: true,
+			expected:    map[string]bool{".DS_Store": true, "vimrc": false},
+		},
+		{
+			name:        "user negation overrides default",
+			useDefaults: true,
+			patterns:    []string{"!.DS_Store"},
+			expected:    map[string]bool{".DS_Store": false, ".git": true},
+		},
+		{
+			name:        "user pattern re-excludes after negation",
+			useDefaults: true,
+			patterns:    []string{"!.DS_Store", ".DS_Store"},
+			expected:    map[string]bool{".DS_Store": true},
+		},
+		{
+			name:     "defaults disabled",
+			patterns: []string{"*.log"},
+			expected: map[string]bool{".DS_Store": false, "debug.log": true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set, err := ignore.NewIgnoreSetFromConfig(tt.useDefaults, tt.patterns)
+			require.NoError(t, err)
+			for path, want := range tt.expected {
+				assert.Equal(t, want, set.ShouldIgnore(path), "ShouldIgnore(%q)", path)
+			}
+		})
+	}
+}
+
+func TestNewIgnoreSetFromConfig_DefaultsFirst(t *testing.T) {
+	set, err := ignore.NewIgnoreSetFromConfig(true, []string{"*.log"})
+	require.NoError(t, err)
+
+	patterns := set.Patterns()
+	require.Len(t, patterns, len(ignore.DefaultIgnorePatterns())+1)
+	assert.Equal(t, "*.log", patterns[len(patterns)-1].String())
+}
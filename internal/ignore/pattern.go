@@ -25,6 +25,7 @@ type Pattern struct {
 	original string
 	regex    *regexp.Regexp
 	typ      PatternType
+	root     string
 }
 
 // NewPattern creates a pattern from a glob pattern.
@@ -53,6 +54,19 @@ func NewPattern(glob string) domain.Result[*Pattern] {
 	})
 }
 
+// NewScopedPattern creates a pattern that only applies beneath root.
+// Paths are matched relative to root, so a pattern such as "logs/*.log"
+// in a package's .dotignore matches files in that package's logs directory.
+func NewScopedPattern(glob, root string) domain.Result[*Pattern] {
+	result := NewPattern(glob)
+	if result.IsErr() {
+		return result
+	}
+	pattern := result.Unwrap()
+	pattern.root = filepath.Clean(root)
+	return domain.Ok(pattern)
+}
+
 // NewPatternFromRegex creates a pattern from a regex string.
 func NewPatternFromRegex(regex string) domain.Result[*Pattern] {
 	compiled, err := regexp.Compile(regex)
@@ -68,17 +82,39 @@ func NewPatternFromRegex(regex string) domain.Result[*Pattern] {
 }
 
 // Match checks if the path matches the pattern.
+// Scoped patterns match the path relative to their root and never match
+// paths outside it.
 func (p *Pattern) Match(path string) bool {
-	return p.regex.MatchString(path)
+	rel, ok := p.scopedPath(path)
+	if !ok {
+		return false
+	}
+	return p.regex.MatchString(rel)
 }
 
 // MatchBasename checks if the basename of the path matches the pattern.
 // Useful for patterns like ".DS_Store" that should match anywhere in tree.
 func (p *Pattern) MatchBasename(path string) bool {
+	if _, ok := p.scopedPath(path); !ok {
+		return false
+	}
 	basename := filepath.Base(path)
 	return p.regex.MatchString(basename)
 }
 
+// scopedPath returns path relative to the pattern's root, or path unchanged
+// for unscoped patterns. Reports false if path lies outside the root.
+func (p *Pattern) scopedPath(path string) (string, bool) {
+	if p.root == "" {
+		return path, true
+	}
+	rel, err := filepath.Rel(p.root, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return rel, true
+}
+
 // String returns the original pattern string.
 func (p *Pattern) String() string {
 	return p.original
@@ -280,3 +280,32 @@ func TestPattern_NegationMatching(t *testing.T) {
 		})
 	}
 }
+
+func TestScopedPattern_Match(t *testing.T) {
+	pattern := ignore.NewScopedPattern("logs/*.log", "/dotfiles/vim").Unwrap()
+
+	tests := []struct {
+		name  string
+		path  string
+		match bool
+	}{
+		{"relative path inside root", "/dotfiles/vim/logs/debug.log", true},
+		{"different directory inside root", "/dotfiles/vim/other/debug.log", false},
+		{"same relative path in another package", "/dotfiles/zsh/logs/debug.log", false},
+		{"sibling with shared prefix", "/dotfiles/vim2/logs/debug.log", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.match, pattern.Match(tt.path))
+		})
+	}
+}
+
+func TestScopedPattern_MatchBasename(t *testing.T) {
+	pattern := ignore.NewScopedPattern("scratch.txt", "/dotfiles/vim").Unwrap()
+
+	assert.True(t, pattern.MatchBasename("/dotfiles/vim/scratch.txt"))
+	assert.True(t, pattern.MatchBasename("/dotfiles/vim/nested/scratch.txt"))
+	assert.False(t, pattern.MatchBasename("/dotfiles/zsh/scratch.txt"))
+}
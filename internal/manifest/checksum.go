@@ -0,0 +1,57 @@
+package manifest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+
+// checksumField is the JSON key holding the manifest checksum.
+const checksumField = "checksum"
+
+// Checksum returns the SHA-256 content hash of serialized manifest data,
+// excluding the checksum field itself.
+//
+// The data is decoded and re-encoded before hashing; encoding/json writes
+// object keys in sorted order, so the result does not depend on map
+// iteration order, key order in the file, or whitespace.
+func Checksum(data []byte) (string, error) {
+	var doc map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return "", fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	delete(doc, checksumField)
+
+	canonical, err := json.Marshal(doc)
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize manifest: %w", err)
+	}
+
+	sum := sha256.Sum256(canonical)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// VerifyChecksum reports whether serialized manifest data matches its stored
+// checksum. Manifests written before checksums were introduced carry none
+// and are reported as valid; they gain one on their next save.
+func VerifyChecksum(data []byte) (bool, error) {
+	var stored struct {
+		Checksum string `json:"checksum"`
+	}
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return false, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	if stored.Checksum == "" {
+		return true, nil
+	}
+
+	actual, err := Checksum(data)
+	if err != nil {
+		return false, err
+	}
+	return actual == stored.Checksum, nil
+}
@@ -0,0 +1,87 @@
+package manifest
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChecksum_IndependentOfKeyOrderAndWhitespace(t *testing.T) {
+	a := `{"version":"1.0","packages":{"vim":{"name":"vim"},"zsh":{"name":"zsh"}},"hashes":{"b":"2","a":"1"}}`
+	b := `{
+  "hashes": {"a": "1", "b": "2"},
+  "packages": {"zsh": {"name": "zsh"}, "vim": {"name": "vim"}},
+  "version": "1.0"
+}`
+
+	sumA, err := Checksum([]byte(a))
+	require.NoError(t, err)
+	sumB, err := Checksum([]byte(b))
+	require.NoError(t, err)
+
+	assert.Equal(t, sumA, sumB)
+	assert.Len(t, sumA, 64)
+}
+
+func TestChecksum_ExcludesChecksumField(t *testing.T) {
+	without, err := Checksum([]byte(`{"version":"1.0"}`))
+	require.NoError(t, err)
+	with, err := Checksum([]byte(`{"version":"1.0","checksum":"anything"}`))
+	require.NoError(t, err)
+
+	assert.Equal(t, without, with)
+}
+
+func TestChecksum_StableAcrossMapIteration(t *testing.T) {
+	m := New()
+	for _, name := range []string{"vim", "zsh", "git", "tmux", "bash", "fish", "emacs", "ssh"} {
+		m.AddPackage(PackageInfo{Name: name, Links: []string{"." + name + "rc"}})
+		m.SetHash(name, strings.Repeat(name, 2))
+	}
+
+	first := ""
+	for i := 0; i < 20; i++ {
+		data, err := json.Marshal(m)
+		require.NoError(t, err)
+		sum, err := Checksum(data)
+		require.NoError(t, err)
+		if i == 0 {
+			first = sum
+		}
+		assert.Equal(t, first, sum)
+	}
+}
+
+func TestChecksum_InvalidJSON(t *testing.T) {
+	_, err := Checksum([]byte(`{"version":`))
+	require.Error(t, err)
+}
+
+func TestVerifyChecksum(t *testing.T) {
+	sum, err := Checksum([]byte(`{"version":"1.0","packages":{}}`))
+	require.NoError(t, err)
+	signed := `{"version":"1.0","packages":{},"checksum":"` + sum + `"}`
+
+	tests := []struct {
+		name  string
+		data  string
+		valid bool
+	}{
+		{name: "matching checksum", data: signed, valid: true},
+		{name: "no checksum", data: `{"version":"1.0"}`, valid: true},
+		{name: "mutated content", data: strings.Replace(signed, `"1.0"`, `"1.1"`, 1), valid: false},
+		{name: "added field", data: strings.Replace(signed, `"packages":{}`, `"packages":{"vim":{}}`, 1), valid: false},
+		{name: "wrong checksum", data: `{"version":"1.0","packages":{},"checksum":"deadbeef"}`, valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valid, err := VerifyChecksum([]byte(tt.data))
+			require.NoError(t, err)
+			assert.Equal(t, tt.valid, valid)
+		})
+	}
+}
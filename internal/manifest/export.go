@@ -0,0 +1,71 @@
+package manifest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// ExportFormat identifies a document produced by WriteExport.
+const ExportFormat = "dot-manifest-export"
+
+// ExportFormatVersion is the current version of the export document.
+// Increment it when the document layout changes incompatibly.
+const ExportFormatVersion = 1
+
+// ExportDocument wraps a manifest for backup and transfer between machines.
+type ExportDocument struct {
+	Format        string    `json:"format"`
+	FormatVersion int       `json:"format_version"`
+	ExportedAt    time.Time `json:"exported_at"`
+	Manifest      Manifest  `json:"manifest"`
+}
+
+// WriteExport writes m to w as an indented export document.
+func WriteExport(w io.Writer, m Manifest) error {
+	doc := ExportDocument{
+		Format:        ExportFormat,
+		FormatVersion: ExportFormatVersion,
+		ExportedAt:    time.Now().UTC(),
+		Manifest:      m,
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return fmt.Errorf("failed to write manifest export: %w", err)
+	}
+	return nil
+}
+
+// ReadExport reads an export document from r and returns its manifest.
+// Returns an error if the document is not a manifest export or was written
+// by a newer, unsupported format version.
+func ReadExport(r io.Reader) (Manifest, error) {
+	var doc ExportDocument
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&doc); err != nil {
+		return Manifest{}, fmt.Errorf("failed to parse manifest export: %w", err)
+	}
+
+	if doc.Format != ExportFormat {
+		return Manifest{}, fmt.Errorf("not a manifest export: format %q", doc.Format)
+	}
+	if doc.FormatVersion < 1 || doc.FormatVersion > ExportFormatVersion {
+		return Manifest{}, fmt.Errorf("unsupported manifest export version %d", doc.FormatVersion)
+	}
+
+	m := doc.Manifest
+	if m.Version == "" {
+		m.Version = New().Version
+	}
+	if m.Packages == nil {
+		m.Packages = make(map[string]PackageInfo)
+	}
+	if m.Hashes == nil {
+		m.Hashes = make(map[string]string)
+	}
+	return m, nil
+}
@@ -0,0 +1,79 @@
+package manifest
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteExport_ReadExport_RoundTrip(t *testing.T) {
+	m := New()
+	m.AddPackage(PackageInfo{Name: "vim", LinkCount: 1, Links: []string{".vimrc"}, Source: SourceManaged})
+	m.SetHash("vim", "abc123")
+	m.AddIgnoredPattern("*.bak")
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteExport(&buf, m))
+	assert.Contains(t, buf.String(), `"format": "dot-manifest-export"`)
+
+	imported, err := ReadExport(&buf)
+	require.NoError(t, err)
+
+	pkg, ok := imported.GetPackage("vim")
+	require.True(t, ok)
+	assert.Equal(t, []string{".vimrc"}, pkg.Links)
+	assert.Equal(t, "abc123", imported.Hashes["vim"])
+	require.NotNil(t, imported.Doctor)
+	assert.Equal(t, []string{"*.bak"}, imported.Doctor.IgnoredPatterns)
+}
+
+func TestReadExport_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{
+			name:    "malformed json",
+			input:   `{"format":`,
+			wantErr: "failed to parse manifest export",
+		},
+		{
+			name:    "raw manifest",
+			input:   `{"version":"1.0","packages":{}}`,
+			wantErr: "failed to parse manifest export",
+		},
+		{
+			name:    "wrong format",
+			input:   `{"format":"something-else","format_version":1}`,
+			wantErr: "not a manifest export",
+		},
+		{
+			name:    "newer version",
+			input:   `{"format":"dot-manifest-export","format_version":99}`,
+			wantErr: "unsupported manifest export version 99",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadExport(strings.NewReader(tt.input))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestReadExport_InitializesEmptyMaps(t *testing.T) {
+	input := `{"format":"dot-manifest-export","format_version":1,"manifest":{}}`
+
+	m, err := ReadExport(strings.NewReader(input))
+	require.NoError(t, err)
+
+	assert.Equal(t, "1.0", m.Version)
+	assert.NotNil(t, m.Packages)
+	assert.NotNil(t, m.Hashes)
+}
@@ -14,10 +14,17 @@ import (
 
 const manifestFileName = ".dot-manifest.json"
 
+// defaultManifestPerm is the mode of every manifest written. Saving or
+// migrating a manifest with a looser mode tightens it to this one.
+const defaultManifestPerm os.FileMode = 0600
+
 // FSManifestStore implements ManifestStore using filesystem
 type FSManifestStore struct {
 	fs          domain.FS
 	manifestDir string // Directory to store manifest (empty means use target directory)
+	logger      domain.Logger
+	locker      Locker
+	lockTimeout time.Duration
 }
 
 // NewFSManifestStore creates filesystem-based manifest store.
@@ -26,6 +33,8 @@ func NewFSManifestStore(fs domain.FS) *FSManifestStore {
 	return &FSManifestStore{
 		fs:          fs,
 		manifestDir: "", // Empty means use target directory
+		locker:      FileLocker{},
+		lockTimeout: DefaultLockTimeout,
 	}
 }
 
@@ -35,11 +44,43 @@ func NewFSManifestStoreWithDir(fs domain.FS, manifestDir string) *FSManifestStor
 	return &FSManifestStore{
 		fs:          fs,
 		manifestDir: manifestDir,
+		locker:      FileLocker{},
+		lockTimeout: DefaultLockTimeout,
 	}
 }
 
-// Load retrieves manifest from configured directory
+// WithLogger sets the logger that records manifest migrations.
+func (s *FSManifestStore) WithLogger(logger domain.Logger) *FSManifestStore {
+	s.logger = logger
+	return s
+}
+
+// WithLocker sets the locker that serializes manifest writes between
+// processes.
+func (s *FSManifestStore) WithLocker(locker Locker) *FSManifestStore {
+	s.locker = locker
+	return s
+}
+
+// WithLockTimeout sets how long a write waits for another process to
+// release the manifest lock before failing with ErrManifestLocked. A zero
+// timeout fails at once.
+func (s *FSManifestStore) WithLockTimeout(timeout time.Duration) *FSManifestStore {
+	s.lockTimeout = timeout
+	return s
+}
+
+// Load retrieves manifest from configured directory.
+//
+// When a manifest directory is configured and holds no manifest, a manifest
+// left in the target directory by an older version is moved there first.
 func (s *FSManifestStore) Load(ctx context.Context, targetDir domain.TargetPath) domain.Result[Manifest] {
+	return s.load(ctx, targetDir, false)
+}
+
+// load reads the manifest. held reports that the caller already holds the
+// manifest lock.
+func (s *FSManifestStore) load(ctx context.Context, targetDir domain.TargetPath, held bool) domain.Result[Manifest] {
 	if ctx.Err() != nil {
 		return domain.Err[Manifest](ctx.Err())
 	}
@@ -47,6 +88,9 @@ func (s *FSManifestStore) Load(ctx context.Context, targetDir domain.TargetPath)
 	manifestPath := s.getManifestPath(targetDir)
 
 	data, err := s.fs.ReadFile(ctx, manifestPath)
+	if errors.Is(err, os.ErrNotExist) {
+		data, err = s.migrateLegacy(ctx, targetDir, held)
+	}
 	if err != nil {
 		if errors.Is(err, os.ErrNotExist) {
 			// Missing manifest is not an error - return empty manifest
@@ -63,6 +107,29 @@ func (s *FSManifestStore) Load(ctx context.Context, targetDir domain.TargetPath)
 	return domain.Ok(m)
 }
 
+// Verify reports whether the manifest on disk matches its stored checksum.
+// A missing manifest, or one saved before checksums existed, is valid.
+func (s *FSManifestStore) Verify(ctx context.Context, targetDir domain.TargetPath) (bool, error) {
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+
+	data, err := s.fs.ReadFile(ctx, s.getManifestPath(targetDir))
+	if errors.Is(err, os.ErrNotExist) {
+		if legacyPath := s.legacyManifestPath(targetDir); legacyPath != "" {
+			data, err = s.fs.ReadFile(ctx, legacyPath)
+		}
+	}
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return true, nil
+		}
+		return false, fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	return VerifyChecksum(data)
+}
+
 // getManifestPath returns the full path to the manifest file.
 // Uses manifestDir if configured, otherwise falls back to targetDir.
 func (s *FSManifestStore) getManifestPath(targetDir domain.TargetPath) string {
@@ -72,6 +139,155 @@ func (s *FSManifestStore) getManifestPath(targetDir domain.TargetPath) string {
 	return filepath.Join(targetDir.String(), manifestFileName)
 }
 
+// legacyManifestPath returns where versions that stored the manifest in
+// the target directory kept it, or "" if that is the configured location.
+func (s *FSManifestStore) legacyManifestPath(targetDir domain.TargetPath) string {
+	if s.manifestDir == "" || filepath.Clean(s.manifestDir) == filepath.Clean(targetDir.String()) {
+		return ""
+	}
+	return filepath.Join(targetDir.String(), manifestFileName)
+}
+
+// migrateLegacy moves a manifest from the target directory to the
+// configured manifest directory and returns its contents. It returns an
+// os.ErrNotExist error if there is no legacy manifest.
+//
+// The move holds the manifest lock and writes through a temp file and
+// rename, so concurrent migrations leave a single complete manifest. If
+// the manifest cannot be moved, such as on a read-only filesystem, the
+// legacy contents are still returned.
+func (s *FSManifestStore) migrateLegacy(ctx context.Context, targetDir domain.TargetPath, held bool) ([]byte, error) {
+	legacyPath := s.legacyManifestPath(targetDir)
+	if legacyPath == "" {
+		return nil, os.ErrNotExist
+	}
+	manifestPath := s.getManifestPath(targetDir)
+	data, err := s.fs.ReadFile(ctx, legacyPath)
+	if errors.Is(err, os.ErrNotExist) {
+		// Another process may have just moved it
+		return s.fs.ReadFile(ctx, manifestPath)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.moveLegacy(ctx, legacyPath, manifestPath, data, held); err != nil {
+		// Dry runs read through a read-only filesystem and migrate later
+		var roErr domain.ErrReadOnlyFS
+		if !errors.As(err, &roErr) {
+			s.warn(ctx, "manifest_migration_failed", "from", legacyPath, "to", manifestPath, "error", err)
+		}
+		return data, nil
+	}
+
+	// Another process may have finished a migration first
+	return s.fs.ReadFile(ctx, manifestPath)
+}
+
+// moveLegacy writes data to manifestPath unless a manifest appeared there
+// meanwhile, then removes the legacy manifest. It takes the manifest lock
+// unless held reports the caller holds it.
+func (s *FSManifestStore) moveLegacy(ctx context.Context, legacyPath, manifestPath string, data []byte, held bool) error {
+	if err := s.fs.MkdirAll(ctx, s.manifestDir, 0755); err != nil {
+		return fmt.Errorf("create manifest directory: %w", err)
+	}
+
+	if !held {
+		unlock, err := s.lock(s.manifestDir)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+	}
+
+	if !s.fs.Exists(ctx, manifestPath) {
+		// A unique temp file keeps concurrent migrations from writing
+		// into each other's file when locking is unavailable
+		tempPath := fmt.Sprintf("%s.%d-%d.tmp", manifestPath, os.Getpid(), time.Now().UnixNano())
+		if err := s.fs.WriteFile(ctx, tempPath, data, defaultManifestPerm); err != nil {
+			_ = s.fs.Remove(ctx, tempPath)
+			return fmt.Errorf("write temp manifest: %w", err)
+		}
+		if err := s.fs.Rename(ctx, tempPath, manifestPath); err != nil {
+			_ = s.fs.Remove(ctx, tempPath)
+			return fmt.Errorf("rename manifest: %w", err)
+		}
+		s.info(ctx, "manifest_migrated", "from", legacyPath, "to", manifestPath)
+	}
+
+	if err := s.fs.Remove(ctx, legacyPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		s.warn(ctx, "legacy_manifest_not_removed", "path", legacyPath, "error", err)
+	}
+	return nil
+}
+
+func (s *FSManifestStore) info(ctx context.Context, msg string, fields ...any) {
+	if s.logger != nil {
+		s.logger.Info(ctx, msg, fields...)
+	}
+}
+
+func (s *FSManifestStore) warn(ctx context.Context, msg string, fields ...any) {
+	if s.logger != nil {
+		s.logger.Warn(ctx, msg, fields...)
+	}
+}
+
+// lock acquires the manifest lock for dir. Locking is advisory and best
+// effort: only a lock held by another process is an error. If the lock
+// cannot be taken for another reason, such as a manifest on an in-memory
+// or read-only filesystem, the returned function does nothing.
+func (s *FSManifestStore) lock(dir string) (func() error, error) {
+	unlock, err := s.locker.Lock(dir, s.lockTimeout)
+	if errors.Is(err, ErrManifestLocked{}) {
+		return nil, err
+	}
+	if err != nil {
+		return func() error { return nil }, nil
+	}
+	return unlock, nil
+}
+
+// lockManifestDir creates the manifest directory if needed and locks it.
+func (s *FSManifestStore) lockManifestDir(ctx context.Context, targetDir domain.TargetPath) (func() error, error) {
+	manifestDir := filepath.Dir(s.getManifestPath(targetDir))
+	if !s.fs.Exists(ctx, manifestDir) {
+		if err := s.fs.MkdirAll(ctx, manifestDir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create manifest directory: %w", err)
+		}
+	}
+	return s.lock(manifestDir)
+}
+
+// Update applies fn to the stored manifest and saves the result, holding
+// the manifest lock from the read to the write so concurrent dot processes
+// cannot lose each other's changes. fn reports whether it changed the
+// manifest; an unchanged manifest is not saved. The lock is released
+// however Update returns.
+func (s *FSManifestStore) Update(ctx context.Context, targetDir domain.TargetPath, fn func(m *Manifest) (bool, error)) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	unlock, err := s.lockManifestDir(ctx, targetDir)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	result := s.load(ctx, targetDir, true)
+	if !result.IsOk() {
+		return result.UnwrapErr()
+	}
+	m := result.Unwrap()
+
+	changed, err := fn(&m)
+	if err != nil || !changed {
+		return err
+	}
+	return s.save(ctx, targetDir, m)
+}
+
 // Save persists manifest to configured directory.
 // Uses advisory file locking to prevent concurrent write corruption.
 func (s *FSManifestStore) Save(ctx context.Context, targetDir domain.TargetPath, manifest Manifest) error {
@@ -79,9 +295,32 @@ func (s *FSManifestStore) Save(ctx context.Context, targetDir domain.TargetPath,
 		return ctx.Err()
 	}
 
+	unlock, err := s.lockManifestDir(ctx, targetDir)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	return s.save(ctx, targetDir, manifest)
+}
+
+// save writes the manifest. The caller holds the manifest lock.
+func (s *FSManifestStore) save(ctx context.Context, targetDir domain.TargetPath, manifest Manifest) error {
 	// Update timestamp
 	manifest.UpdatedAt = time.Now()
 
+	// Stamp the content checksum, computed over the manifest without it
+	manifest.Checksum = ""
+	unsigned, err := json.Marshal(manifest)
+	if err != nil {
+		return fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+	checksum, err := Checksum(unsigned)
+	if err != nil {
+		return err
+	}
+	manifest.Checksum = checksum
+
 	// Marshal to JSON with indentation
 	data, err := json.MarshalIndent(manifest, "", "  ")
 	if err != nil {
@@ -90,35 +329,21 @@ func (s *FSManifestStore) Save(ctx context.Context, targetDir domain.TargetPath,
 
 	manifestPath := s.getManifestPath(targetDir)
 
-	// Ensure manifest directory exists
-	manifestDir := filepath.Dir(manifestPath)
-	if !s.fs.Exists(ctx, manifestDir) {
-		if err := s.fs.MkdirAll(ctx, manifestDir, 0755); err != nil {
-			return fmt.Errorf("failed to create manifest directory: %w", err)
-		}
-	}
-
-	// Acquire advisory lock to prevent concurrent manifest corruption.
-	// Best-effort: if locking fails (e.g., MemFS in tests), proceed without lock.
-	lock := NewFileLock(manifestDir)
-	lockErr := lock.Lock(5 * time.Second)
-	if lockErr == nil {
-		defer lock.Unlock()
-	}
-
-	// Atomic write via temp file and rename
+	// Atomic write via temp file and rename, so a crash mid-write never
+	// leaves a truncated manifest behind
 	tempPath := manifestPath + ".tmp"
 
-	// Write to temp file
-	if err := s.fs.WriteFile(ctx, tempPath, data, 0644); err != nil {
+	// Remove any temp file left by an interrupted save; writing over it
+	// would keep its old permissions
+	_ = s.fs.Remove(ctx, tempPath)
+
+	if err := s.fs.WriteFile(ctx, tempPath, data, defaultManifestPerm); err != nil {
+		_ = s.fs.Remove(ctx, tempPath)
 		return fmt.Errorf("failed to write temp manifest: %w", err)
 	}
 
-	// Atomic rename
 	if err := s.fs.Rename(ctx, tempPath, manifestPath); err != nil {
-		// Ignore cleanup error: best-effort during error recovery.
-		// Temp file (.dot-manifest.json.tmp) is harmless and will be
-		// overwritten on next successful write operation.
+		// Best-effort cleanup; the original manifest is untouched
 		_ = s.fs.Remove(ctx, tempPath)
 		return fmt.Errorf("failed to rename manifest: %w", err)
 	}
@@ -2,14 +2,20 @@ package manifest
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/yaklabco/dot/internal/adapters"
+	"github.com/yaklabco/dot/internal/domain"
 )
 
 func TestFSManifestStore_Load_MissingFile(t *testing.T) {
@@ -253,3 +259,486 @@ func TestFSManifestStore_Save_WithContext(t *testing.T) {
 
 	assert.Error(t, err)
 }
+
+// failingFS wraps a filesystem and fails temp manifest writes or renames.
+type failingFS struct {
+	domain.FS
+	failWrite  bool
+	failRename bool
+}
+
+func (f *failingFS) WriteFile(ctx context.Context, path string, data []byte, perm os.FileMode) error {
+	if f.failWrite && strings.HasSuffix(path, ".tmp") {
+		// Leave a partial file behind, as an interrupted write would
+		_ = f.FS.WriteFile(ctx, path, data[:len(data)/2], perm)
+		return errors.New("disk full")
+	}
+	return f.FS.WriteFile(ctx, path, data, perm)
+}
+
+func (f *failingFS) Rename(ctx context.Context, oldPath, newPath string) error {
+	if f.failRename {
+		return errors.New("rename failed")
+	}
+	return f.FS.Rename(ctx, oldPath, newPath)
+}
+
+func TestFSManifestStore_Save_FailureLeavesOriginalIntact(t *testing.T) {
+	tests := []struct {
+		name string
+		fs   failingFS
+	}{
+		{name: "temp write fails", fs: failingFS{failWrite: true}},
+		{name: "rename fails", fs: failingFS{failRename: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			memFS := adapters.NewMemFS()
+			manifestDir := "/data/manifest"
+			targetDir := mustTargetPath(t, "/home/user")
+
+			original := New()
+			original.AddPackage(PackageInfo{Name: "vim", Links: []string{".vimrc"}})
+			require.NoError(t, NewFSManifestStoreWithDir(memFS, manifestDir).Save(ctx, targetDir, original))
+
+			manifestPath := filepath.Join(manifestDir, manifestFileName)
+			before, err := memFS.ReadFile(ctx, manifestPath)
+			require.NoError(t, err)
+
+			fs := tt.fs
+			fs.FS = memFS
+			updated := New()
+			updated.AddPackage(PackageInfo{Name: "zsh", Links: []string{".zshrc"}})
+			err = NewFSManifestStoreWithDir(&fs, manifestDir).Save(ctx, targetDir, updated)
+			require.Error(t, err)
+
+			after, err := memFS.ReadFile(ctx, manifestPath)
+			require.NoError(t, err)
+			assert.Equal(t, before, after, "original manifest must be untouched")
+			assert.False(t, memFS.Exists(ctx, manifestPath+".tmp"), "temp file must be cleaned up")
+		})
+	}
+}
+
+func TestFSManifestStore_Save_Permissions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Unix file permissions")
+	}
+	ctx := context.Background()
+	fs := adapters.NewOSFilesystem()
+	manifestDir := t.TempDir()
+	store := NewFSManifestStoreWithDir(fs, manifestDir)
+	targetDir := mustTargetPath(t, "/home/user")
+	manifestPath := filepath.Join(manifestDir, manifestFileName)
+
+	require.NoError(t, store.Save(ctx, targetDir, New()))
+	info, err := os.Stat(manifestPath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm(), "new manifest is private")
+
+	// A looser existing manifest is tightened, even if a stale temp file is
+	// looser still
+	require.NoError(t, os.Chmod(manifestPath, 0644))
+	require.NoError(t, os.WriteFile(manifestPath+".tmp", []byte("stale"), 0666))
+	require.NoError(t, store.Save(ctx, targetDir, New()))
+	info, err = os.Stat(manifestPath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
+
+func TestFSManifestStore_Verify(t *testing.T) {
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	targetDir := mustTargetPath(t, "/home/user")
+	require.NoError(t, fs.MkdirAll(ctx, targetDir.String(), 0755))
+	store := NewFSManifestStore(fs)
+	manifestPath := filepath.Join(targetDir.String(), ".dot-manifest.json")
+
+	// No manifest yet
+	valid, err := store.Verify(ctx, targetDir)
+	require.NoError(t, err)
+	assert.True(t, valid)
+
+	m := New()
+	m.AddPackage(PackageInfo{Name: "vim", LinkCount: 1, Links: []string{".vimrc"}})
+	m.SetHash("vim", "abc123")
+	m.AddIgnoredPattern("*.bak")
+	require.NoError(t, store.Save(ctx, targetDir, m))
+
+	original, err := fs.ReadFile(ctx, manifestPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(original), `"checksum"`)
+
+	valid, err = store.Verify(ctx, targetDir)
+	require.NoError(t, err)
+	assert.True(t, valid)
+
+	mutations := map[string]func(string) string{
+		"edited link": func(s string) string {
+			return strings.Replace(s, `".vimrc"`, `".evilrc"`, 1)
+		},
+		"edited hash": func(s string) string {
+			return strings.Replace(s, `"abc123"`, `"abc124"`, 1)
+		},
+		"removed ignore pattern": func(s string) string {
+			return strings.Replace(s, `"*.bak"`, `"*.tmp"`, 1)
+		},
+		"flipped byte": func(s string) string {
+			i := strings.Index(s, `"vim"`) + 1
+			return s[:i] + "V" + s[i+1:]
+		},
+	}
+	for name, mutate := range mutations {
+		t.Run(name, func(t *testing.T) {
+			mutated := mutate(string(original))
+			require.NotEqual(t, string(original), mutated)
+			require.NoError(t, fs.WriteFile(ctx, manifestPath, []byte(mutated), 0600))
+
+			valid, err := store.Verify(ctx, targetDir)
+			require.NoError(t, err)
+			assert.False(t, valid)
+		})
+	}
+
+	// Reformatting without changing content keeps the checksum valid
+	var doc map[string]any
+	require.NoError(t, json.Unmarshal(original, &doc))
+	compact, err := json.Marshal(doc)
+	require.NoError(t, err)
+	require.NoError(t, fs.WriteFile(ctx, manifestPath, compact, 0600))
+	valid, err = store.Verify(ctx, targetDir)
+	require.NoError(t, err)
+	assert.True(t, valid)
+
+	// Truncated manifest cannot be parsed
+	require.NoError(t, fs.WriteFile(ctx, manifestPath, original[:len(original)/2], 0600))
+	_, err = store.Verify(ctx, targetDir)
+	require.Error(t, err)
+}
+
+// writeManifestFile saves a manifest holding pkg at path.
+func writeManifestFile(t *testing.T, fs domain.FS, path, pkg string) {
+	t.Helper()
+	ctx := context.Background()
+	m := New()
+	m.AddPackage(PackageInfo{Name: pkg, LinkCount: 1, Links: []string{".vimrc"}})
+	data, err := json.Marshal(m)
+	require.NoError(t, err)
+	require.NoError(t, fs.MkdirAll(ctx, filepath.Dir(path), 0755))
+	require.NoError(t, fs.WriteFile(ctx, path, data, 0600))
+}
+
+func TestFSManifestStore_Load_MigratesLegacyManifest(t *testing.T) {
+	const (
+		manifestDir = "/home/user/.local/share/dot/manifest"
+		newPath     = manifestDir + "/.dot-manifest.json"
+		legacyPath  = "/home/user/.dot-manifest.json"
+	)
+
+	tests := []struct {
+		name        string
+		setup       func(t *testing.T, fs domain.FS)
+		wantPackage string // empty for an empty manifest
+		wantLegacy  bool   // legacy manifest still present after Load
+		wantNew     bool   // manifest present in the manifest directory
+	}{
+		{
+			name:  "fresh install",
+			setup: func(t *testing.T, fs domain.FS) {},
+		},
+		{
+			name: "legacy manifest only",
+			setup: func(t *testing.T, fs domain.FS) {
+				writeManifestFile(t, fs, legacyPath, "legacy")
+			},
+			wantPackage: "legacy",
+			wantNew:     true,
+		},
+		{
+			name: "both present",
+			setup: func(t *testing.T, fs domain.FS) {
+				writeManifestFile(t, fs, legacyPath, "legacy")
+				writeManifestFile(t, fs, newPath, "current")
+			},
+			wantPackage: "current",
+			wantLegacy:  true,
+			wantNew:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			fs := adapters.NewMemFS()
+			require.NoError(t, fs.MkdirAll(ctx, "/home/user", 0755))
+			tt.setup(t, fs)
+			store := NewFSManifestStoreWithDir(fs, manifestDir)
+			targetDir := mustTargetPath(t, "/home/user")
+
+			// Loading twice shows the migration is idempotent
+			for i := 0; i < 2; i++ {
+				result := store.Load(ctx, targetDir)
+				require.True(t, result.IsOk(), "load %d", i)
+				m := result.Unwrap()
+				if tt.wantPackage == "" {
+					assert.Empty(t, m.Packages)
+				} else {
+					_, ok := m.GetPackage(tt.wantPackage)
+					assert.True(t, ok, "load %d returns %s manifest", i, tt.wantPackage)
+				}
+			}
+
+			assert.Equal(t, tt.wantLegacy, fs.Exists(ctx, legacyPath))
+			assert.Equal(t, tt.wantNew, fs.Exists(ctx, newPath))
+		})
+	}
+}
+
+func TestFSManifestStore_Load_MigrationTightensPermissions(t *testing.T) {
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	writeManifestFile(t, fs, "/home/user/.dot-manifest.json", "legacy")
+	require.NoError(t, fs.WriteFile(ctx, "/home/user/.dot-manifest.json", mustRead(t, fs, "/home/user/.dot-manifest.json"), 0644))
+
+	store := NewFSManifestStoreWithDir(fs, "/data/dot")
+	require.True(t, store.Load(ctx, mustTargetPath(t, "/home/user")).IsOk())
+
+	info, err := fs.Stat(ctx, "/data/dot/.dot-manifest.json")
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	entries, err := fs.ReadDir(ctx, "/data/dot")
+	require.NoError(t, err)
+	for _, entry := range entries {
+		assert.NotContains(t, entry.Name(), ".tmp", "temp file left behind")
+	}
+}
+
+func mustRead(t *testing.T, fs domain.FS, path string) []byte {
+	t.Helper()
+	data, err := fs.ReadFile(context.Background(), path)
+	require.NoError(t, err)
+	return data
+}
+
+func TestFSManifestStore_Load_ReadOnlyLeavesLegacyManifest(t *testing.T) {
+	ctx := context.Background()
+	mem := adapters.NewMemFS()
+	writeManifestFile(t, mem, "/home/user/.dot-manifest.json", "legacy")
+
+	// Dry runs read through a read-only filesystem
+	store := NewFSManifestStoreWithDir(adapters.NewReadOnlyFS(mem), "/data/dot")
+	result := store.Load(ctx, mustTargetPath(t, "/home/user"))
+	require.True(t, result.IsOk())
+	m := result.Unwrap()
+	_, ok := m.GetPackage("legacy")
+	assert.True(t, ok)
+
+	assert.True(t, mem.Exists(ctx, "/home/user/.dot-manifest.json"))
+	assert.False(t, mem.Exists(ctx, "/data/dot/.dot-manifest.json"))
+}
+
+func TestFSManifestStore_Load_ConcurrentMigration(t *testing.T) {
+	ctx := context.Background()
+	fs := adapters.NewOSFilesystem()
+	targetDir := t.TempDir()
+	manifestDir := filepath.Join(t.TempDir(), "manifest")
+	writeManifestFile(t, fs, filepath.Join(targetDir, ".dot-manifest.json"), "legacy")
+
+	const goroutines = 8
+	errCh := make(chan error, goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			store := NewFSManifestStoreWithDir(fs, manifestDir)
+			result := store.Load(ctx, mustTargetPath(t, targetDir))
+			if !result.IsOk() {
+				errCh <- result.UnwrapErr()
+				return
+			}
+			m := result.Unwrap()
+			if _, ok := m.GetPackage("legacy"); !ok {
+				errCh <- errors.New("migrated manifest is missing the legacy package")
+				return
+			}
+			errCh <- nil
+		}()
+	}
+	for i := 0; i < goroutines; i++ {
+		assert.NoError(t, <-errCh)
+	}
+
+	assert.False(t, fs.Exists(ctx, filepath.Join(targetDir, ".dot-manifest.json")))
+	entries, err := os.ReadDir(manifestDir)
+	require.NoError(t, err)
+	for _, entry := range entries {
+		assert.NotContains(t, entry.Name(), ".tmp", "temp file left behind")
+	}
+	result := NewFSManifestStoreWithDir(fs, manifestDir).Load(ctx, mustTargetPath(t, targetDir))
+	require.True(t, result.IsOk())
+}
+
+func TestFSManifestStore_Verify_LegacyManifest(t *testing.T) {
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	require.NoError(t, fs.MkdirAll(ctx, "/home/user", 0755))
+	require.NoError(t, fs.WriteFile(ctx, "/home/user/.dot-manifest.json", []byte(`{"version":"1.0","checksum":"sha256:bad"}`), 0600))
+
+	store := NewFSManifestStoreWithDir(fs, "/data/dot")
+	valid, err := store.Verify(ctx, mustTargetPath(t, "/home/user"))
+	require.NoError(t, err)
+	assert.False(t, valid, "an unmigrated legacy manifest is still verified")
+}
+
+// fakeLocker records lock calls and fails them with err.
+type fakeLocker struct {
+	err      error
+	locks    int
+	unlocks  int
+	timeouts []time.Duration
+}
+
+func (l *fakeLocker) Lock(_ string, timeout time.Duration) (func() error, error) {
+	l.timeouts = append(l.timeouts, timeout)
+	if l.err != nil {
+		return nil, l.err
+	}
+	l.locks++
+	return func() error {
+		l.unlocks++
+		return nil
+	}, nil
+}
+
+func TestFSManifestStore_Update(t *testing.T) {
+	ctx := context.Background()
+	targetDir := mustTargetPath(t, "/home/user")
+	addPackage := func(name string) func(*Manifest) (bool, error) {
+		return func(m *Manifest) (bool, error) {
+			m.AddPackage(PackageInfo{Name: name, LinkCount: 1, Links: []string{"." + name}})
+			return true, nil
+		}
+	}
+
+	t.Run("applies the change under the lock", func(t *testing.T) {
+		locker := &fakeLocker{}
+		store := NewFSManifestStore(adapters.NewMemFS()).WithLocker(locker)
+
+		require.NoError(t, store.Update(ctx, targetDir, addPackage("vim")))
+		require.NoError(t, store.Update(ctx, targetDir, addPackage("zsh")))
+
+		m := store.Load(ctx, targetDir).Unwrap()
+		assert.Len(t, m.Packages, 2)
+		assert.Equal(t, 2, locker.locks)
+		assert.Equal(t, 2, locker.unlocks)
+		assert.Equal(t, []time.Duration{DefaultLockTimeout, DefaultLockTimeout}, locker.timeouts)
+	})
+
+	t.Run("unchanged manifest is not saved", func(t *testing.T) {
+		fs := adapters.NewMemFS()
+		store := NewFSManifestStore(fs).WithLocker(&fakeLocker{})
+
+		require.NoError(t, store.Update(ctx, targetDir, func(*Manifest) (bool, error) { return false, nil }))
+		assert.False(t, fs.Exists(ctx, "/home/user/.dot-manifest.json"))
+	})
+
+	t.Run("error from fn releases the lock", func(t *testing.T) {
+		fs := adapters.NewMemFS()
+		locker := &fakeLocker{}
+		store := NewFSManifestStore(fs).WithLocker(locker)
+		fnErr := errors.New("boom")
+
+		err := store.Update(ctx, targetDir, func(*Manifest) (bool, error) { return true, fnErr })
+		require.ErrorIs(t, err, fnErr)
+		assert.Equal(t, 1, locker.unlocks)
+		assert.False(t, fs.Exists(ctx, "/home/user/.dot-manifest.json"))
+	})
+
+	t.Run("corrupt manifest releases the lock", func(t *testing.T) {
+		fs := adapters.NewMemFS()
+		require.NoError(t, fs.MkdirAll(ctx, "/home/user", 0755))
+		require.NoError(t, fs.WriteFile(ctx, "/home/user/.dot-manifest.json", []byte("{"), 0600))
+		locker := &fakeLocker{}
+		store := NewFSManifestStore(fs).WithLocker(locker)
+
+		require.Error(t, store.Update(ctx, targetDir, addPackage("vim")))
+		assert.Equal(t, 1, locker.unlocks)
+	})
+}
+
+func TestFSManifestStore_Locked(t *testing.T) {
+	ctx := context.Background()
+	targetDir := mustTargetPath(t, "/home/user")
+	locked := ErrManifestLocked{Path: "/home/user/.dot-manifest.lock"}
+
+	fs := adapters.NewMemFS()
+	writeManifestFile(t, fs, "/home/user/.dot-manifest.json", "vim")
+	before := mustRead(t, fs, "/home/user/.dot-manifest.json")
+	locker := &fakeLocker{err: locked}
+	store := NewFSManifestStore(fs).WithLocker(locker).WithLockTimeout(0)
+
+	err := store.Save(ctx, targetDir, New())
+	require.ErrorIs(t, err, ErrManifestLocked{})
+	err = store.Update(ctx, targetDir, func(*Manifest) (bool, error) {
+		t.Fatal("fn must not run without the lock")
+		return false, nil
+	})
+	require.ErrorIs(t, err, ErrManifestLocked{})
+
+	assert.Equal(t, before, mustRead(t, fs, "/home/user/.dot-manifest.json"), "manifest is untouched")
+	assert.Equal(t, []time.Duration{0, 0}, locker.timeouts, "fail fast is passed to the locker")
+}
+
+func TestFSManifestStore_LockUnavailable(t *testing.T) {
+	// A lock that cannot be taken for a reason other than another holder,
+	// such as a read-only lock directory, does not block writes
+	ctx := context.Background()
+	targetDir := mustTargetPath(t, "/home/user")
+	fs := adapters.NewMemFS()
+	store := NewFSManifestStore(fs).WithLocker(&fakeLocker{err: os.ErrPermission})
+
+	require.NoError(t, store.Save(ctx, targetDir, New()))
+	assert.True(t, fs.Exists(ctx, "/home/user/.dot-manifest.json"))
+}
+
+func TestFSManifestStore_Update_ConcurrentUpdatesKeepEveryChange(t *testing.T) {
+	ctx := context.Background()
+	fs := adapters.NewOSFilesystem()
+	targetDir := mustTargetPath(t, t.TempDir())
+
+	const updates = 20
+	errCh := make(chan error, 2)
+	for worker := range 2 {
+		go func() {
+			// Separate stores, as two dot processes would have
+			store := NewFSManifestStore(fs)
+			for i := range updates {
+				name := fmt.Sprintf("pkg-%d-%d", worker, i)
+				err := store.Update(ctx, targetDir, func(m *Manifest) (bool, error) {
+					m.AddPackage(PackageInfo{Name: name, LinkCount: 1, Links: []string{"." + name}})
+					// Widen the window between read and write
+					time.Sleep(time.Millisecond)
+					return true, nil
+				})
+				if err != nil {
+					errCh <- err
+					return
+				}
+			}
+			errCh <- nil
+		}()
+	}
+	for range 2 {
+		require.NoError(t, <-errCh)
+	}
+
+	store := NewFSManifestStore(fs)
+	valid, err := store.Verify(ctx, targetDir)
+	require.NoError(t, err)
+	assert.True(t, valid, "manifest matches its checksum")
+
+	result := store.Load(ctx, targetDir)
+	require.True(t, result.IsOk())
+	assert.Len(t, result.Unwrap().Packages, 2*updates, "no update is lost")
+}
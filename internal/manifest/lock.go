@@ -1,14 +1,63 @@
 package manifest
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 )
 
 const lockFileName = ".dot-manifest.lock"
 
+// DefaultLockTimeout is how long a manifest update waits for another
+// process to release the manifest lock.
+const DefaultLockTimeout = 5 * time.Second
+
+// lockPollInterval is how often a waiting Lock retries.
+const lockPollInterval = 50 * time.Millisecond
+
+// ErrManifestLocked indicates another process holds the manifest lock.
+type ErrManifestLocked struct {
+	Path    string
+	Timeout time.Duration
+}
+
+func (e ErrManifestLocked) Error() string {
+	if e.Timeout > 0 {
+		return fmt.Sprintf("manifest is locked by another dot process (lock timeout after %v): %s", e.Timeout, e.Path)
+	}
+	return fmt.Sprintf("manifest is locked by another dot process: %s", e.Path)
+}
+
+// Is implements errors.Is for ErrManifestLocked.
+func (e ErrManifestLocked) Is(target error) bool {
+	_, ok := target.(ErrManifestLocked)
+	return ok
+}
+
+// Locker serializes manifest updates between processes.
+type Locker interface {
+	// Lock acquires an exclusive lock on the manifest in dir, waiting up to
+	// timeout for another holder to release it. A zero timeout fails at
+	// once. It returns ErrManifestLocked if the lock is still held, and
+	// otherwise a function that releases the lock.
+	Lock(dir string, timeout time.Duration) (unlock func() error, err error)
+}
+
+// FileLocker is the Locker backed by a lock file next to the manifest,
+// using flock(2) on Unix and LockFileEx on Windows.
+type FileLocker struct{}
+
+// Lock implements Locker.
+func (FileLocker) Lock(dir string, timeout time.Duration) (func() error, error) {
+	lock := NewFileLock(dir)
+	if err := lock.Lock(timeout); err != nil {
+		return nil, err
+	}
+	return lock.Unlock, nil
+}
+
 // FileLock provides advisory file locking for manifest operations.
-// On Unix, it uses flock(2). On Windows, it returns an error (best-effort).
 type FileLock struct {
 	path string
 	file *os.File
@@ -20,3 +69,50 @@ func NewFileLock(manifestDir string) *FileLock {
 		path: filepath.Join(manifestDir, lockFileName),
 	}
 }
+
+// Lock acquires an exclusive advisory lock, waiting up to timeout for
+// another holder to release it. Returns ErrManifestLocked if the lock is
+// still held after timeout.
+func (l *FileLock) Lock(timeout time.Duration) error {
+	f, err := os.OpenFile(l.path, os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return fmt.Errorf("open lock file: %w", err)
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		locked, err := tryLockFile(f)
+		if err != nil {
+			_ = f.Close()
+			return fmt.Errorf("lock %s: %w", l.path, err)
+		}
+		if locked {
+			l.file = f
+			return nil
+		}
+		if !time.Now().Before(deadline) {
+			_ = f.Close()
+			return ErrManifestLocked{Path: l.path, Timeout: timeout}
+		}
+		time.Sleep(lockPollInterval)
+	}
+}
+
+// Unlock releases the advisory lock. Unlocking a lock that is not held
+// does nothing.
+func (l *FileLock) Unlock() error {
+	if l.file == nil {
+		return nil
+	}
+	f := l.file
+	l.file = nil
+
+	if err := unlockFile(f); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("unlock: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("close lock file: %w", err)
+	}
+	return nil
+}
@@ -76,3 +76,31 @@ func TestFileLock_UnlockIdempotent(t *testing.T) {
 	err = lock.Unlock()
 	assert.NoError(t, err)
 }
+
+func TestFileLock_ZeroTimeoutFailsFast(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	lock1 := NewFileLock(tmpDir)
+	require.NoError(t, lock1.Lock(time.Second))
+	defer lock1.Unlock()
+
+	start := time.Now()
+	err := NewFileLock(tmpDir).Lock(0)
+	require.ErrorIs(t, err, ErrManifestLocked{})
+	assert.Less(t, time.Since(start), lockPollInterval, "a zero timeout does not wait")
+}
+
+func TestFileLocker_Lock(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	unlock, err := FileLocker{}.Lock(tmpDir, time.Second)
+	require.NoError(t, err)
+
+	_, err = FileLocker{}.Lock(tmpDir, 0)
+	require.ErrorIs(t, err, ErrManifestLocked{})
+
+	require.NoError(t, unlock())
+	unlock, err = FileLocker{}.Lock(tmpDir, 0)
+	require.NoError(t, err, "the lock can be taken once released")
+	require.NoError(t, unlock())
+}
@@ -3,17 +3,26 @@
 package manifest
 
 import (
-	"fmt"
-	"time"
+	"errors"
+	"os"
+
+	"golang.org/x/sys/windows"
 )
 
-// Lock is a no-op on Windows. Advisory locking is not supported.
-// Returns an error so callers can fall through to best-effort behavior.
-func (l *FileLock) Lock(timeout time.Duration) error {
-	return fmt.Errorf("advisory file locking not supported on Windows")
+// tryLockFile takes an exclusive lock on the first byte of f with
+// LockFileEx without blocking. It reports false if another handle holds
+// the lock.
+func tryLockFile(f *os.File) (bool, error) {
+	var overlapped windows.Overlapped
+	err := windows.LockFileEx(windows.Handle(f.Fd()), windows.LOCKFILE_EXCLUSIVE_LOCK|windows.LOCKFILE_FAIL_IMMEDIATELY, 0, 1, 0, &overlapped)
+	if errors.Is(err, windows.ERROR_LOCK_VIOLATION) {
+		return false, nil
+	}
+	return err == nil, err
 }
 
-// Unlock is a no-op on Windows.
-func (l *FileLock) Unlock() error {
-	return nil
+// unlockFile releases the lock taken by tryLockFile.
+func unlockFile(f *os.File) error {
+	var overlapped windows.Overlapped
+	return windows.UnlockFileEx(windows.Handle(f.Fd()), 0, 1, 0, &overlapped)
 }
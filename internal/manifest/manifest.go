@@ -15,6 +15,30 @@ type Manifest struct {
 	Hashes     map[string]string      `json:"hashes"`
 	Repository *RepositoryInfo        `json:"repository,omitempty"`
 	Doctor     *DoctorState           `json:"doctor,omitempty"`
+	// Resolutions is an audit log of conflicts resolved automatically by
+	// policy, oldest first.
+	Resolutions []ResolutionRecord `json:"resolutions,omitempty"`
+	// CreatedDirs lists the directories dot created in the target
+	// directory, relative to it and sorted, so they can be removed once
+	// they are empty again.
+	CreatedDirs []string `json:"created_dirs,omitempty"`
+	// Checksum is the content hash of the manifest as saved, used to
+	// detect tampering or corruption. See Checksum.
+	Checksum string `json:"checksum,omitempty"`
+}
+
+// MaxResolutionRecords bounds the resolution audit log; older records are
+// dropped first.
+const MaxResolutionRecords = 500
+
+// ResolutionRecord records how a conflict was resolved during a run.
+type ResolutionRecord struct {
+	ResolvedAt time.Time `json:"resolved_at"`
+	Package    string    `json:"package,omitempty"`
+	Type       string    `json:"type"`
+	Path       string    `json:"path"`
+	Policy     string    `json:"policy"`
+	BackupPath string    `json:"backup_path,omitempty"`
 }
 
 // PackageSource indicates how a package was installed
@@ -37,6 +61,24 @@ type PackageInfo struct {
 	Source      PackageSource     `json:"source,omitempty"`      // How package was installed (adopted vs managed)
 	TargetDir   string            `json:"target_dir,omitempty"`  // Target directory where symlinks are created
 	PackageDir  string            `json:"package_dir,omitempty"` // Package directory containing source files
+	// Junctions lists the links, a subset of Links, created as Windows
+	// directory junctions because symbolic links were not permitted.
+	Junctions []string `json:"junctions,omitempty"`
+	// Sources maps each link to the absolute source path it was created to
+	// point at. Links recorded before sources were tracked have no entry.
+	Sources map[string]string `json:"sources,omitempty"`
+	// Include lists the globs that restricted which of the package's files
+	// were linked. Empty means the whole package is managed.
+	Include []string `json:"include,omitempty"`
+	// StateHash fingerprints what manage last linked for the package: its
+	// desired links, include globs, link mode, and file contents. Manage
+	// skips the package while the hash matches and its links are intact.
+	StateHash string `json:"state_hash,omitempty"`
+}
+
+// Partial reports whether only part of the package is managed.
+func (p PackageInfo) Partial() bool {
+	return len(p.Include) > 0
 }
 
 // RepositoryInfo contains metadata about the cloned repository.
@@ -122,6 +164,50 @@ func (m *Manifest) PackageList() []PackageInfo {
 	return packages
 }
 
+// AddResolutions appends records to the resolution audit log, keeping at
+// most MaxResolutionRecords of the most recent entries.
+func (m *Manifest) AddResolutions(records ...ResolutionRecord) {
+	if len(records) == 0 {
+		return
+	}
+	m.Resolutions = append(m.Resolutions, records...)
+	if excess := len(m.Resolutions) - MaxResolutionRecords; excess > 0 {
+		m.Resolutions = append([]ResolutionRecord(nil), m.Resolutions[excess:]...)
+	}
+	m.UpdatedAt = time.Now()
+}
+
+// AddCreatedDirs records directories dot created, keeping CreatedDirs
+// sorted and free of duplicates.
+func (m *Manifest) AddCreatedDirs(dirs ...string) {
+	if len(dirs) == 0 {
+		return
+	}
+	for _, dir := range dirs {
+		if i, found := slices.BinarySearch(m.CreatedDirs, dir); !found {
+			m.CreatedDirs = slices.Insert(m.CreatedDirs, i, dir)
+		}
+	}
+	m.UpdatedAt = time.Now()
+}
+
+// RemoveCreatedDirs forgets directories recorded by AddCreatedDirs.
+func (m *Manifest) RemoveCreatedDirs(dirs ...string) {
+	removed := false
+	for _, dir := range dirs {
+		if i, found := slices.BinarySearch(m.CreatedDirs, dir); found {
+			m.CreatedDirs = slices.Delete(m.CreatedDirs, i, i+1)
+			removed = true
+		}
+	}
+	if len(m.CreatedDirs) == 0 {
+		m.CreatedDirs = nil
+	}
+	if removed {
+		m.UpdatedAt = time.Now()
+	}
+}
+
 // SetRepository sets the repository information for the manifest.
 func (m *Manifest) SetRepository(info RepositoryInfo) {
 	m.Repository = &info
@@ -2,6 +2,7 @@ package manifest
 
 import (
 	"encoding/json"
+	"fmt"
 	"testing"
 	"time"
 
@@ -398,3 +399,43 @@ func TestPackageInfo_BackwardCompatibility_NoDirectories(t *testing.T) {
 	assert.Equal(t, "", pkg.TargetDir)
 	assert.Equal(t, "", pkg.PackageDir)
 }
+
+func TestManifest_AddResolutions(t *testing.T) {
+	m := New()
+	m.AddResolutions()
+	assert.Empty(t, m.Resolutions)
+
+	m.AddResolutions(ResolutionRecord{Path: "/home/user/.bashrc", Policy: "backup", BackupPath: "/backup/.bashrc.1"})
+	m.AddResolutions(ResolutionRecord{Path: "/home/user/.vimrc", Policy: "overwrite"})
+
+	require.Len(t, m.Resolutions, 2)
+	assert.Equal(t, "/home/user/.bashrc", m.Resolutions[0].Path)
+	assert.Equal(t, "/home/user/.vimrc", m.Resolutions[1].Path)
+}
+
+func TestManifest_AddResolutions_KeepsMostRecent(t *testing.T) {
+	m := New()
+	for i := 0; i < MaxResolutionRecords+10; i++ {
+		m.AddResolutions(ResolutionRecord{Path: fmt.Sprintf("/home/user/.file%d", i), Policy: "skip"})
+	}
+
+	require.Len(t, m.Resolutions, MaxResolutionRecords)
+	assert.Equal(t, "/home/user/.file10", m.Resolutions[0].Path)
+	assert.Equal(t, fmt.Sprintf("/home/user/.file%d", MaxResolutionRecords+9), m.Resolutions[MaxResolutionRecords-1].Path)
+}
+
+func TestManifest_CreatedDirs(t *testing.T) {
+	m := New()
+	m.AddCreatedDirs()
+	assert.Nil(t, m.CreatedDirs)
+
+	m.AddCreatedDirs(".config/nvim", ".config", ".local/share")
+	m.AddCreatedDirs(".config")
+	assert.Equal(t, []string{".config", ".config/nvim", ".local/share"}, m.CreatedDirs)
+
+	m.RemoveCreatedDirs(".config/nvim", ".missing")
+	assert.Equal(t, []string{".config", ".local/share"}, m.CreatedDirs)
+
+	m.RemoveCreatedDirs(".config", ".local/share")
+	assert.Nil(t, m.CreatedDirs, "an empty list is omitted from the manifest")
+}
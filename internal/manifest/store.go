@@ -15,4 +15,12 @@ type ManifestStore interface {
 	// Save persists manifest to target directory
 	// Write is atomic via temp file and rename
 	Save(ctx context.Context, targetDir domain.TargetPath, manifest Manifest) error
+
+	// Update applies fn to the stored manifest and saves it if fn reports
+	// a change, excluding other writers from the read to the write
+	Update(ctx context.Context, targetDir domain.TargetPath, fn func(m *Manifest) (bool, error)) error
+
+	// Verify reports whether the stored manifest matches its checksum
+	// Returns true if the manifest doesn't exist or has no checksum
+	Verify(ctx context.Context, targetDir domain.TargetPath) (bool, error)
 }
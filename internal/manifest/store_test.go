@@ -13,8 +13,10 @@ func TestManifestStore_Interface(t *testing.T) {
 }
 
 type mockManifestStore struct {
-	loadFn func(context.Context, domain.TargetPath) domain.Result[Manifest]
-	saveFn func(context.Context, domain.TargetPath, Manifest) error
+	loadFn   func(context.Context, domain.TargetPath) domain.Result[Manifest]
+	saveFn   func(context.Context, domain.TargetPath, Manifest) error
+	verifyFn func(context.Context, domain.TargetPath) (bool, error)
+	updateFn func(context.Context, domain.TargetPath, func(*Manifest) (bool, error)) error
 }
 
 func (m *mockManifestStore) Load(ctx context.Context, target domain.TargetPath) domain.Result[Manifest] {
@@ -24,3 +26,11 @@ func (m *mockManifestStore) Load(ctx context.Context, target domain.TargetPath)
 func (m *mockManifestStore) Save(ctx context.Context, target domain.TargetPath, manifest Manifest) error {
 	return m.saveFn(ctx, target, manifest)
 }
+
+func (m *mockManifestStore) Verify(ctx context.Context, target domain.TargetPath) (bool, error) {
+	return m.verifyFn(ctx, target)
+}
+
+func (m *mockManifestStore) Update(ctx context.Context, target domain.TargetPath, fn func(*Manifest) (bool, error)) error {
+	return m.updateFn(ctx, target, fn)
+}
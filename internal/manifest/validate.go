@@ -127,12 +127,7 @@ func (v *Validator) validateLink(ctx context.Context, fullPath, linkPath, pkgNam
 	}
 
 	// Resolve relative targets against the symlink's directory
-	resolvedTarget := target
-	if !filepath.IsAbs(target) {
-		// Relative target - resolve against symlink's directory
-		linkDir := filepath.Dir(fullPath)
-		resolvedTarget = filepath.Clean(filepath.Join(linkDir, target))
-	}
+	resolvedTarget := domain.ResolveLinkTarget(fullPath, target)
 
 	// Check if resolved target exists
 	targetExists := v.fs.Exists(ctx, resolvedTarget)
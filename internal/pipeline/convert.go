@@ -42,6 +42,35 @@ func convertWarnings(warnings []planner.Warning) []domain.WarningInfo {
 	return infos
 }
 
+// convertResolutions converts planner.Resolution to domain.ResolutionInfo for
+// plan metadata, attributing each resolution to the package whose link
+// caused the conflict.
+func convertResolutions(packages []domain.Package, resolutions []planner.Resolution) []domain.ResolutionInfo {
+	if len(resolutions) == 0 {
+		return nil
+	}
+
+	infos := make([]domain.ResolutionInfo, 0, len(resolutions))
+	for _, r := range resolutions {
+		info := domain.ResolutionInfo{
+			Type:       r.Type.String(),
+			Path:       r.Path,
+			Policy:     r.Policy.String(),
+			BackupPath: r.BackupPath,
+		}
+		if linkOp, ok := r.Op.(domain.LinkCreate); ok {
+			for _, pkg := range packages {
+				if isUnderPath(linkOp.Source.String(), pkg.Path.String()) {
+					info.Package = pkg.Name
+					break
+				}
+			}
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
 // copyContext creates a shallow copy of a context map.
 // Returns nil if the input is nil, otherwise returns a new map with copied entries.
 // This prevents shared mutation between planner structures and public API metadata.
@@ -2,6 +2,7 @@ package pipeline
 
 import (
 	"context"
+	"fmt"
 	"path/filepath"
 	"strings"
 
@@ -18,8 +19,12 @@ type ManagePipelineOpts struct {
 	ScanConfig         scanner.ScanConfig
 	Policies           planner.ResolutionPolicies
 	BackupDir          string
+	LinkMode           planner.LinkMode // zero value creates absolute links
+	Folding            bool             // link whole directories where possible
 	PackageNameMapping bool
-	Translate          *bool // nil means true (default behavior)
+	PackageMappings    map[string]string // package name -> target subpath
+	Translate          *bool             // nil means true (default behavior)
+	Tracer             domain.Tracer     // nil means no tracing
 }
 
 // ManageInput contains the input for manage operations
@@ -27,6 +32,19 @@ type ManageInput struct {
 	PackageDir domain.PackagePath
 	TargetDir  domain.TargetPath
 	Packages   []string
+	// PathPolicies overrides the configured resolution policy for
+	// conflicts at specific target paths.
+	PathPolicies map[string]planner.ResolutionPolicy
+	// Adopt resolves conflicts with existing files by moving each file
+	// into its package, overriding the configured file-exists policy.
+	// AdoptReplace lets an adopted file replace a package file with
+	// different content.
+	Adopt        bool
+	AdoptReplace bool
+	// Include restricts each named package to the files whose path
+	// within the package, or one of its parent directories, matches one
+	// of its globs. Packages without globs include every file.
+	Include map[string][]string
 }
 
 // ManagePipeline implements the complete manage workflow.
@@ -37,6 +55,9 @@ type ManagePipeline struct {
 
 // NewManagePipeline creates a new Manage pipeline with the given options.
 func NewManagePipeline(opts ManagePipelineOpts) *ManagePipeline {
+	if opts.Tracer == nil {
+		opts.Tracer = domain.NewNoopTracer()
+	}
 	return &ManagePipeline{
 		opts: opts,
 	}
@@ -44,6 +65,8 @@ func NewManagePipeline(opts ManagePipelineOpts) *ManagePipeline {
 
 // Execute runs the complete manage pipeline.
 // It performs: scan packages -> compute desired state -> resolve conflicts -> sort operations
+//
+// The scan, plan, and resolve stages each run in their own span.
 func (p *ManagePipeline) Execute(ctx context.Context, input ManageInput) domain.Result[domain.Plan] {
 	// Stage 1: Scan packages
 	scanInput := ScanInput{
@@ -55,25 +78,41 @@ func (p *ManagePipeline) Execute(ctx context.Context, input ManageInput) domain.
 		FS:         p.opts.FS,
 	}
 
-	scanResult := ScanStage()(ctx, scanInput)
+	scanCtx, span := p.opts.Tracer.Start(ctx, "scan")
+	scanResult := ScanStage()(scanCtx, scanInput)
 	if scanResult.IsErr() {
+		endSpan(span, scanResult.UnwrapErr())
 		return domain.Err[domain.Plan](scanResult.UnwrapErr())
 	}
 	packages := scanResult.Unwrap()
+	span.SetAttributes(domain.Attribute{Key: "package_count", Value: len(packages)})
+	endSpan(span, nil)
 
 	// Stage 2: Compute desired state
 	planInput := PlanInput{
 		Packages:           packages,
 		TargetDir:          input.TargetDir,
 		PackageNameMapping: p.opts.PackageNameMapping,
+		PackageMappings:    p.opts.PackageMappings,
 		Translate:          p.opts.Translate,
 	}
 
-	planResult := PlanStage()(ctx, planInput)
+	planCtx, span := p.opts.Tracer.Start(ctx, "plan")
+	planResult := PlanStage()(planCtx, planInput)
 	if planResult.IsErr() {
+		endSpan(span, planResult.UnwrapErr())
 		return domain.Err[domain.Plan](planResult.UnwrapErr())
 	}
-	desired := planResult.Unwrap()
+	desired, err := filterIncluded(planResult.Unwrap(), packages, input)
+	if err != nil {
+		endSpan(span, err)
+		return domain.Err[domain.Plan](err)
+	}
+	span.SetAttributes(
+		domain.Attribute{Key: "link_count", Value: len(desired.Links)},
+		domain.Attribute{Key: "dir_count", Value: len(desired.Dirs)},
+	)
+	endSpan(span, nil)
 
 	// Validate no self-management - check if any package attempts to manage dot's directories
 	for _, pkg := range packages {
@@ -86,25 +125,43 @@ func (p *ManagePipeline) Execute(ctx context.Context, input ManageInput) domain.
 	}
 
 	// Stage 3: Resolve conflicts and generate operations
+	policies := p.opts.Policies
+	if len(input.PathPolicies) > 0 {
+		policies.Paths = input.PathPolicies
+	}
+	if input.Adopt {
+		policies.OnFileExists = planner.PolicyAdopt
+		policies.AdoptReplace = input.AdoptReplace
+	}
 	resolveInput := ResolveInput{
-		Desired:   desired,
-		TargetDir: input.TargetDir,
-		FS:        p.opts.FS,
-		Policies:  p.opts.Policies,
-		BackupDir: p.opts.BackupDir,
+		Desired:    desired,
+		PackageDir: input.PackageDir,
+		TargetDir:  input.TargetDir,
+		FS:         p.opts.FS,
+		Policies:   policies,
+		BackupDir:  p.opts.BackupDir,
+		LinkMode:   p.opts.LinkMode,
+		Folding:    p.opts.Folding,
 	}
 
-	resolveResult := ResolveStage()(ctx, resolveInput)
+	resolveCtx, span := p.opts.Tracer.Start(ctx, "resolve")
+	resolveResult := ResolveStage()(resolveCtx, resolveInput)
 	if resolveResult.IsErr() {
+		endSpan(span, resolveResult.UnwrapErr())
 		return domain.Err[domain.Plan](resolveResult.UnwrapErr())
 	}
 	resolved := resolveResult.Unwrap()
+	span.SetAttributes(
+		domain.Attribute{Key: "operation_count", Value: len(resolved.Operations)},
+		domain.Attribute{Key: "conflict_count", Value: len(resolved.Conflicts)},
+	)
+	endSpan(span, nil)
 
 	// Check for unresolved conflicts
 	if resolved.HasConflicts() {
 		// Return plan with conflicts for user to handle
 		// The caller can inspect the conflicts in the metadata
-		return domain.Ok(domain.Plan{
+		return domain.Ok(domain.AnnotateRisk(ctx, p.opts.FS, domain.Plan{
 			Operations: resolved.Operations,
 			Metadata: domain.PlanMetadata{
 				PackageCount:   len(packages),
@@ -113,8 +170,9 @@ func (p *ManagePipeline) Execute(ctx context.Context, input ManageInput) domain.
 				DirCount:       countOperationsByKind(resolved.Operations, domain.OpKindDirCreate),
 				Conflicts:      convertConflicts(resolved.Conflicts),
 				Warnings:       convertWarnings(resolved.Warnings),
+				Resolutions:    convertResolutions(packages, resolved.Resolutions),
 			},
-		})
+		}))
 	}
 
 	// Stage 4: Sort operations topologically
@@ -141,12 +199,105 @@ func (p *ManagePipeline) Execute(ctx context.Context, input ManageInput) domain.
 			DirCount:       countOperationsByKind(sorted, domain.OpKindDirCreate),
 			Conflicts:      nil, // No conflicts in success path
 			Warnings:       convertWarnings(resolved.Warnings),
+			Resolutions:    convertResolutions(packages, resolved.Resolutions),
 		},
+		Batches:             planner.BatchOperations(sorted),
 		PackageOperations:   packageOps,
 		PackageSkippedLinks: buildPackageSkippedLinks(packages, resolved.Skipped),
 	}
 
-	return domain.Ok(plan)
+	return domain.Ok(domain.AnnotateRisk(ctx, p.opts.FS, plan))
+}
+
+// PackageTarget returns the directory the named package's files are linked
+// into under target, following the pipeline's package mappings.
+func (p *ManagePipeline) PackageTarget(name string, target domain.TargetPath) (domain.TargetPath, error) {
+	return planner.PackageTarget(name, target, planner.DesiredStateOptions{
+		PackageNameMapping: p.opts.PackageNameMapping,
+		PackageMappings:    p.opts.PackageMappings,
+	})
+}
+
+// DesiredState scans the input packages and computes the links and
+// directories they declare, folded as Execute would fold them. Nothing is
+// checked against the target, so the result describes the package source
+// rather than what manage would change.
+func (p *ManagePipeline) DesiredState(ctx context.Context, input ManageInput) domain.Result[planner.DesiredState] {
+	scanResult := ScanStage()(ctx, ScanInput{
+		PackageDir: input.PackageDir,
+		TargetDir:  input.TargetDir,
+		Packages:   input.Packages,
+		IgnoreSet:  p.opts.IgnoreSet,
+		ScanConfig: p.opts.ScanConfig,
+		FS:         p.opts.FS,
+	})
+	if scanResult.IsErr() {
+		return domain.Err[planner.DesiredState](scanResult.UnwrapErr())
+	}
+
+	packages := scanResult.Unwrap()
+	planResult := PlanStage()(ctx, PlanInput{
+		Packages:           packages,
+		TargetDir:          input.TargetDir,
+		PackageNameMapping: p.opts.PackageNameMapping,
+		PackageMappings:    p.opts.PackageMappings,
+		Translate:          p.opts.Translate,
+	})
+	if planResult.IsErr() {
+		return planResult
+	}
+	desired, err := filterIncluded(planResult.Unwrap(), packages, input)
+	if err != nil {
+		return domain.Err[planner.DesiredState](err)
+	}
+	if !p.opts.Folding {
+		return domain.Ok(desired)
+	}
+	return domain.Ok(planner.FoldDesiredState(ctx, desired, input.TargetDir, p.opts.FS))
+}
+
+// filterIncluded restricts desired to each package's include globs, if any.
+// A glob matches a file's path within its package, as written or with
+// dot- prefixes translated, or one of the file's parent directories.
+func filterIncluded(desired planner.DesiredState, packages []domain.Package, input ManageInput) (planner.DesiredState, error) {
+	patterns := make(map[string][]*ignore.Pattern)
+	for _, pkg := range packages {
+		for _, glob := range input.Include[pkg.Name] {
+			result := ignore.NewPattern(filepath.ToSlash(filepath.Clean(glob)))
+			if result.IsErr() {
+				return planner.DesiredState{}, fmt.Errorf("invalid include pattern %q: %w", glob, result.UnwrapErr())
+			}
+			patterns[pkg.Path.String()] = append(patterns[pkg.Path.String()], result.Unwrap())
+		}
+	}
+	if len(patterns) == 0 {
+		return desired, nil
+	}
+
+	return planner.FilterDesiredState(desired, func(link planner.LinkSpec) bool {
+		for pkgPath, globs := range patterns {
+			rel, err := filepath.Rel(pkgPath, link.Source.String())
+			if err != nil || !isUnderPath(link.Source.String(), pkgPath) {
+				continue
+			}
+			return matchesInclude(rel, globs) || matchesInclude(scanner.TranslatePathAll(rel), globs)
+		}
+		return true
+	}), nil
+}
+
+// matchesInclude reports whether rel or one of its parent directories
+// matches any of the globs.
+func matchesInclude(rel string, globs []*ignore.Pattern) bool {
+	for rel != "." && rel != "" && rel != string(filepath.Separator) {
+		for _, glob := range globs {
+			if glob.Match(filepath.ToSlash(rel)) {
+				return true
+			}
+		}
+		rel = filepath.Dir(rel)
+	}
+	return false
 }
 
 // buildPackageSkippedLinks maps package names to the target paths of link
@@ -175,6 +326,14 @@ func buildPackageSkippedLinks(packages []domain.Package, skipped []domain.Operat
 	return result
 }
 
+// endSpan records err on span, if any, and ends it.
+func endSpan(span domain.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+	}
+	span.End()
+}
+
 // countOperationsByKind counts operations of a specific kind
 func countOperationsByKind(ops []domain.Operation, kind domain.OperationKind) int {
 	count := 0
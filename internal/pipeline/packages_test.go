@@ -99,3 +99,132 @@ func TestManagePipeline_Execute(t *testing.T) {
 		assert.ErrorAs(t, err, &pkgErr)
 	})
 }
+
+func TestManagePipeline_DesiredState(t *testing.T) {
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	require.NoError(t, fs.MkdirAll(ctx, "/packages/vim/dot-vim/colors", 0755))
+	require.NoError(t, fs.WriteFile(ctx, "/packages/vim/dot-vimrc", []byte("set nocp"), 0644))
+	require.NoError(t, fs.WriteFile(ctx, "/packages/vim/dot-vim/colors/dark.vim", []byte("hi"), 0644))
+	require.NoError(t, fs.MkdirAll(ctx, "/target", 0755))
+	// An existing file must not affect the declared state
+	require.NoError(t, fs.WriteFile(ctx, "/target/.vimrc", []byte("local"), 0644))
+
+	input := ManageInput{
+		PackageDir: domain.NewPackagePath("/packages").Unwrap(),
+		TargetDir:  domain.MustParseTargetPath("/target"),
+		Packages:   []string{"vim"},
+	}
+
+	t.Run("file links", func(t *testing.T) {
+		pipeline := NewManagePipeline(ManagePipelineOpts{FS: fs, IgnoreSet: ignore.NewIgnoreSet()})
+		result := pipeline.DesiredState(ctx, input)
+		require.True(t, result.IsOk(), "%v", result)
+		links := result.Unwrap().Links
+		assert.Len(t, links, 2)
+		assert.Equal(t, "/packages/vim/dot-vimrc", links["/target/.vimrc"].Source.String())
+		assert.Contains(t, links, "/target/.vim/colors/dark.vim")
+	})
+
+	t.Run("folded", func(t *testing.T) {
+		pipeline := NewManagePipeline(ManagePipelineOpts{FS: fs, IgnoreSet: ignore.NewIgnoreSet(), Folding: true})
+		result := pipeline.DesiredState(ctx, input)
+		require.True(t, result.IsOk(), "%v", result)
+		links := result.Unwrap().Links
+		assert.Len(t, links, 2)
+		assert.Equal(t, "/packages/vim/dot-vim", links["/target/.vim"].Source.String())
+	})
+
+	t.Run("unknown package", func(t *testing.T) {
+		pipeline := NewManagePipeline(ManagePipelineOpts{FS: fs, IgnoreSet: ignore.NewIgnoreSet()})
+		missing := input
+		missing.Packages = []string{"emacs"}
+		result := pipeline.DesiredState(ctx, missing)
+		require.True(t, result.IsErr())
+		assert.ErrorAs(t, result.UnwrapErr(), &domain.ErrPackageNotFound{})
+	})
+}
+
+func TestManagePipeline_Include(t *testing.T) {
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	require.NoError(t, fs.MkdirAll(ctx, "/packages/editors/dot-config/nvim/lua", 0755))
+	require.NoError(t, fs.MkdirAll(ctx, "/packages/editors/dot-config/helix", 0755))
+	require.NoError(t, fs.WriteFile(ctx, "/packages/editors/dot-config/nvim/init.lua", []byte("-- init"), 0644))
+	require.NoError(t, fs.WriteFile(ctx, "/packages/editors/dot-config/nvim/lua/plugins.lua", []byte("-- plugins"), 0644))
+	require.NoError(t, fs.WriteFile(ctx, "/packages/editors/dot-config/helix/config.toml", []byte("theme"), 0644))
+	require.NoError(t, fs.WriteFile(ctx, "/packages/editors/dot-vimrc", []byte("set nocp"), 0644))
+	require.NoError(t, fs.MkdirAll(ctx, "/target", 0755))
+
+	input := ManageInput{
+		PackageDir: domain.NewPackagePath("/packages").Unwrap(),
+		TargetDir:  domain.MustParseTargetPath("/target"),
+		Packages:   []string{"editors"},
+		Include:    map[string][]string{"editors": {".config/nvim"}},
+	}
+	pipeline := NewManagePipeline(ManagePipelineOpts{
+		FS:        fs,
+		IgnoreSet: ignore.NewIgnoreSet(),
+		Policies:  planner.DefaultPolicies(),
+	})
+
+	t.Run("plan links only the nested subtree", func(t *testing.T) {
+		result := pipeline.Execute(ctx, input)
+		require.True(t, result.IsOk(), "%v", result)
+		plan := result.Unwrap()
+
+		var links, dirs []string
+		for _, op := range plan.Operations {
+			switch o := op.(type) {
+			case domain.LinkCreate:
+				links = append(links, o.Target.String())
+			case domain.DirCreate:
+				dirs = append(dirs, o.Path.String())
+			}
+		}
+		assert.ElementsMatch(t, []string{"/target/.config/nvim/init.lua", "/target/.config/nvim/lua/plugins.lua"}, links)
+		assert.ElementsMatch(t, []string{"/target/.config", "/target/.config/nvim", "/target/.config/nvim/lua"}, dirs)
+	})
+
+	t.Run("wildcard glob", func(t *testing.T) {
+		globbed := input
+		globbed.Include = map[string][]string{"editors": {".config/*/init.lua", ".vimrc"}}
+		result := pipeline.DesiredState(ctx, globbed)
+		require.True(t, result.IsOk(), "%v", result)
+		links := result.Unwrap().Links
+		assert.Len(t, links, 2)
+		assert.Contains(t, links, "/target/.config/nvim/init.lua")
+		assert.Contains(t, links, "/target/.vimrc")
+	})
+
+	t.Run("glob as stored in the package", func(t *testing.T) {
+		stored := input
+		stored.Include = map[string][]string{"editors": {"dot-config/nvim"}}
+		result := pipeline.DesiredState(ctx, stored)
+		require.True(t, result.IsOk(), "%v", result)
+		links := result.Unwrap().Links
+		assert.Len(t, links, 2)
+		assert.Contains(t, links, "/target/.config/nvim/lua/plugins.lua")
+	})
+
+	t.Run("packages without globs are not filtered", func(t *testing.T) {
+		require.NoError(t, fs.MkdirAll(ctx, "/packages/zsh", 0755))
+		require.NoError(t, fs.WriteFile(ctx, "/packages/zsh/dot-zshrc", []byte("autoload"), 0644))
+		both := input
+		both.Packages = []string{"editors", "zsh"}
+		result := pipeline.DesiredState(ctx, both)
+		require.True(t, result.IsOk(), "%v", result)
+		links := result.Unwrap().Links
+		assert.Len(t, links, 3)
+		assert.Contains(t, links, "/target/.zshrc")
+	})
+
+	t.Run("desired state folds within the subtree", func(t *testing.T) {
+		folding := NewManagePipeline(ManagePipelineOpts{FS: fs, IgnoreSet: ignore.NewIgnoreSet(), Folding: true})
+		result := folding.DesiredState(ctx, input)
+		require.True(t, result.IsOk(), "%v", result)
+		links := result.Unwrap().Links
+		assert.Len(t, links, 1)
+		assert.Equal(t, "/packages/editors/dot-config/nvim", links["/target/.config/nvim"].Source.String())
+	})
+}
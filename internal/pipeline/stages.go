@@ -1,6 +1,7 @@
 package pipeline
 
 import (
+	"bytes"
 	"context"
 	"path/filepath"
 
@@ -78,6 +79,90 @@ func scanCurrentState(ctx context.Context, fs domain.FSReader, desired planner.D
 	return current
 }
 
+// compareWithSources records, for each regular file at a desired link
+// target, whether its content matches the link's source in the package.
+func compareWithSources(ctx context.Context, fs domain.FSReader, desired planner.DesiredState, current planner.CurrentState) {
+	for path, info := range current.Files {
+		link, ok := desired.Links[path]
+		if !ok {
+			continue
+		}
+		existing, err := fs.ReadFile(ctx, path)
+		if err != nil {
+			continue
+		}
+		source, err := fs.ReadFile(ctx, link.Source.String())
+		if err != nil {
+			continue
+		}
+		info.SameAsSource = bytes.Equal(existing, source)
+		current.Files[path] = info
+	}
+}
+
+// adoptBackupDir returns the directory adopted files are set aside in, or
+// "" when no conflict was resolved by adopting the file. Every backup of a
+// run shares the run's subdirectory of the backup directory.
+func adoptBackupDir(result planner.ResolveResult) string {
+	for _, r := range result.Resolutions {
+		if r.Policy == planner.PolicyAdopt {
+			return filepath.Dir(r.BackupPath)
+		}
+	}
+	return ""
+}
+
+// backupDirOperations returns the operations creating backupDir and any
+// missing parents, outermost first, so adopted files can be moved into it.
+func backupDirOperations(ctx context.Context, fs domain.FSReader, backupDir string) []domain.Operation {
+	var missing []string
+	for dir := filepath.Clean(backupDir); !fs.Exists(ctx, dir); dir = filepath.Dir(dir) {
+		missing = append(missing, dir)
+		if filepath.Dir(dir) == dir {
+			break
+		}
+	}
+
+	ops := make([]domain.Operation, 0, len(missing))
+	for i := len(missing) - 1; i >= 0; i-- {
+		pathResult := domain.NewFilePath(missing[i])
+		if pathResult.IsErr() {
+			continue
+		}
+		ops = append(ops, domain.NewDirCreate(domain.OperationID("adopt-backup-dir-"+missing[i]), pathResult.Unwrap()))
+	}
+	return ops
+}
+
+// scanBackups returns the paths of the files already in the backup
+// directory and in its run subdirectories. A missing or unreadable
+// directory holds none.
+func scanBackups(ctx context.Context, fs domain.FSReader, backupDir string) map[string]struct{} {
+	backups := make(map[string]struct{})
+	if backupDir == "" {
+		return backups
+	}
+	entries, err := fs.ReadDir(ctx, backupDir)
+	if err != nil {
+		return backups
+	}
+	for _, entry := range entries {
+		path := filepath.Join(backupDir, entry.Name())
+		backups[path] = struct{}{}
+		if !entry.IsDir() {
+			continue
+		}
+		runEntries, err := fs.ReadDir(ctx, path)
+		if err != nil {
+			continue
+		}
+		for _, runEntry := range runEntries {
+			backups[filepath.Join(path, runEntry.Name())] = struct{}{}
+		}
+	}
+	return backups
+}
+
 // addParentPaths adds all parent directory paths to the set
 func addParentPaths(path string, paths map[string]struct{}) {
 	dir := filepath.Dir(path)
@@ -131,7 +216,7 @@ func ScanStage() Pipeline[ScanInput, []domain.Package] {
 
 			// Use ScanPackageWithConfig if any advanced features are enabled
 			var pkgResult domain.Result[domain.Package]
-			if input.ScanConfig.PerPackageIgnore || input.ScanConfig.MaxFileSize > 0 {
+			if input.ScanConfig.PerPackageIgnore || input.ScanConfig.MaxFileSize > 0 || input.ScanConfig.Concurrency > 1 || input.ScanConfig.Cache != nil {
 				pkgResult = scanner.ScanPackageWithConfig(ctx, input.FS, pkgPath, pkgName, input.IgnoreSet, input.ScanConfig)
 			} else {
 				// Use standard scan for backward compatibility
@@ -154,7 +239,8 @@ type PlanInput struct {
 	Packages           []domain.Package
 	TargetDir          domain.TargetPath
 	PackageNameMapping bool
-	Translate          *bool // nil means true (default behavior)
+	PackageMappings    map[string]string // package name -> target subpath
+	Translate          *bool             // nil means true (default behavior)
 }
 
 // PlanStage creates a pipeline stage that computes desired state.
@@ -172,17 +258,24 @@ func PlanStage() Pipeline[PlanInput, planner.DesiredState] {
 		if input.Translate != nil {
 			translate = *input.Translate
 		}
-		return planner.ComputeDesiredState(input.Packages, input.TargetDir, input.PackageNameMapping, translate)
+		return planner.ComputeDesiredStateWithOptions(input.Packages, input.TargetDir, planner.DesiredStateOptions{
+			PackageNameMapping: input.PackageNameMapping,
+			Translate:          translate,
+			PackageMappings:    input.PackageMappings,
+		})
 	}
 }
 
 // ResolveInput contains the input for conflict resolution
 type ResolveInput struct {
-	Desired   planner.DesiredState
-	TargetDir domain.TargetPath
-	FS        domain.FS
-	Policies  planner.ResolutionPolicies
-	BackupDir string
+	Desired    planner.DesiredState
+	PackageDir domain.PackagePath
+	TargetDir  domain.TargetPath
+	FS         domain.FS
+	Policies   planner.ResolutionPolicies
+	BackupDir  string
+	LinkMode   planner.LinkMode
+	Folding    bool
 }
 
 // ResolveStage creates a pipeline stage that resolves conflicts.
@@ -196,8 +289,14 @@ func ResolveStage() Pipeline[ResolveInput, planner.ResolveResult] {
 		default:
 		}
 
+		desired := input.Desired
+		if input.Folding {
+			desired = planner.FoldDesiredState(ctx, desired, input.TargetDir, input.FS)
+		}
+
 		// Convert desired state to operations
-		operations := planner.ComputeOperationsFromDesiredState(input.Desired)
+		policy := planner.NewLinkPolicy(ctx, input.LinkMode, input.FS)
+		operations := planner.ComputeOperationsFromDesiredState(desired, policy)
 
 		// Check for cancellation before building current state
 		select {
@@ -208,7 +307,8 @@ func ResolveStage() Pipeline[ResolveInput, planner.ResolveResult] {
 
 		// Scan only the specific paths we care about for conflict detection
 		// This is much more efficient than scanning the entire target directory
-		current := scanCurrentState(ctx, input.FS, input.Desired)
+		current := scanCurrentState(ctx, input.FS, desired)
+		current.Backups = scanBackups(ctx, input.FS, input.BackupDir)
 
 		// Check for cancellation before potentially long-running conflict resolution
 		select {
@@ -217,8 +317,18 @@ func ResolveStage() Pipeline[ResolveInput, planner.ResolveResult] {
 		default:
 		}
 
+		if input.Policies.Adopts() {
+			compareWithSources(ctx, input.FS, desired, current)
+		}
+
 		// Resolve conflicts
 		result := planner.Resolve(operations, current, input.Policies, input.BackupDir)
+		for _, c := range planner.FoldedDirConflicts(desired, current, input.PackageDir.String()) {
+			result = result.WithConflict(c)
+		}
+		if dir := adoptBackupDir(result); dir != "" {
+			result.Operations = append(backupDirOperations(ctx, input.FS, dir), result.Operations...)
+		}
 		return domain.Ok(result)
 	}
 }
@@ -445,3 +445,16 @@ func TestScanCurrentState_DeepNesting(t *testing.T) {
 	assert.Len(t, result.Files, 1, "should detect 1 file")
 	assert.Contains(t, result.Files, "/target/a/b/c/d/e/deep.txt")
 }
+
+func TestScanBackups_IncludesRunDirectories(t *testing.T) {
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	require.NoError(t, fs.MkdirAll(ctx, "/backup/20240101-153045", 0755))
+	require.NoError(t, fs.WriteFile(ctx, "/backup/.bashrc.bak", []byte("old"), 0644))
+	require.NoError(t, fs.WriteFile(ctx, "/backup/20240101-153045/.bashrc.20240101-153045", []byte("old"), 0644))
+
+	backups := scanBackups(ctx, fs, "/backup")
+
+	assert.Contains(t, backups, "/backup/.bashrc.bak")
+	assert.Contains(t, backups, "/backup/20240101-153045/.bashrc.20240101-153045")
+}
@@ -0,0 +1,88 @@
+package planner
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+)
+
+// BackupStrategy chooses how PolicyBackup names the backups it makes.
+type BackupStrategy string
+
+const (
+	// BackupTimestamped names each backup <timestamp>/<name>.<timestamp>,
+	// so every run keeps its backups in a subdirectory of its own. When
+	// that name is already taken, by an earlier backup or one made in the
+	// same plan, a counter is appended (<name>.<timestamp>-2), so no backup
+	// is ever overwritten. This is the default.
+	BackupTimestamped BackupStrategy = "timestamped"
+	// BackupOverwrite names each backup <name><suffix> directly in the
+	// backup directory, replacing any earlier backup of a file with the
+	// same name.
+	BackupOverwrite BackupStrategy = "overwrite"
+)
+
+// DefaultBackupSuffix is the suffix BackupOverwrite appends when none is
+// configured.
+const DefaultBackupSuffix = ".bak"
+
+// backupTimestampLayout formats the timestamp in timestamped backup names
+// and run directories.
+const backupTimestampLayout = "20060102-150405"
+
+// backupNamer chooses the backup path for each conflicting file in a plan.
+type backupNamer struct {
+	dir      string
+	strategy BackupStrategy
+	suffix   string
+	stamp    string
+	// taken holds backup paths already in use, on disk or earlier in the
+	// plan
+	taken map[string]struct{}
+}
+
+// newBackupNamer returns a namer for backups in dir. existing lists the
+// files already in dir; it is not modified.
+func newBackupNamer(dir string, policies ResolutionPolicies, existing map[string]struct{}, now time.Time) *backupNamer {
+	taken := make(map[string]struct{}, len(existing))
+	for path := range existing {
+		taken[path] = struct{}{}
+	}
+	return &backupNamer{
+		dir:      dir,
+		strategy: policies.BackupStrategy,
+		suffix:   policies.BackupSuffix,
+		stamp:    now.Format(backupTimestampLayout),
+		taken:    taken,
+	}
+}
+
+// backupPath returns the path PolicyBackup backs up target to, following
+// the configured strategy.
+func (n *backupNamer) backupPath(target string) string {
+	if n.strategy != BackupOverwrite {
+		return n.timestampedPath(target)
+	}
+	suffix := n.suffix
+	if suffix == "" {
+		suffix = DefaultBackupSuffix
+	}
+	path := filepath.Join(n.dir, filepath.Base(target)+suffix)
+	n.taken[path] = struct{}{}
+	return path
+}
+
+// timestampedPath returns an unused <name>.<timestamp> path for target in
+// the run's subdirectory of the backup directory and marks it taken.
+func (n *backupNamer) timestampedPath(target string) string {
+	base := filepath.Join(n.dir, n.stamp, fmt.Sprintf("%s.%s", filepath.Base(target), n.stamp))
+	path := base
+	for i := 2; ; i++ {
+		if _, ok := n.taken[path]; !ok {
+			break
+		}
+		path = fmt.Sprintf("%s-%d", base, i)
+	}
+	n.taken[path] = struct{}{}
+	return path
+}
@@ -74,7 +74,7 @@ func TestBackupPolicy_PreservesContent(t *testing.T) {
 			conflict := NewConflict(ConflictFileExists, targetPathForConflict, "File exists at target")
 
 			// Apply backup policy
-			outcome := applyBackupPolicy(linkOp, conflict, "/backup")
+			outcome := applyBackupPolicy(linkOp, conflict, testBackups("/backup"))
 
 			// Verify policy generated correct operations
 			require.Equal(t, ResolveOK, outcome.Status, "backup policy should resolve successfully")
@@ -175,7 +175,7 @@ func TestBackupPolicy_MultipleConcurrentBackups(t *testing.T) {
 		conflict := NewConflict(ConflictFileExists, targetPathForConflict, "File exists")
 
 		// Apply backup policy
-		outcome := applyBackupPolicy(linkOp, conflict, "/backup")
+		outcome := applyBackupPolicy(linkOp, conflict, testBackups("/backup"))
 		require.Equal(t, ResolveOK, outcome.Status)
 
 		// Execute all operations
@@ -236,7 +236,7 @@ func TestBackupPolicy_PermissionPreservation(t *testing.T) {
 			targetPathForConflict := domain.MustParsePath(targetPath)
 			conflict := NewConflict(ConflictFileExists, targetPathForConflict, "File exists")
 
-			outcome := applyBackupPolicy(linkOp, conflict, "/backup")
+			outcome := applyBackupPolicy(linkOp, conflict, testBackups("/backup"))
 			require.Equal(t, ResolveOK, outcome.Status)
 
 			// Execute backup operation only
@@ -0,0 +1,101 @@
+package planner
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaklabco/dot/internal/domain"
+)
+
+// testBackups returns a namer for dir with the default strategy and no
+// existing backups.
+func testBackups(dir string) *backupNamer {
+	return newBackupNamer(dir, ResolutionPolicies{}, nil, time.Now())
+}
+
+// backupOf returns the backup path of the FileBackup operation in outcome.
+func backupOf(t *testing.T, outcome ResolutionOutcome) string {
+	t.Helper()
+	require.Equal(t, ResolveOK, outcome.Status)
+	backupOp, ok := outcome.Operations[0].(domain.FileBackup)
+	require.True(t, ok, "first operation should be FileBackup")
+	assert.Equal(t, backupOp.Backup.String(), outcome.Resolution.BackupPath, "resolution records the final path")
+	return backupOp.Backup.String()
+}
+
+func TestApplyBackupPolicy_Strategies(t *testing.T) {
+	now := time.Date(2024, 1, 1, 15, 30, 45, 0, time.Local)
+	sourcePath := domain.NewFilePath("/packages/bash/dot-bashrc").Unwrap()
+	targetPath := domain.NewTargetPath("/home/user/.bashrc").Unwrap()
+	op := domain.NewLinkCreate("link-bashrc", sourcePath, targetPath)
+	conflict := NewConflict(ConflictFileExists, domain.NewFilePath(targetPath.String()).Unwrap(), "File exists")
+
+	t.Run("timestamped by default", func(t *testing.T) {
+		backups := newBackupNamer("/backup", ResolutionPolicies{}, nil, now)
+		assert.Equal(t, "/backup/20240101-153045/.bashrc.20240101-153045", backupOf(t, applyBackupPolicy(op, conflict, backups)))
+	})
+
+	t.Run("timestamped second backup does not clobber the first", func(t *testing.T) {
+		existing := map[string]struct{}{"/backup/20240101-153045/.bashrc.20240101-153045": {}}
+		backups := newBackupNamer("/backup", ResolutionPolicies{BackupStrategy: BackupTimestamped}, existing, now)
+
+		second := backupOf(t, applyBackupPolicy(op, conflict, backups))
+		third := backupOf(t, applyBackupPolicy(op, conflict, backups))
+
+		assert.Equal(t, "/backup/20240101-153045/.bashrc.20240101-153045-2", second)
+		assert.Equal(t, "/backup/20240101-153045/.bashrc.20240101-153045-3", third, "backups planned earlier are taken too")
+		assert.Len(t, existing, 1, "the existing set is not modified")
+	})
+
+	t.Run("timestamped backups go in the run's subdirectory", func(t *testing.T) {
+		backups := newBackupNamer("/backup", ResolutionPolicies{}, nil, now)
+		later := newBackupNamer("/backup", ResolutionPolicies{}, nil, now.Add(time.Minute))
+
+		assert.Equal(t, "/backup/20240101-153045", filepath.Dir(backupOf(t, applyBackupPolicy(op, conflict, backups))))
+		assert.Equal(t, "/backup/20240101-153145", filepath.Dir(backupOf(t, applyBackupPolicy(op, conflict, later))))
+	})
+
+	t.Run("overwrite reuses the plain path", func(t *testing.T) {
+		existing := map[string]struct{}{"/backup/.bashrc.bak": {}}
+		backups := newBackupNamer("/backup", ResolutionPolicies{BackupStrategy: BackupOverwrite}, existing, now)
+		assert.Equal(t, "/backup/.bashrc.bak", backupOf(t, applyBackupPolicy(op, conflict, backups)))
+	})
+
+	t.Run("overwrite with a custom suffix", func(t *testing.T) {
+		backups := newBackupNamer("/backup", ResolutionPolicies{BackupStrategy: BackupOverwrite, BackupSuffix: ".orig"}, nil, now)
+		assert.Equal(t, "/backup/.bashrc.orig", backupOf(t, applyBackupPolicy(op, conflict, backups)))
+	})
+}
+
+func TestResolve_TimestampedBackupsOfSameName(t *testing.T) {
+	// Two conflicting files with the same name in one plan
+	var ops []domain.Operation
+	current := CurrentState{
+		Files:   map[string]FileInfo{},
+		Links:   map[string]LinkTarget{},
+		Dirs:    map[string]struct{}{},
+		Backups: map[string]struct{}{},
+	}
+	for _, dir := range []string{"a", "b"} {
+		target := "/home/user/.config/" + dir + "/config"
+		current.Files[target] = FileInfo{Size: 1}
+		ops = append(ops, domain.NewLinkCreate(domain.OperationID("link-"+dir),
+			domain.NewFilePath("/packages/"+dir+"/config").Unwrap(),
+			domain.NewTargetPath(target).Unwrap()))
+	}
+
+	result := Resolve(ops, current, ResolutionPolicies{OnFileExists: PolicyBackup}, "/backup")
+	require.Empty(t, result.Conflicts)
+
+	var backups []string
+	for _, op := range result.Operations {
+		if backup, ok := op.(domain.FileBackup); ok {
+			backups = append(backups, backup.Backup.String())
+		}
+	}
+	require.Len(t, backups, 2)
+	assert.NotEqual(t, backups[0], backups[1])
+}
@@ -3,7 +3,7 @@ package planner
 
 import (
 	"fmt"
-	"path/filepath"
+	"sort"
 
 	"github.com/yaklabco/dot/internal/domain"
 	"github.com/yaklabco/dot/internal/scanner"
@@ -37,6 +37,20 @@ func (pr PlanResult) HasConflicts() bool {
 	return pr.Resolved != nil && pr.Resolved.HasConflicts()
 }
 
+// DesiredStateOptions controls how package files map to target paths.
+type DesiredStateOptions struct {
+	// PackageNameMapping prepends the translated package name to each
+	// target path, so package "dot-gnupg" targets <target>/.gnupg/.
+	PackageNameMapping bool
+
+	// Translate rewrites dot- prefixes in file names (dot-vimrc -> .vimrc).
+	Translate bool
+
+	// PackageMappings maps package names to target subpaths. A mapped
+	// package targets <target>/<subpath>/ regardless of PackageNameMapping.
+	PackageMappings map[string]string
+}
+
 // ComputeDesiredState computes desired state from packages.
 // This is a pure function that determines what links and directories
 // should exist based on the package contents.
@@ -55,6 +69,16 @@ func ComputeDesiredState(packages []domain.Package, target domain.TargetPath, pa
 		doTranslate = translate[0]
 	}
 
+	return ComputeDesiredStateWithOptions(packages, target, DesiredStateOptions{
+		PackageNameMapping: packageNameMapping,
+		Translate:          doTranslate,
+	})
+}
+
+// ComputeDesiredStateWithOptions computes desired state from packages
+// using explicit options. An explicit package mapping takes precedence
+// over package name mapping and must stay within the target directory.
+func ComputeDesiredStateWithOptions(packages []domain.Package, target domain.TargetPath, opts DesiredStateOptions) domain.Result[DesiredState] {
 	state := DesiredState{
 		Links: make(map[string]LinkSpec),
 		Dirs:  make(map[string]DirSpec),
@@ -67,7 +91,7 @@ func ComputeDesiredState(packages []domain.Package, target domain.TargetPath, pa
 		}
 
 		// Process all files in the package tree
-		if err := processPackageTree(pkg, target, packageNameMapping, doTranslate, &state); err != nil {
+		if err := processPackageTree(pkg, target, opts, &state); err != nil {
 			return domain.Err[DesiredState](err)
 		}
 	}
@@ -76,12 +100,41 @@ func ComputeDesiredState(packages []domain.Package, target domain.TargetPath, pa
 }
 
 // processPackageTree walks a package tree and adds link/dir specs to state.
-func processPackageTree(pkg domain.Package, target domain.TargetPath, packageNameMapping bool, translate bool, state *DesiredState) error {
-	return walkPackageFiles(*pkg.Tree, pkg.Path, pkg.Name, target, packageNameMapping, translate, state)
+func processPackageTree(pkg domain.Package, target domain.TargetPath, opts DesiredStateOptions, state *DesiredState) error {
+	pkgTarget, err := PackageTarget(pkg.Name, target, opts)
+	if err != nil {
+		return err
+	}
+	return walkPackageFiles(*pkg.Tree, pkg.Path, pkgTarget, target, opts.Translate, state)
 }
 
-// walkPackageFiles recursively processes files in a package tree.
-func walkPackageFiles(node domain.Node, pkgRoot domain.PackagePath, pkgName string, target domain.TargetPath, packageNameMapping bool, translate bool, state *DesiredState) error {
+// PackageTarget returns the directory a package's files are linked into.
+func PackageTarget(pkgName string, target domain.TargetPath, opts DesiredStateOptions) (domain.TargetPath, error) {
+	if subpath, ok := opts.PackageMappings[pkgName]; ok {
+		if err := domain.ValidateTargetSubpath(subpath); err != nil {
+			return domain.TargetPath{}, fmt.Errorf("package %s mapping: %w", pkgName, err)
+		}
+		result := target.JoinSafe(subpath)
+		if result.IsErr() {
+			return domain.TargetPath{}, fmt.Errorf("package %s mapping: %w", pkgName, result.UnwrapErr())
+		}
+		return result.Unwrap(), nil
+	}
+
+	if opts.PackageNameMapping {
+		// Note: TranslatePackageName is intentionally not gated by the translate flag.
+		// packageNameMapping controls directory structure (dot-gnupg -> .gnupg/),
+		// while translate controls file-level dot- prefix rewriting (dot-vimrc -> .vimrc).
+		return target.Join(scanner.TranslatePackageName(pkgName)), nil
+	}
+
+	// Legacy behavior: no package name mapping
+	return target, nil
+}
+
+// walkPackageFiles recursively processes files in a package tree, linking
+// each into pkgTarget.
+func walkPackageFiles(node domain.Node, pkgRoot domain.PackagePath, pkgTarget domain.TargetPath, target domain.TargetPath, translate bool, state *DesiredState) error {
 	// Process files only (not directories or symlinks)
 	if node.Type == domain.NodeFile {
 		// Compute relative path from package root
@@ -98,19 +151,7 @@ func walkPackageFiles(node domain.Node, pkgRoot domain.PackagePath, pkgName stri
 		}
 
 		// Compute target path
-		var targetPath domain.TargetPath
-		if packageNameMapping {
-			// Apply package name translation and prepend to path.
-			// Note: TranslatePackageName is intentionally not gated by the translate flag.
-			// packageNameMapping controls directory structure (dot-gnupg -> .gnupg/),
-			// while translate controls file-level dot- prefix rewriting (dot-vimrc -> .vimrc).
-			translatedPkgName := scanner.TranslatePackageName(pkgName)
-			combinedPath := filepath.Join(translatedPkgName, translated)
-			targetPath = target.Join(combinedPath)
-		} else {
-			// Legacy behavior: no package name mapping
-			targetPath = target.Join(translated)
-		}
+		targetPath := pkgTarget.Join(translated)
 
 		// Add link spec
 		state.Links[targetPath.String()] = LinkSpec{
@@ -126,7 +167,7 @@ func walkPackageFiles(node domain.Node, pkgRoot domain.PackagePath, pkgName stri
 
 	// Recurse on children
 	for _, child := range node.Children {
-		if err := walkPackageFiles(child, pkgRoot, pkgName, target, packageNameMapping, translate, state); err != nil {
+		if err := walkPackageFiles(child, pkgRoot, pkgTarget, target, translate, state); err != nil {
 			return err
 		}
 	}
@@ -195,22 +236,45 @@ func translatePath(path string) string {
 	return scanner.TranslatePathAll(path)
 }
 
-// ComputeOperationsFromDesiredState converts desired state into operations
-func ComputeOperationsFromDesiredState(desired DesiredState) []domain.Operation {
+// ComputeOperationsFromDesiredState converts desired state into operations.
+// An optional policy decides per link whether it stores a relative path;
+// without one, links store absolute paths.
+func ComputeOperationsFromDesiredState(desired DesiredState, policy ...LinkPolicy) []domain.Operation {
+	var linkPolicy LinkPolicy
+	if len(policy) > 0 {
+		linkPolicy = policy[0]
+	}
+
 	// Preallocate slice for directories and links
 	ops := make([]domain.Operation, 0, len(desired.Dirs)+len(desired.Links))
 
-	// Create directory operations with content-based IDs for determinism
-	for _, dirSpec := range desired.Dirs {
+	// Create directory operations with content-based IDs for determinism.
+	// Map keys are visited in sorted order so the same desired state always
+	// yields operations in the same order.
+	for _, path := range sortedKeys(desired.Dirs) {
+		dirSpec := desired.Dirs[path]
 		id := domain.OperationID(fmt.Sprintf("dir-%s", dirSpec.Path.String()))
 		ops = append(ops, domain.NewDirCreate(id, dirSpec.Path))
 	}
 
 	// Create link operations with content-based IDs for determinism
-	for _, linkSpec := range desired.Links {
+	for _, path := range sortedKeys(desired.Links) {
+		linkSpec := desired.Links[path]
 		id := domain.OperationID(fmt.Sprintf("link-%s->%s", linkSpec.Source.String(), linkSpec.Target.String()))
-		ops = append(ops, domain.NewLinkCreate(id, linkSpec.Source, linkSpec.Target))
+		op := domain.NewLinkCreate(id, linkSpec.Source, linkSpec.Target)
+		op.Relative = linkPolicy.Relative(linkSpec.Source.String(), linkSpec.Target.String())
+		ops = append(ops, op)
 	}
 
 	return ops
 }
+
+// sortedKeys returns the keys of m in sorted order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -542,3 +542,99 @@ func TestComputeDesiredState_PackageNameMapping(t *testing.T) {
 		assert.Equal(t, "/home/user/dotfiles/vim/dot-vimrc", linkSpec.Source.String())
 	})
 }
+
+func TestComputeDesiredStateWithOptions_PackageMappings(t *testing.T) {
+	target := domain.NewTargetPath("/home/user").Unwrap()
+
+	// singleFilePackage returns a package holding one file at rel.
+	singleFilePackage := func(name, rel string) domain.Package {
+		pkgPath := domain.NewPackagePath("/home/user/dotfiles/" + name).Unwrap()
+		fileNode := domain.Node{
+			Path: domain.NewFilePath("/home/user/dotfiles/" + name + "/" + rel).Unwrap(),
+			Type: domain.NodeFile,
+		}
+		return domain.Package{Name: name, Path: pkgPath, Tree: &fileNode}
+	}
+
+	packages := []domain.Package{
+		singleFilePackage("dot-work-ssh", "config"),
+		singleFilePackage("dot-gnupg", "common.conf"),
+	}
+
+	tests := []struct {
+		name               string
+		packageNameMapping bool
+		mappings           map[string]string
+		wantLinks          []string
+	}{
+		{
+			name:               "mapped package alongside conventional one",
+			packageNameMapping: true,
+			mappings:           map[string]string{"dot-work-ssh": ".ssh"},
+			wantLinks:          []string{"/home/user/.ssh/config", "/home/user/.gnupg/common.conf"},
+		},
+		{
+			name:               "mapping applies without package name mapping",
+			packageNameMapping: false,
+			mappings:           map[string]string{"dot-work-ssh": ".ssh"},
+			wantLinks:          []string{"/home/user/.ssh/config", "/home/user/common.conf"},
+		},
+		{
+			name:               "nested mapping",
+			packageNameMapping: true,
+			mappings:           map[string]string{"dot-work-ssh": ".config/work/ssh/"},
+			wantLinks:          []string{"/home/user/.config/work/ssh/config", "/home/user/.gnupg/common.conf"},
+		},
+		{
+			name:               "mapping to target root",
+			packageNameMapping: true,
+			mappings:           map[string]string{"dot-work-ssh": "."},
+			wantLinks:          []string{"/home/user/config", "/home/user/.gnupg/common.conf"},
+		},
+		{
+			name:               "no mappings",
+			packageNameMapping: true,
+			wantLinks:          []string{"/home/user/.work-ssh/config", "/home/user/.gnupg/common.conf"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := planner.ComputeDesiredStateWithOptions(packages, target, planner.DesiredStateOptions{
+				PackageNameMapping: tt.packageNameMapping,
+				Translate:          true,
+				PackageMappings:    tt.mappings,
+			})
+			require.True(t, result.IsOk())
+
+			state := result.Unwrap()
+			links := make([]string, 0, len(state.Links))
+			for path := range state.Links {
+				links = append(links, path)
+			}
+			assert.ElementsMatch(t, tt.wantLinks, links)
+		})
+	}
+
+	t.Run("creates parent directories of mapped target", func(t *testing.T) {
+		result := planner.ComputeDesiredStateWithOptions(packages[:1], target, planner.DesiredStateOptions{
+			PackageMappings: map[string]string{"dot-work-ssh": ".config/ssh"},
+		})
+		require.True(t, result.IsOk())
+
+		state := result.Unwrap()
+		assert.Contains(t, state.Dirs, "/home/user/.config")
+		assert.Contains(t, state.Dirs, "/home/user/.config/ssh")
+	})
+
+	t.Run("rejects mappings that escape the target", func(t *testing.T) {
+		for _, subpath := range []string{"..", "../etc", ".ssh/../../etc", "/etc", ""} {
+			result := planner.ComputeDesiredStateWithOptions(packages, target, planner.DesiredStateOptions{
+				PackageNameMapping: true,
+				PackageMappings:    map[string]string{"dot-work-ssh": subpath},
+			})
+			require.True(t, result.IsErr(), "mapping %q should be rejected", subpath)
+			assert.Contains(t, result.UnwrapErr().Error(), "dot-work-ssh")
+		}
+	})
+}
@@ -0,0 +1,10 @@
+//go:build !unix
+
+package planner
+
+import "io/fs"
+
+// deviceID is unavailable on this platform; callers compare volumes instead.
+func deviceID(fs.FileInfo) (uint64, bool) {
+	return 0, false
+}
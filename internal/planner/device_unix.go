@@ -0,0 +1,18 @@
+//go:build unix
+
+package planner
+
+import (
+	"io/fs"
+	"syscall"
+)
+
+// deviceID returns the device number holding the file described by info.
+func deviceID(info fs.FileInfo) (uint64, bool) {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 0, false
+	}
+	// Dev is int32 on darwin and uint64 on linux
+	return uint64(stat.Dev), true
+}
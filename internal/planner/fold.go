@@ -0,0 +1,214 @@
+package planner
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/yaklabco/dot/internal/domain"
+)
+
+// foldCandidate is a target directory whose links all come from one
+// package directory.
+type foldCandidate struct {
+	source string // package directory providing every link beneath
+	links  int    // number of links beneath the target directory
+	valid  bool
+}
+
+// FoldDesiredState replaces the links beneath a target directory with one
+// link to the package directory that provides them, when that directory
+// can be linked as a whole. A directory folds when:
+//   - every link beneath it comes from the same package directory, with
+//     names unchanged by dotfile translation;
+//   - the package directory holds only those files, so ignored files are
+//     never exposed through the folded link;
+//   - the target directory does not exist yet, or is already a link to
+//     that package directory.
+//
+// The outermost foldable directory wins. The target directory itself is
+// never folded.
+func FoldDesiredState(ctx context.Context, desired DesiredState, target domain.TargetPath, fsys domain.FSReader) DesiredState {
+	root := filepath.Clean(target.String())
+	candidates := collectFoldCandidates(desired, root)
+
+	dirs := make([]string, 0, len(candidates))
+	for dir, c := range candidates {
+		if c.valid {
+			dirs = append(dirs, dir)
+		}
+	}
+	// Shallowest first, so an outer fold absorbs the directories inside it
+	sort.Slice(dirs, func(i, j int) bool {
+		if len(dirs[i]) != len(dirs[j]) {
+			return len(dirs[i]) < len(dirs[j])
+		}
+		return dirs[i] < dirs[j]
+	})
+
+	var folded []string
+	for _, dir := range dirs {
+		if underAny(dir, folded) {
+			continue
+		}
+		c := candidates[dir]
+		if !foldableTarget(ctx, fsys, dir, c.source) {
+			continue
+		}
+		if n, ok := countPackageFiles(ctx, fsys, c.source); !ok || n != c.links {
+			continue
+		}
+		folded = append(folded, dir)
+	}
+	if len(folded) == 0 {
+		return desired
+	}
+
+	result := DesiredState{
+		Links: make(map[string]LinkSpec, len(desired.Links)),
+		Dirs:  make(map[string]DirSpec, len(desired.Dirs)),
+	}
+	for path, spec := range desired.Links {
+		if !underAny(path, folded) {
+			result.Links[path] = spec
+		}
+	}
+	for path, spec := range desired.Dirs {
+		if !underAny(path, folded) && !slices.Contains(folded, path) {
+			result.Dirs[path] = spec
+		}
+	}
+	for _, dir := range folded {
+		source := domain.NewFilePath(candidates[dir].source)
+		link := domain.NewTargetPath(dir)
+		if source.IsErr() || link.IsErr() {
+			return desired
+		}
+		result.Links[dir] = LinkSpec{Source: source.Unwrap(), Target: link.Unwrap()}
+	}
+	return result
+}
+
+// collectFoldCandidates records, for every directory between the target
+// root and a link, which package directory provides the link. Directories
+// fed by several package directories, or whose names differ between target
+// and package, are marked invalid.
+func collectFoldCandidates(desired DesiredState, root string) map[string]*foldCandidate {
+	candidates := make(map[string]*foldCandidate)
+	for path, spec := range desired.Links {
+		link := filepath.Clean(path)
+		source := filepath.Clean(spec.Source.String())
+		for dir := filepath.Dir(link); dir != root && isBelow(dir, root); dir = filepath.Dir(dir) {
+			rel := link[len(dir)+1:]
+			c, ok := candidates[dir]
+			if !ok {
+				c = &foldCandidate{valid: true}
+				candidates[dir] = c
+			}
+			c.links++
+
+			if !strings.HasSuffix(source, string(filepath.Separator)+rel) {
+				c.valid = false
+				continue
+			}
+			sourceDir := source[:len(source)-len(rel)-1]
+			switch {
+			case c.source == "":
+				c.source = sourceDir
+			case c.source != sourceDir:
+				c.valid = false
+			}
+		}
+	}
+	return candidates
+}
+
+// foldableTarget reports whether a link to source can be placed at dir:
+// nothing exists there, or dir already links to source.
+func foldableTarget(ctx context.Context, fsys domain.FSReader, dir, source string) bool {
+	info, err := fsys.Lstat(ctx, dir)
+	if err != nil {
+		return true
+	}
+	if info.Mode()&fs.ModeSymlink == 0 {
+		return false
+	}
+	dest, err := fsys.ReadLink(ctx, dir)
+	if err != nil {
+		return false
+	}
+	return domain.ResolveLinkTarget(dir, dest) == source
+}
+
+// countPackageFiles counts the regular files beneath dir. It reports false
+// if dir holds anything else, such as a symlink, that a per-file plan would
+// not link.
+func countPackageFiles(ctx context.Context, fsys domain.FSReader, dir string) (int, bool) {
+	entries, err := fsys.ReadDir(ctx, dir)
+	if err != nil {
+		return 0, false
+	}
+	count := 0
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		switch {
+		case entry.IsDir():
+			n, ok := countPackageFiles(ctx, fsys, path)
+			if !ok {
+				return 0, false
+			}
+			count += n
+		case entry.Type()&fs.ModeType == 0:
+			count++
+		default:
+			return 0, false
+		}
+	}
+	return count, true
+}
+
+// underAny reports whether path lies strictly beneath one of dirs.
+func underAny(path string, dirs []string) bool {
+	for _, dir := range dirs {
+		if isBelow(path, dir) {
+			return true
+		}
+	}
+	return false
+}
+
+// isBelow reports whether path lies strictly beneath dir.
+func isBelow(path, dir string) bool {
+	return strings.HasPrefix(path, dir+string(filepath.Separator))
+}
+
+// FoldedDirConflicts reports desired directories that exist as links into
+// packageDir. Such a directory was folded into a package, so linking files
+// beneath it would place them inside that package instead of the target.
+func FoldedDirConflicts(desired DesiredState, current CurrentState, packageDir string) []Conflict {
+	root := filepath.Clean(packageDir)
+	var conflicts []Conflict
+	for _, path := range sortedKeys(desired.Dirs) {
+		link, ok := current.Links[path]
+		if !ok {
+			continue
+		}
+		dest := domain.ResolveLinkTarget(path, link.Target)
+		if dest != root && !isBelow(dest, root) {
+			continue
+		}
+		conflict := NewConflict(ConflictDirExpected, desired.Dirs[path].Path,
+			"Directory is folded into a package link").
+			WithContext("link_target", dest).
+			WithSuggestion(Suggestion{
+				Action:      "Unmanage the package that owns the folded directory",
+				Explanation: "Removes the directory link so both packages can link files beneath it",
+				Example:     "dot unmanage <other-package> && dot manage --no-folding <other-package> <package>",
+			})
+		conflicts = append(conflicts, conflict)
+	}
+	return conflicts
+}
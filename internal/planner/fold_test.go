@@ -0,0 +1,164 @@
+package planner
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yaklabco/dot/internal/adapters"
+	"github.com/yaklabco/dot/internal/domain"
+)
+
+// foldFixture writes package files and returns the desired state of
+// managing package "app" with package name mapping disabled.
+func foldFixture(t *testing.T, fs *adapters.MemFS, files ...string) DesiredState {
+	t.Helper()
+	ctx := context.Background()
+	require.NoError(t, fs.MkdirAll(ctx, "/home", 0755))
+
+	var children []domain.Node
+	for _, file := range files {
+		path := "/pkgs/app/" + file
+		require.NoError(t, fs.MkdirAll(ctx, filepath.Dir(path), 0755))
+		require.NoError(t, fs.WriteFile(ctx, path, []byte(file), 0644))
+		children = append(children, domain.Node{Path: domain.MustParsePath(path), Type: domain.NodeFile})
+	}
+
+	pkgPath := domain.NewPackagePath("/pkgs/app").Unwrap()
+	root := domain.Node{Path: domain.MustParsePath("/pkgs/app"), Type: domain.NodeDir, Children: children}
+	pkg := domain.Package{Name: "app", Path: pkgPath, Tree: &root}
+	target := domain.NewTargetPath("/home").Unwrap()
+
+	desired := ComputeDesiredState([]domain.Package{pkg}, target, false)
+	require.True(t, desired.IsOk())
+	return desired.Unwrap()
+}
+
+func TestFoldDesiredState(t *testing.T) {
+	tests := []struct {
+		name      string
+		files     []string
+		setup     func(t *testing.T, fs *adapters.MemFS)
+		wantLinks map[string]string
+		wantDirs  []string
+	}{
+		{
+			name:  "folds missing directory",
+			files: []string{"dot-config/nvim/init.lua", "dot-config/nvim/lua/plugins.lua"},
+			setup: func(t *testing.T, fs *adapters.MemFS) {
+				require.NoError(t, fs.MkdirAll(context.Background(), "/home/.config", 0755))
+			},
+			wantLinks: map[string]string{"/home/.config/nvim": "/pkgs/app/dot-config/nvim"},
+			wantDirs:  []string{"/home/.config"},
+		},
+		{
+			name:  "folds subdirectory of existing directory",
+			files: []string{"dot-config/nvim/init.lua", "dot-config/nvim/lua/plugins.lua"},
+			setup: func(t *testing.T, fs *adapters.MemFS) {
+				require.NoError(t, fs.MkdirAll(context.Background(), "/home/.config/nvim", 0755))
+			},
+			wantLinks: map[string]string{
+				"/home/.config/nvim/init.lua": "/pkgs/app/dot-config/nvim/init.lua",
+				"/home/.config/nvim/lua":      "/pkgs/app/dot-config/nvim/lua",
+			},
+			wantDirs: []string{"/home/.config", "/home/.config/nvim"},
+		},
+		{
+			name:  "keeps existing directory",
+			files: []string{"dot-config/nvim/init.lua"},
+			setup: func(t *testing.T, fs *adapters.MemFS) {
+				require.NoError(t, fs.MkdirAll(context.Background(), "/home/.config/nvim", 0755))
+			},
+			wantLinks: map[string]string{"/home/.config/nvim/init.lua": "/pkgs/app/dot-config/nvim/init.lua"},
+			wantDirs:  []string{"/home/.config", "/home/.config/nvim"},
+		},
+		{
+			name:  "does not fold translated names",
+			files: []string{"dot-config/nvim/dot-luarc"},
+			setup: func(t *testing.T, fs *adapters.MemFS) {
+				require.NoError(t, fs.MkdirAll(context.Background(), "/home/.config", 0755))
+			},
+			wantLinks: map[string]string{"/home/.config/nvim/.luarc": "/pkgs/app/dot-config/nvim/dot-luarc"},
+			wantDirs:  []string{"/home/.config", "/home/.config/nvim"},
+		},
+		{
+			name:  "does not expose ignored files",
+			files: []string{"dot-config/nvim/init.lua"},
+			setup: func(t *testing.T, fs *adapters.MemFS) {
+				ctx := context.Background()
+				require.NoError(t, fs.MkdirAll(ctx, "/home/.config", 0755))
+				require.NoError(t, fs.WriteFile(ctx, "/pkgs/app/dot-config/nvim/secret.pem", []byte("k"), 0600))
+			},
+			wantLinks: map[string]string{"/home/.config/nvim/init.lua": "/pkgs/app/dot-config/nvim/init.lua"},
+			wantDirs:  []string{"/home/.config", "/home/.config/nvim"},
+		},
+		{
+			name:  "keeps folded link",
+			files: []string{"dot-config/nvim/init.lua"},
+			setup: func(t *testing.T, fs *adapters.MemFS) {
+				ctx := context.Background()
+				require.NoError(t, fs.MkdirAll(ctx, "/home/.config", 0755))
+				require.NoError(t, fs.Symlink(ctx, "/pkgs/app/dot-config/nvim", "/home/.config/nvim"))
+			},
+			wantLinks: map[string]string{"/home/.config/nvim": "/pkgs/app/dot-config/nvim"},
+			wantDirs:  []string{"/home/.config"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := adapters.NewMemFS()
+			desired := foldFixture(t, fs, tt.files...)
+			tt.setup(t, fs)
+
+			folded := FoldDesiredState(context.Background(), desired, domain.NewTargetPath("/home").Unwrap(), fs)
+
+			links := make(map[string]string, len(folded.Links))
+			for path, spec := range folded.Links {
+				links[path] = spec.Source.String()
+			}
+			assert.Equal(t, tt.wantLinks, links)
+			assert.ElementsMatch(t, tt.wantDirs, sortedKeys(folded.Dirs))
+		})
+	}
+}
+
+func TestFoldDesiredState_MixedPackages(t *testing.T) {
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	desired := foldFixture(t, fs, "dot-config/nvim/init.lua")
+	require.NoError(t, fs.MkdirAll(ctx, "/home/.config", 0755))
+
+	// A second package contributes to the same directory
+	other := domain.MustParsePath("/pkgs/other/dot-config/nvim/extra.lua")
+	require.NoError(t, fs.MkdirAll(ctx, "/pkgs/other/dot-config/nvim", 0755))
+	require.NoError(t, fs.WriteFile(ctx, other.String(), []byte("x"), 0644))
+	desired.Links["/home/.config/nvim/extra.lua"] = LinkSpec{
+		Source: other,
+		Target: domain.NewTargetPath("/home/.config/nvim/extra.lua").Unwrap(),
+	}
+
+	folded := FoldDesiredState(ctx, desired, domain.NewTargetPath("/home").Unwrap(), fs)
+	assert.Len(t, folded.Links, 2)
+	assert.Contains(t, folded.Dirs, "/home/.config/nvim")
+}
+
+func TestFoldedDirConflicts(t *testing.T) {
+	desired := DesiredState{Dirs: map[string]DirSpec{
+		"/home/.config":      {Path: domain.MustParsePath("/home/.config")},
+		"/home/.config/nvim": {Path: domain.MustParsePath("/home/.config/nvim")},
+	}}
+	current := CurrentState{Links: map[string]LinkTarget{
+		"/home/.config":      {Target: "/mnt/data/config"},
+		"/home/.config/nvim": {Target: "../../pkgs/app/dot-config/nvim"},
+	}}
+
+	conflicts := FoldedDirConflicts(desired, current, "/pkgs")
+	require.Len(t, conflicts, 1)
+	assert.Equal(t, ConflictDirExpected, conflicts[0].Type)
+	assert.Equal(t, "/home/.config/nvim", conflicts[0].Path.String())
+	assert.Equal(t, "/pkgs/app/dot-config/nvim", conflicts[0].Context["link_target"])
+}
@@ -199,6 +199,10 @@ func (m *mockOperation) Rollback(ctx context.Context, fs domain.FS) error {
 	return m.op.Rollback(ctx, fs)
 }
 
+func (m *mockOperation) DryRunExecute(ctx context.Context, fs domain.FSReader) (domain.DryRunEffect, error) {
+	return m.op.DryRunExecute(ctx, fs)
+}
+
 func (m *mockOperation) String() string {
 	return m.op.String()
 }
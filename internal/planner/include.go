@@ -0,0 +1,30 @@
+package planner
+
+import (
+	"path/filepath"
+)
+
+// FilterDesiredState restricts state to the links keep accepts. Directories
+// are kept only where a remaining link needs them, so a filter selecting a
+// nested subtree still creates the directories leading to it.
+func FilterDesiredState(state DesiredState, keep func(link LinkSpec) bool) DesiredState {
+	filtered := DesiredState{
+		Links: make(map[string]LinkSpec),
+		Dirs:  make(map[string]DirSpec),
+	}
+
+	for path, spec := range state.Links {
+		if !keep(spec) {
+			continue
+		}
+		filtered.Links[path] = spec
+
+		for dir := filepath.Dir(path); dir != filepath.Dir(dir); dir = filepath.Dir(dir) {
+			if spec, ok := state.Dirs[dir]; ok {
+				filtered.Dirs[dir] = spec
+			}
+		}
+	}
+
+	return filtered
+}
@@ -0,0 +1,80 @@
+package planner_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yaklabco/dot/internal/domain"
+	"github.com/yaklabco/dot/internal/planner"
+)
+
+func TestFilterDesiredState(t *testing.T) {
+	target := filepath.Join(string(filepath.Separator), "home", "user")
+	link := func(rel string) planner.LinkSpec {
+		path := filepath.Join(target, rel)
+		return planner.LinkSpec{
+			Source: domain.NewFilePath(filepath.Join(string(filepath.Separator), "dotfiles", "editors", rel)).Unwrap(),
+			Target: domain.NewTargetPath(path).Unwrap(),
+		}
+	}
+	dir := func(rel string) planner.DirSpec {
+		return planner.DirSpec{Path: domain.NewFilePath(filepath.Join(target, rel)).Unwrap()}
+	}
+
+	state := planner.DesiredState{
+		Links: map[string]planner.LinkSpec{},
+		Dirs:  map[string]planner.DirSpec{},
+	}
+	for _, rel := range []string{".config/nvim/init.lua", ".config/nvim/lua/plugins.lua", ".config/helix/config.toml", ".vimrc"} {
+		rel = filepath.FromSlash(rel)
+		state.Links[filepath.Join(target, rel)] = link(rel)
+	}
+	for _, rel := range []string{".config", ".config/nvim", ".config/nvim/lua", ".config/helix"} {
+		rel = filepath.FromSlash(rel)
+		state.Dirs[filepath.Join(target, rel)] = dir(rel)
+	}
+
+	tests := []struct {
+		name      string
+		keep      func(planner.LinkSpec) bool
+		wantLinks []string
+		wantDirs  []string
+	}{
+		{
+			name:      "nested subtree keeps its parents",
+			keep:      func(link planner.LinkSpec) bool { return strings.Contains(link.Source.String(), "nvim") },
+			wantLinks: []string{".config/nvim/init.lua", ".config/nvim/lua/plugins.lua"},
+			wantDirs:  []string{".config", ".config/nvim", ".config/nvim/lua"},
+		},
+		{
+			name:      "file match",
+			keep:      func(link planner.LinkSpec) bool { return filepath.Base(link.Source.String()) == ".vimrc" },
+			wantLinks: []string{".vimrc"},
+		},
+		{
+			name: "no match",
+			keep: func(planner.LinkSpec) bool { return false },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filtered := planner.FilterDesiredState(state, tt.keep)
+
+			links := make([]string, 0, len(filtered.Links))
+			for path := range filtered.Links {
+				rel, _ := filepath.Rel(target, path)
+				links = append(links, filepath.ToSlash(rel))
+			}
+			dirs := make([]string, 0, len(filtered.Dirs))
+			for path := range filtered.Dirs {
+				rel, _ := filepath.Rel(target, path)
+				dirs = append(dirs, filepath.ToSlash(rel))
+			}
+			assert.ElementsMatch(t, tt.wantLinks, links)
+			assert.ElementsMatch(t, tt.wantDirs, dirs)
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package planner
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	"github.com/yaklabco/dot/internal/domain"
+)
+
+// LinkMode selects how a link stores the path of its source.
+type LinkMode int
+
+const (
+	// LinkModeAbsolute stores the absolute source path (zero value).
+	LinkModeAbsolute LinkMode = iota
+	// LinkModeRelative stores the source relative to the link's directory.
+	LinkModeRelative
+	// LinkModeAuto chooses per link: relative when source and target share
+	// a common base directory on the same device, absolute otherwise.
+	LinkModeAuto
+)
+
+// LinkPolicy decides, for each link, whether it stores a relative path.
+type LinkPolicy struct {
+	Mode LinkMode
+
+	// SameDevice reports whether two paths are on the same device. It is
+	// consulted only in LinkModeAuto; nil treats every path as one device.
+	SameDevice func(a, b string) bool
+}
+
+// NewLinkPolicy creates a policy for mode that reads device information
+// from fs.
+func NewLinkPolicy(ctx context.Context, mode LinkMode, fs domain.FSReader) LinkPolicy {
+	return LinkPolicy{
+		Mode: mode,
+		SameDevice: func(a, b string) bool {
+			return sameDevice(ctx, fs, a, b)
+		},
+	}
+}
+
+// Relative reports whether the link at target pointing to source should
+// store a relative path.
+//
+// In LinkModeAuto a relative path is chosen only when it is stable: source
+// and target must share a directory below the filesystem root, so moving
+// that directory keeps the link valid, and both must live on the same
+// device, so mounts cannot change the path between them.
+func (p LinkPolicy) Relative(source, target string) bool {
+	switch p.Mode {
+	case LinkModeRelative:
+		return true
+	case LinkModeAuto:
+		if !shareBase(source, target) {
+			return false
+		}
+		return p.SameDevice == nil || p.SameDevice(source, filepath.Dir(target))
+	default:
+		return false
+	}
+}
+
+// shareBase reports whether two absolute paths have a common ancestor other
+// than the filesystem root.
+func shareBase(a, b string) bool {
+	a, b = filepath.Clean(a), filepath.Clean(b)
+	if filepath.VolumeName(a) != filepath.VolumeName(b) {
+		return false
+	}
+	sep := string(filepath.Separator)
+	partsA := strings.Split(strings.TrimPrefix(a[len(filepath.VolumeName(a)):], sep), sep)
+	partsB := strings.Split(strings.TrimPrefix(b[len(filepath.VolumeName(b)):], sep), sep)
+	return len(partsA) > 1 && len(partsB) > 1 && partsA[0] != "" && partsA[0] == partsB[0]
+}
+
+// sameDevice reports whether a and b are on the same device. Paths that do
+// not exist yet are judged by their nearest existing ancestor. When device
+// numbers are unavailable, paths on the same volume count as one device.
+func sameDevice(ctx context.Context, fs domain.FSReader, a, b string) bool {
+	devA, okA := nearestDevice(ctx, fs, a)
+	devB, okB := nearestDevice(ctx, fs, b)
+	if okA && okB {
+		return devA == devB
+	}
+	return filepath.VolumeName(a) == filepath.VolumeName(b)
+}
+
+// nearestDevice returns the device of path or its closest existing ancestor.
+func nearestDevice(ctx context.Context, fs domain.FSReader, path string) (uint64, bool) {
+	for {
+		if info, err := fs.Stat(ctx, path); err == nil {
+			return deviceID(info)
+		}
+		parent := filepath.Dir(path)
+		if parent == path {
+			return 0, false
+		}
+		path = parent
+	}
+}
@@ -0,0 +1,124 @@
+package planner
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yaklabco/dot/internal/adapters"
+	"github.com/yaklabco/dot/internal/domain"
+)
+
+// devices maps path prefixes to device numbers; the longest prefix wins,
+// mimicking a mount table.
+func devices(mounts map[string]int) func(a, b string) bool {
+	device := func(path string) int {
+		best, dev := -1, 0
+		for prefix, d := range mounts {
+			if (path == prefix || strings.HasPrefix(path, prefix+"/")) && len(prefix) > best {
+				best, dev = len(prefix), d
+			}
+		}
+		return dev
+	}
+	return func(a, b string) bool { return device(a) == device(b) }
+}
+
+func TestLinkPolicy_Relative(t *testing.T) {
+	sameDevice := devices(map[string]int{"/": 1})
+	splitHome := devices(map[string]int{"/": 1, "/home/user/mnt": 2})
+
+	tests := []struct {
+		name   string
+		policy LinkPolicy
+		source string
+		target string
+		want   bool
+	}{
+		{"absolute mode", LinkPolicy{Mode: LinkModeAbsolute}, "/home/user/dotfiles/vim/dot-vimrc", "/home/user/.vimrc", false},
+		{"relative mode", LinkPolicy{Mode: LinkModeRelative}, "/opt/dotfiles/vim/dot-vimrc", "/home/user/.vimrc", true},
+		{"auto same base same device", LinkPolicy{Mode: LinkModeAuto, SameDevice: sameDevice}, "/home/user/dotfiles/vim/dot-vimrc", "/home/user/.vimrc", true},
+		{"auto only root in common", LinkPolicy{Mode: LinkModeAuto, SameDevice: sameDevice}, "/opt/dotfiles/vim/dot-vimrc", "/home/user/.vimrc", false},
+		{"auto across devices", LinkPolicy{Mode: LinkModeAuto, SameDevice: splitHome}, "/home/user/mnt/dotfiles/vim/dot-vimrc", "/home/user/.vimrc", false},
+		{"auto same mounted device", LinkPolicy{Mode: LinkModeAuto, SameDevice: splitHome}, "/home/user/mnt/dotfiles/vim/dot-vimrc", "/home/user/mnt/.vimrc", true},
+		{"auto without device check", LinkPolicy{Mode: LinkModeAuto}, "/home/user/dotfiles/vim/dot-vimrc", "/home/user/.vimrc", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.policy.Relative(tt.source, tt.target))
+		})
+	}
+}
+
+func TestShareBase(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"/home/user/dotfiles/vim", "/home/user/.vimrc", true},
+		{"/home/a", "/home/b", true},
+		{"/home/user", "/opt/user", false},
+		{"/home", "/home/user", false},
+		{"/", "/home/user", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+" "+tt.b, func(t *testing.T) {
+			assert.Equal(t, tt.want, shareBase(tt.a, tt.b))
+		})
+	}
+}
+
+func TestNewLinkPolicy_UsesNearestExistingDevice(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	fs := adapters.NewOSFilesystem()
+
+	policy := NewLinkPolicy(ctx, LinkModeAuto, fs)
+
+	// Neither path exists yet; both resolve to the temporary directory
+	source := filepath.Join(dir, "dotfiles", "vim", "dot-vimrc")
+	target := filepath.Join(dir, "home", ".vimrc")
+	assert.True(t, policy.SameDevice(source, filepath.Dir(target)))
+}
+
+func TestComputeOperationsFromDesiredState_LinkPolicy(t *testing.T) {
+	source := domain.NewFilePath("/home/user/dotfiles/vim/dot-vimrc").Unwrap()
+	target := domain.NewTargetPath("/home/user/.vimrc").Unwrap()
+	desired := DesiredState{
+		Links: map[string]LinkSpec{target.String(): {Source: source, Target: target}},
+		Dirs:  map[string]DirSpec{},
+	}
+
+	linkOp := func(ops []domain.Operation) domain.LinkCreate {
+		require.Len(t, ops, 1)
+		op, ok := ops[0].(domain.LinkCreate)
+		require.True(t, ok)
+		return op
+	}
+
+	t.Run("absolute without policy", func(t *testing.T) {
+		op := linkOp(ComputeOperationsFromDesiredState(desired))
+		assert.False(t, op.Relative)
+		assert.Equal(t, source.String(), op.LinkValue())
+	})
+
+	t.Run("auto on one device", func(t *testing.T) {
+		policy := LinkPolicy{Mode: LinkModeAuto, SameDevice: devices(map[string]int{"/": 1})}
+		op := linkOp(ComputeOperationsFromDesiredState(desired, policy))
+		assert.True(t, op.Relative)
+		assert.Equal(t, filepath.Join("dotfiles", "vim", "dot-vimrc"), op.LinkValue())
+	})
+
+	t.Run("auto across devices", func(t *testing.T) {
+		policy := LinkPolicy{Mode: LinkModeAuto, SameDevice: devices(map[string]int{"/": 1, "/home/user/dotfiles": 2})}
+		op := linkOp(ComputeOperationsFromDesiredState(desired, policy))
+		assert.False(t, op.Relative)
+		assert.Equal(t, source.String(), op.LinkValue())
+	})
+}
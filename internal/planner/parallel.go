@@ -1,6 +1,10 @@
 package planner
 
-import "github.com/yaklabco/dot/internal/domain"
+import (
+	"path/filepath"
+
+	"github.com/yaklabco/dot/internal/domain"
+)
 
 // ParallelizationPlan computes batches of operations that can execute concurrently.
 // Returns a slice of batches where operations within each batch have no dependencies
@@ -70,3 +74,94 @@ func (g *DependencyGraph) ParallelizationPlan() [][]domain.Operation {
 
 	return levels
 }
+
+// BatchOperations groups topologically sorted operations into batches that
+// can execute concurrently, keeping the order of operations that share a
+// path. Operations declare few explicit dependencies: a link does not
+// depend on the directory created for it, nor on the backup that clears
+// its target. So an operation is placed in the batch after every earlier
+// operation it depends on or whose paths equal, contain, or lie within its
+// own. Operations of unknown kinds run alone, after everything before them
+// and before everything after them.
+//
+// Time complexity: O(n * d) where n is the number of operations and d is
+// the depth of the paths they touch.
+func BatchOperations(sorted []domain.Operation) [][]domain.Operation {
+	var batches [][]domain.Operation
+	levels := make(map[domain.OperationID]int, len(sorted))
+	// touched holds the highest level of an operation on each exact path;
+	// within holds the highest level of an operation on or under each path.
+	touched := make(map[string]int)
+	within := make(map[string]int)
+	floor := 0
+
+	for _, op := range sorted {
+		level := floor
+		for _, dep := range op.Dependencies() {
+			if depLevel, ok := levels[dep.ID()]; ok {
+				level = max(level, depLevel+1)
+			}
+		}
+
+		paths, known := operationPaths(op)
+		if !known {
+			level = max(level, len(batches))
+			floor = level + 1
+		}
+		for _, path := range paths {
+			if l, ok := within[path]; ok {
+				level = max(level, l+1)
+			}
+			for dir := path; dir != filepath.Dir(dir); {
+				dir = filepath.Dir(dir)
+				if l, ok := touched[dir]; ok {
+					level = max(level, l+1)
+				}
+			}
+		}
+
+		levels[op.ID()] = level
+		for _, path := range paths {
+			touched[path] = max(touched[path], level)
+			for dir := path; ; dir = filepath.Dir(dir) {
+				within[dir] = max(within[dir], level)
+				if dir == filepath.Dir(dir) {
+					break
+				}
+			}
+		}
+		for len(batches) <= level {
+			batches = append(batches, nil)
+		}
+		batches[level] = append(batches[level], op)
+	}
+
+	return batches
+}
+
+// operationPaths returns the paths an operation reads or writes, and false
+// for operation kinds whose paths are unknown.
+func operationPaths(op domain.Operation) ([]string, bool) {
+	switch o := op.(type) {
+	case domain.LinkCreate:
+		return []string{o.Source.String(), o.Target.String()}, true
+	case domain.LinkDelete:
+		return []string{o.Target.String()}, true
+	case domain.DirCreate:
+		return []string{o.Path.String()}, true
+	case domain.DirDelete:
+		return []string{o.Path.String()}, true
+	case domain.DirRemoveAll:
+		return []string{o.Path.String()}, true
+	case domain.FileMove:
+		return []string{o.Source.String(), o.Dest.String()}, true
+	case domain.FileBackup:
+		return []string{o.Source.String(), o.Backup.String()}, true
+	case domain.FileDelete:
+		return []string{o.Path.String()}, true
+	case domain.DirCopy:
+		return []string{o.Source.String(), o.Dest.String()}, true
+	default:
+		return nil, false
+	}
+}
@@ -333,3 +333,106 @@ func formatPath(template string, args ...interface{}) string {
 	}
 	return result
 }
+
+// batchIDs returns the operation IDs in each batch.
+func batchIDs(batches [][]domain.Operation) [][]domain.OperationID {
+	ids := make([][]domain.OperationID, 0, len(batches))
+	for _, batch := range batches {
+		batchIDs := make([]domain.OperationID, 0, len(batch))
+		for _, op := range batch {
+			batchIDs = append(batchIDs, op.ID())
+		}
+		ids = append(ids, batchIDs)
+	}
+	return ids
+}
+
+func TestBatchOperations(t *testing.T) {
+	tests := []struct {
+		name string
+		ops  []domain.Operation
+		want [][]domain.OperationID
+	}{
+		{
+			name: "empty",
+			ops:  nil,
+			want: [][]domain.OperationID{},
+		},
+		{
+			name: "independent links share a batch",
+			ops: []domain.Operation{
+				domain.NewLinkCreate("link1", mustParsePath("/pkg/vimrc"), mustParseTargetPath("/home/.vimrc")),
+				domain.NewLinkCreate("link2", mustParsePath("/pkg/zshrc"), mustParseTargetPath("/home/.zshrc")),
+				domain.NewLinkCreate("link3", mustParsePath("/pkg/bashrc"), mustParseTargetPath("/home/.bashrc")),
+			},
+			want: [][]domain.OperationID{{"link1", "link2", "link3"}},
+		},
+		{
+			name: "links wait for the directories they are created in",
+			ops: []domain.Operation{
+				domain.NewDirCreate("dir1", mustParsePath("/home/.config")),
+				domain.NewDirCreate("dir2", mustParsePath("/home/.config/nvim")),
+				domain.NewLinkCreate("link1", mustParsePath("/pkg/init.lua"), mustParseTargetPath("/home/.config/nvim/init.lua")),
+				domain.NewLinkCreate("link2", mustParsePath("/pkg/vimrc"), mustParseTargetPath("/home/.vimrc")),
+			},
+			want: [][]domain.OperationID{{"dir1", "link2"}, {"dir2"}, {"link1"}},
+		},
+		{
+			name: "operations on the same target keep their order",
+			ops: []domain.Operation{
+				domain.NewFileBackup("backup1", mustParsePath("/home/.vimrc"), mustParsePath("/backup/.vimrc.20250101-000000")),
+				domain.NewFileDelete("delete1", mustParsePath("/home/.vimrc")),
+				domain.NewLinkCreate("link1", mustParsePath("/pkg/vimrc"), mustParseTargetPath("/home/.vimrc")),
+				domain.NewFileBackup("backup2", mustParsePath("/home/.zshrc"), mustParsePath("/backup/.zshrc.20250101-000000")),
+			},
+			want: [][]domain.OperationID{{"backup1", "backup2"}, {"delete1"}, {"link1"}},
+		},
+		{
+			name: "links wait for files moved into the package",
+			ops: []domain.Operation{
+				domain.NewFileMove("move1", mustParseTargetPath("/home/.vimrc"), mustParsePath("/pkg/dot-vimrc")),
+				domain.NewLinkCreate("link1", mustParsePath("/pkg/dot-vimrc"), mustParseTargetPath("/home/.vimrc")),
+			},
+			want: [][]domain.OperationID{{"move1"}, {"link1"}},
+		},
+		{
+			name: "removing a directory waits for operations inside it",
+			ops: []domain.Operation{
+				domain.NewLinkDelete("unlink1", mustParseTargetPath("/home/.config/nvim/init.lua")),
+				domain.NewLinkDelete("unlink2", mustParseTargetPath("/home/.vimrc")),
+				domain.NewDirDelete("rmdir1", mustParsePath("/home/.config/nvim")),
+			},
+			want: [][]domain.OperationID{{"unlink1", "unlink2"}, {"rmdir1"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, batchIDs(BatchOperations(tt.ops)))
+		})
+	}
+}
+
+func TestBatchOperations_UnknownOperationRunsAlone(t *testing.T) {
+	before := domain.NewLinkCreate("link1", mustParsePath("/pkg/a"), mustParseTargetPath("/home/a"))
+	unknown := &mockOperation{op: domain.NewDirCreate("custom", mustParsePath("/elsewhere"))}
+	after := domain.NewLinkCreate("link2", mustParsePath("/pkg/b"), mustParseTargetPath("/home/b"))
+
+	batches := BatchOperations([]domain.Operation{before, unknown, after})
+
+	assert.Equal(t, [][]domain.OperationID{{"link1"}, {"custom"}, {"link2"}}, batchIDs(batches))
+}
+
+func TestBatchOperations_ExplicitDependencies(t *testing.T) {
+	dir := domain.NewDirCreate("dir1", mustParsePath("/a"))
+	link := domain.NewLinkCreate("link1", mustParsePath("/pkg/b"), mustParseTargetPath("/b"))
+	dependent := &mockOperation{
+		op:   domain.NewDirCreate("dir2", mustParsePath("/c")),
+		deps: []domain.Operation{dir},
+	}
+
+	batches := BatchOperations([]domain.Operation{dir, link, dependent})
+
+	require.Len(t, batches, 2)
+	assert.Equal(t, [][]domain.OperationID{{"dir1", "link1"}, {"dir2"}}, batchIDs(batches))
+}
@@ -2,8 +2,6 @@ package planner
 
 import (
 	"fmt"
-	"path/filepath"
-	"time"
 
 	"github.com/yaklabco/dot/internal/domain"
 )
@@ -20,6 +18,8 @@ const (
 	PolicyOverwrite
 	// PolicySkip skips conflicting operation
 	PolicySkip
+	// PolicyAdopt moves the conflicting file into the package before linking
+	PolicyAdopt
 )
 
 // String returns the string representation of ResolutionPolicy
@@ -33,6 +33,8 @@ func (rp ResolutionPolicy) String() string {
 		return "overwrite"
 	case PolicySkip:
 		return "skip"
+	case PolicyAdopt:
+		return "adopt"
 	default:
 		return "unknown"
 	}
@@ -45,6 +47,45 @@ type ResolutionPolicies struct {
 	OnPermissionErr ResolutionPolicy
 	OnCircular      ResolutionPolicy
 	OnTypeMismatch  ResolutionPolicy
+
+	// Paths overrides the policy for conflicts at specific target paths,
+	// keyed by absolute path. Interactive resolution fills it per file.
+	Paths map[string]ResolutionPolicy
+
+	// AdoptReplace lets PolicyAdopt replace a package file whose content
+	// differs from the conflicting file. Without it the difference is
+	// left as a conflict.
+	AdoptReplace bool
+
+	// BackupStrategy chooses how PolicyBackup names backups. Empty means
+	// BackupTimestamped.
+	BackupStrategy BackupStrategy
+
+	// BackupSuffix is appended to backups under BackupOverwrite. Empty
+	// means DefaultBackupSuffix.
+	BackupSuffix string
+}
+
+// Adopts reports whether any conflict may be resolved with PolicyAdopt.
+func (p ResolutionPolicies) Adopts() bool {
+	if p.OnFileExists == PolicyAdopt {
+		return true
+	}
+	for _, policy := range p.Paths {
+		if policy == PolicyAdopt {
+			return true
+		}
+	}
+	return false
+}
+
+// policyFor returns the policy for a conflict at path, preferring a
+// per-path override to the policy for the conflict's type.
+func (p ResolutionPolicies) policyFor(path string, byType ResolutionPolicy) ResolutionPolicy {
+	if policy, ok := p.Paths[path]; ok {
+		return policy
+	}
+	return byType
 }
 
 // DefaultPolicies returns safe default policies (all fail)
@@ -58,6 +99,17 @@ func DefaultPolicies() ResolutionPolicies {
 	}
 }
 
+// newResolution records how policy resolved the conflict for op.
+func newResolution(op domain.Operation, c Conflict, policy ResolutionPolicy, backupPath string) *Resolution {
+	return &Resolution{
+		Type:       c.Type,
+		Path:       c.Path.String(),
+		Policy:     policy,
+		BackupPath: backupPath,
+		Op:         op,
+	}
+}
+
 // applyFailPolicy returns unresolved conflict
 func applyFailPolicy(c Conflict) ResolutionOutcome {
 	return ResolutionOutcome{
@@ -74,8 +126,9 @@ func applySkipPolicy(op domain.LinkCreate, c Conflict) ResolutionOutcome {
 	}
 
 	return ResolutionOutcome{
-		Status:  ResolveSkip,
-		Warning: &warning,
+		Status:     ResolveSkip,
+		Warning:    &warning,
+		Resolution: newResolution(op, c, PolicySkip, ""),
 	}
 }
 
@@ -83,16 +136,12 @@ func applySkipPolicy(op domain.LinkCreate, c Conflict) ResolutionOutcome {
 func applyBackupPolicy(
 	op domain.LinkCreate,
 	conflict Conflict,
-	backupDir string,
+	backups *backupNamer,
 ) ResolutionOutcome {
-	// Generate timestamp for backup file
-	timestamp := time.Now().Format("20060102-150405")
-
-	// Extract filename from conflict path
-	filename := filepath.Base(conflict.Path.String())
+	timestamp := backups.stamp
 
-	// Generate backup path: <backupDir>/<filename>.<timestamp>
-	backupPath := filepath.Join(backupDir, fmt.Sprintf("%s.%s", filename, timestamp))
+	// The final backup path, resolved against existing backups
+	backupPath := backups.backupPath(conflict.Path.String())
 	backupFilePathResult := domain.NewFilePath(backupPath)
 	if backupFilePathResult.IsErr() {
 		// If backup path is invalid, fall back to fail policy
@@ -114,6 +163,64 @@ func applyBackupPolicy(
 	return ResolutionOutcome{
 		Status:     ResolveOK,
 		Operations: []domain.Operation{backupOp, deleteOp, op},
+		Resolution: newResolution(op, conflict, PolicyBackup, backupPath),
+	}
+}
+
+// applyAdoptPolicy moves the conflicting file into the package, then
+// creates the symlink. Every step is a move, so rolling back restores both
+// the target file and the package file.
+//
+// When the file matches the package version it is moved to the backup
+// directory and the package is left as is. Otherwise the package version is
+// moved to the backup directory and replaced by the file, which requires
+// replace; without it the conflict stands.
+func applyAdoptPolicy(
+	op domain.LinkCreate,
+	conflict Conflict,
+	file FileInfo,
+	replace bool,
+	backups *backupNamer,
+) ResolutionOutcome {
+	if !file.SameAsSource && !replace {
+		conflict.Details = fmt.Sprintf("File exists at target and differs from the package version %s", op.Source.String())
+		return applyFailPolicy(conflict.WithContext("differs_from_package", "true"))
+	}
+
+	timestamp := backups.stamp
+	targetResult := domain.NewTargetPath(conflict.Path.String())
+	sourceResult := domain.NewTargetPath(op.Source.String())
+	// Adopted files always get timestamped backups, whatever the strategy
+	backupResult := domain.NewFilePath(backups.timestampedPath(conflict.Path.String()))
+	if targetResult.IsErr() || sourceResult.IsErr() || backupResult.IsErr() {
+		return applyFailPolicy(conflict)
+	}
+	backupPath := backupResult.Unwrap()
+
+	var ops []domain.Operation
+	if file.SameAsSource {
+		// 1. FileMove: the file is already in the package, set it aside
+		ops = append(ops, domain.NewFileMove(
+			domain.OperationID(fmt.Sprintf("adopt-backup-%s-%s", conflict.Path.String(), timestamp)),
+			targetResult.Unwrap(), backupPath))
+	} else {
+		// 1. FileMove: set the package version aside
+		// 2. FileMove: move the file into its place in the package
+		ops = append(ops,
+			domain.NewFileMove(
+				domain.OperationID(fmt.Sprintf("adopt-backup-%s-%s", op.Source.String(), timestamp)),
+				sourceResult.Unwrap(), backupPath),
+			domain.NewFileMove(
+				domain.OperationID(fmt.Sprintf("adopt-%s", conflict.Path.String())),
+				targetResult.Unwrap(), op.Source))
+	}
+	// Last: LinkCreate, the original operation
+	ops = append(ops, op)
+
+	return ResolutionOutcome{
+		Status:     ResolveOK,
+		Operations: ops,
+		Resolution: newResolution(op, conflict, PolicyAdopt, backupPath.String()),
 	}
 }
 
@@ -132,5 +239,6 @@ func applyOverwritePolicy(
 	return ResolutionOutcome{
 		Status:     ResolveOK,
 		Operations: []domain.Operation{deleteOp, op},
+		Resolution: newResolution(op, conflict, PolicyOverwrite, ""),
 	}
 }
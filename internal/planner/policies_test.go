@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/yaklabco/dot/internal/domain"
 )
 
@@ -128,7 +129,7 @@ func TestApplyBackupPolicy(t *testing.T) {
 	conflict := NewConflict(ConflictFileExists, targetFilePath, "File exists")
 
 	t.Run("creates backup, delete, and link operations", func(t *testing.T) {
-		outcome := applyBackupPolicy(op, conflict, "/backup")
+		outcome := applyBackupPolicy(op, conflict, testBackups("/backup"))
 
 		assert.Equal(t, ResolveOK, outcome.Status)
 		assert.Len(t, outcome.Operations, 3, "should create 3 operations: backup, delete, link")
@@ -139,8 +140,20 @@ func TestApplyBackupPolicy(t *testing.T) {
 		assert.IsType(t, domain.LinkCreate{}, outcome.Operations[2], "third operation should be LinkCreate")
 	})
 
+	t.Run("records resolution with backup path", func(t *testing.T) {
+		outcome := applyBackupPolicy(op, conflict, testBackups("/backup"))
+
+		require.NotNil(t, outcome.Resolution)
+		backupOp := outcome.Operations[0].(domain.FileBackup)
+		assert.Equal(t, PolicyBackup, outcome.Resolution.Policy)
+		assert.Equal(t, ConflictFileExists, outcome.Resolution.Type)
+		assert.Equal(t, targetFilePath.String(), outcome.Resolution.Path)
+		assert.Equal(t, backupOp.Backup.String(), outcome.Resolution.BackupPath)
+		assert.Equal(t, op, outcome.Resolution.Op)
+	})
+
 	t.Run("backup operation has correct paths", func(t *testing.T) {
-		outcome := applyBackupPolicy(op, conflict, "/backup")
+		outcome := applyBackupPolicy(op, conflict, testBackups("/backup"))
 
 		backupOp, ok := outcome.Operations[0].(domain.FileBackup)
 		assert.True(t, ok, "first operation must be FileBackup")
@@ -150,18 +163,18 @@ func TestApplyBackupPolicy(t *testing.T) {
 	})
 
 	t.Run("backup path includes timestamp", func(t *testing.T) {
-		outcome := applyBackupPolicy(op, conflict, "/backup")
+		outcome := applyBackupPolicy(op, conflict, testBackups("/backup"))
 
 		backupOp := outcome.Operations[0].(domain.FileBackup)
 		backupPath := backupOp.Backup.String()
 
 		// Timestamp format is YYYYMMDD-HHMMSS
-		// Should have format like: /backup/.bashrc.20060102-150405
-		assert.Regexp(t, `/backup/.bashrc\.\d{8}-\d{6}$`, backupPath, "backup path should have timestamp suffix")
+		// Should have format like: /backup/20060102-150405/.bashrc.20060102-150405
+		assert.Regexp(t, `/backup/\d{8}-\d{6}/.bashrc\.\d{8}-\d{6}$`, backupPath, "backup path should have timestamp suffix")
 	})
 
 	t.Run("delete operation targets conflict path", func(t *testing.T) {
-		outcome := applyBackupPolicy(op, conflict, "/backup")
+		outcome := applyBackupPolicy(op, conflict, testBackups("/backup"))
 
 		deleteOp, ok := outcome.Operations[1].(domain.FileDelete)
 		assert.True(t, ok, "second operation must be FileDelete")
@@ -169,7 +182,7 @@ func TestApplyBackupPolicy(t *testing.T) {
 	})
 
 	t.Run("link operation is original operation", func(t *testing.T) {
-		outcome := applyBackupPolicy(op, conflict, "/backup")
+		outcome := applyBackupPolicy(op, conflict, testBackups("/backup"))
 
 		linkOp, ok := outcome.Operations[2].(domain.LinkCreate)
 		assert.True(t, ok, "third operation must be LinkCreate")
@@ -222,6 +235,84 @@ func TestApplyOverwritePolicy(t *testing.T) {
 	})
 }
 
+func TestApplyAdoptPolicy(t *testing.T) {
+	sourcePath := domain.NewFilePath("/packages/bash/dot-bashrc").Unwrap()
+	targetPath := domain.NewTargetPath("/home/user/.bashrc").Unwrap()
+	targetFilePath := domain.NewFilePath(targetPath.String()).Unwrap()
+
+	op := domain.NewLinkCreate("link-auto", sourcePath, targetPath)
+	conflict := NewConflict(ConflictFileExists, targetFilePath, "File exists")
+
+	t.Run("identical file is set aside", func(t *testing.T) {
+		outcome := applyAdoptPolicy(op, conflict, FileInfo{SameAsSource: true}, false, testBackups("/backup"))
+
+		require.Equal(t, ResolveOK, outcome.Status)
+		require.Len(t, outcome.Operations, 2)
+		move, ok := outcome.Operations[0].(domain.FileMove)
+		require.True(t, ok, "first operation should be FileMove")
+		assert.Equal(t, targetPath.String(), move.Source.String())
+		assert.Regexp(t, `^/backup/\d{8}-\d{6}/\.bashrc\.`, move.Dest.String())
+		assert.Equal(t, op, outcome.Operations[1])
+
+		require.NotNil(t, outcome.Resolution)
+		assert.Equal(t, PolicyAdopt, outcome.Resolution.Policy)
+		assert.Equal(t, move.Dest.String(), outcome.Resolution.BackupPath)
+	})
+
+	t.Run("different file is a conflict without replace", func(t *testing.T) {
+		outcome := applyAdoptPolicy(op, conflict, FileInfo{}, false, testBackups("/backup"))
+
+		require.Equal(t, ResolveConflict, outcome.Status)
+		assert.Empty(t, outcome.Operations)
+		assert.Nil(t, outcome.Resolution)
+		assert.Contains(t, outcome.Conflict.Details, "differs from the package version")
+		assert.Equal(t, "true", outcome.Conflict.Context["differs_from_package"])
+	})
+
+	t.Run("different file replaces the package version", func(t *testing.T) {
+		outcome := applyAdoptPolicy(op, conflict, FileInfo{}, true, testBackups("/backup"))
+
+		require.Equal(t, ResolveOK, outcome.Status)
+		require.Len(t, outcome.Operations, 3)
+		setAside, ok := outcome.Operations[0].(domain.FileMove)
+		require.True(t, ok, "first operation should move the package version aside")
+		assert.Equal(t, sourcePath.String(), setAside.Source.String())
+		assert.Contains(t, setAside.Dest.String(), "/backup/")
+		adopt, ok := outcome.Operations[1].(domain.FileMove)
+		require.True(t, ok, "second operation should adopt the file")
+		assert.Equal(t, targetPath.String(), adopt.Source.String())
+		assert.Equal(t, sourcePath.String(), adopt.Dest.String())
+		assert.Equal(t, op, outcome.Operations[2])
+		assert.Equal(t, setAside.Dest.String(), outcome.Resolution.BackupPath)
+	})
+}
+
+func TestResolveLinkCreate_Adopt(t *testing.T) {
+	sourcePath := domain.NewFilePath("/packages/bash/dot-bashrc").Unwrap()
+	targetPath := domain.NewTargetPath("/home/user/.bashrc").Unwrap()
+	op := domain.NewLinkCreate("link-auto", sourcePath, targetPath)
+	policies := DefaultPolicies()
+	policies.OnFileExists = PolicyAdopt
+	assert.True(t, policies.Adopts())
+	assert.False(t, DefaultPolicies().Adopts())
+
+	t.Run("regular file", func(t *testing.T) {
+		current := CurrentState{Files: map[string]FileInfo{targetPath.String(): {SameAsSource: true}}}
+		outcome := resolveLinkCreate(op, current, policies, testBackups("/backup"))
+		assert.Equal(t, ResolveOK, outcome.Status)
+		assert.Equal(t, PolicyAdopt, outcome.Resolution.Policy)
+	})
+
+	t.Run("wrong link is not adopted", func(t *testing.T) {
+		wrongLink := policies
+		wrongLink.OnWrongLink = PolicyAdopt
+		current := CurrentState{Links: map[string]LinkTarget{targetPath.String(): {Target: "/elsewhere"}}}
+		outcome := resolveLinkCreate(op, current, wrongLink, testBackups("/backup"))
+		assert.Equal(t, ResolveConflict, outcome.Status)
+		assert.Equal(t, ConflictWrongLink, outcome.Conflict.Type)
+	})
+}
+
 // Test that backup timestamps are unique
 func TestBackupTimestampsUnique(t *testing.T) {
 	sourcePath := domain.NewFilePath("/packages/bash/dot-bashrc").Unwrap()
@@ -234,7 +325,7 @@ func TestBackupTimestampsUnique(t *testing.T) {
 	// Create multiple backups rapidly
 	backupPaths := make(map[string]bool)
 	for i := 0; i < 10; i++ {
-		outcome := applyBackupPolicy(op, conflict, "/backup")
+		outcome := applyBackupPolicy(op, conflict, testBackups("/backup"))
 		backupOp := outcome.Operations[0].(domain.FileBackup)
 		path := backupOp.Backup.String()
 
@@ -2,6 +2,7 @@ package planner
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/yaklabco/dot/internal/domain"
 )
@@ -161,6 +162,17 @@ type ResolutionOutcome struct {
 	Conflict   *Conflict          // If status is ResolveConflict
 	Warning    *Warning           // If status is ResolveWarning
 	Skipped    []domain.Operation // Operations whose desired effect already exists on disk
+	Resolution *Resolution        // If a policy resolved a conflict
+}
+
+// Resolution records a conflict that a non-fail policy resolved
+// automatically, for the audit trail.
+type Resolution struct {
+	Type       ConflictType
+	Path       string
+	Policy     ResolutionPolicy
+	BackupPath string           // Set for PolicyBackup and PolicyAdopt
+	Op         domain.Operation // Operation whose conflict was resolved
 }
 
 // ResolveResult contains all resolved operations, conflicts, and warnings
@@ -172,6 +184,8 @@ type ResolveResult struct {
 	// effect already exists on disk (e.g. a symlink that already points at
 	// the correct source). They carry no work but still describe managed state.
 	Skipped []domain.Operation
+	// Resolutions lists conflicts resolved automatically by policy.
+	Resolutions []Resolution
 }
 
 // NewResolveResult creates a new ResolveResult with the given operations
@@ -224,6 +238,10 @@ func (r ResolveResult) WarningCount() int {
 type FileInfo struct {
 	Size int64
 	Mode uint32
+	// SameAsSource reports whether the file has the same content as the
+	// source of the link planned at its path. It is only determined when
+	// a policy may adopt the file.
+	SameAsSource bool
 }
 
 // LinkTarget represents a symlink target
@@ -236,6 +254,10 @@ type CurrentState struct {
 	Files map[string]FileInfo   // Regular files at target paths
 	Links map[string]LinkTarget // Existing symlinks
 	Dirs  map[string]struct{}   // Existing directories (set)
+
+	// Backups holds the files already in the backup directory, so new
+	// backups do not take their names
+	Backups map[string]struct{}
 }
 
 // detectLinkCreateConflicts checks for conflicts when creating a symlink
@@ -244,7 +266,7 @@ func detectLinkCreateConflicts(op domain.LinkCreate, current CurrentState) Resol
 
 	// Check if symlink already exists and points to the correct location
 	if link, exists := current.Links[targetKey]; exists {
-		if link.Target == op.Source.String() {
+		if domain.ResolveLinkTarget(targetKey, link.Target) == op.Source.String() {
 			// Link already correct: no operation needed, but the link is part of
 			// the desired state and must still be recorded in the manifest.
 			return ResolutionOutcome{
@@ -349,11 +371,11 @@ func resolveOperation(
 	op domain.Operation,
 	current CurrentState,
 	policies ResolutionPolicies,
-	backupDir string,
+	backups *backupNamer,
 ) ResolutionOutcome {
 	switch op := op.(type) {
 	case domain.LinkCreate:
-		return resolveLinkCreate(op, current, policies, backupDir)
+		return resolveLinkCreate(op, current, policies, backups)
 	case domain.DirCreate:
 		return resolveDirCreate(op, current, policies)
 	case domain.LinkDelete:
@@ -382,7 +404,7 @@ func resolveLinkCreate(
 	op domain.LinkCreate,
 	current CurrentState,
 	policies ResolutionPolicies,
-	backupDir string,
+	backups *backupNamer,
 ) ResolutionOutcome {
 	// Detect conflicts
 	outcome := detectLinkCreateConflicts(op, current)
@@ -404,8 +426,18 @@ func resolveLinkCreate(
 	default:
 		policy = PolicyFail
 	}
+	policy = policies.policyFor(conflict.Path.String(), policy)
 
-	return applyPolicyToLinkCreate(op, conflict, policy, backupDir)
+	if policy == PolicyAdopt {
+		// Only regular files can be adopted
+		file, isFile := current.Files[op.Target.String()]
+		if conflict.Type != ConflictFileExists || !isFile {
+			return applyFailPolicy(conflict)
+		}
+		return applyAdoptPolicy(op, conflict, file, policies.AdoptReplace, backups)
+	}
+
+	return applyPolicyToLinkCreate(op, conflict, policy, backups)
 }
 
 // resolveDirCreate detects and resolves conflicts for DirCreate operations
@@ -422,7 +454,7 @@ func resolveDirCreate(
 
 	// Apply policy
 	conflict := *outcome.Conflict
-	policy := policies.OnTypeMismatch
+	policy := policies.policyFor(conflict.Path.String(), policies.OnTypeMismatch)
 
 	return applyPolicyToDirCreate(op, conflict, policy)
 }
@@ -432,7 +464,7 @@ func applyPolicyToLinkCreate(
 	op domain.LinkCreate,
 	conflict Conflict,
 	policy ResolutionPolicy,
-	backupDir string,
+	backups *backupNamer,
 ) ResolutionOutcome {
 	switch policy {
 	case PolicyFail:
@@ -440,7 +472,7 @@ func applyPolicyToLinkCreate(
 	case PolicySkip:
 		return applySkipPolicy(op, conflict)
 	case PolicyBackup:
-		return applyBackupPolicy(op, conflict, backupDir)
+		return applyBackupPolicy(op, conflict, backups)
 	case PolicyOverwrite:
 		return applyOverwritePolicy(op, conflict)
 	default:
@@ -463,8 +495,9 @@ func applyPolicyToDirCreate(
 			Severity: WarnInfo,
 		}
 		return ResolutionOutcome{
-			Status:  ResolveSkip,
-			Warning: &warning,
+			Status:     ResolveSkip,
+			Warning:    &warning,
+			Resolution: newResolution(op, conflict, PolicySkip, ""),
 		}
 	default:
 		return applyFailPolicy(conflict)
@@ -479,9 +512,13 @@ func Resolve(
 	backupDir string,
 ) ResolveResult {
 	result := NewResolveResult(nil)
+	backups := newBackupNamer(backupDir, policies, current.Backups, time.Now())
 
 	for _, op := range operations {
-		outcome := resolveOperation(op, current, policies, backupDir)
+		outcome := resolveOperation(op, current, policies, backups)
+		if outcome.Resolution != nil {
+			result.Resolutions = append(result.Resolutions, *outcome.Resolution)
+		}
 
 		switch outcome.Status {
 		case ResolveOK:
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/yaklabco/dot/internal/domain"
 )
 
@@ -289,6 +290,25 @@ func TestDetectLinkAlreadyCorrect(t *testing.T) {
 	assert.Nil(t, outcome.Conflict)
 }
 
+func TestDetectLinkAlreadyCorrect_RelativeLink(t *testing.T) {
+	targetPath := domain.NewTargetPath("/home/user/.bashrc").Unwrap()
+	sourcePath := domain.NewFilePath("/home/user/dotfiles/bash/dot-bashrc").Unwrap()
+
+	op := domain.NewLinkCreate("link-auto", sourcePath, targetPath)
+
+	current := CurrentState{
+		Files: make(map[string]FileInfo),
+		Links: map[string]LinkTarget{
+			targetPath.String(): {Target: "dotfiles/bash/dot-bashrc"},
+		},
+	}
+
+	outcome := detectLinkCreateConflicts(op, current)
+
+	assert.Equal(t, ResolveSkip, outcome.Status)
+	assert.Nil(t, outcome.Conflict)
+}
+
 func TestDetectDirCreateConflicts(t *testing.T) {
 	t.Run("file exists where directory expected", func(t *testing.T) {
 		dirPath := domain.NewFilePath("/home/user/.config").Unwrap()
@@ -419,6 +439,70 @@ func TestResolveFunction(t *testing.T) {
 		assert.False(t, result.HasConflicts())
 		assert.Empty(t, result.Operations) // Operation was skipped
 		assert.Len(t, result.Warnings, 1)
+		require.Len(t, result.Resolutions, 1)
+		assert.Equal(t, PolicySkip, result.Resolutions[0].Policy)
+		assert.Equal(t, targetPath.String(), result.Resolutions[0].Path)
+	})
+
+	t.Run("records no resolutions without conflicts", func(t *testing.T) {
+		sourcePath := domain.NewFilePath("/packages/bash/dot-bashrc").Unwrap()
+		targetPath := domain.NewTargetPath("/home/user/.bashrc").Unwrap()
+
+		ops := []domain.Operation{
+			domain.NewLinkCreate("link-auto", sourcePath, targetPath),
+		}
+		current := CurrentState{
+			Files: make(map[string]FileInfo),
+			Links: make(map[string]LinkTarget),
+			Dirs:  make(map[string]struct{}),
+		}
+		policies := DefaultPolicies()
+		policies.OnFileExists = PolicyOverwrite
+
+		result := Resolve(ops, current, policies, "/backup")
+
+		assert.Empty(t, result.Resolutions)
+	})
+
+	t.Run("per-path policies override type policy", func(t *testing.T) {
+		bashrc := domain.NewTargetPath("/home/user/.bashrc").Unwrap()
+		vimrc := domain.NewTargetPath("/home/user/.vimrc").Unwrap()
+		profile := domain.NewTargetPath("/home/user/.profile").Unwrap()
+
+		ops := []domain.Operation{
+			domain.NewLinkCreate("link-bashrc", domain.NewFilePath("/packages/bash/dot-bashrc").Unwrap(), bashrc),
+			domain.NewLinkCreate("link-vimrc", domain.NewFilePath("/packages/vim/dot-vimrc").Unwrap(), vimrc),
+			domain.NewLinkCreate("link-profile", domain.NewFilePath("/packages/bash/dot-profile").Unwrap(), profile),
+		}
+		current := CurrentState{
+			Files: map[string]FileInfo{
+				bashrc.String():  {Size: 100},
+				vimrc.String():   {Size: 100},
+				profile.String(): {Size: 100},
+			},
+			Links: make(map[string]LinkTarget),
+			Dirs:  make(map[string]struct{}),
+		}
+		policies := DefaultPolicies()
+		policies.Paths = map[string]ResolutionPolicy{
+			bashrc.String(): PolicyBackup,
+			vimrc.String():  PolicySkip,
+		}
+
+		result := Resolve(ops, current, policies, "/backup")
+
+		// The unlisted path still fails under the type policy
+		require.Len(t, result.Conflicts, 1)
+		assert.Equal(t, profile.String(), result.Conflicts[0].Path.String())
+
+		policyByPath := make(map[string]ResolutionPolicy)
+		for _, r := range result.Resolutions {
+			policyByPath[r.Path] = r.Policy
+		}
+		assert.Equal(t, map[string]ResolutionPolicy{
+			bashrc.String(): PolicyBackup,
+			vimrc.String():  PolicySkip,
+		}, policyByPath)
 	})
 }
 
@@ -519,7 +603,7 @@ func TestResolveOperationWithAllTypes(t *testing.T) {
 		linkPath := domain.NewTargetPath("/home/user/.bashrc").Unwrap()
 		op := domain.NewLinkDelete("link-del-auto", linkPath)
 
-		outcome := resolveOperation(op, current, policies, "")
+		outcome := resolveOperation(op, current, policies, testBackups(""))
 
 		assert.Equal(t, ResolveOK, outcome.Status)
 		assert.Len(t, outcome.Operations, 1)
@@ -529,7 +613,7 @@ func TestResolveOperationWithAllTypes(t *testing.T) {
 		dirPath := domain.NewFilePath("/home/user/.config").Unwrap()
 		op := domain.NewDirDelete("dir-del-auto", dirPath)
 
-		outcome := resolveOperation(op, current, policies, "")
+		outcome := resolveOperation(op, current, policies, testBackups(""))
 
 		assert.Equal(t, ResolveOK, outcome.Status)
 		assert.Len(t, outcome.Operations, 1)
@@ -540,7 +624,7 @@ func TestResolveOperationWithAllTypes(t *testing.T) {
 		dest := domain.NewFilePath("/packages/bash/dot-bashrc").Unwrap()
 		op := domain.NewFileMove("move-auto", source, dest)
 
-		outcome := resolveOperation(op, current, policies, "")
+		outcome := resolveOperation(op, current, policies, testBackups(""))
 
 		assert.Equal(t, ResolveOK, outcome.Status)
 		assert.Len(t, outcome.Operations, 1)
@@ -551,7 +635,7 @@ func TestResolveOperationWithAllTypes(t *testing.T) {
 		backup := domain.NewFilePath("/backup/.bashrc").Unwrap()
 		op := domain.NewFileBackup("backup-auto", source, backup)
 
-		outcome := resolveOperation(op, current, policies, "")
+		outcome := resolveOperation(op, current, policies, testBackups(""))
 
 		assert.Equal(t, ResolveOK, outcome.Status)
 		assert.Len(t, outcome.Operations, 1)
@@ -590,21 +674,21 @@ func TestApplyPolicyToLinkCreateEdgeCases(t *testing.T) {
 	conflict := NewConflict(ConflictFileExists, targetFilePath, "File exists")
 
 	t.Run("backup policy creates backup and delete operations", func(t *testing.T) {
-		outcome := applyPolicyToLinkCreate(op, conflict, PolicyBackup, "/backup")
+		outcome := applyPolicyToLinkCreate(op, conflict, PolicyBackup, testBackups("/backup"))
 		// Should create FileBackup, FileDelete, and LinkCreate operations
 		assert.Equal(t, ResolveOK, outcome.Status)
 		assert.Len(t, outcome.Operations, 3)
 	})
 
 	t.Run("overwrite policy creates delete operation", func(t *testing.T) {
-		outcome := applyPolicyToLinkCreate(op, conflict, PolicyOverwrite, "/backup")
+		outcome := applyPolicyToLinkCreate(op, conflict, PolicyOverwrite, testBackups("/backup"))
 		// Should create FileDelete and LinkCreate operations
 		assert.Equal(t, ResolveOK, outcome.Status)
 		assert.Len(t, outcome.Operations, 2)
 	})
 
 	t.Run("unknown policy defaults to fail", func(t *testing.T) {
-		outcome := applyPolicyToLinkCreate(op, conflict, ResolutionPolicy(999), "/backup")
+		outcome := applyPolicyToLinkCreate(op, conflict, ResolutionPolicy(999), testBackups("/backup"))
 		assert.Equal(t, ResolveConflict, outcome.Status)
 	})
 }
@@ -628,7 +712,7 @@ func TestResolveLinkCreateWithDifferentConflicts(t *testing.T) {
 			Dirs: make(map[string]struct{}),
 		}
 
-		outcome := resolveLinkCreate(op, current, policies, "")
+		outcome := resolveLinkCreate(op, current, policies, testBackups(""))
 		assert.Equal(t, ResolveSkip, outcome.Status)
 	})
 }
@@ -1,6 +1,10 @@
 package planner
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/yaklabco/dot/internal/domain"
+)
 
 // generateSuggestions creates actionable suggestions for conflicts
 func generateSuggestions(c Conflict) []Suggestion {
@@ -22,6 +26,20 @@ func generateSuggestions(c Conflict) []Suggestion {
 
 // generateFileExistsSuggestions provides suggestions for existing files
 func generateFileExistsSuggestions(c Conflict) []Suggestion {
+	if c.Context["differs_from_package"] == "true" {
+		return []Suggestion{
+			{
+				Action:      "Use --adopt-replace to replace the package version",
+				Explanation: "Moves the package version to the backup directory and adopts the existing file",
+				Example:     "dot manage --adopt --adopt-replace <package>",
+			},
+			{
+				Action:      "Compare the file with the package version",
+				Explanation: "Decide which version to keep",
+				Example:     fmt.Sprintf("diff %s <package-file>", c.Path.String()),
+			},
+		}
+	}
 	return []Suggestion{
 		{
 			Action:      "Use --backup flag to preserve existing file",
@@ -29,9 +47,9 @@ func generateFileExistsSuggestions(c Conflict) []Suggestion {
 			Example:     "dot manage --backup <package>",
 		},
 		{
-			Action:      "Use dot adopt to move file into package",
+			Action:      "Use --adopt to move file into package",
 			Explanation: "Incorporates existing file into package management",
-			Example:     fmt.Sprintf("dot adopt <package> %s", c.Path.String()),
+			Example:     "dot manage --adopt <package>",
 		},
 		{
 			Action:      "Remove conflicting file manually",
@@ -64,13 +82,9 @@ func generateWrongLinkSuggestions(c Conflict) []Suggestion {
 
 // generatePermissionSuggestions provides suggestions for permission errors
 func generatePermissionSuggestions(c Conflict) []Suggestion {
-	parentPath := c.Path.Parent()
-	var parentStr string
-	if parentPath.IsOk() {
-		parentStr = parentPath.Unwrap().String()
-	} else {
-		parentStr = c.Path.String()
-	}
+	parentStr := domain.Match(c.Path.Parent(),
+		func(parent domain.FilePath) string { return parent.String() },
+		func(error) string { return c.Path.String() })
 
 	return []Suggestion{
 		{
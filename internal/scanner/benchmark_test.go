@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/yaklabco/dot/internal/adapters"
 	"github.com/yaklabco/dot/internal/domain"
@@ -191,3 +192,124 @@ func BenchmarkTranslatePackageName(b *testing.B) {
 		}
 	}
 }
+
+// latencyFS adds a fixed delay to directory reads, approximating a network
+// filesystem where each ReadDir is a round trip.
+type latencyFS struct {
+	domain.FS
+	delay time.Duration
+}
+
+func (f latencyFS) ReadDir(ctx context.Context, name string) ([]domain.DirEntry, error) {
+	time.Sleep(f.delay)
+	return f.FS.ReadDir(ctx, name)
+}
+
+// setupWideBenchmarkTree creates a temporary tree of width directories with
+// two levels of subdirectories, each holding a few files.
+func setupWideBenchmarkTree(b *testing.B, width int) string {
+	b.Helper()
+
+	tmpDir := b.TempDir()
+	for i := 0; i < width; i++ {
+		for j := 0; j < 4; j++ {
+			dir := filepath.Join(tmpDir, fmt.Sprintf("dir%d", i), fmt.Sprintf("sub%d", j))
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				b.Fatalf("failed to create dir: %v", err)
+			}
+			for k := 0; k < 5; k++ {
+				if err := os.WriteFile(filepath.Join(dir, fmt.Sprintf("file%d", k)), []byte("content"), 0644); err != nil {
+					b.Fatalf("failed to create file: %v", err)
+				}
+			}
+		}
+	}
+	return tmpDir
+}
+
+// BenchmarkScanTree_Wide compares serial and parallel scans of a wide tree,
+// on the local filesystem and with simulated network latency.
+func BenchmarkScanTree_Wide(b *testing.B) {
+	tmpDir := setupWideBenchmarkTree(b, 50)
+	ctx := context.Background()
+	treePath := domain.NewFilePath(tmpDir).Unwrap()
+
+	filesystems := []struct {
+		name string
+		fs   domain.FS
+	}{
+		{"local", adapters.NewOSFilesystem()},
+		{"latency", latencyFS{FS: adapters.NewOSFilesystem(), delay: 200 * time.Microsecond}},
+	}
+
+	for _, f := range filesystems {
+		for _, concurrency := range []int{1, 4, 16} {
+			name := fmt.Sprintf("%s/concurrency=%d", f.name, concurrency)
+			b.Run(name, func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					if result := ScanTreeParallel(ctx, f.fs, treePath, 0, nil, concurrency); result.IsErr() {
+						b.Fatal(result.UnwrapErr())
+					}
+				}
+			})
+		}
+	}
+}
+
+// setupIgnoredBenchmarkTree creates a package holding a few dotfiles next
+// to a deep .git directory, the shape pruning is meant for.
+func setupIgnoredBenchmarkTree(b *testing.B) string {
+	b.Helper()
+
+	tmpDir := b.TempDir()
+	for i := 0; i < 5; i++ {
+		if err := os.WriteFile(filepath.Join(tmpDir, fmt.Sprintf("dot-file%d", i)), []byte("content"), 0644); err != nil {
+			b.Fatalf("failed to create file: %v", err)
+		}
+	}
+	for i := 0; i < 64; i++ {
+		dir := filepath.Join(tmpDir, ".git", "objects", fmt.Sprintf("%02x", i), "pack", "refs")
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			b.Fatalf("failed to create dir: %v", err)
+		}
+		for j := 0; j < 8; j++ {
+			if err := os.WriteFile(filepath.Join(dir, fmt.Sprintf("obj%d", j)), []byte("object"), 0644); err != nil {
+				b.Fatalf("failed to create file: %v", err)
+			}
+		}
+	}
+	return tmpDir
+}
+
+// BenchmarkScanPackage_IgnoredDirectory compares scanning the full tree and
+// filtering it afterwards with walking it and pruning ignored directories.
+func BenchmarkScanPackage_IgnoredDirectory(b *testing.B) {
+	tmpDir := setupIgnoredBenchmarkTree(b)
+	ctx := context.Background()
+	fs := adapters.NewOSFilesystem()
+	treePath := domain.NewFilePath(tmpDir).Unwrap()
+	ignoreSet := ignore.NewDefaultIgnoreSet()
+
+	b.Run("scan+filter", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			result := ScanTree(ctx, fs, treePath)
+			if result.IsErr() {
+				b.Fatal(result.UnwrapErr())
+			}
+			_ = filterTree(result.Unwrap(), ignoreSet)
+		}
+	})
+
+	b.Run("walk+prune", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			result := WalkTree(ctx, fs, treePath, 0, nil, ignoreSkip(ignoreSet))
+			if result.IsErr() {
+				b.Fatal(result.UnwrapErr())
+			}
+			_ = filterTree(result.Unwrap(), ignoreSet)
+		}
+	})
+}
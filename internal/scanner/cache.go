@@ -0,0 +1,169 @@
+package scanner
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"time"
+
+	"github.com/yaklabco/dot/internal/domain"
+)
+
+// treeCacheVersion is the schema version of cache entries. Entries written
+// with any other version are discarded and rescanned; bump it whenever
+// the entry layout or the meaning of a cached tree changes.
+const treeCacheVersion = 2
+
+// racyWindow is how recently a directory may have been modified for its
+// tree to still be cached. Filesystems with coarse timestamps could give a
+// directory changed right after the scan the same mtime as the scanned
+// one, so such trees are rescanned until their directories settle.
+const racyWindow = time.Second
+
+// TreeCache stores scanned package trees on disk, one entry per package
+// directory, so unchanged packages need not be walked again. An entry
+// records the mtime of every directory in the tree and is used only while
+// all of them still match; adding, removing or renaming anything in a
+// directory updates its mtime and forces a rescan. Cached trees are pruned
+// by the ignore patterns they were scanned with, so an entry is also
+// discarded when those patterns change.
+type TreeCache struct {
+	fs  domain.FS
+	dir string
+}
+
+// NewTreeCache creates a cache storing entries in dir through fs.
+func NewTreeCache(fs domain.FS, dir string) *TreeCache {
+	return &TreeCache{fs: fs, dir: dir}
+}
+
+// cacheEntry is the on-disk form of a cached tree.
+type cacheEntry struct {
+	Version int    `json:"version"`
+	Root    string `json:"root"`
+	// Ignore is the fingerprint of the ignore patterns that pruned Tree.
+	Ignore string `json:"ignore"`
+	// Dirs maps every directory in the tree to its mtime in nanoseconds.
+	Dirs map[string]int64 `json:"dirs"`
+	Tree cachedNode       `json:"tree"`
+}
+
+// cachedNode is a tree node stored by name relative to its parent.
+type cachedNode struct {
+	Name     string          `json:"name"`
+	Type     domain.NodeType `json:"type"`
+	Children []cachedNode    `json:"children,omitempty"`
+}
+
+// Load returns the cached tree of root if it was pruned with the ignore
+// patterns fingerprinted by ignore and every directory in it is unchanged
+// on fsys. Unreadable, corrupt and outdated entries are misses.
+func (c *TreeCache) Load(ctx context.Context, fsys domain.FSReader, root domain.FilePath, ignore string) (domain.Node, bool) {
+	data, err := c.fs.ReadFile(ctx, c.entryPath(root))
+	if err != nil {
+		return domain.Node{}, false
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return domain.Node{}, false
+	}
+	if entry.Version != treeCacheVersion || entry.Root != root.String() || entry.Ignore != ignore || len(entry.Dirs) == 0 {
+		return domain.Node{}, false
+	}
+
+	for dir, mtime := range entry.Dirs {
+		info, err := fsys.Lstat(ctx, dir)
+		if err != nil || !info.IsDir() || info.ModTime().UnixNano() != mtime {
+			return domain.Node{}, false
+		}
+	}
+	return entry.Tree.node(root), true
+}
+
+// Store caches tree as the scan of root that started at scannedAt, pruned
+// with the ignore patterns fingerprinted by ignore. Trees with a directory
+// modified within racyWindow of scannedAt, or during the scan, are not
+// stored.
+func (c *TreeCache) Store(ctx context.Context, fsys domain.FSReader, root domain.FilePath, ignore string, tree domain.Node, scannedAt time.Time) error {
+	dirs := make(map[string]int64)
+	err := Walk(tree, func(node domain.Node) error {
+		if !node.IsDir() {
+			return nil
+		}
+		info, err := fsys.Lstat(ctx, node.Path.String())
+		if err != nil {
+			return err
+		}
+		if !info.ModTime().Before(scannedAt.Add(-racyWindow)) {
+			return errRacyDirectory
+		}
+		dirs[node.Path.String()] = info.ModTime().UnixNano()
+		return nil
+	})
+	if errors.Is(err, errRacyDirectory) || len(dirs) == 0 {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("stat scanned directory: %w", err)
+	}
+
+	data, err := json.Marshal(cacheEntry{
+		Version: treeCacheVersion,
+		Root:    root.String(),
+		Ignore:  ignore,
+		Dirs:    dirs,
+		Tree:    newCachedNode(tree),
+	})
+	if err != nil {
+		return fmt.Errorf("encode scan cache entry: %w", err)
+	}
+	if err := c.fs.MkdirAll(ctx, c.dir, 0o755); err != nil {
+		return fmt.Errorf("create scan cache directory: %w", err)
+	}
+	if err := c.fs.WriteFile(ctx, c.entryPath(root), data, 0o644); err != nil {
+		return fmt.Errorf("write scan cache entry: %w", err)
+	}
+	return nil
+}
+
+// Clear removes every cached tree.
+func (c *TreeCache) Clear(ctx context.Context) error {
+	if err := c.fs.RemoveAll(ctx, c.dir); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("clear scan cache: %w", err)
+	}
+	return nil
+}
+
+// errRacyDirectory stops Store's walk at a recently modified directory.
+var errRacyDirectory = errors.New("directory modified too recently to cache")
+
+// entryPath returns the file caching the tree of root.
+func (c *TreeCache) entryPath(root domain.FilePath) string {
+	sum := sha256.Sum256([]byte(root.String()))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:16])+".json")
+}
+
+func newCachedNode(node domain.Node) cachedNode {
+	cached := cachedNode{Name: filepath.Base(node.Path.String()), Type: node.Type}
+	for _, child := range node.Children {
+		cached.Children = append(cached.Children, newCachedNode(child))
+	}
+	return cached
+}
+
+// node rebuilds the tree rooted at path.
+func (n cachedNode) node(path domain.FilePath) domain.Node {
+	node := domain.Node{Path: path, Type: n.Type}
+	if n.Type == domain.NodeDir {
+		node.Children = make([]domain.Node, 0, len(n.Children))
+		for _, child := range n.Children {
+			node.Children = append(node.Children, child.node(path.Join(child.Name)))
+		}
+	}
+	return node
+}
@@ -0,0 +1,175 @@
+package scanner_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaklabco/dot/internal/adapters"
+	"github.com/yaklabco/dot/internal/domain"
+	"github.com/yaklabco/dot/internal/ignore"
+	"github.com/yaklabco/dot/internal/scanner"
+)
+
+// countingFS counts directory reads, each of which is part of a walk.
+type countingFS struct {
+	domain.FS
+	readDirs atomic.Int64
+}
+
+func (f *countingFS) ReadDir(ctx context.Context, name string) ([]domain.DirEntry, error) {
+	f.readDirs.Add(1)
+	return f.FS.ReadDir(ctx, name)
+}
+
+// Walk goes through ReadDir so the directories it reads are counted.
+func (f *countingFS) Walk(ctx context.Context, root string, fn domain.WalkFunc) error {
+	return domain.WalkDir(ctx, f, root, fn)
+}
+
+// setupCachedPackage creates a package on disk whose directories were
+// last modified an hour ago, old enough to be cached.
+func setupCachedPackage(t *testing.T) string {
+	t.Helper()
+	pkg := filepath.Join(t.TempDir(), "vim")
+	require.NoError(t, os.MkdirAll(filepath.Join(pkg, "dot-config", "nvim"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(pkg, "dot-vimrc"), []byte("set nu"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(pkg, "dot-config", "nvim", "init.vim"), []byte(""), 0o644))
+	for _, dir := range []string{pkg, filepath.Join(pkg, "dot-config"), filepath.Join(pkg, "dot-config", "nvim")} {
+		setMtime(t, dir, time.Now().Add(-time.Hour))
+	}
+	return pkg
+}
+
+func setMtime(t *testing.T, path string, mtime time.Time) {
+	t.Helper()
+	require.NoError(t, os.Chtimes(path, mtime, mtime))
+}
+
+func scanCached(t *testing.T, fs domain.FSReader, pkg string, cache *scanner.TreeCache) domain.Package {
+	t.Helper()
+	path := domain.NewPackagePath(pkg).Unwrap()
+	result := scanner.ScanPackageWithConfig(context.Background(), fs, path, "vim", ignore.NewIgnoreSet(), scanner.ScanConfig{Cache: cache})
+	require.False(t, result.IsErr(), "scan failed")
+	return result.Unwrap()
+}
+
+func TestTreeCache_HitSkipsWalk(t *testing.T) {
+	pkg := setupCachedPackage(t)
+	fs := &countingFS{FS: adapters.NewOSFilesystem()}
+	cache := scanner.NewTreeCache(adapters.NewMemFS(), "/cache")
+
+	first := scanCached(t, fs, pkg, cache)
+	require.Positive(t, fs.readDirs.Load())
+
+	fs.readDirs.Store(0)
+	second := scanCached(t, fs, pkg, cache)
+	assert.Zero(t, fs.readDirs.Load(), "cache hit should not read directories")
+	assert.Equal(t, first, second)
+}
+
+func TestTreeCache_MutatedDirectoryForcesRescan(t *testing.T) {
+	pkg := setupCachedPackage(t)
+	fs := &countingFS{FS: adapters.NewOSFilesystem()}
+	cache := scanner.NewTreeCache(adapters.NewMemFS(), "/cache")
+	scanCached(t, fs, pkg, cache)
+
+	// Change a nested directory only; the package root keeps its mtime
+	nvim := filepath.Join(pkg, "dot-config", "nvim")
+	require.NoError(t, os.WriteFile(filepath.Join(nvim, "lua.vim"), []byte(""), 0o644))
+	setMtime(t, nvim, time.Now().Add(-30*time.Minute))
+
+	fs.readDirs.Store(0)
+	rescanned := scanCached(t, fs, pkg, cache)
+	assert.Positive(t, fs.readDirs.Load(), "mutated directory should force a rescan")
+	assert.Contains(t, scanner.CollectFiles(*rescanned.Tree), domain.NewFilePath(filepath.Join(nvim, "lua.vim")).Unwrap())
+
+	fs.readDirs.Store(0)
+	scanCached(t, fs, pkg, cache)
+	assert.Zero(t, fs.readDirs.Load(), "rescanned tree should be cached again")
+}
+
+func TestTreeCache_DiscardsOtherVersions(t *testing.T) {
+	ctx := context.Background()
+	pkg := setupCachedPackage(t)
+	fs := &countingFS{FS: adapters.NewOSFilesystem()}
+	cacheFS := adapters.NewMemFS()
+	cache := scanner.NewTreeCache(cacheFS, "/cache")
+	scanCached(t, fs, pkg, cache)
+
+	entries, err := cacheFS.ReadDir(ctx, "/cache")
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	entryPath := filepath.Join("/cache", entries[0].Name())
+	data, err := cacheFS.ReadFile(ctx, entryPath)
+	require.NoError(t, err)
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(data, &entry))
+	entry["version"] = 0
+	data, err = json.Marshal(entry)
+	require.NoError(t, err)
+	require.NoError(t, cacheFS.WriteFile(ctx, entryPath, data, 0o644))
+
+	fs.readDirs.Store(0)
+	scanCached(t, fs, pkg, cache)
+	assert.Positive(t, fs.readDirs.Load(), "entry with another schema version should be discarded")
+}
+
+func TestTreeCache_IgnoreChangeForcesRescan(t *testing.T) {
+	pkg := setupCachedPackage(t)
+	fs := &countingFS{FS: adapters.NewOSFilesystem()}
+	cache := scanner.NewTreeCache(adapters.NewMemFS(), "/cache")
+	path := domain.NewPackagePath(pkg).Unwrap()
+	scanCached(t, fs, pkg, cache)
+
+	ignoreSet := ignore.NewIgnoreSet()
+	require.NoError(t, ignoreSet.Add("dot-config"))
+	scan := func() domain.Package {
+		result := scanner.ScanPackageWithConfig(context.Background(), fs, path, "vim", ignoreSet, scanner.ScanConfig{Cache: cache})
+		require.False(t, result.IsErr(), "scan failed")
+		return result.Unwrap()
+	}
+
+	fs.readDirs.Store(0)
+	pruned := scan()
+	assert.Equal(t, int64(1), fs.readDirs.Load(), "new ignore patterns should force a pruned rescan")
+	assert.Equal(t, []domain.FilePath{domain.NewFilePath(filepath.Join(pkg, "dot-vimrc")).Unwrap()}, scanner.CollectFiles(*pruned.Tree))
+
+	fs.readDirs.Store(0)
+	assert.Equal(t, pruned, scan())
+	assert.Zero(t, fs.readDirs.Load(), "pruned tree should be cached under its patterns")
+}
+
+func TestTreeCache_SkipsRecentlyModifiedDirectories(t *testing.T) {
+	pkg := setupCachedPackage(t)
+	setMtime(t, pkg, time.Now())
+	fs := &countingFS{FS: adapters.NewOSFilesystem()}
+	cache := scanner.NewTreeCache(adapters.NewMemFS(), "/cache")
+	scanCached(t, fs, pkg, cache)
+
+	fs.readDirs.Store(0)
+	scanCached(t, fs, pkg, cache)
+	assert.Positive(t, fs.readDirs.Load(), "tree with a racy directory should not be cached")
+}
+
+func TestTreeCache_Clear(t *testing.T) {
+	pkg := setupCachedPackage(t)
+	fs := &countingFS{FS: adapters.NewOSFilesystem()}
+	cacheFS := adapters.NewMemFS()
+	cache := scanner.NewTreeCache(cacheFS, "/cache")
+	scanCached(t, fs, pkg, cache)
+
+	require.NoError(t, cache.Clear(context.Background()))
+	assert.False(t, cacheFS.Exists(context.Background(), "/cache"))
+	require.NoError(t, cache.Clear(context.Background()), "clearing an empty cache succeeds")
+
+	fs.readDirs.Store(0)
+	scanCached(t, fs, pkg, cache)
+	assert.Positive(t, fs.readDirs.Load())
+}
@@ -3,6 +3,7 @@ package scanner
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/yaklabco/dot/internal/domain"
 	"github.com/yaklabco/dot/internal/ignore"
@@ -18,6 +19,15 @@ type ScanConfig struct {
 
 	// Interactive enables interactive prompts for large files
 	Interactive bool
+
+	// Concurrency is the maximum number of directories read in parallel
+	// (0 or 1 = scan serially)
+	Concurrency int
+
+	// Cache reuses package trees scanned earlier while their directories
+	// are unchanged (nil = always scan). It is bypassed when MaxFileSize is
+	// set, since size decisions may depend on prompts.
+	Cache *TreeCache
 }
 
 // ScanPackage scans a single package directory.
@@ -25,8 +35,8 @@ type ScanConfig struct {
 //
 // The scanner:
 // 1. Verifies package directory exists
-// 2. Scans the directory tree
-// 3. Applies ignore patterns (filtered during tree scan)
+// 2. Walks the directory tree, not descending into ignored directories
+// 3. Applies ignore patterns to the remaining entries
 // 4. Returns Package with tree
 func ScanPackage(ctx context.Context, fs domain.FSReader, path domain.PackagePath, name string, ignoreSet *ignore.IgnoreSet) domain.Result[domain.Package] {
 	// Check if package exists
@@ -38,7 +48,7 @@ func ScanPackage(ctx context.Context, fs domain.FSReader, path domain.PackagePat
 
 	// Scan the package directory tree
 	pkgFilePath := domain.NewFilePath(path.String()).Unwrap()
-	treeResult := ScanTree(ctx, fs, pkgFilePath)
+	treeResult := WalkTree(ctx, fs, pkgFilePath, 0, nil, ignoreSkip(ignoreSet))
 	if treeResult.IsErr() {
 		return domain.Err[domain.Package](treeResult.UnwrapErr())
 	}
@@ -80,9 +90,11 @@ func ScanPackageWithConfig(ctx context.Context, fs domain.FSReader, path domain.
 			return domain.Err[domain.Package](fmt.Errorf("load .dotignore: %w", err))
 		}
 
-		// Add per-package patterns (these can include negation patterns)
+		// Add per-package patterns after the global ones so they take
+		// precedence, scoped to the package root. Negation patterns can
+		// un-ignore files matched by global patterns.
 		for _, pattern := range patterns {
-			if err := packageIgnoreSet.Add(pattern); err != nil {
+			if err := packageIgnoreSet.AddScoped(pattern, path.String()); err != nil {
 				return domain.Err[domain.Package](fmt.Errorf("invalid pattern %q in .dotignore: %w", pattern, err))
 			}
 		}
@@ -100,16 +112,7 @@ func ScanPackageWithConfig(ctx context.Context, fs domain.FSReader, path domain.
 
 	// Scan the package directory tree with config
 	pkgFilePath := domain.NewFilePath(path.String()).Unwrap()
-	var treeResult domain.Result[domain.Node]
-
-	if cfg.MaxFileSize > 0 || prompter != nil {
-		// Use size-aware scanning
-		treeResult = ScanTreeWithConfig(ctx, fs, pkgFilePath, cfg.MaxFileSize, prompter)
-	} else {
-		// Use standard scanning (backward compatible)
-		treeResult = ScanTree(ctx, fs, pkgFilePath)
-	}
-
+	treeResult := scanTreeWithConfig(ctx, fs, pkgFilePath, cfg, prompter, packageIgnoreSet)
 	if treeResult.IsErr() {
 		return domain.Err[domain.Package](treeResult.UnwrapErr())
 	}
@@ -126,6 +129,47 @@ func ScanPackageWithConfig(ctx context.Context, fs domain.FSReader, path domain.
 	})
 }
 
+// scanTreeWithConfig scans the tree at root, serving it from cfg.Cache
+// when possible. Directories ignoreSet ignores are pruned from the walk,
+// and cached trees are keyed by the set's fingerprint so that pattern
+// changes force a rescan.
+func scanTreeWithConfig(ctx context.Context, fs domain.FSReader, root domain.FilePath, cfg ScanConfig, prompter LargeFilePrompter, ignoreSet *ignore.IgnoreSet) domain.Result[domain.Node] {
+	cache := cfg.Cache
+	if cfg.MaxFileSize > 0 {
+		cache = nil
+	}
+	fingerprint := ""
+	if cache != nil {
+		fingerprint = ignoreSet.Fingerprint()
+		if tree, ok := cache.Load(ctx, fs, root, fingerprint); ok {
+			return domain.Ok(tree)
+		}
+	}
+
+	scannedAt := time.Now()
+	skip := ignoreSkip(ignoreSet)
+	var treeResult domain.Result[domain.Node]
+	if cfg.Concurrency > 1 {
+		// Read sibling directories in parallel
+		treeResult = scanTreeParallel(ctx, fs, root, cfg.MaxFileSize, prompter, cfg.Concurrency, skip)
+	} else {
+		treeResult = WalkTree(ctx, fs, root, cfg.MaxFileSize, prompter, skip)
+	}
+
+	if cache != nil && treeResult.IsOk() {
+		// The cache is an optimisation; a failed write only costs a rescan
+		_ = cache.Store(ctx, fs, root, fingerprint, treeResult.Unwrap(), scannedAt)
+	}
+	return treeResult
+}
+
+// ignoreSkip returns a SkipFunc leaving out the paths ignoreSet ignores.
+func ignoreSkip(ignoreSet *ignore.IgnoreSet) SkipFunc {
+	return func(path string, _ bool) bool {
+		return ignoreSet.ShouldIgnore(path)
+	}
+}
+
 // filterTree removes ignored files from a tree.
 // Returns a new tree with ignored nodes filtered out.
 func filterTree(node domain.Node, ignoreSet *ignore.IgnoreSet) domain.Node {
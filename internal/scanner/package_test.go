@@ -2,7 +2,10 @@ package scanner_test
 
 import (
 	"context"
+	"fmt"
+	"io/fs"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -12,6 +15,18 @@ import (
 	"github.com/yaklabco/dot/internal/scanner"
 )
 
+// dirInfo describes a directory returned by a mocked Lstat.
+type dirInfo struct {
+	name string
+}
+
+func (i dirInfo) Name() string       { return i.name }
+func (i dirInfo) Size() int64        { return 0 }
+func (i dirInfo) Mode() fs.FileMode  { return fs.ModeDir | 0o755 }
+func (i dirInfo) ModTime() time.Time { return time.Time{} }
+func (i dirInfo) IsDir() bool        { return true }
+func (i dirInfo) Sys() any           { return nil }
+
 func TestScanPackage(t *testing.T) {
 	ctx := context.Background()
 	mockFS := new(MockFS)
@@ -21,8 +36,7 @@ func TestScanPackage(t *testing.T) {
 
 	// Mock: package directory exists and is empty
 	mockFS.On("Exists", ctx, "/home/user/.dotfiles/vim").Return(true)
-	mockFS.On("IsSymlink", ctx, "/home/user/.dotfiles/vim").Return(false, nil)
-	mockFS.On("IsDir", ctx, "/home/user/.dotfiles/vim").Return(true, nil)
+	mockFS.On("Lstat", ctx, "/home/user/.dotfiles/vim").Return(dirInfo{name: "vim"}, nil)
 	mockFS.On("ReadDir", ctx, "/home/user/.dotfiles/vim").Return([]domain.DirEntry{}, nil)
 
 	result := scanner.ScanPackage(ctx, mockFS, packagePath, "vim", ignoreSet)
@@ -68,8 +82,7 @@ func TestScanPackage_WithIgnorePatterns(t *testing.T) {
 
 	// Mock: package exists and is a directory
 	mockFS.On("Exists", ctx, "/home/user/.dotfiles/vim").Return(true)
-	mockFS.On("IsSymlink", ctx, "/home/user/.dotfiles/vim").Return(false, nil)
-	mockFS.On("IsDir", ctx, "/home/user/.dotfiles/vim").Return(true, nil)
+	mockFS.On("Lstat", ctx, "/home/user/.dotfiles/vim").Return(dirInfo{name: "vim"}, nil)
 	mockFS.On("ReadDir", ctx, "/home/user/.dotfiles/vim").Return([]domain.DirEntry{}, nil)
 
 	result := scanner.ScanPackage(ctx, mockFS, packagePath, "vim", ignoreSet)
@@ -359,3 +372,85 @@ func TestFilterTree_MixedIgnore(t *testing.T) {
 	assert.True(t, keptPaths["/test/data.json"])
 	assert.False(t, keptPaths["/test/ignore.log"])
 }
+
+func TestScanPackageWithConfig_DotignoreScopedToPackage(t *testing.T) {
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+
+	vimPath := "/dotfiles/vim"
+	zshPath := "/dotfiles/zsh"
+	for _, pkg := range []string{vimPath, zshPath} {
+		require.NoError(t, fs.MkdirAll(ctx, pkg+"/notes", 0755))
+		require.NoError(t, fs.WriteFile(ctx, pkg+"/dot-rc", []byte("rc"), 0644))
+		require.NoError(t, fs.WriteFile(ctx, pkg+"/scratch.txt", []byte("scratch"), 0644))
+		require.NoError(t, fs.WriteFile(ctx, pkg+"/notes/todo.md", []byte("todo"), 0644))
+		require.NoError(t, fs.WriteFile(ctx, pkg+"/notes/keep.bak", []byte("keep"), 0644))
+	}
+	// Only vim ignores its scratch file and notes, and keeps a backup
+	// the global patterns would otherwise drop
+	require.NoError(t, fs.WriteFile(ctx, vimPath+"/.dotignore", []byte("scratch.txt\nnotes/*.md\n!keep.bak\n"), 0644))
+
+	globalIgnoreSet := ignore.NewIgnoreSet()
+	require.NoError(t, globalIgnoreSet.Add("*.bak"))
+	require.NoError(t, globalIgnoreSet.Add(".dotignore"))
+	cfg := scanner.ScanConfig{PerPackageIgnore: true}
+
+	vim := scanner.ScanPackageWithConfig(ctx, fs, domain.NewPackagePath(vimPath).Unwrap(), "vim", globalIgnoreSet, cfg)
+	require.True(t, vim.IsOk())
+	zsh := scanner.ScanPackageWithConfig(ctx, fs, domain.NewPackagePath(zshPath).Unwrap(), "zsh", globalIgnoreSet, cfg)
+	require.True(t, zsh.IsOk())
+
+	assert.ElementsMatch(t, []string{
+		vimPath + "/dot-rc",
+		vimPath + "/notes/keep.bak",
+	}, filePaths(*vim.Unwrap().Tree))
+	assert.ElementsMatch(t, []string{
+		zshPath + "/dot-rc",
+		zshPath + "/scratch.txt",
+		zshPath + "/notes/todo.md",
+	}, filePaths(*zsh.Unwrap().Tree))
+}
+
+// filePaths returns the paths of all non-directory nodes in a tree.
+func filePaths(node domain.Node) []string {
+	if node.Type != domain.NodeDir {
+		return []string{node.Path.String()}
+	}
+	var paths []string
+	for _, child := range node.Children {
+		paths = append(paths, filePaths(child)...)
+	}
+	return paths
+}
+
+func TestScanPackageWithConfig_PrunesIgnoredDirectories(t *testing.T) {
+	ctx := context.Background()
+	memFS := adapters.NewMemFS()
+	packagePath := "/test/package"
+	require.NoError(t, memFS.MkdirAll(ctx, packagePath+"/node_modules/lib/dist", 0755))
+	require.NoError(t, memFS.MkdirAll(ctx, packagePath+"/dot-config/nvim", 0755))
+	require.NoError(t, memFS.WriteFile(ctx, packagePath+"/node_modules/lib/dist/index.js", []byte("js"), 0644))
+	require.NoError(t, memFS.WriteFile(ctx, packagePath+"/node_modules/keep", []byte("keep"), 0644))
+	require.NoError(t, memFS.WriteFile(ctx, packagePath+"/dot-config/nvim/init.lua", []byte("lua"), 0644))
+
+	ignoreSet := ignore.NewIgnoreSet()
+	require.NoError(t, ignoreSet.Add("node_modules"))
+	// Negations cannot re-include files beneath an ignored directory
+	require.NoError(t, ignoreSet.Add("!keep"))
+
+	pkgPath := domain.NewPackagePath(packagePath).Unwrap()
+	unpruned := scanner.ScanTree(ctx, memFS, domain.NewFilePath(packagePath).Unwrap())
+	require.True(t, unpruned.IsOk())
+	want := scanner.FilterTreeForTest(unpruned.Unwrap(), ignoreSet)
+
+	for _, concurrency := range []int{0, 4} {
+		t.Run(fmt.Sprintf("concurrency %d", concurrency), func(t *testing.T) {
+			fs := &countingFS{FS: memFS}
+			result := scanner.ScanPackageWithConfig(ctx, fs, pkgPath, "pkg", ignoreSet, scanner.ScanConfig{Concurrency: concurrency})
+			require.True(t, result.IsOk())
+
+			assert.Equal(t, want, *result.Unwrap().Tree, "pruning matches filtering the full tree")
+			assert.Equal(t, int64(3), fs.readDirs.Load(), "node_modules is not read")
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package scanner
+
+import (
+	"context"
+	"sync"
+
+	"github.com/yaklabco/dot/internal/domain"
+)
+
+// ScanTreeParallel scans a filesystem tree like ScanTreeWithConfig, but reads
+// sibling directories concurrently with at most concurrency directories in
+// flight. Children are assembled in name order, so the resulting tree is
+// identical to a serial scan. If several children fail, the error of the
+// first one in that order is returned, as the serial scan would.
+//
+// Large file prompts are serialized but may be asked in a different order
+// than during a serial scan. A concurrency of 1 or less scans serially.
+func ScanTreeParallel(ctx context.Context, fs domain.FSReader, path domain.FilePath, maxSize int64, prompter LargeFilePrompter, concurrency int) domain.Result[domain.Node] {
+	return scanTreeParallel(ctx, fs, path, maxSize, prompter, concurrency, nil)
+}
+
+// scanTreeParallel is ScanTreeParallel leaving out entries skip reports,
+// as WalkTree does. Skipped directories are not read.
+func scanTreeParallel(ctx context.Context, fs domain.FSReader, path domain.FilePath, maxSize int64, prompter LargeFilePrompter, concurrency int, skip SkipFunc) domain.Result[domain.Node] {
+	if concurrency <= 1 {
+		if skip != nil {
+			return WalkTree(ctx, fs, path, maxSize, prompter, skip)
+		}
+		return ScanTreeWithConfig(ctx, fs, path, maxSize, prompter)
+	}
+
+	if prompter != nil {
+		prompter = &syncPrompter{prompter: prompter}
+	}
+
+	s := &parallelScanner{
+		fs:       fs,
+		maxSize:  maxSize,
+		prompter: prompter,
+		skip:     skip,
+		// The calling goroutine scans too, so it does not take a slot
+		sem: make(chan struct{}, concurrency-1),
+	}
+
+	node, err := s.scan(ctx, path)
+	if err != nil {
+		return domain.Err[domain.Node](err)
+	}
+	return domain.Ok(node)
+}
+
+// parallelScanner holds the shared state of one parallel tree scan.
+type parallelScanner struct {
+	fs       domain.FSReader
+	maxSize  int64
+	prompter LargeFilePrompter
+	skip     SkipFunc
+	sem      chan struct{}
+}
+
+func (s *parallelScanner) scan(ctx context.Context, path domain.FilePath) (domain.Node, error) {
+	if err := ctx.Err(); err != nil {
+		return domain.Node{}, err
+	}
+
+	node, isDir, err := scanEntry(ctx, s.fs, path, s.maxSize, s.prompter)
+	if err != nil || !isDir {
+		return node, err
+	}
+
+	entries, err := readDirSorted(ctx, s.fs, path)
+	if err != nil {
+		return domain.Node{}, err
+	}
+	if s.skip != nil {
+		kept := entries[:0]
+		for _, entry := range entries {
+			if !s.skip(path.Join(entry.Name()).String(), entry.IsDir()) {
+				kept = append(kept, entry)
+			}
+		}
+		entries = kept
+	}
+
+	nodes := make([]domain.Node, len(entries))
+	errs := make([]error, len(entries))
+
+	var wg sync.WaitGroup
+	for i, entry := range entries {
+		childPath := path.Join(entry.Name())
+
+		// Hand subdirectories to another goroutine when a slot is free;
+		// everything else, and directories when the pool is busy, is
+		// scanned inline so the scan never blocks waiting for a slot.
+		if entry.IsDir() {
+			select {
+			case s.sem <- struct{}{}:
+				wg.Add(1)
+				go func(i int, childPath domain.FilePath) {
+					defer wg.Done()
+					defer func() { <-s.sem }()
+					nodes[i], errs[i] = s.scan(ctx, childPath)
+				}(i, childPath)
+				continue
+			default:
+			}
+		}
+
+		nodes[i], errs[i] = s.scan(ctx, childPath)
+	}
+	wg.Wait()
+
+	children := make([]domain.Node, 0, len(entries))
+	for i := range entries {
+		if errs[i] != nil {
+			// Skip files declined by the prompter, as the serial scan does
+			if _, ok := errs[i].(ErrFileTooLarge); ok {
+				continue
+			}
+			return domain.Node{}, errs[i]
+		}
+		children = append(children, nodes[i])
+	}
+
+	node.Children = children
+	return node, nil
+}
+
+// syncPrompter serializes calls to a prompter shared by scan goroutines.
+type syncPrompter struct {
+	mu       sync.Mutex
+	prompter LargeFilePrompter
+}
+
+func (p *syncPrompter) ShouldInclude(path string, size int64, limit int64) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.prompter.ShouldInclude(path, size, limit)
+}
@@ -0,0 +1,144 @@
+package scanner_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaklabco/dot/internal/adapters"
+	"github.com/yaklabco/dot/internal/domain"
+	"github.com/yaklabco/dot/internal/scanner"
+)
+
+// setupWideTree creates width top-level directories, each holding a nested
+// directory, a few files, one large file and a symlink.
+func setupWideTree(t testing.TB, fs domain.FS, root string, width int) {
+	t.Helper()
+	ctx := context.Background()
+	for i := 0; i < width; i++ {
+		dir := fmt.Sprintf("%s/dir%02d", root, i)
+		require.NoError(t, fs.MkdirAll(ctx, dir+"/nested", 0755))
+		for j := 0; j < 3; j++ {
+			require.NoError(t, fs.WriteFile(ctx, fmt.Sprintf("%s/file%d", dir, j), []byte("content"), 0644))
+		}
+		require.NoError(t, fs.WriteFile(ctx, dir+"/nested/inner", []byte("inner"), 0644))
+		require.NoError(t, fs.WriteFile(ctx, dir+"/large.bin", make([]byte, 2048), 0644))
+		require.NoError(t, fs.Symlink(ctx, dir+"/file0", dir+"/link"))
+	}
+	require.NoError(t, fs.WriteFile(ctx, root+"/top", []byte("top"), 0644))
+}
+
+func TestScanTreeParallel_MatchesSerial(t *testing.T) {
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	setupWideTree(t, fs, "/pkg", 20)
+	path := domain.NewFilePath("/pkg").Unwrap()
+
+	tests := []struct {
+		name     string
+		maxSize  int64
+		prompter scanner.LargeFilePrompter
+	}{
+		{"no size limit", 0, nil},
+		{"size limit", 1024, scanner.NewBatchPrompter()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serial := scanner.ScanTreeWithConfig(ctx, fs, path, tt.maxSize, tt.prompter)
+			require.True(t, serial.IsOk())
+
+			for _, concurrency := range []int{0, 1, 2, 8, 64} {
+				parallel := scanner.ScanTreeParallel(ctx, fs, path, tt.maxSize, tt.prompter, concurrency)
+				require.True(t, parallel.IsOk(), "concurrency %d", concurrency)
+				assert.Equal(t, serial.Unwrap(), parallel.Unwrap(), "concurrency %d", concurrency)
+			}
+		})
+	}
+}
+
+func TestScanTree_SortsChildren(t *testing.T) {
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	setupWideTree(t, fs, "/pkg", 10)
+
+	result := scanner.ScanTree(ctx, fs, domain.NewFilePath("/pkg").Unwrap())
+	require.True(t, result.IsOk())
+
+	var names []string
+	for _, child := range result.Unwrap().Children {
+		names = append(names, child.Path.String())
+	}
+	assert.IsIncreasing(t, names)
+}
+
+// readDirFailFS fails ReadDir for the given directories.
+type readDirFailFS struct {
+	domain.FS
+	fail map[string]bool
+}
+
+func (f readDirFailFS) ReadDir(ctx context.Context, name string) ([]domain.DirEntry, error) {
+	if f.fail[name] {
+		return nil, errors.New("read failed: " + name)
+	}
+	return f.FS.ReadDir(ctx, name)
+}
+
+func TestScanTreeParallel_ReturnsFirstError(t *testing.T) {
+	ctx := context.Background()
+	mem := adapters.NewMemFS()
+	setupWideTree(t, mem, "/pkg", 20)
+	fs := readDirFailFS{FS: mem, fail: map[string]bool{
+		"/pkg/dir03/nested": true,
+		"/pkg/dir15":        true,
+	}}
+	path := domain.NewFilePath("/pkg").Unwrap()
+
+	serial := scanner.ScanTreeWithConfig(ctx, fs, path, 0, nil)
+	require.True(t, serial.IsErr())
+
+	for i := 0; i < 10; i++ {
+		parallel := scanner.ScanTreeParallel(ctx, fs, path, 0, nil, 8)
+		require.True(t, parallel.IsErr())
+		assert.Equal(t, serial.UnwrapErr().Error(), parallel.UnwrapErr().Error())
+		assert.Contains(t, parallel.UnwrapErr().Error(), "/pkg/dir03/nested")
+	}
+}
+
+func TestScanTreeParallel_Cancelled(t *testing.T) {
+	fs := adapters.NewMemFS()
+	setupWideTree(t, fs, "/pkg", 5)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result := scanner.ScanTreeParallel(ctx, fs, domain.NewFilePath("/pkg").Unwrap(), 0, nil, 4)
+	require.True(t, result.IsErr())
+	assert.ErrorIs(t, result.UnwrapErr(), context.Canceled)
+}
+
+// countingPrompter accepts every file and counts calls. It is deliberately
+// not safe for concurrent use; the scanner must serialize calls.
+type countingPrompter struct {
+	calls int
+}
+
+func (p *countingPrompter) ShouldInclude(path string, size int64, limit int64) bool {
+	p.calls++
+	return true
+}
+
+func TestScanTreeParallel_SerializesPrompter(t *testing.T) {
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	setupWideTree(t, fs, "/pkg", 20)
+
+	prompter := &countingPrompter{}
+	result := scanner.ScanTreeParallel(ctx, fs, domain.NewFilePath("/pkg").Unwrap(), 1024, prompter, 8)
+	require.True(t, result.IsOk())
+	assert.Equal(t, 20, prompter.calls)
+}
@@ -6,7 +6,9 @@ package scanner
 import (
 	"context"
 	"fmt"
+	"os"
 	"path/filepath"
+	"sort"
 
 	"github.com/yaklabco/dot/internal/domain"
 )
@@ -27,87 +29,221 @@ func (e ErrFileTooLarge) Error() string {
 // Returns a Node representing the tree structure.
 // Files exceeding maxSize are handled by the prompter (if provided).
 func ScanTreeWithConfig(ctx context.Context, fs domain.FSReader, path domain.FilePath, maxSize int64, prompter LargeFilePrompter) domain.Result[domain.Node] {
+	node, isDir, err := scanEntry(ctx, fs, path, maxSize, prompter)
+	if err != nil {
+		return domain.Err[domain.Node](err)
+	}
+	if !isDir {
+		return domain.Ok(node)
+	}
+
+	// Directory - scan children
+	entries, err := readDirSorted(ctx, fs, path)
+	if err != nil {
+		return domain.Err[domain.Node](err)
+	}
+
+	// Recursively scan each child
+	children := make([]domain.Node, 0, len(entries))
+	for _, entry := range entries {
+		childPath := path.Join(entry.Name())
+
+		childResult := ScanTreeWithConfig(ctx, fs, childPath, maxSize, prompter)
+		if childResult.IsErr() {
+			// Check if it's a "file too large" error - if so, skip silently
+			if _, ok := childResult.UnwrapErr().(ErrFileTooLarge); ok {
+				// Skip this file silently (already handled by prompter)
+				continue
+			}
+			// Other errors are propagated
+			return domain.Err[domain.Node](childResult.UnwrapErr())
+		}
+
+		children = append(children, childResult.Unwrap())
+	}
+
+	node.Children = children
+	return domain.Ok(node)
+}
+
+// scanEntry classifies path without reading directory contents. For
+// symlinks and files it returns the finished leaf node; for directories it
+// returns a node without children and isDir set. Files exceeding maxSize
+// that the prompter declines are reported as ErrFileTooLarge.
+func scanEntry(ctx context.Context, fs domain.FSReader, path domain.FilePath, maxSize int64, prompter LargeFilePrompter) (domain.Node, bool, error) {
 	// Check for symlinks first (symlinks are always leaves)
 	isLink, err := fs.IsSymlink(ctx, path.String())
 	if err != nil {
-		return domain.Err[domain.Node](fmt.Errorf("check symlink %s: %w", path.String(), err))
+		return domain.Node{}, false, fmt.Errorf("check symlink %s: %w", path.String(), err)
 	}
 
 	if isLink {
-		return domain.Ok(domain.Node{
+		return domain.Node{
 			Path:     path,
 			Type:     domain.NodeSymlink,
 			Children: nil,
-		})
+		}, false, nil
 	}
 
 	// Check if directory
 	isDir, err := fs.IsDir(ctx, path.String())
 	if err != nil {
-		return domain.Err[domain.Node](fmt.Errorf("check directory %s: %w", path.String(), err))
+		return domain.Node{}, false, fmt.Errorf("check directory %s: %w", path.String(), err)
 	}
 
-	if !isDir {
-		// Regular file - check size if limit is set
-		if maxSize > 0 {
-			info, err := fs.Stat(ctx, path.String())
-			if err != nil {
-				return domain.Err[domain.Node](fmt.Errorf("stat file %s: %w", path.String(), err))
-			}
+	if isDir {
+		return domain.Node{
+			Path: path,
+			Type: domain.NodeDir,
+		}, true, nil
+	}
+
+	// Regular file - check size if limit is set
+	if maxSize > 0 {
+		info, err := fs.Stat(ctx, path.String())
+		if err != nil {
+			return domain.Node{}, false, fmt.Errorf("stat file %s: %w", path.String(), err)
+		}
 
-			if info.Size() > maxSize {
-				// File exceeds limit
-				if prompter != nil && prompter.ShouldInclude(path.String(), info.Size(), maxSize) {
-					// User chose to include - continue normally
-				} else {
-					// Skip this file - return error that can be caught and logged
-					return domain.Err[domain.Node](ErrFileTooLarge{
-						Path:  path.String(),
-						Size:  info.Size(),
-						Limit: maxSize,
-					})
+		if info.Size() > maxSize {
+			// File exceeds limit
+			if prompter == nil || !prompter.ShouldInclude(path.String(), info.Size(), maxSize) {
+				// Skip this file - return error that can be caught and logged
+				return domain.Node{}, false, ErrFileTooLarge{
+					Path:  path.String(),
+					Size:  info.Size(),
+					Limit: maxSize,
 				}
 			}
+			// User chose to include - continue normally
 		}
-
-		// Regular file within size limit
-		return domain.Ok(domain.Node{
-			Path:     path,
-			Type:     domain.NodeFile,
-			Children: nil,
-		})
 	}
 
-	// Directory - scan children
-	entries, err := fs.ReadDir(ctx, path.String())
-	if err != nil {
-		return domain.Err[domain.Node](fmt.Errorf("read directory %s: %w", path.String(), err))
+	// Regular file within size limit
+	return domain.Node{
+		Path:     path,
+		Type:     domain.NodeFile,
+		Children: nil,
+	}, false, nil
+}
+
+// SkipFunc reports whether the entry at path should be left out of a
+// scan. isDir tells whether the entry is a directory; skipped directories
+// are not descended into.
+type SkipFunc func(path string, isDir bool) bool
+
+// WalkTree scans the tree at path like ScanTreeWithConfig, but in a single
+// FS.Walk pass, leaving out every entry skip reports. Skipped directories
+// are pruned rather than read, so an ignored tree such as .git costs one
+// directory entry instead of a full traversal. The root itself is never
+// skipped, and a nil skip keeps every entry.
+func WalkTree(ctx context.Context, fs domain.FSReader, path domain.FilePath, maxSize int64, prompter LargeFilePrompter, skip SkipFunc) domain.Result[domain.Node] {
+	// Directories still being filled, innermost last
+	var stack []domain.Node
+	// closeDir pops the innermost open directory into its parent
+	closeDir := func() {
+		dir := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		parent := &stack[len(stack)-1]
+		parent.Children = append(parent.Children, dir)
 	}
 
-	// Recursively scan each child
-	children := make([]domain.Node, 0, len(entries))
-	for _, entry := range entries {
-		childPath := path.Join(entry.Name())
+	var root domain.Node
+	rootIsDir := false
+	err := fs.Walk(ctx, path.String(), func(p string, d domain.DirEntry, err error) error {
+		if err != nil {
+			if d != nil && d.IsDir() {
+				return fmt.Errorf("read directory %s: %w", p, err)
+			}
+			return fmt.Errorf("stat %s: %w", p, err)
+		}
 
-		childResult := ScanTreeWithConfig(ctx, fs, childPath, maxSize, prompter)
-		if childResult.IsErr() {
-			// Check if it's a "file too large" error - if so, skip silently
-			if _, ok := childResult.UnwrapErr().(ErrFileTooLarge); ok {
+		if p == path.String() {
+			node, isDir, err := walkEntry(ctx, fs, path, d, maxSize, prompter)
+			if err != nil {
+				return err
+			}
+			if isDir {
+				rootIsDir = true
+				stack = append(stack, node)
+			} else {
+				root = node
+			}
+			return nil
+		}
+
+		// Entries arrive depth-first, so directories that do not
+		// contain p are complete
+		parentPath := filepath.Dir(p)
+		for len(stack) > 1 && stack[len(stack)-1].Path.String() != parentPath {
+			closeDir()
+		}
+		parent := &stack[len(stack)-1]
+
+		isDir := d.IsDir()
+		if skip != nil && skip(p, isDir) {
+			if isDir {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		node, isDir, err := walkEntry(ctx, fs, parent.Path.Join(d.Name()), d, maxSize, prompter)
+		if err != nil {
+			if _, ok := err.(ErrFileTooLarge); ok {
 				// Skip this file silently (already handled by prompter)
-				continue
+				return nil
 			}
-			// Other errors are propagated
-			return domain.Err[domain.Node](childResult.UnwrapErr())
+			return err
 		}
+		if isDir {
+			stack = append(stack, node)
+		} else {
+			parent.Children = append(parent.Children, node)
+		}
+		return nil
+	})
+	if err != nil {
+		return domain.Err[domain.Node](err)
+	}
+	if !rootIsDir {
+		return domain.Ok(root)
+	}
 
-		children = append(children, childResult.Unwrap())
+	for len(stack) > 1 {
+		closeDir()
 	}
+	return domain.Ok(stack[0])
+}
 
-	return domain.Ok(domain.Node{
-		Path:     path,
-		Type:     domain.NodeDir,
-		Children: children,
-	})
+// walkEntry classifies an entry visited by FS.Walk like scanEntry, using
+// the type the walk already reported instead of querying the FS again.
+// Only files checked against maxSize are stat'ed.
+func walkEntry(ctx context.Context, fs domain.FSReader, path domain.FilePath, d domain.DirEntry, maxSize int64, prompter LargeFilePrompter) (domain.Node, bool, error) {
+	if d.Type()&os.ModeSymlink != 0 {
+		return domain.Node{Path: path, Type: domain.NodeSymlink}, false, nil
+	}
+	if d.IsDir() {
+		return domain.Node{Path: path, Type: domain.NodeDir}, true, nil
+	}
+
+	if maxSize > 0 {
+		info, err := fs.Stat(ctx, path.String())
+		if err != nil {
+			return domain.Node{}, false, fmt.Errorf("stat file %s: %w", path.String(), err)
+		}
+		if info.Size() > maxSize {
+			if prompter == nil || !prompter.ShouldInclude(path.String(), info.Size(), maxSize) {
+				return domain.Node{}, false, ErrFileTooLarge{
+					Path:  path.String(),
+					Size:  info.Size(),
+					Limit: maxSize,
+				}
+			}
+		}
+	}
+
+	return domain.Node{Path: path, Type: domain.NodeFile}, false, nil
 }
 
 // ScanTree recursively scans a filesystem tree starting at path.
@@ -151,9 +287,9 @@ func ScanTree(ctx context.Context, fs domain.FSReader, path domain.FilePath) dom
 	}
 
 	// Directory - scan children
-	entries, err := fs.ReadDir(ctx, path.String())
+	entries, err := readDirSorted(ctx, fs, path)
 	if err != nil {
-		return domain.Err[domain.Node](fmt.Errorf("read directory %s: %w", path.String(), err))
+		return domain.Err[domain.Node](err)
 	}
 
 	// Recursively scan each child
@@ -176,6 +312,19 @@ func ScanTree(ctx context.Context, fs domain.FSReader, path domain.FilePath) dom
 	})
 }
 
+// readDirSorted lists a directory with entries sorted by name, so scans
+// produce the same tree regardless of the order the FS returns entries in.
+func readDirSorted(ctx context.Context, fs domain.FSReader, path domain.FilePath) ([]domain.DirEntry, error) {
+	entries, err := fs.ReadDir(ctx, path.String())
+	if err != nil {
+		return nil, fmt.Errorf("read directory %s: %w", path.String(), err)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name() < entries[j].Name()
+	})
+	return entries, nil
+}
+
 // Walk traverses a Node tree, calling fn for each node.
 // Traversal is depth-first pre-order.
 //
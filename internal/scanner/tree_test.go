@@ -3,6 +3,7 @@ package scanner_test
 import (
 	"context"
 	"io/fs"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -55,6 +56,14 @@ func (m *MockFS) ReadFile(ctx context.Context, name string) ([]byte, error) {
 	return args.Get(0).([]byte), args.Error(1)
 }
 
+func (m *MockFS) ReadFileRange(ctx context.Context, name string, offset, length int64) ([]byte, error) {
+	args := m.Called(ctx, name, offset, length)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
 func (m *MockFS) WriteFile(ctx context.Context, name string, data []byte, perm fs.FileMode) error {
 	args := m.Called(ctx, name, data, perm)
 	return args.Error(0)
@@ -85,6 +94,11 @@ func (m *MockFS) Symlink(ctx context.Context, oldname, newname string) error {
 	return args.Error(0)
 }
 
+func (m *MockFS) Link(ctx context.Context, oldname, newname string) error {
+	args := m.Called(ctx, oldname, newname)
+	return args.Error(0)
+}
+
 func (m *MockFS) Rename(ctx context.Context, oldname, newname string) error {
 	args := m.Called(ctx, oldname, newname)
 	return args.Error(0)
@@ -105,6 +119,11 @@ func (m *MockFS) IsSymlink(ctx context.Context, name string) (bool, error) {
 	return args.Bool(0), args.Error(1)
 }
 
+// Walk is derived from the mocked Lstat and ReadDir calls.
+func (m *MockFS) Walk(ctx context.Context, root string, fn domain.WalkFunc) error {
+	return domain.WalkDir(ctx, m, root, fn)
+}
+
 func TestScanTree_SingleFile(t *testing.T) {
 	ctx := context.Background()
 	mockFS := new(MockFS)
@@ -465,3 +484,80 @@ func TestErrFileTooLarge_Error(t *testing.T) {
 	assert.Contains(t, errorMsg, "2.0 KB")
 	assert.Contains(t, errorMsg, "1.0 KB")
 }
+
+func TestWalkTree_MatchesScanTree(t *testing.T) {
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	setupWideTree(t, fs, "/pkg", 5)
+	path := domain.NewFilePath("/pkg").Unwrap()
+
+	tests := []struct {
+		name     string
+		maxSize  int64
+		prompter scanner.LargeFilePrompter
+	}{
+		{"no size limit", 0, nil},
+		{"size limit", 1024, scanner.NewBatchPrompter()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := scanner.ScanTreeWithConfig(ctx, fs, path, tt.maxSize, tt.prompter)
+			require.True(t, want.IsOk())
+
+			got := scanner.WalkTree(ctx, fs, path, tt.maxSize, tt.prompter, nil)
+			require.True(t, got.IsOk())
+			assert.Equal(t, want.Unwrap(), got.Unwrap())
+		})
+	}
+}
+
+func TestWalkTree_SingleFile(t *testing.T) {
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	require.NoError(t, fs.MkdirAll(ctx, "/pkg", 0755))
+	require.NoError(t, fs.WriteFile(ctx, "/pkg/file", []byte("data"), 0644))
+	path := domain.NewFilePath("/pkg/file").Unwrap()
+
+	result := scanner.WalkTree(ctx, fs, path, 0, nil, nil)
+	require.True(t, result.IsOk())
+	assert.Equal(t, domain.Node{Path: path, Type: domain.NodeFile}, result.Unwrap())
+}
+
+func TestWalkTree_PrunesSkippedDirectories(t *testing.T) {
+	ctx := context.Background()
+	memFS := adapters.NewMemFS()
+	require.NoError(t, memFS.MkdirAll(ctx, "/pkg/.git/objects/ab", 0755))
+	require.NoError(t, memFS.MkdirAll(ctx, "/pkg/dot-config", 0755))
+	require.NoError(t, memFS.WriteFile(ctx, "/pkg/.git/objects/ab/cdef", []byte("obj"), 0644))
+	require.NoError(t, memFS.WriteFile(ctx, "/pkg/dot-config/app", []byte("cfg"), 0644))
+	require.NoError(t, memFS.WriteFile(ctx, "/pkg/dot-vimrc", []byte("set nu"), 0644))
+	fs := &countingFS{FS: memFS}
+	path := domain.NewFilePath("/pkg").Unwrap()
+
+	var skipped []string
+	skip := func(p string, isDir bool) bool {
+		if filepath.Base(p) == ".git" || filepath.Base(p) == "dot-vimrc" {
+			skipped = append(skipped, p)
+			return true
+		}
+		return false
+	}
+
+	result := scanner.WalkTree(ctx, fs, path, 0, nil, skip)
+	require.True(t, result.IsOk())
+	assert.Equal(t, []string{"/pkg/.git", "/pkg/dot-vimrc"}, skipped, "skipped directories are not descended into")
+	assert.Equal(t, int64(2), fs.readDirs.Load(), "only /pkg and /pkg/dot-config are read")
+	assert.Equal(t, []domain.FilePath{path.Join("dot-config").Join("app")}, scanner.CollectFiles(result.Unwrap()))
+}
+
+func TestWalkTree_ReadDirError(t *testing.T) {
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	setupWideTree(t, fs, "/pkg", 3)
+	failing := &countingFS{FS: readDirFailFS{FS: fs, fail: map[string]bool{"/pkg/dir01": true}}}
+
+	result := scanner.WalkTree(ctx, failing, domain.NewFilePath("/pkg").Unwrap(), 0, nil, nil)
+	require.True(t, result.IsErr())
+	assert.Contains(t, result.UnwrapErr().Error(), "read directory /pkg/dir01")
+}
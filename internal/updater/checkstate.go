@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 )
 
@@ -12,6 +13,8 @@ import (
 type CheckState struct {
 	LastCheck time.Time `json:"last_check"`
 	LastSkip  time.Time `json:"last_skip"`
+
+	LastUpgrade *UpgradeRecord `json:"last_upgrade,omitempty"`
 }
 
 // StateManager manages the update check state file.
@@ -47,19 +50,25 @@ func (sm *StateManager) Load() (*CheckState, error) {
 
 // Save saves the check state to disk atomically with restrictive permissions.
 func (sm *StateManager) Save(state *CheckState) error {
-	// Ensure directory exists with restrictive permissions
-	dir := filepath.Dir(sm.statePath)
-	if err := os.MkdirAll(dir, 0o700); err != nil {
-		return fmt.Errorf("create state directory: %w", err)
-	}
-
 	data, err := json.MarshalIndent(state, "", "  ")
 	if err != nil {
 		return fmt.Errorf("marshal state: %w", err)
 	}
+	return writeStateFile(sm.statePath, data)
+}
+
+// writeStateFile writes data to path atomically, creating its directory
+// if needed. The directory and file are readable only by the owner.
+func writeStateFile(path string, data []byte) error {
+	// Ensure directory exists with restrictive permissions
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("create state directory: %w", err)
+	}
 
 	// Write atomically using temp file + rename
-	tmp, err := os.CreateTemp(dir, "update-check.*.json")
+	base := filepath.Base(path)
+	tmp, err := os.CreateTemp(dir, strings.TrimSuffix(base, filepath.Ext(base))+".*"+filepath.Ext(base))
 	if err != nil {
 		return fmt.Errorf("create temp state file: %w", err)
 	}
@@ -80,7 +89,7 @@ func (sm *StateManager) Save(state *CheckState) error {
 		return fmt.Errorf("close temp state file: %w", err)
 	}
 
-	cleanPath := filepath.Clean(sm.statePath)
+	cleanPath := filepath.Clean(path)
 	if err := os.Rename(tmpName, cleanPath); err != nil {
 		return fmt.Errorf("replace state file: %w", err)
 	}
@@ -126,3 +135,17 @@ func (sm *StateManager) RecordSkip() error {
 	state.LastSkip = time.Now()
 	return sm.Save(state)
 }
+
+// RecordUpgrade records the outcome of an upgrade attempt.
+func (sm *StateManager) RecordUpgrade(record UpgradeRecord) error {
+	state, err := sm.Load()
+	if err != nil {
+		state = &CheckState{}
+	}
+
+	if record.Time.IsZero() {
+		record.Time = time.Now()
+	}
+	state.LastUpgrade = &record
+	return sm.Save(state)
+}
@@ -186,6 +186,26 @@ func TestStateManager_RecordCheck_PreservesSkip(t *testing.T) {
 	assert.False(t, state.LastCheck.IsZero())
 }
 
+func TestStateManager_RecordUpgrade(t *testing.T) {
+	tmpDir := t.TempDir()
+	sm := NewStateManager(tmpDir)
+
+	checkTime := time.Now().Add(-1 * time.Hour)
+	require.NoError(t, sm.Save(&CheckState{LastCheck: checkTime}))
+
+	err := sm.RecordUpgrade(UpgradeRecord{Attempted: "v1.2.0", Resulting: "1.1.0"})
+	require.NoError(t, err)
+
+	state, err := sm.Load()
+	require.NoError(t, err)
+	require.NotNil(t, state.LastUpgrade)
+	assert.Equal(t, "v1.2.0", state.LastUpgrade.Attempted)
+	assert.Equal(t, "1.1.0", state.LastUpgrade.Resulting)
+	assert.False(t, state.LastUpgrade.Verified)
+	assert.False(t, state.LastUpgrade.Time.IsZero())
+	assert.WithinDuration(t, checkTime, state.LastCheck, time.Second)
+}
+
 func TestCheckState_JSON(t *testing.T) {
 	state := &CheckState{
 		LastCheck: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
@@ -0,0 +1,137 @@
+package updater
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// detectionEntry is the cached result of DetectPackageManager.
+type detectionEntry struct {
+	Manager    string    `json:"manager"`
+	DetectedAt time.Time `json:"detected_at"`
+
+	// Binary and BinaryModTime identify the dot binary that ran the
+	// detection. A different or rebuilt binary detects again.
+	Binary        string    `json:"binary"`
+	BinaryModTime time.Time `json:"binary_mod_time"`
+}
+
+// DetectionCache stores the package manager DetectPackageManager found so
+// later runs can skip probing the system.
+type DetectionCache struct {
+	path string
+
+	// Overridable for tests
+	now        func() time.Time
+	executable func() (string, error)
+	detect     func() PackageManager
+}
+
+// NewDetectionCache creates a detection cache stored in stateDir.
+func NewDetectionCache(stateDir string) *DetectionCache {
+	return &DetectionCache{
+		path:       filepath.Join(stateDir, "package-manager.json"),
+		now:        time.Now,
+		executable: os.Executable,
+		detect:     DetectPackageManager,
+	}
+}
+
+// Detect returns the cached package manager when it was detected less
+// than ttl ago by the running binary. Otherwise, or when refresh is set,
+// it detects the package manager again and caches the result. A ttl of
+// zero or less disables the cache. Cache read and write failures fall
+// back to detection.
+func (c *DetectionCache) Detect(ttl time.Duration, refresh bool) PackageManager {
+	if ttl <= 0 {
+		return c.detect()
+	}
+
+	binary, modTime, err := c.binaryIdentity()
+	if err != nil {
+		return c.detect()
+	}
+
+	if !refresh {
+		if mgr, ok := c.lookup(binary, modTime, ttl); ok {
+			return mgr
+		}
+	}
+
+	mgr := c.detect()
+	// Failing to cache only costs a detection on the next run
+	_ = c.store(detectionEntry{
+		Manager:       mgr.Name(),
+		DetectedAt:    c.now(),
+		Binary:        binary,
+		BinaryModTime: modTime,
+	})
+	return mgr
+}
+
+// lookup returns the cached package manager if the entry is fresh and was
+// written by the binary at path binary with modification time modTime.
+func (c *DetectionCache) lookup(binary string, modTime time.Time, ttl time.Duration) (PackageManager, bool) {
+	data, err := os.ReadFile(c.path)
+	if err != nil {
+		return nil, false
+	}
+
+	var entry detectionEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, false
+	}
+
+	if entry.Binary != binary || !entry.BinaryModTime.Equal(modTime) {
+		return nil, false
+	}
+	age := c.now().Sub(entry.DetectedAt)
+	if age < 0 || age >= ttl {
+		return nil, false
+	}
+
+	mgr, err := GetPackageManager(entry.Manager)
+	if err != nil {
+		return nil, false
+	}
+	return mgr, true
+}
+
+// store writes entry to the cache file.
+func (c *DetectionCache) store(entry detectionEntry) error {
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal detection cache: %w", err)
+	}
+	return writeStateFile(c.path, data)
+}
+
+// binaryIdentity returns the resolved path and modification time of the
+// running binary.
+func (c *DetectionCache) binaryIdentity() (string, time.Time, error) {
+	path, err := c.executable()
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("locate executable: %w", err)
+	}
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("stat executable: %w", err)
+	}
+	return path, info.ModTime(), nil
+}
+
+// ResolvePackageManagerCached resolves the package manager like
+// ResolvePackageManager, serving "auto" detection from cache. See
+// DetectionCache.Detect for ttl and refresh.
+func ResolvePackageManagerCached(configuredManager string, cache *DetectionCache, ttl time.Duration, refresh bool) (PackageManager, error) {
+	if configuredManager == "auto" {
+		return cache.Detect(ttl, refresh), nil
+	}
+	return ResolvePackageManager(configuredManager)
+}
@@ -0,0 +1,158 @@
+package updater
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestDetectionCache creates a cache in a temporary state directory
+// whose binary is a temporary file and whose detection is counted.
+func newTestDetectionCache(t *testing.T) (cache *DetectionCache, binary string, detections *int) {
+	t.Helper()
+	dir := t.TempDir()
+	binary = filepath.Join(dir, "dot")
+	require.NoError(t, os.WriteFile(binary, []byte("binary"), 0o755))
+
+	detections = new(int)
+	cache = NewDetectionCache(filepath.Join(dir, "state"))
+	cache.executable = func() (string, error) { return binary, nil }
+	cache.detect = func() PackageManager {
+		*detections++
+		return &BrewManager{}
+	}
+	return cache, binary, detections
+}
+
+func TestDetectionCache_ReusesFreshResult(t *testing.T) {
+	cache, _, detections := newTestDetectionCache(t)
+
+	assert.Equal(t, "brew", cache.Detect(time.Hour, false).Name())
+	assert.Equal(t, "brew", cache.Detect(time.Hour, false).Name())
+	assert.Equal(t, 1, *detections)
+
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(cache.path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	}
+}
+
+func TestDetectionCache_Invalidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(t *testing.T, cache *DetectionCache, binary string)
+	}{
+		{
+			name: "expired",
+			change: func(t *testing.T, cache *DetectionCache, binary string) {
+				cache.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+			},
+		},
+		{
+			name: "binary moved",
+			change: func(t *testing.T, cache *DetectionCache, binary string) {
+				moved := binary + "-new"
+				require.NoError(t, os.Rename(binary, moved))
+				cache.executable = func() (string, error) { return moved, nil }
+			},
+		},
+		{
+			name: "binary replaced",
+			change: func(t *testing.T, cache *DetectionCache, binary string) {
+				later := time.Now().Add(time.Minute)
+				require.NoError(t, os.Chtimes(binary, later, later))
+			},
+		},
+		{
+			name: "corrupt cache",
+			change: func(t *testing.T, cache *DetectionCache, binary string) {
+				require.NoError(t, os.WriteFile(cache.path, []byte("{"), 0o600))
+			},
+		},
+		{
+			name: "unknown manager",
+			change: func(t *testing.T, cache *DetectionCache, binary string) {
+				path, modTime, err := cache.binaryIdentity()
+				require.NoError(t, err)
+				require.NoError(t, cache.store(detectionEntry{
+					Manager:       "npm",
+					DetectedAt:    time.Now(),
+					Binary:        path,
+					BinaryModTime: modTime,
+				}))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache, binary, detections := newTestDetectionCache(t)
+			cache.Detect(time.Hour, false)
+
+			tt.change(t, cache, binary)
+			assert.Equal(t, "brew", cache.Detect(time.Hour, false).Name())
+			assert.Equal(t, 2, *detections)
+		})
+	}
+}
+
+func TestDetectionCache_Refresh(t *testing.T) {
+	cache, _, detections := newTestDetectionCache(t)
+
+	cache.Detect(time.Hour, false)
+	cache.Detect(time.Hour, true)
+	assert.Equal(t, 2, *detections)
+
+	// The refreshed result is cached
+	cache.Detect(time.Hour, false)
+	assert.Equal(t, 2, *detections)
+}
+
+func TestDetectionCache_Disabled(t *testing.T) {
+	cache, _, detections := newTestDetectionCache(t)
+
+	cache.Detect(0, false)
+	cache.Detect(0, false)
+	assert.Equal(t, 2, *detections)
+	assert.NoFileExists(t, cache.path)
+}
+
+func TestDetectionCache_Unwritable(t *testing.T) {
+	cache, binary, detections := newTestDetectionCache(t)
+	// A file where the state directory should be makes every write fail
+	cache.path = filepath.Join(binary, "package-manager.json")
+
+	assert.Equal(t, "brew", cache.Detect(time.Hour, false).Name())
+	assert.Equal(t, "brew", cache.Detect(time.Hour, false).Name())
+	assert.Equal(t, 2, *detections)
+}
+
+func TestDetectionCache_UnknownExecutable(t *testing.T) {
+	cache, _, detections := newTestDetectionCache(t)
+	cache.executable = func() (string, error) { return "", errors.New("no executable") }
+
+	cache.Detect(time.Hour, false)
+	cache.Detect(time.Hour, false)
+	assert.Equal(t, 2, *detections)
+	assert.NoFileExists(t, cache.path)
+}
+
+func TestResolvePackageManagerCached(t *testing.T) {
+	cache, _, detections := newTestDetectionCache(t)
+
+	mgr, err := ResolvePackageManagerCached("auto", cache, time.Hour, false)
+	require.NoError(t, err)
+	assert.Equal(t, "brew", mgr.Name())
+
+	mgr, err = ResolvePackageManagerCached("manual", cache, time.Hour, false)
+	require.NoError(t, err)
+	assert.Equal(t, "manual", mgr.Name())
+	assert.Equal(t, 1, *detections, "a configured manager is not detected")
+}
@@ -15,6 +15,8 @@ type PackageManager interface {
 	IsAvailable() bool
 	// UpgradeCommand returns the command to upgrade dot
 	UpgradeCommand() []string
+	// ReinstallCommand returns the command to reinstall dot
+	ReinstallCommand() []string
 	// Validate validates the package manager and its upgrade command for security
 	Validate() error
 }
@@ -81,6 +83,10 @@ func (b *BrewManager) UpgradeCommand() []string {
 	return []string{"brew", "upgrade", "dot"}
 }
 
+func (b *BrewManager) ReinstallCommand() []string {
+	return []string{"brew", "reinstall", "dot"}
+}
+
 func (b *BrewManager) Validate() error {
 	if err := validatePackageManager(b.Name()); err != nil {
 		return err
@@ -106,6 +112,10 @@ func (a *AptManager) UpgradeCommand() []string {
 	return []string{"sudo", "apt-get", "install", "--only-upgrade", "-y", "dot"}
 }
 
+func (a *AptManager) ReinstallCommand() []string {
+	return []string{"sudo", "apt-get", "install", "--reinstall", "-y", "dot"}
+}
+
 func (a *AptManager) Validate() error {
 	if err := validatePackageManager(a.Name()); err != nil {
 		return err
@@ -129,6 +139,10 @@ func (y *YumManager) UpgradeCommand() []string {
 	return []string{"sudo", "yum", "upgrade", "-y", "dot"}
 }
 
+func (y *YumManager) ReinstallCommand() []string {
+	return []string{"sudo", "yum", "reinstall", "-y", "dot"}
+}
+
 func (y *YumManager) Validate() error {
 	if err := validatePackageManager(y.Name()); err != nil {
 		return err
@@ -152,6 +166,10 @@ func (p *PacmanManager) UpgradeCommand() []string {
 	return []string{"sudo", "pacman", "-Syu", "--noconfirm", "dot"}
 }
 
+func (p *PacmanManager) ReinstallCommand() []string {
+	return []string{"sudo", "pacman", "-S", "--noconfirm", "dot"}
+}
+
 func (p *PacmanManager) Validate() error {
 	if err := validatePackageManager(p.Name()); err != nil {
 		return err
@@ -175,6 +193,10 @@ func (d *DnfManager) UpgradeCommand() []string {
 	return []string{"sudo", "dnf", "upgrade", "-y", "dot"}
 }
 
+func (d *DnfManager) ReinstallCommand() []string {
+	return []string{"sudo", "dnf", "reinstall", "-y", "dot"}
+}
+
 func (d *DnfManager) Validate() error {
 	if err := validatePackageManager(d.Name()); err != nil {
 		return err
@@ -198,6 +220,10 @@ func (z *ZypperManager) UpgradeCommand() []string {
 	return []string{"sudo", "zypper", "update", "-y", "dot"}
 }
 
+func (z *ZypperManager) ReinstallCommand() []string {
+	return []string{"sudo", "zypper", "install", "--force", "-y", "dot"}
+}
+
 func (z *ZypperManager) Validate() error {
 	if err := validatePackageManager(z.Name()); err != nil {
 		return err
@@ -221,6 +247,11 @@ func (m *ManualManager) UpgradeCommand() []string {
 	return []string{}
 }
 
+func (m *ManualManager) ReinstallCommand() []string {
+	// Reinstalling manually means downloading the release again
+	return []string{}
+}
+
 func (m *ManualManager) Validate() error {
 	// Manual manager doesn't execute commands, so validation always passes
 	return nil
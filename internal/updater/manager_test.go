@@ -186,6 +186,31 @@ func TestPackageManager_UpgradeCommands(t *testing.T) {
 	}
 }
 
+func TestPackageManager_ReinstallCommands(t *testing.T) {
+	tests := []struct {
+		name    string
+		manager PackageManager
+	}{
+		{"brew", &BrewManager{}},
+		{"apt", &AptManager{}},
+		{"yum", &YumManager{}},
+		{"pacman", &PacmanManager{}},
+		{"dnf", &DnfManager{}},
+		{"zypper", &ZypperManager{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := tt.manager.ReinstallCommand()
+			assert.NotEmpty(t, cmd, "reinstall command should not be empty")
+			assert.Contains(t, cmd, "dot", "reinstall command should include 'dot'")
+			assert.NoError(t, validateCommand(cmd))
+		})
+	}
+
+	assert.Empty(t, (&ManualManager{}).ReinstallCommand())
+}
+
 func TestBrewManager_IsAvailable(t *testing.T) {
 	mgr := &BrewManager{}
 
@@ -3,7 +3,6 @@ package updater
 import (
 	"fmt"
 	"io"
-	"os"
 	"strings"
 	"time"
 
@@ -118,8 +117,8 @@ const (
 
 // detectColor determines if color output should be enabled for the given writer
 func detectColor(w io.Writer) bool {
-	if os.Getenv("NO_COLOR") != "" {
-		return false
+	if enabled, ok := terminal.ColorFromEnv(); ok {
+		return enabled
 	}
 	// Check if the writer has an Fd() (e.g., *os.File)
 	if f, ok := w.(interface{ Fd() uintptr }); ok {
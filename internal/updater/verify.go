@@ -0,0 +1,109 @@
+package updater
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// verifyTimeout bounds how long the upgraded binary may take to report its version.
+const verifyTimeout = 10 * time.Second
+
+// UpgradeRecord describes the most recent upgrade attempt.
+type UpgradeRecord struct {
+	Time      time.Time `json:"time"`
+	Attempted string    `json:"attempted"`
+	Resulting string    `json:"resulting,omitempty"`
+	Verified  bool      `json:"verified"`
+}
+
+// ErrUpgradeVerification indicates the upgraded binary did not report the
+// version the upgrade installed.
+type ErrUpgradeVerification struct {
+	Binary    string
+	Attempted string
+	Resulting string
+	Cause     error
+}
+
+func (e ErrUpgradeVerification) Error() string {
+	if e.Cause != nil {
+		return fmt.Sprintf("upgrade to %s could not be verified: %v", e.Attempted, e.Cause)
+	}
+	return fmt.Sprintf("upgrade to %s could not be verified: %s reports version %s", e.Attempted, e.Binary, e.Resulting)
+}
+
+func (e ErrUpgradeVerification) Unwrap() error {
+	return e.Cause
+}
+
+// Is implements errors.Is for ErrUpgradeVerification.
+func (e ErrUpgradeVerification) Is(target error) bool {
+	_, ok := target.(ErrUpgradeVerification)
+	return ok
+}
+
+// FindInstalledBinary returns the path of the dot binary found on PATH,
+// which is the one a package manager upgrades. It falls back to the
+// running executable when dot is not on PATH.
+func FindInstalledBinary() (string, error) {
+	if path, err := exec.LookPath("dot"); err == nil {
+		return path, nil
+	}
+	path, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("locate dot binary: %w", err)
+	}
+	return path, nil
+}
+
+// VerifyUpgrade runs binary --version directly (no shell invocation) and
+// checks it reports at least the attempted version. It returns the
+// version the binary reports.
+func VerifyUpgrade(binary, attempted string) (string, error) {
+	target, err := ParseVersion(attempted)
+	if err != nil {
+		return "", fmt.Errorf("parse attempted version: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), verifyTimeout)
+	defer cancel()
+
+	// #nosec G204 -- binary is the installed dot executable, arguments are fixed
+	out, err := exec.CommandContext(ctx, binary, "--version").Output()
+	if err != nil {
+		return "", ErrUpgradeVerification{
+			Binary:    binary,
+			Attempted: attempted,
+			Cause:     fmt.Errorf("run %s --version: %w", binary, err),
+		}
+	}
+
+	reported, err := parseVersionOutput(string(out))
+	if err != nil {
+		return "", ErrUpgradeVerification{Binary: binary, Attempted: attempted, Cause: err}
+	}
+	resulting, err := ParseVersion(reported)
+	if err != nil {
+		return reported, ErrUpgradeVerification{Binary: binary, Attempted: attempted, Resulting: reported, Cause: err}
+	}
+	if target.IsNewerThan(resulting) {
+		return reported, ErrUpgradeVerification{Binary: binary, Attempted: attempted, Resulting: reported}
+	}
+	return reported, nil
+}
+
+// parseVersionOutput extracts the version from dot --version output,
+// such as "dot version 1.2.3 (commit: abc123, built: 2024-01-01)".
+func parseVersionOutput(output string) (string, error) {
+	fields := strings.Fields(output)
+	for i, field := range fields {
+		if field == "version" && i+1 < len(fields) {
+			return fields[i+1], nil
+		}
+	}
+	return "", fmt.Errorf("unrecognized version output: %q", strings.TrimSpace(output))
+}
@@ -0,0 +1,125 @@
+package updater
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeVersionScript creates an executable that prints output and exits
+// with code, standing in for an upgraded dot binary.
+func writeVersionScript(t *testing.T, output string, code int) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("shell scripts are not executable on Windows")
+	}
+	path := filepath.Join(t.TempDir(), "dot")
+	script := fmt.Sprintf("#!/bin/sh\necho '%s'\nexit %d\n", output, code)
+	require.NoError(t, os.WriteFile(path, []byte(script), 0o755))
+	return path
+}
+
+func TestVerifyUpgrade(t *testing.T) {
+	tests := []struct {
+		name          string
+		output        string
+		code          int
+		attempted     string
+		wantResulting string
+		wantErr       bool
+	}{
+		{
+			name:          "matching version",
+			output:        "dot version 1.2.0 (commit: abc123, built: 2024-01-01)",
+			attempted:     "v1.2.0",
+			wantResulting: "1.2.0",
+		},
+		{
+			name:          "newer version",
+			output:        "dot version v1.3.0 (commit: abc123, built: 2024-01-01)",
+			attempted:     "v1.2.0",
+			wantResulting: "v1.3.0",
+		},
+		{
+			name:          "older version",
+			output:        "dot version 1.1.0 (commit: abc123, built: 2024-01-01)",
+			attempted:     "v1.2.0",
+			wantResulting: "1.1.0",
+			wantErr:       true,
+		},
+		{
+			name:          "unparseable version",
+			output:        "dot version unknown (built from source)",
+			attempted:     "v1.2.0",
+			wantResulting: "unknown",
+			wantErr:       true,
+		},
+		{
+			name:      "unrecognized output",
+			output:    "segmentation fault",
+			attempted: "v1.2.0",
+			wantErr:   true,
+		},
+		{
+			name:      "binary fails",
+			output:    "dot version 1.2.0",
+			code:      1,
+			attempted: "v1.2.0",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			binary := writeVersionScript(t, tt.output, tt.code)
+
+			resulting, err := VerifyUpgrade(binary, tt.attempted)
+			assert.Equal(t, tt.wantResulting, resulting)
+			if !tt.wantErr {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			var verifyErr ErrUpgradeVerification
+			require.True(t, errors.As(err, &verifyErr), "got %T: %v", err, err)
+			assert.Equal(t, binary, verifyErr.Binary)
+			assert.Equal(t, tt.attempted, verifyErr.Attempted)
+			assert.Equal(t, tt.wantResulting, verifyErr.Resulting)
+		})
+	}
+}
+
+func TestVerifyUpgrade_MissingBinary(t *testing.T) {
+	binary := filepath.Join(t.TempDir(), "missing")
+
+	_, err := VerifyUpgrade(binary, "v1.2.0")
+	assert.ErrorIs(t, err, ErrUpgradeVerification{})
+}
+
+func TestVerifyUpgrade_InvalidAttemptedVersion(t *testing.T) {
+	_, err := VerifyUpgrade("dot", "latest")
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrUpgradeVerification{})
+}
+
+func TestErrUpgradeVerification_Error(t *testing.T) {
+	err := ErrUpgradeVerification{Binary: "/usr/bin/dot", Attempted: "v1.2.0", Resulting: "1.1.0"}
+	assert.Equal(t, "upgrade to v1.2.0 could not be verified: /usr/bin/dot reports version 1.1.0", err.Error())
+
+	cause := errors.New("exit status 1")
+	err = ErrUpgradeVerification{Binary: "/usr/bin/dot", Attempted: "v1.2.0", Cause: cause}
+	assert.Equal(t, "upgrade to v1.2.0 could not be verified: exit status 1", err.Error())
+	assert.ErrorIs(t, err, cause)
+}
+
+func TestFindInstalledBinary(t *testing.T) {
+	path, err := FindInstalledBinary()
+	require.NoError(t, err)
+	assert.NotEmpty(t, path)
+}
@@ -57,7 +57,7 @@ func NewVersionCheckerWithConfig(repository string, networkCfg *config.NetworkCo
 	}
 
 	// Create HTTP client with comprehensive timeout configuration
-	client := createHTTPClient(networkCfg)
+	client := NewHTTPClient(networkCfg)
 
 	return &VersionChecker{
 		httpClient: client,
@@ -65,8 +65,9 @@ func NewVersionCheckerWithConfig(repository string, networkCfg *config.NetworkCo
 	}
 }
 
-// createHTTPClient creates an HTTP client with comprehensive timeout and proxy configuration.
-func createHTTPClient(cfg *config.NetworkConfig) *http.Client {
+// NewHTTPClient creates an HTTP client with comprehensive timeout and proxy
+// configuration. Zero timeouts in cfg use the defaults.
+func NewHTTPClient(cfg *config.NetworkConfig) *http.Client {
 	// Apply defaults if values are 0
 	timeout := time.Duration(cfg.Timeout) * time.Second
 	if timeout == 0 {
@@ -491,7 +491,7 @@ func TestHTTPClientTimeout(t *testing.T) {
 			TLSTimeout:     5,
 		}
 
-		client := createHTTPClient(cfg)
+		client := NewHTTPClient(cfg)
 		req, _ := http.NewRequest("GET", server.URL, nil)
 		resp, err := client.Do(req)
 
@@ -516,7 +516,7 @@ func TestHTTPClientTimeout(t *testing.T) {
 			TLSTimeout:     5,
 		}
 
-		client := createHTTPClient(cfg)
+		client := NewHTTPClient(cfg)
 		req, _ := http.NewRequest("GET", server.URL, nil)
 		_, err := client.Do(req)
 
@@ -539,7 +539,7 @@ func TestHTTPClientProxy(t *testing.T) {
 			TLSTimeout:     5,
 		}
 
-		client := createHTTPClient(cfg)
+		client := NewHTTPClient(cfg)
 		require.NotNil(t, client)
 		require.NotNil(t, client.Transport)
 	})
@@ -551,7 +551,7 @@ func TestHTTPClientProxy(t *testing.T) {
 			TLSTimeout:     5,
 		}
 
-		client := createHTTPClient(cfg)
+		client := NewHTTPClient(cfg)
 		require.NotNil(t, client)
 		require.NotNil(t, client.Transport)
 	})
@@ -35,3 +35,27 @@ func NewTextLogger(w io.Writer, level slog.Level) Logger {
 		Level: level,
 	})))
 }
+
+// LogConfig describes the format and destination of structured logs.
+type LogConfig = adapters.LogConfig
+
+// LogRotationConfig controls when a log file is rotated.
+type LogRotationConfig = adapters.RotationConfig
+
+// NewConfiguredLogger returns a logger writing in cfg.Format to
+// cfg.Destination. The returned closer releases the log file, if any.
+func NewConfiguredLogger(cfg LogConfig) (Logger, io.Closer, error) {
+	return adapters.NewLoggerFromConfig(cfg)
+}
+
+// Command describes an external program for a CommandRunner to run.
+type Command = adapters.Command
+
+// CommandRunner runs external commands, such as hooks.
+type CommandRunner = adapters.CommandRunner
+
+// NewExecRunner returns a CommandRunner that runs commands with os/exec,
+// passing arguments to the program without a shell.
+func NewExecRunner() CommandRunner {
+	return adapters.NewExecRunner()
+}
@@ -0,0 +1,157 @@
+package dot_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yaklabco/dot/internal/adapters"
+	"github.com/yaklabco/dot/pkg/dot"
+)
+
+func newNestedAdoptClient(t *testing.T, fs dot.FS) *dot.Client {
+	t.Helper()
+	require.NoError(t, fs.MkdirAll(context.Background(), "/test/packages", 0755))
+	client, err := dot.NewClient(dot.Config{
+		PackageDir: "/test/packages",
+		TargetDir:  "/test/target",
+		FS:         fs,
+		Logger:     adapters.NewNoopLogger(),
+	})
+	require.NoError(t, err)
+	return client
+}
+
+func TestAdoptWithOptions_PreservePath(t *testing.T) {
+	tests := []struct {
+		name   string
+		dir    string            // directory to adopt, relative to target
+		pkgDir string            // expected location in the package
+		files  map[string]string // file in dir -> file in pkgDir
+	}{
+		{
+			name:   "single level",
+			dir:    ".vim",
+			pkgDir: "dot-vim",
+			files:  map[string]string{"vimrc": "vimrc", ".netrwhist": "dot-netrwhist"},
+		},
+		{
+			name:   "nested under config",
+			dir:    ".config/nvim",
+			pkgDir: "dot-config/nvim",
+			files:  map[string]string{"init.lua": "init.lua", "lua/plugins.lua": "lua/plugins.lua"},
+		},
+		{
+			name:   "deeply nested",
+			dir:    ".local/share/app/themes",
+			pkgDir: "dot-local/share/app/themes",
+			files:  map[string]string{"dark/.colors": "dark/dot-colors", "light": "light"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			fs := adapters.NewMemFS()
+			client := newNestedAdoptClient(t, fs)
+
+			source := filepath.Join("/test/target", tt.dir)
+			for name := range tt.files {
+				path := filepath.Join(source, name)
+				require.NoError(t, fs.MkdirAll(ctx, filepath.Dir(path), 0755))
+				require.NoError(t, fs.WriteFile(ctx, path, []byte(name), 0644))
+			}
+
+			opts := dot.AdoptOptions{PreservePath: true}
+			require.NoError(t, client.AdoptWithOptions(ctx, opts, []string{tt.dir}, "app"))
+
+			// Contents are stored at the translated nested path
+			nested := filepath.Join("/test/packages/app", tt.pkgDir)
+			for name, stored := range tt.files {
+				got, err := fs.ReadFile(ctx, filepath.Join(nested, stored))
+				require.NoError(t, err, stored)
+				assert.Equal(t, name, string(got))
+			}
+
+			// The link at the original location points to the nested directory
+			isLink, err := fs.IsSymlink(ctx, source)
+			require.NoError(t, err)
+			require.True(t, isLink)
+			target, err := fs.ReadLink(ctx, source)
+			require.NoError(t, err)
+			if !filepath.IsAbs(target) {
+				target = filepath.Join(filepath.Dir(source), target)
+			}
+			assert.Equal(t, nested, filepath.Clean(target))
+
+			status, err := client.Status(ctx, "app")
+			require.NoError(t, err)
+			require.Len(t, status.Packages, 1)
+			assert.Equal(t, []string{tt.dir}, status.Packages[0].Links)
+		})
+	}
+}
+
+func TestAdoptWithOptions_PreservePathKeepsFlatDefault(t *testing.T) {
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	client := newNestedAdoptClient(t, fs)
+	require.NoError(t, fs.MkdirAll(ctx, "/test/target/.config/nvim", 0755))
+	require.NoError(t, fs.WriteFile(ctx, "/test/target/.config/nvim/init.lua", []byte("x"), 0644))
+
+	require.NoError(t, client.Adopt(ctx, []string{".config/nvim"}, "nvim"))
+
+	assert.True(t, fs.Exists(ctx, "/test/packages/nvim/init.lua"))
+	assert.False(t, fs.Exists(ctx, "/test/packages/nvim/dot-config"))
+}
+
+func TestAdoptWithOptions_PreservePathConflict(t *testing.T) {
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	client := newNestedAdoptClient(t, fs)
+	require.NoError(t, fs.MkdirAll(ctx, "/test/target/.config/nvim", 0755))
+	require.NoError(t, fs.WriteFile(ctx, "/test/target/.config/nvim/init.lua", []byte("x"), 0644))
+	require.NoError(t, fs.MkdirAll(ctx, "/test/packages/nvim/dot-config/nvim", 0755))
+
+	opts := dot.AdoptOptions{PreservePath: true}
+	err := client.AdoptWithOptions(ctx, opts, []string{".config/nvim"}, "nvim")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already exists")
+	assert.True(t, fs.Exists(ctx, "/test/target/.config/nvim/init.lua"))
+}
+
+func TestAdoptWithOptions_PreservePathOutsideTarget(t *testing.T) {
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	client := newNestedAdoptClient(t, fs)
+	require.NoError(t, fs.MkdirAll(ctx, "/elsewhere/conf", 0755))
+
+	opts := dot.AdoptOptions{PreservePath: true}
+	err := client.AdoptWithOptions(ctx, opts, []string{"/elsewhere/conf"}, "conf")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not inside target directory")
+}
+
+func TestUnmanage_RestoresNestedAdoption(t *testing.T) {
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	client := newNestedAdoptClient(t, fs)
+	require.NoError(t, fs.MkdirAll(ctx, "/test/target/.config/nvim/lua", 0755))
+	require.NoError(t, fs.WriteFile(ctx, "/test/target/.config/nvim/init.lua", []byte("init"), 0644))
+	require.NoError(t, fs.WriteFile(ctx, "/test/target/.config/nvim/lua/plugins.lua", []byte("plugins"), 0644))
+
+	opts := dot.AdoptOptions{PreservePath: true}
+	require.NoError(t, client.AdoptWithOptions(ctx, opts, []string{".config/nvim"}, "nvim"))
+	require.NoError(t, client.Unmanage(ctx, "nvim"))
+
+	isLink, err := fs.IsSymlink(ctx, "/test/target/.config/nvim")
+	require.NoError(t, err)
+	assert.False(t, isLink)
+	data, err := fs.ReadFile(ctx, "/test/target/.config/nvim/lua/plugins.lua")
+	require.NoError(t, err)
+	assert.Equal(t, "plugins", string(data))
+	assert.False(t, fs.Exists(ctx, "/test/target/.config/nvim/dot-config"))
+}
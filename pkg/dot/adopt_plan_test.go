@@ -0,0 +1,118 @@
+package dot_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yaklabco/dot/internal/adapters"
+	"github.com/yaklabco/dot/pkg/dot"
+)
+
+// describeOps renders plan operations as comparable strings.
+func describeOps(t *testing.T, ops []dot.Operation) []string {
+	t.Helper()
+	described := make([]string, 0, len(ops))
+	for _, op := range ops {
+		switch o := op.(type) {
+		case dot.DirCreate:
+			described = append(described, "mkdir "+o.Path.String())
+		case dot.DirDelete:
+			described = append(described, "rmdir "+o.Path.String())
+		case dot.FileMove:
+			described = append(described, "move "+o.Source.String()+" -> "+o.Dest.String())
+		case dot.LinkCreate:
+			described = append(described, "link "+o.Target.String()+" -> "+o.Source.String())
+		default:
+			t.Fatalf("unexpected operation %T", op)
+		}
+	}
+	return described
+}
+
+func TestClient_PlanAdopt(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string // target-relative path -> content
+		adopt string
+		pkg   string
+		want  []string
+	}{
+		{
+			name:  "plain file",
+			files: map[string]string{"notes.txt": "notes"},
+			adopt: "notes.txt",
+			pkg:   "notes",
+			want: []string{
+				"mkdir /test/packages/notes",
+				"move /test/target/notes.txt -> /test/packages/notes/notes.txt",
+				"link /test/target/notes.txt -> /test/packages/notes/notes.txt",
+			},
+		},
+		{
+			name:  "dotfile",
+			files: map[string]string{".vimrc": "set nu"},
+			adopt: ".vimrc",
+			pkg:   "vim",
+			want: []string{
+				"mkdir /test/packages/vim",
+				"move /test/target/.vimrc -> /test/packages/vim/dot-vimrc",
+				"link /test/target/.vimrc -> /test/packages/vim/dot-vimrc",
+			},
+		},
+		{
+			name:  "directory",
+			files: map[string]string{".ssh/config": "Host *", ".ssh/keys/.keep": ""},
+			adopt: ".ssh",
+			pkg:   "dot-ssh",
+			want: []string{
+				"mkdir /test/packages/dot-ssh",
+				"mkdir /test/packages/dot-ssh/keys",
+				"move /test/target/.ssh/config -> /test/packages/dot-ssh/config",
+				"move /test/target/.ssh/keys/.keep -> /test/packages/dot-ssh/keys/dot-keep",
+				"rmdir /test/target/.ssh/keys",
+				"rmdir /test/target/.ssh",
+				"link /test/target/.ssh -> /test/packages/dot-ssh",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			fs := adapters.NewMemFS()
+			require.NoError(t, fs.MkdirAll(ctx, "/test/packages", 0755))
+			for path, content := range tt.files {
+				full := "/test/target/" + path
+				require.NoError(t, fs.MkdirAll(ctx, filepath.Dir(full), 0755))
+				require.NoError(t, fs.WriteFile(ctx, full, []byte(content), 0644))
+			}
+
+			client, err := dot.NewClient(dot.Config{
+				PackageDir: "/test/packages",
+				TargetDir:  "/test/target",
+				FS:         fs,
+				Logger:     adapters.NewNoopLogger(),
+			})
+			require.NoError(t, err)
+
+			plan, err := client.PlanAdopt(ctx, []string{tt.adopt}, tt.pkg)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.want, describeOps(t, plan.Operations))
+
+			// Planning leaves the filesystem untouched
+			for path, content := range tt.files {
+				data, err := fs.ReadFile(ctx, "/test/target/"+path)
+				require.NoError(t, err)
+				assert.Equal(t, content, string(data))
+			}
+			assert.False(t, fs.Exists(ctx, "/test/packages/"+tt.pkg))
+			isLink, err := fs.IsSymlink(ctx, "/test/target/"+tt.adopt)
+			require.NoError(t, err)
+			assert.False(t, isLink)
+		})
+	}
+}
@@ -7,11 +7,21 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/yaklabco/dot/internal/domain"
 	"github.com/yaklabco/dot/internal/executor"
 	"github.com/yaklabco/dot/internal/manifest"
 	"github.com/yaklabco/dot/internal/scanner"
 )
 
+// AdoptOptions configures adopt behavior.
+type AdoptOptions struct {
+	// PreservePath stores an adopted directory under the package at its path
+	// relative to the target directory (e.g. .config/nvim becomes
+	// dot-config/nvim) instead of moving its contents into the package root.
+	// Files always keep their relative path.
+	PreservePath bool
+}
+
 // AdoptService handles file adoption operations.
 type AdoptService struct {
 	fs          FS
@@ -125,7 +135,12 @@ func (s *AdoptService) resolveAdoptPath(ctx context.Context, file string) (strin
 
 // Adopt moves existing files from target into package then creates symlinks.
 func (s *AdoptService) Adopt(ctx context.Context, files []string, pkg string) error {
-	plan, err := s.PlanAdopt(ctx, files, pkg)
+	return s.AdoptWithOptions(ctx, AdoptOptions{}, files, pkg)
+}
+
+// AdoptWithOptions adopts files with specified options.
+func (s *AdoptService) AdoptWithOptions(ctx context.Context, opts AdoptOptions, files []string, pkg string) error {
+	plan, err := s.PlanAdoptWithOptions(ctx, opts, files, pkg)
 	if err != nil {
 		return err
 	}
@@ -158,6 +173,12 @@ func (s *AdoptService) Adopt(ctx context.Context, files []string, pkg string) er
 
 // PlanAdopt computes the execution plan for adopting files.
 func (s *AdoptService) PlanAdopt(ctx context.Context, files []string, pkg string) (Plan, error) {
+	return s.PlanAdoptWithOptions(ctx, AdoptOptions{}, files, pkg)
+}
+
+// PlanAdoptWithOptions computes the execution plan for adopting files with
+// specified options.
+func (s *AdoptService) PlanAdoptWithOptions(ctx context.Context, opts AdoptOptions, files []string, pkg string) (Plan, error) {
 	packagePathResult := NewPackagePath(s.packageDir)
 	if !packagePathResult.IsOk() {
 		return Plan{}, packagePathResult.UnwrapErr()
@@ -182,7 +203,7 @@ func (s *AdoptService) PlanAdopt(ctx context.Context, files []string, pkg string
 	}
 
 	for _, file := range files {
-		fileOps, err := s.planAdoptFile(ctx, file, pkgPath)
+		fileOps, err := s.planAdoptFile(ctx, file, pkgPath, opts)
 		if err != nil {
 			return Plan{}, err
 		}
@@ -197,18 +218,18 @@ func (s *AdoptService) PlanAdopt(ctx context.Context, files []string, pkg string
 	}
 	packageOps[pkg] = opIDs
 
-	return Plan{
+	return domain.AnnotateRisk(ctx, s.fs, Plan{
 		Operations:        operations,
 		PackageOperations: packageOps,
 		Metadata: PlanMetadata{
 			PackageCount:   1,
 			OperationCount: len(operations),
 		},
-	}, nil
+	}), nil
 }
 
 // planAdoptFile plans the operations for adopting a single file or directory.
-func (s *AdoptService) planAdoptFile(ctx context.Context, file, pkgPath string) ([]Operation, error) {
+func (s *AdoptService) planAdoptFile(ctx context.Context, file, pkgPath string, opts AdoptOptions) ([]Operation, error) {
 	sourceFile, err := s.resolveAdoptPath(ctx, file)
 	if err != nil {
 		return nil, fmt.Errorf("failed to resolve path %s: %w", file, err)
@@ -228,6 +249,9 @@ func (s *AdoptService) planAdoptFile(ctx context.Context, file, pkgPath string)
 	}
 
 	if isDir {
+		if opts.PreservePath {
+			return s.planNestedDirectoryAdopt(ctx, file, sourceFile, pkgPath)
+		}
 		return s.createDirectoryAdoptOperations(ctx, sourceFile, pkgPath, file)
 	}
 
@@ -270,6 +294,37 @@ func (s *AdoptService) planAdoptFile(ctx context.Context, file, pkgPath string)
 	return operations, nil
 }
 
+// planNestedDirectoryAdopt plans adopting a directory at its path relative
+// to the target directory, so .config/nvim is stored as dot-config/nvim in
+// the package and the link at .config/nvim points there.
+func (s *AdoptService) planNestedDirectoryAdopt(ctx context.Context, file, sourceDir, pkgPath string) ([]Operation, error) {
+	relPath, err := filepath.Rel(s.targetDir, sourceDir)
+	if err != nil || relPath == "." || relPath == ".." || strings.HasPrefix(relPath, ".."+string(filepath.Separator)) {
+		return nil, fmt.Errorf("cannot adopt %s with preserved path: not inside target directory %s", file, s.targetDir)
+	}
+	adoptedRelPath := translatePathComponents(relPath)
+	destDir := filepath.Join(pkgPath, adoptedRelPath)
+
+	if s.fs.Exists(ctx, destDir) {
+		return nil, fmt.Errorf("cannot adopt %s: directory %q already exists in package %q (use 'dot unmanage %s --purge' first to remove the existing package)", file, adoptedRelPath, filepath.Base(pkgPath), filepath.Base(pkgPath))
+	}
+
+	destResult := NewFilePath(destDir)
+	if !destResult.IsOk() {
+		return nil, destResult.UnwrapErr()
+	}
+
+	operations := s.planIntermediateDirs(ctx, adoptedRelPath, pkgPath)
+	dirID := OperationID(fmt.Sprintf("adopt-create-dir-%s", adoptedRelPath))
+	operations = append(operations, NewDirCreate(dirID, destResult.Unwrap()))
+
+	contentOps, err := s.createDirectoryAdoptOperations(ctx, sourceDir, destDir, file)
+	if err != nil {
+		return nil, err
+	}
+	return append(operations, contentOps...), nil
+}
+
 // planIntermediateDirs creates DirCreate operations for all missing intermediate
 // directories between pkgPath and the file's parent directory.
 func (s *AdoptService) planIntermediateDirs(ctx context.Context, adoptedRelPath, pkgPath string) []Operation {
@@ -302,7 +357,8 @@ func (s *AdoptService) planIntermediateDirs(ctx context.Context, adoptedRelPath,
 }
 
 // createDirectoryAdoptOperations creates operations to adopt a directory's contents.
-// Moves directory CONTENTS into package root (flat structure), not the directory itself.
+// Moves directory CONTENTS into pkgPath (flat structure), not the directory itself,
+// and links the original location to pkgPath.
 func (s *AdoptService) createDirectoryAdoptOperations(ctx context.Context, sourceDir, pkgPath, originalPath string) ([]Operation, error) {
 	var operations []Operation
 
@@ -512,10 +568,5 @@ func (s *AdoptService) checkIfSymlink(ctx context.Context, path string) (bool, s
 		return true, "", err
 	}
 
-	// Resolve relative paths
-	if !filepath.IsAbs(target) {
-		target = filepath.Join(filepath.Dir(path), target)
-	}
-
-	return true, target, nil
+	return true, domain.ResolveLinkTarget(path, target), nil
 }
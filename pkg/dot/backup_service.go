@@ -0,0 +1,236 @@
+package dot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// backupTimestampLayout is the suffix the backup conflict policy appends to
+// backed up files: <name>.<timestamp>, or <name>.<timestamp>-<n> when that
+// name was already taken. It also names the per-run subdirectory each
+// manage run writes its backups to.
+const backupTimestampLayout = "20060102-150405"
+
+// PruneOptions selects which conflict backups PruneBackups removes. A
+// backup is removed when it is older than MaxAge or is not among the
+// KeepCount newest backups of the same file. At least one must be set.
+type PruneOptions struct {
+	// MaxAge removes backups created longer ago than this. Zero keeps
+	// backups of any age.
+	MaxAge time.Duration
+
+	// KeepCount keeps only this many of the newest backups of each file.
+	// Zero keeps any number.
+	KeepCount int
+
+	// DryRun reports what would be removed without removing anything.
+	DryRun bool
+}
+
+// validate checks that at least one retention limit is set.
+func (o PruneOptions) validate() error {
+	if o.MaxAge < 0 {
+		return fmt.Errorf("invalid max age %s: must not be negative", o.MaxAge)
+	}
+	if o.KeepCount < 0 {
+		return fmt.Errorf("invalid keep count %d: must not be negative", o.KeepCount)
+	}
+	if o.MaxAge == 0 && o.KeepCount == 0 {
+		return errors.New("prune requires a max age or keep count")
+	}
+	return nil
+}
+
+// BackupFile describes a conflict backup in the backup directory.
+type BackupFile struct {
+	// Path is the absolute path of the backup.
+	Path string `json:"path" yaml:"path"`
+	// Original is the name of the file that was backed up.
+	Original string `json:"original" yaml:"original"`
+	// CreatedAt is when the backup was made, from its timestamp suffix.
+	CreatedAt time.Time `json:"created_at" yaml:"created_at"`
+	// Size is the backup's size in bytes.
+	Size int64 `json:"size" yaml:"size"`
+}
+
+// PruneResult reports the backups PruneBackups removed, or would remove in
+// a dry run.
+type PruneResult struct {
+	Removed    []BackupFile `json:"removed" yaml:"removed"`
+	Kept       int          `json:"kept" yaml:"kept"`
+	FreedBytes int64        `json:"freed_bytes" yaml:"freed_bytes"`
+	DryRun     bool         `json:"dry_run" yaml:"dry_run"`
+}
+
+// BackupService manages the backups conflict resolution leaves in the
+// backup directory.
+type BackupService struct {
+	fs        FS
+	logger    Logger
+	backupDir string
+	dryRun    bool
+}
+
+// newBackupService creates a new backup service.
+func newBackupService(fs FS, logger Logger, backupDir string, dryRun bool) *BackupService {
+	return &BackupService{
+		fs:        fs,
+		logger:    logger,
+		backupDir: backupDir,
+		dryRun:    dryRun,
+	}
+}
+
+// PruneBackups removes conflict backups outside the retention limits in
+// opts. Only regular files whose names carry a backup timestamp, directly
+// in the backup directory or in one of its run subdirectories, are
+// considered, so unrelated files are never touched. A run subdirectory left
+// empty is removed. A missing backup directory has nothing to prune.
+func (s *BackupService) PruneBackups(ctx context.Context, opts PruneOptions) (PruneResult, error) {
+	if err := opts.validate(); err != nil {
+		return PruneResult{}, err
+	}
+	dryRun := opts.DryRun || s.dryRun
+	result := PruneResult{Removed: []BackupFile{}, DryRun: dryRun}
+
+	backups, err := s.listBackups(ctx)
+	if err != nil {
+		return PruneResult{}, err
+	}
+
+	cutoff := time.Now().Add(-opts.MaxAge)
+	byOriginal := make(map[string]int)
+	runDirs := make(map[string]bool)
+	for _, backup := range backups {
+		byOriginal[backup.Original]++
+		expired := opts.MaxAge > 0 && backup.CreatedAt.Before(cutoff)
+		surplus := opts.KeepCount > 0 && byOriginal[backup.Original] > opts.KeepCount
+		if !expired && !surplus {
+			result.Kept++
+			continue
+		}
+
+		if !dryRun {
+			if err := s.fs.Remove(ctx, backup.Path); err != nil {
+				return result, fmt.Errorf("remove backup %s: %w", backup.Path, err)
+			}
+			s.logger.Info(ctx, "backup_pruned", "path", backup.Path, "created_at", backup.CreatedAt)
+			if dir := filepath.Dir(backup.Path); dir != s.backupDir {
+				runDirs[dir] = true
+			}
+		}
+		result.Removed = append(result.Removed, backup)
+		result.FreedBytes += backup.Size
+	}
+
+	for dir := range runDirs {
+		if entries, err := s.fs.ReadDir(ctx, dir); err == nil && len(entries) == 0 {
+			if err := s.fs.Remove(ctx, dir); err != nil {
+				s.logger.Warn(ctx, "backup_run_dir_remove_failed", "path", dir, "error", err)
+			}
+		}
+	}
+	return result, nil
+}
+
+// listBackups returns the backups in the backup directory and its run
+// subdirectories, newest first.
+func (s *BackupService) listBackups(ctx context.Context) ([]BackupFile, error) {
+	entries, err := s.fs.ReadDir(ctx, s.backupDir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read backup directory: %w", err)
+	}
+
+	backups, err := s.backupsIn(ctx, s.backupDir, entries)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() || !isBackupRunDir(entry.Name()) {
+			continue
+		}
+		runDir := filepath.Join(s.backupDir, entry.Name())
+		runEntries, err := s.fs.ReadDir(ctx, runDir)
+		if err != nil {
+			return nil, fmt.Errorf("read backup directory: %w", err)
+		}
+		runBackups, err := s.backupsIn(ctx, runDir, runEntries)
+		if err != nil {
+			return nil, err
+		}
+		backups = append(backups, runBackups...)
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		if !backups[i].CreatedAt.Equal(backups[j].CreatedAt) {
+			return backups[i].CreatedAt.After(backups[j].CreatedAt)
+		}
+		return backups[i].Path < backups[j].Path
+	})
+	return backups, nil
+}
+
+// backupsIn returns the backup files among the entries of dir.
+func (s *BackupService) backupsIn(ctx context.Context, dir string, entries []fs.DirEntry) ([]BackupFile, error) {
+	var backups []BackupFile
+	for _, entry := range entries {
+		original, createdAt, ok := parseBackupName(entry.Name())
+		if !ok {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		info, err := s.fs.Lstat(ctx, path)
+		if err != nil {
+			return nil, fmt.Errorf("inspect backup %s: %w", path, err)
+		}
+		if !info.Mode().IsRegular() {
+			continue
+		}
+		backups = append(backups, BackupFile{
+			Path:      path,
+			Original:  original,
+			CreatedAt: createdAt,
+			Size:      info.Size(),
+		})
+	}
+	return backups, nil
+}
+
+// isBackupRunDir reports whether name is a run subdirectory of the backup
+// directory, named by the run's timestamp.
+func isBackupRunDir(name string) bool {
+	_, err := time.ParseInLocation(backupTimestampLayout, name, time.Local)
+	return err == nil
+}
+
+// parseBackupName splits a backup file name into the original file name
+// and the time the backup was made. It reports false for names without a
+// backup timestamp suffix.
+func parseBackupName(name string) (string, time.Time, bool) {
+	dot := strings.LastIndex(name, ".")
+	if dot <= 0 {
+		return "", time.Time{}, false
+	}
+	stamp := name[dot+1:]
+	// Drop the counter added to a name already taken
+	if len(stamp) > len(backupTimestampLayout)+1 && stamp[len(backupTimestampLayout)] == '-' {
+		if _, err := strconv.Atoi(stamp[len(backupTimestampLayout)+1:]); err == nil {
+			stamp = stamp[:len(backupTimestampLayout)]
+		}
+	}
+	createdAt, err := time.ParseInLocation(backupTimestampLayout, stamp, time.Local)
+	if err != nil {
+		return "", time.Time{}, false
+	}
+	return name[:dot], createdAt, true
+}
@@ -0,0 +1,278 @@
+package dot
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yaklabco/dot/internal/adapters"
+)
+
+const testBackupDir = "/home/.dot-backup"
+
+// writeBackup creates a backup of name made age ago, named the way the
+// backup conflict policy names it, and returns its path.
+func writeBackup(t *testing.T, fs FS, name string, age time.Duration) string {
+	t.Helper()
+	timestamp := time.Now().Add(-age).Format(backupTimestampLayout)
+	path := fmt.Sprintf("%s/%s.%s", testBackupDir, name, timestamp)
+	require.NoError(t, fs.WriteFile(context.Background(), path, []byte("backup of "+name), 0600))
+	return path
+}
+
+func newTestBackupService(t *testing.T) (*BackupService, FS) {
+	t.Helper()
+	fs := adapters.NewMemFS()
+	require.NoError(t, fs.MkdirAll(context.Background(), testBackupDir, 0700))
+	return newBackupService(fs, adapters.NewNoopLogger(), testBackupDir, false), fs
+}
+
+func removedPaths(result PruneResult) []string {
+	paths := make([]string, 0, len(result.Removed))
+	for _, backup := range result.Removed {
+		paths = append(paths, backup.Path)
+	}
+	return paths
+}
+
+func TestBackupService_PruneBackups_MaxAge(t *testing.T) {
+	ctx := context.Background()
+	svc, fs := newTestBackupService(t)
+	recent := writeBackup(t, fs, ".vimrc", time.Hour)
+	old := writeBackup(t, fs, ".vimrc", 72*time.Hour)
+	oldZsh := writeBackup(t, fs, ".zshrc", 96*time.Hour)
+
+	result, err := svc.PruneBackups(ctx, PruneOptions{MaxAge: 48 * time.Hour})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{old, oldZsh}, removedPaths(result))
+	assert.Equal(t, 1, result.Kept)
+	assert.Equal(t, int64(len("backup of .vimrc")+len("backup of .zshrc")), result.FreedBytes)
+	assert.False(t, result.DryRun)
+	assert.True(t, fs.Exists(ctx, recent))
+	assert.False(t, fs.Exists(ctx, old))
+	assert.False(t, fs.Exists(ctx, oldZsh))
+}
+
+func TestBackupService_PruneBackups_KeepCount(t *testing.T) {
+	ctx := context.Background()
+	svc, fs := newTestBackupService(t)
+	var vimrc []string
+	for i := 1; i <= 4; i++ {
+		vimrc = append(vimrc, writeBackup(t, fs, ".vimrc", time.Duration(i)*time.Hour))
+	}
+	zshrc := writeBackup(t, fs, ".zshrc", 10*time.Hour)
+
+	result, err := svc.PruneBackups(ctx, PruneOptions{KeepCount: 2})
+	require.NoError(t, err)
+
+	// Only the two oldest .vimrc backups exceed the count; .zshrc has one
+	assert.Equal(t, []string{vimrc[2], vimrc[3]}, removedPaths(result))
+	assert.Equal(t, 3, result.Kept)
+	assert.True(t, fs.Exists(ctx, vimrc[0]))
+	assert.True(t, fs.Exists(ctx, vimrc[1]))
+	assert.True(t, fs.Exists(ctx, zshrc))
+}
+
+func TestBackupService_PruneBackups_MaxAgeAndKeepCount(t *testing.T) {
+	ctx := context.Background()
+	svc, fs := newTestBackupService(t)
+	newest := writeBackup(t, fs, ".vimrc", time.Hour)
+	second := writeBackup(t, fs, ".vimrc", 2*time.Hour)
+	expired := writeBackup(t, fs, ".zshrc", 200*time.Hour)
+
+	result, err := svc.PruneBackups(ctx, PruneOptions{MaxAge: 100 * time.Hour, KeepCount: 1})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{second, expired}, removedPaths(result))
+	assert.Equal(t, 1, result.Kept)
+	assert.True(t, fs.Exists(ctx, newest))
+}
+
+func TestBackupService_PruneBackups_DryRun(t *testing.T) {
+	ctx := context.Background()
+
+	for _, tt := range []struct {
+		name       string
+		optsDryRun bool
+		svcDryRun  bool
+	}{
+		{name: "option", optsDryRun: true},
+		{name: "client dry-run mode", svcDryRun: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, fs := newTestBackupService(t)
+			svc.dryRun = tt.svcDryRun
+			old := writeBackup(t, fs, ".vimrc", 72*time.Hour)
+
+			result, err := svc.PruneBackups(ctx, PruneOptions{MaxAge: 24 * time.Hour, DryRun: tt.optsDryRun})
+			require.NoError(t, err)
+
+			assert.True(t, result.DryRun)
+			assert.Equal(t, []string{old}, removedPaths(result))
+			assert.Equal(t, int64(len("backup of .vimrc")), result.FreedBytes)
+			assert.True(t, fs.Exists(ctx, old), "dry run must not remove backups")
+		})
+	}
+}
+
+func TestBackupService_PruneBackups_IgnoresUnrelatedFiles(t *testing.T) {
+	ctx := context.Background()
+	svc, fs := newTestBackupService(t)
+	stamp := time.Now().Add(-72 * time.Hour).Format(backupTimestampLayout)
+	unrelated := []string{
+		testBackupDir + "/notes.txt",
+		testBackupDir + "/.vimrc.bak",
+		testBackupDir + "/nested/.vimrc." + stamp,
+	}
+	for _, path := range unrelated {
+		require.NoError(t, fs.MkdirAll(ctx, filepath.Dir(path), 0700))
+		require.NoError(t, fs.WriteFile(ctx, path, []byte("keep"), 0600))
+	}
+	// A directory named like a backup is not a backup file
+	require.NoError(t, fs.MkdirAll(ctx, testBackupDir+"/.config."+stamp, 0700))
+	// A symlink named like a backup is not a backup file either
+	require.NoError(t, fs.Symlink(ctx, "/etc/passwd", testBackupDir+"/.profile."+stamp))
+
+	result, err := svc.PruneBackups(ctx, PruneOptions{MaxAge: time.Hour})
+	require.NoError(t, err)
+
+	assert.Empty(t, result.Removed)
+	for _, path := range unrelated {
+		assert.True(t, fs.Exists(ctx, path), path)
+	}
+	assert.True(t, fs.Exists(ctx, testBackupDir+"/.config."+stamp))
+}
+
+func TestBackupService_PruneBackups_MissingDirectory(t *testing.T) {
+	svc := newBackupService(adapters.NewMemFS(), adapters.NewNoopLogger(), "/nonexistent", false)
+
+	result, err := svc.PruneBackups(context.Background(), PruneOptions{KeepCount: 1})
+	require.NoError(t, err)
+	assert.Empty(t, result.Removed)
+	assert.Zero(t, result.Kept)
+}
+
+func TestPruneOptions_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    PruneOptions
+		wantErr string
+	}{
+		{name: "no limits", opts: PruneOptions{}, wantErr: "max age or keep count"},
+		{name: "dry run only", opts: PruneOptions{DryRun: true}, wantErr: "max age or keep count"},
+		{name: "negative age", opts: PruneOptions{MaxAge: -time.Hour}, wantErr: "max age"},
+		{name: "negative count", opts: PruneOptions{KeepCount: -1}, wantErr: "keep count"},
+		{name: "max age", opts: PruneOptions{MaxAge: time.Hour}},
+		{name: "keep count", opts: PruneOptions{KeepCount: 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.opts.validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestParseBackupName(t *testing.T) {
+	tests := []struct {
+		name         string
+		file         string
+		wantOriginal string
+		wantTime     time.Time
+		wantOK       bool
+	}{
+		{
+			name:         "dotfile backup",
+			file:         ".vimrc.20251007-103000",
+			wantOriginal: ".vimrc",
+			wantTime:     time.Date(2025, 10, 7, 10, 30, 0, 0, time.Local),
+			wantOK:       true,
+		},
+		{
+			name:         "name with dots",
+			file:         "init.lua.20240101-000000",
+			wantOriginal: "init.lua",
+			wantTime:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local),
+			wantOK:       true,
+		},
+		{
+			name:         "second backup in the same second",
+			file:         ".vimrc.20251007-103000-2",
+			wantOriginal: ".vimrc",
+			wantTime:     time.Date(2025, 10, 7, 10, 30, 0, 0, time.Local),
+			wantOK:       true,
+		},
+		{name: "no suffix", file: "notes"},
+		{name: "non-numeric counter", file: ".vimrc.20251007-103000-x"},
+		{name: "other suffix", file: ".vimrc.bak"},
+		{name: "timestamp only", file: ".20240101-000000"},
+		{name: "config backup", file: "20241110-153045-config.bak"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original, createdAt, ok := parseBackupName(tt.file)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantOriginal, original)
+			assert.True(t, tt.wantTime.Equal(createdAt), "got %s", createdAt)
+		})
+	}
+}
+
+func TestClient_PruneBackups(t *testing.T) {
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	require.NoError(t, fs.MkdirAll(ctx, "/test/packages", 0755))
+	require.NoError(t, fs.MkdirAll(ctx, testBackupDir, 0700))
+	old := writeBackup(t, fs, ".vimrc", 72*time.Hour)
+
+	client, err := NewClient(Config{
+		PackageDir: "/test/packages",
+		TargetDir:  "/home",
+		BackupDir:  testBackupDir,
+		FS:         fs,
+		Logger:     adapters.NewNoopLogger(),
+	})
+	require.NoError(t, err)
+
+	result, err := client.PruneBackups(ctx, PruneOptions{MaxAge: 24 * time.Hour})
+	require.NoError(t, err)
+	assert.Equal(t, []string{old}, removedPaths(result))
+	assert.False(t, fs.Exists(ctx, old))
+}
+
+func TestBackupService_PruneBackups_RunDirectories(t *testing.T) {
+	ctx := context.Background()
+	svc, fs := newTestBackupService(t)
+	writeRunBackup := func(name string, age time.Duration) string {
+		stamp := time.Now().Add(-age).Format(backupTimestampLayout)
+		path := fmt.Sprintf("%s/%s/%s.%s", testBackupDir, stamp, name, stamp)
+		require.NoError(t, fs.MkdirAll(ctx, filepath.Dir(path), 0700))
+		require.NoError(t, fs.WriteFile(ctx, path, []byte("backup of "+name), 0600))
+		return path
+	}
+	recent := writeRunBackup(".vimrc", time.Hour)
+	old := writeRunBackup(".vimrc", 72*time.Hour)
+	legacy := writeBackup(t, fs, ".zshrc", 96*time.Hour)
+
+	result, err := svc.PruneBackups(ctx, PruneOptions{MaxAge: 48 * time.Hour})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{old, legacy}, removedPaths(result))
+	assert.True(t, fs.Exists(ctx, recent))
+	assert.False(t, fs.Exists(ctx, filepath.Dir(old)), "an emptied run directory is removed")
+	assert.True(t, fs.Exists(ctx, filepath.Dir(recent)))
+	assert.True(t, fs.Exists(ctx, testBackupDir))
+}
@@ -2,7 +2,6 @@ package dot
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"path/filepath"
 
@@ -16,6 +15,10 @@ type BootstrapService struct {
 	logger     Logger
 	packageDir string
 	targetDir  string
+
+	// manifestSvc loads the manifest from its configured location. If
+	// nil, the manifest is read from the target directory.
+	manifestSvc *ManifestService
 }
 
 // newBootstrapService creates a new bootstrap service.
@@ -156,21 +159,21 @@ func (s *BootstrapService) WriteBootstrap(ctx context.Context, data []byte, outp
 
 // getInstalledPackages retrieves the list of installed packages from manifest.
 func (s *BootstrapService) getInstalledPackages(ctx context.Context) ([]string, error) {
-	// Read manifest file
-	manifestPath := filepath.Join(s.targetDir, ".dot-manifest.json")
-	if !s.fs.Exists(ctx, manifestPath) {
-		return []string{}, nil // No manifest is okay
+	targetPathResult := NewTargetPath(s.targetDir)
+	if !targetPathResult.IsOk() {
+		return nil, targetPathResult.UnwrapErr()
 	}
 
-	data, err := s.fs.ReadFile(ctx, manifestPath)
-	if err != nil {
-		return nil, fmt.Errorf("read manifest file: %w", err)
+	load := manifest.NewFSManifestStore(s.fs).Load
+	if s.manifestSvc != nil {
+		load = s.manifestSvc.Load
 	}
-
-	var m manifest.Manifest
-	if err := json.Unmarshal(data, &m); err != nil {
-		return nil, fmt.Errorf("parse manifest JSON: %w", err)
+	// A missing manifest loads as an empty one
+	result := load(ctx, targetPathResult.Unwrap())
+	if !result.IsOk() {
+		return nil, fmt.Errorf("load manifest: %w", result.UnwrapErr())
 	}
+	m := result.Unwrap()
 
 	installedPackages := make([]string, 0, len(m.Packages))
 	for _, pkg := range m.Packages {
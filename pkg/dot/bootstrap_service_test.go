@@ -380,3 +380,30 @@ func TestBootstrapService_Integration(t *testing.T) {
 	assert.Contains(t, string(data), "version:")
 	assert.Contains(t, string(data), "packages:")
 }
+
+func TestClient_GenerateBootstrap_FromManifestDir(t *testing.T) {
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	require.NoError(t, fs.MkdirAll(ctx, "/test/packages/vim", 0755))
+	require.NoError(t, fs.MkdirAll(ctx, "/test/packages/zsh", 0755))
+	require.NoError(t, fs.MkdirAll(ctx, "/test/target", 0755))
+	require.NoError(t, fs.WriteFile(ctx, "/test/packages/vim/dot-vimrc", []byte("set nocp"), 0644))
+	require.NoError(t, fs.WriteFile(ctx, "/test/packages/zsh/dot-zshrc", []byte("# zsh"), 0644))
+
+	client, err := NewClient(Config{
+		PackageDir:         "/test/packages",
+		TargetDir:          "/test/target",
+		ManifestDir:        "/test/state",
+		PackageNameMapping: true,
+		FS:                 fs,
+		Logger:             adapters.NewNoopLogger(),
+	})
+	require.NoError(t, err)
+	require.NoError(t, client.Manage(ctx, "vim"))
+
+	result, err := client.GenerateBootstrap(ctx, GenerateBootstrapOptions{FromManifest: true})
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.PackageCount)
+	require.Len(t, result.Config.Packages, 1)
+	assert.Equal(t, "vim", result.Config.Packages[0].Name)
+}
@@ -0,0 +1,41 @@
+package dot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// DefaultCacheDir returns the directory for Config.CacheDir under the XDG
+// cache directory, falling back to the platform cache directory.
+func DefaultCacheDir() string {
+	if cacheHome := os.Getenv("XDG_CACHE_HOME"); cacheHome != "" {
+		return filepath.Join(cacheHome, "dot")
+	}
+	if cacheDir, err := os.UserCacheDir(); err == nil {
+		return filepath.Join(cacheDir, "dot")
+	}
+	return filepath.Join(os.TempDir(), "dot", "cache")
+}
+
+// ClearCache removes the scan and status caches stored in dir. Clearing a
+// cache that does not exist succeeds.
+func ClearCache(ctx context.Context, fsys FS, dir string) error {
+	if err := fsys.RemoveAll(ctx, dir); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("clear cache: %w", err)
+	}
+	return nil
+}
+
+// scanCacheDir returns the directory caching scanned package trees.
+func scanCacheDir(cacheDir string) string {
+	return filepath.Join(cacheDir, "scan")
+}
+
+// statusCacheDir returns the directory caching package link health.
+func statusCacheDir(cacheDir string) string {
+	return filepath.Join(cacheDir, "status")
+}
@@ -36,3 +36,14 @@ type WriteOptions = config.WriteOptions
 func UpgradeConfig(configPath string, force bool) (string, error) {
 	return config.UpgradeConfig(configPath, force)
 }
+
+// BackupConfig saves a timestamped copy of the configuration file and
+// returns the backup path.
+func BackupConfig(configPath string) (string, error) {
+	return config.BackupConfig(configPath)
+}
+
+// RestoreConfig copies a configuration backup over the configuration file.
+func RestoreConfig(backupPath, configPath string) error {
+	return config.RestoreConfig(backupPath, configPath)
+}
@@ -4,10 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/yaklabco/dot/internal/adapters"
+	"github.com/yaklabco/dot/internal/cli/render"
 	"github.com/yaklabco/dot/internal/cli/selector"
+	"github.com/yaklabco/dot/internal/domain"
 	"github.com/yaklabco/dot/internal/executor"
 	"github.com/yaklabco/dot/internal/ignore"
 	"github.com/yaklabco/dot/internal/manifest"
@@ -25,6 +28,7 @@ import (
 // All operations are safe for concurrent use from multiple goroutines.
 type Client struct {
 	config       Config
+	manifestSvc  *ManifestService
 	manageSvc    *ManageService
 	unmanageSvc  *UnmanageService
 	statusSvc    *StatusService
@@ -32,6 +36,8 @@ type Client struct {
 	adoptSvc     *AdoptService
 	cloneSvc     *CloneService
 	bootstrapSvc *BootstrapService
+	diffSvc      *DiffService
+	backupSvc    *BackupService
 }
 
 // NewClient creates a new Client with the given configuration.
@@ -50,23 +56,22 @@ func NewClient(cfg Config) (*Client, error) {
 	// Apply defaults
 	cfg = cfg.WithDefaults()
 
-	// Build ignore set from configuration
-	ignoreSet := ignore.NewIgnoreSet()
-
-	// Add default patterns if enabled
-	if cfg.UseDefaultIgnorePatterns {
-		for _, pattern := range ignore.DefaultIgnorePatterns() {
-			if err := ignoreSet.Add(pattern); err != nil {
-				return nil, fmt.Errorf("add default pattern %q: %w", pattern, err)
-			}
-		}
+	// Meter calls that reach the filesystem, beneath the read-only and
+	// caching decorators so cache hits and rejected writes are not counted
+	cfg.FS = adapters.NewMeteredFS(cfg.FS, cfg.Metrics)
+
+	// Status and planning never write, and neither may anything in a dry
+	// run. Those paths use a read-only view, so a missed check fails with
+	// ErrReadOnlyFS instead of modifying the filesystem.
+	readOnlyFS := adapters.NewReadOnlyFS(cfg.FS)
+	if cfg.DryRun {
+		cfg.FS = readOnlyFS
 	}
 
-	// Add user-specified patterns
-	for _, pattern := range cfg.IgnorePatterns {
-		if err := ignoreSet.Add(pattern); err != nil {
-			return nil, fmt.Errorf("add ignore pattern %q: %w", pattern, err)
-		}
+	// Build ignore set from configuration
+	ignoreSet, err := ignore.NewIgnoreSetFromConfig(cfg.UseDefaultIgnorePatterns, cfg.IgnorePatterns)
+	if err != nil {
+		return nil, err
 	}
 
 	// Build scanner configuration
@@ -74,6 +79,10 @@ func NewClient(cfg Config) (*Client, error) {
 		PerPackageIgnore: cfg.PerPackageIgnore,
 		MaxFileSize:      cfg.MaxFileSize,
 		Interactive:      cfg.InteractiveLargeFiles,
+		Concurrency:      cfg.Concurrency,
+	}
+	if cfg.CacheDir != "" {
+		scanConfig.Cache = scanner.NewTreeCache(cfg.FS, scanCacheDir(cfg.CacheDir))
 	}
 
 	// Determine resolution policy from config
@@ -87,54 +96,88 @@ func NewClient(cfg Config) (*Client, error) {
 
 	// Create resolution policies
 	policies := planner.ResolutionPolicies{
-		OnFileExists: fileExistsPolicy,
+		OnFileExists:   fileExistsPolicy,
+		BackupStrategy: cfg.BackupStrategy,
+		BackupSuffix:   cfg.BackupSuffix,
 	}
 
-	// Create manage pipeline
+	// Create manage pipeline. Planning never writes and stats the same paths
+	// repeatedly, so it reads through a read-only cache that the manage
+	// service scopes to each plan.
+	planFS := adapters.NewCachingFS(readOnlyFS)
 	managePipe := pipeline.NewManagePipeline(pipeline.ManagePipelineOpts{
-		FS:                 cfg.FS,
+		FS:                 planFS,
 		IgnoreSet:          ignoreSet,
 		ScanConfig:         scanConfig,
 		Policies:           policies,
 		BackupDir:          cfg.BackupDir,
+		LinkMode:           plannerLinkMode(cfg.LinkMode),
+		Folding:            cfg.Folding,
 		PackageNameMapping: cfg.PackageNameMapping,
+		PackageMappings:    cfg.PackageMappings,
 		Translate:          cfg.Translate,
+		Tracer:             cfg.Tracer,
 	})
 
 	// Create executor
 	exec := executor.New(executor.Opts{
-		FS:          cfg.FS,
-		Logger:      cfg.Logger,
-		Tracer:      cfg.Tracer,
-		Concurrency: cfg.Concurrency,
+		FS:              cfg.FS,
+		Logger:          cfg.Logger,
+		Tracer:          cfg.Tracer,
+		Concurrency:     cfg.Concurrency,
+		TransactionSize: cfg.TransactionSize,
+		Retry: executor.RetryPolicy{
+			MaxAttempts: cfg.RetryAttempts,
+			BaseBackoff: cfg.RetryBackoff,
+		},
 	})
 
 	// Create manifest store and service
 	var manifestStore *manifest.FSManifestStore
 	if cfg.ManifestDir != "" {
-		manifestStore = manifest.NewFSManifestStoreWithDir(cfg.FS, cfg.ManifestDir)
+		manifestStore = manifest.NewFSManifestStoreWithDir(cfg.FS, cfg.ManifestDir).WithLogger(cfg.Logger)
 	} else {
 		manifestStore = manifest.NewFSManifestStore(cfg.FS)
 	}
+	if cfg.ManifestLockNoWait {
+		manifestStore.WithLockTimeout(0)
+	}
 	manifestSvc := newManifestService(cfg.FS, cfg.Logger, manifestStore)
 
 	// Create specialized services (unmanageSvc first since manageSvc depends on it)
 	unmanageSvc := newUnmanageService(cfg.FS, cfg.Logger, exec, manifestSvc, cfg.PackageDir, cfg.TargetDir, cfg.DryRun)
 	manageSvc := newManageService(cfg.FS, cfg.Logger, managePipe, exec, manifestSvc, unmanageSvc, cfg.PackageDir, cfg.TargetDir, cfg.DryRun)
-	statusSvc := newStatusService(cfg.FS, cfg.Logger, manifestSvc, cfg.TargetDir)
-	adoptSvc := newAdoptService(cfg.FS, cfg.Logger, exec, manifestSvc, cfg.PackageDir, cfg.TargetDir, cfg.DryRun)
-	doctorSvc := newDoctorServiceWithAdopt(cfg.FS, cfg.Logger, manifestSvc, adoptSvc, cfg.PackageDir, cfg.TargetDir)
+	manageSvc.planFS = planFS
+	manageSvc.linkMode = cfg.LinkMode
+	hooks := newHookRunner(cfg)
+	manageSvc.hooks = hooks
+	unmanageSvc.hooks = hooks
+	statusSvc := newStatusService(readOnlyFS, cfg.Logger, manifestSvc, cfg.PackageDir, cfg.TargetDir)
+	if cfg.CacheDir != "" {
+		statusSvc.cache = newStatusCache(cfg.FS, statusCacheDir(cfg.CacheDir), cfg.TargetDir, cfg.PackageDir)
+	}
+	// Adoption only reads while planning; the executor performs the moves
+	adoptSvc := newAdoptService(readOnlyFS, cfg.Logger, exec, manifestSvc, cfg.PackageDir, cfg.TargetDir, cfg.DryRun)
+	doctorSvc := newDoctorServiceWithAdopt(cfg.FS, cfg.Logger, manifestSvc, adoptSvc, exec, cfg.PackageDir, cfg.TargetDir)
+	doctorSvc.categories = cfg.DoctorCategories
+	diffSvc := newDiffService(readOnlyFS, cfg.Logger, managePipe, manifestSvc, cfg.PackageDir, cfg.TargetDir)
+	diffSvc.planFS = planFS
+	backupSvc := newBackupService(cfg.FS, cfg.Logger, cfg.BackupDir, cfg.DryRun)
 
 	// Create git cloner and package selector for clone service
 	gitCloner := adapters.NewGoGitCloner()
-	packageSelector := selector.NewInteractiveSelector(cfg.GetStdin(), cfg.GetStdout())
+	theme, _ := render.ThemeByName(cfg.Theme)
+	packageSelector := selector.New(cfg.GetStdin(), cfg.GetStdout(), theme)
 	cloneSvc := newCloneService(cfg.FS, cfg.Logger, manageSvc, gitCloner, packageSelector, cfg.PackageDir, cfg.TargetDir, cfg.DryRun)
+	cloneSvc.prompter = adapters.NewTerminalPassphrasePrompter(cfg.GetStdin(), cfg.GetStdout())
 
 	// Create bootstrap service
 	bootstrapSvc := newBootstrapService(cfg.FS, cfg.Logger, cfg.PackageDir, cfg.TargetDir)
+	bootstrapSvc.manifestSvc = manifestSvc
 
 	return &Client{
 		config:       cfg,
+		manifestSvc:  manifestSvc,
 		manageSvc:    manageSvc,
 		unmanageSvc:  unmanageSvc,
 		statusSvc:    statusSvc,
@@ -142,9 +185,25 @@ func NewClient(cfg Config) (*Client, error) {
 		adoptSvc:     adoptSvc,
 		cloneSvc:     cloneSvc,
 		bootstrapSvc: bootstrapSvc,
+		diffSvc:      diffSvc,
+		backupSvc:    backupSvc,
 	}, nil
 }
 
+// traced runs fn inside a span for a top-level operation, so the pipeline
+// and executor spans started by fn are nested beneath it.
+func (c *Client) traced(ctx context.Context, name string, packageCount int, fn func(context.Context) error) error {
+	ctx, span := c.config.Tracer.Start(ctx, name)
+	defer span.End()
+	span.SetAttributes(Attribute{Key: "package_count", Value: packageCount})
+
+	err := fn(ctx)
+	if err != nil {
+		span.RecordError(err)
+	}
+	return err
+}
+
 // Config returns the client's configuration.
 func (c *Client) Config() Config {
 	return c.config
@@ -154,7 +213,17 @@ func (c *Client) Config() Config {
 
 // Manage installs the specified packages by creating symlinks.
 func (c *Client) Manage(ctx context.Context, packages ...string) error {
-	return c.manageSvc.Manage(ctx, packages...)
+	return c.traced(ctx, "manage", len(packages), func(ctx context.Context) error {
+		return c.manageSvc.Manage(ctx, packages...)
+	})
+}
+
+// ManageWithOptions installs packages, resolving conflicts at the paths in
+// opts.Resolutions with the chosen policy.
+func (c *Client) ManageWithOptions(ctx context.Context, opts ManageOptions, packages ...string) error {
+	return c.traced(ctx, "manage", len(packages), func(ctx context.Context) error {
+		return c.manageSvc.ManageWithOptions(ctx, opts, packages...)
+	})
 }
 
 // PlanManage computes the execution plan for managing packages without applying changes.
@@ -162,17 +231,63 @@ func (c *Client) PlanManage(ctx context.Context, packages ...string) (Plan, erro
 	return c.manageSvc.PlanManage(ctx, packages...)
 }
 
+// PlanManageWithOptions computes the manage plan with per-path conflict
+// resolutions without applying changes.
+func (c *Client) PlanManageWithOptions(ctx context.Context, opts ManageOptions, packages ...string) (Plan, error) {
+	return c.manageSvc.PlanManageWithOptions(ctx, opts, packages...)
+}
+
+// DryRunPlan reports what each operation in plan would do against the
+// current filesystem, in execution order, without modifying it.
+func (c *Client) DryRunPlan(ctx context.Context, plan Plan) ([]DryRunEffect, error) {
+	return domain.DryRunPlan(ctx, c.config.FS, plan.Operations)
+}
+
+// PermanentDeletions lists every file and directory applying plan would
+// delete without a backup, including everything beneath directories it
+// removes recursively, sorted by path.
+func (c *Client) PermanentDeletions(ctx context.Context, plan Plan) ([]string, error) {
+	return domain.PermanentDeletions(ctx, c.config.FS, plan.Operations)
+}
+
 // === Methods from unmanage.go ===
 
 // Unmanage removes the specified packages by deleting symlinks.
 // Adopted packages are automatically restored unless disabled.
 func (c *Client) Unmanage(ctx context.Context, packages ...string) error {
-	return c.unmanageSvc.Unmanage(ctx, packages...)
+	return c.traced(ctx, "unmanage", len(packages), func(ctx context.Context) error {
+		return c.unmanageSvc.Unmanage(ctx, packages...)
+	})
 }
 
 // UnmanageWithOptions removes packages with specified options.
 func (c *Client) UnmanageWithOptions(ctx context.Context, opts UnmanageOptions, packages ...string) error {
-	return c.unmanageSvc.UnmanageWithOptions(ctx, opts, packages...)
+	return c.traced(ctx, "unmanage", len(packages), func(ctx context.Context) error {
+		return c.unmanageSvc.UnmanageWithOptions(ctx, opts, packages...)
+	})
+}
+
+// Unadopt returns paths managed by pkg to the target directory as regular
+// files or directories and removes them from the package and manifest.
+// Paths that are not links managed by pkg are rejected with ErrNotManaged.
+func (c *Client) Unadopt(ctx context.Context, pkg string, paths ...string) error {
+	return c.traced(ctx, "unadopt", 1, func(ctx context.Context) error {
+		return c.unmanageSvc.Unadopt(ctx, pkg, paths...)
+	})
+}
+
+// CleanEmptyDirs removes directories dot created in the target directory
+// that are now empty, deepest first. Directories dot did not create or
+// that still hold files are kept. In dry-run mode the result lists the
+// directories that would be removed.
+func (c *Client) CleanEmptyDirs(ctx context.Context) (CleanResult, error) {
+	var result CleanResult
+	err := c.traced(ctx, "clean", 0, func(ctx context.Context) error {
+		var err error
+		result, err = c.unmanageSvc.CleanEmptyDirs(ctx)
+		return err
+	})
+	return result, err
 }
 
 // UnmanageAll removes all installed packages with specified options.
@@ -186,11 +301,19 @@ func (c *Client) PlanUnmanage(ctx context.Context, packages ...string) (Plan, er
 	return c.unmanageSvc.PlanUnmanage(ctx, packages...)
 }
 
+// PlanUnmanageWithOptions computes the execution plan for unmanaging
+// packages with the given options.
+func (c *Client) PlanUnmanageWithOptions(ctx context.Context, opts UnmanageOptions, packages ...string) (Plan, error) {
+	return c.unmanageSvc.PlanUnmanageWithOptions(ctx, opts, packages...)
+}
+
 // === Methods from remanage.go ===
 
 // Remanage reinstalls packages using incremental hash-based change detection.
 func (c *Client) Remanage(ctx context.Context, packages ...string) error {
-	return c.manageSvc.Remanage(ctx, packages...)
+	return c.traced(ctx, "remanage", len(packages), func(ctx context.Context) error {
+		return c.manageSvc.Remanage(ctx, packages...)
+	})
 }
 
 // PlanRemanage computes incremental execution plan using hash-based change detection.
@@ -198,18 +321,46 @@ func (c *Client) PlanRemanage(ctx context.Context, packages ...string) (Plan, er
 	return c.manageSvc.PlanRemanage(ctx, packages...)
 }
 
+// MovePackage renames package oldName to newName and relinks its links
+// into the renamed directory, at new target paths if the name maps to a
+// different directory. The change is one transaction. It fails with
+// ErrPackageExists if newName is already taken.
+func (c *Client) MovePackage(ctx context.Context, oldName, newName string) error {
+	return c.traced(ctx, "move", 1, func(ctx context.Context) error {
+		return c.manageSvc.MovePackage(ctx, oldName, newName)
+	})
+}
+
 // === Methods from adopt.go ===
 
 // Adopt moves existing files from target into package then creates symlinks.
 func (c *Client) Adopt(ctx context.Context, files []string, pkg string) error {
-	return c.adoptSvc.Adopt(ctx, files, pkg)
+	return c.traced(ctx, "adopt", 1, func(ctx context.Context) error {
+		return c.adoptSvc.Adopt(ctx, files, pkg)
+	})
+}
+
+// AdoptWithOptions adopts files with specified options.
+func (c *Client) AdoptWithOptions(ctx context.Context, opts AdoptOptions, files []string, pkg string) error {
+	return c.traced(ctx, "adopt", 1, func(ctx context.Context) error {
+		return c.adoptSvc.AdoptWithOptions(ctx, opts, files, pkg)
+	})
 }
 
-// PlanAdopt computes the execution plan for adopting files.
+// PlanAdopt computes the execution plan for adopting files without
+// changing the filesystem. The plan lists the directories to create, the
+// moves into the package under their translated names (.vimrc becomes
+// dot-vimrc), and the links back to the original locations.
 func (c *Client) PlanAdopt(ctx context.Context, files []string, pkg string) (Plan, error) {
 	return c.adoptSvc.PlanAdopt(ctx, files, pkg)
 }
 
+// PlanAdoptWithOptions computes the execution plan for adopting files with
+// specified options.
+func (c *Client) PlanAdoptWithOptions(ctx context.Context, opts AdoptOptions, files []string, pkg string) (Plan, error) {
+	return c.adoptSvc.PlanAdoptWithOptions(ctx, opts, files, pkg)
+}
+
 // === Methods from status.go ===
 
 // Status reports the current installation state for packages.
@@ -217,11 +368,112 @@ func (c *Client) Status(ctx context.Context, packages ...string) (Status, error)
 	return c.statusSvc.Status(ctx, packages...)
 }
 
+// StatusWithOptions reports the installation state for packages, filtered
+// by name and ordered as opts requests.
+func (c *Client) StatusWithOptions(ctx context.Context, opts StatusOptions, packages ...string) (Status, error) {
+	return c.statusSvc.StatusWithOptions(ctx, opts, packages...)
+}
+
+// StatusDetailed reports every link of packages, or of all installed
+// packages when none are given, with its source, link mode and health.
+func (c *Client) StatusDetailed(ctx context.Context, packages ...string) (DetailedStatus, error) {
+	return c.statusSvc.StatusDetailed(ctx, packages...)
+}
+
+// Diff compares what a package declares against its live links in the
+// target directory, reporting missing, mistargeted, and orphaned links and
+// files that are not symlinks. It never modifies the filesystem.
+func (c *Client) Diff(ctx context.Context, pkg string) (PackageDiff, error) {
+	return c.diffSvc.Diff(ctx, pkg)
+}
+
 // List returns all installed packages from the manifest.
 func (c *Client) List(ctx context.Context) ([]PackageInfo, error) {
 	return c.statusSvc.List(ctx)
 }
 
+// ListStream returns installed packages one at a time as each is resolved,
+// so large installations can be rendered incrementally. It enumerates
+// exactly what List returns, in name order. The channel closes when
+// enumeration completes or ctx is cancelled.
+func (c *Client) ListStream(ctx context.Context) (<-chan PackageInfo, error) {
+	return c.statusSvc.ListStream(ctx)
+}
+
+// Resolutions returns the audit log of conflicts resolved automatically by
+// policy (backup, overwrite, skip), optionally filtered to packages.
+func (c *Client) Resolutions(ctx context.Context, packages ...string) ([]ResolutionRecord, error) {
+	return c.statusSvc.Resolutions(ctx, packages...)
+}
+
+// VerifyManifest recomputes the manifest checksum and reports whether it
+// matches the stored one. A mismatch means the manifest was edited by hand
+// or corrupted. Returns true when no manifest exists or it predates
+// checksums.
+func (c *Client) VerifyManifest(ctx context.Context) (bool, error) {
+	targetPathResult := NewTargetPath(c.config.TargetDir)
+	if !targetPathResult.IsOk() {
+		return false, targetPathResult.UnwrapErr()
+	}
+	return c.manifestSvc.Verify(ctx, targetPathResult.Unwrap())
+}
+
+// ExportManifest writes the full manifest (packages, links, hashes,
+// repository information, and doctor ignore state) to w as versioned JSON,
+// for backup or transfer to another machine.
+func (c *Client) ExportManifest(ctx context.Context, w io.Writer) error {
+	targetPathResult := NewTargetPath(c.config.TargetDir)
+	if !targetPathResult.IsOk() {
+		return targetPathResult.UnwrapErr()
+	}
+	return c.manifestSvc.Export(ctx, targetPathResult.Unwrap(), w)
+}
+
+// ImportManifest restores a manifest written by ExportManifest, replacing
+// the current manifest. Packages missing from PackageDir are logged as
+// warnings rather than rejected. Symlinks are not created; run Remanage
+// afterwards to bring the target directory in line with the manifest.
+func (c *Client) ImportManifest(ctx context.Context, r io.Reader) error {
+	targetPathResult := NewTargetPath(c.config.TargetDir)
+	if !targetPathResult.IsOk() {
+		return targetPathResult.UnwrapErr()
+	}
+	return c.manifestSvc.Import(ctx, targetPathResult.Unwrap(), c.config.PackageDir, r, c.config.DryRun)
+}
+
+// ManifestRestore reports the outcome of RestoreManifest.
+type ManifestRestore struct {
+	// Managed lists the imported packages found in PackageDir and managed.
+	Managed []string
+	// Missing lists the imported packages absent from PackageDir. They stay
+	// in the manifest but no links are created for them.
+	Missing []string
+}
+
+// RestoreManifest imports a manifest written by ExportManifest and manages
+// every imported package present in PackageDir, recreating its links.
+// Packages missing from PackageDir are reported rather than failing the
+// restore. In dry-run mode nothing is saved and the links are only planned.
+func (c *Client) RestoreManifest(ctx context.Context, r io.Reader) (ManifestRestore, error) {
+	targetPathResult := NewTargetPath(c.config.TargetDir)
+	if !targetPathResult.IsOk() {
+		return ManifestRestore{}, targetPathResult.UnwrapErr()
+	}
+	present, missing, err := c.manifestSvc.importManifest(ctx, targetPathResult.Unwrap(), c.config.PackageDir, r, c.config.DryRun)
+	if err != nil {
+		return ManifestRestore{}, err
+	}
+
+	restore := ManifestRestore{Managed: present, Missing: missing}
+	if len(present) == 0 {
+		return restore, nil
+	}
+	if err := c.Manage(ctx, present...); err != nil && !errors.As(err, new(ErrNoChanges)) {
+		return restore, fmt.Errorf("manage imported packages: %w", err)
+	}
+	return restore, nil
+}
+
 // === Methods from doctor.go ===
 
 // Doctor performs health checks with default scan configuration.
@@ -239,11 +491,32 @@ func (c *Client) DoctorWithMode(ctx context.Context, mode DiagnosticMode, scanCf
 	return c.doctorSvc.DoctorWithMode(ctx, mode, scanCfg)
 }
 
+// DoctorStructuredReport converts a diagnostic report into its
+// machine-readable form for JSON output.
+func (c *Client) DoctorStructuredReport(ctx context.Context, report DiagnosticReport) StructuredReport {
+	return c.doctorSvc.StructuredReport(ctx, report)
+}
+
+// DoctorListOrphans scans for orphaned links and returns each with its
+// target and detected category.
+func (c *Client) DoctorListOrphans(ctx context.Context, scanCfg ScanConfig) ([]OrphanInfo, error) {
+	return c.doctorSvc.ListOrphans(ctx, scanCfg)
+}
+
 // Triage performs interactive triage of orphaned symlinks.
 func (c *Client) Triage(ctx context.Context, scanCfg ScanConfig, opts TriageOptions) (TriageResult, error) {
 	return c.doctorSvc.Triage(ctx, scanCfg, opts)
 }
 
+// DoctorFix repairs issues found by doctor: broken links are recreated or
+// removed, and link ownership conflicts are resolved by choosing an owner.
+func (c *Client) DoctorFix(ctx context.Context, scanCfg ScanConfig, opts FixOptions) (FixResult, error) {
+	if c.config.DryRun {
+		opts.DryRun = true
+	}
+	return c.doctorSvc.Fix(ctx, scanCfg, opts)
+}
+
 // DoctorIgnoreLink adds a target-relative symlink path to the doctor ignore list.
 func (c *Client) DoctorIgnoreLink(ctx context.Context, linkPath, reason string) error {
 	return c.doctorSvc.IgnoreLink(ctx, linkPath, reason)
@@ -270,6 +543,13 @@ func (c *Client) DoctorListIgnored(ctx context.Context) (map[string]IgnoredLink,
 	return c.doctorSvc.ListIgnored(ctx)
 }
 
+// PruneBackups removes conflict backups in the backup directory that are
+// older than opts.MaxAge or beyond the opts.KeepCount newest of each file.
+// In dry-run mode it reports what would be removed without removing it.
+func (c *Client) PruneBackups(ctx context.Context, opts PruneOptions) (PruneResult, error) {
+	return c.backupSvc.PruneBackups(ctx, opts)
+}
+
 // Clone clones a dotfiles repository and installs packages.
 //
 // Workflow:
@@ -291,6 +571,12 @@ func (c *Client) Clone(ctx context.Context, repoURL string, opts CloneOptions) e
 	return c.cloneSvc.Clone(ctx, repoURL, opts)
 }
 
+// Unshallow fetches the full history of a repository cloned shallowly
+// into the package directory. It does nothing if the history is complete.
+func (c *Client) Unshallow(ctx context.Context, opts UnshallowOptions) error {
+	return c.cloneSvc.Unshallow(ctx, opts)
+}
+
 // GenerateBootstrap creates a bootstrap configuration from current installation.
 //
 // Workflow:
@@ -323,3 +609,15 @@ func (c *Client) WriteBootstrap(ctx context.Context, data []byte, outputPath str
 func isManifestNotFoundError(err error) bool {
 	return errors.Is(err, os.ErrNotExist)
 }
+
+// plannerLinkMode maps a configured link mode to the planner's.
+func plannerLinkMode(mode LinkMode) planner.LinkMode {
+	switch mode {
+	case LinkAbsolute:
+		return planner.LinkModeAbsolute
+	case LinkAuto:
+		return planner.LinkModeAuto
+	default:
+		return planner.LinkModeRelative
+	}
+}
@@ -0,0 +1,137 @@
+package dot_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yaklabco/dot/internal/adapters"
+	"github.com/yaklabco/dot/pkg/dot"
+)
+
+// newDiffClient creates a client over a memory filesystem with a vim
+// package holding the given files.
+func newDiffClient(t *testing.T, folding bool, files ...string) (*dot.Client, *adapters.MemFS) {
+	t.Helper()
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	require.NoError(t, fs.MkdirAll(ctx, "/test/packages/vim", 0755))
+	require.NoError(t, fs.MkdirAll(ctx, "/test/target", 0755))
+	for _, file := range files {
+		path := "/test/packages/vim/" + file
+		require.NoError(t, fs.MkdirAll(ctx, filepath.Dir(path), 0755))
+		require.NoError(t, fs.WriteFile(ctx, path, []byte(file), 0644))
+	}
+
+	client, err := dot.NewClient(dot.Config{
+		PackageDir: "/test/packages",
+		TargetDir:  "/test/target",
+		Folding:    folding,
+		FS:         fs,
+		Logger:     adapters.NewNoopLogger(),
+	})
+	require.NoError(t, err)
+	return client, fs
+}
+
+func TestClient_Diff_InSync(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newDiffClient(t, false, "dot-vimrc", "dot-gvimrc")
+	require.NoError(t, client.Manage(ctx, "vim"))
+
+	diff, err := client.Diff(ctx, "vim")
+	require.NoError(t, err)
+	assert.Equal(t, "vim", diff.Package)
+	assert.Equal(t, 2, diff.InSync)
+	assert.False(t, diff.HasDrift())
+	assert.Empty(t, diff.Drift)
+}
+
+func TestClient_Diff_ReportsDrift(t *testing.T) {
+	ctx := context.Background()
+	client, fs := newDiffClient(t, false, "dot-vimrc", "dot-gvimrc", "dot-exrc", "dot-viminfo", "dot-ideavimrc")
+	require.NoError(t, client.Manage(ctx, "vim"))
+
+	// Missing: the link was deleted
+	require.NoError(t, fs.Remove(ctx, "/test/target/.vimrc"))
+	// Wrong target: the link was repointed
+	require.NoError(t, fs.Remove(ctx, "/test/target/.gvimrc"))
+	require.NoError(t, fs.Symlink(ctx, "/elsewhere/gvimrc", "/test/target/.gvimrc"))
+	// Not a symlink: the link was replaced by a copy
+	require.NoError(t, fs.Remove(ctx, "/test/target/.exrc"))
+	require.NoError(t, fs.WriteFile(ctx, "/test/target/.exrc", []byte("copy"), 0644))
+	// Orphaned: the package no longer has the file
+	require.NoError(t, fs.Remove(ctx, "/test/packages/vim/dot-viminfo"))
+
+	diff, err := client.Diff(ctx, "vim")
+	require.NoError(t, err)
+	assert.True(t, diff.HasDrift())
+	assert.Equal(t, 1, diff.InSync)
+	assert.Equal(t, []dot.Drift{
+		{Kind: dot.DriftNotSymlink, Path: ".exrc", Expected: "/test/packages/vim/dot-exrc"},
+		{Kind: dot.DriftWrongTarget, Path: ".gvimrc", Expected: "/test/packages/vim/dot-gvimrc", Actual: "/elsewhere/gvimrc"},
+		{Kind: dot.DriftOrphaned, Path: ".viminfo", Actual: "/test/packages/vim/dot-viminfo"},
+		{Kind: dot.DriftMissing, Path: ".vimrc", Expected: "/test/packages/vim/dot-vimrc"},
+	}, diff.Drift)
+}
+
+func TestClient_Diff_UnmanagedPackage(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newDiffClient(t, false, "dot-vimrc")
+
+	diff, err := client.Diff(ctx, "vim")
+	require.NoError(t, err)
+	assert.Equal(t, []dot.Drift{
+		{Kind: dot.DriftMissing, Path: ".vimrc", Expected: "/test/packages/vim/dot-vimrc"},
+	}, diff.Drift)
+}
+
+func TestClient_Diff_RemovedPackage(t *testing.T) {
+	ctx := context.Background()
+	client, fs := newDiffClient(t, false, "dot-vimrc")
+	require.NoError(t, client.Manage(ctx, "vim"))
+	require.NoError(t, fs.RemoveAll(ctx, "/test/packages/vim"))
+
+	diff, err := client.Diff(ctx, "vim")
+	require.NoError(t, err)
+	assert.Equal(t, []dot.Drift{
+		{Kind: dot.DriftOrphaned, Path: ".vimrc", Actual: "/test/packages/vim/dot-vimrc"},
+	}, diff.Drift)
+}
+
+func TestClient_Diff_UnknownPackage(t *testing.T) {
+	client, _ := newDiffClient(t, false)
+
+	_, err := client.Diff(context.Background(), "emacs")
+	var notFound dot.ErrPackageNotFound
+	require.ErrorAs(t, err, &notFound)
+	assert.Equal(t, "emacs", notFound.Package)
+}
+
+func TestClient_Diff_FoldedDirectory(t *testing.T) {
+	ctx := context.Background()
+	client, fs := newDiffClient(t, true, "dot-vim/colors/dark.vim", "dot-vim/plugin/sensible.vim")
+	require.NoError(t, client.Manage(ctx, "vim"))
+	isLink, err := fs.IsSymlink(ctx, "/test/target/.vim")
+	require.NoError(t, err)
+	require.True(t, isLink, "directory should be folded")
+
+	diff, err := client.Diff(ctx, "vim")
+	require.NoError(t, err)
+	assert.False(t, diff.HasDrift(), "drift: %v", diff.Drift)
+	assert.Equal(t, 1, diff.InSync)
+}
+
+func TestClient_Diff_FoldedDirectoryWithFoldingDisabled(t *testing.T) {
+	ctx := context.Background()
+	client, fs := newDiffClient(t, false, "dot-vim/colors/dark.vim")
+	require.NoError(t, fs.Symlink(ctx, "/test/packages/vim/dot-vim", "/test/target/.vim"))
+
+	diff, err := client.Diff(ctx, "vim")
+	require.NoError(t, err)
+	assert.False(t, diff.HasDrift(), "drift: %v", diff.Drift)
+	assert.Equal(t, 1, diff.InSync)
+}
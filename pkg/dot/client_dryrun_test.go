@@ -0,0 +1,175 @@
+package dot_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaklabco/dot/internal/adapters"
+	"github.com/yaklabco/dot/pkg/dot"
+)
+
+// writeRecordingFS records every mutating call that reaches the wrapped FS.
+type writeRecordingFS struct {
+	*adapters.MemFS
+
+	mu     sync.Mutex
+	writes []string
+}
+
+func (w *writeRecordingFS) record(op, path string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.writes = append(w.writes, op+" "+path)
+}
+
+func (w *writeRecordingFS) recorded() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append([]string(nil), w.writes...)
+}
+
+func (w *writeRecordingFS) WriteFile(ctx context.Context, name string, data []byte, perm os.FileMode) error {
+	w.record("WriteFile", name)
+	return w.MemFS.WriteFile(ctx, name, data, perm)
+}
+
+func (w *writeRecordingFS) Mkdir(ctx context.Context, name string, perm os.FileMode) error {
+	w.record("Mkdir", name)
+	return w.MemFS.Mkdir(ctx, name, perm)
+}
+
+func (w *writeRecordingFS) MkdirAll(ctx context.Context, name string, perm os.FileMode) error {
+	w.record("MkdirAll", name)
+	return w.MemFS.MkdirAll(ctx, name, perm)
+}
+
+func (w *writeRecordingFS) Remove(ctx context.Context, name string) error {
+	w.record("Remove", name)
+	return w.MemFS.Remove(ctx, name)
+}
+
+func (w *writeRecordingFS) RemoveAll(ctx context.Context, name string) error {
+	w.record("RemoveAll", name)
+	return w.MemFS.RemoveAll(ctx, name)
+}
+
+func (w *writeRecordingFS) Symlink(ctx context.Context, oldname, newname string) error {
+	w.record("Symlink", newname)
+	return w.MemFS.Symlink(ctx, oldname, newname)
+}
+
+func (w *writeRecordingFS) Link(ctx context.Context, oldname, newname string) error {
+	w.record("Link", newname)
+	return w.MemFS.Link(ctx, oldname, newname)
+}
+
+func (w *writeRecordingFS) Rename(ctx context.Context, oldpath, newpath string) error {
+	w.record("Rename", oldpath)
+	return w.MemFS.Rename(ctx, oldpath, newpath)
+}
+
+// newRecordingClient sets up packages on a MemFS and returns a client whose
+// writes are recorded from this point on.
+func newRecordingClient(t *testing.T, dryRun bool) (*dot.Client, *writeRecordingFS) {
+	t.Helper()
+	mem := adapters.NewMemFS()
+	setupTestFixtures(t, mem, "vim", "zsh")
+
+	fs := &writeRecordingFS{MemFS: mem}
+	client, err := dot.NewClient(dot.Config{
+		PackageDir: "/test/packages",
+		TargetDir:  "/test/target",
+		FS:         fs,
+		Logger:     adapters.NewNoopLogger(),
+		DryRun:     dryRun,
+	})
+	require.NoError(t, err)
+	return client, fs
+}
+
+func TestClient_DryRun_ManageProducesPlanWithoutWrites(t *testing.T) {
+	ctx := context.Background()
+	client, fs := newRecordingClient(t, true)
+
+	plan, err := client.PlanManage(ctx, "vim", "zsh")
+	require.NoError(t, err)
+	assert.NotEmpty(t, plan.Operations)
+
+	require.NoError(t, client.Manage(ctx, "vim", "zsh"))
+
+	_, err = client.Status(ctx)
+	require.NoError(t, err)
+	_, err = client.List(ctx)
+	require.NoError(t, err)
+
+	assert.Empty(t, fs.recorded(), "dry run must not write")
+	assert.False(t, fs.Exists(ctx, "/test/target/.config"))
+}
+
+func TestClient_DryRun_BlocksWritesThatSkipDryRunChecks(t *testing.T) {
+	ctx := context.Background()
+	client, fs := newRecordingClient(t, true)
+
+	// Adding an ignore pattern saves the manifest and has no dry-run
+	// handling of its own; the read-only filesystem stops the write.
+	err := client.DoctorIgnorePattern(ctx, "*.bak")
+	var roErr dot.ErrReadOnlyFS
+	require.True(t, errors.As(err, &roErr), "expected ErrReadOnlyFS, got %v", err)
+	assert.Empty(t, fs.recorded())
+}
+
+func TestClient_StatusAndPlanDoNotWrite(t *testing.T) {
+	ctx := context.Background()
+	client, fs := newRecordingClient(t, false)
+
+	_, err := client.PlanManage(ctx, "vim")
+	require.NoError(t, err)
+	_, err = client.Status(ctx)
+	require.NoError(t, err)
+	_, err = client.List(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, fs.recorded())
+
+	// Writes are still allowed outside dry-run mode
+	require.NoError(t, client.Manage(ctx, "vim"))
+	assert.NotEmpty(t, fs.recorded())
+}
+
+func TestClient_DryRunPlan(t *testing.T) {
+	ctx := context.Background()
+	client, fs := newRecordingClient(t, true)
+
+	plan, err := client.PlanManage(ctx, "vim", "zsh")
+	require.NoError(t, err)
+
+	effects, err := client.DryRunPlan(ctx, plan)
+	require.NoError(t, err)
+	require.Len(t, effects, len(plan.Operations))
+	for i, effect := range effects {
+		assert.Equal(t, plan.Operations[i].ID(), effect.OpID)
+		assert.Equal(t, dot.EffectApply, effect.Status, effect.String())
+	}
+	assert.Empty(t, fs.recorded(), "dry run must not write")
+}
+
+func TestClient_DryRunPlan_ReportsExistingTargets(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newRecordingClient(t, false)
+
+	plan, err := client.PlanManage(ctx, "vim")
+	require.NoError(t, err)
+	require.NoError(t, client.Manage(ctx, "vim"))
+
+	// Evaluated after managing, the same plan has nothing left to do
+	effects, err := client.DryRunPlan(ctx, plan)
+	require.NoError(t, err)
+	require.NotEmpty(t, effects)
+	for _, effect := range effects {
+		assert.Equal(t, dot.EffectNoop, effect.Status, effect.String())
+	}
+}
@@ -2,6 +2,7 @@ package dot_test
 
 import (
 	"context"
+	"fmt"
 	"path/filepath"
 	"testing"
 
@@ -232,3 +233,42 @@ func TestClient_ManageWithExecutionSuccess(t *testing.T) {
 	assert.True(t, status.Packages[0].LinkCount > 0)
 	assert.NotEmpty(t, status.Packages[0].Links)
 }
+
+func TestClient_PlanManage_ParallelScanMatchesSerial(t *testing.T) {
+	ctx := context.Background()
+
+	planWith := func(concurrency int) dot.Plan {
+		fs := adapters.NewMemFS()
+		for i := 0; i < 8; i++ {
+			pkgDir := fmt.Sprintf("/test/packages/pkg%d", i)
+			for j := 0; j < 4; j++ {
+				dir := fmt.Sprintf("%s/dot-config/tool%d-%d", pkgDir, i, j)
+				require.NoError(t, fs.MkdirAll(ctx, dir, 0755))
+				require.NoError(t, fs.WriteFile(ctx, dir+"/config", []byte("x"), 0644))
+			}
+			require.NoError(t, fs.WriteFile(ctx, fmt.Sprintf("%s/dot-rc%d", pkgDir, i), []byte("x"), 0644))
+		}
+		require.NoError(t, fs.MkdirAll(ctx, "/test/target", 0755))
+
+		client, err := dot.NewClient(dot.Config{
+			PackageDir:  "/test/packages",
+			TargetDir:   "/test/target",
+			FS:          fs,
+			Logger:      adapters.NewNoopLogger(),
+			Concurrency: concurrency,
+		})
+		require.NoError(t, err)
+
+		plan, err := client.PlanManage(ctx, "pkg0", "pkg1", "pkg2", "pkg3", "pkg4", "pkg5", "pkg6", "pkg7")
+		require.NoError(t, err)
+		return plan
+	}
+
+	serial := planWith(1)
+	require.NotEmpty(t, serial.Operations)
+	for _, concurrency := range []int{1, 2, 8} {
+		parallel := planWith(concurrency)
+		assert.Equal(t, fmt.Sprint(serial.Operations), fmt.Sprint(parallel.Operations), "concurrency %d", concurrency)
+		assert.Equal(t, serial.Metadata, parallel.Metadata, "concurrency %d", concurrency)
+	}
+}
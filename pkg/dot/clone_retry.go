@@ -0,0 +1,124 @@
+package dot
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+
+	"github.com/yaklabco/dot/internal/adapters"
+	"github.com/yaklabco/dot/internal/config"
+)
+
+// defaultCloneRetryBackoff is the wait before the first retry when the
+// user's config sets no connection timeout.
+const defaultCloneRetryBackoff = 5 * time.Second
+
+// cloneRetryPolicy controls how a clone that failed with a transient
+// network error is retried.
+type cloneRetryPolicy struct {
+	// retries is the number of attempts after the first one.
+	retries int
+	// backoff is the wait before the first retry; it doubles with each
+	// further attempt.
+	backoff time.Duration
+}
+
+// newCloneRetryPolicy builds the retry policy from the network settings,
+// waiting the connection timeout before the first retry.
+func newCloneRetryPolicy(network *config.NetworkConfig) cloneRetryPolicy {
+	backoff := time.Duration(network.ConnectTimeout) * time.Second
+	if backoff <= 0 {
+		backoff = defaultCloneRetryBackoff
+	}
+	return cloneRetryPolicy{retries: network.CloneRetries, backoff: backoff}
+}
+
+// cloneWithRetry runs the clone, retrying transient network failures
+// with exponential backoff. Authentication and other permanent errors
+// are returned at once, as is the last error when ctx is cancelled
+// while waiting.
+func (s *CloneService) cloneWithRetry(ctx context.Context, repoURL string, opts adapters.CloneOptions) error {
+	policy := s.cloneRetry()
+	for attempt := 1; ; attempt++ {
+		err := s.cloner.Clone(ctx, repoURL, s.packageDir, opts)
+		if err == nil || attempt > policy.retries || ctx.Err() != nil || !isTransientCloneError(err) {
+			return err
+		}
+
+		wait := policy.backoff << (attempt - 1)
+		s.logger.Warn(ctx, "retrying_git_clone",
+			"attempt", attempt+1,
+			"max_attempts", policy.retries+1,
+			"wait", wait,
+			"error", err)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return errors.Join(err, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// cloneRetry returns the retry policy, read from the network settings in
+// the user's config unless one was set.
+func (s *CloneService) cloneRetry() cloneRetryPolicy {
+	if s.retry != nil {
+		return *s.retry
+	}
+	return newCloneRetryPolicy(userNetworkConfig())
+}
+
+// isTransientCloneError reports whether a failed clone may succeed when
+// tried again: timeouts, refused or reset connections, truncated
+// transfers and server-side HTTP errors. Authentication, authorization,
+// host key and missing repository errors are permanent.
+func isTransientCloneError(err error) bool {
+	if errors.Is(err, transport.ErrAuthenticationRequired) ||
+		errors.Is(err, transport.ErrAuthorizationFailed) ||
+		errors.Is(err, transport.ErrInvalidAuthMethod) ||
+		errors.Is(err, transport.ErrRepositoryNotFound) ||
+		errors.Is(err, context.Canceled) {
+		return false
+	}
+	var hostKeyErr adapters.ErrHostKeyVerification
+	if errors.As(err, &hostKeyErr) {
+		return false
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ETIMEDOUT) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	// go-git wraps HTTP status errors in an UnexpectedError, which does
+	// not unwrap
+	var unexpected *plumbing.UnexpectedError
+	if errors.As(err, &unexpected) {
+		err = unexpected.Err
+	}
+	var httpErr *githttp.Err
+	if errors.As(err, &httpErr) && httpErr.Response != nil {
+		code := httpErr.Response.StatusCode
+		return code == http.StatusTooManyRequests || code >= http.StatusInternalServerError
+	}
+	return false
+}
@@ -0,0 +1,136 @@
+package dot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yaklabco/dot/internal/adapters"
+	"github.com/yaklabco/dot/internal/config"
+)
+
+// newRetryCloneService returns a clone service whose cloner fails with the
+// errors in failures, in order, and then succeeds. The returned counter
+// reports the number of clone attempts.
+func newRetryCloneService(policy cloneRetryPolicy, failures ...error) (*CloneService, *int) {
+	attempts := 0
+	cloner := &mockGitCloner{
+		cloneFn: func(ctx context.Context, url string, dest string, opts adapters.CloneOptions) error {
+			attempts++
+			if attempts <= len(failures) {
+				return failures[attempts-1]
+			}
+			return nil
+		},
+	}
+	svc := newCloneService(adapters.NewMemFS(), adapters.NewNoopLogger(), nil, cloner, nil, "/packages", "/home", false)
+	svc.retry = &policy
+	return svc, &attempts
+}
+
+func TestCloneService_CloneWithRetry(t *testing.T) {
+	ctx := context.Background()
+	timeout := &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ETIMEDOUT}
+
+	t.Run("succeeds after transient failures", func(t *testing.T) {
+		svc, attempts := newRetryCloneService(cloneRetryPolicy{retries: 3, backoff: time.Millisecond},
+			timeout, fmt.Errorf("clone repository: %w", io.ErrUnexpectedEOF))
+
+		require.NoError(t, svc.cloneWithRetry(ctx, "https://example.com/repo.git", adapters.CloneOptions{}))
+		assert.Equal(t, 3, *attempts)
+	})
+
+	t.Run("gives up after the configured retries", func(t *testing.T) {
+		svc, attempts := newRetryCloneService(cloneRetryPolicy{retries: 2, backoff: time.Millisecond},
+			timeout, timeout, timeout, timeout)
+
+		err := svc.cloneWithRetry(ctx, "https://example.com/repo.git", adapters.CloneOptions{})
+		require.ErrorIs(t, err, syscall.ETIMEDOUT)
+		assert.Equal(t, 3, *attempts)
+	})
+
+	t.Run("does not retry authentication failures", func(t *testing.T) {
+		authErr := fmt.Errorf("clone repository: %w", transport.ErrAuthenticationRequired)
+		svc, attempts := newRetryCloneService(cloneRetryPolicy{retries: 3, backoff: time.Millisecond}, authErr)
+
+		err := svc.cloneWithRetry(ctx, "https://example.com/repo.git", adapters.CloneOptions{})
+		require.ErrorIs(t, err, transport.ErrAuthenticationRequired)
+		assert.Equal(t, 1, *attempts)
+	})
+
+	t.Run("stops waiting when the context is cancelled", func(t *testing.T) {
+		svc, attempts := newRetryCloneService(cloneRetryPolicy{retries: 3, backoff: time.Hour}, timeout)
+		cancelCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+
+		err := svc.cloneWithRetry(cancelCtx, "https://example.com/repo.git", adapters.CloneOptions{})
+		require.ErrorIs(t, err, syscall.ETIMEDOUT)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, 1, *attempts)
+	})
+}
+
+func TestCloneService_Clone_RetriesTransientFailure(t *testing.T) {
+	ctx := context.Background()
+	svc, attempts := newRetryCloneService(cloneRetryPolicy{retries: 1, backoff: time.Millisecond},
+		syscall.ECONNRESET, syscall.ECONNRESET)
+
+	err := svc.Clone(ctx, "https://example.com/repo.git", CloneOptions{})
+	var cloneErr ErrCloneFailed
+	require.ErrorAs(t, err, &cloneErr)
+	require.ErrorIs(t, cloneErr.Cause, syscall.ECONNRESET)
+	assert.Equal(t, 2, *attempts)
+}
+
+func TestIsTransientCloneError(t *testing.T) {
+	serverErr := func(code int) error {
+		return fmt.Errorf("clone repository: %w",
+			plumbing.NewUnexpectedError(&githttp.Err{Response: &http.Response{StatusCode: code}}))
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"dial timeout", &net.OpError{Op: "dial", Err: syscall.ETIMEDOUT}, true},
+		{"connection refused", fmt.Errorf("clone repository: %w", syscall.ECONNREFUSED), true},
+		{"connection reset", syscall.ECONNRESET, true},
+		{"truncated transfer", io.ErrUnexpectedEOF, true},
+		{"deadline exceeded", context.DeadlineExceeded, true},
+		{"server error", serverErr(http.StatusBadGateway), true},
+		{"rate limited", serverErr(http.StatusTooManyRequests), true},
+		{"client error", serverErr(http.StatusBadRequest), false},
+		{"authentication required", transport.ErrAuthenticationRequired, false},
+		{"authorization failed", fmt.Errorf("clone repository: %w", transport.ErrAuthorizationFailed), false},
+		{"repository not found", transport.ErrRepositoryNotFound, false},
+		{"host key", adapters.ErrHostKeyVerification{Host: "github.com", Cause: errors.New("unknown key")}, false},
+		{"cancelled", context.Canceled, false},
+		{"other", errors.New("target path exists and is not a directory"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isTransientCloneError(tt.err))
+		})
+	}
+}
+
+func TestNewCloneRetryPolicy(t *testing.T) {
+	policy := newCloneRetryPolicy(&config.NetworkConfig{CloneRetries: 4, ConnectTimeout: 2})
+	assert.Equal(t, cloneRetryPolicy{retries: 4, backoff: 2 * time.Second}, policy)
+
+	policy = newCloneRetryPolicy(&config.NetworkConfig{})
+	assert.Equal(t, cloneRetryPolicy{retries: 0, backoff: defaultCloneRetryBackoff}, policy)
+}
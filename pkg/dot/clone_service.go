@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -12,11 +13,13 @@ import (
 
 	"github.com/yaklabco/dot/internal/adapters"
 	"github.com/yaklabco/dot/internal/bootstrap"
+	"github.com/yaklabco/dot/internal/cli/prompt"
 	"github.com/yaklabco/dot/internal/cli/selector"
 	"github.com/yaklabco/dot/internal/cli/terminal"
 	"github.com/yaklabco/dot/internal/config"
 	"github.com/yaklabco/dot/internal/manifest"
 	"github.com/yaklabco/dot/internal/scanner"
+	"github.com/yaklabco/dot/internal/updater"
 )
 
 // CloneService handles repository cloning and package installation.
@@ -29,6 +32,19 @@ type CloneService struct {
 	packageDir string
 	targetDir  string
 	dryRun     bool
+
+	// prompter asks for SSH key passphrases unless CloneOptions
+	// provides its own.
+	prompter PassphrasePrompter
+
+	// includeFetcher retrieves URL includes of the bootstrap config. If
+	// nil, one is built from the network settings in the user's config.
+	includeFetcher bootstrap.Fetcher
+
+	// retry controls retries of a clone that failed with a transient
+	// network error. If nil, it is built from the network settings in the
+	// user's config.
+	retry *cloneRetryPolicy
 }
 
 // newCloneService creates a new clone service.
@@ -70,6 +86,64 @@ type CloneOptions struct {
 	// Branch specifies which branch to clone.
 	// If empty, clones default branch.
 	Branch string
+
+	// Depth limits how many commits are fetched.
+	// Zero clones the full history. Negative values are rejected.
+	// DefaultCloneOptions uses DefaultCloneDepth.
+	Depth int
+
+	// SingleBranch fetches only one branch: Branch if set, otherwise the
+	// remote's default branch.
+	SingleBranch bool
+
+	// SSHKeyPath selects the private key used for SSH URLs. If empty, the
+	// SSH agent is used when running, then keys found in ~/.ssh.
+	SSHKeyPath string
+
+	// KnownHostsPath verifies the server's SSH host key against this file
+	// instead of the default known_hosts files.
+	KnownHostsPath string
+
+	// PassphrasePrompter asks for the passphrase of an encrypted SSH key.
+	// If nil, the client prompts on its configured input and output.
+	PassphrasePrompter PassphrasePrompter
+
+	// ManifestOnly records repoURL in the manifest for a repository that
+	// was already cloned into packageDir by other means. Nothing is cloned
+	// or installed; Branch overrides the branch read from HEAD.
+	ManifestOnly bool
+}
+
+// PassphrasePrompter asks the user for the passphrase of an encrypted SSH key.
+type PassphrasePrompter = adapters.PassphrasePrompter
+
+// UnshallowOptions configures fetching the full history of a shallow clone.
+type UnshallowOptions struct {
+	// SSHKeyPath selects the private key used for SSH remotes. If empty,
+	// authentication is resolved as for Clone.
+	SSHKeyPath string
+
+	// KnownHostsPath verifies the server's SSH host key against this file
+	// instead of the default known_hosts files.
+	KnownHostsPath string
+
+	// PassphrasePrompter asks for the passphrase of an encrypted SSH key.
+	// If nil, the client prompts on its configured input and output.
+	PassphrasePrompter PassphrasePrompter
+
+	// Progress receives fetch progress output. If nil, none is reported.
+	Progress io.Writer
+}
+
+// DefaultCloneDepth is the shallow clone depth used by default.
+const DefaultCloneDepth = 1
+
+// DefaultCloneOptions returns default clone options (shallow clone of the
+// default branch).
+func DefaultCloneOptions() CloneOptions {
+	return CloneOptions{
+		Depth: DefaultCloneDepth,
+	}
 }
 
 // Clone clones a repository and installs packages.
@@ -84,7 +158,17 @@ type CloneOptions struct {
 //  7. Install selected packages via ManageService
 //  8. Update manifest with repository information
 func (s *CloneService) Clone(ctx context.Context, repoURL string, opts CloneOptions) error {
-	s.logger.Info(ctx, "clone_operation_started", "url", repoURL, "package_dir", s.packageDir)
+	// Never log or print credentials embedded in the URL
+	safeURL := adapters.RedactURL(repoURL)
+	s.logger.Info(ctx, "clone_operation_started", "url", safeURL, "package_dir", s.packageDir)
+
+	if opts.Depth < 0 {
+		return fmt.Errorf("invalid clone depth %d: must be 0 (full history) or greater", opts.Depth)
+	}
+
+	if opts.ManifestOnly {
+		return s.recordExistingClone(ctx, repoURL, opts)
+	}
 
 	// Validate package directory
 	s.logger.Debug(ctx, "validating_package_directory", "path", s.packageDir, "force", opts.Force)
@@ -95,8 +179,11 @@ func (s *CloneService) Clone(ctx context.Context, repoURL string, opts CloneOpti
 	s.logger.Debug(ctx, "package_directory_validated")
 
 	// Resolve authentication
-	s.logger.Debug(ctx, "resolving_authentication", "url", repoURL)
-	auth, err := adapters.ResolveAuth(ctx, repoURL)
+	s.logger.Debug(ctx, "resolving_authentication", "url", safeURL)
+	auth, err := adapters.ResolveAuthWithOptions(ctx, repoURL, adapters.AuthOptions{
+		SSHKeyPath:     opts.SSHKeyPath,
+		KnownHostsPath: opts.KnownHostsPath,
+	})
 	if err != nil {
 		s.logger.Error(ctx, "authentication_resolution_failed", "error", err)
 		return ErrAuthFailed{Cause: err}
@@ -106,31 +193,37 @@ func (s *CloneService) Clone(ctx context.Context, repoURL string, opts CloneOpti
 	// In dry-run mode, report what would happen and return early.
 	// No filesystem changes should occur.
 	if s.dryRun {
-		s.logger.Info(ctx, "dry_run_clone", "url", repoURL, "destination", s.packageDir)
-		fmt.Fprintf(os.Stderr, "Would clone %s to %s\n", repoURL, s.packageDir)
+		s.logger.Info(ctx, "dry_run_clone", "url", safeURL, "destination", s.packageDir)
+		fmt.Fprintf(os.Stderr, "Would clone %s to %s\n", safeURL, s.packageDir)
 		return nil
 	}
 
-	s.logger.Info(ctx, "cloning_repository", "url", repoURL, "destination", s.packageDir)
+	s.logger.Info(ctx, "cloning_repository", "url", safeURL, "destination", s.packageDir)
 
 	// Clone repository
+	prompter := opts.PassphrasePrompter
+	if prompter == nil {
+		prompter = s.prompter
+	}
 	cloneOpts := adapters.CloneOptions{
-		Auth:   auth,
-		Branch: opts.Branch,
-		Depth:  1, // Shallow clone for faster cloning
+		Auth:               auth,
+		Branch:             opts.Branch,
+		Depth:              opts.Depth,
+		SingleBranch:       opts.SingleBranch,
+		PassphrasePrompter: prompter,
 	}
 
-	s.logger.Debug(ctx, "initiating_git_clone", "branch", opts.Branch, "depth", 1)
-	if err := s.cloner.Clone(ctx, repoURL, s.packageDir, cloneOpts); err != nil {
+	s.logger.Debug(ctx, "initiating_git_clone", "branch", opts.Branch, "depth", opts.Depth, "single_branch", opts.SingleBranch)
+	if err := s.cloneWithRetry(ctx, repoURL, cloneOpts); err != nil {
 		s.logger.Error(ctx, "git_clone_failed", "error", err)
-		return ErrCloneFailed{URL: repoURL, Cause: err}
+		return ErrCloneFailed{URL: safeURL, Cause: err}
 	}
 
 	s.logger.Info(ctx, "repository_cloned_successfully", "path", s.packageDir)
 
 	// Load bootstrap configuration if present
 	s.logger.Debug(ctx, "checking_for_bootstrap_config")
-	bootstrapConfig, hasBootstrap, err := loadBootstrapConfig(ctx, s.fs, s.packageDir)
+	bootstrapConfig, hasBootstrap, err := loadBootstrapConfig(ctx, s.fs, s.packageDir, s.bootstrapFetcher())
 	if err != nil {
 		s.logger.Error(ctx, "bootstrap_config_load_failed", "error", err)
 		return err
@@ -315,6 +408,46 @@ func (s *CloneService) updateManifestRepository(ctx context.Context, info manife
 	return manifestStore.Save(ctx, targetPathResult.Unwrap(), m)
 }
 
+// Unshallow fetches the full history of the repository in the package
+// directory, which Clone fetches shallowly by default. It does nothing if
+// the history is already complete.
+func (s *CloneService) Unshallow(ctx context.Context, opts UnshallowOptions) error {
+	repoURL, err := adapters.OriginURL(s.packageDir)
+	if err != nil {
+		return fmt.Errorf("unshallow %s: %w", s.packageDir, err)
+	}
+	safeURL := adapters.RedactURL(repoURL)
+
+	auth, err := adapters.ResolveAuthWithOptions(ctx, repoURL, adapters.AuthOptions{
+		SSHKeyPath:     opts.SSHKeyPath,
+		KnownHostsPath: opts.KnownHostsPath,
+	})
+	if err != nil {
+		return ErrAuthFailed{Cause: err}
+	}
+
+	if s.dryRun {
+		s.logger.Info(ctx, "dry_run_unshallow", "url", safeURL, "path", s.packageDir)
+		return nil
+	}
+
+	prompter := opts.PassphrasePrompter
+	if prompter == nil {
+		prompter = s.prompter
+	}
+	s.logger.Info(ctx, "unshallowing_repository", "url", safeURL, "path", s.packageDir, "auth", getAuthMethodName(auth))
+	err = s.cloner.Unshallow(ctx, s.packageDir, adapters.UnshallowOptions{
+		Auth:               auth,
+		Progress:           opts.Progress,
+		PassphrasePrompter: prompter,
+	})
+	if err != nil {
+		s.logger.Error(ctx, "git_unshallow_failed", "error", err)
+		return fmt.Errorf("fetch full history of %s: %w", safeURL, err)
+	}
+	return nil
+}
+
 // validatePackageDir checks if the package directory is suitable for cloning.
 func validatePackageDir(ctx context.Context, fs FS, path string, force bool) error {
 	// Check if directory exists
@@ -350,17 +483,42 @@ func validatePackageDir(ctx context.Context, fs FS, path string, force bool) err
 	return nil
 }
 
-// loadBootstrapConfig loads the bootstrap configuration if it exists.
-func loadBootstrapConfig(ctx context.Context, fs FS, packageDir string) (bootstrap.Config, bool, error) {
-	bootstrapPath := filepath.Join(packageDir, ".dotbootstrap.yaml")
+// bootstrapFetcher returns the fetcher for URL includes of the bootstrap
+// config, honoring the network timeouts and proxies in the user's config.
+func (s *CloneService) bootstrapFetcher() bootstrap.Fetcher {
+	if s.includeFetcher != nil {
+		return s.includeFetcher
+	}
+	return bootstrap.NewHTTPFetcher(updater.NewHTTPClient(userNetworkConfig()))
+}
+
+// userNetworkConfig returns the network settings from the user's config,
+// or the defaults when it cannot be loaded.
+func userNetworkConfig() *config.NetworkConfig {
+	configPath := filepath.Join(config.GetConfigPath("dot"), "config.yaml")
+	if cfg, err := config.NewLoader("dot", configPath).LoadWithEnv(); err == nil && cfg != nil {
+		return &cfg.Network
+	}
+	return &config.DefaultExtended().Network
+}
 
-	// Check if bootstrap file exists
-	if !fs.Exists(ctx, bootstrapPath) {
+// loadBootstrapConfig loads the bootstrap configuration if it exists,
+// resolving its includes. The file may be YAML, TOML or JSON; more than
+// one bootstrap file is an error.
+func loadBootstrapConfig(ctx context.Context, fs FS, packageDir string, fetcher bootstrap.Fetcher) (bootstrap.Config, bool, error) {
+	bootstrapPath, found, err := bootstrap.FindConfig(ctx, fs, packageDir)
+	if err != nil {
+		return bootstrap.Config{}, false, ErrInvalidBootstrap{
+			Reason: "ambiguous bootstrap configuration",
+			Cause:  err,
+		}
+	}
+	if !found {
 		return bootstrap.Config{}, false, nil
 	}
 
 	// Load and parse bootstrap config
-	config, err := bootstrap.Load(ctx, fs, bootstrapPath)
+	config, err := bootstrap.LoadWithOptions(ctx, fs, bootstrapPath, bootstrap.LoadOptions{Fetcher: fetcher})
 	if err != nil {
 		return bootstrap.Config{}, false, ErrInvalidBootstrap{
 			Reason: "failed to parse bootstrap configuration",
@@ -515,13 +673,18 @@ func getAuthMethodName(auth adapters.AuthMethod) string {
 		return "none"
 	}
 
-	switch auth.(type) {
+	switch a := auth.(type) {
 	case adapters.NoAuth:
 		return "none"
 	case adapters.TokenAuth:
 		return "token"
 	case adapters.SSHAuth:
+		if a.PrivateKeyPath == "" {
+			return "ssh-agent"
+		}
 		return "ssh"
+	case adapters.CredentialHelperAuth:
+		return "credential-helper"
 	default:
 		return "unknown"
 	}
@@ -530,6 +693,16 @@ func getAuthMethodName(auth adapters.AuthMethod) string {
 // updateRepoManifest updates the manifest with repository information.
 func (s *CloneService) updateRepoManifest(ctx context.Context, repoURL, branchOpt string) {
 	s.logger.Debug(ctx, "updating_manifest_with_repository_info")
+	if err := s.updateManifestRepository(ctx, s.repositoryInfo(ctx, repoURL, branchOpt)); err != nil {
+		s.logger.Warn(ctx, "failed_to_update_manifest_repository", "error", err)
+	} else {
+		s.logger.Debug(ctx, "manifest_updated_with_repository_info")
+	}
+}
+
+// repositoryInfo describes the repository in packageDir as cloned from
+// repoURL, reading the branch (unless branchOpt is set) and commit from HEAD.
+func (s *CloneService) repositoryInfo(ctx context.Context, repoURL, branchOpt string) manifest.RepositoryInfo {
 	branch := branchOpt
 	if branch == "" {
 		// Read actual branch from repository HEAD
@@ -552,13 +725,39 @@ func (s *CloneService) updateRepoManifest(ctx context.Context, repoURL, branchOp
 		s.logger.Debug(ctx, "detected_commit_sha", "sha", commitSHA)
 	}
 
-	repoInfo := buildRepositoryInfo(repoURL, branch, commitSHA)
+	return buildRepositoryInfo(repoURL, branch, commitSHA)
+}
 
-	if err := s.updateManifestRepository(ctx, repoInfo); err != nil {
-		s.logger.Warn(ctx, "failed_to_update_manifest_repository", "error", err)
-	} else {
-		s.logger.Debug(ctx, "manifest_updated_with_repository_info")
+// recordExistingClone implements CloneOptions.ManifestOnly: it tracks a
+// repository cloned by other tooling without touching packageDir.
+func (s *CloneService) recordExistingClone(ctx context.Context, repoURL string, opts CloneOptions) error {
+	safeURL := adapters.RedactURL(repoURL)
+
+	isDir, err := s.fs.IsDir(ctx, s.packageDir)
+	if err != nil || !isDir {
+		return ErrNotGitRepository{Path: s.packageDir}
+	}
+	if !s.fs.Exists(ctx, filepath.Join(s.packageDir, ".git")) {
+		return ErrNotGitRepository{Path: s.packageDir}
+	}
+
+	if s.dryRun {
+		s.logger.Info(ctx, "dry_run_record_repository", "url", safeURL, "path", s.packageDir)
+		fmt.Fprintf(os.Stderr, "Would record %s as the repository for %s\n", safeURL, s.packageDir)
+		return nil
+	}
+
+	info := s.repositoryInfo(ctx, repoURL, opts.Branch)
+	if err := s.updateManifestRepository(ctx, info); err != nil {
+		return fmt.Errorf("record repository: %w", err)
+	}
+	s.logger.Info(ctx, "repository_recorded", "url", safeURL, "path", s.packageDir, "branch", info.Branch, "commit", info.CommitSHA)
+
+	if err := s.offerToPersistPackageDirectory(ctx, s.packageDir); err != nil {
+		s.logger.Warn(ctx, "failed_to_persist_package_directory", "error", err)
 	}
+
+	return nil
 }
 
 // offerToPersistPackageDirectory asks the user if they want to save the package directory to config.
@@ -576,23 +775,21 @@ func (s *CloneService) offerToPersistPackageDirectory(ctx context.Context, packa
 		}
 	}
 
-	// Ask user for confirmation
-	if !terminal.IsInteractive() {
-		return nil // Skip in non-interactive mode
-	}
-
-	fmt.Printf("\nSave package directory to config?\n")
-	fmt.Printf("  Location: %s\n", packageDir)
-	fmt.Printf("  Config:   %s\n\n", configPath)
-	fmt.Printf("This will make dot automatically use this directory. [Y/n] ")
+	// Ask user for confirmation unless --yes accepts it
+	if !prompt.AssumeYes(ctx) {
+		if !terminal.IsInteractive() {
+			return nil // Skip in non-interactive mode
+		}
 
-	var response string
-	fmt.Scanln(&response)
-	response = strings.ToLower(strings.TrimSpace(response))
+		fmt.Printf("\nSave package directory to config?\n")
+		fmt.Printf("  Location: %s\n", packageDir)
+		fmt.Printf("  Config:   %s\n\n", configPath)
 
-	if response != "" && response != "y" && response != "yes" {
-		fmt.Println("Skipped. Use --dir flag or DOT_PACKAGE_DIR environment variable.")
-		return nil
+		confirmed, err := prompt.Confirm(ctx, os.Stdin, os.Stdout, "This will make dot automatically use this directory.", true)
+		if err != nil || !confirmed {
+			fmt.Println("Skipped. Use --dir flag or DOT_PACKAGE_DIR environment variable.")
+			return nil
+		}
 	}
 
 	return persistPackageDirectory(packageDir, configPath)
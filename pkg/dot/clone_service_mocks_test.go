@@ -8,7 +8,8 @@ import (
 
 // mockGitCloner is a test double for GitCloner.
 type mockGitCloner struct {
-	cloneFn func(ctx context.Context, url string, dest string, opts adapters.CloneOptions) error
+	cloneFn     func(ctx context.Context, url string, dest string, opts adapters.CloneOptions) error
+	unshallowFn func(ctx context.Context, path string, opts adapters.UnshallowOptions) error
 }
 
 func (m *mockGitCloner) Clone(ctx context.Context, url string, dest string, opts adapters.CloneOptions) error {
@@ -18,6 +19,13 @@ func (m *mockGitCloner) Clone(ctx context.Context, url string, dest string, opts
 	return nil
 }
 
+func (m *mockGitCloner) Unshallow(ctx context.Context, path string, opts adapters.UnshallowOptions) error {
+	if m.unshallowFn != nil {
+		return m.unshallowFn(ctx, path, opts)
+	}
+	return nil
+}
+
 // mockPackageSelector is a test double for PackageSelector.
 type mockPackageSelector struct {
 	selectFn func(ctx context.Context, packages []string) ([]string, error)
@@ -3,12 +3,16 @@ package dot
 import (
 	"context"
 	"errors"
+	"fmt"
 	"os"
 	"runtime"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/go-git/go-git/v5"
+	gitconfig "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/yaklabco/dot/internal/adapters"
@@ -238,7 +242,7 @@ packages:
 	err = fs.WriteFile(ctx, "/packages/.dotbootstrap.yaml", []byte(configContent), 0644)
 	require.NoError(t, err)
 
-	config, found, err := loadBootstrapConfig(ctx, fs, "/packages")
+	config, found, err := loadBootstrapConfig(ctx, fs, "/packages", nil)
 	require.NoError(t, err)
 	assert.True(t, found)
 	assert.Equal(t, "1.0", config.Version)
@@ -252,7 +256,7 @@ func TestCloneService_LoadBootstrapConfig_NotFound(t *testing.T) {
 	err := fs.MkdirAll(ctx, "/packages", 0755)
 	require.NoError(t, err)
 
-	config, found, err := loadBootstrapConfig(ctx, fs, "/packages")
+	config, found, err := loadBootstrapConfig(ctx, fs, "/packages", nil)
 	require.NoError(t, err)
 	assert.False(t, found)
 	assert.Equal(t, bootstrap.Config{}, config)
@@ -271,11 +275,89 @@ func TestCloneService_LoadBootstrapConfig_Invalid(t *testing.T) {
 	err = fs.WriteFile(ctx, "/packages/.dotbootstrap.yaml", []byte(invalidConfig), 0644)
 	require.NoError(t, err)
 
-	_, _, err = loadBootstrapConfig(ctx, fs, "/packages")
+	_, _, err = loadBootstrapConfig(ctx, fs, "/packages", nil)
 	assert.Error(t, err)
 	assert.IsType(t, ErrInvalidBootstrap{}, err)
 }
 
+func TestCloneService_LoadBootstrapConfig_TOML(t *testing.T) {
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	require.NoError(t, fs.MkdirAll(ctx, "/packages", 0755))
+	require.NoError(t, fs.WriteFile(ctx, "/packages/.dotbootstrap.toml", []byte(`version = "1.0"
+
+[[packages]]
+name = "dot-vim"
+required = true
+`), 0644))
+
+	config, found, err := loadBootstrapConfig(ctx, fs, "/packages", nil)
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, []bootstrap.PackageSpec{{Name: "dot-vim", Required: true}}, config.Packages)
+}
+
+func TestCloneService_LoadBootstrapConfig_Ambiguous(t *testing.T) {
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	require.NoError(t, fs.MkdirAll(ctx, "/packages", 0755))
+	for _, name := range []string{".dotbootstrap.yaml", ".dotbootstrap.json"} {
+		require.NoError(t, fs.WriteFile(ctx, "/packages/"+name, []byte(""), 0644))
+	}
+
+	_, found, err := loadBootstrapConfig(ctx, fs, "/packages", nil)
+	assert.False(t, found)
+	var invalid ErrInvalidBootstrap
+	require.ErrorAs(t, err, &invalid)
+	var ambiguous bootstrap.ErrAmbiguousConfig
+	require.ErrorAs(t, err, &ambiguous)
+}
+
+// staticFetcher serves bootstrap includes from a map of URL to contents.
+type staticFetcher map[string]string
+
+func (f staticFetcher) Fetch(_ context.Context, url string) ([]byte, error) {
+	data, ok := f[url]
+	if !ok {
+		return nil, fmt.Errorf("not found: %s", url)
+	}
+	return []byte(data), nil
+}
+
+func TestCloneService_LoadBootstrapConfig_Includes(t *testing.T) {
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	require.NoError(t, fs.MkdirAll(ctx, "/packages/bootstrap", 0755))
+	require.NoError(t, fs.WriteFile(ctx, "/packages/bootstrap/local.yaml", []byte(`packages:
+  - name: dot-zsh
+`), 0644))
+	require.NoError(t, fs.WriteFile(ctx, "/packages/.dotbootstrap.yaml", []byte(`version: "1.0"
+include:
+  - https://example.com/team.yaml
+  - bootstrap/local.yaml
+profiles:
+  work:
+    description: Work setup
+    packages: [dot-git, dot-zsh]
+`), 0644))
+	fetcher := staticFetcher{"https://example.com/team.yaml": `packages:
+  - name: dot-git
+    required: true
+defaults:
+  profile: work
+`}
+
+	config, found, err := loadBootstrapConfig(ctx, fs, "/packages", fetcher)
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, []string{"dot-git", "dot-zsh"}, extractPackageNames(config.Packages))
+	assert.Equal(t, "work", config.Defaults.Profile)
+
+	packages, err := selectPackagesFromProfile(config, "work")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"dot-git", "dot-zsh"}, packages)
+}
+
 func TestCloneService_DiscoverPackages(t *testing.T) {
 	ctx := context.Background()
 	fs := adapters.NewMemFS()
@@ -868,6 +950,86 @@ func TestCloneService_Clone_DryRunDoesNotClone(t *testing.T) {
 	assert.False(t, cloneCalled, "dry-run clone should not call git clone")
 }
 
+func TestCloneService_Clone_ManifestOnly(t *testing.T) {
+	ctx := context.Background()
+	fs := adapters.NewOSFilesystem()
+	logger := adapters.NewNoopLogger()
+
+	// An existing clone with one commit on a feature branch
+	repoPath := t.TempDir()
+	repo, err := git.PlainInit(repoPath, false)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(repoPath+"/README", []byte("dotfiles"), 0o644))
+	wt, err := repo.Worktree()
+	require.NoError(t, err)
+	_, err = wt.Add("README")
+	require.NoError(t, err)
+	commit, err := wt.Commit("initial", &git.CommitOptions{
+		Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+	})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(repoPath+"/.git/HEAD", []byte("ref: refs/heads/work\n"), 0o644))
+	require.NoError(t, os.WriteFile(repoPath+"/.git/refs/heads/work", []byte(commit.String()+"\n"), 0o644))
+
+	cloner := &mockGitCloner{
+		cloneFn: func(context.Context, string, string, adapters.CloneOptions) error {
+			t.Fatal("manifest-only must not clone")
+			return nil
+		},
+	}
+	sel := &mockPackageSelector{
+		selectFn: func(context.Context, []string) ([]string, error) {
+			t.Fatal("manifest-only must not select packages")
+			return nil, nil
+		},
+	}
+
+	t.Run("records url, branch and commit", func(t *testing.T) {
+		targetDir := t.TempDir()
+		svc := newCloneService(fs, logger, &ManageService{}, cloner, sel, repoPath, targetDir, false)
+
+		require.NoError(t, svc.Clone(ctx, "https://github.com/user/dotfiles", CloneOptions{ManifestOnly: true}))
+
+		m := manifest.NewFSManifestStore(fs).Load(ctx, NewTargetPath(targetDir).Unwrap()).Unwrap()
+		require.NotNil(t, m.Repository)
+		assert.Equal(t, "https://github.com/user/dotfiles", m.Repository.URL)
+		assert.Equal(t, "work", m.Repository.Branch)
+		assert.Equal(t, commit.String(), m.Repository.CommitSHA)
+		assert.Empty(t, m.Packages, "no packages are installed")
+	})
+
+	t.Run("branch option overrides HEAD", func(t *testing.T) {
+		targetDir := t.TempDir()
+		svc := newCloneService(fs, logger, &ManageService{}, cloner, sel, repoPath, targetDir, false)
+
+		require.NoError(t, svc.Clone(ctx, "https://github.com/user/dotfiles", CloneOptions{ManifestOnly: true, Branch: "main"}))
+
+		m := manifest.NewFSManifestStore(fs).Load(ctx, NewTargetPath(targetDir).Unwrap()).Unwrap()
+		require.NotNil(t, m.Repository)
+		assert.Equal(t, "main", m.Repository.Branch)
+	})
+
+	t.Run("dry run writes nothing", func(t *testing.T) {
+		targetDir := t.TempDir()
+		svc := newCloneService(fs, logger, &ManageService{}, cloner, sel, repoPath, targetDir, true)
+
+		require.NoError(t, svc.Clone(ctx, "https://github.com/user/dotfiles", CloneOptions{ManifestOnly: true}))
+		assert.NoFileExists(t, targetDir+"/.dot-manifest.json")
+	})
+
+	t.Run("package dir must be a git repository", func(t *testing.T) {
+		for name, dir := range map[string]string{
+			"missing":   repoPath + "/missing",
+			"plain dir": t.TempDir(),
+		} {
+			svc := newCloneService(fs, logger, &ManageService{}, cloner, sel, dir, t.TempDir(), false)
+			err := svc.Clone(ctx, "https://github.com/user/dotfiles", CloneOptions{ManifestOnly: true})
+			require.ErrorIs(t, err, ErrNotGitRepository{}, name)
+			assert.Contains(t, err.Error(), dir, name)
+		}
+	})
+}
+
 func TestCloneService_Clone_NoPackagesSelected(t *testing.T) {
 	ctx := context.Background()
 	fs := adapters.NewMemFS()
@@ -1047,11 +1209,26 @@ func TestGetAuthMethodName(t *testing.T) {
 			auth:     adapters.TokenAuth{Token: "ghp_test123"},
 			expected: "token",
 		},
+		{
+			name:     "TokenAuth with host username returns token",
+			auth:     adapters.TokenAuth{Token: "glpat-test", Username: "oauth2"},
+			expected: "token",
+		},
 		{
 			name:     "SSHAuth returns ssh",
-			auth:     adapters.SSHAuth{},
+			auth:     adapters.SSHAuth{PrivateKeyPath: "/home/user/.ssh/id_ed25519"},
 			expected: "ssh",
 		},
+		{
+			name:     "SSHAuth without key returns ssh-agent",
+			auth:     adapters.SSHAuth{},
+			expected: "ssh-agent",
+		},
+		{
+			name:     "CredentialHelperAuth returns credential-helper",
+			auth:     adapters.CredentialHelperAuth{Username: "user", Password: "secret"},
+			expected: "credential-helper",
+		},
 	}
 
 	for _, tt := range tests {
@@ -1061,3 +1238,205 @@ func TestGetAuthMethodName(t *testing.T) {
 		})
 	}
 }
+
+func TestCloneService_Clone_PassesDepthOptions(t *testing.T) {
+	tests := []struct {
+		name             string
+		opts             CloneOptions
+		wantDepth        int
+		wantSingleBranch bool
+	}{
+		{name: "default options", opts: DefaultCloneOptions(), wantDepth: 1},
+		{name: "full history", opts: CloneOptions{Depth: 0}, wantDepth: 0},
+		{name: "custom depth single branch", opts: CloneOptions{Branch: "dev", Depth: 50, SingleBranch: true}, wantDepth: 50, wantSingleBranch: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			fs := adapters.NewMemFS()
+			require.NoError(t, fs.MkdirAll(ctx, "/packages", 0755))
+
+			var got adapters.CloneOptions
+			cloner := &mockGitCloner{
+				cloneFn: func(ctx context.Context, url string, dest string, opts adapters.CloneOptions) error {
+					got = opts
+					// Stop after capturing the options
+					return assert.AnError
+				},
+			}
+
+			svc := newCloneService(fs, adapters.NewNoopLogger(), &ManageService{}, cloner, &mockPackageSelector{}, "/packages", "/home", false)
+			err := svc.Clone(ctx, "https://github.com/user/dotfiles", tt.opts)
+
+			require.ErrorIs(t, err, assert.AnError)
+			assert.Equal(t, tt.wantDepth, got.Depth)
+			assert.Equal(t, tt.wantSingleBranch, got.SingleBranch)
+			assert.Equal(t, tt.opts.Branch, got.Branch)
+		})
+	}
+}
+
+func TestCloneService_Clone_RejectsNegativeDepth(t *testing.T) {
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	cloned := false
+	cloner := &mockGitCloner{
+		cloneFn: func(ctx context.Context, url string, dest string, opts adapters.CloneOptions) error {
+			cloned = true
+			return nil
+		},
+	}
+
+	svc := newCloneService(fs, adapters.NewNoopLogger(), &ManageService{}, cloner, &mockPackageSelector{}, "/packages", "/home", false)
+	err := svc.Clone(ctx, "https://github.com/user/dotfiles", CloneOptions{Depth: -1})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid clone depth -1")
+	assert.False(t, cloned)
+}
+
+// stubPassphrasePrompter returns a fixed passphrase.
+type stubPassphrasePrompter struct {
+	passphrase string
+}
+
+func (p *stubPassphrasePrompter) PromptPassphrase(string) (string, error) {
+	return p.passphrase, nil
+}
+
+func TestCloneService_Clone_SSHAuth(t *testing.T) {
+	keyPath := t.TempDir() + "/id_work"
+	require.NoError(t, os.WriteFile(keyPath, []byte("key"), 0600))
+	defaultPrompter := &stubPassphrasePrompter{passphrase: "default"}
+	optsPrompter := &stubPassphrasePrompter{passphrase: "override"}
+
+	tests := []struct {
+		name         string
+		agentSocket  string
+		opts         CloneOptions
+		wantAuth     adapters.AuthMethod
+		wantPrompter PassphrasePrompter
+	}{
+		{
+			name:         "explicit key path",
+			agentSocket:  "/tmp/agent.sock",
+			opts:         CloneOptions{SSHKeyPath: keyPath, KnownHostsPath: "/etc/known_hosts"},
+			wantAuth:     adapters.SSHAuth{PrivateKeyPath: keyPath, KnownHostsPath: "/etc/known_hosts"},
+			wantPrompter: defaultPrompter,
+		},
+		{
+			name:         "prompter from options",
+			opts:         CloneOptions{SSHKeyPath: keyPath, PassphrasePrompter: optsPrompter},
+			wantAuth:     adapters.SSHAuth{PrivateKeyPath: keyPath},
+			wantPrompter: optsPrompter,
+		},
+		{
+			name:         "agent fallback without key path",
+			agentSocket:  "/tmp/agent.sock",
+			opts:         CloneOptions{KnownHostsPath: "/etc/known_hosts"},
+			wantAuth:     adapters.SSHAuth{KnownHostsPath: "/etc/known_hosts"},
+			wantPrompter: defaultPrompter,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("GITHUB_TOKEN", "")
+			t.Setenv("GIT_TOKEN", "")
+			t.Setenv("SSH_AUTH_SOCK", tt.agentSocket)
+
+			var got adapters.CloneOptions
+			cloner := &mockGitCloner{
+				cloneFn: func(ctx context.Context, url string, dest string, opts adapters.CloneOptions) error {
+					got = opts
+					return assert.AnError
+				},
+			}
+			svc := newCloneService(adapters.NewMemFS(), adapters.NewNoopLogger(), &ManageService{}, cloner, &mockPackageSelector{}, "/packages", "/home", false)
+			svc.prompter = defaultPrompter
+
+			err := svc.Clone(context.Background(), "git@github.com:user/private.git", tt.opts)
+			require.ErrorIs(t, err, assert.AnError)
+			assert.Equal(t, tt.wantAuth, got.Auth)
+			assert.Same(t, tt.wantPrompter, got.PassphrasePrompter)
+		})
+	}
+}
+
+func TestCloneService_Clone_SSHKeyRequiresSSHURL(t *testing.T) {
+	keyPath := t.TempDir() + "/id_work"
+	require.NoError(t, os.WriteFile(keyPath, []byte("key"), 0600))
+
+	svc := newCloneService(adapters.NewMemFS(), adapters.NewNoopLogger(), &ManageService{}, &mockGitCloner{}, &mockPackageSelector{}, "/packages", "/home", false)
+
+	err := svc.Clone(context.Background(), "https://github.com/user/private", CloneOptions{SSHKeyPath: keyPath})
+	var authErr ErrAuthFailed
+	require.ErrorAs(t, err, &authErr)
+	assert.Contains(t, err.Error(), "non-SSH URL")
+}
+
+func TestCloneService_Unshallow(t *testing.T) {
+	// A repository whose origin points at a public HTTPS URL
+	repoPath := t.TempDir()
+	repo, err := git.PlainInit(repoPath, false)
+	require.NoError(t, err)
+	_, err = repo.CreateRemote(&gitconfig.RemoteConfig{Name: "origin", URLs: []string{"https://example.com/user/dotfiles.git"}})
+	require.NoError(t, err)
+
+	t.Run("fetches through the cloner", func(t *testing.T) {
+		t.Setenv("DOT_GIT_TOKEN", "")
+		t.Setenv("GITHUB_TOKEN", "")
+		t.Setenv("GIT_TOKEN", "token123")
+		// Keep credential helpers from the user's gitconfig out of the test
+		t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+
+		var gotPath string
+		var got adapters.UnshallowOptions
+		cloner := &mockGitCloner{
+			unshallowFn: func(ctx context.Context, path string, opts adapters.UnshallowOptions) error {
+				gotPath = path
+				got = opts
+				return nil
+			},
+		}
+		prompter := &stubPassphrasePrompter{}
+		svc := newCloneService(adapters.NewMemFS(), adapters.NewNoopLogger(), &ManageService{}, cloner, &mockPackageSelector{}, repoPath, "/home", false)
+		svc.prompter = prompter
+
+		var progress strings.Builder
+		require.NoError(t, svc.Unshallow(context.Background(), UnshallowOptions{Progress: &progress}))
+		assert.Equal(t, repoPath, gotPath)
+		assert.Equal(t, adapters.TokenAuth{Token: "token123"}, got.Auth)
+		assert.Same(t, &progress, got.Progress)
+		assert.Same(t, prompter, got.PassphrasePrompter)
+	})
+
+	t.Run("dry run does not fetch", func(t *testing.T) {
+		cloner := &mockGitCloner{
+			unshallowFn: func(context.Context, string, adapters.UnshallowOptions) error {
+				t.Fatal("unshallow must not run in dry-run mode")
+				return nil
+			},
+		}
+		svc := newCloneService(adapters.NewMemFS(), adapters.NewNoopLogger(), &ManageService{}, cloner, &mockPackageSelector{}, repoPath, "/home", true)
+		require.NoError(t, svc.Unshallow(context.Background(), UnshallowOptions{}))
+	})
+
+	t.Run("cloner error", func(t *testing.T) {
+		cloner := &mockGitCloner{
+			unshallowFn: func(context.Context, string, adapters.UnshallowOptions) error {
+				return assert.AnError
+			},
+		}
+		svc := newCloneService(adapters.NewMemFS(), adapters.NewNoopLogger(), &ManageService{}, cloner, &mockPackageSelector{}, repoPath, "/home", false)
+		err := svc.Unshallow(context.Background(), UnshallowOptions{})
+		require.ErrorIs(t, err, assert.AnError)
+		assert.Contains(t, err.Error(), "https://example.com/user/dotfiles.git")
+	})
+
+	t.Run("not a repository", func(t *testing.T) {
+		svc := newCloneService(adapters.NewMemFS(), adapters.NewNoopLogger(), &ManageService{}, &mockGitCloner{}, &mockPackageSelector{}, t.TempDir(), "/home", false)
+		require.Error(t, svc.Unshallow(context.Background(), UnshallowOptions{}))
+	})
+}
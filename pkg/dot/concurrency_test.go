@@ -0,0 +1,77 @@
+package dot_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yaklabco/dot/internal/adapters"
+	"github.com/yaklabco/dot/pkg/dot"
+)
+
+// inFlightFS records the most symlinks created at once.
+type inFlightFS struct {
+	*adapters.MemFS
+	mu       sync.Mutex
+	inFlight int
+	peak     int
+}
+
+func (f *inFlightFS) Symlink(ctx context.Context, oldname, newname string) error {
+	f.mu.Lock()
+	f.inFlight++
+	f.peak = max(f.peak, f.inFlight)
+	f.mu.Unlock()
+
+	// Hold the slot long enough for other workers to overlap
+	time.Sleep(5 * time.Millisecond)
+
+	f.mu.Lock()
+	f.inFlight--
+	f.mu.Unlock()
+	return f.MemFS.Symlink(ctx, oldname, newname)
+}
+
+func TestClient_Manage_HonorsConcurrency(t *testing.T) {
+	const fileCount = 12
+
+	for _, limit := range []int{1, 2, 4} {
+		t.Run(fmt.Sprintf("limit %d", limit), func(t *testing.T) {
+			ctx := context.Background()
+			fs := &inFlightFS{MemFS: adapters.NewMemFS()}
+			require.NoError(t, fs.MkdirAll(ctx, "/test/packages/shell/dot-config/shell", 0755))
+			require.NoError(t, fs.MkdirAll(ctx, "/test/target", 0755))
+			for i := 0; i < fileCount; i++ {
+				path := fmt.Sprintf("/test/packages/shell/dot-config/shell/rc%02d", i)
+				require.NoError(t, fs.WriteFile(ctx, path, []byte("rc"), 0644))
+			}
+
+			client, err := dot.NewClient(dot.Config{
+				PackageDir:  "/test/packages",
+				TargetDir:   "/test/target",
+				Concurrency: limit,
+				FS:          fs,
+				Logger:      adapters.NewNoopLogger(),
+			})
+			require.NoError(t, err)
+
+			require.NoError(t, client.Manage(ctx, "shell"))
+
+			assert.LessOrEqual(t, fs.peak, limit, "more symlinks in flight than the concurrency limit")
+			if limit > 1 {
+				assert.Greater(t, fs.peak, 1, "independent links should be created concurrently")
+			}
+			// The links depend on the directories created for them
+			for i := 0; i < fileCount; i++ {
+				isLink, err := fs.IsSymlink(ctx, fmt.Sprintf("/test/target/.config/shell/rc%02d", i))
+				require.NoError(t, err)
+				assert.True(t, isLink)
+			}
+		})
+	}
+}
@@ -6,6 +6,9 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"time"
+
+	"github.com/yaklabco/dot/internal/domain"
 )
 
 // Config holds configuration for the dot Client.
@@ -18,7 +21,8 @@ type Config struct {
 	// Must be an absolute path.
 	TargetDir string
 
-	// LinkMode specifies whether to create relative or absolute symlinks.
+	// LinkMode specifies whether to create relative or absolute symlinks,
+	// or to choose per link.
 	LinkMode LinkMode
 
 	// Folding enables directory-level linking when all contents
@@ -33,6 +37,9 @@ type Config struct {
 
 	// BackupDir specifies where to store backup files.
 	// If empty, backups go to <TargetDir>/.dot-backup/
+	// Timestamped backups are written to a per-run subdirectory,
+	// <BackupDir>/20060102-150405/, so one run's backups can be removed
+	// together.
 	BackupDir string
 
 	// Backup enables automatic backup of conflicting files.
@@ -44,14 +51,44 @@ type Config struct {
 	// Takes precedence over Backup if both are true.
 	Overwrite bool
 
+	// BackupStrategy chooses how backups are named. BackupTimestamped, the
+	// default, writes each run's backups to its own timestamped
+	// subdirectory with a timestamp suffix and never replaces an earlier
+	// backup; BackupOverwrite reuses <BackupDir>/<name><BackupSuffix>.
+	BackupStrategy BackupStrategy
+
+	// BackupSuffix is appended to backups under BackupOverwrite.
+	// If empty, defaults to ".bak".
+	BackupSuffix string
+
 	// ManifestDir specifies where to store the manifest file.
 	// If empty, manifest is stored in TargetDir for backward compatibility.
 	ManifestDir string
 
+	// ManifestLockNoWait makes a manifest write fail at once with
+	// ErrManifestLocked while another dot process holds the manifest lock,
+	// instead of waiting for it to be released.
+	ManifestLockNoWait bool
+
 	// Concurrency limits parallel operation execution.
 	// If zero, defaults to runtime.NumCPU().
 	Concurrency int
 
+	// TransactionSize splits execution into sequential transactions of at
+	// most this many operations. Transactions committed before a failure
+	// are kept; only the failing one is rolled back.
+	// If zero, the whole plan runs as a single transaction.
+	TransactionSize int
+
+	// RetryAttempts is how many times an operation that fails with a
+	// transient filesystem error (EAGAIN, EBUSY, EINTR, ETIMEDOUT) is
+	// tried before the run fails. Zero or one disables retries.
+	RetryAttempts int
+
+	// RetryBackoff is the wait before the first retry; it doubles for each
+	// retry after that.
+	RetryBackoff time.Duration
+
 	// Translate enables dot- prefix to . translation in file names.
 	// When enabled, "dot-vimrc" becomes ".vimrc" in the target.
 	// Default: true. Use boolPtr(false) to disable.
@@ -62,6 +99,11 @@ type Config struct {
 	// Default: true (project is pre-1.0, breaking change acceptable)
 	PackageNameMapping bool
 
+	// PackageMappings maps package names to target subpaths, overriding
+	// PackageNameMapping. With {"dot-work-ssh": ".ssh"}, package
+	// "dot-work-ssh" targets ~/.ssh/. Subpaths must stay within TargetDir.
+	PackageMappings map[string]string
+
 	// IgnorePatterns contains additional ignore patterns beyond defaults.
 	// Supports glob patterns and negation with ! prefix.
 	IgnorePatterns []string
@@ -81,6 +123,28 @@ type Config struct {
 	// Default: true
 	InteractiveLargeFiles bool
 
+	// CacheDir is where scanned package trees and package link health are
+	// cached between runs, reused while the directories and links they were
+	// read from are unchanged. Empty disables caching. See DefaultCacheDir.
+	CacheDir string
+
+	// DoctorCategories adds orphan triage categories to the built-in set.
+	// A category with the same name as a built-in one replaces it.
+	DoctorCategories []PatternCategory
+
+	// Hooks lists commands run before and after managing and unmanaging
+	// packages. Hooks do not run in dry-run mode.
+	Hooks Hooks
+
+	// CommandRunner runs hook commands.
+	// Defaults to running them with os/exec if nil.
+	CommandRunner CommandRunner
+
+	// Theme names the color theme for interactive prompts: default,
+	// solarized, nocolor or high-contrast. Unknown or empty names use the
+	// default theme.
+	Theme string
+
 	// Stdin is the input reader for interactive prompts.
 	// Defaults to os.Stdin if nil.
 	Stdin io.Reader
@@ -104,8 +168,26 @@ const (
 	LinkRelative LinkMode = iota
 	// LinkAbsolute creates absolute symlinks.
 	LinkAbsolute
+	// LinkAuto chooses per link: relative when the source and the link share
+	// a base directory below the filesystem root and sit on the same device,
+	// absolute otherwise.
+	LinkAuto
 )
 
+// String returns the configuration name of the mode.
+func (m LinkMode) String() string {
+	switch m {
+	case LinkRelative:
+		return "relative"
+	case LinkAbsolute:
+		return "absolute"
+	case LinkAuto:
+		return "auto"
+	default:
+		return fmt.Sprintf("LinkMode(%d)", int(m))
+	}
+}
+
 // Validate checks that the configuration is valid.
 func (c Config) Validate() error {
 	if c.PackageDir == "" {
@@ -130,6 +212,10 @@ func (c Config) Validate() error {
 		return fmt.Errorf("Logger is required")
 	}
 
+	if c.LinkMode < LinkRelative || c.LinkMode > LinkAuto {
+		return fmt.Errorf("invalid link mode: %d", int(c.LinkMode))
+	}
+
 	if c.Verbosity < 0 {
 		return fmt.Errorf("verbosity cannot be negative")
 	}
@@ -138,6 +224,34 @@ func (c Config) Validate() error {
 		return fmt.Errorf("concurrency cannot be negative")
 	}
 
+	if c.TransactionSize < 0 {
+		return fmt.Errorf("transaction size cannot be negative")
+	}
+
+	if c.RetryAttempts < 0 {
+		return fmt.Errorf("retry attempts cannot be negative")
+	}
+
+	if c.RetryBackoff < 0 {
+		return fmt.Errorf("retry backoff cannot be negative")
+	}
+
+	switch c.BackupStrategy {
+	case "", BackupTimestamped, BackupOverwrite:
+	default:
+		return fmt.Errorf("invalid backup strategy: %q", c.BackupStrategy)
+	}
+
+	for pkg, subpath := range c.PackageMappings {
+		if err := domain.ValidateTargetSubpath(subpath); err != nil {
+			return fmt.Errorf("invalid mapping for package %s: %w", pkg, err)
+		}
+	}
+
+	if err := c.Hooks.Validate(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -153,6 +267,10 @@ func (c Config) WithDefaults() Config {
 		cfg.Metrics = NewNoopMetrics()
 	}
 
+	if cfg.CommandRunner == nil {
+		cfg.CommandRunner = NewExecRunner()
+	}
+
 	if cfg.BackupDir == "" {
 		cfg.BackupDir = filepath.Join(cfg.TargetDir, ".dot-backup")
 	}
@@ -272,12 +390,32 @@ func (b *ConfigBuilder) WithManifestDir(dir string) *ConfigBuilder {
 	return b
 }
 
+// WithCacheDir sets the directory caching package scans and status.
+func (b *ConfigBuilder) WithCacheDir(dir string) *ConfigBuilder {
+	b.config.CacheDir = dir
+	return b
+}
+
 // WithConcurrency sets the concurrency limit.
 func (b *ConfigBuilder) WithConcurrency(n int) *ConfigBuilder {
 	b.config.Concurrency = n
 	return b
 }
 
+// WithTransactionSize sets the maximum number of operations per transaction.
+func (b *ConfigBuilder) WithTransactionSize(n int) *ConfigBuilder {
+	b.config.TransactionSize = n
+	return b
+}
+
+// WithRetry sets how many times an operation failing with a transient
+// filesystem error is tried, and the wait before the first retry.
+func (b *ConfigBuilder) WithRetry(attempts int, backoff time.Duration) *ConfigBuilder {
+	b.config.RetryAttempts = attempts
+	b.config.RetryBackoff = backoff
+	return b
+}
+
 // WithPackageNameMapping sets whether package name mapping is enabled.
 // Default is true when not explicitly set.
 func (b *ConfigBuilder) WithPackageNameMapping(v bool) *ConfigBuilder {
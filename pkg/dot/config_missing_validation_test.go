@@ -2,6 +2,7 @@ package dot_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -61,3 +62,52 @@ func TestConfig_Validate_NegativeConcurrency(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "concurrency")
 }
+
+func TestConfig_Validate_NegativeTransactionSize(t *testing.T) {
+	cfg := dot.Config{
+		PackageDir:      "/packages",
+		TargetDir:       "/target",
+		FS:              adapters.NewMemFS(),
+		Logger:          adapters.NewNoopLogger(),
+		TransactionSize: -1,
+	}
+
+	err := cfg.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "transaction size")
+}
+
+func TestConfig_Validate_NegativeRetry(t *testing.T) {
+	base := dot.Config{
+		PackageDir: "/packages",
+		TargetDir:  "/target",
+		FS:         adapters.NewMemFS(),
+		Logger:     adapters.NewNoopLogger(),
+	}
+
+	cfg := base
+	cfg.RetryAttempts = -1
+	err := cfg.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "retry attempts")
+
+	cfg = base
+	cfg.RetryBackoff = -time.Second
+	err = cfg.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "retry backoff")
+}
+
+func TestConfig_Validate_PackageMappingEscapesTarget(t *testing.T) {
+	cfg := dot.Config{
+		PackageDir:      "/packages",
+		TargetDir:       "/target",
+		FS:              adapters.NewMemFS(),
+		Logger:          adapters.NewNoopLogger(),
+		PackageMappings: map[string]string{"dot-work-ssh": "../.ssh"},
+	}
+
+	err := cfg.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "dot-work-ssh")
+}
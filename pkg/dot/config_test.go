@@ -4,9 +4,11 @@ import (
 	"bytes"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/yaklabco/dot/internal/adapters"
 	"github.com/yaklabco/dot/pkg/dot"
 )
 
@@ -166,6 +168,8 @@ func TestConfigBuilder_AllFields(t *testing.T) {
 		WithOverwrite(true).
 		WithManifestDir("/manifest").
 		WithConcurrency(4).
+		WithTransactionSize(100).
+		WithRetry(3, 50*time.Millisecond).
 		WithPackageNameMapping(true).
 		WithIgnorePatterns([]string{"*.tmp", "*.log"}).
 		WithUseDefaultIgnorePatterns(true).
@@ -187,6 +191,9 @@ func TestConfigBuilder_AllFields(t *testing.T) {
 	assert.True(t, cfg.Overwrite)
 	assert.Equal(t, "/manifest", cfg.ManifestDir)
 	assert.Equal(t, 4, cfg.Concurrency)
+	assert.Equal(t, 100, cfg.TransactionSize)
+	assert.Equal(t, 3, cfg.RetryAttempts)
+	assert.Equal(t, 50*time.Millisecond, cfg.RetryBackoff)
 	assert.True(t, cfg.PackageNameMapping)
 	assert.Equal(t, []string{"*.tmp", "*.log"}, cfg.IgnorePatterns)
 	assert.True(t, cfg.UseDefaultIgnorePatterns)
@@ -208,3 +215,36 @@ func TestConfigBuilder_FluentChaining(t *testing.T) {
 
 	assert.Same(t, builder, result, "Fluent methods should return the same builder instance")
 }
+
+func TestConfig_ValidateLinkMode(t *testing.T) {
+	base := dot.Config{
+		PackageDir: "/packages",
+		TargetDir:  "/target",
+		FS:         adapters.NewMemFS(),
+		Logger:     adapters.NewNoopLogger(),
+	}
+
+	tests := []struct {
+		mode    dot.LinkMode
+		wantErr bool
+	}{
+		{dot.LinkRelative, false},
+		{dot.LinkAbsolute, false},
+		{dot.LinkAuto, false},
+		{dot.LinkMode(-1), true},
+		{dot.LinkMode(99), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode.String(), func(t *testing.T) {
+			cfg := base
+			cfg.LinkMode = tt.mode
+			err := cfg.Validate()
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "invalid link mode")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
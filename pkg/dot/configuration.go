@@ -6,6 +6,13 @@ import "github.com/yaklabco/dot/internal/config"
 // It is an alias to the internal ExtendedConfig to provide a stable API.
 type ExtendedConfig = config.ExtendedConfig
 
+// HookConfig describes one command in the hooks section of ExtendedConfig.
+type HookConfig = config.HookConfig
+
+// CLIProfileConfig is a named preset of command defaults in the
+// cli_profiles section of ExtendedConfig.
+type CLIProfileConfig = config.CLIProfileConfig
+
 // DefaultExtendedConfig returns extended configuration with sensible defaults.
 func DefaultExtendedConfig() *ExtendedConfig {
 	return config.DefaultExtended()
@@ -16,6 +23,44 @@ func LoadExtendedFromFile(path string) (*ExtendedConfig, error) {
 	return config.LoadExtendedFromFile(path)
 }
 
+// LoadExtendedWithIncludes loads extended configuration from the specified
+// file, resolving the files it includes.
+func LoadExtendedWithIncludes(path string) (*ExtendedConfig, error) {
+	return config.LoadExtendedWithIncludes(path)
+}
+
+// GenerateConfigSchema returns a JSON Schema describing the configuration file.
+func GenerateConfigSchema() ([]byte, error) {
+	return config.GenerateSchema()
+}
+
+// ApplyConfigFlags returns cfg with command-line flag overrides applied.
+// Flags are keyed by their long name, e.g. "dir", "verbose", "log-json".
+func ApplyConfigFlags(cfg *ExtendedConfig, flags map[string]interface{}) *ExtendedConfig {
+	return config.ApplyFlags(cfg, flags)
+}
+
+// RedactedConfig returns a copy of cfg with secrets masked, safe to print.
+func RedactedConfig(cfg *ExtendedConfig) (*ExtendedConfig, error) {
+	return config.Redacted(cfg)
+}
+
+// ConfigKeys returns every configuration key in dotted form.
+func ConfigKeys() []string {
+	return config.Keys()
+}
+
+// SettableConfigKeys returns the configuration keys that ConfigWriter.Update
+// accepts as a single value.
+func SettableConfigKeys() []string {
+	return config.SettableKeys()
+}
+
+// GetConfigValue returns the value of a dotted configuration key as a string.
+func GetConfigValue(cfg *ExtendedConfig, key string) (string, error) {
+	return config.GetValue(cfg, key)
+}
+
 // ConfigLoader handles configuration loading with precedence.
 type ConfigLoader struct {
 	loader *config.Loader
@@ -32,3 +77,10 @@ func NewConfigLoader(appName, configPath string) *ConfigLoader {
 func (l *ConfigLoader) LoadWithEnv() (*ExtendedConfig, error) {
 	return l.loader.LoadWithEnv()
 }
+
+// LoadWithPreset loads configuration like LoadWithEnv with the named CLI
+// profile preset applied beneath the config file. An empty name applies
+// no preset.
+func (l *ConfigLoader) LoadWithPreset(name string) (*ExtendedConfig, error) {
+	return l.loader.LoadWithPreset(name)
+}
@@ -1,9 +1,41 @@
 package dot
 
-import "github.com/yaklabco/dot/internal/domain"
+import (
+	"github.com/yaklabco/dot/internal/domain"
+	"github.com/yaklabco/dot/internal/manifest"
+	"github.com/yaklabco/dot/internal/planner"
+)
 
 // ConflictInfo represents conflict information in plan metadata.
 type ConflictInfo = domain.ConflictInfo
 
 // WarningInfo represents warning information in plan metadata.
 type WarningInfo = domain.WarningInfo
+
+// ResolutionInfo records a conflict resolved automatically by policy.
+type ResolutionInfo = domain.ResolutionInfo
+
+// ResolutionRecord is an entry in the manifest's audit log of conflicts
+// resolved automatically by policy.
+type ResolutionRecord = manifest.ResolutionRecord
+
+// ResolutionPolicy chooses how a conflict is resolved.
+type ResolutionPolicy = planner.ResolutionPolicy
+
+// Resolution policies.
+const (
+	PolicyFail      = planner.PolicyFail
+	PolicyBackup    = planner.PolicyBackup
+	PolicyOverwrite = planner.PolicyOverwrite
+	PolicySkip      = planner.PolicySkip
+	PolicyAdopt     = planner.PolicyAdopt
+)
+
+// BackupStrategy chooses how the backup policy names backups.
+type BackupStrategy = planner.BackupStrategy
+
+// Backup strategies.
+const (
+	BackupTimestamped = planner.BackupTimestamped
+	BackupOverwrite   = planner.BackupOverwrite
+)
@@ -50,6 +50,9 @@ type Issue struct {
 	Path       string        `json:"path,omitempty" yaml:"path,omitempty"`
 	Message    string        `json:"message" yaml:"message"`
 	Suggestion string        `json:"suggestion,omitempty" yaml:"suggestion,omitempty"`
+	// Packages lists the packages involved, such as the competing owners
+	// of a link ownership conflict.
+	Packages []string `json:"packages,omitempty" yaml:"packages,omitempty"`
 }
 
 // IssueSeverity indicates the severity of an issue.
@@ -104,6 +107,12 @@ const (
 	IssueCircular
 	// IssueManifestInconsistency indicates mismatch between manifest and filesystem.
 	IssueManifestInconsistency
+	// IssueManifestCorrupt indicates the manifest does not match its checksum.
+	IssueManifestCorrupt
+	// IssueLinkOwnershipConflict indicates a target link claimed by more than one package.
+	IssueLinkOwnershipConflict
+	// IssueForeignTarget indicates a managed symlink pointing outside the package directory.
+	IssueForeignTarget
 )
 
 // String returns the string representation of issue type.
@@ -121,6 +130,12 @@ func (t IssueType) String() string {
 		return "circular"
 	case IssueManifestInconsistency:
 		return "manifest_inconsistency"
+	case IssueManifestCorrupt:
+		return "manifest_corrupt"
+	case IssueLinkOwnershipConflict:
+		return "link_ownership_conflict"
+	case IssueForeignTarget:
+		return "foreign_target"
 	default:
 		return "unknown"
 	}
@@ -142,6 +157,11 @@ type DiagnosticStats struct {
 	BrokenLinks   int `json:"broken_links" yaml:"broken_links"`
 	OrphanedLinks int `json:"orphaned_links" yaml:"orphaned_links"`
 	ManagedLinks  int `json:"managed_links" yaml:"managed_links"`
+	// OwnershipConflicts counts target links claimed by more than one package.
+	OwnershipConflicts int `json:"ownership_conflicts" yaml:"ownership_conflicts"`
+	// Junctions counts managed links created as Windows directory junctions
+	// instead of symbolic links.
+	Junctions int `json:"junctions" yaml:"junctions"`
 }
 
 // ScanMode controls orphaned link detection behavior.
@@ -0,0 +1,44 @@
+package dot
+
+// DriftKind classifies how a live link differs from what its package declares.
+type DriftKind string
+
+const (
+	// DriftMissing marks a link the package declares that does not exist.
+	DriftMissing DriftKind = "missing"
+	// DriftWrongTarget marks a symlink that points somewhere other than
+	// the package file it should link to.
+	DriftWrongTarget DriftKind = "wrong_target"
+	// DriftNotSymlink marks a regular file or directory where the package
+	// declares a link.
+	DriftNotSymlink DriftKind = "not_symlink"
+	// DriftOrphaned marks a link recorded for the package that the package
+	// no longer declares.
+	DriftOrphaned DriftKind = "orphaned"
+)
+
+// Drift describes one difference between a package and the target directory.
+type Drift struct {
+	Kind DriftKind `json:"kind" yaml:"kind"`
+	// Path is the link path relative to the target directory.
+	Path string `json:"path" yaml:"path"`
+	// Expected is the package file the link should point to. Empty for
+	// orphaned links.
+	Expected string `json:"expected,omitempty" yaml:"expected,omitempty"`
+	// Actual is where the live symlink points. Empty when no symlink exists.
+	Actual string `json:"actual,omitempty" yaml:"actual,omitempty"`
+}
+
+// PackageDiff reports the drift between a package's source and its live
+// links in the target directory.
+type PackageDiff struct {
+	Package string `json:"package" yaml:"package"`
+	// InSync counts the declared links that are in place.
+	InSync int     `json:"in_sync" yaml:"in_sync"`
+	Drift  []Drift `json:"drift" yaml:"drift"`
+}
+
+// HasDrift reports whether any link differs from the package.
+func (d PackageDiff) HasDrift() bool {
+	return len(d.Drift) > 0
+}
@@ -0,0 +1,242 @@
+package dot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+
+	"github.com/yaklabco/dot/internal/adapters"
+	"github.com/yaklabco/dot/internal/domain"
+	"github.com/yaklabco/dot/internal/manifest"
+	"github.com/yaklabco/dot/internal/pipeline"
+	"github.com/yaklabco/dot/internal/planner"
+)
+
+// DiffService compares a package's source against its live links.
+type DiffService struct {
+	fs          FS
+	logger      Logger
+	managePipe  *pipeline.ManagePipeline
+	manifestSvc *ManifestService
+	packageDir  string
+	targetDir   string
+	// planFS is the caching filesystem the manage pipeline plans with, if any.
+	planFS *adapters.CachingFS
+}
+
+// newDiffService creates a new diff service.
+func newDiffService(
+	fs FS,
+	logger Logger,
+	managePipe *pipeline.ManagePipeline,
+	manifestSvc *ManifestService,
+	packageDir string,
+	targetDir string,
+) *DiffService {
+	return &DiffService{
+		fs:          fs,
+		logger:      logger,
+		managePipe:  managePipe,
+		manifestSvc: manifestSvc,
+		packageDir:  packageDir,
+		targetDir:   targetDir,
+	}
+}
+
+// Diff compares every link pkg declares against the target directory, and
+// reports links recorded for pkg in the manifest that it no longer
+// declares. A package removed from the package directory but still
+// recorded in the manifest reports all its links as orphaned. A partially
+// managed package is compared only on the files its include globs select.
+func (s *DiffService) Diff(ctx context.Context, pkg string) (PackageDiff, error) {
+	info, recorded, err := s.recordedPackage(ctx, pkg)
+	if err != nil {
+		return PackageDiff{}, err
+	}
+
+	desired, err := s.desiredLinks(ctx, pkg, info.Include)
+	var notFound ErrPackageNotFound
+	if errors.As(err, &notFound) && recorded {
+		desired = nil
+	} else if err != nil {
+		return PackageDiff{}, err
+	}
+
+	result := PackageDiff{Package: pkg, Drift: []Drift{}}
+	declared := make(map[string]bool, len(desired))
+	for _, spec := range desired {
+		rel := s.relPath(spec.Target.String())
+		declared[rel] = true
+
+		drift, inSync, err := s.checkLink(ctx, rel, spec.Target.String(), spec.Source.String())
+		if err != nil {
+			return PackageDiff{}, err
+		}
+		if inSync {
+			result.InSync++
+			continue
+		}
+		result.Drift = append(result.Drift, drift)
+	}
+
+	for _, rel := range info.Links {
+		if declared[rel] || underDeclared(rel, declared) {
+			continue
+		}
+		if dest, ok := s.liveLink(ctx, filepath.Join(s.targetDir, rel)); ok {
+			result.Drift = append(result.Drift, Drift{Kind: DriftOrphaned, Path: rel, Actual: dest})
+		}
+	}
+
+	sort.SliceStable(result.Drift, func(i, j int) bool {
+		return result.Drift[i].Path < result.Drift[j].Path
+	})
+	s.logger.Debug(ctx, "package_diff", "package", pkg, "in_sync", result.InSync, "drift", len(result.Drift))
+	return result, nil
+}
+
+// recordedPackage returns the manifest entry for pkg and whether one exists.
+// A missing manifest means nothing is recorded.
+func (s *DiffService) recordedPackage(ctx context.Context, pkg string) (manifest.PackageInfo, bool, error) {
+	targetPathResult := NewTargetPath(s.targetDir)
+	if !targetPathResult.IsOk() {
+		return manifest.PackageInfo{}, false, targetPathResult.UnwrapErr()
+	}
+
+	manifestResult := s.manifestSvc.Load(ctx, targetPathResult.Unwrap())
+	if !manifestResult.IsOk() {
+		err := manifestResult.UnwrapErr()
+		if isManifestNotFoundError(err) {
+			return manifest.PackageInfo{}, false, nil
+		}
+		return manifest.PackageInfo{}, false, err
+	}
+	m := manifestResult.Unwrap()
+	info, exists := m.GetPackage(pkg)
+	return info, exists, nil
+}
+
+// desiredLinks scans pkg and returns the links it declares, restricted to
+// the include globs if any, ordered by target path.
+func (s *DiffService) desiredLinks(ctx context.Context, pkg string, include []string) ([]planner.LinkSpec, error) {
+	packagePathResult := NewPackagePath(s.packageDir)
+	if !packagePathResult.IsOk() {
+		return nil, fmt.Errorf("invalid package directory: %w", packagePathResult.UnwrapErr())
+	}
+	targetPathResult := NewTargetPath(s.targetDir)
+	if !targetPathResult.IsOk() {
+		return nil, fmt.Errorf("invalid target directory: %w", targetPathResult.UnwrapErr())
+	}
+
+	if s.planFS != nil {
+		s.planFS.Reset()
+		defer s.planFS.Reset()
+	}
+	desiredResult := s.managePipe.DesiredState(ctx, pipeline.ManageInput{
+		PackageDir: packagePathResult.Unwrap(),
+		TargetDir:  targetPathResult.Unwrap(),
+		Packages:   []string{pkg},
+		Include:    map[string][]string{pkg: include},
+	})
+	if !desiredResult.IsOk() {
+		return nil, desiredResult.UnwrapErr()
+	}
+
+	links := desiredResult.Unwrap().Links
+	paths := make([]string, 0, len(links))
+	for path := range links {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	specs := make([]planner.LinkSpec, 0, len(paths))
+	for _, path := range paths {
+		specs = append(specs, links[path])
+	}
+	return specs, nil
+}
+
+// checkLink compares the live state at target with a link to source. It
+// reports true when the link is in place, directly or through a parent
+// directory linked to the matching package directory.
+func (s *DiffService) checkLink(ctx context.Context, rel, target, source string) (Drift, bool, error) {
+	if s.linkedThroughParent(ctx, target, source) {
+		return Drift{}, true, nil
+	}
+
+	info, err := s.fs.Lstat(ctx, target)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return Drift{Kind: DriftMissing, Path: rel, Expected: source}, false, nil
+		}
+		return Drift{}, false, fmt.Errorf("inspect %s: %w", target, err)
+	}
+	if info.Mode()&fs.ModeSymlink == 0 {
+		return Drift{Kind: DriftNotSymlink, Path: rel, Expected: source}, false, nil
+	}
+
+	dest, ok := s.liveLink(ctx, target)
+	if !ok {
+		return Drift{}, false, fmt.Errorf("read link %s", target)
+	}
+	if dest == filepath.Clean(source) {
+		return Drift{}, true, nil
+	}
+	return Drift{Kind: DriftWrongTarget, Path: rel, Expected: source, Actual: dest}, false, nil
+}
+
+// linkedThroughParent reports whether a directory between the target
+// directory and target is a symlink that makes target resolve to source,
+// as when the directory was folded.
+func (s *DiffService) linkedThroughParent(ctx context.Context, target, source string) bool {
+	root := filepath.Clean(s.targetDir)
+	for dir := filepath.Dir(target); dir != root && dir != filepath.Dir(dir); dir = filepath.Dir(dir) {
+		dest, ok := s.liveLink(ctx, dir)
+		if !ok {
+			continue
+		}
+		rest, err := filepath.Rel(dir, target)
+		if err != nil {
+			return false
+		}
+		return filepath.Join(dest, rest) == filepath.Clean(source)
+	}
+	return false
+}
+
+// liveLink returns the absolute destination of the symlink at path, or
+// false if path is not a readable symlink.
+func (s *DiffService) liveLink(ctx context.Context, path string) (string, bool) {
+	info, err := s.fs.Lstat(ctx, path)
+	if err != nil || info.Mode()&fs.ModeSymlink == 0 {
+		return "", false
+	}
+	dest, err := s.fs.ReadLink(ctx, path)
+	if err != nil {
+		return "", false
+	}
+	return domain.ResolveLinkTarget(path, dest), true
+}
+
+// relPath returns path relative to the target directory, or path itself
+// if it lies elsewhere.
+func (s *DiffService) relPath(path string) string {
+	rel, err := filepath.Rel(s.targetDir, path)
+	if err != nil {
+		return path
+	}
+	return rel
+}
+
+// underDeclared reports whether rel lies beneath a declared link, such as a
+// file inside a folded directory.
+func underDeclared(rel string, declared map[string]bool) bool {
+	for dir := filepath.Dir(rel); dir != "." && dir != filepath.Dir(dir); dir = filepath.Dir(dir) {
+		if declared[dir] {
+			return true
+		}
+	}
+	return false
+}
@@ -72,6 +72,14 @@
 //		fmt.Printf("  %s\n", op.Kind())
 //	}
 //
+// Plan metadata rates how much applying the plan could lose. DestructiveOps
+// counts deletes of files that are not backed up and directory removals,
+// BytesBackedUp totals the files moved aside, and RiskLevel summarizes both:
+//
+//	if plan.Metadata.RiskLevel == dot.RiskHigh {
+//		fmt.Printf("%d destructive operations\n", plan.Metadata.DestructiveOps)
+//	}
+//
 // # Query Operations
 //
 // Check installation status:
@@ -85,6 +93,15 @@
 //		fmt.Printf("%s: %d links\n", pkg.Name, pkg.LinkCount)
 //	}
 //
+// Filter and order packages, here those starting with "n" with the most
+// links first:
+//
+//	status, err := client.StatusWithOptions(ctx, dot.StatusOptions{
+//		Filter:     "n*",
+//		SortBy:     dot.StatusSortLinks,
+//		Descending: true,
+//	})
+//
 // List all installed packages:
 //
 //	packages, err := client.List(ctx)
@@ -96,6 +113,42 @@
 //		fmt.Printf("%s (installed %s)\n", pkg.Name, pkg.InstalledAt)
 //	}
 //
+// For large installations, ListStream delivers the same packages one at a
+// time so output can start before every package has been checked:
+//
+//	stream, err := client.ListStream(ctx)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	for pkg := range stream {
+//		fmt.Println(pkg.Name)
+//	}
+//
+// # Manifest Backup
+//
+// Snapshot the tracked install state and restore it on another machine:
+//
+//	var buf bytes.Buffer
+//	if err := client.ExportManifest(ctx, &buf); err != nil {
+//		log.Fatal(err)
+//	}
+//
+//	// Later, on the rebuilt machine
+//	if err := client.ImportManifest(ctx, &buf); err != nil {
+//		log.Fatal(err)
+//	}
+//
+// The export is a versioned JSON document holding packages, links, hashes,
+// repository information, and doctor ignore state. Import replaces the
+// manifest without touching symlinks and logs a warning for each package
+// missing from PackageDir. RestoreManifest imports and then manages the
+// packages that are present, reporting the missing ones:
+//
+//	restore, err := client.RestoreManifest(ctx, &buf)
+//	for _, pkg := range restore.Missing {
+//		fmt.Printf("skipped %s\n", pkg)
+//	}
+//
 // # Configuration
 //
 // The Config struct controls all dot behavior:
@@ -106,7 +159,7 @@
 //   - Logger: Logger implementation (required)
 //   - Tracer: Distributed tracing (optional, defaults to noop)
 //   - Metrics: Metrics collection (optional, defaults to noop)
-//   - LinkMode: Relative or absolute symlinks (default: relative)
+//   - LinkMode: Relative, absolute, or per-link auto symlinks (default: relative)
 //   - Folding: Enable directory folding (default: true)
 //   - DryRun: Preview mode (default: false)
 //   - Verbosity: Logging level (default: 0)
@@ -145,6 +198,18 @@
 //		Metrics: promMetrics, // Your Prometheus metrics
 //	}
 //
+// Every filesystem call is reported through Metrics as <method>_total,
+// <method>_seconds and <method>_errors_total, such as symlink_total and
+// readfile_seconds.
+//
+// Manage, Remanage, Unmanage, and Adopt each start a span named for the
+// operation. The pipeline phases run in child spans named "scan", "plan",
+// "resolve", "execute", and "rollback", carrying attributes such as
+// package_count, operation_count, and conflict_count. Each operation runs
+// in a span named for its kind, such as "LinkCreate", under the execute
+// span; rolling it back runs in a "LinkCreate.rollback" span under the
+// rollback span. Both carry op_id, target_path, and a rollback flag.
+//
 // # Testing
 //
 // The library is designed for testability:
@@ -180,8 +245,13 @@
 //   - ErrConflict: Conflict detected during operation
 //   - ErrCyclicDependency: Circular dependency in operations
 //   - ErrMultiple: Multiple errors occurred
+//   - ErrExecutionFailed: A plan failed and was rolled back
+//
+// ErrExecutionFailed lists an ErrOperationFailed for each operation that
+// failed, followed by an ErrRollbackFailed for each executed operation that
+// could not be rolled back; both name the operation ID and kind.
 //
-// Errors include user-facing messages via UserFacingErrorMessage().
+// Errors include user-facing messages via UserFacingError().
 //
 // # Safety Guarantees
 //
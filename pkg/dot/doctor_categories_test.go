@@ -0,0 +1,93 @@
+package dot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaklabco/dot/internal/adapters"
+	"github.com/yaklabco/dot/internal/manifest"
+)
+
+func TestDoctorService_GroupOrphans_CustomCategories(t *testing.T) {
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	require.NoError(t, fs.MkdirAll(ctx, "/home", 0755))
+	require.NoError(t, fs.Symlink(ctx, "/opt/work/deploy", "/home/deploy"))
+	require.NoError(t, fs.Symlink(ctx, "/home/.npm/bin/tsc", "/home/tsc"))
+
+	manifestSvc := newManifestService(fs, adapters.NewNoopLogger(), manifest.NewFSManifestStore(fs))
+	svc := newDoctorService(fs, adapters.NewNoopLogger(), manifestSvc, "/packages", "/home")
+	orphans := []Issue{
+		{Type: IssueOrphanedLink, Path: "deploy"},
+		{Type: IssueOrphanedLink, Path: "tsc"},
+	}
+
+	// Built-in categories only: /opt/work/deploy is not matched by /opt/*
+	names := groupNames(svc.groupOrphansByCategory(orphans, svc.readOrphanTargets(ctx, orphans)))
+	assert.Equal(t, map[string]string{"deploy": "", "tsc": "npm"}, names)
+
+	// A custom category is matched, and overriding npm drops its built-in patterns
+	svc.categories = []PatternCategory{
+		{Name: "work", Description: "Work tooling", Patterns: []string{"/opt/work/*"}, Confidence: "high"},
+		{Name: "npm", Description: "Corporate npm", Patterns: []string{"*/corp-npm/*"}},
+	}
+	groups := svc.groupOrphansByCategory(orphans, svc.readOrphanTargets(ctx, orphans))
+	names = groupNames(groups)
+	assert.Equal(t, map[string]string{"deploy": "work", "tsc": ""}, names)
+
+	for _, group := range groups {
+		if group.Category != nil && group.Category.Name == "work" {
+			assert.Equal(t, "/opt/work/*", group.Pattern)
+		}
+	}
+
+	report := svc.StructuredReport(ctx, DiagnosticReport{Issues: orphans})
+	patterns := make(map[string]string)
+	for _, issue := range report.Issues {
+		patterns[issue.Path] = issue.SuggestedPattern
+	}
+	assert.Equal(t, map[string]string{"deploy": "/opt/work/*", "tsc": ""}, patterns)
+}
+
+func TestDoctorService_ReadOrphanTargets(t *testing.T) {
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	require.NoError(t, fs.MkdirAll(ctx, "/home", 0755))
+	require.NoError(t, fs.Symlink(ctx, "/home/.npm/bin/tsc", "/home/tsc"))
+	require.NoError(t, fs.Symlink(ctx, "/somewhere/else", "/home/other"))
+
+	manifestSvc := newManifestService(fs, adapters.NewNoopLogger(), manifest.NewFSManifestStore(fs))
+	svc := newDoctorService(fs, adapters.NewNoopLogger(), manifestSvc, "/packages", "/home")
+	targets := svc.readOrphanTargets(ctx, []Issue{
+		{Type: IssueOrphanedLink, Path: "tsc"},
+		{Type: IssueOrphanedLink, Path: "other"},
+		{Type: IssueOrphanedLink, Path: "missing"},
+	})
+
+	require.Len(t, targets, 3)
+	assert.Equal(t, "/home/.npm/bin/tsc", targets["tsc"].Target)
+	require.NotNil(t, targets["tsc"].Category)
+	assert.Equal(t, "npm", targets["tsc"].Category.Name)
+	assert.Equal(t, "/somewhere/else", targets["other"].Target)
+	assert.Nil(t, targets["other"].Category)
+	assert.Error(t, targets["missing"].Err, "a link that cannot be read keeps its error")
+	assert.Nil(t, targets["missing"].Category)
+}
+
+// groupNames maps each grouped link path to its category name, or "" when
+// uncategorized.
+func groupNames(groups []OrphanGroup) map[string]string {
+	names := make(map[string]string)
+	for _, group := range groups {
+		for _, link := range group.Links {
+			if group.IsUncategorized {
+				names[link.Path] = ""
+				continue
+			}
+			names[link.Path] = group.Category.Name
+		}
+	}
+	return names
+}
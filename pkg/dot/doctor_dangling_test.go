@@ -0,0 +1,61 @@
+package dot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaklabco/dot/internal/adapters"
+	"github.com/yaklabco/dot/internal/manifest"
+)
+
+func TestDoctorService_Fix_DanglingLinks(t *testing.T) {
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	store := manifest.NewFSManifestStore(fs)
+	manifestSvc := newManifestService(fs, adapters.NewNoopLogger(), store)
+
+	// vim's source was deleted from the package; .stray was never managed
+	require.NoError(t, fs.MkdirAll(ctx, "/packages/vim", 0755))
+	require.NoError(t, fs.WriteFile(ctx, "/packages/vim/dot-gvimrc", []byte("set go="), 0644))
+	require.NoError(t, fs.MkdirAll(ctx, "/home", 0755))
+	require.NoError(t, fs.Symlink(ctx, "/packages/vim/dot-vimrc", "/home/.vimrc"))
+	require.NoError(t, fs.Symlink(ctx, "/packages/vim/dot-gvimrc", "/home/.gvimrc"))
+	require.NoError(t, fs.Symlink(ctx, "/nowhere", "/home/.stray"))
+
+	targetPath := NewTargetPath("/home").Unwrap()
+	m := manifest.New()
+	m.AddPackage(manifest.PackageInfo{
+		Name:       "vim",
+		Links:      []string{".gvimrc", ".vimrc"},
+		LinkCount:  2,
+		PackageDir: "/packages/vim",
+	})
+	require.NoError(t, store.Save(ctx, targetPath, m))
+
+	svc := newDoctorService(fs, adapters.NewNoopLogger(), manifestSvc, "/packages", "/home")
+
+	result, err := svc.Fix(ctx, DefaultScanConfig(), FixOptions{AutoConfirm: true})
+	require.NoError(t, err)
+	assert.Empty(t, result.Errors)
+
+	t.Run("managed dangling link is removed", func(t *testing.T) {
+		assert.Equal(t, []string{".vimrc"}, result.Fixed)
+		_, err := fs.Lstat(ctx, "/home/.vimrc")
+		assert.Error(t, err)
+
+		saved := manifestSvc.Load(ctx, targetPath).Unwrap()
+		pkg, ok := saved.GetPackage("vim")
+		require.True(t, ok)
+		assert.Equal(t, []string{".gvimrc"}, pkg.Links)
+		assert.Equal(t, 1, pkg.LinkCount)
+	})
+
+	t.Run("unmanaged dangling link is only reported", func(t *testing.T) {
+		assert.Equal(t, []string{".stray"}, result.Reported)
+		target, err := fs.ReadLink(ctx, "/home/.stray")
+		require.NoError(t, err)
+		assert.Equal(t, "/nowhere", target)
+	})
+}
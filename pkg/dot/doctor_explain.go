@@ -0,0 +1,50 @@
+package dot
+
+import (
+	"embed"
+	"fmt"
+	"strings"
+)
+
+//go:embed explain/*.md
+var explanations embed.FS
+
+// IssueTypes returns every issue type doctor can report, in declaration order.
+func IssueTypes() []IssueType {
+	return []IssueType{
+		IssueBrokenLink,
+		IssueOrphanedLink,
+		IssueWrongTarget,
+		IssuePermission,
+		IssueCircular,
+		IssueManifestInconsistency,
+		IssueManifestCorrupt,
+		IssueLinkOwnershipConflict,
+		IssueForeignTarget,
+	}
+}
+
+// ParseIssueType returns the issue type with the given name, as produced by
+// IssueType.String. Dashes are accepted in place of underscores.
+func ParseIssueType(name string) (IssueType, error) {
+	normalized := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), "-", "_")
+
+	names := make([]string, 0, len(IssueTypes()))
+	for _, t := range IssueTypes() {
+		if t.String() == normalized {
+			return t, nil
+		}
+		names = append(names, t.String())
+	}
+	return 0, fmt.Errorf("unknown issue type %q (valid types: %s)", name, strings.Join(names, ", "))
+}
+
+// Explanation returns Markdown describing what the issue means, its common
+// causes and how to fix it. Unknown types return an empty string.
+func (t IssueType) Explanation() string {
+	data, err := explanations.ReadFile("explain/" + t.String() + ".md")
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
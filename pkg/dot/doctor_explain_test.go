@@ -0,0 +1,62 @@
+package dot_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yaklabco/dot/pkg/dot"
+)
+
+func TestIssueType_Explanation_CoversEveryType(t *testing.T) {
+	for _, issueType := range dot.IssueTypes() {
+		t.Run(issueType.String(), func(t *testing.T) {
+			text := issueType.Explanation()
+			require.NotEmpty(t, text)
+			assert.True(t, strings.HasPrefix(text, "# "+issueType.String()+"\n"))
+			assert.Contains(t, text, "## How to fix")
+		})
+	}
+}
+
+func TestIssueType_Explanation_Unknown(t *testing.T) {
+	assert.Empty(t, dot.IssueType(99).Explanation())
+}
+
+func TestParseIssueType(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    dot.IssueType
+		wantErr bool
+	}{
+		{"underscores", "broken_link", dot.IssueBrokenLink, false},
+		{"dashes", "link-ownership-conflict", dot.IssueLinkOwnershipConflict, false},
+		{"case and space", " Manifest_Corrupt ", dot.IssueManifestCorrupt, false},
+		{"unknown", "bogus", 0, true},
+		{"empty", "", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := dot.ParseIssueType(tt.input)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "broken_link")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseIssueType_RoundTrip(t *testing.T) {
+	for _, issueType := range dot.IssueTypes() {
+		got, err := dot.ParseIssueType(issueType.String())
+		require.NoError(t, err)
+		assert.Equal(t, issueType, got)
+	}
+}
@@ -3,9 +3,14 @@ package dot
 import (
 	"context"
 	"fmt"
+	"io/fs"
+	"maps"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 
+	"github.com/yaklabco/dot/internal/doctor"
 	"github.com/yaklabco/dot/internal/manifest"
 )
 
@@ -20,16 +25,36 @@ type FixOptions struct {
 type FixResult struct {
 	Fixed   []string
 	Skipped []string
-	Errors  map[string]error
+	// Reported lists issues that fix does not touch, such as broken links
+	// that are not under management.
+	Reported []string
+	Errors   map[string]error
 }
 
+// Fix groups for broken links.
+const (
+	categoryManagedBroken   = "Managed broken links"
+	categoryDanglingManaged = "Dangling managed links"
+	categoryUnmanagedBroken = "Unmanaged broken links"
+	categoryForeignTarget   = "Links pointing outside the package directory"
+	categoryWrongTarget     = "Managed links with wrong target"
+	categoryUnmanagedWrong  = "Unmanaged links with wrong target"
+)
+
 // issueGroup groups issues by category for batch processing.
 type issueGroup struct {
 	Category string
 	Issues   []Issue
 }
 
-// Fix repairs broken symlinks found during doctor scan.
+// Fix repairs issues found during doctor scan. Managed broken links are
+// recreated from their package source; managed links whose source no longer
+// exists are removed in a single plan and dropped from the manifest. Managed
+// links pointing outside the package directory are re-pointed at their
+// package source, or untracked when it does not exist. Managed links whose
+// target does not match their recorded source are replaced in a single plan.
+// Broken or wrong-target links that are not under management are only
+// reported.
 func (s *DoctorService) Fix(ctx context.Context, scanCfg ScanConfig, opts FixOptions) (FixResult, error) {
 	// Run doctor to get issues
 	report, err := s.DoctorWithScan(ctx, scanCfg)
@@ -54,11 +79,37 @@ func (s *DoctorService) Fix(ctx context.Context, scanCfg ScanConfig, opts FixOpt
 	m := manifestResult.Unwrap()
 
 	// Group issues for batch prompting
-	groupedIssues := s.groupIssuesForFix(report.Issues, &m)
+	groupedIssues := s.groupIssuesForFix(ctx, report.Issues, &m)
 
 	// Process each group
 	for _, group := range groupedIssues {
-		s.processFixGroup(ctx, &m, group, opts, &result)
+		switch group.Category {
+		case categoryUnmanagedBroken, categoryUnmanagedWrong:
+			for _, issue := range group.Issues {
+				result.Reported = append(result.Reported, issue.Path)
+			}
+		case categoryDanglingManaged:
+			selected := s.selectFixes(ctx, &m, group, opts, &result)
+			s.removeDanglingLinks(ctx, &m, selected, opts, &result)
+		case categoryWrongTarget:
+			selected := s.selectFixes(ctx, &m, group, opts, &result)
+			s.repairWrongTargets(ctx, &m, selected, opts, &result)
+		default:
+			for _, issue := range s.selectFixes(ctx, &m, group, opts, &result) {
+				if err := s.fixIssue(ctx, issue, &m, opts); err != nil {
+					result.Errors[issue.Path] = err
+				} else {
+					result.Fixed = append(result.Fixed, issue.Path)
+				}
+			}
+		}
+	}
+
+	// Ownership conflicts need an owner chosen rather than a yes/no decision
+	for _, issue := range report.Issues {
+		if issue.Type == IssueLinkOwnershipConflict {
+			s.fixOwnershipConflict(ctx, &m, issue, opts, &result)
+		}
 	}
 
 	// Save manifest if changes made
@@ -71,43 +122,128 @@ func (s *DoctorService) Fix(ctx context.Context, scanCfg ScanConfig, opts FixOpt
 	return result, nil
 }
 
-// groupIssuesForFix groups issues by type and managed status for batch processing.
-func (s *DoctorService) groupIssuesForFix(issues []Issue, m *manifest.Manifest) []issueGroup {
+// groupIssuesForFix groups broken links by how they can be fixed: managed
+// links whose source exists, managed links whose source is gone, managed
+// links pointing at the wrong source, and unmanaged links.
+func (s *DoctorService) groupIssuesForFix(ctx context.Context, issues []Issue, m *manifest.Manifest) []issueGroup {
 	groups := []issueGroup{}
 
-	// Group managed broken links
+	// Group managed broken links that can be recreated
 	managedBroken := []Issue{}
+	// Group managed broken links whose source no longer exists
+	danglingManaged := []Issue{}
 	// Group unmanaged broken links
 	unmanagedBroken := []Issue{}
+	// Group managed links pointing outside the package directory
+	foreignTargets := []Issue{}
+	// Group managed symlinks pointing at the wrong source
+	wrongTargets := []Issue{}
+	// Group unmanaged links pointing at the wrong source
+	unmanagedWrong := []Issue{}
 
 	for _, issue := range issues {
-		if issue.Type == IssueBrokenLink {
-			// Check if link is managed
-			if s.isManagedLink(issue.Path, m) {
-				managedBroken = append(managedBroken, issue)
-			} else {
-				unmanagedBroken = append(unmanagedBroken, issue)
+		if issue.Type == IssueForeignTarget {
+			if s.findPackageForLink(issue.Path, m) != "" {
+				foreignTargets = append(foreignTargets, issue)
+			}
+			continue
+		}
+		if issue.Type == IssueWrongTarget {
+			// A regular file in place of the link holds user data, so only
+			// symlinks are replaced. Links claimed by several packages are
+			// left to the ownership conflict fix.
+			switch {
+			case s.findPackageForLink(issue.Path, m) == "":
+				unmanagedWrong = append(unmanagedWrong, issue)
+			case linkClaimants(m, issue.Path) == 1 && s.isSymlink(ctx, issue.Path):
+				wrongTargets = append(wrongTargets, issue)
 			}
+			continue
+		}
+		// The orphan scan reports unmanaged links with a missing target as
+		// errors rather than as broken links
+		if issue.Type == IssueOrphanedLink && issue.Severity == SeverityError {
+			unmanagedBroken = append(unmanagedBroken, issue)
+			continue
+		}
+		if issue.Type != IssueBrokenLink {
+			continue
+		}
+		pkgName := s.findPackageForLink(issue.Path, m)
+		switch {
+		case pkgName == "":
+			unmanagedBroken = append(unmanagedBroken, issue)
+		case s.fs.Exists(ctx, s.constructSourcePath(pkgName, issue.Path)):
+			managedBroken = append(managedBroken, issue)
+		default:
+			danglingManaged = append(danglingManaged, issue)
 		}
 	}
 
 	if len(managedBroken) > 0 {
 		groups = append(groups, issueGroup{
-			Category: "Managed broken links",
+			Category: categoryManagedBroken,
 			Issues:   managedBroken,
 		})
 	}
 
+	if len(danglingManaged) > 0 {
+		groups = append(groups, issueGroup{
+			Category: categoryDanglingManaged,
+			Issues:   danglingManaged,
+		})
+	}
+
+	if len(foreignTargets) > 0 {
+		groups = append(groups, issueGroup{
+			Category: categoryForeignTarget,
+			Issues:   foreignTargets,
+		})
+	}
+
+	if len(wrongTargets) > 0 {
+		groups = append(groups, issueGroup{
+			Category: categoryWrongTarget,
+			Issues:   wrongTargets,
+		})
+	}
+
 	if len(unmanagedBroken) > 0 {
 		groups = append(groups, issueGroup{
-			Category: "Unmanaged broken links",
+			Category: categoryUnmanagedBroken,
 			Issues:   unmanagedBroken,
 		})
 	}
 
+	if len(unmanagedWrong) > 0 {
+		groups = append(groups, issueGroup{
+			Category: categoryUnmanagedWrong,
+			Issues:   unmanagedWrong,
+		})
+	}
+
 	return groups
 }
 
+// linkClaimants returns the number of packages whose manifest entry lists
+// linkPath.
+func linkClaimants(m *manifest.Manifest, linkPath string) int {
+	n := 0
+	for _, pkg := range m.Packages {
+		if slices.Contains(pkg.Links, linkPath) {
+			n++
+		}
+	}
+	return n
+}
+
+// isSymlink reports whether the link path relative to the target directory
+// is a symlink.
+func (s *DoctorService) isSymlink(ctx context.Context, linkPath string) bool {
+	info, err := s.fs.Lstat(ctx, filepath.Join(s.targetDir, linkPath))
+	return err == nil && info.Mode()&fs.ModeSymlink != 0
+}
+
 // isManagedLink checks if a link path is managed by any package.
 func (s *DoctorService) isManagedLink(linkPath string, m *manifest.Manifest) bool {
 	for _, pkg := range m.Packages {
@@ -120,54 +256,40 @@ func (s *DoctorService) isManagedLink(linkPath string, m *manifest.Manifest) boo
 	return false
 }
 
-// processFixGroup processes a group of issues with batched user prompts.
-func (s *DoctorService) processFixGroup(ctx context.Context, m *manifest.Manifest, group issueGroup, opts FixOptions, result *FixResult) {
+// selectFixes decides which issues in a group to fix, with batched user
+// prompts. Declined issues are recorded as skipped.
+func (s *DoctorService) selectFixes(ctx context.Context, m *manifest.Manifest, group issueGroup, opts FixOptions, result *FixResult) []Issue {
+	selected := make([]Issue, 0, len(group.Issues))
 	applyToAll := false
 	applyToAllDecision := false
 
 	for _, issue := range group.Issues {
-		// Skip if apply-to-all was set
-		if applyToAll {
-			if applyToAllDecision {
-				if err := s.fixIssue(ctx, issue, m, opts); err != nil {
-					result.Errors[issue.Path] = err
-				} else {
-					result.Fixed = append(result.Fixed, issue.Path)
-				}
-			} else {
-				result.Skipped = append(result.Skipped, issue.Path)
-			}
-			continue
-		}
-
 		// Auto-confirm if requested
 		if opts.AutoConfirm {
-			if err := s.fixIssue(ctx, issue, m, opts); err != nil {
-				result.Errors[issue.Path] = err
-			} else {
-				result.Fixed = append(result.Fixed, issue.Path)
-			}
+			selected = append(selected, issue)
 			continue
 		}
 
-		// Interactive prompt (default behavior when Interactive=true or both flags are false)
-		// Default to interactive mode to prevent silently dropping issues
-		decision, all := s.promptFixDecision(ctx, issue, group.Category, m)
-		if all {
-			applyToAll = true
-			applyToAllDecision = decision
+		decision := applyToAllDecision
+		if !applyToAll {
+			// Interactive prompt (default behavior when Interactive=true or both flags are false)
+			// Default to interactive mode to prevent silently dropping issues
+			var all bool
+			decision, all = s.promptFixDecision(ctx, issue, group.Category, m)
+			if all {
+				applyToAll = true
+				applyToAllDecision = decision
+			}
 		}
 
 		if decision {
-			if err := s.fixIssue(ctx, issue, m, opts); err != nil {
-				result.Errors[issue.Path] = err
-			} else {
-				result.Fixed = append(result.Fixed, issue.Path)
-			}
+			selected = append(selected, issue)
 		} else {
 			result.Skipped = append(result.Skipped, issue.Path)
 		}
 	}
+
+	return selected
 }
 
 // promptFixDecision prompts user for fix decision.
@@ -179,19 +301,23 @@ func (s *DoctorService) promptFixDecision(ctx context.Context, issue Issue, cate
 
 	// Explain what action will be taken
 	pkgName := s.findPackageForLink(issue.Path, m)
-	if pkgName != "" {
-		// Managed link - check if source exists
-		sourcePath := s.constructSourcePath(pkgName, issue.Path)
-		if s.fs.Exists(ctx, sourcePath) {
-			fmt.Printf("\n  Action: Recreate symlink from package source\n")
-			fmt.Printf("  Source: %s\n", sourcePath)
-		} else {
-			fmt.Printf("\n  Action: Remove broken link (source no longer exists)\n")
-			fmt.Printf("  Package: %s\n", pkgName)
-		}
-	} else {
-		// Unmanaged link
-		fmt.Printf("\n  Action: Remove broken symlink\n")
+	sourcePath := s.constructSourcePath(pkgName, issue.Path)
+	switch {
+	case issue.Type == IssueWrongTarget:
+		fmt.Printf("\n  Action: Replace symlink with one to the recorded source\n")
+		fmt.Printf("  Source: %s\n", s.recordedSource(m, pkgName, issue.Path))
+	case issue.Type == IssueForeignTarget && s.fs.Exists(ctx, sourcePath):
+		fmt.Printf("\n  Action: Re-point symlink at package source\n")
+		fmt.Printf("  Source: %s\n", sourcePath)
+	case issue.Type == IssueForeignTarget:
+		fmt.Printf("\n  Action: Stop tracking link (package source does not exist, link is left in place)\n")
+		fmt.Printf("  Package: %s\n", pkgName)
+	case s.fs.Exists(ctx, sourcePath):
+		fmt.Printf("\n  Action: Recreate symlink from package source\n")
+		fmt.Printf("  Source: %s\n", sourcePath)
+	default:
+		fmt.Printf("\n  Action: Remove broken link (source no longer exists)\n")
+		fmt.Printf("  Package: %s\n", pkgName)
 	}
 
 	fmt.Printf("\nOptions:\n")
@@ -232,14 +358,17 @@ func (s *DoctorService) fixIssue(ctx context.Context, issue Issue, m *manifest.M
 
 	switch issue.Type {
 	case IssueBrokenLink:
-		// Find which package this link belongs to
 		pkgName := s.findPackageForLink(issue.Path, m)
-		if pkgName != "" {
-			// Managed link - try to recreate from source
-			return s.fixBrokenManagedLink(ctx, pkgName, issue.Path, m)
+		if pkgName == "" {
+			return fmt.Errorf("link is not managed by any package: %s", issue.Path)
 		}
-		// Unmanaged link - just remove it
-		return s.fixBrokenUnmanagedLink(ctx, issue.Path)
+		return s.fixBrokenManagedLink(ctx, pkgName, issue.Path)
+	case IssueForeignTarget:
+		pkgName := s.findPackageForLink(issue.Path, m)
+		if pkgName == "" {
+			return fmt.Errorf("link is not managed by any package: %s", issue.Path)
+		}
+		return s.fixForeignTarget(ctx, m, pkgName, issue.Path)
 	default:
 		return fmt.Errorf("unsupported issue type for fix: %v", issue.Type)
 	}
@@ -257,78 +386,219 @@ func (s *DoctorService) findPackageForLink(linkPath string, m *manifest.Manifest
 	return ""
 }
 
-// fixBrokenManagedLink tries to recreate from package source, falls back to removal.
-func (s *DoctorService) fixBrokenManagedLink(ctx context.Context, pkgName, linkPath string, m *manifest.Manifest) error {
-	pkg, exists := m.GetPackage(pkgName)
-	if !exists {
-		return fmt.Errorf("package not found in manifest: %s", pkgName)
+// fixBrokenManagedLink recreates a managed link from its package source.
+func (s *DoctorService) fixBrokenManagedLink(ctx context.Context, pkgName, linkPath string) error {
+	sourcePath := s.constructSourcePath(pkgName, linkPath)
+	if !s.fs.Exists(ctx, sourcePath) {
+		return fmt.Errorf("package source no longer exists: %s", sourcePath)
+	}
+
+	fullPath := filepath.Join(s.targetDir, linkPath)
+
+	// Remove broken link if exists
+	_ = s.fs.Remove(ctx, fullPath)
+
+	// Ensure parent directory exists
+	parentDir := filepath.Dir(fullPath)
+	if err := s.fs.MkdirAll(ctx, parentDir, 0755); err != nil {
+		return fmt.Errorf("failed to create parent directory: %w", err)
 	}
 
-	// Construct source path
-	sourcePath := s.constructSourcePath(pkgName, linkPath)
+	// Create new symlink
+	if err := s.fs.Symlink(ctx, sourcePath, fullPath); err != nil {
+		return fmt.Errorf("failed to create symlink: %w", err)
+	}
 
-	// Check if source exists
-	if s.fs.Exists(ctx, sourcePath) {
-		// Source exists - recreate symlink
-		fullPath := filepath.Join(s.targetDir, linkPath)
+	s.logger.Info(ctx, "recreated_symlink", "path", linkPath, "source", sourcePath)
+	return nil
+}
 
-		// Remove broken link if exists
-		_ = s.fs.Remove(ctx, fullPath)
+// fixForeignTarget re-points a managed link that points outside the package
+// directory at its package source. When the source does not exist the link
+// is dropped from the manifest and left on disk, since dot did not create
+// what it points at.
+func (s *DoctorService) fixForeignTarget(ctx context.Context, m *manifest.Manifest, pkgName, linkPath string) error {
+	if s.fs.Exists(ctx, s.constructSourcePath(pkgName, linkPath)) {
+		return s.fixBrokenManagedLink(ctx, pkgName, linkPath)
+	}
+	dropManifestLink(m, pkgName, linkPath)
+	s.logger.Info(ctx, "untracked_foreign_link", "path", linkPath, "package", pkgName)
+	return nil
+}
+
+// removeDanglingLinks deletes managed links whose package source no longer
+// exists. The deletions run as one plan so a failure rolls back the batch;
+// the links are dropped from the manifest only once the plan succeeds.
+func (s *DoctorService) removeDanglingLinks(ctx context.Context, m *manifest.Manifest, issues []Issue, opts FixOptions, result *FixResult) {
+	if len(issues) == 0 {
+		return
+	}
 
-		// Ensure parent directory exists
-		parentDir := filepath.Dir(fullPath)
-		if err := s.fs.MkdirAll(ctx, parentDir, 0755); err != nil {
-			return fmt.Errorf("failed to create parent directory: %w", err)
+	operations := make([]Operation, 0, len(issues))
+	planned := make([]Issue, 0, len(issues))
+	for _, issue := range issues {
+		targetPathResult := NewTargetPath(filepath.Join(s.targetDir, issue.Path))
+		if !targetPathResult.IsOk() {
+			result.Errors[issue.Path] = targetPathResult.UnwrapErr()
+			continue
 		}
+		id := OperationID(fmt.Sprintf("doctor-fix-link-%s", issue.Path))
+		operations = append(operations, NewLinkDelete(id, targetPathResult.Unwrap()))
+		planned = append(planned, issue)
+	}
+	if len(operations) == 0 {
+		return
+	}
 
-		// Create new symlink
-		if err := s.fs.Symlink(ctx, sourcePath, fullPath); err != nil {
-			return fmt.Errorf("failed to create symlink: %w", err)
+	if opts.DryRun {
+		s.logger.Info(ctx, "dry_run_plan", "operations", len(operations))
+		for _, issue := range planned {
+			result.Fixed = append(result.Fixed, issue.Path)
 		}
+		return
+	}
 
-		s.logger.Info(ctx, "recreated_symlink", "path", linkPath, "source", sourcePath)
-		return nil
+	if execErr := s.executeFixPlan(ctx, operations); execErr != nil {
+		for _, issue := range planned {
+			result.Errors[issue.Path] = execErr
+		}
+		return
+	}
+
+	for _, issue := range planned {
+		pkgName := s.findPackageForLink(issue.Path, m)
+		dropManifestLink(m, pkgName, issue.Path)
+		s.logger.Info(ctx, "removed_broken_link_no_source", "path", issue.Path, "package", pkgName)
+		result.Fixed = append(result.Fixed, issue.Path)
 	}
+}
 
-	// Source doesn't exist - remove from manifest
-	links := pkg.Links
-	for i, link := range links {
-		if link == linkPath {
-			links = append(links[:i], links[i+1:]...)
-			break
+// repairWrongTargets replaces managed symlinks that point somewhere other
+// than their recorded source. Each link is deleted and recreated at its
+// source within one plan, so a failure part way restores every original
+// link.
+func (s *DoctorService) repairWrongTargets(ctx context.Context, m *manifest.Manifest, issues []Issue, opts FixOptions, result *FixResult) {
+	if len(issues) == 0 {
+		return
+	}
+
+	operations := make([]Operation, 0, 2*len(issues))
+	planned := make([]Issue, 0, len(issues))
+	for _, issue := range issues {
+		pkgName := s.findPackageForLink(issue.Path, m)
+		source := s.recordedSource(m, pkgName, issue.Path)
+		if !s.fs.Exists(ctx, source) {
+			result.Errors[issue.Path] = fmt.Errorf("package source no longer exists: %s", source)
+			continue
 		}
+
+		linkPath := filepath.Join(s.targetDir, issue.Path)
+		current, err := s.fs.ReadLink(ctx, linkPath)
+		if err != nil {
+			result.Errors[issue.Path] = fmt.Errorf("failed to read link: %w", err)
+			continue
+		}
+		targetPathResult := NewTargetPath(linkPath)
+		sourcePathResult := NewFilePath(source)
+		if !targetPathResult.IsOk() || !sourcePathResult.IsOk() {
+			result.Errors[issue.Path] = fmt.Errorf("invalid path for %s", issue.Path)
+			continue
+		}
+
+		// Restoring the original link lets a failed repair roll back cleanly
+		unlink := NewLinkDelete(OperationID("doctor-fix-unlink-"+issue.Path), targetPathResult.Unwrap())
+		unlink.Original = current
+		relink := NewLinkCreate(OperationID("doctor-fix-link-"+issue.Path), sourcePathResult.Unwrap(), targetPathResult.Unwrap())
+		operations = append(operations, unlink, relink)
+		planned = append(planned, issue)
+	}
+	if len(operations) == 0 {
+		return
 	}
-	pkg.Links = links
-	pkg.LinkCount = len(links)
 
-	if len(links) == 0 {
-		// No links left - remove package
-		m.RemovePackage(pkgName)
-		s.logger.Info(ctx, "removed_empty_package", "package", pkgName)
-	} else {
-		m.AddPackage(pkg)
+	if opts.DryRun {
+		s.logger.Info(ctx, "dry_run_plan", "operations", len(operations))
+		for _, issue := range planned {
+			result.Fixed = append(result.Fixed, issue.Path)
+		}
+		return
 	}
 
-	// Remove the broken symlink from filesystem
-	fullPath := filepath.Join(s.targetDir, linkPath)
-	if err := s.fs.Remove(ctx, fullPath); err != nil {
-		s.logger.Warn(ctx, "failed_to_remove_broken_link", "path", fullPath, "error", err)
+	if execErr := s.executeFixPlan(ctx, operations); execErr != nil {
+		for _, issue := range planned {
+			result.Errors[issue.Path] = execErr
+		}
+		return
 	}
 
-	s.logger.Info(ctx, "removed_broken_link_no_source", "path", linkPath, "package", pkgName)
-	return nil
+	for _, issue := range planned {
+		s.logger.Info(ctx, "repaired_wrong_target", "path", issue.Path, "package", s.findPackageForLink(issue.Path, m))
+		result.Fixed = append(result.Fixed, issue.Path)
+	}
 }
 
-// fixBrokenUnmanagedLink removes an unmanaged broken symlink.
-func (s *DoctorService) fixBrokenUnmanagedLink(ctx context.Context, linkPath string) error {
-	fullPath := filepath.Join(s.targetDir, linkPath)
+// executeFixPlan runs operations as a single plan, returning an error when
+// it fails or any operation fails.
+func (s *DoctorService) executeFixPlan(ctx context.Context, operations []Operation) error {
+	plan := Plan{
+		Operations: operations,
+		Metadata: PlanMetadata{
+			OperationCount: len(operations),
+		},
+	}
 
-	if err := s.fs.Remove(ctx, fullPath); err != nil {
-		return fmt.Errorf("failed to remove broken link: %w", err)
+	execResult := s.executor.Execute(ctx, plan)
+	var execErr error
+	switch {
+	case !execResult.IsOk():
+		execErr = execResult.UnwrapErr()
+	case !execResult.Unwrap().Success():
+		execErr = ErrMultiple{Errors: execResult.Unwrap().Errors}
 	}
+	if execErr != nil {
+		s.logger.Error(ctx, "execution_failed", "error", execErr)
+	}
+	return execErr
+}
 
-	s.logger.Info(ctx, "removed_unmanaged_broken_link", "path", linkPath)
-	return nil
+// recordedSource returns the source the manifest recorded for a link,
+// falling back to the path derived from the package name for links
+// recorded before sources were tracked.
+func (s *DoctorService) recordedSource(m *manifest.Manifest, pkgName, linkPath string) string {
+	if pkg, ok := m.GetPackage(pkgName); ok {
+		if source := pkg.Sources[linkPath]; source != "" {
+			return source
+		}
+	}
+	return s.constructSourcePath(pkgName, linkPath)
+}
+
+// dropManifestLink removes link from the named package. A package left
+// without links is removed from the manifest.
+func dropManifestLink(m *manifest.Manifest, pkgName, link string) {
+	pkg, ok := m.GetPackage(pkgName)
+	if !ok {
+		return
+	}
+
+	links := make([]string, 0, len(pkg.Links))
+	for _, l := range pkg.Links {
+		if l != link {
+			links = append(links, l)
+		}
+	}
+	if len(links) == 0 {
+		m.RemovePackage(pkgName)
+		return
+	}
+	pkg.Links = links
+	pkg.LinkCount = len(links)
+	pkg.Junctions = slices.DeleteFunc(pkg.Junctions, func(j string) bool { return j == link })
+	if _, ok := pkg.Sources[link]; ok {
+		pkg.Sources = maps.Clone(pkg.Sources)
+		delete(pkg.Sources, link)
+	}
+	m.AddPackage(pkg)
 }
 
 // constructSourcePath builds the expected source path for a link.
@@ -348,3 +618,103 @@ func (s *DoctorService) constructSourcePath(pkgName, linkPath string) string {
 
 	return filepath.Join(s.packageDir, pkgName, targetName)
 }
+
+// fixOwnershipConflict resolves a link claimed by several packages by
+// choosing one owner and dropping the link from the others' manifest entries.
+// With AutoConfirm the package the link currently points into is kept;
+// otherwise the user is prompted, defaulting to that package.
+func (s *DoctorService) fixOwnershipConflict(ctx context.Context, m *manifest.Manifest, issue Issue, opts FixOptions, result *FixResult) {
+	packageDirs := make(map[string]string, len(issue.Packages))
+	for _, name := range issue.Packages {
+		if pkg, ok := m.GetPackage(name); ok {
+			packageDirs[name] = pkg.PackageDir
+		}
+	}
+	current := doctor.LinkOwner(ctx, &doctorFSAdapter{fs: s.fs}, s.targetDir, issue.Path, packageDirs)
+
+	var owner string
+	if opts.AutoConfirm {
+		owner = current
+	} else {
+		owner = s.promptOwnerChoice(issue, current)
+	}
+	if owner == "" {
+		result.Skipped = append(result.Skipped, issue.Path)
+		return
+	}
+
+	if opts.DryRun {
+		s.logger.Info(ctx, "dry_run_fix", "path", issue.Path, "type", issue.Type, "owner", owner)
+		result.Fixed = append(result.Fixed, issue.Path)
+		return
+	}
+
+	assignLinkOwner(m, issue.Path, owner, issue.Packages)
+	s.logger.Info(ctx, "resolved_link_ownership", "path", issue.Path, "owner", owner)
+	result.Fixed = append(result.Fixed, issue.Path)
+}
+
+// promptOwnerChoice asks the user which package should own a link.
+// Returns "" when the user skips the conflict.
+func (s *DoctorService) promptOwnerChoice(issue Issue, current string) string {
+	fmt.Printf("\nChoose owner for %s\n", issue.Path)
+	fmt.Printf("  %s\n", issue.Message)
+	fmt.Printf("\nOptions:\n")
+	for i, name := range issue.Packages {
+		marker := ""
+		if name == current {
+			marker = " (currently linked)"
+		}
+		fmt.Printf("  %d - %s%s\n", i+1, name, marker)
+	}
+	fmt.Printf("  s - Skip this conflict\n")
+	if current != "" {
+		fmt.Printf("\nChoice [1-%d/s] (default %s): ", len(issue.Packages), current)
+	} else {
+		fmt.Printf("\nChoice [1-%d/s]: ", len(issue.Packages))
+	}
+
+	var response string
+	if _, err := fmt.Scanln(&response); err != nil && response == "" {
+		// EOF or empty line: take the default
+		return current
+	}
+	return parseOwnerChoice(response, issue.Packages, current)
+}
+
+// parseOwnerChoice interprets a response to the owner prompt. Accepts a
+// 1-based index or a package name; an empty response selects defaultOwner.
+// Returns "" to skip.
+func parseOwnerChoice(response string, candidates []string, defaultOwner string) string {
+	response = strings.TrimSpace(response)
+	switch strings.ToLower(response) {
+	case "":
+		return defaultOwner
+	case "s", "skip", "n", "no":
+		return ""
+	}
+
+	if index, err := strconv.Atoi(response); err == nil {
+		if index >= 1 && index <= len(candidates) {
+			return candidates[index-1]
+		}
+		return ""
+	}
+
+	for _, name := range candidates {
+		if name == response {
+			return name
+		}
+	}
+	return ""
+}
+
+// assignLinkOwner removes link from every claimant except owner. Packages
+// left without links are removed from the manifest.
+func assignLinkOwner(m *manifest.Manifest, link, owner string, claimants []string) {
+	for _, name := range claimants {
+		if name != owner {
+			dropManifestLink(m, name, link)
+		}
+	}
+}
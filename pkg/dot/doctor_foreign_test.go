@@ -0,0 +1,115 @@
+package dot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaklabco/dot/internal/adapters"
+	"github.com/yaklabco/dot/internal/manifest"
+)
+
+// setupForeignTargets records vim's .vimrc and .gvimrc in the manifest with
+// both links pointing outside the package directory. Only .vimrc still has
+// a package source.
+func setupForeignTargets(t *testing.T) (*adapters.MemFS, *ManifestService) {
+	t.Helper()
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	store := manifest.NewFSManifestStore(fs)
+	manifestSvc := newManifestService(fs, adapters.NewNoopLogger(), store)
+
+	require.NoError(t, fs.MkdirAll(ctx, "/packages/vim", 0755))
+	require.NoError(t, fs.WriteFile(ctx, "/packages/vim/dot-vimrc", []byte("set number"), 0644))
+	require.NoError(t, fs.MkdirAll(ctx, "/elsewhere", 0755))
+	require.NoError(t, fs.WriteFile(ctx, "/elsewhere/vimrc", []byte("set nonumber"), 0644))
+	require.NoError(t, fs.WriteFile(ctx, "/elsewhere/gvimrc", []byte("set go="), 0644))
+	require.NoError(t, fs.MkdirAll(ctx, "/home", 0755))
+	require.NoError(t, fs.Symlink(ctx, "/elsewhere/vimrc", "/home/.vimrc"))
+	require.NoError(t, fs.Symlink(ctx, "../elsewhere/gvimrc", "/home/.gvimrc"))
+
+	m := manifest.New()
+	m.AddPackage(manifest.PackageInfo{
+		Name:       "vim",
+		Links:      []string{".gvimrc", ".vimrc"},
+		LinkCount:  2,
+		PackageDir: "/packages/vim",
+	})
+	require.NoError(t, store.Save(ctx, NewTargetPath("/home").Unwrap(), m))
+	return fs, manifestSvc
+}
+
+func TestDoctorService_ForeignTarget_Reported(t *testing.T) {
+	ctx := context.Background()
+	fs, manifestSvc := setupForeignTargets(t)
+	svc := newDoctorService(fs, adapters.NewNoopLogger(), manifestSvc, "/packages", "/home")
+
+	report, err := svc.Doctor(ctx)
+	require.NoError(t, err)
+
+	foreign := map[string]string{}
+	for _, issue := range report.Issues {
+		if issue.Type == IssueForeignTarget {
+			foreign[issue.Path] = issue.Message
+		}
+	}
+	assert.Equal(t, map[string]string{
+		".vimrc":  "Link points outside the package directory: /elsewhere/vimrc",
+		".gvimrc": "Link points outside the package directory: ../elsewhere/gvimrc",
+	}, foreign, "the actual target is shown")
+}
+
+func TestDoctorService_Fix_ForeignTargets(t *testing.T) {
+	ctx := context.Background()
+	fs, manifestSvc := setupForeignTargets(t)
+	svc := newDoctorService(fs, adapters.NewNoopLogger(), manifestSvc, "/packages", "/home")
+
+	result, err := svc.Fix(ctx, DefaultScanConfig(), FixOptions{AutoConfirm: true})
+	require.NoError(t, err)
+	assert.Empty(t, result.Errors)
+	assert.ElementsMatch(t, []string{".vimrc", ".gvimrc"}, result.Fixed)
+
+	t.Run("link with a package source is re-pointed", func(t *testing.T) {
+		target, err := fs.ReadLink(ctx, "/home/.vimrc")
+		require.NoError(t, err)
+		assert.Equal(t, "/packages/vim/dot-vimrc", target)
+	})
+
+	t.Run("link without a package source is untracked and left in place", func(t *testing.T) {
+		target, err := fs.ReadLink(ctx, "/home/.gvimrc")
+		require.NoError(t, err)
+		assert.Equal(t, "../elsewhere/gvimrc", target)
+
+		saved := manifestSvc.Load(ctx, NewTargetPath("/home").Unwrap()).Unwrap()
+		pkg, ok := saved.GetPackage("vim")
+		require.True(t, ok)
+		assert.Equal(t, []string{".vimrc"}, pkg.Links)
+	})
+
+	t.Run("doctor is clean afterwards", func(t *testing.T) {
+		report, err := svc.Doctor(ctx)
+		require.NoError(t, err)
+		for _, issue := range report.Issues {
+			assert.NotEqual(t, IssueForeignTarget, issue.Type, issue.Path)
+		}
+	})
+}
+
+func TestDoctorService_Fix_ForeignTargets_DryRun(t *testing.T) {
+	ctx := context.Background()
+	fs, manifestSvc := setupForeignTargets(t)
+	svc := newDoctorService(fs, adapters.NewNoopLogger(), manifestSvc, "/packages", "/home")
+
+	result, err := svc.Fix(ctx, DefaultScanConfig(), FixOptions{AutoConfirm: true, DryRun: true})
+	require.NoError(t, err)
+	assert.Len(t, result.Fixed, 2)
+
+	target, err := fs.ReadLink(ctx, "/home/.vimrc")
+	require.NoError(t, err)
+	assert.Equal(t, "/elsewhere/vimrc", target, "nothing is changed")
+	saved := manifestSvc.Load(ctx, NewTargetPath("/home").Unwrap()).Unwrap()
+	pkg, ok := saved.GetPackage("vim")
+	require.True(t, ok)
+	assert.Len(t, pkg.Links, 2)
+}
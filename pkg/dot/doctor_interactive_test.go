@@ -194,34 +194,17 @@ func TestDoctorService_findPackageForLink(t *testing.T) {
 	assert.Empty(t, svc.findPackageForLink(".orphan", &m))
 }
 
-// TestDoctorService_fixBrokenUnmanagedLink tests removing unmanaged broken links
-func TestDoctorService_fixBrokenUnmanagedLink(t *testing.T) {
-	ctx := context.Background()
-	fs := adapters.NewMemFS()
-	store := manifest.NewFSManifestStore(fs)
-
-	targetDir := "/home"
-	require.NoError(t, fs.MkdirAll(ctx, targetDir, 0755))
-
-	// Create broken unmanaged symlink
-	brokenLink := filepath.Join(targetDir, ".orphan")
-	require.NoError(t, fs.Symlink(ctx, "/nowhere", brokenLink))
-
-	svc := newDoctorService(fs, adapters.NewNoopLogger(), newManifestService(fs, adapters.NewNoopLogger(), store), "/packages", targetDir)
-
-	err := svc.fixBrokenUnmanagedLink(ctx, ".orphan")
-	require.NoError(t, err)
-
-	// Verify link was removed
-	assert.False(t, fs.Exists(ctx, brokenLink))
-}
-
 // TestDoctorService_groupIssuesForFix tests grouping issues
 func TestDoctorService_groupIssuesForFix(t *testing.T) {
+	ctx := context.Background()
 	fs := adapters.NewMemFS()
 	store := manifest.NewFSManifestStore(fs)
 	svc := newDoctorService(fs, adapters.NewNoopLogger(), newManifestService(fs, adapters.NewNoopLogger(), store), "/packages", "/home")
 
+	// Only .bashrc still has a package source
+	require.NoError(t, fs.MkdirAll(ctx, "/packages/config", 0755))
+	require.NoError(t, fs.WriteFile(ctx, "/packages/config/dot-bashrc", []byte("content"), 0644))
+
 	m := manifest.New()
 	m.AddPackage(manifest.PackageInfo{
 		Name:  "config",
@@ -229,28 +212,28 @@ func TestDoctorService_groupIssuesForFix(t *testing.T) {
 	})
 
 	issues := []Issue{
-		{Type: IssueBrokenLink, Path: ".bashrc"}, // managed
-		{Type: IssueBrokenLink, Path: ".orphan"}, // unmanaged
-		{Type: IssueBrokenLink, Path: ".vimrc"},  // managed
-		{Type: IssueWrongTarget, Path: ".other"}, // not broken link
+		{Type: IssueBrokenLink, Path: ".bashrc"},                               // managed, source exists
+		{Type: IssueBrokenLink, Path: ".orphan"},                               // unmanaged
+		{Type: IssueBrokenLink, Path: ".vimrc"},                                // managed, source missing
+		{Type: IssueWrongTarget, Path: ".other"},                               // unmanaged, wrong target
+		{Type: IssueOrphanedLink, Path: ".stray", Severity: SeverityError},     // unmanaged, target missing
+		{Type: IssueOrphanedLink, Path: ".adoptme", Severity: SeverityWarning}, // unmanaged, target exists
 	}
 
-	groups := svc.groupIssuesForFix(issues, &m)
-
-	// Should have 2 groups: managed and unmanaged broken links
-	assert.Len(t, groups, 2)
+	groups := svc.groupIssuesForFix(ctx, issues, &m)
+	require.Len(t, groups, 4)
 
-	var managedCount, unmanagedCount int
+	counts := make(map[string][]string)
 	for _, group := range groups {
-		if group.Category == "Managed broken links" {
-			managedCount = len(group.Issues)
-		} else if group.Category == "Unmanaged broken links" {
-			unmanagedCount = len(group.Issues)
+		for _, issue := range group.Issues {
+			counts[group.Category] = append(counts[group.Category], issue.Path)
 		}
 	}
 
-	assert.Equal(t, 2, managedCount, "should have 2 managed broken links")
-	assert.Equal(t, 1, unmanagedCount, "should have 1 unmanaged broken link")
+	assert.Equal(t, []string{".bashrc"}, counts[categoryManagedBroken])
+	assert.Equal(t, []string{".vimrc"}, counts[categoryDanglingManaged])
+	assert.Equal(t, []string{".orphan", ".stray"}, counts[categoryUnmanagedBroken])
+	assert.Equal(t, []string{".other"}, counts[categoryUnmanagedWrong])
 }
 
 // TestDoctorService_fixBrokenManagedLink tests fixing managed broken links
@@ -290,7 +273,7 @@ func TestDoctorService_fixBrokenManagedLink(t *testing.T) {
 		svc := newDoctorService(fs, adapters.NewNoopLogger(), manifestSvc, packageDir, targetDir)
 
 		// Fix the link
-		err := svc.fixBrokenManagedLink(ctx, pkgName, ".bashrc", &m)
+		err := svc.fixBrokenManagedLink(ctx, pkgName, ".bashrc")
 		require.NoError(t, err)
 
 		// Verify link was recreated correctly
@@ -299,81 +282,103 @@ func TestDoctorService_fixBrokenManagedLink(t *testing.T) {
 		assert.Equal(t, sourceFile, target)
 	})
 
-	t.Run("removes link and updates manifest when source missing", func(t *testing.T) {
+	t.Run("fails when source missing", func(t *testing.T) {
 		fs := adapters.NewMemFS()
 		store := manifest.NewFSManifestStore(fs)
 		manifestSvc := newManifestService(fs, adapters.NewNoopLogger(), store)
 
-		packageDir := "/packages"
-		targetDir := "/home"
-		pkgName := "myconfig"
+		require.NoError(t, fs.MkdirAll(ctx, "/packages/myconfig", 0755))
+		require.NoError(t, fs.MkdirAll(ctx, "/home", 0755))
+		require.NoError(t, fs.Symlink(ctx, "/wrong/path", "/home/.bashrc"))
 
-		// Create directories but no source file
-		require.NoError(t, fs.MkdirAll(ctx, filepath.Join(packageDir, pkgName), 0755))
-		require.NoError(t, fs.MkdirAll(ctx, targetDir, 0755))
+		svc := newDoctorService(fs, adapters.NewNoopLogger(), manifestSvc, "/packages", "/home")
 
-		// Create manifest with two links
-		targetPathResult := NewTargetPath(targetDir)
-		require.True(t, targetPathResult.IsOk())
+		err := svc.fixBrokenManagedLink(ctx, "myconfig", ".bashrc")
+		require.Error(t, err)
+
+		// The link is left for removeDanglingLinks to handle
+		_, err = fs.Lstat(ctx, "/home/.bashrc")
+		require.NoError(t, err)
+	})
+}
+
+// TestDoctorService_removeDanglingLinks tests removing managed links whose source is gone
+func TestDoctorService_removeDanglingLinks(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("removes link and updates manifest", func(t *testing.T) {
+		fs := adapters.NewMemFS()
+		store := manifest.NewFSManifestStore(fs)
+		manifestSvc := newManifestService(fs, adapters.NewNoopLogger(), store)
+		require.NoError(t, fs.MkdirAll(ctx, "/home", 0755))
+		require.NoError(t, fs.Symlink(ctx, "/wrong/path", "/home/.bashrc"))
 
 		m := manifest.New()
 		m.AddPackage(manifest.PackageInfo{
-			Name:  pkgName,
-			Links: []string{".bashrc", ".vimrc"},
+			Name:      "myconfig",
+			Links:     []string{".bashrc", ".vimrc"},
+			LinkCount: 2,
 		})
 
-		// Create broken symlink
-		brokenLink := filepath.Join(targetDir, ".bashrc")
-		require.NoError(t, fs.Symlink(ctx, "/wrong/path", brokenLink))
-
-		svc := newDoctorService(fs, adapters.NewNoopLogger(), manifestSvc, packageDir, targetDir)
+		svc := newDoctorService(fs, adapters.NewNoopLogger(), manifestSvc, "/packages", "/home")
+		result := FixResult{Errors: make(map[string]error)}
 
-		// Fix should remove link and update manifest
-		err := svc.fixBrokenManagedLink(ctx, pkgName, ".bashrc", &m)
-		require.NoError(t, err)
+		svc.removeDanglingLinks(ctx, &m, []Issue{{Type: IssueBrokenLink, Path: ".bashrc"}}, FixOptions{}, &result)
 
-		// Verify link was removed
-		assert.False(t, fs.Exists(ctx, brokenLink))
+		assert.Empty(t, result.Errors)
+		assert.Equal(t, []string{".bashrc"}, result.Fixed)
+		assert.False(t, fs.Exists(ctx, "/home/.bashrc"))
 
-		// Verify manifest was updated (link removed)
-		pkg, exists := m.GetPackage(pkgName)
+		pkg, exists := m.GetPackage("myconfig")
 		require.True(t, exists, "package should still exist (has other links)")
-		assert.Len(t, pkg.Links, 1, "should have only 1 link left")
-		assert.Equal(t, ".vimrc", pkg.Links[0])
+		assert.Equal(t, []string{".vimrc"}, pkg.Links)
+		assert.Equal(t, 1, pkg.LinkCount)
 	})
 
 	t.Run("removes package when last link removed", func(t *testing.T) {
 		fs := adapters.NewMemFS()
 		store := manifest.NewFSManifestStore(fs)
 		manifestSvc := newManifestService(fs, adapters.NewNoopLogger(), store)
+		require.NoError(t, fs.MkdirAll(ctx, "/home", 0755))
+		require.NoError(t, fs.Symlink(ctx, "/wrong/path", "/home/.bashrc"))
 
-		packageDir := "/packages"
-		targetDir := "/home"
-		pkgName := "myconfig"
+		m := manifest.New()
+		m.AddPackage(manifest.PackageInfo{
+			Name:  "myconfig",
+			Links: []string{".bashrc"},
+		})
 
-		// Create directories but no source file
-		require.NoError(t, fs.MkdirAll(ctx, filepath.Join(packageDir, pkgName), 0755))
-		require.NoError(t, fs.MkdirAll(ctx, targetDir, 0755))
+		svc := newDoctorService(fs, adapters.NewNoopLogger(), manifestSvc, "/packages", "/home")
+		result := FixResult{Errors: make(map[string]error)}
+
+		svc.removeDanglingLinks(ctx, &m, []Issue{{Type: IssueBrokenLink, Path: ".bashrc"}}, FixOptions{}, &result)
+
+		_, exists := m.GetPackage("myconfig")
+		assert.False(t, exists, "package should be removed when no links remain")
+	})
+
+	t.Run("dry run leaves link and manifest", func(t *testing.T) {
+		fs := adapters.NewMemFS()
+		store := manifest.NewFSManifestStore(fs)
+		manifestSvc := newManifestService(fs, adapters.NewNoopLogger(), store)
+		require.NoError(t, fs.MkdirAll(ctx, "/home", 0755))
+		require.NoError(t, fs.Symlink(ctx, "/wrong/path", "/home/.bashrc"))
 
-		// Create manifest with single link
 		m := manifest.New()
 		m.AddPackage(manifest.PackageInfo{
-			Name:  pkgName,
+			Name:  "myconfig",
 			Links: []string{".bashrc"},
 		})
 
-		// Create broken symlink
-		brokenLink := filepath.Join(targetDir, ".bashrc")
-		require.NoError(t, fs.Symlink(ctx, "/wrong/path", brokenLink))
+		svc := newDoctorService(fs, adapters.NewNoopLogger(), manifestSvc, "/packages", "/home")
+		result := FixResult{Errors: make(map[string]error)}
 
-		svc := newDoctorService(fs, adapters.NewNoopLogger(), manifestSvc, packageDir, targetDir)
+		svc.removeDanglingLinks(ctx, &m, []Issue{{Type: IssueBrokenLink, Path: ".bashrc"}}, FixOptions{DryRun: true}, &result)
 
-		// Fix should remove link and package
-		err := svc.fixBrokenManagedLink(ctx, pkgName, ".bashrc", &m)
+		assert.Equal(t, []string{".bashrc"}, result.Fixed)
+		_, err := fs.Lstat(ctx, "/home/.bashrc")
 		require.NoError(t, err)
-
-		// Verify package was removed from manifest
-		_, exists := m.GetPackage(pkgName)
-		assert.False(t, exists, "package should be removed when no links remain")
+		_, exists := m.GetPackage("myconfig")
+		assert.True(t, exists)
 	})
 }
@@ -0,0 +1,46 @@
+package dot
+
+import (
+	"context"
+	"sort"
+)
+
+// OrphanInfo describes an orphaned symlink: a link in the target directory
+// that dot does not manage.
+type OrphanInfo struct {
+	// Path is the link path relative to the target directory.
+	Path string `json:"path" yaml:"path"`
+	// Target is the link destination, or empty if it cannot be read.
+	Target string `json:"target" yaml:"target"`
+	// Category is the detected orphan category, empty when uncategorized.
+	Category string `json:"category,omitempty" yaml:"category,omitempty"`
+	// Confidence is the category's confidence level.
+	Confidence string `json:"confidence,omitempty" yaml:"confidence,omitempty"`
+}
+
+// ListOrphans scans for orphaned links and returns them sorted by path,
+// each with its target and detected category. Ignored links are excluded.
+func (s *DoctorService) ListOrphans(ctx context.Context, scanCfg ScanConfig) ([]OrphanInfo, error) {
+	report, err := s.DoctorWithScan(ctx, scanCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	issues := filterIssuesByType(report.Issues, IssueOrphanedLink)
+	targets := s.readOrphanTargets(ctx, issues)
+	orphans := make([]OrphanInfo, 0, len(issues))
+	for _, issue := range issues {
+		link := targets[issue.Path]
+		info := OrphanInfo{Path: issue.Path, Target: link.Target}
+		if link.Category != nil {
+			info.Category = link.Category.Name
+			info.Confidence = link.Category.Confidence
+		}
+		orphans = append(orphans, info)
+	}
+
+	sort.Slice(orphans, func(i, j int) bool {
+		return orphans[i].Path < orphans[j].Path
+	})
+	return orphans, nil
+}
@@ -0,0 +1,38 @@
+package dot_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaklabco/dot/pkg/dot"
+)
+
+func TestClient_DoctorListOrphans(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newTriageRulesClient(t)
+
+	orphans, err := client.DoctorListOrphans(ctx, dot.DeepScanConfig(5))
+	require.NoError(t, err)
+
+	assert.Equal(t, []dot.OrphanInfo{
+		{Path: ".other", Target: "/data/other"},
+		{Path: ".tsc", Target: "/data/.npm/bin/tsc", Category: "npm", Confidence: "high"},
+		{Path: ".work-toolrc", Target: "/opt/work/toolrc"},
+	}, orphans)
+}
+
+func TestClient_DoctorListOrphans_ExcludesIgnored(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newTriageRulesClient(t)
+
+	require.NoError(t, client.DoctorIgnoreLink(ctx, ".tsc", "test"))
+	require.NoError(t, client.DoctorIgnorePattern(ctx, ".work-*"))
+
+	orphans, err := client.DoctorListOrphans(ctx, dot.DeepScanConfig(5))
+	require.NoError(t, err)
+
+	require.Len(t, orphans, 1)
+	assert.Equal(t, ".other", orphans[0].Path)
+}
@@ -0,0 +1,156 @@
+package dot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaklabco/dot/internal/adapters"
+	"github.com/yaklabco/dot/internal/manifest"
+)
+
+// newOwnershipConflictService sets up two packages that both claim
+// .gitconfig, with the link on disk pointing into the "git" package.
+func newOwnershipConflictService(t *testing.T) (*DoctorService, *ManifestService, TargetPath) {
+	t.Helper()
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	require.NoError(t, fs.MkdirAll(ctx, "/packages/git", 0755))
+	require.NoError(t, fs.MkdirAll(ctx, "/packages/git-work", 0755))
+	require.NoError(t, fs.MkdirAll(ctx, "/home", 0755))
+	require.NoError(t, fs.WriteFile(ctx, "/packages/git/dot-gitconfig", []byte("personal"), 0644))
+	require.NoError(t, fs.WriteFile(ctx, "/packages/git-work/dot-gitconfig", []byte("work"), 0644))
+	require.NoError(t, fs.WriteFile(ctx, "/packages/git-work/dot-gitignore", []byte("*.o"), 0644))
+	require.NoError(t, fs.Symlink(ctx, "/packages/git/dot-gitconfig", "/home/.gitconfig"))
+	require.NoError(t, fs.Symlink(ctx, "/packages/git-work/dot-gitignore", "/home/.gitignore"))
+
+	store := manifest.NewFSManifestStore(fs)
+	manifestSvc := newManifestService(fs, adapters.NewNoopLogger(), store)
+	targetPath := NewTargetPath("/home").Unwrap()
+
+	m := manifest.New()
+	m.AddPackage(manifest.PackageInfo{
+		Name:       "git",
+		Links:      []string{".gitconfig"},
+		LinkCount:  1,
+		PackageDir: "/packages/git",
+	})
+	m.AddPackage(manifest.PackageInfo{
+		Name:       "git-work",
+		Links:      []string{".gitconfig", ".gitignore"},
+		LinkCount:  2,
+		PackageDir: "/packages/git-work",
+	})
+	require.NoError(t, store.Save(ctx, targetPath, m))
+
+	svc := newDoctorService(fs, adapters.NewNoopLogger(), manifestSvc, "/packages", "/home")
+	return svc, manifestSvc, targetPath
+}
+
+func TestDoctorService_ReportsLinkOwnershipConflict(t *testing.T) {
+	svc, _, _ := newOwnershipConflictService(t)
+
+	report, err := svc.DoctorWithMode(context.Background(), DiagnosticFast, ScanConfig{Mode: ScanOff})
+	require.NoError(t, err)
+
+	var conflicts []Issue
+	for _, issue := range report.Issues {
+		assert.NotEqual(t, IssueBrokenLink, issue.Type)
+		assert.NotEqual(t, IssueOrphanedLink, issue.Type)
+		if issue.Type == IssueLinkOwnershipConflict {
+			conflicts = append(conflicts, issue)
+		}
+	}
+	require.Len(t, conflicts, 1)
+	assert.Equal(t, ".gitconfig", conflicts[0].Path)
+	assert.Equal(t, []string{"git", "git-work"}, conflicts[0].Packages)
+	assert.Contains(t, conflicts[0].Message, "currently linked to git")
+	assert.NotEmpty(t, conflicts[0].Suggestion)
+	assert.Equal(t, 1, report.Statistics.OwnershipConflicts)
+}
+
+func TestDoctorService_Fix_ResolvesOwnershipToCurrentLink(t *testing.T) {
+	ctx := context.Background()
+	svc, manifestSvc, targetPath := newOwnershipConflictService(t)
+
+	result, err := svc.Fix(ctx, ScanConfig{Mode: ScanOff}, FixOptions{AutoConfirm: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{".gitconfig"}, result.Fixed)
+
+	m := manifestSvc.Load(ctx, targetPath).Unwrap()
+	git, ok := m.GetPackage("git")
+	require.True(t, ok)
+	assert.Equal(t, []string{".gitconfig"}, git.Links)
+	work, ok := m.GetPackage("git-work")
+	require.True(t, ok)
+	assert.Equal(t, []string{".gitignore"}, work.Links)
+	assert.Equal(t, 1, work.LinkCount)
+
+	// The losing package no longer reports the link as pointing elsewhere
+	report, err := svc.DoctorWithMode(ctx, DiagnosticFast, ScanConfig{Mode: ScanOff})
+	require.NoError(t, err)
+	assert.Zero(t, report.Statistics.OwnershipConflicts)
+	assert.Empty(t, report.Issues)
+	assert.Equal(t, HealthOK, report.OverallHealth)
+}
+
+func TestDoctorService_Fix_OwnershipDryRun(t *testing.T) {
+	ctx := context.Background()
+	svc, manifestSvc, targetPath := newOwnershipConflictService(t)
+
+	result, err := svc.Fix(ctx, ScanConfig{Mode: ScanOff}, FixOptions{AutoConfirm: true, DryRun: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{".gitconfig"}, result.Fixed)
+
+	m := manifestSvc.Load(ctx, targetPath).Unwrap()
+	work, _ := m.GetPackage("git-work")
+	assert.Equal(t, []string{".gitconfig", ".gitignore"}, work.Links)
+}
+
+func TestParseOwnerChoice(t *testing.T) {
+	candidates := []string{"git", "git-work"}
+
+	tests := []struct {
+		name     string
+		response string
+		def      string
+		want     string
+	}{
+		{"empty takes default", "", "git", "git"},
+		{"empty without default skips", "", "", ""},
+		{"index", "2", "git", "git-work"},
+		{"index out of range", "3", "git", ""},
+		{"zero index", "0", "git", ""},
+		{"package name", "git-work", "git", "git-work"},
+		{"unknown name", "vim", "git", ""},
+		{"skip", "s", "git", ""},
+		{"no", "N", "git", ""},
+		{"whitespace", " 1 ", "git-work", "git"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, parseOwnerChoice(tt.response, candidates, tt.def))
+		})
+	}
+}
+
+func TestAssignLinkOwner_RemovesEmptyPackages(t *testing.T) {
+	m := manifest.New()
+	m.AddPackage(manifest.PackageInfo{Name: "a", Links: []string{".rc"}, LinkCount: 1})
+	m.AddPackage(manifest.PackageInfo{Name: "b", Links: []string{".rc"}, LinkCount: 1})
+	m.AddPackage(manifest.PackageInfo{Name: "c", Links: []string{".rc", ".other"}, LinkCount: 2})
+
+	assignLinkOwner(&m, ".rc", "a", []string{"a", "b", "c"})
+
+	a, ok := m.GetPackage("a")
+	require.True(t, ok)
+	assert.Equal(t, []string{".rc"}, a.Links)
+	_, ok = m.GetPackage("b")
+	assert.False(t, ok)
+	c, ok := m.GetPackage("c")
+	require.True(t, ok)
+	assert.Equal(t, []string{".other"}, c.Links)
+	assert.Equal(t, 1, c.LinkCount)
+}
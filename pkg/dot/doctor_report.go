@@ -0,0 +1,120 @@
+package dot
+
+import (
+	"context"
+	"sort"
+)
+
+// StructuredReport is the machine-readable form of a DiagnosticReport,
+// intended for monitoring and scripts. Status and ExitCode follow the
+// doctor exit-code contract so callers can branch on either.
+type StructuredReport struct {
+	Status   HealthStatus      `json:"status" yaml:"status"`
+	ExitCode int               `json:"exit_code" yaml:"exit_code"`
+	Summary  ReportSummary     `json:"summary" yaml:"summary"`
+	Issues   []StructuredIssue `json:"issues" yaml:"issues"`
+}
+
+// ReportSummary contains issue counts and link statistics.
+type ReportSummary struct {
+	TotalIssues int `json:"total_issues" yaml:"total_issues"`
+	Errors      int `json:"errors" yaml:"errors"`
+	Warnings    int `json:"warnings" yaml:"warnings"`
+	Info        int `json:"info" yaml:"info"`
+	// ByType counts issues by issue type name.
+	ByType     map[string]int  `json:"by_type" yaml:"by_type"`
+	Statistics DiagnosticStats `json:"statistics" yaml:"statistics"`
+}
+
+// StructuredIssue is a single issue in a StructuredReport.
+type StructuredIssue struct {
+	Type       IssueType     `json:"type" yaml:"type"`
+	Path       string        `json:"path" yaml:"path"`
+	Severity   IssueSeverity `json:"severity" yaml:"severity"`
+	Details    string        `json:"details" yaml:"details"`
+	Suggestion string        `json:"suggestion,omitempty" yaml:"suggestion,omitempty"`
+	// SuggestedPattern is an ignore pattern for orphaned links whose target
+	// matches a known category, as offered by triage.
+	SuggestedPattern string   `json:"suggested_pattern,omitempty" yaml:"suggested_pattern,omitempty"`
+	Packages         []string `json:"packages,omitempty" yaml:"packages,omitempty"`
+}
+
+// ExitCode returns the doctor command exit code for the health status:
+// 0 when healthy, 1 for warnings and 2 for errors.
+func (h HealthStatus) ExitCode() int {
+	switch h {
+	case HealthWarnings:
+		return 1
+	case HealthErrors:
+		return 2
+	default:
+		return 0
+	}
+}
+
+// NewStructuredReport builds a StructuredReport from a diagnostic report.
+// patterns maps orphaned link paths to suggested ignore patterns and may be nil.
+func NewStructuredReport(report DiagnosticReport, patterns map[string]string) StructuredReport {
+	result := StructuredReport{
+		Status:   report.OverallHealth,
+		ExitCode: report.OverallHealth.ExitCode(),
+		Summary: ReportSummary{
+			TotalIssues: len(report.Issues),
+			ByType:      make(map[string]int),
+			Statistics:  report.Statistics,
+		},
+		Issues: make([]StructuredIssue, 0, len(report.Issues)),
+	}
+
+	for _, issue := range report.Issues {
+		switch issue.Severity {
+		case SeverityError:
+			result.Summary.Errors++
+		case SeverityWarning:
+			result.Summary.Warnings++
+		default:
+			result.Summary.Info++
+		}
+		result.Summary.ByType[issue.Type.String()]++
+
+		result.Issues = append(result.Issues, StructuredIssue{
+			Type:             issue.Type,
+			Path:             issue.Path,
+			Severity:         issue.Severity,
+			Details:          issue.Message,
+			Suggestion:       issue.Suggestion,
+			SuggestedPattern: patterns[issue.Path],
+			Packages:         issue.Packages,
+		})
+	}
+
+	// Order by severity, then type and path, so output is stable across runs
+	sort.SliceStable(result.Issues, func(i, j int) bool {
+		a, b := result.Issues[i], result.Issues[j]
+		if a.Severity != b.Severity {
+			return a.Severity > b.Severity
+		}
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return a.Path < b.Path
+	})
+
+	return result
+}
+
+// StructuredReport builds a StructuredReport for report, suggesting ignore
+// patterns for categorized orphaned links.
+func (s *DoctorService) StructuredReport(ctx context.Context, report DiagnosticReport) StructuredReport {
+	orphans := filterIssuesByType(report.Issues, IssueOrphanedLink)
+	patterns := make(map[string]string)
+	for _, group := range s.groupOrphansByCategory(orphans, s.readOrphanTargets(ctx, orphans)) {
+		if group.IsUncategorized || group.Pattern == "" {
+			continue
+		}
+		for _, issue := range group.Links {
+			patterns[issue.Path] = group.Pattern
+		}
+	}
+	return NewStructuredReport(report, patterns)
+}
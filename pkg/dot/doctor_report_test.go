@@ -0,0 +1,55 @@
+package dot_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yaklabco/dot/pkg/dot"
+)
+
+func TestHealthStatus_ExitCode(t *testing.T) {
+	assert.Equal(t, 0, dot.HealthOK.ExitCode())
+	assert.Equal(t, 1, dot.HealthWarnings.ExitCode())
+	assert.Equal(t, 2, dot.HealthErrors.ExitCode())
+}
+
+func TestNewStructuredReport(t *testing.T) {
+	report := dot.DiagnosticReport{
+		OverallHealth: dot.HealthWarnings,
+		Issues: []dot.Issue{
+			{Type: dot.IssueOrphanedLink, Severity: dot.SeverityWarning, Path: "b", Message: "orphan b"},
+			{Type: dot.IssueOrphanedLink, Severity: dot.SeverityWarning, Path: "a", Message: "orphan a"},
+			{Type: dot.IssueManifestInconsistency, Severity: dot.SeverityInfo, Message: "note"},
+		},
+		Statistics: dot.DiagnosticStats{OrphanedLinks: 2},
+	}
+
+	got := dot.NewStructuredReport(report, map[string]string{"a": "*/.npm/*"})
+
+	assert.Equal(t, dot.HealthWarnings, got.Status)
+	assert.Equal(t, 1, got.ExitCode)
+	assert.Equal(t, 3, got.Summary.TotalIssues)
+	assert.Equal(t, 0, got.Summary.Errors)
+	assert.Equal(t, 2, got.Summary.Warnings)
+	assert.Equal(t, 1, got.Summary.Info)
+	assert.Equal(t, map[string]int{"orphaned_link": 2, "manifest_inconsistency": 1}, got.Summary.ByType)
+	assert.Equal(t, 2, got.Summary.Statistics.OrphanedLinks)
+
+	// Sorted by severity, then type and path
+	if assert.Len(t, got.Issues, 3) {
+		assert.Equal(t, "a", got.Issues[0].Path)
+		assert.Equal(t, "orphan a", got.Issues[0].Details)
+		assert.Equal(t, "*/.npm/*", got.Issues[0].SuggestedPattern)
+		assert.Equal(t, "b", got.Issues[1].Path)
+		assert.Empty(t, got.Issues[1].SuggestedPattern)
+		assert.Equal(t, dot.SeverityInfo, got.Issues[2].Severity)
+	}
+}
+
+func TestNewStructuredReport_Empty(t *testing.T) {
+	got := dot.NewStructuredReport(dot.DiagnosticReport{OverallHealth: dot.HealthOK}, nil)
+
+	assert.Equal(t, 0, got.ExitCode)
+	assert.NotNil(t, got.Issues, "issues should encode as [] rather than null")
+	assert.Empty(t, got.Summary.ByType)
+}
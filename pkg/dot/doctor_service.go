@@ -10,6 +10,7 @@ import (
 
 	"github.com/yaklabco/dot/internal/doctor"
 	"github.com/yaklabco/dot/internal/domain"
+	"github.com/yaklabco/dot/internal/executor"
 	"github.com/yaklabco/dot/internal/manifest"
 )
 
@@ -22,6 +23,8 @@ type DoctorService struct {
 	targetDir     string
 	healthChecker *HealthChecker
 	adoptSvc      *AdoptService
+	executor      *executor.Executor
+	categories    []PatternCategory
 }
 
 // newDoctorService creates a new doctor service (for tests).
@@ -38,8 +41,9 @@ func newDoctorService(
 		manifestSvc:   manifestSvc,
 		packageDir:    packageDir,
 		targetDir:     targetDir,
-		healthChecker: newHealthChecker(fs, targetDir),
+		healthChecker: newHealthChecker(fs, packageDir, targetDir),
 		adoptSvc:      nil,
+		executor:      executor.New(executor.Opts{FS: fs, Logger: logger, Tracer: NewNoopTracer()}),
 	}
 }
 
@@ -49,6 +53,7 @@ func newDoctorServiceWithAdopt(
 	logger Logger,
 	manifestSvc *ManifestService,
 	adoptSvc *AdoptService,
+	exec *executor.Executor,
 	packageDir string,
 	targetDir string,
 ) *DoctorService {
@@ -58,8 +63,9 @@ func newDoctorServiceWithAdopt(
 		manifestSvc:   manifestSvc,
 		packageDir:    packageDir,
 		targetDir:     targetDir,
-		healthChecker: newHealthChecker(fs, targetDir),
+		healthChecker: newHealthChecker(fs, packageDir, targetDir),
 		adoptSvc:      adoptSvc,
+		executor:      exec,
 	}
 }
 
@@ -116,7 +122,10 @@ func (s *DoctorService) DoctorWithMode(ctx context.Context, mode DiagnosticMode,
 	// 2. Managed Packages Check
 	engine.RegisterCheck(doctor.NewManagedPackageCheck(fsAdapter, manifestLoader, healthChecker, s.targetDir, newTargetPath, IsManifestNotFoundError))
 
-	// 3. Orphan Check - registered when scan mode enables it, regardless of diagnostic mode.
+	// 3. Link Ownership Check
+	engine.RegisterCheck(doctor.NewLinkOwnershipCheck(fsAdapter, manifestLoader, s.targetDir, newTargetPath, IsManifestNotFoundError))
+
+	// 4. Orphan Check - registered when scan mode enables it, regardless of diagnostic mode.
 	// Users set --scan-mode to control orphan detection independently from --mode.
 	if scanCfg.Mode != ScanOff {
 		engine.RegisterCheck(doctor.NewOrphanCheck(
@@ -130,7 +139,7 @@ func (s *DoctorService) DoctorWithMode(ctx context.Context, mode DiagnosticMode,
 
 	// Deep mode: Additional comprehensive checks
 	if mode == DiagnosticDeep {
-		// 4. Platform Compatibility Check
+		// 5. Platform Compatibility Check
 		engine.RegisterCheck(doctor.NewPlatformCheck(fsAdapter, manifestLoader, s.packageDir, s.targetDir, newTargetPath))
 	}
 
@@ -221,6 +230,12 @@ func convertIssueType(code string) IssueType {
 		return IssuePermission
 	case "circular":
 		return IssueCircular
+	case "manifest_corrupt":
+		return IssueManifestCorrupt
+	case "link_ownership_conflict":
+		return IssueLinkOwnershipConflict
+	case "foreign_target":
+		return IssueForeignTarget
 	case "manifest_inconsistency", "no_manifest", "manifest_inconsistent", "check_execution_error":
 		return IssueManifestInconsistency
 	case "conflict_detected", "access_error":
@@ -244,6 +259,16 @@ func extractSuggestion(ctx map[string]any) string {
 	return ""
 }
 
+// extractPackages extracts involved package names from context.
+func extractPackages(ctx map[string]any) []string {
+	if val, ok := ctx["packages"]; ok {
+		if pkgs, ok := val.([]string); ok {
+			return pkgs
+		}
+	}
+	return nil
+}
+
 // convertIssue converts domain issue to public issue.
 func convertIssue(internalIssue domain.Issue) Issue {
 	return Issue{
@@ -252,6 +277,7 @@ func convertIssue(internalIssue domain.Issue) Issue {
 		Path:       internalIssue.Path,
 		Message:    internalIssue.Message,
 		Suggestion: extractSuggestion(internalIssue.Context),
+		Packages:   extractPackages(internalIssue.Context),
 	}
 }
 
@@ -281,6 +307,8 @@ func (s *DoctorService) transformReport(internal doctor.DiagnosticReport) Diagno
 		stats.BrokenLinks += aggregateStat(res.Stats, "broken_links")
 		stats.OrphanedLinks += aggregateStat(res.Stats, "orphaned_links")
 		stats.ManagedLinks += aggregateStat(res.Stats, "managed_links")
+		stats.OwnershipConflicts += aggregateStat(res.Stats, "ownership_conflicts")
+		stats.Junctions += aggregateStat(res.Stats, "junctions")
 
 		for _, internalIssue := range res.Issues {
 			issues = append(issues, convertIssue(internalIssue))
@@ -349,6 +377,10 @@ func (a *manifestLoaderAdapter) Load(ctx context.Context, targetPath domain.Targ
 	return a.svc.Load(ctx, targetPath)
 }
 
+func (a *manifestLoaderAdapter) Verify(ctx context.Context, targetPath domain.TargetPath) (bool, error) {
+	return a.svc.Verify(ctx, targetPath)
+}
+
 // linkHealthCheckerAdapter adapts HealthChecker to doctor.LinkHealthChecker interface.
 type linkHealthCheckerAdapter struct {
 	checker *HealthChecker
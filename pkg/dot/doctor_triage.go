@@ -3,20 +3,31 @@ package dot
 import (
 	"context"
 	"fmt"
+	"os"
 	"path/filepath"
 	"sort"
 	"strings"
 
+	"github.com/yaklabco/dot/internal/cli/prompt"
 	"github.com/yaklabco/dot/internal/doctor"
 	"github.com/yaklabco/dot/internal/ignore"
 	"github.com/yaklabco/dot/internal/manifest"
 )
 
+// PatternCategory is a named set of glob patterns used to categorize
+// orphaned symlinks during triage.
+type PatternCategory = doctor.PatternCategory
+
 // TriageOptions configures triage behavior.
 type TriageOptions struct {
 	AutoIgnoreHighConfidence bool // Automatically ignore high confidence categories
 	DryRun                   bool // Show what would change without modifying
 	AutoConfirm              bool // Skip confirmation prompts (--yes flag)
+
+	// Rules triage orphans without prompting; see TriageRule. Rules from
+	// RulesFile, a YAML file read with ParseTriageRules, follow Rules.
+	Rules     []TriageRule
+	RulesFile string
 }
 
 // TriageResult contains the results of a triage operation.
@@ -37,13 +48,19 @@ type OrphanGroup struct {
 	IsUncategorized bool
 }
 
-// Triage performs interactive triage of orphaned symlinks.
+// Triage performs interactive triage of orphaned symlinks. When opts has
+// rules, triage runs without prompting for individual links or categories.
 func (s *DoctorService) Triage(ctx context.Context, scanCfg ScanConfig, opts TriageOptions) (TriageResult, error) {
 	result := TriageResult{
 		Adopted: make(map[string]string),
 		Errors:  make(map[string]error),
 	}
 
+	rules, err := s.loadTriageRules(ctx, opts)
+	if err != nil {
+		return result, err
+	}
+
 	// Run doctor to get issues
 	report, err := s.DoctorWithScan(ctx, scanCfg)
 	if err != nil {
@@ -72,11 +89,18 @@ func (s *DoctorService) Triage(ctx context.Context, scanCfg ScanConfig, opts Tri
 		return result, nil
 	}
 
+	// Read every link target once; grouping, prompts, and actions share it
+	targets := s.readOrphanTargets(ctx, orphanedIssues)
+
 	// Group by category
-	groups := s.groupOrphansByCategory(ctx, orphanedIssues)
+	groups := s.groupOrphansByCategory(orphanedIssues, targets)
 
-	// If auto-ignore flag is set, automatically ignore high confidence categories
-	if opts.AutoIgnoreHighConfidence {
+	if len(rules) > 0 {
+		if err := s.applyTriageRules(ctx, targetPath, &m, groups, targets, rules, opts, &result); err != nil {
+			return result, err
+		}
+	} else if opts.AutoIgnoreHighConfidence {
+		// Automatically ignore high confidence categories
 		s.autoIgnoreHighConfidence(ctx, &m, groups, &result)
 	} else {
 		// Present overview and get processing choice
@@ -84,9 +108,9 @@ func (s *DoctorService) Triage(ctx context.Context, scanCfg ScanConfig, opts Tri
 
 		switch choice {
 		case "c": // Process by category
-			s.processTriageByCategory(ctx, &m, groups, opts, &result)
+			s.processTriageByCategory(ctx, &m, groups, targets, opts, &result)
 		case "l": // Process linearly
-			s.processTriageLinearly(ctx, &m, orphanedIssues, groups, opts, &result)
+			s.processTriageLinearly(ctx, &m, orphanedIssues, targets, opts, &result)
 		case "a": // Auto-ignore high confidence
 			s.autoIgnoreHighConfidence(ctx, &m, groups, &result)
 		case "q": // Quit
@@ -114,7 +138,7 @@ func (s *DoctorService) saveTriageResults(ctx context.Context, targetPath Target
 		return nil
 	}
 
-	if !opts.AutoConfirm && !s.confirmTriageChanges(result) {
+	if !opts.AutoConfirm && !s.confirmTriageChanges(ctx, result) {
 		fmt.Println("\nChanges cancelled")
 		return nil
 	}
@@ -150,7 +174,7 @@ func (s *DoctorService) addIgnorePatternIfNew(m *manifest.Manifest, pattern stri
 }
 
 // confirmTriageChanges shows a summary and asks for confirmation before saving.
-func (s *DoctorService) confirmTriageChanges(result TriageResult) bool {
+func (s *DoctorService) confirmTriageChanges(ctx context.Context, result TriageResult) bool {
 	fmt.Printf("\nSummary of changes:\n")
 	if len(result.Ignored) > 0 {
 		fmt.Printf("  • %d links to ignore\n", len(result.Ignored))
@@ -165,15 +189,13 @@ func (s *DoctorService) confirmTriageChanges(result TriageResult) bool {
 		fmt.Printf("  • %d errors occurred\n", len(result.Errors))
 	}
 
-	fmt.Printf("\nSave these changes? [Y/n]: ")
-	var response string
-	if _, err := fmt.Scanln(&response); err != nil {
-		// Handle EOF or input error - default to yes
+	fmt.Println()
+	confirmed, err := prompt.Confirm(ctx, os.Stdin, os.Stdout, "Save these changes?", true)
+	if err != nil {
+		// Input error - default to yes
 		return true
 	}
-	response = strings.ToLower(strings.TrimSpace(response))
-
-	return response == "" || response == "y" || response == "yes"
+	return confirmed
 }
 
 // buildIgnoreSet creates an IgnoreSet from manifest's ignored patterns.
@@ -215,23 +237,50 @@ func (s *DoctorService) filterAlreadyIgnored(ctx context.Context, issues []Issue
 	return filtered
 }
 
-// groupOrphansByCategory groups orphaned links by their category.
-func (s *DoctorService) groupOrphansByCategory(ctx context.Context, issues []Issue) []OrphanGroup {
-	categories := doctor.DefaultPatternCategories()
-	categoryMap := make(map[string]*OrphanGroup)
-	var uncategorized []Issue
+// patternCategories returns the built-in triage categories merged with any
+// categories from configuration.
+func (s *DoctorService) patternCategories() []doctor.PatternCategory {
+	return doctor.MergePatternCategories(doctor.DefaultPatternCategories(), s.categories)
+}
 
+// orphanTarget is the target of an orphaned link and the category it falls
+// in, or the error reading the link.
+type orphanTarget struct {
+	Target   string
+	Category *doctor.PatternCategory
+	Err      error
+}
+
+// orphanTargets maps orphaned link paths, relative to the target
+// directory, to their targets.
+type orphanTargets map[string]orphanTarget
+
+// readOrphanTargets reads and categorizes the target of each orphaned link
+// so triage touches each link once, however many times it is shown.
+func (s *DoctorService) readOrphanTargets(ctx context.Context, issues []Issue) orphanTargets {
+	categories := s.patternCategories()
+	targets := make(orphanTargets, len(issues))
 	for _, issue := range issues {
-		// Read the link target
-		fullPath := filepath.Join(s.targetDir, issue.Path)
-		target, err := s.fs.ReadLink(ctx, fullPath)
+		target, err := s.fs.ReadLink(ctx, filepath.Join(s.targetDir, issue.Path))
 		if err != nil {
-			uncategorized = append(uncategorized, issue)
+			targets[issue.Path] = orphanTarget{Err: err}
 			continue
 		}
+		targets[issue.Path] = orphanTarget{
+			Target:   target,
+			Category: doctor.CategorizeSymlink(target, categories),
+		}
+	}
+	return targets
+}
+
+// groupOrphansByCategory groups orphaned links by their category.
+func (s *DoctorService) groupOrphansByCategory(issues []Issue, targets orphanTargets) []OrphanGroup {
+	categoryMap := make(map[string]*OrphanGroup)
+	var uncategorized []Issue
 
-		// Try to categorize
-		cat := doctor.CategorizeSymlink(target, categories)
+	for _, issue := range issues {
+		cat := targets[issue.Path].Category
 		if cat == nil {
 			uncategorized = append(uncategorized, issue)
 			continue
@@ -334,11 +383,11 @@ func (s *DoctorService) promptTriageOverview(allIssues []Issue, groups []OrphanG
 }
 
 // processTriageByCategory processes orphans grouped by category.
-func (s *DoctorService) processTriageByCategory(ctx context.Context, m *manifest.Manifest, groups []OrphanGroup, opts TriageOptions, result *TriageResult) {
+func (s *DoctorService) processTriageByCategory(ctx context.Context, m *manifest.Manifest, groups []OrphanGroup, targets orphanTargets, opts TriageOptions, result *TriageResult) {
 	for _, group := range groups {
 		s.displayCategoryInfo(group)
 		action := s.getCategoryAction(group, opts)
-		if !s.handleCategoryAction(ctx, m, group, action, opts, result) {
+		if !s.handleCategoryAction(ctx, m, group, targets, action, opts, result) {
 			return
 		}
 	}
@@ -374,12 +423,12 @@ func (s *DoctorService) getCategoryAction(group OrphanGroup, opts TriageOptions)
 }
 
 // handleCategoryAction handles the action for a category. Returns false if user quit.
-func (s *DoctorService) handleCategoryAction(ctx context.Context, m *manifest.Manifest, group OrphanGroup, action string, opts TriageOptions, result *TriageResult) bool {
+func (s *DoctorService) handleCategoryAction(ctx context.Context, m *manifest.Manifest, group OrphanGroup, targets orphanTargets, action string, opts TriageOptions, result *TriageResult) bool {
 	switch action {
 	case "i":
 		s.handleIgnoreCategory(m, group.Pattern, opts.DryRun, result)
 	case "r":
-		s.processLinksIndividually(ctx, m, group.Links, result, opts.DryRun)
+		s.processLinksIndividually(ctx, m, group.Links, targets, result, opts.DryRun)
 	case "s":
 		s.handleSkipCategory(group, result)
 	case "q":
@@ -459,28 +508,28 @@ func (s *DoctorService) promptCategoryAction(group OrphanGroup) string {
 }
 
 // processTriageLinearly processes orphans one by one.
-func (s *DoctorService) processTriageLinearly(ctx context.Context, m *manifest.Manifest, issues []Issue, groups []OrphanGroup, opts TriageOptions, result *TriageResult) {
-	s.processLinksIndividually(ctx, m, issues, result, opts.DryRun)
+func (s *DoctorService) processTriageLinearly(ctx context.Context, m *manifest.Manifest, issues []Issue, targets orphanTargets, opts TriageOptions, result *TriageResult) {
+	s.processLinksIndividually(ctx, m, issues, targets, result, opts.DryRun)
 }
 
 // processLinksIndividually processes each link with individual prompts.
-func (s *DoctorService) processLinksIndividually(ctx context.Context, m *manifest.Manifest, issues []Issue, result *TriageResult, dryRun bool) {
+func (s *DoctorService) processLinksIndividually(ctx context.Context, m *manifest.Manifest, issues []Issue, targets orphanTargets, result *TriageResult, dryRun bool) {
 	applyToAll := false
 	applyToAllAction := ""
 
 	for i, issue := range issues {
 		if applyToAll {
-			s.applyTriageAction(ctx, m, issue, applyToAllAction, result, dryRun)
+			s.applyTriageAction(ctx, m, issue, targets[issue.Path], applyToAllAction, result, dryRun)
 			continue
 		}
 
-		action, all := s.promptLinkAction(ctx, issue, i+1, len(issues))
+		action, all := s.promptLinkAction(issue, targets[issue.Path], i+1, len(issues))
 		if all {
 			applyToAll = true
 			applyToAllAction = action
 		}
 
-		s.applyTriageAction(ctx, m, issue, action, result, dryRun)
+		s.applyTriageAction(ctx, m, issue, targets[issue.Path], action, result, dryRun)
 
 		if action == "q" {
 			break
@@ -490,16 +539,12 @@ func (s *DoctorService) processLinksIndividually(ctx context.Context, m *manifes
 
 // promptLinkAction prompts for action on an individual link.
 // Returns (action, applyToAll).
-func (s *DoctorService) promptLinkAction(ctx context.Context, issue Issue, current, total int) (string, bool) {
-	fullPath := filepath.Join(s.targetDir, issue.Path)
-	target, err := s.fs.ReadLink(ctx, fullPath)
-	if err != nil {
+func (s *DoctorService) promptLinkAction(issue Issue, link orphanTarget, current, total int) (string, bool) {
+	target := link.Target
+	if link.Err != nil {
 		target = "(unable to read target)"
 	}
-
-	// Try to categorize
-	categories := doctor.DefaultPatternCategories()
-	cat := doctor.CategorizeSymlink(target, categories)
+	cat := link.Category
 
 	fmt.Printf("\nOrphaned symlink [%d/%d]: %s\n", current, total, issue.Path)
 	fmt.Printf("  Target: %s\n", target)
@@ -566,24 +611,21 @@ func actionDescription(action string) string {
 }
 
 // applyTriageAction applies the chosen action to a link.
-func (s *DoctorService) applyTriageAction(ctx context.Context, m *manifest.Manifest, issue Issue, action string, result *TriageResult, dryRun bool) {
+func (s *DoctorService) applyTriageAction(ctx context.Context, m *manifest.Manifest, issue Issue, link orphanTarget, action string, result *TriageResult, dryRun bool) {
 	if dryRun {
 		fmt.Printf("[DRY RUN] Would %s: %s\n", actionDescription(action), issue.Path)
 		return
 	}
 
-	fullPath := filepath.Join(s.targetDir, issue.Path)
-	target, _ := s.fs.ReadLink(ctx, fullPath)
-
 	switch action {
 	case "i": // Ignore this link
-		s.applyIgnoreLink(m, issue, target, result)
+		s.applyIgnoreLink(m, issue, link.Target, result)
 	case "p": // Ignore with custom pattern
 		s.applyIgnoreCustomPattern(m, result)
 	case "P": // Auto-ignore pattern
-		s.applyAutoIgnorePattern(m, issue, target, result)
+		s.applyAutoIgnorePattern(m, issue, link.Category, result)
 	case "c": // Ignore all in category
-		s.applyIgnoreCategory(m, target, result)
+		s.applyIgnoreCategory(m, link.Category, result)
 	case "a": // Adopt
 		s.applyAdoptLink(ctx, m, issue, result)
 	case "s": // Skip
@@ -625,9 +667,7 @@ func (s *DoctorService) applyIgnoreCustomPattern(m *manifest.Manifest, result *T
 	}
 }
 
-func (s *DoctorService) applyAutoIgnorePattern(m *manifest.Manifest, issue Issue, target string, result *TriageResult) {
-	categories := doctor.DefaultPatternCategories()
-	cat := doctor.CategorizeSymlink(target, categories)
+func (s *DoctorService) applyAutoIgnorePattern(m *manifest.Manifest, issue Issue, cat *doctor.PatternCategory, result *TriageResult) {
 	if cat != nil {
 		pattern := s.generateIgnorePattern(cat, issue.Path)
 		if s.addIgnorePatternIfNew(m, pattern, result) {
@@ -638,9 +678,7 @@ func (s *DoctorService) applyAutoIgnorePattern(m *manifest.Manifest, issue Issue
 	}
 }
 
-func (s *DoctorService) applyIgnoreCategory(m *manifest.Manifest, target string, result *TriageResult) {
-	categories := doctor.DefaultPatternCategories()
-	cat := doctor.CategorizeSymlink(target, categories)
+func (s *DoctorService) applyIgnoreCategory(m *manifest.Manifest, cat *doctor.PatternCategory, result *TriageResult) {
 	if cat != nil {
 		addedCount := 0
 		for _, pattern := range cat.Patterns {
@@ -0,0 +1,64 @@
+package dot
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/yaklabco/dot/internal/adapters"
+	"github.com/yaklabco/dot/internal/manifest"
+)
+
+// newOrphanBenchService creates a doctor service over n orphaned links
+// spread across categorized and uncategorized targets.
+func newOrphanBenchService(b *testing.B, n int) (*DoctorService, []Issue) {
+	b.Helper()
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	if err := fs.MkdirAll(ctx, "/home", 0755); err != nil {
+		b.Fatal(err)
+	}
+
+	targets := []string{"/home/.npm/bin/tool", "/home/.cargo/bin/tool", "/opt/vendor/tool", "/usr/local/share/tool"}
+	issues := make([]Issue, 0, n)
+	for i := 0; i < n; i++ {
+		name := fmt.Sprintf("orphan-%d", i)
+		if err := fs.Symlink(ctx, fmt.Sprintf("%s-%d", targets[i%len(targets)], i), "/home/"+name); err != nil {
+			b.Fatal(err)
+		}
+		issues = append(issues, Issue{Type: IssueOrphanedLink, Path: name})
+	}
+
+	manifestSvc := newManifestService(fs, adapters.NewNoopLogger(), manifest.NewFSManifestStore(fs))
+	return newDoctorService(fs, adapters.NewNoopLogger(), manifestSvc, "/packages", "/home"), issues
+}
+
+// BenchmarkTriage_GroupOrphans benchmarks reading, categorizing, and
+// grouping 5,000 orphaned links as triage does when it starts.
+func BenchmarkTriage_GroupOrphans(b *testing.B) {
+	svc, issues := newOrphanBenchService(b, 5000)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		targets := svc.readOrphanTargets(ctx, issues)
+		_ = svc.groupOrphansByCategory(issues, targets)
+	}
+}
+
+// BenchmarkTriage_CachedLookups benchmarks the per-link lookups the
+// interactive loop makes once targets are read.
+func BenchmarkTriage_CachedLookups(b *testing.B) {
+	svc, issues := newOrphanBenchService(b, 5000)
+	targets := svc.readOrphanTargets(context.Background(), issues)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, issue := range issues {
+			link := targets[issue.Path]
+			if link.Category != nil {
+				_ = svc.generateIgnorePattern(link.Category, issue.Path)
+			}
+		}
+	}
+}
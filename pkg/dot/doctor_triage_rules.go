@@ -0,0 +1,203 @@
+package dot
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/yaklabco/dot/internal/manifest"
+)
+
+// TriageAction is the action a triage rule applies to matching orphans.
+type TriageAction string
+
+const (
+	// TriageIgnore records matching links as ignored in the manifest.
+	TriageIgnore TriageAction = "ignore"
+	// TriageAdopt adopts matching links into the rule's package.
+	TriageAdopt TriageAction = "adopt"
+	// TriageSkip leaves matching links untouched.
+	TriageSkip TriageAction = "skip"
+)
+
+// TriageRule maps orphaned links to an action for non-interactive triage.
+// A rule matches a link when every criterion it sets matches; rules are
+// evaluated in order and the first match wins.
+type TriageRule struct {
+	// Category matches the orphan category name, such as "npm" or a
+	// category from doctor.categories.
+	Category string `yaml:"category" json:"category,omitempty"`
+	// Pattern is a glob matched against the link path relative to the
+	// target directory.
+	Pattern string `yaml:"pattern" json:"pattern,omitempty"`
+	// Action is ignore, adopt or skip.
+	Action TriageAction `yaml:"action" json:"action"`
+	// Package is the package to adopt into. Required for adopt.
+	Package string `yaml:"package" json:"package,omitempty"`
+}
+
+// triageRulesFile is the on-disk format of a triage rules file.
+type triageRulesFile struct {
+	Rules []TriageRule `yaml:"rules"`
+}
+
+// Validate checks that the rule has a criterion and a usable action.
+func (r TriageRule) Validate() error {
+	if r.Category == "" && r.Pattern == "" {
+		return fmt.Errorf("rule needs a category or pattern")
+	}
+	if r.Pattern != "" {
+		if _, err := filepath.Match(r.Pattern, ""); err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", r.Pattern, err)
+		}
+	}
+	switch r.Action {
+	case TriageIgnore, TriageSkip:
+	case TriageAdopt:
+		if r.Package == "" {
+			return fmt.Errorf("adopt rule needs a package")
+		}
+	default:
+		return fmt.Errorf("invalid action %q (must be one of: ignore, adopt, skip)", r.Action)
+	}
+	return nil
+}
+
+// matches reports whether the rule applies to a link in the given category.
+// category is "" for uncategorized links.
+func (r TriageRule) matches(linkPath, category string) bool {
+	if r.Category != "" && r.Category != category {
+		return false
+	}
+	if r.Pattern != "" {
+		if ok, _ := filepath.Match(r.Pattern, linkPath); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseTriageRules parses and validates a YAML triage rules file:
+//
+//	rules:
+//	  - category: npm
+//	    action: ignore
+//	  - pattern: ".local/bin/work-*"
+//	    action: adopt
+//	    package: work
+func ParseTriageRules(data []byte) ([]TriageRule, error) {
+	var file triageRulesFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parse triage rules: %w", err)
+	}
+	if len(file.Rules) == 0 {
+		return nil, fmt.Errorf("triage rules file contains no rules")
+	}
+
+	var errs []error
+	for i, rule := range file.Rules {
+		if err := rule.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("rule %d: %w", i+1, err))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, ErrMultiple{Errors: errs}
+	}
+	return file.Rules, nil
+}
+
+// loadTriageRules returns the rules from opts, reading RulesFile if set.
+func (s *DoctorService) loadTriageRules(ctx context.Context, opts TriageOptions) ([]TriageRule, error) {
+	for i, rule := range opts.Rules {
+		if err := rule.Validate(); err != nil {
+			return nil, fmt.Errorf("triage rule %d: %w", i+1, err)
+		}
+	}
+	if opts.RulesFile == "" {
+		return opts.Rules, nil
+	}
+
+	data, err := s.fs.ReadFile(ctx, opts.RulesFile)
+	if err != nil {
+		return nil, fmt.Errorf("read triage rules: %w", err)
+	}
+	fileRules, err := ParseTriageRules(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", opts.RulesFile, err)
+	}
+	// Rules passed directly take precedence over the file
+	return append(append([]TriageRule{}, opts.Rules...), fileRules...), nil
+}
+
+// applyTriageRules applies the first matching rule to every grouped orphan
+// without prompting. Orphans no rule matches are recorded as skipped.
+// Adoptions run first because the adopt service saves the manifest itself,
+// so *m is reloaded before ignores are recorded.
+func (s *DoctorService) applyTriageRules(ctx context.Context, targetPath TargetPath, m *manifest.Manifest, groups []OrphanGroup, targets orphanTargets, rules []TriageRule, opts TriageOptions, result *TriageResult) error {
+	type decision struct {
+		issue Issue
+		rule  TriageRule
+	}
+	var ignores, adopts []decision
+
+	for _, group := range groups {
+		category := ""
+		if !group.IsUncategorized && group.Category != nil {
+			category = group.Category.Name
+		}
+		for _, issue := range group.Links {
+			rule, ok := firstMatchingRule(rules, issue.Path, category)
+			if !ok || rule.Action == TriageSkip {
+				result.Skipped = append(result.Skipped, issue.Path)
+				continue
+			}
+			if rule.Action == TriageAdopt {
+				adopts = append(adopts, decision{issue, rule})
+			} else {
+				ignores = append(ignores, decision{issue, rule})
+			}
+		}
+	}
+
+	adopted := false
+	for _, d := range adopts {
+		if opts.DryRun {
+			fmt.Printf("[DRY RUN] Would adopt %s into %s\n", d.issue.Path, d.rule.Package)
+			result.Adopted[d.issue.Path] = d.rule.Package
+			continue
+		}
+		if err := s.executeAdoption(ctx, d.issue.Path, d.rule.Package); err != nil {
+			result.Errors[d.issue.Path] = err
+			continue
+		}
+		result.Adopted[d.issue.Path] = d.rule.Package
+		adopted = true
+	}
+
+	if adopted {
+		reloaded := s.manifestSvc.Load(ctx, targetPath)
+		if !reloaded.IsOk() {
+			return reloaded.UnwrapErr()
+		}
+		*m = reloaded.Unwrap()
+	}
+
+	for _, d := range ignores {
+		m.AddIgnoredLink(d.issue.Path, targets[d.issue.Path].Target, "triage rule")
+		result.Ignored = append(result.Ignored, d.issue.Path)
+	}
+
+	return nil
+}
+
+// firstMatchingRule returns the first rule matching the link.
+func firstMatchingRule(rules []TriageRule, linkPath, category string) (TriageRule, bool) {
+	for _, rule := range rules {
+		if rule.matches(linkPath, category) {
+			return rule, true
+		}
+	}
+	return TriageRule{}, false
+}
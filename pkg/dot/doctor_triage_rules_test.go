@@ -0,0 +1,183 @@
+package dot_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaklabco/dot/internal/adapters"
+	"github.com/yaklabco/dot/pkg/dot"
+)
+
+// newTriageRulesClient manages one package and leaves three orphaned links
+// in the target: one in the npm category, one uncategorized work tool and
+// one unrelated link.
+func newTriageRulesClient(t *testing.T) (*dot.Client, *adapters.MemFS) {
+	t.Helper()
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+
+	require.NoError(t, fs.MkdirAll(ctx, "/test/packages/app", 0755))
+	require.NoError(t, fs.MkdirAll(ctx, "/test/target", 0755))
+	require.NoError(t, fs.WriteFile(ctx, "/test/packages/app/dot-config", []byte("cfg"), 0644))
+
+	require.NoError(t, fs.MkdirAll(ctx, "/data/.npm/bin", 0755))
+	require.NoError(t, fs.WriteFile(ctx, "/data/.npm/bin/tsc", []byte("tsc"), 0755))
+	require.NoError(t, fs.MkdirAll(ctx, "/opt/work", 0755))
+	require.NoError(t, fs.WriteFile(ctx, "/opt/work/toolrc", []byte("work"), 0644))
+	require.NoError(t, fs.WriteFile(ctx, "/data/other", []byte("other"), 0644))
+
+	client, err := dot.NewClient(dot.Config{
+		PackageDir: "/test/packages",
+		TargetDir:  "/test/target",
+		FS:         fs,
+		Logger:     adapters.NewNoopLogger(),
+	})
+	require.NoError(t, err)
+	require.NoError(t, client.Manage(ctx, "app"))
+
+	require.NoError(t, fs.Symlink(ctx, "/data/.npm/bin/tsc", "/test/target/.tsc"))
+	require.NoError(t, fs.Symlink(ctx, "/opt/work/toolrc", "/test/target/.work-toolrc"))
+	require.NoError(t, fs.Symlink(ctx, "/data/other", "/test/target/.other"))
+
+	return client, fs
+}
+
+var triageRules = []dot.TriageRule{
+	{Category: "npm", Action: dot.TriageIgnore},
+	{Pattern: ".work-*", Action: dot.TriageAdopt, Package: "work"},
+}
+
+func TestClient_Triage_Rules(t *testing.T) {
+	ctx := context.Background()
+	client, fs := newTriageRulesClient(t)
+
+	result, err := client.Triage(ctx, dot.DeepScanConfig(5), dot.TriageOptions{
+		Rules:       triageRules,
+		AutoConfirm: true,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{".tsc"}, result.Ignored)
+	assert.Equal(t, map[string]string{".work-toolrc": "work"}, result.Adopted)
+	assert.Equal(t, []string{".other"}, result.Skipped, "unmatched orphans are skipped")
+	assert.Empty(t, result.Errors)
+
+	// The adopted link is managed and the ignore survived the adoption's own
+	// manifest save
+	assert.True(t, fs.Exists(ctx, "/test/packages/work"))
+	status, err := client.Status(ctx, "work")
+	require.NoError(t, err)
+	require.Len(t, status.Packages, 1)
+
+	ignored, _, err := client.DoctorListIgnored(ctx)
+	require.NoError(t, err)
+	assert.Contains(t, ignored, ".tsc")
+
+	report, err := client.DoctorWithScan(ctx, dot.DeepScanConfig(5))
+	require.NoError(t, err)
+	var orphans []string
+	for _, issue := range report.Issues {
+		if issue.Type == dot.IssueOrphanedLink {
+			orphans = append(orphans, issue.Path)
+		}
+	}
+	assert.Equal(t, []string{".other"}, orphans)
+}
+
+func TestClient_Triage_RulesDryRun(t *testing.T) {
+	ctx := context.Background()
+	client, fs := newTriageRulesClient(t)
+
+	result, err := client.Triage(ctx, dot.DeepScanConfig(5), dot.TriageOptions{
+		Rules:       triageRules,
+		AutoConfirm: true,
+		DryRun:      true,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{".tsc"}, result.Ignored)
+	assert.Equal(t, map[string]string{".work-toolrc": "work"}, result.Adopted)
+	assert.False(t, fs.Exists(ctx, "/test/packages/work"), "dry run must not adopt")
+
+	ignored, _, err := client.DoctorListIgnored(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, ignored, "dry run must not save the manifest")
+}
+
+func TestClient_Triage_RulesFile(t *testing.T) {
+	ctx := context.Background()
+	client, fs := newTriageRulesClient(t)
+
+	rules := `
+rules:
+  - pattern: ".other"
+    action: skip
+  - pattern: ".*"
+    action: ignore
+`
+	require.NoError(t, fs.WriteFile(ctx, "/test/rules.yaml", []byte(rules), 0644))
+
+	result, err := client.Triage(ctx, dot.DeepScanConfig(5), dot.TriageOptions{
+		RulesFile:   "/test/rules.yaml",
+		AutoConfirm: true,
+	})
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{".tsc", ".work-toolrc"}, result.Ignored)
+	assert.Equal(t, []string{".other"}, result.Skipped)
+	assert.Empty(t, result.Adopted)
+}
+
+func TestClient_Triage_InvalidRules(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newTriageRulesClient(t)
+
+	_, err := client.Triage(ctx, dot.DeepScanConfig(5), dot.TriageOptions{
+		Rules: []dot.TriageRule{{Category: "npm", Action: dot.TriageAdopt}},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "adopt rule needs a package")
+
+	_, err = client.Triage(ctx, dot.DeepScanConfig(5), dot.TriageOptions{RulesFile: "/test/missing.yaml"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "read triage rules")
+}
+
+func TestParseTriageRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    []dot.TriageRule
+		wantErr string
+	}{
+		{
+			name: "valid",
+			data: "rules:\n  - category: npm\n    action: ignore\n  - pattern: '.local/bin/*'\n    action: adopt\n    package: tools\n",
+			want: []dot.TriageRule{
+				{Category: "npm", Action: dot.TriageIgnore},
+				{Pattern: ".local/bin/*", Action: dot.TriageAdopt, Package: "tools"},
+			},
+		},
+		{name: "empty", data: "rules: []\n", wantErr: "no rules"},
+		{name: "malformed", data: "rules: [\n", wantErr: "parse triage rules"},
+		{name: "no criteria", data: "rules:\n  - action: skip\n", wantErr: "rule 1: rule needs a category or pattern"},
+		{name: "bad action", data: "rules:\n  - category: npm\n    action: delete\n", wantErr: `invalid action "delete"`},
+		{name: "bad pattern", data: "rules:\n  - pattern: '['\n    action: skip\n", wantErr: "invalid pattern"},
+		{name: "adopt without package", data: "rules:\n  - category: npm\n    action: adopt\n", wantErr: "needs a package"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, err := dot.ParseTriageRules([]byte(tt.data))
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, rules)
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package dot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaklabco/dot/internal/adapters"
+	"github.com/yaklabco/dot/internal/manifest"
+)
+
+// setupWrongTarget records vim's .vimrc in the manifest with the link
+// pointing at emacs's copy of the file instead of vim's.
+func setupWrongTarget(t *testing.T) (*adapters.MemFS, *ManifestService) {
+	t.Helper()
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	store := manifest.NewFSManifestStore(fs)
+	manifestSvc := newManifestService(fs, adapters.NewNoopLogger(), store)
+
+	require.NoError(t, fs.MkdirAll(ctx, "/packages/vim", 0755))
+	require.NoError(t, fs.WriteFile(ctx, "/packages/vim/dot-vimrc", []byte("set number"), 0644))
+	require.NoError(t, fs.MkdirAll(ctx, "/packages/emacs", 0755))
+	require.NoError(t, fs.WriteFile(ctx, "/packages/emacs/dot-vimrc", []byte("set nonumber"), 0644))
+	require.NoError(t, fs.MkdirAll(ctx, "/home", 0755))
+	require.NoError(t, fs.Symlink(ctx, "/packages/emacs/dot-vimrc", "/home/.vimrc"))
+
+	m := manifest.New()
+	m.AddPackage(manifest.PackageInfo{
+		Name:       "vim",
+		Links:      []string{".vimrc"},
+		LinkCount:  1,
+		PackageDir: "/packages/vim",
+		Sources:    map[string]string{".vimrc": "/packages/vim/dot-vimrc"},
+	})
+	require.NoError(t, store.Save(ctx, NewTargetPath("/home").Unwrap(), m))
+	return fs, manifestSvc
+}
+
+func TestDoctorService_Fix_WrongTarget(t *testing.T) {
+	ctx := context.Background()
+	fs, manifestSvc := setupWrongTarget(t)
+	svc := newDoctorService(fs, adapters.NewNoopLogger(), manifestSvc, "/packages", "/home")
+
+	result, err := svc.Fix(ctx, DefaultScanConfig(), FixOptions{AutoConfirm: true})
+	require.NoError(t, err)
+	assert.Empty(t, result.Errors)
+	assert.Equal(t, []string{".vimrc"}, result.Fixed)
+
+	target, err := fs.ReadLink(ctx, "/home/.vimrc")
+	require.NoError(t, err)
+	assert.Equal(t, "/packages/vim/dot-vimrc", target, "link is recreated at the recorded source")
+
+	report, err := svc.Doctor(ctx)
+	require.NoError(t, err)
+	for _, issue := range report.Issues {
+		assert.NotEqual(t, IssueWrongTarget, issue.Type, issue.Path)
+	}
+}
+
+func TestDoctorService_Fix_WrongTarget_DryRun(t *testing.T) {
+	ctx := context.Background()
+	fs, manifestSvc := setupWrongTarget(t)
+	svc := newDoctorService(fs, adapters.NewNoopLogger(), manifestSvc, "/packages", "/home")
+
+	result, err := svc.Fix(ctx, DefaultScanConfig(), FixOptions{AutoConfirm: true, DryRun: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{".vimrc"}, result.Fixed)
+
+	target, err := fs.ReadLink(ctx, "/home/.vimrc")
+	require.NoError(t, err)
+	assert.Equal(t, "/packages/emacs/dot-vimrc", target, "nothing is changed")
+}
+
+func TestDoctorService_GroupIssuesForFix_WrongTarget(t *testing.T) {
+	ctx := context.Background()
+	fs, manifestSvc := setupWrongTarget(t)
+	svc := newDoctorService(fs, adapters.NewNoopLogger(), manifestSvc, "/packages", "/home")
+	require.NoError(t, fs.Symlink(ctx, "/packages/emacs/dot-vimrc", "/home/.exrc"))
+	require.NoError(t, fs.WriteFile(ctx, "/home/.gvimrc", []byte("set go="), 0644))
+
+	m := manifestSvc.Load(ctx, NewTargetPath("/home").Unwrap()).Unwrap()
+	pkg, _ := m.GetPackage("vim")
+	pkg.Links = append(pkg.Links, ".gvimrc")
+	m.AddPackage(pkg)
+
+	groups := svc.groupIssuesForFix(ctx, []Issue{
+		{Type: IssueWrongTarget, Path: ".vimrc"},
+		{Type: IssueWrongTarget, Path: ".exrc"},
+		{Type: IssueWrongTarget, Path: ".gvimrc"},
+	}, &m)
+
+	categories := map[string][]string{}
+	for _, group := range groups {
+		for _, issue := range group.Issues {
+			categories[group.Category] = append(categories[group.Category], issue.Path)
+		}
+	}
+	assert.Equal(t, map[string][]string{
+		categoryWrongTarget:    {".vimrc"},
+		categoryUnmanagedWrong: {".exrc"},
+	}, categories, "unmanaged links are only reported and regular files are left alone")
+}
@@ -25,3 +25,13 @@ type Plan = domain.Plan
 
 // PlanMetadata contains statistics and diagnostic information about a plan.
 type PlanMetadata = domain.PlanMetadata
+
+// RiskLevel summarizes how much applying a plan could lose.
+type RiskLevel = domain.RiskLevel
+
+// Risk levels reported in PlanMetadata.RiskLevel.
+const (
+	RiskLow    = domain.RiskLow
+	RiskMedium = domain.RiskMedium
+	RiskHigh   = domain.RiskHigh
+)
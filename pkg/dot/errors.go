@@ -2,8 +2,11 @@ package dot
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/yaklabco/dot/internal/adapters"
 	"github.com/yaklabco/dot/internal/domain"
+	"github.com/yaklabco/dot/internal/manifest"
 )
 
 // Error types re-exported from internal/domain
@@ -26,6 +29,13 @@ type ErrFilesystemOperation = domain.ErrFilesystemOperation
 // ErrPermissionDenied represents a permission denied error.
 type ErrPermissionDenied = domain.ErrPermissionDenied
 
+// ErrReadOnlyFS represents a write attempted through a read-only filesystem.
+type ErrReadOnlyFS = domain.ErrReadOnlyFS
+
+// ErrSymlinkNotPermitted represents a symbolic link the operating system
+// refused to create for lack of privilege.
+type ErrSymlinkNotPermitted = domain.ErrSymlinkNotPermitted
+
 // ErrMultiple represents multiple aggregated errors.
 type ErrMultiple = domain.ErrMultiple
 
@@ -38,6 +48,14 @@ type ErrSourceNotFound = domain.ErrSourceNotFound
 // ErrExecutionFailed represents an execution failure error.
 type ErrExecutionFailed = domain.ErrExecutionFailed
 
+// ErrOperationFailed represents a single operation that failed during
+// execution.
+type ErrOperationFailed = domain.ErrOperationFailed
+
+// ErrRollbackFailed represents an executed operation that could not be
+// rolled back.
+type ErrRollbackFailed = domain.ErrRollbackFailed
+
 // ErrParentNotFound represents a missing parent directory error.
 type ErrParentNotFound = domain.ErrParentNotFound
 
@@ -47,6 +65,9 @@ type ErrCheckpointNotFound = domain.ErrCheckpointNotFound
 // ErrNotImplemented represents a not implemented error.
 type ErrNotImplemented = domain.ErrNotImplemented
 
+// ErrManifestLocked indicates another dot process holds the manifest lock.
+type ErrManifestLocked = manifest.ErrManifestLocked
+
 // ErrNoChanges indicates that an operation found no changes to apply.
 type ErrNoChanges struct {
 	Packages []string
@@ -66,6 +87,70 @@ func (e ErrNoChanges) Is(target error) bool {
 	return ok
 }
 
+// ErrNotManaged indicates a path is not a link managed by the package.
+type ErrNotManaged struct {
+	Package string
+	Path    string
+}
+
+func (e ErrNotManaged) Error() string {
+	return fmt.Sprintf("%s is not managed by package %s", e.Path, e.Package)
+}
+
+// Is implements errors.Is for ErrNotManaged.
+func (e ErrNotManaged) Is(target error) bool {
+	_, ok := target.(ErrNotManaged)
+	return ok
+}
+
+// ErrPackageExists indicates a package name is already taken, by a
+// package directory or by a package recorded in the manifest.
+type ErrPackageExists struct {
+	Package string
+}
+
+func (e ErrPackageExists) Error() string {
+	return fmt.Sprintf("package %q already exists", e.Package)
+}
+
+// Is implements errors.Is for ErrPackageExists.
+func (e ErrPackageExists) Is(target error) bool {
+	_, ok := target.(ErrPackageExists)
+	return ok
+}
+
+// ErrNoPackagesMatch indicates a package glob matched no package.
+type ErrNoPackagesMatch struct {
+	Pattern string
+}
+
+func (e ErrNoPackagesMatch) Error() string {
+	return fmt.Sprintf("no packages match %q", e.Pattern)
+}
+
+// Is implements errors.Is for ErrNoPackagesMatch.
+func (e ErrNoPackagesMatch) Is(target error) bool {
+	_, ok := target.(ErrNoPackagesMatch)
+	return ok
+}
+
+// ErrHookFailed indicates a configured hook command failed. A failing
+// pre-operation hook aborts the operation.
+type ErrHookFailed struct {
+	Stage   HookStage
+	Package string
+	Command []string
+	Err     error
+}
+
+func (e ErrHookFailed) Error() string {
+	return fmt.Sprintf("%s hook %q for package %s failed: %v", e.Stage, strings.Join(e.Command, " "), e.Package, e.Err)
+}
+
+func (e ErrHookFailed) Unwrap() error {
+	return e.Err
+}
+
 // Clone-specific error types
 
 // ErrPackageDirNotEmpty indicates the package directory is not empty.
@@ -148,6 +233,10 @@ func (e ErrAuthFailed) Is(target error) bool {
 	return ok
 }
 
+// ErrHostKeyVerification indicates the SSH server's host key could not be
+// verified against known_hosts.
+type ErrHostKeyVerification = adapters.ErrHostKeyVerification
+
 // ErrCloneFailed indicates repository cloning failed.
 type ErrCloneFailed struct {
 	URL   string
@@ -168,6 +257,22 @@ func (e ErrCloneFailed) Is(target error) bool {
 	return ok
 }
 
+// ErrNotGitRepository indicates a directory expected to hold a git
+// repository does not exist or has no .git entry.
+type ErrNotGitRepository struct {
+	Path string
+}
+
+func (e ErrNotGitRepository) Error() string {
+	return fmt.Sprintf("not a git repository: %s", e.Path)
+}
+
+// Is implements errors.Is for ErrNotGitRepository.
+func (e ErrNotGitRepository) Is(target error) bool {
+	_, ok := target.(ErrNotGitRepository)
+	return ok
+}
+
 // ErrProfileNotFound indicates the requested profile does not exist.
 type ErrProfileNotFound struct {
 	Profile string
@@ -0,0 +1,97 @@
+package dot_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yaklabco/dot/internal/adapters"
+	"github.com/yaklabco/dot/pkg/dot"
+)
+
+func TestClient_ManageFolding(t *testing.T) {
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	require.NoError(t, fs.MkdirAll(ctx, "/test/target/.config", 0755))
+	require.NoError(t, fs.MkdirAll(ctx, "/test/packages/nvim/dot-config/nvim/lua", 0755))
+	require.NoError(t, fs.WriteFile(ctx, "/test/packages/nvim/dot-config/nvim/init.lua", []byte("init"), 0644))
+	require.NoError(t, fs.WriteFile(ctx, "/test/packages/nvim/dot-config/nvim/lua/plugins.lua", []byte("plugins"), 0644))
+	require.NoError(t, fs.MkdirAll(ctx, "/test/packages/extra/dot-config/nvim", 0755))
+	require.NoError(t, fs.WriteFile(ctx, "/test/packages/extra/dot-config/nvim/extra.lua", []byte("extra"), 0644))
+
+	client, err := dot.NewClient(dot.Config{
+		PackageDir: "/test/packages",
+		TargetDir:  "/test/target",
+		Folding:    true,
+		FS:         fs,
+		Logger:     adapters.NewNoopLogger(),
+	})
+	require.NoError(t, err)
+	require.NoError(t, client.Manage(ctx, "nvim"))
+
+	isLink, err := fs.IsSymlink(ctx, "/test/target/.config/nvim")
+	require.NoError(t, err)
+	assert.True(t, isLink, "nvim directory should be folded into one link")
+	target, err := fs.ReadLink(ctx, "/test/target/.config/nvim")
+	require.NoError(t, err)
+	assert.Equal(t, "../../packages/nvim/dot-config/nvim", target)
+
+	status, err := client.Status(ctx, "nvim")
+	require.NoError(t, err)
+	require.Len(t, status.Packages, 1)
+	assert.Equal(t, []string{".config/nvim"}, status.Packages[0].Links)
+
+	// Remanaging keeps the folded link
+	plan, err := client.PlanManage(ctx, "nvim")
+	require.NoError(t, err)
+	assert.Zero(t, plan.Metadata.LinkCount)
+
+	// Another package cannot link files into the folded directory
+	err = client.Manage(ctx, "extra")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "folded")
+	assert.False(t, fs.Exists(ctx, "/test/packages/nvim/dot-config/nvim/extra.lua"))
+}
+
+func TestClient_ManageFolding_KeepsExistingPerFileInstall(t *testing.T) {
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	require.NoError(t, fs.MkdirAll(ctx, "/test/target", 0755))
+	require.NoError(t, fs.MkdirAll(ctx, "/test/packages/nvim/dot-config/nvim/lua", 0755))
+	require.NoError(t, fs.WriteFile(ctx, "/test/packages/nvim/dot-config/nvim/init.lua", []byte("init"), 0644))
+	require.NoError(t, fs.WriteFile(ctx, "/test/packages/nvim/dot-config/nvim/lua/plugins.lua", []byte("plugins"), 0644))
+
+	newClient := func(folding bool) *dot.Client {
+		client, err := dot.NewClient(dot.Config{
+			PackageDir: "/test/packages",
+			TargetDir:  "/test/target",
+			Folding:    folding,
+			FS:         fs,
+			Logger:     adapters.NewNoopLogger(),
+		})
+		require.NoError(t, err)
+		return client
+	}
+
+	// An install made before folding links every file
+	require.NoError(t, newClient(false).Manage(ctx, "nvim"))
+	perFile := []string{".config/nvim/init.lua", ".config/nvim/lua/plugins.lua"}
+
+	// Turning folding on does not change the layout of the install
+	client := newClient(true)
+	plan, err := client.PlanManage(ctx, "nvim")
+	require.NoError(t, err)
+	assert.Empty(t, plan.Operations)
+	var noChanges dot.ErrNoChanges
+	require.ErrorAs(t, client.Manage(ctx, "nvim"), &noChanges)
+
+	isLink, err := fs.IsSymlink(ctx, "/test/target/.config/nvim")
+	require.NoError(t, err)
+	assert.False(t, isLink, "existing directories are not folded")
+	status, err := client.Status(ctx, "nvim")
+	require.NoError(t, err)
+	require.Len(t, status.Packages, 1)
+	assert.ElementsMatch(t, perFile, status.Packages[0].Links)
+}
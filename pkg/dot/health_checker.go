@@ -7,6 +7,8 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/yaklabco/dot/internal/domain"
 )
 
 // LinkHealthResult contains detailed health information for a single link.
@@ -20,15 +22,19 @@ type LinkHealthResult struct {
 
 // HealthChecker provides unified health checking logic for symlinks.
 type HealthChecker struct {
-	fs        FS
-	targetDir string
+	fs         FS
+	packageDir string
+	targetDir  string
 }
 
-// newHealthChecker creates a new health checker instance.
-func newHealthChecker(fs FS, targetDir string) *HealthChecker {
+// newHealthChecker creates a new health checker instance. Links resolving
+// outside packageDir are reported as foreign targets; an empty packageDir
+// disables the check.
+func newHealthChecker(fs FS, packageDir, targetDir string) *HealthChecker {
 	return &HealthChecker{
-		fs:        fs,
-		targetDir: targetDir,
+		fs:         fs,
+		packageDir: packageDir,
+		targetDir:  targetDir,
 	}
 }
 
@@ -83,11 +89,18 @@ func (h *HealthChecker) CheckLink(ctx context.Context, pkgName, linkPath, packag
 	}
 
 	// Resolve target to absolute path
-	var absTarget string
-	if filepath.IsAbs(target) {
-		absTarget = target
-	} else {
-		absTarget = filepath.Join(filepath.Dir(fullPath), target)
+	absTarget := domain.ResolveLinkTarget(fullPath, target)
+
+	// A managed link must point into the package directory, whether or not
+	// its target exists
+	if h.packageDir != "" && !isInPackageDir(absTarget, h.packageDir) {
+		return LinkHealthResult{
+			IsHealthy:  false,
+			IssueType:  IssueForeignTarget,
+			Severity:   SeverityError,
+			Message:    "Link points outside the package directory: " + target,
+			Suggestion: "Run 'dot doctor --fix' to re-point the link at the package or stop tracking it",
+		}
 	}
 
 	// Check if target exists using Stat (follows symlink)
@@ -130,49 +143,67 @@ func (h *HealthChecker) CheckLink(ctx context.Context, pkgName, linkPath, packag
 	}
 }
 
-// CheckPackage validates all symlinks for a package and returns aggregated health status.
-// Returns healthy status and issue type if problems are found.
-func (h *HealthChecker) CheckPackage(ctx context.Context, pkgName string, links []string, packageDir string) (bool, string) {
-	brokenLinks := 0
-	wrongTargets := 0
-	missingLinks := 0
-	permissionIssues := 0
+// packageLinkCounts tallies the unhealthy links of a package by kind.
+type packageLinkCounts struct {
+	broken      int
+	wrongTarget int
+	missing     int
+	permission  int
+}
+
+// total returns the number of unhealthy links.
+func (c packageLinkCounts) total() int {
+	return c.broken + c.wrongTarget + c.missing + c.permission
+}
 
+// countPackageLinks checks every link of a package and tallies the
+// unhealthy ones.
+func (h *HealthChecker) countPackageLinks(ctx context.Context, pkgName string, links []string, packageDir string) packageLinkCounts {
+	var counts packageLinkCounts
 	for _, linkPath := range links {
 		result := h.CheckLink(ctx, pkgName, linkPath, packageDir)
 		if !result.IsHealthy {
 			switch result.IssueType {
 			case IssueBrokenLink:
 				if strings.Contains(result.Message, "does not exist") && !strings.Contains(result.Message, "target") {
-					missingLinks++
+					counts.missing++
 				} else {
-					brokenLinks++
+					counts.broken++
 				}
-			case IssueWrongTarget:
-				wrongTargets++
+			case IssueWrongTarget, IssueForeignTarget:
+				counts.wrongTarget++
 			case IssuePermission:
-				permissionIssues++
+				counts.permission++
 			}
 		}
 	}
+	return counts
+}
+
+// CheckPackage validates all symlinks for a package and returns aggregated health status.
+// Returns healthy status and issue type if problems are found.
+func (h *HealthChecker) CheckPackage(ctx context.Context, pkgName string, links []string, packageDir string) (bool, string) {
+	return packageHealth(h.countPackageLinks(ctx, pkgName, links, packageDir))
+}
 
-	// Determine health status and issue type
-	totalIssues := brokenLinks + wrongTargets + missingLinks + permissionIssues
-	if totalIssues == 0 {
+// packageHealth reports whether a package with the given link counts is
+// healthy, and otherwise its most severe issue.
+func packageHealth(counts packageLinkCounts) (bool, string) {
+	if counts.total() == 0 {
 		return true, ""
 	}
 
 	// Return most specific issue type (prioritize by severity)
-	if brokenLinks > 0 {
+	if counts.broken > 0 {
 		return false, "broken links"
 	}
-	if wrongTargets > 0 {
+	if counts.wrongTarget > 0 {
 		return false, "wrong target"
 	}
-	if missingLinks > 0 {
+	if counts.missing > 0 {
 		return false, "missing links"
 	}
-	if permissionIssues > 0 {
+	if counts.permission > 0 {
 		return false, "permission issues"
 	}
 
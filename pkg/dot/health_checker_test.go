@@ -20,7 +20,7 @@ func TestHealthChecker_CheckLink(t *testing.T) {
 	require.NoError(t, fs.MkdirAll(ctx, targetDir, 0755))
 	require.NoError(t, fs.MkdirAll(ctx, packageDir, 0755))
 
-	checker := newHealthChecker(fs, targetDir)
+	checker := newHealthChecker(fs, "", targetDir)
 
 	t.Run("healthy link", func(t *testing.T) {
 		// Create source file
@@ -92,7 +92,7 @@ func TestHealthChecker_CheckPackage(t *testing.T) {
 	require.NoError(t, fs.MkdirAll(ctx, targetDir, 0755))
 	require.NoError(t, fs.MkdirAll(ctx, packageDir, 0755))
 
-	checker := newHealthChecker(fs, targetDir)
+	checker := newHealthChecker(fs, "", targetDir)
 
 	t.Run("all links healthy", func(t *testing.T) {
 		// Create source files
@@ -162,7 +162,7 @@ func TestHealthChecker_Consistency(t *testing.T) {
 	fullLink := filepath.Join(targetDir, linkPath)
 	require.NoError(t, fs.Symlink(ctx, outsideFile, fullLink))
 
-	checker := newHealthChecker(fs, targetDir)
+	checker := newHealthChecker(fs, "", targetDir)
 
 	// Both CheckLink and CheckPackage should report unhealthy
 	linkResult := checker.CheckLink(ctx, "config", linkPath, packageDir)
@@ -192,21 +192,21 @@ func TestHealthChecker_CheckPackage_PriorityOrder(t *testing.T) {
 
 	t.Run("broken links take priority", func(t *testing.T) {
 		// When both broken links and wrong targets exist, broken links should be reported
-		checker := newHealthChecker(fs, targetDir)
+		checker := newHealthChecker(fs, "", targetDir)
 		healthy, issueType := checker.CheckPackage(ctx, "config", []string{".broken", ".regular"}, packageDir)
 		assert.False(t, healthy)
 		assert.Equal(t, "broken links", issueType)
 	})
 
 	t.Run("wrong target reported when no broken links", func(t *testing.T) {
-		checker := newHealthChecker(fs, targetDir)
+		checker := newHealthChecker(fs, "", targetDir)
 		healthy, issueType := checker.CheckPackage(ctx, "config", []string{".regular"}, packageDir)
 		assert.False(t, healthy)
 		assert.Equal(t, "wrong target", issueType)
 	})
 
 	t.Run("missing links reported when only missing", func(t *testing.T) {
-		checker := newHealthChecker(fs, targetDir)
+		checker := newHealthChecker(fs, "", targetDir)
 		healthy, issueType := checker.CheckPackage(ctx, "config", []string{".missing1", ".missing2"}, packageDir)
 		assert.False(t, healthy)
 		assert.Equal(t, "missing links", issueType)
@@ -231,7 +231,7 @@ func TestHealthChecker_CheckLink_EmptyPackageDir(t *testing.T) {
 	fullLink := filepath.Join(targetDir, linkPath)
 	require.NoError(t, fs.Symlink(ctx, outsideFile, fullLink))
 
-	checker := newHealthChecker(fs, targetDir)
+	checker := newHealthChecker(fs, "", targetDir)
 
 	// With empty packageDir, should skip target location validation
 	result := checker.CheckLink(ctx, "legacy", linkPath, "")
@@ -258,9 +258,37 @@ func TestHealthChecker_CheckLink_RelativeTarget(t *testing.T) {
 	relativeTarget := "../../packages/config/dot-bashrc"
 	require.NoError(t, fs.Symlink(ctx, relativeTarget, fullLink))
 
-	checker := newHealthChecker(fs, targetDir)
+	checker := newHealthChecker(fs, "", targetDir)
 	result := checker.CheckLink(ctx, "config", linkPath, packageDir)
 
 	assert.True(t, result.IsHealthy)
 	assert.Empty(t, result.IssueType)
 }
+
+func TestHealthChecker_CheckLink_ForeignTarget(t *testing.T) {
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	require.NoError(t, fs.MkdirAll(ctx, "/packages/vim", 0755))
+	require.NoError(t, fs.MkdirAll(ctx, "/home", 0755))
+	require.NoError(t, fs.WriteFile(ctx, "/packages/vim/dot-vimrc", []byte("set number"), 0644))
+	require.NoError(t, fs.Symlink(ctx, "../packages/vim/dot-vimrc", "/home/.vimrc"))
+	require.NoError(t, fs.Symlink(ctx, "/packages-old/vim/dot-gvimrc", "/home/.gvimrc"))
+
+	checker := newHealthChecker(fs, "/packages", "/home")
+
+	t.Run("relative link into the package directory", func(t *testing.T) {
+		assert.True(t, checker.CheckLink(ctx, "vim", ".vimrc", "/packages/vim").IsHealthy)
+	})
+
+	t.Run("missing target outside the package directory", func(t *testing.T) {
+		result := checker.CheckLink(ctx, "vim", ".gvimrc", "/packages/vim")
+		assert.False(t, result.IsHealthy)
+		assert.Equal(t, IssueForeignTarget, result.IssueType)
+		assert.Contains(t, result.Message, "/packages-old/vim/dot-gvimrc", "a sibling with a common prefix is outside")
+	})
+
+	t.Run("disabled without a package directory", func(t *testing.T) {
+		result := newHealthChecker(fs, "", "/home").CheckLink(ctx, "vim", ".gvimrc", "")
+		assert.Equal(t, IssueBrokenLink, result.IssueType)
+	})
+}
@@ -0,0 +1,194 @@
+package dot
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+)
+
+// HookStage names the point in an operation at which a hook runs.
+type HookStage string
+
+const (
+	// HookPreManage runs before packages are managed.
+	HookPreManage HookStage = "pre_manage"
+	// HookPostManage runs after packages are managed.
+	HookPostManage HookStage = "post_manage"
+	// HookPreUnmanage runs before packages are unmanaged.
+	HookPreUnmanage HookStage = "pre_unmanage"
+	// HookPostUnmanage runs after packages are unmanaged.
+	HookPostUnmanage HookStage = "post_unmanage"
+)
+
+// Hook is a command run before or after an operation on a package.
+type Hook struct {
+	// Command is the program and its arguments. The arguments are passed
+	// to the program as given, without shell expansion.
+	Command []string
+
+	// Shell runs Command[0] as a script with sh -c (cmd /C on Windows).
+	// Command must then hold exactly one element.
+	Shell bool
+
+	// Packages limits the hook to these packages. Empty means every
+	// package.
+	Packages []string
+}
+
+// Hooks lists the commands run at each stage of manage and unmanage.
+//
+// Each hook runs once per package it applies to, in the target directory,
+// with DOT_PACKAGE, DOT_HOOK, DOT_PACKAGE_DIR and DOT_TARGET_DIR set in its
+// environment. A failing pre hook aborts the operation with ErrHookFailed;
+// a failing post hook is logged as a warning.
+type Hooks struct {
+	PreManage    []Hook
+	PostManage   []Hook
+	PreUnmanage  []Hook
+	PostUnmanage []Hook
+}
+
+// stage returns the hooks configured for stage.
+func (h Hooks) stage(stage HookStage) []Hook {
+	switch stage {
+	case HookPreManage:
+		return h.PreManage
+	case HookPostManage:
+		return h.PostManage
+	case HookPreUnmanage:
+		return h.PreUnmanage
+	case HookPostUnmanage:
+		return h.PostUnmanage
+	default:
+		return nil
+	}
+}
+
+// Validate checks that every hook has a command and that shell hooks hold
+// a single script.
+func (h Hooks) Validate() error {
+	for _, stage := range []HookStage{HookPreManage, HookPostManage, HookPreUnmanage, HookPostUnmanage} {
+		for i, hook := range h.stage(stage) {
+			if len(hook.Command) == 0 || hook.Command[0] == "" {
+				return fmt.Errorf("%s hook %d has no command", stage, i)
+			}
+			if hook.Shell && len(hook.Command) != 1 {
+				return fmt.Errorf("%s hook %d: a shell hook takes a single script, got %d arguments", stage, i, len(hook.Command))
+			}
+		}
+	}
+	return nil
+}
+
+// appliesTo reports whether the hook runs for pkg.
+func (h Hook) appliesTo(pkg string) bool {
+	return len(h.Packages) == 0 || slices.Contains(h.Packages, pkg)
+}
+
+// argv returns the arguments to run the hook with.
+func (h Hook) argv() []string {
+	if !h.Shell {
+		return h.Command
+	}
+	if runtime.GOOS == "windows" {
+		return []string{"cmd", "/C", h.Command[0]}
+	}
+	return []string{"sh", "-c", h.Command[0]}
+}
+
+// hookRunner runs configured hooks around manage and unmanage.
+type hookRunner struct {
+	hooks      Hooks
+	runner     CommandRunner
+	logger     Logger
+	packageDir string
+	targetDir  string
+	stdout     io.Writer
+	stderr     io.Writer
+	dryRun     bool
+}
+
+// runPre runs the hooks for stage for each package, stopping at the first
+// failure.
+func (r *hookRunner) runPre(ctx context.Context, stage HookStage, packages []string) error {
+	if r == nil {
+		return nil
+	}
+	for _, pkg := range packages {
+		for _, hook := range r.hooks.stage(stage) {
+			if err := r.run(ctx, stage, hook, pkg); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// runPost runs the hooks for stage for each package. Failures are logged
+// and do not stop the remaining hooks.
+func (r *hookRunner) runPost(ctx context.Context, stage HookStage, packages []string) {
+	if r == nil {
+		return
+	}
+	for _, pkg := range packages {
+		for _, hook := range r.hooks.stage(stage) {
+			if err := r.run(ctx, stage, hook, pkg); err != nil {
+				r.logger.Warn(ctx, "hook_failed", "stage", string(stage), "package", pkg, "error", err)
+			}
+		}
+	}
+}
+
+// run runs hook for pkg if it applies to the package.
+func (r *hookRunner) run(ctx context.Context, stage HookStage, hook Hook, pkg string) error {
+	if !hook.appliesTo(pkg) {
+		return nil
+	}
+	command := strings.Join(hook.Command, " ")
+	if r.dryRun {
+		r.logger.Info(ctx, "dry_run_skip_hook", "stage", string(stage), "package", pkg, "command", command)
+		return nil
+	}
+
+	r.logger.Debug(ctx, "running_hook", "stage", string(stage), "package", pkg, "command", command)
+	err := r.runner.Run(ctx, Command{
+		Args: hook.argv(),
+		Env: []string{
+			"DOT_PACKAGE=" + pkg,
+			"DOT_HOOK=" + string(stage),
+			"DOT_PACKAGE_DIR=" + filepath.Join(r.packageDir, pkg),
+			"DOT_TARGET_DIR=" + r.targetDir,
+		},
+		Dir:    r.targetDir,
+		Stdout: r.stdout,
+		Stderr: r.stderr,
+	})
+	if err != nil {
+		return ErrHookFailed{Stage: stage, Package: pkg, Command: hook.Command, Err: err}
+	}
+	return nil
+}
+
+// newHookRunner returns a runner for the hooks in cfg, or nil if none are
+// configured.
+func newHookRunner(cfg Config) *hookRunner {
+	h := cfg.Hooks
+	if len(h.PreManage)+len(h.PostManage)+len(h.PreUnmanage)+len(h.PostUnmanage) == 0 {
+		return nil
+	}
+	return &hookRunner{
+		hooks:      h,
+		runner:     cfg.CommandRunner,
+		logger:     cfg.Logger,
+		packageDir: cfg.PackageDir,
+		targetDir:  cfg.TargetDir,
+		stdout:     cfg.GetStdout(),
+		stderr:     os.Stderr,
+		dryRun:     cfg.DryRun,
+	}
+}
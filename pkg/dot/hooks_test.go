@@ -0,0 +1,181 @@
+package dot_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yaklabco/dot/internal/adapters"
+	"github.com/yaklabco/dot/pkg/dot"
+)
+
+// recordingRunner records the commands it is asked to run and fails those
+// whose first argument is in fail.
+type recordingRunner struct {
+	commands []dot.Command
+	fail     map[string]bool
+}
+
+func (r *recordingRunner) Run(_ context.Context, cmd dot.Command) error {
+	r.commands = append(r.commands, cmd)
+	if r.fail[cmd.Args[0]] {
+		return errors.New("exit status 1")
+	}
+	return nil
+}
+
+// args returns the argument vectors of the recorded commands.
+func (r *recordingRunner) args() [][]string {
+	args := make([][]string, 0, len(r.commands))
+	for _, cmd := range r.commands {
+		args = append(args, cmd.Args)
+	}
+	return args
+}
+
+// newHooksClient creates a client over a memory filesystem with vim and
+// gnupg packages, running hooks through runner.
+func newHooksClient(t *testing.T, hooks dot.Hooks, runner dot.CommandRunner, dryRun bool) (*dot.Client, *adapters.MemFS) {
+	t.Helper()
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	require.NoError(t, fs.MkdirAll(ctx, "/test/packages/vim", 0755))
+	require.NoError(t, fs.MkdirAll(ctx, "/test/packages/gnupg", 0755))
+	require.NoError(t, fs.MkdirAll(ctx, "/test/target", 0755))
+	require.NoError(t, fs.WriteFile(ctx, "/test/packages/vim/dot-vimrc", []byte("set number"), 0644))
+	require.NoError(t, fs.WriteFile(ctx, "/test/packages/gnupg/dot-gpg.conf", []byte("armor"), 0644))
+
+	client, err := dot.NewClient(dot.Config{
+		PackageDir:    "/test/packages",
+		TargetDir:     "/test/target",
+		DryRun:        dryRun,
+		Hooks:         hooks,
+		CommandRunner: runner,
+		FS:            fs,
+		Logger:        adapters.NewNoopLogger(),
+	})
+	require.NoError(t, err)
+	return client, fs
+}
+
+func TestClient_Manage_Hooks(t *testing.T) {
+	ctx := context.Background()
+	runner := &recordingRunner{}
+	hooks := dot.Hooks{
+		PreManage:  []dot.Hook{{Command: []string{"check"}}},
+		PostManage: []dot.Hook{{Command: []string{"gpg", "--import", "$HOME/key"}, Packages: []string{"gnupg"}}},
+	}
+	client, fs := newHooksClient(t, hooks, runner, false)
+
+	require.NoError(t, client.Manage(ctx, "vim", "gnupg"))
+
+	assert.Equal(t, [][]string{
+		{"check"},
+		{"check"},
+		{"gpg", "--import", "$HOME/key"},
+	}, runner.args(), "arguments are passed as given, post hooks only for their packages")
+	assert.ElementsMatch(t, []string{
+		"DOT_PACKAGE=gnupg",
+		"DOT_HOOK=post_manage",
+		"DOT_PACKAGE_DIR=/test/packages/gnupg",
+		"DOT_TARGET_DIR=/test/target",
+	}, runner.commands[2].Env)
+	assert.Equal(t, "/test/target", runner.commands[2].Dir)
+	assert.True(t, fs.Exists(ctx, "/test/target/.gpg.conf"))
+}
+
+func TestClient_Manage_PreHookFailureAborts(t *testing.T) {
+	ctx := context.Background()
+	runner := &recordingRunner{fail: map[string]bool{"check": true}}
+	hooks := dot.Hooks{
+		PreManage:  []dot.Hook{{Command: []string{"check"}, Packages: []string{"vim"}}},
+		PostManage: []dot.Hook{{Command: []string{"after"}}},
+	}
+	client, fs := newHooksClient(t, hooks, runner, false)
+
+	err := client.Manage(ctx, "vim")
+	var hookErr dot.ErrHookFailed
+	require.ErrorAs(t, err, &hookErr)
+	assert.Equal(t, dot.HookPreManage, hookErr.Stage)
+	assert.Equal(t, "vim", hookErr.Package)
+	assert.False(t, fs.Exists(ctx, "/test/target/.vimrc"), "nothing is linked")
+	assert.Equal(t, [][]string{{"check"}}, runner.args(), "post hooks do not run")
+}
+
+func TestClient_Manage_PostHookFailureWarns(t *testing.T) {
+	ctx := context.Background()
+	runner := &recordingRunner{fail: map[string]bool{"after": true}}
+	hooks := dot.Hooks{PostManage: []dot.Hook{{Command: []string{"after"}}, {Command: []string{"next"}}}}
+	client, fs := newHooksClient(t, hooks, runner, false)
+
+	require.NoError(t, client.Manage(ctx, "vim"))
+	assert.True(t, fs.Exists(ctx, "/test/target/.vimrc"))
+	assert.Equal(t, [][]string{{"after"}, {"next"}}, runner.args(), "later hooks still run")
+}
+
+func TestClient_Unmanage_Hooks(t *testing.T) {
+	ctx := context.Background()
+	runner := &recordingRunner{}
+	hooks := dot.Hooks{
+		PreUnmanage:  []dot.Hook{{Command: []string{"gpgconf --kill gpg-agent"}, Shell: true}},
+		PostUnmanage: []dot.Hook{{Command: []string{"after"}}},
+	}
+	client, fs := newHooksClient(t, hooks, runner, false)
+	require.NoError(t, client.Manage(ctx, "gnupg"))
+	require.Empty(t, runner.commands)
+
+	require.NoError(t, client.Unmanage(ctx, "gnupg"))
+	require.Len(t, runner.commands, 2)
+	assert.Equal(t, "gpgconf --kill gpg-agent", runner.commands[0].Args[len(runner.commands[0].Args)-1], "a shell hook passes its script to the shell")
+	assert.Contains(t, runner.commands[0].Env, "DOT_HOOK=pre_unmanage")
+	assert.Equal(t, []string{"after"}, runner.commands[1].Args)
+	assert.False(t, fs.Exists(ctx, "/test/target/.gpg.conf"))
+
+	t.Run("pre hook failure keeps the package", func(t *testing.T) {
+		runner := &recordingRunner{fail: map[string]bool{"check": true}}
+		client, fs := newHooksClient(t, dot.Hooks{PreUnmanage: []dot.Hook{{Command: []string{"check"}}}}, runner, false)
+		require.NoError(t, client.Manage(ctx, "vim"))
+
+		require.ErrorAs(t, client.Unmanage(ctx, "vim"), new(dot.ErrHookFailed))
+		assert.True(t, fs.Exists(ctx, "/test/target/.vimrc"))
+	})
+}
+
+func TestClient_Manage_HooksSkippedInDryRun(t *testing.T) {
+	ctx := context.Background()
+	runner := &recordingRunner{}
+	hooks := dot.Hooks{
+		PreManage:  []dot.Hook{{Command: []string{"before"}}},
+		PostManage: []dot.Hook{{Command: []string{"after"}}},
+	}
+	client, _ := newHooksClient(t, hooks, runner, true)
+
+	require.NoError(t, client.Manage(ctx, "vim"))
+	assert.Empty(t, runner.commands)
+}
+
+func TestHooks_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		hooks   dot.Hooks
+		wantErr string
+	}{
+		{name: "valid", hooks: dot.Hooks{PreManage: []dot.Hook{{Command: []string{"true"}}, {Command: []string{"echo hi"}, Shell: true}}}},
+		{name: "empty command", hooks: dot.Hooks{PostUnmanage: []dot.Hook{{}}}, wantErr: "post_unmanage hook 0 has no command"},
+		{name: "shell with arguments", hooks: dot.Hooks{PreManage: []dot.Hook{{Command: []string{"echo", "hi"}, Shell: true}}}, wantErr: "single script"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.hooks.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
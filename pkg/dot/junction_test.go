@@ -0,0 +1,58 @@
+package dot_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yaklabco/dot/internal/adapters"
+	"github.com/yaklabco/dot/internal/domain"
+	"github.com/yaklabco/dot/internal/manifest"
+	"github.com/yaklabco/dot/pkg/dot"
+)
+
+// junctionMemFS reports the given paths as junctions, as the OS filesystem
+// does on Windows when it falls back from symbolic links.
+type junctionMemFS struct {
+	*adapters.MemFS
+	junctions map[string]bool
+}
+
+func (j *junctionMemFS) LinkKind(ctx context.Context, name string) (domain.LinkKind, error) {
+	if j.junctions[name] {
+		return domain.LinkJunction, nil
+	}
+	return domain.LinkSymlink, nil
+}
+
+func TestClient_RecordsJunctions(t *testing.T) {
+	ctx := context.Background()
+	mem := adapters.NewMemFS()
+	setupTestFixtures(t, mem, "vim")
+	require.NoError(t, mem.MkdirAll(ctx, "/test/packages/zsh", 0755))
+	require.NoError(t, mem.WriteFile(ctx, "/test/packages/zsh/dot-zshrc", []byte("x"), 0644))
+
+	fs := &junctionMemFS{MemFS: mem, junctions: map[string]bool{"/test/target/.zshrc": true}}
+	client, err := dot.NewClient(dot.Config{
+		PackageDir: "/test/packages",
+		TargetDir:  "/test/target",
+		FS:         fs,
+		Logger:     adapters.NewNoopLogger(),
+	})
+	require.NoError(t, err)
+	require.NoError(t, client.Manage(ctx, "vim", "zsh"))
+
+	targetPath := dot.NewTargetPath("/test/target").Unwrap()
+	result := manifest.NewFSManifestStore(mem).Load(ctx, targetPath)
+	require.True(t, result.IsOk())
+	m := result.Unwrap()
+	assert.Empty(t, m.Packages["vim"].Junctions)
+	assert.Equal(t, []string{".zshrc"}, m.Packages["zsh"].Junctions)
+
+	report, err := client.Doctor(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Statistics.Junctions)
+	assert.Equal(t, 2, report.Statistics.ManagedLinks)
+}
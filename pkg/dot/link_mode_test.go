@@ -0,0 +1,95 @@
+package dot_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yaklabco/dot/internal/adapters"
+	"github.com/yaklabco/dot/pkg/dot"
+)
+
+func TestClient_LinkMode(t *testing.T) {
+	tests := []struct {
+		name       string
+		mode       dot.LinkMode
+		packageDir string
+		want       string
+	}{
+		{"relative", dot.LinkRelative, "/opt/dotfiles", "../../opt/dotfiles/vim/dot-vimrc"},
+		{"absolute", dot.LinkAbsolute, "/home/user/dotfiles", "/home/user/dotfiles/vim/dot-vimrc"},
+		{"auto within shared base", dot.LinkAuto, "/home/user/dotfiles", "dotfiles/vim/dot-vimrc"},
+		{"auto across bases", dot.LinkAuto, "/opt/dotfiles", "/opt/dotfiles/vim/dot-vimrc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			fs := adapters.NewMemFS()
+			require.NoError(t, fs.MkdirAll(ctx, tt.packageDir+"/vim", 0755))
+			require.NoError(t, fs.WriteFile(ctx, tt.packageDir+"/vim/dot-vimrc", []byte("set nu"), 0644))
+			require.NoError(t, fs.MkdirAll(ctx, "/home/user", 0755))
+
+			client, err := dot.NewClient(dot.Config{
+				PackageDir: tt.packageDir,
+				TargetDir:  "/home/user",
+				LinkMode:   tt.mode,
+				FS:         fs,
+				Logger:     adapters.NewNoopLogger(),
+			})
+			require.NoError(t, err)
+			require.NoError(t, client.Manage(ctx, "vim"))
+
+			target, err := fs.ReadLink(ctx, "/home/user/.vimrc")
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, target)
+
+			// A link in the chosen form is recognized as already correct
+			plan, err := client.PlanManage(ctx, "vim")
+			require.NoError(t, err)
+			assert.Empty(t, plan.Operations)
+		})
+	}
+}
+
+func TestClient_LinkMode_KeepsAbsoluteInstallUnderDefault(t *testing.T) {
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	require.NoError(t, fs.MkdirAll(ctx, "/opt/dotfiles/vim", 0755))
+	require.NoError(t, fs.WriteFile(ctx, "/opt/dotfiles/vim/dot-vimrc", []byte("set nu"), 0644))
+	require.NoError(t, fs.MkdirAll(ctx, "/home/user", 0755))
+
+	// Installed by a release that always created absolute links
+	legacy, err := dot.NewClient(dot.Config{
+		PackageDir: "/opt/dotfiles",
+		TargetDir:  "/home/user",
+		LinkMode:   dot.LinkAbsolute,
+		FS:         fs,
+		Logger:     adapters.NewNoopLogger(),
+	})
+	require.NoError(t, err)
+	require.NoError(t, legacy.Manage(ctx, "vim"))
+
+	client, err := dot.NewClient(dot.Config{
+		PackageDir: "/opt/dotfiles",
+		TargetDir:  "/home/user",
+		FS:         fs,
+		Logger:     adapters.NewNoopLogger(),
+	})
+	require.NoError(t, err)
+
+	plan, err := client.PlanManage(ctx, "vim")
+	require.NoError(t, err)
+	assert.Empty(t, plan.Operations)
+
+	status, err := client.Status(ctx, "vim")
+	require.NoError(t, err)
+	require.Len(t, status.Packages, 1)
+	assert.True(t, status.Packages[0].IsHealthy)
+
+	target, err := fs.ReadLink(ctx, "/home/user/.vimrc")
+	require.NoError(t, err)
+	assert.Equal(t, "/opt/dotfiles/vim/dot-vimrc", target)
+}
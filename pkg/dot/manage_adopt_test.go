@@ -0,0 +1,182 @@
+package dot_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yaklabco/dot/internal/adapters"
+	"github.com/yaklabco/dot/pkg/dot"
+)
+
+// newAdoptClient creates a client over a memory filesystem with a vim
+// package whose vimrc conflicts with an existing ~/.vimrc holding existing.
+func newAdoptClient(t *testing.T, existing string) (*dot.Client, *adapters.MemFS) {
+	t.Helper()
+	return newAdoptClientWithDryRun(t, existing, false)
+}
+
+// newAdoptClientWithDryRun is newAdoptClient with the client's dry-run mode
+// set to dryRun.
+func newAdoptClientWithDryRun(t *testing.T, existing string, dryRun bool) (*dot.Client, *adapters.MemFS) {
+	t.Helper()
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	require.NoError(t, fs.MkdirAll(ctx, "/test/packages/vim", 0755))
+	require.NoError(t, fs.MkdirAll(ctx, "/test/target", 0755))
+	require.NoError(t, fs.WriteFile(ctx, "/test/packages/vim/dot-vimrc", []byte("set number"), 0644))
+	require.NoError(t, fs.WriteFile(ctx, "/test/packages/vim/dot-gvimrc", []byte("set guifont"), 0644))
+	require.NoError(t, fs.WriteFile(ctx, "/test/target/.vimrc", []byte(existing), 0600))
+
+	client, err := dot.NewClient(dot.Config{
+		PackageDir: "/test/packages",
+		TargetDir:  "/test/target",
+		FS:         fs,
+		Logger:     adapters.NewNoopLogger(),
+		DryRun:     dryRun,
+	})
+	require.NoError(t, err)
+	return client, fs
+}
+
+// backupFiles lists the files in the run subdirectories of the default
+// backup directory.
+func backupFiles(t *testing.T, fs *adapters.MemFS) map[string]string {
+	t.Helper()
+	ctx := context.Background()
+	runs, err := fs.ReadDir(ctx, "/test/target/.dot-backup")
+	require.NoError(t, err)
+	files := make(map[string]string)
+	for _, run := range runs {
+		require.True(t, run.IsDir(), "backups are written to a run subdirectory")
+		runDir := filepath.Join("/test/target/.dot-backup", run.Name())
+		entries, err := fs.ReadDir(ctx, runDir)
+		require.NoError(t, err)
+		for _, entry := range entries {
+			data, err := fs.ReadFile(ctx, filepath.Join(runDir, entry.Name()))
+			require.NoError(t, err)
+			files[entry.Name()] = string(data)
+		}
+	}
+	return files
+}
+
+func TestClient_ManageWithOptions_AdoptIdenticalFile(t *testing.T) {
+	ctx := context.Background()
+	client, fs := newAdoptClient(t, "set number")
+
+	require.NoError(t, client.ManageWithOptions(ctx, dot.ManageOptions{Adopt: true}, "vim"))
+
+	isLink, err := fs.IsSymlink(ctx, "/test/target/.vimrc")
+	require.NoError(t, err)
+	assert.True(t, isLink)
+	data, err := fs.ReadFile(ctx, "/test/packages/vim/dot-vimrc")
+	require.NoError(t, err)
+	assert.Equal(t, "set number", string(data))
+
+	backups := backupFiles(t, fs)
+	require.Len(t, backups, 1, "the existing copy is set aside")
+	for _, content := range backups {
+		assert.Equal(t, "set number", content)
+	}
+
+	info := recordedPackage(t, fs, "vim")
+	assert.ElementsMatch(t, []string{".vimrc", ".gvimrc"}, info.Links)
+
+	records, err := client.Resolutions(ctx, "vim")
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "adopt", records[0].Policy)
+	assert.Equal(t, "/test/target/.vimrc", records[0].Path)
+}
+
+func TestClient_ManageWithOptions_AdoptDifferentFile(t *testing.T) {
+	t.Run("conflict without replace", func(t *testing.T) {
+		ctx := context.Background()
+		client, fs := newAdoptClient(t, "set relativenumber")
+
+		err := client.ManageWithOptions(ctx, dot.ManageOptions{Adopt: true}, "vim")
+		var conflictErr dot.ErrConflict
+		require.ErrorAs(t, err, &conflictErr)
+		assert.Contains(t, conflictErr.Reason, "differs from the package version")
+
+		data, err := fs.ReadFile(ctx, "/test/target/.vimrc")
+		require.NoError(t, err)
+		assert.Equal(t, "set relativenumber", string(data), "the existing file is untouched")
+		assert.False(t, fs.Exists(ctx, "/test/target/.dot-backup"))
+	})
+
+	t.Run("replace", func(t *testing.T) {
+		ctx := context.Background()
+		client, fs := newAdoptClient(t, "set relativenumber")
+
+		opts := dot.ManageOptions{Adopt: true, AdoptReplace: true}
+		require.NoError(t, client.ManageWithOptions(ctx, opts, "vim"))
+
+		isLink, err := fs.IsSymlink(ctx, "/test/target/.vimrc")
+		require.NoError(t, err)
+		assert.True(t, isLink)
+		data, err := fs.ReadFile(ctx, "/test/packages/vim/dot-vimrc")
+		require.NoError(t, err)
+		assert.Equal(t, "set relativenumber", string(data), "the package holds the adopted file")
+
+		backups := backupFiles(t, fs)
+		require.Len(t, backups, 1)
+		for _, content := range backups {
+			assert.Equal(t, "set number", content, "the package's version is set aside")
+		}
+
+		// The manifest records the package with the adopted content
+		require.ErrorAs(t, client.ManageWithOptions(ctx, dot.ManageOptions{}, "vim"), new(dot.ErrNoChanges))
+	})
+}
+
+func TestClient_ManageWithOptions_AdoptDryRun(t *testing.T) {
+	ctx := context.Background()
+	client, fs := newAdoptClientWithDryRun(t, "set relativenumber", true)
+
+	opts := dot.ManageOptions{Adopt: true, AdoptReplace: true}
+	plan, err := client.PlanManageWithOptions(ctx, opts, "vim")
+	require.NoError(t, err)
+	require.Empty(t, plan.Metadata.Conflicts, "the existing dotfile is planned for adoption")
+
+	require.NoError(t, client.ManageWithOptions(ctx, opts, "vim"))
+
+	isLink, err := fs.IsSymlink(ctx, "/test/target/.vimrc")
+	require.NoError(t, err)
+	assert.False(t, isLink, "dry run leaves the existing file in place")
+	data, err := fs.ReadFile(ctx, "/test/target/.vimrc")
+	require.NoError(t, err)
+	assert.Equal(t, "set relativenumber", string(data))
+	data, err = fs.ReadFile(ctx, "/test/packages/vim/dot-vimrc")
+	require.NoError(t, err)
+	assert.Equal(t, "set number", string(data), "dry run leaves the package untouched")
+	assert.False(t, fs.Exists(ctx, "/test/target/.dot-backup"))
+}
+
+func TestClient_PlanManageWithOptions_AdoptRollsBack(t *testing.T) {
+	ctx := context.Background()
+	client, fs := newAdoptClient(t, "set relativenumber")
+
+	plan, err := client.PlanManageWithOptions(ctx, dot.ManageOptions{Adopt: true, AdoptReplace: true}, "vim")
+	require.NoError(t, err)
+	require.Empty(t, plan.Metadata.Conflicts)
+
+	for _, op := range plan.Operations {
+		require.NoError(t, op.Execute(ctx, fs), op.String())
+	}
+	for i := len(plan.Operations) - 1; i >= 0; i-- {
+		require.NoError(t, plan.Operations[i].Rollback(ctx, fs), plan.Operations[i].String())
+	}
+
+	data, err := fs.ReadFile(ctx, "/test/target/.vimrc")
+	require.NoError(t, err)
+	assert.Equal(t, "set relativenumber", string(data), "rollback restores the existing file")
+	data, err = fs.ReadFile(ctx, "/test/packages/vim/dot-vimrc")
+	require.NoError(t, err)
+	assert.Equal(t, "set number", string(data), "rollback restores the package version")
+	assert.False(t, fs.Exists(ctx, "/test/target/.dot-backup"), "rollback removes the backup directory it created")
+}
@@ -0,0 +1,137 @@
+package dot_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yaklabco/dot/internal/adapters"
+	"github.com/yaklabco/dot/internal/manifest"
+	"github.com/yaklabco/dot/pkg/dot"
+)
+
+// newIncludeClient creates a client over a memory filesystem with an
+// editors package holding nested nvim and helix configs and a vimrc.
+func newIncludeClient(t *testing.T) (*dot.Client, *adapters.MemFS) {
+	t.Helper()
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	require.NoError(t, fs.MkdirAll(ctx, "/test/packages/editors/dot-config/nvim/lua", 0755))
+	require.NoError(t, fs.MkdirAll(ctx, "/test/packages/editors/dot-config/helix", 0755))
+	require.NoError(t, fs.MkdirAll(ctx, "/test/target", 0755))
+	for _, file := range []string{
+		"dot-config/nvim/init.lua",
+		"dot-config/nvim/lua/plugins.lua",
+		"dot-config/helix/config.toml",
+		"dot-vimrc",
+	} {
+		require.NoError(t, fs.WriteFile(ctx, "/test/packages/editors/"+file, []byte(file), 0644))
+	}
+
+	client, err := dot.NewClient(dot.Config{
+		PackageDir: "/test/packages",
+		TargetDir:  "/test/target",
+		FS:         fs,
+		Logger:     adapters.NewNoopLogger(),
+	})
+	require.NoError(t, err)
+	return client, fs
+}
+
+// recordedPackage reads pkg's manifest entry from the target directory.
+func recordedPackage(t *testing.T, fs *adapters.MemFS, pkg string) manifest.PackageInfo {
+	t.Helper()
+	data, err := fs.ReadFile(context.Background(), "/test/target/.dot-manifest.json")
+	require.NoError(t, err)
+	var m manifest.Manifest
+	require.NoError(t, json.Unmarshal(data, &m))
+	info, ok := m.GetPackage(pkg)
+	require.True(t, ok, "package %s not recorded", pkg)
+	return info
+}
+
+func TestClient_ManageWithOptions_IncludeNestedSubtree(t *testing.T) {
+	ctx := context.Background()
+	client, fs := newIncludeClient(t)
+
+	opts := dot.ManageOptions{Include: []string{".config/nvim"}}
+	require.NoError(t, client.ManageWithOptions(ctx, opts, "editors"))
+
+	for _, link := range []string{"/test/target/.config/nvim/init.lua", "/test/target/.config/nvim/lua/plugins.lua"} {
+		isLink, err := fs.IsSymlink(ctx, link)
+		require.NoError(t, err)
+		assert.True(t, isLink, "%s should be linked", link)
+	}
+	for _, path := range []string{"/test/target/.config/helix", "/test/target/.vimrc"} {
+		assert.False(t, fs.Exists(ctx, path), "%s should not be linked", path)
+	}
+
+	info := recordedPackage(t, fs, "editors")
+	assert.True(t, info.Partial())
+	assert.Equal(t, []string{".config/nvim"}, info.Include)
+	assert.ElementsMatch(t, []string{".config/nvim/init.lua", ".config/nvim/lua/plugins.lua"}, info.Links)
+
+	diff, err := client.Diff(ctx, "editors")
+	require.NoError(t, err)
+	assert.Equal(t, 2, diff.InSync)
+	assert.False(t, diff.HasDrift(), "excluded files are not drift: %v", diff.Drift)
+}
+
+func TestClient_ManageWithOptions_IncludeWildcard(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newIncludeClient(t)
+
+	plan, err := client.PlanManageWithOptions(ctx, dot.ManageOptions{Include: []string{".config/*/*.toml", ".vimrc"}}, "editors")
+	require.NoError(t, err)
+
+	var targets []string
+	for _, op := range plan.Operations {
+		if link, ok := op.(dot.LinkCreate); ok {
+			targets = append(targets, link.Target.String())
+		}
+	}
+	assert.ElementsMatch(t, []string{"/test/target/.config/helix/config.toml", "/test/target/.vimrc"}, targets)
+}
+
+func TestClient_Remanage_KeepsInclude(t *testing.T) {
+	ctx := context.Background()
+	client, fs := newIncludeClient(t)
+	require.NoError(t, client.ManageWithOptions(ctx, dot.ManageOptions{Include: []string{".config/nvim"}}, "editors"))
+
+	// A changed package forces a full remanage
+	require.NoError(t, fs.WriteFile(ctx, "/test/packages/editors/dot-config/nvim/lua/keys.lua", []byte("keys"), 0644))
+	require.NoError(t, client.Remanage(ctx, "editors"))
+
+	isLink, err := fs.IsSymlink(ctx, "/test/target/.config/nvim/lua/keys.lua")
+	require.NoError(t, err)
+	assert.True(t, isLink)
+	assert.False(t, fs.Exists(ctx, "/test/target/.vimrc"), "remanage must not link excluded files")
+	assert.Equal(t, []string{".config/nvim"}, recordedPackage(t, fs, "editors").Include)
+}
+
+func TestClient_Manage_FullClearsInclude(t *testing.T) {
+	ctx := context.Background()
+	client, fs := newIncludeClient(t)
+	require.NoError(t, client.ManageWithOptions(ctx, dot.ManageOptions{Include: []string{".config/nvim"}}, "editors"))
+	require.NoError(t, client.Manage(ctx, "editors"))
+
+	info := recordedPackage(t, fs, "editors")
+	assert.False(t, info.Partial())
+	assert.Len(t, info.Links, 4)
+}
+
+func TestClient_DoctorListOrphans_PartialPackage(t *testing.T) {
+	ctx := context.Background()
+	client, fs := newIncludeClient(t)
+	require.NoError(t, client.ManageWithOptions(ctx, dot.ManageOptions{Include: []string{".config/nvim"}}, "editors"))
+
+	// The user links a file the include left out themselves
+	require.NoError(t, fs.Symlink(ctx, "/test/packages/editors/dot-vimrc", "/test/target/.vimrc"))
+
+	orphans, err := client.DoctorListOrphans(ctx, dot.DeepScanConfig(5))
+	require.NoError(t, err)
+	assert.Empty(t, orphans)
+}
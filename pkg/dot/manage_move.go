@@ -0,0 +1,261 @@
+package dot
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/yaklabco/dot/internal/domain"
+	"github.com/yaklabco/dot/internal/manifest"
+)
+
+// packageMove describes a planned package rename: the plan that carries
+// it out and the links it leaves, relative to the target directory.
+type packageMove struct {
+	plan Plan
+	// links maps each of the package's links to the link replacing it.
+	links map[string]string
+	// backups maps the absolute target path of each recorded backup to
+	// the path of the link that now stands there.
+	backups map[string]string
+	// sources maps each replacement link to its source in the renamed
+	// package directory.
+	sources map[string]string
+}
+
+// MovePackage renames package oldName to newName. The package directory is
+// renamed and, if the package is managed, its links are replaced by links
+// into the renamed directory at the paths the new name maps to, which
+// differ from the old paths when package name mapping or a package mapping
+// applies. The manifest entry moves to the new name.
+//
+// The links and the directory are changed in one transaction: if any step
+// fails, the directory is renamed back and the original links restored.
+// MovePackage refuses with ErrPackageExists if newName is already a package
+// directory or a managed package.
+func (s *ManageService) MovePackage(ctx context.Context, oldName, newName string) error {
+	for _, name := range []string{oldName, newName} {
+		if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+			return fmt.Errorf("invalid package name %q", name)
+		}
+	}
+	if oldName == newName {
+		return fmt.Errorf("package %s already has that name", oldName)
+	}
+	s.logger.Info(ctx, "moving_package", "from", oldName, "to", newName)
+
+	targetPathResult := NewTargetPath(s.targetDir)
+	if !targetPathResult.IsOk() {
+		return targetPathResult.UnwrapErr()
+	}
+	targetPath := targetPathResult.Unwrap()
+
+	m := manifest.New()
+	if manifestResult := s.manifestSvc.Load(ctx, targetPath); manifestResult.IsOk() {
+		m = manifestResult.Unwrap()
+	} else if err := manifestResult.UnwrapErr(); !isManifestNotFoundError(err) {
+		return err
+	}
+
+	oldRoot := filepath.Join(s.packageDir, oldName)
+	newRoot := filepath.Join(s.packageDir, newName)
+	if isDir, err := s.fs.IsDir(ctx, oldRoot); err != nil || !isDir {
+		return domain.ErrPackageNotFound{Package: oldName}
+	}
+	if _, managed := m.GetPackage(newName); managed || s.fs.Exists(ctx, newRoot) {
+		return ErrPackageExists{Package: newName}
+	}
+
+	pkgInfo, managed := m.GetPackage(oldName)
+	move, err := s.planMove(ctx, pkgInfo, oldName, newName)
+	if err != nil {
+		return err
+	}
+
+	if s.dryRun {
+		s.logger.Info(ctx, "dry_run_plan", "operations", len(move.plan.Operations))
+		return nil
+	}
+
+	result := s.executor.Execute(ctx, move.plan)
+	if !result.IsOk() {
+		return result.UnwrapErr()
+	}
+	if execResult := result.Unwrap(); !execResult.Success() {
+		return ErrMultiple{Errors: execResult.Errors}
+	}
+
+	if !managed {
+		s.logger.Info(ctx, "move_complete", "from", oldName, "to", newName)
+		return nil
+	}
+
+	// Directories that held only the old links are no longer needed
+	s.unmanageSvc.cleanEmptyParentDirs(ctx, m, []string{oldName})
+
+	hash, hashed := m.GetHash(oldName)
+	m.RemovePackage(oldName)
+	m.AddPackage(movedPackageInfo(ctx, s.manifestSvc, pkgInfo, move, newName, newRoot, s.targetDir))
+	m.AddCreatedDirs(createdDirs(move.plan.Operations, s.targetDir)...)
+	if hashed {
+		// The package's files are unchanged, only their location
+		m.SetHash(newName, hash)
+	}
+	if err := s.manifestSvc.Save(ctx, targetPath, m); err != nil {
+		return fmt.Errorf("manifest update failed: %w", err)
+	}
+	s.logger.Info(ctx, "move_complete", "from", oldName, "to", newName, "links", len(move.links))
+	return nil
+}
+
+// planMove builds the operations that rename the package directory and
+// relink pkgInfo's links into it. The old links are deleted before the
+// directory moves and the new links created after it, so rolling back in
+// reverse restores the original state.
+func (s *ManageService) planMove(ctx context.Context, pkgInfo manifest.PackageInfo, oldName, newName string) (packageMove, error) {
+	oldRoot := filepath.Join(s.packageDir, oldName)
+	newRoot := filepath.Join(s.packageDir, newName)
+	move := packageMove{links: make(map[string]string, len(pkgInfo.Links)), backups: make(map[string]string), sources: make(map[string]string, len(pkgInfo.Links))}
+
+	targetResult := NewTargetPath(s.targetDir)
+	if targetResult.IsErr() {
+		return packageMove{}, targetResult.UnwrapErr()
+	}
+	oldTarget, err := s.managePipe.PackageTarget(oldName, targetResult.Unwrap())
+	if err != nil {
+		return packageMove{}, err
+	}
+	newTarget, err := s.managePipe.PackageTarget(newName, targetResult.Unwrap())
+	if err != nil {
+		return packageMove{}, err
+	}
+
+	var unlinks, dirs, relinks []Operation
+	created := make(map[string]bool)
+	for _, link := range pkgInfo.Links {
+		linkPath := filepath.Join(s.targetDir, link)
+		dest, err := s.fs.ReadLink(ctx, linkPath)
+		if err != nil {
+			return packageMove{}, fmt.Errorf("read link %s: %w", link, err)
+		}
+		source := domain.ResolveLinkTarget(linkPath, dest)
+		rel, err := filepath.Rel(oldRoot, source)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return packageMove{}, ErrNotManaged{Package: oldName, Path: link}
+		}
+		within, err := filepath.Rel(oldTarget.String(), linkPath)
+		if err != nil || within == "." || within == ".." || strings.HasPrefix(within, ".."+string(filepath.Separator)) {
+			return packageMove{}, fmt.Errorf("link %s is outside the target of package %s; remanage it before moving", link, oldName)
+		}
+
+		newLinkPath := filepath.Join(newTarget.String(), within)
+		newLink, err := filepath.Rel(s.targetDir, newLinkPath)
+		if err != nil {
+			return packageMove{}, fmt.Errorf("invalid path for %s: %w", link, err)
+		}
+		if newLinkPath != linkPath && !slices.Contains(pkgInfo.Links, newLink) && s.fs.Exists(ctx, newLinkPath) {
+			return packageMove{}, domain.ErrConflict{Path: newLinkPath, Reason: "path already exists"}
+		}
+
+		oldLinkResult := NewTargetPath(linkPath)
+		newLinkResult := NewTargetPath(newLinkPath)
+		newSourceResult := NewFilePath(filepath.Join(newRoot, rel))
+		if oldLinkResult.IsErr() || newLinkResult.IsErr() || newSourceResult.IsErr() {
+			return packageMove{}, fmt.Errorf("invalid path for %s", link)
+		}
+
+		// Restoring the original link lets a failed move roll back cleanly
+		unlink := NewLinkDelete(OperationID("move-unlink-"+link), oldLinkResult.Unwrap())
+		unlink.Original = dest
+		unlinks = append(unlinks, unlink)
+
+		dirs = append(dirs, s.missingParents(ctx, newLinkPath, created)...)
+
+		relink := NewLinkCreate(OperationID("move-link-"+newLink), newSourceResult.Unwrap(), newLinkResult.Unwrap())
+		relink.Relative = !filepath.IsAbs(dest)
+		relinks = append(relinks, relink)
+
+		move.links[link] = newLink
+		move.backups[linkPath] = newLinkPath
+		move.sources[newLink] = newSourceResult.Unwrap().String()
+	}
+
+	oldRootResult := NewTargetPath(oldRoot)
+	newRootResult := NewFilePath(newRoot)
+	if oldRootResult.IsErr() || newRootResult.IsErr() {
+		return packageMove{}, fmt.Errorf("invalid package path for %s", newName)
+	}
+	rename := NewFileMove(OperationID("move-package-"+oldName), oldRootResult.Unwrap(), newRootResult.Unwrap())
+
+	operations := make([]Operation, 0, len(unlinks)+1+len(dirs)+len(relinks))
+	operations = append(operations, unlinks...)
+	operations = append(operations, rename)
+	operations = append(operations, dirs...)
+	operations = append(operations, relinks...)
+
+	move.plan = domain.AnnotateRisk(ctx, s.fs, Plan{
+		Operations: operations,
+		Metadata: PlanMetadata{
+			PackageCount:   1,
+			OperationCount: len(operations),
+		},
+	})
+	return move, nil
+}
+
+// missingParents returns operations creating the directories above path,
+// up to the target directory, that do not exist yet, outermost first.
+// Directories already in created are skipped and new ones added to it.
+func (s *ManageService) missingParents(ctx context.Context, path string, created map[string]bool) []Operation {
+	var missing []string
+	for dir := filepath.Dir(path); dir != s.targetDir && strings.HasPrefix(dir, s.targetDir); dir = filepath.Dir(dir) {
+		if created[dir] || s.fs.Exists(ctx, dir) {
+			break
+		}
+		missing = append(missing, dir)
+	}
+
+	ops := make([]Operation, 0, len(missing))
+	for i := len(missing) - 1; i >= 0; i-- {
+		dirResult := NewFilePath(missing[i])
+		if dirResult.IsErr() {
+			continue
+		}
+		created[missing[i]] = true
+		ops = append(ops, NewDirCreate(OperationID("move-dir-"+missing[i]), dirResult.Unwrap()))
+	}
+	return ops
+}
+
+// movedPackageInfo returns pkgInfo renamed to newName, with its links,
+// junctions and backups moved to the paths move relinked them to.
+func movedPackageInfo(ctx context.Context, manifestSvc *ManifestService, pkgInfo manifest.PackageInfo, move packageMove, newName, newRoot, targetDir string) manifest.PackageInfo {
+	links := make([]string, 0, len(pkgInfo.Links))
+	for _, link := range pkgInfo.Links {
+		links = append(links, move.links[link])
+	}
+	slices.Sort(links)
+
+	info := pkgInfo
+	info.Name = newName
+	info.Links = links
+	info.LinkCount = len(links)
+	info.PackageDir = newRoot
+	info.Junctions = manifestSvc.junctionLinks(ctx, targetDir, links)
+	info.Sources = move.sources
+	if pkgInfo.Backups != nil {
+		info.Backups = make(map[string]string, len(pkgInfo.Backups))
+		for path, backup := range pkgInfo.Backups {
+			if moved, ok := move.backups[path]; ok {
+				path = moved
+			}
+			info.Backups[path] = backup
+		}
+	}
+	// The link paths are part of the state hash, so the next manage
+	// recomputes it rather than trusting the old one
+	info.StateHash = ""
+	return info
+}
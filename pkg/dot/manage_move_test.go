@@ -0,0 +1,169 @@
+package dot_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yaklabco/dot/internal/adapters"
+	"github.com/yaklabco/dot/internal/manifest"
+	"github.com/yaklabco/dot/pkg/dot"
+)
+
+// newMoveClient creates a client over fs with a managed package pkg
+// holding a vimrc and a colors file.
+func newMoveClient(t *testing.T, fs dot.FS, pkg string, nameMapping bool) *dot.Client {
+	t.Helper()
+	ctx := context.Background()
+	require.NoError(t, fs.MkdirAll(ctx, "/test/packages/"+pkg+"/colors", 0755))
+	require.NoError(t, fs.MkdirAll(ctx, "/test/target", 0755))
+	require.NoError(t, fs.WriteFile(ctx, "/test/packages/"+pkg+"/dot-vimrc", []byte("set number"), 0644))
+	require.NoError(t, fs.WriteFile(ctx, "/test/packages/"+pkg+"/colors/dark.vim", []byte("hi"), 0644))
+
+	client, err := dot.NewClient(dot.Config{
+		PackageDir:         "/test/packages",
+		TargetDir:          "/test/target",
+		PackageNameMapping: nameMapping,
+		FS:                 fs,
+		Logger:             adapters.NewNoopLogger(),
+	})
+	require.NoError(t, err)
+	require.NoError(t, client.Manage(ctx, pkg))
+	return client
+}
+
+// readManifest reads the manifest from the target directory.
+func readManifest(t *testing.T, fs *adapters.MemFS) manifest.Manifest {
+	t.Helper()
+	data, err := fs.ReadFile(context.Background(), "/test/target/.dot-manifest.json")
+	require.NoError(t, err)
+	var m manifest.Manifest
+	require.NoError(t, json.Unmarshal(data, &m))
+	return m
+}
+
+// assertLink checks that link exists and resolves to dest.
+func assertLink(t *testing.T, fs dot.FS, link, dest string) {
+	t.Helper()
+	got, err := fs.ReadLink(context.Background(), link)
+	require.NoError(t, err, "link %s", link)
+	if !filepath.IsAbs(got) {
+		got = filepath.Join(filepath.Dir(link), got)
+	}
+	assert.Equal(t, dest, got)
+}
+
+func TestClient_MovePackage_SameTargetPaths(t *testing.T) {
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	client := newMoveClient(t, fs, "vim", false)
+	hash := readManifest(t, fs).Hashes["vim"]
+
+	require.NoError(t, client.MovePackage(ctx, "vim", "neovim"))
+
+	assert.False(t, fs.Exists(ctx, "/test/packages/vim"))
+	assert.True(t, fs.Exists(ctx, "/test/packages/neovim/dot-vimrc"))
+	assertLink(t, fs, "/test/target/.vimrc", "/test/packages/neovim/dot-vimrc")
+	assertLink(t, fs, "/test/target/colors/dark.vim", "/test/packages/neovim/colors/dark.vim")
+
+	m := readManifest(t, fs)
+	_, ok := m.GetPackage("vim")
+	assert.False(t, ok, "the old name is no longer recorded")
+	info, ok := m.GetPackage("neovim")
+	require.True(t, ok)
+	assert.ElementsMatch(t, []string{".vimrc", "colors/dark.vim"}, info.Links)
+	assert.Equal(t, "/test/packages/neovim", info.PackageDir)
+	assert.Equal(t, hash, m.Hashes["neovim"], "the content hash moves with the package")
+
+	status, err := client.Status(ctx, "neovim")
+	require.NoError(t, err)
+	require.Len(t, status.Packages, 1)
+	assert.Equal(t, 2, status.Packages[0].LinkCount)
+}
+
+func TestClient_MovePackage_TargetPathsChange(t *testing.T) {
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	client := newMoveClient(t, fs, "dot-vim", true)
+	assertLink(t, fs, "/test/target/.vim/.vimrc", "/test/packages/dot-vim/dot-vimrc")
+
+	require.NoError(t, client.MovePackage(ctx, "dot-vim", "neovim"))
+
+	assertLink(t, fs, "/test/target/neovim/.vimrc", "/test/packages/neovim/dot-vimrc")
+	assertLink(t, fs, "/test/target/neovim/colors/dark.vim", "/test/packages/neovim/colors/dark.vim")
+	assert.False(t, fs.Exists(ctx, "/test/target/.vim"), "directories left empty are removed")
+
+	info := recordedPackage(t, fs, "neovim")
+	assert.ElementsMatch(t, []string{"neovim/.vimrc", "neovim/colors/dark.vim"}, info.Links)
+
+	// Managing under the new name finds everything in place
+	err := client.Manage(ctx, "neovim")
+	assert.True(t, err == nil || errors.As(err, &dot.ErrNoChanges{}), "unexpected error: %v", err)
+	assertLink(t, fs, "/test/target/neovim/.vimrc", "/test/packages/neovim/dot-vimrc")
+}
+
+func TestClient_MovePackage_NameTaken(t *testing.T) {
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	client := newMoveClient(t, fs, "vim", false)
+	require.NoError(t, fs.MkdirAll(ctx, "/test/packages/neovim", 0755))
+
+	err := client.MovePackage(ctx, "vim", "neovim")
+	require.ErrorIs(t, err, dot.ErrPackageExists{})
+
+	assert.True(t, fs.Exists(ctx, "/test/packages/vim/dot-vimrc"))
+	assertLink(t, fs, "/test/target/.vimrc", "/test/packages/vim/dot-vimrc")
+	recordedPackage(t, fs, "vim")
+}
+
+func TestClient_MovePackage_Invalid(t *testing.T) {
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	client := newMoveClient(t, fs, "vim", false)
+
+	assert.Error(t, client.MovePackage(ctx, "vim", "vim"))
+	assert.Error(t, client.MovePackage(ctx, "vim", "../neovim"))
+	assert.ErrorIs(t, client.MovePackage(ctx, "emacs", "neovim"), dot.ErrPackageNotFound{Package: "emacs"})
+}
+
+// failingLinkFS fails to create the symlink at path.
+type failingLinkFS struct {
+	*adapters.MemFS
+	path string
+}
+
+func (f failingLinkFS) Symlink(ctx context.Context, oldname, newname string) error {
+	if newname == f.path {
+		return errors.New("injected symlink failure")
+	}
+	return f.MemFS.Symlink(ctx, oldname, newname)
+}
+
+func TestClient_MovePackage_RollsBack(t *testing.T) {
+	ctx := context.Background()
+	mem := adapters.NewMemFS()
+	newMoveClient(t, mem, "dot-vim", true)
+
+	client, err := dot.NewClient(dot.Config{
+		PackageDir:         "/test/packages",
+		TargetDir:          "/test/target",
+		PackageNameMapping: true,
+		FS:                 failingLinkFS{MemFS: mem, path: "/test/target/neovim/colors/dark.vim"},
+		Logger:             adapters.NewNoopLogger(),
+	})
+	require.NoError(t, err)
+
+	require.Error(t, client.MovePackage(ctx, "dot-vim", "neovim"))
+
+	assert.True(t, mem.Exists(ctx, "/test/packages/dot-vim/dot-vimrc"), "the directory is renamed back")
+	assert.False(t, mem.Exists(ctx, "/test/packages/neovim"))
+	assertLink(t, mem, "/test/target/.vim/.vimrc", "/test/packages/dot-vim/dot-vimrc")
+	assertLink(t, mem, "/test/target/.vim/colors/dark.vim", "/test/packages/dot-vim/colors/dark.vim")
+	assert.False(t, mem.Exists(ctx, "/test/target/neovim/.vimrc"))
+	recordedPackage(t, mem, "dot-vim")
+}
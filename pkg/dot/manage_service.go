@@ -9,11 +9,43 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/yaklabco/dot/internal/adapters"
+	"github.com/yaklabco/dot/internal/domain"
 	"github.com/yaklabco/dot/internal/executor"
 	"github.com/yaklabco/dot/internal/manifest"
 	"github.com/yaklabco/dot/internal/pipeline"
 )
 
+// ManageOptions configures manage operations.
+type ManageOptions struct {
+	// Resolutions chooses how to resolve the conflict at specific target
+	// paths, keyed by absolute path as reported in ConflictInfo.Path.
+	// Conflicts at other paths follow the configured policy.
+	Resolutions map[string]ResolutionPolicy
+
+	// Include restricts the packages to the files whose path within the
+	// package matches one of these globs, written either as stored
+	// ("dot-config/nvim") or as linked (".config/nvim"). A glob matching a
+	// directory includes everything beneath it, and the parent directories
+	// of included files are still created. Empty includes every file. The
+	// manifest records the globs, and remanage and diff keep to them.
+	Include []string
+
+	// Force plans every package even when nothing changed since it was
+	// last managed. Without it, a package whose state hash matches the
+	// manifest and whose links are intact is skipped.
+	Force bool
+
+	// Adopt resolves conflicts with existing regular files by moving each
+	// file into its package in place of the package's version, then
+	// linking it. The displaced copy is kept in the backup directory. A
+	// file whose content differs from the package's version is left as a
+	// conflict unless AdoptReplace is set. Per-path Resolutions still take
+	// precedence.
+	Adopt        bool
+	AdoptReplace bool
+}
+
 // ManageService handles package installation (manage and remanage operations).
 type ManageService struct {
 	fs          FS
@@ -25,6 +57,13 @@ type ManageService struct {
 	packageDir  string
 	targetDir   string
 	dryRun      bool
+	// linkMode is the configured link mode, part of each package's state hash.
+	linkMode LinkMode
+	// planFS is the caching filesystem the manage pipeline plans with, if any.
+	// It is reset around each plan so results never outlive one operation.
+	planFS *adapters.CachingFS
+	// hooks runs the configured pre and post manage hooks, if any.
+	hooks *hookRunner
 }
 
 // newManageService creates a new manage service.
@@ -54,6 +93,13 @@ func newManageService(
 
 // Manage installs the specified packages by creating symlinks.
 func (s *ManageService) Manage(ctx context.Context, packages ...string) error {
+	return s.ManageWithOptions(ctx, ManageOptions{}, packages...)
+}
+
+// ManageWithOptions installs packages, resolving conflicts as opts directs.
+// Pre-manage hooks run first and abort the operation if one fails;
+// post-manage hooks run once the packages are managed.
+func (s *ManageService) ManageWithOptions(ctx context.Context, opts ManageOptions, packages ...string) error {
 	// Validate package names
 	for _, pkg := range packages {
 		if pkg == "" {
@@ -61,7 +107,19 @@ func (s *ManageService) Manage(ctx context.Context, packages ...string) error {
 		}
 	}
 
-	plan, err := s.PlanManage(ctx, packages...)
+	if err := s.hooks.runPre(ctx, HookPreManage, packages); err != nil {
+		return err
+	}
+	if err := s.manageWithOptions(ctx, opts, packages); err != nil {
+		return err
+	}
+	s.hooks.runPost(ctx, HookPostManage, packages)
+	return nil
+}
+
+// manageWithOptions plans and executes the manage of packages.
+func (s *ManageService) manageWithOptions(ctx context.Context, opts ManageOptions, packages []string) error {
+	plan, changed, err := s.planChangedManage(ctx, opts, packages)
 	if err != nil {
 		return err
 	}
@@ -74,36 +132,96 @@ func (s *ManageService) Manage(ctx context.Context, packages ...string) error {
 	// A corrupt manifest could cause the pipeline to produce zero operations
 	// (symlinks exist on disk but manifest is unreadable), masking data integrity issues.
 	if len(plan.Operations) == 0 {
-		return s.manageZeroOperations(ctx, packages, plan)
+		if len(changed) == 0 {
+			// Every package was skipped as unchanged
+			if err := s.validateManifestReadable(ctx); err != nil {
+				return err
+			}
+			return ErrNoChanges{Packages: packages}
+		}
+		return s.manageZeroOperations(ctx, opts, changed, plan)
 	}
 
 	if s.dryRun {
 		return nil
 	}
+	targetPathResult := NewTargetPath(s.targetDir)
+	if !targetPathResult.IsOk() {
+		return targetPathResult.UnwrapErr()
+	}
 	result := s.executor.Execute(ctx, plan)
 	if !result.IsOk() {
-		return result.UnwrapErr()
+		err := result.UnwrapErr()
+		s.recordCommitted(ctx, targetPathResult.Unwrap(), opts, changed, plan, err)
+		return err
 	}
 	execResult := result.Unwrap()
 	if !execResult.Success() {
 		return fmt.Errorf("execution failed: %d operations failed", len(execResult.Failed))
 	}
 	// Update manifest
-	targetPathResult := NewTargetPath(s.targetDir)
-	if !targetPathResult.IsOk() {
-		return targetPathResult.UnwrapErr()
-	}
-	if err := s.manifestSvc.Update(ctx, targetPathResult.Unwrap(), s.packageDir, packages, plan); err != nil {
+	if err := s.manifestSvc.UpdateWithInclude(ctx, targetPathResult.Unwrap(), s.packageDir, changed, plan, opts.Include); err != nil {
 		return fmt.Errorf("manifest update failed: %w", err)
 	}
+	s.recordStateHashes(ctx, opts.Include, changed)
 	return nil
 }
 
+// recordCommitted records in the manifest the links created by the
+// transactions that committed before a split plan failed with err, so the
+// manifest matches what stays on disk. State hashes are not recorded, so
+// the next manage plans the packages again.
+func (s *ManageService) recordCommitted(ctx context.Context, targetPath TargetPath, opts ManageOptions, packages []string, plan Plan, err error) {
+	committed, ok := committedPlan(plan, err)
+	if !ok {
+		return
+	}
+
+	recorded := make([]string, 0, len(packages))
+	for _, pkg := range packages {
+		if len(committed.OperationsForPackage(pkg)) > 0 {
+			recorded = append(recorded, pkg)
+		}
+	}
+	if len(recorded) == 0 {
+		return
+	}
+
+	if err := s.manifestSvc.UpdateWithInclude(ctx, targetPath, s.packageDir, recorded, committed, opts.Include); err != nil {
+		s.logger.Warn(ctx, "failed_to_record_committed_transactions", "packages", recorded, "error", err)
+	}
+}
+
+// committedPlan returns plan restricted to the operations of the
+// transactions that committed before err, and whether any did. Only a plan
+// split by TransactionSize can fail with committed transactions.
+func committedPlan(plan Plan, err error) (Plan, bool) {
+	var execFailed ErrExecutionFailed
+	if !errors.As(err, &execFailed) || execFailed.CommittedTransactions == 0 {
+		return Plan{}, false
+	}
+
+	ids := make(map[OperationID]struct{}, len(execFailed.Committed))
+	for _, id := range execFailed.Committed {
+		ids[id] = struct{}{}
+	}
+	committed := plan
+	committed.Operations = make([]Operation, 0, len(execFailed.Committed))
+	for _, op := range plan.Operations {
+		if _, ok := ids[op.ID()]; ok {
+			committed.Operations = append(committed.Operations, op)
+		}
+	}
+	return committed, len(committed.Operations) > 0
+}
+
 // manageZeroOperations handles a manage whose plan produced no operations.
 // It validates the manifest, then reconciles it against reality: packages
 // missing entirely are re-registered from a disk scan, and already-correct
 // links the manifest does not record are adopted from the plan's skipped set.
-func (s *ManageService) manageZeroOperations(ctx context.Context, packages []string, plan Plan) error {
+// The state hash of each package is then recorded so the next manage can
+// skip it.
+func (s *ManageService) manageZeroOperations(ctx context.Context, opts ManageOptions, packages []string, plan Plan) error {
 	if err := s.validateManifestReadable(ctx); err != nil {
 		return err
 	}
@@ -127,6 +245,7 @@ func (s *ManageService) manageZeroOperations(ctx context.Context, packages []str
 		}
 		reconciled = reconciled || adopted
 	}
+	s.recordStateHashes(ctx, opts.Include, packages)
 	if reconciled {
 		return nil
 	}
@@ -157,6 +276,65 @@ func checkPlanConflicts(plan Plan) error {
 
 // PlanManage computes the execution plan for managing packages without applying changes.
 func (s *ManageService) PlanManage(ctx context.Context, packages ...string) (Plan, error) {
+	return s.PlanManageWithOptions(ctx, ManageOptions{}, packages...)
+}
+
+// PlanManageWithOptions computes the manage plan, resolving conflicts as
+// opts directs, without applying changes. Packages unchanged since they
+// were last managed contribute no operations unless opts.Force is set.
+func (s *ManageService) PlanManageWithOptions(ctx context.Context, opts ManageOptions, packages ...string) (Plan, error) {
+	plan, _, err := s.planChangedManage(ctx, opts, packages)
+	return plan, err
+}
+
+// planChangedManage plans managing the packages that changed since they
+// were last managed, returning the plan and the packages it covers. When
+// none changed the plan is empty.
+func (s *ManageService) planChangedManage(ctx context.Context, opts ManageOptions, packages []string) (Plan, []string, error) {
+	changed := s.changedPackages(ctx, opts, packages)
+	if len(changed) == 0 {
+		s.logger.Info(ctx, "packages_unchanged", "packages", packages)
+		return domain.AnnotateRisk(ctx, s.fs, Plan{
+			Operations: []Operation{},
+			Metadata:   PlanMetadata{PackageCount: len(packages)},
+		}), nil, nil
+	}
+
+	var include map[string][]string
+	if len(opts.Include) > 0 {
+		include = make(map[string][]string, len(changed))
+		for _, pkg := range changed {
+			include[pkg] = opts.Include
+		}
+	}
+	plan, err := s.planManage(ctx, opts, include, changed...)
+	return plan, changed, err
+}
+
+// planRecordedManage plans managing packages, keeping each partially
+// managed package to the include globs recorded in the manifest.
+func (s *ManageService) planRecordedManage(ctx context.Context, packages ...string) (Plan, error) {
+	var include map[string][]string
+	targetPathResult := NewTargetPath(s.targetDir)
+	if targetPathResult.IsOk() {
+		if manifestResult := s.manifestSvc.Load(ctx, targetPathResult.Unwrap()); manifestResult.IsOk() {
+			m := manifestResult.Unwrap()
+			for _, pkg := range packages {
+				if info, exists := m.GetPackage(pkg); exists && info.Partial() {
+					if include == nil {
+						include = make(map[string][]string)
+					}
+					include[pkg] = info.Include
+				}
+			}
+		}
+	}
+	return s.planManage(ctx, ManageOptions{}, include, packages...)
+}
+
+// planManage computes the manage plan, resolving conflicts as opts directs
+// and applying per-package include globs. opts.Include is not consulted.
+func (s *ManageService) planManage(ctx context.Context, opts ManageOptions, include map[string][]string, packages ...string) (Plan, error) {
 	// Validate packages - filter out reserved names
 	validPackages := make([]string, 0, len(packages))
 	var reservedNames []string
@@ -197,9 +375,17 @@ func (s *ManageService) PlanManage(ctx context.Context, packages ...string) (Pla
 	targetPath := targetPathResult.Unwrap()
 
 	input := pipeline.ManageInput{
-		PackageDir: packagePath,
-		TargetDir:  targetPath,
-		Packages:   packages,
+		PackageDir:   packagePath,
+		TargetDir:    targetPath,
+		Packages:     packages,
+		PathPolicies: opts.Resolutions,
+		Adopt:        opts.Adopt,
+		AdoptReplace: opts.AdoptReplace,
+		Include:      include,
+	}
+	if s.planFS != nil {
+		s.planFS.Reset()
+		defer s.planFS.Reset()
 	}
 	planResult := s.managePipe.Execute(ctx, input)
 	if !planResult.IsOk() {
@@ -230,7 +416,7 @@ func (s *ManageService) Remanage(ctx context.Context, packages ...string) error
 // via the manage pipeline: any missing links are created, and links that
 // already exist correctly are adopted into the manifest.
 func (s *ManageService) remanageZeroOperations(ctx context.Context, packages []string) error {
-	managePlan, err := s.PlanManage(ctx, packages...)
+	managePlan, err := s.planRecordedManage(ctx, packages...)
 	if err != nil {
 		return err
 	}
@@ -389,7 +575,7 @@ func (s *ManageService) PlanRemanage(ctx context.Context, packages ...string) (P
 		skippedLinks = nil
 	}
 
-	return Plan{
+	return domain.AnnotateRisk(ctx, s.fs, Plan{
 		Operations: allOperations,
 		Metadata: PlanMetadata{
 			PackageCount:   len(packages),
@@ -397,7 +583,7 @@ func (s *ManageService) PlanRemanage(ctx context.Context, packages ...string) (P
 		},
 		PackageOperations:   packageOps,
 		PackageSkippedLinks: skippedLinks,
-	}, nil
+	}), nil
 }
 
 // planSinglePackageRemanage plans remanage for a single package using hash comparison.
@@ -443,7 +629,7 @@ func (s *ManageService) planSinglePackageRemanage(
 
 // planNewPackageInstall plans installation of a package not yet in manifest.
 func (s *ManageService) planNewPackageInstall(ctx context.Context, pkg string) ([]Operation, map[string][]OperationID, map[string][]string, error) {
-	pkgPlan, err := s.PlanManage(ctx, pkg)
+	pkgPlan, err := s.planRecordedManage(ctx, pkg)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -492,7 +678,7 @@ func (s *ManageService) planFullRemanage(ctx context.Context, pkg string) ([]Ope
 	}
 
 	// Get manage operations (scanner will now not see the old symlinks)
-	managePlan, err := s.PlanManage(ctx, pkg)
+	managePlan, err := s.planRecordedManage(ctx, pkg)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -544,7 +730,7 @@ func (s *ManageService) planAdoptedPackageRemanage(ctx context.Context, pkg stri
 	}
 
 	// Re-run the normal manage pipeline to create file-level symlinks
-	managePlan, err := s.PlanManage(ctx, pkg)
+	managePlan, err := s.planRecordedManage(ctx, pkg)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -712,10 +898,7 @@ func (s *ManageService) findSymlinksRecursive(ctx context.Context, path, pkgDir,
 		if err != nil {
 			return
 		}
-		if !filepath.IsAbs(target) {
-			target = filepath.Join(filepath.Dir(path), target)
-		}
-		target = filepath.Clean(target)
+		target = domain.ResolveLinkTarget(path, target)
 		if strings.HasPrefix(target, filepath.Clean(pkgDir)+string(os.PathSeparator)) || target == filepath.Clean(pkgDir) {
 			*links = append(*links, rel)
 		}
@@ -118,6 +118,40 @@ func TestManageService_PlanManage(t *testing.T) {
 		require.NoError(t, err)
 		assert.Greater(t, len(plan.Operations), 0)
 	})
+
+	t.Run("plan cache does not outlive a plan", func(t *testing.T) {
+		fs := adapters.NewMemFS()
+		ctx := context.Background()
+		packageDir := "/test/packages"
+		targetDir := "/test/target"
+
+		require.NoError(t, fs.MkdirAll(ctx, packageDir+"/test-pkg", 0755))
+		require.NoError(t, fs.MkdirAll(ctx, targetDir, 0755))
+		require.NoError(t, fs.WriteFile(ctx, packageDir+"/test-pkg/dot-vimrc", []byte("vim"), 0644))
+
+		planFS := adapters.NewCachingFS(fs)
+		managePipe := pipeline.NewManagePipeline(pipeline.ManagePipelineOpts{
+			FS:        planFS,
+			IgnoreSet: ignore.NewDefaultIgnoreSet(),
+			Policies:  planner.ResolutionPolicies{OnFileExists: planner.PolicyFail},
+		})
+		exec := executor.New(executor.Opts{FS: fs, Logger: adapters.NewNoopLogger()})
+		manifestSvc := newManifestService(fs, adapters.NewNoopLogger(), manifest.NewFSManifestStore(fs))
+		unmanageSvc := newUnmanageService(fs, adapters.NewNoopLogger(), exec, manifestSvc, packageDir, targetDir, false)
+		svc := newManageService(fs, adapters.NewNoopLogger(), managePipe, exec, manifestSvc, unmanageSvc, packageDir, targetDir, false)
+		svc.planFS = planFS
+
+		plan, err := svc.PlanManage(ctx, "test-pkg")
+		require.NoError(t, err)
+		assert.Empty(t, plan.Metadata.Conflicts)
+
+		// A file written behind the cache's back must be seen by the next plan
+		require.NoError(t, fs.WriteFile(ctx, targetDir+"/.vimrc", []byte("mine"), 0644))
+
+		plan, err = svc.PlanManage(ctx, "test-pkg")
+		require.NoError(t, err)
+		assert.NotEmpty(t, plan.Metadata.Conflicts)
+	})
 }
 
 func TestManageService_Remanage(t *testing.T) {
@@ -0,0 +1,148 @@
+package dot
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/yaklabco/dot/internal/manifest"
+	"github.com/yaklabco/dot/internal/pipeline"
+)
+
+// stateHash fingerprints what managing pkg with the include globs would
+// link: the desired links and directories, the include globs, the link
+// mode, and the content of the package's files. Any change to these
+// changes the hash.
+func (s *ManageService) stateHash(ctx context.Context, pkg string, include []string) (string, error) {
+	packagePathResult := NewPackagePath(s.packageDir)
+	if !packagePathResult.IsOk() {
+		return "", fmt.Errorf("invalid package directory: %w", packagePathResult.UnwrapErr())
+	}
+	targetPathResult := NewTargetPath(s.targetDir)
+	if !targetPathResult.IsOk() {
+		return "", fmt.Errorf("invalid target directory: %w", targetPathResult.UnwrapErr())
+	}
+	pkgPath, err := s.getPackagePath(pkg)
+	if err != nil {
+		return "", err
+	}
+
+	if s.planFS != nil {
+		s.planFS.Reset()
+		defer s.planFS.Reset()
+	}
+	desiredResult := s.managePipe.DesiredState(ctx, pipeline.ManageInput{
+		PackageDir: packagePathResult.Unwrap(),
+		TargetDir:  targetPathResult.Unwrap(),
+		Packages:   []string{pkg},
+		Include:    map[string][]string{pkg: include},
+	})
+	if !desiredResult.IsOk() {
+		return "", desiredResult.UnwrapErr()
+	}
+	desired := desiredResult.Unwrap()
+
+	contentHash, err := manifest.NewContentHasher(s.fs).HashPackage(ctx, pkgPath)
+	if err != nil {
+		return "", err
+	}
+
+	hasher := sha256.New()
+	fmt.Fprintf(hasher, "content\x00%s\n", contentHash)
+	fmt.Fprintf(hasher, "mode\x00%s\n", s.linkMode)
+
+	globs := slices.Clone(include)
+	sort.Strings(globs)
+	for _, glob := range globs {
+		fmt.Fprintf(hasher, "include\x00%s\n", glob)
+	}
+
+	links := make([]string, 0, len(desired.Links))
+	for target := range desired.Links {
+		links = append(links, target)
+	}
+	sort.Strings(links)
+	for _, target := range links {
+		fmt.Fprintf(hasher, "link\x00%s\x00%s\n", target, desired.Links[target].Source.String())
+	}
+
+	dirs := make([]string, 0, len(desired.Dirs))
+	for dir := range desired.Dirs {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+	for _, dir := range dirs {
+		fmt.Fprintf(hasher, "dir\x00%s\n", dir)
+	}
+
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// changedPackages returns the packages that need planning: those not yet
+// managed, whose state hash differs from the one recorded in the manifest,
+// or whose recorded links are no longer symlinks. Force, or per-path
+// conflict resolutions, plan every package.
+func (s *ManageService) changedPackages(ctx context.Context, opts ManageOptions, packages []string) []string {
+	if opts.Force || len(opts.Resolutions) > 0 {
+		return packages
+	}
+	targetPathResult := NewTargetPath(s.targetDir)
+	if !targetPathResult.IsOk() {
+		return packages
+	}
+	manifestResult := s.manifestSvc.Load(ctx, targetPathResult.Unwrap())
+	if !manifestResult.IsOk() {
+		return packages
+	}
+	m := manifestResult.Unwrap()
+
+	changed := make([]string, 0, len(packages))
+	for _, pkg := range packages {
+		info, exists := m.GetPackage(pkg)
+		if !exists || info.StateHash == "" {
+			changed = append(changed, pkg)
+			continue
+		}
+		hash, err := s.stateHash(ctx, pkg, opts.Include)
+		if err != nil || hash != info.StateHash {
+			changed = append(changed, pkg)
+			continue
+		}
+		if intact, err := s.verifyLinksExist(ctx, pkg, &m); err != nil || !intact {
+			changed = append(changed, pkg)
+			continue
+		}
+		s.logger.Debug(ctx, "package_unchanged", "package", pkg)
+	}
+	return changed
+}
+
+// recordStateHashes stores the current state hash of each package in the
+// manifest so the next manage can skip the packages if nothing changes.
+// Failures are logged rather than returned: a missing hash only means the
+// next manage plans the package in full.
+func (s *ManageService) recordStateHashes(ctx context.Context, include []string, packages []string) {
+	if s.dryRun {
+		return
+	}
+	targetPathResult := NewTargetPath(s.targetDir)
+	if !targetPathResult.IsOk() {
+		return
+	}
+
+	hashes := make(map[string]string, len(packages))
+	for _, pkg := range packages {
+		hash, err := s.stateHash(ctx, pkg, include)
+		if err != nil {
+			s.logger.Warn(ctx, "state_hash_failed", "package", pkg, "error", err)
+			continue
+		}
+		hashes[pkg] = hash
+	}
+	if err := s.manifestSvc.SetStateHashes(ctx, targetPathResult.Unwrap(), hashes); err != nil {
+		s.logger.Warn(ctx, "state_hash_record_failed", "error", err)
+	}
+}
@@ -0,0 +1,95 @@
+package dot_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yaklabco/dot/pkg/dot"
+)
+
+func TestClient_Manage_UnchangedPackageHasEmptyPlan(t *testing.T) {
+	ctx := context.Background()
+	client, fs := newIncludeClient(t)
+
+	require.NoError(t, client.Manage(ctx, "editors"))
+	info := recordedPackage(t, fs, "editors")
+	require.NotEmpty(t, info.StateHash, "manage records the state hash")
+
+	plan, err := client.PlanManage(ctx, "editors")
+	require.NoError(t, err)
+	assert.Empty(t, plan.Operations)
+	assert.Empty(t, plan.PackageSkippedLinks, "an unchanged package is not planned at all")
+
+	err = client.Manage(ctx, "editors")
+	var noChanges dot.ErrNoChanges
+	require.ErrorAs(t, err, &noChanges)
+	assert.Equal(t, []string{"editors"}, noChanges.Packages)
+	assert.Equal(t, info.InstalledAt, recordedPackage(t, fs, "editors").InstalledAt, "a skipped manage leaves the manifest alone")
+
+	forced, err := client.PlanManageWithOptions(ctx, dot.ManageOptions{Force: true}, "editors")
+	require.NoError(t, err)
+	assert.Empty(t, forced.Operations)
+	assert.NotEmpty(t, forced.PackageSkippedLinks["editors"], "force plans the package and finds its links in place")
+}
+
+func TestClient_Manage_StateHashInvalidation(t *testing.T) {
+	t.Run("package file edited", func(t *testing.T) {
+		ctx := context.Background()
+		client, fs := newIncludeClient(t)
+		require.NoError(t, client.Manage(ctx, "editors"))
+		before := recordedPackage(t, fs, "editors").StateHash
+
+		require.NoError(t, fs.WriteFile(ctx, "/test/packages/editors/dot-vimrc", []byte("set number"), 0644))
+
+		plan, err := client.PlanManage(ctx, "editors")
+		require.NoError(t, err)
+		assert.NotEmpty(t, plan.PackageSkippedLinks["editors"], "an edited package is planned again")
+
+		// Nothing needs linking, but the new hash is recorded
+		require.ErrorAs(t, client.Manage(ctx, "editors"), new(dot.ErrNoChanges))
+		after := recordedPackage(t, fs, "editors").StateHash
+		assert.NotEqual(t, before, after)
+
+		plan, err = client.PlanManage(ctx, "editors")
+		require.NoError(t, err)
+		assert.Empty(t, plan.PackageSkippedLinks, "the package is skipped again once its hash is recorded")
+	})
+
+	t.Run("file added", func(t *testing.T) {
+		ctx := context.Background()
+		client, fs := newIncludeClient(t)
+		require.NoError(t, client.Manage(ctx, "editors"))
+
+		require.NoError(t, fs.WriteFile(ctx, "/test/packages/editors/dot-inputrc", []byte("set editing-mode vi"), 0644))
+
+		require.NoError(t, client.Manage(ctx, "editors"))
+		isLink, err := fs.IsSymlink(ctx, "/test/target/.inputrc")
+		require.NoError(t, err)
+		assert.True(t, isLink, "the new file is linked")
+	})
+
+	t.Run("link removed", func(t *testing.T) {
+		ctx := context.Background()
+		client, fs := newIncludeClient(t)
+		require.NoError(t, client.Manage(ctx, "editors"))
+
+		require.NoError(t, fs.Remove(ctx, "/test/target/.vimrc"))
+
+		require.NoError(t, client.Manage(ctx, "editors"))
+		isLink, err := fs.IsSymlink(ctx, "/test/target/.vimrc")
+		require.NoError(t, err)
+		assert.True(t, isLink, "the missing link is recreated")
+	})
+
+	t.Run("include changed", func(t *testing.T) {
+		ctx := context.Background()
+		client, fs := newIncludeClient(t)
+		require.NoError(t, client.ManageWithOptions(ctx, dot.ManageOptions{Include: []string{".config/nvim"}}, "editors"))
+
+		require.NoError(t, client.ManageWithOptions(ctx, dot.ManageOptions{Include: []string{".vimrc"}}, "editors"))
+		assert.Equal(t, []string{".vimrc"}, recordedPackage(t, fs, "editors").Include)
+	})
+}
@@ -2,7 +2,10 @@ package dot
 
 import (
 	"context"
+	"maps"
 	"path/filepath"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/yaklabco/dot/internal/domain"
@@ -35,21 +38,68 @@ func (s *ManifestService) Save(ctx context.Context, targetPath TargetPath, m man
 	return s.store.Save(ctx, targetPath, m)
 }
 
+// Modify applies fn to the stored manifest and saves it if fn reports a
+// change. Other dot processes cannot write the manifest between the read
+// and the write.
+func (s *ManifestService) Modify(ctx context.Context, targetPath TargetPath, fn func(m *manifest.Manifest) (bool, error)) error {
+	return s.store.Update(ctx, targetPath, fn)
+}
+
+// Verify reports whether the stored manifest matches its checksum.
+func (s *ManifestService) Verify(ctx context.Context, targetPath TargetPath) (bool, error) {
+	return s.store.Verify(ctx, targetPath)
+}
+
 // Update updates the manifest with package information from a plan.
 func (s *ManifestService) Update(ctx context.Context, targetPath TargetPath, packageDir string, packages []string, plan Plan) error {
-	return s.UpdateWithSource(ctx, targetPath, packageDir, packages, plan, manifest.SourceManaged)
+	return s.UpdateWithInclude(ctx, targetPath, packageDir, packages, plan, nil)
 }
 
-// UpdateWithSource updates the manifest with package information and source type.
+// UpdateWithInclude updates the manifest for managed packages, recording
+// include as the globs that restricted which files were linked. A nil
+// include records the packages as wholly managed.
+func (s *ManifestService) UpdateWithInclude(ctx context.Context, targetPath TargetPath, packageDir string, packages []string, plan Plan, include []string) error {
+	return s.update(ctx, targetPath, packageDir, packages, plan, manifest.SourceManaged, func(manifest.PackageInfo) []string {
+		return include
+	})
+}
+
+// UpdateWithSource updates the manifest with package information and source
+// type, keeping any include globs already recorded for each package.
 func (s *ManifestService) UpdateWithSource(ctx context.Context, targetPath TargetPath, packageDir string, packages []string, plan Plan, source manifest.PackageSource) error {
-	// Load existing manifest (Load returns new manifest for not found case)
-	manifestResult := s.Load(ctx, targetPath)
-	if !manifestResult.IsOk() {
-		// Propagate non-not-found errors (Load already handles not found by returning new manifest)
-		return manifestResult.UnwrapErr()
-	}
-	m := manifestResult.Unwrap()
+	return s.update(ctx, targetPath, packageDir, packages, plan, source, func(existing manifest.PackageInfo) []string {
+		return existing.Include
+	})
+}
+
+// update records packages in the manifest. include returns the include
+// globs to record given a package's existing entry.
+func (s *ManifestService) update(
+	ctx context.Context,
+	targetPath TargetPath,
+	packageDir string,
+	packages []string,
+	plan Plan,
+	source manifest.PackageSource,
+	include func(existing manifest.PackageInfo) []string,
+) error {
+	return s.Modify(ctx, targetPath, func(m *manifest.Manifest) (bool, error) {
+		s.recordPackages(ctx, m, targetPath, packageDir, packages, plan, source, include)
+		return true, nil
+	})
+}
 
+// recordPackages updates the entries of packages in m from plan.
+func (s *ManifestService) recordPackages(
+	ctx context.Context,
+	m *manifest.Manifest,
+	targetPath TargetPath,
+	packageDir string,
+	packages []string,
+	plan Plan,
+	source manifest.PackageSource,
+	include func(existing manifest.PackageInfo) []string,
+) {
 	// Update package entries
 	hasher := manifest.NewContentHasher(s.fs)
 
@@ -71,7 +121,8 @@ func (s *ManifestService) UpdateWithSource(ctx context.Context, targetPath Targe
 		newLinks = append(newLinks, s.relativeLinkPaths(plan.SkippedLinksForPackage(pkg), targetPath.String())...)
 
 		// Merge with existing links: start from existing, remove deleted, add new
-		links := s.mergeLinks(m, pkg, newLinks, deletedLinks)
+		links := s.mergeLinks(*m, pkg, newLinks, deletedLinks)
+		existing, _ := m.GetPackage(pkg)
 
 		m.AddPackage(manifest.PackageInfo{
 			Name:        pkg,
@@ -82,6 +133,9 @@ func (s *ManifestService) UpdateWithSource(ctx context.Context, targetPath Targe
 			Source:      source,
 			TargetDir:   targetPath.String(),
 			PackageDir:  filepath.Join(packageDir, pkg),
+			Junctions:   s.junctionLinks(ctx, targetPath.String(), links),
+			Include:     include(existing),
+			Sources:     s.linkSources(ctx, existing, ops, plan.SkippedLinksForPackage(pkg), links, targetPath.String()),
 		})
 
 		// Compute and store package hash
@@ -98,8 +152,44 @@ func (s *ManifestService) UpdateWithSource(ctx context.Context, targetPath Targe
 		}
 	}
 
-	// Save manifest
-	return s.Save(ctx, targetPath, m)
+	// Record conflicts the plan resolved automatically
+	m.AddResolutions(resolutionRecords(plan.Metadata.Resolutions, time.Now())...)
+	m.AddCreatedDirs(createdDirs(plan.Operations, targetPath.String())...)
+}
+
+// SetStateHashes records the state hash of each package already in the
+// manifest, keyed by package name. The manifest is saved only if a hash
+// changed, so recording an unchanged state leaves it untouched.
+func (s *ManifestService) SetStateHashes(ctx context.Context, targetPath TargetPath, hashes map[string]string) error {
+	return s.Modify(ctx, targetPath, func(m *manifest.Manifest) (bool, error) {
+		changed := false
+		for pkg, hash := range hashes {
+			info, exists := m.GetPackage(pkg)
+			if !exists || info.StateHash == hash {
+				continue
+			}
+			info.StateHash = hash
+			m.AddPackage(info)
+			changed = true
+		}
+		return changed, nil
+	})
+}
+
+// resolutionRecords converts plan resolutions into manifest audit records.
+func resolutionRecords(resolutions []ResolutionInfo, resolvedAt time.Time) []manifest.ResolutionRecord {
+	records := make([]manifest.ResolutionRecord, 0, len(resolutions))
+	for _, r := range resolutions {
+		records = append(records, manifest.ResolutionRecord{
+			ResolvedAt: resolvedAt,
+			Package:    r.Package,
+			Type:       r.Type,
+			Path:       r.Path,
+			Policy:     r.Policy,
+			BackupPath: r.BackupPath,
+		})
+	}
+	return records
 }
 
 // RemovePackage removes a package from the manifest.
@@ -109,17 +199,78 @@ func (s *ManifestService) RemovePackage(ctx context.Context, targetPath TargetPa
 
 // RemovePackages removes multiple packages from the manifest in a single load-save cycle.
 func (s *ManifestService) RemovePackages(ctx context.Context, targetPath TargetPath, pkgs []string) error {
-	manifestResult := s.Load(ctx, targetPath)
-	if !manifestResult.IsOk() {
-		return manifestResult.UnwrapErr()
-	}
+	return s.Modify(ctx, targetPath, func(m *manifest.Manifest) (bool, error) {
+		for _, pkg := range pkgs {
+			m.RemovePackage(pkg)
+		}
+		// Unmanage removes directories its links leave empty; forget them so a
+		// directory later created in their place is not taken for dot's
+		s.forgetRemovedDirs(ctx, m, targetPath.String())
+		return true, nil
+	})
+}
 
-	m := manifestResult.Unwrap()
-	for _, pkg := range pkgs {
-		m.RemovePackage(pkg)
+// ForgetLinks removes links, keyed by package and relative to the target
+// directory, from the packages' manifest entries.
+func (s *ManifestService) ForgetLinks(ctx context.Context, targetPath TargetPath, links map[string][]string) error {
+	return s.Modify(ctx, targetPath, func(m *manifest.Manifest) (bool, error) {
+		changed := false
+		for pkg, forgotten := range links {
+			info, ok := m.GetPackage(pkg)
+			if !ok {
+				continue
+			}
+			info.Links = slices.DeleteFunc(slices.Clone(info.Links), func(link string) bool {
+				return slices.Contains(forgotten, link)
+			})
+			info.Junctions = slices.DeleteFunc(slices.Clone(info.Junctions), func(link string) bool {
+				return slices.Contains(forgotten, link)
+			})
+			if info.Sources != nil {
+				info.Sources = maps.Clone(info.Sources)
+				for _, link := range forgotten {
+					delete(info.Sources, link)
+				}
+			}
+			info.LinkCount = len(info.Links)
+			// The package no longer matches what manage last linked
+			info.StateHash = ""
+			m.AddPackage(info)
+			changed = true
+		}
+		s.forgetRemovedDirs(ctx, m, targetPath.String())
+		return changed, nil
+	})
+}
+
+// forgetRemovedDirs drops recorded created directories that no longer
+// exist.
+func (s *ManifestService) forgetRemovedDirs(ctx context.Context, m *manifest.Manifest, targetDir string) {
+	var removed []string
+	for _, dir := range m.CreatedDirs {
+		if !s.fs.Exists(ctx, filepath.Join(targetDir, dir)) {
+			removed = append(removed, dir)
+		}
 	}
+	m.RemoveCreatedDirs(removed...)
+}
 
-	return s.Save(ctx, targetPath, m)
+// createdDirs returns the directories within targetDir that ops create,
+// relative to targetDir.
+func createdDirs(ops []Operation, targetDir string) []string {
+	var dirs []string
+	for _, op := range ops {
+		dirOp, ok := op.(DirCreate)
+		if !ok {
+			continue
+		}
+		rel, err := filepath.Rel(targetDir, dirOp.Path.String())
+		if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		dirs = append(dirs, rel)
+	}
+	return dirs
 }
 
 // extractLinksFromOperations extracts link paths from LinkCreate operations.
@@ -138,6 +289,46 @@ func (s *ManifestService) extractLinksFromOperations(ops []Operation, targetDir
 	return links
 }
 
+// linkSources returns the source each of links points at: the source of
+// its LinkCreate operation, the current target of a skipped link (which was
+// already correct), or else the source recorded before.
+func (s *ManifestService) linkSources(
+	ctx context.Context,
+	existing manifest.PackageInfo,
+	ops []Operation,
+	skipped []string,
+	links []string,
+	targetDir string,
+) map[string]string {
+	found := make(map[string]string, len(links))
+	for link, source := range existing.Sources {
+		found[link] = source
+	}
+	for _, path := range skipped {
+		target, err := s.fs.ReadLink(ctx, path)
+		if err != nil {
+			continue
+		}
+		found[s.relativeLinkPaths([]string{path}, targetDir)[0]] = domain.ResolveLinkTarget(path, target)
+	}
+	for _, op := range ops {
+		if linkOp, ok := op.(LinkCreate); ok {
+			found[s.relativeLinkPaths([]string{linkOp.Target.String()}, targetDir)[0]] = linkOp.Source.String()
+		}
+	}
+
+	sources := make(map[string]string, len(links))
+	for _, link := range links {
+		if source, ok := found[link]; ok {
+			sources[link] = source
+		}
+	}
+	if len(sources) == 0 {
+		return nil
+	}
+	return sources
+}
+
 // relativeLinkPaths converts absolute target link paths to paths relative to
 // the target directory, matching the manifest's link representation.
 func (s *ManifestService) relativeLinkPaths(paths []string, targetDir string) []string {
@@ -155,6 +346,22 @@ func (s *ManifestService) relativeLinkPaths(paths []string, targetDir string) []
 	return links
 }
 
+// junctionLinks returns the links that were created as junctions rather than
+// symbolic links.
+func (s *ManifestService) junctionLinks(ctx context.Context, targetDir string, links []string) []string {
+	if _, ok := s.fs.(domain.LinkKindReader); !ok {
+		return nil
+	}
+	var junctions []string
+	for _, link := range links {
+		kind, err := domain.LinkKindOf(ctx, s.fs, filepath.Join(targetDir, link))
+		if err == nil && kind == domain.LinkJunction {
+			junctions = append(junctions, link)
+		}
+	}
+	return junctions
+}
+
 // extractDeletedLinksFromOperations extracts link paths from LinkDelete operations.
 func (s *ManifestService) extractDeletedLinksFromOperations(ops []Operation, targetDir string) []string {
 	var links []string
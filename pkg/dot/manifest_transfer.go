@@ -0,0 +1,68 @@
+package dot
+
+import (
+	"context"
+	"io"
+	"path/filepath"
+	"sort"
+
+	"github.com/yaklabco/dot/internal/manifest"
+)
+
+// Export writes the manifest for targetPath to w as a manifest export
+// document. A missing manifest exports as an empty one.
+func (s *ManifestService) Export(ctx context.Context, targetPath TargetPath, w io.Writer) error {
+	manifestResult := s.Load(ctx, targetPath)
+	if !manifestResult.IsOk() {
+		return manifestResult.UnwrapErr()
+	}
+	return manifest.WriteExport(w, manifestResult.Unwrap())
+}
+
+// Import reads a manifest export document from r and saves it as the
+// manifest for targetPath, replacing any existing manifest.
+//
+// Packages that do not exist in packageDir are still imported, but a
+// warning is logged for each so the user can restore them. When dryRun is
+// set the document is validated and nothing is saved.
+func (s *ManifestService) Import(ctx context.Context, targetPath TargetPath, packageDir string, r io.Reader, dryRun bool) error {
+	_, _, err := s.importManifest(ctx, targetPath, packageDir, r, dryRun)
+	return err
+}
+
+// importManifest implements Import and returns the imported package names
+// split into those present in packageDir and those missing, both sorted.
+func (s *ManifestService) importManifest(ctx context.Context, targetPath TargetPath, packageDir string, r io.Reader, dryRun bool) ([]string, []string, error) {
+	m, err := manifest.ReadExport(r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	names := make([]string, 0, len(m.Packages))
+	for name := range m.Packages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var present, missing []string
+	for _, name := range names {
+		pkgPath := filepath.Join(packageDir, name)
+		if !s.fs.Exists(ctx, pkgPath) {
+			s.logger.Warn(ctx, "imported_package_missing", "package", name, "path", pkgPath)
+			missing = append(missing, name)
+			continue
+		}
+		present = append(present, name)
+	}
+
+	if dryRun {
+		s.logger.Info(ctx, "dry_run_import_manifest", "packages", len(m.Packages))
+		return present, missing, nil
+	}
+
+	s.logger.Info(ctx, "import_manifest", "packages", len(m.Packages))
+	if err := s.Save(ctx, targetPath, m); err != nil {
+		return nil, nil, err
+	}
+	return present, missing, nil
+}
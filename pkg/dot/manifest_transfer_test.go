@@ -0,0 +1,238 @@
+package dot_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaklabco/dot/internal/adapters"
+	"github.com/yaklabco/dot/pkg/dot"
+)
+
+func newTransferTestFS(t *testing.T, packages map[string]string) *adapters.MemFS {
+	t.Helper()
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	require.NoError(t, fs.MkdirAll(ctx, "/home", 0o755))
+	for pkg, file := range packages {
+		require.NoError(t, fs.MkdirAll(ctx, "/dotfiles/"+pkg, 0o755))
+		require.NoError(t, fs.WriteFile(ctx, "/dotfiles/"+pkg+"/"+file, []byte(pkg), 0o644))
+	}
+	return fs
+}
+
+func TestClient_ExportImportManifest_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+
+	// Source machine with two managed packages and doctor ignore state
+	srcFS := newTransferTestFS(t, map[string]string{"vim": "dot-vimrc", "zsh": "dot-zshrc"})
+	src, err := dot.NewClient(dot.Config{
+		PackageDir: "/dotfiles",
+		TargetDir:  "/home",
+		FS:         srcFS,
+		Logger:     adapters.NewNoopLogger(),
+	})
+	require.NoError(t, err)
+	require.NoError(t, src.Manage(ctx, "vim", "zsh"))
+
+	require.NoError(t, srcFS.Symlink(ctx, "/opt/legacy/profile", "/home/.profile"))
+	require.NoError(t, src.DoctorIgnoreLink(ctx, ".profile", "managed by IT"))
+	patterns := []string{".cache/**", "Library/Application Support/*/links/*.lnk", "[!.]*.tmp"}
+	for _, pattern := range patterns {
+		require.NoError(t, src.DoctorIgnorePattern(ctx, pattern))
+	}
+
+	var exported bytes.Buffer
+	require.NoError(t, src.ExportManifest(ctx, &exported))
+
+	// Rebuilt machine where only vim has been restored to the package directory
+	var logs bytes.Buffer
+	dstFS := newTransferTestFS(t, map[string]string{"vim": "dot-vimrc"})
+	dst, err := dot.NewClient(dot.Config{
+		PackageDir: "/dotfiles",
+		TargetDir:  "/home",
+		FS:         dstFS,
+		Logger:     adapters.NewSlogLogger(slog.New(slog.NewTextHandler(&logs, nil))),
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, dst.ImportManifest(ctx, bytes.NewReader(exported.Bytes())))
+	assert.Contains(t, logs.String(), "imported_package_missing")
+	assert.Contains(t, logs.String(), "package=zsh")
+	assert.NotContains(t, logs.String(), "package=vim")
+
+	srcPackages, err := src.List(ctx)
+	require.NoError(t, err)
+	dstPackages, err := dst.List(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, packageLinks(srcPackages), packageLinks(dstPackages))
+
+	srcLinks, srcPatterns, err := src.DoctorListIgnored(ctx)
+	require.NoError(t, err)
+	dstLinks, dstPatterns, err := dst.DoctorListIgnored(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, patterns, dstPatterns)
+	assert.Equal(t, srcPatterns, dstPatterns)
+	require.Contains(t, dstLinks, ".profile")
+	assert.Equal(t, "/opt/legacy/profile", dstLinks[".profile"].Target)
+	assert.Equal(t, "managed by IT", dstLinks[".profile"].Reason)
+	assert.Equal(t, srcLinks[".profile"].TargetHash, dstLinks[".profile"].TargetHash)
+	assert.True(t, srcLinks[".profile"].AcknowledgedAt.Equal(dstLinks[".profile"].AcknowledgedAt))
+
+	// Exporting the imported manifest yields the same content
+	var reexported bytes.Buffer
+	require.NoError(t, dst.ExportManifest(ctx, &reexported))
+	assert.Equal(t, withoutTimestamps(exported.String()), withoutTimestamps(reexported.String()))
+}
+
+func TestClient_ImportManifest_RejectsInvalidDocument(t *testing.T) {
+	client, err := dot.NewClient(dot.Config{
+		PackageDir: "/dotfiles",
+		TargetDir:  "/home",
+		FS:         newTransferTestFS(t, nil),
+		Logger:     adapters.NewNoopLogger(),
+	})
+	require.NoError(t, err)
+
+	err = client.ImportManifest(context.Background(), strings.NewReader(`{"format":"other","format_version":1}`))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not a manifest export")
+}
+
+func TestClient_ImportManifest_DryRunDoesNotSave(t *testing.T) {
+	ctx := context.Background()
+	fs := newTransferTestFS(t, map[string]string{"vim": "dot-vimrc"})
+	src, err := dot.NewClient(dot.Config{
+		PackageDir: "/dotfiles",
+		TargetDir:  "/home",
+		FS:         fs,
+		Logger:     adapters.NewNoopLogger(),
+	})
+	require.NoError(t, err)
+	require.NoError(t, src.Manage(ctx, "vim"))
+
+	var exported bytes.Buffer
+	require.NoError(t, src.ExportManifest(ctx, &exported))
+
+	dst, err := dot.NewClient(dot.Config{
+		PackageDir: "/dotfiles",
+		TargetDir:  "/home",
+		DryRun:     true,
+		FS:         newTransferTestFS(t, map[string]string{"vim": "dot-vimrc"}),
+		Logger:     adapters.NewNoopLogger(),
+	})
+	require.NoError(t, err)
+	require.NoError(t, dst.ImportManifest(ctx, &exported))
+
+	packages, err := dst.List(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, packages)
+}
+
+func TestClient_RestoreManifest(t *testing.T) {
+	ctx := context.Background()
+	srcFS := newTransferTestFS(t, map[string]string{"vim": "dot-vimrc", "zsh": "dot-zshrc"})
+	src, err := dot.NewClient(dot.Config{
+		PackageDir: "/dotfiles",
+		TargetDir:  "/home",
+		FS:         srcFS,
+		Logger:     adapters.NewNoopLogger(),
+	})
+	require.NoError(t, err)
+	require.NoError(t, src.Manage(ctx, "vim", "zsh"))
+
+	var exported bytes.Buffer
+	require.NoError(t, src.ExportManifest(ctx, &exported))
+
+	// The new machine has the package directory but no links yet, and zsh
+	// has not been restored
+	dstFS := newTransferTestFS(t, map[string]string{"vim": "dot-vimrc"})
+	dst, err := dot.NewClient(dot.Config{
+		PackageDir: "/dotfiles",
+		TargetDir:  "/home",
+		FS:         dstFS,
+		Logger:     adapters.NewNoopLogger(),
+	})
+	require.NoError(t, err)
+
+	restore, err := dst.RestoreManifest(ctx, &exported)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"vim"}, restore.Managed)
+	assert.Equal(t, []string{"zsh"}, restore.Missing)
+
+	isLink, err := dstFS.IsSymlink(ctx, "/home/.vimrc")
+	require.NoError(t, err)
+	assert.True(t, isLink, "vim link is recreated")
+	assert.False(t, dstFS.Exists(ctx, "/home/.zshrc"))
+
+	packages, err := dst.List(ctx)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"vim", "zsh"}, packageNames(packages))
+}
+
+func TestClient_RestoreManifest_DryRun(t *testing.T) {
+	ctx := context.Background()
+	src, err := dot.NewClient(dot.Config{
+		PackageDir: "/dotfiles",
+		TargetDir:  "/home",
+		FS:         newTransferTestFS(t, map[string]string{"vim": "dot-vimrc"}),
+		Logger:     adapters.NewNoopLogger(),
+	})
+	require.NoError(t, err)
+	require.NoError(t, src.Manage(ctx, "vim"))
+
+	var exported bytes.Buffer
+	require.NoError(t, src.ExportManifest(ctx, &exported))
+
+	dstFS := newTransferTestFS(t, map[string]string{"vim": "dot-vimrc"})
+	dst, err := dot.NewClient(dot.Config{
+		PackageDir: "/dotfiles",
+		TargetDir:  "/home",
+		DryRun:     true,
+		FS:         dstFS,
+		Logger:     adapters.NewNoopLogger(),
+	})
+	require.NoError(t, err)
+
+	restore, err := dst.RestoreManifest(ctx, &exported)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"vim"}, restore.Managed)
+	assert.False(t, dstFS.Exists(ctx, "/home/.vimrc"))
+	assert.False(t, dstFS.Exists(ctx, "/home/.dot-manifest.json"))
+}
+
+// packageLinks maps package names to their recorded links.
+func packageLinks(packages []dot.PackageInfo) map[string][]string {
+	links := make(map[string][]string, len(packages))
+	for _, pkg := range packages {
+		links[pkg.Name] = pkg.Links
+	}
+	return links
+}
+
+// withoutTimestamps drops lines carrying export and save timestamps, and the
+// checksum derived from them, which legitimately differ between exports.
+func withoutTimestamps(doc string) string {
+	var kept []string
+	for _, line := range strings.Split(doc, "\n") {
+		if strings.Contains(line, `"exported_at"`) || strings.Contains(line, `"updated_at"`) ||
+			strings.Contains(line, `"checksum"`) {
+			continue
+		}
+		kept = append(kept, line)
+	}
+	return strings.Join(kept, "\n")
+}
+
+// packageNames returns the names of packages.
+func packageNames(packages []dot.PackageInfo) []string {
+	names := make([]string, 0, len(packages))
+	for _, pkg := range packages {
+		names = append(names, pkg.Name)
+	}
+	return names
+}
@@ -0,0 +1,69 @@
+package dot_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaklabco/dot/internal/adapters"
+	"github.com/yaklabco/dot/pkg/dot"
+)
+
+func TestClient_VerifyManifest_DetectsTampering(t *testing.T) {
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	require.NoError(t, fs.MkdirAll(ctx, "/dotfiles/vim", 0o755))
+	require.NoError(t, fs.MkdirAll(ctx, "/home", 0o755))
+	require.NoError(t, fs.WriteFile(ctx, "/dotfiles/vim/dot-vimrc", []byte("set nu"), 0o644))
+
+	client, err := dot.NewClient(dot.Config{
+		PackageDir: "/dotfiles",
+		TargetDir:  "/home",
+		FS:         fs,
+		Logger:     adapters.NewNoopLogger(),
+	})
+	require.NoError(t, err)
+
+	valid, err := client.VerifyManifest(ctx)
+	require.NoError(t, err)
+	assert.True(t, valid, "missing manifest should verify")
+
+	require.NoError(t, client.Manage(ctx, "vim"))
+
+	valid, err = client.VerifyManifest(ctx)
+	require.NoError(t, err)
+	assert.True(t, valid)
+
+	report, err := client.Doctor(ctx)
+	require.NoError(t, err)
+	for _, issue := range report.Issues {
+		assert.NotEqual(t, dot.IssueManifestCorrupt, issue.Type)
+	}
+
+	// Hand-edit the manifest
+	manifestPath := "/home/.dot-manifest.json"
+	data, err := fs.ReadFile(ctx, manifestPath)
+	require.NoError(t, err)
+	tampered := strings.Replace(string(data), `".vimrc"`, `".bashrc"`, 1)
+	require.NotEqual(t, string(data), tampered)
+	require.NoError(t, fs.WriteFile(ctx, manifestPath, []byte(tampered), 0o600))
+
+	valid, err = client.VerifyManifest(ctx)
+	require.NoError(t, err)
+	assert.False(t, valid)
+
+	report, err = client.Doctor(ctx)
+	require.NoError(t, err)
+	var corrupt []dot.Issue
+	for _, issue := range report.Issues {
+		if issue.Type == dot.IssueManifestCorrupt {
+			corrupt = append(corrupt, issue)
+		}
+	}
+	require.Len(t, corrupt, 1)
+	assert.Equal(t, dot.SeverityError, corrupt[0].Severity)
+	assert.NotEmpty(t, corrupt[0].Suggestion)
+	assert.Equal(t, dot.HealthErrors, report.OverallHealth)
+}
@@ -0,0 +1,89 @@
+package dot_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaklabco/dot/internal/adapters"
+	"github.com/yaklabco/dot/pkg/dot"
+)
+
+// countingMetrics totals counters by name and ignores everything else.
+type countingMetrics struct {
+	mu     sync.Mutex
+	counts map[string]float64
+}
+
+func (m *countingMetrics) Counter(name string, _ ...string) dot.Counter {
+	return countingCounter{m: m, name: name}
+}
+
+func (m *countingMetrics) Histogram(name string, labels ...string) dot.Histogram {
+	return dot.NewNoopMetrics().Histogram(name, labels...)
+}
+
+func (m *countingMetrics) Gauge(name string, labels ...string) dot.Gauge {
+	return dot.NewNoopMetrics().Gauge(name, labels...)
+}
+
+func (m *countingMetrics) count(name string) float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.counts[name]
+}
+
+type countingCounter struct {
+	m    *countingMetrics
+	name string
+}
+
+func (c countingCounter) Inc(labels ...string) { c.Add(1, labels...) }
+
+func (c countingCounter) Add(value float64, _ ...string) {
+	c.m.mu.Lock()
+	defer c.m.mu.Unlock()
+	c.m.counts[c.name] += value
+}
+
+func TestClient_MetersFilesystemCalls(t *testing.T) {
+	tests := []struct {
+		name         string
+		dryRun       bool
+		wantSymlinks bool
+	}{
+		{"manage", false, true},
+		{"dry run", true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			mem := adapters.NewMemFS()
+			setupTestFixtures(t, mem, "vim")
+
+			metrics := &countingMetrics{counts: make(map[string]float64)}
+			client, err := dot.NewClient(dot.Config{
+				PackageDir: "/test/packages",
+				TargetDir:  "/test/target",
+				FS:         mem,
+				Logger:     adapters.NewNoopLogger(),
+				Metrics:    metrics,
+				DryRun:     tt.dryRun,
+			})
+			require.NoError(t, err)
+
+			require.NoError(t, client.Manage(ctx, "vim"))
+
+			assert.Positive(t, metrics.count("readdir_total"))
+			if tt.wantSymlinks {
+				assert.Positive(t, metrics.count("symlink_total"))
+			} else {
+				assert.Zero(t, metrics.count("symlink_total"))
+			}
+			assert.Zero(t, metrics.count("symlink_errors_total"))
+		})
+	}
+}
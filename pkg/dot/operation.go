@@ -97,3 +97,16 @@ func NewFileDelete(id OperationID, path FilePath) FileDelete {
 func NewDirCopy(id OperationID, source, dest FilePath) DirCopy {
 	return domain.NewDirCopy(id, source, dest)
 }
+
+// DryRunEffect describes what an operation would do to the filesystem.
+type DryRunEffect = domain.DryRunEffect
+
+// EffectStatus classifies what an operation would do to the filesystem.
+type EffectStatus = domain.EffectStatus
+
+// Effect status constants
+const (
+	EffectApply   = domain.EffectApply
+	EffectNoop    = domain.EffectNoop
+	EffectBlocked = domain.EffectBlocked
+)
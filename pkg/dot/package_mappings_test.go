@@ -0,0 +1,65 @@
+package dot_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yaklabco/dot/internal/adapters"
+	"github.com/yaklabco/dot/pkg/dot"
+)
+
+func TestClient_Manage_PackageMappings(t *testing.T) {
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	require.NoError(t, fs.MkdirAll(ctx, "/test/packages/dot-work-ssh", 0755))
+	require.NoError(t, fs.MkdirAll(ctx, "/test/packages/dot-gnupg", 0755))
+	require.NoError(t, fs.MkdirAll(ctx, "/test/target", 0755))
+	require.NoError(t, fs.WriteFile(ctx, "/test/packages/dot-work-ssh/config", []byte("Host work"), 0644))
+	require.NoError(t, fs.WriteFile(ctx, "/test/packages/dot-gnupg/gpg.conf", []byte("# gpg"), 0644))
+
+	client, err := dot.NewClient(dot.Config{
+		PackageDir:         "/test/packages",
+		TargetDir:          "/test/target",
+		PackageNameMapping: true,
+		PackageMappings:    map[string]string{"dot-work-ssh": ".ssh"},
+		FS:                 fs,
+		Logger:             adapters.NewNoopLogger(),
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, client.Manage(ctx, "dot-work-ssh", "dot-gnupg"))
+
+	// The mapped package targets ~/.ssh instead of the derived ~/.work-ssh
+	isLink, err := fs.IsSymlink(ctx, "/test/target/.ssh/config")
+	require.NoError(t, err)
+	assert.True(t, isLink)
+	assert.False(t, fs.Exists(ctx, "/test/target/.work-ssh"))
+
+	// The conventionally-named package keeps its derived target
+	isLink, err = fs.IsSymlink(ctx, "/test/target/.gnupg/gpg.conf")
+	require.NoError(t, err)
+	assert.True(t, isLink)
+
+	status, err := client.Status(ctx, "dot-work-ssh")
+	require.NoError(t, err)
+	require.Len(t, status.Packages, 1)
+	assert.Contains(t, status.Packages[0].Links, ".ssh/config")
+
+	require.NoError(t, client.Unmanage(ctx, "dot-work-ssh"))
+	assert.False(t, fs.Exists(ctx, "/test/target/.ssh/config"))
+}
+
+func TestNewClient_RejectsEscapingPackageMapping(t *testing.T) {
+	_, err := dot.NewClient(dot.Config{
+		PackageDir:      "/test/packages",
+		TargetDir:       "/test/target",
+		PackageMappings: map[string]string{"dot-work-ssh": "../../etc"},
+		FS:              adapters.NewMemFS(),
+		Logger:          adapters.NewNoopLogger(),
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "dot-work-ssh")
+}
@@ -0,0 +1,166 @@
+package dot
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+)
+
+// IsPackagePattern reports whether a package argument is a glob rather than
+// a literal name: it contains *, ?, [ or a {a,b} alternation.
+func IsPackagePattern(arg string) bool {
+	return strings.ContainsAny(arg, "*?[") || len(expandBraces(arg)) > 1
+}
+
+// ExpandPackagePatterns expands package arguments against names, the
+// packages that exist. Literal arguments are kept as given, so a missing
+// package is still reported by whatever uses the result. Globs support *,
+// ? and [...] as in path.Match, plus {a,b} alternation, and are replaced by
+// the names they match in the order names lists them. The result keeps the
+// order of the arguments and lists each package once.
+//
+// A glob that matches nothing is an ErrNoPackagesMatch; a {a,b} glob needs
+// only one of its alternatives to match. When no argument is
+// a glob, args is returned unchanged.
+func ExpandPackagePatterns(args, names []string) ([]string, error) {
+	if !hasPackagePattern(args) {
+		return args, nil
+	}
+
+	expanded := make([]string, 0, len(args))
+	seen := make(map[string]bool, len(args))
+	add := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			expanded = append(expanded, name)
+		}
+	}
+
+	for _, arg := range args {
+		if !IsPackagePattern(arg) {
+			add(arg)
+			continue
+		}
+
+		alternatives := expandBraces(arg)
+		matched := false
+		for _, name := range names {
+			for _, alt := range alternatives {
+				ok, err := path.Match(alt, name)
+				if err != nil {
+					return nil, fmt.Errorf("invalid package pattern %q: %w", arg, err)
+				}
+				if ok {
+					add(name)
+					matched = true
+					break
+				}
+			}
+		}
+		if !matched {
+			return nil, ErrNoPackagesMatch{Pattern: arg}
+		}
+	}
+	return expanded, nil
+}
+
+// expandBraces expands the first {a,b} alternation in pattern, and
+// recursively any that follow or are nested, into the patterns it stands
+// for. Braces without a top-level comma, or unbalanced ones, are literal.
+func expandBraces(pattern string) []string {
+	open := -1
+	depth := 0
+	var commas []int
+	for i, r := range pattern {
+		switch r {
+		case '{':
+			if depth == 0 {
+				open = i
+				commas = commas[:0]
+			}
+			depth++
+		case ',':
+			if depth == 1 {
+				commas = append(commas, i)
+			}
+		case '}':
+			if depth == 0 {
+				continue
+			}
+			depth--
+			if depth > 0 {
+				continue
+			}
+			if len(commas) == 0 {
+				// {x} is literal; look for an alternation further on
+				rest := expandBraces(pattern[i+1:])
+				if len(rest) == 1 {
+					return []string{pattern}
+				}
+				out := make([]string, 0, len(rest))
+				for _, tail := range rest {
+					out = append(out, pattern[:i+1]+tail)
+				}
+				return out
+			}
+
+			prefix, suffix := pattern[:open], pattern[i+1:]
+			bounds := append(append([]int{open}, commas...), i)
+			var out []string
+			for j := 0; j+1 < len(bounds); j++ {
+				alt := pattern[bounds[j]+1 : bounds[j+1]]
+				out = append(out, expandBraces(prefix+alt+suffix)...)
+			}
+			return out
+		}
+	}
+	return []string{pattern}
+}
+
+// ExpandPackages expands glob arguments against the package directories in
+// PackageDir, for commands that act on packages yet to be managed. A glob
+// expands to the matching packages sorted by name. See
+// ExpandPackagePatterns.
+func (c *Client) ExpandPackages(ctx context.Context, args []string) ([]string, error) {
+	if !hasPackagePattern(args) {
+		return args, nil
+	}
+	names, err := discoverPackages(ctx, c.config.FS, c.config.PackageDir)
+	if err != nil {
+		return nil, err
+	}
+	// Not every FS lists directories in order
+	slices.Sort(names)
+	return ExpandPackagePatterns(args, names)
+}
+
+// ExpandInstalledPackages expands glob arguments against the packages
+// recorded in the manifest, for commands that act on managed packages. See
+// ExpandPackagePatterns.
+func (c *Client) ExpandInstalledPackages(ctx context.Context, args []string) ([]string, error) {
+	if !hasPackagePattern(args) {
+		return args, nil
+	}
+	// Health is not needed, so the manifest is read without checking links
+	installed, _, err := c.statusSvc.enumerate(ctx, StatusOptions{}, nil)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(installed))
+	for _, pkg := range installed {
+		names = append(names, pkg.Name)
+	}
+	return ExpandPackagePatterns(args, names)
+}
+
+// hasPackagePattern reports whether any argument is a glob.
+func hasPackagePattern(args []string) bool {
+	for _, arg := range args {
+		if IsPackagePattern(arg) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+package dot_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaklabco/dot/internal/adapters"
+	"github.com/yaklabco/dot/pkg/dot"
+)
+
+func TestExpandPackagePatterns(t *testing.T) {
+	names := []string{"git", "shell-bash", "shell-fish", "shell-zsh", "vim", "vim2"}
+
+	tests := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{name: "star", args: []string{"shell-*"}, want: []string{"shell-bash", "shell-fish", "shell-zsh"}},
+		{name: "question mark", args: []string{"vim?"}, want: []string{"vim2"}},
+		{name: "character class", args: []string{"shell-[bz]*"}, want: []string{"shell-bash", "shell-zsh"}},
+		{name: "braces", args: []string{"{git,vim}"}, want: []string{"git", "vim"}},
+		{name: "braces with globs", args: []string{"shell-{z,f}*"}, want: []string{"shell-fish", "shell-zsh"}},
+		{name: "nested braces", args: []string{"{git,shell-{bash,zsh}}"}, want: []string{"git", "shell-bash", "shell-zsh"}},
+		{name: "argument order kept", args: []string{"vim", "shell-*", "git"}, want: []string{"vim", "shell-bash", "shell-fish", "shell-zsh", "git"}},
+		{name: "duplicates removed", args: []string{"shell-zsh", "shell-*", "*-zsh"}, want: []string{"shell-zsh", "shell-bash", "shell-fish"}},
+		{name: "literal with pattern kept as given", args: []string{"missing", "vim*"}, want: []string{"missing", "vim", "vim2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := dot.ExpandPackagePatterns(tt.args, names)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("literals are unchanged", func(t *testing.T) {
+		args := []string{"vim", "vim", "missing", "{single}"}
+		got, err := dot.ExpandPackagePatterns(args, names)
+		require.NoError(t, err)
+		assert.Equal(t, args, got)
+	})
+
+	t.Run("no match is an error", func(t *testing.T) {
+		_, err := dot.ExpandPackagePatterns([]string{"vim", "emacs-*"}, names)
+		var noMatch dot.ErrNoPackagesMatch
+		require.True(t, errors.As(err, &noMatch))
+		assert.Equal(t, "emacs-*", noMatch.Pattern)
+		assert.EqualError(t, err, `no packages match "emacs-*"`)
+	})
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		_, err := dot.ExpandPackagePatterns([]string{"shell-[a"}, names)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid package pattern")
+	})
+}
+
+func TestIsPackagePattern(t *testing.T) {
+	for arg, want := range map[string]bool{
+		"vim":       false,
+		"dot-ssh":   false,
+		"{vim}":     false,
+		"a{b,c":     false,
+		"vim*":      true,
+		"vim?":      true,
+		"[vz]im":    true,
+		"{vim,zsh}": true,
+	} {
+		assert.Equal(t, want, dot.IsPackagePattern(arg), arg)
+	}
+}
+
+func TestClient_ExpandPackages(t *testing.T) {
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	for _, pkg := range []string{"shell-bash", "shell-zsh", "vim"} {
+		require.NoError(t, fs.MkdirAll(ctx, "/dotfiles/"+pkg, 0o755))
+		require.NoError(t, fs.WriteFile(ctx, "/dotfiles/"+pkg+"/dot-"+pkg+"rc", []byte("x"), 0o644))
+	}
+	require.NoError(t, fs.MkdirAll(ctx, "/home", 0o755))
+
+	client, err := dot.NewClient(dot.Config{
+		PackageDir: "/dotfiles",
+		TargetDir:  "/home",
+		FS:         fs,
+		Logger:     adapters.NewNoopLogger(),
+	})
+	require.NoError(t, err)
+
+	available, err := client.ExpandPackages(ctx, []string{"shell-*"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"shell-bash", "shell-zsh"}, available)
+
+	_, err = client.ExpandInstalledPackages(ctx, []string{"shell-*"})
+	require.ErrorIs(t, err, dot.ErrNoPackagesMatch{}, "nothing is installed yet")
+
+	require.NoError(t, client.Manage(ctx, "shell-zsh", "vim"))
+	installed, err := client.ExpandInstalledPackages(ctx, []string{"shell-*", "v?m"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"shell-zsh", "vim"}, installed)
+}
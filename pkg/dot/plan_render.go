@@ -0,0 +1,131 @@
+package dot
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/yaklabco/dot/internal/cli/output"
+	"github.com/yaklabco/dot/internal/cli/render"
+)
+
+// PlanRenderMode controls how much detail RenderPlan includes.
+type PlanRenderMode int
+
+const (
+	// PlanRenderCompact shows operation counts per kind only.
+	PlanRenderCompact PlanRenderMode = iota
+
+	// PlanRenderVerbose shows counts per kind followed by every operation.
+	PlanRenderVerbose
+)
+
+// RenderOptions configures RenderPlan output.
+type RenderOptions struct {
+	// Mode selects compact (counts only) or verbose (every operation) output.
+	Mode PlanRenderMode
+
+	// Color enables ANSI colorization of kind headers and conflicts.
+	Color bool
+
+	// Indent is the prefix used for each nesting level. Defaults to two spaces.
+	Indent string
+
+	// Theme names the color theme used when Color is set. Unknown or empty
+	// names use the default theme.
+	Theme string
+}
+
+// planKindOrder is the order in which operation groups are rendered.
+// It mirrors the natural execution order: create structure, back up,
+// move, link, then tear down.
+var planKindOrder = []OperationKind{
+	OpKindDirCreate,
+	OpKindFileBackup,
+	OpKindFileMove,
+	OpKindDirCopy,
+	OpKindLinkCreate,
+	OpKindLinkDelete,
+	OpKindFileDelete,
+	OpKindDirDelete,
+	OpKindDirRemoveAll,
+}
+
+// RenderPlan returns a human-readable summary of a plan.
+//
+// Operations are grouped by kind with a count per group. In verbose mode
+// each operation is listed beneath its group using Operation.String().
+// Conflicts and warnings recorded in the plan metadata are appended.
+func RenderPlan(plan Plan, opts RenderOptions) string {
+	indent := opts.Indent
+	if indent == "" {
+		indent = "  "
+	}
+	theme, _ := render.ThemeByName(opts.Theme)
+	c := render.NewColorizer(opts.Color, theme)
+
+	groups := make(map[OperationKind][]Operation)
+	for _, op := range plan.Operations {
+		groups[op.Kind()] = append(groups[op.Kind()], op)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %d %s\n", c.Bold("Plan:"), len(plan.Operations),
+		output.Pluralize(len(plan.Operations), "operation", "operations"))
+
+	if len(plan.Operations) == 0 {
+		fmt.Fprintf(&b, "%sNo operations required\n", indent)
+	}
+
+	for _, kind := range orderedKinds(groups) {
+		ops := groups[kind]
+		fmt.Fprintf(&b, "%s%s (%d)\n", indent, c.Accent(kind.String()), len(ops))
+		if opts.Mode != PlanRenderVerbose {
+			continue
+		}
+		for _, op := range ops {
+			fmt.Fprintf(&b, "%s%s%s\n", indent, indent, op.String())
+		}
+	}
+
+	if n := len(plan.Metadata.Conflicts); n > 0 {
+		fmt.Fprintf(&b, "%s\n", c.Error(fmt.Sprintf("Conflicts: %d", n)))
+		if opts.Mode == PlanRenderVerbose {
+			for _, conflict := range plan.Metadata.Conflicts {
+				fmt.Fprintf(&b, "%s%s: %s\n", indent, conflict.Path, conflict.Details)
+			}
+		}
+	}
+
+	if n := len(plan.Metadata.Warnings); n > 0 {
+		fmt.Fprintf(&b, "%s\n", c.Warning(fmt.Sprintf("Warnings: %d", n)))
+		if opts.Mode == PlanRenderVerbose {
+			for _, warning := range plan.Metadata.Warnings {
+				fmt.Fprintf(&b, "%s%s\n", indent, warning.Message)
+			}
+		}
+	}
+
+	return b.String()
+}
+
+// orderedKinds returns the kinds present in groups in render order.
+// Kinds not covered by planKindOrder are appended last.
+func orderedKinds(groups map[OperationKind][]Operation) []OperationKind {
+	kinds := make([]OperationKind, 0, len(groups))
+	seen := make(map[OperationKind]bool, len(groups))
+	for _, kind := range planKindOrder {
+		if _, ok := groups[kind]; ok {
+			kinds = append(kinds, kind)
+			seen[kind] = true
+		}
+	}
+	extra := make([]OperationKind, 0)
+	for kind := range groups {
+		if !seen[kind] {
+			extra = append(extra, kind)
+		}
+	}
+	sort.Slice(extra, func(i, j int) bool { return extra[i] < extra[j] })
+	return append(kinds, extra...)
+}
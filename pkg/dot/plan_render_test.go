@@ -0,0 +1,78 @@
+package dot_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yaklabco/dot/pkg/dot"
+)
+
+func newRenderTestPlan() dot.Plan {
+	src := dot.NewFilePath("/packages/vim/dot-vimrc").Unwrap()
+	tgt := dot.NewTargetPath("/home/user/.vimrc").Unwrap()
+	src2 := dot.NewFilePath("/packages/vim/dot-gvimrc").Unwrap()
+	tgt2 := dot.NewTargetPath("/home/user/.gvimrc").Unwrap()
+	dir := dot.NewFilePath("/home/user/.vim").Unwrap()
+
+	return dot.Plan{
+		Operations: []dot.Operation{
+			dot.NewLinkCreate("link1", src, tgt),
+			dot.NewDirCreate("dir1", dir),
+			dot.NewLinkCreate("link2", src2, tgt2),
+		},
+	}
+}
+
+func TestRenderPlan_Compact(t *testing.T) {
+	out := dot.RenderPlan(newRenderTestPlan(), dot.RenderOptions{})
+
+	assert.Contains(t, out, "Plan: 3 operations")
+	assert.Contains(t, out, "  DirCreate (1)")
+	assert.Contains(t, out, "  LinkCreate (2)")
+	assert.NotContains(t, out, "create link")
+	assert.NotContains(t, out, "\033[")
+
+	// Directory creation is grouped before link creation.
+	assert.Less(t, strings.Index(out, "DirCreate"), strings.Index(out, "LinkCreate"))
+}
+
+func TestRenderPlan_Verbose(t *testing.T) {
+	out := dot.RenderPlan(newRenderTestPlan(), dot.RenderOptions{Mode: dot.PlanRenderVerbose})
+
+	assert.Contains(t, out, "    create directory /home/user/.vim")
+	assert.Contains(t, out, "    create link /home/user/.vimrc -> /packages/vim/dot-vimrc")
+	assert.Contains(t, out, "    create link /home/user/.gvimrc -> /packages/vim/dot-gvimrc")
+}
+
+func TestRenderPlan_Empty(t *testing.T) {
+	out := dot.RenderPlan(dot.Plan{}, dot.RenderOptions{})
+
+	assert.Contains(t, out, "Plan: 0 operations")
+	assert.Contains(t, out, "No operations required")
+}
+
+func TestRenderPlan_ConflictsAndWarnings(t *testing.T) {
+	plan := dot.Plan{
+		Metadata: dot.PlanMetadata{
+			Conflicts: []dot.ConflictInfo{{Type: "file_exists", Path: "/home/user/.bashrc", Details: "file exists"}},
+			Warnings:  []dot.WarningInfo{{Message: "skipping large file"}},
+		},
+	}
+
+	compact := dot.RenderPlan(plan, dot.RenderOptions{})
+	assert.Contains(t, compact, "Conflicts: 1")
+	assert.Contains(t, compact, "Warnings: 1")
+	assert.NotContains(t, compact, "/home/user/.bashrc")
+
+	verbose := dot.RenderPlan(plan, dot.RenderOptions{Mode: dot.PlanRenderVerbose, Indent: "\t"})
+	assert.Contains(t, verbose, "\t/home/user/.bashrc: file exists")
+	assert.Contains(t, verbose, "\tskipping large file")
+}
+
+func TestRenderPlan_Color(t *testing.T) {
+	out := dot.RenderPlan(newRenderTestPlan(), dot.RenderOptions{Color: true})
+
+	assert.Contains(t, out, "\033[")
+}
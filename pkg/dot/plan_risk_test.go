@@ -0,0 +1,77 @@
+package dot_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yaklabco/dot/internal/adapters"
+	"github.com/yaklabco/dot/pkg/dot"
+)
+
+// newRiskClient creates a client over a memory filesystem with a shell
+// package of n files, each conflicting with an existing file in the target.
+func newRiskClient(t *testing.T, n int, backup, overwrite bool) *dot.Client {
+	t.Helper()
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	require.NoError(t, fs.MkdirAll(ctx, "/test/packages/shell", 0755))
+	require.NoError(t, fs.MkdirAll(ctx, "/test/target", 0755))
+	for i := range n {
+		name := fmt.Sprintf("rc%d", i)
+		require.NoError(t, fs.WriteFile(ctx, "/test/packages/shell/dot-"+name, []byte("new"), 0644))
+		require.NoError(t, fs.WriteFile(ctx, "/test/target/."+name, []byte("existing"), 0644))
+	}
+
+	client, err := dot.NewClient(dot.Config{
+		PackageDir: "/test/packages",
+		TargetDir:  "/test/target",
+		Backup:     backup,
+		Overwrite:  overwrite,
+		FS:         fs,
+		Logger:     adapters.NewNoopLogger(),
+	})
+	require.NoError(t, err)
+	return client
+}
+
+func TestClient_PlanManage_RiskMetadata(t *testing.T) {
+	tests := []struct {
+		name            string
+		files           int
+		backup          bool
+		overwrite       bool
+		wantDestructive int
+		wantBytes       int64
+		wantRisk        dot.RiskLevel
+	}{
+		{name: "backups", files: 2, backup: true, wantBytes: 2 * int64(len("existing")), wantRisk: dot.RiskMedium},
+		{name: "few overwrites", files: 2, overwrite: true, wantDestructive: 2, wantRisk: dot.RiskMedium},
+		{name: "many overwrites", files: 6, overwrite: true, wantDestructive: 6, wantRisk: dot.RiskHigh},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newRiskClient(t, tt.files, tt.backup, tt.overwrite)
+
+			plan, err := client.PlanManage(context.Background(), "shell")
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantDestructive, plan.Metadata.DestructiveOps)
+			assert.Equal(t, tt.wantBytes, plan.Metadata.BytesBackedUp)
+			assert.Equal(t, tt.wantRisk, plan.Metadata.RiskLevel)
+		})
+	}
+}
+
+func TestClient_PlanManage_RiskMetadataLow(t *testing.T) {
+	client, _ := newDiffClient(t, false, "dot-vimrc")
+
+	plan, err := client.PlanManage(context.Background(), "vim")
+	require.NoError(t, err)
+	assert.Zero(t, plan.Metadata.DestructiveOps)
+	assert.Zero(t, plan.Metadata.BytesBackedUp)
+	assert.Equal(t, dot.RiskLow, plan.Metadata.RiskLevel)
+}
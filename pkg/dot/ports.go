@@ -40,6 +40,9 @@ type FileInfo = domain.FileInfo
 // DirEntry provides information about a directory entry.
 type DirEntry = domain.DirEntry
 
+// WalkFunc is called for each entry visited by FSReader.Walk.
+type WalkFunc = domain.WalkFunc
+
 // Logger provides structured logging.
 type Logger = domain.Logger
 
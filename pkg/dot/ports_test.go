@@ -45,6 +45,14 @@ func (m *MockFS) ReadFile(ctx context.Context, name string) ([]byte, error) {
 	return args.Get(0).([]byte), args.Error(1)
 }
 
+func (m *MockFS) ReadFileRange(ctx context.Context, name string, offset, length int64) ([]byte, error) {
+	args := m.Called(ctx, name, offset, length)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
 func (m *MockFS) WriteFile(ctx context.Context, name string, data []byte, perm fs.FileMode) error {
 	args := m.Called(ctx, name, data, perm)
 	return args.Error(0)
@@ -75,6 +83,11 @@ func (m *MockFS) Symlink(ctx context.Context, oldname, newname string) error {
 	return args.Error(0)
 }
 
+func (m *MockFS) Link(ctx context.Context, oldname, newname string) error {
+	args := m.Called(ctx, oldname, newname)
+	return args.Error(0)
+}
+
 func (m *MockFS) Rename(ctx context.Context, oldname, newname string) error {
 	args := m.Called(ctx, oldname, newname)
 	return args.Error(0)
@@ -95,6 +108,11 @@ func (m *MockFS) IsSymlink(ctx context.Context, name string) (bool, error) {
 	return args.Bool(0), args.Error(1)
 }
 
+func (m *MockFS) Walk(ctx context.Context, root string, fn dot.WalkFunc) error {
+	args := m.Called(ctx, root, fn)
+	return args.Error(0)
+}
+
 func TestMockFS(t *testing.T) {
 	ctx := context.Background()
 	mockFS := new(MockFS)
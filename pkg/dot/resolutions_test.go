@@ -0,0 +1,140 @@
+package dot_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaklabco/dot/internal/adapters"
+	"github.com/yaklabco/dot/pkg/dot"
+)
+
+func TestClient_Manage_RecordsBackupResolutions(t *testing.T) {
+	ctx := context.Background()
+	tmpDir := t.TempDir()
+	packageDir := filepath.Join(tmpDir, "packages")
+	targetDir := filepath.Join(tmpDir, "target")
+	backupDir := filepath.Join(tmpDir, "backups")
+
+	files := map[string]string{"bash": "dot-bashrc", "vim": "dot-vimrc"}
+	for pkg, file := range files {
+		require.NoError(t, os.MkdirAll(filepath.Join(packageDir, pkg), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(packageDir, pkg, file), []byte("managed"), 0644))
+	}
+	require.NoError(t, os.MkdirAll(targetDir, 0755))
+	require.NoError(t, os.MkdirAll(backupDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(targetDir, ".bashrc"), []byte("original bashrc"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(targetDir, ".vimrc"), []byte("original vimrc"), 0644))
+
+	client, err := dot.NewClient(dot.Config{
+		PackageDir: packageDir,
+		TargetDir:  targetDir,
+		BackupDir:  backupDir,
+		Backup:     true,
+		FS:         adapters.NewOSFilesystem(),
+		Logger:     adapters.NewNoopLogger(),
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, client.Manage(ctx, "bash", "vim"))
+
+	records, err := client.Resolutions(ctx)
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+
+	byPath := make(map[string]dot.ResolutionRecord)
+	for _, record := range records {
+		byPath[record.Path] = record
+	}
+	for pkg, original := range map[string]string{"bash": ".bashrc", "vim": ".vimrc"} {
+		record, ok := byPath[filepath.Join(targetDir, original)]
+		require.True(t, ok, "missing resolution for %s", original)
+		assert.Equal(t, pkg, record.Package)
+		assert.Equal(t, "backup", record.Policy)
+		assert.Equal(t, "file_exists", record.Type)
+		assert.False(t, record.ResolvedAt.IsZero())
+
+		// The recorded backup holds the original content
+		data, err := os.ReadFile(record.BackupPath)
+		require.NoError(t, err)
+		assert.Equal(t, "original "+original[1:], string(data))
+	}
+
+	vimOnly, err := client.Resolutions(ctx, "vim")
+	require.NoError(t, err)
+	require.Len(t, vimOnly, 1)
+	assert.Equal(t, filepath.Join(targetDir, ".vimrc"), vimOnly[0].Path)
+}
+
+func TestClient_Resolutions_EmptyWithoutManifest(t *testing.T) {
+	client, err := dot.NewClient(dot.Config{
+		PackageDir: "/test/packages",
+		TargetDir:  "/test/target",
+		FS:         adapters.NewMemFS(),
+		Logger:     adapters.NewNoopLogger(),
+	})
+	require.NoError(t, err)
+
+	records, err := client.Resolutions(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, records)
+}
+
+func TestClient_ManageWithOptions_PerPathResolutions(t *testing.T) {
+	ctx := context.Background()
+	tmpDir := t.TempDir()
+	packageDir := filepath.Join(tmpDir, "packages")
+	targetDir := filepath.Join(tmpDir, "target")
+	backupDir := filepath.Join(tmpDir, "backups")
+
+	require.NoError(t, os.MkdirAll(filepath.Join(packageDir, "shell"), 0755))
+	for _, name := range []string{"bashrc", "profile", "zshrc"} {
+		require.NoError(t, os.WriteFile(filepath.Join(packageDir, "shell", "dot-"+name), []byte("managed"), 0644))
+	}
+	require.NoError(t, os.MkdirAll(targetDir, 0755))
+	require.NoError(t, os.MkdirAll(backupDir, 0755))
+	for _, name := range []string{"bashrc", "profile", "zshrc"} {
+		require.NoError(t, os.WriteFile(filepath.Join(targetDir, "."+name), []byte("original"), 0644))
+	}
+
+	client, err := dot.NewClient(dot.Config{
+		PackageDir: packageDir,
+		TargetDir:  targetDir,
+		BackupDir:  backupDir,
+		FS:         adapters.NewOSFilesystem(),
+		Logger:     adapters.NewNoopLogger(),
+	})
+	require.NoError(t, err)
+
+	// Without resolutions the default policy fails on every conflict
+	plan, err := client.PlanManage(ctx, "shell")
+	require.NoError(t, err)
+	require.Len(t, plan.Metadata.Conflicts, 3)
+
+	opts := dot.ManageOptions{Resolutions: map[string]dot.ResolutionPolicy{
+		filepath.Join(targetDir, ".bashrc"):  dot.PolicyBackup,
+		filepath.Join(targetDir, ".profile"): dot.PolicyOverwrite,
+		filepath.Join(targetDir, ".zshrc"):   dot.PolicySkip,
+	}}
+	require.NoError(t, client.ManageWithOptions(ctx, opts, "shell"))
+
+	for _, name := range []string{".bashrc", ".profile"} {
+		info, err := os.Lstat(filepath.Join(targetDir, name))
+		require.NoError(t, err)
+		assert.Equal(t, os.ModeSymlink, info.Mode()&os.ModeSymlink, name)
+	}
+	data, err := os.ReadFile(filepath.Join(targetDir, ".zshrc"))
+	require.NoError(t, err)
+	assert.Equal(t, "original", string(data), "skipped file is left alone")
+
+	runs, err := os.ReadDir(backupDir)
+	require.NoError(t, err)
+	require.Len(t, runs, 1, "one run subdirectory")
+	backups, err := os.ReadDir(filepath.Join(backupDir, runs[0].Name()))
+	require.NoError(t, err)
+	require.Len(t, backups, 1)
+	assert.Contains(t, backups[0].Name(), ".bashrc")
+}
@@ -58,6 +58,17 @@ func FlatMap[T, U any](r Result[T], fn func(T) Result[U]) Result[U] {
 	return Result[U](domain.FlatMap(domain.Result[T](r), wrapped))
 }
 
+// Match returns onOk applied to the contained value if Ok, or onErr applied
+// to the error otherwise.
+func Match[T, R any](r Result[T], onOk func(T) R, onErr func(error) R) R {
+	return domain.Match(domain.Result[T](r), onOk, onErr)
+}
+
+// Tap calls fn with the contained value if Ok and returns r unchanged.
+func Tap[T any](r Result[T], fn func(T)) Result[T] {
+	return Result[T](domain.Tap(domain.Result[T](r), fn))
+}
+
 // Collect aggregates a slice of Results into a Result containing a slice.
 // Returns Err if any Result is Err, otherwise returns Ok with all values.
 func Collect[T any](results []Result[T]) Result[[]T] {
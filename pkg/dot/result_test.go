@@ -2,6 +2,7 @@ package dot_test
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -98,6 +99,50 @@ func TestFlatMap(t *testing.T) {
 	})
 }
 
+func TestMatch(t *testing.T) {
+	describe := func(r dot.Result[int]) string {
+		return dot.Match(r,
+			func(x int) string { return fmt.Sprintf("ok %d", x) },
+			func(err error) string { return "err " + err.Error() })
+	}
+
+	t.Run("match on Ok", func(t *testing.T) {
+		var got string
+		assert.NotPanics(t, func() { got = describe(dot.Ok(42)) })
+		assert.Equal(t, "ok 42", got)
+	})
+
+	t.Run("match on Err", func(t *testing.T) {
+		var got string
+		assert.NotPanics(t, func() { got = describe(dot.Err[int](errors.New("test error"))) })
+		assert.Equal(t, "err test error", got)
+	})
+}
+
+func TestTap(t *testing.T) {
+	t.Run("tap on Ok", func(t *testing.T) {
+		var seen []int
+		result := dot.Tap(dot.Ok(42), func(x int) { seen = append(seen, x) })
+
+		assert.Equal(t, []int{42}, seen)
+		assert.True(t, result.IsOk())
+		assert.Equal(t, 42, result.Unwrap())
+	})
+
+	t.Run("tap on Err", func(t *testing.T) {
+		err := errors.New("test error")
+		called := false
+		var result dot.Result[int]
+		assert.NotPanics(t, func() {
+			result = dot.Tap(dot.Err[int](err), func(int) { called = true })
+		})
+
+		assert.False(t, called)
+		assert.True(t, result.IsErr())
+		assert.Equal(t, err, result.UnwrapErr())
+	})
+}
+
 func TestCollect(t *testing.T) {
 	t.Run("all Ok", func(t *testing.T) {
 		results := []dot.Result[int]{
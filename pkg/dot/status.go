@@ -1,6 +1,13 @@
 package dot
 
-import "time"
+import (
+	"cmp"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+	"time"
+)
 
 // Status represents the installation state of packages.
 type Status struct {
@@ -19,4 +26,130 @@ type PackageInfo struct {
 	PackageDir  string    `json:"package_dir,omitempty" yaml:"package_dir,omitempty"`
 	IsHealthy   bool      `json:"is_healthy" yaml:"is_healthy"`
 	IssueType   string    `json:"issue_type,omitempty" yaml:"issue_type,omitempty"`
+	// BrokenLinks counts links that dangle, point outside the package or
+	// cannot be read.
+	BrokenLinks int `json:"broken_links,omitempty" yaml:"broken_links,omitempty"`
+	// Orphaned counts links recorded in the manifest that are no longer
+	// present in the target directory.
+	Orphaned int `json:"orphaned,omitempty" yaml:"orphaned,omitempty"`
+}
+
+// LinkState is the health of a single managed link.
+type LinkState string
+
+const (
+	// LinkStateOK means the link resolves to an existing source in its
+	// package.
+	LinkStateOK LinkState = "ok"
+	// LinkStateBroken means the link is missing, dangling, points outside
+	// its package, was replaced by a file, or cannot be read.
+	LinkStateBroken LinkState = "broken"
+)
+
+// DetailedStatus is the per-link installation state returned by
+// StatusDetailed.
+type DetailedStatus struct {
+	Packages []PackageDetail `json:"packages" yaml:"packages"`
+	NotFound []string        `json:"not_found,omitempty" yaml:"not_found,omitempty"`
+}
+
+// PackageDetail lists the links of one installed package.
+type PackageDetail struct {
+	Name        string       `json:"name" yaml:"name"`
+	PackageDir  string       `json:"package_dir,omitempty" yaml:"package_dir,omitempty"`
+	InstalledAt time.Time    `json:"installed_at" yaml:"installed_at"`
+	IsHealthy   bool         `json:"is_healthy" yaml:"is_healthy"`
+	Links       []LinkDetail `json:"links" yaml:"links"`
+}
+
+// LinkDetail describes one managed link.
+type LinkDetail struct {
+	// Target is the absolute path of the link in the target directory.
+	Target string `json:"target" yaml:"target"`
+	// Source is the absolute path the link resolves to, or the source
+	// recorded in the manifest when the link is missing or not a symlink.
+	Source string `json:"source,omitempty" yaml:"source,omitempty"`
+	// Mode is "relative" or "absolute" depending on the stored target, and
+	// empty when the link is missing or not a symlink.
+	Mode  string    `json:"mode,omitempty" yaml:"mode,omitempty"`
+	State LinkState `json:"state" yaml:"state"`
+	// Issue explains why a broken link is broken.
+	Issue string `json:"issue,omitempty" yaml:"issue,omitempty"`
+}
+
+// StatusSortKey selects the field StatusWithOptions orders packages by.
+type StatusSortKey string
+
+// Sort keys, mirroring the packages.sort_by configuration values.
+const (
+	// StatusSortNone keeps the order packages were requested in, or orders
+	// by name when none were named.
+	StatusSortNone StatusSortKey = ""
+	// StatusSortName orders by package name.
+	StatusSortName StatusSortKey = "name"
+	// StatusSortLinks orders by link count.
+	StatusSortLinks StatusSortKey = "links"
+	// StatusSortDate orders by installation time.
+	StatusSortDate StatusSortKey = "date"
+)
+
+// StatusOptions configures StatusWithOptions. The zero value reports every
+// requested package in the same order as Status.
+type StatusOptions struct {
+	// Filter is a glob matched against package names, such as "n*". Empty
+	// matches every package.
+	Filter string
+	// SortBy orders the returned packages. Ties are broken by name.
+	SortBy StatusSortKey
+	// Descending reverses the order of SortBy: largest, latest or last
+	// name first. Ties are still broken by ascending name.
+	Descending bool
+}
+
+// validate checks the filter pattern and sort key.
+func (o StatusOptions) validate() error {
+	if _, err := path.Match(o.Filter, ""); err != nil {
+		return fmt.Errorf("invalid filter %q: %w", o.Filter, err)
+	}
+	switch o.SortBy {
+	case StatusSortNone, StatusSortName, StatusSortLinks, StatusSortDate:
+		return nil
+	default:
+		return fmt.Errorf("invalid sort key %q (must be one of: name, links, date)", o.SortBy)
+	}
+}
+
+// matches reports whether name passes the filter.
+func (o StatusOptions) matches(name string) bool {
+	if o.Filter == "" {
+		return true
+	}
+	ok, _ := path.Match(o.Filter, name)
+	return ok
+}
+
+// sort orders packages by SortBy, leaving them untouched when it is unset.
+func (o StatusOptions) sort(packages []PackageInfo) {
+	var compare func(a, b PackageInfo) int
+	switch o.SortBy {
+	case StatusSortName:
+		compare = func(a, b PackageInfo) int { return strings.Compare(a.Name, b.Name) }
+	case StatusSortLinks:
+		compare = func(a, b PackageInfo) int { return cmp.Compare(a.LinkCount, b.LinkCount) }
+	case StatusSortDate:
+		compare = func(a, b PackageInfo) int { return a.InstalledAt.Compare(b.InstalledAt) }
+	default:
+		return
+	}
+
+	slices.SortStableFunc(packages, func(a, b PackageInfo) int {
+		c := compare(a, b)
+		if o.Descending {
+			c = -c
+		}
+		if c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
 }
@@ -0,0 +1,269 @@
+package dot
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/yaklabco/dot/internal/domain"
+	"github.com/yaklabco/dot/internal/manifest"
+)
+
+// statusCacheVersion is the schema version of the status cache file. A
+// file with any other version is discarded; bump it whenever the layout or
+// the meaning of the cached counts changes.
+const statusCacheVersion = 2
+
+// statusRacyWindow is how recently a tracked path may have changed for a
+// package's health to still be cached. Filesystems with coarse timestamps
+// could give a path changed right after the check the same mtime, so such
+// packages are checked again until their paths settle.
+const statusRacyWindow = time.Second
+
+// missingMtime records that a tracked path did not exist.
+const missingMtime int64 = -1
+
+// statusCache stores the link health of installed packages on disk, so
+// status need not check every link of an unchanged package again. An entry
+// records the mtime and mode of the package directory, of the directories
+// holding each link, and of each link's source and the directories leading
+// to it, and is used only while all of them still match. Symlinks cannot be
+// changed in place, so removing, re-pointing or replacing a link, like
+// adding or removing a source file, updates one of those directories. A
+// chmod changes only the mode, which decides permission results.
+type statusCache struct {
+	fs         FS
+	dir        string
+	targetDir  string
+	packageDir string
+	// now returns the current time; tests move it forward so freshly
+	// created paths are old enough to cache.
+	now func() time.Time
+}
+
+// newStatusCache creates a cache for the status of packages installed from
+// packageDir into targetDir, storing its file in dir through fs.
+func newStatusCache(fs FS, dir, targetDir, packageDir string) *statusCache {
+	return &statusCache{
+		fs:         fs,
+		dir:        dir,
+		targetDir:  targetDir,
+		packageDir: packageDir,
+		now:        time.Now,
+	}
+}
+
+// statusCacheFile is the on-disk form of the cache for one target
+// directory.
+type statusCacheFile struct {
+	Version    int                         `json:"version"`
+	TargetDir  string                      `json:"target_dir"`
+	PackageDir string                      `json:"package_dir"`
+	Packages   map[string]statusCacheEntry `json:"packages"`
+}
+
+// statusCacheEntry is the cached link health of one package.
+type statusCacheEntry struct {
+	PackageDir string   `json:"package_dir"`
+	Links      []string `json:"links"`
+	// Paths maps every tracked path to its state when checked.
+	Paths       map[string]pathStamp `json:"paths"`
+	Broken      int                  `json:"broken"`
+	WrongTarget int                  `json:"wrong_target"`
+	Missing     int                  `json:"missing"`
+	Permission  int                  `json:"permission"`
+}
+
+// pathStamp is the state of a tracked path that invalidates a cache entry
+// when it changes.
+type pathStamp struct {
+	// Mtime is in nanoseconds, or missingMtime if the path did not exist.
+	Mtime int64       `json:"mtime"`
+	Mode  fs.FileMode `json:"mode"`
+}
+
+// statusCacheSession holds the cache file loaded for one status call and
+// collects the entries to write back.
+type statusCacheSession struct {
+	cache     *statusCache
+	file      statusCacheFile
+	checkedAt time.Time
+	changed   bool
+}
+
+// load reads the cache file, starting empty if it is missing, unreadable
+// or written for another version or installation.
+func (c *statusCache) load(ctx context.Context) *statusCacheSession {
+	session := &statusCacheSession{
+		cache:     c,
+		checkedAt: c.now(),
+		file: statusCacheFile{
+			Version:    statusCacheVersion,
+			TargetDir:  c.targetDir,
+			PackageDir: c.packageDir,
+			Packages:   make(map[string]statusCacheEntry),
+		},
+	}
+
+	data, err := c.fs.ReadFile(ctx, c.path())
+	if err != nil {
+		return session
+	}
+	var file statusCacheFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return session
+	}
+	if file.Version != statusCacheVersion || file.TargetDir != c.targetDir ||
+		file.PackageDir != c.packageDir || file.Packages == nil {
+		// Rewrite the outdated file even if nothing can be cached
+		session.changed = true
+		return session
+	}
+	session.file = file
+	return session
+}
+
+// path returns the cache file for the target directory.
+func (c *statusCache) path() string {
+	sum := sha256.Sum256([]byte(c.targetDir))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:16])+".json")
+}
+
+// counts returns the cached link counts of the package if none of its
+// tracked paths changed on fsys.
+func (s *statusCacheSession) counts(ctx context.Context, fsys FS, info manifest.PackageInfo) (packageLinkCounts, bool) {
+	entry, ok := s.file.Packages[info.Name]
+	if !ok || entry.PackageDir != info.PackageDir || !slices.Equal(entry.Links, info.Links) || len(entry.Paths) == 0 {
+		return packageLinkCounts{}, false
+	}
+	for path, stamp := range entry.Paths {
+		current, err := lstatStamp(ctx, fsys, path)
+		if err != nil || current != stamp {
+			return packageLinkCounts{}, false
+		}
+	}
+	return packageLinkCounts{
+		broken:      entry.Broken,
+		wrongTarget: entry.WrongTarget,
+		missing:     entry.Missing,
+		permission:  entry.Permission,
+	}, true
+}
+
+// record caches freshly checked counts for the package, unless one of its
+// tracked paths cannot be read or changed too recently to be trusted.
+func (s *statusCacheSession) record(ctx context.Context, fsys FS, targetDir string, info manifest.PackageInfo, counts packageLinkCounts) {
+	delete(s.file.Packages, info.Name)
+	s.changed = true
+
+	stamps, err := trackedStamps(ctx, fsys, targetDir, info)
+	if err != nil {
+		return
+	}
+	racyAfter := s.checkedAt.Add(-statusRacyWindow).UnixNano()
+	for _, stamp := range stamps {
+		if stamp.Mtime != missingMtime && stamp.Mtime >= racyAfter {
+			return
+		}
+	}
+
+	s.file.Packages[info.Name] = statusCacheEntry{
+		PackageDir:  info.PackageDir,
+		Links:       slices.Clone(info.Links),
+		Paths:       stamps,
+		Broken:      counts.broken,
+		WrongTarget: counts.wrongTarget,
+		Missing:     counts.missing,
+		Permission:  counts.permission,
+	}
+}
+
+// save writes the cache file back if any entry changed.
+func (s *statusCacheSession) save(ctx context.Context) error {
+	if !s.changed {
+		return nil
+	}
+	data, err := json.Marshal(s.file)
+	if err != nil {
+		return fmt.Errorf("encode status cache: %w", err)
+	}
+	if err := s.cache.fs.MkdirAll(ctx, s.cache.dir, domain.PermUserRWX); err != nil {
+		return fmt.Errorf("create status cache directory: %w", err)
+	}
+	if err := s.cache.fs.WriteFile(ctx, s.cache.path(), data, domain.PermUserRW); err != nil {
+		return fmt.Errorf("write status cache: %w", err)
+	}
+	return nil
+}
+
+// trackedStamps returns the state of the paths whose changes may affect
+// the health of the package's links: the package directory, the
+// directories holding each link, and each link's source with the
+// directories from the package down to it. A single lstat of each is still
+// cheaper than the lstat, readlink and stat of checking a link.
+func trackedStamps(ctx context.Context, fsys FS, targetDir string, info manifest.PackageInfo) (map[string]pathStamp, error) {
+	stamps := make(map[string]pathStamp)
+	track := func(path string) error {
+		if _, seen := stamps[path]; seen {
+			return nil
+		}
+		stamp, err := lstatStamp(ctx, fsys, path)
+		if err != nil {
+			return err
+		}
+		stamps[path] = stamp
+		return nil
+	}
+
+	if info.PackageDir != "" {
+		if err := track(info.PackageDir); err != nil {
+			return nil, err
+		}
+	}
+	for _, link := range info.Links {
+		fullPath := filepath.Join(targetDir, link)
+		if err := track(filepath.Dir(fullPath)); err != nil {
+			return nil, err
+		}
+		target, err := fsys.ReadLink(ctx, fullPath)
+		if err != nil {
+			// Missing links and regular files have no source to track
+			continue
+		}
+		source := domain.ResolveLinkTarget(fullPath, target)
+		if err := track(source); err != nil {
+			return nil, err
+		}
+		// Search permission on every directory down to the source decides
+		// whether it can be reached
+		for dir := filepath.Dir(source); ; dir = filepath.Dir(dir) {
+			if err := track(dir); err != nil {
+				return nil, err
+			}
+			if info.PackageDir == "" || dir == info.PackageDir || !isInPackageDir(dir, info.PackageDir) {
+				break
+			}
+		}
+	}
+	return stamps, nil
+}
+
+// lstatStamp returns the mtime and mode of path, with missingMtime if it
+// does not exist.
+func lstatStamp(ctx context.Context, fsys FS, path string) (pathStamp, error) {
+	info, err := fsys.Lstat(ctx, path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return pathStamp{Mtime: missingMtime}, nil
+	}
+	if err != nil {
+		return pathStamp{}, err
+	}
+	return pathStamp{Mtime: info.ModTime().UnixNano(), Mode: info.Mode()}, nil
+}
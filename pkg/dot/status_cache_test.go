@@ -0,0 +1,221 @@
+package dot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaklabco/dot/internal/adapters"
+)
+
+// linkReadCountingFS counts symlink reads, which every link health check
+// makes and a status cache hit does not.
+type linkReadCountingFS struct {
+	FS
+	readLinks atomic.Int64
+}
+
+func (f *linkReadCountingFS) ReadLink(ctx context.Context, name string) (string, error) {
+	f.readLinks.Add(1)
+	return f.FS.ReadLink(ctx, name)
+}
+
+// newStatusCacheClient manages packages with nested files on disk and
+// returns a client whose status cache treats every path as settled.
+func newStatusCacheClient(tb testing.TB, packages, files int, cached bool) (*Client, *linkReadCountingFS, *testEnv) {
+	tb.Helper()
+	env := &testEnv{PackageDir: filepath.Join(tb.TempDir(), "packages"), TargetDir: tb.TempDir(), ctx: context.Background()}
+	names := make([]string, 0, packages)
+	for p := 0; p < packages; p++ {
+		name := fmt.Sprintf("pkg%d", p)
+		names = append(names, name)
+		dir := filepath.Join(env.PackageDir, name, "dot-config", name)
+		require.NoError(tb, os.MkdirAll(dir, 0o755))
+		for f := 0; f < files; f++ {
+			require.NoError(tb, os.WriteFile(filepath.Join(dir, fmt.Sprintf("file%d", f)), []byte("x"), 0o644))
+		}
+	}
+
+	fs := &linkReadCountingFS{FS: adapters.NewOSFilesystem()}
+	cfg := Config{
+		PackageDir: env.PackageDir,
+		TargetDir:  env.TargetDir,
+		Folding:    false,
+		FS:         fs,
+		Logger:     adapters.NewNoopLogger(),
+	}
+	if cached {
+		cfg.CacheDir = filepath.Join(tb.TempDir(), "cache")
+	}
+	client, err := NewClient(cfg)
+	require.NoError(tb, err)
+	require.NoError(tb, client.Manage(env.ctx, names...))
+	if cached {
+		client.statusSvc.cache.now = func() time.Time { return time.Now().Add(time.Hour) }
+	}
+	return client, fs, env
+}
+
+func TestStatusCache_HitSkipsLinkChecks(t *testing.T) {
+	client, fs, env := newStatusCacheClient(t, 2, 3, true)
+
+	first, err := client.Status(env.ctx)
+	require.NoError(t, err)
+	require.Positive(t, fs.readLinks.Load())
+
+	fs.readLinks.Store(0)
+	second, err := client.Status(env.ctx)
+	require.NoError(t, err)
+	assert.Zero(t, fs.readLinks.Load(), "cache hit should not check links")
+	assert.Equal(t, first, second)
+}
+
+func TestStatusCache_StaleEntriesAreRechecked(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(t *testing.T, env *testEnv)
+		check  func(t *testing.T, info PackageInfo)
+	}{
+		{
+			name: "source file removed from nested directory",
+			mutate: func(t *testing.T, env *testEnv) {
+				require.NoError(t, os.Remove(filepath.Join(env.PackageDir, "pkg0", "dot-config", "pkg0", "file1")))
+			},
+			check: func(t *testing.T, info PackageInfo) {
+				assert.False(t, info.IsHealthy)
+				assert.Equal(t, 1, info.BrokenLinks)
+			},
+		},
+		{
+			name: "link removed",
+			mutate: func(t *testing.T, env *testEnv) {
+				require.NoError(t, os.Remove(filepath.Join(env.TargetDir, ".config", "pkg0", "file0")))
+			},
+			check: func(t *testing.T, info PackageInfo) {
+				assert.False(t, info.IsHealthy)
+				assert.Equal(t, 1, info.Orphaned)
+			},
+		},
+		{
+			name: "link re-pointed outside the package",
+			mutate: func(t *testing.T, env *testEnv) {
+				link := filepath.Join(env.TargetDir, ".config", "pkg0", "file2")
+				foreign := filepath.Join(env.TargetDir, "foreign")
+				require.NoError(t, os.WriteFile(foreign, []byte("y"), 0o644))
+				require.NoError(t, os.Remove(link))
+				require.NoError(t, os.Symlink(foreign, link))
+			},
+			check: func(t *testing.T, info PackageInfo) {
+				assert.False(t, info.IsHealthy)
+				assert.Equal(t, "wrong target", info.IssueType)
+			},
+		},
+		{
+			name: "source file permissions changed",
+			mutate: func(t *testing.T, env *testEnv) {
+				require.NoError(t, os.Chmod(filepath.Join(env.PackageDir, "pkg0", "dot-config", "pkg0", "file0"), 0o600))
+			},
+			check: func(t *testing.T, info PackageInfo) {
+				assert.True(t, info.IsHealthy)
+			},
+		},
+		{
+			name: "source directory permissions changed",
+			mutate: func(t *testing.T, env *testEnv) {
+				dir := filepath.Join(env.PackageDir, "pkg0", "dot-config")
+				require.NoError(t, os.Chmod(dir, 0o700))
+			},
+			check: func(t *testing.T, info PackageInfo) {
+				assert.True(t, info.IsHealthy)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, fs, env := newStatusCacheClient(t, 1, 3, true)
+			status, err := client.Status(env.ctx)
+			require.NoError(t, err)
+			require.True(t, status.Packages[0].IsHealthy)
+
+			tt.mutate(t, env)
+			fs.readLinks.Store(0)
+			status, err = client.Status(env.ctx)
+			require.NoError(t, err)
+			assert.Positive(t, fs.readLinks.Load(), "stale entry should be rechecked")
+			require.Len(t, status.Packages, 1)
+			tt.check(t, status.Packages[0])
+		})
+	}
+}
+
+func TestStatusCache_KeepsRecentChangesUncached(t *testing.T) {
+	client, fs, env := newStatusCacheClient(t, 1, 2, true)
+	client.statusSvc.cache.now = time.Now
+
+	_, err := client.Status(env.ctx)
+	require.NoError(t, err)
+	fs.readLinks.Store(0)
+	_, err = client.Status(env.ctx)
+	require.NoError(t, err)
+	assert.Positive(t, fs.readLinks.Load(), "freshly created links should not be cached")
+}
+
+func TestStatusCache_DiscardsOtherVersions(t *testing.T) {
+	client, fs, env := newStatusCacheClient(t, 1, 2, true)
+	_, err := client.Status(env.ctx)
+	require.NoError(t, err)
+
+	cache := client.statusSvc.cache
+	data, err := os.ReadFile(cache.path())
+	require.NoError(t, err)
+	var file map[string]any
+	require.NoError(t, json.Unmarshal(data, &file))
+	file["version"] = statusCacheVersion + 1
+	data, err = json.Marshal(file)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(cache.path(), data, 0o644))
+
+	fs.readLinks.Store(0)
+	_, err = client.Status(env.ctx)
+	require.NoError(t, err)
+	assert.Positive(t, fs.readLinks.Load(), "file with another schema version should be discarded")
+}
+
+func TestStatusCache_Disabled(t *testing.T) {
+	client, fs, env := newStatusCacheClient(t, 1, 2, false)
+	assert.Nil(t, client.statusSvc.cache)
+
+	for i := 0; i < 2; i++ {
+		fs.readLinks.Store(0)
+		_, err := client.Status(env.ctx)
+		require.NoError(t, err)
+		assert.Positive(t, fs.readLinks.Load())
+	}
+}
+
+// BenchmarkStatus compares status of 20 packages of 25 links each with and
+// without a warm status cache.
+func BenchmarkStatus(b *testing.B) {
+	for _, cached := range []bool{false, true} {
+		b.Run(fmt.Sprintf("cached=%t", cached), func(b *testing.B) {
+			client, _, env := newStatusCacheClient(b, 20, 25, cached)
+			if _, err := client.Status(env.ctx); err != nil {
+				b.Fatal(err)
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := client.Status(env.ctx); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -2,6 +2,11 @@ package dot
 
 import (
 	"context"
+	"path/filepath"
+	"sort"
+
+	"github.com/yaklabco/dot/internal/domain"
+	"github.com/yaklabco/dot/internal/manifest"
 )
 
 // StatusService handles status and listing operations.
@@ -11,87 +16,211 @@ type StatusService struct {
 	manifestSvc   *ManifestService
 	targetDir     string
 	healthChecker *HealthChecker
+	// cache holds the link health of unchanged packages between calls;
+	// nil checks every link each time.
+	cache *statusCache
 }
 
 // newStatusService creates a new status service.
-func newStatusService(fs FS, logger Logger, manifestSvc *ManifestService, targetDir string) *StatusService {
+func newStatusService(fs FS, logger Logger, manifestSvc *ManifestService, packageDir, targetDir string) *StatusService {
 	return &StatusService{
 		fs:            fs,
 		logger:        logger,
 		manifestSvc:   manifestSvc,
 		targetDir:     targetDir,
-		healthChecker: newHealthChecker(fs, targetDir),
+		healthChecker: newHealthChecker(fs, packageDir, targetDir),
 	}
 }
 
 // Status reports the current installation state for packages.
 func (s *StatusService) Status(ctx context.Context, packages ...string) (Status, error) {
+	return s.StatusWithOptions(ctx, StatusOptions{}, packages...)
+}
+
+// StatusWithOptions reports the installation state for packages, filtered
+// and ordered as opts requests.
+func (s *StatusService) StatusWithOptions(ctx context.Context, opts StatusOptions, packages ...string) (Status, error) {
+	if err := opts.validate(); err != nil {
+		return Status{}, err
+	}
+
+	selected, notFound, err := s.enumerate(ctx, opts, packages)
+	if err != nil {
+		return Status{}, err
+	}
+
+	session := s.loadCache(ctx)
+	pkgInfos := make([]PackageInfo, 0, len(selected))
+	for _, info := range selected {
+		pkgInfos = append(pkgInfos, s.describe(ctx, session, info))
+	}
+	s.saveCache(ctx, session)
+	opts.sort(pkgInfos)
+
+	return Status{
+		Packages: pkgInfos,
+		NotFound: notFound,
+	}, nil
+}
+
+// enumerate loads the manifest and returns the requested packages that
+// pass the filter in opts, in request order or by name when none are
+// named, along with the requested names the manifest lacks. Health is not
+// checked here, so filtered out packages cost nothing.
+func (s *StatusService) enumerate(ctx context.Context, opts StatusOptions, packages []string) ([]manifest.PackageInfo, []string, error) {
 	targetPathResult := NewTargetPath(s.targetDir)
 	if !targetPathResult.IsOk() {
-		return Status{}, targetPathResult.UnwrapErr()
+		return nil, nil, targetPathResult.UnwrapErr()
 	}
-	targetPath := targetPathResult.Unwrap()
 
-	// Load manifest
-	manifestResult := s.manifestSvc.Load(ctx, targetPath)
+	manifestResult := s.manifestSvc.Load(ctx, targetPathResult.Unwrap())
 	if !manifestResult.IsOk() {
 		err := manifestResult.UnwrapErr()
 		if isManifestNotFoundError(err) {
 			// No manifest means nothing installed
-			return Status{
-				Packages: []PackageInfo{},
-				NotFound: packages,
-			}, nil
+			return nil, packages, nil
 		}
-		return Status{}, err
+		return nil, nil, err
 	}
-
 	m := manifestResult.Unwrap()
 
-	// Filter to requested packages if specified
-	pkgInfos := make([]PackageInfo, 0)
+	var selected []manifest.PackageInfo
 	var notFound []string
 	if len(packages) == 0 {
-		// Return all packages
 		for _, info := range m.Packages {
-			isHealthy, issueType := s.checkPackageHealth(ctx, info.Name, info.Links, info.PackageDir)
-			pkgInfos = append(pkgInfos, PackageInfo{
-				Name:        info.Name,
-				Source:      string(info.Source),
-				InstalledAt: info.InstalledAt,
-				LinkCount:   info.LinkCount,
-				Links:       info.Links,
-				TargetDir:   info.TargetDir,
-				PackageDir:  info.PackageDir,
-				IsHealthy:   isHealthy,
-				IssueType:   issueType,
-			})
+			if opts.matches(info.Name) {
+				selected = append(selected, info)
+			}
+		}
+		sort.Slice(selected, func(i, j int) bool {
+			return selected[i].Name < selected[j].Name
+		})
+		return selected, nil, nil
+	}
+
+	for _, pkg := range packages {
+		info, exists := m.GetPackage(pkg)
+		if !exists {
+			notFound = append(notFound, pkg)
+			continue
+		}
+		if opts.matches(info.Name) {
+			selected = append(selected, info)
+		}
+	}
+	return selected, notFound, nil
+}
+
+// describe converts a manifest entry to a PackageInfo, checking the health
+// of its links unless session holds it for the unchanged package.
+func (s *StatusService) describe(ctx context.Context, session *statusCacheSession, info manifest.PackageInfo) PackageInfo {
+	counts := s.linkCounts(ctx, session, info)
+	isHealthy, issueType := packageHealth(counts)
+	return PackageInfo{
+		Name:        info.Name,
+		Source:      string(info.Source),
+		InstalledAt: info.InstalledAt,
+		LinkCount:   info.LinkCount,
+		Links:       info.Links,
+		TargetDir:   info.TargetDir,
+		PackageDir:  info.PackageDir,
+		IsHealthy:   isHealthy,
+		IssueType:   issueType,
+		BrokenLinks: counts.broken + counts.wrongTarget + counts.permission,
+		Orphaned:    counts.missing,
+	}
+}
+
+// linkCounts tallies the unhealthy links of a package, from session when
+// the package is unchanged since it was cached.
+func (s *StatusService) linkCounts(ctx context.Context, session *statusCacheSession, info manifest.PackageInfo) packageLinkCounts {
+	if session != nil {
+		if counts, ok := session.counts(ctx, s.fs, info); ok {
+			return counts
+		}
+	}
+	counts := s.healthChecker.countPackageLinks(ctx, info.Name, info.Links, info.PackageDir)
+	if session != nil {
+		session.record(ctx, s.fs, s.targetDir, info, counts)
+	}
+	return counts
+}
+
+// loadCache starts a status cache session, or returns nil when caching is
+// disabled.
+func (s *StatusService) loadCache(ctx context.Context) *statusCacheSession {
+	if s.cache == nil {
+		return nil
+	}
+	return s.cache.load(ctx)
+}
+
+// saveCache writes the session's entries back unless ctx was cancelled.
+// The cache only saves work, so a failed write is logged rather than
+// returned.
+func (s *StatusService) saveCache(ctx context.Context, session *statusCacheSession) {
+	if session == nil || ctx.Err() != nil {
+		return
+	}
+	if err := session.save(ctx); err != nil {
+		s.logger.Warn(ctx, "status_cache_write_failed", "error", err)
+	}
+}
+
+// StatusDetailed reports every link of packages, or of all installed
+// packages when none are given. It reads the manifest and inspects each
+// link in place rather than planning the packages again.
+func (s *StatusService) StatusDetailed(ctx context.Context, packages ...string) (DetailedStatus, error) {
+	selected, notFound, err := s.enumerate(ctx, StatusOptions{}, packages)
+	if err != nil {
+		return DetailedStatus{}, err
+	}
+
+	details := make([]PackageDetail, 0, len(selected))
+	for _, info := range selected {
+		detail := PackageDetail{
+			Name:        info.Name,
+			PackageDir:  info.PackageDir,
+			InstalledAt: info.InstalledAt,
+			IsHealthy:   true,
+			Links:       make([]LinkDetail, 0, len(info.Links)),
 		}
-	} else {
-		// Return only specified packages
-		for _, pkg := range packages {
-			if info, exists := m.GetPackage(pkg); exists {
-				isHealthy, issueType := s.checkPackageHealth(ctx, info.Name, info.Links, info.PackageDir)
-				pkgInfos = append(pkgInfos, PackageInfo{
-					Name:        info.Name,
-					Source:      string(info.Source),
-					InstalledAt: info.InstalledAt,
-					LinkCount:   info.LinkCount,
-					Links:       info.Links,
-					TargetDir:   info.TargetDir,
-					PackageDir:  info.PackageDir,
-					IsHealthy:   isHealthy,
-					IssueType:   issueType,
-				})
-			} else {
-				notFound = append(notFound, pkg)
+		for _, link := range info.Links {
+			ld := s.describeLink(ctx, info, link)
+			if ld.State != LinkStateOK {
+				detail.IsHealthy = false
 			}
+			detail.Links = append(detail.Links, ld)
 		}
+		details = append(details, detail)
 	}
-	return Status{
-		Packages: pkgInfos,
-		NotFound: notFound,
-	}, nil
+
+	return DetailedStatus{Packages: details, NotFound: notFound}, nil
+}
+
+// describeLink checks the health of one link of a package and reads where
+// it points.
+func (s *StatusService) describeLink(ctx context.Context, info manifest.PackageInfo, link string) LinkDetail {
+	fullPath := filepath.Join(s.targetDir, link)
+	detail := LinkDetail{
+		Target: fullPath,
+		Source: info.Sources[link],
+		State:  LinkStateOK,
+	}
+
+	if target, err := s.fs.ReadLink(ctx, fullPath); err == nil {
+		detail.Mode = LinkRelative.String()
+		if filepath.IsAbs(target) {
+			detail.Mode = LinkAbsolute.String()
+		}
+		detail.Source = domain.ResolveLinkTarget(fullPath, target)
+	}
+
+	if result := s.healthChecker.CheckLink(ctx, info.Name, link, info.PackageDir); !result.IsHealthy {
+		detail.State = LinkStateBroken
+		detail.Issue = result.Message
+	}
+	return detail
 }
 
 // List returns all installed packages from the manifest.
@@ -103,6 +232,70 @@ func (s *StatusService) List(ctx context.Context) ([]PackageInfo, error) {
 	return status.Packages, nil
 }
 
+// ListStream returns all installed packages one at a time, each sent as
+// soon as its health is checked. The manifest is read before returning,
+// so a load error is reported directly. The channel closes after the last
+// package or once ctx is cancelled.
+func (s *StatusService) ListStream(ctx context.Context) (<-chan PackageInfo, error) {
+	selected, _, err := s.enumerate(ctx, StatusOptions{}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan PackageInfo)
+	go func() {
+		defer close(ch)
+		session := s.loadCache(ctx)
+		defer s.saveCache(ctx, session)
+		for _, info := range selected {
+			if ctx.Err() != nil {
+				return
+			}
+			select {
+			case ch <- s.describe(ctx, session, info):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+// Resolutions returns the audit log of conflicts resolved automatically by
+// policy, oldest first. If packages are given, only their records are returned.
+func (s *StatusService) Resolutions(ctx context.Context, packages ...string) ([]ResolutionRecord, error) {
+	targetPathResult := NewTargetPath(s.targetDir)
+	if !targetPathResult.IsOk() {
+		return nil, targetPathResult.UnwrapErr()
+	}
+
+	manifestResult := s.manifestSvc.Load(ctx, targetPathResult.Unwrap())
+	if !manifestResult.IsOk() {
+		err := manifestResult.UnwrapErr()
+		if isManifestNotFoundError(err) {
+			return []ResolutionRecord{}, nil
+		}
+		return nil, err
+	}
+
+	records := manifestResult.Unwrap().Resolutions
+	if len(packages) == 0 {
+		return records, nil
+	}
+
+	wanted := make(map[string]bool, len(packages))
+	for _, pkg := range packages {
+		wanted[pkg] = true
+	}
+	filtered := make([]ResolutionRecord, 0, len(records))
+	for _, record := range records {
+		if wanted[record.Package] {
+			filtered = append(filtered, record)
+		}
+	}
+	return filtered, nil
+}
+
 // checkPackageHealth validates all symlinks for a package.
 // Returns healthy status and issue type if problems are found.
 func (s *StatusService) checkPackageHealth(ctx context.Context, pkgName string, links []string, packageDir string) (bool, string) {
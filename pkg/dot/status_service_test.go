@@ -3,6 +3,7 @@ package dot
 import (
 	"context"
 	"path/filepath"
+	"runtime"
 	"testing"
 	"time"
 
@@ -37,7 +38,7 @@ func TestStatusService_checkPackageHealth_Healthy(t *testing.T) {
 	manifestSvc := newManifestService(fs, logger, manifestStore)
 
 	// Create status service
-	svc := newStatusService(fs, logger, manifestSvc, targetDir)
+	svc := newStatusService(fs, logger, manifestSvc, "", targetDir)
 
 	// Test - use relative path from targetDir
 	isHealthy, issueType := svc.checkPackageHealth(ctx, "vim", []string{".vimrc"}, packageDir)
@@ -67,7 +68,7 @@ func TestStatusService_checkPackageHealth_BrokenLinks(t *testing.T) {
 	manifestSvc := newManifestService(fs, logger, manifestStore)
 
 	// Create status service
-	svc := newStatusService(fs, logger, manifestSvc, targetDir)
+	svc := newStatusService(fs, logger, manifestSvc, "", targetDir)
 
 	// Test - use relative path from targetDir
 	isHealthy, issueType := svc.checkPackageHealth(ctx, "vim", []string{".vimrc"}, packageDir)
@@ -102,7 +103,7 @@ func TestStatusService_checkPackageHealth_WrongTarget(t *testing.T) {
 	manifestSvc := newManifestService(fs, logger, manifestStore)
 
 	// Create status service
-	svc := newStatusService(fs, logger, manifestSvc, targetDir)
+	svc := newStatusService(fs, logger, manifestSvc, "", targetDir)
 
 	// Test - use relative path from targetDir
 	isHealthy, issueType := svc.checkPackageHealth(ctx, "vim", []string{".vimrc"}, packageDir)
@@ -127,7 +128,7 @@ func TestStatusService_checkPackageHealth_MissingLinks(t *testing.T) {
 	manifestSvc := newManifestService(fs, logger, manifestStore)
 
 	// Create status service
-	svc := newStatusService(fs, logger, manifestSvc, targetDir)
+	svc := newStatusService(fs, logger, manifestSvc, "", targetDir)
 
 	// Test with non-existent link - use relative path
 	isHealthy, issueType := svc.checkPackageHealth(ctx, "vim", []string{".vimrc"}, packageDir)
@@ -159,7 +160,7 @@ func TestStatusService_checkPackageHealth_MultipleIssues(t *testing.T) {
 	manifestSvc := newManifestService(fs, logger, manifestStore)
 
 	// Create status service
-	svc := newStatusService(fs, logger, manifestSvc, targetDir)
+	svc := newStatusService(fs, logger, manifestSvc, "", targetDir)
 
 	// Test - should report broken links (highest priority) - use relative paths
 	isHealthy, issueType := svc.checkPackageHealth(ctx, "vim", []string{".vimrc", ".vim"}, packageDir)
@@ -219,7 +220,7 @@ func TestStatusService_List_WithHealthStatus(t *testing.T) {
 	require.NoError(t, manifestSvc.Save(ctx, targetPath, m))
 
 	// Create status service
-	svc := newStatusService(fs, logger, manifestSvc, targetDir)
+	svc := newStatusService(fs, logger, manifestSvc, "", targetDir)
 
 	// Test List
 	packages, err := svc.List(ctx)
@@ -280,7 +281,7 @@ func TestStatusService_Status_NotFoundPackages(t *testing.T) {
 		manifestSvc := newManifestService(fs, logger, manifestStore)
 		require.NoError(t, manifestSvc.Save(ctx, targetPathResult.Unwrap(), m))
 
-		svc := newStatusService(fs, logger, manifestSvc, targetDir)
+		svc := newStatusService(fs, logger, manifestSvc, "", targetDir)
 
 		// Request status for vim (installed) and tmux (not installed)
 		status, err := svc.Status(ctx, "vim", "tmux")
@@ -310,7 +311,7 @@ func TestStatusService_Status_NotFoundPackages(t *testing.T) {
 		manifestSvc := newManifestService(fs, logger, manifestStore)
 		require.NoError(t, manifestSvc.Save(ctx, targetPathResult.Unwrap(), m))
 
-		svc := newStatusService(fs, logger, manifestSvc, targetDir)
+		svc := newStatusService(fs, logger, manifestSvc, "", targetDir)
 
 		status, err := svc.Status(ctx, "nonexistent")
 		require.NoError(t, err)
@@ -330,7 +331,7 @@ func TestStatusService_Status_NotFoundPackages(t *testing.T) {
 		manifestStore := manifest.NewFSManifestStore(fs)
 		manifestSvc := newManifestService(fs, logger, manifestStore)
 
-		svc := newStatusService(fs, logger, manifestSvc, targetDir)
+		svc := newStatusService(fs, logger, manifestSvc, "", targetDir)
 
 		status, err := svc.Status(ctx, "vim", "tmux")
 		require.NoError(t, err)
@@ -365,7 +366,7 @@ func TestStatusService_checkPackageHealth_RelativeSymlinks(t *testing.T) {
 	manifestSvc := newManifestService(fs, logger, manifestStore)
 
 	// Create status service
-	svc := newStatusService(fs, logger, manifestSvc, targetDir)
+	svc := newStatusService(fs, logger, manifestSvc, "", targetDir)
 
 	// Test - use relative path from targetDir
 	isHealthy, issueType := svc.checkPackageHealth(ctx, "vim", []string{".vimrc"}, packageDir)
@@ -394,7 +395,7 @@ func TestStatusService_checkPackageHealth_NotSymlink(t *testing.T) {
 	manifestSvc := newManifestService(fs, logger, manifestStore)
 
 	// Create status service
-	svc := newStatusService(fs, logger, manifestSvc, targetDir)
+	svc := newStatusService(fs, logger, manifestSvc, "", targetDir)
 
 	// Test - use relative path from targetDir
 	isHealthy, issueType := svc.checkPackageHealth(ctx, "vim", []string{".vimrc"}, packageDir)
@@ -427,7 +428,7 @@ func TestStatusService_checkPackageHealth_NoPackageDir(t *testing.T) {
 	manifestSvc := newManifestService(fs, logger, manifestStore)
 
 	// Create status service
-	svc := newStatusService(fs, logger, manifestSvc, targetDir)
+	svc := newStatusService(fs, logger, manifestSvc, "", targetDir)
 
 	// Test with empty package directory (simulating old adopted package)
 	isHealthy, issueType := svc.checkPackageHealth(ctx, "vim", []string{".vimrc"}, "")
@@ -436,3 +437,217 @@ func TestStatusService_checkPackageHealth_NoPackageDir(t *testing.T) {
 	assert.True(t, isHealthy, "Package without package_dir should be healthy if symlink exists and target exists")
 	assert.Empty(t, issueType)
 }
+
+// newSortingStatusService stores a manifest whose packages tie on link count
+// and install time in pairs, so tie breaking is observable.
+func newSortingStatusService(t *testing.T) *StatusService {
+	t.Helper()
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	logger := adapters.NewNoopLogger()
+	targetDir := "/test/target"
+	require.NoError(t, fs.MkdirAll(ctx, targetDir, 0755))
+
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	m := manifest.New()
+	for _, pkg := range []struct {
+		name  string
+		links int
+		day   int
+	}{
+		{"zsh", 2, 3},
+		{"git", 1, 1},
+		{"nvim", 3, 2},
+		{"bash", 2, 3},
+		{"tmux", 1, 1},
+	} {
+		m.AddPackage(manifest.PackageInfo{
+			Name:        pkg.name,
+			InstalledAt: base.AddDate(0, 0, pkg.day),
+			LinkCount:   pkg.links,
+		})
+	}
+
+	store := manifest.NewFSManifestStore(fs)
+	require.NoError(t, store.Save(ctx, NewTargetPath(targetDir).Unwrap(), m))
+	return newStatusService(fs, logger, newManifestService(fs, logger, store), "", targetDir)
+}
+
+func packageNames(packages []PackageInfo) []string {
+	names := make([]string, len(packages))
+	for i, pkg := range packages {
+		names[i] = pkg.Name
+	}
+	return names
+}
+
+func TestStatusService_StatusWithOptions_Sort(t *testing.T) {
+	tests := []struct {
+		name string
+		opts StatusOptions
+		want []string
+	}{
+		{"name", StatusOptions{SortBy: StatusSortName}, []string{"bash", "git", "nvim", "tmux", "zsh"}},
+		{"name descending", StatusOptions{SortBy: StatusSortName, Descending: true}, []string{"zsh", "tmux", "nvim", "git", "bash"}},
+		{"links", StatusOptions{SortBy: StatusSortLinks}, []string{"git", "tmux", "bash", "zsh", "nvim"}},
+		{"links descending", StatusOptions{SortBy: StatusSortLinks, Descending: true}, []string{"nvim", "bash", "zsh", "git", "tmux"}},
+		{"date", StatusOptions{SortBy: StatusSortDate}, []string{"git", "tmux", "nvim", "bash", "zsh"}},
+		{"date descending", StatusOptions{SortBy: StatusSortDate, Descending: true}, []string{"bash", "zsh", "nvim", "git", "tmux"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newSortingStatusService(t)
+
+			// Ties must resolve the same way however the manifest is iterated
+			for range 5 {
+				status, err := svc.StatusWithOptions(context.Background(), tt.opts)
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, packageNames(status.Packages))
+			}
+		})
+	}
+}
+
+func TestStatusService_StatusWithOptions_Filter(t *testing.T) {
+	svc := newSortingStatusService(t)
+	ctx := context.Background()
+
+	status, err := svc.StatusWithOptions(ctx, StatusOptions{Filter: "*s*", SortBy: StatusSortName})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bash", "zsh"}, packageNames(status.Packages))
+
+	// Named packages are filtered too, and missing ones still reported
+	status, err = svc.StatusWithOptions(ctx, StatusOptions{Filter: "z*"}, "zsh", "git", "fish")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"zsh"}, packageNames(status.Packages))
+	assert.Equal(t, []string{"fish"}, status.NotFound)
+}
+
+func TestStatusService_StatusWithOptions_DefaultKeepsRequestOrder(t *testing.T) {
+	svc := newSortingStatusService(t)
+
+	status, err := svc.StatusWithOptions(context.Background(), StatusOptions{}, "zsh", "bash", "git")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"zsh", "bash", "git"}, packageNames(status.Packages))
+}
+
+func TestStatusService_StatusWithOptions_Invalid(t *testing.T) {
+	svc := newSortingStatusService(t)
+	ctx := context.Background()
+
+	_, err := svc.StatusWithOptions(ctx, StatusOptions{SortBy: "size"})
+	assert.ErrorContains(t, err, `invalid sort key "size"`)
+
+	_, err = svc.StatusWithOptions(ctx, StatusOptions{Filter: "[z"})
+	assert.ErrorContains(t, err, `invalid filter "[z"`)
+}
+
+func TestStatusService_ListStream_MatchesList(t *testing.T) {
+	svc := newSortingStatusService(t)
+	ctx := context.Background()
+
+	listed, err := svc.List(ctx)
+	require.NoError(t, err)
+
+	stream, err := svc.ListStream(ctx)
+	require.NoError(t, err)
+	var streamed []PackageInfo
+	for pkg := range stream {
+		streamed = append(streamed, pkg)
+	}
+	assert.Equal(t, listed, streamed)
+	assert.Equal(t, []string{"bash", "git", "nvim", "tmux", "zsh"}, packageNames(streamed))
+}
+
+func TestStatusService_ListStream_NoManifest(t *testing.T) {
+	fs := adapters.NewMemFS()
+	logger := adapters.NewNoopLogger()
+	manifestSvc := newManifestService(fs, logger, manifest.NewFSManifestStore(fs))
+	svc := newStatusService(fs, logger, manifestSvc, "", "/test/target")
+
+	stream, err := svc.ListStream(context.Background())
+	require.NoError(t, err)
+	_, open := <-stream
+	assert.False(t, open)
+}
+
+func TestStatusService_ListStream_CancelStopsGoroutine(t *testing.T) {
+	svc := newSortingStatusService(t)
+	baseline := runtime.NumGoroutine()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := svc.ListStream(ctx)
+	require.NoError(t, err)
+
+	first, open := <-stream
+	require.True(t, open)
+	assert.Equal(t, "bash", first.Name)
+
+	// Stop reading; the sender must notice cancellation while blocked
+	cancel()
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > baseline && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	require.LessOrEqual(t, runtime.NumGoroutine(), baseline, "stream goroutine leaked")
+
+	_, open = <-stream
+	assert.False(t, open, "channel closes after cancellation")
+}
+
+func TestStatusService_StatusDetailed(t *testing.T) {
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	require.NoError(t, fs.MkdirAll(ctx, "/dotfiles/vim", 0755))
+	require.NoError(t, fs.MkdirAll(ctx, "/home", 0755))
+	for _, name := range []string{"vimrc", "gvimrc", "exrc"} {
+		require.NoError(t, fs.WriteFile(ctx, "/dotfiles/vim/"+name, []byte("x"), 0644))
+	}
+	require.NoError(t, fs.Symlink(ctx, "../dotfiles/vim/vimrc", "/home/.vimrc"))
+	require.NoError(t, fs.Symlink(ctx, "/dotfiles/vim/missing", "/home/.gvimrc"))
+	require.NoError(t, fs.WriteFile(ctx, "/home/.exrc", []byte("local"), 0644))
+
+	store := manifest.NewFSManifestStore(fs)
+	m := manifest.New()
+	m.AddPackage(manifest.PackageInfo{
+		Name:       "vim",
+		PackageDir: "/dotfiles/vim",
+		Links:      []string{".exrc", ".gvimrc", ".vimrc"},
+		Sources:    map[string]string{".exrc": "/dotfiles/vim/exrc"},
+	})
+	target := NewTargetPath("/home").Unwrap()
+	require.NoError(t, store.Save(ctx, target, m))
+
+	manifestSvc := newManifestService(fs, adapters.NewNoopLogger(), store)
+	svc := newStatusService(fs, adapters.NewNoopLogger(), manifestSvc, "/dotfiles", "/home")
+
+	status, err := svc.StatusDetailed(ctx, "vim", "emacs")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"emacs"}, status.NotFound)
+	require.Len(t, status.Packages, 1)
+
+	pkg := status.Packages[0]
+	assert.False(t, pkg.IsHealthy)
+	assert.Equal(t, []LinkDetail{
+		{
+			Target: "/home/.exrc",
+			Source: "/dotfiles/vim/exrc",
+			State:  LinkStateBroken,
+			Issue:  "Expected symlink but found regular file",
+		},
+		{
+			Target: "/home/.gvimrc",
+			Source: "/dotfiles/vim/missing",
+			Mode:   "absolute",
+			State:  LinkStateBroken,
+			Issue:  "Link target does not exist: /dotfiles/vim/missing",
+		},
+		{
+			Target: "/home/.vimrc",
+			Source: "/dotfiles/vim/vimrc",
+			Mode:   "relative",
+			State:  LinkStateOK,
+		},
+	}, pkg.Links)
+}
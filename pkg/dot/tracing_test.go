@@ -0,0 +1,77 @@
+package dot_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaklabco/dot/internal/adapters"
+	"github.com/yaklabco/dot/internal/domain/tracetest"
+	"github.com/yaklabco/dot/pkg/dot"
+)
+
+func TestClient_Manage_TracesPhases(t *testing.T) {
+	fs := adapters.NewMemFS()
+	ctx := context.Background()
+	require.NoError(t, fs.MkdirAll(ctx, "/test/packages/bash", 0755))
+	require.NoError(t, fs.MkdirAll(ctx, "/test/target", 0755))
+	require.NoError(t, fs.WriteFile(ctx, "/test/packages/bash/dot-bashrc", []byte("# bashrc"), 0644))
+	require.NoError(t, fs.WriteFile(ctx, "/test/packages/bash/dot-profile", []byte("# profile"), 0644))
+
+	tracer := &tracetest.Tracer{}
+	client, err := dot.NewClient(dot.Config{
+		PackageDir: "/test/packages",
+		TargetDir:  "/test/target",
+		FS:         fs,
+		Logger:     adapters.NewNoopLogger(),
+		Tracer:     tracer,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, client.Manage(ctx, "bash"))
+
+	root := tracer.Find("manage")
+	require.NotNil(t, root)
+	assert.Nil(t, root.Parent)
+	assert.Equal(t, 1, root.Attrs["package_count"])
+
+	for _, name := range []string{"scan", "plan", "resolve", "execute"} {
+		span := tracer.Find(name)
+		require.NotNil(t, span, "missing %s span", name)
+		assert.Same(t, root, span.Parent, "%s span should be a child of manage", name)
+		assert.True(t, span.Ended, "%s span should be ended", name)
+	}
+
+	assert.Equal(t, 1, tracer.Find("scan").Attrs["package_count"])
+	assert.Equal(t, 2, tracer.Find("resolve").Attrs["operation_count"])
+	assert.Equal(t, 0, tracer.Find("resolve").Attrs["conflict_count"])
+	assert.Equal(t, 2, tracer.Find("execute").Attrs["operation_count"])
+	assert.Nil(t, tracer.Find("rollback"), "successful execution does not roll back")
+	assert.True(t, root.Ended)
+}
+
+func TestClient_Manage_TracesConflictError(t *testing.T) {
+	fs := adapters.NewMemFS()
+	ctx := context.Background()
+	require.NoError(t, fs.MkdirAll(ctx, "/test/packages/bash", 0755))
+	require.NoError(t, fs.MkdirAll(ctx, "/test/target", 0755))
+	require.NoError(t, fs.WriteFile(ctx, "/test/packages/bash/dot-bashrc", []byte("# bashrc"), 0644))
+	require.NoError(t, fs.WriteFile(ctx, "/test/target/.bashrc", []byte("existing"), 0644))
+
+	tracer := &tracetest.Tracer{}
+	client, err := dot.NewClient(dot.Config{
+		PackageDir: "/test/packages",
+		TargetDir:  "/test/target",
+		FS:         fs,
+		Logger:     adapters.NewNoopLogger(),
+		Tracer:     tracer,
+	})
+	require.NoError(t, err)
+
+	require.Error(t, client.Manage(ctx, "bash"))
+
+	assert.Equal(t, 1, tracer.Find("resolve").Attrs["conflict_count"])
+	assert.Nil(t, tracer.Find("execute"))
+	assert.NotEmpty(t, tracer.Find("manage").Errs)
+}
@@ -0,0 +1,121 @@
+package dot_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yaklabco/dot/internal/adapters"
+	"github.com/yaklabco/dot/pkg/dot"
+)
+
+// transactionFiles are the shell package's files, each linked at the
+// target root under the same name with a leading dot.
+var transactionFiles = []string{"aliases", "bashrc", "profile", "zshrc"}
+
+// failingRemoveFS fails to remove the file at path.
+type failingRemoveFS struct {
+	*adapters.MemFS
+	path string
+}
+
+func (f failingRemoveFS) Remove(ctx context.Context, name string) error {
+	if name == f.path {
+		return errors.New("injected remove failure")
+	}
+	return f.MemFS.Remove(ctx, name)
+}
+
+// newTransactionClient creates a client over fs that commits one
+// operation per transaction.
+func newTransactionClient(t *testing.T, fs dot.FS) *dot.Client {
+	t.Helper()
+	client, err := dot.NewClient(dot.Config{
+		PackageDir:      "/test/packages",
+		TargetDir:       "/test/target",
+		TransactionSize: 1,
+		FS:              fs,
+		Logger:          adapters.NewNoopLogger(),
+	})
+	require.NoError(t, err)
+	return client
+}
+
+// newShellPackage creates the shell package in a memory filesystem.
+func newShellPackage(t *testing.T) *adapters.MemFS {
+	t.Helper()
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	require.NoError(t, fs.MkdirAll(ctx, "/test/packages/shell", 0755))
+	require.NoError(t, fs.MkdirAll(ctx, "/test/target", 0755))
+	for _, name := range transactionFiles {
+		require.NoError(t, fs.WriteFile(ctx, "/test/packages/shell/dot-"+name, []byte(name), 0644))
+	}
+	return fs
+}
+
+// linkedOnDisk returns the shell package's links that exist on disk,
+// relative to the target directory.
+func linkedOnDisk(t *testing.T, fs *adapters.MemFS) []string {
+	t.Helper()
+	ctx := context.Background()
+	var linked []string
+	for _, name := range transactionFiles {
+		isLink, err := fs.IsSymlink(ctx, "/test/target/."+name)
+		if err == nil && isLink {
+			linked = append(linked, "."+name)
+		}
+	}
+	return linked
+}
+
+func TestClient_Manage_FailedTransactionRecordsCommitted(t *testing.T) {
+	ctx := context.Background()
+	mem := newShellPackage(t)
+	client := newTransactionClient(t, failingLinkFS{MemFS: mem, path: "/test/target/.zshrc"})
+
+	err := client.Manage(ctx, "shell")
+	var execFailed dot.ErrExecutionFailed
+	require.ErrorAs(t, err, &execFailed)
+	require.Positive(t, execFailed.CommittedTransactions)
+
+	linked := linkedOnDisk(t, mem)
+	require.NotEmpty(t, linked, "committed transactions stay on disk")
+	assert.NotContains(t, linked, ".zshrc")
+
+	info := recordedPackage(t, mem, "shell")
+	assert.ElementsMatch(t, linked, info.Links, "the manifest matches the links on disk")
+	assert.Equal(t, len(linked), info.LinkCount)
+	assert.Empty(t, info.StateHash, "the package is planned again by the next manage")
+
+	// Once the failure is gone, manage completes the package
+	require.NoError(t, newTransactionClient(t, mem).Manage(ctx, "shell"))
+	assert.Len(t, recordedPackage(t, mem, "shell").Links, len(transactionFiles))
+}
+
+func TestClient_Unmanage_FailedTransactionForgetsCommitted(t *testing.T) {
+	ctx := context.Background()
+	mem := newShellPackage(t)
+	require.NoError(t, newTransactionClient(t, mem).Manage(ctx, "shell"))
+
+	client := newTransactionClient(t, failingRemoveFS{MemFS: mem, path: "/test/target/.zshrc"})
+	err := client.Unmanage(ctx, "shell")
+	var execFailed dot.ErrExecutionFailed
+	require.ErrorAs(t, err, &execFailed)
+	require.Positive(t, execFailed.CommittedTransactions)
+
+	linked := linkedOnDisk(t, mem)
+	require.Less(t, len(linked), len(transactionFiles), "committed deletions stay on disk")
+	assert.Contains(t, linked, ".zshrc")
+
+	info := recordedPackage(t, mem, "shell")
+	assert.ElementsMatch(t, linked, info.Links, "the manifest matches the links on disk")
+	assert.Equal(t, len(linked), info.LinkCount)
+
+	// Once the failure is gone, unmanage removes the rest
+	require.NoError(t, newTransactionClient(t, mem).Unmanage(ctx, "shell"))
+	assert.Empty(t, linkedOnDisk(t, mem))
+}
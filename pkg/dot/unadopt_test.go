@@ -0,0 +1,196 @@
+package dot_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yaklabco/dot/internal/adapters"
+	"github.com/yaklabco/dot/pkg/dot"
+)
+
+// newUnadoptFixture creates dotfiles in the target directory and returns a
+// client managing them through MemFS.
+func newUnadoptFixture(t *testing.T) (*dot.Client, *adapters.MemFS) {
+	t.Helper()
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	require.NoError(t, fs.MkdirAll(ctx, "/test/packages", 0755))
+	require.NoError(t, fs.MkdirAll(ctx, "/test/target/.ssh/keys", 0755))
+	require.NoError(t, fs.MkdirAll(ctx, "/test/target/.config/nvim", 0755))
+	files := map[string]string{
+		"/test/target/.vimrc":                "vimrc",
+		"/test/target/.bashrc":               "bashrc",
+		"/test/target/.ssh/config":           "Host *",
+		"/test/target/.ssh/keys/id":          "key",
+		"/test/target/.config/nvim/init.lua": "init",
+	}
+	for path, content := range files {
+		require.NoError(t, fs.WriteFile(ctx, path, []byte(content), 0644))
+	}
+
+	client, err := dot.NewClient(dot.Config{
+		PackageDir: "/test/packages",
+		TargetDir:  "/test/target",
+		FS:         fs,
+		Logger:     adapters.NewNoopLogger(),
+	})
+	require.NoError(t, err)
+	return client, fs
+}
+
+func TestClient_Unadopt(t *testing.T) {
+	tests := []struct {
+		name      string
+		adopt     func(t *testing.T, client *dot.Client)
+		pkg       string
+		paths     []string
+		restored  map[string]string // target file -> content
+		removed   []string          // package paths that no longer exist
+		wantLinks []string          // links left in the package; nil if removed
+	}{
+		{
+			name: "file",
+			adopt: func(t *testing.T, client *dot.Client) {
+				require.NoError(t, client.Adopt(context.Background(), []string{".vimrc", ".bashrc"}, "shell"))
+			},
+			pkg:       "shell",
+			paths:     []string{".vimrc"},
+			restored:  map[string]string{"/test/target/.vimrc": "vimrc"},
+			removed:   []string{"/test/packages/shell/dot-vimrc"},
+			wantLinks: []string{".bashrc"},
+		},
+		{
+			name: "absolute path",
+			adopt: func(t *testing.T, client *dot.Client) {
+				require.NoError(t, client.Adopt(context.Background(), []string{".vimrc", ".bashrc"}, "shell"))
+			},
+			pkg:       "shell",
+			paths:     []string{"/test/target/.bashrc"},
+			restored:  map[string]string{"/test/target/.bashrc": "bashrc"},
+			removed:   []string{"/test/packages/shell/dot-bashrc"},
+			wantLinks: []string{".vimrc"},
+		},
+		{
+			name: "directory",
+			adopt: func(t *testing.T, client *dot.Client) {
+				require.NoError(t, client.Adopt(context.Background(), []string{".ssh"}, "dot-ssh"))
+			},
+			pkg:   "dot-ssh",
+			paths: []string{".ssh"},
+			restored: map[string]string{
+				"/test/target/.ssh/config":  "Host *",
+				"/test/target/.ssh/keys/id": "key",
+			},
+			removed: []string{"/test/packages/dot-ssh"},
+		},
+		{
+			name: "nested directory",
+			adopt: func(t *testing.T, client *dot.Client) {
+				opts := dot.AdoptOptions{PreservePath: true}
+				require.NoError(t, client.AdoptWithOptions(context.Background(), opts, []string{".config/nvim"}, "nvim"))
+				require.NoError(t, client.Adopt(context.Background(), []string{".vimrc"}, "nvim"))
+			},
+			pkg:       "nvim",
+			paths:     []string{".config/nvim"},
+			restored:  map[string]string{"/test/target/.config/nvim/init.lua": "init"},
+			removed:   []string{"/test/packages/nvim/dot-config/nvim"},
+			wantLinks: []string{".vimrc"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			client, fs := newUnadoptFixture(t)
+			tt.adopt(t, client)
+
+			require.NoError(t, client.Unadopt(ctx, tt.pkg, tt.paths...))
+
+			for path, content := range tt.restored {
+				data, err := fs.ReadFile(ctx, path)
+				require.NoError(t, err, path)
+				assert.Equal(t, content, string(data))
+			}
+			for _, path := range tt.paths {
+				if path[0] != '/' {
+					path = "/test/target/" + path
+				}
+				isLink, err := fs.IsSymlink(ctx, path)
+				require.NoError(t, err)
+				assert.False(t, isLink, "%s is a regular path again", path)
+			}
+			for _, path := range tt.removed {
+				assert.False(t, fs.Exists(ctx, path), "%s removed from package", path)
+			}
+
+			status, err := client.Status(ctx, tt.pkg)
+			require.NoError(t, err)
+			if tt.wantLinks == nil {
+				assert.Empty(t, status.Packages, "package without links leaves the manifest")
+				return
+			}
+			require.Len(t, status.Packages, 1)
+			assert.ElementsMatch(t, tt.wantLinks, status.Packages[0].Links)
+			assert.Equal(t, len(tt.wantLinks), status.Packages[0].LinkCount)
+		})
+	}
+}
+
+func TestClient_Unadopt_RejectsUnmanagedPaths(t *testing.T) {
+	tests := []struct {
+		name  string
+		paths []string
+	}{
+		{name: "not a link of the package", paths: []string{".ssh"}},
+		{name: "unknown path", paths: []string{".profile"}},
+		{name: "outside target", paths: []string{"/etc/hosts"}},
+		{name: "valid path with invalid one", paths: []string{".vimrc", ".profile"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			client, fs := newUnadoptFixture(t)
+			require.NoError(t, client.Adopt(ctx, []string{".vimrc", ".bashrc"}, "shell"))
+			require.NoError(t, client.Adopt(ctx, []string{".ssh"}, "dot-ssh"))
+
+			err := client.Unadopt(ctx, "shell", tt.paths...)
+			require.Error(t, err)
+			var notManaged dot.ErrNotManaged
+			require.True(t, errors.As(err, &notManaged), "got %T: %v", err, err)
+			assert.Equal(t, "shell", notManaged.Package)
+
+			// Nothing was changed
+			isLink, err := fs.IsSymlink(ctx, "/test/target/.vimrc")
+			require.NoError(t, err)
+			assert.True(t, isLink)
+			assert.True(t, fs.Exists(ctx, "/test/packages/shell/dot-vimrc"))
+		})
+	}
+}
+
+func TestClient_Unadopt_UnknownPackage(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newUnadoptFixture(t)
+	require.NoError(t, client.Adopt(ctx, []string{".vimrc"}, "shell"))
+
+	err := client.Unadopt(ctx, "missing", ".vimrc")
+	var notFound dot.ErrPackageNotFound
+	assert.True(t, errors.As(err, &notFound))
+}
+
+func TestClient_Unadopt_RejectsSharedPackageRoot(t *testing.T) {
+	ctx := context.Background()
+	client, fs := newUnadoptFixture(t)
+	// A directory adopted with other files is stored at the package root
+	require.NoError(t, client.Adopt(ctx, []string{".vimrc", ".ssh"}, "mixed"))
+
+	err := client.Unadopt(ctx, "mixed", ".ssh")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unmanage the package instead")
+	assert.True(t, fs.Exists(ctx, "/test/packages/mixed/dot-vimrc"))
+}
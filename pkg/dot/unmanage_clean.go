@@ -0,0 +1,148 @@
+package dot
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/yaklabco/dot/internal/domain"
+)
+
+// CleanResult reports the directories removed by CleanEmptyDirs.
+type CleanResult struct {
+	// Removed lists the removed directories as absolute paths, deepest
+	// first. In a dry run it lists the directories that would be removed.
+	Removed []string
+	// DryRun reports that nothing was removed.
+	DryRun bool
+}
+
+// CleanEmptyDirs removes directories dot created in the target directory
+// that no longer hold anything, deepest first. A directory is removed only
+// if the manifest records dot as having created it and it is empty, or
+// holds only directories that are themselves removed. Directories dot did
+// not create, symbolic links and directories holding any file are kept.
+//
+// The directories are removed in one transaction. In dry-run mode nothing
+// is removed and the result lists what would be.
+func (s *UnmanageService) CleanEmptyDirs(ctx context.Context) (CleanResult, error) {
+	result := CleanResult{DryRun: s.dryRun}
+
+	targetPathResult := NewTargetPath(s.targetDir)
+	if !targetPathResult.IsOk() {
+		return result, targetPathResult.UnwrapErr()
+	}
+	targetPath := targetPathResult.Unwrap()
+
+	manifestResult := s.manifestSvc.Load(ctx, targetPath)
+	if !manifestResult.IsOk() {
+		err := manifestResult.UnwrapErr()
+		if isManifestNotFoundError(err) {
+			return result, nil
+		}
+		return result, err
+	}
+	m := manifestResult.Unwrap()
+
+	removable := s.removableDirs(ctx, m.CreatedDirs)
+	for _, dir := range removable {
+		result.Removed = append(result.Removed, filepath.Join(s.targetDir, dir))
+	}
+	s.logger.Info(ctx, "clean_empty_dirs", "recorded", len(m.CreatedDirs), "removable", len(removable))
+
+	if s.dryRun {
+		s.logger.Info(ctx, "dry_run_plan", "operations", len(removable))
+		return result, nil
+	}
+
+	if len(removable) > 0 {
+		plan, err := s.planCleanEmptyDirs(ctx, removable)
+		if err != nil {
+			return CleanResult{DryRun: s.dryRun}, err
+		}
+		execResult := s.executor.Execute(ctx, plan)
+		if !execResult.IsOk() {
+			return CleanResult{DryRun: s.dryRun}, execResult.UnwrapErr()
+		}
+		if executed := execResult.Unwrap(); !executed.Success() {
+			return CleanResult{DryRun: s.dryRun}, ErrMultiple{Errors: executed.Errors}
+		}
+	}
+
+	// Forget the removed directories and any already gone
+	recorded := len(m.CreatedDirs)
+	s.manifestSvc.forgetRemovedDirs(ctx, &m, s.targetDir)
+	if len(m.CreatedDirs) == recorded {
+		return result, nil
+	}
+	if err := s.manifestSvc.Save(ctx, targetPath, m); err != nil {
+		return result, fmt.Errorf("manifest update failed: %w", err)
+	}
+	return result, nil
+}
+
+// removableDirs returns the directories in recorded, relative to the target
+// directory, that are empty once the removable directories beneath them
+// are gone, deepest first.
+func (s *UnmanageService) removableDirs(ctx context.Context, recorded []string) []string {
+	sorted := slices.Clone(recorded)
+	slices.SortFunc(sorted, func(a, b string) int {
+		if depth := strings.Count(b, string(filepath.Separator)) - strings.Count(a, string(filepath.Separator)); depth != 0 {
+			return depth
+		}
+		return strings.Compare(a, b)
+	})
+
+	removable := make(map[string]bool, len(sorted))
+	var order []string
+	for _, rel := range sorted {
+		rel = filepath.Clean(rel)
+		if rel == "." || filepath.IsAbs(rel) || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		dir := filepath.Join(s.targetDir, rel)
+		// Lstat so a link that replaced the directory is never followed
+		info, err := s.fs.Lstat(ctx, dir)
+		if err != nil || !info.IsDir() {
+			continue
+		}
+		entries, err := s.fs.ReadDir(ctx, dir)
+		if err != nil {
+			continue
+		}
+		empty := true
+		for _, entry := range entries {
+			if !removable[filepath.Join(rel, entry.Name())] {
+				empty = false
+				break
+			}
+		}
+		if empty {
+			removable[rel] = true
+			order = append(order, rel)
+		}
+	}
+	return order
+}
+
+// planCleanEmptyDirs builds a plan deleting dirs, relative to the target
+// directory, in the order given.
+func (s *UnmanageService) planCleanEmptyDirs(ctx context.Context, dirs []string) (Plan, error) {
+	operations := make([]Operation, 0, len(dirs))
+	for _, dir := range dirs {
+		pathResult := NewFilePath(filepath.Join(s.targetDir, dir))
+		if pathResult.IsErr() {
+			return Plan{}, pathResult.UnwrapErr()
+		}
+		operations = append(operations, NewDirDelete(OperationID("clean-dir-"+dir), pathResult.Unwrap()))
+	}
+
+	return domain.AnnotateRisk(ctx, s.fs, Plan{
+		Operations: operations,
+		Metadata: PlanMetadata{
+			OperationCount: len(operations),
+		},
+	}), nil
+}
@@ -0,0 +1,141 @@
+package dot_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yaklabco/dot/internal/adapters"
+	"github.com/yaklabco/dot/internal/manifest"
+	"github.com/yaklabco/dot/pkg/dot"
+)
+
+// newCleanClient creates a client over fs with packages in /test/packages
+// and the target /test/target.
+func newCleanClient(t *testing.T, fs *adapters.MemFS, dryRun bool) *dot.Client {
+	t.Helper()
+	client, err := dot.NewClient(dot.Config{
+		PackageDir: "/test/packages",
+		TargetDir:  "/test/target",
+		DryRun:     dryRun,
+		FS:         fs,
+		Logger:     adapters.NewNoopLogger(),
+	})
+	require.NoError(t, err)
+	return client
+}
+
+// managedTools manages a tools package linking ~/.config/tool/sub/conf and
+// then removes the link, as if deleted by hand, leaving the directories
+// dot created.
+func managedTools(t *testing.T) *adapters.MemFS {
+	t.Helper()
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	require.NoError(t, fs.MkdirAll(ctx, "/test/packages/tools/dot-config/tool/sub", 0755))
+	require.NoError(t, fs.MkdirAll(ctx, "/test/target", 0755))
+	require.NoError(t, fs.WriteFile(ctx, "/test/packages/tools/dot-config/tool/sub/conf", []byte("conf"), 0644))
+
+	require.NoError(t, newCleanClient(t, fs, false).Manage(ctx, "tools"))
+	assert.Equal(t, []string{".config", ".config/tool", ".config/tool/sub"}, createdDirs(t, fs))
+
+	require.NoError(t, fs.Remove(ctx, "/test/target/.config/tool/sub/conf"))
+	return fs
+}
+
+// createdDirs returns the created directories recorded in the manifest.
+func createdDirs(t *testing.T, fs *adapters.MemFS) []string {
+	t.Helper()
+	data, err := fs.ReadFile(context.Background(), "/test/target/.dot-manifest.json")
+	require.NoError(t, err)
+	var m manifest.Manifest
+	require.NoError(t, json.Unmarshal(data, &m))
+	return m.CreatedDirs
+}
+
+func TestClient_CleanEmptyDirs_NestedEmpties(t *testing.T) {
+	ctx := context.Background()
+	fs := managedTools(t)
+
+	result, err := newCleanClient(t, fs, false).CleanEmptyDirs(ctx)
+	require.NoError(t, err)
+
+	assert.False(t, result.DryRun)
+	assert.Equal(t, []string{
+		"/test/target/.config/tool/sub",
+		"/test/target/.config/tool",
+		"/test/target/.config",
+	}, result.Removed, "deepest first")
+	assert.False(t, fs.Exists(ctx, "/test/target/.config"))
+	assert.True(t, fs.Exists(ctx, "/test/target"))
+	assert.Empty(t, createdDirs(t, fs))
+}
+
+func TestClient_CleanEmptyDirs_KeepsNonEmpty(t *testing.T) {
+	ctx := context.Background()
+	fs := managedTools(t)
+	require.NoError(t, fs.WriteFile(ctx, "/test/target/.config/tool/notes", []byte("mine"), 0644))
+
+	result, err := newCleanClient(t, fs, false).CleanEmptyDirs(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"/test/target/.config/tool/sub"}, result.Removed)
+	assert.True(t, fs.Exists(ctx, "/test/target/.config/tool/notes"))
+	assert.Equal(t, []string{".config", ".config/tool"}, createdDirs(t, fs), "kept directories stay recorded")
+}
+
+func TestClient_CleanEmptyDirs_KeepsDirsNotCreated(t *testing.T) {
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	require.NoError(t, fs.MkdirAll(ctx, "/test/packages/tools/dot-config/tool/sub", 0755))
+	require.NoError(t, fs.WriteFile(ctx, "/test/packages/tools/dot-config/tool/sub/conf", []byte("conf"), 0644))
+	// The user's own directory, empty once the link is gone
+	require.NoError(t, fs.MkdirAll(ctx, "/test/target/.config", 0755))
+
+	client := newCleanClient(t, fs, false)
+	require.NoError(t, client.Manage(ctx, "tools"))
+	require.NoError(t, fs.Remove(ctx, "/test/target/.config/tool/sub/conf"))
+
+	result, err := client.CleanEmptyDirs(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"/test/target/.config/tool/sub", "/test/target/.config/tool"}, result.Removed)
+	assert.True(t, fs.Exists(ctx, "/test/target/.config"))
+}
+
+func TestClient_CleanEmptyDirs_DryRun(t *testing.T) {
+	ctx := context.Background()
+	fs := managedTools(t)
+
+	result, err := newCleanClient(t, fs, true).CleanEmptyDirs(ctx)
+	require.NoError(t, err)
+
+	assert.True(t, result.DryRun)
+	assert.Len(t, result.Removed, 3)
+	assert.True(t, fs.Exists(ctx, "/test/target/.config/tool/sub"), "nothing is removed")
+	assert.Len(t, createdDirs(t, fs), 3)
+}
+
+func TestClient_Unmanage_ForgetsRemovedDirs(t *testing.T) {
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	require.NoError(t, fs.MkdirAll(ctx, "/test/packages/tools/dot-config/tool/sub", 0755))
+	require.NoError(t, fs.MkdirAll(ctx, "/test/target", 0755))
+	require.NoError(t, fs.WriteFile(ctx, "/test/packages/tools/dot-config/tool/sub/conf", []byte("conf"), 0644))
+
+	client := newCleanClient(t, fs, false)
+	require.NoError(t, client.Manage(ctx, "tools"))
+	require.NoError(t, client.Unmanage(ctx, "tools"))
+
+	assert.Empty(t, createdDirs(t, fs), "directories unmanage removed are no longer recorded")
+
+	// A directory the user creates at the same path later is not dot's
+	require.NoError(t, fs.MkdirAll(ctx, "/test/target/.config", 0755))
+	result, err := client.CleanEmptyDirs(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, result.Removed)
+	assert.True(t, fs.Exists(ctx, "/test/target/.config"))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 
@@ -41,6 +42,8 @@ type UnmanageService struct {
 	packageDir  string
 	targetDir   string
 	dryRun      bool
+	// hooks runs the configured pre and post unmanage hooks, if any.
+	hooks *hookRunner
 }
 
 // newUnmanageService creates a new UnmanageService instance.
@@ -71,10 +74,25 @@ func (s *UnmanageService) Unmanage(ctx context.Context, packages ...string) erro
 }
 
 // UnmanageWithOptions removes packages with specified options.
+// Pre-unmanage hooks run first and abort the operation if one fails;
+// post-unmanage hooks run once the packages are unmanaged.
 func (s *UnmanageService) UnmanageWithOptions(ctx context.Context, opts UnmanageOptions, packages ...string) error {
 	if len(packages) == 0 {
 		return fmt.Errorf("no packages specified")
 	}
+
+	if err := s.hooks.runPre(ctx, HookPreUnmanage, packages); err != nil {
+		return err
+	}
+	if err := s.unmanageWithOptions(ctx, opts, packages); err != nil {
+		return err
+	}
+	s.hooks.runPost(ctx, HookPostUnmanage, packages)
+	return nil
+}
+
+// unmanageWithOptions plans and executes the unmanage of packages.
+func (s *UnmanageService) unmanageWithOptions(ctx context.Context, opts UnmanageOptions, packages []string) error {
 	s.logger.Info(ctx, "unmanaging_packages", "count", len(packages), "packages", packages)
 
 	targetPathResult := NewTargetPath(s.targetDir)
@@ -122,8 +140,10 @@ func (s *UnmanageService) UnmanageWithOptions(ctx context.Context, opts Unmanage
 		s.logger.Debug(ctx, "executing_plan", "operation_count", len(plan.Operations))
 		result := s.executor.Execute(ctx, plan)
 		if !result.IsOk() {
-			s.logger.Error(ctx, "execution_error", "error", result.UnwrapErr())
-			return result.UnwrapErr()
+			err := result.UnwrapErr()
+			s.logger.Error(ctx, "execution_error", "error", err)
+			s.recordCommitted(ctx, targetPath, plan, err)
+			return err
 		}
 		execResult := result.Unwrap()
 		if !execResult.Success() {
@@ -156,6 +176,58 @@ func (s *UnmanageService) UnmanageWithOptions(ctx context.Context, opts Unmanage
 	return nil
 }
 
+// recordCommitted updates the manifest for the transactions that committed
+// before a split plan failed with err, so it matches what stays on disk.
+// A package whose operations all committed is removed. Otherwise the links
+// it had deleted are forgotten, unless it still has restore or purge
+// operations pending: those are planned from its recorded links, and
+// deleting a link again is harmless, so its entry is kept for a rerun.
+func (s *UnmanageService) recordCommitted(ctx context.Context, targetPath TargetPath, plan Plan, err error) {
+	committed, ok := committedPlan(plan, err)
+	if !ok {
+		return
+	}
+
+	var removed []string
+	forgotten := make(map[string][]string)
+	for _, pkg := range plan.PackageNames() {
+		ops := plan.OperationsForPackage(pkg)
+		done := committed.OperationsForPackage(pkg)
+		switch {
+		case len(done) == 0:
+		case len(done) == len(ops):
+			removed = append(removed, pkg)
+		case !hasPendingNonDelete(ops, done):
+			forgotten[pkg] = s.manifestSvc.extractDeletedLinksFromOperations(done, targetPath.String())
+		}
+	}
+
+	if len(forgotten) > 0 {
+		if err := s.manifestSvc.ForgetLinks(ctx, targetPath, forgotten); err != nil {
+			s.logger.Warn(ctx, "failed_to_record_committed_transactions", "error", err)
+		}
+	}
+	if len(removed) > 0 {
+		if err := s.manifestSvc.RemovePackages(ctx, targetPath, removed); err != nil {
+			s.logger.Warn(ctx, "failed_to_record_committed_transactions", "packages", removed, "error", err)
+		}
+	}
+}
+
+// hasPendingNonDelete reports whether ops has an operation other than a
+// link deletion that is not among done.
+func hasPendingNonDelete(ops, done []Operation) bool {
+	for _, op := range ops {
+		if _, isDelete := op.(LinkDelete); isDelete {
+			continue
+		}
+		if !slices.ContainsFunc(done, func(d Operation) bool { return d.ID() == op.ID() }) {
+			return true
+		}
+	}
+	return false
+}
+
 // UnmanageAll removes all installed packages with specified options.
 // Returns the count of packages unmanaged.
 func (s *UnmanageService) UnmanageAll(ctx context.Context, opts UnmanageOptions) (int, error) {
@@ -216,6 +288,12 @@ func (s *UnmanageService) filterOrphanedPackages(ctx context.Context, m manifest
 
 // PlanUnmanage computes the execution plan for unmanaging packages.
 func (s *UnmanageService) PlanUnmanage(ctx context.Context, packages ...string) (Plan, error) {
+	return s.PlanUnmanageWithOptions(ctx, DefaultUnmanageOptions(), packages...)
+}
+
+// PlanUnmanageWithOptions computes the execution plan for unmanaging
+// packages with the given restore, purge, and cleanup options.
+func (s *UnmanageService) PlanUnmanageWithOptions(ctx context.Context, opts UnmanageOptions, packages ...string) (Plan, error) {
 	if len(packages) == 0 {
 		return Plan{}, fmt.Errorf("no packages specified")
 	}
@@ -241,7 +319,7 @@ func (s *UnmanageService) PlanUnmanage(ctx context.Context, packages ...string)
 	}
 
 	m := manifestResult.Unwrap()
-	return s.planUnmanageWithOptions(ctx, m, packages, DefaultUnmanageOptions())
+	return s.planUnmanageWithOptions(ctx, m, packages, opts)
 }
 
 // planUnmanageWithOptions creates an unmanage plan with restoration/purge/cleanup logic.
@@ -250,7 +328,9 @@ func (s *UnmanageService) planUnmanageWithOptions(ctx context.Context, m manifes
 
 	// Build operations for each package
 	var operations []Operation
+	packageOps := make(map[string][]OperationID)
 	for _, pkg := range packages {
+		first := len(operations)
 		pkgInfo, exists := m.GetPackage(pkg)
 		if !exists {
 			return Plan{}, domain.ErrPackageNotFound{Package: pkg}
@@ -305,17 +385,22 @@ func (s *UnmanageService) planUnmanageWithOptions(ctx context.Context, m manifes
 			id := OperationID(fmt.Sprintf("unmanage-purge-%s", pkg))
 			operations = append(operations, NewDirRemoveAll(id, pkgPathResult.Unwrap()))
 		}
+
+		for _, op := range operations[first:] {
+			packageOps[pkg] = append(packageOps[pkg], op.ID())
+		}
 	}
 
 	s.logger.Debug(ctx, "plan_unmanage_completed", "operations", len(operations))
 
-	return Plan{
+	return domain.AnnotateRisk(ctx, s.fs, Plan{
 		Operations: operations,
 		Metadata: PlanMetadata{
 			PackageCount:   len(packages),
 			OperationCount: len(operations),
 		},
-	}, nil
+		PackageOperations: packageOps,
+	}), nil
 }
 
 // cleanEmptyParentDirs removes empty directories left behind after symlink deletion.
@@ -463,6 +548,13 @@ func (s *UnmanageService) createRestoreOperations(ctx context.Context, pkg strin
 // createDirectoryRestoreOperations handles restoration of directory packages.
 // Detects and handles corrupted nested structures.
 func (s *UnmanageService) createDirectoryRestoreOperations(ctx context.Context, pkg, link, pkgRootPath, targetFilePath string) []Operation {
+	// Nested adoption - the package keeps the link's path, e.g. dot-config/nvim
+	if filepath.Dir(link) != "." {
+		if ops, ok := s.createNestedRestoreOperations(ctx, pkg, link, pkgRootPath, targetFilePath); ok {
+			return ops
+		}
+	}
+
 	// Improved detection: check what actually exists in package
 	linkBase := filepath.Base(link)
 	translatedName := scanner.UntranslateDotfile(linkBase)
@@ -513,6 +605,31 @@ func (s *UnmanageService) createDirectoryRestoreOperations(ctx context.Context,
 	return []Operation{NewDirCopy(id, sourceResult.Unwrap(), destResult.Unwrap())}
 }
 
+// createNestedRestoreOperations restores a link whose file or directory is
+// stored in the package at the link's own translated path. It reports false
+// when the package has nothing at that path.
+func (s *UnmanageService) createNestedRestoreOperations(ctx context.Context, pkg, link, pkgRootPath, targetFilePath string) ([]Operation, bool) {
+	nestedPath := filepath.Join(pkgRootPath, translatePathComponents(link))
+	info, err := s.fs.Stat(ctx, nestedPath)
+	if err != nil {
+		return nil, false
+	}
+
+	sourceResult := NewFilePath(nestedPath)
+	destResult := NewFilePath(targetFilePath)
+	if !sourceResult.IsOk() || !destResult.IsOk() {
+		return nil, true
+	}
+
+	s.logger.Info(ctx, "restoring_nested_adoption", "package", pkg, "link", link)
+	if info.IsDir() {
+		id := OperationID(fmt.Sprintf("restore-dir-%s-%s", pkg, link))
+		return []Operation{NewDirCopy(id, sourceResult.Unwrap(), destResult.Unwrap())}, true
+	}
+	id := OperationID(fmt.Sprintf("restore-file-%s", link))
+	return []Operation{NewFileBackup(id, sourceResult.Unwrap(), destResult.Unwrap())}, true
+}
+
 // createCorruptedStructureRepair attempts to repair a nested directory structure.
 // This handles the case where a package has a corrupted nested structure like dot-ssh/dot-ssh/files.
 func (s *UnmanageService) createCorruptedStructureRepair(ctx context.Context, pkg, nestedPath, targetPath string) []Operation {
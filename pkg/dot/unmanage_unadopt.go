@@ -0,0 +1,184 @@
+package dot
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/yaklabco/dot/internal/domain"
+	"github.com/yaklabco/dot/internal/manifest"
+)
+
+// Unadopt returns individual paths from a package to the target directory
+// as regular files or directories and stops managing them. Each path must
+// be a link the package manages, given relative to the target directory or
+// as an absolute path inside it. The package keeps its other links.
+//
+// The links are replaced in one transaction: if moving any path fails, the
+// paths already moved are returned to the package and their links restored.
+func (s *UnmanageService) Unadopt(ctx context.Context, pkg string, paths ...string) error {
+	if len(paths) == 0 {
+		return fmt.Errorf("no paths specified")
+	}
+	s.logger.Info(ctx, "unadopting_paths", "package", pkg, "count", len(paths))
+
+	targetPathResult := NewTargetPath(s.targetDir)
+	if !targetPathResult.IsOk() {
+		return targetPathResult.UnwrapErr()
+	}
+	targetPath := targetPathResult.Unwrap()
+
+	manifestResult := s.manifestSvc.Load(ctx, targetPath)
+	if !manifestResult.IsOk() {
+		err := manifestResult.UnwrapErr()
+		if isManifestNotFoundError(err) {
+			return domain.ErrPackageNotFound{Package: pkg}
+		}
+		return err
+	}
+	m := manifestResult.Unwrap()
+	pkgInfo, exists := m.GetPackage(pkg)
+	if !exists {
+		return domain.ErrPackageNotFound{Package: pkg}
+	}
+
+	plan, links, err := s.planUnadopt(ctx, pkg, pkgInfo, paths)
+	if err != nil {
+		return err
+	}
+
+	if s.dryRun {
+		s.logger.Info(ctx, "dry_run_plan", "operations", len(plan.Operations))
+		return nil
+	}
+
+	result := s.executor.Execute(ctx, plan)
+	if !result.IsOk() {
+		return result.UnwrapErr()
+	}
+	if execResult := result.Unwrap(); !execResult.Success() {
+		return ErrMultiple{Errors: execResult.Errors}
+	}
+
+	removeManifestLinks(&m, pkgInfo, s.targetDir, links)
+	if err := s.manifestSvc.Save(ctx, targetPath, m); err != nil {
+		return fmt.Errorf("manifest update failed: %w", err)
+	}
+	s.logger.Info(ctx, "unadopt_complete", "package", pkg, "links", links)
+	return nil
+}
+
+// planUnadopt builds the operations that replace each link with the file
+// or directory it points to in the package. It returns the plan and the
+// manifest links it covers.
+func (s *UnmanageService) planUnadopt(ctx context.Context, pkg string, pkgInfo manifest.PackageInfo, paths []string) (Plan, []string, error) {
+	pkgRoot := filepath.Join(s.packageDir, pkg)
+	operations := make([]Operation, 0, 2*len(paths))
+	links := make([]string, 0, len(paths))
+
+	for _, path := range paths {
+		link, ok := s.relativeToTarget(path)
+		if !ok || !slices.Contains(pkgInfo.Links, link) {
+			return Plan{}, nil, ErrNotManaged{Package: pkg, Path: path}
+		}
+		if slices.Contains(links, link) {
+			continue
+		}
+
+		linkPath := filepath.Join(s.targetDir, link)
+		dest, err := s.fs.ReadLink(ctx, linkPath)
+		if err != nil {
+			return Plan{}, nil, fmt.Errorf("read link %s: %w", link, err)
+		}
+		source := domain.ResolveLinkTarget(linkPath, dest)
+		if !strings.HasPrefix(source, pkgRoot+string(filepath.Separator)) && source != pkgRoot {
+			return Plan{}, nil, ErrNotManaged{Package: pkg, Path: path}
+		}
+		// An adopted directory may be the package root itself, which
+		// can only be moved out once nothing else lives in it
+		if source == pkgRoot && len(pkgInfo.Links) > 1 {
+			return Plan{}, nil, fmt.Errorf("%s links to the root of package %s, which holds other links; unmanage the package instead", link, pkg)
+		}
+
+		targetResult := NewTargetPath(linkPath)
+		sourceResult := NewTargetPath(source)
+		destResult := NewFilePath(linkPath)
+		if targetResult.IsErr() || sourceResult.IsErr() || destResult.IsErr() {
+			return Plan{}, nil, fmt.Errorf("invalid path for %s", link)
+		}
+
+		// Restoring the original link lets a failed move roll back cleanly
+		unlink := NewLinkDelete(OperationID("unadopt-unlink-"+link), targetResult.Unwrap())
+		unlink.Original = dest
+		move := NewFileMove(OperationID("unadopt-move-"+link), sourceResult.Unwrap(), destResult.Unwrap())
+		operations = append(operations, unlink, move)
+		links = append(links, link)
+	}
+
+	return domain.AnnotateRisk(ctx, s.fs, Plan{
+		Operations: operations,
+		Metadata: PlanMetadata{
+			PackageCount:   1,
+			OperationCount: len(operations),
+		},
+	}), links, nil
+}
+
+// relativeToTarget converts path to a path relative to the target
+// directory, as links are recorded in the manifest. It reports false
+// if path lies outside the target directory.
+func (s *UnmanageService) relativeToTarget(path string) (string, bool) {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(s.targetDir, path)
+	}
+	rel, err := filepath.Rel(s.targetDir, path)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return rel, true
+}
+
+// removeManifestLinks drops links, with their junction and backup records,
+// from the package's manifest entry, removing the package once it has none
+// left.
+func removeManifestLinks(m *manifest.Manifest, pkgInfo manifest.PackageInfo, targetDir string, links []string) {
+	remaining := make([]string, 0, len(pkgInfo.Links))
+	for _, link := range pkgInfo.Links {
+		if !slices.Contains(links, link) {
+			remaining = append(remaining, link)
+		}
+	}
+	if len(remaining) == 0 {
+		m.RemovePackage(pkgInfo.Name)
+		return
+	}
+
+	pkgInfo.Links = remaining
+	pkgInfo.LinkCount = len(remaining)
+	pkgInfo.Junctions = slices.DeleteFunc(slices.Clone(pkgInfo.Junctions), func(j string) bool {
+		return slices.Contains(links, j)
+	})
+	if pkgInfo.Backups != nil {
+		backups := make(map[string]string, len(pkgInfo.Backups))
+		for path, backup := range pkgInfo.Backups {
+			// Backups are keyed by absolute target path
+			rel, err := filepath.Rel(targetDir, path)
+			if err != nil || !slices.Contains(links, rel) {
+				backups[path] = backup
+			}
+		}
+		pkgInfo.Backups = backups
+	}
+	if pkgInfo.Sources != nil {
+		sources := make(map[string]string, len(pkgInfo.Sources))
+		for link, source := range pkgInfo.Sources {
+			if !slices.Contains(links, link) {
+				sources[link] = source
+			}
+		}
+		pkgInfo.Sources = sources
+	}
+	m.AddPackage(pkgInfo)
+}
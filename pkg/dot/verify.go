@@ -0,0 +1,136 @@
+package dot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+
+	"github.com/yaklabco/dot/internal/domain"
+	"github.com/yaklabco/dot/internal/manifest"
+)
+
+// DiscrepancyKind classifies a managed link that does not match the
+// manifest.
+type DiscrepancyKind string
+
+const (
+	// DiscrepancyMissing means nothing exists at the link path.
+	DiscrepancyMissing DiscrepancyKind = "missing"
+	// DiscrepancyWrongTarget means the link points somewhere other than
+	// its recorded source.
+	DiscrepancyWrongTarget DiscrepancyKind = "wrong_target"
+	// DiscrepancyNotSymlink means a regular file or directory replaced the
+	// link.
+	DiscrepancyNotSymlink DiscrepancyKind = "not_symlink"
+)
+
+// LinkDiscrepancy describes one managed link that does not match the
+// manifest.
+type LinkDiscrepancy struct {
+	// Link is the link path relative to the target directory.
+	Link string          `json:"link"`
+	Kind DiscrepancyKind `json:"kind"`
+	// Expected is the source the link should resolve to.
+	Expected string `json:"expected,omitempty"`
+	// Actual is the link's resolved target, for wrong_target.
+	Actual string `json:"actual,omitempty"`
+}
+
+// PackageVerification is the verification result of one package.
+type PackageVerification struct {
+	Package       string            `json:"package"`
+	Links         int               `json:"links"`
+	Discrepancies []LinkDiscrepancy `json:"discrepancies,omitempty"`
+}
+
+// VerifyReport is the result of Client.Verify, with packages sorted by
+// name.
+type VerifyReport struct {
+	Packages []PackageVerification `json:"packages"`
+}
+
+// OK reports whether every verified link matches the manifest.
+func (r VerifyReport) OK() bool {
+	return r.DiscrepancyCount() == 0
+}
+
+// DiscrepancyCount returns the number of mismatched links.
+func (r VerifyReport) DiscrepancyCount() int {
+	n := 0
+	for _, pkg := range r.Packages {
+		n += len(pkg.Discrepancies)
+	}
+	return n
+}
+
+// Verify checks that every link the manifest records for packages, or for
+// all managed packages when none are given, is a symlink resolving to
+// exactly the source recorded when it was linked. Unlike Doctor it looks
+// only at the manifest's links and applies no heuristics, so the same tree
+// always yields the same report. Links recorded before sources were
+// tracked only need to resolve into their package directory.
+//
+// Mismatches are reported, not returned as errors; a package that is not
+// managed is an ErrPackageNotFound.
+func (c *Client) Verify(ctx context.Context, packages ...string) (VerifyReport, error) {
+	selected, notFound, err := c.statusSvc.enumerate(ctx, StatusOptions{}, packages)
+	if err != nil {
+		return VerifyReport{}, err
+	}
+	if len(notFound) > 0 {
+		return VerifyReport{}, ErrPackageNotFound{Package: notFound[0]}
+	}
+
+	report := VerifyReport{Packages: make([]PackageVerification, 0, len(selected))}
+	for _, info := range selected {
+		result := PackageVerification{Package: info.Name, Links: len(info.Links)}
+		for _, link := range info.Links {
+			d, err := verifyLink(ctx, c.config.FS, c.config.TargetDir, info, link)
+			if err != nil {
+				return VerifyReport{}, fmt.Errorf("verify %s: %w", link, err)
+			}
+			if d != nil {
+				result.Discrepancies = append(result.Discrepancies, *d)
+			}
+		}
+		report.Packages = append(report.Packages, result)
+	}
+	return report, nil
+}
+
+// verifyLink compares link with its manifest entry, returning nil when it
+// matches.
+func verifyLink(ctx context.Context, fsys FS, targetDir string, info manifest.PackageInfo, link string) (*LinkDiscrepancy, error) {
+	linkPath := filepath.Join(targetDir, link)
+	expected := info.Sources[link]
+
+	fi, err := fsys.Lstat(ctx, linkPath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return &LinkDiscrepancy{Link: link, Kind: DiscrepancyMissing, Expected: expected}, nil
+		}
+		return nil, err
+	}
+	if fi.Mode()&fs.ModeSymlink == 0 {
+		return &LinkDiscrepancy{Link: link, Kind: DiscrepancyNotSymlink, Expected: expected}, nil
+	}
+
+	target, err := fsys.ReadLink(ctx, linkPath)
+	if err != nil {
+		return nil, err
+	}
+	target = domain.ResolveLinkTarget(linkPath, target)
+
+	if expected != "" {
+		if target != filepath.Clean(expected) {
+			return &LinkDiscrepancy{Link: link, Kind: DiscrepancyWrongTarget, Expected: expected, Actual: target}, nil
+		}
+		return nil, nil
+	}
+	if info.PackageDir != "" && !isInPackageDir(target, info.PackageDir) {
+		return &LinkDiscrepancy{Link: link, Kind: DiscrepancyWrongTarget, Expected: info.PackageDir, Actual: target}, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,168 @@
+package dot_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaklabco/dot/internal/adapters"
+	"github.com/yaklabco/dot/internal/manifest"
+	"github.com/yaklabco/dot/pkg/dot"
+)
+
+// setupVerifyClient manages packages vim (.vimrc, .gvimrc) and zsh (.zshrc)
+// into /home on a MemFS.
+func setupVerifyClient(t *testing.T) (*dot.Client, *adapters.MemFS) {
+	t.Helper()
+	ctx := context.Background()
+	fs := adapters.NewMemFS()
+	require.NoError(t, fs.MkdirAll(ctx, "/dotfiles/vim", 0o755))
+	require.NoError(t, fs.WriteFile(ctx, "/dotfiles/vim/dot-vimrc", []byte("set nu"), 0o644))
+	require.NoError(t, fs.WriteFile(ctx, "/dotfiles/vim/dot-gvimrc", []byte("set go"), 0o644))
+	require.NoError(t, fs.MkdirAll(ctx, "/dotfiles/zsh", 0o755))
+	require.NoError(t, fs.WriteFile(ctx, "/dotfiles/zsh/dot-zshrc", []byte("export A=1"), 0o644))
+	require.NoError(t, fs.MkdirAll(ctx, "/home", 0o755))
+
+	client, err := dot.NewClient(dot.Config{
+		PackageDir: "/dotfiles",
+		TargetDir:  "/home",
+		FS:         fs,
+		Logger:     adapters.NewNoopLogger(),
+	})
+	require.NoError(t, err)
+	require.NoError(t, client.Manage(ctx, "vim", "zsh"))
+	return client, fs
+}
+
+func TestClient_Verify(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("clean tree", func(t *testing.T) {
+		client, _ := setupVerifyClient(t)
+
+		report, err := client.Verify(ctx)
+		require.NoError(t, err)
+		assert.True(t, report.OK())
+		require.Len(t, report.Packages, 2)
+		assert.Equal(t, dot.PackageVerification{Package: "vim", Links: 2}, report.Packages[0])
+		assert.Equal(t, dot.PackageVerification{Package: "zsh", Links: 1}, report.Packages[1])
+	})
+
+	t.Run("missing link", func(t *testing.T) {
+		client, fs := setupVerifyClient(t)
+		require.NoError(t, fs.Remove(ctx, "/home/.vimrc"))
+
+		report, err := client.Verify(ctx)
+		require.NoError(t, err)
+		assert.False(t, report.OK())
+		assert.Equal(t, []dot.LinkDiscrepancy{
+			{Link: ".vimrc", Kind: dot.DiscrepancyMissing, Expected: "/dotfiles/vim/dot-vimrc"},
+		}, report.Packages[0].Discrepancies)
+		assert.Empty(t, report.Packages[1].Discrepancies)
+	})
+
+	t.Run("wrong target", func(t *testing.T) {
+		client, fs := setupVerifyClient(t)
+		// Still inside the package, so only the recorded source catches it
+		require.NoError(t, fs.Remove(ctx, "/home/.vimrc"))
+		require.NoError(t, fs.Symlink(ctx, "/dotfiles/vim/dot-gvimrc", "/home/.vimrc"))
+
+		report, err := client.Verify(ctx, "vim")
+		require.NoError(t, err)
+		require.Len(t, report.Packages, 1)
+		assert.Equal(t, []dot.LinkDiscrepancy{{
+			Link:     ".vimrc",
+			Kind:     dot.DiscrepancyWrongTarget,
+			Expected: "/dotfiles/vim/dot-vimrc",
+			Actual:   "/dotfiles/vim/dot-gvimrc",
+		}}, report.Packages[0].Discrepancies)
+	})
+
+	t.Run("relative link to the recorded source matches", func(t *testing.T) {
+		client, fs := setupVerifyClient(t)
+		require.NoError(t, fs.Remove(ctx, "/home/.zshrc"))
+		require.NoError(t, fs.Symlink(ctx, "../dotfiles/zsh/dot-zshrc", "/home/.zshrc"))
+
+		report, err := client.Verify(ctx, "zsh")
+		require.NoError(t, err)
+		assert.True(t, report.OK())
+	})
+
+	t.Run("replaced by regular file", func(t *testing.T) {
+		client, fs := setupVerifyClient(t)
+		require.NoError(t, fs.Remove(ctx, "/home/.zshrc"))
+		require.NoError(t, fs.WriteFile(ctx, "/home/.zshrc", []byte("local edit"), 0o644))
+
+		report, err := client.Verify(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, report.DiscrepancyCount())
+		assert.Equal(t, []dot.LinkDiscrepancy{
+			{Link: ".zshrc", Kind: dot.DiscrepancyNotSymlink, Expected: "/dotfiles/zsh/dot-zshrc"},
+		}, report.Packages[1].Discrepancies)
+	})
+
+	t.Run("links without recorded sources must stay in the package", func(t *testing.T) {
+		client, fs := setupVerifyClient(t)
+		store := manifest.NewFSManifestStore(fs)
+		target := dot.NewTargetPath("/home").Unwrap()
+		m := store.Load(ctx, target).Unwrap()
+		info, ok := m.GetPackage("vim")
+		require.True(t, ok)
+		info.Sources = nil
+		m.AddPackage(info)
+		require.NoError(t, store.Save(ctx, target, m))
+
+		require.NoError(t, fs.Remove(ctx, "/home/.vimrc"))
+		require.NoError(t, fs.Symlink(ctx, "/dotfiles/vim/dot-gvimrc", "/home/.vimrc"))
+		require.NoError(t, fs.Remove(ctx, "/home/.gvimrc"))
+		require.NoError(t, fs.Symlink(ctx, "/dotfiles/zsh/dot-zshrc", "/home/.gvimrc"))
+
+		report, err := client.Verify(ctx, "vim")
+		require.NoError(t, err)
+		assert.Equal(t, []dot.LinkDiscrepancy{{
+			Link:     ".gvimrc",
+			Kind:     dot.DiscrepancyWrongTarget,
+			Expected: "/dotfiles/vim",
+			Actual:   "/dotfiles/zsh/dot-zshrc",
+		}}, report.Packages[0].Discrepancies)
+	})
+
+	t.Run("unmanaged package", func(t *testing.T) {
+		client, _ := setupVerifyClient(t)
+
+		_, err := client.Verify(ctx, "vim", "emacs")
+		require.ErrorIs(t, err, dot.ErrPackageNotFound{Package: "emacs"})
+	})
+}
+
+func TestManage_RecordsLinkSources(t *testing.T) {
+	ctx := context.Background()
+	client, fs := setupVerifyClient(t)
+
+	load := func() manifest.PackageInfo {
+		m := manifest.NewFSManifestStore(fs).Load(ctx, dot.NewTargetPath("/home").Unwrap()).Unwrap()
+		info, ok := m.GetPackage("vim")
+		require.True(t, ok)
+		return info
+	}
+	want := map[string]string{
+		".vimrc":  "/dotfiles/vim/dot-vimrc",
+		".gvimrc": "/dotfiles/vim/dot-gvimrc",
+	}
+	assert.Equal(t, want, load().Sources)
+
+	// Remanaging skips the links that are already correct; their sources
+	// must survive
+	require.NoError(t, fs.WriteFile(ctx, "/dotfiles/vim/dot-exrc", []byte("set ai"), 0o644))
+	require.NoError(t, client.Remanage(ctx, "vim"))
+	want[".exrc"] = "/dotfiles/vim/dot-exrc"
+	assert.Equal(t, want, load().Sources)
+
+	require.NoError(t, client.Unmanage(ctx, "zsh"))
+	report, err := client.Verify(ctx)
+	require.NoError(t, err)
+	assert.True(t, report.OK())
+	require.Len(t, report.Packages, 1)
+	assert.Equal(t, 3, report.Packages[0].Links)
+}
@@ -1,6 +1,11 @@
 package dot
 
-import "github.com/yaklabco/dot/internal/updater"
+import (
+	"time"
+
+	"github.com/yaklabco/dot/internal/config"
+	"github.com/yaklabco/dot/internal/updater"
+)
 
 // VersionChecker checks for new versions on GitHub.
 type VersionChecker struct {
@@ -26,3 +31,41 @@ type PackageManager = updater.PackageManager
 func ResolvePackageManager(configured string) (PackageManager, error) {
 	return updater.ResolvePackageManager(configured)
 }
+
+// DefaultStateDir returns the directory for dot's state files under the
+// XDG state directory.
+func DefaultStateDir() string {
+	return config.XDGStatePath("dot")
+}
+
+// ResolvePackageManagerCached resolves the package manager like
+// ResolvePackageManager, reusing an "auto" detection cached in stateDir
+// for ttl. A ttl of zero or less always detects, and refresh detects
+// again and replaces the cached result.
+func ResolvePackageManagerCached(configured, stateDir string, ttl time.Duration, refresh bool) (PackageManager, error) {
+	return updater.ResolvePackageManagerCached(configured, updater.NewDetectionCache(stateDir), ttl, refresh)
+}
+
+// UpgradeRecord describes the most recent upgrade attempt.
+type UpgradeRecord = updater.UpgradeRecord
+
+// ErrUpgradeVerification indicates the upgraded binary did not report the
+// version the upgrade installed.
+type ErrUpgradeVerification = updater.ErrUpgradeVerification
+
+// FindInstalledBinary returns the path of the dot binary a package manager upgrades.
+func FindInstalledBinary() (string, error) {
+	return updater.FindInstalledBinary()
+}
+
+// VerifyUpgrade checks that binary reports at least the attempted version
+// and returns the version it reports.
+func VerifyUpgrade(binary, attempted string) (string, error) {
+	return updater.VerifyUpgrade(binary, attempted)
+}
+
+// RecordUpgrade stores the outcome of an upgrade attempt in the update
+// check state under configDir.
+func RecordUpgrade(configDir string, record UpgradeRecord) error {
+	return updater.NewStateManager(configDir).RecordUpgrade(record)
+}